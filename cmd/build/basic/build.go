@@ -21,6 +21,7 @@ import (
 
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
+	"github.com/okteto/okteto/pkg/build/buildkit"
 	"github.com/okteto/okteto/pkg/env"
 	"github.com/okteto/okteto/pkg/log/io"
 	"github.com/okteto/okteto/pkg/types"
@@ -63,7 +64,11 @@ func (ob *Builder) Build(ctx context.Context, options *types.BuildOptions) error
 		return err
 	}
 
-	var err error
+	output, err := buildkit.ParseOutput(options.Output)
+	if err != nil {
+		return err
+	}
+
 	options.Tag, err = env.ExpandEnv(options.Tag)
 	if err != nil {
 		return err
@@ -74,7 +79,9 @@ func (ob *Builder) Build(ctx context.Context, options *types.BuildOptions) error
 		return err
 	}
 
-	if options.Tag == "" {
+	if !output.IsRegistry() {
+		ob.IoCtrl.Out().Success("Image successfully exported to %s", output)
+	} else if options.Tag == "" {
 		ob.IoCtrl.Out().Success("Build succeeded")
 		ob.IoCtrl.Out().Infof("Your image won't be pushed. To push your image specify the flag '-t'.")
 	} else {
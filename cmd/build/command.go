@@ -101,6 +101,8 @@ const (
 // Build build and optionally push a Docker image
 func Build(ctx context.Context, ioCtrl *io.Controller, at, insights buildTrackerInterface, k8slogger *io.K8sLogger) *cobra.Command {
 	options := &types.BuildOptions{}
+	var builderFlag, warmListFile string
+	var warmCache bool
 	cmd := &cobra.Command{
 		Use:   "build [image...]",
 		Short: "Build and push the images defined in the 'build' section of your Okteto Manifest",
@@ -114,7 +116,8 @@ func Build(ctx context.Context, ioCtrl *io.Controller, at, insights buildTracker
 				return err
 			}
 
-			if !okteto.IsOkteto() {
+			localCluster := getLocalCluster(oktetoContext)
+			if !okteto.IsOkteto() && localCluster == nil {
 				return oktetoErrors.ErrContextIsNotOktetoCluster
 			}
 
@@ -124,8 +127,27 @@ func Build(ctx context.Context, ioCtrl *io.Controller, at, insights buildTracker
 
 			ioCtrl.Logger().Info("context loaded")
 
+			if err := buildCmd.SelectBuilder(ctx, builderFlag, oktetoContext.GetCurrentBuilder(), buildCmd.ProbeBuilder); err != nil {
+				return err
+			}
+			if localCluster != nil {
+				if err := validateLocalClusterBuilder(localCluster, oktetoContext.GetCurrentBuilder()); err != nil {
+					return err
+				}
+			}
+
+			for _, s := range options.Secrets {
+				if err := validateBuildSecretFlag(s); err != nil {
+					return err
+				}
+			}
+
 			bc := NewBuildCommand(ioCtrl, at, insights, oktetoContext, k8slogger)
 
+			if warmCache || warmListFile != "" {
+				return bc.warmCache(ctx, options, warmListFile, oktetoContext, afero.NewOsFs())
+			}
+
 			builder, err := bc.getBuilder(options, oktetoContext)
 			if err != nil {
 				return err
@@ -140,10 +162,18 @@ func Build(ctx context.Context, ioCtrl *io.Controller, at, insights buildTracker
 				}
 			}
 
-			for _, s := range options.Secrets {
-				if err := validateBuildSecretFlag(s); err != nil {
+			options.Output, err = resolveClusterOutput(options.Output, localCluster, oktetoContext.GetCurrentName())
+			if err != nil {
+				return err
+			}
+
+			if !builder.IsV1() {
+				// build args are merged into the manifest so the args of a build entry take precedence
+				// and they are part of the build hash of each image
+				if err := buildCmd.MergeBuildArgs(options.Manifest, options.BuildArgs); err != nil {
 					return err
 				}
+				options.BuildArgs = nil
 			}
 
 			analytics.TrackBuildWithManifestVsDockerfile(builder.IsV1())
@@ -159,10 +189,14 @@ func Build(ctx context.Context, ioCtrl *io.Controller, at, insights buildTracker
 	cmd.Flags().StringArrayVar(&options.CacheFrom, "cache-from", nil, "list of cache source images (optional)")
 	cmd.Flags().StringArrayVar(&options.ExportCache, "export-cache", nil, "image tag for exported cache when build (optional)s")
 	cmd.Flags().StringVarP(&options.OutputMode, "progress", "", string(TTYFormat), "show plain/tty build output")
+	cmd.Flags().StringVar(&options.Output, "output", "", "where the image is exported to: 'type=registry' (default), 'type=docker' to load it into the local docker daemon, 'type=oci,dest=image.tar', 'type=tar,dest=rootfs.tar', 'type=cluster' to load it into the nodes of a kind or k3d cluster (default for kind and k3d clusters) or 'type=cacheonly' to only keep the build cache")
 	cmd.Flags().StringArrayVar(&options.BuildArgs, "build-arg", nil, "set build-time variables (optional)")
 	cmd.Flags().StringArrayVar(&options.Secrets, "secret", nil, "secret exposed to the build. Formats: id=mysecret,src=/local/secret (file) or id=mysecret,env=MY_ENV_VAR (env var)")
 	cmd.Flags().StringVar(&options.Platform, "platform", "", "specify which platform to build the container image for (optional)")
 	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "overwrite the current Okteto Namespace")
+	cmd.Flags().StringVar(&builderFlag, "builder", "", "overwrite the builder of the current Okteto Context, like 'tcp://localhost:1234' or 'docker://local' (optional)")
+	cmd.Flags().BoolVar(&warmCache, "remote-cache-warm", false, "build the images of the build section to warm the build cache, without pushing them (optional)")
+	cmd.Flags().StringVar(&warmListFile, "remote-cache-warm-list", "", "file with the names of the build section to warm, one per line. It implies '--remote-cache-warm' (optional)")
 	return cmd
 }
 
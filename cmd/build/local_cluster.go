@@ -0,0 +1,74 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/okteto/okteto/pkg/build/buildkit"
+	buildCmd "github.com/okteto/okteto/pkg/cmd/build"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/localcluster"
+	"github.com/okteto/okteto/pkg/okteto"
+)
+
+// getLocalCluster returns the kind or k3d cluster of a kubernetes context, or nil for Okteto contexts and other clusters
+func getLocalCluster(okCtx *okteto.ContextStateless) *localcluster.Cluster {
+	if okCtx.IsOktetoCluster() {
+		return nil
+	}
+	return localcluster.Detect(okCtx.GetCurrentName())
+}
+
+// validateLocalClusterBuilder checks that there is a builder for a local cluster, as only Okteto contexts provide one
+func validateLocalClusterBuilder(cluster *localcluster.Cluster, contextBuilder string) error {
+	if contextBuilder != "" || os.Getenv(buildCmd.OktetoBuilderEnvVar) != "" {
+		return nil
+	}
+	return oktetoErrors.UserError{
+		E:    fmt.Errorf("a BuildKit builder is required to build images for the %s", cluster),
+		Hint: "Run a BuildKit container with 'docker run -d --name buildkitd --privileged moby/buildkit' and use it with '--builder docker://local'",
+	}
+}
+
+// resolveClusterOutput returns the output of a build. The images are loaded into the nodes of a local cluster
+// by default, as it has no registry to push them to
+func resolveClusterOutput(value string, cluster *localcluster.Cluster, k8sContext string) (string, error) {
+	if value == "" {
+		if cluster == nil {
+			return "", nil
+		}
+		return fmt.Sprintf("type=%s,cluster=%s", buildkit.OutputTypeCluster, k8sContext), nil
+	}
+	output, err := buildkit.ParseOutput(value)
+	if err != nil {
+		return "", err
+	}
+	if output.Type != buildkit.OutputTypeCluster {
+		return value, nil
+	}
+
+	if output.Cluster != "" {
+		k8sContext = output.Cluster
+		cluster = localcluster.Detect(k8sContext)
+	}
+	if cluster == nil {
+		return "", oktetoErrors.UserError{
+			E:    fmt.Errorf("the '%s' output is only available for kind and k3d clusters", buildkit.OutputTypeCluster),
+			Hint: "Use a kubernetes context created by kind or k3d, like 'kind-<cluster>' or 'k3d-<cluster>'",
+		}
+	}
+	return fmt.Sprintf("type=%s,cluster=%s", buildkit.OutputTypeCluster, k8sContext), nil
+}
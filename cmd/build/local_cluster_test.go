@@ -0,0 +1,101 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"testing"
+
+	buildCmd "github.com/okteto/okteto/pkg/cmd/build"
+	"github.com/okteto/okteto/pkg/localcluster"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveClusterOutput(t *testing.T) {
+	kind := &localcluster.Cluster{Provider: localcluster.ProviderKind, Name: "dev"}
+	tests := []struct {
+		cluster    *localcluster.Cluster
+		name       string
+		value      string
+		k8sContext string
+		expected   string
+		wantErr    bool
+	}{
+		{
+			name:       "okteto context",
+			k8sContext: "https://okteto.example.com",
+		},
+		{
+			name:       "local cluster defaults to the cluster output",
+			cluster:    kind,
+			k8sContext: "kind-dev",
+			expected:   "type=cluster,cluster=kind-dev",
+		},
+		{
+			name:       "cluster output of the current context",
+			value:      "type=cluster",
+			cluster:    kind,
+			k8sContext: "kind-dev",
+			expected:   "type=cluster,cluster=kind-dev",
+		},
+		{
+			name:       "cluster output of another context",
+			value:      "type=cluster,cluster=k3d-other",
+			k8sContext: "https://okteto.example.com",
+			expected:   "type=cluster,cluster=k3d-other",
+		},
+		{
+			name:       "other outputs are kept",
+			value:      "type=docker",
+			cluster:    kind,
+			k8sContext: "kind-dev",
+			expected:   "type=docker",
+		},
+		{
+			name:       "cluster output without local cluster",
+			value:      "type=cluster",
+			k8sContext: "https://okteto.example.com",
+			wantErr:    true,
+		},
+		{
+			name:       "invalid output",
+			value:      "type=tar",
+			cluster:    kind,
+			k8sContext: "kind-dev",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := resolveClusterOutput(tt.value, tt.cluster, tt.k8sContext)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestValidateLocalClusterBuilder(t *testing.T) {
+	cluster := &localcluster.Cluster{Provider: localcluster.ProviderKind, Name: "dev"}
+
+	t.Setenv(buildCmd.OktetoBuilderEnvVar, "")
+	assert.EqualError(t, validateLocalClusterBuilder(cluster, ""), "a BuildKit builder is required to build images for the kind cluster 'dev'")
+	assert.NoError(t, validateLocalClusterBuilder(cluster, "tcp://buildkit:1234"))
+
+	t.Setenv(buildCmd.OktetoBuilderEnvVar, "docker-container://buildkitd")
+	assert.NoError(t, validateLocalClusterBuilder(cluster, ""))
+}
@@ -28,6 +28,7 @@ import (
 	buildTypes "github.com/okteto/okteto/cmd/build/v2/types"
 	"github.com/okteto/okteto/pkg/analytics"
 	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/build/buildkit"
 	buildCmd "github.com/okteto/okteto/pkg/cmd/build"
 	"github.com/okteto/okteto/pkg/devenvironment"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
@@ -257,9 +258,10 @@ func (ob *OktetoBuilder) Build(ctx context.Context, options *types.BuildOptions)
 		}
 	}(svcInfos)
 
+	// images exported out of the registry can't be reused from the registry cache
 	var cachedSvcs []*buildTypes.BuildInfo
 	notCachedSvcs := svcInfos
-	if !options.NoCache && ob.smartBuildCtrl.IsEnabled() {
+	if !options.NoCache && ob.smartBuildCtrl.IsEnabled() && isRegistryOutput(options) {
 		if options.EnableStages {
 			ob.ioCtrl.SetStage("Checking if images were already built...")
 		}
@@ -366,6 +368,10 @@ func (bc *OktetoBuilder) buildSvcFromDockerfile(ctx context.Context, manifest *m
 	if err := bc.Builder.Build(ctx, buildOptions); err != nil {
 		return "", err
 	}
+	if !isRegistryOutput(buildOptions) {
+		// the image isn't pushed, so it has no digest in the registry
+		return strings.Split(buildOptions.Tag, ",")[0], nil
+	}
 	var imageTagWithDigest string
 	tags := strings.Split(buildOptions.Tag, ",")
 
@@ -400,6 +406,12 @@ func (bc *OktetoBuilder) buildSvcFromDockerfile(ctx context.Context, manifest *m
 	return imageTagWithDigest, nil
 }
 
+// isRegistryOutput returns true when the images are pushed to the registry. The output is validated by validateOptions
+func isRegistryOutput(options *types.BuildOptions) bool {
+	output, err := buildkit.ParseOutput(options.Output)
+	return err != nil || output.IsRegistry()
+}
+
 // serviceHasDockerfile returns true when service BuildInfo Dockerfile is not empty
 func serviceHasDockerfile(buildInfo *build.Info) bool {
 	return buildInfo.Dockerfile != ""
@@ -439,7 +451,14 @@ func validateOptions(manifest *model.Manifest, svcsToBuild []string, options *ty
 		return err
 	}
 
-	if len(svcsToBuild) != 1 && (options.Tag != "" || options.Target != "" || options.CacheFrom != nil || options.Secrets != nil) {
+	output, err := buildkit.ParseOutput(options.Output)
+	if err != nil {
+		return err
+	}
+
+	// every image can be loaded into the nodes of the same cluster
+	hasImageOutput := options.Output != "" && output.Type != buildkit.OutputTypeCluster
+	if len(svcsToBuild) != 1 && (options.Tag != "" || options.Target != "" || options.CacheFrom != nil || options.Secrets != nil || hasImageOutput) {
 		return oktetoErrors.ErrNoFlagAllowedOnSingleImageBuild
 	}
 
@@ -285,6 +285,52 @@ func TestValidateOptions(t *testing.T) {
 			},
 			expectedErr: false,
 		},
+		{
+			name: "several services with output",
+			buildSection: build.ManifestBuild{
+				"test":   &build.Info{},
+				"test-2": &build.Info{},
+			},
+			svcsToBuild: []string{"test", "test-2"},
+			options: types.BuildOptions{
+				Output: "type=docker",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "several services loaded into a local cluster",
+			buildSection: build.ManifestBuild{
+				"test":   &build.Info{},
+				"test-2": &build.Info{},
+			},
+			svcsToBuild: []string{"test", "test-2"},
+			options: types.BuildOptions{
+				Output: "type=cluster,cluster=kind-dev",
+			},
+			expectedErr: false,
+		},
+		{
+			name: "only one service with output",
+			buildSection: build.ManifestBuild{
+				"test": &build.Info{},
+			},
+			svcsToBuild: []string{"test"},
+			options: types.BuildOptions{
+				Output: "type=tar,dest=image.tar",
+			},
+			expectedErr: false,
+		},
+		{
+			name: "only one service with invalid output",
+			buildSection: build.ManifestBuild{
+				"test": &build.Info{},
+			},
+			svcsToBuild: []string{"test"},
+			options: types.BuildOptions{
+				Output: "type=tar",
+			},
+			expectedErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -203,3 +203,20 @@ func TestServiceHasher_HashBuildContextWithError(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceHasher_HashIncludesArgs(t *testing.T) {
+	sh := &serviceHasher{
+		fs:     afero.NewMemMapFs(),
+		ioCtrl: io.NewIOController(),
+	}
+	info := &build.Info{
+		Context: ".",
+		Args: build.Args{
+			{Name: "COMMIT_SHA", Value: "abc123"},
+		},
+	}
+	hash := sh.hash(info, "commit", "diff")
+
+	info.Args = info.Args.Merge(build.Args{{Name: "ENV", Value: "dev"}})
+	assert.NotEqual(t, hash, sh.hash(info, "commit", "diff"))
+}
@@ -0,0 +1,177 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/build/buildkit"
+	buildCmd "github.com/okteto/okteto/pkg/cmd/build"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
+)
+
+// buildMetadataGetter returns the metadata of the last build, including the cache hits reported by buildkit
+type buildMetadataGetter interface {
+	GetMetadata() *buildkit.BuildMetadata
+}
+
+// warmCache builds the images of the build section with the cacheonly output, so the build cache of
+// the builder is populated without pushing any image. It's meant to be run periodically, like from a CI cron job
+func (bc *Command) warmCache(ctx context.Context, options *types.BuildOptions, listFile string, okCtx buildCmd.OktetoContextInterface, fs afero.Fs) error {
+	if options.Tag != "" || options.Output != "" {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the flag '--remote-cache-warm' can't be combined with '--tag' or '--output'"),
+			Hint: "Warming the build cache doesn't export any image",
+		}
+	}
+
+	manifest, err := bc.GetManifest(options.File, fs)
+	if err != nil {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the build cache can only be warmed from the build section of an okteto manifest: %w", err),
+			Hint: "Use the flag '--file' to select your okteto manifest",
+		}
+	}
+
+	names, err := getWarmEntries(manifest, options.CommandArgs, listFile, fs)
+	if err != nil {
+		return err
+	}
+
+	// build args are merged into the manifest so the args of a build entry take precedence
+	if err := buildCmd.MergeBuildArgs(manifest, options.BuildArgs); err != nil {
+		return err
+	}
+	options.BuildArgs = nil
+
+	failed := []string{}
+	for _, name := range names {
+		opts := buildCmd.OptsFromBuildInfo(manifest, name, manifest.Build[name].Copy(), options, bc.Registry, okCtx)
+		opts.Output = fmt.Sprintf("type=%s", buildkit.OutputTypeCacheOnly)
+
+		bc.ioCtrl.Out().Infof("Warming the build cache of '%s'...", name)
+		if err := bc.Builder.Run(ctx, opts, bc.ioCtrl); err != nil {
+			bc.ioCtrl.Logger().Infof("failed to warm the build cache of '%s': %s", name, err)
+			bc.ioCtrl.Out().Warning("Failed to warm the build cache of '%s': %s", name, err)
+			failed = append(failed, name)
+			continue
+		}
+		bc.reportCacheStats(name)
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to warm the build cache of '%s'", strings.Join(failed, "', '"))
+	}
+	bc.ioCtrl.Out().Success("Build cache warmed for %d image(s)", len(names))
+	return nil
+}
+
+// reportCacheStats shows the steps of the last build resolved from the cache, when buildkit reports them
+func (bc *Command) reportCacheStats(name string) {
+	var metadata *buildkit.BuildMetadata
+	if getter, ok := bc.Builder.(buildMetadataGetter); ok {
+		metadata = getter.GetMetadata()
+	}
+	summary, steps := describeCacheStats(metadata)
+	if summary == "" {
+		bc.ioCtrl.Out().Success("Build cache of '%s' warmed", name)
+		return
+	}
+	bc.ioCtrl.Out().Success("Build cache of '%s' warmed: %s", name, summary)
+	for _, step := range steps {
+		bc.ioCtrl.Out().Println(step)
+	}
+}
+
+// describeCacheStats returns the cache hit ratio of a build and a line per step with its cache status.
+// The summary is empty if buildkit didn't report the steps of the build
+func describeCacheStats(metadata *buildkit.BuildMetadata) (string, []string) {
+	if metadata == nil {
+		return "", nil
+	}
+	cached, total := metadata.CacheHits()
+	if total == 0 {
+		return "", nil
+	}
+	steps := make([]string, 0, total)
+	for _, step := range metadata.CacheSteps {
+		status := "built"
+		if step.Cached {
+			status = "cached"
+		}
+		steps = append(steps, fmt.Sprintf("    %-6s  %s", status, step.Name))
+	}
+	return fmt.Sprintf("%d/%d steps cached (%d%%)", cached, total, cached*100/total), steps
+}
+
+// getWarmEntries returns the build entries to warm: the ones passed as arguments and the ones listed in
+// listFile, one per line. All the entries of the build section are warmed if none is selected
+func getWarmEntries(manifest *model.Manifest, args []string, listFile string, fs afero.Fs) ([]string, error) {
+	names := append([]string{}, args...)
+	if listFile != "" {
+		content, err := afero.ReadFile(fs, listFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the list of build entries: %w", err)
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(content))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			names = append(names, line)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read the list of build entries: %w", err)
+		}
+	}
+
+	if len(names) == 0 {
+		for name := range manifest.Build {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+	if len(names) == 0 {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("your okteto manifest doesn't contain a build section"),
+			Hint: "Add the images to warm to the build section of your okteto manifest",
+		}
+	}
+
+	result := []string{}
+	seen := map[string]bool{}
+	for _, name := range names {
+		if _, ok := manifest.Build[name]; !ok {
+			return nil, oktetoErrors.UserError{
+				E:    fmt.Errorf("'%s' is not defined in the build section of your okteto manifest", name),
+				Hint: "Use the names of the build section of your okteto manifest",
+			}
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		result = append(result, name)
+	}
+	return result, nil
+}
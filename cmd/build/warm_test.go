@@ -0,0 +1,267 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/build/buildkit"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/log/io"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// fakeWarmBuilder records the builds and reports the cache steps of each image
+type fakeWarmBuilder struct {
+	errs     map[string]error
+	steps    map[string][]buildkit.CacheStep
+	metadata *buildkit.BuildMetadata
+	builds   []*types.BuildOptions
+}
+
+func (*fakeWarmBuilder) GetBuilder() string { return "" }
+
+func (b *fakeWarmBuilder) Run(_ context.Context, opts *types.BuildOptions, _ *io.Controller) error {
+	b.builds = append(b.builds, opts)
+	if err := b.errs[opts.Path]; err != nil {
+		return err
+	}
+	b.metadata = &buildkit.BuildMetadata{CacheSteps: b.steps[opts.Path]}
+	return nil
+}
+
+func (b *fakeWarmBuilder) GetMetadata() *buildkit.BuildMetadata { return b.metadata }
+
+func newWarmManifest() *model.Manifest {
+	return &model.Manifest{
+		Name: "movies",
+		Build: build.ManifestBuild{
+			"api": &build.Info{Context: "api", Image: "okteto/api", ExportCache: []string{"okteto/api:cache"}},
+			"frontend": &build.Info{
+				Context: "frontend",
+				Args:    build.Args{{Name: "NODE_ENV", Value: "production"}},
+			},
+		},
+	}
+}
+
+func newWarmCommand(builder *fakeWarmBuilder, manifest *model.Manifest) *Command {
+	return &Command{
+		GetManifest: func(string, afero.Fs) (*model.Manifest, error) {
+			if manifest == nil {
+				return nil, oktetoErrors.ErrInvalidManifest
+			}
+			return manifest, nil
+		},
+		Builder:  builder,
+		Registry: newFakeRegistry(),
+		ioCtrl:   io.NewIOController(),
+	}
+}
+
+func newWarmContext() *okteto.ContextStateless {
+	return &okteto.ContextStateless{
+		Store: &okteto.ContextStore{
+			Contexts: map[string]*okteto.Context{
+				"test": {Namespace: "test", Cfg: &api.Config{}},
+			},
+			CurrentContext: "test",
+		},
+	}
+}
+
+func TestWarmCache(t *testing.T) {
+	builder := &fakeWarmBuilder{
+		steps: map[string][]buildkit.CacheStep{
+			"api": {
+				{Name: "[1/3] FROM golang", Cached: true},
+				{Name: "[2/3] COPY . .", Cached: true},
+				{Name: "[3/3] RUN make", Cached: false},
+			},
+		},
+	}
+	bc := newWarmCommand(builder, newWarmManifest())
+
+	options := &types.BuildOptions{BuildArgs: []string{"NODE_ENV=dev", "VERSION=1"}}
+	err := bc.warmCache(context.Background(), options, "", newWarmContext(), afero.NewMemMapFs())
+	require.NoError(t, err)
+
+	require.Len(t, builder.builds, 2)
+	api, frontend := builder.builds[0], builder.builds[1]
+	assert.Equal(t, "api", api.Path)
+	assert.Equal(t, "type=cacheonly", api.Output)
+	assert.Equal(t, []string{"okteto/api:cache"}, api.ExportCache)
+	assert.Equal(t, "frontend", frontend.Path)
+	assert.Equal(t, "type=cacheonly", frontend.Output)
+	// the args of the build entry take precedence over the flag
+	assert.ElementsMatch(t, []string{"NODE_ENV=production", "VERSION=1"}, frontend.BuildArgs)
+}
+
+func TestWarmCacheContinuesAfterFailures(t *testing.T) {
+	builder := &fakeWarmBuilder{
+		errs: map[string]error{"api": errors.New("build failed")},
+	}
+	bc := newWarmCommand(builder, newWarmManifest())
+
+	err := bc.warmCache(context.Background(), &types.BuildOptions{}, "", newWarmContext(), afero.NewMemMapFs())
+	require.EqualError(t, err, "failed to warm the build cache of 'api'")
+	assert.Len(t, builder.builds, 2)
+}
+
+func TestWarmCacheErrors(t *testing.T) {
+	tests := []struct {
+		manifest *model.Manifest
+		options  *types.BuildOptions
+		name     string
+		wantErr  string
+	}{
+		{
+			name:     "tag",
+			manifest: newWarmManifest(),
+			options:  &types.BuildOptions{Tag: "okteto/api"},
+			wantErr:  "the flag '--remote-cache-warm' can't be combined with '--tag' or '--output'",
+		},
+		{
+			name:     "output",
+			manifest: newWarmManifest(),
+			options:  &types.BuildOptions{Output: "type=docker"},
+			wantErr:  "the flag '--remote-cache-warm' can't be combined with '--tag' or '--output'",
+		},
+		{
+			name:    "no manifest",
+			options: &types.BuildOptions{},
+			wantErr: "the build cache can only be warmed from the build section of an okteto manifest",
+		},
+		{
+			name:     "unknown entry",
+			manifest: newWarmManifest(),
+			options:  &types.BuildOptions{CommandArgs: []string{"worker"}},
+			wantErr:  "'worker' is not defined in the build section of your okteto manifest",
+		},
+		{
+			name:     "empty build section",
+			manifest: &model.Manifest{Build: build.ManifestBuild{}},
+			options:  &types.BuildOptions{},
+			wantErr:  "your okteto manifest doesn't contain a build section",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := &fakeWarmBuilder{}
+			bc := newWarmCommand(builder, tt.manifest)
+			err := bc.warmCache(context.Background(), tt.options, "", newWarmContext(), afero.NewMemMapFs())
+			assert.ErrorContains(t, err, tt.wantErr)
+			assert.Empty(t, builder.builds)
+		})
+	}
+}
+
+func TestDescribeCacheStats(t *testing.T) {
+	tests := []struct {
+		metadata        *buildkit.BuildMetadata
+		name            string
+		expectedSummary string
+		expectedSteps   []string
+	}{
+		{
+			name: "no metadata",
+		},
+		{
+			name:     "no steps reported",
+			metadata: &buildkit.BuildMetadata{},
+		},
+		{
+			name: "partially cached",
+			metadata: &buildkit.BuildMetadata{
+				CacheSteps: []buildkit.CacheStep{
+					{Name: "[1/3] FROM golang", Cached: true},
+					{Name: "[2/3] COPY . .", Cached: true},
+					{Name: "[3/3] RUN make"},
+				},
+			},
+			expectedSummary: "2/3 steps cached (66%)",
+			expectedSteps: []string{
+				"    cached  [1/3] FROM golang",
+				"    cached  [2/3] COPY . .",
+				"    built   [3/3] RUN make",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary, steps := describeCacheStats(tt.metadata)
+			assert.Equal(t, tt.expectedSummary, summary)
+			assert.Equal(t, tt.expectedSteps, steps)
+		})
+	}
+}
+
+func TestGetWarmEntries(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "warm.txt", []byte("# base images\nfrontend\n\n  api  \n"), 0600))
+
+	tests := []struct {
+		name     string
+		listFile string
+		wantErr  string
+		args     []string
+		expected []string
+	}{
+		{
+			name:     "all the entries",
+			expected: []string{"api", "frontend"},
+		},
+		{
+			name:     "args",
+			args:     []string{"frontend"},
+			expected: []string{"frontend"},
+		},
+		{
+			name:     "list file",
+			listFile: "warm.txt",
+			expected: []string{"frontend", "api"},
+		},
+		{
+			name:     "args and list file without duplicates",
+			args:     []string{"api"},
+			listFile: "warm.txt",
+			expected: []string{"api", "frontend"},
+		},
+		{
+			name:     "missing list file",
+			listFile: "missing.txt",
+			wantErr:  "failed to read the list of build entries",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := getWarmEntries(newWarmManifest(), tt.args, tt.listFile, fs)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
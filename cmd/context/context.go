@@ -49,6 +49,7 @@ This will prompt you to select one of your existing Okteto Contexts or to create
 	cmd.AddCommand(Use())
 	cmd.AddCommand(List())
 	cmd.AddCommand(DeleteCMD())
+	cmd.AddCommand(Update())
 
 	cmd.PersistentFlags().BoolVarP(&ctxOptions.InsecureSkipTlsVerify, "insecure-skip-tls-verify", "", false, "skip validation of server's certificates")
 	cmd.Flags().StringVarP(&ctxOptions.Token, "token", "t", "", "API token for authentication. Use this when scripting or if you don't want to use browser-based authentication")
@@ -17,6 +17,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/okteto/okteto/cmd/utils"
@@ -90,11 +91,11 @@ func (k *KubeconfigCMD) execute(okCtx *okteto.Context, kubeconfigPaths []string)
 		}
 	}
 
-	if err := kubeconfig.Write(okCtx.Cfg, kubeconfigPaths[0]); err != nil {
+	if err := kubeconfig.Modify(okCtx.Cfg, kubeconfigPaths); err != nil {
 		return err
 	}
 
-	oktetoLog.Success("Updated kubernetes context '%s/%s' in '%s'", contextName, okCtx.Namespace, kubeconfigPaths)
+	oktetoLog.Success("Updated kubernetes context '%s/%s' in '%s'", contextName, okCtx.Namespace, strings.Join(kubeconfigPaths, string(filepath.ListSeparator)))
 	return nil
 }
 
@@ -358,6 +358,49 @@ func Test_ExecuteUpdateKubeconfig_ForNonOktetoContext(t *testing.T) {
 	assert.NotNil(t, cfg.AuthInfos["test-user"].Exec)
 }
 
+func Test_ExecuteUpdateKubeconfig_MultipleKubeconfigFiles(t *testing.T) {
+	personal, err := test.CreateKubeconfig(test.KubeconfigFields{
+		Name:           []string{"personal"},
+		Namespace:      []string{"default"},
+		CurrentContext: "personal",
+	})
+	require.NoError(t, err)
+	defer os.Remove(personal)
+	work, err := test.CreateKubeconfig(test.KubeconfigFields{
+		Name:           []string{"work"},
+		Namespace:      []string{"team"},
+		CurrentContext: "work",
+	})
+	require.NoError(t, err)
+	defer os.Remove(work)
+	kubeconfigPaths := []string{personal, work}
+
+	cfg := kubeconfig.Get(kubeconfigPaths)
+	cfg.CurrentContext = "work"
+	cfg.Contexts["work"].Namespace = "cindy"
+	okteto.CurrentStore = &okteto.ContextStore{
+		CurrentContext: "work",
+		Contexts: map[string]*okteto.Context{
+			"work": {
+				Namespace: "cindy",
+				Cfg:       cfg,
+			},
+		},
+	}
+
+	err = newKubeconfigController(nil).execute(okteto.GetContext(), kubeconfigPaths)
+	require.NoError(t, err)
+
+	personalCfg := kubeconfig.Get([]string{personal})
+	assert.Equal(t, "work", personalCfg.CurrentContext)
+	assert.Equal(t, "default", personalCfg.Contexts["personal"].Namespace)
+	assert.NotContains(t, personalCfg.Contexts, "work")
+
+	workCfg := kubeconfig.Get([]string{work})
+	assert.Equal(t, "cindy", workCfg.Contexts["work"].Namespace)
+	assert.NotContains(t, workCfg.Contexts, "personal")
+}
+
 func Test_ExecuteUpdateKubeconfig_WithRightCertificate(t *testing.T) {
 	oktetoCert := "okteto-cert"
 	oktetoCertBase64 := base64.StdEncoding.EncodeToString([]byte(oktetoCert))
@@ -0,0 +1,85 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package context
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// platformAuto clears the platform of a context, so it's detected again
+	platformAuto = "auto"
+)
+
+var errNothingToUpdate = errors.New("nothing to update: set the '--platform' flag")
+
+// Update updates the attributes of an okteto context
+func Update() *cobra.Command {
+	var platform string
+	cmd := &cobra.Command{
+		Use:   "update [context]",
+		Args:  utils.MaximumNArgsAccepted(1, "https://okteto.com/docs/reference/okteto-cli/#context"),
+		Short: "Update the attributes of an Okteto Context",
+		Long: `Update the attributes of an Okteto Context. It updates the current Okteto Context if none is given.
+
+Use '--platform' to force the platform of the context when it's not detected properly, for example behind a proxy:
+
+    $ okteto context update --platform vanilla
+
+Set it to 'auto' to detect the platform again.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("platform") {
+				return errNothingToUpdate
+			}
+			ctxStore := okteto.GetContextStore()
+			name := ctxStore.CurrentContext
+			if len(args) == 1 {
+				name = strings.TrimSuffix(okteto.AddSchema(args[0]), "/")
+			}
+			if err := updateContextPlatform(ctxStore, name, platform); err != nil {
+				return err
+			}
+			if err := okteto.NewContextConfigWriter().Write(); err != nil {
+				return err
+			}
+			oktetoLog.Success("Context '%s' updated", name)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&platform, "platform", "", "force the platform of the context (okteto, vanilla) or detect it again (auto)")
+	return cmd
+}
+
+// updateContextPlatform sets the platform of a context, clearing it when it's 'auto'
+func updateContextPlatform(ctxStore *okteto.ContextStore, name, platform string) error {
+	okCtx, ok := ctxStore.Contexts[name]
+	if !ok {
+		return fmt.Errorf("'%s' context doesn't exist", name)
+	}
+	if platform == platformAuto {
+		platform = ""
+	}
+	if err := okteto.ValidatePlatform(platform); err != nil {
+		return err
+	}
+	okCtx.Platform = platform
+	return nil
+}
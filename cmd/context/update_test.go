@@ -0,0 +1,40 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package context
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_updateContextPlatform(t *testing.T) {
+	ctxStore := &okteto.ContextStore{
+		CurrentContext: "test",
+		Contexts: map[string]*okteto.Context{
+			"test": {Name: "test", IsOkteto: true},
+		},
+	}
+
+	require.NoError(t, updateContextPlatform(ctxStore, "test", okteto.PlatformVanilla))
+	assert.Equal(t, okteto.PlatformVanilla, ctxStore.Contexts["test"].Platform)
+
+	require.NoError(t, updateContextPlatform(ctxStore, "test", platformAuto))
+	assert.Empty(t, ctxStore.Contexts["test"].Platform)
+
+	require.ErrorContains(t, updateContextPlatform(ctxStore, "test", "openshift"), "invalid platform")
+	require.ErrorContains(t, updateContextPlatform(ctxStore, "missing", okteto.PlatformOkteto), "'missing' context doesn't exist")
+}
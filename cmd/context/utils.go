@@ -14,14 +14,13 @@
 package context
 
 import (
-	"fmt"
 	"net/url"
 	"strings"
 
+	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/k8s/kubeconfig"
-	oktetoLog "github.com/okteto/okteto/pkg/log"
 )
 
 type SelectItem struct {
@@ -73,14 +72,10 @@ func isCreateNewContextOption(option string) bool {
 }
 
 func askForOktetoURL(message string) (string, error) {
-	err := oktetoLog.Question("%s", message)
+	oktetoURL, err := utils.AskForInput(message)
 	if err != nil {
 		return "", err
 	}
-	var oktetoURL string
-	if _, err := fmt.Scanln(&oktetoURL); err != nil {
-		return "", err
-	}
 
 	url, err := url.Parse(oktetoURL)
 	if err != nil {
@@ -18,12 +18,14 @@ import (
 	"testing"
 
 	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/deps"
 	"github.com/okteto/okteto/pkg/externalresource"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/model/forward"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_GetManifestV2(t *testing.T) {
@@ -104,7 +106,7 @@ deploy:
 			name: "manifest-namespace-not-valid",
 			file: "file",
 			manifestYAML: []byte(`
-namespace: value
+namespace: Not_Valid
 deploy:
   - echo "deploy"`),
 			expectedErr: true,
@@ -140,3 +142,10 @@ deploy:
 		})
 	}
 }
+
+func Test_askForOktetoURLNonInteractive(t *testing.T) {
+	t.Setenv(constants.OktetoNonInteractiveEnvVar, "true")
+
+	_, err := askForOktetoURL(messageSuggestingCurrentContext)
+	require.ErrorContains(t, err, "in non-interactive mode")
+}
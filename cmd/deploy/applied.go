@@ -0,0 +1,55 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// showAppliedResources prints the inventory of resources applied through the deploy proxy
+func (dc *Command) showAppliedResources(ctx context.Context, opts *Options) {
+	resources, err := dc.CfgMapHandler.GetAppliedResources(ctx, opts.Name, opts.Namespace)
+	if err != nil {
+		oktetoLog.Infof("could not retrieve the applied resources: %s", err)
+		oktetoLog.Warning("Could not retrieve the resources applied by '%s'", opts.Name)
+		return
+	}
+	printAppliedResources(os.Stdout, opts.Name, resources)
+}
+
+// printAppliedResources writes the table of resources applied by the development environment
+func printAppliedResources(w io.Writer, name string, resources []pipeline.AppliedResource) {
+	if len(resources) == 0 {
+		fmt.Fprintf(w, "No resources have been applied by '%s'\n", name)
+		return
+	}
+	fmt.Fprintf(w, "Resources applied by '%s':\n", name)
+	tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+	fmt.Fprintf(tw, "Kind\tNamespace\tName\n")
+	for _, r := range resources {
+		namespace := r.Namespace
+		if namespace == "" {
+			namespace = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", r.TypeName(), namespace, r.Name)
+	}
+	tw.Flush()
+}
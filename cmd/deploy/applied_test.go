@@ -0,0 +1,45 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintAppliedResources(t *testing.T) {
+	var out bytes.Buffer
+	printAppliedResources(&out, "movies", []pipeline.AppliedResource{
+		{Version: "v1", Resource: "services", Kind: "Service", Namespace: "ns", Name: "api"},
+		{Group: "apps", Version: "v1", Resource: "deployments", Kind: "Deployment", Namespace: "ns", Name: "api"},
+		{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "clusterroles", Name: "reader"},
+	})
+
+	expected := `Resources applied by 'movies':
+Kind                                    Namespace  Name
+service                                 ns         api
+deployment.apps                         ns         api
+clusterroles.rbac.authorization.k8s.io  -          reader
+`
+	assert.Equal(t, expected, out.String())
+}
+
+func TestPrintAppliedResourcesEmpty(t *testing.T) {
+	var out bytes.Buffer
+	printAppliedResources(&out, "movies", nil)
+	assert.Equal(t, "No resources have been applied by 'movies'\n", out.String())
+}
@@ -38,6 +38,9 @@ type ConfigMapHandler interface {
 	SetBuildEnvVars(context.Context, string, string, map[string]string) error
 	GetConfigmapVariablesEncoded(ctx context.Context, name, namespace string) (string, error)
 	AddPhaseDuration(context.Context, string, string, string, time.Duration) error
+	UpdateAppliedResources(context.Context, string, string, []pipeline.AppliedResource) error
+	GetAppliedResources(ctx context.Context, name, namespace string) ([]pipeline.AppliedResource, error)
+	UpdateHealthChecks(context.Context, string, string, []pipeline.HealthCheckResult) error
 }
 
 // oktetoDefaultConfigMapHandler is the runner used when the okteto is executed
@@ -110,6 +113,33 @@ func (ch *defaultConfigMapHandler) AddPhaseDuration(ctx context.Context, name, n
 	return pipeline.AddPhaseDuration(ctx, name, namespace, phase, duration, c)
 }
 
+// UpdateAppliedResources merges the resources applied through the proxy into the inventory stored in the config map
+func (ch *defaultConfigMapHandler) UpdateAppliedResources(ctx context.Context, name, namespace string, resources []pipeline.AppliedResource) error {
+	c, _, err := ch.k8sClientProvider.ProvideWithLogger(okteto.GetContext().Cfg, ch.k8slogger)
+	if err != nil {
+		return err
+	}
+	return pipeline.UpdateAppliedResources(ctx, name, namespace, resources, c)
+}
+
+// GetAppliedResources returns the inventory of resources applied through the proxy stored in the config map
+func (ch *defaultConfigMapHandler) GetAppliedResources(ctx context.Context, name, namespace string) ([]pipeline.AppliedResource, error) {
+	c, _, err := ch.k8sClientProvider.ProvideWithLogger(okteto.GetContext().Cfg, ch.k8slogger)
+	if err != nil {
+		return nil, err
+	}
+	return pipeline.GetAppliedResources(ctx, name, namespace, c)
+}
+
+// UpdateHealthChecks stores the results of the deploy health checks in the config map
+func (ch *defaultConfigMapHandler) UpdateHealthChecks(ctx context.Context, name, namespace string, results []pipeline.HealthCheckResult) error {
+	c, _, err := ch.k8sClientProvider.ProvideWithLogger(okteto.GetContext().Cfg, ch.k8slogger)
+	if err != nil {
+		return err
+	}
+	return pipeline.UpdateHealthChecks(ctx, name, namespace, results, c)
+}
+
 func (ch *defaultConfigMapHandler) SetBuildEnvVars(ctx context.Context, name, ns string, envVars map[string]string) error {
 	c, _, err := ch.k8sClientProvider.ProvideWithLogger(okteto.GetContext().Cfg, ch.k8slogger)
 	if err != nil {
@@ -29,6 +29,7 @@ import (
 	modelUtils "github.com/okteto/okteto/pkg/model/utils"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/repository"
+	"github.com/spf13/afero"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -81,7 +82,11 @@ func setDeployOptionsValuesFromManifest(ctx context.Context, deployOptions *Opti
 		}
 	}
 	if deployOptions.Manifest.Deploy != nil && deployOptions.Manifest.Deploy.ComposeSection != nil {
-		svcs, err := getStackServicesToDeploy(ctx, deployOptions.Manifest.Deploy.ComposeSection, c)
+		fs := deployOptions.Manifest.Fs
+		if fs == nil {
+			fs = afero.NewOsFs()
+		}
+		svcs, err := getStackServicesToDeploy(ctx, deployOptions.Manifest.Deploy.ComposeSection, c, fs)
 		if err != nil {
 			return err
 		}
@@ -90,15 +95,12 @@ func setDeployOptionsValuesFromManifest(ctx context.Context, deployOptions *Opti
 	return nil
 }
 
-func getStackServicesToDeploy(ctx context.Context, composeSectionInfo *model.ComposeSectionInfo, c kubernetes.Interface) ([]string, error) {
-	svcs := []string{}
-
-	servicesToDeploy := 0
-	for _, composeInfo := range composeSectionInfo.ComposesInfo {
-		svcs = append(svcs, composeInfo.ServicesToDeploy...)
-		servicesToDeploy += len(composeInfo.ServicesToDeploy)
+func getStackServicesToDeploy(ctx context.Context, composeSectionInfo *model.ComposeSectionInfo, c kubernetes.Interface, fs afero.Fs) ([]string, error) {
+	svcs, err := composeSectionInfo.GetServicesToDeploy(fs)
+	if err != nil {
+		return []string{}, err
 	}
-	if servicesToDeploy == 0 {
+	if svcs == nil {
 		svcs = []string{}
 		if composeSectionInfo.Stack == nil {
 			oktetoLog.Info("There is no stack defined in the manifest")
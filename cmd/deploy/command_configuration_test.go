@@ -23,7 +23,9 @@ import (
 	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
@@ -226,10 +228,49 @@ func Test_getStackServicesToDeploy(t *testing.T) {
 			ctx := context.Background()
 			c := fake.NewSimpleClientset()
 
-			svcs, _ := getStackServicesToDeploy(ctx, tt.composeSectionInfo, c)
+			svcs, _ := getStackServicesToDeploy(ctx, tt.composeSectionInfo, c, afero.NewMemMapFs())
 
 			assert.ElementsMatch(t, tt.expected, svcs)
 
 		})
 	}
 }
+
+func Test_getStackServicesToDeployWithSelectiveComposeFiles(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "infra.yml", []byte(`services:
+  db:
+    image: postgres
+  cache:
+    image: redis
+  queue:
+    image: rabbitmq
+`), 0600))
+	require.NoError(t, afero.WriteFile(fs, "app.yml", []byte(`services:
+  api:
+    image: okteto/api
+  worker:
+    image: okteto/worker
+`), 0600))
+	stack := &model.Stack{
+		Name: "test-stack",
+		Services: map[string]*model.Service{
+			"db":     {},
+			"cache":  {},
+			"queue":  {},
+			"api":    {},
+			"worker": {},
+		},
+	}
+	composeSectionInfo := &model.ComposeSectionInfo{
+		ComposesInfo: []model.ComposeInfo{
+			{File: "infra.yml", ServicesToDeploy: []string{"db", "cache"}},
+			{File: "app.yml"},
+		},
+		Stack: stack,
+	}
+
+	svcs, err := getStackServicesToDeploy(context.Background(), composeSectionInfo, fake.NewSimpleClientset(), fs)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"db", "cache", "api", "worker"}, svcs)
+}
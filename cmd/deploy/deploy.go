@@ -65,7 +65,13 @@ const (
 )
 
 var (
-	errDepenNotAvailableInVanilla = errors.New("dependency deployment is only supported in contexts with Okteto installed")
+	errDepenNotAvailableInVanilla     = errors.New("dependency deployment is only supported in contexts with Okteto installed")
+	errSetWithoutCompose              = errors.New("the '--set' flag is only supported for okteto manifests with compose files")
+	errResolveDigestsWithoutCompose   = errors.New("the '--resolve-digests' flag is only supported for okteto manifests with compose files")
+	errSkipUnresolvableWithoutResolve = errors.New("the '--skip-unresolvable' flag requires the '--resolve-digests' flag")
+	errBuilderWithRemote              = errors.New("the '--builder' flag is not supported with '--remote'")
+	errWorkdirWithRemote              = errors.New("a working directory other than the folder of the okteto manifest is not supported with remote execution")
+	errRemoteInVanilla                = errors.New("remote execution is only supported in contexts with Okteto installed")
 )
 
 // Options represents options for deploy command
@@ -76,20 +82,38 @@ type Options struct {
 	ManifestPathFlag string
 	// ManifestPath is the path to the manifest used though the command execution.
 	// This might change its value during execution
-	ManifestPath          string
+	ManifestPath string
+	// Workdir is the directory where builds and deploy commands are resolved, instead of the folder of the manifest
+	Workdir               string
 	Name                  string
 	Namespace             string
 	K8sContext            string
 	Variables             []string
+	NamespaceLabels       []string
+	NamespacePrefix       string
+	BuildArgs             []string
 	StackServicesToDeploy []string
+	StackOverrides        []string
+	Builder               string
 	Timeout               time.Duration
 	NoBuild               bool
 	Dependencies          bool
 	RunWithoutBash        bool
 	RunInRemote           bool
 	RunInRemoteSet        bool
-	Wait                  bool
-	ShowCTA               bool
+	// TimeoutSet is true when the timeout is set by the user, taking precedence over 'deploy.timeout'
+	TimeoutSet       bool
+	Wait             bool
+	ShowCTA          bool
+	AllowPrivileged  bool
+	AllowHostAccess  bool
+	Strict           bool
+	ResolveDigests   bool
+	SkipUnresolvable bool
+	// ShowApplied prints the resources applied through the deploy proxy once the deploy finishes
+	ShowApplied bool
+	// HealthChecks runs the health checks of the manifest once the deployed resources are healthy
+	HealthChecks bool
 }
 
 type builderInterface interface {
@@ -141,6 +165,8 @@ type Command struct {
 	// to be able to call to deployer's cleanUp function as the deployer is gotten at runtime.
 	// This can probably be improved using context cancellation
 	onCleanUp []cleanUpFunc
+	// timer records the time taken by the dependencies and the deploy commands, and enforces the timeout set by the user
+	timer *deployTimer
 
 	IsRemote           bool
 	RunningInInstaller bool
@@ -199,11 +225,15 @@ $ okteto deploy --remote
 
 
 # Execute okteto deploy skipping the build
-$ okteto deploy --no-build=true`,
+$ okteto deploy --no-build=true
+
+# Execute okteto deploy and run the health checks of the manifest
+$ okteto deploy --health-checks`,
 		Args: utils.NoArgsAccepted(""),
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			// check if remote flag is used by the user
 			options.RunInRemoteSet = cmd.Flag("remote").Changed
+			options.TimeoutSet = cmd.Flag("timeout").Changed
 			// validate cmd options
 			if options.Dependencies && !okteto.IsOkteto() {
 				return fmt.Errorf("'dependencies' is only supported in contexts that have Okteto installed")
@@ -213,11 +243,48 @@ $ okteto deploy --no-build=true`,
 				return err
 			}
 
+			// the namespace can be a template like 'pr-${PR_NUMBER}-app' for ephemeral namespaces
+			ns, err := namespace.ExpandNamespace(options.NamespacePrefix, options.Namespace)
+			if err != nil {
+				return err
+			}
+			options.Namespace = ns
+			namespaceLabels, err := namespace.ExpandNamespaceLabels(options.NamespaceLabels)
+			if err != nil {
+				return err
+			}
+
+			if _, err := model.ParseStackOverrides(options.StackOverrides); err != nil {
+				return err
+			}
+
+			if options.SkipUnresolvable && !options.ResolveDigests {
+				return errSkipUnresolvableWithoutResolve
+			}
+
+			// the health checks run once the deployed resources are healthy
+			if options.HealthChecks {
+				options.Wait = true
+			}
+
 			// This is needed because the deploy command needs the original kubeconfig configuration even in the execution within another
 			// deploy command. If not, we could be proxying a proxy and we would be applying the incorrect deployed-by label
 			os.Setenv(constants.OktetoSkipConfigCredentialsUpdate, "false")
 
-			err := checkOktetoManifestPathFlag(options, afero.NewOsFs())
+			if options.AllowHostAccess {
+				os.Setenv(model.OktetoAllowHostAccessEnvVar, "true")
+			}
+
+			if options.Workdir != "" {
+				// the workdir is relative to the directory where the command is executed, before moving to the manifest folder
+				workdir, err := filepath.Abs(options.Workdir)
+				if err != nil {
+					return err
+				}
+				options.Workdir = workdir
+			}
+
+			err = checkOktetoManifestPathFlag(options, afero.NewOsFs())
 			if err != nil {
 				return err
 			}
@@ -226,27 +293,35 @@ $ okteto deploy --no-build=true`,
 				return err
 			}
 
-			if !okteto.IsOkteto() {
-				return oktetoErrors.ErrContextIsNotOktetoCluster
-			}
-
-			create, err := utils.ShouldCreateNamespace(ctx, okteto.GetContext().Namespace)
-			if err != nil {
+			if err := checkRemoteInVanilla(okteto.IsOkteto(), options.RunInRemote); err != nil {
 				return err
 			}
-			if create {
-				nsCmd, err := namespace.NewCommand(ioCtrl)
-				if err != nil {
-					return err
-				}
-				if err := nsCmd.Create(ctx, &namespace.CreateOptions{Namespace: okteto.GetContext().Namespace}); err != nil {
-					return err
+
+			if options.Builder != "" && options.RunInRemote {
+				return oktetoErrors.UserError{
+					E:    errBuilderWithRemote,
+					Hint: "Remote Execution always builds with the builder of your Okteto Context. Remove the '--builder' flag or the '--remote' flag",
 				}
 			}
+			if err := buildCmd.SelectBuilder(ctx, options.Builder, okteto.GetContext().Builder, buildCmd.ProbeBuilder); err != nil {
+				return err
+			}
 
 			options.ShowCTA = oktetoLog.IsInteractive()
 
 			k8sClientProvider := okteto.NewK8sClientProviderWithLogger(k8sLogger)
+			k8sClient, _, err := k8sClientProvider.Provide(okteto.GetContext().Cfg)
+			if err != nil {
+				return err
+			}
+
+			if err := createNamespace(ctx, okteto.GetContext().Namespace, namespaceLabels, k8sClient, ioCtrl); err != nil {
+				return err
+			}
+			if err := utils.CheckNamespaceAccess(ctx, okteto.GetContext().Namespace, k8sClient); err != nil {
+				return err
+			}
+
 			pc, err := pipelineCMD.NewCommand(at)
 			if err != nil {
 				return fmt.Errorf("could not create pipeline command: %w", err)
@@ -315,16 +390,29 @@ $ okteto deploy --no-build=true`,
 
 	cmd.Flags().StringVar(&options.Name, "name", "", "the name of the Development Environment")
 	cmd.Flags().StringVarP(&options.ManifestPath, "file", "f", "", "the path to the Okteto Manifest")
-	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "overwrite the current Okteto Namespace")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "overwrite the current Okteto Namespace, expanding environment variables like 'pr-${PR_NUMBER}-app'")
+	cmd.Flags().StringVar(&options.NamespacePrefix, "namespace-prefix", "", "prepend a prefix to the namespace separated by a dash, expanding environment variables like 'pr-${PR_NUMBER}'")
+	cmd.Flags().StringArrayVar(&options.NamespaceLabels, "namespace-label", nil, "set a label with the format 'key=value' on the namespace when it is created by the deploy, expanding environment variables (can be set more than once)")
 	cmd.Flags().StringVarP(&options.K8sContext, "context", "c", "", "overwrite the current Okteto Context")
 	cmd.Flags().StringArrayVarP(&options.Variables, "var", "v", []string{}, "set a variable for the deploy commands (can be set more than once)")
+	cmd.Flags().StringArrayVar(&options.BuildArgs, "build-arg", nil, "set a build-time variable for all the images of the build section (can be set more than once)")
+	cmd.Flags().StringArrayVar(&options.StackOverrides, "set", nil, "override a value of the compose services before deploying them, like 'services.api.replicas=3' (can be set more than once)")
+	cmd.Flags().StringVar(&options.Builder, "builder", "", "overwrite the builder of the current Okteto Context, like 'tcp://localhost:1234' or 'docker://local'")
 	cmd.Flags().BoolVarP(&options.NoBuild, "no-build", "", false, "skips the re-build of images")
 	cmd.Flags().BoolVarP(&options.Dependencies, "dependencies", "", false, "force deployment of repositories in the 'dependencies' section")
 	cmd.Flags().BoolVarP(&options.RunWithoutBash, "no-bash", "", false, "execute the command using the container's default shell instead of bash")
 	cmd.Flags().BoolVarP(&options.RunInRemote, "remote", "", false, "run the deploy commands using Remote Execution")
+	cmd.Flags().BoolVarP(&options.AllowPrivileged, "allow-privileged", "", false, "allow compose services with 'privileged' or 'devices'")
+	cmd.Flags().StringVar(&options.Workdir, "workdir", "", "the directory where builds and deploy commands are resolved (defaults to the folder of the Okteto Manifest)")
+	cmd.Flags().BoolVarP(&options.AllowHostAccess, "allow-host-access", "", false, "allow compose services with 'pid', 'ipc' or 'network_mode' set to 'host'")
+	cmd.Flags().BoolVar(&options.Strict, "strict", false, "fail on manifest fields that are not understood by this version of okteto instead of ignoring them")
+	cmd.Flags().BoolVar(&options.ResolveDigests, "resolve-digests", false, "deploy the images of the compose services with their digest instead of their tag")
+	cmd.Flags().BoolVar(&options.SkipUnresolvable, "skip-unresolvable", false, "when using '--resolve-digests', deploy the images that can't be resolved to a digest with their tag")
+	cmd.Flags().BoolVar(&options.ShowApplied, "show-applied", false, "print the resources applied by the deploy commands once the deploy finishes")
 
 	cmd.Flags().BoolVarP(&options.Wait, "wait", "w", false, "wait until the deployment finishes and pods are healthy")
-	cmd.Flags().DurationVarP(&options.Timeout, "timeout", "t", getDefaultTimeout(), "when using `wait`, the maximum time to wait for the resources of the deployment to be healthy")
+	cmd.Flags().DurationVarP(&options.Timeout, "timeout", "t", GetDefaultTimeout(), "the maximum time to deploy the dependencies and run the deploy commands, overriding 'deploy.timeout'. When using `wait`, also the maximum time to wait for the resources of the deployment to be healthy")
+	cmd.Flags().BoolVar(&options.HealthChecks, "health-checks", false, "run the health checks of the 'deploy' section once the resources are healthy (implies '--wait')")
 
 	return cmd
 }
@@ -359,8 +447,22 @@ func (dc *Command) Run(ctx context.Context, deployOptions *Options) error {
 	if err != nil {
 		return err
 	}
+	if err := manifest.ChangeWorkdir(deployOptions.Workdir, dc.Fs); err != nil {
+		return err
+	}
+	if manifest.Workdir != "" && manifest.ManifestPath != "" {
+		deployOptions.ManifestPath = manifest.ManifestPath
+	}
+	if err := model.CheckNewerVersionFields(manifest.GetNewerVersionFields(), deployOptions.Strict); err != nil {
+		return err
+	}
 	deployOptions.Manifest = manifest
 	oktetoLog.Debug("found okteto manifest")
+	setDeployTimeout(deployOptions)
+	dc.timer = newDeployTimer(deployOptions.Timeout, deployOptions.TimeoutSet && deployOptions.Timeout > 0)
+	if err := buildCmd.MergeBuildArgs(deployOptions.Manifest, deployOptions.BuildArgs); err != nil {
+		return err
+	}
 	dc.PipelineType = deployOptions.Manifest.Type
 
 	if deployOptions.Manifest.Deploy == nil && !deployOptions.Manifest.HasDependencies() {
@@ -489,7 +591,10 @@ func (dc *Command) Run(ctx context.Context, deployOptions *Options) error {
 		return nil
 	}
 
-	if err := buildImages(ctx, dc.Builder, dc.CfgMapHandler, deployOptions); err != nil {
+	err = dc.timer.run(ctx, "build", func(ctx context.Context) error {
+		return buildImages(ctx, dc.Builder, dc.CfgMapHandler, deployOptions)
+	})
+	if err != nil {
 		if errStatus := dc.CfgMapHandler.UpdateConfigMap(ctx, cfg, data, err); errStatus != nil {
 			return errStatus
 		}
@@ -552,6 +657,16 @@ func (dc *Command) Run(ctx context.Context, deployOptions *Options) error {
 		data.Status = pipeline.DeployedStatus
 	}
 
+	if err == nil && deployOptions.HealthChecks {
+		if err = dc.runHealthChecks(ctx, deployOptions); err != nil {
+			data.Status = pipeline.ErrorStatus
+		}
+	}
+
+	if deployOptions.ShowApplied {
+		dc.showAppliedResources(ctx, deployOptions)
+	}
+
 	if errStatus := dc.CfgMapHandler.UpdateConfigMap(ctx, cfg, data, err); errStatus != nil {
 		return errStatus
 	}
@@ -560,6 +675,19 @@ func (dc *Command) Run(ctx context.Context, deployOptions *Options) error {
 }
 
 func (dc *Command) deploy(ctx context.Context, deployOptions *Options, cwd string, c kubernetes.Interface) error {
+	if len(deployOptions.StackOverrides) > 0 && deployOptions.Manifest.Deploy.ComposeSection == nil {
+		return oktetoErrors.UserError{
+			E:    errSetWithoutCompose,
+			Hint: "Remove the '--set' flag or add a compose file to the deploy section of your okteto manifest",
+		}
+	}
+	if deployOptions.ResolveDigests && deployOptions.Manifest.Deploy.ComposeSection == nil {
+		return oktetoErrors.UserError{
+			E:    errResolveDigestsWithoutCompose,
+			Hint: "Remove the '--resolve-digests' flag or add a compose file to the deploy section of your okteto manifest",
+		}
+	}
+
 	// If the command is configured to execute things remotely (--remote, deploy.image or deploy.remote) it should be executed in the remote. If not, it should be executed locally
 	deployer, err := dc.GetDeployer(
 		ctx,
@@ -576,10 +704,21 @@ func (dc *Command) deploy(ctx context.Context, deployOptions *Options, cwd strin
 		return err
 	}
 
+	if ld, ok := deployer.(*localDeployer); ok {
+		ld.timeCommands(ctx, dc.timer)
+	}
+
 	// Once we have the deployer, we add the clean up function to the list of clean up functions to be executed to clean all the resources
 	dc.onCleanUp = append(dc.onCleanUp, deployer.CleanUp)
 
-	err = deployer.Deploy(ctx, deployOptions)
+	// the commands of a local deploy are timed one by one by the executor of the deployer
+	if ShouldRunInRemote(deployOptions) {
+		err = dc.timer.run(ctx, "remote deploy", func(ctx context.Context) error {
+			return deployer.Deploy(ctx, deployOptions)
+		})
+	} else {
+		err = deployer.Deploy(ctx, deployOptions)
+	}
 	if err != nil {
 		return err
 	}
@@ -596,7 +735,9 @@ func (dc *Command) deploy(ctx context.Context, deployOptions *Options, cwd strin
 		oktetoLog.SetStage(stage)
 		oktetoLog.Information("Running stage '%s'", stage)
 		startTime := time.Now()
-		err := dc.deployStack(ctx, deployOptions)
+		err := dc.timer.run(ctx, "compose", func(ctx context.Context) error {
+			return dc.deployStack(ctx, deployOptions)
+		})
 		elapsedTime := time.Since(startTime)
 		if addPhaseErr := dc.CfgMapHandler.AddPhaseDuration(ctx, deployOptions.Name, okteto.GetContext().Namespace, deployComposePhaseName, elapsedTime); addPhaseErr != nil {
 			oktetoLog.Infof("error adding phase to configmap: %s", addPhaseErr)
@@ -637,7 +778,8 @@ func (dc *Command) deploy(ctx context.Context, deployOptions *Options, cwd strin
 	return nil
 }
 
-func getDefaultTimeout() time.Duration {
+// GetDefaultTimeout returns the timeout of the deploy set by OKTETO_TIMEOUT, or 5 minutes if it's not set
+func GetDefaultTimeout() time.Duration {
 	defaultTimeout := 5 * time.Minute
 	t := os.Getenv(model.OktetoTimeoutEnvVar)
 	if t == "" {
@@ -654,6 +796,15 @@ func getDefaultTimeout() time.Duration {
 	return parsed
 }
 
+// setDeployTimeout sets the timeout of the deploy: the '--timeout' flag takes precedence over the 'deploy.timeout' field.
+// The timeout is only enforced for the whole deploy when it's set by one of them
+func setDeployTimeout(opts *Options) {
+	if !opts.TimeoutSet && opts.Manifest.Deploy != nil && opts.Manifest.Deploy.Timeout != 0 {
+		opts.Timeout = opts.Manifest.Deploy.Timeout
+		opts.TimeoutSet = true
+	}
+}
+
 // ShouldRunInRemote determines if the deploy command should run in remote
 // default behavior is set by cluster config, but can be overridden by the user using the flag --remote or the manifest deploy.remote
 func ShouldRunInRemote(opts *Options) bool {
@@ -706,6 +857,12 @@ func GetDeployer(ctx context.Context,
 	conn buildCmd.BuildkitConnector,
 ) (Deployer, error) {
 	if ShouldRunInRemote(opts) {
+		if opts.Manifest.Workdir != "" {
+			return nil, oktetoErrors.UserError{
+				E:    errWorkdirWithRemote,
+				Hint: "Remote Execution runs from the folder of the okteto manifest. Remove the '--workdir' flag and the 'context' field of your okteto manifest, or run 'okteto deploy --remote=false'",
+			}
+		}
 		oktetoLog.Info("Deploying remotely...")
 		return newRemoteDeployer(buildEnvVarsGetter, ioCtrl, dependencyEnvVarsGetter, conn), nil
 	}
@@ -784,6 +941,43 @@ func resolveIsRedeploy(ctx context.Context, name, namespace string, c kubernetes
 }
 
 // deployDependencies deploy the dependencies in the manifest
+// checkRemoteInVanilla fails when remote execution is requested in a context without Okteto installed.
+// Vanilla contexts deploy from the local machine
+func checkRemoteInVanilla(isOkteto, runInRemote bool) error {
+	if isOkteto || !runInRemote {
+		return nil
+	}
+	return oktetoErrors.UserError{
+		E:    errRemoteInVanilla,
+		Hint: "Remove the '--remote' flag to deploy from your machine",
+	}
+}
+
+// createNamespace creates the namespace of the deploy if it doesn't exist. Okteto contexts create it through the Okteto API,
+// while vanilla contexts create it through the Kubernetes API without asking for confirmation
+func createNamespace(ctx context.Context, ns string, labels map[string]string, c kubernetes.Interface, ioCtrl *io.Controller) error {
+	if !okteto.IsOkteto() {
+		_, err := namespace.CreateVanillaNamespace(ctx, ns, labels, c)
+		return err
+	}
+
+	create, err := utils.ShouldCreateNamespace(ctx, ns)
+	if err != nil {
+		return err
+	}
+	if !create {
+		return nil
+	}
+	nsCmd, err := namespace.NewCommand(ioCtrl)
+	if err != nil {
+		return err
+	}
+	if err := nsCmd.Create(ctx, &namespace.CreateOptions{Namespace: ns}); err != nil {
+		return err
+	}
+	return namespace.LabelCreatedNamespace(ctx, ns, labels, c)
+}
+
 func (dc *Command) deployDependencies(ctx context.Context, deployOptions *Options) error {
 	if len(deployOptions.Manifest.Dependencies) > 0 && !okteto.GetContext().IsOkteto {
 		return errDepenNotAvailableInVanilla
@@ -823,7 +1017,10 @@ func (dc *Command) deployDependencies(ctx context.Context, deployOptions *Option
 			ParentWorkflowID: parentWorkflowID,
 		}
 
-		if err := dc.PipelineCMD.ExecuteDeployPipeline(ctx, pipOpts); err != nil {
+		err = dc.timer.run(ctx, fmt.Sprintf("dependency '%s'", depName), func(ctx context.Context) error {
+			return dc.PipelineCMD.ExecuteDeployPipeline(ctx, pipOpts)
+		})
+		if err != nil {
 			return err
 		}
 		if dep.Wait {
@@ -925,6 +1122,19 @@ func (dc *Command) deployStack(ctx context.Context, opts *Options) error {
 	composeSectionInfo := opts.Manifest.Deploy.ComposeSection
 	composeSectionInfo.Stack.Namespace = okteto.GetContext().Namespace
 
+	if len(opts.StackOverrides) > 0 {
+		overrides, err := model.ParseStackOverrides(opts.StackOverrides)
+		if err != nil {
+			return err
+		}
+		if err := composeSectionInfo.Stack.ApplyOverrides(overrides); err != nil {
+			return err
+		}
+		if err := composeSectionInfo.Stack.Validate(); err != nil {
+			return err
+		}
+	}
+
 	var composeFiles []string
 	for _, composeInfo := range composeSectionInfo.ComposesInfo {
 		composeFiles = append(composeFiles, composeInfo.File)
@@ -936,6 +1146,9 @@ func (dc *Command) deployStack(ctx context.Context, opts *Options) error {
 		Timeout:          opts.Timeout,
 		ServicesToDeploy: opts.StackServicesToDeploy,
 		InsidePipeline:   true,
+		ResolveDigests:   opts.ResolveDigests,
+		SkipUnresolvable: opts.SkipUnresolvable,
+		AllowPrivileged:  opts.AllowPrivileged,
 	}
 
 	c, cfg, err := dc.K8sClientProvider.ProvideWithLogger(okteto.GetContext().Cfg, dc.K8sLogger)
@@ -24,6 +24,7 @@ import (
 
 	"github.com/google/go-containerregistry/pkg/name"
 	buildv2 "github.com/okteto/okteto/cmd/build/v2"
+	"github.com/okteto/okteto/cmd/namespace"
 	pipelineCMD "github.com/okteto/okteto/cmd/pipeline"
 	"github.com/okteto/okteto/internal/test"
 	"github.com/okteto/okteto/pkg/analytics"
@@ -490,6 +491,78 @@ func TestDeployWithErrorDeploying(t *testing.T) {
 	fakeDeployer.AssertExpectations(t)
 }
 
+func TestDeployWithStackOverridesWithoutCompose(t *testing.T) {
+	fakeNamespace := "test"
+	fakeK8sClientProvider := test.NewFakeK8sProvider()
+	fakeDeployer := &fakeDeployer{}
+	okteto.CurrentStore = &okteto.ContextStore{
+		Contexts: map[string]*okteto.Context{
+			"test": {
+				Namespace: fakeNamespace,
+				Cfg:       &api.Config{},
+			},
+		},
+		CurrentContext: "test",
+	}
+	c := &Command{
+		AnalyticsTracker:  &fakeTracker{},
+		GetManifest:       getFakeManifest,
+		GetDeployer:       fakeDeployer.Get,
+		K8sClientProvider: fakeK8sClientProvider,
+		CfgMapHandler:     newDefaultConfigMapHandler(fakeK8sClientProvider, nil),
+		Fs:                afero.NewMemMapFs(),
+		Builder:           &fakeV2Builder{},
+		IoCtrl:            io.NewIOController(),
+	}
+	opts := &Options{
+		Name:           "movies",
+		Namespace:      fakeNamespace,
+		Variables:      []string{},
+		StackOverrides: []string{"services.api.replicas=3"},
+	}
+
+	err := c.Run(context.Background(), opts)
+
+	assert.ErrorIs(t, err, errSetWithoutCompose)
+	fakeDeployer.AssertNotCalled(t, "Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeployWithResolveDigestsWithoutCompose(t *testing.T) {
+	fakeNamespace := "test"
+	fakeK8sClientProvider := test.NewFakeK8sProvider()
+	fakeDeployer := &fakeDeployer{}
+	okteto.CurrentStore = &okteto.ContextStore{
+		Contexts: map[string]*okteto.Context{
+			"test": {
+				Namespace: fakeNamespace,
+				Cfg:       &api.Config{},
+			},
+		},
+		CurrentContext: "test",
+	}
+	c := &Command{
+		AnalyticsTracker:  &fakeTracker{},
+		GetManifest:       getFakeManifest,
+		GetDeployer:       fakeDeployer.Get,
+		K8sClientProvider: fakeK8sClientProvider,
+		CfgMapHandler:     newDefaultConfigMapHandler(fakeK8sClientProvider, nil),
+		Fs:                afero.NewMemMapFs(),
+		Builder:           &fakeV2Builder{},
+		IoCtrl:            io.NewIOController(),
+	}
+	opts := &Options{
+		Name:           "movies",
+		Namespace:      fakeNamespace,
+		Variables:      []string{},
+		ResolveDigests: true,
+	}
+
+	err := c.Run(context.Background(), opts)
+
+	assert.ErrorIs(t, err, errResolveDigestsWithoutCompose)
+	fakeDeployer.AssertNotCalled(t, "Get", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestDeployWithErrorBecauseOtherPipelineRunning(t *testing.T) {
 	fakeNamespace := "test"
 	opts := &Options{
@@ -638,6 +711,90 @@ func TestDeployWithoutErrors(t *testing.T) {
 	divert.AssertExpectations(t)
 }
 
+func TestDeployRunsFromWorkdir(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	apiDir := filepath.Join(root, "services", "api")
+	require.NoError(t, os.MkdirAll(apiDir, 0700))
+	initialCWD, err := os.Getwd()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, os.Chdir(initialCWD))
+	})
+
+	fakeNamespace := "test"
+	fakeK8sClientProvider := test.NewFakeK8sProvider()
+	fakeDeployer := &fakeDeployer{}
+	okteto.CurrentStore = &okteto.ContextStore{
+		Contexts: map[string]*okteto.Context{
+			"test": {
+				Namespace: fakeNamespace,
+				Cfg:       &api.Config{},
+			},
+		},
+		CurrentContext: "test",
+	}
+
+	c := &Command{
+		AnalyticsTracker: &fakeTracker{},
+		GetManifest: func(_ string, _ afero.Fs) (*model.Manifest, error) {
+			return &model.Manifest{
+				ManifestPath: filepath.Join(apiDir, "okteto.yml"),
+				Context:      "../..",
+				Deploy: &model.DeployInfo{
+					Commands: []model.DeployCommand{{Name: "deploy", Command: "make deploy"}},
+				},
+			}, nil
+		},
+		K8sClientProvider: fakeK8sClientProvider,
+		EndpointGetter:    getFakeEndpoint,
+		Fs:                afero.NewOsFs(),
+		CfgMapHandler:     newDefaultConfigMapHandler(fakeK8sClientProvider, nil),
+		GetDeployer:       fakeDeployer.Get,
+		Builder:           &fakeV2Builder{},
+		IoCtrl:            io.NewIOController(),
+	}
+	opts := &Options{
+		Name:         "movies",
+		Namespace:    fakeNamespace,
+		ManifestPath: filepath.Join(apiDir, "okteto.yml"),
+		Variables:    []string{},
+	}
+
+	var deployCWD string
+	fakeDeployer.On(
+		"Get",
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+		mock.Anything,
+	).Return(fakeDeployer, nil)
+	fakeDeployer.On("Deploy", mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+		deployCWD, err = os.Getwd()
+	}).Return(nil)
+
+	require.NoError(t, c.Run(context.Background(), opts))
+	assert.Equal(t, root, deployCWD)
+	assert.Equal(t, filepath.Join("services", "api", "okteto.yml"), opts.ManifestPath)
+}
+
+func TestGetDeployerWithWorkdirInRemote(t *testing.T) {
+	opts := &Options{
+		RunInRemote: true,
+		Manifest: &model.Manifest{
+			Workdir: "/repo",
+			Deploy:  &model.DeployInfo{},
+		},
+	}
+	_, err := GetDeployer(context.Background(), opts, nil, nil, nil, io.NewIOController(), nil, nil, nil)
+	assert.ErrorIs(t, err, errWorkdirWithRemote)
+}
+
 func TestDeployWithErrorGettingDivertDriver(t *testing.T) {
 	fakeNamespace := "test"
 	fakeOs := afero.NewMemMapFs()
@@ -835,7 +992,7 @@ func TestGetDefaultTimeout(t *testing.T) {
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Setenv(model.OktetoTimeoutEnvVar, tc.envarValue)
-			assert.Equal(t, tc.expected, getDefaultTimeout())
+			assert.Equal(t, tc.expected, GetDefaultTimeout())
 		})
 	}
 }
@@ -1565,3 +1722,41 @@ func Test_resolveIsRedeploy_FallbackToConfigmap(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckRemoteInVanilla(t *testing.T) {
+	require.NoError(t, checkRemoteInVanilla(true, true))
+	require.NoError(t, checkRemoteInVanilla(true, false))
+	require.NoError(t, checkRemoteInVanilla(false, false))
+	require.ErrorIs(t, checkRemoteInVanilla(false, true), errRemoteInVanilla)
+}
+
+func TestCreateNamespaceInVanilla(t *testing.T) {
+	okteto.CurrentStore = &okteto.ContextStore{
+		Contexts: map[string]*okteto.Context{
+			"test": {
+				Namespace: "pr-1234-app",
+				Cfg:       &api.Config{},
+			},
+		},
+		CurrentContext: "test",
+	}
+	ctx := context.Background()
+	c := fake.NewSimpleClientset(&apiv1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "existing",
+			Labels: map[string]string{"team": "backend"},
+		},
+	})
+	labels := map[string]string{"pr": "1234"}
+
+	require.NoError(t, createNamespace(ctx, "pr-1234-app", labels, c, io.NewIOController()))
+	ns, err := c.CoreV1().Namespaces().Get(ctx, "pr-1234-app", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"pr": "1234", namespace.CreatedByOktetoLabel: "true"}, ns.Labels)
+
+	// existing namespaces are not labeled, so they are never deleted by 'okteto destroy --delete-namespace'
+	require.NoError(t, createNamespace(ctx, "existing", labels, c, io.NewIOController()))
+	ns, err = c.CoreV1().Namespaces().Get(ctx, "existing", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"team": "backend"}, ns.Labels)
+}
@@ -0,0 +1,246 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	"github.com/okteto/okteto/pkg/env"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/k8s/exec"
+	"github.com/okteto/okteto/pkg/k8s/pods"
+	"github.com/okteto/okteto/pkg/k8s/statefulsets"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	httpHealthCheckType = "http"
+	execHealthCheckType = "exec"
+
+	// healthCheckRetryInterval is the time between the attempts of a failed health check
+	healthCheckRetryInterval = 2 * time.Second
+)
+
+// podExecutor runs a command in a pod of a service of the development environment
+type podExecutor interface {
+	Exec(ctx context.Context, service string, command []string, output io.Writer) error
+}
+
+// k8sPodExecutor runs the commands in the first running pod of a deployment or statefulset
+type k8sPodExecutor struct {
+	client     kubernetes.Interface
+	restConfig *rest.Config
+	namespace  string
+}
+
+// Exec runs the command in the first container of a running pod of the service
+func (e k8sPodExecutor) Exec(ctx context.Context, service string, command []string, output io.Writer) error {
+	pod, err := e.getRunningPod(ctx, service)
+	if err != nil {
+		return err
+	}
+	stderr := &bytes.Buffer{}
+	container := pod.Spec.Containers[0].Name
+	if err := exec.Exec(ctx, e.client, e.restConfig, e.namespace, pod.Name, container, false, nil, output, stderr, command); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+func (e k8sPodExecutor) getRunningPod(ctx context.Context, service string) (*apiv1.Pod, error) {
+	var selector map[string]string
+	if d, err := deployments.Get(ctx, service, e.namespace, e.client); err == nil {
+		selector = d.Spec.Selector.MatchLabels
+	} else if sfs, err := statefulsets.Get(ctx, service, e.namespace, e.client); err == nil {
+		selector = sfs.Spec.Selector.MatchLabels
+	} else {
+		return nil, fmt.Errorf("service '%s' not found in namespace '%s'", service, e.namespace)
+	}
+
+	podList, err := pods.ListBySelector(ctx, e.namespace, selector, e.client)
+	if err != nil {
+		return nil, err
+	}
+	for i := range podList {
+		if podList[i].Status.Phase == apiv1.PodRunning && podList[i].DeletionTimestamp == nil {
+			return &podList[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no running pods found for service '%s'", service)
+}
+
+// healthCheckRunner runs the health checks of the deploy section
+type healthCheckRunner struct {
+	client        *http.Client
+	executor      podExecutor
+	retryInterval time.Duration
+}
+
+// run runs every health check, retrying the failed attempts, and returns their results
+func (r *healthCheckRunner) run(ctx context.Context, checks []model.DeployHealthCheck) []pipeline.HealthCheckResult {
+	results := make([]pipeline.HealthCheckResult, 0, len(checks))
+	for i := range checks {
+		results = append(results, r.runCheck(ctx, &checks[i]))
+	}
+	return results
+}
+
+func (r *healthCheckRunner) runCheck(ctx context.Context, hc *model.DeployHealthCheck) pipeline.HealthCheckResult {
+	result := pipeline.HealthCheckResult{
+		Name: hc.Name,
+		Type: execHealthCheckType,
+	}
+	if hc.HTTP != nil {
+		result.Type = httpHealthCheckType
+	}
+
+	start := time.Now()
+	var err error
+attempts:
+	for attempt := 1; attempt <= hc.Retries+1; attempt++ {
+		result.Attempts = attempt
+		err = r.attempt(ctx, hc)
+		if err == nil || attempt > hc.Retries {
+			break
+		}
+		oktetoLog.Infof("health check '%s' failed: %s. Retrying...", hc.Name, err)
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+			break attempts
+		case <-time.After(r.retryInterval):
+		}
+	}
+	result.Duration = math.Round(time.Since(start).Seconds()*1000) / 1000
+
+	result.Status = pipeline.HealthCheckPassed
+	if err != nil {
+		result.Status = pipeline.HealthCheckFailed
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// attempt runs the health check once, within its timeout
+func (r *healthCheckRunner) attempt(ctx context.Context, hc *model.DeployHealthCheck) error {
+	ctx, cancel := context.WithTimeout(ctx, hc.Timeout)
+	defer cancel()
+	if hc.HTTP != nil {
+		return r.checkHTTP(ctx, hc.HTTP)
+	}
+	err := r.executor.Exec(ctx, hc.Exec.Service, hc.Exec.Command.Values, io.Discard)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("command timed out after %s", hc.Timeout)
+	}
+	return err
+}
+
+func (r *healthCheckRunner) checkHTTP(ctx context.Context, check *model.HTTPDeployHealthCheck) error {
+	url, err := env.ExpandEnv(check.URL)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != check.Status {
+		return fmt.Errorf("expected status %d, got %d", check.Status, resp.StatusCode)
+	}
+	return nil
+}
+
+// runHealthChecks runs the health checks of the manifest, prints their results and records them in the config map
+func (dc *Command) runHealthChecks(ctx context.Context, opts *Options) error {
+	checks := opts.Manifest.Deploy.HealthChecks
+	if len(checks) == 0 {
+		oktetoLog.Warning("No health checks defined in the 'deploy' section of your okteto manifest")
+		return nil
+	}
+
+	namespace := okteto.GetContext().Namespace
+	c, restConfig, err := dc.K8sClientProvider.ProvideWithLogger(okteto.GetContext().Cfg, dc.K8sLogger)
+	if err != nil {
+		return err
+	}
+	runner := &healthCheckRunner{
+		client:        &http.Client{},
+		executor:      k8sPodExecutor{client: c, restConfig: restConfig, namespace: namespace},
+		retryInterval: healthCheckRetryInterval,
+	}
+
+	oktetoLog.Spinner(fmt.Sprintf("Running health checks of %s...", opts.Name))
+	oktetoLog.StartSpinner()
+	results := runner.run(ctx, checks)
+	oktetoLog.StopSpinner()
+
+	printHealthChecks(os.Stdout, results)
+	if err := dc.CfgMapHandler.UpdateHealthChecks(ctx, opts.Name, namespace, results); err != nil {
+		oktetoLog.Infof("could not record the health checks: %s", err)
+	}
+	return healthChecksError(results)
+}
+
+// printHealthChecks writes the table of results of the health checks
+func printHealthChecks(w io.Writer, results []pipeline.HealthCheckResult) {
+	tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+	fmt.Fprintf(tw, "Health check\tType\tStatus\tAttempts\tDuration\tError\n")
+	for _, r := range results {
+		errMsg := r.Error
+		if errMsg == "" {
+			errMsg = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%.1fs\t%s\n", r.Name, r.Type, r.Status, r.Attempts, r.Duration, errMsg)
+	}
+	tw.Flush()
+}
+
+// healthChecksError returns an error naming the failed health checks, if any
+func healthChecksError(results []pipeline.HealthCheckResult) error {
+	var failed []string
+	for _, r := range results {
+		if r.Status == pipeline.HealthCheckFailed {
+			failed = append(failed, fmt.Sprintf("'%s'", r.Name))
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return oktetoErrors.UserError{
+		E:    fmt.Errorf("health checks failed: %s", strings.Join(failed, ", ")),
+		Hint: "Check the errors of the health checks above, or run 'okteto deploy' without '--health-checks' to skip them",
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePodExecutor struct {
+	errs     map[string][]error
+	commands map[string][][]string
+}
+
+func (e *fakePodExecutor) Exec(ctx context.Context, service string, command []string, _ io.Writer) error {
+	if e.commands == nil {
+		e.commands = map[string][][]string{}
+	}
+	e.commands[service] = append(e.commands[service], command)
+	errs := e.errs[service]
+	if len(errs) == 0 {
+		return nil
+	}
+	e.errs[service] = errs[1:]
+	if errs[0] == context.DeadlineExceeded {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return errs[0]
+}
+
+func TestHealthCheckRunner(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			w.WriteHeader(http.StatusOK)
+		case "/created":
+			w.WriteHeader(http.StatusCreated)
+		case "/flaky":
+			calls++
+			if calls < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("HEALTHCHECK_SERVER", server.URL)
+
+	executor := &fakePodExecutor{
+		errs: map[string][]error{
+			"api":    {errors.New("exit code 1"), nil},
+			"worker": {errors.New("exit code 1"), errors.New("exit code 2")},
+			"db":     {context.DeadlineExceeded},
+		},
+	}
+	runner := &healthCheckRunner{
+		client:   server.Client(),
+		executor: executor,
+	}
+
+	checks := []model.DeployHealthCheck{
+		{Name: "ok", HTTP: &model.HTTPDeployHealthCheck{URL: "${HEALTHCHECK_SERVER}/ok", Status: http.StatusOK}, Timeout: time.Second},
+		{Name: "created", HTTP: &model.HTTPDeployHealthCheck{URL: server.URL + "/created", Status: http.StatusCreated}, Timeout: time.Second},
+		{Name: "flaky", HTTP: &model.HTTPDeployHealthCheck{URL: server.URL + "/flaky", Status: http.StatusOK}, Timeout: time.Second, Retries: 2},
+		{Name: "missing", HTTP: &model.HTTPDeployHealthCheck{URL: server.URL + "/missing", Status: http.StatusOK}, Timeout: time.Second, Retries: 1},
+		{Name: "api", Exec: &model.ExecDeployHealthCheck{Service: "api", Command: model.Command{Values: []string{"sh", "-c", "curl localhost"}}}, Timeout: time.Second, Retries: 1},
+		{Name: "worker", Exec: &model.ExecDeployHealthCheck{Service: "worker", Command: model.Command{Values: []string{"true"}}}, Timeout: time.Second, Retries: 1},
+		{Name: "db", Exec: &model.ExecDeployHealthCheck{Service: "db", Command: model.Command{Values: []string{"pg_isready"}}}, Timeout: 10 * time.Millisecond},
+	}
+	results := runner.run(context.Background(), checks)
+	for i := range results {
+		results[i].Duration = 0
+	}
+
+	expected := []pipeline.HealthCheckResult{
+		{Name: "ok", Type: httpHealthCheckType, Status: pipeline.HealthCheckPassed, Attempts: 1},
+		{Name: "created", Type: httpHealthCheckType, Status: pipeline.HealthCheckPassed, Attempts: 1},
+		{Name: "flaky", Type: httpHealthCheckType, Status: pipeline.HealthCheckPassed, Attempts: 3},
+		{Name: "missing", Type: httpHealthCheckType, Status: pipeline.HealthCheckFailed, Attempts: 2, Error: "expected status 200, got 404"},
+		{Name: "api", Type: execHealthCheckType, Status: pipeline.HealthCheckPassed, Attempts: 2},
+		{Name: "worker", Type: execHealthCheckType, Status: pipeline.HealthCheckFailed, Attempts: 2, Error: "exit code 2"},
+		{Name: "db", Type: execHealthCheckType, Status: pipeline.HealthCheckFailed, Attempts: 1, Error: "command timed out after 10ms"},
+	}
+	assert.Equal(t, expected, results)
+	assert.Equal(t, [][]string{{"sh", "-c", "curl localhost"}, {"sh", "-c", "curl localhost"}}, executor.commands["api"])
+}
+
+func TestHealthCheckRunnerCanceled(t *testing.T) {
+	executor := &fakePodExecutor{
+		errs: map[string][]error{
+			"api": {errors.New("exit code 1")},
+		},
+	}
+	runner := &healthCheckRunner{
+		executor:      executor,
+		retryInterval: time.Hour,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	checks := []model.DeployHealthCheck{
+		{Name: "api", Exec: &model.ExecDeployHealthCheck{Service: "api", Command: model.Command{Values: []string{"true"}}}, Timeout: time.Second, Retries: 5},
+	}
+	results := runner.run(ctx, checks)
+	require.Len(t, results, 1)
+	assert.Equal(t, pipeline.HealthCheckFailed, results[0].Status)
+	assert.Equal(t, 1, results[0].Attempts)
+	assert.Equal(t, context.Canceled.Error(), results[0].Error)
+}
+
+func TestHealthChecksError(t *testing.T) {
+	assert.NoError(t, healthChecksError([]pipeline.HealthCheckResult{
+		{Name: "ok", Status: pipeline.HealthCheckPassed},
+	}))
+
+	err := healthChecksError([]pipeline.HealthCheckResult{
+		{Name: "ok", Status: pipeline.HealthCheckPassed},
+		{Name: "api", Status: pipeline.HealthCheckFailed},
+		{Name: "db", Status: pipeline.HealthCheckFailed},
+	})
+	require.ErrorAs(t, err, &oktetoErrors.UserError{})
+	assert.EqualError(t, err, "health checks failed: 'api', 'db'")
+}
+
+func TestPrintHealthChecks(t *testing.T) {
+	var b bytes.Buffer
+	printHealthChecks(&b, []pipeline.HealthCheckResult{
+		{Name: "frontend", Type: httpHealthCheckType, Status: pipeline.HealthCheckPassed, Attempts: 1, Duration: 0.25},
+		{Name: "api", Type: execHealthCheckType, Status: pipeline.HealthCheckFailed, Attempts: 3, Duration: 12, Error: "exit code 1"},
+	})
+	expected := `Health check  Type  Status  Attempts  Duration  Error
+frontend      http  passed  1         0.2s      -
+api           exec  failed  3         12.0s     exit code 1
+`
+	assert.Equal(t, expected, b.String())
+}
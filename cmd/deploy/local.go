@@ -73,6 +73,13 @@ func (ld *localDeployer) Deploy(ctx context.Context, deployOptions *Options) err
 	return err
 }
 
+// timeCommands runs each deploy command as a step of the deploy timer
+func (ld *localDeployer) timeCommands(ctx context.Context, timer *deployTimer) {
+	if r, ok := ld.runner.(*deployable.DeployRunner); ok && timer != nil {
+		r.Executor = &timedExecutor{ManifestExecutor: r.Executor, ctx: ctx, timer: timer}
+	}
+}
+
 func (ld *localDeployer) CleanUp(ctx context.Context, err error) {
 	ld.runner.CleanUp(ctx, err)
 }
@@ -0,0 +1,136 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/okteto/okteto/cmd/utils/executor"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+var (
+	// errDeployTimeout is returned when the deploy doesn't finish before its timeout
+	errDeployTimeout = errors.New("deploy timed out")
+)
+
+// deployStep is a step of the deploy, like a dependency or a deploy command, and the time it took
+type deployStep struct {
+	name     string
+	elapsed  time.Duration
+	timedOut bool
+}
+
+// deployTimer runs the steps of a deploy recording the time each of them takes.
+// When the timeout is enforced, the step running when the timeout expires fails with an error naming it
+type deployTimer struct {
+	deadline time.Time
+	now      func() time.Time
+	steps    []deployStep
+	timeout  time.Duration
+	enforced bool
+	mu       sync.Mutex
+}
+
+// newDeployTimer returns a deploy timer. The timeout is only enforced if it's set by the user,
+// with the '--timeout' flag or the 'deploy.timeout' field, as deploys used to have no time limit
+func newDeployTimer(timeout time.Duration, enforced bool) *deployTimer {
+	return &deployTimer{
+		deadline: time.Now().Add(timeout),
+		now:      time.Now,
+		timeout:  timeout,
+		enforced: enforced,
+	}
+}
+
+// run runs a step of the deploy. A nil timer runs the step without recording it
+func (t *deployTimer) run(ctx context.Context, name string, step func(ctx context.Context) error) error {
+	if t == nil {
+		return step(ctx)
+	}
+
+	start := t.now()
+	if !t.enforced {
+		err := step(ctx)
+		t.record(name, t.now().Sub(start), false)
+		return err
+	}
+
+	stepCtx, cancel := context.WithDeadline(ctx, t.deadline)
+	defer cancel()
+	result := make(chan error, 1)
+	go func() {
+		result <- step(stepCtx)
+	}()
+
+	select {
+	case err := <-result:
+		if err != nil && errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			t.record(name, t.now().Sub(start), true)
+			return t.timeoutError(name)
+		}
+		t.record(name, t.now().Sub(start), false)
+		return err
+	case <-stepCtx.Done():
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		t.record(name, t.now().Sub(start), true)
+		return t.timeoutError(name)
+	}
+}
+
+func (t *deployTimer) record(name string, elapsed time.Duration, timedOut bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.steps = append(t.steps, deployStep{name: name, elapsed: elapsed.Round(time.Second), timedOut: timedOut})
+}
+
+// timeoutError returns the error of a step running when the timeout expired, with the time taken by each step
+func (t *deployTimer) timeoutError(name string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	steps := make([]string, 0, len(t.steps))
+	for _, s := range t.steps {
+		step := fmt.Sprintf("%s: %s", s.name, s.elapsed)
+		if s.timedOut {
+			step = fmt.Sprintf("%s (timed out)", step)
+		}
+		steps = append(steps, step)
+	}
+	return oktetoErrors.UserError{
+		E:    fmt.Errorf("%w after %s while running %s. Elapsed time of each step:\n    - %s", errDeployTimeout, t.timeout, name, strings.Join(steps, "\n    - ")),
+		Hint: "Increase the timeout with the '--timeout' flag or the 'deploy.timeout' field of your okteto manifest",
+	}
+}
+
+// timedExecutor runs each deploy command as a step of the deploy timer
+type timedExecutor struct {
+	executor.ManifestExecutor
+	ctx   context.Context
+	timer *deployTimer
+}
+
+// Execute runs a deploy command as a step of the deploy timer
+func (e *timedExecutor) Execute(command model.DeployCommand, env []string) error {
+	return e.timer.run(e.ctx, fmt.Sprintf("command '%s'", command.Name), func(context.Context) error {
+		return e.ManifestExecutor.Execute(command, env)
+	})
+}
@@ -0,0 +1,170 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deploy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pipelineCMD "github.com/okteto/okteto/cmd/pipeline"
+	"github.com/okteto/okteto/pkg/deps"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// slowExecutor blocks the commands in slow until release is closed
+type slowExecutor struct {
+	slow     map[string]bool
+	release  chan struct{}
+	executed []string
+}
+
+func (e *slowExecutor) Execute(command model.DeployCommand, _ []string) error {
+	e.executed = append(e.executed, command.Name)
+	if e.slow[command.Name] {
+		<-e.release
+	}
+	return nil
+}
+
+func (*slowExecutor) CleanUp(error) {}
+
+// slowPipelineDeployer blocks the deploy of the dependencies in slow until their context is done
+type slowPipelineDeployer struct {
+	slow map[string]bool
+}
+
+func (d *slowPipelineDeployer) ExecuteDeployPipeline(ctx context.Context, opts *pipelineCMD.DeployOptions) error {
+	if d.slow[opts.Name] {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return nil
+}
+
+func TestDeployTimerAttributesTimeoutToCommand(t *testing.T) {
+	exec := &slowExecutor{slow: map[string]bool{"helm upgrade": true}, release: make(chan struct{})}
+	defer close(exec.release)
+
+	timer := newDeployTimer(100*time.Millisecond, true)
+	timedExec := &timedExecutor{ManifestExecutor: exec, ctx: context.Background(), timer: timer}
+
+	require.NoError(t, timer.run(context.Background(), "build", func(context.Context) error { return nil }))
+	require.NoError(t, timedExec.Execute(model.DeployCommand{Name: "kubectl apply"}, nil))
+	err := timedExec.Execute(model.DeployCommand{Name: "helm upgrade"}, nil)
+
+	require.ErrorIs(t, err, errDeployTimeout)
+	var userErr oktetoErrors.UserError
+	require.ErrorAs(t, err, &userErr)
+	assert.Equal(t, "deploy timed out after 100ms while running command 'helm upgrade'. Elapsed time of each step:\n    - build: 0s\n    - command 'kubectl apply': 0s\n    - command 'helm upgrade': 0s (timed out)", userErr.E.Error())
+	assert.Contains(t, userErr.Hint, "'deploy.timeout'")
+	assert.Equal(t, []string{"kubectl apply", "helm upgrade"}, exec.executed)
+}
+
+func TestDeployTimerAttributesTimeoutToDependency(t *testing.T) {
+	okteto.CurrentStore = &okteto.ContextStore{
+		Contexts: map[string]*okteto.Context{
+			"test": {
+				Namespace: "test",
+				IsOkteto:  true,
+				Cfg:       &api.Config{},
+			},
+		},
+		CurrentContext: "test",
+	}
+	opts := &Options{
+		Manifest: &model.Manifest{
+			Dependencies: deps.ManifestSection{
+				"db": &deps.Dependency{},
+			},
+		},
+	}
+	dc := &Command{
+		PipelineCMD: &slowPipelineDeployer{slow: map[string]bool{"db": true}},
+		timer:       newDeployTimer(100*time.Millisecond, true),
+	}
+
+	err := dc.deployDependencies(context.Background(), opts)
+	require.ErrorIs(t, err, errDeployTimeout)
+	require.ErrorContains(t, err, "while running dependency 'db'")
+	require.ErrorContains(t, err, "dependency 'db': 0s (timed out)")
+}
+
+func TestDeployTimerNotEnforced(t *testing.T) {
+	timer := newDeployTimer(time.Millisecond, false)
+	err := timer.run(context.Background(), "command 'helm upgrade'", func(context.Context) error {
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, timer.steps, 1)
+	assert.Equal(t, "command 'helm upgrade'", timer.steps[0].name)
+}
+
+func TestDeployTimerCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	timer := newDeployTimer(time.Minute, true)
+	err := timer.run(ctx, "compose", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.NotErrorIs(t, err, errDeployTimeout)
+}
+
+func TestSetDeployTimeout(t *testing.T) {
+	tests := []struct {
+		name             string
+		opts             *Options
+		expectedTimeout  time.Duration
+		expectedEnforced bool
+	}{
+		{
+			name:            "default",
+			opts:            &Options{Manifest: &model.Manifest{Deploy: &model.DeployInfo{}}, Timeout: 5 * time.Minute},
+			expectedTimeout: 5 * time.Minute,
+		},
+		{
+			name:             "manifest",
+			opts:             &Options{Manifest: &model.Manifest{Deploy: &model.DeployInfo{Timeout: 10 * time.Minute}}, Timeout: 5 * time.Minute},
+			expectedTimeout:  10 * time.Minute,
+			expectedEnforced: true,
+		},
+		{
+			name:             "flag takes precedence over the manifest",
+			opts:             &Options{Manifest: &model.Manifest{Deploy: &model.DeployInfo{Timeout: 10 * time.Minute}}, Timeout: 2 * time.Minute, TimeoutSet: true},
+			expectedTimeout:  2 * time.Minute,
+			expectedEnforced: true,
+		},
+		{
+			name:             "dependencies only",
+			opts:             &Options{Manifest: &model.Manifest{}, Timeout: 2 * time.Minute, TimeoutSet: true},
+			expectedTimeout:  2 * time.Minute,
+			expectedEnforced: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setDeployTimeout(tt.opts)
+			assert.Equal(t, tt.expectedTimeout, tt.opts.Timeout)
+			assert.Equal(t, tt.expectedEnforced, tt.opts.TimeoutSet)
+		})
+	}
+}
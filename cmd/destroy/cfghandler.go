@@ -30,6 +30,7 @@ type configMapHandler interface {
 	destroyConfigMap(context.Context, *apiv1.ConfigMap, string) error
 	setErrorStatus(context.Context, *apiv1.ConfigMap, *pipeline.CfgData, error) error
 	getConfigmapVariablesEncoded(ctx context.Context, name, namespace string) (string, error)
+	getAppliedResources(ctx context.Context, name, namespace string) ([]pipeline.AppliedResource, error)
 }
 
 // oktetoDefaultConfigMapHandler is the runner used when the okteto is executed
@@ -56,6 +57,10 @@ func (ch *defaultConfigMapHandler) getConfigmapVariablesEncoded(ctx context.Cont
 	return pipeline.GetConfigmapVariablesEncoded(ctx, name, namespace, ch.k8sClient)
 }
 
+func (ch *defaultConfigMapHandler) getAppliedResources(ctx context.Context, name, namespace string) ([]pipeline.AppliedResource, error) {
+	return pipeline.GetAppliedResources(ctx, name, namespace, ch.k8sClient)
+}
+
 func (ch *defaultConfigMapHandler) destroyConfigMap(ctx context.Context, cfg *apiv1.ConfigMap, namespace string) error {
 	return configmaps.Destroy(ctx, cfg.Name, namespace, ch.k8sClient)
 }
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/namespace"
 	pipelineCMD "github.com/okteto/okteto/cmd/pipeline"
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/cmd/utils/executor"
@@ -37,7 +38,6 @@ import (
 	"github.com/okteto/okteto/pkg/env"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/filesystem"
-	"github.com/okteto/okteto/pkg/format"
 	"github.com/okteto/okteto/pkg/k8s/kubeconfig"
 	"github.com/okteto/okteto/pkg/k8s/namespaces"
 	"github.com/okteto/okteto/pkg/k8s/secrets"
@@ -51,8 +51,6 @@ import (
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	v1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/labels"
-	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -63,9 +61,12 @@ const (
 	helmUninstallCommand = "helm uninstall %s"
 )
 
+var errRemoteInVanilla = errors.New("remote execution is only supported in contexts with Okteto installed")
+
 type destroyer interface {
 	DestroyWithLabel(ctx context.Context, ns string, opts namespaces.DeleteAllOptions) error
 	DestroySFSVolumes(ctx context.Context, ns string, opts namespaces.DeleteAllOptions) error
+	DestroyResources(ctx context.Context, ns string, resources []namespaces.Resource, opts namespaces.DeleteAllOptions) error
 }
 
 type secretHandler interface {
@@ -93,6 +94,7 @@ type Options struct {
 	ManifestPath        string
 	Name                string
 	Namespace           string
+	NamespacePrefix     string
 	K8sContext          string
 	Variables           []string
 	DestroyVolumes      bool
@@ -102,6 +104,12 @@ type Options struct {
 	DestroyAll          bool
 	RunInRemote         bool
 	RunInRemoteSet      bool
+	// Yes skips the confirmation of the volumes destroyed by DestroyVolumes
+	Yes bool
+	// DryRun only lists the volumes that would be destroyed
+	DryRun bool
+	// DeleteNamespace deletes the namespace once the development environment is destroyed, if it was created by 'okteto deploy'
+	DeleteNamespace bool
 }
 
 type destroyInterface interface {
@@ -135,6 +143,7 @@ type destroyCommand struct {
 	getDivertDriver      divertProvider
 	getPipelineDestroyer pipelineDestroyerProvider
 	buildCtrlProvider    buildControlProviderInterface
+	askForInput          func(question string) (string, error)
 }
 
 // Destroy destroys the dev application defined by the manifest
@@ -154,6 +163,12 @@ If you need to destroy external resources (like s3 buckets or other Cloud resour
 		Args: utils.NoArgsAccepted("https://okteto.com/docs/reference/okteto-cli/#destroy"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			options.RunInRemoteSet = cmd.Flags().Changed("remote")
+			if options.DryRun && options.DestroyAll {
+				return oktetoErrors.UserError{
+					E:    errors.New("the flag '--dry-run' is not supported with '--all'"),
+					Hint: "Remove the '--all' flag to list the volumes of a development environment",
+				}
+			}
 
 			if options.ManifestPath != "" {
 				// if path is absolute, its transformed to rel from root
@@ -181,6 +196,13 @@ If you need to destroy external resources (like s3 buckets or other Cloud resour
 				options.ManifestPath = uptManifestPath
 			}
 
+			// the namespace can be a template like 'pr-${PR_NUMBER}-app' for ephemeral namespaces
+			ns, err := namespace.ExpandNamespace(options.NamespacePrefix, options.Namespace)
+			if err != nil {
+				return err
+			}
+			options.Namespace = ns
+
 			ctxOpts := &contextCMD.Options{
 				Show:      true,
 				Context:   options.K8sContext,
@@ -190,8 +212,8 @@ If you need to destroy external resources (like s3 buckets or other Cloud resour
 				return err
 			}
 
-			if !okteto.IsOkteto() {
-				return oktetoErrors.ErrContextIsNotOktetoCluster
+			if err := checkRemoteInVanilla(okteto.IsOkteto(), options.RunInRemote); err != nil {
+				return err
 			}
 
 			// cwd could have been changed by the manifest path flag
@@ -217,6 +239,14 @@ If you need to destroy external resources (like s3 buckets or other Cloud resour
 				options.Namespace = okteto.GetContext().Namespace
 			}
 
+			deleteNamespace := options.DeleteNamespace && !options.DryRun
+			if deleteNamespace {
+				// fail before destroying anything if the namespace can't be deleted
+				if err := namespace.CheckNamespaceCreatedByOkteto(ctx, options.Namespace, k8sClient); err != nil {
+					return err
+				}
+			}
+
 			var okClient = &okteto.Client{}
 			if okteto.GetContext().IsOkteto {
 				okClient, err = okteto.NewOktetoClient()
@@ -257,6 +287,7 @@ If you need to destroy external resources (like s3 buckets or other Cloud resour
 				getPipelineDestroyer: func() (pipelineDestroyer, error) {
 					return pipelineCMD.NewCommand(at)
 				},
+				askForInput: utils.AskForInput,
 			}
 
 			// We need to create a custom kubeconfig file to avoid to modify the user's kubeconfig when running the
@@ -269,7 +300,13 @@ If you need to destroy external resources (like s3 buckets or other Cloud resour
 			os.Setenv("KUBECONFIG", kubeconfigPath)
 			defer os.Remove(kubeconfigPath)
 
-			return c.runDestroy(ctx, options)
+			if err := c.runDestroy(ctx, options); err != nil {
+				return err
+			}
+			if !deleteNamespace {
+				return nil
+			}
+			return destroyNamespace(ctx, options.Namespace, k8sClient, ioCtrl, k8sLogger)
 		},
 	}
 
@@ -278,16 +315,45 @@ If you need to destroy external resources (like s3 buckets or other Cloud resour
 	cmd.Flags().BoolVarP(&options.DestroyVolumes, "volumes", "v", false, "remove persistent volumes")
 	cmd.Flags().BoolVar(&options.DestroyDependencies, "dependencies", false, "destroy repositories in the 'dependencies' section")
 	cmd.Flags().BoolVar(&options.ForceDestroy, "force-destroy", false, "forces the development environment to be destroyed even if there is an error executing the custom destroy commands defined in the manifest")
-	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "overwrite the namespace where the development environment was deployed")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "overwrite the namespace where the development environment was deployed, expanding environment variables like 'pr-${PR_NUMBER}-app'")
+	cmd.Flags().StringVar(&options.NamespacePrefix, "namespace-prefix", "", "prepend a prefix to the namespace separated by a dash, expanding environment variables like 'pr-${PR_NUMBER}'")
 	cmd.Flags().StringVarP(&options.K8sContext, "context", "c", "", "context where the development environment was deployed")
 	cmd.Flags().BoolVarP(&options.RunWithoutBash, "no-bash", "", false, "execute commands without bash")
 	cmd.Flags().BoolVarP(&options.DestroyAll, "all", "", false, "destroy all Development Environments, excluding resources annotated with dev.okteto.com/policy: keep")
 	cmd.Flags().BoolVarP(&options.RunInRemote, "remote", "", false, "force run destroy commands in remote")
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", false, "skip the confirmation of the persistent volumes destroyed by '--volumes'")
+	cmd.Flags().BoolVar(&options.DeleteNamespace, "delete-namespace", false, "delete the namespace once the development environment is destroyed, only if it was created by 'okteto deploy'")
+	cmd.Flags().BoolVar(&options.DryRun, "dry-run", false, "list the persistent volumes that would be destroyed by '--volumes' without destroying anything")
 
 	return cmd
 }
 
 // getTempKubeConfigFile creates the temporal kubernetes config file needed to avoid to modify the user's kubeconfig
+// checkRemoteInVanilla fails when remote execution is requested in a context without Okteto installed.
+// Vanilla contexts destroy from the local machine
+func checkRemoteInVanilla(isOkteto, runInRemote bool) error {
+	if isOkteto || !runInRemote {
+		return nil
+	}
+	return oktetoErrors.UserError{
+		E:    errRemoteInVanilla,
+		Hint: "Remove the '--remote' flag to destroy from your machine",
+	}
+}
+
+// destroyNamespace deletes the namespace of the development environment. Okteto contexts delete it through the Okteto API,
+// while vanilla contexts delete it through the Kubernetes API
+func destroyNamespace(ctx context.Context, ns string, c kubernetes.Interface, ioCtrl *io.Controller, k8sLogger *io.K8sLogger) error {
+	if !okteto.IsOkteto() {
+		return namespace.DeleteVanillaNamespace(ctx, ns, c)
+	}
+	nsCmd, err := namespace.NewCommand(ioCtrl)
+	if err != nil {
+		return err
+	}
+	return nsCmd.ExecuteDeleteNamespace(ctx, ns, k8sLogger)
+}
+
 func getTempKubeConfigFile(name string) string {
 	tempKubeconfigFileName := fmt.Sprintf("kubeconfig-destroy-%s-%d", name, time.Now().UnixMilli())
 	return filepath.Join(config.GetOktetoHome(), tempKubeconfigFileName)
@@ -334,6 +400,10 @@ func shouldRunInRemote(opts *Options) bool {
 
 // runDestroy runs the main logic of the destroy command
 func (dc *destroyCommand) runDestroy(ctx context.Context, opts *Options) error {
+	if opts.DryRun {
+		return dc.dryRun(ctx, opts)
+	}
+
 	var err error
 	isDestroyAll := false
 	isRemote := false
@@ -429,6 +499,10 @@ func (dc *destroyCommand) destroy(ctx context.Context, opts *Options) error {
 		namespace = okteto.GetContext().Namespace
 	}
 
+	if err := dc.confirmVolumesDestroy(ctx, opts); err != nil {
+		return err
+	}
+
 	oktetoLog.AddToBuffer(oktetoLog.InfoLevel, "Destroying...")
 
 	cfgVariablesString, err := dc.ConfigMapHandler.getConfigmapVariablesEncoded(ctx, opts.Name, namespace)
@@ -507,7 +581,12 @@ func (dc *destroyCommand) destroy(ctx context.Context, opts *Options) error {
 	oktetoLog.StartSpinner()
 	defer oktetoLog.StopSpinner()
 
-	if err := dc.destroyK8sResources(ctx, opts); err != nil {
+	applied, err := dc.ConfigMapHandler.getAppliedResources(ctx, opts.Name, namespace)
+	if err != nil {
+		oktetoLog.Infof("could not retrieve the resources applied by '%s': %s", opts.Name, err)
+	}
+
+	if err := dc.destroyK8sResources(ctx, opts, applied); err != nil {
 		if err := dc.ConfigMapHandler.setErrorStatus(ctx, cfg, data, err); err != nil {
 			return err
 		}
@@ -562,16 +641,13 @@ func (dc *destroyCommand) destroyDivert(ctx context.Context, manifest *model.Man
 	return driver.Destroy(ctx)
 }
 
-func (dc *destroyCommand) destroyK8sResources(ctx context.Context, opts *Options) error {
-	deployedByLs, err := labels.NewRequirement(
-		model.DeployedByLabel,
-		selection.Equals,
-		[]string{format.ResourceK8sMetaString(opts.Name)},
-	)
+// destroyK8sResources deletes the resources of the dev environment. When the deploy recorded the resources applied
+// through the proxy, exactly those resources are deleted. Otherwise, the resources are found by the deployed-by label
+func (dc *destroyCommand) destroyK8sResources(ctx context.Context, opts *Options, applied []pipeline.AppliedResource) error {
+	deployedBySelector, err := getDeployedBySelector(opts.Name)
 	if err != nil {
 		return err
 	}
-	deployedBySelector := labels.NewSelector().Add(*deployedByLs).String()
 	deleteOpts := namespaces.DeleteAllOptions{
 		LabelSelector:  deployedBySelector,
 		IncludeVolumes: opts.DestroyVolumes,
@@ -589,6 +665,24 @@ func (dc *destroyCommand) destroyK8sResources(ctx context.Context, opts *Options
 		}
 	}
 
+	if len(applied) > 0 {
+		oktetoLog.Debugf("destroying the %d resources applied by '%s'", len(applied), opts.Name)
+		oktetoLog.SetStage("Destroying applied resources")
+		resources := make([]namespaces.Resource, 0, len(applied))
+		for _, r := range applied {
+			resources = append(resources, namespaces.Resource{GVR: r.GVR(), Namespace: r.Namespace, Name: r.Name})
+		}
+		if err := dc.nsDestroyer.DestroyResources(ctx, opts.Namespace, resources, deleteOpts); err != nil {
+			oktetoLog.Infof("could not delete all the applied resources: %s", err)
+			return err
+		}
+
+		// Compose services are deployed by okteto without going through the proxy, so they are not part of the inventory
+		if !hasComposeSection(opts.Manifest) {
+			return nil
+		}
+	}
+
 	oktetoLog.Debugf("destroying resources with deployed-by label '%s'", deployedBySelector)
 	oktetoLog.SetStage(fmt.Sprintf("Destroying by label '%s'", deployedBySelector))
 	if err := dc.nsDestroyer.DestroyWithLabel(ctx, opts.Namespace, deleteOpts); err != nil {
@@ -599,6 +693,10 @@ func (dc *destroyCommand) destroyK8sResources(ctx context.Context, opts *Options
 	return nil
 }
 
+func hasComposeSection(manifest *model.Manifest) bool {
+	return manifest != nil && manifest.Deploy != nil && manifest.Deploy.ComposeSection != nil
+}
+
 func (dc *destroyCommand) destroyHelmReleasesIfPresent(ctx context.Context, opts *Options, labelSelector string) error {
 	sList, err := dc.secrets.List(ctx, opts.Namespace, labelSelector)
 	if err != nil {
@@ -19,6 +19,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/okteto/okteto/cmd/namespace"
 	pipelineCMD "github.com/okteto/okteto/cmd/pipeline"
 	"github.com/okteto/okteto/internal/test"
 	buildCmd "github.com/okteto/okteto/pkg/cmd/build"
@@ -36,8 +37,11 @@ import (
 	"github.com/stretchr/testify/require"
 	istioNetworkingV1beta1 "istio.io/api/networking/v1beta1"
 	v1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/clientcmd/api"
 )
 
@@ -80,10 +84,11 @@ var fakeManifestWithDependencies = &model.Manifest{
 }
 
 type fakeDestroyer struct {
-	err              error
-	errOnVolumes     error
-	destroyed        bool
-	destroyedVolumes bool
+	err                error
+	errOnVolumes       error
+	destroyedResources []namespaces.Resource
+	destroyed          bool
+	destroyedVolumes   bool
 }
 
 type fakeSecretHandler struct {
@@ -105,6 +110,15 @@ func (fd *fakeDestroyer) DestroyWithLabel(_ context.Context, _ string, _ namespa
 	return nil
 }
 
+func (fd *fakeDestroyer) DestroyResources(_ context.Context, _ string, resources []namespaces.Resource, _ namespaces.DeleteAllOptions) error {
+	if fd.err != nil {
+		return fd.err
+	}
+
+	fd.destroyedResources = append(fd.destroyedResources, resources...)
+	return nil
+}
+
 func (fd *fakeDestroyer) DestroySFSVolumes(_ context.Context, _ string, _ namespaces.DeleteAllOptions) error {
 	if fd.errOnVolumes != nil {
 		return fd.errOnVolumes
@@ -445,7 +459,7 @@ func TestDestroyK8sResourcesWithErrorDestroyingVolumes(t *testing.T) {
 		nsDestroyer: destroyer,
 	}
 
-	err := dc.destroyK8sResources(ctx, opts)
+	err := dc.destroyK8sResources(ctx, opts, nil)
 
 	require.ErrorIs(t, err, assert.AnError)
 	require.False(t, destroyer.destroyed)
@@ -467,7 +481,7 @@ func TestDestroyK8sResourcesWithErrorDestroyingHelmAppWithoutForce(t *testing.T)
 		},
 	}
 
-	err := dc.destroyK8sResources(ctx, opts)
+	err := dc.destroyK8sResources(ctx, opts, nil)
 
 	require.ErrorIs(t, err, assert.AnError)
 	require.True(t, destroyer.destroyedVolumes)
@@ -489,7 +503,7 @@ func TestDestroyK8sResourcesWithErrorDestroyingHelmAppWithForce(t *testing.T) {
 		},
 	}
 
-	err := dc.destroyK8sResources(ctx, opts)
+	err := dc.destroyK8sResources(ctx, opts, nil)
 
 	require.NoError(t, err)
 	require.True(t, destroyer.destroyedVolumes)
@@ -511,7 +525,7 @@ func TestDestroyK8sResourcesWithErrorDestroyingWithLabel(t *testing.T) {
 		secrets:     &fakeSecretHandler{},
 	}
 
-	err := dc.destroyK8sResources(ctx, opts)
+	err := dc.destroyK8sResources(ctx, opts, nil)
 
 	require.ErrorIs(t, err, assert.AnError)
 	require.True(t, destroyer.destroyedVolumes)
@@ -531,13 +545,81 @@ func TestDestroyK8sResourcesWithoutErrors(t *testing.T) {
 		secrets:     &fakeSecretHandler{},
 	}
 
-	err := dc.destroyK8sResources(ctx, opts)
+	err := dc.destroyK8sResources(ctx, opts, nil)
 
 	require.NoError(t, err)
 	require.True(t, destroyer.destroyedVolumes)
 	require.True(t, destroyer.destroyed)
 }
 
+func TestDestroyK8sResourcesWithAppliedResources(t *testing.T) {
+	ctx := context.Background()
+	applied := []pipeline.AppliedResource{
+		{Group: "apps", Version: "v1", Resource: "deployments", Kind: "Deployment", Namespace: "namespace", Name: "api"},
+		{Version: "v1", Resource: "services", Kind: "Service", Namespace: "namespace", Name: "api"},
+	}
+	expected := []namespaces.Resource{
+		{GVR: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}, Namespace: "namespace", Name: "api"},
+		{GVR: schema.GroupVersionResource{Version: "v1", Resource: "services"}, Namespace: "namespace", Name: "api"},
+	}
+
+	tests := []struct {
+		manifest          *model.Manifest
+		name              string
+		expectedDestroyed bool
+	}{
+		{
+			name:     "without compose",
+			manifest: &model.Manifest{Deploy: &model.DeployInfo{}},
+		},
+		{
+			name:              "with compose",
+			manifest:          &model.Manifest{Deploy: &model.DeployInfo{ComposeSection: &model.ComposeSectionInfo{}}},
+			expectedDestroyed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &Options{
+				Name:      "test-app",
+				Namespace: "namespace",
+				Manifest:  tt.manifest,
+			}
+			destroyer := &fakeDestroyer{}
+			dc := &destroyCommand{
+				nsDestroyer: destroyer,
+				secrets:     &fakeSecretHandler{},
+			}
+
+			err := dc.destroyK8sResources(ctx, opts, applied)
+
+			require.NoError(t, err)
+			require.Equal(t, expected, destroyer.destroyedResources)
+			require.Equal(t, tt.expectedDestroyed, destroyer.destroyed)
+		})
+	}
+}
+
+func TestDestroyK8sResourcesWithErrorDestroyingAppliedResources(t *testing.T) {
+	ctx := context.Background()
+	opts := &Options{
+		Name:      "test-app",
+		Namespace: "namespace",
+	}
+	destroyer := &fakeDestroyer{
+		err: assert.AnError,
+	}
+	dc := &destroyCommand{
+		nsDestroyer: destroyer,
+		secrets:     &fakeSecretHandler{},
+	}
+
+	err := dc.destroyK8sResources(ctx, opts, []pipeline.AppliedResource{{Version: "v1", Resource: "services", Namespace: "namespace", Name: "api"}})
+
+	require.ErrorIs(t, err, assert.AnError)
+	require.Empty(t, destroyer.destroyedResources)
+}
+
 func TestShouldRunInRemoteDestroy(t *testing.T) {
 	var tempManifest = &model.Manifest{
 		Destroy: &model.DestroyInfo{
@@ -1099,3 +1181,40 @@ func TestHasDivert(t *testing.T) {
 func loadBoolPointer(v bool) *bool {
 	return &v
 }
+
+func TestCheckRemoteInVanilla(t *testing.T) {
+	require.NoError(t, checkRemoteInVanilla(true, true))
+	require.NoError(t, checkRemoteInVanilla(false, false))
+	require.ErrorIs(t, checkRemoteInVanilla(false, true), errRemoteInVanilla)
+}
+
+func TestDestroyNamespaceInVanilla(t *testing.T) {
+	ctx := context.Background()
+	// the context of the tests doesn't have Okteto installed
+	require.False(t, okteto.IsOkteto())
+
+	c := fake.NewSimpleClientset(
+		&v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "pr-1234-app",
+				Labels: map[string]string{namespace.CreatedByOktetoLabel: "true"},
+			},
+		},
+		&v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "shared",
+			},
+		},
+	)
+
+	require.NoError(t, namespace.CheckNamespaceCreatedByOkteto(ctx, "pr-1234-app", c))
+	require.NoError(t, destroyNamespace(ctx, "pr-1234-app", c, io.NewIOController(), io.NewK8sLogger()))
+	_, err := c.CoreV1().Namespaces().Get(ctx, "pr-1234-app", metav1.GetOptions{})
+	require.True(t, k8sErrors.IsNotFound(err))
+
+	// namespaces not created by okteto are never deleted
+	require.Error(t, namespace.CheckNamespaceCreatedByOkteto(ctx, "shared", c))
+
+	// deleting a namespace that doesn't exist is not an error
+	require.NoError(t, destroyNamespace(ctx, "pr-1234-app", c, io.NewIOController(), io.NewK8sLogger()))
+}
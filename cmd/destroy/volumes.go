@@ -0,0 +1,275 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destroy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/env"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/format"
+	"github.com/okteto/okteto/pkg/k8s/statefulsets"
+	"github.com/okteto/okteto/pkg/k8s/volumes"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	resourcePolicyAnnotation = "dev.okteto.com/policy"
+	keepPolicy               = "keep"
+
+	// unknownVolumeInfo is displayed when some information of a volume can't be retrieved
+	unknownVolumeInfo = "-"
+)
+
+// volumeSummary describes a persistent volume claim that is going to be destroyed
+type volumeSummary struct {
+	name         string
+	size         string
+	storageClass string
+	age          string
+	lastPod      string
+}
+
+// getDeployedBySelector returns the label selector of the resources deployed by the development environment
+func getDeployedBySelector(name string) (string, error) {
+	deployedByLs, err := labels.NewRequirement(
+		model.DeployedByLabel,
+		selection.Equals,
+		[]string{format.ResourceK8sMetaString(name)},
+	)
+	if err != nil {
+		return "", err
+	}
+	return labels.NewSelector().Add(*deployedByLs).String(), nil
+}
+
+// listVolumesToDestroy returns the persistent volume claims destroyed by 'okteto destroy --volumes':
+// the ones deployed by the development environment and the ones created by its statefulsets.
+// Volumes with the keep policy are not included
+func listVolumesToDestroy(ctx context.Context, c kubernetes.Interface, namespace, name string) ([]volumeSummary, error) {
+	deployedBySelector, err := getDeployedBySelector(name)
+	if err != nil {
+		return nil, err
+	}
+	pvcs, err := volumes.List(ctx, namespace, deployedBySelector, c)
+	if err != nil {
+		return nil, fmt.Errorf("error getting volumes: %w", err)
+	}
+
+	sfsPVCs, err := listStatefulsetVolumes(ctx, c, namespace, deployedBySelector)
+	if err != nil {
+		return nil, err
+	}
+	pvcs = append(pvcs, sfsPVCs...)
+
+	lastPods := getLastPodByClaim(ctx, c, namespace)
+	now := time.Now()
+	result := []volumeSummary{}
+	for _, pvc := range pvcs {
+		if pvc.Annotations[resourcePolicyAnnotation] == keepPolicy {
+			continue
+		}
+		result = append(result, volumeSummary{
+			name:         pvc.Name,
+			size:         getVolumeSize(pvc),
+			storageClass: getVolumeStorageClass(pvc),
+			age:          getVolumeAge(pvc, now),
+			lastPod:      lastPods[pvc.Name],
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].name < result[j].name
+	})
+	for i := range result {
+		if result[i].lastPod == "" {
+			result[i].lastPod = unknownVolumeInfo
+		}
+	}
+	return result, nil
+}
+
+// listStatefulsetVolumes returns the volumes created from the volume claim templates of the statefulsets.
+// They don't have the deployed-by label, the same way namespaces.DestroySFSVolumes finds them
+func listStatefulsetVolumes(ctx context.Context, c kubernetes.Interface, namespace, deployedBySelector string) ([]v1.PersistentVolumeClaim, error) {
+	ssList, err := statefulsets.List(ctx, namespace, deployedBySelector, c)
+	if err != nil {
+		return nil, fmt.Errorf("error getting statefulsets: %w", err)
+	}
+	prefixes := []string{}
+	for _, ss := range ssList {
+		for _, pvcTemplate := range ss.Spec.VolumeClaimTemplates {
+			prefixes = append(prefixes, fmt.Sprintf("%s-%s-", pvcTemplate.Name, ss.Name))
+		}
+	}
+	if len(prefixes) == 0 {
+		return nil, nil
+	}
+
+	deployedByNotExist, err := labels.NewRequirement(model.DeployedByLabel, selection.DoesNotExist, nil)
+	if err != nil {
+		return nil, err
+	}
+	vList, err := volumes.List(ctx, namespace, labels.NewSelector().Add(*deployedByNotExist).String(), c)
+	if err != nil {
+		return nil, fmt.Errorf("error getting volumes: %w", err)
+	}
+	result := []v1.PersistentVolumeClaim{}
+	for _, v := range vList {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(v.Name, prefix) {
+				result = append(result, v)
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// getLastPodByClaim returns the most recent pod mounting each persistent volume claim of the namespace.
+// It returns an empty map if the pods can't be listed
+func getLastPodByClaim(ctx context.Context, c kubernetes.Interface, namespace string) map[string]string {
+	result := map[string]string{}
+	podList, err := c.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		oktetoLog.Infof("could not list pods to get the volume mounts: %s", err)
+		return result
+	}
+	created := map[string]time.Time{}
+	for _, pod := range podList.Items {
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim == nil {
+				continue
+			}
+			claim := volume.PersistentVolumeClaim.ClaimName
+			if t, ok := created[claim]; ok && !pod.CreationTimestamp.After(t) {
+				continue
+			}
+			created[claim] = pod.CreationTimestamp.Time
+			result[claim] = pod.Name
+		}
+	}
+	return result
+}
+
+func getVolumeSize(pvc v1.PersistentVolumeClaim) string {
+	if size, ok := pvc.Status.Capacity[v1.ResourceStorage]; ok {
+		return size.String()
+	}
+	if size, ok := pvc.Spec.Resources.Requests[v1.ResourceStorage]; ok {
+		return size.String()
+	}
+	return unknownVolumeInfo
+}
+
+func getVolumeStorageClass(pvc v1.PersistentVolumeClaim) string {
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return unknownVolumeInfo
+	}
+	return *pvc.Spec.StorageClassName
+}
+
+func getVolumeAge(pvc v1.PersistentVolumeClaim, now time.Time) string {
+	if pvc.CreationTimestamp.IsZero() {
+		return unknownVolumeInfo
+	}
+	return duration.HumanDuration(now.Sub(pvc.CreationTimestamp.Time))
+}
+
+// printVolumesToDestroy writes the table of volumes that are going to be destroyed
+func printVolumesToDestroy(w io.Writer, name string, volumes []volumeSummary) {
+	if len(volumes) == 0 {
+		fmt.Fprintf(w, "No persistent volumes of '%s' will be destroyed\n", name)
+		return
+	}
+	fmt.Fprintf(w, "The following persistent volumes of '%s' and their data will be destroyed:\n", name)
+	tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+	fmt.Fprintf(tw, "Name\tSize\tStorage Class\tAge\tLast Mounted By\n")
+	for _, v := range volumes {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", v.name, v.size, v.storageClass, v.age, v.lastPod)
+	}
+	tw.Flush()
+}
+
+// getVolumesToDestroy lists the volumes that 'okteto destroy --volumes' is going to destroy
+func (dc *destroyCommand) getVolumesToDestroy(ctx context.Context, opts *Options) ([]volumeSummary, error) {
+	c, _, err := dc.k8sClientProvider.Provide(okteto.GetContext().Cfg)
+	if err != nil {
+		return nil, err
+	}
+	namespace := opts.Namespace
+	if namespace == "" {
+		namespace = okteto.GetContext().Namespace
+	}
+	return listVolumesToDestroy(ctx, c, namespace, opts.Name)
+}
+
+// dryRun prints the volumes that would be destroyed without destroying anything
+func (dc *destroyCommand) dryRun(ctx context.Context, opts *Options) error {
+	if !opts.DestroyVolumes {
+		oktetoLog.Information("Persistent volumes of '%s' are kept. Use '--volumes' to list the volumes that would be destroyed", opts.Name)
+		oktetoLog.Information("Dry run: nothing was destroyed")
+		return nil
+	}
+	volumes, err := dc.getVolumesToDestroy(ctx, opts)
+	if err != nil {
+		return err
+	}
+	printVolumesToDestroy(os.Stdout, opts.Name, volumes)
+	oktetoLog.Information("Dry run: nothing was destroyed")
+	return nil
+}
+
+// confirmVolumesDestroy lists the volumes destroyed by '--volumes' and asks the user to type the name of the development environment.
+// The confirmation is skipped with '--yes' and when running in the remote pipeline runner
+func (dc *destroyCommand) confirmVolumesDestroy(ctx context.Context, opts *Options) error {
+	if !opts.DestroyVolumes || opts.Yes || env.LoadBoolean(constants.OktetoDeployRemote) {
+		return nil
+	}
+	volumes, err := dc.getVolumesToDestroy(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if len(volumes) == 0 {
+		return nil
+	}
+	printVolumesToDestroy(os.Stdout, opts.Name, volumes)
+
+	answer, err := dc.askForInput(fmt.Sprintf("Type '%s' to confirm: ", opts.Name))
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(answer) != opts.Name {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("destroy of '%s' was not confirmed", opts.Name),
+			Hint: "Type the name of the development environment to confirm, or use '--yes' to skip the confirmation",
+		}
+	}
+	return nil
+}
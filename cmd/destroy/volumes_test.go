@@ -0,0 +1,196 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package destroy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/internal/test"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+func volumesTestObjects() []runtime.Object {
+	created := metav1.NewTime(time.Now().Add(-48 * time.Hour))
+	deployedBy := map[string]string{model.DeployedByLabel: "my-app"}
+	return []runtime.Object{
+		&v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "namespace", Labels: deployedBy, CreationTimestamp: created},
+			Spec: v1.PersistentVolumeClaimSpec{
+				StorageClassName: ptr.To("standard"),
+				Resources: v1.VolumeResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse("1Gi")},
+				},
+			},
+			Status: v1.PersistentVolumeClaimStatus{
+				Capacity: v1.ResourceList{v1.ResourceStorage: resource.MustParse("2Gi")},
+			},
+		},
+		&v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "kept",
+				Namespace:   "namespace",
+				Labels:      deployedBy,
+				Annotations: map[string]string{resourcePolicyAnnotation: keepPolicy},
+			},
+		},
+		&v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "pgdata-db-0", Namespace: "namespace"},
+		},
+		&v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "namespace"},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "namespace", Labels: deployedBy},
+			Spec: appsv1.StatefulSetSpec{
+				VolumeClaimTemplates: []v1.PersistentVolumeClaim{{ObjectMeta: metav1.ObjectMeta{Name: "pgdata"}}},
+			},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "api-old", Namespace: "namespace", CreationTimestamp: created},
+			Spec: v1.PodSpec{
+				Volumes: []v1.Volume{{Name: "data", VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "data"}}}},
+			},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "api-new", Namespace: "namespace", CreationTimestamp: metav1.NewTime(time.Now())},
+			Spec: v1.PodSpec{
+				Volumes: []v1.Volume{{Name: "data", VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "data"}}}},
+			},
+		},
+	}
+}
+
+func TestListVolumesToDestroy(t *testing.T) {
+	c := fake.NewSimpleClientset(volumesTestObjects()...)
+
+	volumes, err := listVolumesToDestroy(context.Background(), c, "namespace", "my-app")
+	require.NoError(t, err)
+	expected := []volumeSummary{
+		{
+			name:         "data",
+			size:         "2Gi",
+			storageClass: "standard",
+			age:          "2d",
+			lastPod:      "api-new",
+		},
+		{
+			name:         "pgdata-db-0",
+			size:         unknownVolumeInfo,
+			storageClass: unknownVolumeInfo,
+			age:          unknownVolumeInfo,
+			lastPod:      unknownVolumeInfo,
+		},
+	}
+	assert.Equal(t, expected, volumes)
+
+	var out bytes.Buffer
+	printVolumesToDestroy(&out, "my-app", volumes)
+	assert.Contains(t, out.String(), "The following persistent volumes of 'my-app' and their data will be destroyed")
+	assert.Regexp(t, `data\s+2Gi\s+standard\s+2d\s+api-new`, out.String())
+	assert.Regexp(t, `pgdata-db-0\s+-\s+-\s+-\s+-`, out.String())
+}
+
+func TestDryRunDoesNotDestroy(t *testing.T) {
+	k8sClientProvider := test.NewFakeK8sProvider(volumesTestObjects()...)
+	destroyer := &fakeDestroyer{}
+	dc := &destroyCommand{
+		nsDestroyer:       destroyer,
+		k8sClientProvider: k8sClientProvider,
+		askForInput: func(string) (string, error) {
+			t.Fatal("dry run must not ask for confirmation")
+			return "", nil
+		},
+	}
+
+	err := dc.runDestroy(context.Background(), &Options{
+		Name:           "my-app",
+		Namespace:      "namespace",
+		DestroyVolumes: true,
+		DryRun:         true,
+	})
+	require.NoError(t, err)
+	assert.False(t, destroyer.destroyed)
+	assert.False(t, destroyer.destroyedVolumes)
+
+	c, _, err := k8sClientProvider.Provide(nil)
+	require.NoError(t, err)
+	for _, action := range c.(*fake.Clientset).Actions() {
+		assert.Equal(t, "list", action.GetVerb(), "unexpected %s on %s", action.GetVerb(), action.GetResource().Resource)
+	}
+}
+
+func TestConfirmVolumesDestroy(t *testing.T) {
+	tests := []struct {
+		name        string
+		answer      string
+		opts        *Options
+		expectAsked bool
+		expectErr   bool
+	}{
+		{
+			name:        "confirmed",
+			answer:      "my-app",
+			opts:        &Options{Name: "my-app", Namespace: "namespace", DestroyVolumes: true},
+			expectAsked: true,
+		},
+		{
+			name:        "not confirmed",
+			answer:      "my-ap",
+			opts:        &Options{Name: "my-app", Namespace: "namespace", DestroyVolumes: true},
+			expectAsked: true,
+			expectErr:   true,
+		},
+		{
+			name: "skipped with yes",
+			opts: &Options{Name: "my-app", Namespace: "namespace", DestroyVolumes: true, Yes: true},
+		},
+		{
+			name: "without volumes",
+			opts: &Options{Name: "my-app", Namespace: "namespace"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			asked := false
+			dc := &destroyCommand{
+				k8sClientProvider: test.NewFakeK8sProvider(volumesTestObjects()...),
+				askForInput: func(string) (string, error) {
+					asked = true
+					return tt.answer, nil
+				},
+			}
+			err := dc.confirmVolumesDestroy(context.Background(), tt.opts)
+			assert.Equal(t, tt.expectAsked, asked)
+			if tt.expectErr {
+				var uErr oktetoErrors.UserError
+				require.ErrorAs(t, err, &uErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
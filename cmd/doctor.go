@@ -15,20 +15,28 @@ package cmd
 
 import (
 	"context"
+	"crypto/x509"
 	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
 
 	contextCMD "github.com/okteto/okteto/cmd/context"
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
 	"github.com/okteto/okteto/pkg/cmd/doctor"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoHttp "github.com/okteto/okteto/pkg/http"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/log/io"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/registry"
 	"github.com/okteto/okteto/pkg/validator"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
 )
 
 // doctorOptions refers to all the options that can be passed to Doctor command
@@ -98,6 +106,16 @@ func Doctor(k8sLogger *io.K8sLogger, fs afero.Fs) *cobra.Command {
 					return err
 				}
 			}
+			oktetoLog.Information("Ports reserved by okteto for '%s', they can't be used by 'forward' or 'reverse':", dev.Name)
+			for _, p := range dev.ReservedPorts() {
+				oktetoLog.Println(fmt.Sprintf("    %s", p.String()))
+			}
+
+			devImages := doctor.GetOverriddenDevImages(manifest, dev)
+			for _, errImage := range doctor.CheckDevImages(devImages, registry.NewOktetoRegistry(okteto.Config{})) {
+				oktetoLog.Warning("%s", errImage.Error())
+			}
+
 			filename, err := doctor.Run(ctx, dev, doctorOpts.DevPath, okteto.GetContext().Namespace, c)
 			if err == nil {
 				oktetoLog.Information("Your doctor file is available at %s", filename)
@@ -109,5 +127,89 @@ func Doctor(k8sLogger *io.K8sLogger, fs afero.Fs) *cobra.Command {
 	cmd.Flags().StringVarP(&doctorOpts.DevPath, "file", "f", "", "the path to the Okteto Manifest")
 	cmd.Flags().StringVarP(&doctorOpts.Namespace, "namespace", "n", "", "overwrite the current Okteto Namespace")
 	cmd.Flags().StringVarP(&doctorOpts.K8sContext, "context", "c", "", "overwrite the current Okteto Context")
+	cmd.AddCommand(DoctorConnectivity(k8sLogger))
 	return cmd
 }
+
+// DoctorConnectivity checks the connectivity with the cluster, the okteto api, the registry and buildkit
+func DoctorConnectivity(k8sLogger *io.K8sLogger) *cobra.Command {
+	doctorOpts := &doctorOptions{}
+	var output string
+	var timeout time.Duration
+	cmd := &cobra.Command{
+		Use:   "connectivity",
+		Short: "Check the connectivity with your cluster, the Okteto API, the registry and the build service",
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/okteto-cli/#doctor"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := doctor.ValidateConnectivityOutput(output); err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			ctxOpts := &contextCMD.Options{
+				Show:      output == "",
+				Context:   doctorOpts.K8sContext,
+				Namespace: doctorOpts.Namespace,
+			}
+			if err := contextCMD.NewContextCommand().Run(ctx, ctxOpts); err != nil {
+				return err
+			}
+
+			c, _, err := okteto.GetK8sClientWithLogger(k8sLogger)
+			if err != nil {
+				return err
+			}
+
+			results := doctor.RunConnectivityProbes(ctx, getConnectivityProbes(c), timeout)
+			if err := doctor.PrintConnectivityResults(os.Stdout, results, output); err != nil {
+				return err
+			}
+			if doctor.HasFailedProbes(results) {
+				return errConnectivityChecksFailed
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&doctorOpts.Namespace, "namespace", "n", "", "overwrite the current Okteto Namespace")
+	cmd.Flags().StringVarP(&doctorOpts.K8sContext, "context", "c", "", "overwrite the current Okteto Context")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "output format. One of: ['json']")
+	cmd.Flags().DurationVar(&timeout, "timeout", doctor.DefaultProbeTimeout, "max time to wait for each check")
+	return cmd
+}
+
+var errConnectivityChecksFailed = errors.New("some connectivity checks failed")
+
+// getConnectivityProbes returns the probes of the components of the current context.
+// The okteto api, registry and buildkit probes are skipped for contexts that don't use them
+func getConnectivityProbes(c kubernetes.Interface) []doctor.ConnectivityProbe {
+	okCtx := okteto.GetContext()
+	transport := getContextTransport()
+	oktetoAPIProbe := &doctor.OktetoAPIProbe{HTTPClient: &http.Client{Transport: transport}}
+	registryProbe := &doctor.RegistryProbe{Transport: transport}
+	if okteto.IsOkteto() {
+		oktetoAPIProbe.URL = okCtx.Name
+		oktetoAPIProbe.Token = okCtx.Token
+		registryProbe.Registry = okCtx.Registry
+		registryProbe.Username = okCtx.UserID
+		registryProbe.Password = okCtx.Token
+	}
+	return []doctor.ConnectivityProbe{
+		&doctor.KubeAPIProbe{Client: c, Namespace: okCtx.Namespace},
+		&doctor.PortForwardProbe{Client: c, Namespace: okCtx.Namespace},
+		oktetoAPIProbe,
+		registryProbe,
+		&doctor.BuildkitProbe{Address: okCtx.Builder, TLSConfig: transport.TLSClientConfig.Clone()},
+	}
+}
+
+// getContextTransport returns a transport trusting the certificate of the current context
+func getContextTransport() *http.Transport {
+	if okteto.GetContext().IsInsecure {
+		return oktetoHttp.InsecureTransport()
+	}
+	opts := &oktetoHttp.SSLTransportOption{}
+	if cert, err := okteto.GetContextCertificate(); err == nil {
+		opts.Certs = []*x509.Certificate{cert}
+	}
+	return oktetoHttp.StrictSSLTransport(opts)
+}
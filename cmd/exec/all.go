@@ -0,0 +1,166 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exec
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	okerrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/exec"
+	oktetoIO "github.com/okteto/okteto/pkg/log/io"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"golang.org/x/sync/errgroup"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// defaultMaxConcurrentExecs is the maximum number of pods where the command is executed at the same time
+	defaultMaxConcurrentExecs = 5
+)
+
+// podExecutor executes a non-interactive command in a pod
+type podExecutor interface {
+	execute(ctx context.Context, pod apiv1.Pod, cmd []string, stdout, stderr io.Writer) error
+}
+
+// allPodsExec executes a command in every pod of a stack service
+type allPodsExec struct {
+	ioCtrl         *oktetoIO.Controller
+	k8sClient      kubernetes.Interface
+	executor       podExecutor
+	out            io.Writer
+	maxConcurrency int
+}
+
+// k8sPodExecutor executes commands in pods using the kubernetes exec API
+type k8sPodExecutor struct {
+	k8sClient kubernetes.Interface
+	cfg       *rest.Config
+}
+
+func (k *k8sPodExecutor) execute(ctx context.Context, pod apiv1.Pod, cmd []string, stdout, stderr io.Writer) error {
+	return exec.Exec(
+		ctx,
+		k.k8sClient,
+		k.cfg,
+		pod.Namespace,
+		pod.Name,
+		pod.Spec.Containers[0].Name,
+		false,
+		strings.NewReader(""),
+		stdout,
+		stderr,
+		cmd)
+}
+
+// getAllPodsSelector returns the label selector of the pods targeted by 'okteto exec --all'
+func getAllPodsSelector(service, selector string) string {
+	selectors := []string{model.StackNameLabel}
+	if service != "" {
+		selectors = append(selectors, fmt.Sprintf("%s=%s", model.StackServiceNameLabel, service))
+	}
+	if selector != "" {
+		selectors = append(selectors, selector)
+	}
+	return strings.Join(selectors, ",")
+}
+
+// run executes the command in all the running pods matching the selector
+func (a *allPodsExec) run(ctx context.Context, namespace, selector string, cmd []string) error {
+	podList, err := a.k8sClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	pods := []apiv1.Pod{}
+	for _, pod := range podList.Items {
+		if pod.Status.Phase != apiv1.PodRunning || pod.DeletionTimestamp != nil {
+			a.ioCtrl.Out().Infof("Skipping pod '%s': it is not running", pod.Name)
+			continue
+		}
+		pods = append(pods, pod)
+	}
+	if len(pods) == 0 {
+		return okerrors.UserError{
+			E:    fmt.Errorf("no running pods found in namespace '%s' matching '%s'", namespace, selector),
+			Hint: "Run 'okteto deploy' to deploy your services or use '--selector' to target other pods",
+		}
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Name < pods[j].Name
+	})
+
+	maxConcurrency := a.maxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = defaultMaxConcurrentExecs
+	}
+
+	var mu sync.Mutex
+	failed := []string{}
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(maxConcurrency)
+	for _, pod := range pods {
+		g.Go(func() error {
+			w := oktetoIO.NewPrefixWriter(a.out, &mu, fmt.Sprintf("[%s] ", pod.Name))
+			err := a.executor.execute(gCtx, pod, cmd, w, w)
+			if flushErr := w.Flush(); flushErr != nil {
+				a.ioCtrl.Logger().Infof("failed to write the output of pod '%s': %s", pod.Name, flushErr)
+			}
+			if err != nil {
+				a.ioCtrl.Logger().Infof("command failed in pod '%s': %s", pod.Name, err)
+				mu.Lock()
+				failed = append(failed, pod.Name)
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return fmt.Errorf("command failed in %d of %d pods: %s", len(failed), len(pods), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// RunAll executes the command in every running pod of a stack service
+func (e *Exec) RunAll(ctx context.Context, service, selector, namespace string, cmd []string) error {
+	c, cfg, err := e.k8sClientProvider.Provide(okteto.GetContext().Cfg)
+	if err != nil {
+		return fmt.Errorf("failed to get k8s client: %w", err)
+	}
+	a := &allPodsExec{
+		ioCtrl:    e.ioCtrl,
+		k8sClient: c,
+		executor: &k8sPodExecutor{
+			k8sClient: c,
+			cfg:       cfg,
+		},
+		out:            defaultStdout,
+		maxConcurrency: defaultMaxConcurrentExecs,
+	}
+	podSelector := getAllPodsSelector(service, selector)
+	e.ioCtrl.Logger().Infof("executing command '%s' in pods matching '%s'", cmd, podSelector)
+	return a.run(ctx, namespace, podSelector, cmd)
+}
@@ -0,0 +1,164 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exec
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	okerrors "github.com/okteto/okteto/pkg/errors"
+	oktetoIO "github.com/okteto/okteto/pkg/log/io"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type fakePodExecutor struct {
+	failures map[string]bool
+	executed []string
+	mu       sync.Mutex
+}
+
+func (f *fakePodExecutor) execute(_ context.Context, pod apiv1.Pod, cmd []string, stdout, _ io.Writer) error {
+	f.mu.Lock()
+	f.executed = append(f.executed, pod.Name)
+	f.mu.Unlock()
+	fmt.Fprintf(stdout, "running %s\npartial", strings.Join(cmd, " "))
+	if f.failures[pod.Name] {
+		return assert.AnError
+	}
+	return nil
+}
+
+func newStackPod(name, service string, phase apiv1.PodPhase) *apiv1.Pod {
+	return &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+			Labels: map[string]string{
+				model.StackNameLabel:        "stack",
+				model.StackServiceNameLabel: service,
+			},
+		},
+		Spec: apiv1.PodSpec{
+			Containers: []apiv1.Container{{Name: service}},
+		},
+		Status: apiv1.PodStatus{Phase: phase},
+	}
+}
+
+func TestAllPodsExec_run(t *testing.T) {
+	objects := []runtime.Object{
+		newStackPod("web-1", "web", apiv1.PodRunning),
+		newStackPod("web-2", "web", apiv1.PodRunning),
+		newStackPod("web-3", "web", apiv1.PodRunning),
+		newStackPod("web-4", "web", apiv1.PodPending),
+		newStackPod("api-1", "api", apiv1.PodRunning),
+	}
+
+	tests := []struct {
+		failures         map[string]bool
+		name             string
+		expectedErr      string
+		expectedExecuted []string
+	}{
+		{
+			name:             "all pods succeed",
+			expectedExecuted: []string{"web-1", "web-2", "web-3"},
+		},
+		{
+			name:             "one pod fails",
+			failures:         map[string]bool{"web-2": true},
+			expectedExecuted: []string{"web-1", "web-2", "web-3"},
+			expectedErr:      "command failed in 1 of 3 pods: web-2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			executor := &fakePodExecutor{failures: tt.failures}
+			a := &allPodsExec{
+				ioCtrl:         oktetoIO.NewIOController(),
+				k8sClient:      fake.NewSimpleClientset(objects...),
+				executor:       executor,
+				out:            out,
+				maxConcurrency: 2,
+			}
+			err := a.run(context.Background(), "test", getAllPodsSelector("web", ""), []string{"kill", "-HUP", "1"})
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.ElementsMatch(t, tt.expectedExecuted, executor.executed)
+			for _, pod := range tt.expectedExecuted {
+				assert.Contains(t, out.String(), fmt.Sprintf("[%s] running kill -HUP 1\n", pod))
+				assert.Contains(t, out.String(), fmt.Sprintf("[%s] partial\n", pod))
+			}
+			assert.NotContains(t, out.String(), "web-4")
+		})
+	}
+}
+
+func TestAllPodsExec_runNoPods(t *testing.T) {
+	a := &allPodsExec{
+		ioCtrl:    oktetoIO.NewIOController(),
+		k8sClient: fake.NewSimpleClientset(newStackPod("web-1", "web", apiv1.PodPending)),
+		executor:  &fakePodExecutor{},
+		out:       &bytes.Buffer{},
+	}
+	err := a.run(context.Background(), "test", getAllPodsSelector("web", ""), []string{"ls"})
+	var userErr okerrors.UserError
+	require.ErrorAs(t, err, &userErr)
+}
+
+func TestGetAllPodsSelector(t *testing.T) {
+	tests := []struct {
+		name     string
+		service  string
+		selector string
+		expected string
+	}{
+		{
+			name:     "service",
+			service:  "web",
+			expected: "stack.okteto.com/name,stack.okteto.com/service=web",
+		},
+		{
+			name:     "selector",
+			selector: "tier=frontend",
+			expected: "stack.okteto.com/name,tier=frontend",
+		},
+		{
+			name:     "service and selector",
+			service:  "web",
+			selector: "tier=frontend",
+			expected: "stack.okteto.com/name,stack.okteto.com/service=web,tier=frontend",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getAllPodsSelector(tt.service, tt.selector))
+		})
+	}
+}
@@ -33,9 +33,12 @@ import (
 
 // execFlags is the input of the user to exec command
 type execFlags struct {
-	manifestPath string
-	namespace    string
-	k8sContext   string
+	manifestPath   string
+	namespace      string
+	k8sContext     string
+	selector       string
+	envPassthrough []string
+	all            bool
 }
 
 // metadataTracker is an interface to track metadata
@@ -90,7 +93,10 @@ func (e *Exec) Cmd(ctx context.Context) *cobra.Command {
 okteto exec api -- echo this is a test
 
 # Get an interactive shell session inside the Development Container 'api'
-okteto exec api -- bash`,
+okteto exec api -- bash
+
+# Run the 'kill -HUP 1' command in every pod of the service 'web'
+okteto exec --all web -- kill -HUP 1`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := validator.FileArgumentIsNotDir(e.fs, execFlags.manifestPath); err != nil {
 				return err
@@ -105,6 +111,10 @@ okteto exec api -- bash`,
 				return err
 			}
 
+			if execFlags.all {
+				return e.runAll(ctx, cmd, args, execFlags)
+			}
+
 			manifestOpts := contextCMD.ManifestOptions{Filename: execFlags.manifestPath}
 			manifest, err := model.GetManifestV2(manifestOpts.Filename, e.fs)
 			if err != nil {
@@ -130,16 +140,57 @@ okteto exec api -- bash`,
 				return err
 			}
 
+			dev := manifest.Dev[argsResult.DevName]
+			if len(execFlags.envPassthrough) > 0 {
+				if err := model.ValidateEnvPassthrough(execFlags.envPassthrough); err != nil {
+					return err
+				}
+				dev.EnvironmentPassthrough = append(dev.EnvironmentPassthrough, execFlags.envPassthrough...)
+			}
+
 			e.ioCtrl.Out().Infof("Executing command in development container '%s'", argsResult.DevName)
-			return e.Run(ctx, argsResult, manifest.Dev[argsResult.DevName], okteto.GetContext().Namespace)
+			return e.Run(ctx, argsResult, dev, okteto.GetContext().Namespace)
 		},
 	}
 	cmd.Flags().StringVarP(&execFlags.manifestPath, "file", "f", "", "the path to the Okteto Manifest")
 	cmd.Flags().StringVarP(&execFlags.namespace, "namespace", "n", "", "overwrite the current Okteto Namespace")
 	cmd.Flags().StringVarP(&execFlags.k8sContext, "context", "c", "", "overwrite the current Okteto Context")
+	cmd.Flags().StringArrayVar(&execFlags.envPassthrough, "env-passthrough", nil, "set the local environment variables matching a pattern like 'AWS_*' in the session of the command (can be set more than once)")
+	cmd.Flags().BoolVar(&execFlags.all, "all", false, "execute the command in every running pod of the given service")
+	cmd.Flags().StringVar(&execFlags.selector, "selector", "", "label selector to filter the pods when using --all")
 	return cmd
 }
 
+// runAll parses the arguments of 'okteto exec --all' and executes the command in all the matching pods
+func (e *Exec) runAll(ctx context.Context, cmd *cobra.Command, args []string, execFlags *execFlags) error {
+	argsLenAtDash := cmd.ArgsLenAtDash()
+	if argsLenAtDash < 0 || argsLenAtDash == len(args) {
+		return okerrors.UserError{
+			E:    fmt.Errorf("no command specified"),
+			Hint: "Specify the command to execute after '--', for example: 'okteto exec --all web -- kill -HUP 1'",
+		}
+	}
+	if argsLenAtDash > 1 {
+		return okerrors.UserError{
+			E:    fmt.Errorf("only one service can be specified when using --all"),
+			Hint: "Use '--selector' to target pods of several services",
+		}
+	}
+
+	service := ""
+	if argsLenAtDash == 1 {
+		service = args[0]
+	}
+	if service == "" && execFlags.selector == "" {
+		return okerrors.UserError{
+			E:    fmt.Errorf("a service or a selector is required when using --all"),
+			Hint: "Run 'okteto exec --all <service> -- COMMAND' or 'okteto exec --all --selector <selector> -- COMMAND'",
+		}
+	}
+
+	return e.RunAll(ctx, service, execFlags.selector, okteto.GetContext().Namespace, args[argsLenAtDash:])
+}
+
 // Run executes the exec command
 func (e *Exec) Run(ctx context.Context, opts *oargs.Result, dev *model.Dev, namespace string) error {
 	e.ioCtrl.Logger().Infof("executing command '%s' in development container '%s'", opts.Command, opts.DevName)
@@ -171,7 +222,11 @@ func (e *Exec) Run(ctx context.Context, opts *oargs.Result, dev *model.Dev, name
 	if err != nil {
 		return fmt.Errorf("failed to get executor: %w", err)
 	}
-	err = executor.execute(ctx, opts.Command)
+	cmd := opts.Command
+	if !dev.IsHybridModeEnabled() {
+		cmd = dev.RunAsCommand(cmd)
+	}
+	err = executor.execute(ctx, cmd)
 	e.mixpanelTracker.Track(&analytics.TrackExecMetadata{
 		Mode:               dev.Mode,
 		FirstArgIsDev:      opts.FirstArgIsDevName,
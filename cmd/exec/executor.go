@@ -66,7 +66,8 @@ func (e executorProvider) provide(dev *model.Dev, podName, namespace string) (ex
 	if dev.RemoteModeEnabled() {
 		e.ioCtrl.Logger().Info("Using remote executor")
 		return &sshExecutor{
-			dev: dev,
+			dev:  dev,
+			envs: dev.GetPassthroughEnvironment(os.Environ()),
 		}, nil
 	}
 	return &k8sExecutor{
@@ -75,6 +76,7 @@ func (e executorProvider) provide(dev *model.Dev, podName, namespace string) (ex
 		namespace: namespace,
 		podName:   podName,
 		container: dev.Container,
+		envs:      dev.GetPassthroughEnvironment(os.Environ()),
 	}, nil
 }
 
@@ -107,6 +109,8 @@ func (h *hybridExecutor) execute(ctx context.Context, cmdToExec []string) error
 
 type sshExecutor struct {
 	dev *model.Dev
+	// envs are the local variables passed through to the command
+	envs []string
 }
 
 func (s *sshExecutor) execute(ctx context.Context, cmd []string) error {
@@ -122,7 +126,7 @@ func (s *sshExecutor) execute(ctx context.Context, cmd []string) error {
 		}
 	}
 	s.dev.LoadRemote(ssh.GetPublicKey())
-	return ssh.Exec(
+	return ssh.ExecWithEnv(
 		ctx,
 		s.dev.Interface,
 		p,
@@ -130,7 +134,8 @@ func (s *sshExecutor) execute(ctx context.Context, cmd []string) error {
 		defaultStdin,
 		defaultStdout,
 		defaultStderr,
-		cmd)
+		s.dev.InWorkdirCommand(cmd),
+		s.envs)
 }
 
 type k8sExecutor struct {
@@ -139,10 +144,12 @@ type k8sExecutor struct {
 	namespace string
 	podName   string
 	container string
+	// envs are the local variables passed through to the command
+	envs []string
 }
 
 func (k *k8sExecutor) execute(ctx context.Context, cmd []string) error {
-	return exec.Exec(
+	return exec.ExecWithEnv(
 		ctx,
 		k.k8sClient,
 		k.cfg,
@@ -153,5 +160,6 @@ func (k *k8sExecutor) execute(ctx context.Context, cmd []string) error {
 		defaultStdin,
 		defaultStdout,
 		defaultStderr,
-		cmd)
+		cmd,
+		k.envs)
 }
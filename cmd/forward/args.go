@@ -0,0 +1,184 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forward
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model/forward"
+)
+
+const (
+	devTarget         = "dev"
+	serviceTarget     = "svc"
+	podTarget         = "pod"
+	deploymentTarget  = "deployment"
+	statefulsetTarget = "statefulset"
+
+	maxPort = 65535
+)
+
+var targetAliases = map[string]string{
+	"svc":          serviceTarget,
+	"service":      serviceTarget,
+	"services":     serviceTarget,
+	"po":           podTarget,
+	"pod":          podTarget,
+	"pods":         podTarget,
+	"deploy":       deploymentTarget,
+	"deployment":   deploymentTarget,
+	"deployments":  deploymentTarget,
+	"sts":          statefulsetTarget,
+	"statefulset":  statefulsetTarget,
+	"statefulsets": statefulsetTarget,
+}
+
+// target is the destination of the port forwards: a development container of the okteto manifest or a kubernetes resource
+type target struct {
+	kind string
+	name string
+}
+
+func (t target) isDev() bool {
+	return t.kind == devTarget
+}
+
+func (t target) String() string {
+	if t.isDev() {
+		return t.name
+	}
+	return fmt.Sprintf("%s/%s", t.kind, t.name)
+}
+
+// parseTarget returns the target of the command arguments. Arguments of the form 'kind/name' are kubernetes resources,
+// anything else is the name of a development container
+func parseTarget(args []string) (target, error) {
+	if len(args) == 0 {
+		return target{kind: devTarget}, nil
+	}
+	if len(args) > 1 {
+		return target{}, oktetoErrors.UserError{
+			E:    fmt.Errorf("only one development container or resource can be specified"),
+			Hint: "Run 'okteto forward <devContainer>' or 'okteto forward -p 8080:80 svc/<name>'",
+		}
+	}
+
+	kind, name, found := strings.Cut(args[0], "/")
+	if !found {
+		return target{kind: devTarget, name: args[0]}, nil
+	}
+	resolvedKind, ok := targetAliases[strings.ToLower(kind)]
+	if !ok {
+		return target{}, oktetoErrors.UserError{
+			E:    fmt.Errorf("resource type '%s' is not supported", kind),
+			Hint: "Use one of: svc/<name>, pod/<name>, deployment/<name> or statefulset/<name>",
+		}
+	}
+	if name == "" || strings.Contains(name, "/") {
+		return target{}, oktetoErrors.UserError{
+			E:    fmt.Errorf("invalid resource '%s'", args[0]),
+			Hint: "Use the form <type>/<name>, for example 'svc/web'",
+		}
+	}
+	return target{kind: resolvedKind, name: name}, nil
+}
+
+// parsePorts translates the values of the '--port' flag into forwards to the target.
+// It supports the forms 'port', 'localPort:remotePort' and 'localPort:serviceName:remotePort'
+func parsePorts(ports []string, t target) ([]forward.Forward, error) {
+	result := []forward.Forward{}
+	for _, p := range ports {
+		f, err := parsePort(p)
+		if err != nil {
+			return nil, oktetoErrors.UserError{
+				E:    err,
+				Hint: "Use the form 'localPort:remotePort', for example '-p 8080:80'",
+			}
+		}
+		if !f.Service && t.kind == serviceTarget {
+			f.Service = true
+			f.ServiceName = t.name
+		}
+		result = append(result, f)
+	}
+
+	if !t.isDev() && len(result) == 0 {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("no ports specified for '%s'", t),
+			Hint: fmt.Sprintf("Specify the ports to forward, for example 'okteto forward -p 8080:80 %s'", t),
+		}
+	}
+	return result, nil
+}
+
+func parsePort(value string) (forward.Forward, error) {
+	parts := strings.Split(value, ":")
+	f := forward.Forward{}
+	var err error
+	switch len(parts) {
+	case 1:
+		if f.Local, err = parsePortNumber(parts[0], value); err != nil {
+			return f, err
+		}
+		f.Remote = f.Local
+	case 2:
+		if f.Local, err = parsePortNumber(parts[0], value); err != nil {
+			return f, err
+		}
+		if f.Remote, err = parsePortNumber(parts[1], value); err != nil {
+			return f, err
+		}
+	case 3:
+		if parts[1] == "" {
+			return f, fmt.Errorf(forward.MalformedPortForward, value)
+		}
+		if f.Local, err = parsePortNumber(parts[0], value); err != nil {
+			return f, err
+		}
+		if f.Remote, err = parsePortNumber(parts[2], value); err != nil {
+			return f, err
+		}
+		f.Service = true
+		f.ServiceName = parts[1]
+	default:
+		return f, fmt.Errorf(forward.MalformedPortForward, value)
+	}
+	return f, nil
+}
+
+func parsePortNumber(port, value string) (int, error) {
+	p, err := strconv.Atoi(port)
+	if err != nil || p <= 0 || p > maxPort {
+		return 0, fmt.Errorf("invalid port '%s' in port-forward '%s'", port, value)
+	}
+	return p, nil
+}
+
+// checkDuplicatedPorts returns an error if a local port is used by several forwards
+func checkDuplicatedPorts(forwards []forward.Forward) error {
+	seen := map[int]bool{}
+	for _, f := range forwards {
+		if seen[f.Local] {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("local port %d is listed multiple times", f.Local),
+				Hint: "Check the forward section of your okteto manifest and the '--port' flags",
+			}
+		}
+		seen[f.Local] = true
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forward
+
+import (
+	"bytes"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTarget(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expected    target
+		expectedErr bool
+	}{
+		{
+			name:     "no args",
+			args:     []string{},
+			expected: target{kind: devTarget},
+		},
+		{
+			name:     "dev name",
+			args:     []string{"api"},
+			expected: target{kind: devTarget, name: "api"},
+		},
+		{
+			name:     "service",
+			args:     []string{"svc/web"},
+			expected: target{kind: serviceTarget, name: "web"},
+		},
+		{
+			name:     "service alias",
+			args:     []string{"service/web"},
+			expected: target{kind: serviceTarget, name: "web"},
+		},
+		{
+			name:     "pod",
+			args:     []string{"pod/web-123"},
+			expected: target{kind: podTarget, name: "web-123"},
+		},
+		{
+			name:     "deployment alias",
+			args:     []string{"deploy/web"},
+			expected: target{kind: deploymentTarget, name: "web"},
+		},
+		{
+			name:     "statefulset alias",
+			args:     []string{"sts/db"},
+			expected: target{kind: statefulsetTarget, name: "db"},
+		},
+		{
+			name:        "unsupported kind",
+			args:        []string{"job/migrate"},
+			expectedErr: true,
+		},
+		{
+			name:        "empty name",
+			args:        []string{"svc/"},
+			expectedErr: true,
+		},
+		{
+			name:        "too many args",
+			args:        []string{"api", "svc/web"},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseTarget(tt.args)
+			if tt.expectedErr {
+				require.ErrorAs(t, err, &oktetoErrors.UserError{})
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestParsePorts(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      target
+		ports       []string
+		expected    []forward.Forward
+		expectedErr bool
+	}{
+		{
+			name:     "dev without ports",
+			target:   target{kind: devTarget, name: "api"},
+			expected: []forward.Forward{},
+		},
+		{
+			name:   "dev ports",
+			target: target{kind: devTarget, name: "api"},
+			ports:  []string{"8080", "9090:90", "5432:db:5432"},
+			expected: []forward.Forward{
+				{Local: 8080, Remote: 8080},
+				{Local: 9090, Remote: 90},
+				{Local: 5432, Remote: 5432, Service: true, ServiceName: "db"},
+			},
+		},
+		{
+			name:   "service target",
+			target: target{kind: serviceTarget, name: "web"},
+			ports:  []string{"8080:80"},
+			expected: []forward.Forward{
+				{Local: 8080, Remote: 80, Service: true, ServiceName: "web"},
+			},
+		},
+		{
+			name:   "pod target",
+			target: target{kind: podTarget, name: "web-123"},
+			ports:  []string{"8080:80"},
+			expected: []forward.Forward{
+				{Local: 8080, Remote: 80},
+			},
+		},
+		{
+			name:        "resource target without ports",
+			target:      target{kind: serviceTarget, name: "web"},
+			expectedErr: true,
+		},
+		{
+			name:        "not a number",
+			target:      target{kind: devTarget, name: "api"},
+			ports:       []string{"8080:http"},
+			expectedErr: true,
+		},
+		{
+			name:        "out of range",
+			target:      target{kind: devTarget, name: "api"},
+			ports:       []string{"8080:70000"},
+			expectedErr: true,
+		},
+		{
+			name:        "empty service name",
+			target:      target{kind: devTarget, name: "api"},
+			ports:       []string{"8080::80"},
+			expectedErr: true,
+		},
+		{
+			name:        "too many parts",
+			target:      target{kind: devTarget, name: "api"},
+			ports:       []string{"8080:web:80:81"},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parsePorts(tt.ports, tt.target)
+			if tt.expectedErr {
+				require.ErrorAs(t, err, &oktetoErrors.UserError{})
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestCheckDuplicatedPorts(t *testing.T) {
+	require.NoError(t, checkDuplicatedPorts([]forward.Forward{
+		{Local: 8080, Remote: 80},
+		{Local: 8081, Remote: 80, Service: true, ServiceName: "web"},
+	}))
+
+	err := checkDuplicatedPorts([]forward.Forward{
+		{Local: 8080, Remote: 80},
+		{Local: 8080, Remote: 80, Service: true, ServiceName: "web"},
+	})
+	require.ErrorAs(t, err, &oktetoErrors.UserError{})
+	assert.Contains(t, err.Error(), "8080")
+}
+
+func TestPrintForwards(t *testing.T) {
+	var out bytes.Buffer
+	printForwards(&out, "localhost", "api-123", []forward.Forward{
+		{Local: 5432, Remote: 5432, Service: true, ServiceName: "db"},
+		{Local: 8080, Remote: 80},
+	})
+	expected := "Local           Remote\n" +
+		"localhost:8080  pod/api-123:80\n" +
+		"localhost:5432  svc/db:5432\n"
+	assert.Equal(t, expected, out.String())
+}
@@ -0,0 +1,408 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forward
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/apps"
+	"github.com/okteto/okteto/pkg/k8s/deployments"
+	forwardk8s "github.com/okteto/okteto/pkg/k8s/forward"
+	"github.com/okteto/okteto/pkg/k8s/statefulsets"
+	oktetoIO "github.com/okteto/okteto/pkg/log/io"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/validator"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// connectionCheckInterval is the interval to check the port forwards to the pod
+	connectionCheckInterval = 1 * time.Second
+
+	// reconnectInterval is the interval between attempts to reconnect the port forwards to the pod
+	reconnectInterval = 3 * time.Second
+)
+
+// forwardFlags is the input of the user to forward command
+type forwardFlags struct {
+	manifestPath string
+	namespace    string
+	k8sContext   string
+	ports        []string
+}
+
+// forwardOptions are the port forwards resolved from the arguments and the okteto manifest
+type forwardOptions struct {
+	target    target
+	dev       *model.Dev
+	iface     string
+	namespace string
+	forwards  []forward.Forward
+}
+
+// Forward establishes the port forwards of a development container without activating it
+type Forward struct {
+	ioCtrl            *oktetoIO.Controller
+	fs                afero.Fs
+	k8sClientProvider okteto.K8sClientProvider
+}
+
+// NewForward creates a new forward command
+func NewForward(fs afero.Fs, ioCtrl *oktetoIO.Controller, k8sProvider okteto.K8sClientProvider) *Forward {
+	return &Forward{
+		ioCtrl:            ioCtrl,
+		fs:                fs,
+		k8sClientProvider: k8sProvider,
+	}
+}
+
+// Cmd returns the cobra forward command
+func (fw *Forward) Cmd(ctx context.Context) *cobra.Command {
+	flags := &forwardFlags{}
+	cmd := &cobra.Command{
+		Use:   "forward [devContainer|type/name]",
+		Short: "Forward local ports to your development container or to a kubernetes resource",
+		Long: `Forward local ports to your development container or to a kubernetes resource.
+
+The forwards of the development container are read from the Okteto Manifest. The development container is not activated and no files are synchronized.
+The forwards are reconnected automatically until you press CTRL+C.`,
+		Example: `# Forward the ports defined in the forward section of the development container 'api'
+okteto forward api
+
+# Forward the local port 8080 to the port 80 of the service 'web'
+okteto forward -p 8080:80 svc/web`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validator.FileArgumentIsNotDir(fw.fs, flags.manifestPath); err != nil {
+				return err
+			}
+
+			t, err := parseTarget(args)
+			if err != nil {
+				return err
+			}
+			adhocForwards, err := parsePorts(flags.ports, t)
+			if err != nil {
+				return err
+			}
+
+			ctxOpts := &contextCMD.Options{
+				Show:      true,
+				Context:   flags.k8sContext,
+				Namespace: flags.namespace,
+			}
+			if err := contextCMD.NewContextCommand().Run(ctx, ctxOpts); err != nil {
+				return err
+			}
+
+			opts := &forwardOptions{
+				target:    t,
+				iface:     model.Localhost,
+				namespace: okteto.GetContext().Namespace,
+				forwards:  adhocForwards,
+			}
+			if t.isDev() {
+				manifest, err := model.GetManifestV2(flags.manifestPath, fw.fs)
+				if err != nil {
+					return fmt.Errorf("failed to load manifest: %w", err)
+				}
+				dev, err := utils.GetDevFromManifest(manifest, t.name)
+				if err != nil {
+					if errors.Is(err, utils.ErrNoDevSelected) {
+						return oktetoErrors.UserError{
+							E:    err,
+							Hint: "Specify the development container: 'okteto forward <devContainer>'",
+						}
+					}
+					return err
+				}
+				opts.target.name = dev.Name
+				opts.dev = dev
+				opts.iface = dev.Interface
+				opts.forwards = make([]forward.Forward, 0, len(dev.Forward)+len(adhocForwards))
+				opts.forwards = append(opts.forwards, dev.Forward...)
+				opts.forwards = append(opts.forwards, adhocForwards...)
+				for _, r := range dev.Reverse {
+					fw.ioCtrl.Out().Warning("Skipping reverse forward %d:%d: reverse forwards require 'okteto up'", r.Remote, r.Local)
+				}
+
+				lock := newSessionLock(fw.fs, opts.namespace, dev.Name)
+				if err := lock.acquire(); err != nil {
+					return err
+				}
+				defer lock.release()
+			}
+
+			if len(opts.forwards) == 0 {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("there are no ports to forward for '%s'", opts.target),
+					Hint: "Add a forward section to your development container or use the '--port' flag",
+				}
+			}
+			if err := checkDuplicatedPorts(opts.forwards); err != nil {
+				return err
+			}
+
+			return fw.Run(ctx, opts)
+		},
+	}
+	cmd.Flags().StringVarP(&flags.manifestPath, "file", "f", "", "the path to the Okteto Manifest")
+	cmd.Flags().StringVarP(&flags.namespace, "namespace", "n", "", "overwrite the current Okteto Namespace")
+	cmd.Flags().StringVarP(&flags.k8sContext, "context", "c", "", "overwrite the current Okteto Context")
+	cmd.Flags().StringArrayVarP(&flags.ports, "port", "p", []string{}, "port to forward in the form 'localPort:remotePort'")
+	return cmd
+}
+
+// Run establishes the port forwards and blocks until the user stops the command
+func (fw *Forward) Run(ctx context.Context, opts *forwardOptions) error {
+	c, restConfig, err := fw.k8sClientProvider.Provide(okteto.GetContext().Cfg)
+	if err != nil {
+		return fmt.Errorf("failed to get k8s client: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(stop)
+
+	serviceForwards := []forward.Forward{}
+	podForwards := []forward.Forward{}
+	for _, f := range opts.forwards {
+		if f.Service {
+			serviceForwards = append(serviceForwards, f)
+			continue
+		}
+		podForwards = append(podForwards, f)
+	}
+
+	// forwards to services reconnect by themselves, only the forwards to the pod need to be restarted
+	if len(serviceForwards) > 0 {
+		serviceForwarder := forwardk8s.NewPortForwardManager(ctx, opts.iface, restConfig, c, opts.namespace)
+		for i, f := range serviceForwards {
+			if f.Labels != nil {
+				f, err = serviceForwarder.TransformLabelsToServiceName(f)
+				if err != nil {
+					return err
+				}
+				serviceForwards[i] = f
+			}
+			if err := serviceForwarder.Add(f); err != nil {
+				return err
+			}
+		}
+		if err := serviceForwarder.Start("", opts.namespace); err != nil {
+			return err
+		}
+		defer serviceForwarder.Stop()
+	}
+
+	if len(podForwards) == 0 {
+		printForwards(os.Stdout, opts.iface, "", serviceForwards)
+		fw.ioCtrl.Out().Success("Forwarding ports to '%s'. Press CTRL+C to stop", opts.target)
+		select {
+		case <-stop:
+		case <-ctx.Done():
+		}
+		return nil
+	}
+
+	podName, podForwarder, err := fw.startPodForwards(ctx, c, restConfig, opts, podForwards)
+	if err != nil {
+		return err
+	}
+	printForwards(os.Stdout, opts.iface, podName, append(podForwards, serviceForwards...))
+	fw.ioCtrl.Out().Success("Forwarding ports to '%s'. Press CTRL+C to stop", opts.target)
+
+	ticker := time.NewTicker(connectionCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			fw.ioCtrl.Logger().Info("stop signal received, stopping port forwards")
+			podForwarder.Stop()
+			return nil
+		case <-ctx.Done():
+			podForwarder.Stop()
+			return nil
+		case <-ticker.C:
+			err := podForwarder.DevPodError()
+			if err == nil {
+				continue
+			}
+			fw.ioCtrl.Logger().Infof("port forward to pod '%s' finished: %s", podName, err)
+			podForwarder.Stop()
+			fw.ioCtrl.Out().Warning("Connection lost to '%s', reconnecting...", opts.target)
+			podName, podForwarder, err = fw.reconnectPodForwards(ctx, stop, c, restConfig, opts, podForwards)
+			if err != nil {
+				return err
+			}
+			if podForwarder == nil {
+				return nil
+			}
+			fw.ioCtrl.Out().Success("Reconnected to pod '%s'", podName)
+		}
+	}
+}
+
+// reconnectPodForwards retries the port forwards to the pod until they are established or the user stops the command.
+// It returns a nil forwarder if the user stopped the command
+func (fw *Forward) reconnectPodForwards(ctx context.Context, stop chan os.Signal, c kubernetes.Interface, restConfig *rest.Config, opts *forwardOptions, forwards []forward.Forward) (string, *forwardk8s.PortForwardManager, error) {
+	ticker := time.NewTicker(reconnectInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return "", nil, nil
+		case <-ctx.Done():
+			return "", nil, nil
+		case <-ticker.C:
+			podName, podForwarder, err := fw.startPodForwards(ctx, c, restConfig, opts, forwards)
+			if err != nil {
+				fw.ioCtrl.Logger().Infof("failed to reconnect port forwards: %s", err)
+				continue
+			}
+			return podName, podForwarder, nil
+		}
+	}
+}
+
+// startPodForwards starts the port forwards to the running pod of the target
+func (fw *Forward) startPodForwards(ctx context.Context, c kubernetes.Interface, restConfig *rest.Config, opts *forwardOptions, forwards []forward.Forward) (string, *forwardk8s.PortForwardManager, error) {
+	pod, err := getRunningPod(ctx, c, opts)
+	if err != nil {
+		return "", nil, err
+	}
+
+	podForwarder := forwardk8s.NewPortForwardManager(ctx, opts.iface, restConfig, c, opts.namespace)
+	for _, f := range forwards {
+		if err := podForwarder.Add(f); err != nil {
+			return "", nil, err
+		}
+	}
+	if err := podForwarder.Start(pod.Name, opts.namespace); err != nil {
+		return "", nil, err
+	}
+	return pod.Name, podForwarder, nil
+}
+
+// getRunningPod returns the running pod of the target. For development containers it is the pod of the original
+// application, or the pod of the development container if development mode is enabled
+func getRunningPod(ctx context.Context, c kubernetes.Interface, opts *forwardOptions) (*apiv1.Pod, error) {
+	t := opts.target
+	switch t.kind {
+	case devTarget:
+		dev := *opts.dev
+		if dev.Autocreate {
+			dev.Name = model.DevCloneName(dev.Name)
+		}
+		app, err := apps.Get(ctx, &dev, opts.namespace, c)
+		if err != nil {
+			return nil, err
+		}
+		if apps.IsDevModeOn(app) {
+			app, err = app.GetDevClone(ctx, c)
+			if err != nil {
+				return nil, err
+			}
+		}
+		pod, err := app.GetRunningPod(ctx, c)
+		if err != nil {
+			return nil, fmt.Errorf("no running pods found for the development container '%s': %w", t.name, err)
+		}
+		return pod, nil
+	case podTarget:
+		pod, err := c.CoreV1().Pods(opts.namespace).Get(ctx, t.name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		if !isRunning(pod) {
+			return nil, fmt.Errorf("pod '%s' is not running", t.name)
+		}
+		return pod, nil
+	case deploymentTarget:
+		d, err := deployments.Get(ctx, t.name, opts.namespace, c)
+		if err != nil {
+			return nil, err
+		}
+		return getRunningPodBySelector(ctx, c, opts.namespace, d.Spec.Selector, t)
+	case statefulsetTarget:
+		sfs, err := statefulsets.Get(ctx, t.name, opts.namespace, c)
+		if err != nil {
+			return nil, err
+		}
+		return getRunningPodBySelector(ctx, c, opts.namespace, sfs.Spec.Selector, t)
+	}
+	return nil, fmt.Errorf("ports of '%s' can't be forwarded to a pod", t)
+}
+
+func getRunningPodBySelector(ctx context.Context, c kubernetes.Interface, namespace string, selector *metav1.LabelSelector, t target) (*apiv1.Pod, error) {
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	podList, err := c.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: s.String()})
+	if err != nil {
+		return nil, err
+	}
+	for i := range podList.Items {
+		if isRunning(&podList.Items[i]) {
+			return &podList.Items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no running pods found for '%s'", t)
+}
+
+func isRunning(pod *apiv1.Pod) bool {
+	return pod.Status.Phase == apiv1.PodRunning && pod.DeletionTimestamp == nil
+}
+
+// printForwards prints the table of the established port forwards
+func printForwards(out io.Writer, iface, podName string, forwards []forward.Forward) {
+	sorted := make([]forward.Forward, len(forwards))
+	copy(sorted, forwards)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Less(&sorted[j])
+	})
+
+	w := tabwriter.NewWriter(out, 1, 1, 2, ' ', 0)
+	fmt.Fprintf(w, "Local\tRemote\n")
+	for _, f := range sorted {
+		remote := fmt.Sprintf("pod/%s:%d", podName, f.Remote)
+		if f.Service {
+			remote = fmt.Sprintf("svc/%s:%d", f.ServiceName, f.Remote)
+		}
+		fmt.Fprintf(w, "%s:%d\t%s\n", f.GetInterface(iface), f.Local, remote)
+	}
+	w.Flush()
+}
@@ -0,0 +1,124 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forward
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/process"
+	"github.com/spf13/afero"
+)
+
+const (
+	// upPIDFilename is the PID file written by 'okteto up'
+	upPIDFilename = "okteto.pid"
+
+	// forwardPIDFilename is the PID file written by 'okteto forward'
+	forwardPIDFilename = "okteto-forward.pid"
+)
+
+var (
+	errUpSessionActive      = errors.New("'okteto up' is running")
+	errForwardSessionActive = errors.New("'okteto forward' is already running")
+)
+
+// sessionLock prevents running 'okteto forward' for a development container
+// with an active 'okteto up' or 'okteto forward' session
+type sessionLock struct {
+	fs        afero.Fs
+	isRunning func(pid int) bool
+	dir       string
+	devName   string
+	pid       int
+}
+
+func newSessionLock(fs afero.Fs, namespace, devName string) *sessionLock {
+	return &sessionLock{
+		fs:        fs,
+		isRunning: process.IsRunning,
+		dir:       config.GetAppHome(namespace, devName),
+		devName:   devName,
+		pid:       os.Getpid(),
+	}
+}
+
+// acquire writes the PID file of 'okteto forward' if there are no other active sessions for the development container
+func (l *sessionLock) acquire() error {
+	if pid, ok := l.activePID(upPIDFilename); ok {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("%w for the development container '%s' (pid %d)", errUpSessionActive, l.devName, pid),
+			Hint: "The port forwards are already available while 'okteto up' is running",
+		}
+	}
+	if pid, ok := l.activePID(forwardPIDFilename); ok {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("%w for the development container '%s' (pid %d)", errForwardSessionActive, l.devName, pid),
+			Hint: "Stop the other 'okteto forward' session and try again",
+		}
+	}
+
+	path := filepath.Join(l.dir, forwardPIDFilename)
+	if err := afero.WriteFile(l.fs, path, []byte(strconv.Itoa(l.pid)), 0600); err != nil {
+		return fmt.Errorf("unable to create PID file at %s: %w", path, err)
+	}
+	return nil
+}
+
+// release removes the PID file of 'okteto forward' if it is owned by the current process
+func (l *sessionLock) release() {
+	path := filepath.Join(l.dir, forwardPIDFilename)
+	pid, err := l.readPID(forwardPIDFilename)
+	if err != nil {
+		oktetoLog.Infof("unable to read PID file at %s: %s", path, err)
+		return
+	}
+	if pid != l.pid {
+		oktetoLog.Infof("okteto process with PID '%d' has the ownership of the file %s", pid, path)
+		return
+	}
+	if err := l.fs.Remove(path); err != nil && !os.IsNotExist(err) {
+		oktetoLog.Infof("unable to delete PID file at %s: %s", path, err)
+	}
+}
+
+// activePID returns the PID stored in the given file if it belongs to another running process
+func (l *sessionLock) activePID(filename string) (int, bool) {
+	pid, err := l.readPID(filename)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			oktetoLog.Infof("ignoring PID file %s: %s", filename, err)
+		}
+		return 0, false
+	}
+	if pid == l.pid {
+		return 0, false
+	}
+	return pid, l.isRunning(pid)
+}
+
+func (l *sessionLock) readPID(filename string) (int, error) {
+	b, err := afero.ReadFile(l.fs, filepath.Join(l.dir, filename))
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(b)))
+}
@@ -0,0 +1,127 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forward
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testLockDir = "/okteto/ns/api"
+	testPID     = 100
+)
+
+func newTestSessionLock(fs afero.Fs, running map[int]bool) *sessionLock {
+	return &sessionLock{
+		fs:        fs,
+		isRunning: func(pid int) bool { return running[pid] },
+		dir:       testLockDir,
+		devName:   "api",
+		pid:       testPID,
+	}
+}
+
+func TestSessionLockAcquire(t *testing.T) {
+	tests := []struct {
+		files       map[string]string
+		running     map[int]bool
+		expectedErr error
+		name        string
+	}{
+		{
+			name: "no active sessions",
+		},
+		{
+			name:        "active up session",
+			files:       map[string]string{upPIDFilename: "200"},
+			running:     map[int]bool{200: true},
+			expectedErr: errUpSessionActive,
+		},
+		{
+			name:    "stale up session",
+			files:   map[string]string{upPIDFilename: "200"},
+			running: map[int]bool{},
+		},
+		{
+			name:        "active forward session",
+			files:       map[string]string{forwardPIDFilename: "300\n"},
+			running:     map[int]bool{300: true},
+			expectedErr: errForwardSessionActive,
+		},
+		{
+			name:    "stale forward session",
+			files:   map[string]string{forwardPIDFilename: "300"},
+			running: map[int]bool{},
+		},
+		{
+			name:    "own forward session",
+			files:   map[string]string{forwardPIDFilename: "100"},
+			running: map[int]bool{testPID: true},
+		},
+		{
+			name:  "malformed pid file",
+			files: map[string]string{upPIDFilename: "not-a-pid"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			for name, content := range tt.files {
+				require.NoError(t, afero.WriteFile(fs, filepath.Join(testLockDir, name), []byte(content), 0600))
+			}
+			l := newTestSessionLock(fs, tt.running)
+
+			err := l.acquire()
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				require.ErrorAs(t, err, &oktetoErrors.UserError{})
+				return
+			}
+			require.NoError(t, err)
+			content, err := afero.ReadFile(fs, filepath.Join(testLockDir, forwardPIDFilename))
+			require.NoError(t, err)
+			assert.Equal(t, "100", string(content))
+		})
+	}
+}
+
+func TestSessionLockRelease(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	l := newTestSessionLock(fs, nil)
+	require.NoError(t, l.acquire())
+
+	l.release()
+	_, err := fs.Stat(filepath.Join(testLockDir, forwardPIDFilename))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestSessionLockReleaseKeepsOtherOwner(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	path := filepath.Join(testLockDir, forwardPIDFilename)
+	require.NoError(t, afero.WriteFile(fs, path, []byte("300"), 0600))
+	l := newTestSessionLock(fs, nil)
+
+	l.release()
+	content, err := afero.ReadFile(fs, path)
+	require.NoError(t, err)
+	assert.Equal(t, "300", string(content))
+}
@@ -0,0 +1,398 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/apps"
+	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/k8s/services"
+	"github.com/okteto/okteto/pkg/k8s/statefulsets"
+	"github.com/okteto/okteto/pkg/k8s/volumes"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	oktetoIO "github.com/okteto/okteto/pkg/log/io"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/process"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// defaultOlderThan is the inactivity period after which dev environments are collected
+	defaultOlderThan = "30d"
+
+	// volumeKind is the kind displayed for persistent volumes
+	volumeKind = "PersistentVolumeClaim"
+)
+
+// Options represents the options available on gc command
+type Options struct {
+	Namespace  string
+	K8sContext string
+	OlderThan  string
+	Volumes    bool
+	DryRun     bool
+}
+
+// garbage is a resource of a dev environment that hasn't been used for longer than the inactivity period
+type garbage struct {
+	lastActivity time.Time
+	app          apps.App
+	// original is the app in dev mode that has to be restored when its dev clone is removed
+	original apps.App
+	kind     string
+	name     string
+	// devService is the name of the service created by okteto up for autocreated dev containers
+	devService string
+}
+
+// collector finds the resources of inactive dev environments
+type collector struct {
+	c         kubernetes.Interface
+	now       func() time.Time
+	isRunning func(pid int) bool
+	namespace string
+	hostname  string
+}
+
+// GC removes the dev environments that haven't been used for a while
+func GC(ctx context.Context, k8sLogger *oktetoIO.K8sLogger) *cobra.Command {
+	opts := &Options{}
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Remove the Development Containers that haven't been used for a while",
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/okteto-cli/#gc"),
+		Example: `# list the development containers not used in the last 30 days
+okteto gc --dry-run
+
+# remove the development containers not used in the last week, including their volumes
+okteto gc --older-than 7d --volumes`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			olderThan, err := parseOlderThan(opts.OlderThan)
+			if err != nil {
+				return err
+			}
+
+			ctxOpts := &contextCMD.Options{
+				Show:      true,
+				Context:   opts.K8sContext,
+				Namespace: opts.Namespace,
+			}
+			if err := contextCMD.NewContextCommand().Run(ctx, ctxOpts); err != nil {
+				return err
+			}
+
+			c, _, err := okteto.GetK8sClientWithLogger(k8sLogger)
+			if err != nil {
+				return fmt.Errorf("failed to load k8s client: %w", err)
+			}
+			gc := newCollector(okteto.GetContext().Namespace, c)
+			return gc.run(ctx, opts, olderThan, os.Stdout)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.OlderThan, "older-than", defaultOlderThan, "remove the development containers not used for this period, like '30d' or '12h'")
+	cmd.Flags().BoolVar(&opts.Volumes, "volumes", false, "remove the persistent volumes of the development containers too")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "list the resources that would be removed without removing them")
+	cmd.Flags().StringVarP(&opts.Namespace, "namespace", "n", "", "overwrite the current Okteto Namespace")
+	cmd.Flags().StringVarP(&opts.K8sContext, "context", "c", "", "overwrite the current Okteto Context")
+	return cmd
+}
+
+// parseOlderThan parses durations like '30d', '12h' or '90m'
+func parseOlderThan(value string) (time.Duration, error) {
+	var result time.Duration
+	var err error
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		var n int
+		n, err = strconv.Atoi(days)
+		result = time.Duration(n) * 24 * time.Hour
+	} else {
+		result, err = time.ParseDuration(value)
+	}
+	if err != nil || result <= 0 {
+		return 0, oktetoErrors.UserError{
+			E:    fmt.Errorf("invalid value '%s' for '--older-than'", value),
+			Hint: "Use a positive period in days, hours or minutes, like '30d', '12h' or '90m'",
+		}
+	}
+	return result, nil
+}
+
+func newCollector(namespace string, c kubernetes.Interface) *collector {
+	return &collector{
+		c:         c,
+		now:       time.Now,
+		isRunning: process.IsRunning,
+		namespace: namespace,
+		hostname:  process.Hostname(),
+	}
+}
+
+// run prints the resources of the dev environments inactive for longer than olderThan and removes them unless it's a dry run
+func (gc *collector) run(ctx context.Context, opts *Options, olderThan time.Duration, w io.Writer) error {
+	items, err := gc.list(ctx, gc.now().Add(-olderThan), opts.Volumes)
+	if err != nil {
+		return err
+	}
+	printGarbage(w, items, opts.OlderThan, gc.now())
+	if opts.DryRun {
+		oktetoLog.Information("Dry run: nothing was removed")
+		return nil
+	}
+	for _, item := range items {
+		if err := gc.remove(ctx, item); err != nil {
+			return fmt.Errorf("failed to remove %s '%s': %w", item.kind, item.name, err)
+		}
+		oktetoLog.Success("%s '%s' removed", item.kind, item.name)
+	}
+	return nil
+}
+
+// list returns the dev clones and, if withVolumes is set, the dev volumes whose last activity is before the cutoff.
+// Resources of okteto up sessions that could still be running are never returned
+func (gc *collector) list(ctx context.Context, cutoff time.Time, withVolumes bool) ([]garbage, error) {
+	clones, err := gc.listDevClones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []garbage{}
+	// claimsInUse are the volumes mounted by dev clones that are kept
+	claimsInUse := map[string]bool{}
+	for _, clone := range clones {
+		lastActivity := getLastActivity(clone.ObjectMeta().Annotations, clone.ObjectMeta().CreationTimestamp.Time)
+		if gc.isActive(clone) || !lastActivity.Before(cutoff) {
+			for _, v := range clone.PodSpec().Volumes {
+				if v.PersistentVolumeClaim != nil {
+					claimsInUse[v.PersistentVolumeClaim.ClaimName] = true
+				}
+			}
+			continue
+		}
+		item, err := gc.newDevCloneGarbage(ctx, clone, lastActivity)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+
+	if withVolumes {
+		pvcs, err := volumes.List(ctx, gc.namespace, fmt.Sprintf("%s=true", constants.DevLabel), gc.c)
+		if err != nil {
+			return nil, fmt.Errorf("error getting volumes: %w", err)
+		}
+		for _, pvc := range pvcs {
+			lastActivity := getLastActivity(pvc.Annotations, pvc.CreationTimestamp.Time)
+			if claimsInUse[pvc.Name] || !lastActivity.Before(cutoff) {
+				continue
+			}
+			result = append(result, garbage{kind: volumeKind, name: pvc.Name, lastActivity: lastActivity})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].kind != result[j].kind {
+			return result[i].kind < result[j].kind
+		}
+		return result[i].name < result[j].name
+	})
+	return result, nil
+}
+
+// listDevClones returns the deployments and statefulsets created by okteto up to run development containers
+func (gc *collector) listDevClones(ctx context.Context) ([]apps.App, error) {
+	selectors := []string{fmt.Sprintf("%s=true", constants.DevLabel), model.DevCloneLabel}
+	seen := map[string]bool{}
+	result := []apps.App{}
+	add := func(app apps.App) {
+		key := fmt.Sprintf("%s/%s", app.Kind(), app.ObjectMeta().Name)
+		if seen[key] || !isDevClone(app) {
+			return
+		}
+		seen[key] = true
+		result = append(result, app)
+	}
+	for _, selector := range selectors {
+		dList, err := deployments.List(ctx, gc.namespace, selector, gc.c)
+		if err != nil {
+			return nil, fmt.Errorf("error getting deployments: %w", err)
+		}
+		for i := range dList {
+			add(apps.NewDeploymentApp(&dList[i]))
+		}
+		sfsList, err := statefulsets.List(ctx, gc.namespace, selector, gc.c)
+		if err != nil {
+			return nil, fmt.Errorf("error getting statefulsets: %w", err)
+		}
+		for i := range sfsList {
+			add(apps.NewStatefulSetApp(&sfsList[i]))
+		}
+	}
+	return result, nil
+}
+
+// isDevClone returns if the app runs a development container. Apps in dev mode also have the dev label, but they are not dev clones
+func isDevClone(app apps.App) bool {
+	if _, ok := app.ObjectMeta().Labels[model.DevCloneLabel]; ok {
+		return true
+	}
+	templateLabels := app.TemplateObjectMeta().Labels
+	if _, ok := templateLabels[model.InteractiveDevLabel]; ok {
+		return true
+	}
+	_, ok := templateLabels[model.DetachedDevLabel]
+	return ok
+}
+
+// isActive returns if the dev clone could be in use by an okteto up session. Dev clones without holder, held by
+// other machines or with an invalid holder are considered active because their session can't be checked
+func (gc *collector) isActive(clone apps.App) bool {
+	holder, ok := clone.ObjectMeta().Annotations[model.OktetoHolderAnnotation]
+	if !ok {
+		oktetoLog.Infof("dev clone '%s' has no holder, skipping it", clone.ObjectMeta().Name)
+		return true
+	}
+	if holder == model.OktetoHolderReleased {
+		return false
+	}
+	hostname, pid, err := process.ParseHolderIdentity(holder)
+	if err != nil || hostname != gc.hostname {
+		oktetoLog.Infof("dev clone '%s' is held by '%s', skipping it", clone.ObjectMeta().Name, holder)
+		return true
+	}
+	return gc.isRunning(pid)
+}
+
+// newDevCloneGarbage returns the garbage of a dev clone, including the original app to restore or the service of autocreated dev containers
+func (gc *collector) newDevCloneGarbage(ctx context.Context, clone apps.App, lastActivity time.Time) (garbage, error) {
+	item := garbage{
+		kind:         clone.Kind(),
+		name:         clone.ObjectMeta().Name,
+		lastActivity: lastActivity,
+		app:          clone,
+	}
+	originalName, ok := model.DevCloneOriginalName(item.name)
+	if !ok {
+		return item, nil
+	}
+	original, err := gc.getApp(ctx, clone.Kind(), originalName)
+	if err != nil {
+		if !oktetoErrors.IsNotFound(err) {
+			return item, err
+		}
+		// autocreated dev containers don't have an original app, but okteto up creates a service for them
+		item.devService = originalName
+		return item, nil
+	}
+	if original.ObjectMeta().Labels[constants.DevLabel] == "true" && original.ObjectMeta().Annotations[model.OktetoAutoCreateAnnotation] != model.OktetoUpCmd {
+		item.original = original
+	}
+	return item, nil
+}
+
+func (gc *collector) getApp(ctx context.Context, kind, name string) (apps.App, error) {
+	if kind == okteto.StatefulSet {
+		sfs, err := statefulsets.Get(ctx, name, gc.namespace, gc.c)
+		if err != nil {
+			return nil, err
+		}
+		return apps.NewStatefulSetApp(sfs), nil
+	}
+	d, err := deployments.Get(ctx, name, gc.namespace, gc.c)
+	if err != nil {
+		return nil, err
+	}
+	return apps.NewDeploymentApp(d), nil
+}
+
+// remove deletes a resource of an inactive dev environment, restoring the original app of dev clones
+func (gc *collector) remove(ctx context.Context, item garbage) error {
+	if item.kind == volumeKind {
+		return volumes.DestroyWithoutTimeout(ctx, item.name, gc.namespace, gc.c)
+	}
+
+	if item.original != nil {
+		tr := &apps.Translation{App: item.original, Dev: &model.Dev{Metadata: &model.Metadata{}}}
+		if err := tr.DevModeOff(); err != nil {
+			return err
+		}
+		if err := tr.App.Deploy(ctx, gc.c); err != nil {
+			return err
+		}
+	}
+	if err := item.app.Destroy(ctx, gc.c); err != nil && !oktetoErrors.IsNotFound(err) {
+		return err
+	}
+	if item.devService != "" {
+		svc, err := services.Get(ctx, item.devService, gc.namespace, gc.c)
+		if err != nil {
+			if oktetoErrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		if svc.Labels[constants.DevLabel] == "true" {
+			return services.Destroy(ctx, item.devService, gc.namespace, gc.c)
+		}
+	}
+	return nil
+}
+
+// getLastActivity returns when a resource was last used by okteto up, falling back to when it was created
+func getLastActivity(annotations map[string]string, created time.Time) time.Time {
+	for _, key := range []string{model.OktetoLastActivityAnnotation, model.OktetoHolderTimestampAnnotation} {
+		value, ok := annotations[key]
+		if !ok {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			oktetoLog.Infof("invalid value '%s' for annotation '%s': %s", value, key, err)
+			continue
+		}
+		return t
+	}
+	return created
+}
+
+// printGarbage writes the table of resources that are going to be removed
+func printGarbage(w io.Writer, items []garbage, olderThan string, now time.Time) {
+	if len(items) == 0 {
+		fmt.Fprintf(w, "No development containers have been inactive for more than %s\n", olderThan)
+		return
+	}
+	fmt.Fprintf(w, "The following resources have been inactive for more than %s and will be removed:\n", olderThan)
+	tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+	fmt.Fprintf(tw, "Kind\tName\tLast Activity\n")
+	for _, item := range items {
+		fmt.Fprintf(tw, "%s\t%s\t%s ago\n", item.kind, item.name, duration.HumanDuration(now.Sub(item.lastActivity)))
+	}
+	tw.Flush()
+}
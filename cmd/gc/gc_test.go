@@ -0,0 +1,254 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gc
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+var now = time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+func daysAgo(days int) string {
+	return now.Add(-time.Duration(days) * 24 * time.Hour).Format(time.RFC3339)
+}
+
+func newDevClone(name string, annotations map[string]string, claim string) *appsv1.Deployment {
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              model.DevCloneName(name),
+			Namespace:         "ns",
+			Labels:            map[string]string{model.DevCloneLabel: "uid"},
+			Annotations:       annotations,
+			CreationTimestamp: metav1.NewTime(now.Add(-365 * 24 * time.Hour)),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: apiv1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{model.InteractiveDevLabel: name}},
+			},
+		},
+	}
+	if claim != "" {
+		d.Spec.Template.Spec.Volumes = []apiv1.Volume{
+			{
+				Name:         "okteto",
+				VolumeSource: apiv1.VolumeSource{PersistentVolumeClaim: &apiv1.PersistentVolumeClaimVolumeSource{ClaimName: claim}},
+			},
+		}
+	}
+	return d
+}
+
+func newDevVolume(name, lastActivity string) *apiv1.PersistentVolumeClaim {
+	return &apiv1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "ns",
+			Labels:            map[string]string{constants.DevLabel: "true"},
+			Annotations:       map[string]string{model.OktetoLastActivityAnnotation: lastActivity},
+			CreationTimestamp: metav1.NewTime(now.Add(-365 * 24 * time.Hour)),
+		},
+	}
+}
+
+func newTestClientset() *fake.Clientset {
+	original := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "api",
+			Namespace:   "ns",
+			Labels:      map[string]string{constants.DevLabel: "true"},
+			Annotations: map[string]string{model.AppReplicasAnnotation: "2", constants.OktetoDevModeAnnotation: "sync"},
+		},
+		Spec: appsv1.DeploymentSpec{Replicas: ptr.To(int32(0))},
+	}
+
+	sandbox := newDevClone("sandbox", map[string]string{
+		model.OktetoHolderAnnotation:          "laptop/300",
+		model.OktetoHolderTimestampAnnotation: daysAgo(40),
+	}, "")
+	delete(sandbox.Labels, model.DevCloneLabel)
+	sandbox.Labels[constants.DevLabel] = "true"
+	sandboxService := &apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "sandbox", Namespace: "ns", Labels: map[string]string{constants.DevLabel: "true"}},
+	}
+
+	return fake.NewSimpleClientset(
+		original,
+		newDevClone("api", map[string]string{model.OktetoHolderAnnotation: model.OktetoHolderReleased, model.OktetoLastActivityAnnotation: daysAgo(60)}, "api-okteto"),
+		newDevClone("worker", map[string]string{model.OktetoHolderAnnotation: "laptop/100", model.OktetoLastActivityAnnotation: daysAgo(60)}, ""),
+		newDevClone("web", map[string]string{model.OktetoHolderAnnotation: "desktop/200", model.OktetoLastActivityAnnotation: daysAgo(60)}, "web-okteto"),
+		newDevClone("recent", map[string]string{model.OktetoHolderAnnotation: model.OktetoHolderReleased, model.OktetoLastActivityAnnotation: daysAgo(1)}, "recent-okteto"),
+		newDevClone("legacy", map[string]string{model.OktetoLastActivityAnnotation: daysAgo(60)}, "legacy-okteto"),
+		sandbox,
+		sandboxService,
+		newDevVolume("api-okteto", daysAgo(60)),
+		newDevVolume("web-okteto", daysAgo(60)),
+		newDevVolume("recent-okteto", daysAgo(1)),
+		newDevVolume("orphan-okteto", daysAgo(90)),
+	)
+}
+
+func newTestCollector(c *fake.Clientset) *collector {
+	return &collector{
+		c:         c,
+		now:       func() time.Time { return now },
+		isRunning: func(pid int) bool { return pid == 100 },
+		namespace: "ns",
+		hostname:  "laptop",
+	}
+}
+
+func TestList(t *testing.T) {
+	tests := []struct {
+		name        string
+		expected    []string
+		olderThan   time.Duration
+		withVolumes bool
+	}{
+		{
+			name:      "without volumes",
+			olderThan: 30 * 24 * time.Hour,
+			expected:  []string{"Deployment/api-okteto", "Deployment/sandbox-okteto"},
+		},
+		{
+			name:        "with volumes",
+			olderThan:   30 * 24 * time.Hour,
+			withVolumes: true,
+			expected:    []string{"Deployment/api-okteto", "Deployment/sandbox-okteto", "PersistentVolumeClaim/api-okteto", "PersistentVolumeClaim/orphan-okteto"},
+		},
+		{
+			name:        "longer inactivity period",
+			olderThan:   50 * 24 * time.Hour,
+			withVolumes: true,
+			expected:    []string{"Deployment/api-okteto", "PersistentVolumeClaim/api-okteto", "PersistentVolumeClaim/orphan-okteto"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gc := newTestCollector(newTestClientset())
+			items, err := gc.list(context.Background(), now.Add(-tt.olderThan), tt.withVolumes)
+			require.NoError(t, err)
+
+			result := []string{}
+			for _, item := range items {
+				result = append(result, item.kind+"/"+item.name)
+			}
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestRunRemovesInactiveDevEnvironments(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClientset()
+	gc := newTestCollector(c)
+
+	var out bytes.Buffer
+	require.NoError(t, gc.run(ctx, &Options{OlderThan: "30d", Volumes: true}, 30*24*time.Hour, &out))
+
+	for _, name := range []string{"api-okteto", "sandbox-okteto"} {
+		_, err := c.AppsV1().Deployments("ns").Get(ctx, name, metav1.GetOptions{})
+		assert.True(t, oktetoErrors.IsNotFound(err), "deployment '%s' should be removed", name)
+	}
+	for _, name := range []string{"worker-okteto", "web-okteto", "recent-okteto", "legacy-okteto"} {
+		_, err := c.AppsV1().Deployments("ns").Get(ctx, name, metav1.GetOptions{})
+		assert.NoError(t, err, "deployment '%s' should be kept", name)
+	}
+
+	original, err := c.AppsV1().Deployments("ns").Get(ctx, "api", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), *original.Spec.Replicas)
+	assert.NotContains(t, original.Labels, constants.DevLabel)
+	assert.NotContains(t, original.Annotations, constants.OktetoDevModeAnnotation)
+
+	_, err = c.CoreV1().Services("ns").Get(ctx, "sandbox", metav1.GetOptions{})
+	assert.True(t, oktetoErrors.IsNotFound(err))
+
+	pvcs, err := c.CoreV1().PersistentVolumeClaims("ns").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	kept := []string{}
+	for _, pvc := range pvcs.Items {
+		kept = append(kept, pvc.Name)
+	}
+	assert.ElementsMatch(t, []string{"web-okteto", "recent-okteto"}, kept)
+}
+
+func TestRunDryRun(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClientset()
+	gc := newTestCollector(c)
+
+	var out bytes.Buffer
+	require.NoError(t, gc.run(ctx, &Options{OlderThan: "30d", DryRun: true}, 30*24*time.Hour, &out))
+
+	expected := `The following resources have been inactive for more than 30d and will be removed:
+Kind        Name            Last Activity
+Deployment  api-okteto      60d ago
+Deployment  sandbox-okteto  40d ago
+`
+	assert.Equal(t, expected, out.String())
+
+	_, err := c.AppsV1().Deployments("ns").Get(ctx, "api-okteto", metav1.GetOptions{})
+	require.NoError(t, err)
+	original, err := c.AppsV1().Deployments("ns").Get(ctx, "api", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "true", original.Labels[constants.DevLabel])
+}
+
+func TestRunDryRunNothingToRemove(t *testing.T) {
+	gc := newTestCollector(fake.NewSimpleClientset())
+	var out bytes.Buffer
+	require.NoError(t, gc.run(context.Background(), &Options{OlderThan: "7d", DryRun: true}, 7*24*time.Hour, &out))
+	assert.Equal(t, "No development containers have been inactive for more than 7d\n", out.String())
+}
+
+func TestParseOlderThan(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{value: "30d", expected: 30 * 24 * time.Hour},
+		{value: "12h", expected: 12 * time.Hour},
+		{value: "90m", expected: 90 * time.Minute},
+		{value: "0d", wantErr: true},
+		{value: "-1h", wantErr: true},
+		{value: "d", wantErr: true},
+		{value: "month", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			result, err := parseOlderThan(tt.value)
+			if tt.wantErr {
+				assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"github.com/okteto/okteto/pkg/log/io"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+// Generate has all the generate subcommands
+func Generate(fs afero.Fs, ioCtrl *io.Controller) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate files from your Okteto configuration",
+	}
+	cmd.AddCommand(K8s(fs, ioCtrl))
+	return cmd
+}
@@ -0,0 +1,335 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/cmd/stack"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/log/io"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	defaultK8sOutputDir    = "k8s"
+	defaultManifestOutput  = "okteto.yml"
+	kustomizationFileName  = "kustomization.yaml"
+	generatedManifestsPerm = 0644
+)
+
+// K8sOptions are the options of the generate k8s command
+type K8sOptions struct {
+	Name         string
+	Output       string
+	ManifestPath string
+	Files        []string
+}
+
+// k8sGenerator writes the kubernetes manifests generated from a stack
+type k8sGenerator struct {
+	fs     afero.Fs
+	ioCtrl *io.Controller
+}
+
+// generatedBuild is the build section of a service in the generated okteto manifest
+type generatedBuild struct {
+	Args       map[string]string `yaml:"args,omitempty"`
+	Context    string            `yaml:"context,omitempty"`
+	Dockerfile string            `yaml:"dockerfile,omitempty"`
+	Target     string            `yaml:"target,omitempty"`
+}
+
+// generatedDev is the dev section of a service in the generated okteto manifest
+type generatedDev struct {
+	Sync    []string `yaml:"sync"`
+	Forward []string `yaml:"forward,omitempty"`
+}
+
+// generatedOktetoManifest is the okteto manifest deploying the generated kubernetes manifests
+type generatedOktetoManifest struct {
+	Build  map[string]generatedBuild `yaml:"build,omitempty"`
+	Dev    map[string]generatedDev   `yaml:"dev,omitempty"`
+	Deploy []model.DeployCommand     `yaml:"deploy"`
+}
+
+// kustomization is the kustomization file of the generated directory
+type kustomization struct {
+	APIVersion string   `yaml:"apiVersion"`
+	Kind       string   `yaml:"kind"`
+	Resources  []string `yaml:"resources"`
+}
+
+// K8s generates kubernetes manifests from a compose file
+func K8s(fs afero.Fs, ioCtrl *io.Controller) *cobra.Command {
+	options := &K8sOptions{}
+	cmd := &cobra.Command{
+		Use:   "k8s",
+		Short: "Generate Kubernetes manifests from your Docker Compose file",
+		Long: `Generate Kubernetes manifests from your Docker Compose file.
+
+The manifests are written to a kustomize directory, together with an Okteto Manifest that deploys them with kubectl.
+Running the command again updates the generated content and preserves the edits made outside of the okteto markers.`,
+		Example: `  okteto generate k8s
+  okteto generate k8s -f docker-compose.yml --output manifests`,
+		Args: utils.NoArgsAccepted("https://okteto.com/docs/reference/okteto-cli/"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := model.LoadStack(options.Name, options.Files, true, fs)
+			if err != nil {
+				return err
+			}
+			wd, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			g := &k8sGenerator{fs: fs, ioCtrl: ioCtrl}
+			return g.generate(s, wd, options)
+		},
+	}
+	cmd.Flags().StringVar(&options.Name, "name", "", "name of the compose project")
+	cmd.Flags().StringArrayVarP(&options.Files, "file", "f", []string{}, "path to the compose file")
+	cmd.Flags().StringVarP(&options.Output, "output", "o", defaultK8sOutputDir, "directory where the kubernetes manifests are written")
+	cmd.Flags().StringVar(&options.ManifestPath, "manifest", defaultManifestOutput, "path of the generated okteto manifest")
+	return cmd
+}
+
+// generate writes the kubernetes manifests, the kustomization file and the okteto manifest.
+// Nothing is written if any of the files has conflicting changes
+func (g *k8sGenerator) generate(s *model.Stack, wd string, options *K8sOptions) error {
+	generated := stack.GenerateK8sManifests(s)
+
+	files := map[string]string{}
+	resources := []string{}
+	for _, f := range generated.Files {
+		content, err := marshalObjects(f.Objects)
+		if err != nil {
+			return fmt.Errorf("error generating '%s': %w", f.Path, err)
+		}
+		files[filepath.Join(options.Output, filepath.FromSlash(f.Path))] = content
+		resources = append(resources, f.Path)
+	}
+
+	kustomizationContent, err := marshalYAML(kustomization{
+		APIVersion: "kustomize.config.k8s.io/v1beta1",
+		Kind:       "Kustomization",
+		Resources:  resources,
+	})
+	if err != nil {
+		return err
+	}
+	files[filepath.Join(options.Output, kustomizationFileName)] = kustomizationContent
+
+	manifestContent, err := g.generateOktetoManifest(s, generated, wd, options)
+	if err != nil {
+		return err
+	}
+	files[options.ManifestPath] = manifestContent
+
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	updates := []*managedFileUpdate{}
+	conflicts := []*fileConflict{}
+	for _, path := range paths {
+		update, conflict, err := mergeManagedFile(g.fs, path, files[path])
+		if err != nil {
+			return err
+		}
+		if conflict != nil {
+			conflicts = append(conflicts, conflict)
+			continue
+		}
+		updates = append(updates, update)
+	}
+
+	if len(conflicts) > 0 {
+		conflictPaths := []string{}
+		for _, c := range conflicts {
+			g.ioCtrl.Out().Warning("'%s' can't be regenerated: %s", c.path, c.err)
+			g.ioCtrl.Out().Print(c.diff)
+			conflictPaths = append(conflictPaths, c.path)
+		}
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("refusing to overwrite %s", strings.Join(conflictPaths, ", ")),
+			Hint: "Move your changes outside of the okteto markers or remove the files and run 'okteto generate k8s' again",
+		}
+	}
+
+	for _, update := range updates {
+		if !update.changed {
+			g.ioCtrl.Logger().Infof("'%s' is up to date", update.path)
+			continue
+		}
+		if err := g.fs.MkdirAll(filepath.Dir(update.path), 0755); err != nil {
+			return err
+		}
+		if err := afero.WriteFile(g.fs, update.path, []byte(update.content), generatedManifestsPerm); err != nil {
+			return fmt.Errorf("error writing '%s': %w", update.path, err)
+		}
+		// the files generated by previous versions were only readable by the owner
+		if err := g.fs.Chmod(update.path, generatedManifestsPerm); err != nil {
+			return fmt.Errorf("error writing '%s': %w", update.path, err)
+		}
+		g.ioCtrl.Out().Infof("'%s' generated", update.path)
+	}
+	g.ioCtrl.Out().Success("Kubernetes manifests generated in '%s'", options.Output)
+	return nil
+}
+
+// generateOktetoManifest returns an okteto manifest that builds the service images, deploys the generated
+// directory with kubectl and keeps the dev workflows of the services with bind mounts
+func (g *k8sGenerator) generateOktetoManifest(s *model.Stack, generated *stack.GeneratedManifests, wd string, options *K8sOptions) (string, error) {
+	manifestDir := filepath.Dir(options.ManifestPath)
+	if !filepath.IsAbs(manifestDir) {
+		manifestDir = filepath.Join(wd, manifestDir)
+	}
+	relPath := func(path string) string {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(wd, path)
+		}
+		rel, err := filepath.Rel(manifestDir, path)
+		if err != nil {
+			return path
+		}
+		return filepath.ToSlash(rel)
+	}
+
+	m := generatedOktetoManifest{
+		Build: map[string]generatedBuild{},
+		Dev:   map[string]generatedDev{},
+		Deploy: []model.DeployCommand{
+			{
+				Name:    "Deploy Kubernetes manifests",
+				Command: fmt.Sprintf("kubectl apply -k %s", relPath(options.Output)),
+			},
+		},
+	}
+
+	for _, w := range generated.Workloads {
+		svc := s.Services[w.Service]
+		if svc.Build == nil {
+			continue
+		}
+		b := generatedBuild{
+			Context:    relPath(svc.Build.Context),
+			Dockerfile: svc.Build.Dockerfile,
+			Target:     svc.Build.Target,
+		}
+		if filepath.IsAbs(b.Dockerfile) {
+			b.Dockerfile = relPath(b.Dockerfile)
+		}
+		if len(svc.Build.Args) > 0 {
+			b.Args = map[string]string{}
+			for _, arg := range svc.Build.Args {
+				b.Args[arg.Name] = arg.Value
+			}
+		}
+		m.Build[w.Service] = b
+
+		if w.Kind == "job" {
+			g.ioCtrl.Out().Warning("the image of the job '%s' must be updated in '%s' to use the image built by okteto", w.Service, options.Output)
+			continue
+		}
+		imageEnvVar := fmt.Sprintf("OKTETO_BUILD_%s_IMAGE", strings.ToUpper(strings.ReplaceAll(w.Service, "-", "_")))
+		m.Deploy = append(m.Deploy, model.DeployCommand{
+			Name:    fmt.Sprintf("Update image of service '%s'", w.Service),
+			Command: fmt.Sprintf("kubectl set image %s/%s %s=${%s}", w.Kind, w.Service, w.Service, imageEnvVar),
+		})
+	}
+
+	for _, w := range generated.Workloads {
+		svc := s.Services[w.Service]
+		d := generatedDev{}
+		for _, v := range svc.VolumeMounts {
+			d.Sync = append(d.Sync, fmt.Sprintf("%s:%s", relPath(v.LocalPath), v.RemotePath))
+		}
+		if len(d.Sync) == 0 {
+			continue
+		}
+		for _, p := range svc.Ports {
+			if p.HostPort != 0 {
+				d.Forward = append(d.Forward, fmt.Sprintf("%d:%d", p.HostPort, p.ContainerPort))
+			}
+		}
+		m.Dev[w.Service] = d
+	}
+
+	return marshalYAML(m)
+}
+
+// marshalObjects serializes kubernetes objects as a multi-document yaml
+func marshalObjects(objects []runtime.Object) (string, error) {
+	docs := []string{}
+	for _, obj := range objects {
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return "", err
+		}
+		delete(u, "status")
+		removeEmptyFields(u)
+		doc, err := marshalYAML(u)
+		if err != nil {
+			return "", err
+		}
+		docs = append(docs, doc)
+	}
+	return strings.Join(docs, "---\n"), nil
+}
+
+// removeEmptyFields removes the null values and empty status fields that the unstructured converter adds for non-pointer structs
+func removeEmptyFields(u map[string]interface{}) {
+	for k, v := range u {
+		switch value := v.(type) {
+		case nil:
+			delete(u, k)
+		case map[string]interface{}:
+			removeEmptyFields(value)
+			if k == "status" && len(value) == 0 {
+				delete(u, k)
+			}
+		case []interface{}:
+			for _, item := range value {
+				if m, ok := item.(map[string]interface{}); ok {
+					removeEmptyFields(m)
+				}
+			}
+		}
+	}
+}
+
+func marshalYAML(v interface{}) (string, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(v); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,192 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/log/io"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const twoServicesDir = "testdata/two-services"
+
+func loadTwoServicesStack(t *testing.T) *model.Stack {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join(twoServicesDir, "docker-compose.yml"))
+	require.NoError(t, err)
+	s, err := model.ReadStack(b, true)
+	require.NoError(t, err)
+	s.Name = "myapp"
+	return s
+}
+
+func generateTwoServices(t *testing.T, fs afero.Fs) (string, error) {
+	t.Helper()
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	g := &k8sGenerator{fs: fs, ioCtrl: io.NewIOController()}
+	options := &K8sOptions{
+		Output:       filepath.Join(wd, defaultK8sOutputDir),
+		ManifestPath: filepath.Join(wd, defaultManifestOutput),
+	}
+	return wd, g.generate(loadTwoServicesStack(t), wd, options)
+}
+
+// readGeneratedFiles returns the content of all the files in dir indexed by their relative path
+func readGeneratedFiles(t *testing.T, fs afero.Fs, dir string) map[string]string {
+	t.Helper()
+	result := map[string]string{}
+	err := afero.Walk(fs, dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		content, err := afero.ReadFile(fs, path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		result[filepath.ToSlash(rel)] = string(content)
+		return nil
+	})
+	require.NoError(t, err)
+	return result
+}
+
+func TestGenerateK8sGolden(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	wd, err := generateTwoServices(t, fs)
+	require.NoError(t, err)
+
+	expected := readGeneratedFiles(t, afero.NewOsFs(), filepath.Join(twoServicesDir, "golden"))
+	generated := readGeneratedFiles(t, fs, wd)
+	require.Len(t, generated, len(expected))
+	for path, content := range expected {
+		assert.Equal(t, content, generated[path], "file %s differs from the golden file", path)
+	}
+}
+
+func TestGenerateK8sFilesAreReadable(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	wd, err := generateTwoServices(t, fs)
+	require.NoError(t, err)
+
+	// the generated manifests are committed to the repository, they must be readable by other users and tools
+	err = afero.Walk(fs, wd, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		assert.Equal(t, os.FileMode(0644), info.Mode().Perm(), "file %s", path)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestGenerateK8sIsIdempotent(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	wd, err := generateTwoServices(t, fs)
+	require.NoError(t, err)
+	first := readGeneratedFiles(t, fs, wd)
+
+	_, err = generateTwoServices(t, fs)
+	require.NoError(t, err)
+	assert.Equal(t, first, readGeneratedFiles(t, fs, wd))
+}
+
+func TestGenerateK8sPreservesEditsOutsideMarkers(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	wd, err := generateTwoServices(t, fs)
+	require.NoError(t, err)
+
+	kustomizationPath := filepath.Join(wd, defaultK8sOutputDir, kustomizationFileName)
+	content, err := afero.ReadFile(fs, kustomizationPath)
+	require.NoError(t, err)
+	edited := "# my kustomization\n" + string(content) + "namePrefix: dev-\n"
+	require.NoError(t, afero.WriteFile(fs, kustomizationPath, []byte(edited), 0600))
+
+	_, err = generateTwoServices(t, fs)
+	require.NoError(t, err)
+
+	regenerated, err := afero.ReadFile(fs, kustomizationPath)
+	require.NoError(t, err)
+	assert.Equal(t, edited, string(regenerated))
+}
+
+func TestGenerateK8sRefusesConflicts(t *testing.T) {
+	tests := []struct {
+		edit        func(content string) string
+		name        string
+		expectedErr error
+	}{
+		{
+			name: "managed content modified",
+			edit: func(content string) string {
+				return strings.Replace(content, "replicas: 1", "replicas: 3", 1)
+			},
+			expectedErr: errManagedEdited,
+		},
+		{
+			name: "file not generated by okteto",
+			edit: func(string) string {
+				return "apiVersion: apps/v1\nkind: Deployment\n"
+			},
+			expectedErr: errNoMarkers,
+		},
+		{
+			name: "end marker removed",
+			edit: func(content string) string {
+				return strings.Replace(content, endMarker, "", 1)
+			},
+			expectedErr: errMalformedBlock,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			wd, err := generateTwoServices(t, fs)
+			require.NoError(t, err)
+
+			deploymentPath := filepath.Join(wd, defaultK8sOutputDir, "api", "deployment.yaml")
+			content, err := afero.ReadFile(fs, deploymentPath)
+			require.NoError(t, err)
+			edited := tt.edit(string(content))
+			require.NoError(t, afero.WriteFile(fs, deploymentPath, []byte(edited), 0600))
+
+			_, err = generateTwoServices(t, fs)
+			require.ErrorAs(t, err, &oktetoErrors.UserError{})
+			assert.Contains(t, err.Error(), deploymentPath)
+
+			current, err := afero.ReadFile(fs, deploymentPath)
+			require.NoError(t, err)
+			assert.Equal(t, edited, string(current))
+
+			_, conflict, err := mergeManagedFile(fs, deploymentPath, "")
+			require.NoError(t, err)
+			require.NotNil(t, conflict)
+			assert.ErrorIs(t, conflict.err, tt.expectedErr)
+			assert.NotEmpty(t, conflict.diff)
+		})
+	}
+}
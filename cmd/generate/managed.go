@@ -0,0 +1,144 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/afero"
+)
+
+const (
+	beginMarker = "# BEGIN okteto generate k8s"
+	endMarker   = "# END okteto generate k8s"
+
+	// checksumLength is the number of hex characters of the checksum stored in the begin marker
+	checksumLength = 16
+)
+
+var (
+	beginMarkerRegex = regexp.MustCompile(`^# BEGIN okteto generate k8s \(checksum: ([0-9a-f]+)\)`)
+
+	errNoMarkers      = errors.New("the file was not generated by 'okteto generate k8s'")
+	errManagedEdited  = errors.New("the content between the okteto markers was modified")
+	errMalformedBlock = errors.New("the okteto markers are malformed")
+)
+
+// fileConflict is a generated file that can't be updated without losing user changes
+type fileConflict struct {
+	err  error
+	path string
+	diff string
+}
+
+// managedFileUpdate is the result of merging the generated content into a file
+type managedFileUpdate struct {
+	path    string
+	content string
+	changed bool
+}
+
+// checksum returns the checksum of the managed content of a file
+func checksum(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])[:checksumLength]
+}
+
+// renderManagedBlock wraps the generated content between the okteto markers
+func renderManagedBlock(content string) string {
+	return fmt.Sprintf("%s (checksum: %s). Edits outside of the BEGIN/END markers are preserved\n%s%s\n", beginMarker, checksum(content), content, endMarker)
+}
+
+// splitManagedFile returns the content before the managed block, the managed content, its stored checksum and the content after the managed block
+func splitManagedFile(content string) (string, string, string, string, error) {
+	lines := strings.SplitAfter(content, "\n")
+	begin, end := -1, -1
+	storedChecksum := ""
+	for i, line := range lines {
+		trimmed := strings.TrimRight(line, "\r\n")
+		if begin == -1 {
+			if m := beginMarkerRegex.FindStringSubmatch(trimmed); m != nil {
+				begin = i
+				storedChecksum = m[1]
+			}
+			continue
+		}
+		if trimmed == endMarker {
+			end = i
+			break
+		}
+	}
+	if begin == -1 && end == -1 {
+		return "", "", "", "", errNoMarkers
+	}
+	if begin == -1 || end == -1 {
+		return "", "", "", "", errMalformedBlock
+	}
+	prefix := strings.Join(lines[:begin], "")
+	managed := strings.Join(lines[begin+1:end], "")
+	suffix := strings.Join(lines[end+1:], "")
+	return prefix, managed, storedChecksum, suffix, nil
+}
+
+// mergeManagedFile computes the new content of a generated file, preserving the content outside of the okteto markers.
+// It returns a conflict if the file exists but it was not generated by okteto, or if the managed content was modified
+func mergeManagedFile(fs afero.Fs, path, generated string) (*managedFileUpdate, *fileConflict, error) {
+	current, err := afero.ReadFile(fs, path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &managedFileUpdate{path: path, content: renderManagedBlock(generated), changed: true}, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	prefix, managed, storedChecksum, suffix, err := splitManagedFile(string(current))
+	if err != nil {
+		return nil, &fileConflict{
+			path: path,
+			err:  err,
+			diff: unifiedDiff(path, string(current), renderManagedBlock(generated)),
+		}, nil
+	}
+	if checksum(managed) != storedChecksum {
+		return nil, &fileConflict{
+			path: path,
+			err:  errManagedEdited,
+			diff: unifiedDiff(path, managed, generated),
+		}, nil
+	}
+
+	content := prefix + renderManagedBlock(generated) + suffix
+	return &managedFileUpdate{path: path, content: content, changed: content != string(current)}, nil, nil
+}
+
+func unifiedDiff(path, current, generated string) string {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(current),
+		B:        difflib.SplitLines(generated),
+		FromFile: path,
+		ToFile:   fmt.Sprintf("%s (generated)", path),
+		Context:  3,
+	})
+	if err != nil {
+		return ""
+	}
+	return diff
+}
@@ -34,6 +34,10 @@ type CreateOptions struct {
 	Namespace    string
 	Show         bool
 	SetCurrentNs bool
+	// CopySecretsFrom, Labels and DryRun are only supported in clusters not managed by Okteto
+	CopySecretsFrom string
+	Labels          []string
+	DryRun          bool
 }
 
 // Create creates a namespace
@@ -51,7 +55,13 @@ func Create(ctx context.Context, ioCtrl *io.Controller) *cobra.Command {
 			options.Namespace = args[0]
 
 			if !okteto.IsOkteto() {
-				return oktetoErrors.ErrContextIsNotOktetoCluster
+				return createVanillaNamespace(ctx, options)
+			}
+			if options.CopySecretsFrom != "" || len(options.Labels) > 0 || options.DryRun {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("the flags '--copy-secrets-from', '--label' and '--dry-run' are only supported in clusters not managed by Okteto"),
+					Hint: "Remove these flags to create an Okteto Namespace",
+				}
 			}
 
 			nsCmd, err := NewCommand(ioCtrl)
@@ -67,9 +77,37 @@ func Create(ctx context.Context, ioCtrl *io.Controller) *cobra.Command {
 
 	options.Members = cmd.Flags().StringArrayP("members", "m", []string{}, "members of the Okteto Namespace, it can by username or email")
 	cmd.Flags().BoolVarP(&options.SetCurrentNs, "use", "", true, "use the newly created Okteto Namespace as the current namespace")
+	cmd.Flags().StringVar(&options.CopySecretsFrom, "copy-secrets-from", "", "copy the registry pull secrets, TLS certificates and resources labeled 'dev.okteto.com/copy=true' of a namespace (only for clusters not managed by Okteto)")
+	cmd.Flags().StringArrayVarP(&options.Labels, "label", "l", nil, "set a label on the namespace with the format 'key=value' (only for clusters not managed by Okteto)")
+	cmd.Flags().BoolVar(&options.DryRun, "dry-run", false, "list what would be created and copied without creating anything (only for clusters not managed by Okteto)")
 	return cmd
 }
 
+// createVanillaNamespace creates the namespace in a cluster not managed by Okteto
+func createVanillaNamespace(ctx context.Context, opts *CreateOptions) error {
+	c, _, err := okteto.GetK8sClient()
+	if err != nil {
+		return err
+	}
+	vc := &vanillaNamespaceCreator{k8sClient: c}
+	if err := vc.create(ctx, opts); err != nil {
+		return err
+	}
+	if !opts.SetCurrentNs || opts.DryRun {
+		return nil
+	}
+	ctxOptions := &contextCMD.Options{
+		Context:   okteto.GetContext().Name,
+		Namespace: opts.Namespace,
+		Save:      true,
+		Show:      true,
+	}
+	if err := contextCMD.NewContextCommand().Run(ctx, ctxOptions); err != nil {
+		return fmt.Errorf("failed to activate your new namespace %s: %w", opts.Namespace, err)
+	}
+	return nil
+}
+
 func (nc *Command) Create(ctx context.Context, opts *CreateOptions) error {
 	oktetoNS, err := nc.okClient.Namespaces().Create(ctx, opts.Namespace)
 	if err != nil {
@@ -0,0 +1,204 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	apiv1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// copyLabel marks the secrets and configmaps of the source namespace that are copied even if their type is not in the allowlist
+	copyLabel = "dev.okteto.com/copy"
+
+	secretKind    = "secret"
+	configMapKind = "configmap"
+)
+
+// copiedSecretTypes are the secret types copied from the source namespace by default: registry pull secrets and TLS certificates
+var copiedSecretTypes = map[apiv1.SecretType]bool{
+	apiv1.SecretTypeDockerConfigJson: true,
+	apiv1.SecretTypeDockercfg:        true,
+	apiv1.SecretTypeTLS:              true,
+}
+
+// copyCandidate is a secret or configmap of the source namespace to copy to the new namespace
+type copyCandidate struct {
+	kind string
+	name string
+}
+
+// vanillaNamespaceCreator creates namespaces in clusters that are not managed by Okteto
+type vanillaNamespaceCreator struct {
+	k8sClient kubernetes.Interface
+}
+
+// parseNamespaceLabels parses the labels with the format 'key=value'
+func parseNamespaceLabels(values []string) (map[string]string, error) {
+	result := map[string]string{}
+	for _, value := range values {
+		key, v, found := strings.Cut(value, "=")
+		if !found {
+			return nil, oktetoErrors.UserError{
+				E:    fmt.Errorf("invalid label '%s'", value),
+				Hint: "Labels must have the format 'key=value'",
+			}
+		}
+		errs := validation.IsQualifiedName(key)
+		errs = append(errs, validation.IsValidLabelValue(v)...)
+		if len(errs) > 0 {
+			return nil, oktetoErrors.UserError{
+				E:    fmt.Errorf("invalid label '%s': %s", value, strings.Join(errs, ", ")),
+				Hint: "Labels must have the format 'key=value'",
+			}
+		}
+		result[key] = v
+	}
+	return result, nil
+}
+
+// create creates the namespace with the labels and copies the allowlisted secrets and configmaps of the source namespace.
+// Secrets and configmaps already present in the namespace are skipped. With dryRun nothing is created
+func (vc *vanillaNamespaceCreator) create(ctx context.Context, opts *CreateOptions) error {
+	labels, err := parseNamespaceLabels(opts.Labels)
+	if err != nil {
+		return err
+	}
+
+	var candidates []copyCandidate
+	secrets := map[string]apiv1.Secret{}
+	configmaps := map[string]apiv1.ConfigMap{}
+	if opts.CopySecretsFrom != "" {
+		candidates, secrets, configmaps, err = vc.listCopyCandidates(ctx, opts.CopySecretsFrom)
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.DryRun {
+		oktetoLog.Information("Namespace '%s' would be created", opts.Namespace)
+		for _, c := range candidates {
+			oktetoLog.Information("%s '%s' would be copied from namespace '%s'", c.kind, c.name, opts.CopySecretsFrom)
+		}
+		oktetoLog.Information("Dry run: nothing was created")
+		return nil
+	}
+
+	ns := &apiv1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   opts.Namespace,
+			Labels: labels,
+		},
+	}
+	if _, err := vc.k8sClient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{}); err != nil {
+		if !k8sErrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create namespace '%s': %w", opts.Namespace, err)
+		}
+		oktetoLog.Warning("Namespace '%s' already exists", opts.Namespace)
+	} else {
+		oktetoLog.Success("Namespace '%s' created", opts.Namespace)
+	}
+
+	for _, c := range candidates {
+		var err error
+		switch c.kind {
+		case secretKind:
+			s := secrets[c.name]
+			_, err = vc.k8sClient.CoreV1().Secrets(opts.Namespace).Create(ctx, &apiv1.Secret{
+				ObjectMeta: copiedObjectMeta(s.ObjectMeta, opts.Namespace),
+				Type:       s.Type,
+				Data:       s.Data,
+			}, metav1.CreateOptions{})
+		case configMapKind:
+			cm := configmaps[c.name]
+			_, err = vc.k8sClient.CoreV1().ConfigMaps(opts.Namespace).Create(ctx, &apiv1.ConfigMap{
+				ObjectMeta: copiedObjectMeta(cm.ObjectMeta, opts.Namespace),
+				Data:       cm.Data,
+				BinaryData: cm.BinaryData,
+			}, metav1.CreateOptions{})
+		}
+		if err != nil {
+			if k8sErrors.IsAlreadyExists(err) {
+				oktetoLog.Warning("Skipping %s '%s': it already exists in namespace '%s'", c.kind, c.name, opts.Namespace)
+				continue
+			}
+			return fmt.Errorf("failed to copy %s '%s': %w", c.kind, c.name, err)
+		}
+		oktetoLog.Success("Copied %s '%s' from namespace '%s'", c.kind, c.name, opts.CopySecretsFrom)
+	}
+	return nil
+}
+
+// listCopyCandidates returns the secrets and configmaps of the source namespace to copy.
+// Secrets with an allowlisted type and secrets and configmaps with the copy label are copied. Service account tokens are never copied
+func (vc *vanillaNamespaceCreator) listCopyCandidates(ctx context.Context, source string) ([]copyCandidate, map[string]apiv1.Secret, map[string]apiv1.ConfigMap, error) {
+	candidates := []copyCandidate{}
+	secrets := map[string]apiv1.Secret{}
+	configmaps := map[string]apiv1.ConfigMap{}
+
+	secretList, err := vc.k8sClient.CoreV1().Secrets(source).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to list secrets of namespace '%s': %w", source, err)
+	}
+	for _, s := range secretList.Items {
+		if s.Type == apiv1.SecretTypeServiceAccountToken {
+			continue
+		}
+		if !copiedSecretTypes[s.Type] && s.Labels[copyLabel] != "true" {
+			continue
+		}
+		secrets[s.Name] = s
+		candidates = append(candidates, copyCandidate{kind: secretKind, name: s.Name})
+	}
+
+	cmList, err := vc.k8sClient.CoreV1().ConfigMaps(source).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to list configmaps of namespace '%s': %w", source, err)
+	}
+	for _, cm := range cmList.Items {
+		if cm.Labels[copyLabel] != "true" {
+			continue
+		}
+		configmaps[cm.Name] = cm
+		candidates = append(candidates, copyCandidate{kind: configMapKind, name: cm.Name})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].kind != candidates[j].kind {
+			return candidates[i].kind > candidates[j].kind
+		}
+		return candidates[i].name < candidates[j].name
+	})
+	return candidates, secrets, configmaps, nil
+}
+
+// copiedObjectMeta returns the metadata of a copied object, without the server-side fields of the original one
+func copiedObjectMeta(meta metav1.ObjectMeta, namespace string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        meta.Name,
+		Namespace:   namespace,
+		Labels:      meta.Labels,
+		Annotations: meta.Annotations,
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func templateNamespaceObjects() []runtime.Object {
+	return []runtime.Object{
+		&apiv1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "template"}},
+		&apiv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "template", ResourceVersion: "10"},
+			Type:       apiv1.SecretTypeDockerConfigJson,
+			Data:       map[string][]byte{apiv1.DockerConfigJsonKey: []byte("{}")},
+		},
+		&apiv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "wildcard-tls", Namespace: "template"},
+			Type:       apiv1.SecretTypeTLS,
+			Data:       map[string][]byte{"tls.crt": []byte("cert"), "tls.key": []byte("key")},
+		},
+		&apiv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-token", Namespace: "template", Labels: map[string]string{copyLabel: "true"}},
+			Type:       apiv1.SecretTypeServiceAccountToken,
+		},
+		&apiv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "db-password", Namespace: "template"},
+			Type:       apiv1.SecretTypeOpaque,
+		},
+		&apiv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "api-keys", Namespace: "template", Labels: map[string]string{copyLabel: "true"}},
+			Type:       apiv1.SecretTypeOpaque,
+			Data:       map[string][]byte{"key": []byte("value")},
+		},
+		&apiv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "kube-root-ca.crt", Namespace: "template"},
+		},
+		&apiv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-settings", Namespace: "template", Labels: map[string]string{copyLabel: "true"}},
+			Data:       map[string]string{"team": "backend"},
+		},
+	}
+}
+
+func TestListCopyCandidates(t *testing.T) {
+	vc := &vanillaNamespaceCreator{k8sClient: fake.NewSimpleClientset(templateNamespaceObjects()...)}
+
+	candidates, secrets, configmaps, err := vc.listCopyCandidates(context.Background(), "template")
+	require.NoError(t, err)
+	assert.Equal(t, []copyCandidate{
+		{kind: secretKind, name: "api-keys"},
+		{kind: secretKind, name: "registry"},
+		{kind: secretKind, name: "wildcard-tls"},
+		{kind: configMapKind, name: "team-settings"},
+	}, candidates)
+	assert.Len(t, secrets, 3)
+	assert.Len(t, configmaps, 1)
+}
+
+func TestVanillaNamespaceCreate(t *testing.T) {
+	ctx := context.Background()
+	objects := templateNamespaceObjects()
+	objects = append(objects, &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "cindy"},
+		Type:       apiv1.SecretTypeDockerConfigJson,
+		Data:       map[string][]byte{apiv1.DockerConfigJsonKey: []byte(`{"auths":{}}`)},
+	})
+	c := fake.NewSimpleClientset(objects...)
+	vc := &vanillaNamespaceCreator{k8sClient: c}
+
+	err := vc.create(ctx, &CreateOptions{
+		Namespace:       "cindy",
+		CopySecretsFrom: "template",
+		Labels:          []string{"team=backend"},
+	})
+	require.NoError(t, err)
+
+	ns, err := c.CoreV1().Namespaces().Get(ctx, "cindy", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "backend"}, ns.Labels)
+
+	// the conflicting secret is not overridden
+	registry, err := c.CoreV1().Secrets("cindy").Get(ctx, "registry", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, `{"auths":{}}`, string(registry.Data[apiv1.DockerConfigJsonKey]))
+
+	tls, err := c.CoreV1().Secrets("cindy").Get(ctx, "wildcard-tls", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, apiv1.SecretTypeTLS, tls.Type)
+	assert.Equal(t, "cert", string(tls.Data["tls.crt"]))
+	assert.Empty(t, tls.ResourceVersion)
+
+	_, err = c.CoreV1().Secrets("cindy").Get(ctx, "api-keys", metav1.GetOptions{})
+	require.NoError(t, err)
+	_, err = c.CoreV1().Secrets("cindy").Get(ctx, "default-token", metav1.GetOptions{})
+	require.Error(t, err)
+	_, err = c.CoreV1().Secrets("cindy").Get(ctx, "db-password", metav1.GetOptions{})
+	require.Error(t, err)
+
+	cm, err := c.CoreV1().ConfigMaps("cindy").Get(ctx, "team-settings", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "backend", cm.Data["team"])
+	_, err = c.CoreV1().ConfigMaps("cindy").Get(ctx, "kube-root-ca.crt", metav1.GetOptions{})
+	require.Error(t, err)
+}
+
+func TestVanillaNamespaceCreateDryRun(t *testing.T) {
+	c := fake.NewSimpleClientset(templateNamespaceObjects()...)
+	vc := &vanillaNamespaceCreator{k8sClient: c}
+
+	err := vc.create(context.Background(), &CreateOptions{
+		Namespace:       "cindy",
+		CopySecretsFrom: "template",
+		DryRun:          true,
+	})
+	require.NoError(t, err)
+	for _, action := range c.Actions() {
+		assert.Equal(t, "list", action.GetVerb(), "unexpected %s on %s", action.GetVerb(), action.GetResource().Resource)
+	}
+}
+
+func TestVanillaNamespaceCreateExistingNamespace(t *testing.T) {
+	c := fake.NewSimpleClientset(templateNamespaceObjects()...)
+	vc := &vanillaNamespaceCreator{k8sClient: c}
+
+	require.NoError(t, vc.create(context.Background(), &CreateOptions{Namespace: "template"}))
+}
+
+func TestParseNamespaceLabels(t *testing.T) {
+	labels, err := parseNamespaceLabels([]string{"team=backend", "okteto.com/owner=cindy", "empty="})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "backend", "okteto.com/owner": "cindy", "empty": ""}, labels)
+
+	for _, value := range []string{"team", "=backend", "team=back end"} {
+		_, err := parseNamespaceLabels([]string{value})
+		var uErr oktetoErrors.UserError
+		require.ErrorAs(t, err, &uErr, value)
+	}
+}
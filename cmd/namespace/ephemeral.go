@@ -0,0 +1,160 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/env"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	apiv1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+)
+
+// CreatedByOktetoLabel marks the namespaces created by 'okteto deploy', the only ones deleted by 'okteto destroy --delete-namespace'
+const CreatedByOktetoLabel = "dev.okteto.com/okteto-created"
+
+// ExpandNamespace expands the environment variables of a namespace template like 'pr-${PR_NUMBER}-app'.
+// The prefix, like 'pr-${PR_NUMBER}', is prepended to the namespace separated by a dash
+func ExpandNamespace(prefix, namespace string) (string, error) {
+	if prefix != "" {
+		if namespace == "" {
+			return "", oktetoErrors.UserError{
+				E:    fmt.Errorf("the namespace prefix '%s' requires a namespace", prefix),
+				Hint: "Set the namespace with the '--namespace' flag",
+			}
+		}
+		namespace = fmt.Sprintf("%s-%s", prefix, namespace)
+	}
+	if namespace == "" {
+		return "", nil
+	}
+	expanded, err := env.ExpandEnv(namespace)
+	if err != nil {
+		return "", err
+	}
+	if errs := validation.IsDNS1123Label(expanded); len(errs) > 0 {
+		return "", oktetoErrors.UserError{
+			E:    fmt.Errorf("invalid namespace '%s': %s", expanded, strings.Join(errs, ", ")),
+			Hint: fmt.Sprintf("Check the environment variables used by the namespace '%s'", namespace),
+		}
+	}
+	return expanded, nil
+}
+
+// ExpandNamespaceLabels parses the labels with the format 'key=value', expanding the environment variables of their values
+func ExpandNamespaceLabels(values []string) (map[string]string, error) {
+	expanded := make([]string, 0, len(values))
+	for _, value := range values {
+		v, err := env.ExpandEnv(value)
+		if err != nil {
+			return nil, err
+		}
+		expanded = append(expanded, v)
+	}
+	return parseNamespaceLabels(expanded)
+}
+
+// CreateVanillaNamespace creates the namespace in a cluster not managed by Okteto if it doesn't exist, with the labels
+// and marked as created by okteto. It never asks for confirmation, so it can run in CI. It returns if the namespace was created
+func CreateVanillaNamespace(ctx context.Context, name string, labels map[string]string, c kubernetes.Interface) (bool, error) {
+	_, err := c.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	if err == nil {
+		return false, nil
+	}
+	if !k8sErrors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to get namespace '%s': %w", name, err)
+	}
+
+	nsLabels := map[string]string{CreatedByOktetoLabel: "true"}
+	for key, value := range labels {
+		nsLabels[key] = value
+	}
+	ns := &apiv1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: nsLabels,
+		},
+	}
+	if _, err := c.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{}); err != nil {
+		if k8sErrors.IsAlreadyExists(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to create namespace '%s': %w", name, err)
+	}
+	oktetoLog.Success("Namespace '%s' created", name)
+	return true, nil
+}
+
+// LabelCreatedNamespace sets the labels on a namespace created by okteto through the Okteto API and marks it as created by okteto.
+// The labels are patched, as users might not be allowed to update Okteto Namespaces. If the patch is forbidden the namespace is left
+// unlabeled, and it won't be deleted by 'okteto destroy --delete-namespace'
+func LabelCreatedNamespace(ctx context.Context, name string, labels map[string]string, c kubernetes.Interface) error {
+	nsLabels := map[string]string{CreatedByOktetoLabel: "true"}
+	for key, value := range labels {
+		nsLabels[key] = value
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": nsLabels,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := c.CoreV1().Namespaces().Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		if k8sErrors.IsForbidden(err) {
+			oktetoLog.Warning("You are not allowed to label the namespace '%s': it won't be deleted by 'okteto destroy --delete-namespace'", name)
+			return nil
+		}
+		return fmt.Errorf("failed to label namespace '%s': %w", name, err)
+	}
+	return nil
+}
+
+// DeleteVanillaNamespace deletes a namespace of a cluster not managed by Okteto
+func DeleteVanillaNamespace(ctx context.Context, name string, c kubernetes.Interface) error {
+	if err := c.CoreV1().Namespaces().Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+		if k8sErrors.IsNotFound(err) {
+			oktetoLog.Information("Namespace '%s' not found", name)
+			return nil
+		}
+		return fmt.Errorf("%w: %w", errFailedDeleteNamespace, err)
+	}
+	oktetoLog.Success("Namespace '%s' deleted", name)
+	return nil
+}
+
+// CheckNamespaceCreatedByOkteto refuses to delete namespaces that were not created by 'okteto deploy'
+func CheckNamespaceCreatedByOkteto(ctx context.Context, name string, c kubernetes.Interface) error {
+	ns, err := c.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get namespace '%s': %w", name, err)
+	}
+	if ns.Labels[CreatedByOktetoLabel] != "true" {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("refusing to delete namespace '%s': it wasn't created by 'okteto deploy'", name),
+			Hint: fmt.Sprintf("Only namespaces with the label '%s=true' are deleted by '--delete-namespace'. Run 'okteto namespace delete %s' to delete it", CreatedByOktetoLabel, name),
+		}
+	}
+	return nil
+}
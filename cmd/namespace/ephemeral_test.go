@@ -0,0 +1,174 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+func TestExpandNamespace(t *testing.T) {
+	t.Setenv("PR_NUMBER", "1234")
+	tests := []struct {
+		name        string
+		prefix      string
+		namespace   string
+		expected    string
+		expectedErr bool
+	}{
+		{
+			name:      "empty",
+			namespace: "",
+			expected:  "",
+		},
+		{
+			name:      "without variables",
+			namespace: "staging",
+			expected:  "staging",
+		},
+		{
+			name:      "template",
+			namespace: "pr-${PR_NUMBER}-app",
+			expected:  "pr-1234-app",
+		},
+		{
+			name:      "default value",
+			namespace: "pr-${MISSING_NUMBER:-0}-app",
+			expected:  "pr-0-app",
+		},
+		{
+			name:      "prefix",
+			prefix:    "pr-${PR_NUMBER}",
+			namespace: "app",
+			expected:  "pr-1234-app",
+		},
+		{
+			name:        "prefix without namespace",
+			prefix:      "pr-${PR_NUMBER}",
+			expectedErr: true,
+		},
+		{
+			name:        "invalid expanded namespace",
+			namespace:   "pr-${MISSING_NUMBER}-",
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ExpandNamespace(tt.prefix, tt.namespace)
+			if tt.expectedErr {
+				var uErr oktetoErrors.UserError
+				require.ErrorAs(t, err, &uErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExpandNamespaceLabels(t *testing.T) {
+	t.Setenv("PR_NUMBER", "1234")
+	labels, err := ExpandNamespaceLabels([]string{"pr=${PR_NUMBER}", "team=backend"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"pr": "1234", "team": "backend"}, labels)
+
+	_, err = ExpandNamespaceLabels([]string{"pr"})
+	require.Error(t, err)
+}
+
+func TestCreateVanillaNamespace(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset(&apiv1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "production"}})
+
+	created, err := CreateVanillaNamespace(ctx, "pr-1234-app", map[string]string{"pr": "1234"}, c)
+	require.NoError(t, err)
+	assert.True(t, created)
+	ns, err := c.CoreV1().Namespaces().Get(ctx, "pr-1234-app", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"pr": "1234", CreatedByOktetoLabel: "true"}, ns.Labels)
+
+	created, err = CreateVanillaNamespace(ctx, "production", map[string]string{"pr": "1234"}, c)
+	require.NoError(t, err)
+	assert.False(t, created)
+	ns, err = c.CoreV1().Namespaces().Get(ctx, "production", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, ns.Labels)
+}
+
+func TestLabelCreatedNamespace(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset(&apiv1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr-1234-app", Labels: map[string]string{"dev.okteto.com": "true"}},
+	})
+
+	err := LabelCreatedNamespace(ctx, "pr-1234-app", map[string]string{"pr": "1234"}, c)
+	require.NoError(t, err)
+
+	ns, err := c.CoreV1().Namespaces().Get(ctx, "pr-1234-app", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"dev.okteto.com":     "true",
+		"pr":                 "1234",
+		CreatedByOktetoLabel: "true",
+	}, ns.Labels)
+	require.NoError(t, CheckNamespaceCreatedByOkteto(ctx, "pr-1234-app", c))
+
+	require.Error(t, LabelCreatedNamespace(ctx, "missing", nil, c))
+
+	c.PrependReactor("patch", "namespaces", func(k8sTesting.Action) (bool, runtime.Object, error) {
+		return true, nil, k8sErrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "pr-1234-app", errors.New("denied"))
+	})
+	require.NoError(t, LabelCreatedNamespace(ctx, "pr-1234-app", nil, c))
+}
+
+func TestDeleteVanillaNamespace(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset(&apiv1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "pr-1234-app"}})
+
+	require.NoError(t, DeleteVanillaNamespace(ctx, "pr-1234-app", c))
+	_, err := c.CoreV1().Namespaces().Get(ctx, "pr-1234-app", metav1.GetOptions{})
+	assert.True(t, k8sErrors.IsNotFound(err))
+
+	require.NoError(t, DeleteVanillaNamespace(ctx, "missing", c))
+}
+
+func TestCheckNamespaceCreatedByOkteto(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset(
+		&apiv1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "created", Labels: map[string]string{CreatedByOktetoLabel: "true"}}},
+		&apiv1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "production"}},
+	)
+
+	require.NoError(t, CheckNamespaceCreatedByOkteto(ctx, "created", c))
+
+	err := CheckNamespaceCreatedByOkteto(ctx, "production", c)
+	var uErr oktetoErrors.UserError
+	require.ErrorAs(t, err, &uErr)
+	assert.Contains(t, uErr.E.Error(), "refusing to delete namespace 'production'")
+
+	require.Error(t, CheckNamespaceCreatedByOkteto(ctx, "missing", c))
+}
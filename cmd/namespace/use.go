@@ -21,7 +21,6 @@ import (
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/analytics"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
-	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/log/io"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/spf13/cobra"
@@ -158,14 +157,7 @@ func getNamespacesSelection(ctx context.Context) ([]utils.SelectorItem, error) {
 }
 
 func askForOktetoNamespace() (string, error) {
-	var namespace string
-	if err := oktetoLog.Question("Enter the namespace you want to use: "); err != nil {
-		return "", err
-	}
-	if _, err := fmt.Scanln(&namespace); err != nil {
-		return "", err
-	}
-	return namespace, nil
+	return utils.AskForInput("Enter the namespace you want to use: ")
 }
 
 func getInitialPosition(options []utils.SelectorItem) int {
@@ -80,7 +80,7 @@ okteto preview deploy --wait=false`,
 				return err
 			}
 
-			if !okteto.IsOkteto() {
+			if !okteto.GetCapabilities().SupportsPreviews {
 				return oktetoErrors.ErrContextIsNotOktetoCluster
 			}
 
@@ -71,7 +71,7 @@ okteto preview destroy --wait=false`,
 				return err
 			}
 
-			if !okteto.IsOkteto() {
+			if !okteto.GetCapabilities().SupportsPreviews {
 				return oktetoErrors.ErrContextIsNotOktetoCluster
 			}
 
@@ -51,7 +51,7 @@ func Endpoints(ctx context.Context) *cobra.Command {
 				return err
 			}
 
-			if !okteto.IsOkteto() {
+			if !okteto.GetCapabilities().SupportsPreviews {
 				return oktetoErrors.ErrContextIsNotOktetoCluster
 			}
 
@@ -81,7 +81,7 @@ func List(ctx context.Context) *cobra.Command {
 				return err
 			}
 
-			if !okteto.IsOkteto() {
+			if !okteto.GetCapabilities().SupportsPreviews {
 				return oktetoErrors.ErrContextIsNotOktetoCluster
 			}
 
@@ -38,7 +38,7 @@ func Sleep(ctx context.Context, at previewAnalyticsTracker) *cobra.Command {
 				return err
 			}
 
-			if !okteto.IsOkteto() {
+			if !okteto.GetCapabilities().SupportsPreviews {
 				return oktetoErrors.ErrContextIsNotOktetoCluster
 			}
 
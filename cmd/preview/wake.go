@@ -39,7 +39,7 @@ func Wake(ctx context.Context, at previewAnalyticsTracker) *cobra.Command {
 				return err
 			}
 
-			if !okteto.IsOkteto() {
+			if !okteto.GetCapabilities().SupportsPreviews {
 				return oktetoErrors.ErrContextIsNotOktetoCluster
 			}
 
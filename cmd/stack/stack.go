@@ -0,0 +1,316 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	stackCmd "github.com/okteto/okteto/pkg/cmd/stack"
+	"github.com/okteto/okteto/pkg/devenvironment"
+	"github.com/okteto/okteto/pkg/filesystem"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/log/io"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/validator"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+var (
+	errPersistAndReset   = errors.New("'--persist' and '--reset' can't be used together")
+	errNoServicesToScale = errors.New("at least one '<service>=<replicas>' argument is required")
+)
+
+const (
+	defaultScaleTimeout = 5 * time.Minute
+
+	defaultLogsTail = 50
+)
+
+// Options defines the options of the stack commands
+type Options struct {
+	Name         string
+	ManifestPath string
+	Namespace    string
+	K8sContext   string
+	Timeout      time.Duration
+	Wait         bool
+}
+
+// Stack manages the services of a Docker Compose stack deployed by okteto
+func Stack(ctx context.Context, k8sLogger *io.K8sLogger) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stack",
+		Short: "Manage the services of your Docker Compose stack",
+	}
+	cmd.AddCommand(Stop(ctx, k8sLogger))
+	cmd.AddCommand(Start(ctx, k8sLogger))
+	cmd.AddCommand(Scale(ctx, k8sLogger))
+	cmd.AddCommand(Logs(ctx, k8sLogger))
+	cmd.AddCommand(Validate(ctx, k8sLogger))
+	return cmd
+}
+
+// Stop scales the services of a stack to zero without destroying them
+func Stop(ctx context.Context, k8sLogger *io.K8sLogger) *cobra.Command {
+	options := &Options{}
+	cmd := &cobra.Command{
+		Use:   "stop [service...]",
+		Short: "Stop the services of your Docker Compose stack without destroying them",
+		Long: `Stop the services of your Docker Compose stack without destroying them.
+
+The deployments and statefulsets of the services are scaled to zero, keeping their volumes.
+Run 'okteto stack start' to scale them back to the replicas they had before being stopped.`,
+		Example: `  okteto stack stop
+  okteto stack stop elasticsearch --wait`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := options.init(ctx, k8sLogger)
+			if err != nil {
+				return err
+			}
+			return stackCmd.Stop(ctx, options.toScaleOptions(args), c)
+		},
+	}
+	options.addFlags(cmd, "wait for the pods of the services to be terminated")
+	return cmd
+}
+
+// Start scales the stopped services of a stack back to their replicas
+func Start(ctx context.Context, k8sLogger *io.K8sLogger) *cobra.Command {
+	options := &Options{}
+	cmd := &cobra.Command{
+		Use:   "start [service...]",
+		Short: "Start the services of your Docker Compose stack stopped by 'okteto stack stop'",
+		Example: `  okteto stack start
+  okteto stack start elasticsearch --wait`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := options.init(ctx, k8sLogger)
+			if err != nil {
+				return err
+			}
+			return stackCmd.Start(ctx, options.toScaleOptions(args), c)
+		},
+	}
+	options.addFlags(cmd, "wait for the pods of the services to be ready")
+	return cmd
+}
+
+// Scale sets the replicas of the services of a stack
+func Scale(ctx context.Context, k8sLogger *io.K8sLogger) *cobra.Command {
+	options := &Options{}
+	var persist, reset bool
+	cmd := &cobra.Command{
+		Use:   "scale <service>=<replicas>...",
+		Short: "Scale the services of your Docker Compose stack",
+		Long: `Scale the services of your Docker Compose stack.
+
+The replicas are kept until the next deploy, which applies the replicas of your compose file.
+Use --persist to keep them on the next deploys too, until they are reset with --reset.`,
+		Example: `  okteto stack scale api=3 worker=0
+  okteto stack scale api=3 --persist
+  okteto stack scale api --reset`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var replicas map[string]int32
+			if reset {
+				if persist {
+					return errPersistAndReset
+				}
+			} else {
+				if len(args) == 0 {
+					return errNoServicesToScale
+				}
+				var err error
+				replicas, err = stackCmd.ParseScaleArgs(args)
+				if err != nil {
+					return err
+				}
+			}
+
+			c, err := options.init(ctx, k8sLogger)
+			if err != nil {
+				return err
+			}
+			scaleOptions := options.toScaleOptions(nil)
+			scaleOptions.Replicas = replicas
+			scaleOptions.Persist = persist
+			scaleOptions.Reset = reset
+			if reset {
+				scaleOptions.Services = args
+			}
+			return stackCmd.Scale(ctx, scaleOptions, c)
+		},
+	}
+	options.addFlags(cmd, "wait for the pods of the services to be ready")
+	cmd.Flags().BoolVar(&persist, "persist", false, "keep the replicas on the next deploys instead of the replicas of your compose file")
+	cmd.Flags().BoolVar(&reset, "reset", false, "remove the replicas kept by --persist for the given services, or for all of them if none is given")
+	return cmd
+}
+
+// Logs shows the logs of the services of a stack
+func Logs(ctx context.Context, k8sLogger *io.K8sLogger) *cobra.Command {
+	options := &Options{}
+	var tail int64
+	var previous bool
+	cmd := &cobra.Command{
+		Use:   "logs [service...]",
+		Short: "Show the logs of the services of your Docker Compose stack",
+		Long: `Show the logs of the services of your Docker Compose stack.
+
+The logs are fetched from the unhealthiest pod of each service.
+Use --previous to diagnose crashing services: it shows the logs of the container that crashed and the events of its pod.`,
+		Example: `  okteto stack logs
+  okteto stack logs api --previous`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := options.init(ctx, k8sLogger)
+			if err != nil {
+				return err
+			}
+			return stackCmd.Logs(ctx, &stackCmd.LogsOptions{
+				Name:      options.Name,
+				Namespace: options.Namespace,
+				Services:  args,
+				Tail:      tail,
+				Previous:  previous,
+			}, c)
+		},
+	}
+	cmd.Flags().StringVar(&options.Name, "name", "", "the name of the Development Environment")
+	cmd.Flags().StringVarP(&options.ManifestPath, "file", "f", "", "the path to the Okteto Manifest")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "overwrite the current Okteto Namespace")
+	cmd.Flags().StringVarP(&options.K8sContext, "context", "c", "", "overwrite the current Okteto Context")
+	cmd.Flags().Int64Var(&tail, "tail", defaultLogsTail, "the number of lines from the end of the logs to show")
+	cmd.Flags().BoolVarP(&previous, "previous", "p", false, "show the logs of the previous container of crashing services and the events of their pods")
+	return cmd
+}
+
+// Validate checks a stack and, with --against-cluster, the resources of the cluster it references
+func Validate(ctx context.Context, k8sLogger *io.K8sLogger) *cobra.Command {
+	options := &Options{}
+	var files []string
+	var againstCluster bool
+	var strict bool
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate your Docker Compose stack",
+		Long: `Validate your Docker Compose stack.
+
+Use --against-cluster to check that the storage classes, service accounts and priority classes referenced by the stack exist in the cluster.
+The command fails if any of them is missing.
+
+Use --strict to fail on the fields that are not understood by this version of okteto instead of ignoring them.`,
+		Example: `  okteto stack validate
+  okteto stack validate -f docker-compose.yml --against-cluster`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := model.LoadStack("", files, true, afero.NewOsFs())
+			if err != nil {
+				return err
+			}
+			if err := model.CheckNewerVersionFields(s.Warnings.NewerVersionFields, strict); err != nil {
+				return err
+			}
+			stackCmd.DisplayNewerVersionFieldsWarnings(s.Warnings.NewerVersionFields)
+			if !againstCluster {
+				oktetoLog.Success("The stack is valid")
+				oktetoLog.Information("The resources of the cluster referenced by the stack were not checked. Run 'okteto stack validate --against-cluster' to check them")
+				return nil
+			}
+
+			if err := contextCMD.NewContextCommand().Run(ctx, &contextCMD.Options{Namespace: options.Namespace, Context: options.K8sContext, Show: true}); err != nil {
+				return err
+			}
+			if options.Namespace == "" {
+				options.Namespace = okteto.GetContext().Namespace
+			}
+			c, _, err := okteto.NewK8sClientProviderWithLogger(k8sLogger).Provide(okteto.GetContext().Cfg)
+			if err != nil {
+				return err
+			}
+			return stackCmd.ValidateAgainstCluster(ctx, s, options.Namespace, c)
+		},
+	}
+	cmd.Flags().StringArrayVarP(&files, "file", "f", []string{}, "the path to the Docker Compose files")
+	cmd.Flags().BoolVar(&againstCluster, "against-cluster", false, "check the resources of the cluster referenced by the stack")
+	cmd.Flags().BoolVar(&strict, "strict", false, "fail on fields that are not understood by this version of okteto")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "overwrite the current Okteto Namespace")
+	cmd.Flags().StringVarP(&options.K8sContext, "context", "c", "", "overwrite the current Okteto Context")
+	return cmd
+}
+
+func (o *Options) addFlags(cmd *cobra.Command, waitUsage string) {
+	cmd.Flags().StringVar(&o.Name, "name", "", "the name of the Development Environment")
+	cmd.Flags().StringVarP(&o.ManifestPath, "file", "f", "", "the path to the Okteto Manifest")
+	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "", "overwrite the current Okteto Namespace")
+	cmd.Flags().StringVarP(&o.K8sContext, "context", "c", "", "overwrite the current Okteto Context")
+	cmd.Flags().BoolVarP(&o.Wait, "wait", "w", false, waitUsage)
+	cmd.Flags().DurationVarP(&o.Timeout, "timeout", "t", defaultScaleTimeout, "when using --wait, the maximum time to wait for the pods of the services")
+}
+
+func (o *Options) toScaleOptions(services []string) *stackCmd.ScaleOptions {
+	return &stackCmd.ScaleOptions{
+		Name:      o.Name,
+		Namespace: o.Namespace,
+		Services:  services,
+		Wait:      o.Wait,
+		Timeout:   o.Timeout,
+	}
+}
+
+// init loads the okteto context and resolves the name of the stack
+func (o *Options) init(ctx context.Context, k8sLogger *io.K8sLogger) (kubernetes.Interface, error) {
+	fs := afero.NewOsFs()
+	if o.ManifestPath != "" {
+		workdir := filesystem.GetWorkdirFromManifestPath(o.ManifestPath)
+		if err := os.Chdir(workdir); err != nil {
+			return nil, err
+		}
+		o.ManifestPath = filesystem.GetManifestPathFromWorkdir(o.ManifestPath, workdir)
+		if err := validator.FileArgumentIsNotDir(fs, o.ManifestPath); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := contextCMD.NewContextCommand().Run(ctx, &contextCMD.Options{Namespace: o.Namespace, Context: o.K8sContext, Show: true}); err != nil {
+		return nil, err
+	}
+	if o.Namespace == "" {
+		o.Namespace = okteto.GetContext().Namespace
+	}
+
+	c, _, err := okteto.NewK8sClientProviderWithLogger(k8sLogger).Provide(okteto.GetContext().Cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.Name != "" {
+		return c, nil
+	}
+	manifest, err := model.GetManifestV2(o.ManifestPath, fs)
+	if err == nil && manifest.Name != "" {
+		o.Name = manifest.Name
+		return c, nil
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the current working directory: %w", err)
+	}
+	o.Name = devenvironment.NewNameInferer(c).InferName(ctx, cwd, o.Namespace, o.ManifestPath)
+	return c, nil
+}
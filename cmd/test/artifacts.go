@@ -0,0 +1,89 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
+)
+
+// extractArtifact extracts the tar stream of an artifact of the test container into its local destination, relative to root.
+// The entries of the stream are relative to the artifact path, as generated by 'tar cf - <path>'
+func extractArtifact(fs afero.Fs, r io.Reader, artifact model.Artifact, root string) error {
+	// tar removes the leading slash of absolute paths
+	remote := strings.TrimPrefix(path.Clean(artifact.Path), "/")
+	destination := filepath.Join(root, artifact.Destination)
+
+	tr := tar.NewReader(r)
+	found := false
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read artifact '%s': %w", artifact.Path, err)
+		}
+
+		name := strings.TrimPrefix(path.Clean(header.Name), "/")
+		if name != remote && !strings.HasPrefix(name, remote+"/") {
+			continue
+		}
+		target := filepath.Join(destination, filepath.FromSlash(strings.TrimPrefix(name, remote)))
+		if target != destination && !strings.HasPrefix(target, destination+string(filepath.Separator)) {
+			return fmt.Errorf("artifact '%s' contains an invalid path '%s'", artifact.Path, header.Name)
+		}
+		found = true
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := fs.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := writeArtifactFile(fs, target, tr, os.FileMode(header.Mode).Perm()); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("artifact '%s' not found in the test container", artifact.Path)
+	}
+	return nil
+}
+
+func writeArtifactFile(fs afero.Fs, target string, r io.Reader, mode os.FileMode) error {
+	if err := fs.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	f, err := fs.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
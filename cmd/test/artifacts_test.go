@@ -0,0 +1,123 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"archive/tar"
+	"bytes"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tarEntry struct {
+	name    string
+	content string
+	dir     bool
+}
+
+func buildTar(t *testing.T, entries []tarEntry) *bytes.Buffer {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	for _, e := range entries {
+		h := &tar.Header{Name: e.name, Mode: 0644, Size: int64(len(e.content)), Typeflag: tar.TypeReg}
+		if e.dir {
+			h = &tar.Header{Name: e.name, Mode: 0755, Typeflag: tar.TypeDir}
+		}
+		require.NoError(t, tw.WriteHeader(h))
+		if !e.dir {
+			_, err := tw.Write([]byte(e.content))
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tw.Close())
+	return buf
+}
+
+func TestExtractArtifact(t *testing.T) {
+	tests := []struct {
+		expected    map[string]string
+		name        string
+		expectedErr string
+		artifact    model.Artifact
+		entries     []tarEntry
+	}{
+		{
+			name:     "file",
+			artifact: model.Artifact{Path: "coverage.out", Destination: "coverage.out"},
+			entries:  []tarEntry{{name: "coverage.out", content: "mode: set"}},
+			expected: map[string]string{"/src/coverage.out": "mode: set"},
+		},
+		{
+			name:     "file renamed",
+			artifact: model.Artifact{Path: "coverage.out", Destination: "reports/unit.out"},
+			entries:  []tarEntry{{name: "coverage.out", content: "mode: set"}},
+			expected: map[string]string{"/src/reports/unit.out": "mode: set"},
+		},
+		{
+			name:     "folder",
+			artifact: model.Artifact{Path: "reports/", Destination: "out"},
+			entries: []tarEntry{
+				{name: "reports/", dir: true},
+				{name: "reports/junit.xml", content: "<xml/>"},
+				{name: "reports/html/index.html", content: "<html/>"},
+			},
+			expected: map[string]string{
+				"/src/out/junit.xml":       "<xml/>",
+				"/src/out/html/index.html": "<html/>",
+			},
+		},
+		{
+			name:     "absolute path",
+			artifact: model.Artifact{Path: "/app/coverage.out", Destination: "coverage.out"},
+			entries:  []tarEntry{{name: "app/coverage.out", content: "mode: set"}},
+			expected: map[string]string{"/src/coverage.out": "mode: set"},
+		},
+		{
+			name:        "not found",
+			artifact:    model.Artifact{Path: "coverage.out", Destination: "coverage.out"},
+			entries:     []tarEntry{{name: "other.out", content: "mode: set"}},
+			expectedErr: "artifact 'coverage.out' not found in the test container",
+		},
+		{
+			name:        "path traversal",
+			artifact:    model.Artifact{Path: "reports", Destination: "out"},
+			entries:     []tarEntry{{name: "reports/../../../etc/passwd", content: "root"}},
+			expectedErr: "artifact 'reports' not found in the test container",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			err := extractArtifact(fs, buildTar(t, tt.entries), tt.artifact, "/src")
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				exists, existsErr := afero.Exists(fs, "/etc/passwd")
+				require.NoError(t, existsErr)
+				assert.False(t, exists)
+				return
+			}
+			require.NoError(t, err)
+			for path, content := range tt.expected {
+				b, err := afero.ReadFile(fs, path)
+				require.NoError(t, err)
+				assert.Equal(t, content, string(b))
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"os"
 	"os/signal"
 	"path"
+	"sync"
 	"time"
 
 	buildv2 "github.com/okteto/okteto/cmd/build/v2"
@@ -65,6 +66,8 @@ type Options struct {
 	Timeout          time.Duration
 	Deploy           bool
 	NoCache          bool
+	Job              bool
+	Parallel         bool
 }
 
 type builder interface {
@@ -88,6 +91,13 @@ func Test(ctx context.Context, ioCtrl *io.Controller, k8sLogger *io.K8sLogger, a
 				return err
 			}
 
+			if options.Parallel && !options.Job {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("the flag '--parallel' is only supported with '--job'"),
+					Hint: "Run 'okteto test --job --parallel' to run your Test Containers as Jobs in parallel",
+				}
+			}
+
 			stop := make(chan os.Signal, 1)
 			signal.Notify(stop, os.Interrupt)
 			exit := make(chan error, 1)
@@ -132,6 +142,8 @@ func Test(ctx context.Context, ioCtrl *io.Controller, k8sLogger *io.K8sLogger, a
 	cmd.Flags().StringVar(&options.Name, "name", "", "the name of the Development Environment")
 	cmd.Flags().BoolVar(&options.Deploy, "deploy", false, "Force execution of the commands in the 'deploy' section")
 	cmd.Flags().BoolVar(&options.NoCache, "no-cache", false, "by default, the caches of a Test Container are reused between executions")
+	cmd.Flags().BoolVar(&options.Job, "job", false, "run the Test Containers as Kubernetes Jobs in your namespace instead of using Remote Execution")
+	cmd.Flags().BoolVar(&options.Parallel, "parallel", false, "run the Test Containers concurrently once their dependencies succeed. Requires '--job'")
 
 	return cmd
 }
@@ -336,6 +348,14 @@ func doRun(ctx context.Context, servicesToTest []string, options *Options, ioCtr
 		}
 	}(testAnalytics)
 
+	if options.Job {
+		if err := runJobs(ctx, manifest, testServices, options, builder.GetBuildEnvVars(), cwd); err != nil {
+			return metadata, err
+		}
+		metadata.Success = true
+		return metadata, nil
+	}
+
 	for _, name := range testServices {
 		test := manifest.Test[name]
 
@@ -443,6 +463,52 @@ func doRun(ctx context.Context, servicesToTest []string, options *Options, ioCtr
 	return metadata, nil
 }
 
+// runJobs runs the tests as Kubernetes Jobs in the current namespace
+func runJobs(ctx context.Context, manifest *model.Manifest, testServices []string, options *Options, buildEnvVars map[string]string, cwd string) error {
+	envs, err := getJobEnvVars(buildEnvVars, options.Variables)
+	if err != nil {
+		return err
+	}
+
+	c, restConfig, err := okteto.GetK8sClient()
+	if err != nil {
+		return err
+	}
+
+	runner := &jobRunner{
+		client:   c,
+		executor: k8sPodExecutor{client: c, restConfig: restConfig},
+		fs:       afero.NewOsFs(),
+		opts: jobOptions{
+			Envs:          envs,
+			ManifestName:  manifest.Name,
+			Namespace:     okteto.GetContext().Namespace,
+			ArtifactsRoot: cwd,
+			Timeout:       options.Timeout,
+		},
+	}
+
+	var outLock sync.Mutex
+	return runTests(ctx, testServices, manifest.Test, options.Parallel, func(ctx context.Context, name string) error {
+		prefix := ""
+		if options.Parallel {
+			prefix = fmt.Sprintf("[%s] ", name)
+		}
+		out := io.NewPrefixWriter(oktetoLog.GetOutputWriter(), &outLock, prefix)
+
+		oktetoLog.Information("Executing test container '%s' as a job", name)
+		err := runner.Run(ctx, name, manifest.Test[name], out)
+		if flushErr := out.Flush(); flushErr != nil {
+			oktetoLog.Infof("failed to write the logs of test container '%s': %s", name, flushErr)
+		}
+		if err != nil {
+			return err
+		}
+		oktetoLog.Success("Test container '%s' passed", name)
+		return nil
+	})
+}
+
 func doBuild(ctx context.Context, manifest *model.Manifest, svcs []string, builder builder, ioCtrl *io.Controller) (bool, error) {
 	// make sure the images used for the tests exist. If they don't build them
 	svcsToBuild := []string{}
@@ -0,0 +1,439 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	deployCMD "github.com/okteto/okteto/cmd/deploy"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/deployable"
+	"github.com/okteto/okteto/pkg/env"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/format"
+	"github.com/okteto/okteto/pkg/k8s/exec"
+	"github.com/okteto/okteto/pkg/k8s/jobs"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/afero"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// testNameLabel is the label with the name of the test run by a job
+	testNameLabel = "dev.okteto.com/test"
+
+	// testContainerName is the name of the container running the test commands
+	testContainerName = "test"
+
+	// testStatusVolume is the volume used to coordinate the artifact copy with the test container
+	testStatusVolume = "okteto-test-status"
+	testStatusPath   = "/okteto/test"
+	exitCodeFile     = testStatusPath + "/exit-code"
+	artifactsDone    = testStatusPath + "/artifacts-copied"
+)
+
+var jobPollInterval = time.Second
+
+// podExecutor runs commands in a running container
+type podExecutor interface {
+	Exec(ctx context.Context, namespace, pod string, command []string, stdout io.Writer) error
+}
+
+type k8sPodExecutor struct {
+	client     kubernetes.Interface
+	restConfig *rest.Config
+}
+
+// Exec runs the command in the test container of the pod
+func (e k8sPodExecutor) Exec(ctx context.Context, namespace, pod string, command []string, stdout io.Writer) error {
+	stderr := &bytes.Buffer{}
+	if err := exec.Exec(ctx, e.client, e.restConfig, namespace, pod, testContainerName, false, nil, stdout, stderr, command); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// jobOptions are the settings shared by all the tests run as jobs
+type jobOptions struct {
+	// Envs are the environment variables injected in all the test containers
+	Envs map[string]string
+	// ManifestName is the name of the development environment
+	ManifestName string
+	// Namespace is the namespace where the jobs are created
+	Namespace string
+	// ArtifactsRoot is the local folder where the artifacts are copied, joined with the context of each test
+	ArtifactsRoot string
+	// Timeout is the maximum duration of a test container
+	Timeout time.Duration
+}
+
+// jobRunner runs test containers as Kubernetes Jobs in the namespace
+type jobRunner struct {
+	client   kubernetes.Interface
+	executor podExecutor
+	fs       afero.Fs
+	opts     jobOptions
+}
+
+// Run runs the test as a job, streams its logs to out and copies its artifacts back once the commands finish.
+// The error mirrors the exit code of the test container
+func (r *jobRunner) Run(ctx context.Context, name string, test *model.Test, out io.Writer) error {
+	job, secret := translateTestJob(name, test, r.opts)
+
+	if err := r.cleanUp(ctx, job.Name, secret.Name); err != nil {
+		return err
+	}
+	if _, err := r.client.CoreV1().Secrets(r.opts.Namespace).Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to create the environment of test container '%s': %w", name, err)
+	}
+	defer func() {
+		// the test resources are deleted even if the command is canceled
+		if err := r.cleanUp(context.Background(), job.Name, secret.Name); err != nil {
+			oktetoLog.Infof("failed to delete test container '%s': %s", name, err)
+		}
+	}()
+	if err := jobs.Create(ctx, job, r.client); err != nil {
+		return fmt.Errorf("failed to create test container '%s': %w", name, err)
+	}
+
+	pod, err := r.waitForPod(ctx, job)
+	if err != nil {
+		return err
+	}
+
+	logsDone := make(chan struct{})
+	go func() {
+		defer close(logsDone)
+		if err := r.streamLogs(ctx, pod, out); err != nil {
+			oktetoLog.Infof("failed to stream logs of test container '%s': %s", name, err)
+		}
+	}()
+
+	if len(test.Artifacts) > 0 {
+		if err := r.retrieveArtifacts(ctx, pod, test.Artifacts, filepath.Join(r.opts.ArtifactsRoot, test.Context)); err != nil {
+			return err
+		}
+	}
+
+	code, err := r.waitForCompletion(ctx, job, pod)
+	if err != nil {
+		return err
+	}
+	<-logsDone
+
+	if code != 0 {
+		return oktetoErrors.ExitCodeError{
+			E:    fmt.Errorf("test container '%s' failed with exit code %d", name, code),
+			Code: code,
+		}
+	}
+	return nil
+}
+
+func (r *jobRunner) cleanUp(ctx context.Context, jobName, secretName string) error {
+	if err := jobs.Destroy(ctx, jobName, r.opts.Namespace, r.client); err != nil {
+		return err
+	}
+	err := r.client.CoreV1().Secrets(r.opts.Namespace).Delete(ctx, secretName, metav1.DeleteOptions{})
+	if err != nil && !oktetoErrors.IsNotFound(err) {
+		return fmt.Errorf("error deleting kubernetes secret: %w", err)
+	}
+	return nil
+}
+
+// waitForPod waits until the pod of the job is scheduled and its container started
+func (r *jobRunner) waitForPod(ctx context.Context, job *batchv1.Job) (string, error) {
+	selector := fmt.Sprintf("%s=%s", testNameLabel, job.Spec.Template.Labels[testNameLabel])
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+	for {
+		podList, err := r.client.CoreV1().Pods(r.opts.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return "", fmt.Errorf("failed to get the pod of test container '%s': %w", job.Name, err)
+		}
+		for _, pod := range podList.Items {
+			if pod.Status.Phase != apiv1.PodPending {
+				return pod.Name, nil
+			}
+			if err := getPendingPodError(&pod); err != nil {
+				return "", err
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+}
+
+// getPendingPodError returns an error if the test container can't start, e.g. because its image can't be pulled
+func getPendingPodError(pod *apiv1.Pod) error {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Waiting == nil {
+			continue
+		}
+		switch status.State.Waiting.Reason {
+		case "ErrImagePull", "ImagePullBackOff", "InvalidImageName", "CreateContainerConfigError":
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("test container can't start: %s", status.State.Waiting.Message),
+				Hint: "Please verify the specified image is accessible",
+			}
+		}
+	}
+	return nil
+}
+
+func (r *jobRunner) streamLogs(ctx context.Context, pod string, out io.Writer) error {
+	stream, err := r.client.CoreV1().Pods(r.opts.Namespace).GetLogs(pod, &apiv1.PodLogOptions{
+		Container: testContainerName,
+		Follow:    true,
+	}).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	_, err = io.Copy(out, stream)
+	return err
+}
+
+// retrieveArtifacts waits for the test commands to finish, copies the artifacts and lets the test container exit
+func (r *jobRunner) retrieveArtifacts(ctx context.Context, pod string, artifacts []model.Artifact, root string) error {
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+	for {
+		err := r.executor.Exec(ctx, r.opts.Namespace, pod, []string{"cat", exitCodeFile}, io.Discard)
+		if err == nil {
+			break
+		}
+		oktetoLog.Infof("test commands are still running: %s", err)
+		if p, err := r.client.CoreV1().Pods(r.opts.Namespace).Get(ctx, pod, metav1.GetOptions{}); err == nil {
+			if p.Status.Phase == apiv1.PodSucceeded || p.Status.Phase == apiv1.PodFailed {
+				oktetoLog.Infof("test container finished before copying its artifacts")
+				return nil
+			}
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	for _, artifact := range artifacts {
+		reader, writer := io.Pipe()
+		go func() {
+			err := r.executor.Exec(ctx, r.opts.Namespace, pod, []string{"tar", "cf", "-", artifact.Path}, writer)
+			writer.CloseWithError(err)
+		}()
+		if err := extractArtifact(r.fs, reader, artifact, root); err != nil {
+			oktetoLog.Warning("Artifact '%s' couldn't be copied: %s", artifact.Path, err)
+		}
+		reader.Close()
+	}
+
+	return r.executor.Exec(ctx, r.opts.Namespace, pod, []string{"touch", artifactsDone}, io.Discard)
+}
+
+// waitForCompletion waits for the job to finish and returns the exit code of the test container
+func (r *jobRunner) waitForCompletion(ctx context.Context, job *batchv1.Job, pod string) (int, error) {
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+	for {
+		j, err := r.client.BatchV1().Jobs(r.opts.Namespace).Get(ctx, job.Name, metav1.GetOptions{})
+		if err != nil {
+			return 0, fmt.Errorf("failed to get test container '%s': %w", job.Name, err)
+		}
+		if j.Status.Succeeded > 0 {
+			return 0, nil
+		}
+		if j.Status.Failed > 0 {
+			return r.getExitCode(ctx, j, pod)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+}
+
+func (r *jobRunner) getExitCode(ctx context.Context, job *batchv1.Job, pod string) (int, error) {
+	p, err := r.client.CoreV1().Pods(r.opts.Namespace).Get(ctx, pod, metav1.GetOptions{})
+	if err == nil {
+		for _, status := range p.Status.ContainerStatuses {
+			if status.Name == testContainerName && status.State.Terminated != nil && status.State.Terminated.ExitCode != 0 {
+				return int(status.State.Terminated.ExitCode), nil
+			}
+		}
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Reason == "DeadlineExceeded" {
+			return 0, oktetoErrors.UserError{
+				E:    fmt.Errorf("test container '%s' didn't finish in %s", job.Name, r.opts.Timeout),
+				Hint: "Increase the timeout with the '--timeout' flag",
+			}
+		}
+	}
+	return 1, nil
+}
+
+// translateTestJob returns the job that runs the test and the secret with its environment variables
+func translateTestJob(name string, test *model.Test, opts jobOptions) (*batchv1.Job, *apiv1.Secret) {
+	jobName := format.ResourceK8sMetaString(fmt.Sprintf("okteto-test-%s-%s", opts.ManifestName, name))
+	labels := map[string]string{
+		testNameLabel: jobName,
+	}
+
+	secret := &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-env", jobName),
+			Namespace: opts.Namespace,
+			Labels:    labels,
+		},
+		Type:       apiv1.SecretTypeOpaque,
+		StringData: map[string]string{},
+	}
+	for k, v := range opts.Envs {
+		secret.StringData[k] = v
+	}
+	// the environment of the test takes precedence over the build and context variables
+	for _, v := range test.Environment {
+		secret.StringData[v.Name] = v.Value
+	}
+
+	container := apiv1.Container{
+		Name:    testContainerName,
+		Image:   test.Image,
+		Command: []string{"sh", "-c", getTestScript(test)},
+		EnvFrom: []apiv1.EnvFromSource{
+			{
+				SecretRef: &apiv1.SecretEnvSource{
+					LocalObjectReference: apiv1.LocalObjectReference{Name: secret.Name},
+				},
+			},
+		},
+	}
+
+	podSpec := apiv1.PodSpec{
+		RestartPolicy: apiv1.RestartPolicyNever,
+		Containers:    []apiv1.Container{container},
+	}
+	for _, h := range test.Hosts {
+		podSpec.HostAliases = append(podSpec.HostAliases, apiv1.HostAlias{IP: h.IP, Hostnames: []string{h.Hostname}})
+	}
+	if len(test.Artifacts) > 0 {
+		podSpec.Volumes = []apiv1.Volume{
+			{
+				Name:         testStatusVolume,
+				VolumeSource: apiv1.VolumeSource{EmptyDir: &apiv1.EmptyDirVolumeSource{}},
+			},
+		}
+		podSpec.Containers[0].VolumeMounts = []apiv1.VolumeMount{
+			{Name: testStatusVolume, MountPath: testStatusPath},
+		}
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: opts.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To(int32(0)),
+			Template: apiv1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+	if opts.Timeout > 0 {
+		job.Spec.ActiveDeadlineSeconds = ptr.To(int64(opts.Timeout.Seconds()))
+	}
+	return job, secret
+}
+
+// getTestScript returns the script that runs the test commands, stopping at the first failure.
+// If the test defines artifacts, the script saves the exit code and waits for the artifacts to be copied before exiting
+func getTestScript(test *model.Test) string {
+	commands := make([]string, 0, len(test.Commands))
+	for _, c := range test.Commands {
+		commands = append(commands, c.Command)
+	}
+	script := fmt.Sprintf("set -e\n%s", strings.Join(commands, "\n"))
+	if len(test.Artifacts) == 0 {
+		return script
+	}
+	return fmt.Sprintf(`(
+%s
+)
+code=$?
+echo $code > %s
+while [ ! -f %s ]; do sleep 1; done
+exit $code`, script, exitCodeFile, artifactsDone)
+}
+
+// getJobEnvVars returns the environment variables injected in the test containers run as jobs:
+// the build variables, the variables of the Okteto Context and the ones set with '--var'
+func getJobEnvVars(buildEnvVars map[string]string, variables []string) (map[string]string, error) {
+	envs := map[string]string{}
+	for k, v := range buildEnvVars {
+		envs[k] = v
+	}
+	for k, v := range deployCMD.GetDependencyEnvVars(os.Environ) {
+		envs[k] = v
+	}
+	for k, v := range deployable.GetGatewayEnvironment() {
+		envs[k] = v
+	}
+	envs[model.OktetoContextEnvVar] = okteto.GetContext().Name
+	envs[model.OktetoNamespaceEnvVar] = okteto.GetContext().Namespace
+	envs[model.OktetoTokenEnvVar] = okteto.GetContext().Token
+	envs[model.OktetoRegistryURLEnvVar] = okteto.GetContext().Registry
+	envs[constants.OktetoGitCommitEnvVar] = os.Getenv(constants.OktetoGitCommitEnvVar)
+	envs[constants.OktetoGitBranchEnvVar] = os.Getenv(constants.OktetoGitBranchEnvVar)
+	envs[constants.OktetoIsPreviewEnvVar] = os.Getenv(constants.OktetoIsPreviewEnvVar)
+	envs[constants.CIEnvVar] = "true"
+	if val, ok := os.LookupEnv(constants.CIEnvVar); ok {
+		envs[constants.CIEnvVar] = val
+	}
+
+	vars, err := env.Parse(variables)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range vars {
+		envs[v.Name] = v.Value
+	}
+	return envs, nil
+}
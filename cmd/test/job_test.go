@@ -0,0 +1,278 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/env"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+)
+
+func TestTranslateTestJob(t *testing.T) {
+	test := &model.Test{
+		Image: "okteto/golang:1",
+		Commands: []model.TestCommand{
+			{Command: "make unit"},
+			{Command: "make integration"},
+		},
+		Environment: env.Environment{
+			{Name: "CI", Value: "false"},
+			{Name: "DEBUG", Value: "1"},
+		},
+		Hosts: []model.Host{{Hostname: "db.local", IP: "10.0.0.1"}},
+	}
+	opts := jobOptions{
+		Envs:         map[string]string{"CI": "true", "OKTETO_NAMESPACE": "ns"},
+		ManifestName: "movies",
+		Namespace:    "ns",
+		Timeout:      5 * time.Minute,
+	}
+
+	job, secret := translateTestJob("unit", test, opts)
+
+	labels := map[string]string{testNameLabel: "okteto-test-movies-unit"}
+	assert.Equal(t, &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "okteto-test-movies-unit-env", Namespace: "ns", Labels: labels},
+		Type:       apiv1.SecretTypeOpaque,
+		StringData: map[string]string{"CI": "false", "DEBUG": "1", "OKTETO_NAMESPACE": "ns"},
+	}, secret)
+
+	assert.Equal(t, &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: "okteto-test-movies-unit", Namespace: "ns", Labels: labels},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          ptr.To(int32(0)),
+			ActiveDeadlineSeconds: ptr.To(int64(300)),
+			Template: apiv1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: apiv1.PodSpec{
+					RestartPolicy: apiv1.RestartPolicyNever,
+					HostAliases:   []apiv1.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"db.local"}}},
+					Containers: []apiv1.Container{
+						{
+							Name:    testContainerName,
+							Image:   "okteto/golang:1",
+							Command: []string{"sh", "-c", "set -e\nmake unit\nmake integration"},
+							EnvFrom: []apiv1.EnvFromSource{
+								{SecretRef: &apiv1.SecretEnvSource{LocalObjectReference: apiv1.LocalObjectReference{Name: "okteto-test-movies-unit-env"}}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, job)
+}
+
+func TestTranslateTestJobWithArtifacts(t *testing.T) {
+	test := &model.Test{
+		Image:     "okteto/golang:1",
+		Commands:  []model.TestCommand{{Command: "make coverage"}},
+		Artifacts: []model.Artifact{{Path: "coverage.out", Destination: "coverage.out"}},
+	}
+
+	job, _ := translateTestJob("unit", test, jobOptions{ManifestName: "movies", Namespace: "ns"})
+
+	spec := job.Spec.Template.Spec
+	assert.Nil(t, job.Spec.ActiveDeadlineSeconds)
+	assert.Equal(t, []apiv1.Volume{
+		{Name: testStatusVolume, VolumeSource: apiv1.VolumeSource{EmptyDir: &apiv1.EmptyDirVolumeSource{}}},
+	}, spec.Volumes)
+	assert.Equal(t, []apiv1.VolumeMount{{Name: testStatusVolume, MountPath: testStatusPath}}, spec.Containers[0].VolumeMounts)
+	assert.Equal(t, []string{"sh", "-c", `(
+set -e
+make coverage
+)
+code=$?
+echo $code > /okteto/test/exit-code
+while [ ! -f /okteto/test/artifacts-copied ]; do sleep 1; done
+exit $code`}, spec.Containers[0].Command)
+}
+
+type fakeExecutor struct {
+	files    map[string]map[string]string
+	commands [][]string
+	lock     sync.Mutex
+}
+
+func (f *fakeExecutor) Exec(_ context.Context, _, _ string, command []string, stdout io.Writer) error {
+	f.lock.Lock()
+	f.commands = append(f.commands, command)
+	f.lock.Unlock()
+	if command[0] != "tar" {
+		return nil
+	}
+	tw := tar.NewWriter(stdout)
+	for name, content := range f.files[command[3]] {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			return err
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// newFakeJobClient returns a client that completes the test jobs as soon as they are created, with the given exit code
+func newFakeJobClient(exitCode int32) *fake.Clientset {
+	c := fake.NewSimpleClientset()
+	c.PrependReactor("create", "jobs", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		job := action.(k8sTesting.CreateAction).GetObject().(*batchv1.Job)
+		phase := apiv1.PodSucceeded
+		if exitCode == 0 {
+			job.Status.Succeeded = 1
+		} else {
+			job.Status.Failed = 1
+			phase = apiv1.PodFailed
+		}
+		pod := &apiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: job.Name + "-abcde", Namespace: job.Namespace, Labels: job.Spec.Template.Labels},
+			Status: apiv1.PodStatus{
+				Phase: phase,
+				ContainerStatuses: []apiv1.ContainerStatus{
+					{
+						Name:  testContainerName,
+						State: apiv1.ContainerState{Terminated: &apiv1.ContainerStateTerminated{ExitCode: exitCode}},
+					},
+				},
+			},
+		}
+		if err := c.Tracker().Add(pod); err != nil {
+			return true, nil, err
+		}
+		return false, nil, nil
+	})
+	return c
+}
+
+func TestJobRunnerRun(t *testing.T) {
+	jobPollInterval = time.Millisecond
+	test := &model.Test{
+		Image:    "okteto/golang:1",
+		Commands: []model.TestCommand{{Command: "make unit"}},
+	}
+	tests := []struct {
+		expectedErr string
+		name        string
+		exitCode    int32
+	}{
+		{
+			name: "succeeded",
+		},
+		{
+			name:        "failed",
+			exitCode:    3,
+			expectedErr: "test container 'unit' failed with exit code 3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newFakeJobClient(tt.exitCode)
+			r := &jobRunner{
+				client:   c,
+				executor: &fakeExecutor{},
+				fs:       afero.NewMemMapFs(),
+				opts:     jobOptions{ManifestName: "movies", Namespace: "ns"},
+			}
+			out := &bytes.Buffer{}
+
+			err := r.Run(context.Background(), "unit", test, out)
+
+			assert.Equal(t, "fake logs", out.String())
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				exitErr := oktetoErrors.ExitCodeError{}
+				require.ErrorAs(t, err, &exitErr)
+				assert.Equal(t, int(tt.exitCode), exitErr.Code)
+			} else {
+				require.NoError(t, err)
+			}
+
+			jobs, err := c.BatchV1().Jobs("ns").List(context.Background(), metav1.ListOptions{})
+			require.NoError(t, err)
+			assert.Empty(t, jobs.Items)
+			secrets, err := c.CoreV1().Secrets("ns").List(context.Background(), metav1.ListOptions{})
+			require.NoError(t, err)
+			assert.Empty(t, secrets.Items)
+		})
+	}
+}
+
+func TestJobRunnerRunCopiesArtifacts(t *testing.T) {
+	jobPollInterval = time.Millisecond
+	test := &model.Test{
+		Image:     "okteto/golang:1",
+		Context:   "api",
+		Commands:  []model.TestCommand{{Command: "make coverage"}},
+		Artifacts: []model.Artifact{{Path: "reports", Destination: "out/reports"}},
+	}
+	executor := &fakeExecutor{
+		files: map[string]map[string]string{
+			"reports": {"reports/coverage.out": "mode: set"},
+		},
+	}
+	fs := afero.NewMemMapFs()
+	r := &jobRunner{
+		client:   newFakeJobClient(0),
+		executor: executor,
+		fs:       fs,
+		opts:     jobOptions{ManifestName: "movies", Namespace: "ns", ArtifactsRoot: "/src"},
+	}
+
+	require.NoError(t, r.Run(context.Background(), "unit", test, io.Discard))
+
+	content, err := afero.ReadFile(fs, "/src/api/out/reports/coverage.out")
+	require.NoError(t, err)
+	assert.Equal(t, "mode: set", string(content))
+	assert.Equal(t, [][]string{
+		{"cat", exitCodeFile},
+		{"tar", "cf", "-", "reports"},
+		{"touch", artifactsDone},
+	}, executor.commands)
+}
+
+func TestGetPendingPodError(t *testing.T) {
+	pod := &apiv1.Pod{
+		Status: apiv1.PodStatus{
+			ContainerStatuses: []apiv1.ContainerStatus{
+				{State: apiv1.ContainerState{Waiting: &apiv1.ContainerStateWaiting{Reason: "ContainerCreating"}}},
+			},
+		},
+	}
+	assert.NoError(t, getPendingPodError(pod))
+
+	pod.Status.ContainerStatuses[0].State.Waiting = &apiv1.ContainerStateWaiting{Reason: "ErrImagePull", Message: "image not found"}
+	err := getPendingPodError(pod)
+	require.EqualError(t, err, "test container can't start: image not found")
+	assert.True(t, strings.Contains(err.(oktetoErrors.UserError).Hint, "image"))
+}
@@ -0,0 +1,81 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/okteto/okteto/pkg/model"
+)
+
+// runTests runs the tests in the given order. When parallel is set, each test starts as soon as its dependencies succeed.
+// It returns the error of the first failed test, in the given order
+func runTests(ctx context.Context, names []string, tests model.ManifestTests, parallel bool, run func(ctx context.Context, name string) error) error {
+	if !parallel {
+		for _, name := range names {
+			if err := run(ctx, name); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	done := make(map[string]chan struct{}, len(names))
+	for _, name := range names {
+		done[name] = make(chan struct{})
+	}
+
+	errs := make(map[string]error, len(names))
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	for _, name := range names {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			defer close(done[name])
+
+			for _, dep := range tests[name].DependsOn {
+				ch, ok := done[dep]
+				if !ok {
+					continue
+				}
+				<-ch
+				lock.Lock()
+				depErr := errs[dep]
+				lock.Unlock()
+				if depErr != nil {
+					lock.Lock()
+					errs[name] = fmt.Errorf("test container '%s' skipped: its dependency '%s' failed", name, dep)
+					lock.Unlock()
+					return
+				}
+			}
+
+			err := run(ctx, name)
+			lock.Lock()
+			errs[name] = err
+			lock.Unlock()
+		}(name)
+	}
+	wg.Wait()
+
+	for _, name := range names {
+		if errs[name] != nil {
+			return errs[name]
+		}
+	}
+	return nil
+}
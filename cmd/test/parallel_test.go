@@ -0,0 +1,91 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunTests(t *testing.T) {
+	tests := model.ManifestTests{
+		"unit":        &model.Test{},
+		"lint":        &model.Test{},
+		"integration": &model.Test{DependsOn: []string{"unit"}},
+	}
+	order := []string{"lint", "unit", "integration"}
+
+	t.Run("sequential stops at the first failure", func(t *testing.T) {
+		var run []string
+		err := runTests(context.Background(), order, tests, false, func(_ context.Context, name string) error {
+			run = append(run, name)
+			if name == "unit" {
+				return errors.New("unit failed")
+			}
+			return nil
+		})
+		require.EqualError(t, err, "unit failed")
+		assert.Equal(t, []string{"lint", "unit"}, run)
+	})
+
+	t.Run("parallel runs the dependencies first", func(t *testing.T) {
+		var lock sync.Mutex
+		finished := map[string]bool{}
+		unitStarted := make(chan struct{})
+		lintStarted := make(chan struct{})
+		err := runTests(context.Background(), order, tests, true, func(_ context.Context, name string) error {
+			switch name {
+			case "unit":
+				close(unitStarted)
+				// unit and lint run at the same time
+				<-lintStarted
+			case "lint":
+				close(lintStarted)
+				<-unitStarted
+			case "integration":
+				lock.Lock()
+				assert.True(t, finished["unit"])
+				lock.Unlock()
+			}
+			lock.Lock()
+			finished[name] = true
+			lock.Unlock()
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Len(t, finished, 3)
+	})
+
+	t.Run("parallel skips the tests of failed dependencies", func(t *testing.T) {
+		var lock sync.Mutex
+		var run []string
+		err := runTests(context.Background(), order, tests, true, func(_ context.Context, name string) error {
+			lock.Lock()
+			run = append(run, name)
+			lock.Unlock()
+			if name == "unit" {
+				return errors.New("unit failed")
+			}
+			return nil
+		})
+		require.EqualError(t, err, "unit failed")
+		assert.ElementsMatch(t, []string{"lint", "unit"}, run)
+	})
+}
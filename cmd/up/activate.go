@@ -15,6 +15,7 @@ package up
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -34,6 +35,7 @@ import (
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/repository"
+	oktetoTypes "github.com/okteto/okteto/pkg/types"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -61,6 +63,7 @@ func (up *upContext) activate() error {
 	if err := config.UpdateStateFile(up.Dev.Name, up.Namespace, config.Activating); err != nil {
 		return err
 	}
+	up.events.publishPhase(oktetoTypes.UpPhaseActivating)
 
 	// create a new context on every iteration
 	ctx, cancel := context.WithCancel(context.Background())
@@ -83,7 +86,7 @@ func (up *upContext) activate() error {
 	if err != nil {
 		return err
 	}
-	app, create, err := utils.GetApp(ctx, up.Dev, okteto.GetContext().Namespace, k8sClient, up.isRetry)
+	app, create, err := up.appRetriever.GetApp(ctx, up.Dev, up.Namespace, k8sClient, up.isRetry)
 	if err != nil {
 		return err
 	}
@@ -183,6 +186,11 @@ func (up *upContext) activate() error {
 	// success means all context is ready to run the activation
 	up.success = true
 
+	if up.isWaitReady() {
+		up.exitWhenReady(ctx)
+		return nil
+	}
+
 	go func() {
 		output := <-up.cleaned
 		oktetoLog.Debugf("clean command output: %s", output)
@@ -213,7 +221,11 @@ func (up *upContext) activate() error {
 		go TrackLatestBranchOnDevContainer(ctx, up.Namespace, up.Manifest, up.Options.ManifestPathFlag, up.K8sClientProvider)
 
 		startRunCommand := time.Now()
-		up.CommandResult <- up.RunCommand(ctx, up.Dev.Command.Values)
+		if up.Dev.IsAutoRestartEnabled() {
+			up.CommandResult <- up.runCommandWithAutoRestart(ctx)
+		} else {
+			up.CommandResult <- up.RunCommand(ctx, up.Dev.Command.Values)
+		}
 		up.analyticsMeta.ExecDuration(time.Since(startRunCommand))
 
 	}()
@@ -239,7 +251,7 @@ func (up *upContext) shouldRetry(ctx context.Context, err error) bool {
 	case oktetoErrors.ErrLostSyncthing:
 		return true
 	case oktetoErrors.ErrCommandFailed:
-		return !up.Sy.Ping(ctx, false)
+		return !up.syncthingCtrl.Ping(ctx, up.Sy, false)
 	case oktetoErrors.ErrApplyToApp:
 		return true
 	}
@@ -277,9 +289,14 @@ func (up *upContext) createDevContainer(ctx context.Context, app apps.App, creat
 	}
 
 	if up.Dev.PersistentVolumeEnabled() {
-		if err := volumes.CreateForDev(ctx, up.Dev, up.Options.ManifestPath, up.Namespace, k8sClient); err != nil {
+		if err := up.createDevVolume(ctx, k8sClient); err != nil {
 			return err
 		}
+		stampVolumeActivity(ctx, up.Dev, up.Namespace, k8sClient)
+	}
+
+	if err := resolveEnvironmentReferences(ctx, up.Dev, up.Namespace, k8sClient); err != nil {
+		return err
 	}
 
 	resetOnDevContainerStart := up.resetSyncthing || !up.Dev.PersistentVolumeEnabled()
@@ -292,6 +309,9 @@ func (up *upContext) createDevContainer(ctx context.Context, app apps.App, creat
 	if err := apps.TranslateDevMode(trMap); err != nil {
 		return err
 	}
+	for _, tr := range trMap {
+		setHolderAnnotations(tr.DevApp)
+	}
 
 	initSyncErr := <-up.hardTerminate
 	if initSyncErr != nil {
@@ -315,12 +335,16 @@ func (up *upContext) createDevContainer(ctx context.Context, app apps.App, creat
 	}
 
 	if create {
-		if err := services.CreateDev(ctx, up.Dev, up.Namespace, k8sClient); err != nil {
+		if err := services.CreateDev(ctx, up.Dev, up.Namespace, okteto.GetCapabilities().SupportsAutoIngress, k8sClient); err != nil {
 			return err
 		}
 	}
 
-	pod, err := apps.GetRunningPodInLoop(ctx, up.Dev, dd.mainTranslation.DevApp, k8sClient)
+	timeout := apps.GetPodReadyTimeout(up.Dev, dd.mainTranslation.App)
+	if apps.IsRecreateStrategy(dd.mainTranslation.App) {
+		oktetoLog.Information("'%s' uses the Recreate strategy: its pods are terminated before the development container starts. Waiting up to %s", dd.mainTranslation.App.ObjectMeta().Name, timeout)
+	}
+	pod, err := up.podWaiter.WaitForRunningPod(ctx, up.Dev, dd.mainTranslation.DevApp, k8sClient, timeout)
 	if err != nil {
 		return err
 	}
@@ -330,6 +354,46 @@ func (up *upContext) createDevContainer(ctx context.Context, app apps.App, creat
 	return nil
 }
 
+// createDevVolume creates the persistent volume of the development container.
+// If the cluster can't provision it, the development container falls back to an ephemeral volume
+func (up *upContext) createDevVolume(ctx context.Context, k8sClient kubernetes.Interface) error {
+	if err := volumes.CheckProvisioner(ctx, up.Dev, up.Namespace, k8sClient); err != nil {
+		if !errors.Is(err, volumes.ErrNoDefaultStorageClass) {
+			return err
+		}
+		return up.fallbackToEphemeralVolume(err)
+	}
+
+	if err := volumes.CreateForDev(ctx, up.Dev, up.Options.ManifestPath, up.Namespace, k8sClient); err != nil {
+		return err
+	}
+
+	if err := volumes.WaitForProvisioning(ctx, up.Dev, up.Namespace, k8sClient, up.Dev.Timeout.Default); err != nil {
+		if !errors.Is(err, volumes.ErrVolumeNotProvisioned) {
+			return err
+		}
+		if err := volumes.DestroyWithoutTimeout(ctx, up.Dev.GetVolumeName(), up.Namespace, k8sClient); err != nil {
+			oktetoLog.Infof("failed to destroy pending volume claim: %s", err)
+		}
+		return up.fallbackToEphemeralVolume(err)
+	}
+	return nil
+}
+
+// fallbackToEphemeralVolume disables the persistent volume of the development container
+func (up *upContext) fallbackToEphemeralVolume(reason error) error {
+	if err := up.Dev.DisablePersistentVolume(); err != nil {
+		return oktetoErrors.UserError{
+			E: fmt.Errorf("your cluster can't provision the persistent volume of your development container: %w", reason),
+			Hint: fmt.Sprintf(`Your development container can't run without a persistent volume: %s.
+    Set 'persistentVolume.storageClass' in your okteto manifest or configure a default storage class in your cluster`, err),
+		}
+	}
+
+	oktetoLog.Warning("Your cluster can't provision persistent volumes (%s): using an ephemeral volume.\n    The data of your development container won't survive pod restarts", reason)
+	return nil
+}
+
 func (up *upContext) waitUntilDevelopmentContainerIsRunning(ctx context.Context, app apps.App) error {
 	msg := "Preparing development environment..."
 	if !up.Dev.IsHybridModeEnabled() {
@@ -16,12 +16,29 @@ package up
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/okteto/okteto/internal/test"
+	fakeUp "github.com/okteto/okteto/internal/test/up"
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/k8s/secrets"
+	"github.com/okteto/okteto/pkg/k8s/services"
+	"github.com/okteto/okteto/pkg/k8s/volumes"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/syncthing"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/utils/ptr"
 )
 
 func TestWaitUntilAppAwaken(t *testing.T) {
@@ -100,3 +117,183 @@ func TestWaitUntilDevelopmentContainerIsRunning(t *testing.T) {
 		})
 	}
 }
+
+func TestShouldRetry(t *testing.T) {
+	tt := []struct {
+		err           error
+		name          string
+		remotePing    bool
+		expected      bool
+		expectedPings int
+	}{
+		{
+			name:     "no error",
+			expected: false,
+		},
+		{
+			name:     "lost syncthing",
+			err:      oktetoErrors.ErrLostSyncthing,
+			expected: true,
+		},
+		{
+			name:     "apply to app",
+			err:      oktetoErrors.ErrApplyToApp,
+			expected: true,
+		},
+		{
+			name:          "command failed with syncthing running",
+			err:           oktetoErrors.ErrCommandFailed,
+			remotePing:    true,
+			expected:      false,
+			expectedPings: 1,
+		},
+		{
+			name:          "command failed with syncthing not responding",
+			err:           oktetoErrors.ErrCommandFailed,
+			remotePing:    false,
+			expected:      true,
+			expectedPings: 1,
+		},
+		{
+			name:     "other error",
+			err:      assert.AnError,
+			expected: false,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			syncthingCtrl := &fakeUp.FakeSyncthingController{RemotePing: tc.remotePing}
+			up := &upContext{
+				syncthingCtrl: syncthingCtrl,
+			}
+			assert.Equal(t, tc.expected, up.shouldRetry(context.Background(), tc.err))
+			assert.Equal(t, tc.expectedPings, syncthingCtrl.PingCalls)
+		})
+	}
+}
+
+func TestCreateDevVolumeFallsBackToEphemeralVolume(t *testing.T) {
+	up := &upContext{
+		Dev: &model.Dev{
+			Name:                 "test",
+			PersistentVolumeInfo: &model.PersistentVolumeInfo{Enabled: true, Size: "2Gi"},
+		},
+		Namespace: "ns",
+		Options:   &Options{},
+	}
+	c := fake.NewSimpleClientset()
+
+	require.NoError(t, up.createDevVolume(context.Background(), c))
+	assert.False(t, up.Dev.PersistentVolumeEnabled())
+	assert.Equal(t, "2Gi", up.Dev.EphemeralVolumeSizeLimit())
+
+	pvcs, err := c.CoreV1().PersistentVolumeClaims("ns").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, pvcs.Items)
+}
+
+func TestCreateDevVolumeRequiresPersistentVolume(t *testing.T) {
+	up := &upContext{
+		Dev: &model.Dev{
+			Name:                 "test",
+			PersistentVolumeInfo: &model.PersistentVolumeInfo{Enabled: true},
+			Volumes:              []model.Volume{{RemotePath: "/cache"}},
+		},
+		Namespace: "ns",
+		Options:   &Options{},
+	}
+
+	err := up.createDevVolume(context.Background(), fake.NewSimpleClientset())
+	var uErr oktetoErrors.UserError
+	require.ErrorAs(t, err, &uErr)
+	assert.ErrorIs(t, err, volumes.ErrNoDefaultStorageClass)
+	assert.True(t, up.Dev.PersistentVolumeEnabled())
+}
+
+func TestCreateDevVolumeWithDefaultStorageClass(t *testing.T) {
+	up := &upContext{
+		Dev: &model.Dev{
+			Name:                 "test",
+			PersistentVolumeInfo: &model.PersistentVolumeInfo{Enabled: true},
+			Timeout:              model.Timeout{Default: time.Second},
+		},
+		Namespace: "ns",
+		Options:   &Options{},
+	}
+	c := fake.NewSimpleClientset(&storagev1.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "standard",
+			Annotations: map[string]string{model.DefaultStorageClassAnnotation: "true"},
+		},
+	})
+	// the fake client doesn't run the admission controller that assigns the default storage class
+	c.PrependReactor("create", "persistentvolumeclaims", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		pvc := action.(k8sTesting.CreateAction).GetObject().(*apiv1.PersistentVolumeClaim)
+		pvc.Spec.StorageClassName = ptr.To("standard")
+		return false, nil, nil
+	})
+
+	require.NoError(t, up.createDevVolume(context.Background(), c))
+	assert.True(t, up.Dev.PersistentVolumeEnabled())
+
+	_, err := c.CoreV1().PersistentVolumeClaims("ns").Get(context.Background(), up.Dev.GetVolumeName(), metav1.GetOptions{})
+	require.NoError(t, err)
+}
+
+func TestDevResourcesHaveManifestMetadata(t *testing.T) {
+	ctx := context.Background()
+	manifest, err := model.Read([]byte(`
+metadata:
+  labels:
+    team: payments
+  annotations:
+    owner: payments@example.com
+dev:
+  api:
+    image: okteto/golang:1
+    autocreate: true
+    sync:
+      - .:/app
+`))
+	require.NoError(t, err)
+	dev := manifest.Dev["api"]
+	dev.Metadata.Annotations[model.OktetoRestartAnnotation] = "1"
+
+	c := fake.NewSimpleClientset()
+	require.NoError(t, volumes.CreateForDev(ctx, dev, "", "ns", c))
+	require.NoError(t, secrets.Create(ctx, dev, "ns", c, &syncthing.Syncthing{}))
+	require.NoError(t, services.CreateDev(ctx, dev, "ns", true, c))
+	_, err = deployments.Deploy(ctx, deployments.Sandbox(dev, "ns"), c)
+	require.NoError(t, err)
+
+	objects := []metav1.Object{}
+	pvcs, err := c.CoreV1().PersistentVolumeClaims("ns").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	for i := range pvcs.Items {
+		objects = append(objects, &pvcs.Items[i])
+	}
+	secretList, err := c.CoreV1().Secrets("ns").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	for i := range secretList.Items {
+		objects = append(objects, &secretList.Items[i])
+	}
+	svcs, err := c.CoreV1().Services("ns").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	for i := range svcs.Items {
+		objects = append(objects, &svcs.Items[i])
+	}
+	deploys, err := c.AppsV1().Deployments("ns").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	for i := range deploys.Items {
+		objects = append(objects, &deploys.Items[i])
+		assert.Equal(t, "payments", deploys.Items[i].Spec.Template.Labels["team"])
+	}
+	require.Len(t, objects, 4)
+
+	for _, obj := range objects {
+		assert.Equal(t, "payments", obj.GetLabels()["team"], obj.GetName())
+		assert.Equal(t, "true", obj.GetLabels()[constants.DevLabel], obj.GetName())
+		assert.Equal(t, "payments@example.com", obj.GetAnnotations()["owner"], obj.GetName())
+		assert.NotContains(t, obj.GetAnnotations(), model.OktetoRestartAnnotation, obj.GetName())
+	}
+}
@@ -47,6 +47,10 @@ func (m *mockAnalyticsTracker) TrackUp(metadata *analytics.UpMetricsMetadata) {
 	m.Called(metadata)
 }
 
+func (m *mockAnalyticsTracker) TrackUpSessionEnd(metadata *analytics.UpMetricsMetadata) {
+	m.Called(metadata)
+}
+
 func (m *mockAnalyticsTracker) TrackDown(success bool) {
 	m.Called(success)
 }
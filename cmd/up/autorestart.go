@@ -0,0 +1,210 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/moby/patternmatcher"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// autoRestartPollInterval is the interval to check for files synchronized to the development container
+	autoRestartPollInterval = time.Second
+
+	// autoRestartMinUptime is the time the command must run to not be considered crashing on start
+	autoRestartMinUptime = 5 * time.Second
+
+	// autoRestartMaxQuickExits is the number of consecutive crashes on start before okteto up stops restarting the command
+	autoRestartMaxQuickExits = 3
+)
+
+// finishedItemsGetter returns the files synchronized to the development container since a syncthing event
+type finishedItemsGetter interface {
+	GetFinishedItems(ctx context.Context, since int) ([]string, int, error)
+}
+
+// autoRestarter runs the command of the development container and restarts it when the synchronized files change
+type autoRestarter struct {
+	matcher       *patternmatcher.PatternMatcher
+	run           func(ctx context.Context) error
+	changes       <-chan string
+	command       string
+	debounce      time.Duration
+	minUptime     time.Duration
+	maxQuickExits int
+}
+
+func newAutoRestarter(paths []string, debounce time.Duration, command string, changes <-chan string, run func(ctx context.Context) error) (*autoRestarter, error) {
+	matcher, err := patternmatcher.New(paths)
+	if err != nil {
+		return nil, err
+	}
+	return &autoRestarter{
+		matcher:       matcher,
+		run:           run,
+		changes:       changes,
+		command:       command,
+		debounce:      debounce,
+		minUptime:     autoRestartMinUptime,
+		maxQuickExits: autoRestartMaxQuickExits,
+	}, nil
+}
+
+// runCommandWithAutoRestart runs the command of the development container and restarts it on file changes
+func (up *upContext) runCommandWithAutoRestart(ctx context.Context) error {
+	changes := make(chan string)
+	go pollFinishedItems(ctx, up.Sy, autoRestartPollInterval, changes)
+
+	ar, err := newAutoRestarter(
+		up.Dev.AutoRestart.Paths,
+		up.Dev.AutoRestart.Debounce,
+		strings.Join(up.Dev.Command.Values, " "),
+		changes,
+		func(ctx context.Context) error {
+			return up.RunCommand(ctx, up.Dev.Command.Values)
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return ar.start(ctx)
+}
+
+// start runs the command until it exits successfully or ctx is done.
+// Bursts of file changes are debounced into a single restart. If the command keeps crashing right after starting,
+// it isn't restarted again until the next file change
+func (ar *autoRestarter) start(ctx context.Context) error {
+	var debounceC <-chan time.Time
+	var debounceTimer *time.Timer
+	quickExits := 0
+
+	cancel, result := ar.runAsync(ctx)
+	startedAt := time.Now()
+	running := true
+	for {
+		select {
+		case <-ctx.Done():
+			cancel()
+			if running {
+				<-result
+			}
+			return ctx.Err()
+
+		case path := <-ar.changes:
+			if !ar.matches(path) {
+				continue
+			}
+			oktetoLog.Infof("autoRestart: '%s' changed", path)
+			if debounceTimer != nil {
+				debounceTimer.Stop()
+			}
+			debounceTimer = time.NewTimer(ar.debounce)
+			debounceC = debounceTimer.C
+
+		case <-debounceC:
+			debounceC = nil
+			oktetoLog.Information("Files changed, restarting '%s'...", ar.command)
+			cancel()
+			if running {
+				<-result
+			}
+			quickExits = 0
+			cancel, result = ar.runAsync(ctx)
+			startedAt = time.Now()
+			running = true
+
+		case err := <-result:
+			running = false
+			cancel()
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if err == nil {
+				return nil
+			}
+			oktetoLog.Infof("autoRestart: command exited: %s", err)
+			if time.Since(startedAt) < ar.minUptime {
+				quickExits++
+			} else {
+				quickExits = 1
+			}
+			if quickExits >= ar.maxQuickExits {
+				oktetoLog.Warning("'%s' failed %d times in a row right after starting: %s", ar.command, quickExits, err)
+				oktetoLog.Information("Waiting for file changes to restart '%s'...", ar.command)
+				continue
+			}
+			oktetoLog.Information("'%s' exited with error: %s. Restarting it...", ar.command, err)
+			cancel, result = ar.runAsync(ctx)
+			startedAt = time.Now()
+			running = true
+		}
+	}
+}
+
+// runAsync runs the command under a child context. Cancelling the child context interrupts the command
+func (ar *autoRestarter) runAsync(ctx context.Context) (context.CancelFunc, <-chan error) {
+	cmdCtx, cancel := context.WithCancel(ctx)
+	result := make(chan error, 1)
+	go func() {
+		result <- ar.run(cmdCtx)
+	}()
+	return cancel, result
+}
+
+func (ar *autoRestarter) matches(path string) bool {
+	matches, err := ar.matcher.MatchesOrParentMatches(filepath.FromSlash(path))
+	if err != nil {
+		oktetoLog.Infof("autoRestart: failed to match '%s': %s", path, err)
+		return false
+	}
+	return matches
+}
+
+// pollFinishedItems sends the files synchronized to the development container to changes until ctx is done.
+// The events before the first poll are skipped, they were synchronized before the command started
+func pollFinishedItems(ctx context.Context, getter finishedItemsGetter, interval time.Duration, changes chan<- string) {
+	_, since, err := getter.GetFinishedItems(ctx, 0)
+	if err != nil {
+		oktetoLog.Infof("autoRestart: failed to get synchronized files: %s", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		var items []string
+		items, since, err = getter.GetFinishedItems(ctx, since)
+		if err != nil {
+			oktetoLog.Infof("autoRestart: failed to get synchronized files: %s", err)
+			continue
+		}
+		for _, item := range items {
+			select {
+			case changes <- item:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
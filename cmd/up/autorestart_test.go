@@ -0,0 +1,204 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeExecutor records the executions of the command. Each execution runs until it is interrupted,
+// unless an exit error is queued for it
+type fakeExecutor struct {
+	exits   chan error
+	started chan struct{}
+	mu      sync.Mutex
+	runs    int
+}
+
+func newFakeExecutor() *fakeExecutor {
+	return &fakeExecutor{
+		exits:   make(chan error, 10),
+		started: make(chan struct{}, 10),
+	}
+}
+
+func (f *fakeExecutor) run(ctx context.Context) error {
+	f.mu.Lock()
+	f.runs++
+	f.mu.Unlock()
+	f.started <- struct{}{}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-f.exits:
+		return err
+	}
+}
+
+func (f *fakeExecutor) getRuns() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.runs
+}
+
+func (f *fakeExecutor) waitStarted(t *testing.T) {
+	t.Helper()
+	select {
+	case <-f.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("command not started")
+	}
+}
+
+func newTestAutoRestarter(t *testing.T, paths []string, changes <-chan string, executor *fakeExecutor) *autoRestarter {
+	t.Helper()
+	ar, err := newAutoRestarter(paths, 50*time.Millisecond, "go run main.go", changes, executor.run)
+	require.NoError(t, err)
+	return ar
+}
+
+func startAutoRestarter(ctx context.Context, ar *autoRestarter) <-chan error {
+	result := make(chan error, 1)
+	go func() {
+		result <- ar.start(ctx)
+	}()
+	return result
+}
+
+func TestAutoRestarterDebouncesChanges(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan string)
+	executor := newFakeExecutor()
+	result := startAutoRestarter(ctx, newTestAutoRestarter(t, []string{"**/*.go"}, changes, executor))
+	executor.waitStarted(t)
+
+	for _, path := range []string{"main.go", "pkg/api/api.go", "pkg/api/handler.go"} {
+		changes <- path
+	}
+	executor.waitStarted(t)
+
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(t, 2, executor.getRuns())
+
+	cancel()
+	require.ErrorIs(t, <-result, context.Canceled)
+}
+
+func TestAutoRestarterIgnoresNotMatchingPaths(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan string)
+	executor := newFakeExecutor()
+	result := startAutoRestarter(ctx, newTestAutoRestarter(t, []string{"src", "!src/**/*.md"}, changes, executor))
+	executor.waitStarted(t)
+
+	for _, path := range []string{"README.md", "src/docs/index.md", "node_modules/lib/index.js"} {
+		changes <- path
+	}
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(t, 1, executor.getRuns())
+
+	changes <- "src/index.js"
+	executor.waitStarted(t)
+	assert.Equal(t, 2, executor.getRuns())
+
+	cancel()
+	require.ErrorIs(t, <-result, context.Canceled)
+}
+
+func TestAutoRestarterDetectsCrashLoop(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan string)
+	executor := newFakeExecutor()
+	for i := 0; i < autoRestartMaxQuickExits; i++ {
+		executor.exits <- errors.New("exit status 1")
+	}
+	result := startAutoRestarter(ctx, newTestAutoRestarter(t, []string{"**"}, changes, executor))
+
+	for i := 0; i < autoRestartMaxQuickExits; i++ {
+		executor.waitStarted(t)
+	}
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(t, autoRestartMaxQuickExits, executor.getRuns())
+
+	// a file change restarts the command after a crash loop
+	changes <- "main.go"
+	executor.waitStarted(t)
+	assert.Equal(t, autoRestartMaxQuickExits+1, executor.getRuns())
+
+	cancel()
+	require.ErrorIs(t, <-result, context.Canceled)
+}
+
+func TestAutoRestarterCommandExitsSuccessfully(t *testing.T) {
+	executor := newFakeExecutor()
+	executor.exits <- nil
+
+	ar := newTestAutoRestarter(t, []string{"**"}, make(chan string), executor)
+	require.NoError(t, ar.start(context.Background()))
+	assert.Equal(t, 1, executor.getRuns())
+}
+
+type fakeFinishedItemsGetter struct {
+	events [][]string
+	calls  []int
+	mu     sync.Mutex
+}
+
+func (f *fakeFinishedItemsGetter) GetFinishedItems(_ context.Context, since int) ([]string, int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, since)
+	if len(f.events) == 0 {
+		return nil, since, nil
+	}
+	items := f.events[0]
+	f.events = f.events[1:]
+	return items, since + len(items), nil
+}
+
+func TestPollFinishedItems(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	getter := &fakeFinishedItemsGetter{
+		events: [][]string{
+			{"synced-before-start.go"},
+			{"main.go", "api.go"},
+		},
+	}
+	changes := make(chan string)
+	go pollFinishedItems(ctx, getter, 10*time.Millisecond, changes)
+
+	assert.Equal(t, "main.go", <-changes)
+	assert.Equal(t, "api.go", <-changes)
+	cancel()
+
+	getter.mu.Lock()
+	defer getter.mu.Unlock()
+	require.GreaterOrEqual(t, len(getter.calls), 2)
+	assert.Equal(t, []int{0, 1}, getter.calls[:2])
+}
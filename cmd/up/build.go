@@ -16,12 +16,22 @@ package up
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
 
 	buildv2 "github.com/okteto/okteto/cmd/build/v2"
 	"github.com/okteto/okteto/pkg/analytics"
 	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/build/buildkit"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/filesystem"
+	"github.com/okteto/okteto/pkg/localcluster"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/types"
+	apiv1 "k8s.io/api/core/v1"
 )
 
 type upBuilder struct {
@@ -30,6 +40,10 @@ type upBuilder struct {
 	manifest      *model.Manifest
 	analyticsMeta *analytics.UpMetricsMetadata
 	devName       string
+
+	// localCluster is the kubernetes context of a kind or k3d cluster, whose nodes load the images instead of pulling them
+	localCluster string
+	built        bool
 }
 
 func newUpBuilder(m *model.Manifest, devName string, builder builderInterface, reg registryInterface, meta *analytics.UpMetricsMetadata) *upBuilder {
@@ -39,7 +53,20 @@ func newUpBuilder(m *model.Manifest, devName string, builder builderInterface, r
 		devName:       devName,
 		registry:      reg,
 		analyticsMeta: meta,
+		localCluster:  getLocalClusterContext(),
+	}
+}
+
+// getLocalClusterContext returns the kubernetes context of the current context if it is a kind or k3d cluster
+func getLocalClusterContext() string {
+	if okteto.IsOkteto() {
+		return ""
+	}
+	name := okteto.GetContext().Name
+	if localcluster.Detect(name) == nil {
+		return ""
 	}
+	return name
 }
 
 func (ub *upBuilder) build(ctx context.Context) error {
@@ -64,6 +91,12 @@ func (ub *upBuilder) build(ctx context.Context) error {
 		}
 	}
 
+	if ub.localCluster != "" {
+		// the image is loaded into the nodes of the cluster, there is no registry to pull it from
+		oktetoLog.Infof("using pull policy %s for the image of '%s' in the local cluster", apiv1.PullNever, ub.devName)
+		ub.manifest.Dev[ub.devName].ImagePullPolicy = apiv1.PullNever
+	}
+
 	// get all the services that need to be built
 	visited := make(map[string]bool)
 	dependentSvcs := ub.getDependentServices(buildSvc, ub.manifest.Build, visited)
@@ -83,11 +116,67 @@ func (ub *upBuilder) build(ctx context.Context) error {
 		CommandArgs: svcsToBuild,
 		Manifest:    ub.manifest,
 	}
+	if ub.localCluster != "" {
+		buildOptions.Output = fmt.Sprintf("type=%s,cluster=%s", buildkit.OutputTypeCluster, ub.localCluster)
+	}
+	if err := validateDevImageOutput(buildOptions); err != nil {
+		return err
+	}
 	err = ub.builder.Build(ctx, buildOptions)
 	ub.analyticsMeta.HasRunBuild()
+	ub.built = err == nil
 	return err
 }
 
+// validateDevImageOutput checks that the images are pushed to the registry, as the development container pulls its image from there,
+// or loaded into the nodes of a local cluster
+func validateDevImageOutput(options *types.BuildOptions) error {
+	output, err := buildkit.ParseOutput(options.Output)
+	if err != nil {
+		return err
+	}
+	if output.IsRegistry() || output.Type == buildkit.OutputTypeCluster {
+		return nil
+	}
+	return oktetoErrors.UserError{
+		E:    fmt.Errorf("the image of the development container can't be exported to %s", output),
+		Hint: "The development container pulls its image from the registry. Use 'okteto build --output' to export images out of the registry",
+	}
+}
+
+// getSyncedBuildContexts returns the services of the build section whose build context overlaps a sync folder of the dev
+func getSyncedBuildContexts(dev *model.Dev, manifest *model.Manifest) []string {
+	buildDir := manifest.GetWorkdir()
+	result := []string{}
+	for svcName, info := range manifest.Build {
+		if info == nil {
+			continue
+		}
+		buildContext := info.Context
+		if buildContext == "" {
+			buildContext = "."
+		}
+		for _, folder := range dev.Sync.Folders {
+			if filesystem.PathsOverlap(buildDir, buildContext, folder.LocalPath) {
+				result = append(result, svcName)
+				break
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// warnSyncedBuildContexts explains the relationship between the images built from a synced folder and the file synchronization
+func warnSyncedBuildContexts(dev *model.Dev, manifest *model.Manifest) {
+	svcs := getSyncedBuildContexts(dev, manifest)
+	if len(svcs) == 0 {
+		return
+	}
+	oktetoLog.Information("The build context of %s is also synchronized with your development container.", strings.Join(svcs, ", "))
+	oktetoLog.Information("Images are built from the files on disk when the build runs, and the file synchronization updates the running container afterwards: local changes made after the build are synchronized but they are not part of the image until it is rebuilt.")
+}
+
 func (ub *upBuilder) getBuildSvcFromDev(manifest *model.Manifest) string {
 	dev := ub.manifest.Dev[ub.devName]
 	if dev == nil {
@@ -15,15 +15,20 @@ package up
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	buildv2 "github.com/okteto/okteto/cmd/build/v2"
 	"github.com/okteto/okteto/pkg/analytics"
 	"github.com/okteto/okteto/pkg/build"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
 )
 
 type fakeRegistry struct{}
@@ -152,6 +157,34 @@ func TestUpBuilder_Build(t *testing.T) {
 	}
 }
 
+func TestUpBuilder_BuildLocalCluster(t *testing.T) {
+	manifest := &model.Manifest{
+		Build: build.ManifestBuild{
+			"my-dev": {Image: "my-image"},
+		},
+		Dev: map[string]*model.Dev{
+			"my-dev": {Image: "my-image", ImagePullPolicy: apiv1.PullAlways},
+		},
+	}
+	builder := &fakeBuilder{
+		getSvcFromRegexErr: buildv2.ErrImageIsNotAOktetoBuildSyntax,
+		services:           []string{"my-dev"},
+	}
+	ub := &upBuilder{
+		manifest:      manifest,
+		devName:       "my-dev",
+		builder:       builder,
+		registry:      &fakeRegistry{},
+		analyticsMeta: analytics.NewUpMetricsMetadata(),
+		localCluster:  "kind-dev",
+	}
+
+	require.NoError(t, ub.build(context.Background()))
+	assert.Equal(t, apiv1.PullNever, manifest.Dev["my-dev"].ImagePullPolicy)
+	require.NotNil(t, builder.usedBuildOptions)
+	assert.Equal(t, "type=cluster,cluster=kind-dev", builder.usedBuildOptions.Output)
+}
+
 func TestGetBuildServiceFromImage(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -369,3 +402,110 @@ func TestGetDependentServices(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSyncedBuildContexts(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "api", "src"), 0700))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "frontend"), 0700))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "worker"), 0700))
+
+	manifest := &model.Manifest{
+		ManifestPath: filepath.Join(root, "okteto.yml"),
+		Build: build.ManifestBuild{
+			"api":      {Context: "api"},
+			"frontend": {Context: "frontend"},
+			"worker":   {Context: filepath.Join(root, "worker")},
+			"root":     {},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		folders  []model.SyncFolder
+		expected []string
+	}{
+		{
+			name:     "sync folder inside a build context",
+			folders:  []model.SyncFolder{{LocalPath: filepath.Join(root, "api", "src"), RemotePath: "/app"}},
+			expected: []string{"api", "root"},
+		},
+		{
+			name:     "sync folder containing build contexts",
+			folders:  []model.SyncFolder{{LocalPath: root, RemotePath: "/app"}},
+			expected: []string{"api", "frontend", "root", "worker"},
+		},
+		{
+			name:     "sync folder outside the build contexts",
+			folders:  []model.SyncFolder{{LocalPath: filepath.Join(t.TempDir(), "other"), RemotePath: "/app"}},
+			expected: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev := &model.Dev{Sync: model.Sync{Folders: tt.folders}}
+			assert.Equal(t, tt.expected, getSyncedBuildContexts(dev, manifest))
+		})
+	}
+}
+
+func TestValidateDevImageOutput(t *testing.T) {
+	tests := []struct {
+		name        string
+		output      string
+		expectedErr string
+	}{
+		{
+			name: "default output",
+		},
+		{
+			name:   "registry output",
+			output: "type=registry",
+		},
+		{
+			name:        "docker output",
+			output:      "type=docker",
+			expectedErr: "the image of the development container can't be exported to the local docker daemon",
+		},
+		{
+			name:   "local cluster output",
+			output: "type=cluster,cluster=kind-dev",
+		},
+		{
+			name:        "tar output",
+			output:      "type=tar,dest=image.tar",
+			expectedErr: "the image of the development container can't be exported to 'image.tar'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateDevImageOutput(&types.BuildOptions{Output: tt.output})
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+			var userErr oktetoErrors.UserError
+			assert.ErrorAs(t, err, &userErr)
+		})
+	}
+}
+
+func TestGetSyncedBuildContextsWithWorkdir(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "services", "api", "src"), 0700))
+
+	manifest := &model.Manifest{
+		ManifestPath: filepath.Join(root, "services", "api", "okteto.yml"),
+		Workdir:      root,
+		Build: build.ManifestBuild{
+			"api": {Context: filepath.Join("services", "api")},
+			"web": {Context: filepath.Join("services", "web")},
+		},
+	}
+	dev := &model.Dev{
+		Sync: model.Sync{
+			Folders: []model.SyncFolder{{LocalPath: filepath.Join(root, "services", "api", "src"), RemotePath: "/app"}},
+		},
+	}
+	assert.Equal(t, []string{"api"}, getSyncedBuildContexts(dev, manifest))
+}
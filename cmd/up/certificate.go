@@ -0,0 +1,157 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/afero"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+var errNoClientCertificate = errors.New("no client certificate found")
+
+// maxKubeconfigReloads is the number of consecutive times the kubeconfig is reloaded before giving up on an expired client certificate
+const maxKubeconfigReloads = 3
+
+// kubeconfigReloader reloads the credentials of the current context when its client certificate has expired
+type kubeconfigReloader interface {
+	Reload() error
+}
+
+// kubeconfigReloaderController reloads the kubeconfig from disk, in case the client certificate was rotated by an external tool
+type kubeconfigReloaderController struct {
+	fs             afero.Fs
+	now            func() time.Time
+	loadKubeconfig func() (*clientcmdapi.Config, error)
+}
+
+func newKubeconfigReloaderController(fs afero.Fs) *kubeconfigReloaderController {
+	return &kubeconfigReloaderController{
+		fs:  fs,
+		now: time.Now,
+		loadKubeconfig: func() (*clientcmdapi.Config, error) {
+			loadingRules := clientcmd.ClientConfigLoadingRules{
+				Precedence: config.GetKubeconfigPath(),
+			}
+			return loadingRules.Load()
+		},
+	}
+}
+
+// Reload replaces the credentials of the current context with the ones stored on disk.
+// It returns an error naming the expired credential if the kubeconfig on disk doesn't contain a valid client certificate
+func (krc *kubeconfigReloaderController) Reload() error {
+	okCtx := okteto.GetContext()
+	cfg := okCtx.Cfg
+	if cfg == nil {
+		return errConfigNotConfigured
+	}
+
+	contextName := cfg.CurrentContext
+	authInfoName := contextName
+	if kubeCtx, ok := cfg.Contexts[contextName]; ok && kubeCtx.AuthInfo != "" {
+		authInfoName = kubeCtx.AuthInfo
+	}
+	// contexts authenticated with a token or an exec plugin don't have a client certificate to rotate
+	current, ok := cfg.AuthInfos[authInfoName]
+	if !ok || (len(current.ClientCertificateData) == 0 && current.ClientCertificate == "") {
+		return errNoClientCertificate
+	}
+
+	diskCfg, err := krc.loadKubeconfig()
+	if err != nil {
+		return fmt.Errorf("error reloading your kubeconfig: %w", err)
+	}
+	diskCtx, ok := diskCfg.Contexts[contextName]
+	if !ok {
+		return newCertificateExpiredError(authInfoName, contextName, time.Time{})
+	}
+	authInfo, ok := diskCfg.AuthInfos[diskCtx.AuthInfo]
+	if !ok {
+		return newCertificateExpiredError(authInfoName, contextName, time.Time{})
+	}
+
+	cert, err := krc.getClientCertificate(authInfo)
+	if err != nil {
+		return newCertificateExpiredError(diskCtx.AuthInfo, contextName, time.Time{})
+	}
+	if krc.now().After(cert.NotAfter) {
+		return newCertificateExpiredError(diskCtx.AuthInfo, contextName, cert.NotAfter)
+	}
+
+	if cfg.AuthInfos == nil {
+		cfg.AuthInfos = map[string]*clientcmdapi.AuthInfo{}
+	}
+	cfg.AuthInfos[authInfoName] = authInfo.DeepCopy()
+	return nil
+}
+
+// getClientCertificate returns the client certificate of an AuthInfo, either embedded or stored in a file
+func (krc *kubeconfigReloaderController) getClientCertificate(authInfo *clientcmdapi.AuthInfo) (*x509.Certificate, error) {
+	data := authInfo.ClientCertificateData
+	if len(data) == 0 && authInfo.ClientCertificate != "" {
+		var err error
+		data, err = afero.ReadFile(krc.fs, authInfo.ClientCertificate)
+		if err != nil {
+			return nil, err
+		}
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errNoClientCertificate
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// reloadKubeconfig reloads the kubeconfig after the cluster rejected the client certificate of the current context.
+// It returns true if the activation must be retried with the reloaded credentials
+func (up *upContext) reloadKubeconfig(err error, attempt int) (bool, error) {
+	if attempt > maxKubeconfigReloads {
+		return false, oktetoErrors.UserError{
+			E:    fmt.Errorf("the client certificate was still rejected after reloading your kubeconfig %d times: %w", maxKubeconfigReloads, err),
+			Hint: "Renew the client certificate of your kubeconfig and run 'okteto up' again",
+		}
+	}
+	oktetoLog.Info("client certificate expired, reloading kubeconfig")
+	if err := up.kubeconfigReloader.Reload(); err != nil {
+		if errors.Is(err, errNoClientCertificate) {
+			oktetoLog.Info("the current context doesn't use a client certificate, skipping the kubeconfig reload")
+			return false, nil
+		}
+		oktetoLog.Infof("error reloading kubeconfig: %s", err)
+		return false, err
+	}
+	return true, nil
+}
+
+func newCertificateExpiredError(authInfo, contextName string, expiration time.Time) error {
+	err := fmt.Errorf("the client certificate of user '%s' in context '%s' has expired", authInfo, contextName)
+	if !expiration.IsZero() {
+		err = fmt.Errorf("the client certificate of user '%s' in context '%s' expired on %s", authInfo, contextName, expiration.Format(time.RFC3339))
+	}
+	return oktetoErrors.UserError{
+		E:    err,
+		Hint: "Renew the client certificate of your kubeconfig and run 'okteto up' again",
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func generateClientCertificate(t *testing.T, notAfter time.Time) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "kubernetes-admin"},
+		NotBefore:    notAfter.Add(-24 * time.Hour),
+		NotAfter:     notAfter,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func newKubeconfigWithAuthInfo(authInfo *clientcmdapi.AuthInfo) *clientcmdapi.Config {
+	return &clientcmdapi.Config{
+		CurrentContext: "kind-test",
+		Contexts: map[string]*clientcmdapi.Context{
+			"kind-test": {
+				Cluster:  "kind-test",
+				AuthInfo: "kind-admin",
+			},
+		},
+		AuthInfos: map[string]*clientcmdapi.AuthInfo{
+			"kind-admin": authInfo,
+		},
+	}
+}
+
+func TestKubeconfigReloader(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	expiredCert := generateClientCertificate(t, now.Add(-1*time.Hour))
+	validCert := generateClientCertificate(t, now.Add(23*time.Hour))
+
+	tests := []struct {
+		diskCfg          *clientcmdapi.Config
+		files            map[string][]byte
+		expectedAuthInfo *clientcmdapi.AuthInfo
+		name             string
+		expectedErr      string
+	}{
+		{
+			name: "rotated embedded certificate",
+			diskCfg: newKubeconfigWithAuthInfo(&clientcmdapi.AuthInfo{
+				ClientCertificateData: validCert,
+				ClientKeyData:         []byte("new-key"),
+			}),
+			expectedAuthInfo: &clientcmdapi.AuthInfo{
+				ClientCertificateData: validCert,
+				ClientKeyData:         []byte("new-key"),
+			},
+		},
+		{
+			name: "rotated certificate file",
+			diskCfg: newKubeconfigWithAuthInfo(&clientcmdapi.AuthInfo{
+				ClientCertificate: "/home/okteto/.kube/client.crt",
+				ClientKey:         "/home/okteto/.kube/client.key",
+			}),
+			files: map[string][]byte{
+				"/home/okteto/.kube/client.crt": validCert,
+			},
+			expectedAuthInfo: &clientcmdapi.AuthInfo{
+				ClientCertificate: "/home/okteto/.kube/client.crt",
+				ClientKey:         "/home/okteto/.kube/client.key",
+			},
+		},
+		{
+			name: "certificate on disk is also expired",
+			diskCfg: newKubeconfigWithAuthInfo(&clientcmdapi.AuthInfo{
+				ClientCertificateData: expiredCert,
+				ClientKeyData:         []byte("old-key"),
+			}),
+			expectedAuthInfo: &clientcmdapi.AuthInfo{
+				ClientCertificateData: expiredCert,
+				ClientKeyData:         []byte("old-key"),
+			},
+			expectedErr: "the client certificate of user 'kind-admin' in context 'kind-test' expired on 2024-06-01T11:00:00Z",
+		},
+		{
+			name: "certificate file not found",
+			diskCfg: newKubeconfigWithAuthInfo(&clientcmdapi.AuthInfo{
+				ClientCertificate: "/home/okteto/.kube/client.crt",
+			}),
+			expectedAuthInfo: &clientcmdapi.AuthInfo{
+				ClientCertificateData: expiredCert,
+				ClientKeyData:         []byte("old-key"),
+			},
+			expectedErr: "the client certificate of user 'kind-admin' in context 'kind-test' has expired",
+		},
+		{
+			name: "context removed from disk",
+			diskCfg: &clientcmdapi.Config{
+				Contexts:  map[string]*clientcmdapi.Context{},
+				AuthInfos: map[string]*clientcmdapi.AuthInfo{},
+			},
+			expectedAuthInfo: &clientcmdapi.AuthInfo{
+				ClientCertificateData: expiredCert,
+				ClientKeyData:         []byte("old-key"),
+			},
+			expectedErr: "the client certificate of user 'kind-admin' in context 'kind-test' has expired",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			okteto.CurrentStore = &okteto.ContextStore{
+				CurrentContext: "kind-test",
+				Contexts: map[string]*okteto.Context{
+					"kind-test": {
+						Name: "kind-test",
+						Cfg: newKubeconfigWithAuthInfo(&clientcmdapi.AuthInfo{
+							ClientCertificateData: expiredCert,
+							ClientKeyData:         []byte("old-key"),
+						}),
+					},
+				},
+			}
+			fs := afero.NewMemMapFs()
+			for name, content := range tt.files {
+				require.NoError(t, afero.WriteFile(fs, name, content, 0600))
+			}
+			reloader := &kubeconfigReloaderController{
+				fs:  fs,
+				now: func() time.Time { return now },
+				loadKubeconfig: func() (*clientcmdapi.Config, error) {
+					return tt.diskCfg, nil
+				},
+			}
+
+			err := reloader.Reload()
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedAuthInfo, okteto.GetContext().Cfg.AuthInfos["kind-admin"])
+		})
+	}
+}
+
+func TestKubeconfigReloaderWithoutConfig(t *testing.T) {
+	okteto.CurrentStore = &okteto.ContextStore{
+		CurrentContext: "kind-test",
+		Contexts: map[string]*okteto.Context{
+			"kind-test": {
+				Name: "kind-test",
+			},
+		},
+	}
+	reloader := newKubeconfigReloaderController(afero.NewMemMapFs())
+	require.ErrorIs(t, reloader.Reload(), errConfigNotConfigured)
+}
+
+func TestKubeconfigReloaderWithoutClientCertificate(t *testing.T) {
+	okteto.CurrentStore = &okteto.ContextStore{
+		CurrentContext: "kind-test",
+		Contexts: map[string]*okteto.Context{
+			"kind-test": {
+				Name: "kind-test",
+				Cfg:  newKubeconfigWithAuthInfo(&clientcmdapi.AuthInfo{Token: "token"}),
+			},
+		},
+	}
+	reloader := &kubeconfigReloaderController{
+		fs:  afero.NewMemMapFs(),
+		now: time.Now,
+		loadKubeconfig: func() (*clientcmdapi.Config, error) {
+			t.Fatal("the kubeconfig must not be loaded")
+			return nil, nil
+		},
+	}
+	require.ErrorIs(t, reloader.Reload(), errNoClientCertificate)
+	assert.Equal(t, &clientcmdapi.AuthInfo{Token: "token"}, okteto.GetContext().Cfg.AuthInfos["kind-admin"])
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/k8s/apps"
+	forwardk8s "github.com/okteto/okteto/pkg/k8s/forward"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/okteto/okteto/pkg/ssh"
+	"github.com/okteto/okteto/pkg/syncthing"
+	"github.com/spf13/afero"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// k8sAppRetriever retrieves the app of the development container from the cluster
+type k8sAppRetriever struct{}
+
+// GetApp returns the app of the development container and if it has to be created
+func (k8sAppRetriever) GetApp(ctx context.Context, dev *model.Dev, namespace string, c kubernetes.Interface, isRetry bool) (apps.App, bool, error) {
+	return utils.GetApp(ctx, dev, namespace, c, isRetry)
+}
+
+// k8sPodWaiter waits for the pod of the development container in the cluster
+type k8sPodWaiter struct{}
+
+// WaitForRunningPod returns the pod of the development container once it is running
+func (k8sPodWaiter) WaitForRunningPod(ctx context.Context, dev *model.Dev, app apps.App, c kubernetes.Interface, timeout time.Duration) (*apiv1.Pod, error) {
+	return apps.GetRunningPodInLoop(ctx, dev, app, c, timeout)
+}
+
+// portForwarderFactory creates the kubernetes and SSH port forwarders
+type portForwarderFactory struct{}
+
+// NewPortForwarder returns a forwarder based on the kubernetes port-forward API
+func (portForwarderFactory) NewPortForwarder(ctx context.Context, dev *model.Dev, restConfig *rest.Config, c kubernetes.Interface, namespace string) forwardk8s.Forwarder {
+	return forwardk8s.NewPortForwardManager(ctx, dev.Interface, restConfig, c, namespace)
+}
+
+// NewSSHForwarder returns a forwarder through the SSH server of the development container
+func (portForwarderFactory) NewSSHForwarder(ctx context.Context, dev *model.Dev, restConfig *rest.Config, c kubernetes.Interface, namespace string) (forwardk8s.Forwarder, error) {
+	f := forwardk8s.NewPortForwardManager(ctx, dev.Interface, restConfig, c, namespace)
+	if err := f.Add(forward.Forward{Local: dev.RemotePort, Remote: dev.SSHServerPort}); err != nil {
+		return nil, err
+	}
+	return ssh.NewForwardManager(ctx, fmt.Sprintf(":%d", dev.RemotePort), dev.Interface, "0.0.0.0", f, namespace), nil
+}
+
+// localSyncthingController manages the local syncthing processes
+type localSyncthingController struct{}
+
+// New returns a syncthing instance for the development container
+func (localSyncthingController) New(dev *model.Dev, namespace string, fs afero.Fs) (*syncthing.Syncthing, error) {
+	return syncthing.New(dev, namespace, fs)
+}
+
+// Ping returns if the local or remote syncthing is responding
+func (localSyncthingController) Ping(ctx context.Context, sy *syncthing.Syncthing, local bool) bool {
+	return sy.Ping(ctx, local)
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/okteto/okteto/pkg/log/io"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
 	"github.com/spf13/afero"
 	"k8s.io/client-go/kubernetes"
 )
@@ -45,6 +46,7 @@ type devEnvDeployerManager struct {
 
 	k8sClientProvider okteto.K8sClientProvider
 	ioCtrl            *io.Controller
+	events            *eventsPublisher
 	getDeployer       func(deployParams) (deployer, error)
 }
 
@@ -59,6 +61,10 @@ type deployParams struct {
 	devenvName, ns                 string
 	manifestPathFlag, manifestPath string
 	manifest                       *model.Manifest
+	// timeout is the timeout of the deploy set by the user, zero to use the one of the manifest or the default one
+	timeout time.Duration
+	// allowPrivileged deploys the compose services with 'privileged' or 'devices'
+	allowPrivileged bool
 }
 
 // NewDevEnvDeployerManager creates a new DevEnvDeployer
@@ -66,6 +72,7 @@ func NewDevEnvDeployerManager(up *upContext, ioCtrl *io.Controller, k8sLogger *i
 	return &devEnvDeployerManager{
 		ioCtrl:            ioCtrl,
 		k8sClientProvider: up.K8sClientProvider,
+		events:            up.events,
 		isDevEnvDeployed:  pipeline.IsDeployed,
 		getDeployer: func(params deployParams) (deployer, error) {
 			k8sProvider := okteto.NewK8sClientProviderWithLogger(k8sLogger)
@@ -116,19 +123,26 @@ func (dd *devEnvDeployerManager) DeployIfNeeded(ctx context.Context, params depl
 
 	isAlreadyDeployed := !mustDeploy && dd.isDevEnvDeployed(ctx, params.devenvName, params.ns, k8sClient)
 	if mustDeploy || !isAlreadyDeployed {
+		dd.events.publishPhase(types.UpPhaseDeploying)
 		deployer, err := dd.getDeployer(params)
 		if err != nil {
 			dd.ioCtrl.Logger().Infof("failed to create deployer: %s", err)
 			return fmt.Errorf("failed to create deployer: %w", err)
 		}
 
+		timeout := params.timeout
+		if timeout == 0 {
+			timeout = deploy.GetDefaultTimeout()
+		}
 		deployOpts := &deploy.Options{
 			Name:             params.devenvName,
 			Namespace:        params.ns,
 			ManifestPathFlag: params.manifestPathFlag,
 			ManifestPath:     params.manifestPath,
-			Timeout:          5 * time.Minute,
+			Timeout:          timeout,
+			TimeoutSet:       params.timeout != 0,
 			NoBuild:          false,
+			AllowPrivileged:  params.allowPrivileged,
 		}
 		startTime := time.Now()
 		err = deployer.Run(ctx, deployOpts)
@@ -0,0 +1,168 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/env"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/services"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// resolveEnvironmentReferences resolves the 'fromService' environment variables of the development container
+// and its services into concrete values. It runs on every activation so reconnections pick up the latest state
+func resolveEnvironmentReferences(ctx context.Context, dev *model.Dev, namespace string, c kubernetes.Interface) error {
+	devs := append([]*model.Dev{dev}, dev.Services...)
+	for _, d := range devs {
+		for i := range d.Environment {
+			ref := d.Environment[i].FromService
+			if ref == nil {
+				continue
+			}
+			value, err := resolveServiceRef(ctx, ref, namespace, c)
+			if err != nil {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("failed to resolve the environment variable '%s' of '%s': %w", d.Environment[i].Name, d.Name, err),
+					Hint: "Check the 'fromService' references of the environment section of your okteto manifest",
+				}
+			}
+			oktetoLog.Infof("resolved environment variable '%s' from service '%s'", d.Environment[i].Name, ref.Name)
+			d.Environment[i].Value = value
+			if d.Environment[i].Secret {
+				oktetoLog.AddSecret(value)
+			}
+		}
+	}
+	return nil
+}
+
+// resolveServiceRef returns the value of a 'fromService' reference, like "postgres://:password@postgres:5432"
+func resolveServiceRef(ctx context.Context, ref *env.ServiceRef, namespace string, c kubernetes.Interface) (string, error) {
+	svc, err := services.Get(ctx, ref.Name, namespace, c)
+	if err != nil {
+		if !oktetoErrors.IsNotFound(err) {
+			return "", err
+		}
+		available, errList := listServiceNames(ctx, namespace, c)
+		if errList != nil {
+			return "", fmt.Errorf("service '%s' not found", ref.Name)
+		}
+		return "", fmt.Errorf("service '%s' not found, available services: %s", ref.Name, formatAvailable(available))
+	}
+
+	port, err := getServiceRefPort(svc, ref.Port)
+	if err != nil {
+		return "", err
+	}
+	host := net.JoinHostPort(ref.Name, strconv.Itoa(port))
+	if ref.Scheme == "" {
+		return host, nil
+	}
+
+	u := url.URL{
+		Scheme: ref.Scheme,
+		Host:   host,
+		Path:   ref.Path,
+	}
+	if ref.SecretRef != nil {
+		password, err := getSecretRefValue(ctx, ref.SecretRef, namespace, c)
+		if err != nil {
+			return "", err
+		}
+		u.User = url.UserPassword(ref.User, password)
+	} else if ref.User != "" {
+		u.User = url.User(ref.User)
+	}
+	return u.String(), nil
+}
+
+// getServiceRefPort returns the port of the service to reference. It defaults to the first one
+func getServiceRefPort(svc *apiv1.Service, port int) (int, error) {
+	if len(svc.Spec.Ports) == 0 {
+		return 0, fmt.Errorf("service '%s' doesn't expose any port", svc.Name)
+	}
+	if port == 0 {
+		return int(svc.Spec.Ports[0].Port), nil
+	}
+	available := make([]string, 0, len(svc.Spec.Ports))
+	for _, p := range svc.Spec.Ports {
+		if int(p.Port) == port {
+			return port, nil
+		}
+		available = append(available, strconv.Itoa(int(p.Port)))
+	}
+	return 0, fmt.Errorf("service '%s' doesn't expose the port %d, available ports: %s", svc.Name, port, formatAvailable(available))
+}
+
+// getSecretRefValue returns the value of the key of the referenced secret
+func getSecretRefValue(ctx context.Context, ref *env.SecretKeyRef, namespace string, c kubernetes.Interface) (string, error) {
+	secret, err := c.CoreV1().Secrets(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		if !oktetoErrors.IsNotFound(err) {
+			return "", err
+		}
+		list, errList := c.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{})
+		if errList != nil {
+			return "", fmt.Errorf("secret '%s' not found", ref.Name)
+		}
+		available := make([]string, 0, len(list.Items))
+		for _, s := range list.Items {
+			available = append(available, s.Name)
+		}
+		return "", fmt.Errorf("secret '%s' not found, available secrets: %s", ref.Name, formatAvailable(available))
+	}
+
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		available := make([]string, 0, len(secret.Data))
+		for key := range secret.Data {
+			available = append(available, key)
+		}
+		return "", fmt.Errorf("secret '%s' doesn't have the key '%s', available keys: %s", ref.Name, ref.Key, formatAvailable(available))
+	}
+	return string(value), nil
+}
+
+func listServiceNames(ctx context.Context, namespace string, c kubernetes.Interface) ([]string, error) {
+	list, err := services.List(ctx, namespace, "", c)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(list))
+	for _, svc := range list {
+		names = append(names, svc.Name)
+	}
+	return names, nil
+}
+
+// formatAvailable returns a sorted and quoted list of names to be displayed in error messages
+func formatAvailable(names []string) string {
+	if len(names) == 0 {
+		return "none"
+	}
+	sort.Strings(names)
+	return fmt.Sprintf("'%s'", strings.Join(names, "', '"))
+}
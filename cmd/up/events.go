@@ -0,0 +1,207 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/okteto/okteto/pkg/types"
+)
+
+const (
+	// maxEventsHistory is the number of events sent to the clients when they connect
+	maxEventsHistory = 100
+
+	// eventsWriteTimeout is the maximum time to send an event to a client before disconnecting it
+	eventsWriteTimeout = time.Second
+)
+
+// eventsPublisher publishes the okteto up events to the clients of the events socket, so IDEs know the state of up.
+// The events published before a client connects are sent to it on connection, so it doesn't miss any phase.
+// A nil eventsPublisher discards the events
+type eventsPublisher struct {
+	listener net.Listener
+	clients  map[net.Conn]struct{}
+	now      func() time.Time
+	phase    types.UpPhase
+	history  [][]byte
+	mu       sync.Mutex
+	closed   bool
+}
+
+func newEventsPublisher() *eventsPublisher {
+	return &eventsPublisher{
+		clients: map[net.Conn]struct{}{},
+		now:     time.Now,
+	}
+}
+
+// listen starts accepting clients in the events socket
+func (p *eventsPublisher) listen(path string) error {
+	if p == nil {
+		return nil
+	}
+	l, err := types.ListenUpEvents(path)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return l.Close()
+	}
+	p.listener = l
+	p.mu.Unlock()
+
+	oktetoLog.Infof("publishing up events in '%s'", path)
+	go p.accept(l)
+	return nil
+}
+
+func (p *eventsPublisher) accept(l net.Listener) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				oktetoLog.Infof("failed to accept events client: %s", err)
+			}
+			return
+		}
+
+		p.mu.Lock()
+		if p.closed {
+			p.mu.Unlock()
+			_ = conn.Close()
+			return
+		}
+		if p.write(conn, p.history...) {
+			p.clients[conn] = struct{}{}
+		}
+		p.mu.Unlock()
+	}
+}
+
+// write sends the events to the client, closing it if it fails. It must be called with the lock held
+func (p *eventsPublisher) write(conn net.Conn, events ...[]byte) bool {
+	if err := conn.SetWriteDeadline(time.Now().Add(eventsWriteTimeout)); err != nil {
+		oktetoLog.Infof("failed to set events write deadline: %s", err)
+	}
+	for _, e := range events {
+		if _, err := conn.Write(e); err != nil {
+			oktetoLog.Infof("disconnecting events client: %s", err)
+			_ = conn.Close()
+			return false
+		}
+	}
+	return true
+}
+
+func (p *eventsPublisher) publish(e types.UpEvent) {
+	if p == nil {
+		return
+	}
+	e.Timestamp = p.now()
+	e.SchemaVersion = types.UpEventsSchemaVersion
+	b, err := json.Marshal(e)
+	if err != nil {
+		oktetoLog.Infof("failed to encode up event: %s", err)
+		return
+	}
+	b = append(b, '\n')
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return
+	}
+	p.history = append(p.history, b)
+	if len(p.history) > maxEventsHistory {
+		p.history = p.history[len(p.history)-maxEventsHistory:]
+	}
+	for conn := range p.clients {
+		if !p.write(conn, b) {
+			delete(p.clients, conn)
+		}
+	}
+}
+
+func (p *eventsPublisher) publishPhase(phase types.UpPhase) {
+	if p != nil {
+		p.mu.Lock()
+		p.phase = phase
+		p.mu.Unlock()
+	}
+	p.publish(types.UpEvent{Type: types.UpEventPhase, Phase: phase})
+}
+
+// currentPhase returns the last phase published, or an empty phase if none was published yet
+func (p *eventsPublisher) currentPhase() types.UpPhase {
+	if p == nil {
+		return ""
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.phase
+}
+
+func (p *eventsPublisher) publishSyncProgress(progress float64) {
+	p.publish(types.UpEvent{Type: types.UpEventSyncProgress, SyncProgress: progress})
+}
+
+func (p *eventsPublisher) publishForwards(forwards []forward.Forward, status types.UpForwardStatus) {
+	for _, f := range forwards {
+		p.publish(types.UpEvent{
+			Type: types.UpEventForward,
+			Forward: &types.UpForward{
+				Status:      status,
+				ServiceName: f.ServiceName,
+				Local:       f.Local,
+				Remote:      f.Remote,
+			},
+		})
+	}
+}
+
+func (p *eventsPublisher) publishError(err error) {
+	p.publish(types.UpEvent{Type: types.UpEventError, Error: err.Error()})
+}
+
+// close disconnects the clients and removes the events socket
+func (p *eventsPublisher) close() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return
+	}
+	p.closed = true
+	for conn := range p.clients {
+		_ = conn.Close()
+	}
+	p.clients = map[net.Conn]struct{}{}
+	if p.listener != nil {
+		if err := p.listener.Close(); err != nil {
+			oktetoLog.Infof("failed to close the events socket: %s", err)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !windows
+
+package up
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEventsPublisher(t *testing.T) (*eventsPublisher, string) {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "events")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, "okteto-events.sock")
+	p := newEventsPublisher()
+	p.now = func() time.Time { return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) }
+	require.NoError(t, p.listen(path))
+	t.Cleanup(p.close)
+	return p, path
+}
+
+func dialTestEvents(t *testing.T, path string) *types.UpEventsClient {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	c, err := types.DialUpEvents(ctx, path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func nextTestEvent(t *testing.T, c *types.UpEventsClient) *types.UpEvent {
+	t.Helper()
+	e, err := c.Next()
+	require.NoError(t, err)
+	assert.Equal(t, types.UpEventsSchemaVersion, e.SchemaVersion)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), e.Timestamp)
+	return e
+}
+
+func TestEventsPublisherOrdering(t *testing.T) {
+	p, path := newTestEventsPublisher(t)
+
+	// events published before the client connects are replayed
+	p.publishPhase(types.UpPhaseDeploying)
+	p.publishPhase(types.UpPhaseBuilding)
+	c := dialTestEvents(t, path)
+
+	assert.Equal(t, types.UpPhaseDeploying, nextTestEvent(t, c).Phase)
+	assert.Equal(t, types.UpPhaseBuilding, nextTestEvent(t, c).Phase)
+
+	p.publishPhase(types.UpPhaseActivating)
+	p.publishForwards([]forward.Forward{{Local: 8080, Remote: 80}}, types.UpForwardActive)
+	p.publishPhase(types.UpPhaseSyncing)
+	p.publishSyncProgress(50)
+	p.publishPhase(types.UpPhaseReady)
+	p.publishError(errors.New("connection lost"))
+
+	assert.Equal(t, types.UpPhaseActivating, nextTestEvent(t, c).Phase)
+
+	e := nextTestEvent(t, c)
+	assert.Equal(t, types.UpEventForward, e.Type)
+	assert.Equal(t, &types.UpForward{Status: types.UpForwardActive, Local: 8080, Remote: 80}, e.Forward)
+
+	assert.Equal(t, types.UpPhaseSyncing, nextTestEvent(t, c).Phase)
+
+	e = nextTestEvent(t, c)
+	assert.Equal(t, types.UpEventSyncProgress, e.Type)
+	assert.Equal(t, float64(50), e.SyncProgress)
+
+	assert.Equal(t, types.UpPhaseReady, nextTestEvent(t, c).Phase)
+
+	e = nextTestEvent(t, c)
+	assert.Equal(t, types.UpEventError, e.Type)
+	assert.Equal(t, "connection lost", e.Error)
+}
+
+func TestEventsPublisherHistoryLimit(t *testing.T) {
+	p, path := newTestEventsPublisher(t)
+
+	for i := 0; i <= maxEventsHistory; i++ {
+		p.publishSyncProgress(float64(i))
+	}
+	c := dialTestEvents(t, path)
+
+	assert.Equal(t, float64(1), nextTestEvent(t, c).SyncProgress)
+}
+
+func TestEventsPublisherClose(t *testing.T) {
+	p, path := newTestEventsPublisher(t)
+	p.publishPhase(types.UpPhaseReady)
+	c := dialTestEvents(t, path)
+	nextTestEvent(t, c)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	p.close()
+	p.close()
+
+	_, err = c.Next()
+	assert.ErrorIs(t, err, io.EOF)
+
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+
+	// events published after close are discarded
+	p.publishPhase(types.UpPhaseReady)
+}
+
+func TestNilEventsPublisher(t *testing.T) {
+	var p *eventsPublisher
+	require.NoError(t, p.listen("okteto-events.sock"))
+	p.publishPhase(types.UpPhaseReady)
+	p.publishForwards([]forward.Forward{{Local: 8080, Remote: 80}}, types.UpForwardStopped)
+	p.publishError(errors.New("error"))
+	p.close()
+	assert.Empty(t, p.currentPhase())
+}
+
+func TestEventsPublisherCurrentPhase(t *testing.T) {
+	p := newEventsPublisher()
+	assert.Empty(t, p.currentPhase())
+
+	p.publishPhase(types.UpPhaseBuilding)
+	p.publishSyncProgress(50)
+	assert.Equal(t, types.UpPhaseBuilding, p.currentPhase())
+
+	p.publishPhase(types.UpPhaseSyncing)
+	assert.Equal(t, types.UpPhaseSyncing, p.currentPhase())
+}
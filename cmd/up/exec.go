@@ -41,6 +41,7 @@ import (
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/registry"
 	"github.com/okteto/okteto/pkg/ssh"
+	"github.com/okteto/okteto/pkg/types"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
 )
@@ -109,10 +110,11 @@ func expandExecutableInCurrentDirectory(args0, dir string) (string, error) {
 type syncExecutor struct {
 	iface      string
 	remotePort int
+	envs       []string
 }
 
 func (se *syncExecutor) RunCommand(ctx context.Context, cmd []string) error {
-	return ssh.Exec(ctx, se.iface, se.remotePort, true, os.Stdin, os.Stdout, os.Stderr, cmd)
+	return ssh.ExecWithEnv(ctx, se.iface, se.remotePort, true, os.Stdin, os.Stdout, os.Stderr, cmd, se.envs)
 }
 
 func NewHybridExecutor(ctx context.Context, hybridCtx *HybridExecCtx) (*hybridExecutor, error) {
@@ -136,6 +138,7 @@ func newSyncExecutor(up *upContext) *syncExecutor {
 	return &syncExecutor{
 		iface:      up.Dev.Interface,
 		remotePort: up.Dev.RemotePort,
+		envs:       up.Dev.GetPassthroughEnvironment(os.Environ()),
 	}
 }
 
@@ -252,6 +255,9 @@ func (eg *envsGetter) getEnvs(ctx context.Context) ([]string, error) {
 
 	envs = append(envs, eg.getDefaultLocalEnvs()...)
 
+	// the variables of the environment of the manifest take precedence
+	envs = append(envs, eg.dev.GetPassthroughEnvironment(os.Environ())...)
+
 	for _, env := range eg.dev.Environment {
 		envs = append(envs, fmt.Sprintf("%s=%s", env.Name, env.Value))
 	}
@@ -429,6 +435,7 @@ func (up *upContext) RunCommand(ctx context.Context, cmd []string) error {
 	if err := config.UpdateStateFile(up.Dev.Name, up.Namespace, config.Ready); err != nil {
 		return err
 	}
+	up.events.publishPhase(types.UpPhaseReady)
 
 	k8sClient, restConfig, err := up.K8sClientProvider.Provide(okteto.GetContext().Cfg)
 	if err != nil {
@@ -459,12 +466,12 @@ func (up *upContext) RunCommand(ctx context.Context, cmd []string) error {
 			return executor.RunCommand(cmd)
 		} else {
 			executor := newSyncExecutor(up)
-			return executor.RunCommand(ctx, cmd)
+			return executor.RunCommand(ctx, up.Dev.InWorkdirCommand(up.Dev.RunAsCommand(cmd)))
 		}
 
 	}
 
-	return k8sExec.Exec(
+	return k8sExec.ExecWithEnv(
 		ctx,
 		k8sClient,
 		restConfig,
@@ -475,7 +482,8 @@ func (up *upContext) RunCommand(ctx context.Context, cmd []string) error {
 		os.Stdin,
 		os.Stdout,
 		os.Stderr,
-		cmd,
+		up.Dev.RunAsCommand(cmd),
+		up.Dev.GetPassthroughEnvironment(os.Environ()),
 	)
 }
 
@@ -426,6 +426,50 @@ func TestGetEnvForHybridModeWithProperPriority(t *testing.T) {
 	require.Equal(t, expectedEnvsSortedByPriority, envs)
 }
 
+func TestGetEnvForHybridModeWithPassthrough(t *testing.T) {
+	t.Setenv("PASSTHROUGH_TOKEN", "local-token")
+	t.Setenv("PASSTHROUGH_REGION", "local-region")
+	t.Setenv("OTHER_TOKEN", "other-token")
+
+	client := fake.NewSimpleClientset(&appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "test",
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{{}},
+				},
+			},
+		},
+	})
+	dev := &model.Dev{
+		Name:                   "test",
+		EnvironmentPassthrough: []string{"PASSTHROUGH_*"},
+		Environment: env.Environment{
+			env.Var{
+				Name:  "PASSTHROUGH_REGION",
+				Value: "manifest-region",
+			},
+		},
+	}
+	eg := envsGetter{
+		dev:                         dev,
+		name:                        "test",
+		namespace:                   "test",
+		client:                      client,
+		devContainerEnvGetter:       &fakeGetter{},
+		configMapEnvsGetter:         &fakeGetter{},
+		platformVariablesEnvsGetter: &fakeGetter{},
+		imageEnvsGetter:             &fakeGetter{},
+		getDefaultLocalEnvs:         func() []string { return []string{} },
+	}
+	envs, err := eg.getEnvs(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"PASSTHROUGH_TOKEN=local-token", "PASSTHROUGH_REGION=manifest-region"}, envs)
+}
+
 type fakeImageGetter struct {
 	err           error
 	imageMetadata registry.ImageMetadata
@@ -17,15 +17,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
-	forwardk8s "github.com/okteto/okteto/pkg/k8s/forward"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model/forward"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/ssh"
 	"github.com/okteto/okteto/pkg/syncthing"
+	"github.com/okteto/okteto/pkg/types"
 )
 
 func (up *upContext) forwards(ctx context.Context) error {
@@ -47,7 +48,7 @@ func (up *upContext) forwards(ctx context.Context) error {
 	}
 
 	oktetoLog.Infof("starting port forwards")
-	up.Forwarder = forwardk8s.NewPortForwardManager(ctx, up.Dev.Interface, restConfig, k8sClient, up.Namespace)
+	up.Forwarder = up.forwarderFactory.NewPortForwarder(ctx, up.Dev, restConfig, k8sClient, up.Namespace)
 
 	for idx, f := range up.Dev.Forward {
 		if f.Labels != nil {
@@ -75,13 +76,9 @@ func (up *upContext) forwards(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	up.events.publishForwards(up.Dev.Forward, types.UpForwardActive)
 
-	if isNeededGlobalForwarder(up.Manifest.GlobalForward) {
-		up.GlobalForwarderStatus = make(chan error, 1)
-		go up.setGlobalForwardsIfRequiredLoop(ctx)
-	}
-
-	return nil
+	return up.startGlobalForwards(ctx)
 }
 
 func (up *upContext) sshForwards(ctx context.Context) error {
@@ -96,12 +93,10 @@ func (up *upContext) sshForwards(ctx context.Context) error {
 	up.Dev.AssignRemotePort()
 
 	oktetoLog.Infof("starting SSH port forwards")
-	f := forwardk8s.NewPortForwardManager(ctx, up.Dev.Interface, restConfig, k8sClient, up.Namespace)
-	if err := f.Add(forward.Forward{Local: up.Dev.RemotePort, Remote: up.Dev.SSHServerPort}); err != nil {
+	up.Forwarder, err = up.forwarderFactory.NewSSHForwarder(ctx, up.Dev, restConfig, k8sClient, up.Namespace)
+	if err != nil {
 		return err
 	}
-
-	up.Forwarder = ssh.NewForwardManager(ctx, fmt.Sprintf(":%d", up.Dev.RemotePort), up.Dev.Interface, "0.0.0.0", f, up.Namespace)
 	if err := up.Forwarder.Add(forward.Forward{Local: up.Sy.RemotePort, Remote: syncthing.ClusterPort}); err != nil {
 		return err
 	}
@@ -118,18 +113,14 @@ func (up *upContext) sshForwards(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	up.events.publishForwards(up.Dev.Forward, types.UpForwardActive)
 
 	if err := ssh.AddEntry(up.Dev.Name, up.Dev.Interface, up.Dev.RemotePort); err != nil {
 		oktetoLog.Infof("failed to add entry to your SSH config file: %s", err)
 		return fmt.Errorf("failed to add entry to your SSH config file")
 	}
 
-	if isNeededGlobalForwarder(up.Manifest.GlobalForward) {
-		up.GlobalForwarderStatus = make(chan error, 1)
-		go up.setGlobalForwardsIfRequiredLoop(ctx)
-	}
-
-	return nil
+	return up.startGlobalForwards(ctx)
 }
 
 func addToForwarder(up *upContext) error {
@@ -193,8 +184,33 @@ func addToForwarder(up *upContext) error {
 	}
 }
 
+// startGlobalForwards registers the session as a user of the global forwards of the manifest and
+// starts the loop that establishes the ones owned by the session
+func (up *upContext) startGlobalForwards(ctx context.Context) error {
+	if len(up.Manifest.GlobalForward) == 0 || up.globalForwards == nil {
+		return nil
+	}
+
+	definitions := map[int]globalForwardDefinition{}
+	for idx, gf := range up.Manifest.GlobalForward {
+		// every activation creates a new forwarder, so the global forwards have to be added again
+		up.Manifest.GlobalForward[idx].IsAdded = false
+		definitions[gf.Local] = newGlobalForwardDefinition(gf, okteto.GetContext().Name, up.Namespace)
+	}
+	if err := up.globalForwards.acquire(definitions); err != nil {
+		return err
+	}
+
+	up.GlobalForwarderStatus = make(chan error, 1)
+	go up.setGlobalForwardsIfRequiredLoop(ctx)
+	return nil
+}
+
+// setGlobalForwardsIfRequiredLoop establishes the global forwards claimed by the session. It keeps
+// running while any of them is established by another session, to take it over when that session ends
 func (up *upContext) setGlobalForwardsIfRequiredLoop(ctx context.Context) {
 	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
 
 	for {
 		if !isNeededGlobalForwarder(up.Manifest.GlobalForward) {
@@ -203,14 +219,36 @@ func (up *upContext) setGlobalForwardsIfRequiredLoop(ctx context.Context) {
 
 		select {
 		case <-ticker.C:
-			err := addGlobalForwards(up)
+			ports, err := up.globalForwards.claim()
 			if err != nil {
 				up.GlobalForwarderStatus <- err
 				return
 			}
 
-			err = up.Forwarder.StartGlobalForwarding()
-			if err != nil {
+			added := false
+			for idx, gf := range up.Manifest.GlobalForward {
+				if gf.IsAdded || !slices.Contains(ports, gf.Local) {
+					continue
+				}
+				err := addGlobalForward(up, idx)
+				if err == nil {
+					added = true
+					continue
+				}
+				if !errors.Is(err, oktetoErrors.ErrPortAlreadyAllocated) {
+					up.GlobalForwarderStatus <- err
+					return
+				}
+				oktetoLog.Infof("local port %d of the global forward is busy: %s", gf.Local, err)
+				if err := up.globalForwards.unclaim(gf.Local); err != nil {
+					oktetoLog.Infof("failed to unclaim the global forward of the local port %d: %s", gf.Local, err)
+				}
+			}
+			if !added {
+				continue
+			}
+
+			if err := up.Forwarder.StartGlobalForwarding(); err != nil {
 				up.GlobalForwarderStatus <- err
 				return
 			}
@@ -236,33 +274,40 @@ func addGlobalForwards(up *upContext) error {
 			continue
 		}
 
-		f := forward.Forward{
-			Local:       gf.Local,
-			Remote:      gf.Remote,
-			Service:     true,
-			IsGlobal:    true,
-			ServiceName: gf.ServiceName,
-			Labels:      gf.Labels,
-		}
-
-		if gf.Labels != nil {
-			forwardWithServiceName, err := up.Forwarder.TransformLabelsToServiceName(f)
-			if err != nil {
+		if err := addGlobalForward(up, idx); err != nil {
+			if !errors.Is(err, oktetoErrors.ErrPortAlreadyAllocated) {
 				return err
 			}
-			up.Manifest.GlobalForward[idx].ServiceName = forwardWithServiceName.ServiceName
-			f = forwardWithServiceName
 		}
+	}
 
-		err := up.Forwarder.Add(f)
+	return nil
+}
+
+// addGlobalForward adds the global forward of the manifest at position idx to the forwarder
+func addGlobalForward(up *upContext, idx int) error {
+	gf := up.Manifest.GlobalForward[idx]
+	f := forward.Forward{
+		Local:       gf.Local,
+		Remote:      gf.Remote,
+		Service:     true,
+		IsGlobal:    true,
+		ServiceName: gf.ServiceName,
+		Labels:      gf.Labels,
+	}
+
+	if gf.Labels != nil {
+		forwardWithServiceName, err := up.Forwarder.TransformLabelsToServiceName(f)
 		if err != nil {
-			if !errors.Is(err, oktetoErrors.ErrPortAlreadyAllocated) {
-				return err
-			}
-		} else {
-			up.Manifest.GlobalForward[idx].IsAdded = true
+			return err
 		}
+		up.Manifest.GlobalForward[idx].ServiceName = forwardWithServiceName.ServiceName
+		f = forwardWithServiceName
 	}
 
+	if err := up.Forwarder.Add(f); err != nil {
+		return err
+	}
+	up.Manifest.GlobalForward[idx].IsAdded = true
 	return nil
 }
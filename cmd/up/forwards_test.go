@@ -19,11 +19,15 @@ import (
 	"testing"
 
 	"github.com/okteto/okteto/internal/test"
+	fakeUp "github.com/okteto/okteto/internal/test/up"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/model/forward"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/ssh"
+	"github.com/okteto/okteto/pkg/syncthing"
 	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestGlobalForwarderStartsWhenRequired(t *testing.T) {
@@ -164,6 +168,7 @@ func TestForwards(t *testing.T) {
 					},
 				},
 				K8sClientProvider: tc.clientProvider,
+				forwarderFactory:  portForwarderFactory{},
 			}
 			t.Setenv(model.OktetoExecuteSSHEnvVar, tc.OktetoExecuteSSHEnvVar)
 			err := up.forwards(context.Background())
@@ -208,3 +213,44 @@ func TestSSHForwarss(t *testing.T) {
 		})
 	}
 }
+
+func TestForwardsWithFakeForwarder(t *testing.T) {
+	t.Setenv(model.OktetoExecuteSSHEnvVar, "false")
+	fwd := &fakeUp.FakeForwarder{}
+	up := &upContext{
+		Namespace: "test",
+		Dev: &model.Dev{
+			Forward: []forward.Forward{
+				{Local: 8080, Remote: 80},
+			},
+		},
+		Manifest:          &model.Manifest{},
+		Pod:               &apiv1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api-123"}},
+		Sy:                &syncthing.Syncthing{RemotePort: 22001, RemoteGUIPort: 8385},
+		K8sClientProvider: test.NewFakeK8sProvider(),
+		forwarderFactory:  &fakeUp.FakeForwarderFactory{Forwarder: fwd},
+	}
+
+	err := up.forwards(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []forward.Forward{
+		{Local: 8080, Remote: 80},
+		{Local: 22001, Remote: syncthing.ClusterPort},
+		{Local: 8385, Remote: syncthing.GUIPort},
+	}, fwd.Forwards)
+	assert.True(t, fwd.Started)
+	assert.Equal(t, "api-123", fwd.StartedPod)
+}
+
+func TestSSHForwardsWithFactoryError(t *testing.T) {
+	up := &upContext{
+		Dev:               &model.Dev{},
+		K8sClientProvider: test.NewFakeK8sProvider(),
+		forwarderFactory:  &fakeUp.FakeForwarderFactory{ErrSSH: assert.AnError},
+	}
+
+	err := up.sshForwards(context.Background())
+
+	assert.ErrorIs(t, err, assert.AnError)
+}
@@ -0,0 +1,263 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/gofrs/flock"
+	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/okteto/okteto/pkg/process"
+)
+
+const (
+	// globalForwardsFile stores the global forwards shared by the okteto up sessions of the machine
+	globalForwardsFile = "global-forwards.json"
+
+	// globalForwardsLockFile serializes the access of concurrent okteto up sessions to globalForwardsFile
+	globalForwardsLockFile = "global-forwards.lock"
+)
+
+// globalForwardDefinition is the target of a global forward. Sessions can only share a local port
+// if they forward it to the same target
+type globalForwardDefinition struct {
+	Context   string `json:"context"`
+	Namespace string `json:"namespace"`
+	Service   string `json:"service"`
+	Remote    int    `json:"remote"`
+}
+
+// globalForwardEntry is the state of a local port used by a global forward
+type globalForwardEntry struct {
+	globalForwardDefinition
+	// Sessions are the PIDs of the okteto up sessions using the global forward
+	Sessions []int `json:"sessions"`
+	// Owner is the PID of the session that establishes the port forward, 0 if none does
+	Owner int `json:"owner,omitempty"`
+}
+
+// globalForwardRegistry reference counts the global forwards across concurrent okteto up sessions.
+// The first session establishes each forward, the rest of sessions take over when the owner ends,
+// and the forward is released when the last session using it ends
+type globalForwardRegistry struct {
+	isRunning func(pid int) bool
+	path      string
+	lockPath  string
+	pid       int
+}
+
+func newGlobalForwardRegistry() *globalForwardRegistry {
+	okHome := config.GetOktetoHome()
+	return &globalForwardRegistry{
+		isRunning: process.IsRunning,
+		path:      filepath.Join(okHome, globalForwardsFile),
+		lockPath:  filepath.Join(okHome, globalForwardsLockFile),
+		pid:       os.Getpid(),
+	}
+}
+
+// newGlobalForwardDefinition returns the definition of a global forward of the manifest
+func newGlobalForwardDefinition(gf forward.GlobalForward, context, namespace string) globalForwardDefinition {
+	service := gf.ServiceName
+	if service == "" && len(gf.Labels) > 0 {
+		labels := make([]string, 0, len(gf.Labels))
+		for k, v := range gf.Labels {
+			labels = append(labels, fmt.Sprintf("%s=%s", k, v))
+		}
+		sort.Strings(labels)
+		service = strings.Join(labels, ",")
+	}
+	return globalForwardDefinition{
+		Context:   context,
+		Namespace: namespace,
+		Service:   service,
+		Remote:    gf.Remote,
+	}
+}
+
+func (d globalForwardDefinition) String() string {
+	return fmt.Sprintf("%s:%d in namespace '%s'", d.Service, d.Remote, d.Namespace)
+}
+
+// acquire registers the session as a user of the global forwards. It fails if a running session
+// forwards any of the local ports to a different target
+func (r *globalForwardRegistry) acquire(forwards map[int]globalForwardDefinition) error {
+	return r.update(func(entries map[int]*globalForwardEntry) error {
+		for port, definition := range forwards {
+			entry, ok := entries[port]
+			if !ok || entry.globalForwardDefinition == definition {
+				continue
+			}
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("the global forward of the local port %d to %s conflicts with the global forward to %s of another 'okteto up' session (pid %d)", port, definition, entry.globalForwardDefinition, entry.Sessions[0]),
+				Hint: "Use the same 'global_forward' definition for the port in all your okteto manifests, or use a different local port",
+			}
+		}
+		for port, definition := range forwards {
+			entry, ok := entries[port]
+			if !ok {
+				entry = &globalForwardEntry{globalForwardDefinition: definition}
+				entries[port] = entry
+			}
+			if !slices.Contains(entry.Sessions, r.pid) {
+				entry.Sessions = append(entry.Sessions, r.pid)
+			}
+		}
+		return nil
+	})
+}
+
+// claim returns the local ports the session has to forward: the ones without a running owner,
+// which are assigned to the session, and the ones the session already owns
+func (r *globalForwardRegistry) claim() ([]int, error) {
+	result := []int{}
+	err := r.update(func(entries map[int]*globalForwardEntry) error {
+		for port, entry := range entries {
+			if !slices.Contains(entry.Sessions, r.pid) {
+				continue
+			}
+			if entry.Owner == 0 {
+				oktetoLog.Infof("establishing the global forward of the local port %d", port)
+				entry.Owner = r.pid
+			}
+			if entry.Owner == r.pid {
+				result = append(result, port)
+			}
+		}
+		return nil
+	})
+	sort.Ints(result)
+	return result, err
+}
+
+// unclaim gives up the ownership of a local port the session could not forward, so other sessions can try
+func (r *globalForwardRegistry) unclaim(port int) error {
+	return r.update(func(entries map[int]*globalForwardEntry) error {
+		if entry, ok := entries[port]; ok && entry.Owner == r.pid {
+			entry.Owner = 0
+		}
+		return nil
+	})
+}
+
+// release removes the session from the global forwards. The ports owned by the session are
+// taken over by the rest of sessions, and ports without sessions are released
+func (r *globalForwardRegistry) release() error {
+	return r.update(func(entries map[int]*globalForwardEntry) error {
+		for port, entry := range entries {
+			entry.Sessions = slices.DeleteFunc(entry.Sessions, func(pid int) bool { return pid == r.pid })
+			if entry.Owner == r.pid {
+				entry.Owner = 0
+			}
+			if len(entry.Sessions) == 0 {
+				oktetoLog.Infof("releasing the global forward of the local port %d", port)
+				delete(entries, port)
+			}
+		}
+		return nil
+	})
+}
+
+// update runs fn over the state of the global forwards holding the file lock, and stores the result
+func (r *globalForwardRegistry) update(fn func(entries map[int]*globalForwardEntry) error) error {
+	if err := os.MkdirAll(filepath.Dir(r.lockPath), 0700); err != nil {
+		return fmt.Errorf("failed to create the okteto home folder: %w", err)
+	}
+	lock := flock.New(r.lockPath)
+	if err := lock.Lock(); err != nil {
+		return fmt.Errorf("failed to lock %s: %w", r.lockPath, err)
+	}
+	defer func() {
+		if err := lock.Unlock(); err != nil {
+			oktetoLog.Infof("failed to unlock %s: %s", r.lockPath, err)
+		}
+	}()
+
+	entries, err := r.read()
+	if err != nil {
+		return err
+	}
+	r.prune(entries)
+	if err := fn(entries); err != nil {
+		return err
+	}
+	return r.write(entries)
+}
+
+// prune removes the sessions that ended without releasing their global forwards
+func (r *globalForwardRegistry) prune(entries map[int]*globalForwardEntry) {
+	for port, entry := range entries {
+		sessions := []int{}
+		for _, pid := range entry.Sessions {
+			if pid == r.pid || r.isRunning(pid) {
+				sessions = append(sessions, pid)
+				continue
+			}
+			oktetoLog.Infof("removing the finished session %d from the global forward of the local port %d", pid, port)
+		}
+		entry.Sessions = sessions
+		if !slices.Contains(entry.Sessions, entry.Owner) {
+			entry.Owner = 0
+		}
+		if len(entry.Sessions) == 0 {
+			delete(entries, port)
+		}
+	}
+}
+
+func (r *globalForwardRegistry) read() (map[int]*globalForwardEntry, error) {
+	entries := map[int]*globalForwardEntry{}
+	b, err := os.ReadFile(r.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return entries, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", r.path, err)
+	}
+	if len(b) == 0 {
+		return entries, nil
+	}
+	if err := json.Unmarshal(b, &entries); err != nil {
+		oktetoLog.Infof("ignoring malformed global forwards file %s: %s", r.path, err)
+		return map[int]*globalForwardEntry{}, nil
+	}
+	return entries, nil
+}
+
+func (r *globalForwardRegistry) write(entries map[int]*globalForwardEntry) error {
+	if len(entries) == 0 {
+		if err := os.Remove(r.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to delete %s: %w", r.path, err)
+		}
+		return nil
+	}
+	b, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode the global forwards: %w", err)
+	}
+	if err := os.WriteFile(r.path, b, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", r.path, err)
+	}
+	return nil
+}
@@ -0,0 +1,331 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	dbDefinition    = globalForwardDefinition{Context: "ctx", Namespace: "ns", Service: "db", Remote: 5432}
+	redisDefinition = globalForwardDefinition{Context: "ctx", Namespace: "ns", Service: "redis", Remote: 6379}
+)
+
+// fakeSessions simulates the okteto up sessions running in the machine
+type fakeSessions struct {
+	running map[int]bool
+	mu      sync.Mutex
+}
+
+func newFakeSessions() *fakeSessions {
+	return &fakeSessions{running: map[int]bool{}}
+}
+
+func (s *fakeSessions) isRunning(pid int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.running[pid]
+}
+
+func (s *fakeSessions) start(dir string, pid int) *globalForwardRegistry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.running[pid] = true
+	return &globalForwardRegistry{
+		isRunning: s.isRunning,
+		path:      filepath.Join(dir, globalForwardsFile),
+		lockPath:  filepath.Join(dir, globalForwardsLockFile),
+		pid:       pid,
+	}
+}
+
+func (s *fakeSessions) kill(pid int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.running, pid)
+}
+
+func readGlobalForwardEntries(t *testing.T, r *globalForwardRegistry) map[int]*globalForwardEntry {
+	t.Helper()
+	entries, err := r.read()
+	require.NoError(t, err)
+	return entries
+}
+
+func TestGlobalForwardRegistryAcquire(t *testing.T) {
+	dir := t.TempDir()
+	sessions := newFakeSessions()
+	first := sessions.start(dir, 100)
+	second := sessions.start(dir, 200)
+
+	require.NoError(t, first.acquire(map[int]globalForwardDefinition{5432: dbDefinition}))
+	require.NoError(t, second.acquire(map[int]globalForwardDefinition{5432: dbDefinition, 6379: redisDefinition}))
+	// acquiring twice doesn't count the session twice
+	require.NoError(t, second.acquire(map[int]globalForwardDefinition{5432: dbDefinition}))
+
+	entries := readGlobalForwardEntries(t, first)
+	assert.Equal(t, map[int]*globalForwardEntry{
+		5432: {globalForwardDefinition: dbDefinition, Sessions: []int{100, 200}},
+		6379: {globalForwardDefinition: redisDefinition, Sessions: []int{200}},
+	}, entries)
+}
+
+func TestGlobalForwardRegistryAcquireConflict(t *testing.T) {
+	dir := t.TempDir()
+	sessions := newFakeSessions()
+	first := sessions.start(dir, 100)
+	second := sessions.start(dir, 200)
+
+	require.NoError(t, first.acquire(map[int]globalForwardDefinition{5432: dbDefinition}))
+
+	tests := []struct {
+		definition globalForwardDefinition
+		name       string
+	}{
+		{
+			name:       "different service",
+			definition: globalForwardDefinition{Context: "ctx", Namespace: "ns", Service: "postgres", Remote: 5432},
+		},
+		{
+			name:       "different remote port",
+			definition: globalForwardDefinition{Context: "ctx", Namespace: "ns", Service: "db", Remote: 5433},
+		},
+		{
+			name:       "different namespace",
+			definition: globalForwardDefinition{Context: "ctx", Namespace: "other", Service: "db", Remote: 5432},
+		},
+		{
+			name:       "different context",
+			definition: globalForwardDefinition{Context: "other", Namespace: "ns", Service: "db", Remote: 5432},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := second.acquire(map[int]globalForwardDefinition{5432: tt.definition, 6379: redisDefinition})
+			require.Error(t, err)
+			assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+			assert.ErrorContains(t, err, "conflicts with the global forward to db:5432 in namespace 'ns' of another 'okteto up' session (pid 100)")
+
+			// a conflicting session doesn't acquire any of its global forwards
+			assert.Equal(t, map[int]*globalForwardEntry{
+				5432: {globalForwardDefinition: dbDefinition, Sessions: []int{100}},
+			}, readGlobalForwardEntries(t, first))
+		})
+	}
+}
+
+func TestGlobalForwardRegistryClaim(t *testing.T) {
+	dir := t.TempDir()
+	sessions := newFakeSessions()
+	first := sessions.start(dir, 100)
+	second := sessions.start(dir, 200)
+
+	require.NoError(t, first.acquire(map[int]globalForwardDefinition{5432: dbDefinition}))
+	require.NoError(t, second.acquire(map[int]globalForwardDefinition{5432: dbDefinition, 6379: redisDefinition}))
+
+	ports, err := first.claim()
+	require.NoError(t, err)
+	assert.Equal(t, []int{5432}, ports)
+
+	// the first session owns 5432, so the second session only establishes 6379
+	ports, err = second.claim()
+	require.NoError(t, err)
+	assert.Equal(t, []int{6379}, ports)
+
+	// claiming again returns the ports the session already owns
+	ports, err = first.claim()
+	require.NoError(t, err)
+	assert.Equal(t, []int{5432}, ports)
+
+	// the second session takes over 5432 when the owner ends
+	require.NoError(t, first.release())
+	ports, err = second.claim()
+	require.NoError(t, err)
+	assert.Equal(t, []int{5432, 6379}, ports)
+
+	entries := readGlobalForwardEntries(t, second)
+	assert.Equal(t, 200, entries[5432].Owner)
+	assert.Equal(t, []int{200}, entries[5432].Sessions)
+}
+
+func TestGlobalForwardRegistryUnclaim(t *testing.T) {
+	dir := t.TempDir()
+	sessions := newFakeSessions()
+	first := sessions.start(dir, 100)
+	second := sessions.start(dir, 200)
+
+	require.NoError(t, first.acquire(map[int]globalForwardDefinition{5432: dbDefinition}))
+	require.NoError(t, second.acquire(map[int]globalForwardDefinition{5432: dbDefinition}))
+
+	ports, err := first.claim()
+	require.NoError(t, err)
+	assert.Equal(t, []int{5432}, ports)
+
+	// unclaiming a port owned by another session does nothing
+	require.NoError(t, second.unclaim(5432))
+	assert.Equal(t, 100, readGlobalForwardEntries(t, first)[5432].Owner)
+
+	// the port is busy for the owner, so it gives it up and another session can try
+	require.NoError(t, first.unclaim(5432))
+	ports, err = second.claim()
+	require.NoError(t, err)
+	assert.Equal(t, []int{5432}, ports)
+
+	entries := readGlobalForwardEntries(t, first)
+	assert.Equal(t, 200, entries[5432].Owner)
+	assert.Equal(t, []int{100, 200}, entries[5432].Sessions)
+}
+
+func TestGlobalForwardRegistryRelease(t *testing.T) {
+	dir := t.TempDir()
+	sessions := newFakeSessions()
+	first := sessions.start(dir, 100)
+	second := sessions.start(dir, 200)
+
+	require.NoError(t, first.acquire(map[int]globalForwardDefinition{5432: dbDefinition, 6379: redisDefinition}))
+	require.NoError(t, second.acquire(map[int]globalForwardDefinition{5432: dbDefinition}))
+	_, err := first.claim()
+	require.NoError(t, err)
+
+	require.NoError(t, first.release())
+	assert.Equal(t, map[int]*globalForwardEntry{
+		5432: {globalForwardDefinition: dbDefinition, Sessions: []int{200}},
+	}, readGlobalForwardEntries(t, second))
+
+	// the last session releases the global forwards and removes the file
+	require.NoError(t, second.release())
+	_, err = os.Stat(first.path)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	// releasing without global forwards is a no-op
+	require.NoError(t, second.release())
+}
+
+func TestGlobalForwardRegistryPrunesFinishedSessions(t *testing.T) {
+	dir := t.TempDir()
+	sessions := newFakeSessions()
+	first := sessions.start(dir, 100)
+	second := sessions.start(dir, 200)
+
+	require.NoError(t, first.acquire(map[int]globalForwardDefinition{5432: dbDefinition, 6379: redisDefinition}))
+	_, err := first.claim()
+	require.NoError(t, err)
+
+	// the first session ends without releasing its global forwards
+	sessions.kill(100)
+
+	// a new session can redefine the global forwards of the finished session
+	otherDefinition := globalForwardDefinition{Context: "ctx", Namespace: "other", Service: "db", Remote: 5432}
+	require.NoError(t, second.acquire(map[int]globalForwardDefinition{5432: otherDefinition}))
+	ports, err := second.claim()
+	require.NoError(t, err)
+	assert.Equal(t, []int{5432}, ports)
+
+	assert.Equal(t, map[int]*globalForwardEntry{
+		5432: {globalForwardDefinition: otherDefinition, Sessions: []int{200}, Owner: 200},
+	}, readGlobalForwardEntries(t, second))
+}
+
+func TestGlobalForwardRegistryIgnoresMalformedFile(t *testing.T) {
+	dir := t.TempDir()
+	sessions := newFakeSessions()
+	r := sessions.start(dir, 100)
+	require.NoError(t, os.WriteFile(r.path, []byte("not json"), 0600))
+
+	require.NoError(t, r.acquire(map[int]globalForwardDefinition{5432: dbDefinition}))
+	assert.Equal(t, map[int]*globalForwardEntry{
+		5432: {globalForwardDefinition: dbDefinition, Sessions: []int{100}},
+	}, readGlobalForwardEntries(t, r))
+}
+
+func TestGlobalForwardRegistryConcurrentSessions(t *testing.T) {
+	dir := t.TempDir()
+	sessions := newFakeSessions()
+	registries := []*globalForwardRegistry{}
+	for pid := 1; pid <= 20; pid++ {
+		registries = append(registries, sessions.start(dir, pid))
+	}
+
+	var wg sync.WaitGroup
+	owners := make(chan int, len(registries))
+	for _, r := range registries {
+		wg.Add(1)
+		go func(r *globalForwardRegistry) {
+			defer wg.Done()
+			assert.NoError(t, r.acquire(map[int]globalForwardDefinition{5432: dbDefinition}))
+			ports, err := r.claim()
+			assert.NoError(t, err)
+			if len(ports) > 0 {
+				owners <- r.pid
+			}
+		}(r)
+	}
+	wg.Wait()
+	close(owners)
+
+	// every session is counted and only one of them establishes the global forward
+	entries := readGlobalForwardEntries(t, registries[0])
+	require.Contains(t, entries, 5432)
+	assert.Len(t, entries[5432].Sessions, len(registries))
+	owned := []int{}
+	for pid := range owners {
+		owned = append(owned, pid)
+	}
+	require.Len(t, owned, 1)
+	assert.Equal(t, owned[0], entries[5432].Owner)
+
+	for _, r := range registries {
+		wg.Add(1)
+		go func(r *globalForwardRegistry) {
+			defer wg.Done()
+			assert.NoError(t, r.release())
+		}(r)
+	}
+	wg.Wait()
+
+	_, err := os.Stat(registries[0].path)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestNewGlobalForwardDefinition(t *testing.T) {
+	tests := []struct {
+		name     string
+		gf       forward.GlobalForward
+		expected globalForwardDefinition
+	}{
+		{
+			name:     "service name",
+			gf:       forward.GlobalForward{Local: 5432, Remote: 5432, ServiceName: "db"},
+			expected: globalForwardDefinition{Context: "ctx", Namespace: "ns", Service: "db", Remote: 5432},
+		},
+		{
+			name:     "labels",
+			gf:       forward.GlobalForward{Local: 5432, Remote: 5432, Labels: map[string]string{"tier": "data", "app": "db"}},
+			expected: globalForwardDefinition{Context: "ctx", Namespace: "ns", Service: "app=db,tier=data", Remote: 5432},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, newGlobalForwardDefinition(tt.gf, "ctx", "ns"))
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"fmt"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// resolveNamespace returns the namespace used by every operation of okteto up.
+// The --namespace flag has priority over the namespace of the okteto manifest, and the namespace
+// of the okteto manifest has priority over the namespace of the okteto context.
+// contextNamespace is the namespace of the okteto context after applying the --namespace flag
+func resolveNamespace(flagNamespace, manifestNamespace, contextNamespace string, strict bool) (string, error) {
+	if manifestNamespace == "" || manifestNamespace == contextNamespace {
+		return contextNamespace, nil
+	}
+
+	if strict {
+		source := "your Okteto Context"
+		if flagNamespace != "" {
+			source = "the '--namespace' flag"
+		}
+		return "", oktetoErrors.UserError{
+			E:    fmt.Errorf("the okteto manifest namespace '%s' doesn't match the namespace '%s' of %s", manifestNamespace, contextNamespace, source),
+			Hint: fmt.Sprintf("Switch to the namespace with 'okteto namespace %s' or update the 'namespace' field of your okteto manifest", manifestNamespace),
+		}
+	}
+
+	if flagNamespace != "" {
+		oktetoLog.Information("Using namespace '%s' from the '--namespace' flag instead of '%s' defined in the okteto manifest", contextNamespace, manifestNamespace)
+		return contextNamespace, nil
+	}
+	oktetoLog.Information("Using namespace '%s' defined in the okteto manifest instead of '%s' of your Okteto Context", manifestNamespace, contextNamespace)
+	return manifestNamespace, nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/okteto/okteto/internal/test"
+	fakeUp "github.com/okteto/okteto/internal/test/up"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/syncthing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResolveNamespace(t *testing.T) {
+	tests := []struct {
+		name              string
+		flagNamespace     string
+		manifestNamespace string
+		contextNamespace  string
+		expected          string
+		strict            bool
+		expectedErr       bool
+	}{
+		{
+			name:             "context namespace",
+			contextNamespace: "team-b",
+			expected:         "team-b",
+		},
+		{
+			name:              "manifest namespace over context namespace",
+			manifestNamespace: "team-a",
+			contextNamespace:  "team-b",
+			expected:          "team-a",
+		},
+		{
+			name:              "flag namespace over manifest namespace",
+			flagNamespace:     "team-c",
+			manifestNamespace: "team-a",
+			contextNamespace:  "team-c",
+			expected:          "team-c",
+		},
+		{
+			name:              "matching namespaces in strict mode",
+			manifestNamespace: "team-a",
+			contextNamespace:  "team-a",
+			strict:            true,
+			expected:          "team-a",
+		},
+		{
+			name:              "context mismatch in strict mode",
+			manifestNamespace: "team-a",
+			contextNamespace:  "team-b",
+			strict:            true,
+			expectedErr:       true,
+		},
+		{
+			name:              "flag mismatch in strict mode",
+			flagNamespace:     "team-c",
+			manifestNamespace: "team-a",
+			contextNamespace:  "team-c",
+			strict:            true,
+			expectedErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := resolveNamespace(tt.flagNamespace, tt.manifestNamespace, tt.contextNamespace, tt.strict)
+			if tt.expectedErr {
+				var uErr oktetoErrors.UserError
+				require.ErrorAs(t, err, &uErr)
+				assert.Contains(t, uErr.Hint, "okteto namespace team-a")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+// TestUpOperationsUseResolvedNamespace checks that the operations of okteto up use the namespace of the
+// up context instead of reading the namespace of the okteto context again
+func TestUpOperationsUseResolvedNamespace(t *testing.T) {
+	t.Setenv(model.OktetoExecuteSSHEnvVar, "false")
+	retriever := &fakeUp.FakeAppRetriever{
+		Responses: []fakeUp.FakeAppResponse{{Err: assert.AnError}},
+	}
+	up := newActivateLoopTestContext(t, retriever, &fakeKubeconfigReloader{}, strconv.Itoa(os.Getpid()))
+	okteto.CurrentStore.Contexts["test"].Namespace = "team-b"
+	up.Namespace = "team-a"
+
+	up.activateLoop()
+	require.ErrorIs(t, <-up.Exit, assert.AnError)
+	require.Equal(t, []string{"team-a"}, retriever.Namespaces)
+
+	fwd := &fakeUp.FakeForwarder{}
+	factory := &fakeUp.FakeForwarderFactory{Forwarder: fwd}
+	up.Dev.Forward = []forward.Forward{{Local: 8080, Remote: 80}}
+	up.Manifest = &model.Manifest{}
+	up.Pod = &apiv1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api-123"}}
+	up.Sy = &syncthing.Syncthing{RemotePort: 22001, RemoteGUIPort: 8385}
+	up.K8sClientProvider = test.NewFakeK8sProvider()
+	up.forwarderFactory = factory
+
+	require.NoError(t, up.forwards(context.Background()))
+	require.Equal(t, []string{"team-a"}, factory.Namespaces)
+	require.Equal(t, "team-a", fwd.StartedNamespace)
+}
@@ -0,0 +1,146 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	k8sExec "github.com/okteto/okteto/pkg/k8s/exec"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+)
+
+const (
+	// ownershipPollInterval is the interval to check for files synchronized to the development container
+	ownershipPollInterval = time.Second
+
+	// ownershipMaxPathsPerCommand is the max number of files changed by a single chown command
+	ownershipMaxPathsPerCommand = 200
+)
+
+// updatedRemotePathsGetter returns the paths in the development container of the files synchronized since a syncthing event
+type updatedRemotePathsGetter interface {
+	GetUpdatedRemotePaths(ctx context.Context, since int) ([]string, int, error)
+}
+
+// ownershipTranslator changes the owner of the files synchronized to the development container to the one of 'sync.uidMap'
+type ownershipTranslator struct {
+	getter             updatedRemotePathsGetter
+	exec               func(ctx context.Context, command []string) error
+	owner              string
+	folders            []string
+	interval           time.Duration
+	maxPathsPerCommand int
+}
+
+// translateOwnership changes the owner of the synchronized files until ctx is done
+func (up *upContext) translateOwnership(ctx context.Context) {
+	folders := []string{}
+	for _, folder := range up.Sy.Folders {
+		folders = append(folders, folder.RemotePath)
+	}
+	ot := &ownershipTranslator{
+		getter:             up.Sy,
+		exec:               up.execQuietly,
+		owner:              up.Dev.Sync.GetChownOwner(),
+		folders:            folders,
+		interval:           ownershipPollInterval,
+		maxPathsPerCommand: ownershipMaxPathsPerCommand,
+	}
+	ot.start(ctx)
+}
+
+// execQuietly runs a command in the development container without attaching it to the terminal
+func (up *upContext) execQuietly(ctx context.Context, command []string) error {
+	k8sClient, restConfig, err := up.K8sClientProvider.Provide(okteto.GetContext().Cfg)
+	if err != nil {
+		return err
+	}
+	var output bytes.Buffer
+	err = k8sExec.Exec(ctx, k8sClient, restConfig, up.Namespace, up.Pod.Name, up.Dev.Container, false, strings.NewReader(""), &output, &output, command)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(output.String()))
+	}
+	return nil
+}
+
+// start changes the owner of the sync folders, and then the owner of each file synchronized to the development container.
+// Failures are logged only once, the container must run as root to change the owner of the files
+func (ot *ownershipTranslator) start(ctx context.Context) {
+	_, since, err := ot.getter.GetUpdatedRemotePaths(ctx, 0)
+	if err != nil {
+		oktetoLog.Infof("uidMap: failed to get synchronized files: %s", err)
+	}
+
+	warned := false
+	chown := func(command []string) {
+		if err := ot.exec(ctx, command); err != nil && ctx.Err() == nil {
+			oktetoLog.Infof("uidMap: '%s' failed: %s", strings.Join(command, " "), err)
+			if !warned {
+				warned = true
+				oktetoLog.Warning("Failed to change the owner of the synchronized files to '%s': %s", ot.owner, err)
+				oktetoLog.Hint("    'sync.uidMap' requires a development container running as root")
+			}
+		}
+	}
+
+	if len(ot.folders) > 0 {
+		chown(append([]string{"chown", "-R", "-h", ot.owner, "--"}, ot.folders...))
+	}
+
+	ticker := time.NewTicker(ot.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		var paths []string
+		paths, since, err = ot.getter.GetUpdatedRemotePaths(ctx, since)
+		if err != nil {
+			oktetoLog.Infof("uidMap: failed to get synchronized files: %s", err)
+			continue
+		}
+		for _, command := range chownCommands(ot.owner, paths, ot.maxPathsPerCommand) {
+			chown(command)
+		}
+	}
+}
+
+// chownCommands returns the chown commands changing the owner of paths, with at most maxPaths paths each
+func chownCommands(owner string, paths []string, maxPaths int) [][]string {
+	seen := map[string]bool{}
+	unique := []string{}
+	for _, p := range paths {
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		unique = append(unique, p)
+	}
+
+	var result [][]string
+	for len(unique) > 0 {
+		n := min(maxPaths, len(unique))
+		result = append(result, append([]string{"chown", "-h", owner, "--"}, unique[:n]...))
+		unique = unique[n:]
+	}
+	return result
+}
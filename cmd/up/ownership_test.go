@@ -0,0 +1,109 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeUpdatedRemotePathsGetter struct {
+	events [][]string
+	mu     sync.Mutex
+}
+
+func (f *fakeUpdatedRemotePathsGetter) GetUpdatedRemotePaths(_ context.Context, since int) ([]string, int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.events) == 0 {
+		return nil, since, nil
+	}
+	paths := f.events[0]
+	f.events = f.events[1:]
+	return paths, since + len(paths), nil
+}
+
+type fakeChownExecutor struct {
+	err      error
+	commands chan []string
+}
+
+func (f *fakeChownExecutor) exec(_ context.Context, command []string) error {
+	f.commands <- command
+	return f.err
+}
+
+func TestOwnershipTranslator(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	getter := &fakeUpdatedRemotePathsGetter{
+		events: [][]string{
+			{"/app/synced-before-start.go"},
+			{"/app/main.go", "/app/api.go", "/app/main.go"},
+		},
+	}
+	executor := &fakeChownExecutor{commands: make(chan []string)}
+	ot := &ownershipTranslator{
+		getter:             getter,
+		exec:               executor.exec,
+		owner:              "1001:1001",
+		folders:            []string{"/app"},
+		interval:           10 * time.Millisecond,
+		maxPathsPerCommand: 10,
+	}
+	go ot.start(ctx)
+
+	assert.Equal(t, []string{"chown", "-R", "-h", "1001:1001", "--", "/app"}, <-executor.commands)
+	assert.Equal(t, []string{"chown", "-h", "1001:1001", "--", "/app/main.go", "/app/api.go"}, <-executor.commands)
+}
+
+func TestOwnershipTranslatorKeepsRunningOnErrors(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	getter := &fakeUpdatedRemotePathsGetter{
+		events: [][]string{
+			nil,
+			{"/app/main.go"},
+		},
+	}
+	executor := &fakeChownExecutor{commands: make(chan []string), err: errors.New("operation not permitted")}
+	ot := &ownershipTranslator{
+		getter:             getter,
+		exec:               executor.exec,
+		owner:              "1001",
+		folders:            []string{"/app"},
+		interval:           10 * time.Millisecond,
+		maxPathsPerCommand: 10,
+	}
+	go ot.start(ctx)
+
+	require.Equal(t, []string{"chown", "-R", "-h", "1001", "--", "/app"}, <-executor.commands)
+	require.Equal(t, []string{"chown", "-h", "1001", "--", "/app/main.go"}, <-executor.commands)
+}
+
+func TestChownCommands(t *testing.T) {
+	assert.Nil(t, chownCommands("1001", nil, 2))
+	assert.Equal(t, [][]string{
+		{"chown", "-h", "1001", "--", "/app/a", "/app/b"},
+		{"chown", "-h", "1001", "--", "/app/c"},
+	}, chownCommands("1001", []string{"/app/a", "/app/b", "/app/a", "/app/c"}, 2))
+}
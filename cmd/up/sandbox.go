@@ -0,0 +1,283 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/okteto/okteto/pkg/analytics"
+	"github.com/okteto/okteto/pkg/cmd/down"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/log/io"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// defaultSandboxName is the name of the sandbox development container when --name is not set
+	defaultSandboxName = "sandbox"
+
+	// defaultSandboxRemotePath is where the current folder is synchronized when --sync is not set
+	defaultSandboxRemotePath = "/okteto"
+)
+
+// SandboxOptions represents the options available on sandbox command
+type SandboxOptions struct {
+	Namespace  string
+	K8sContext string
+	Name       string
+	Image      string
+	Forwards   []string
+	Syncs      []string
+	Envs       []string
+	Keep       bool
+}
+
+// Sandbox starts a disposable development container from an image, without an Okteto Manifest
+func Sandbox(at analyticsTrackerInterface, insights buildDeployTrackerInterface, ioCtrl *io.Controller, k8sLogger *io.K8sLogger, fs afero.Fs) *cobra.Command {
+	opts := &SandboxOptions{}
+	cmd := &cobra.Command{
+		Use:   "sandbox --image IMAGE [flags] -- COMMAND [args...]",
+		Short: "Start a disposable Development Container from an image, without an Okteto Manifest",
+		Example: `# start a python sandbox synchronizing the current folder to '/app'
+okteto sandbox --image python:3.11 --forward 8080:8080 --sync .:/app
+
+# start a sandbox running a command, keeping it deployed on exit
+okteto sandbox --image golang:1 --keep -- go run main.go
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if okteto.InDevContainer() {
+				return oktetoErrors.ErrNotInDevContainer
+			}
+
+			command, err := getSandboxCommand(args, cmd.ArgsLenAtDash())
+			if err != nil {
+				return err
+			}
+			if err := opts.validate(); err != nil {
+				return err
+			}
+
+			ctx := context.Background()
+			ctxOpts := &contextCMD.Options{
+				Show:      true,
+				Context:   opts.K8sContext,
+				Namespace: opts.Namespace,
+			}
+			if err := contextCMD.NewContextCommand().Run(ctx, ctxOpts); err != nil {
+				return err
+			}
+			ns := okteto.GetContext().Namespace
+
+			k8sClient, _, err := okteto.GetK8sClientWithLogger(k8sLogger)
+			if err != nil {
+				return fmt.Errorf("failed to load k8s client: %w", err)
+			}
+			if err := utils.CheckNamespaceAccess(ctx, ns, k8sClient); err != nil {
+				return err
+			}
+
+			wd, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("failed to get the current working directory: %w", err)
+			}
+			manifest, err := newSandboxManifest(opts, command, wd, fs)
+			if err != nil {
+				return err
+			}
+			dev := manifest.Dev[opts.Name]
+
+			upMeta := analytics.NewUpMetricsMetadata()
+			defer at.TrackUp(upMeta)
+
+			upOptions := &Options{
+				ManifestPath: manifest.ManifestPath,
+				Namespace:    ns,
+				K8sContext:   opts.K8sContext,
+				DevName:      opts.Name,
+				Envs:         opts.Envs,
+			}
+			up, err := newUpContext(ns, manifest, upOptions, fs, at, insights, ioCtrl, k8sLogger, upMeta)
+			if err != nil {
+				return err
+			}
+			// the sandbox is cleaned up below, including its volumes
+			up.autoDown.autoDown = false
+			up.Dev = dev
+
+			if err := up.events.listen(types.GetUpEventsSocketPath(ns, dev.Name)); err != nil {
+				oktetoLog.Infof("failed to listen in the up events socket: %s", err)
+			}
+			defer up.events.close()
+
+			at.TrackUpStarted(dev.Name, ns, "", upMeta.WorkflowID())
+
+			err = up.startDev()
+			if !opts.Keep {
+				if downErr := destroySandbox(ctx, dev, ns, manifest.Name, fs, k8sLogger, at); downErr != nil {
+					oktetoLog.Warning("failed to clean up sandbox '%s': %s. Run 'okteto down %s -v' to clean it up", dev.Name, downErr, dev.Name)
+				}
+			}
+			if err != nil {
+				return err
+			}
+
+			up.analyticsMeta.CommandSuccess()
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Image, "image", "", "the image of the Development Container")
+	cmd.Flags().StringVar(&opts.Name, "name", defaultSandboxName, "the name of the Development Container")
+	cmd.Flags().StringArrayVar(&opts.Forwards, "forward", nil, "forward a local port to the Development Container, like '8080:8080' (can be set more than once)")
+	cmd.Flags().StringArrayVar(&opts.Syncs, "sync", nil, fmt.Sprintf("synchronize a local folder with the Development Container, like '.:/app' (can be set more than once). Defaults to '.:%s'", defaultSandboxRemotePath))
+	cmd.Flags().StringArrayVarP(&opts.Envs, "env", "e", []string{}, "set environment variable in the Development Container")
+	cmd.Flags().BoolVar(&opts.Keep, "keep", false, "keep the Development Container and its volumes deployed on exit")
+	cmd.Flags().StringVarP(&opts.Namespace, "namespace", "n", "", "overwrite the current Okteto Namespace")
+	cmd.Flags().StringVarP(&opts.K8sContext, "context", "c", "", "overwrite the current Okteto Context")
+	return cmd
+}
+
+// getSandboxCommand returns the command after '--', which is the only kind of argument accepted by the sandbox command
+func getSandboxCommand(args []string, argsLenAtDash int) ([]string, error) {
+	if argsLenAtDash < 0 {
+		argsLenAtDash = len(args)
+	}
+	if argsLenAtDash > 0 {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("unexpected argument '%s'", args[0]),
+			Hint: "Specify the command of the sandbox after '--', for example: 'okteto sandbox --image python:3.11 -- python app.py'",
+		}
+	}
+	return args[argsLenAtDash:], nil
+}
+
+func (o *SandboxOptions) validate() error {
+	if o.Image == "" {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the '--image' flag is required"),
+			Hint: "Specify the image of the sandbox, for example: 'okteto sandbox --image python:3.11'",
+		}
+	}
+	if _, err := parseSandboxForwards(o.Forwards); err != nil {
+		return err
+	}
+	if _, err := parseSandboxSyncs(o.Syncs); err != nil {
+		return err
+	}
+	return nil
+}
+
+// newSandboxManifest synthesizes an in-memory manifest with an autocreated development container
+// built from the sandbox options. Relative sync folders are relative to wd
+func newSandboxManifest(o *SandboxOptions, command []string, wd string, fs afero.Fs) (*model.Manifest, error) {
+	forwards, err := parseSandboxForwards(o.Forwards)
+	if err != nil {
+		return nil, err
+	}
+	folders, err := parseSandboxSyncs(o.Syncs)
+	if err != nil {
+		return nil, err
+	}
+	if len(folders) == 0 {
+		folders = []model.SyncFolder{{LocalPath: ".", RemotePath: defaultSandboxRemotePath}}
+	}
+
+	dev := model.NewDev()
+	dev.Name = o.Name
+	dev.Image = o.Image
+	dev.Autocreate = true
+	dev.Forward = forwards
+	dev.Sync.Folders = folders
+	dev.Workdir = folders[0].RemotePath
+	if len(command) > 0 {
+		dev.Command.Values = command
+	}
+	if err := dev.SetDefaults(); err != nil {
+		return nil, err
+	}
+	manifestPath := filepath.Join(wd, utils.DefaultManifest)
+	if err := dev.PreparePathsAndExpandEnvFiles(manifestPath, fs); err != nil {
+		return nil, err
+	}
+	if err := dev.Validate(); err != nil {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("invalid sandbox '%s': %w", o.Name, err),
+			Hint: "Review the flags of the sandbox command",
+		}
+	}
+
+	manifest := model.NewManifest()
+	manifest.Name = o.Name
+	manifest.ManifestPath = manifestPath
+	manifest.Dev[o.Name] = dev
+	return manifest, nil
+}
+
+func parseSandboxForwards(values []string) ([]forward.Forward, error) {
+	result := make([]forward.Forward, 0, len(values))
+	for _, value := range values {
+		var f forward.Forward
+		if err := unmarshalFlagValue(value, &f); err != nil {
+			return nil, oktetoErrors.UserError{
+				E:    fmt.Errorf("invalid value '%s' for '--forward': %w", value, err),
+				Hint: "Use the format 'localPort:remotePort' or 'localPort:service:remotePort', like '8080:8080'",
+			}
+		}
+		result = append(result, f)
+	}
+	return result, nil
+}
+
+func parseSandboxSyncs(values []string) ([]model.SyncFolder, error) {
+	result := make([]model.SyncFolder, 0, len(values))
+	for _, value := range values {
+		var s model.SyncFolder
+		if err := unmarshalFlagValue(value, &s); err != nil {
+			return nil, oktetoErrors.UserError{
+				E:    fmt.Errorf("invalid value '%s' for '--sync': %w", value, err),
+				Hint: "Use the format 'localPath:remotePath', like '.:/app'",
+			}
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+// unmarshalFlagValue parses a flag value with the same rules as the equivalent field of the Okteto Manifest
+func unmarshalFlagValue(value string, out interface{}) error {
+	b, err := yaml.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(b, out)
+}
+
+// destroySandbox deactivates the sandbox development container and removes its deployment, secrets and volume
+func destroySandbox(ctx context.Context, dev *model.Dev, namespace, manifestName string, fs afero.Fs, k8sLogger *io.K8sLogger, at analyticsTrackerInterface) error {
+	oktetoLog.Information("Cleaning up sandbox '%s'...", dev.Name)
+	dc := down.New(fs, okteto.NewK8sClientProviderWithLogger(k8sLogger), at)
+	return dc.Down(ctx, dev, namespace, manifestName, true)
+}
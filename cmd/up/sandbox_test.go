@@ -0,0 +1,168 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+func TestSandboxOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    SandboxOptions
+		wantErr string
+	}{
+		{
+			name: "valid",
+			opts: SandboxOptions{Image: "python:3.11", Forwards: []string{"8080:8080", "5432:db:5432"}, Syncs: []string{".:/app"}},
+		},
+		{
+			name:    "missing image",
+			opts:    SandboxOptions{Forwards: []string{"8080:8080"}},
+			wantErr: "the '--image' flag is required",
+		},
+		{
+			name:    "invalid forward",
+			opts:    SandboxOptions{Image: "python:3.11", Forwards: []string{"8080"}},
+			wantErr: "invalid value '8080' for '--forward'",
+		},
+		{
+			name:    "invalid forward port",
+			opts:    SandboxOptions{Image: "python:3.11", Forwards: []string{"http:8080"}},
+			wantErr: "invalid value 'http:8080' for '--forward'",
+		},
+		{
+			name:    "invalid sync",
+			opts:    SandboxOptions{Image: "python:3.11", Syncs: []string{"/app"}},
+			wantErr: "invalid value '/app' for '--sync'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+			assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+		})
+	}
+}
+
+func TestGetSandboxCommand(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		argsLenAtDash int
+		expected      []string
+		wantErr       bool
+	}{
+		{
+			name:          "no args",
+			argsLenAtDash: -1,
+			expected:      []string{},
+		},
+		{
+			name:          "command after dash",
+			args:          []string{"python", "app.py"},
+			argsLenAtDash: 0,
+			expected:      []string{"python", "app.py"},
+		},
+		{
+			name:          "argument without dash",
+			args:          []string{"api"},
+			argsLenAtDash: -1,
+			wantErr:       true,
+		},
+		{
+			name:          "argument before dash",
+			args:          []string{"api", "python"},
+			argsLenAtDash: 1,
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command, err := getSandboxCommand(tt.args, tt.argsLenAtDash)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.expected, command)
+		})
+	}
+}
+
+func TestNewSandboxManifest(t *testing.T) {
+	wd, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, os.Mkdir(filepath.Join(wd, "data"), 0700))
+	opts := &SandboxOptions{
+		Name:     "my-sandbox",
+		Image:    "python:3.11",
+		Forwards: []string{"9229:9229", "8080:8080"},
+		Syncs:    []string{".:/app", "data:/data"},
+	}
+
+	manifest, err := newSandboxManifest(opts, []string{"python", "app.py"}, wd, afero.NewOsFs())
+	require.NoError(t, err)
+
+	assert.Equal(t, "my-sandbox", manifest.Name)
+	assert.Equal(t, filepath.Join(wd, "okteto.yml"), manifest.ManifestPath)
+	require.Len(t, manifest.Dev, 1)
+
+	dev := manifest.Dev["my-sandbox"]
+	require.NotNil(t, dev)
+	assert.Equal(t, "my-sandbox", dev.Name)
+	assert.Equal(t, "python:3.11", dev.Image)
+	assert.True(t, dev.Autocreate)
+	assert.Equal(t, []string{"python", "app.py"}, dev.Command.Values)
+	assert.Equal(t, "/app", dev.Workdir)
+	assert.Equal(t, []forward.Forward{{Local: 8080, Remote: 8080}, {Local: 9229, Remote: 9229}}, dev.Forward)
+	assert.Equal(t, []model.SyncFolder{{LocalPath: wd, RemotePath: "/app"}, {LocalPath: filepath.Join(wd, "data"), RemotePath: "/data"}}, dev.Sync.Folders)
+	assert.Equal(t, apiv1.PullAlways, dev.ImagePullPolicy)
+	assert.True(t, dev.PersistentVolumeEnabled())
+}
+
+func TestNewSandboxManifestDefaults(t *testing.T) {
+	wd, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	manifest, err := newSandboxManifest(&SandboxOptions{Name: defaultSandboxName, Image: "alpine"}, nil, wd, afero.NewOsFs())
+	require.NoError(t, err)
+
+	dev := manifest.Dev[defaultSandboxName]
+	require.NotNil(t, dev)
+	assert.Equal(t, []string{"sh"}, dev.Command.Values)
+	assert.Equal(t, defaultSandboxRemotePath, dev.Workdir)
+	assert.Equal(t, []model.SyncFolder{{LocalPath: wd, RemotePath: defaultSandboxRemotePath}}, dev.Sync.Folders)
+	assert.Empty(t, dev.Forward)
+}
+
+func TestNewSandboxManifestInvalidName(t *testing.T) {
+	_, err := newSandboxManifest(&SandboxOptions{Name: "My_Sandbox", Image: "alpine"}, nil, t.TempDir(), afero.NewOsFs())
+	require.ErrorContains(t, err, "invalid sandbox 'My_Sandbox'")
+	assert.ErrorAs(t, err, &oktetoErrors.UserError{})
+}
@@ -0,0 +1,122 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// shutdownTimeoutEnvVar sets the maximum time to wait for the shutdown sequence using OKTETO_UP_SHUTDOWN_TIMEOUT
+	shutdownTimeoutEnvVar = "OKTETO_UP_SHUTDOWN_TIMEOUT"
+
+	defaultShutdownTimeout = 10 * time.Second
+
+	// forceExitCode is the exit code when the user forces the exit with a second CTRL+C
+	forceExitCode = 130
+)
+
+// shutdownStep is an independent step of the shutdown sequence
+type shutdownStep struct {
+	run  func()
+	name string
+}
+
+// runShutdownSteps runs the steps concurrently until all of them finish, the timeout expires or force is notified.
+// It returns the names of the steps that didn't finish and if the shutdown was forced
+func runShutdownSteps(steps []shutdownStep, timeout time.Duration, force <-chan os.Signal) ([]string, bool) {
+	pending := map[string]bool{}
+	mu := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	for _, step := range steps {
+		pending[step.name] = true
+	}
+	for _, step := range steps {
+		wg.Add(1)
+		go func(step shutdownStep) {
+			defer wg.Done()
+			step.run()
+			mu.Lock()
+			delete(pending, step.name)
+			mu.Unlock()
+		}(step)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	forced := false
+	select {
+	case <-done:
+		return nil, false
+	case <-time.After(timeout):
+		oktetoLog.Infof("shutdown sequence timed out after %s", timeout)
+	case <-force:
+		oktetoLog.Infof("second CTRL+C received, forcing exit")
+		forced = true
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	result := []string{}
+	for _, step := range steps {
+		if pending[step.name] {
+			result = append(result, step.name)
+		}
+	}
+	return result, forced
+}
+
+// exitGuard runs the registered cleanups before exiting the process, as deferred functions don't run on os.Exit
+type exitGuard struct {
+	exit     func(code int)
+	cleanups []func()
+	mu       sync.Mutex
+	once     sync.Once
+}
+
+func newExitGuard() *exitGuard {
+	return &exitGuard{exit: os.Exit}
+}
+
+// register adds a cleanup to run on exit
+func (g *exitGuard) register(cleanup func()) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.cleanups = append(g.cleanups, cleanup)
+}
+
+// cleanup runs the registered cleanups in reverse order. It only runs them once
+func (g *exitGuard) cleanup() {
+	g.once.Do(func() {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		for i := len(g.cleanups) - 1; i >= 0; i-- {
+			g.cleanups[i]()
+		}
+	})
+}
+
+// forceExit runs the cleanups and exits the process with the given code
+func (g *exitGuard) forceExit(code int) {
+	g.cleanup()
+	g.exit(code)
+}
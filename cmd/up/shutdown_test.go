@@ -0,0 +1,176 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	fakeUp "github.com/okteto/okteto/internal/test/up"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hangingForwarder never returns from Stop until it is released
+type hangingForwarder struct {
+	fakeUp.FakeForwarder
+	release chan struct{}
+}
+
+func (f *hangingForwarder) Stop() {
+	<-f.release
+}
+
+func TestRunShutdownSteps(t *testing.T) {
+	hang := make(chan struct{})
+	defer close(hang)
+
+	tests := []struct {
+		force           func() <-chan os.Signal
+		name            string
+		steps           []shutdownStep
+		expectedSkipped []string
+		expectedForced  bool
+	}{
+		{
+			name: "all steps finish",
+			steps: []shutdownStep{
+				{name: "a", run: func() {}},
+				{name: "b", run: func() {}},
+			},
+			force: func() <-chan os.Signal { return nil },
+		},
+		{
+			name: "hanging step times out",
+			steps: []shutdownStep{
+				{name: "a", run: func() {}},
+				{name: "hang", run: func() { <-hang }},
+			},
+			force:           func() <-chan os.Signal { return nil },
+			expectedSkipped: []string{"hang"},
+		},
+		{
+			name: "second interrupt forces the exit",
+			steps: []shutdownStep{
+				{name: "hang", run: func() { <-hang }},
+				{name: "hang again", run: func() { <-hang }},
+			},
+			force: func() <-chan os.Signal {
+				ch := make(chan os.Signal, 1)
+				ch <- os.Interrupt
+				return ch
+			},
+			expectedSkipped: []string{"hang", "hang again"},
+			expectedForced:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeout := 50 * time.Millisecond
+			if tt.expectedForced {
+				timeout = time.Minute
+			}
+			skipped, forced := runShutdownSteps(tt.steps, timeout, tt.force())
+			assert.Equal(t, tt.expectedForced, forced)
+			if len(tt.expectedSkipped) == 0 {
+				assert.Empty(t, skipped)
+				return
+			}
+			assert.Equal(t, tt.expectedSkipped, skipped)
+		})
+	}
+}
+
+func TestExitGuard(t *testing.T) {
+	calls := []string{}
+	exitCode := -1
+	g := &exitGuard{exit: func(code int) { exitCode = code }}
+	g.register(func() { calls = append(calls, "first") })
+	g.register(func() { calls = append(calls, "second") })
+
+	g.forceExit(forceExitCode)
+	g.cleanup()
+
+	assert.Equal(t, []string{"second", "first"}, calls)
+	assert.Equal(t, forceExitCode, exitCode)
+}
+
+func TestShutdownWithHangingForwarder(t *testing.T) {
+	t.Setenv(shutdownTimeoutEnvVar, "50ms")
+	forwarder := &hangingForwarder{release: make(chan struct{})}
+	defer close(forwarder.release)
+
+	canceled := false
+	up := &upContext{
+		Dev:               &model.Dev{},
+		Forwarder:         forwarder,
+		Cancel:            func() { canceled = true },
+		ShutdownCompleted: make(chan bool, 1),
+		success:           true,
+	}
+
+	done := make(chan struct{})
+	go func() {
+		up.shutdown()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("shutdown didn't finish after the timeout")
+	}
+	assert.True(t, canceled)
+	require.Len(t, up.ShutdownCompleted, 1)
+}
+
+func TestShutdownForcedBySecondInterrupt(t *testing.T) {
+	t.Setenv(shutdownTimeoutEnvVar, "1m")
+	forwarder := &hangingForwarder{release: make(chan struct{})}
+	defer close(forwarder.release)
+
+	interrupt := make(chan os.Signal, 1)
+	pidFileDeleted := false
+	exitCode := -1
+	guard := &exitGuard{exit: func(code int) { exitCode = code }}
+	guard.register(func() { pidFileDeleted = true })
+
+	up := &upContext{
+		Dev:               &model.Dev{},
+		Forwarder:         forwarder,
+		ShutdownCompleted: make(chan bool, 1),
+		success:           true,
+		interruptReceived: true,
+		interrupt:         interrupt,
+		exitGuard:         guard,
+	}
+
+	done := make(chan struct{})
+	go func() {
+		up.shutdown()
+		close(done)
+	}()
+	interrupt <- os.Interrupt
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("shutdown wasn't forced by the second interrupt")
+	}
+	assert.True(t, pidFileDeleted)
+	assert.Equal(t, forceExitCode, exitCode)
+}
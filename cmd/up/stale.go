@@ -0,0 +1,206 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/apps"
+	"github.com/okteto/okteto/pkg/k8s/volumes"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/process"
+	"github.com/spf13/afero"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	stateFilename        = "okteto.state"
+	stignoreFilenameGlob = ".stignore-*"
+)
+
+// staleSessionCollector removes the artifacts left behind by okteto up sessions that didn't exit cleanly
+type staleSessionCollector struct {
+	fs        afero.Fs
+	isRunning func(pid int) bool
+	hostname  string
+	appHome   string
+}
+
+// staleSessionSummary counts the artifacts removed by the staleSessionCollector
+type staleSessionSummary struct {
+	files     int
+	devClones int
+}
+
+func newStaleSessionCollector(namespace, devName string) *staleSessionCollector {
+	return &staleSessionCollector{
+		fs:        afero.NewOsFs(),
+		isRunning: process.IsRunning,
+		hostname:  process.Hostname(),
+		appHome:   config.GetAppHome(namespace, devName),
+	}
+}
+
+// setHolderAnnotations marks the dev clone as held by the current okteto up session.
+// They are only set on the dev clone metadata to avoid restarting the development container
+func setHolderAnnotations(app apps.App) {
+	now := time.Now().UTC().Format(time.RFC3339)
+	app.ObjectMeta().Annotations[model.OktetoHolderAnnotation] = process.HolderIdentity()
+	app.ObjectMeta().Annotations[model.OktetoHolderTimestampAnnotation] = now
+	app.ObjectMeta().Annotations[model.OktetoLastActivityAnnotation] = now
+}
+
+// releaseDevClones marks the dev clones held by the current okteto up session as released and records when
+// the session stopped using them. Dev clones held by other sessions are not modified
+func releaseDevClones(ctx context.Context, trMap map[string]*apps.Translation, k8sClient kubernetes.Interface) {
+	identity := process.HolderIdentity()
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, tr := range trMap {
+		devClone, err := tr.App.GetDevClone(ctx, k8sClient)
+		if err != nil {
+			if !oktetoErrors.IsNotFound(err) {
+				oktetoLog.Infof("failed to get dev clone of '%s': %s", tr.App.ObjectMeta().Name, err)
+			}
+			continue
+		}
+		annotations := devClone.ObjectMeta().Annotations
+		if annotations[model.OktetoHolderAnnotation] != identity {
+			continue
+		}
+		annotations[model.OktetoHolderAnnotation] = model.OktetoHolderReleased
+		delete(annotations, model.OktetoHolderTimestampAnnotation)
+		annotations[model.OktetoLastActivityAnnotation] = now
+		if err := devClone.PatchAnnotations(ctx, k8sClient); err != nil && !oktetoErrors.IsNotFound(err) {
+			oktetoLog.Infof("failed to release dev clone '%s': %s", devClone.ObjectMeta().Name, err)
+		}
+	}
+}
+
+// stampVolumeActivity records the current time as the last activity of the persistent volume of the development container
+func stampVolumeActivity(ctx context.Context, dev *model.Dev, namespace string, k8sClient kubernetes.Interface) {
+	if !dev.PersistentVolumeEnabled() {
+		return
+	}
+	annotations := map[string]string{model.OktetoLastActivityAnnotation: time.Now().UTC().Format(time.RFC3339)}
+	if err := volumes.PatchAnnotations(ctx, dev.GetVolumeName(), namespace, annotations, k8sClient); err != nil && !oktetoErrors.IsNotFound(err) {
+		oktetoLog.Infof("failed to record the activity of volume '%s': %s", dev.GetVolumeName(), err)
+	}
+}
+
+// collect removes the local files and the dev clone of a previous okteto up session of the same dev whose process is gone.
+// Nothing is removed if the previous session is still running, and dev clones held by other machines are never touched
+func (c *staleSessionCollector) collect(ctx context.Context, app apps.App, k8sClient kubernetes.Interface) staleSessionSummary {
+	summary := staleSessionSummary{}
+	pidPath := filepath.Join(c.appHome, oktetoPIDFilename)
+	if pid, ok := c.readPID(pidPath); ok && c.isRunning(pid) {
+		oktetoLog.Infof("okteto up session with PID %d is still running, skipping stale artifacts cleanup", pid)
+		return summary
+	}
+
+	summary.files = c.removeLocalFiles(pidPath)
+	if app != nil {
+		summary.devClones = c.removeDevClone(ctx, app, k8sClient)
+	}
+
+	if summary.files > 0 || summary.devClones > 0 {
+		oktetoLog.Information("Cleaned up a previous okteto up session that didn't exit cleanly: %d local file(s) and %d dev clone(s) removed", summary.files, summary.devClones)
+	}
+	return summary
+}
+
+func (c *staleSessionCollector) readPID(pidPath string) (int, bool) {
+	bytes, err := afero.ReadFile(c.fs, pidPath)
+	if err != nil {
+		return 0, false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(bytes)))
+	if err != nil {
+		oktetoLog.Infof("invalid content in PID file '%s': %s", pidPath, err)
+		return 0, false
+	}
+	return pid, true
+}
+
+func (c *staleSessionCollector) removeLocalFiles(pidPath string) int {
+	paths := []string{pidPath, filepath.Join(c.appHome, stateFilename)}
+	stignores, err := afero.Glob(c.fs, filepath.Join(c.appHome, stignoreFilenameGlob))
+	if err != nil {
+		oktetoLog.Infof("failed to list stignore files in '%s': %s", c.appHome, err)
+	}
+	paths = append(paths, stignores...)
+
+	removed := 0
+	for _, path := range paths {
+		if err := c.fs.Remove(path); err != nil {
+			if !os.IsNotExist(err) {
+				oktetoLog.Infof("failed to remove stale file '%s': %s", path, err)
+			}
+			continue
+		}
+		oktetoLog.Infof("removed stale file '%s'", path)
+		removed++
+	}
+	return removed
+}
+
+func (c *staleSessionCollector) removeDevClone(ctx context.Context, app apps.App, k8sClient kubernetes.Interface) int {
+	devClone, err := app.GetDevClone(ctx, k8sClient)
+	if err != nil {
+		if !oktetoErrors.IsNotFound(err) {
+			oktetoLog.Infof("failed to get dev clone of '%s': %s", app.ObjectMeta().Name, err)
+		}
+		return 0
+	}
+
+	name := devClone.ObjectMeta().Name
+	holder, ok := devClone.ObjectMeta().Annotations[model.OktetoHolderAnnotation]
+	if !ok {
+		oktetoLog.Infof("dev clone '%s' has no holder, skipping stale cleanup", name)
+		return 0
+	}
+	if holder == model.OktetoHolderReleased {
+		oktetoLog.Infof("dev clone '%s' was released, skipping stale cleanup", name)
+		return 0
+	}
+	hostname, pid, err := process.ParseHolderIdentity(holder)
+	if err != nil {
+		oktetoLog.Infof("dev clone '%s' has an invalid holder '%s', skipping stale cleanup", name, holder)
+		return 0
+	}
+	if hostname != c.hostname {
+		oktetoLog.Infof("dev clone '%s' is held by '%s' since '%s', skipping stale cleanup", name, holder, devClone.ObjectMeta().Annotations[model.OktetoHolderTimestampAnnotation])
+		return 0
+	}
+	if c.isRunning(pid) {
+		oktetoLog.Infof("dev clone '%s' is held by the running process %d, skipping stale cleanup", name, pid)
+		return 0
+	}
+
+	if err := devClone.Destroy(ctx, k8sClient); err != nil {
+		if !oktetoErrors.IsNotFound(err) {
+			oktetoLog.Infof("failed to destroy stale dev clone '%s': %s", name, err)
+		}
+		return 0
+	}
+	oktetoLog.Infof("destroyed stale dev clone '%s' held by '%s'", name, holder)
+	return 1
+}
@@ -0,0 +1,202 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/k8s/apps"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/process"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestStaleSessionCollector(t *testing.T) {
+	appHome := "/home/.okteto/ns/api"
+	tests := []struct {
+		name              string
+		pidFileContent    string
+		holder            string
+		runningPIDs       map[int]bool
+		expectedFiles     int
+		expectedDevClones int
+		expectCloneExists bool
+	}{
+		{
+			name:              "previous session is still running",
+			pidFileContent:    "100",
+			holder:            "laptop/100",
+			runningPIDs:       map[int]bool{100: true},
+			expectCloneExists: true,
+		},
+		{
+			name:              "crashed session on the same machine",
+			pidFileContent:    "100",
+			holder:            "laptop/100",
+			expectedFiles:     4,
+			expectedDevClones: 1,
+		},
+		{
+			name:              "dev clone held by another machine",
+			pidFileContent:    "100",
+			holder:            "desktop/200",
+			expectedFiles:     4,
+			expectCloneExists: true,
+		},
+		{
+			name:              "dev clone held by a running process",
+			holder:            "laptop/200",
+			runningPIDs:       map[int]bool{200: true},
+			expectedFiles:     3,
+			expectCloneExists: true,
+		},
+		{
+			name:              "released dev clone",
+			pidFileContent:    "100",
+			holder:            model.OktetoHolderReleased,
+			expectedFiles:     4,
+			expectCloneExists: true,
+		},
+		{
+			name:              "dev clone without holder",
+			pidFileContent:    "100",
+			expectedFiles:     4,
+			expectCloneExists: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fs := afero.NewMemMapFs()
+			if tt.pidFileContent != "" {
+				require.NoError(t, afero.WriteFile(fs, filepath.Join(appHome, oktetoPIDFilename), []byte(tt.pidFileContent), 0600))
+			}
+			require.NoError(t, afero.WriteFile(fs, filepath.Join(appHome, stateFilename), []byte("synchronizing"), 0600))
+			require.NoError(t, afero.WriteFile(fs, filepath.Join(appHome, ".stignore-1"), []byte("node_modules"), 0600))
+			require.NoError(t, afero.WriteFile(fs, filepath.Join(appHome, ".stignore-2"), []byte(".git"), 0600))
+			require.NoError(t, afero.WriteFile(fs, filepath.Join(appHome, "okteto.log"), []byte("logs"), 0600))
+
+			d := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "ns"}}
+			clone := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        model.DevCloneName("api"),
+					Namespace:   "ns",
+					Annotations: map[string]string{},
+				},
+			}
+			if tt.holder != "" {
+				clone.Annotations[model.OktetoHolderAnnotation] = tt.holder
+			}
+			c := fake.NewSimpleClientset(d, clone)
+
+			collector := &staleSessionCollector{
+				fs:        fs,
+				isRunning: func(pid int) bool { return tt.runningPIDs[pid] },
+				hostname:  "laptop",
+				appHome:   appHome,
+			}
+			summary := collector.collect(ctx, apps.NewDeploymentApp(d), c)
+			assert.Equal(t, tt.expectedFiles, summary.files)
+			assert.Equal(t, tt.expectedDevClones, summary.devClones)
+
+			logExists, err := afero.Exists(fs, filepath.Join(appHome, "okteto.log"))
+			require.NoError(t, err)
+			assert.True(t, logExists)
+
+			_, err = c.AppsV1().Deployments("ns").Get(ctx, model.DevCloneName("api"), metav1.GetOptions{})
+			if tt.expectCloneExists {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+			_, err = c.AppsV1().Deployments("ns").Get(ctx, "api", metav1.GetOptions{})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSetHolderAnnotations(t *testing.T) {
+	app := apps.NewDeploymentApp(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}}})
+	setHolderAnnotations(app)
+	assert.Equal(t, process.HolderIdentity(), app.ObjectMeta().Annotations[model.OktetoHolderAnnotation])
+	assert.NotEmpty(t, app.ObjectMeta().Annotations[model.OktetoHolderTimestampAnnotation])
+	assert.Equal(t, app.ObjectMeta().Annotations[model.OktetoHolderTimestampAnnotation], app.ObjectMeta().Annotations[model.OktetoLastActivityAnnotation])
+	assert.Empty(t, app.TemplateObjectMeta().Annotations[model.OktetoHolderAnnotation])
+}
+
+func TestReleaseDevClones(t *testing.T) {
+	ctx := context.Background()
+	newClone := func(name, holder string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      model.DevCloneName(name),
+				Namespace: "ns",
+				Annotations: map[string]string{
+					model.OktetoHolderAnnotation:          holder,
+					model.OktetoHolderTimestampAnnotation: "2024-01-01T00:00:00Z",
+				},
+			},
+		}
+	}
+	api := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "ns"}}
+	worker := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "ns"}}
+	db := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "ns"}}
+	c := fake.NewSimpleClientset(api, worker, db, newClone("api", process.HolderIdentity()), newClone("worker", "desktop/200"))
+
+	trMap := map[string]*apps.Translation{
+		"api":    {App: apps.NewDeploymentApp(api)},
+		"worker": {App: apps.NewDeploymentApp(worker)},
+		"db":     {App: apps.NewDeploymentApp(db)},
+	}
+	releaseDevClones(ctx, trMap, c)
+
+	apiClone, err := c.AppsV1().Deployments("ns").Get(ctx, model.DevCloneName("api"), metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, model.OktetoHolderReleased, apiClone.Annotations[model.OktetoHolderAnnotation])
+	assert.NotContains(t, apiClone.Annotations, model.OktetoHolderTimestampAnnotation)
+	assert.NotEmpty(t, apiClone.Annotations[model.OktetoLastActivityAnnotation])
+
+	workerClone, err := c.AppsV1().Deployments("ns").Get(ctx, model.DevCloneName("worker"), metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "desktop/200", workerClone.Annotations[model.OktetoHolderAnnotation])
+	assert.NotContains(t, workerClone.Annotations, model.OktetoLastActivityAnnotation)
+}
+
+func TestStampVolumeActivity(t *testing.T) {
+	ctx := context.Background()
+	dev := &model.Dev{Name: "api", PersistentVolumeInfo: &model.PersistentVolumeInfo{Enabled: true}}
+	pvc := &apiv1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        dev.GetVolumeName(),
+			Namespace:   "ns",
+			Annotations: map[string]string{"team": "backend"},
+		},
+	}
+	c := fake.NewSimpleClientset(pvc)
+
+	stampVolumeActivity(ctx, dev, "ns", c)
+
+	result, err := c.CoreV1().PersistentVolumeClaims("ns").Get(ctx, dev.GetVolumeName(), metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "backend", result.Annotations["team"])
+	assert.NotEmpty(t, result.Annotations[model.OktetoLastActivityAnnotation])
+}
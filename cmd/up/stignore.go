@@ -36,68 +36,29 @@ import (
 
 func addStignoreSecrets(dev *model.Dev, namespace string) error {
 	output := ""
-	for i, folder := range dev.Sync.Folders {
+	folders := append(append([]model.SyncFolder{}, dev.Sync.Folders...), dev.GetServicesSyncFolders()...)
+	for i, folder := range folders {
 		stignorePath := filepath.Join(folder.LocalPath, ".stignore")
-		if !filesystem.FileExists(stignorePath) {
-			continue
-		}
-		infile, err := os.Open(stignorePath)
+		lines, err := getRemoteStignoreLines(stignorePath)
 		if err != nil {
-			return oktetoErrors.UserError{
-				E:    err,
-				Hint: "Update the 'sync' field of your okteto manifest to point to a valid directory path",
-			}
+			return err
 		}
-		defer func() {
-			if err := infile.Close(); err != nil {
-				oktetoLog.Debugf("Error closing file %s: %s", stignorePath, err)
-			}
-		}()
-		reader := bufio.NewReader(infile)
+		if lines == nil && len(dev.Sync.RemoteExcludes) == 0 {
+			continue
+		}
+		// remote excludes are only added to the remote .stignore, so remote-generated paths never travel back
+		lines = append(lines, transformStignoreLines(dev.Sync.RemoteExcludes)...)
 
 		stignoreName := fmt.Sprintf(".stignore-%d", i+1)
 		transformedStignorePath := filepath.Join(config.GetAppHome(namespace, dev.Name), stignoreName)
-		outfile, err := os.OpenFile(transformedStignorePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
-		if err != nil {
-			return err
-		}
-		defer func() {
-			if err := outfile.Close(); err != nil {
-				oktetoLog.Infof("Error closing file %s: %s", transformedStignorePath, err)
-			}
-		}()
-
-		writer := bufio.NewWriter(outfile)
-		defer writer.Flush()
-
-		for {
-			bytes, _, err := reader.ReadLine()
-			if err != nil {
-				if err == io.EOF {
-					break
-				}
-				return err
-			}
-
-			line := strings.TrimSpace(string(bytes))
-			// ignore local lines that are empty, comments or includes more files
-			// TODO: support remote #include https://github.com/okteto/okteto/issues/2832
-			if strings.Compare(line, "") == 0 || strings.HasPrefix(line, "//") || strings.HasPrefix(line, "#") {
-				continue
-			}
-
-			// transform line by adding (?d) unless the line starts with ! or already has (?d)
-			if !strings.HasPrefix(line, "!") && !strings.Contains(line, "(?d)") {
-				line = fmt.Sprintf("(?d)%s", line)
-			}
-
-			// write new line at remote .stignore
-			_, err = writer.WriteString(fmt.Sprintf("%s\n", line))
-			if err != nil {
-				return err
-			}
+		var content strings.Builder
+		for _, line := range lines {
+			content.WriteString(line + "\n")
 			output = fmt.Sprintf("%s\n%s", output, line)
 		}
+		if err := os.WriteFile(transformedStignorePath, []byte(content.String()), 0600); err != nil {
+			return err
+		}
 
 		dev.Secrets = append(
 			dev.Secrets,
@@ -112,6 +73,60 @@ func addStignoreSecrets(dev *model.Dev, namespace string) error {
 	return nil
 }
 
+// getRemoteStignoreLines returns the lines of a local .stignore transformed for the remote .stignore.
+// It returns nil if the file doesn't exist
+func getRemoteStignoreLines(stignorePath string) ([]string, error) {
+	if !filesystem.FileExists(stignorePath) {
+		return nil, nil
+	}
+	infile, err := os.Open(stignorePath)
+	if err != nil {
+		return nil, oktetoErrors.UserError{
+			E:    err,
+			Hint: "Update the 'sync' field of your okteto manifest to point to a valid directory path",
+		}
+	}
+	defer func() {
+		if err := infile.Close(); err != nil {
+			oktetoLog.Debugf("Error closing file %s: %s", stignorePath, err)
+		}
+	}()
+	reader := bufio.NewReader(infile)
+
+	lines := []string{}
+	for {
+		bytes, _, err := reader.ReadLine()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		lines = append(lines, string(bytes))
+	}
+	return transformStignoreLines(lines), nil
+}
+
+// transformStignoreLines transforms stignore patterns for the remote .stignore
+func transformStignoreLines(lines []string) []string {
+	result := []string{}
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		// ignore local lines that are empty, comments or includes more files
+		// TODO: support remote #include https://github.com/okteto/okteto/issues/2832
+		if strings.Compare(line, "") == 0 || strings.HasPrefix(line, "//") || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// transform line by adding (?d) unless the line starts with ! or already has (?d)
+		if !strings.HasPrefix(line, "!") && !strings.Contains(line, "(?d)") {
+			line = fmt.Sprintf("(?d)%s", line)
+		}
+		result = append(result, line)
+	}
+	return result
+}
+
 func addSyncFieldHash(dev *model.Dev) error {
 	output, err := json.Marshal(dev.Sync)
 	if err != nil {
@@ -126,7 +141,8 @@ func checkStignoreConfiguration(dev *model.Dev) error {
 		return nil
 	}
 
-	for _, folder := range dev.Sync.Folders {
+	folders := append(append([]model.SyncFolder{}, dev.Sync.Folders...), dev.GetServicesSyncFolders()...)
+	for _, folder := range folders {
 		stignorePath := filepath.Join(folder.LocalPath, ".stignore")
 		gitPath := filepath.Join(folder.LocalPath, ".git")
 		if !filesystem.FileExists(stignorePath) {
@@ -216,7 +232,7 @@ func getLanguage(language, workDir string) (string, error) {
 		l = linguist.Unrecognized
 	}
 	oktetoLog.Infof("language '%s' inferred for your current directory", l)
-	if l == linguist.Unrecognized {
+	if l == linguist.Unrecognized && !utils.IsNonInteractive() {
 		l, err = askForLanguage()
 		if err != nil {
 			return "", err
@@ -21,6 +21,8 @@ import (
 	"testing"
 
 	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/linguist"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/stretchr/testify/assert"
 )
@@ -107,3 +109,109 @@ func Test_addStignoreSecrets(t *testing.T) {
 		})
 	}
 }
+
+func Test_addStignoreSecretsWithServicesSyncFolders(t *testing.T) {
+	mainPath := t.TempDir()
+	servicePath := t.TempDir()
+	namespace := "test-namespace"
+
+	if err := os.WriteFile(filepath.Join(mainPath, ".stignore"), []byte("node_modules"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(servicePath, ".stignore"), []byte("*.pb.go"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	dev := &model.Dev{
+		Name: "test-services",
+		Sync: model.Sync{
+			Folders: []model.SyncFolder{
+				{LocalPath: mainPath, RemotePath: "/app"},
+			},
+		},
+		Services: []*model.Dev{
+			{
+				Name: "worker",
+				Sync: model.Sync{
+					Folders: []model.SyncFolder{
+						{LocalPath: servicePath, RemotePath: "/protos"},
+					},
+				},
+			},
+		},
+		Metadata: &model.Metadata{
+			Annotations: model.Annotations{},
+		},
+	}
+
+	assert.NoError(t, addStignoreSecrets(dev, namespace))
+	assert.Len(t, dev.Secrets, 2)
+	assert.Equal(t, "/app/.stignore", dev.Secrets[0].RemotePath)
+	assert.Equal(t, filepath.ToSlash(filepath.Join(dev.GetServicesSyncFolders()[0].RemotePath, ".stignore")), dev.Secrets[1].RemotePath)
+
+	file, err := os.ReadFile(filepath.Join(config.GetAppHome(namespace, dev.Name), ".stignore-2"))
+	assert.NoError(t, err)
+	assert.Equal(t, "(?d)*.pb.go\n", string(file))
+}
+
+func Test_addStignoreSecretsWithRemoteExcludes(t *testing.T) {
+	mainPath := t.TempDir()
+	otherPath := t.TempDir()
+	namespace := "test-namespace"
+
+	localStignore := "node_modules\n"
+	if err := os.WriteFile(filepath.Join(mainPath, ".stignore"), []byte(localStignore), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	dev := &model.Dev{
+		Name: "test-remote-excludes",
+		Sync: model.Sync{
+			Folders: []model.SyncFolder{
+				{LocalPath: mainPath, RemotePath: "/app"},
+				{LocalPath: otherPath, RemotePath: "/other"},
+			},
+			RemoteExcludes: []string{"target/", "# generated by next", ".next/", "!target/keep"},
+		},
+		Metadata: &model.Metadata{
+			Annotations: model.Annotations{},
+		},
+	}
+
+	assert.NoError(t, addStignoreSecrets(dev, namespace))
+	assert.Len(t, dev.Secrets, 2)
+	assert.Equal(t, "/app/.stignore", dev.Secrets[0].RemotePath)
+	assert.Equal(t, "/other/.stignore", dev.Secrets[1].RemotePath)
+
+	// the remote .stignore files include the remote excludes as (?d) patterns
+	file, err := os.ReadFile(filepath.Join(config.GetAppHome(namespace, dev.Name), ".stignore-1"))
+	assert.NoError(t, err)
+	assert.Equal(t, "(?d)node_modules\n(?d)target/\n(?d).next/\n!target/keep\n", string(file))
+
+	file, err = os.ReadFile(filepath.Join(config.GetAppHome(namespace, dev.Name), ".stignore-2"))
+	assert.NoError(t, err)
+	assert.Equal(t, "(?d)target/\n(?d).next/\n!target/keep\n", string(file))
+
+	// the local .stignore is left untouched, so the local ignore set doesn't include the remote excludes
+	file, err = os.ReadFile(filepath.Join(mainPath, ".stignore"))
+	assert.NoError(t, err)
+	assert.Equal(t, localStignore, string(file))
+	assert.NoFileExists(t, filepath.Join(otherPath, ".stignore"))
+
+	expectedOutput := "\n(?d)node_modules\n(?d)target/\n(?d).next/\n!target/keep\n(?d)target/\n(?d).next/\n!target/keep"
+	assert.Equal(t, fmt.Sprintf("%x", sha512.Sum512([]byte(expectedOutput))), dev.Metadata.Annotations[model.OktetoStignoreAnnotation])
+}
+
+func Test_askIfCreateStignoreDefaultsNonInteractive(t *testing.T) {
+	t.Setenv(constants.OktetoNonInteractiveEnvVar, "true")
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+	folder := t.TempDir()
+	stignorePath := filepath.Join(folder, ".stignore")
+
+	err := askIfCreateStignoreDefaults(folder, stignorePath)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(stignorePath)
+	assert.NoError(t, err)
+	assert.Equal(t, string(linguist.GetSTIgnore(linguist.Unrecognized)), string(content))
+}
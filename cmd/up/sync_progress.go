@@ -0,0 +1,98 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/syncthing"
+)
+
+const (
+	// syncStallTimeout is the time without progress after which the initial synchronization is considered stalled
+	syncStallTimeout = 60 * time.Second
+
+	// plainSyncProgressInterval is the interval between the progress lines of the initial synchronization when the output is not a TTY
+	plainSyncProgressInterval = 10 * time.Second
+)
+
+// syncProgressBar renders the progress of the initial synchronization in TTY mode
+type syncProgressBar interface {
+	SetCurrent(v int64)
+	SetDetails(details string)
+}
+
+// syncProgress displays the progress of the initial synchronization: a progress bar with the transfer rate and ETA
+// in TTY mode and periodic lines otherwise. It warns once when the synchronization stalls
+type syncProgress struct {
+	lastLine    time.Time
+	bar         syncProgressBar
+	tracker     *utils.TransferTracker
+	now         func() time.Time
+	printLine   func(line string)
+	warnStall   func()
+	plain       bool
+	stallWarned bool
+}
+
+func newSyncProgress(bar syncProgressBar, plain bool) *syncProgress {
+	return &syncProgress{
+		bar:       bar,
+		tracker:   utils.NewTransferTracker(),
+		now:       time.Now,
+		printLine: func(line string) { oktetoLog.Println(line) },
+		warnStall: warnSyncStalled,
+		plain:     plain,
+	}
+}
+
+// update displays a new report of the synchronization
+func (p *syncProgress) update(progress syncthing.Progress) {
+	now := p.now()
+	p.tracker.Update(now, progress.DoneBytes, progress.TotalBytes)
+
+	value := int64(progress.Percentage)
+	if value > 0 && value < totalProgressValue {
+		if p.plain {
+			if now.Sub(p.lastLine) >= plainSyncProgressInterval {
+				p.printLine(formatSyncProgressLine(value, p.tracker.Details()))
+				p.lastLine = now
+			}
+		} else {
+			oktetoLog.StopSpinner()
+			p.bar.SetDetails(p.tracker.Details())
+			p.bar.SetCurrent(value)
+		}
+	}
+
+	if !p.stallWarned && p.tracker.StalledFor(now) >= syncStallTimeout {
+		p.stallWarned = true
+		p.warnStall()
+	}
+}
+
+func formatSyncProgressLine(value int64, details string) string {
+	if details == "" {
+		return fmt.Sprintf("Synchronizing your files: %d%%", value)
+	}
+	return fmt.Sprintf("Synchronizing your files: %d%% (%s)", value, details)
+}
+
+func warnSyncStalled() {
+	oktetoLog.Warning("The synchronization of your files has not progressed in the last minute")
+	oktetoLog.Hint("    Antivirus software or the file watchers of your IDE can lock the files being synchronized.\n    Try excluding your project folder from them, or add large folders to your .stignore file")
+}
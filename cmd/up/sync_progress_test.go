@@ -0,0 +1,117 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/syncthing"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSyncProgressBar struct {
+	details []string
+	current []int64
+}
+
+func (f *fakeSyncProgressBar) SetCurrent(v int64) {
+	f.current = append(f.current, v)
+}
+
+func (f *fakeSyncProgressBar) SetDetails(details string) {
+	f.details = append(f.details, details)
+}
+
+type scriptedReport struct {
+	after    time.Duration
+	progress syncthing.Progress
+}
+
+// runScriptedSync feeds the reports to a syncProgress and returns the printed lines and the number of stall warnings
+func runScriptedSync(bar *fakeSyncProgressBar, plain bool, reports []scriptedReport) ([]string, int) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	var lines []string
+	stalls := 0
+	p := newSyncProgress(bar, plain)
+	p.now = func() time.Time { return now }
+	p.printLine = func(line string) { lines = append(lines, line) }
+	p.warnStall = func() { stalls++ }
+	for _, r := range reports {
+		now = start.Add(r.after)
+		p.update(r.progress)
+	}
+	return lines, stalls
+}
+
+func progressOf(done, total int64) syncthing.Progress {
+	return syncthing.Progress{Percentage: float64(done) / float64(total) * 100, DoneBytes: done, TotalBytes: total}
+}
+
+func TestSyncProgressTTY(t *testing.T) {
+	bar := &fakeSyncProgressBar{}
+	lines, stalls := runScriptedSync(bar, false, []scriptedReport{
+		{after: 0, progress: progressOf(0, 10_000_000)},
+		{after: time.Second, progress: progressOf(1_000_000, 10_000_000)},
+		{after: 2 * time.Second, progress: progressOf(5_000_000, 10_000_000)},
+		{after: 3 * time.Second, progress: progressOf(10_000_000, 10_000_000)},
+	})
+
+	assert.Empty(t, lines)
+	assert.Zero(t, stalls)
+	assert.Equal(t, []int64{10, 50}, bar.current)
+	assert.Equal(t, []string{
+		"1.0MB/10.0MB, 1.0MB/s, ETA 9s",
+		"5.0MB/10.0MB, 2.5MB/s, ETA 2s",
+	}, bar.details)
+}
+
+func TestSyncProgressPlain(t *testing.T) {
+	bar := &fakeSyncProgressBar{}
+	reports := []scriptedReport{{after: 0, progress: progressOf(0, 100_000_000)}}
+	for i := int64(1); i <= 25; i++ {
+		reports = append(reports, scriptedReport{after: time.Duration(i) * time.Second, progress: progressOf(i*2_000_000, 100_000_000)})
+	}
+	lines, stalls := runScriptedSync(bar, true, reports)
+
+	assert.Empty(t, bar.current)
+	assert.Zero(t, stalls)
+	assert.Equal(t, []string{
+		"Synchronizing your files: 2% (2.0MB/100.0MB, 2.0MB/s, ETA 49s)",
+		"Synchronizing your files: 22% (22.0MB/100.0MB, 2.0MB/s, ETA 39s)",
+		"Synchronizing your files: 42% (42.0MB/100.0MB, 2.0MB/s, ETA 29s)",
+	}, lines)
+}
+
+func TestSyncProgressStall(t *testing.T) {
+	bar := &fakeSyncProgressBar{}
+	reports := []scriptedReport{
+		{after: 0, progress: progressOf(0, 1_000_000)},
+		{after: time.Second, progress: progressOf(500_000, 1_000_000)},
+	}
+	for i := 10; i <= 120; i += 10 {
+		reports = append(reports, scriptedReport{after: time.Duration(i) * time.Second, progress: progressOf(500_000, 1_000_000)})
+	}
+	_, stalls := runScriptedSync(bar, false, reports)
+	assert.Equal(t, 1, stalls)
+
+	_, stalls = runScriptedSync(bar, false, []scriptedReport{
+		{after: 0, progress: progressOf(0, 1_000_000)},
+		{after: 50 * time.Second, progress: progressOf(500_000, 1_000_000)},
+		{after: 100 * time.Second, progress: progressOf(900_000, 1_000_000)},
+		{after: 150 * time.Second, progress: progressOf(1_000_000, 1_000_000)},
+	})
+	assert.Zero(t, stalls)
+}
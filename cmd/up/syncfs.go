@@ -0,0 +1,96 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/filesystem"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+const (
+	// maxCaseCollisionsShown is the number of case collisions listed when okteto up refuses to start
+	maxCaseCollisionsShown = 10
+)
+
+// caseCollisionsSkipDirs are the directories not scanned for case collisions
+var caseCollisionsSkipDirs = []string{".git"}
+
+// checkSyncFolders warns about sync folders on filesystems that break the file synchronization, and fails if
+// a sync folder has files whose names only differ in case, unless ignoreCaseCollisions is true
+func checkSyncFolders(dev *model.Dev, ignoreCaseCollisions bool) error {
+	if dev.IsHybridModeEnabled() {
+		return nil
+	}
+
+	folders := append(append([]model.SyncFolder{}, dev.Sync.Folders...), dev.GetServicesSyncFolders()...)
+	for _, folder := range folders {
+		warnSyncFolderFilesystem(folder.LocalPath)
+
+		collisions, err := filesystem.FindCaseCollisions(folder.LocalPath, caseCollisionsSkipDirs)
+		if err != nil {
+			oktetoLog.Infof("failed to check case collisions of '%s': %s", folder.LocalPath, err)
+			continue
+		}
+		if len(collisions) == 0 {
+			continue
+		}
+		if ignoreCaseCollisions {
+			oktetoLog.Warning("The sync folder '%s' has %d files whose names only differ in case. They will conflict in your development container", folder.LocalPath, len(collisions))
+			continue
+		}
+		return newCaseCollisionsError(folder.LocalPath, collisions)
+	}
+	return nil
+}
+
+// warnSyncFolderFilesystem warns if the sync folder is on a network filesystem or synchronized by a cloud storage provider
+func warnSyncFolderFilesystem(localPath string) {
+	if provider := filesystem.GetCloudSyncProvider(localPath); provider != "" {
+		oktetoLog.Warning("The sync folder '%s' is synchronized by %s. Its changes can be synchronized back and forth, causing conflicts. Move the folder out of %s if you see unexpected file changes", localPath, provider, provider)
+	}
+
+	fsType, err := filesystem.GetFilesystemType(localPath)
+	if err != nil {
+		if !errors.Is(err, filesystem.ErrFilesystemTypeNotSupported) {
+			oktetoLog.Infof("failed to get the filesystem type of '%s': %s", localPath, err)
+		}
+		return
+	}
+	oktetoLog.Infof("sync folder '%s' is on a '%s' filesystem", localPath, fsType)
+	if filesystem.IsProblematicFilesystem(fsType) {
+		oktetoLog.Warning("The sync folder '%s' is on a '%s' filesystem. Network filesystems don't always notify file changes or keep modification times, which can cause slow or repeated synchronizations. Use a local folder for a better experience", localPath, fsType)
+	}
+}
+
+func newCaseCollisionsError(localPath string, collisions [][]string) error {
+	lines := []string{}
+	for i, group := range collisions {
+		if i == maxCaseCollisionsShown {
+			lines = append(lines, fmt.Sprintf("    ... and %d more", len(collisions)-maxCaseCollisionsShown))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("    - %s", strings.Join(group, ", ")))
+	}
+	return oktetoErrors.UserError{
+		E: fmt.Errorf("the sync folder '%s' has files whose names only differ in case:\n%s", localPath, strings.Join(lines, "\n")),
+		Hint: `These files conflict when they are synchronized with a case-insensitive filesystem.
+    Rename them or run 'okteto up' with the '--ignore-case-collisions' flag to continue anyway`,
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSyncFolders(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Foo.go"), []byte("Foo"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "foo.go"), []byte("foo"), 0600))
+	content, err := os.ReadFile(filepath.Join(dir, "Foo.go"))
+	require.NoError(t, err)
+	if string(content) != "Foo" {
+		t.Skip("the filesystem is case-insensitive")
+	}
+
+	dev := &model.Dev{
+		Sync: model.Sync{
+			Folders: []model.SyncFolder{{LocalPath: dir, RemotePath: "/app"}},
+		},
+	}
+
+	err = checkSyncFolders(dev, false)
+	var userErr oktetoErrors.UserError
+	require.ErrorAs(t, err, &userErr)
+	assert.Contains(t, err.Error(), "- Foo.go, foo.go")
+	assert.Contains(t, userErr.Hint, "--ignore-case-collisions")
+
+	assert.NoError(t, checkSyncFolders(dev, true))
+
+	dev.Mode = constants.OktetoHybridModeFieldValue
+	assert.NoError(t, checkSyncFolders(dev, false))
+}
+
+func TestNewCaseCollisionsErrorTruncates(t *testing.T) {
+	collisions := [][]string{}
+	for i := 0; i < maxCaseCollisionsShown+3; i++ {
+		collisions = append(collisions, []string{fmt.Sprintf("A%d", i), fmt.Sprintf("a%d", i)})
+	}
+
+	err := newCaseCollisionsError("/src", collisions)
+	assert.Contains(t, err.Error(), "- A9, a9")
+	assert.NotContains(t, err.Error(), "- A10, a10")
+	assert.Contains(t, err.Error(), "... and 3 more")
+}
@@ -23,6 +23,7 @@ import (
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/syncthing"
+	"github.com/okteto/okteto/pkg/types"
 	"github.com/spf13/afero"
 )
 
@@ -32,7 +33,7 @@ const (
 )
 
 func (up *upContext) initializeSyncthing() error {
-	sy, err := syncthing.New(up.Dev, up.Namespace, up.Fs)
+	sy, err := up.syncthingCtrl.New(up.Dev, up.Namespace, up.Fs)
 	if err != nil {
 		return err
 	}
@@ -60,6 +61,7 @@ func (up *upContext) sync(ctx context.Context) error {
 	if err := config.UpdateStateFile(up.Dev.Name, up.Namespace, config.Synchronizing); err != nil {
 		return err
 	}
+	up.events.publishPhase(types.UpPhaseSyncing)
 
 	up.checkForSystemErrors(ctx)
 
@@ -93,6 +95,9 @@ func (up *upContext) sync(ctx context.Context) error {
 
 	go up.Sy.Monitor(ctx, up.Disconnect)
 	go up.Sy.MonitorStatus(ctx, up.Disconnect)
+	if up.Dev.Sync.GetChownOwner() != "" && !up.Dev.IsHybridModeEnabled() {
+		go up.translateOwnership(ctx)
+	}
 	oktetoLog.Infof("restarting syncthing to update sync mode to sendreceive")
 	return up.Sy.Restart(ctx)
 }
@@ -164,6 +169,8 @@ func (up *upContext) synchronizeFiles(ctx context.Context) error {
 
 	progressBar := utils.NewSyncthingProgressBar(defaultProgressBarWidth)
 	defer progressBar.Finish()
+	plain := oktetoLog.GetOutputFormat() != oktetoLog.TTYFormat
+	progress := newSyncProgress(progressBar, plain)
 
 	quit := make(chan bool)
 
@@ -174,7 +181,7 @@ func (up *upContext) synchronizeFiles(ctx context.Context) error {
 				return
 			case <-time.NewTicker(1 * time.Second).C:
 				inSynchronizationFile := up.Sy.GetInSynchronizationFile(ctx)
-				if inSynchronizationFile != "" && oktetoLog.GetOutputFormat() != oktetoLog.PlainFormat {
+				if inSynchronizationFile != "" && !plain {
 					oktetoLog.StopSpinner()
 					progressBar.UpdateItemInSync(inSynchronizationFile)
 				}
@@ -182,18 +189,11 @@ func (up *upContext) synchronizeFiles(ctx context.Context) error {
 		}
 	}()
 
-	reporter := make(chan float64)
+	reporter := make(chan syncthing.Progress)
 	go func() {
-		for c := range reporter {
-			value := int64(c)
-			if value > 0 && value < 100 {
-				if oktetoLog.GetOutputFormat() == oktetoLog.PlainFormat {
-					oktetoLog.Spinner(fmt.Sprintf("Synchronizing your files [%d]...", value))
-				} else {
-					oktetoLog.StopSpinner()
-					progressBar.SetCurrent(value)
-				}
-			}
+		for p := range reporter {
+			up.events.publishSyncProgress(p.Percentage)
+			progress.update(p)
 		}
 		quit <- true
 	}()
@@ -15,6 +15,7 @@ package up
 
 import (
 	"context"
+	"os"
 	"os/exec"
 	"time"
 
@@ -22,13 +23,15 @@ import (
 	"github.com/okteto/okteto/pkg/analytics"
 	buildCmd "github.com/okteto/okteto/pkg/cmd/build"
 	"github.com/okteto/okteto/pkg/k8s/apps"
+	forwardk8s "github.com/okteto/okteto/pkg/k8s/forward"
 	"github.com/okteto/okteto/pkg/model"
-	"github.com/okteto/okteto/pkg/model/forward"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/syncthing"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/spf13/afero"
 	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 )
 
 type registryInterface interface {
@@ -53,6 +56,7 @@ type analyticsTrackerInterface interface {
 	TrackWakeTriggered(ctx context.Context, m analytics.WakeTriggeredMetadata)
 	TrackUp(*analytics.UpMetricsMetadata)
 	TrackUpStarted(service, namespace, repoURL, workflowID string)
+	TrackUpSessionEnd(*analytics.UpMetricsMetadata)
 	TrackDown(bool)
 	TrackDownVolumes(bool)
 }
@@ -76,8 +80,13 @@ type upContext struct {
 	Namespace             string
 	autoDown              *autoDownRunner
 	StartTime             time.Time
-	Forwarder             forwarder
+	Forwarder             forwardk8s.Forwarder
+	appRetriever          AppRetriever
+	podWaiter             PodWaiter
+	forwarderFactory      ForwarderFactory
+	syncthingCtrl         SyncthingController
 	tokenUpdater          tokenUpdater
+	kubeconfigReloader    kubeconfigReloader
 	builder               builderInterface
 	analyticsTracker      analyticsTrackerInterface
 	Fs                    afero.Fs
@@ -91,6 +100,10 @@ type upContext struct {
 	Sy                    *syncthing.Syncthing
 	cleaned               chan string
 	hardTerminate         chan error
+	interrupt             <-chan os.Signal
+	exitGuard             *exitGuard
+	events                *eventsPublisher
+	globalForwards        *globalForwardRegistry
 	Translations          map[string]*apps.Translation
 	Manifest              *model.Manifest
 	analyticsMeta         *analytics.UpMetricsMetadata
@@ -109,12 +122,24 @@ type upContext struct {
 	interruptReceived     bool
 }
 
-// Forwarder is an interface for the port-forwarding features
-type forwarder interface {
-	Add(forward.Forward) error
-	AddReverse(model.Reverse) error
-	Start(string, string) error
-	StartGlobalForwarding() error
-	Stop()
-	TransformLabelsToServiceName(forward.Forward) (forward.Forward, error)
+// AppRetriever retrieves the app of the development container
+type AppRetriever interface {
+	GetApp(ctx context.Context, dev *model.Dev, namespace string, c kubernetes.Interface, isRetry bool) (apps.App, bool, error)
+}
+
+// PodWaiter waits until the pod of the development container is running
+type PodWaiter interface {
+	WaitForRunningPod(ctx context.Context, dev *model.Dev, app apps.App, c kubernetes.Interface, timeout time.Duration) (*apiv1.Pod, error)
+}
+
+// ForwarderFactory creates the forwarders to the development container
+type ForwarderFactory interface {
+	NewPortForwarder(ctx context.Context, dev *model.Dev, restConfig *rest.Config, c kubernetes.Interface, namespace string) forwardk8s.Forwarder
+	NewSSHForwarder(ctx context.Context, dev *model.Dev, restConfig *rest.Config, c kubernetes.Interface, namespace string) (forwardk8s.Forwarder, error)
+}
+
+// SyncthingController creates and checks the syncthing instances of the development container
+type SyncthingController interface {
+	New(dev *model.Dev, namespace string, fs afero.Fs) (*syncthing.Syncthing, error)
+	Ping(ctx context.Context, sy *syncthing.Syncthing, local bool) bool
 }
@@ -17,6 +17,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -45,6 +46,7 @@ import (
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/log/io"
 	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
 	modelutils "github.com/okteto/okteto/pkg/model/utils"
 	"github.com/okteto/okteto/pkg/okteto"
 	oktetoPath "github.com/okteto/okteto/pkg/path"
@@ -80,14 +82,31 @@ type Options struct {
 	// ManifestPath is the path to the manifest used though the command execution.
 	// This might change its value during execution
 	ManifestPath string
-	Namespace    string
-	K8sContext   string
-	DevName      string
-	Envs         []string
-	Remote       int
-	Deploy       bool
-	ForcePull    bool
-	Reset        bool
+	// Workdir is the directory where builds, deploy commands and sync folders are resolved,
+	// instead of the folder of the manifest
+	Workdir          string
+	Namespace        string
+	K8sContext       string
+	DevName          string
+	Envs             []string
+	EnvPassthrough   []string
+	Aliases          []string
+	BuildArgs        []string
+	Remote           int
+	Deploy           bool
+	ForcePull        bool
+	Reset            bool
+	ForwardInterface string
+	AllowPrivileged  bool
+	AllowHostAccess  bool
+	StrictNamespace  bool
+	Builder          string
+	// WaitReady exits okteto up once the development container is ready, leaving it deployed
+	WaitReady bool
+	// IgnoreCaseCollisions starts okteto up even if a sync folder has files whose names only differ in case
+	IgnoreCaseCollisions bool
+	// DeployTimeout is the maximum time to deploy the development environment and its dependencies, overriding 'deploy.timeout'
+	DeployTimeout time.Duration
 }
 
 // Up starts a development container
@@ -121,6 +140,10 @@ okteto up api -- echo this is a test
 
 			checkLocalWatchesConfiguration()
 
+			if upOptions.AllowHostAccess {
+				os.Setenv(model.OktetoAllowHostAccessEnvVar, "true")
+			}
+
 			ctx := context.Background()
 
 			ctxOpts := &contextCMD.Options{
@@ -139,6 +162,14 @@ okteto up api -- echo this is a test
 			defer at.TrackUp(upMeta)
 
 			startOkContextConfig := time.Now()
+			if upOptions.Workdir != "" {
+				// the workdir is relative to the directory where the command is executed, before moving to the manifest folder
+				workdir, err := filepath.Abs(upOptions.Workdir)
+				if err != nil {
+					return err
+				}
+				upOptions.Workdir = workdir
+			}
 			if upOptions.ManifestPath != "" {
 				// if path is absolute, its transformed to rel from root
 				initialCWD, err := os.Getwd()
@@ -174,15 +205,46 @@ okteto up api -- echo this is a test
 				}
 			}
 
+			ns, err := resolveNamespace(upOptions.Namespace, oktetoManifest.Namespace, okteto.GetContext().Namespace, upOptions.StrictNamespace)
+			if err != nil {
+				return err
+			}
+			if ns != okteto.GetContext().Namespace {
+				// the deploy, build and secrets of the development environment read the namespace from the okteto context
+				ctxOpts := &contextCMD.Options{
+					Context:   okteto.GetContext().Name,
+					Namespace: ns,
+				}
+				if err := contextCMD.NewContextCommand().Run(ctx, ctxOpts); err != nil {
+					return err
+				}
+				// the env vars of the okteto manifest are expanded with the resolved namespace
+				oktetoManifest, err = model.GetManifestV2(upOptions.ManifestPath, fs)
+				if err != nil {
+					return err
+				}
+			}
+
+			if err := oktetoManifest.ChangeWorkdir(upOptions.Workdir, fs); err != nil {
+				return err
+			}
+			if oktetoManifest.Workdir != "" && oktetoManifest.ManifestPath != "" {
+				upOptions.ManifestPath = oktetoManifest.ManifestPath
+			}
+
 			if !okteto.IsOkteto() {
 				if err := oktetoManifest.ValidateForCLIOnly(); err != nil {
 					return err
 				}
 			}
 
+			if err := buildCmd.MergeBuildArgs(oktetoManifest, upOptions.BuildArgs); err != nil {
+				return err
+			}
+
 			upMeta.OktetoContextConfig(time.Since(startOkContextConfig))
 			if okteto.IsOkteto() {
-				create, err := utils.ShouldCreateNamespace(ctx, okteto.GetContext().Namespace)
+				create, err := utils.ShouldCreateNamespace(ctx, ns)
 				if err != nil {
 					return err
 				}
@@ -191,24 +253,28 @@ okteto up api -- echo this is a test
 					if err != nil {
 						return err
 					}
-					if err := nsCmd.Create(ctx, &namespace.CreateOptions{Namespace: okteto.GetContext().Namespace}); err != nil {
+					if err := nsCmd.Create(ctx, &namespace.CreateOptions{Namespace: ns}); err != nil {
 						return err
 					}
 				}
 			}
 
+			k8sClient, _, err := okteto.GetK8sClientWithLogger(k8sLogger)
+			if err != nil {
+				return fmt.Errorf("failed to load k8s client: %w", err)
+			}
+			if err := utils.CheckNamespaceAccess(ctx, ns, k8sClient); err != nil {
+				return err
+			}
+
 			wd, err := os.Getwd()
 			if err != nil {
 				return err
 			}
 			if oktetoManifest.Name == "" {
 				oktetoLog.Info("okteto manifest doesn't have a name, inferring it...")
-				c, _, err := okteto.NewK8sClientProviderWithLogger(k8sLogger).Provide(okteto.GetContext().Cfg)
-				if err != nil {
-					return err
-				}
-				inferer := devenvironment.NewNameInferer(c)
-				oktetoManifest.Name = inferer.InferName(ctx, wd, okteto.GetContext().Namespace, upOptions.ManifestPathFlag)
+				inferer := devenvironment.NewNameInferer(k8sClient)
+				oktetoManifest.Name = inferer.InferName(ctx, wd, ns, upOptions.ManifestPathFlag)
 			}
 			os.Setenv(constants.OktetoNameEnvVar, oktetoManifest.Name)
 
@@ -219,42 +285,13 @@ okteto up api -- echo this is a test
 				return oktetoErrors.ErrManifestNoDevSection
 			}
 
-			onBuildFinish := []buildv2.OnBuildFinish{
-				at.TrackImageBuild,
-				insights.TrackImageBuild,
-			}
-
-			up := &upContext{
-				Namespace:         okteto.GetContext().Namespace,
-				Manifest:          oktetoManifest,
-				Dev:               nil,
-				Exit:              make(chan error, 1),
-				resetSyncthing:    upOptions.Reset,
-				StartTime:         time.Now(),
-				Registry:          registry.NewOktetoRegistry(okteto.Config{}),
-				Options:           upOptions,
-				Fs:                fs,
-				analyticsTracker:  at,
-				analyticsMeta:     upMeta,
-				K8sClientProvider: okteto.NewK8sClientProviderWithLogger(k8sLogger),
-				tokenUpdater:      newTokenUpdaterController(),
-				builder:           buildv2.NewBuilderFromScratch(ioCtrl, onBuildFinish, buildCmd.GetBuildkitConnector(&okteto.ContextStateless{Store: okteto.GetContextStore()}, ioCtrl, at)),
-				autoDown:          newAutoDown(ioCtrl, k8sLogger, at, upMeta),
-			}
-			up.inFd, up.isTerm = term.GetFdInfo(os.Stdin)
-			if up.isTerm {
-				var err error
-				up.stateTerm, err = term.SaveState(up.inFd)
-				if err != nil {
-					oktetoLog.Infof("failed to save the state of the terminal: %s", err.Error())
-					return fmt.Errorf("failed to save the state of the terminal")
-				}
-				oktetoLog.Infof("Terminal: %v", up.stateTerm)
+			if err := buildCmd.SelectBuilder(ctx, upOptions.Builder, okteto.GetContext().Builder, buildCmd.ProbeBuilder); err != nil {
+				return err
 			}
 
-			k8sClient, _, err := okteto.GetK8sClientWithLogger(k8sLogger)
+			up, err := newUpContext(ns, oktetoManifest, upOptions, fs, at, insights, ioCtrl, k8sLogger, upMeta)
 			if err != nil {
-				return fmt.Errorf("failed to load k8s client: %w", err)
+				return err
 			}
 
 			devEnvDeployer := NewDevEnvDeployerManager(up, ioCtrl, k8sLogger)
@@ -262,18 +299,20 @@ okteto up api -- echo this is a test
 				deployFlag:       upOptions.Deploy,
 				okCtx:            okteto.GetContext(),
 				devenvName:       up.Manifest.Name,
-				ns:               okteto.GetContext().Namespace,
+				ns:               ns,
 				manifestPathFlag: upOptions.ManifestPathFlag,
 				manifestPath:     upOptions.ManifestPath,
 				manifest:         oktetoManifest,
+				timeout:          upOptions.DeployTimeout,
+				allowPrivileged:  upOptions.AllowPrivileged,
 			}
 			if err := devEnvDeployer.DeployIfNeeded(ctx, deployParams, up.analyticsMeta); err != nil {
-				return err
+				return up.waitReadyError(err)
 			}
 
 			devCommandParser := oargs.NewDevCommandArgParser(oargs.NewManifestDevLister(), ioCtrl, false)
 
-			argsparserResult, err := devCommandParser.Parse(ctx, args, cmd.ArgsLenAtDash(), oktetoManifest.Dev, okteto.GetContext().Namespace)
+			argsparserResult, err := devCommandParser.Parse(ctx, args, cmd.ArgsLenAtDash(), oktetoManifest.Dev, ns)
 			if err != nil {
 				return err
 			}
@@ -287,19 +326,31 @@ okteto up api -- echo this is a test
 			if err != nil {
 				oktetoLog.Infof("failed to get repo URL for analytics: %s", err)
 			}
-			at.TrackUpStarted(dev.Name, okteto.GetContext().Namespace, upStartedRepoURL, upMeta.WorkflowID())
+			at.TrackUpStarted(dev.Name, ns, upStartedRepoURL, upMeta.WorkflowID())
 			upMeta.SetRepoURL(upStartedRepoURL)
 
 			if len(argsparserResult.Command) > 0 {
 				dev.Command.Values = argsparserResult.Command
 			}
 
+			if len(upOptions.EnvPassthrough) > 0 {
+				if err := model.ValidateEnvPassthrough(upOptions.EnvPassthrough); err != nil {
+					return err
+				}
+				dev.EnvironmentPassthrough = append(dev.EnvironmentPassthrough, upOptions.EnvPassthrough...)
+			}
+
 			if err := dev.PreparePathsAndExpandEnvFiles(oktetoManifest.ManifestPath, up.Fs); err != nil {
 				return fmt.Errorf("error in 'dev' section of your manifest: %w", err)
 			}
 
 			up.Dev = dev
 
+			if err := up.events.listen(types.GetUpEventsSocketPath(up.Namespace, dev.Name)); err != nil {
+				oktetoLog.Infof("failed to listen in the up events socket: %s", err)
+			}
+			defer up.events.close()
+
 			// only if the context is an okteto one, we should verify if the namespace has to be woken up
 			if okteto.GetContext().IsOkteto {
 				// We execute it in a goroutine to not impact the command performance
@@ -317,84 +368,170 @@ okteto up api -- echo this is a test
 			}
 
 			// build images and set env vars for the services at the manifest
-			if err := newUpBuilder(oktetoManifest, argsparserResult.DevName, up.builder, up.Registry, upMeta).build(ctx); err != nil {
-				return err
-			}
-
-			if err := loadManifestOverrides(dev, upOptions); err != nil {
-				return err
-			}
-
-			if syncthing.ShouldUpgrade() {
-				oktetoLog.Println("Installing dependencies...")
-				if err := downloadSyncthing(); err != nil {
-					oktetoLog.Infof("failed to upgrade syncthing: %s", err)
-
-					if !syncthing.IsInstalled() {
-						return fmt.Errorf("couldn't download syncthing, please try again")
-					}
-
-					oktetoLog.Yellow("couldn't upgrade syncthing, will try again later")
-					oktetoLog.Println()
-				} else {
-					oktetoLog.Success("Dependencies successfully installed")
-				}
-			}
-
-			oktetoLog.ConfigureFileLogger(config.GetAppHome(okteto.GetContext().Namespace, dev.Name), config.VersionString)
-
-			if err := checkStignoreConfiguration(dev); err != nil {
-				oktetoLog.Infof("failed to check '.stignore' configuration: %s", err.Error())
+			up.events.publishPhase(types.UpPhaseBuilding)
+			ub := newUpBuilder(oktetoManifest, argsparserResult.DevName, up.builder, up.Registry, upMeta)
+			if err := ub.build(ctx); err != nil {
+				upMeta.ErrBuild()
+				return up.waitReadyError(err)
 			}
-
-			if err := addStignoreSecrets(dev, okteto.GetContext().Namespace); err != nil {
-				return err
-			}
-
-			if err := addSyncFieldHash(dev); err != nil {
-				return err
+			if upOptions.Deploy || ub.built {
+				warnSyncedBuildContexts(dev, oktetoManifest)
 			}
 
-			if err := setSyncDefaultsByDevMode(dev, up.getSyncTempDir); err != nil {
+			if err := up.startDev(); err != nil {
 				return err
 			}
 
-			if err = up.start(); err != nil {
-				switch err.(type) {
-				default:
-					return fmt.Errorf("%w\n    Find additional logs at: %s/okteto.log", err, config.GetAppHome(okteto.GetContext().Namespace, dev.Name))
-				case oktetoErrors.CommandError:
-					oktetoLog.Infof("CommandError: %v", err)
-					return err
-				case oktetoErrors.UserError:
-					return err
-				}
-			}
-
 			up.analyticsMeta.CommandSuccess()
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVarP(&upOptions.ManifestPath, "file", "f", "", "the path to the Okteto Manifest")
+	cmd.Flags().StringVar(&upOptions.Workdir, "workdir", "", "the directory where builds, deploy commands and sync folders are resolved (defaults to the folder of the Okteto Manifest)")
 	cmd.Flags().StringVarP(&upOptions.Namespace, "namespace", "n", "", "overwrite the current Okteto Namespace")
 	cmd.Flags().StringVarP(&upOptions.K8sContext, "context", "c", "", "overwrite the current Okteto Context")
+	cmd.Flags().BoolVarP(&upOptions.StrictNamespace, "strict-namespace", "", false, "fail if the namespace of the Okteto Manifest doesn't match the namespace of the Okteto Context")
 	cmd.Flags().StringArrayVarP(&upOptions.Envs, "env", "e", []string{}, "set environment variable in the Development Container")
+	cmd.Flags().StringArrayVar(&upOptions.EnvPassthrough, "env-passthrough", nil, "set the local environment variables matching a pattern like 'AWS_*' in the session of the Development Container (can be set more than once)")
+	cmd.Flags().StringArrayVar(&upOptions.Aliases, "alias", nil, "resolve a hostname in the Development Container to an IP, like 'db=192.168.1.10:5432' (can be set more than once)")
+	cmd.Flags().StringArrayVar(&upOptions.BuildArgs, "build-arg", nil, "set a build-time variable for all the images of the build section (can be set more than once)")
 	cmd.Flags().IntVarP(&upOptions.Remote, "remote", "r", 0, "exposes the SSH server in a given port")
 	cmd.Flags().BoolVarP(&upOptions.Deploy, "deploy", "d", false, "force the redeployment of your Development Environment")
+	cmd.Flags().DurationVar(&upOptions.DeployTimeout, "timeout", 0, "the maximum time to deploy your Development Environment and its dependencies, overriding 'deploy.timeout'")
 	cmd.Flags().BoolVarP(&upOptions.ForcePull, "pull", "", false, "force the Development Container image to be pulled")
 	if err := cmd.Flags().MarkHidden("pull"); err != nil {
 		oktetoLog.Infof("failed to mark 'pull' flag as hidden: %s", err)
 	}
 	cmd.Flags().BoolVarP(&upOptions.Reset, "reset", "", false, "resets the file synchronization service. Use it if the file synchronization service stops working")
+	cmd.Flags().BoolVarP(&upOptions.AllowPrivileged, "allow-privileged", "", false, "allow compose services with 'privileged' or 'devices'")
+	cmd.Flags().BoolVarP(&upOptions.AllowHostAccess, "allow-host-access", "", false, "allow compose services with 'pid', 'ipc' or 'network_mode' set to 'host'")
+	cmd.Flags().StringVarP(&upOptions.ForwardInterface, "forward-interface", "", "", "the local interface where the forwards listen, overriding the 'interface' field of the Okteto Manifest (e.g. 0.0.0.0)")
+	cmd.Flags().StringVar(&upOptions.Builder, "builder", "", "overwrite the builder of the current Okteto Context, like 'tcp://localhost:1234' or 'docker://local'")
+	cmd.Flags().BoolVar(&upOptions.WaitReady, "wait-ready", false, "exit once the Development Container is ready and the files are synchronized, leaving it deployed")
+	cmd.Flags().BoolVar(&upOptions.IgnoreCaseCollisions, "ignore-case-collisions", false, "start even if a sync folder has files whose names only differ in case, like 'Foo.go' and 'foo.go'")
 	return cmd
 }
 
+// newUpContext returns the context to activate the development containers of a manifest
+func newUpContext(ns string, manifest *model.Manifest, upOptions *Options, fs afero.Fs, at analyticsTrackerInterface, insights buildDeployTrackerInterface, ioCtrl *io.Controller, k8sLogger *io.K8sLogger, upMeta *analytics.UpMetricsMetadata) (*upContext, error) {
+	onBuildFinish := []buildv2.OnBuildFinish{
+		at.TrackImageBuild,
+		insights.TrackImageBuild,
+	}
+
+	up := &upContext{
+		Namespace:          ns,
+		Manifest:           manifest,
+		Dev:                nil,
+		Exit:               make(chan error, 1),
+		resetSyncthing:     upOptions.Reset,
+		StartTime:          time.Now(),
+		Registry:           registry.NewOktetoRegistry(okteto.Config{}),
+		Options:            upOptions,
+		Fs:                 fs,
+		analyticsTracker:   at,
+		analyticsMeta:      upMeta,
+		K8sClientProvider:  okteto.NewK8sClientProviderWithLogger(k8sLogger),
+		tokenUpdater:       newTokenUpdaterController(),
+		kubeconfigReloader: newKubeconfigReloaderController(fs),
+		builder:            buildv2.NewBuilderFromScratch(ioCtrl, onBuildFinish, buildCmd.GetBuildkitConnector(&okteto.ContextStateless{Store: okteto.GetContextStore()}, ioCtrl, at)),
+		autoDown:           newAutoDown(ioCtrl, k8sLogger, at, upMeta),
+		appRetriever:       k8sAppRetriever{},
+		podWaiter:          k8sPodWaiter{},
+		forwarderFactory:   portForwarderFactory{},
+		syncthingCtrl:      localSyncthingController{},
+		exitGuard:          newExitGuard(),
+		events:             newEventsPublisher(),
+		globalForwards:     newGlobalForwardRegistry(),
+	}
+	up.inFd, up.isTerm = term.GetFdInfo(os.Stdin)
+	if up.isTerm {
+		var err error
+		up.stateTerm, err = term.SaveState(up.inFd)
+		if err != nil {
+			oktetoLog.Infof("failed to save the state of the terminal: %s", err.Error())
+			return nil, fmt.Errorf("failed to save the state of the terminal")
+		}
+		oktetoLog.Infof("Terminal: %v", up.stateTerm)
+	}
+	return up, nil
+}
+
+// startDev applies the command overrides to the development container, checks its sync folders and activates it
+func (up *upContext) startDev() error {
+	dev := up.Dev
+	if err := loadManifestOverrides(dev, up.Options); err != nil {
+		return err
+	}
+
+	if syncthing.ShouldUpgrade() {
+		oktetoLog.Println("Installing dependencies...")
+		if err := downloadSyncthing(); err != nil {
+			oktetoLog.Infof("failed to upgrade syncthing: %s", err)
+
+			if !syncthing.IsInstalled() {
+				return fmt.Errorf("couldn't download syncthing, please try again")
+			}
+
+			oktetoLog.Yellow("couldn't upgrade syncthing, will try again later")
+			oktetoLog.Println()
+		} else {
+			oktetoLog.Success("Dependencies successfully installed")
+		}
+	}
+
+	oktetoLog.ConfigureFileLogger(config.GetAppHome(up.Namespace, dev.Name), config.VersionString)
+
+	if err := checkStignoreConfiguration(dev); err != nil {
+		oktetoLog.Infof("failed to check '.stignore' configuration: %s", err.Error())
+	}
+
+	if err := checkSyncFolders(dev, up.Options.IgnoreCaseCollisions); err != nil {
+		return err
+	}
+
+	if err := addStignoreSecrets(dev, up.Namespace); err != nil {
+		return err
+	}
+
+	if err := addSyncFieldHash(dev); err != nil {
+		return err
+	}
+
+	if err := setSyncDefaultsByDevMode(dev, up.getSyncTempDir); err != nil {
+		return err
+	}
+
+	if err := up.start(); err != nil {
+		switch err.(type) {
+		default:
+			return fmt.Errorf("%w\n    Find additional logs at: %s/okteto.log", err, config.GetAppHome(up.Namespace, dev.Name))
+		case oktetoErrors.CommandError:
+			oktetoLog.Infof("CommandError: %v", err)
+			return err
+		case oktetoErrors.UserError:
+			return err
+		}
+	}
+	return nil
+}
+
 func loadManifestOverrides(dev *model.Dev, upOptions *Options) error {
 	if upOptions.Remote > 0 {
 		dev.RemotePort = upOptions.Remote
 	}
 
+	if upOptions.ForwardInterface != "" {
+		if err := forward.ValidateInterface(upOptions.ForwardInterface); err != nil {
+			return oktetoErrors.UserError{
+				E:    err,
+				Hint: "Use 'localhost', '0.0.0.0' or an IP address of your local machine as '--forward-interface'",
+			}
+		}
+		dev.Interface = upOptions.ForwardInterface
+	}
+
 	if dev.RemoteModeEnabled() {
 		if err := sshKeys(); err != nil {
 			return err
@@ -407,6 +544,21 @@ func loadManifestOverrides(dev *model.Dev, upOptions *Options) error {
 		dev.LoadForcePull()
 	}
 
+	if len(upOptions.Aliases) > 0 {
+		aliases := []model.HostAlias{}
+		for _, value := range upOptions.Aliases {
+			alias, err := model.ParseHostAlias(value)
+			if err != nil {
+				return oktetoErrors.UserError{
+					E:    err,
+					Hint: "Use the format 'hostname=ip[:port]' for the '--alias' flag, like 'db=192.168.1.10:5432'",
+				}
+			}
+			aliases = append(aliases, alias)
+		}
+		dev.HostAliases = model.MergeHostAliases(dev.HostAliases, aliases)
+	}
+
 	if len(upOptions.Envs) > 0 {
 		overridedEnvVars, err := getOverridedEnvVarsFromCmd(dev.Environment, upOptions.Envs)
 		if err != nil {
@@ -474,7 +626,8 @@ func getOverridedEnvVarsFromCmd(manifestEnvVars env.Environment, commandEnvVaria
 	return &overridedEnvVars, nil
 }
 
-func (up *upContext) start() error {
+func (up *upContext) start() (err error) {
+	up.collectStaleSession(context.Background())
 	up.pidController = newPIDController(up.Namespace, up.Dev.Name)
 
 	if err := up.pidController.create(); err != nil {
@@ -489,10 +642,17 @@ func (up *upContext) start() error {
 		}
 	}
 
-	defer up.pidController.delete()
+	// the pid file must be deleted even if the user forces the exit
+	up.exitGuard.register(up.pidController.delete)
+	up.exitGuard.register(up.events.close)
+	defer up.exitGuard.cleanup()
+	defer func() {
+		up.trackSessionEnd(err)
+	}()
 
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	up.interrupt = stop
 
 	pidFileCh := make(chan error, 1)
 
@@ -509,10 +669,13 @@ func (up *upContext) start() error {
 	if err != nil {
 		return err
 	}
+	defer up.endSession(k8sClient)
+
 	select {
 	case <-stop:
 		oktetoLog.Infof("CTRL+C received, starting shutdown sequence")
 		up.interruptReceived = true
+		up.analyticsMeta.UserCancel()
 		up.shutdown()
 
 		if err := up.autoDown.run(context.Background(), up.Dev, up.Namespace, up.Manifest.Name, k8sClient); err != nil {
@@ -524,9 +687,14 @@ func (up *upContext) start() error {
 			up.shutdownHybridMode()
 		}
 		if err != nil {
+			up.events.publishError(err)
 			oktetoLog.Warning("Exited without running okteto down. Your dev environment is still active. Run okteto down to clean it up and free resources.")
 			oktetoLog.Infof("exit signal received due to error: %s", err)
-			return err
+			return up.waitReadyError(err)
+		}
+		if up.isWaitReady() {
+			oktetoLog.Success("Development container '%s' is ready. Run 'okteto up' to start working on it or 'okteto down' to deactivate it", up.Dev.Name)
+			return nil
 		}
 		if err := up.autoDown.run(context.Background(), up.Dev, up.Namespace, up.Manifest.Name, k8sClient); err != nil {
 			return err
@@ -541,11 +709,47 @@ func (up *upContext) start() error {
 	return nil
 }
 
+// collectStaleSession removes the artifacts of a previous okteto up session of the same dev that didn't exit cleanly
+func (up *upContext) collectStaleSession(ctx context.Context) {
+	var app apps.App
+	k8sClient, _, err := up.K8sClientProvider.Provide(okteto.GetContext().Cfg)
+	if err != nil {
+		oktetoLog.Infof("failed to get k8s client for stale artifacts cleanup: %s", err)
+	} else {
+		app, _, err = up.appRetriever.GetApp(ctx, up.Dev, up.Namespace, k8sClient, false)
+		if err != nil {
+			oktetoLog.Infof("failed to get app for stale artifacts cleanup: %s", err)
+			app = nil
+		}
+	}
+	newStaleSessionCollector(up.Namespace, up.Dev.Name).collect(ctx, app, k8sClient)
+}
+
+// endSession releases the dev clones held by the session and records the last activity of the development container volume
+func (up *upContext) endSession(k8sClient kubernetes.Interface) {
+	ctx := context.Background()
+	releaseDevClones(ctx, up.Translations, k8sClient)
+	stampVolumeActivity(ctx, up.Dev, up.Namespace, k8sClient)
+	if up.globalForwards != nil {
+		if err := up.globalForwards.release(); err != nil {
+			oktetoLog.Infof("failed to release the global forwards: %s", err)
+		}
+	}
+}
+
+// trackSessionEnd sends the analytics event of the end of the up session
+func (up *upContext) trackSessionEnd(err error) {
+	up.analyticsMeta.ErrUp(err)
+	up.analyticsMeta.EndSession(time.Now())
+	up.analyticsTracker.TrackUpSessionEnd(up.analyticsMeta)
+}
+
 // activateLoop activates the development container in a retry loop
 func (up *upContext) activateLoop() {
 	isTransientError := false
 	t := time.NewTicker(1 * time.Second)
 	iter := 0
+	certificateReloads := 0
 	defer t.Stop()
 
 	defer func() {
@@ -585,6 +789,21 @@ func (up *upContext) activateLoop() {
 		if err != nil {
 			oktetoLog.Infof("activate failed with: %s", err)
 
+			if oktetoErrors.IsClientCertificateExpired(err) {
+				certificateReloads++
+				retry, reloadErr := up.reloadKubeconfig(err, certificateReloads)
+				if reloadErr != nil {
+					up.Exit <- reloadErr
+					return
+				}
+				if retry {
+					isTransientError = true
+					continue
+				}
+			} else {
+				certificateReloads = 0
+			}
+
 			if okteto.IsOkteto() {
 				oktetoLog.Info("updating kubeconfig token")
 				if err := up.tokenUpdater.UpdateKubeConfigToken(); err != nil {
@@ -637,6 +856,14 @@ func (up *upContext) waitUntilExitOrInterruptOrApply(ctx context.Context) error
 
 		case err := <-up.Disconnect:
 			if err == oktetoErrors.ErrInsufficientSpace {
+				if up.Dev.PersistentVolumeEnabled() && up.Dev.PersistentVolumeAutoExpand() {
+					errExpand := up.autoExpandVolume(ctx)
+					if errExpand == nil {
+						go up.Sy.MonitorStatus(ctx, up.Disconnect)
+						continue
+					}
+					oktetoLog.Warning("Could not expand the persistent volume: %s", errExpand)
+				}
 				return up.getInsufficientSpaceError(err)
 			}
 			return err
@@ -692,7 +919,8 @@ func (up *upContext) getInsufficientSpaceError(err error) error {
 		return oktetoErrors.UserError{
 			E: err,
 			Hint: fmt.Sprintf(`Okteto volume is full.
-    Increase your persistent volume size, run '%s' and try 'okteto up' again.
+    Run 'okteto volume resize <size>' to expand it without restarting your development container,
+    or increase your persistent volume size, run '%s' and try 'okteto up' again.
     More information about configuring your persistent volume at https://okteto.com/docs/reference/okteto-manifest/#persistentvolume-object-optional`, utils.GetDownCommand(up.Options.ManifestPathFlag)),
 		}
 	}
@@ -725,21 +953,56 @@ func (up *upContext) shutdown() {
 		oktetoLog.Info("sent cancellation signal")
 	}
 
-	if up.Sy != nil {
-		oktetoLog.Infof("stopping syncthing")
-		if err := up.Sy.SoftTerminate(); err != nil {
-			oktetoLog.Infof("failed to stop syncthing during shutdown: %s", err.Error())
-		}
+	steps := []shutdownStep{}
+	if sy := up.Sy; sy != nil {
+		steps = append(steps, shutdownStep{
+			name: "stop syncthing",
+			run: func() {
+				oktetoLog.Infof("stopping syncthing")
+				if err := sy.SoftTerminate(); err != nil {
+					oktetoLog.Infof("failed to stop syncthing during shutdown: %s", err.Error())
+				}
+			},
+		})
 	}
 
-	oktetoLog.Infof("stopping forwarders")
-	if up.Forwarder != nil {
-		up.Forwarder.Stop()
+	if forwarder := up.Forwarder; forwarder != nil {
+		steps = append(steps, shutdownStep{
+			name: "stop forwarders",
+			run: func() {
+				oktetoLog.Infof("stopping forwarders")
+				forwarder.Stop()
+				up.events.publishForwards(up.Dev.Forward, types.UpForwardStopped)
+			},
+		})
 	}
 
 	if up.Dev.IsHybridModeEnabled() {
-		oktetoLog.Infof("stopping local process...")
-		up.shutdownHybridMode()
+		steps = append(steps, shutdownStep{
+			name: "stop local process",
+			run: func() {
+				oktetoLog.Infof("stopping local process...")
+				up.shutdownHybridMode()
+			},
+		})
+	}
+
+	// a second CTRL+C only forces the exit once the user has asked to stop
+	var force <-chan os.Signal
+	if up.interruptReceived {
+		force = up.interrupt
+	}
+	skipped, forced := runShutdownSteps(steps, env.LoadTimeOrDefault(shutdownTimeoutEnvVar, defaultShutdownTimeout), force)
+	if forced {
+		if len(skipped) > 0 {
+			oktetoLog.Warning("Forcing exit, skipped: %s", strings.Join(skipped, ", "))
+		}
+		if up.exitGuard != nil {
+			up.exitGuard.forceExit(forceExitCode)
+		}
+	}
+	if len(skipped) > 0 {
+		oktetoLog.Infof("shutdown sequence didn't complete: %s", strings.Join(skipped, ", "))
 	}
 
 	oktetoLog.Info("completed shutdown sequence")
@@ -811,10 +1074,10 @@ func printDisplayContext(up *upContext) {
 	if len(up.Manifest.GlobalForward) > 0 {
 		anyGlobalForward = true
 
-		oktetoLog.Println(fmt.Sprintf("    %s   %d -> %s:%d", oktetoLog.BlueString("Forward:"), up.Manifest.GlobalForward[0].Local, up.Manifest.GlobalForward[0].ServiceName, up.Manifest.GlobalForward[0].Remote))
+		oktetoLog.Println(fmt.Sprintf("    %s   %s -> %s:%d", oktetoLog.BlueString("Forward:"), getForwardLocalAddress(up.Dev.Interface, up.Manifest.GlobalForward[0].Local), up.Manifest.GlobalForward[0].ServiceName, up.Manifest.GlobalForward[0].Remote))
 
 		for i := 1; i < len(up.Manifest.GlobalForward); i++ {
-			oktetoLog.Println(fmt.Sprintf("               %d -> %s:%d", up.Manifest.GlobalForward[i].Local, up.Manifest.GlobalForward[i].ServiceName, up.Manifest.GlobalForward[i].Remote))
+			oktetoLog.Println(fmt.Sprintf("               %s -> %s:%d", getForwardLocalAddress(up.Dev.Interface, up.Manifest.GlobalForward[i].Local), up.Manifest.GlobalForward[i].ServiceName, up.Manifest.GlobalForward[i].Remote))
 		}
 	}
 
@@ -823,19 +1086,21 @@ func printDisplayContext(up *upContext) {
 		fromIdxToShowWithoutForwardLabel := 0
 		if !anyGlobalForward {
 			fromIdxToShowWithoutForwardLabel = 1
+			local := getForwardLocalAddress(up.Dev.Forward[0].GetInterface(up.Dev.Interface), up.Dev.Forward[0].Local)
 			if up.Dev.Forward[0].Service {
-				oktetoLog.Println(fmt.Sprintf("    %s   %d -> %s:%d", oktetoLog.BlueString("Forward:"), up.Dev.Forward[0].Local, up.Dev.Forward[0].ServiceName, up.Dev.Forward[0].Remote))
+				oktetoLog.Println(fmt.Sprintf("    %s   %s -> %s:%d", oktetoLog.BlueString("Forward:"), local, up.Dev.Forward[0].ServiceName, up.Dev.Forward[0].Remote))
 			} else {
-				oktetoLog.Println(fmt.Sprintf("    %s   %d -> %d", oktetoLog.BlueString("Forward:"), up.Dev.Forward[0].Local, up.Dev.Forward[0].Remote))
+				oktetoLog.Println(fmt.Sprintf("    %s   %s -> %d", oktetoLog.BlueString("Forward:"), local, up.Dev.Forward[0].Remote))
 			}
 		}
 
 		for i := fromIdxToShowWithoutForwardLabel; i < len(up.Dev.Forward); i++ {
+			local := getForwardLocalAddress(up.Dev.Forward[i].GetInterface(up.Dev.Interface), up.Dev.Forward[i].Local)
 			if up.Dev.Forward[i].Service {
-				oktetoLog.Println(fmt.Sprintf("               %d -> %s:%d", up.Dev.Forward[i].Local, up.Dev.Forward[i].ServiceName, up.Dev.Forward[i].Remote))
+				oktetoLog.Println(fmt.Sprintf("               %s -> %s:%d", local, up.Dev.Forward[i].ServiceName, up.Dev.Forward[i].Remote))
 				continue
 			}
-			oktetoLog.Println(fmt.Sprintf("               %d -> %d", up.Dev.Forward[i].Local, up.Dev.Forward[i].Remote))
+			oktetoLog.Println(fmt.Sprintf("               %s -> %d", local, up.Dev.Forward[i].Remote))
 		}
 	}
 
@@ -847,6 +1112,31 @@ func printDisplayContext(up *upContext) {
 	}
 
 	oktetoLog.Println()
+
+	if iface := getExposedForwardInterface(up.Dev, len(up.Manifest.GlobalForward) > 0); iface != "" {
+		oktetoLog.Warning("Forwards listening on '%s' are reachable from other machines in your network: anyone with access to them can connect to your development environment", iface)
+	}
+}
+
+// getForwardLocalAddress returns the local side of a forward, including the interface when it's not localhost
+func getForwardLocalAddress(iface string, port int) string {
+	if iface == "" || iface == model.Localhost {
+		return strconv.Itoa(port)
+	}
+	return net.JoinHostPort(iface, strconv.Itoa(port))
+}
+
+// getExposedForwardInterface returns the first interface of the forwards that accepts connections from other machines, if any
+func getExposedForwardInterface(dev *model.Dev, anyGlobalForward bool) string {
+	if anyGlobalForward && forward.IsExposedInterface(dev.Interface) {
+		return dev.Interface
+	}
+	for _, f := range dev.Forward {
+		if iface := f.GetInterface(dev.Interface); forward.IsExposedInterface(iface) {
+			return iface
+		}
+	}
+	return ""
 }
 
 // wakeAnalyticsTracker tracks the wake_triggered event.
@@ -17,20 +17,27 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"strconv"
 	"testing"
 
 	"github.com/okteto/okteto/internal/test"
 	"github.com/okteto/okteto/internal/test/client"
+	fakeUp "github.com/okteto/okteto/internal/test/up"
+	"github.com/okteto/okteto/pkg/analytics"
 	buildCmd "github.com/okteto/okteto/pkg/cmd/build"
 	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/env"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/apps"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/model/forward"
 	"github.com/okteto/okteto/pkg/okteto"
 	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	authenticationv1 "k8s.io/api/authentication/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -651,3 +658,347 @@ func (fb *fakeBuilder) GetSvcToBuildFromRegex(_ *model.Manifest, _ model.ImageFr
 func (*fakeBuilder) GetConnector() buildCmd.BuildkitConnector {
 	return nil
 }
+
+func TestTrackSessionEnd(t *testing.T) {
+	at := &mockAnalyticsTracker{}
+	meta := analytics.NewUpMetricsMetadata()
+	at.On("TrackUpSessionEnd", meta).Return()
+	up := &upContext{
+		analyticsTracker: at,
+		analyticsMeta:    meta,
+	}
+
+	up.trackSessionEnd(oktetoErrors.ErrLostSyncthing)
+
+	at.AssertExpectations(t)
+}
+
+type fakeKubeconfigReloader struct {
+	err   error
+	calls int
+}
+
+func (f *fakeKubeconfigReloader) Reload() error {
+	f.calls++
+	return f.err
+}
+
+func newActivateLoopTestContext(t *testing.T, retriever *fakeUp.FakeAppRetriever, reloader kubeconfigReloader, pid string) *upContext {
+	t.Helper()
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
+	okteto.CurrentStore = &okteto.ContextStore{
+		Contexts: map[string]*okteto.Context{
+			"test": {
+				Name:      "test",
+				Namespace: "test",
+			},
+		},
+		CurrentContext: "test",
+	}
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "/okteto.pid", []byte(pid), 0600))
+	return &upContext{
+		Namespace:          "test",
+		Dev:                &model.Dev{Name: "api"},
+		Exit:               make(chan error, 1),
+		K8sClientProvider:  test.NewFakeK8sProvider(),
+		analyticsMeta:      analytics.NewUpMetricsMetadata(),
+		kubeconfigReloader: reloader,
+		pidController: pidController{
+			filesystem:  fs,
+			pidFilePath: "/okteto.pid",
+		},
+		appRetriever: retriever,
+	}
+}
+
+func Test_activateLoop(t *testing.T) {
+	ownPID := strconv.Itoa(os.Getpid())
+	transientErr := errors.New("unexpected EOF")
+	certificateErr := errors.New("remote error: tls: expired certificate")
+	tests := []struct {
+		expectedErr     error
+		reloader        *fakeKubeconfigReloader
+		name            string
+		pid             string
+		responses       []fakeUp.FakeAppResponse
+		expectedCalls   int
+		expectedReloads int
+	}{
+		{
+			name:          "non transient error exits",
+			pid:           ownPID,
+			responses:     []fakeUp.FakeAppResponse{{Err: assert.AnError}},
+			expectedErr:   assert.AnError,
+			expectedCalls: 1,
+		},
+		{
+			name:          "transient error is retried",
+			pid:           ownPID,
+			responses:     []fakeUp.FakeAppResponse{{Err: transientErr}, {Err: assert.AnError}},
+			expectedErr:   assert.AnError,
+			expectedCalls: 2,
+		},
+		{
+			name:          "lost syncthing is retried",
+			pid:           ownPID,
+			responses:     []fakeUp.FakeAppResponse{{Err: oktetoErrors.ErrLostSyncthing}, {Err: assert.AnError}},
+			expectedErr:   assert.AnError,
+			expectedCalls: 2,
+		},
+		{
+			name:            "expired certificate reloads the kubeconfig and retries",
+			pid:             ownPID,
+			responses:       []fakeUp.FakeAppResponse{{Err: certificateErr}, {Err: assert.AnError}},
+			expectedErr:     assert.AnError,
+			expectedCalls:   2,
+			expectedReloads: 1,
+		},
+		{
+			name:            "failed kubeconfig reload exits",
+			pid:             ownPID,
+			reloader:        &fakeKubeconfigReloader{err: errConfigNotConfigured},
+			responses:       []fakeUp.FakeAppResponse{{Err: certificateErr}},
+			expectedErr:     errConfigNotConfigured,
+			expectedCalls:   1,
+			expectedReloads: 1,
+		},
+		{
+			name:            "expired certificate without client certificate is not reloaded",
+			pid:             ownPID,
+			reloader:        &fakeKubeconfigReloader{err: errNoClientCertificate},
+			responses:       []fakeUp.FakeAppResponse{{Err: certificateErr}},
+			expectedErr:     certificateErr,
+			expectedCalls:   1,
+			expectedReloads: 1,
+		},
+		{
+			name:            "kubeconfig reloads are capped",
+			pid:             ownPID,
+			responses:       []fakeUp.FakeAppResponse{{Err: certificateErr}, {Err: certificateErr}, {Err: certificateErr}, {Err: certificateErr}},
+			expectedErr:     certificateErr,
+			expectedCalls:   maxKubeconfigReloads + 1,
+			expectedReloads: maxKubeconfigReloads,
+		},
+		{
+			name:          "another up session took over the development container",
+			pid:           "1",
+			responses:     []fakeUp.FakeAppResponse{{Err: transientErr}},
+			expectedErr:   errAnotherUpCommandStarted,
+			expectedCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			retriever := &fakeUp.FakeAppRetriever{Responses: tt.responses}
+			reloader := tt.reloader
+			if reloader == nil {
+				reloader = &fakeKubeconfigReloader{}
+			}
+			up := newActivateLoopTestContext(t, retriever, reloader, tt.pid)
+
+			up.activateLoop()
+
+			err := <-up.Exit
+			require.ErrorIs(t, err, tt.expectedErr)
+			require.Equal(t, tt.expectedCalls, retriever.Calls)
+			require.Equal(t, tt.expectedReloads, reloader.calls)
+		})
+	}
+}
+
+func Test_activateLoopDevContainerDeactivated(t *testing.T) {
+	app := apps.NewDeploymentApp(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "api",
+			Namespace: "test",
+		},
+	})
+	retriever := &fakeUp.FakeAppRetriever{
+		Responses: []fakeUp.FakeAppResponse{{App: app}},
+	}
+	up := newActivateLoopTestContext(t, retriever, &fakeKubeconfigReloader{}, strconv.Itoa(os.Getpid()))
+	// simulates a reconnection after a previous session
+	up.isRetry = true
+	up.ShutdownCompleted = make(chan bool, 1)
+	up.ShutdownCompleted <- true
+
+	up.activateLoop()
+
+	require.NoError(t, <-up.Exit)
+	require.Equal(t, 1, retriever.Calls)
+}
+
+func Test_waitUntilExitOrInterruptOrApplyChannels(t *testing.T) {
+	tests := []struct {
+		commandResult   error
+		disconnect      error
+		globalForwarder error
+		check           func(t *testing.T, err error)
+		name            string
+		sendCommand     bool
+	}{
+		{
+			name:        "command succeeded",
+			sendCommand: true,
+			check: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:          "command failed",
+			sendCommand:   true,
+			commandResult: assert.AnError,
+			check: func(t *testing.T, err error) {
+				cmdErr := oktetoErrors.CommandError{}
+				require.ErrorAs(t, err, &cmdErr)
+				require.ErrorIs(t, cmdErr.E, oktetoErrors.ErrCommandFailed)
+				require.ErrorIs(t, cmdErr.Reason, assert.AnError)
+			},
+		},
+		{
+			name:          "command failed with transient error",
+			sendCommand:   true,
+			commandResult: errors.New("connection reset by peer"),
+			check: func(t *testing.T, err error) {
+				require.EqualError(t, err, "connection reset by peer")
+			},
+		},
+		{
+			name:       "syncthing disconnected",
+			disconnect: oktetoErrors.ErrLostSyncthing,
+			check: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, oktetoErrors.ErrLostSyncthing)
+			},
+		},
+		{
+			name:       "out of space",
+			disconnect: oktetoErrors.ErrInsufficientSpace,
+			check: func(t *testing.T, err error) {
+				require.ErrorAs(t, err, &oktetoErrors.UserError{})
+				require.ErrorIs(t, err, oktetoErrors.ErrInsufficientSpace)
+			},
+		},
+		{
+			name:            "global forwarder failed",
+			globalForwarder: assert.AnError,
+			check: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			up := &upContext{
+				Dev:                   &model.Dev{},
+				Options:               &Options{},
+				K8sClientProvider:     test.NewFakeK8sProvider(),
+				CommandResult:         make(chan error, 1),
+				Disconnect:            make(chan error, 1),
+				GlobalForwarderStatus: make(chan error, 1),
+			}
+			if tt.sendCommand {
+				up.CommandResult <- tt.commandResult
+			}
+			if tt.disconnect != nil {
+				up.Disconnect <- tt.disconnect
+			}
+			if tt.globalForwarder != nil {
+				up.GlobalForwarderStatus <- tt.globalForwarder
+			}
+
+			tt.check(t, up.waitUntilExitOrInterruptOrApply(context.Background()))
+		})
+	}
+}
+
+func TestGetForwardLocalAddress(t *testing.T) {
+	assert.Equal(t, "8080", getForwardLocalAddress("", 8080))
+	assert.Equal(t, "8080", getForwardLocalAddress(model.Localhost, 8080))
+	assert.Equal(t, "0.0.0.0:8080", getForwardLocalAddress(model.PrivilegedLocalhost, 8080))
+	assert.Equal(t, "[::1]:8080", getForwardLocalAddress("::1", 8080))
+}
+
+func TestGetExposedForwardInterface(t *testing.T) {
+	tests := []struct {
+		dev              *model.Dev
+		name             string
+		expected         string
+		anyGlobalForward bool
+	}{
+		{
+			name: "localhost",
+			dev: &model.Dev{
+				Interface: model.Localhost,
+				Forward:   []forward.Forward{{Local: 8080, Remote: 8080}},
+			},
+		},
+		{
+			name: "dev interface",
+			dev: &model.Dev{
+				Interface: model.PrivilegedLocalhost,
+				Forward:   []forward.Forward{{Local: 8080, Remote: 8080}},
+			},
+			expected: model.PrivilegedLocalhost,
+		},
+		{
+			name: "dev interface without forwards",
+			dev: &model.Dev{
+				Interface: model.PrivilegedLocalhost,
+			},
+		},
+		{
+			name: "dev interface with global forwards",
+			dev: &model.Dev{
+				Interface: model.PrivilegedLocalhost,
+			},
+			anyGlobalForward: true,
+			expected:         model.PrivilegedLocalhost,
+		},
+		{
+			name: "forward interface",
+			dev: &model.Dev{
+				Interface: model.Localhost,
+				Forward:   []forward.Forward{{Local: 8080, Remote: 8080}, {Local: 3000, Remote: 3000, Interface: "192.168.1.20"}},
+			},
+			expected: "192.168.1.20",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getExposedForwardInterface(tt.dev, tt.anyGlobalForward))
+		})
+	}
+}
+
+func TestLoadManifestOverridesForwardInterface(t *testing.T) {
+	t.Setenv(model.OktetoExecuteSSHEnvVar, "false")
+
+	dev := &model.Dev{Interface: model.Localhost}
+	require.NoError(t, loadManifestOverrides(dev, &Options{ForwardInterface: model.PrivilegedLocalhost}))
+	assert.Equal(t, model.PrivilegedLocalhost, dev.Interface)
+
+	dev = &model.Dev{Interface: model.Localhost}
+	err := loadManifestOverrides(dev, &Options{ForwardInterface: "203.0.113.10"})
+	var uErr oktetoErrors.UserError
+	require.ErrorAs(t, err, &uErr)
+	assert.ErrorContains(t, err, "interface '203.0.113.10' is not an address of your local machine")
+	assert.Equal(t, model.Localhost, dev.Interface)
+}
+
+func TestLoadManifestOverridesAliases(t *testing.T) {
+	t.Setenv(model.OktetoExecuteSSHEnvVar, "false")
+
+	dev := &model.Dev{
+		HostAliases: []model.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"db", "cache"}}},
+	}
+	require.NoError(t, loadManifestOverrides(dev, &Options{Aliases: []string{"db=127.0.0.1:5432"}}))
+	assert.Equal(t, []model.HostAlias{
+		{IP: "127.0.0.1", Hostnames: []string{"db"}, Port: 5432},
+		{IP: "10.0.0.1", Hostnames: []string{"cache"}},
+	}, dev.HostAliases)
+
+	err := loadManifestOverrides(dev, &Options{Aliases: []string{"db=localhost:5432"}})
+	var uErr oktetoErrors.UserError
+	require.ErrorAs(t, err, &uErr)
+}
@@ -0,0 +1,58 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/okteto/okteto/pkg/k8s/volumes"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/okteto"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// autoExpandVolume doubles the size of the persistent volume of the development container
+// and resumes the synchronization once the new capacity is available
+func (up *upContext) autoExpandVolume(ctx context.Context) error {
+	k8sClient, _, err := up.K8sClientProvider.Provide(okteto.GetContext().Cfg)
+	if err != nil {
+		return err
+	}
+
+	name := up.Dev.GetVolumeName()
+	pvc, err := k8sClient.CoreV1().PersistentVolumeClaims(up.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting kubernetes volume claim: %w", err)
+	}
+	current := pvc.Spec.Resources.Requests[apiv1.ResourceStorage]
+	size := *resource.NewQuantity(2*current.Value(), current.Format)
+
+	oktetoLog.Spinner(fmt.Sprintf("Expanding persistent volume to %s...", size.String()))
+	oktetoLog.StartSpinner()
+	defer oktetoLog.StopSpinner()
+	if err := volumes.Resize(ctx, name, up.Namespace, size, k8sClient, up.Dev.Timeout.Resources); err != nil {
+		return err
+	}
+	if up.Sy != nil {
+		if err := up.Sy.Resume(ctx); err != nil {
+			return err
+		}
+	}
+	oktetoLog.StopSpinner()
+	oktetoLog.Success("Persistent volume expanded to %s", size.String())
+	return nil
+}
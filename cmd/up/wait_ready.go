@@ -0,0 +1,71 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/syncthing"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/tonistiigi/units"
+)
+
+// isWaitReady returns true when okteto up exits as soon as the development container is ready
+func (up *upContext) isWaitReady() bool {
+	return up.Options != nil && up.Options.WaitReady
+}
+
+// exitWhenReady prints the endpoints and the file synchronization stats of the development container
+// and publishes the ready phase. The local side of okteto up is shut down when activate returns
+func (up *upContext) exitWhenReady(ctx context.Context) {
+	printDisplayContext(up)
+	up.printSyncStats(ctx)
+	up.events.publishPhase(types.UpPhaseReady)
+}
+
+// printSyncStats prints the files and bytes synchronized with the development container
+func (up *upContext) printSyncStats(ctx context.Context) {
+	if up.Sy == nil {
+		return
+	}
+	completion, err := up.Sy.GetCompletion(ctx, true, syncthing.DefaultRemoteDeviceID)
+	if err != nil {
+		oktetoLog.Infof("failed to get the file synchronization stats: %s", err)
+		return
+	}
+	oktetoLog.Information("Files synchronized: %d (%.2f)", completion.GlobalItems, units.Bytes(completion.GlobalBytes))
+}
+
+// waitReadyError adds the phase of okteto up that failed to the error when okteto up is waiting for the development container to be ready
+func (up *upContext) waitReadyError(err error) error {
+	if err == nil || !up.isWaitReady() {
+		return err
+	}
+	phase := up.events.currentPhase()
+	if phase == "" {
+		return err
+	}
+	var uErr oktetoErrors.UserError
+	if errors.As(err, &uErr) {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("development container not ready: failed while %s: %w", phase, uErr.E),
+			Hint: uErr.Hint,
+		}
+	}
+	return fmt.Errorf("development container not ready: failed while %s: %w", phase, err)
+}
@@ -0,0 +1,105 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitReadyError(t *testing.T) {
+	userErr := oktetoErrors.UserError{E: assert.AnError, Hint: "check your build"}
+
+	tests := []struct {
+		err          error
+		name         string
+		phase        types.UpPhase
+		expectedMsg  string
+		expectedHint string
+		waitReady    bool
+	}{
+		{
+			name:        "without wait-ready",
+			err:         assert.AnError,
+			phase:       types.UpPhaseBuilding,
+			expectedMsg: assert.AnError.Error(),
+		},
+		{
+			name:        "before any phase",
+			err:         assert.AnError,
+			waitReady:   true,
+			expectedMsg: assert.AnError.Error(),
+		},
+		{
+			name:        "error",
+			err:         assert.AnError,
+			phase:       types.UpPhaseSyncing,
+			waitReady:   true,
+			expectedMsg: "development container not ready: failed while syncing: " + assert.AnError.Error(),
+		},
+		{
+			name:         "user error keeps the hint",
+			err:          userErr,
+			phase:        types.UpPhaseBuilding,
+			waitReady:    true,
+			expectedMsg:  "development container not ready: failed while building: " + assert.AnError.Error(),
+			expectedHint: "check your build",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			up := &upContext{
+				Options: &Options{WaitReady: tt.waitReady},
+				events:  newEventsPublisher(),
+			}
+			if tt.phase != "" {
+				up.events.publishPhase(tt.phase)
+			}
+
+			err := up.waitReadyError(tt.err)
+			require.ErrorIs(t, err, assert.AnError)
+			assert.Equal(t, tt.expectedMsg, err.Error())
+			if tt.expectedHint != "" {
+				var uErr oktetoErrors.UserError
+				require.ErrorAs(t, err, &uErr)
+				assert.Equal(t, tt.expectedHint, uErr.Hint)
+			}
+		})
+	}
+
+	up := &upContext{Options: &Options{WaitReady: true}, events: newEventsPublisher()}
+	require.NoError(t, up.waitReadyError(nil))
+}
+
+func TestExitWhenReady(t *testing.T) {
+	up := &upContext{
+		Options:  &Options{WaitReady: true},
+		Dev:      &model.Dev{Name: "dev"},
+		Manifest: &model.Manifest{},
+		events:   newEventsPublisher(),
+	}
+	require.True(t, up.isWaitReady())
+
+	up.exitWhenReady(context.Background())
+	assert.Equal(t, types.UpPhaseReady, up.events.currentPhase())
+
+	assert.False(t, (&upContext{Options: &Options{}}).isWaitReady())
+	assert.False(t, (&upContext{}).isWaitReady())
+}
@@ -118,8 +118,16 @@ const (
 	YesNoDefault_No          = "[y/N]"
 )
 
-// AskYesNo prompts for yes/no confirmation
+// AskYesNo prompts for yes/no confirmation. In non-interactive mode it returns the default answer
 func AskYesNo(q string, d YesNoDefault) (bool, error) {
+	if IsNonInteractive() {
+		if d == YesNoDefault_Unspecified {
+			return false, newNonInteractiveError(q)
+		}
+		oktetoLog.Infof("non-interactive mode: answering '%s' to '%s'", d, q)
+		return d == YesNoDefault_Yes, nil
+	}
+
 	var answer string
 	for {
 		if err := oktetoLog.Question("%s %s: ", q, d); err != nil {
@@ -150,7 +158,12 @@ func AskYesNo(q string, d YesNoDefault) (bool, error) {
 	return false, nil
 }
 
+// AskForOptions prompts the user to select one of the options. It fails in non-interactive mode
 func AskForOptions(options []string, label string) (string, error) {
+	if IsNonInteractive() {
+		return "", newNonInteractiveError(label)
+	}
+
 	selectedTemplate := `{{ " ✓ " | bgGreen | black }} {{ .Label | green }}`
 	activeTemplate := fmt.Sprintf("%s {{ . | oktetoblue }}", promptui.IconSelect)
 	inactiveTemplate := "  {{ . | oktetoblue }}"
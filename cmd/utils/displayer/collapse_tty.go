@@ -129,7 +129,7 @@ func (d *TTYCollapseDisplayer) displayStdout(wg *sync.WaitGroup) {
 		select {
 		case <-d.commandContext.Done():
 		default:
-			line := strings.TrimSpace(d.stdoutScanner.Text())
+			line := oktetoLog.MaskSecrets(strings.TrimSpace(d.stdoutScanner.Text()))
 			if isTopDisplay(line) {
 				prevState := d.isBuilding
 				d.isBuilding = checkIfIsBuildingLine(line)
@@ -170,7 +170,7 @@ func (d *TTYCollapseDisplayer) displayStderr(wg *sync.WaitGroup) {
 		select {
 		case <-d.commandContext.Done():
 		default:
-			line := strings.TrimSpace(d.stderrScanner.Text())
+			line := oktetoLog.MaskSecrets(strings.TrimSpace(d.stderrScanner.Text()))
 			d.err = errors.New(line)
 			if len(d.linesToDisplay) >= d.numberOfLines {
 				d.linesToDisplay = d.linesToDisplay[1:]
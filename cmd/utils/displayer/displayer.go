@@ -15,7 +15,9 @@ package displayer
 
 import (
 	"io"
+	"strings"
 
+	"github.com/acarl005/stripansi"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 )
 
@@ -25,18 +27,32 @@ type Displayer interface {
 	CleanUp(err error)
 }
 
-// NewDisplayer returns a new displayer
-func NewDisplayer(output string, stdout, stderr io.Reader) Displayer {
+// NewDisplayer returns a new displayer. The lines of the command are displayed as soon as they are read, starting with prefix
+func NewDisplayer(output, prefix string, stdout, stderr io.Reader) Displayer {
 	var displayer Displayer
 	switch output {
 	case oktetoLog.TTYFormat:
-		displayer = newTTYDisplayer(stdout, stderr)
+		displayer = newTTYDisplayer(prefix, stdout, stderr)
 	case oktetoLog.PlainFormat:
-		displayer = newPlainDisplayer(stdout, stderr)
+		displayer = newPlainDisplayer(prefix, stdout, stderr)
 	case oktetoLog.JSONFormat:
 		displayer = newJSONDisplayer(stdout, stderr)
 	default:
-		displayer = newTTYDisplayer(stdout, stderr)
+		displayer = newTTYDisplayer(prefix, stdout, stderr)
 	}
 	return displayer
 }
+
+// formatLine returns the text a terminal would show for a line of the command, starting with prefix.
+// Progress bars redraw a line with carriage returns, so only the text after the last one is kept.
+// ANSI escape sequences are removed if stripColors is true
+func formatLine(prefix, line string, stripColors bool) string {
+	line = strings.TrimRight(line, "\r")
+	if i := strings.LastIndex(line, "\r"); i >= 0 {
+		line = line[i+1:]
+	}
+	if stripColors {
+		line = stripansi.Strip(line)
+	}
+	return prefix + line
+}
@@ -0,0 +1,110 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package displayer
+
+import (
+	"bytes"
+	"os/exec"
+	"runtime"
+	"strings"
+	"testing"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatLine(t *testing.T) {
+	tests := []struct {
+		name        string
+		prefix      string
+		line        string
+		expected    string
+		stripColors bool
+	}{
+		{
+			name:     "plain line",
+			prefix:   "[1 helm] ",
+			line:     "Release \"api\" has been upgraded",
+			expected: "[1 helm] Release \"api\" has been upgraded",
+		},
+		{
+			name:     "keep colors",
+			prefix:   "[1 helm] ",
+			line:     "\x1b[32mdeployed\x1b[0m",
+			expected: "[1 helm] \x1b[32mdeployed\x1b[0m",
+		},
+		{
+			name:        "strip colors",
+			prefix:      "[1 helm] ",
+			line:        "\x1b[32mdeployed\x1b[0m",
+			expected:    "[1 helm] deployed",
+			stripColors: true,
+		},
+		{
+			name:     "crlf line ending",
+			line:     "waiting\r",
+			expected: "waiting",
+		},
+		{
+			name:     "progress bar",
+			line:     "progress 10%\rprogress 50%\rprogress 100%",
+			expected: "progress 100%",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, formatLine(tt.prefix, tt.line, tt.stripColors))
+		})
+	}
+}
+
+func TestPlainDisplayerInterleavedStreams(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test script requires sh")
+	}
+	oktetoLog.SetOutputFormat(oktetoLog.PlainFormat)
+	defer oktetoLog.SetOutputFormat(oktetoLog.TTYFormat)
+
+	script := `
+printf '\033[32mout 1\033[0m\n'
+sleep 0.2
+echo 'err 1' >&2
+sleep 0.2
+echo 'out 2'
+sleep 0.2
+echo 'err 2' >&2
+`
+	cmd := exec.Command("sh", "-c", script)
+	stdout, err := cmd.StdoutPipe()
+	require.NoError(t, err)
+	stderr, err := cmd.StderrPipe()
+	require.NoError(t, err)
+
+	d := newPlainDisplayer("[1 script] ", stdout, stderr)
+	out := &bytes.Buffer{}
+	d.out = out
+
+	require.NoError(t, cmd.Start())
+	d.Display("script")
+	require.NoError(t, cmd.Wait())
+	d.CleanUp(nil)
+
+	assert.Equal(t, []string{
+		"[1 script] out 1",
+		"WARNING: [1 script] err 1",
+		"[1 script] out 2",
+		"WARNING: [1 script] err 2",
+	}, strings.Split(strings.TrimSpace(out.String()), "\n"))
+}
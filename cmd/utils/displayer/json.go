@@ -23,9 +23,11 @@ import (
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 )
 
+// jsonDisplayer doesn't prefix the lines of the command, they are already identified by the stage of the json log
 type jsonDisplayer struct {
 	stdoutScanner *bufio.Scanner
 	stderrScanner *bufio.Scanner
+	out           io.Writer
 
 	commandContext context.Context
 	cancel         context.CancelFunc
@@ -48,6 +50,7 @@ func newJSONDisplayer(stdout, stderr io.Reader) *jsonDisplayer {
 	return &jsonDisplayer{
 		stdoutScanner:  stdoutScanner,
 		stderrScanner:  stderrScanner,
+		out:            os.Stdout,
 		commandContext: commandContext,
 		cancel:         cancel,
 	}
@@ -70,7 +73,7 @@ func (d *jsonDisplayer) Display(_ string) {
 				case <-d.commandContext.Done():
 				default:
 					line := d.stdoutScanner.Text()
-					oktetoLog.FPrintln(os.Stdout, line)
+					oktetoLog.FPrintln(d.out, formatLine("", line, true))
 					continue
 				}
 				break
@@ -86,7 +89,7 @@ func (d *jsonDisplayer) Display(_ string) {
 				case <-d.commandContext.Done():
 				default:
 					line := d.stderrScanner.Text()
-					oktetoLog.FPrintln(os.Stdout, line)
+					oktetoLog.FPrintln(d.out, formatLine("", line, true))
 					continue
 				}
 				break
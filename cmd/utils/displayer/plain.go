@@ -26,12 +26,15 @@ import (
 type plainDisplayer struct {
 	stdoutScanner *bufio.Scanner
 	stderrScanner *bufio.Scanner
+	out           io.Writer
 
 	commandContext context.Context
 	cancel         context.CancelFunc
+
+	prefix string
 }
 
-func newPlainDisplayer(stdout, stderr io.Reader) *plainDisplayer {
+func newPlainDisplayer(prefix string, stdout, stderr io.Reader) *plainDisplayer {
 	var (
 		stdoutScanner *bufio.Scanner
 		stderrScanner *bufio.Scanner
@@ -48,8 +51,10 @@ func newPlainDisplayer(stdout, stderr io.Reader) *plainDisplayer {
 	return &plainDisplayer{
 		stdoutScanner:  stdoutScanner,
 		stderrScanner:  stderrScanner,
+		out:            os.Stdout,
 		commandContext: commandContext,
 		cancel:         cancel,
+		prefix:         prefix,
 	}
 }
 
@@ -70,7 +75,7 @@ func (d *plainDisplayer) Display(_ string) {
 				case <-d.commandContext.Done():
 				default:
 					line := d.stdoutScanner.Text()
-					oktetoLog.FPrintln(os.Stdout, line)
+					oktetoLog.FPrintln(d.out, formatLine(d.prefix, line, true))
 					continue
 				}
 				break
@@ -86,7 +91,7 @@ func (d *plainDisplayer) Display(_ string) {
 				case <-d.commandContext.Done():
 				default:
 					line := d.stderrScanner.Text()
-					oktetoLog.FWarning(os.Stdout, "%s", line)
+					oktetoLog.FWarning(d.out, "%s", formatLine(d.prefix, line, true))
 					continue
 				}
 				break
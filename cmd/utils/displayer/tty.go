@@ -32,10 +32,11 @@ type ttyDisplayer struct {
 	commandContext context.Context
 	cancel         context.CancelFunc
 
+	prefix         string
 	linesToDisplay []string
 }
 
-func newTTYDisplayer(stdout, stderr io.Reader) *ttyDisplayer {
+func newTTYDisplayer(prefix string, stdout, stderr io.Reader) *ttyDisplayer {
 	var (
 		stdoutScanner *bufio.Scanner
 		stderrScanner *bufio.Scanner
@@ -56,6 +57,7 @@ func newTTYDisplayer(stdout, stderr io.Reader) *ttyDisplayer {
 		commandContext: commandContext,
 		cancel:         cancel,
 
+		prefix:         prefix,
 		linesToDisplay: []string{},
 	}
 }
@@ -77,7 +79,7 @@ func (d *ttyDisplayer) Display(_ string) {
 				case <-d.commandContext.Done():
 				default:
 					line := d.stdoutScanner.Text()
-					oktetoLog.Println(line)
+					oktetoLog.Println(formatLine(d.prefix, line, false))
 					continue
 				}
 				break
@@ -96,7 +98,7 @@ func (d *ttyDisplayer) Display(_ string) {
 				case <-d.commandContext.Done():
 				default:
 					line := d.stderrScanner.Text()
-					oktetoLog.Println(line)
+					oktetoLog.Println(formatLine(d.prefix, line, false))
 					continue
 				}
 				break
@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
 
 	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/env"
@@ -36,14 +37,22 @@ type Executor struct {
 	outputMode     string
 	shell, dir     string
 	runWithoutBash bool
+
+	// commandIndex is the number of commands executed, used to prefix their output lines
+	commandIndex int
 }
 
 type executorDisplayer interface {
 	display(command string)
-	startCommand(cmd *exec.Cmd) error
+	startCommand(cmd *exec.Cmd, prefix string) error
 	cleanUp(err error)
 }
 
+const (
+	// maxPrefixNameLength is the max length of the command name shown in the prefix of the output lines
+	maxPrefixNameLength = 20
+)
+
 // NewExecutor returns a new executor
 func NewExecutor(output string, runWithoutBash bool, dir string) *Executor {
 	var displayer executorDisplayer
@@ -86,7 +95,8 @@ func (e *Executor) Execute(cmdInfo model.DeployCommand, env []string) error {
 		cmd.Dir = e.dir
 	}
 
-	if err := e.displayer.startCommand(cmd); err != nil {
+	e.commandIndex++
+	if err := e.displayer.startCommand(cmd, getCommandPrefix(e.commandIndex, cmdInfo.Name)); err != nil {
 		if execErr, ok := err.(*exec.Error); ok {
 			if execErr != nil && execErr.Name == e.shell {
 				return fmt.Errorf("%w: \"%s\" is a required dependency for executing the command", err, e.shell)
@@ -113,3 +123,15 @@ func (e *Executor) CleanUp(err error) {
 func startCommand(cmd *exec.Cmd) error {
 	return cmd.Start()
 }
+
+// getCommandPrefix returns the prefix of the output lines of a command, so the lines of each command can be told apart
+func getCommandPrefix(index int, name string) string {
+	name = strings.TrimSpace(strings.SplitN(name, "\n", 2)[0])
+	if len(name) > maxPrefixNameLength {
+		name = fmt.Sprintf("%s...", name[:maxPrefixNameLength-3])
+	}
+	if name == "" {
+		return fmt.Sprintf("[%d] ", index)
+	}
+	return fmt.Sprintf("[%d %s] ", index, name)
+}
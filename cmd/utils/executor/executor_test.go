@@ -0,0 +1,104 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCommandPrefix(t *testing.T) {
+	tests := []struct {
+		name     string
+		cmdName  string
+		expected string
+		index    int
+	}{
+		{
+			name:     "short name",
+			index:    1,
+			cmdName:  "helm upgrade",
+			expected: "[1 helm upgrade] ",
+		},
+		{
+			name:     "long name",
+			index:    2,
+			cmdName:  "kubectl apply -f manifests/",
+			expected: "[2 kubectl apply -f ...] ",
+		},
+		{
+			name:     "multiline name",
+			index:    3,
+			cmdName:  "echo hello\necho world",
+			expected: "[3 echo hello] ",
+		},
+		{
+			name:     "empty name",
+			index:    4,
+			expected: "[4] ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getCommandPrefix(tt.index, tt.cmdName))
+		})
+	}
+}
+
+func TestExecuteMasksSecrets(t *testing.T) {
+	oktetoLog.Init(logrus.WarnLevel)
+	oktetoLog.SetOutputFormat(oktetoLog.PlainFormat)
+	oktetoLog.SetStage("deploy")
+	oktetoLog.AddSecret("s3cr3t-token")
+	oktetoLog.AddSecret("-----BEGIN KEY-----\nline-one-of-the-key\n-----END KEY-----")
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	stdout := os.Stdout
+	os.Stdout = w
+	oktetoLog.SetOutput(w)
+	defer func() {
+		os.Stdout = stdout
+		oktetoLog.SetStage("")
+		oktetoLog.Init(logrus.WarnLevel)
+	}()
+
+	e := NewExecutor(oktetoLog.PlainFormat, false, "")
+	err = e.Execute(model.DeployCommand{
+		Name:    "print secrets",
+		Command: `echo "token: $API_TOKEN"; echo "$PRIVATE_KEY"; echo "stderr: $API_TOKEN" >&2`,
+	}, []string{"API_TOKEN=s3cr3t-token", "PRIVATE_KEY=-----BEGIN KEY-----\nline-one-of-the-key\n-----END KEY-----"})
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	output := string(out)
+	assert.Contains(t, output, "token: ***")
+	assert.Contains(t, output, "stderr: ***")
+	assert.NotContains(t, output, "s3cr3t-token")
+	assert.NotContains(t, output, "line-one-of-the-key")
+
+	stored := oktetoLog.GetOutputBuffer().String()
+	assert.Contains(t, stored, "token: ***")
+	assert.NotContains(t, stored, "s3cr3t-token")
+	assert.NotContains(t, stored, "line-one-of-the-key")
+}
@@ -28,7 +28,7 @@ func newJSONExecutor() *jsonExecutor {
 	return &jsonExecutor{}
 }
 
-func (e *jsonExecutor) startCommand(cmd *exec.Cmd) error {
+func (e *jsonExecutor) startCommand(cmd *exec.Cmd, prefix string) error {
 	stdoutReader, err := cmd.StdoutPipe()
 	if err != nil {
 		return err
@@ -38,7 +38,7 @@ func (e *jsonExecutor) startCommand(cmd *exec.Cmd) error {
 	if err != nil {
 		return err
 	}
-	e.displayer = displayer.NewDisplayer(oktetoLog.GetOutputFormat(), stdoutReader, stderrReader)
+	e.displayer = displayer.NewDisplayer(oktetoLog.GetOutputFormat(), prefix, stdoutReader, stderrReader)
 	return startCommand(cmd)
 }
 
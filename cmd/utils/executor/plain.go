@@ -26,11 +26,11 @@ type plainExecutor struct {
 
 func newPlainExecutor() *plainExecutor {
 	return &plainExecutor{
-		displayer: displayer.NewDisplayer(oktetoLog.PlainFormat, nil, nil),
+		displayer: displayer.NewDisplayer(oktetoLog.PlainFormat, "", nil, nil),
 	}
 }
 
-func (e *plainExecutor) startCommand(cmd *exec.Cmd) error {
+func (e *plainExecutor) startCommand(cmd *exec.Cmd, prefix string) error {
 	stdoutReader, err := cmd.StdoutPipe()
 	if err != nil {
 		return err
@@ -40,7 +40,7 @@ func (e *plainExecutor) startCommand(cmd *exec.Cmd) error {
 	if err != nil {
 		return err
 	}
-	e.displayer = displayer.NewDisplayer(oktetoLog.GetOutputFormat(), stdoutReader, stderrReader)
+	e.displayer = displayer.NewDisplayer(oktetoLog.GetOutputFormat(), prefix, stdoutReader, stderrReader)
 	return startCommand(cmd)
 }
 
@@ -14,14 +14,23 @@
 package executor
 
 import (
+	"errors"
+	"io"
+	"os"
 	"os/exec"
+	"syscall"
 
+	"github.com/creack/pty"
 	"github.com/okteto/okteto/cmd/utils/displayer"
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/env"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"golang.org/x/term"
 )
 
 type ttyExecutor struct {
 	displayer displayer.Displayer
+	ptmx      *os.File
 }
 
 func newTTYExecutor() *ttyExecutor {
@@ -36,9 +45,23 @@ func (e *ttyExecutor) cleanUp(err error) {
 	if e.displayer != nil {
 		e.displayer.CleanUp(err)
 	}
+	if e.ptmx != nil {
+		if err := e.ptmx.Close(); err != nil {
+			oktetoLog.Infof("failed to close pty: %s", err)
+		}
+		e.ptmx = nil
+	}
 }
 
-func (e *ttyExecutor) startCommand(cmd *exec.Cmd) error {
+func (e *ttyExecutor) startCommand(cmd *exec.Cmd, prefix string) error {
+	if shouldAllocatePTY() {
+		err := e.startCommandWithPTY(cmd, prefix)
+		if err == nil || !errors.Is(err, errPTYNotAvailable) {
+			return err
+		}
+		oktetoLog.Infof("running command without pty: %s", err)
+	}
+
 	stdoutReader, err := cmd.StdoutPipe()
 	if err != nil {
 		return err
@@ -48,6 +71,61 @@ func (e *ttyExecutor) startCommand(cmd *exec.Cmd) error {
 	if err != nil {
 		return err
 	}
-	e.displayer = displayer.NewDisplayer(oktetoLog.GetOutputFormat(), stdoutReader, stderrReader)
+	e.displayer = displayer.NewDisplayer(oktetoLog.GetOutputFormat(), prefix, stdoutReader, stderrReader)
 	return startCommand(cmd)
 }
+
+var errPTYNotAvailable = errors.New("pty not available")
+
+// startCommandWithPTY runs the command attached to a pseudo-terminal, so tools like helm or kubectl keep
+// their colors and progress output. Stdout and stderr share the terminal, which keeps the order of their lines
+func (e *ttyExecutor) startCommandWithPTY(cmd *exec.Cmd, prefix string) error {
+	ptmx, tty, err := pty.Open()
+	if err != nil {
+		return errors.Join(errPTYNotAvailable, err)
+	}
+	defer func() {
+		if err := tty.Close(); err != nil {
+			oktetoLog.Infof("failed to close tty: %s", err)
+		}
+	}()
+
+	if err := pty.InheritSize(os.Stdout, ptmx); err != nil {
+		oktetoLog.Infof("failed to resize pty: %s", err)
+	}
+
+	cmd.Stdout = tty
+	cmd.Stderr = tty
+	if err := startCommand(cmd); err != nil {
+		if closeErr := ptmx.Close(); closeErr != nil {
+			oktetoLog.Infof("failed to close pty: %s", closeErr)
+		}
+		return err
+	}
+
+	e.ptmx = ptmx
+	e.displayer = displayer.NewDisplayer(oktetoLog.GetOutputFormat(), prefix, &ptyReader{r: ptmx}, nil)
+	return nil
+}
+
+// shouldAllocatePTY returns if the commands should run in a pseudo-terminal
+func shouldAllocatePTY() bool {
+	if env.LoadBoolean(constants.OktetoDisableCommandPTYEnvVar) {
+		return false
+	}
+	return oktetoLog.GetOutputFormat() == oktetoLog.TTYFormat && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// ptyReader reads from a pseudo-terminal. Reading fails with EIO once the command exits and closes
+// the terminal, which is the end of the output of the command
+type ptyReader struct {
+	r io.Reader
+}
+
+func (p *ptyReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if errors.Is(err, syscall.EIO) {
+		return n, io.EOF
+	}
+	return n, err
+}
@@ -0,0 +1,104 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/env"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// namespaceHint is shown when the namespace of the okteto context can't be used
+const namespaceHint = `Create the namespace with 'okteto namespace create %[1]s'
+    or switch to another one with 'okteto namespace use'.
+    If '%[1]s' was a preview environment, it might have expired: deploy it again.
+    Set %[2]s=true to skip this check`
+
+// namespacePermission is a permission checked before running up or deploy
+type namespacePermission struct {
+	verb     string
+	group    string
+	resource string
+}
+
+var namespacePermissions = []namespacePermission{
+	{verb: "get", resource: "pods"},
+	{verb: "create", group: "apps", resource: "deployments"},
+}
+
+// CheckNamespaceAccess verifies that the namespace exists and the user has the basic permissions
+// needed to develop on it. It can be skipped with OKTETO_SKIP_NAMESPACE_PREFLIGHT for custom RBAC setups
+func CheckNamespaceAccess(ctx context.Context, namespace string, c kubernetes.Interface) error {
+	if env.LoadBoolean(constants.OktetoSkipNamespacePreflightEnvVar) {
+		oktetoLog.Infof("skipping namespace pre-flight checks")
+		return nil
+	}
+
+	hint := fmt.Sprintf(namespaceHint, namespace, constants.OktetoSkipNamespacePreflightEnvVar)
+
+	_, err := c.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	switch {
+	case err == nil:
+	case k8sErrors.IsNotFound(err):
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("namespace '%s' doesn't exist", namespace),
+			Hint: hint,
+		}
+	case k8sErrors.IsForbidden(err):
+		// users might not be allowed to get their namespace, the access reviews below check their permissions on it
+		oktetoLog.Infof("not allowed to get namespace '%s': %s", namespace, err)
+	default:
+		oktetoLog.Infof("error getting namespace '%s': %s", namespace, err)
+		return nil
+	}
+
+	denied := []string{}
+	for _, p := range namespacePermissions {
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace: namespace,
+					Verb:      p.verb,
+					Group:     p.group,
+					Resource:  p.resource,
+				},
+			},
+		}
+		result, err := c.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+		if err != nil {
+			oktetoLog.Infof("error checking permission to %s %s in namespace '%s': %s", p.verb, p.resource, namespace, err)
+			return nil
+		}
+		if !result.Status.Allowed {
+			denied = append(denied, fmt.Sprintf("%s %s", p.verb, p.resource))
+		}
+	}
+
+	if len(denied) > 0 {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("you don't have permission to %s in namespace '%s'", strings.Join(denied, " or "), namespace),
+			Hint: hint,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apiv1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+// allowAccessReviews makes the fake clientset answer the access reviews, denying the given resources
+func allowAccessReviews(c *fake.Clientset, deniedResources ...string) {
+	c.PrependReactor("create", "selfsubjectaccessreviews", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8sTesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		review.Status.Allowed = true
+		for _, r := range deniedResources {
+			if review.Spec.ResourceAttributes.Resource == r {
+				review.Status.Allowed = false
+			}
+		}
+		return true, review, nil
+	})
+}
+
+func TestCheckNamespaceAccess(t *testing.T) {
+	ns := &apiv1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+
+	tests := []struct {
+		setup       func() *fake.Clientset
+		name        string
+		errContains string
+	}{
+		{
+			name: "namespace exists and user has permissions",
+			setup: func() *fake.Clientset {
+				c := fake.NewSimpleClientset(ns)
+				allowAccessReviews(c)
+				return c
+			},
+		},
+		{
+			name: "namespace not found",
+			setup: func() *fake.Clientset {
+				c := fake.NewSimpleClientset()
+				allowAccessReviews(c)
+				return c
+			},
+			errContains: "namespace 'test' doesn't exist",
+		},
+		{
+			name: "forbidden to create deployments",
+			setup: func() *fake.Clientset {
+				c := fake.NewSimpleClientset(ns)
+				allowAccessReviews(c, "deployments")
+				return c
+			},
+			errContains: "you don't have permission to create deployments in namespace 'test'",
+		},
+		{
+			name: "forbidden to get namespace but allowed in it",
+			setup: func() *fake.Clientset {
+				c := fake.NewSimpleClientset()
+				c.PrependReactor("get", "namespaces", func(k8sTesting.Action) (bool, runtime.Object, error) {
+					return true, nil, k8sErrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "test", assert.AnError)
+				})
+				allowAccessReviews(c)
+				return c
+			},
+		},
+		{
+			name: "forbidden to get namespace and pods",
+			setup: func() *fake.Clientset {
+				c := fake.NewSimpleClientset()
+				c.PrependReactor("get", "namespaces", func(k8sTesting.Action) (bool, runtime.Object, error) {
+					return true, nil, k8sErrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "test", assert.AnError)
+				})
+				allowAccessReviews(c, "pods", "deployments")
+				return c
+			},
+			errContains: "you don't have permission to get pods or create deployments in namespace 'test'",
+		},
+		{
+			name: "access reviews not available",
+			setup: func() *fake.Clientset {
+				c := fake.NewSimpleClientset(ns)
+				c.PrependReactor("create", "selfsubjectaccessreviews", func(k8sTesting.Action) (bool, runtime.Object, error) {
+					return true, nil, assert.AnError
+				})
+				return c
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckNamespaceAccess(context.Background(), "test", tt.setup())
+			if tt.errContains == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.errContains)
+			var uErr oktetoErrors.UserError
+			require.ErrorAs(t, err, &uErr)
+			assert.Contains(t, uErr.Hint, "okteto namespace create test")
+		})
+	}
+}
+
+func TestCheckNamespaceAccessSkipped(t *testing.T) {
+	t.Setenv(constants.OktetoSkipNamespacePreflightEnvVar, "true")
+
+	err := CheckNamespaceAccess(context.Background(), "test", fake.NewSimpleClientset())
+	require.NoError(t, err)
+}
@@ -0,0 +1,52 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/env"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// IsNonInteractive returns true if the prompts are disabled with --non-interactive or OKTETO_NON_INTERACTIVE
+func IsNonInteractive() bool {
+	return env.LoadBoolean(constants.OktetoNonInteractiveEnvVar)
+}
+
+// newNonInteractiveError returns the error raised when a prompt without a safe default runs in non-interactive mode
+func newNonInteractiveError(question string) error {
+	return oktetoErrors.UserError{
+		E:    fmt.Errorf("cannot answer '%s' in non-interactive mode", strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(question), ":"))),
+		Hint: fmt.Sprintf("Provide the value with the command flags or arguments, or run the command without --non-interactive and %s", constants.OktetoNonInteractiveEnvVar),
+	}
+}
+
+// AskForInput prompts the question and returns the line typed by the user
+func AskForInput(question string) (string, error) {
+	if IsNonInteractive() {
+		return "", newNonInteractiveError(question)
+	}
+	if err := oktetoLog.Question("%s", question); err != nil {
+		return "", err
+	}
+	var answer string
+	if _, err := fmt.Scanln(&answer); err != nil {
+		return "", err
+	}
+	return answer, nil
+}
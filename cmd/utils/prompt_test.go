@@ -0,0 +1,122 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAskYesNoNonInteractive(t *testing.T) {
+	t.Setenv(constants.OktetoNonInteractiveEnvVar, "true")
+
+	tests := []struct {
+		name        string
+		d           YesNoDefault
+		expected    bool
+		expectedErr bool
+	}{
+		{
+			name:     "default yes",
+			d:        YesNoDefault_Yes,
+			expected: true,
+		},
+		{
+			name:     "default no",
+			d:        YesNoDefault_No,
+			expected: false,
+		},
+		{
+			name:        "no default",
+			d:           YesNoDefault_Unspecified,
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := AskYesNo("Do you want to continue?", tt.d)
+			if tt.expectedErr {
+				var uErr oktetoErrors.UserError
+				require.ErrorAs(t, err, &uErr)
+				assert.ErrorContains(t, err, "cannot answer 'Do you want to continue?' in non-interactive mode")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestAskForOptionsNonInteractive(t *testing.T) {
+	t.Setenv(constants.OktetoNonInteractiveEnvVar, "true")
+
+	_, err := AskForOptions([]string{"go", "python"}, "Pick your project's main language from the list below:")
+	require.ErrorContains(t, err, "cannot answer 'Pick your project's main language from the list below' in non-interactive mode")
+}
+
+func TestAskForInputNonInteractive(t *testing.T) {
+	t.Setenv(constants.OktetoNonInteractiveEnvVar, "true")
+
+	_, err := AskForInput("Enter the namespace you want to use: ")
+	require.ErrorContains(t, err, "cannot answer 'Enter the namespace you want to use' in non-interactive mode")
+}
+
+func TestOktetoSelectorNonInteractive(t *testing.T) {
+	t.Setenv(constants.OktetoNonInteractiveEnvVar, "true")
+
+	options := []SelectorItem{
+		{Name: "a", Label: "a", Enable: true},
+		{Label: "", Enable: false},
+		{Name: "b", Label: "b", Enable: true},
+	}
+
+	tests := []struct {
+		name            string
+		expected        string
+		initialPosition int
+		expectedErr     bool
+	}{
+		{
+			name:            "initial position is selected",
+			initialPosition: 2,
+			expected:        "b",
+		},
+		{
+			name:            "no initial position",
+			initialPosition: -1,
+			expectedErr:     true,
+		},
+		{
+			name:            "initial position disabled",
+			initialPosition: 1,
+			expectedErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector := NewOktetoSelector("Select the development container:", "Development container")
+			result, err := selector.AskForOptionsOkteto(options, tt.initialPosition)
+			if tt.expectedErr {
+				require.ErrorContains(t, err, "cannot answer 'Select the development container' in non-interactive mode")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
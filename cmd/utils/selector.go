@@ -92,8 +92,16 @@ func NewOktetoSelector(label string, selectedTpl string) *OktetoSelector {
 	}
 }
 
-// AskForOptionsOkteto given some options ask the user to select one
+// AskForOptionsOkteto given some options ask the user to select one.
+// In non-interactive mode it returns the option in the initial position, or fails if there is none
 func (s *OktetoSelector) AskForOptionsOkteto(options []SelectorItem, initialPosition int) (string, error) {
+	if IsNonInteractive() {
+		if initialPosition >= 0 && initialPosition < len(options) && options[initialPosition].Enable {
+			oktetoLog.Infof("non-interactive mode: selecting '%s'", options[initialPosition].Name)
+			return options[initialPosition].Name, nil
+		}
+		return "", newNonInteractiveError(s.Label)
+	}
 	s.Items = options
 	s.Size = len(options)
 	s.Templates.FuncMap["oktetoblue"] = oktetoLog.BlueString
@@ -16,6 +16,7 @@ package utils
 import (
 	"fmt"
 	"io"
+	"sync"
 
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/vbauerster/mpb/v7"
@@ -31,6 +32,8 @@ type SyncthingProgress struct {
 	progressContainer *mpb.Progress
 	progressBar       *mpb.Bar
 	itemInSync        string
+	details           string
+	lock              sync.Mutex
 }
 
 // NewSyncthingProgressBar creates a new syncthing progress
@@ -49,7 +52,7 @@ func (s *SyncthingProgress) initProgressBar() {
 			decor.OnComplete(decor.Name(" "), ""),
 			decor.OnComplete(s.ItemStartedDecorator(), ""),
 		),
-		mpb.BarExtender(NewLineBarFiller(mpb.NewBarFiller(mpb.BarStyle().Lbound("[").Filler("-").Tip(">").Padding("_").Rbound("]")))),
+		mpb.BarExtender(NewLineBarFiller(mpb.NewBarFiller(mpb.BarStyle().Lbound("[").Filler("-").Tip(">").Padding("_").Rbound("]")), s.getDetails)),
 		mpb.BarRemoveOnComplete(),
 	)
 }
@@ -62,6 +65,19 @@ func (s *SyncthingProgress) UpdateItemInSync(lastItem string) {
 	}
 }
 
+// SetDetails sets the text displayed after the percentage, like the transfer rate and ETA
+func (s *SyncthingProgress) SetDetails(details string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.details = details
+}
+
+func (s *SyncthingProgress) getDetails() string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.details
+}
+
 // SetCurrent sets current progress of the syncthing progress bar
 func (s *SyncthingProgress) SetCurrent(v int64) {
 	if s.progressBar == nil {
@@ -78,7 +94,8 @@ func (s *SyncthingProgress) Finish() {
 	s.progressContainer.Wait()
 }
 
-func NewLineBarFiller(filler mpb.BarFiller) mpb.BarFiller {
+// NewLineBarFiller returns a filler that renders the bar in its own line, followed by the percentage and the text returned by details
+func NewLineBarFiller(filler mpb.BarFiller, details func() string) mpb.BarFiller {
 	return mpb.BarFillerFunc(func(w io.Writer, reqWidth int, st decor.Statistics) {
 		if _, err := w.Write([]byte("   ")); err != nil {
 			oktetoLog.Infof("error writing to writer: %s", err)
@@ -86,6 +103,9 @@ func NewLineBarFiller(filler mpb.BarFiller) mpb.BarFiller {
 		filler.Fill(w, reqWidth, st)
 		percentage := Percentage(st.Total, st.Current, totalProgressValue)
 		afterBarText := fmt.Sprintf(" %d%%\n", int(percentage))
+		if text := details(); text != "" {
+			afterBarText = fmt.Sprintf(" %d%% %s\n", int(percentage), text)
+		}
 		if _, err := w.Write([]byte(afterBarText)); err != nil {
 			oktetoLog.Infof("error writing to writer: %s", err)
 		}
@@ -0,0 +1,164 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultRateWindow is the period used to compute the transfer rate, so it follows changes of speed without flickering
+	defaultRateWindow = 10 * time.Second
+)
+
+type transferSample struct {
+	at   time.Time
+	done int64
+}
+
+// TransferTracker computes the percentage, rate and estimated time left of a transfer from periodic samples
+// of the transferred bytes. It is safe to use from several goroutines
+type TransferTracker struct {
+	lastProgress time.Time
+	samples      []transferSample
+	done         int64
+	total        int64
+	window       time.Duration
+	lock         sync.Mutex
+}
+
+// NewTransferTracker returns a tracker computing the transfer rate over the last 10 seconds
+func NewTransferTracker() *TransferTracker {
+	return &TransferTracker{window: defaultRateWindow}
+}
+
+// Update records the bytes transferred at a given time
+func (t *TransferTracker) Update(now time.Time, done, total int64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if len(t.samples) == 0 || done != t.done {
+		t.lastProgress = now
+	}
+	if done < t.done {
+		// the transfer went back (i.e. files were added to the transfer), the previous samples are no longer valid
+		t.samples = nil
+	}
+	t.done = done
+	t.total = total
+	t.samples = append(t.samples, transferSample{at: now, done: done})
+
+	i := 0
+	for i < len(t.samples)-2 && now.Sub(t.samples[i+1].at) >= t.window {
+		i++
+	}
+	t.samples = t.samples[i:]
+}
+
+// Percentage returns the percentage of the transfer completed
+func (t *TransferTracker) Percentage() float64 {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return Percentage(t.total, t.done, totalProgressValue)
+}
+
+// Rate returns the transferred bytes per second
+func (t *TransferTracker) Rate() float64 {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.rate()
+}
+
+func (t *TransferTracker) rate() float64 {
+	if len(t.samples) < 2 {
+		return 0
+	}
+	first, last := t.samples[0], t.samples[len(t.samples)-1]
+	elapsed := last.at.Sub(first.at).Seconds()
+	if elapsed <= 0 || last.done <= first.done {
+		return 0
+	}
+	return float64(last.done-first.done) / elapsed
+}
+
+// ETA returns the estimated time to complete the transfer. It returns false when there is no rate to estimate it
+func (t *TransferTracker) ETA() (time.Duration, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.eta()
+}
+
+func (t *TransferTracker) eta() (time.Duration, bool) {
+	rate := t.rate()
+	if rate <= 0 {
+		return 0, false
+	}
+	left := float64(t.total - t.done)
+	if left <= 0 {
+		return 0, true
+	}
+	return time.Duration(left / rate * float64(time.Second)), true
+}
+
+// StalledFor returns how long the transfer has been without progress
+func (t *TransferTracker) StalledFor(now time.Time) time.Duration {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.lastProgress.IsZero() || t.done >= t.total {
+		return 0
+	}
+	return now.Sub(t.lastProgress)
+}
+
+// Details returns the transferred bytes, the rate and the ETA of the transfer, like "12.0MB/28.0MB, 1.2MB/s, ETA 14s"
+func (t *TransferTracker) Details() string {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.total <= 0 {
+		return ""
+	}
+	details := []string{fmt.Sprintf("%s/%s", FormatBytes(t.done), FormatBytes(t.total))}
+	if rate := t.rate(); rate > 0 {
+		details = append(details, fmt.Sprintf("%s/s", FormatBytes(int64(rate))))
+	}
+	if eta, ok := t.eta(); ok {
+		details = append(details, fmt.Sprintf("ETA %s", formatETA(eta)))
+	}
+	return strings.Join(details, ", ")
+}
+
+// FormatBytes returns a human readable size, like "1.2MB"
+func FormatBytes(b int64) string {
+	const unit = 1000
+	if b < unit {
+		return fmt.Sprintf("%dB", b)
+	}
+	div, exp := int64(unit), 0
+	for n := b / unit; n >= unit && exp < 4; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%cB", float64(b)/float64(div), "kMGTP"[exp])
+}
+
+func formatETA(d time.Duration) string {
+	if d < time.Second {
+		return "<1s"
+	}
+	return d.Round(time.Second).String()
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransferTracker(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	type sample struct {
+		after time.Duration
+		done  int64
+		total int64
+	}
+	tests := []struct {
+		name            string
+		expectedDetails string
+		samples         []sample
+		expectedRate    float64
+		expectedETA     time.Duration
+		expectedPct     float64
+		expectedETAOk   bool
+	}{
+		{
+			name:            "single sample",
+			samples:         []sample{{done: 0, total: 10_000_000}},
+			expectedDetails: "0B/10.0MB",
+		},
+		{
+			name: "steady transfer",
+			samples: []sample{
+				{after: 0, done: 0, total: 10_000_000},
+				{after: time.Second, done: 1_000_000, total: 10_000_000},
+				{after: 2 * time.Second, done: 2_000_000, total: 10_000_000},
+			},
+			expectedRate:    1_000_000,
+			expectedETA:     8 * time.Second,
+			expectedETAOk:   true,
+			expectedPct:     20,
+			expectedDetails: "2.0MB/10.0MB, 1.0MB/s, ETA 8s",
+		},
+		{
+			name: "rate follows the last seconds",
+			samples: []sample{
+				{after: 0, done: 0, total: 100_000_000},
+				{after: 10 * time.Second, done: 50_000_000, total: 100_000_000},
+				{after: 20 * time.Second, done: 60_000_000, total: 100_000_000},
+			},
+			expectedRate:    1_000_000,
+			expectedETA:     40 * time.Second,
+			expectedETAOk:   true,
+			expectedPct:     60,
+			expectedDetails: "60.0MB/100.0MB, 1.0MB/s, ETA 40s",
+		},
+		{
+			name: "transfer restarted after files were added",
+			samples: []sample{
+				{after: 0, done: 0, total: 1_000},
+				{after: time.Second, done: 900, total: 1_000},
+				{after: 2 * time.Second, done: 100, total: 5_000},
+			},
+			expectedPct:     2,
+			expectedDetails: "100B/5.0kB",
+		},
+		{
+			name: "no progress",
+			samples: []sample{
+				{after: 0, done: 500, total: 1_000},
+				{after: time.Minute, done: 500, total: 1_000},
+			},
+			expectedPct:     50,
+			expectedDetails: "500B/1.0kB",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := NewTransferTracker()
+			for _, s := range tt.samples {
+				tracker.Update(start.Add(s.after), s.done, s.total)
+			}
+			assert.Equal(t, tt.expectedRate, tracker.Rate())
+			eta, ok := tracker.ETA()
+			assert.Equal(t, tt.expectedETAOk, ok)
+			assert.Equal(t, tt.expectedETA, eta)
+			assert.Equal(t, tt.expectedPct, tracker.Percentage())
+			assert.Equal(t, tt.expectedDetails, tracker.Details())
+		})
+	}
+}
+
+func TestTransferTrackerStalledFor(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker := NewTransferTracker()
+	assert.Zero(t, tracker.StalledFor(start))
+
+	tracker.Update(start, 100, 1_000)
+	tracker.Update(start.Add(30*time.Second), 100, 1_000)
+	assert.Equal(t, 45*time.Second, tracker.StalledFor(start.Add(45*time.Second)))
+
+	tracker.Update(start.Add(50*time.Second), 200, 1_000)
+	assert.Equal(t, 10*time.Second, tracker.StalledFor(start.Add(60*time.Second)))
+
+	tracker.Update(start.Add(70*time.Second), 1_000, 1_000)
+	assert.Zero(t, tracker.StalledFor(start.Add(10*time.Minute)))
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[int64]string{
+		0:             "0B",
+		999:           "999B",
+		1_000:         "1.0kB",
+		1_500_000:     "1.5MB",
+		2_000_000_000: "2.0GB",
+	}
+	for b, expected := range tests {
+		assert.Equal(t, expected, FormatBytes(b))
+	}
+}
@@ -14,48 +14,94 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/okteto/okteto/cmd/utils"
 	"github.com/okteto/okteto/pkg/config"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/selfupdate"
 	"github.com/spf13/cobra"
 )
 
+var errInvalidVersionOutput = errors.New("output format is not accepted. Value must be one of: ['json']")
+
+// releaseUpdater gets the okteto releases and replaces the current binary
+type releaseUpdater interface {
+	GetLatestVersion(ctx context.Context, channel string) (*semver.Version, error)
+	Update(ctx context.Context, channel string, version *semver.Version, binaryPath string) error
+}
+
+type versionCheckOutput struct {
+	Current         string `json:"current"`
+	Latest          string `json:"latest"`
+	Channel         string `json:"channel"`
+	UpdateAvailable bool   `json:"updateAvailable"`
+}
+
 // Version returns information about the binary
 func Version() *cobra.Command {
+	var check bool
+	var output string
+	channel := selfupdate.StableChannel
 	cmd := &cobra.Command{
 		Use:   "version",
 		Short: "Show the current installed Okteto CLI binary version",
 		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/okteto-cli/#version"),
-		RunE:  Show().RunE,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !check {
+				return Show().RunE(cmd, args)
+			}
+
+			current, err := getCurrentVersion()
+			if err != nil {
+				return err
+			}
+			updater := selfupdate.NewUpdater(selfupdate.DefaultDownloadsURL, runtime.GOOS, runtime.GOARCH)
+			return checkVersion(context.Background(), updater, current, channel, output, os.Stdout)
+		},
 	}
+	cmd.Flags().BoolVar(&check, "check", false, "check if there is a new version of the Okteto CLI available")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "output format of --check. One of: ['json']")
+	cmd.Flags().StringVar(&channel, "channel", selfupdate.StableChannel, "release channel used by --check. One of: ['stable', 'beta']")
 	cmd.AddCommand(Update())
 	cmd.AddCommand(Show())
 	return cmd
 }
 
-// Update checks if there is a new version available and updates it
+// Update updates the Okteto CLI binary to the latest version of the channel
 func Update() *cobra.Command {
-	return &cobra.Command{
+	channel := selfupdate.StableChannel
+	cmd := &cobra.Command{
 		Use:   "update",
-		Short: "Show information about how to update the Okteto CLI binary",
+		Short: "Update the Okteto CLI binary to the latest version",
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/okteto-cli/#version"),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			currentVersion, err := semver.NewVersion(config.VersionString)
+			current, err := getCurrentVersion()
 			if err != nil {
-				return fmt.Errorf("could not retrieve version")
+				return err
 			}
 
-			if isUpdateAvailable(currentVersion) {
-				displayUpdateSteps()
-			} else {
-				oktetoLog.Success("The latest okteto version is already installed")
+			binaryPath, err := getBinaryPath()
+			if err != nil {
+				return err
 			}
 
-			return nil
+			updater := selfupdate.NewUpdater(selfupdate.DefaultDownloadsURL, runtime.GOOS, runtime.GOARCH)
+			return update(context.Background(), updater, current, channel, binaryPath)
 		},
 	}
+	cmd.Flags().StringVar(&channel, "channel", selfupdate.StableChannel, "release channel to update from. One of: ['stable', 'beta']")
+	return cmd
 }
 
 // Show shows the current Okteto CLI version
@@ -70,31 +116,99 @@ func Show() *cobra.Command {
 	}
 }
 
-// isUpdateAvailable checks if there is a new version available
-func isUpdateAvailable(currentVersion *semver.Version) bool {
-	v, err := utils.GetLatestVersionFromGithub()
+func getCurrentVersion() (*semver.Version, error) {
+	current, err := semver.NewVersion(config.VersionString)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve version")
+	}
+	return current, nil
+}
+
+// getBinaryPath returns the path of the running binary, resolving symlinks
+func getBinaryPath() (string, error) {
+	p, err := os.Executable()
 	if err != nil {
-		oktetoLog.Infof("failed to get latest version from github: %s", err)
-		return false
+		return "", fmt.Errorf("failed to get the path of the okteto binary: %w", err)
 	}
+	p, err = filepath.EvalSymlinks(p)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the path of the okteto binary: %w", err)
+	}
+	return p, nil
+}
 
-	if len(v) > 0 {
-		latest, err := semver.NewVersion(v)
+// isManagedInstall returns true if the binary was installed by a package manager, which must be used to update it
+func isManagedInstall(binaryPath string) bool {
+	p := filepath.ToSlash(strings.ToLower(binaryPath))
+	return strings.Contains(p, "/cellar/") || strings.Contains(p, "/homebrew/") || strings.Contains(p, "/scoop/")
+}
+
+func checkVersion(ctx context.Context, updater releaseUpdater, current *semver.Version, channel, output string, w io.Writer) error {
+	if output != "" && output != "json" {
+		return errInvalidVersionOutput
+	}
+
+	latest, err := updater.GetLatestVersion(ctx, channel)
+	if err != nil {
+		return err
+	}
+
+	result := versionCheckOutput{
+		Current:         current.String(),
+		Latest:          latest.String(),
+		Channel:         channel,
+		UpdateAvailable: latest.GreaterThan(current),
+	}
+
+	if output == "json" {
+		b, err := json.MarshalIndent(result, "", "  ")
 		if err != nil {
-			oktetoLog.Infof("failed to parse latest version '%s': %s", v, err)
-			return false
+			return err
 		}
+		fmt.Fprintln(w, string(b))
+		return nil
+	}
 
-		if latest.GreaterThan(currentVersion) {
-			oktetoLog.Infof("new version available: %s -> %s", currentVersion.String(), latest)
-			return true
+	fmt.Fprintf(w, "okteto version %s\n", result.Current)
+	if !result.UpdateAvailable {
+		fmt.Fprintln(w, "The latest okteto version is already installed")
+		return nil
+	}
+	fmt.Fprintf(w, "A new version of okteto is available in the '%s' channel: %s\n", channel, result.Latest)
+	fmt.Fprintln(w, "Run 'okteto update' to update it")
+	return nil
+}
+
+func update(ctx context.Context, updater releaseUpdater, current *semver.Version, channel, binaryPath string) error {
+	if err := selfupdate.ValidateChannel(channel); err != nil {
+		return err
+	}
+
+	if isManagedInstall(binaryPath) {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("okteto was installed by a package manager in '%s'", binaryPath),
+			Hint: fmt.Sprintf("Update okteto with your package manager instead:%s", utils.GetUpgradeInstructions()),
 		}
 	}
 
-	return false
-}
+	latest, err := updater.GetLatestVersion(ctx, channel)
+	if err != nil {
+		return err
+	}
+
+	if !latest.GreaterThan(current) {
+		oktetoLog.Success("The latest okteto version is already installed")
+		return nil
+	}
+
+	oktetoLog.Information("Updating okteto from %s to %s...", current, latest)
+	if err := updater.Update(ctx, channel, latest, binaryPath); err != nil {
+		return oktetoErrors.UserError{
+			E:    err,
+			Hint: fmt.Sprintf("You can update okteto manually:%s", utils.GetUpgradeInstructions()),
+		}
+	}
 
-func displayUpdateSteps() {
-	oktetoLog.Println("You can update okteto with the following:")
-	oktetoLog.Print(utils.GetUpgradeInstructions())
+	oktetoLog.Success("Updated okteto from %s to %s", current, latest)
+	return nil
 }
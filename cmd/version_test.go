@@ -0,0 +1,142 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeReleaseUpdater struct {
+	latest     *semver.Version
+	err        error
+	updateErr  error
+	updatedTo  *semver.Version
+	binaryPath string
+}
+
+func (f *fakeReleaseUpdater) GetLatestVersion(_ context.Context, _ string) (*semver.Version, error) {
+	return f.latest, f.err
+}
+
+func (f *fakeReleaseUpdater) Update(_ context.Context, _ string, version *semver.Version, binaryPath string) error {
+	if f.updateErr != nil {
+		return f.updateErr
+	}
+	f.updatedTo = version
+	f.binaryPath = binaryPath
+	return nil
+}
+
+func TestCheckVersionJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		latest   string
+		expected versionCheckOutput
+	}{
+		{
+			name:   "update available",
+			latest: "3.1.0",
+			expected: versionCheckOutput{
+				Current:         "3.0.0",
+				Latest:          "3.1.0",
+				Channel:         "stable",
+				UpdateAvailable: true,
+			},
+		},
+		{
+			name:   "latest installed",
+			latest: "3.0.0",
+			expected: versionCheckOutput{
+				Current: "3.0.0",
+				Latest:  "3.0.0",
+				Channel: "stable",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updater := &fakeReleaseUpdater{latest: semver.MustParse(tt.latest)}
+			out := &bytes.Buffer{}
+			require.NoError(t, checkVersion(context.Background(), updater, semver.MustParse("3.0.0"), "stable", "json", out))
+
+			var result versionCheckOutput
+			require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestCheckVersionText(t *testing.T) {
+	updater := &fakeReleaseUpdater{latest: semver.MustParse("3.1.0")}
+	out := &bytes.Buffer{}
+	require.NoError(t, checkVersion(context.Background(), updater, semver.MustParse("3.0.0"), "stable", "", out))
+	assert.Contains(t, out.String(), "A new version of okteto is available in the 'stable' channel: 3.1.0")
+}
+
+func TestCheckVersionErrors(t *testing.T) {
+	updater := &fakeReleaseUpdater{err: errors.New("connection refused")}
+	err := checkVersion(context.Background(), updater, semver.MustParse("3.0.0"), "stable", "json", &bytes.Buffer{})
+	assert.ErrorContains(t, err, "connection refused")
+
+	err = checkVersion(context.Background(), updater, semver.MustParse("3.0.0"), "stable", "yaml", &bytes.Buffer{})
+	assert.ErrorIs(t, err, errInvalidVersionOutput)
+}
+
+func TestUpdate(t *testing.T) {
+	current := semver.MustParse("3.0.0")
+
+	t.Run("update available", func(t *testing.T) {
+		updater := &fakeReleaseUpdater{latest: semver.MustParse("3.1.0")}
+		require.NoError(t, update(context.Background(), updater, current, "stable", "/usr/local/bin/okteto"))
+		assert.Equal(t, "3.1.0", updater.updatedTo.String())
+		assert.Equal(t, "/usr/local/bin/okteto", updater.binaryPath)
+	})
+
+	t.Run("latest installed", func(t *testing.T) {
+		updater := &fakeReleaseUpdater{latest: semver.MustParse("3.0.0")}
+		require.NoError(t, update(context.Background(), updater, current, "stable", "/usr/local/bin/okteto"))
+		assert.Nil(t, updater.updatedTo)
+	})
+
+	t.Run("invalid channel", func(t *testing.T) {
+		updater := &fakeReleaseUpdater{latest: semver.MustParse("3.1.0")}
+		require.ErrorContains(t, update(context.Background(), updater, current, "nightly", "/usr/local/bin/okteto"), "channel 'nightly' is not valid")
+		assert.Nil(t, updater.updatedTo)
+	})
+
+	t.Run("installed by brew", func(t *testing.T) {
+		updater := &fakeReleaseUpdater{latest: semver.MustParse("3.1.0")}
+		err := update(context.Background(), updater, current, "stable", "/opt/homebrew/Cellar/okteto/3.0.0/bin/okteto")
+		var uErr oktetoErrors.UserError
+		require.ErrorAs(t, err, &uErr)
+		assert.Nil(t, updater.updatedTo)
+	})
+
+	t.Run("update fails", func(t *testing.T) {
+		updater := &fakeReleaseUpdater{latest: semver.MustParse("3.1.0"), updateErr: errors.New("checksum doesn't match")}
+		err := update(context.Background(), updater, current, "stable", "/usr/local/bin/okteto")
+		var uErr oktetoErrors.UserError
+		require.ErrorAs(t, err, &uErr)
+		assert.ErrorContains(t, err, "checksum doesn't match")
+	})
+}
@@ -0,0 +1,149 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package volume
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	contextCMD "github.com/okteto/okteto/cmd/context"
+	"github.com/okteto/okteto/cmd/utils"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/k8s/volumes"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/okteto"
+	"github.com/okteto/okteto/pkg/syncthing"
+	"github.com/okteto/okteto/pkg/validator"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Resize expands the persistent volume of a development container without restarting it
+func Resize(ctx context.Context, fs afero.Fs) *cobra.Command {
+	var namespace string
+	var k8sContext string
+	var devPath string
+
+	cmd := &cobra.Command{
+		Use:   "resize <size> [devContainer]",
+		Short: "Expand the persistent volume of a development container without restarting it",
+		Args:  utils.MinimumNArgsAccepted(1, "https://okteto.com/docs/reference/okteto-cli/#volume"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 2 {
+				return fmt.Errorf("%q accepts at most 2 args, but received %d", cmd.CommandPath(), len(args))
+			}
+			size, err := resource.ParseQuantity(args[0])
+			if err != nil {
+				return oktetoErrors.UserError{
+					E:    fmt.Errorf("invalid volume size '%s'", args[0]),
+					Hint: "Use a Kubernetes quantity, like '20Gi'",
+				}
+			}
+
+			if err := validator.FileArgumentIsNotDir(fs, devPath); err != nil {
+				return err
+			}
+
+			manifest, err := model.GetManifestV2(devPath, fs)
+			if err != nil {
+				return err
+			}
+
+			if err := contextCMD.NewContextCommand().Run(ctx, &contextCMD.Options{Show: true, Namespace: namespace, Context: k8sContext}); err != nil {
+				return err
+			}
+
+			devName := ""
+			if len(args) == 2 {
+				devName = args[1]
+			}
+			dev, err := utils.GetDevFromManifest(manifest, devName)
+			if err != nil {
+				if !errors.Is(err, utils.ErrNoDevSelected) {
+					return err
+				}
+				selector := utils.NewOktetoSelector("Select which development container's volume to resize:", "Development container")
+				dev, err = utils.SelectDevFromManifest(manifest, selector, manifest.Dev.GetDevs())
+				if err != nil {
+					return err
+				}
+			}
+
+			if namespace == "" {
+				namespace = okteto.GetContext().Namespace
+			}
+			c, _, err := okteto.GetK8sClient()
+			if err != nil {
+				return err
+			}
+
+			if err := executeResize(ctx, dev, devPath, namespace, size, c); err != nil {
+				return err
+			}
+			oktetoLog.Success("Persistent volume of '%s' expanded to %s", dev.Name, size.String())
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&devPath, "file", "f", "", "the path to the Okteto Manifest")
+	cmd.Flags().StringVarP(&namespace, "namespace", "n", "", "overwrite the current Okteto Namespace")
+	cmd.Flags().StringVarP(&k8sContext, "context", "c", "", "overwrite the current Okteto Context")
+	return cmd
+}
+
+func executeResize(ctx context.Context, dev *model.Dev, devPath, namespace string, size resource.Quantity, c kubernetes.Interface) error {
+	if !dev.PersistentVolumeEnabled() {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("'%s' doesn't use a persistent volume", dev.Name),
+			Hint: "Enable persistent volumes in your okteto manifest to resize them",
+		}
+	}
+
+	oktetoLog.Spinner(fmt.Sprintf("Expanding persistent volume to %s...", size.String()))
+	oktetoLog.StartSpinner()
+	defer oktetoLog.StopSpinner()
+
+	if err := volumes.Resize(ctx, dev.GetVolumeName(), namespace, size, c, dev.Timeout.Resources); err != nil {
+		var notSupportedErr volumes.ExpansionNotSupportedError
+		if errors.As(err, &notSupportedErr) {
+			return oktetoErrors.UserError{
+				E: err,
+				Hint: fmt.Sprintf(`Talk to your administrator to enable volume expansion,
+    or increase your persistent volume size, run '%s' and try 'okteto up' again`, utils.GetDownCommand(devPath)),
+			}
+		}
+		if oktetoErrors.IsNotFound(err) {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("'%s' doesn't have a persistent volume", dev.Name),
+				Hint: "Run 'okteto up' to create it",
+			}
+		}
+		return err
+	}
+
+	sy, err := syncthing.Load(dev, namespace)
+	if err != nil {
+		oktetoLog.Infof("synchronization not resumed, 'okteto up' is not running: %s", err)
+		return nil
+	}
+	if err := sy.Resume(ctx); err != nil {
+		oktetoLog.Infof("failed to resume the synchronization: %s", err)
+		oktetoLog.Warning("Could not resume the synchronization of your files. It will resume on the next 'okteto up'")
+	}
+	return nil
+}
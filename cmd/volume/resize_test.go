@@ -0,0 +1,82 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package volume
+
+import (
+	"context"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+func TestExecuteResizeErrors(t *testing.T) {
+	dev := &model.Dev{Name: "test"}
+	pvc := &apiv1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: dev.GetVolumeName(), Namespace: "ns"},
+		Spec: apiv1.PersistentVolumeClaimSpec{
+			StorageClassName: ptr.To("standard"),
+			VolumeName:       "pv-test",
+			Resources: apiv1.VolumeResourceRequirements{
+				Requests: apiv1.ResourceList{apiv1.ResourceStorage: resource.MustParse("5Gi")},
+			},
+		},
+	}
+	tests := []struct {
+		dev          *model.Dev
+		name         string
+		expectedErr  string
+		expectedHint string
+		objects      []runtime.Object
+	}{
+		{
+			name:         "persistent volume disabled",
+			dev:          &model.Dev{Name: "test", PersistentVolumeInfo: &model.PersistentVolumeInfo{Enabled: false}},
+			expectedErr:  "'test' doesn't use a persistent volume",
+			expectedHint: "Enable persistent volumes in your okteto manifest to resize them",
+		},
+		{
+			name:         "volume not found",
+			dev:          dev,
+			expectedErr:  "'test' doesn't have a persistent volume",
+			expectedHint: "Run 'okteto up' to create it",
+		},
+		{
+			name:         "expansion not supported",
+			dev:          dev,
+			objects:      []runtime.Object{pvc, &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "standard"}}},
+			expectedErr:  "the volume 'test-okteto' can't be expanded: the storage class 'standard' doesn't set 'allowVolumeExpansion: true'",
+			expectedHint: "Talk to your administrator to enable volume expansion,\n    or increase your persistent volume size, run 'okteto down -v' and try 'okteto up' again",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewSimpleClientset(tt.objects...)
+			err := executeResize(context.Background(), tt.dev, "", "ns", resource.MustParse("10Gi"), c)
+			require.EqualError(t, err, tt.expectedErr)
+			userErr := oktetoErrors.UserError{}
+			require.ErrorAs(t, err, &userErr)
+			assert.Equal(t, tt.expectedHint, userErr.Hint)
+		})
+	}
+}
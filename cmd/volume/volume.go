@@ -0,0 +1,33 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package volume
+
+import (
+	"context"
+
+	"github.com/okteto/okteto/cmd/utils"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+// Volume manages the persistent volumes of the development containers
+func Volume(ctx context.Context, fs afero.Fs) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "volume",
+		Short: "Manage the persistent volume of your development containers",
+		Args:  utils.NoArgsAccepted("https://okteto.com/docs/reference/okteto-cli/#volume"),
+	}
+	cmd.AddCommand(Resize(ctx, fs))
+	return cmd
+}
@@ -0,0 +1,164 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package up
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/okteto/okteto/pkg/k8s/apps"
+	forwardk8s "github.com/okteto/okteto/pkg/k8s/forward"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/okteto/okteto/pkg/syncthing"
+	"github.com/spf13/afero"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// FakeAppResponse is the result returned by FakeAppRetriever on a call to GetApp
+type FakeAppResponse struct {
+	App    apps.App
+	Err    error
+	Create bool
+}
+
+// FakeAppRetriever returns the configured responses in order. The last response is repeated once all of them are consumed
+type FakeAppRetriever struct {
+	Responses  []FakeAppResponse
+	Namespaces []string
+	Calls      int
+	mu         sync.Mutex
+}
+
+func (f *FakeAppRetriever) GetApp(_ context.Context, _ *model.Dev, namespace string, _ kubernetes.Interface, _ bool) (apps.App, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Calls++
+	f.Namespaces = append(f.Namespaces, namespace)
+	if len(f.Responses) == 0 {
+		return nil, false, nil
+	}
+	idx := f.Calls - 1
+	if idx >= len(f.Responses) {
+		idx = len(f.Responses) - 1
+	}
+	r := f.Responses[idx]
+	return r.App, r.Create, r.Err
+}
+
+// FakePodWaiter returns the configured pod or error
+type FakePodWaiter struct {
+	Pod *apiv1.Pod
+	Err error
+}
+
+func (f *FakePodWaiter) WaitForRunningPod(context.Context, *model.Dev, apps.App, kubernetes.Interface, time.Duration) (*apiv1.Pod, error) {
+	return f.Pod, f.Err
+}
+
+// FakeForwarder records the forwards added to it
+type FakeForwarder struct {
+	ErrAdd           error
+	ErrStart         error
+	Forwards         []forward.Forward
+	Reverses         []model.Reverse
+	StartedPod       string
+	StartedNamespace string
+	Started          bool
+	GlobalStarted    bool
+	Stopped          bool
+}
+
+func (f *FakeForwarder) Add(fwd forward.Forward) error {
+	if f.ErrAdd != nil {
+		return f.ErrAdd
+	}
+	f.Forwards = append(f.Forwards, fwd)
+	return nil
+}
+
+func (f *FakeForwarder) AddReverse(r model.Reverse) error {
+	f.Reverses = append(f.Reverses, r)
+	return nil
+}
+
+func (f *FakeForwarder) Start(pod, namespace string) error {
+	if f.ErrStart != nil {
+		return f.ErrStart
+	}
+	f.Started = true
+	f.StartedPod = pod
+	f.StartedNamespace = namespace
+	return nil
+}
+
+func (f *FakeForwarder) StartGlobalForwarding() error {
+	f.GlobalStarted = true
+	return nil
+}
+
+func (f *FakeForwarder) Stop() {
+	f.Stopped = true
+}
+
+func (*FakeForwarder) TransformLabelsToServiceName(fwd forward.Forward) (forward.Forward, error) {
+	return fwd, nil
+}
+
+// FakeForwarderFactory returns the same FakeForwarder for port and SSH forwards
+type FakeForwarderFactory struct {
+	Forwarder  *FakeForwarder
+	ErrSSH     error
+	Namespaces []string
+}
+
+func (f *FakeForwarderFactory) NewPortForwarder(_ context.Context, _ *model.Dev, _ *rest.Config, _ kubernetes.Interface, namespace string) forwardk8s.Forwarder {
+	f.Namespaces = append(f.Namespaces, namespace)
+	return f.Forwarder
+}
+
+func (f *FakeForwarderFactory) NewSSHForwarder(_ context.Context, _ *model.Dev, _ *rest.Config, _ kubernetes.Interface, namespace string) (forwardk8s.Forwarder, error) {
+	f.Namespaces = append(f.Namespaces, namespace)
+	if f.ErrSSH != nil {
+		return nil, f.ErrSSH
+	}
+	return f.Forwarder, nil
+}
+
+// FakeSyncthingController returns the configured syncthing instance and ping results
+type FakeSyncthingController struct {
+	Syncthing  *syncthing.Syncthing
+	Err        error
+	LocalPing  bool
+	RemotePing bool
+	PingCalls  int
+	mu         sync.Mutex
+}
+
+func (f *FakeSyncthingController) New(*model.Dev, string, afero.Fs) (*syncthing.Syncthing, error) {
+	return f.Syncthing, f.Err
+}
+
+func (f *FakeSyncthingController) Ping(_ context.Context, _ *syncthing.Syncthing, local bool) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.PingCalls++
+	if local {
+		return f.LocalPing
+	}
+	return f.RemotePing
+}
@@ -31,6 +31,9 @@ import (
 	"github.com/okteto/okteto/cmd/deploy"
 	"github.com/okteto/okteto/cmd/destroy"
 	"github.com/okteto/okteto/cmd/exec"
+	"github.com/okteto/okteto/cmd/forward"
+	"github.com/okteto/okteto/cmd/gc"
+	"github.com/okteto/okteto/cmd/generate"
 	"github.com/okteto/okteto/cmd/kubetoken"
 	"github.com/okteto/okteto/cmd/logs"
 	"github.com/okteto/okteto/cmd/namespace"
@@ -38,10 +41,14 @@ import (
 	"github.com/okteto/okteto/cmd/preview"
 	"github.com/okteto/okteto/cmd/registrytoken"
 	"github.com/okteto/okteto/cmd/remoterun"
+	"github.com/okteto/okteto/cmd/stack"
 	"github.com/okteto/okteto/cmd/test"
 	"github.com/okteto/okteto/cmd/up"
+	"github.com/okteto/okteto/cmd/volume"
 	"github.com/okteto/okteto/pkg/analytics"
+	"github.com/okteto/okteto/pkg/cmd/login"
 	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/constants"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/insights"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
@@ -96,12 +103,16 @@ func main() {
 	var logLevel string
 	var outputMode string
 	var serverNameOverride string
+	var nonInteractive bool
+	var noOverride bool
+	var platform string
 
 	if err := analytics.Init(); err != nil {
 		oktetoLog.Infof("error initializing okteto analytics: %s", err)
 	}
 
 	okteto.InitContextWithDeprecatedToken()
+	okteto.SetTokenRefresher(login.RefreshTokenWithBrowser)
 
 	k8sLogger := io.NewK8sLogger()
 
@@ -110,7 +121,7 @@ func main() {
 		Short:         "The Okteto Command Line Interface is a unified tool to manage Development Environments",
 		Long:          "The Okteto Command Line Interface is a unified tool to manage Development Environments",
 		SilenceErrors: true,
-		PersistentPreRun: func(ccmd *cobra.Command, args []string) {
+		PersistentPreRunE: func(ccmd *cobra.Command, args []string) error {
 			ccmd.SilenceUsage = true
 			if !registrytoken.IsRegistryCredentialHelperCommand(os.Args) {
 				oktetoLog.SetLevel(logLevel)          // TODO: Remove when we fully move to ioController
@@ -120,6 +131,24 @@ func main() {
 				ioController.SetOutputFormat(outputMode)
 			}
 			okteto.SetServerNameOverride(serverNameOverride)
+			if nonInteractive {
+				if err := os.Setenv(constants.OktetoNonInteractiveEnvVar, "true"); err != nil {
+					ioController.Logger().Infof("error setting %s: %s", constants.OktetoNonInteractiveEnvVar, err)
+				}
+			}
+			if noOverride {
+				if err := os.Setenv(constants.OktetoNoManifestOverrideEnvVar, "true"); err != nil {
+					ioController.Logger().Infof("error setting %s: %s", constants.OktetoNoManifestOverrideEnvVar, err)
+				}
+			}
+			if platform != "" {
+				if err := okteto.ValidatePlatform(platform); err != nil {
+					return err
+				}
+				if err := os.Setenv(constants.OktetoPlatformEnvVar, platform); err != nil {
+					ioController.Logger().Infof("error setting %s: %s", constants.OktetoPlatformEnvVar, err)
+				}
+			}
 			ioController.Logger().Infof("started %s", strings.Join(os.Args, " "))
 
 			if k8sLogger.IsEnabled() {
@@ -127,6 +156,7 @@ func main() {
 				k8sLogger.Start(config.GetOktetoHome(), cmdName, flags)
 				ioController.Logger().Debugf("okteto k8s log file: %s", io.GetK8sLoggerFilePath(config.GetOktetoHome()))
 			}
+			return nil
 		},
 		PersistentPostRun: func(ccmd *cobra.Command, args []string) {
 			ioController.Logger().Infof("finished %s", strings.Join(os.Args, " "))
@@ -136,6 +166,10 @@ func main() {
 	root.PersistentFlags().StringVarP(&logLevel, "log-level", "l", "warn", "amount of information output (debug, info, warn, error)")
 	root.PersistentFlags().StringVar(&outputMode, "log-output", oktetoLog.TTYFormat, "output format for logs (tty, plain, json)")
 
+	root.PersistentFlags().BoolVar(&nonInteractive, "non-interactive", false, "disable the interactive prompts, taking their default answer or failing if there is none")
+	root.PersistentFlags().BoolVar(&noOverride, "no-override", false, "ignore the override of the okteto manifest, like 'okteto.override.yml'")
+	root.PersistentFlags().StringVar(&platform, "platform", "", "force the platform of the current context instead of detecting it (okteto, vanilla)")
+
 	root.PersistentFlags().StringVarP(&serverNameOverride, "server-name", "", "", "The address and port of the Okteto Ingress server")
 	err := root.PersistentFlags().MarkHidden("server-name")
 	if err != nil {
@@ -151,6 +185,7 @@ func main() {
 
 	root.AddCommand(cmd.Analytics())
 	root.AddCommand(cmd.Version())
+	root.AddCommand(cmd.Update())
 
 	root.AddCommand(contextCMD.Context())
 	root.AddCommand(cmd.Kubeconfig(okClientProvider))
@@ -162,20 +197,26 @@ func main() {
 
 	root.AddCommand(namespace.Namespace(ctx, k8sLogger, ioController, at))
 	root.AddCommand(up.Up(at, insights, ioController, k8sLogger, fs))
+	root.AddCommand(up.Sandbox(at, insights, ioController, k8sLogger, fs))
 	root.AddCommand(cmd.Down(at, k8sLogger, fs))
 	root.AddCommand(cmd.Status(fs))
 	root.AddCommand(cmd.Doctor(k8sLogger, fs))
 	root.AddCommand(exec.NewExec(fs, ioController, k8sClientProvider).Cmd(ctx))
+	root.AddCommand(forward.NewForward(fs, ioController, k8sClientProvider).Cmd(ctx))
 	root.AddCommand(preview.Preview(ctx, at))
 	root.AddCommand(cmd.Restart(fs))
+	root.AddCommand(volume.Volume(ctx, fs))
+	root.AddCommand(gc.GC(ctx, k8sLogger))
 	root.AddCommand(deploy.Deploy(ctx, at, insights, ioController, k8sLogger))
 	root.AddCommand(destroy.Destroy(ctx, at, insights, ioController, k8sLogger, fs))
 	root.AddCommand(deploy.Endpoints(ctx, k8sLogger))
+	root.AddCommand(stack.Stack(ctx, k8sLogger))
 	root.AddCommand(logs.Logs(ctx, k8sLogger, fs))
 	root.AddCommand(generateFigSpec.NewCmdGenFigSpec())
 	root.AddCommand(remoterun.RemoteRun(ctx, k8sLogger, ioController))
 	root.AddCommand(test.Test(ctx, ioController, k8sLogger, at, insights))
 	root.AddCommand(cmd.GenerateSchema())
+	root.AddCommand(generate.Generate(fs, ioController))
 	root.AddCommand(cmd.Validate(fs))
 
 	root.AddCommand(pipeline.Pipeline(ctx, at))
@@ -197,6 +238,13 @@ func main() {
 				oktetoLog.Hint("    %s", uErr.Hint)
 			}
 		}
+		var exitErr oktetoErrors.ExitCodeError
+		if errors.As(err, &exitErr) && exitErr.Code > 0 {
+			os.Exit(exitErr.Code)
+		}
+		if errors.Is(err, oktetoErrors.ErrTokenExpired) {
+			os.Exit(oktetoErrors.TokenExpiredExitCode)
+		}
 		os.Exit(1)
 	}
 }
@@ -157,6 +157,7 @@ func (b *posthogBackend) enqueue(ctx context.Context, userID, event string, prop
 		for _, enrich := range enrichers {
 			enrich(ctx, props)
 		}
+		maskSecrets(props)
 		if err := b.client.Enqueue(posthog.Capture{
 			DistinctId: userID,
 			Event:      event,
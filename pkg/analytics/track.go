@@ -278,12 +278,29 @@ func track(event string, success bool, props map[string]any) {
 	props["context"] = okteto.GetContext().CompanyName
 	props["isTrial"] = okteto.GetContext().IsTrial
 
+	maskSecrets(props)
 	e := &mixpanel.Event{Properties: props}
 	if err := mixpanelClient.Track(getTrackID(), event, e); err != nil {
 		oktetoLog.Infof("Failed to send analytics: %s", err)
 	}
 }
 
+// maskSecrets replaces the secret values of the manifest in the string properties of an event
+func maskSecrets(props map[string]any) {
+	for k, v := range props {
+		switch value := v.(type) {
+		case string:
+			props[k] = oktetoLog.MaskSecrets(value)
+		case []string:
+			masked := make([]string, len(value))
+			for i := range value {
+				masked[i] = oktetoLog.MaskSecrets(value[i])
+			}
+			props[k] = masked
+		}
+	}
+}
+
 func disabledByOktetoAdmin() bool {
 	return !okteto.GetContext().Analytics
 }
@@ -14,9 +14,11 @@
 package analytics
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/model"
 )
 
@@ -24,6 +26,9 @@ const (
 	// Event that tracks when a user activates a development container
 	upEvent = "Up"
 
+	// Event that tracks when an up session finishes
+	upSessionEndEvent = "Up Session End"
+
 	// reconnectCauseDefault is the default cause for a reconnection
 	reconnectCauseDefault = "unrecognised"
 
@@ -31,6 +36,30 @@ const (
 	reconnectCauseDevPodRecreated = "dev-pod-recreated"
 )
 
+// UpFailureReason is the category of the error that finished an up session.
+// It never contains paths, names or error messages
+type UpFailureReason string
+
+const (
+	// UpFailureSync is the failure reason when the file synchronization fails
+	UpFailureSync UpFailureReason = "sync"
+
+	// UpFailureK8sConnect is the failure reason when the connection to the cluster is lost
+	UpFailureK8sConnect UpFailureReason = "k8s-connect"
+
+	// UpFailureBuild is the failure reason when the images of the development container can't be built
+	UpFailureBuild UpFailureReason = "build"
+
+	// UpFailureImagePull is the failure reason when the image of the development container can't be pulled
+	UpFailureImagePull UpFailureReason = "image-pull"
+
+	// UpFailureUserCancel is the failure reason when the user cancels the session
+	UpFailureUserCancel UpFailureReason = "user-cancel"
+
+	// UpFailureOther is the failure reason of any other error
+	UpFailureOther UpFailureReason = "other"
+)
+
 // UpMetricsMetadata defines the properties of the Up event we want to track
 type UpMetricsMetadata struct {
 	workflowID     string
@@ -40,6 +69,9 @@ type UpMetricsMetadata struct {
 	service        string
 	namespace      string
 	repoURL        string
+	failureReason  UpFailureReason
+
+	startTime time.Time
 
 	activateDuration             time.Duration
 	initialSyncDuration          time.Duration
@@ -48,6 +80,7 @@ type UpMetricsMetadata struct {
 	contextSyncDuration          time.Duration
 	localFoldersScanDuration     time.Duration
 	execDuration                 time.Duration
+	sessionDuration              time.Duration
 
 	reconnectCount int
 
@@ -68,12 +101,14 @@ type UpMetricsMetadata struct {
 	success                  bool
 	hasRunDeploy             bool
 	isAutoDownEnabled        bool
+	hasPersistentVolume      bool
 }
 
 // NewUpMetricsMetadata returns a new UpMetricsMetadata with a unique workflow ID.
 func NewUpMetricsMetadata() *UpMetricsMetadata {
 	return &UpMetricsMetadata{
 		workflowID: uuid.New().String(),
+		startTime:  time.Now(),
 	}
 }
 
@@ -109,6 +144,19 @@ func (u *UpMetricsMetadata) toProps() map[string]any {
 		"localFoldersScanDurationSeconds":     u.localFoldersScanDuration.Seconds(),
 		"execDurationSeconds":                 u.execDuration.Seconds(),
 		"isAutoDownEnabled":                   u.isAutoDownEnabled,
+		"reconnectCount":                      u.reconnectCount,
+		"failureReason":                       u.failureReason,
+		"hasPersistentVolume":                 u.hasPersistentVolume,
+	}
+}
+
+// toSessionEndProps transforms UpMetricsMetadata into the properties of the up session end event
+func (u *UpMetricsMetadata) toSessionEndProps() map[string]any {
+	return map[string]any{
+		"failureReason":          u.failureReason,
+		"reconnectCount":         u.reconnectCount,
+		"sessionDurationSeconds": u.sessionDuration.Seconds(),
+		"hasPersistentVolume":    u.hasPersistentVolume,
 	}
 }
 
@@ -126,6 +174,7 @@ func (u *UpMetricsMetadata) DevProps(d *model.Dev) {
 	u.mode = d.Mode
 	u.isInteractive = d.IsInteractive()
 	u.service = d.Name
+	u.hasPersistentVolume = d.PersistentVolumeEnabled()
 }
 
 // RepositoryProps adds the tracking properties of the repository
@@ -182,6 +231,61 @@ func (u *UpMetricsMetadata) ErrSyncLostSyncthing() {
 	u.errSyncLostSyncthing = true
 }
 
+// ErrBuild sets the failure reason of the session to build
+func (u *UpMetricsMetadata) ErrBuild() {
+	u.failureReason = UpFailureBuild
+}
+
+// UserCancel sets the failure reason of the session to user-cancel
+func (u *UpMetricsMetadata) UserCancel() {
+	u.failureReason = UpFailureUserCancel
+}
+
+// ErrUp sets the failure reason of the session from the error that finished it.
+// The failure reason is not overwritten if it was already set
+func (u *UpMetricsMetadata) ErrUp(err error) {
+	if err == nil || u.failureReason != "" {
+		return
+	}
+	reason := categorizeUpError(err)
+	if reason == UpFailureOther && u.errSync {
+		reason = UpFailureSync
+	}
+	u.failureReason = reason
+}
+
+// EndSession sets the duration of the session
+func (u *UpMetricsMetadata) EndSession(end time.Time) {
+	if u.startTime.IsZero() {
+		return
+	}
+	u.sessionDuration = end.Sub(u.startTime)
+}
+
+// categorizeUpError returns the failure reason of an up error using the same classification
+// used to decide if up has to reconnect
+func categorizeUpError(err error) UpFailureReason {
+	switch {
+	case errors.Is(err, oktetoErrors.ErrIntSig):
+		return UpFailureUserCancel
+	case errors.Is(err, oktetoErrors.ErrLostSyncthing),
+		errors.Is(err, oktetoErrors.ErrInsufficientSpace),
+		errors.Is(err, oktetoErrors.ErrUnknownSyncError),
+		errors.Is(err, oktetoErrors.ErrNeedsResetSyncError),
+		errors.Is(err, oktetoErrors.ErrBusySyncthing):
+		return UpFailureSync
+	case oktetoErrors.IsImagePull(err):
+		return UpFailureImagePull
+	case oktetoErrors.IsTransient(err),
+		oktetoErrors.IsClientCertificateExpired(err),
+		oktetoErrors.IsX509(err),
+		oktetoErrors.IsClosedNetwork(err):
+		return UpFailureK8sConnect
+	default:
+		return UpFailureOther
+	}
+}
+
 // CommandSuccess sets to true the property success
 func (u *UpMetricsMetadata) CommandSuccess() {
 	u.success = true
@@ -283,6 +387,10 @@ func (u *UpMetricsMetadata) toPostHogProps() map[string]any {
 			props["error_reason"] = reason
 		}
 	}
+	if u.failureReason != "" {
+		props["failure_reason"] = string(u.failureReason)
+	}
+	props["has_persistent_volume"] = u.hasPersistentVolume
 	return props
 }
 
@@ -295,6 +403,11 @@ func (a *Tracker) TrackUp(m *UpMetricsMetadata) {
 	}
 }
 
+// TrackUpSessionEnd sends a tracking event to mixpanel when an up session finishes
+func (a *Tracker) TrackUpSessionEnd(m *UpMetricsMetadata) {
+	a.trackFn(upSessionEndEvent, m.failureReason == "" || m.failureReason == UpFailureUserCancel, m.toSessionEndProps())
+}
+
 // TrackUpStarted fires the okteto_up_started event at the beginning of the up command.
 func (a *Tracker) TrackUpStarted(service, namespace, repoURL, workflowID string) {
 	for _, b := range a.backends {
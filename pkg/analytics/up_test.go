@@ -14,11 +14,13 @@
 package analytics
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/okteto/okteto/pkg/build"
 	"github.com/okteto/okteto/pkg/deps"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -103,8 +105,9 @@ func Test_UpMetricsMetadata_DevProps(t *testing.T) {
 				Mode: "sync",
 			},
 			expected: &UpMetricsMetadata{
-				mode:          "sync",
-				isInteractive: true,
+				hasPersistentVolume: true,
+				mode:                "sync",
+				isInteractive:       true,
 			},
 		},
 		{
@@ -113,15 +116,17 @@ func Test_UpMetricsMetadata_DevProps(t *testing.T) {
 				Mode: "hybrid",
 			},
 			expected: &UpMetricsMetadata{
-				mode:          "hybrid",
-				isInteractive: true,
+				hasPersistentVolume: true,
+				mode:                "hybrid",
+				isInteractive:       true,
 			},
 		},
 		{
 			name: "dev interactive",
 			dev:  &model.Dev{},
 			expected: &UpMetricsMetadata{
-				isInteractive: true,
+				hasPersistentVolume: true,
+				isInteractive:       true,
 			},
 		},
 		{
@@ -131,7 +136,9 @@ func Test_UpMetricsMetadata_DevProps(t *testing.T) {
 					Values: []string{"yarn start"},
 				},
 			},
-			expected: &UpMetricsMetadata{},
+			expected: &UpMetricsMetadata{
+				hasPersistentVolume: true,
+			},
 		},
 		{
 			name: "dev interactive with reverse",
@@ -144,7 +151,19 @@ func Test_UpMetricsMetadata_DevProps(t *testing.T) {
 				},
 			},
 			expected: &UpMetricsMetadata{
-				hasReverse:    true,
+				hasPersistentVolume: true,
+				hasReverse:          true,
+				isInteractive:       true,
+			},
+		},
+		{
+			name: "dev with persistent volume disabled",
+			dev: &model.Dev{
+				PersistentVolumeInfo: &model.PersistentVolumeInfo{
+					Enabled: false,
+				},
+			},
+			expected: &UpMetricsMetadata{
 				isInteractive: true,
 			},
 		},
@@ -155,9 +174,10 @@ func Test_UpMetricsMetadata_DevProps(t *testing.T) {
 				Mode: "sync",
 			},
 			expected: &UpMetricsMetadata{
-				service:       "api",
-				mode:          "sync",
-				isInteractive: true,
+				hasPersistentVolume: true,
+				service:             "api",
+				mode:                "sync",
+				isInteractive:       true,
 			},
 		},
 	}
@@ -307,6 +327,7 @@ func Test_UpMetricsMetadata_ToPostHogProps(t *testing.T) {
 			"is_reconnect":                  false,
 			"reconnect_count":               0,
 			"is_auto_down":                  false,
+			"has_persistent_volume":         false,
 			"workflow_id":                   "",
 			"service":                       "",
 			"repo_url":                      "",
@@ -373,9 +394,9 @@ func Test_UpMetricsMetadata_ToPostHogProps(t *testing.T) {
 				devContainerCreationDuration: 5 * time.Second,
 			},
 			expected: baseProps(map[string]any{
-				"result":                                  true,
-				"duration_seconds":                        60,
-				"initial_sync_duration_seconds":           10,
+				"result":                        true,
+				"duration_seconds":              60,
+				"initial_sync_duration_seconds": 10,
 				"dev_container_creation_duration_seconds": 5,
 			}),
 		},
@@ -471,6 +492,9 @@ func Test_UpTracker(t *testing.T) {
 					"errSyncInsufficientSpace":            false,
 					"errSyncLostSyncthing":                false,
 					"isAutoDownEnabled":                   false,
+					"reconnectCount":                      0,
+					"failureReason":                       UpFailureReason(""),
+					"hasPersistentVolume":                 false,
 				},
 			},
 		},
@@ -507,6 +531,9 @@ func Test_UpTracker(t *testing.T) {
 					"errSyncInsufficientSpace":            false,
 					"errSyncLostSyncthing":                false,
 					"isAutoDownEnabled":                   false,
+					"reconnectCount":                      0,
+					"failureReason":                       UpFailureReason(""),
+					"hasPersistentVolume":                 false,
 				},
 			},
 		},
@@ -560,6 +587,9 @@ func Test_UpTracker(t *testing.T) {
 					"errSyncInsufficientSpace":            false,
 					"errSyncLostSyncthing":                false,
 					"isAutoDownEnabled":                   false,
+					"reconnectCount":                      0,
+					"failureReason":                       UpFailureReason(""),
+					"hasPersistentVolume":                 false,
 				},
 			},
 		},
@@ -610,6 +640,9 @@ func Test_UpTracker(t *testing.T) {
 					"errSyncInsufficientSpace":            false,
 					"errSyncLostSyncthing":                false,
 					"isAutoDownEnabled":                   false,
+					"reconnectCount":                      0,
+					"failureReason":                       UpFailureReason(""),
+					"hasPersistentVolume":                 false,
 				},
 			},
 		},
@@ -659,6 +692,9 @@ func Test_UpTracker(t *testing.T) {
 					"errSyncInsufficientSpace":            false,
 					"errSyncLostSyncthing":                false,
 					"isAutoDownEnabled":                   false,
+					"reconnectCount":                      0,
+					"failureReason":                       UpFailureReason(""),
+					"hasPersistentVolume":                 false,
 				},
 			},
 		},
@@ -770,3 +806,197 @@ func TestAnalyticsTracker_TrackUp(t *testing.T) {
 		})
 	}
 }
+
+func Test_UpMetricsMetadata_ErrUp(t *testing.T) {
+	tests := []struct {
+		err      error
+		meta     *UpMetricsMetadata
+		name     string
+		expected UpFailureReason
+	}{
+		{
+			name:     "no error",
+			meta:     &UpMetricsMetadata{},
+			expected: "",
+		},
+		{
+			name:     "interrupt signal",
+			meta:     &UpMetricsMetadata{},
+			err:      oktetoErrors.ErrIntSig,
+			expected: UpFailureUserCancel,
+		},
+		{
+			name:     "lost syncthing",
+			meta:     &UpMetricsMetadata{},
+			err:      oktetoErrors.ErrLostSyncthing,
+			expected: UpFailureSync,
+		},
+		{
+			name:     "wrapped insufficient space",
+			meta:     &UpMetricsMetadata{},
+			err:      oktetoErrors.UserError{E: oktetoErrors.ErrInsufficientSpace},
+			expected: UpFailureSync,
+		},
+		{
+			name:     "unknown error after a sync error",
+			meta:     &UpMetricsMetadata{errSync: true},
+			err:      assert.AnError,
+			expected: UpFailureSync,
+		},
+		{
+			name:     "image pull",
+			meta:     &UpMetricsMetadata{},
+			err:      fmt.Errorf(`Failed to pull image "okteto/api:dev": rpc error: code = NotFound`),
+			expected: UpFailureImagePull,
+		},
+		{
+			name:     "transient connection error",
+			meta:     &UpMetricsMetadata{},
+			err:      fmt.Errorf("dial tcp 10.0.0.1:443: i/o timeout"),
+			expected: UpFailureK8sConnect,
+		},
+		{
+			name:     "expired certificate",
+			meta:     &UpMetricsMetadata{},
+			err:      fmt.Errorf("remote error: tls: expired certificate"),
+			expected: UpFailureK8sConnect,
+		},
+		{
+			name:     "unknown error",
+			meta:     &UpMetricsMetadata{},
+			err:      assert.AnError,
+			expected: UpFailureOther,
+		},
+		{
+			name:     "reason already set",
+			meta:     &UpMetricsMetadata{failureReason: UpFailureUserCancel},
+			err:      fmt.Errorf("connection reset by peer"),
+			expected: UpFailureUserCancel,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.meta.ErrUp(tt.err)
+			assert.Equal(t, tt.expected, tt.meta.failureReason)
+		})
+	}
+}
+
+func TestAnalyticsTracker_TrackUpSessionEnd(t *testing.T) {
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		script   func(m *UpMetricsMetadata)
+		expected mockEvent
+		name     string
+	}{
+		{
+			name: "session cancelled by the user after reconnecting",
+			script: func(m *UpMetricsMetadata) {
+				m.ReconnectDefault()
+				m.ReconnectDevPodRecreated()
+				m.UserCancel()
+				m.ErrUp(nil)
+				m.EndSession(start.Add(90 * time.Second))
+			},
+			expected: mockEvent{
+				event:   "Up Session End",
+				success: true,
+				props: map[string]any{
+					"failureReason":          UpFailureUserCancel,
+					"reconnectCount":         2,
+					"sessionDurationSeconds": float64(90),
+					"hasPersistentVolume":    true,
+				},
+			},
+		},
+		{
+			name: "synchronization lost",
+			script: func(m *UpMetricsMetadata) {
+				m.ErrSync()
+				m.ErrSyncLostSyncthing()
+				m.ErrUp(oktetoErrors.ErrLostSyncthing)
+				m.EndSession(start.Add(10 * time.Minute))
+			},
+			expected: mockEvent{
+				event:   "Up Session End",
+				success: false,
+				props: map[string]any{
+					"failureReason":          UpFailureSync,
+					"reconnectCount":         0,
+					"sessionDurationSeconds": float64(600),
+					"hasPersistentVolume":    true,
+				},
+			},
+		},
+		{
+			name: "image can't be pulled",
+			script: func(m *UpMetricsMetadata) {
+				m.ErrUp(fmt.Errorf("Back-off pulling image: ImagePullBackOff"))
+				m.EndSession(start.Add(30 * time.Second))
+			},
+			expected: mockEvent{
+				event:   "Up Session End",
+				success: false,
+				props: map[string]any{
+					"failureReason":          UpFailureImagePull,
+					"reconnectCount":         0,
+					"sessionDurationSeconds": float64(30),
+					"hasPersistentVolume":    true,
+				},
+			},
+		},
+		{
+			name: "cluster unreachable after reconnecting",
+			script: func(m *UpMetricsMetadata) {
+				m.ReconnectDefault()
+				m.ErrUp(fmt.Errorf("dial tcp: connect: network is unreachable"))
+				m.EndSession(start.Add(time.Hour))
+			},
+			expected: mockEvent{
+				event:   "Up Session End",
+				success: false,
+				props: map[string]any{
+					"failureReason":          UpFailureK8sConnect,
+					"reconnectCount":         1,
+					"sessionDurationSeconds": float64(3600),
+					"hasPersistentVolume":    true,
+				},
+			},
+		},
+		{
+			name: "build failure is not overwritten",
+			script: func(m *UpMetricsMetadata) {
+				m.ErrBuild()
+				m.ErrUp(fmt.Errorf("error building image: exit code 1"))
+				m.EndSession(start.Add(5 * time.Second))
+			},
+			expected: mockEvent{
+				event:   "Up Session End",
+				success: false,
+				props: map[string]any{
+					"failureReason":          UpFailureBuild,
+					"reconnectCount":         0,
+					"sessionDurationSeconds": float64(5),
+					"hasPersistentVolume":    true,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &UpMetricsMetadata{startTime: start}
+			m.DevProps(&model.Dev{Name: "api"})
+			tt.script(m)
+
+			event := mockEvent{}
+			tracker := &Tracker{
+				trackFn: func(name string, success bool, props map[string]any) {
+					event = mockEvent{event: name, success: success, props: props}
+				},
+			}
+			tracker.TrackUpSessionEnd(m)
+			assert.Equal(t, tt.expected, event)
+		})
+	}
+}
@@ -15,6 +15,7 @@ package build
 
 import (
 	"fmt"
+	"os"
 	"sort"
 	"strings"
 
@@ -118,3 +119,44 @@ func SerializeArgs(buildArgs Args) []string {
 	sort.Strings(result)
 	return result
 }
+
+// ParseArgs parses build args with the form 'KEY=VALUE' as received from the command line.
+// Values are expanded with the environment variables. An arg without value takes it from the environment variable with the same name
+func ParseArgs(values []string) (Args, error) {
+	result := Args{}
+	for _, value := range values {
+		name, argValue, found := strings.Cut(value, "=")
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("invalid build arg '%s': name is empty", value)
+		}
+		if !found {
+			result = append(result, Arg{Name: name, Value: os.Getenv(name)})
+			continue
+		}
+		expandedValue, err := env.ExpandEnvIfNotEmpty(argValue)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, Arg{Name: name, Value: expandedValue})
+	}
+	return result, nil
+}
+
+// Merge returns the args with the given args appended. Args already defined take precedence
+func (a Args) Merge(args Args) Args {
+	result := make(Args, 0, len(a)+len(args))
+	defined := map[string]bool{}
+	for _, arg := range a {
+		defined[arg.Name] = true
+		result = append(result, arg)
+	}
+	for _, arg := range args {
+		if defined[arg.Name] {
+			continue
+		}
+		defined[arg.Name] = true
+		result = append(result, arg)
+	}
+	return result
+}
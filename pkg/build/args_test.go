@@ -174,3 +174,83 @@ func TestSerializeArs(t *testing.T) {
 		})
 	}
 }
+
+func TestParseArgs(t *testing.T) {
+	t.Setenv("COMMIT_SHA", "abc123")
+	tests := []struct {
+		name        string
+		input       []string
+		expected    Args
+		expectedErr bool
+	}{
+		{
+			name:     "no args",
+			input:    nil,
+			expected: Args{},
+		},
+		{
+			name:  "key and value",
+			input: []string{"KEY=VALUE", "OTHER=a=b"},
+			expected: Args{
+				{Name: "KEY", Value: "VALUE"},
+				{Name: "OTHER", Value: "a=b"},
+			},
+		},
+		{
+			name:  "value with env var",
+			input: []string{"SHA=${COMMIT_SHA}"},
+			expected: Args{
+				{Name: "SHA", Value: "abc123"},
+			},
+		},
+		{
+			name:  "empty value",
+			input: []string{"KEY="},
+			expected: Args{
+				{Name: "KEY", Value: ""},
+			},
+		},
+		{
+			name:  "key without value takes it from the environment",
+			input: []string{"COMMIT_SHA"},
+			expected: Args{
+				{Name: "COMMIT_SHA", Value: "abc123"},
+			},
+		},
+		{
+			name:        "empty key",
+			input:       []string{"=VALUE"},
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseArgs(tt.input)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestArgsMerge(t *testing.T) {
+	args := Args{
+		{Name: "COMMIT_SHA", Value: "entry"},
+		{Name: "ENTRY", Value: "value"},
+	}
+	result := args.Merge(Args{
+		{Name: "GLOBAL", Value: "value"},
+		{Name: "COMMIT_SHA", Value: "global"},
+		{Name: "GLOBAL", Value: "duplicated"},
+	})
+	expected := Args{
+		{Name: "COMMIT_SHA", Value: "entry"},
+		{Name: "ENTRY", Value: "value"},
+		{Name: "GLOBAL", Value: "value"},
+	}
+	assert.Equal(t, expected, result)
+	assert.Len(t, args, 2)
+}
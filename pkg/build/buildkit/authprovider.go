@@ -15,11 +15,9 @@ package buildkit
 
 import (
 	"context"
-	"io"
 	"strings"
 	"sync"
 
-	"github.com/docker/cli/cli/config"
 	"github.com/docker/cli/cli/config/configfile"
 	"github.com/docker/cli/cli/config/types"
 	"github.com/moby/buildkit/session/auth"
@@ -35,9 +33,9 @@ const oktetoLocalRegistryStorePriorityEnabledEnvVarKey = "OKTETO_LOCAL_REGISTRY_
 
 var oktetoRegistry = ""
 
-func newDockerAndOktetoAuthProvider(registryURL, username, password string, authContext authProviderContextInterface, stderr io.Writer) *authProvider {
+func newDockerAndOktetoAuthProvider(cfg *configfile.ConfigFile, registryURL, username, password string, authContext authProviderContextInterface) *authProvider {
 	result := &authProvider{
-		config:          config.LoadDefaultConfigFile(stderr),
+		config:          cfg,
 		externalAuth:    authContext.getExternalRegistryCreds,
 		newOktetoClient: okteto.NewOktetoClientStateless,
 		authContext:     authContext,
@@ -0,0 +1,61 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connector
+
+import (
+	"context"
+
+	"github.com/moby/buildkit/client"
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/log/io"
+)
+
+// ExternalConnector connects to a buildkit server that is not managed by Okteto.
+// The okteto certificate and token are never sent to external builders
+type ExternalConnector struct {
+	buildkitClientFactory *ClientFactory
+	waiter                *Waiter
+}
+
+// NewExternalConnector creates a new connector for the buildkit server at address
+func NewExternalConnector(address string, ioCtrl *io.Controller) *ExternalConnector {
+	return &ExternalConnector{
+		buildkitClientFactory: NewBuildkitClientFactory("", address, "", config.GetCertificatePath(), ioCtrl),
+		waiter:                NewBuildkitClientWaiter(ioCtrl),
+	}
+}
+
+// Start is a no-op for the external connector since it doesn't maintain a persistent connection
+func (e *ExternalConnector) Start(ctx context.Context) error {
+	return nil
+}
+
+// WaitUntilIsReady waits for the buildkit server to be ready
+func (e *ExternalConnector) WaitUntilIsReady(ctx context.Context) error {
+	return e.waiter.WaitUntilIsUp(ctx, e.GetBuildkitClient)
+}
+
+func (e *ExternalConnector) GetBuildkitClient(ctx context.Context) (*client.Client, error) {
+	return e.buildkitClientFactory.GetBuildkitClient(ctx)
+}
+
+// GetType returns the connector type name for logging
+func (e *ExternalConnector) GetType() string {
+	return "external"
+}
+
+// Stop is a no-op for the external connector since it doesn't maintain a persistent connection
+func (e *ExternalConnector) Stop() {
+	// No-op: external connector doesn't maintain a persistent connection that needs to be closed
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package buildkit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+const defaultDockerHost = "unix:///var/run/docker.sock"
+
+// dockerLoader streams an image tarball to the docker daemon, which loads it into its image store
+type dockerLoader struct {
+	pw   *io.PipeWriter
+	done chan error
+}
+
+type dockerLoadMessage struct {
+	Stream string `json:"stream"`
+	Error  string `json:"error"`
+}
+
+// newDockerLoader starts loading an image into the docker daemon listening at dockerHost.
+// The image is loaded when the returned writer is closed
+func newDockerLoader(dockerHost string) (*dockerLoader, error) {
+	httpClient, baseURL, err := newDockerHTTPClient(dockerHost)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, baseURL+"/images/load?quiet=1", pr)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-tar")
+
+	l := &dockerLoader{
+		pw:   pw,
+		done: make(chan error, 1),
+	}
+	go func() {
+		err := doDockerLoad(httpClient, req)
+		// unblock the writer if the daemon fails before reading the whole tarball
+		pr.CloseWithError(err)
+		l.done <- err
+	}()
+	return l, nil
+}
+
+// newDockerHTTPClient returns a client to the docker API listening at dockerHost, and its base URL
+func newDockerHTTPClient(dockerHost string) (*http.Client, string, error) {
+	if dockerHost == "" {
+		dockerHost = defaultDockerHost
+	}
+	u, err := url.Parse(dockerHost)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid DOCKER_HOST '%s': %w", dockerHost, err)
+	}
+
+	switch u.Scheme {
+	case "unix":
+		socket := u.Path
+		transport := &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socket)
+			},
+		}
+		return &http.Client{Transport: transport}, "http://docker", nil
+	case "tcp", "http":
+		return &http.Client{}, fmt.Sprintf("http://%s", u.Host), nil
+	default:
+		return nil, "", fmt.Errorf("loading images into the docker daemon at '%s' is not supported", dockerHost)
+	}
+}
+
+func doDockerLoad(httpClient *http.Client, req *http.Request) error {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect to the docker daemon: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("docker daemon failed to load the image: %s", string(body))
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var msg dockerLoadMessage
+		if err := decoder.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to read the response of the docker daemon: %w", err)
+		}
+		if msg.Error != "" {
+			return fmt.Errorf("docker daemon failed to load the image: %s", msg.Error)
+		}
+	}
+}
+
+// Write writes a chunk of the image tarball
+func (l *dockerLoader) Write(p []byte) (int, error) {
+	return l.pw.Write(p)
+}
+
+// Close finishes the image tarball and waits for the docker daemon to load it
+func (l *dockerLoader) Close() error {
+	if err := l.pw.Close(); err != nil {
+		return err
+	}
+	return <-l.done
+}
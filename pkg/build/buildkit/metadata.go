@@ -21,4 +21,23 @@ type BuildMetadata struct {
 	ContextTransferDuration      time.Duration
 	BuildContextSize             int64
 	ConnectionType               string
+	// CacheSteps are the steps of the build reported by buildkit, in order of completion
+	CacheSteps []CacheStep
+}
+
+// CacheStep is a step of the build and whether buildkit resolved it from the cache
+type CacheStep struct {
+	Name   string
+	Cached bool
+}
+
+// CacheHits returns the number of steps resolved from the cache and the number of steps of the build
+func (m *BuildMetadata) CacheHits() (int, int) {
+	cached := 0
+	for _, s := range m.CacheSteps {
+		if s.Cached {
+			cached++
+		}
+	}
+	return cached, len(m.CacheSteps)
 }
@@ -23,7 +23,6 @@ import (
 	"path/filepath"
 	"strings"
 
-	dockerConfig "github.com/docker/cli/cli/config"
 	"github.com/moby/buildkit/client"
 	buildctl "github.com/moby/buildkit/cmd/buildctl/build"
 	"github.com/moby/buildkit/session"
@@ -108,6 +107,11 @@ func (b *SolveOptBuilder) Build(ctx context.Context, buildOptions *types.BuildOp
 		return nil, err
 	}
 
+	output, err := ParseOutput(buildOptions.Output)
+	if err != nil {
+		return nil, err
+	}
+
 	// extend okteto.dev images into the extended syntax
 	buildOptions.Tag = b.extendRegistries(buildOptions.Tag)
 	for i := range buildOptions.CacheFrom {
@@ -186,7 +190,18 @@ func (b *SolveOptBuilder) Build(ctx context.Context, buildOptions *types.BuildOp
 		}
 		frontendAttrs["build-arg:"+kv[0]] = kv[1]
 	}
-	attachable := []session.Attachable{}
+	// the docker config secret is forwarded as registry credentials, it's never mounted in the builder
+	secrets, dockerConfigPath, err := extractDockerConfigSecret(buildOptions.Secrets)
+	if err != nil {
+		return nil, err
+	}
+	buildOptions.Secrets = secrets
+	dockerCfg, err := loadDockerConfig(b.fs, dockerConfigPath, os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+
+	var ap registryAuthServer
 	if b.okCtx.IsOktetoCluster() {
 		apCtx := &authProviderContext{
 			isOkteto: b.okCtx.IsOktetoCluster(),
@@ -195,15 +210,19 @@ func (b *SolveOptBuilder) Build(ctx context.Context, buildOptions *types.BuildOp
 			cert:     b.okCtx.GetCurrentCertStr(),
 		}
 
-		ap := newDockerAndOktetoAuthProvider(b.okCtx.GetRegistryURL(), b.okCtx.GetCurrentUser(), b.okCtx.GetCurrentToken(), apCtx, os.Stderr)
-		attachable = append(attachable, ap)
+		ap = newDockerAndOktetoAuthProvider(dockerCfg, b.okCtx.GetRegistryURL(), b.okCtx.GetCurrentUser(), b.okCtx.GetCurrentToken(), apCtx)
 	} else {
-		dockerCfg := dockerConfig.LoadDefaultConfigFile(os.Stderr)
 		authProviderConfig := authprovider.DockerAuthProviderConfig{
 			AuthConfigProvider: authprovider.LoadAuthConfig(dockerCfg),
 			TLSConfigs:         map[string]*authprovider.AuthTLSConfig{},
 		}
-		attachable = append(attachable, authprovider.NewDockerAuthProvider(authProviderConfig))
+		if dockerAP, ok := authprovider.NewDockerAuthProvider(authProviderConfig).(registryAuthServer); ok {
+			ap = dockerAP
+		}
+	}
+	attachable := []session.Attachable{}
+	if ap != nil {
+		attachable = append(attachable, b.scopeAuthProvider(ap, buildOptions, localDirs != nil, frontend.Image))
 	}
 
 	for _, sess := range buildOptions.SshSessions {
@@ -249,7 +268,7 @@ func (b *SolveOptBuilder) Build(ctx context.Context, buildOptions *types.BuildOp
 		Internal: env.LoadBoolean("OKTETO_BUILDKIT_INTERNAL_MODE"),
 	}
 
-	if buildOptions.Tag != "" {
+	if buildOptions.Tag != "" && output.IsRegistry() {
 		exportAttrs := map[string]string{
 			"name": buildOptions.Tag,
 			"push": "true",
@@ -279,6 +298,11 @@ func (b *SolveOptBuilder) Build(ctx context.Context, buildOptions *types.BuildOp
 		}
 	}
 
+	// the cacheonly output has no exporter: the result is only kept in the build cache
+	if !output.IsRegistry() && !output.IsCacheOnly() {
+		opt.Exports = append(opt.Exports, output.exportEntry(buildOptions.Tag))
+	}
+
 	if buildOptions.LocalOutputPath != "" {
 		opt.Exports = append(opt.Exports, client.ExportEntry{
 			Type:      "local",
@@ -296,13 +320,50 @@ func (b *SolveOptBuilder) Build(ctx context.Context, buildOptions *types.BuildOp
 		)
 	}
 
-	for _, exportCacheTo := range buildOptions.ExportCache {
+	opt.CacheExports = getCacheExports(buildOptions.ExportCache, buildOptions.Tag, output)
+
+	return opt, nil
+}
+
+// scopeAuthProvider restricts the registry credentials forwarded to the builder to the registries referenced
+// by the build: the Dockerfile images, the image tag, the cache images and the frontend image.
+// If the Dockerfile isn't local or it can't be parsed, the credentials of the Dockerfile images are not forwarded
+func (b *SolveOptBuilder) scopeAuthProvider(ap registryAuthServer, buildOptions *types.BuildOptions, isLocalDockerfile bool, frontendImage string) *scopedAuthProvider {
+	registries := []string{}
+	if isLocalDockerfile {
+		dockerfileRegistries, err := getDockerfileRegistries(b.fs, buildOptions.File, buildOptions.BuildArgs)
+		if err != nil {
+			oktetoLog.Infof("could not get the registries of dockerfile '%s', the credentials of its images are not forwarded: %s", buildOptions.File, err)
+		} else {
+			registries = append(registries, dockerfileRegistries...)
+		}
+	}
+	images := []string{buildOptions.Tag, frontendImage}
+	images = append(images, buildOptions.CacheFrom...)
+	images = append(images, buildOptions.ExportCache...)
+	for _, image := range images {
+		if registry := getImageRegistry(image); image != "" && registry != "" {
+			registries = append(registries, registry)
+		}
+	}
+	if b.okCtx.IsOktetoCluster() {
+		registries = append(registries, b.okCtx.GetRegistryURL())
+	}
+	oktetoLog.Infof("forwarding registry credentials for: %s", strings.Join(registries, ", "))
+	return newScopedAuthProvider(ap, registries)
+}
+
+// getCacheExports returns the cache exporters of the build. The cache exported to the image tag is
+// inlined in the image, except for the cacheonly output, which doesn't export any image
+func getCacheExports(exportCache []string, tag string, output *Output) []client.CacheOptionsEntry {
+	result := []client.CacheOptionsEntry{}
+	for _, exportCacheTo := range exportCache {
 		exportType := "inline"
-		if exportCacheTo != buildOptions.Tag {
+		if exportCacheTo != tag || output.IsCacheOnly() {
 			exportType = "registry"
 		}
-		opt.CacheExports = append(
-			opt.CacheExports,
+		result = append(
+			result,
 			client.CacheOptionsEntry{
 				Type: exportType,
 				Attrs: map[string]string{
@@ -312,8 +373,7 @@ func (b *SolveOptBuilder) Build(ctx context.Context, buildOptions *types.BuildOp
 			},
 		)
 	}
-
-	return opt, nil
+	return result
 }
 
 // validate validates the build options
@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/moby/buildkit/client"
 	"github.com/okteto/okteto/pkg/types"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/require"
@@ -120,3 +121,42 @@ func Test_replaceSecretsSourceEnvWithTempFile(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCacheExports(t *testing.T) {
+	tests := []struct {
+		output   *Output
+		name     string
+		tag      string
+		cache    []string
+		expected []client.CacheOptionsEntry
+	}{
+		{
+			name:     "no export cache",
+			tag:      "okteto.dev/api:1.0",
+			expected: []client.CacheOptionsEntry{},
+		},
+		{
+			name:  "inline when exporting to the image tag",
+			tag:   "okteto.dev/api:1.0",
+			cache: []string{"okteto.dev/api:1.0", "okteto.dev/api:cache"},
+			expected: []client.CacheOptionsEntry{
+				{Type: "inline", Attrs: map[string]string{"ref": "okteto.dev/api:1.0", "mode": "max"}},
+				{Type: "registry", Attrs: map[string]string{"ref": "okteto.dev/api:cache", "mode": "max"}},
+			},
+		},
+		{
+			name:   "registry with cacheonly output",
+			tag:    "okteto.dev/api:1.0",
+			cache:  []string{"okteto.dev/api:1.0"},
+			output: &Output{Type: OutputTypeCacheOnly},
+			expected: []client.CacheOptionsEntry{
+				{Type: "registry", Attrs: map[string]string{"ref": "okteto.dev/api:1.0", "mode": "max"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, getCacheExports(tt.cache, tt.tag, tt.output))
+		})
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package buildkit
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/moby/buildkit/client"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/localcluster"
+)
+
+const (
+	// OutputTypeRegistry pushes the image to the registry. It's the default output
+	OutputTypeRegistry = "registry"
+
+	// OutputTypeDocker loads the image into the local docker daemon, or writes it as a docker tarball if dest is set
+	OutputTypeDocker = "docker"
+
+	// OutputTypeOCI writes the image as an OCI image layout tarball
+	OutputTypeOCI = "oci"
+
+	// OutputTypeTar writes the filesystem of the image as a tarball
+	OutputTypeTar = "tar"
+
+	// OutputTypeCacheOnly discards the image and only keeps the build cache
+	OutputTypeCacheOnly = "cacheonly"
+
+	// OutputTypeCluster loads the image into the nodes of a local kind or k3d cluster, which has no registry
+	OutputTypeCluster = "cluster"
+
+	outputHint = "The output format is 'type=registry', 'type=docker', 'type=oci,dest=image.tar', 'type=tar,dest=rootfs.tar', 'type=cluster' or 'type=cacheonly'"
+)
+
+// Output defines where the built image is exported to
+type Output struct {
+	Type string
+	Dest string
+
+	// Cluster is the kubernetes context of the local cluster of the cluster output
+	Cluster string
+}
+
+// ParseOutput parses the value of the output flag, like 'type=docker' or 'type=tar,dest=image.tar'.
+// An empty value is the registry output
+func ParseOutput(value string) (*Output, error) {
+	output := &Output{Type: OutputTypeRegistry}
+	if strings.TrimSpace(value) == "" {
+		return output, nil
+	}
+
+	fields, err := csv.NewReader(strings.NewReader(value)).Read()
+	if err != nil {
+		return nil, newOutputError(fmt.Errorf("invalid output %q: %w", value, err))
+	}
+	for _, field := range fields {
+		key, v, found := strings.Cut(strings.TrimSpace(field), "=")
+		if !found {
+			return nil, newOutputError(fmt.Errorf("invalid output %q: %q is not a key=value pair", value, field))
+		}
+		switch strings.ToLower(key) {
+		case "type":
+			output.Type = strings.ToLower(v)
+		case "dest":
+			output.Dest = v
+		case "cluster":
+			output.Cluster = v
+		default:
+			return nil, newOutputError(fmt.Errorf("invalid output %q: unknown key %q", value, key))
+		}
+	}
+
+	if output.Cluster != "" && output.Type != OutputTypeCluster {
+		return nil, newOutputError(fmt.Errorf("invalid output %q: 'cluster' is only supported by the %s output", value, OutputTypeCluster))
+	}
+	switch output.Type {
+	case OutputTypeRegistry, OutputTypeCacheOnly, OutputTypeCluster:
+		if output.Dest != "" {
+			return nil, newOutputError(fmt.Errorf("invalid output %q: 'dest' is not supported by the %s output", value, output.Type))
+		}
+	case OutputTypeDocker:
+	case OutputTypeOCI, OutputTypeTar:
+		if output.Dest == "" {
+			return nil, newOutputError(fmt.Errorf("invalid output %q: 'dest' is required by the %s output", value, output.Type))
+		}
+	default:
+		return nil, newOutputError(fmt.Errorf("invalid output %q: unknown type %q", value, output.Type))
+	}
+	return output, nil
+}
+
+func newOutputError(err error) error {
+	return oktetoErrors.UserError{
+		E:    err,
+		Hint: outputHint,
+	}
+}
+
+// IsRegistry returns if the image is pushed to the registry
+func (o *Output) IsRegistry() bool {
+	return o == nil || o.Type == OutputTypeRegistry
+}
+
+// IsCacheOnly returns if the image is discarded and only the build cache is kept
+func (o *Output) IsCacheOnly() bool {
+	return o != nil && o.Type == OutputTypeCacheOnly
+}
+
+// String returns a description of where the image is exported to
+func (o *Output) String() string {
+	switch {
+	case o.IsRegistry():
+		return "the registry"
+	case o.IsCacheOnly():
+		return "the build cache"
+	case o.Type == OutputTypeDocker && o.Dest == "":
+		return "the local docker daemon"
+	case o.Type == OutputTypeCluster:
+		if cluster := localcluster.Detect(o.Cluster); cluster != nil {
+			return fmt.Sprintf("the nodes of the %s", cluster)
+		}
+		return "the nodes of the cluster"
+	default:
+		return fmt.Sprintf("'%s'", o.Dest)
+	}
+}
+
+// exportEntry returns the buildkit exporter of a non registry output. The image is named as tag, if set
+func (o *Output) exportEntry(tag string) client.ExportEntry {
+	entry := client.ExportEntry{
+		Type:  o.Type,
+		Attrs: map[string]string{},
+	}
+	if tag != "" && o.Type != OutputTypeTar {
+		entry.Attrs["name"] = tag
+	}
+
+	if o.Type == OutputTypeCluster {
+		// the nodes import the docker tarball of the image
+		entry.Type = OutputTypeDocker
+		entry.Output = func(_ map[string]string) (io.WriteCloser, error) {
+			cluster := localcluster.Detect(o.Cluster)
+			if cluster == nil {
+				return nil, fmt.Errorf("'%s' is not a kind or k3d cluster", o.Cluster)
+			}
+			return localcluster.NewImageLoader(context.Background(), cluster, localcluster.NewDockerNodeExecutor())
+		}
+		return entry
+	}
+
+	dest := o.Dest
+	if o.Type == OutputTypeDocker && dest == "" {
+		entry.Output = func(_ map[string]string) (io.WriteCloser, error) {
+			loader, err := newDockerLoader(os.Getenv("DOCKER_HOST"))
+			if err != nil {
+				return nil, err
+			}
+			return loader, nil
+		}
+		return entry
+	}
+	entry.Output = func(_ map[string]string) (io.WriteCloser, error) {
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return nil, err
+		}
+		return os.Create(dest)
+	}
+	return entry
+}
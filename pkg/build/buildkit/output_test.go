@@ -0,0 +1,261 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package buildkit
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOutput(t *testing.T) {
+	tests := []struct {
+		expected *Output
+		name     string
+		value    string
+		wantErr  bool
+	}{
+		{
+			name:     "default",
+			value:    "",
+			expected: &Output{Type: OutputTypeRegistry},
+		},
+		{
+			name:     "registry",
+			value:    "type=registry",
+			expected: &Output{Type: OutputTypeRegistry},
+		},
+		{
+			name:     "docker",
+			value:    "type=docker",
+			expected: &Output{Type: OutputTypeDocker},
+		},
+		{
+			name:     "docker with dest",
+			value:    "type=docker,dest=image.tar",
+			expected: &Output{Type: OutputTypeDocker, Dest: "image.tar"},
+		},
+		{
+			name:     "oci",
+			value:    "type=oci,dest=out/image.tar",
+			expected: &Output{Type: OutputTypeOCI, Dest: "out/image.tar"},
+		},
+		{
+			name:     "tar",
+			value:    "TYPE=Tar, dest=rootfs.tar",
+			expected: &Output{Type: OutputTypeTar, Dest: "rootfs.tar"},
+		},
+		{
+			name:     "cacheonly",
+			value:    "type=cacheonly",
+			expected: &Output{Type: OutputTypeCacheOnly},
+		},
+		{
+			name:     "cluster",
+			value:    "type=cluster,cluster=kind-dev",
+			expected: &Output{Type: OutputTypeCluster, Cluster: "kind-dev"},
+		},
+		{
+			name:    "cluster with dest",
+			value:   "type=cluster,dest=image.tar",
+			wantErr: true,
+		},
+		{
+			name:    "cluster of another output",
+			value:   "type=docker,cluster=kind-dev",
+			wantErr: true,
+		},
+		{
+			name:    "cacheonly with dest",
+			value:   "type=cacheonly,dest=image.tar",
+			wantErr: true,
+		},
+		{
+			name:    "tar without dest",
+			value:   "type=tar",
+			wantErr: true,
+		},
+		{
+			name:    "registry with dest",
+			value:   "type=registry,dest=image.tar",
+			wantErr: true,
+		},
+		{
+			name:    "unknown type",
+			value:   "type=local",
+			wantErr: true,
+		},
+		{
+			name:    "unknown key",
+			value:   "type=docker,compression=zstd",
+			wantErr: true,
+		},
+		{
+			name:    "not key value",
+			value:   "docker",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseOutput(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestOutputExportEntry(t *testing.T) {
+	tests := []struct {
+		output        *Output
+		expectedAttrs map[string]string
+		name          string
+		tag           string
+		expectedType  string
+	}{
+		{
+			name:          "docker",
+			output:        &Output{Type: OutputTypeDocker},
+			tag:           "okteto/api:dev",
+			expectedType:  "docker",
+			expectedAttrs: map[string]string{"name": "okteto/api:dev"},
+		},
+		{
+			name:          "oci without tag",
+			output:        &Output{Type: OutputTypeOCI, Dest: "image.tar"},
+			expectedType:  "oci",
+			expectedAttrs: map[string]string{},
+		},
+		{
+			name:          "cluster loads the docker tarball",
+			output:        &Output{Type: OutputTypeCluster, Cluster: "kind-dev"},
+			tag:           "okteto/api:dev",
+			expectedType:  "docker",
+			expectedAttrs: map[string]string{"name": "okteto/api:dev"},
+		},
+		{
+			name:          "tar ignores tag",
+			output:        &Output{Type: OutputTypeTar, Dest: "rootfs.tar"},
+			tag:           "okteto/api:dev",
+			expectedType:  "tar",
+			expectedAttrs: map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := tt.output.exportEntry(tt.tag)
+			assert.Equal(t, tt.expectedType, entry.Type)
+			assert.Equal(t, tt.expectedAttrs, entry.Attrs)
+			assert.NotNil(t, entry.Output)
+			assert.Empty(t, entry.OutputDir)
+		})
+	}
+}
+
+func TestOutputExportEntryWritesDest(t *testing.T) {
+	dest := filepath.Join(t.TempDir(), "out", "image.tar")
+	entry := (&Output{Type: OutputTypeOCI, Dest: dest}).exportEntry("")
+
+	w, err := entry.Output(nil)
+	require.NoError(t, err)
+	_, err = w.Write([]byte("content"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	content, err := os.ReadFile(dest)
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(content))
+}
+
+func TestOutputString(t *testing.T) {
+	assert.Equal(t, "the registry", (&Output{Type: OutputTypeRegistry}).String())
+	assert.Equal(t, "the local docker daemon", (&Output{Type: OutputTypeDocker}).String())
+	assert.Equal(t, "'image.tar'", (&Output{Type: OutputTypeDocker, Dest: "image.tar"}).String())
+	assert.Equal(t, "the build cache", (&Output{Type: OutputTypeCacheOnly}).String())
+	assert.Equal(t, "the nodes of the k3d cluster 'dev'", (&Output{Type: OutputTypeCluster, Cluster: "k3d-dev"}).String())
+}
+
+func TestOutputIsCacheOnly(t *testing.T) {
+	assert.True(t, (&Output{Type: OutputTypeCacheOnly}).IsCacheOnly())
+	assert.False(t, (&Output{Type: OutputTypeRegistry}).IsCacheOnly())
+	assert.False(t, (*Output)(nil).IsCacheOnly())
+}
+
+// startFakeDockerDaemon serves the docker API in a unix socket, calling handler on image loads
+func startFakeDockerDaemon(t *testing.T, handler http.HandlerFunc) string {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix sockets are not available")
+	}
+	socket := filepath.Join(t.TempDir(), "docker.sock")
+	l, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/images/load", handler)
+	srv := &http.Server{Handler: mux}
+	go func() {
+		_ = srv.Serve(l)
+	}()
+	t.Cleanup(func() {
+		_ = srv.Close()
+	})
+	return fmt.Sprintf("unix://%s", socket)
+}
+
+func TestDockerLoader(t *testing.T) {
+	var received []byte
+	host := startFakeDockerDaemon(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/x-tar", r.Header.Get("Content-Type"))
+		received, _ = io.ReadAll(r.Body)
+		fmt.Fprintln(w, `{"stream":"Loaded image: okteto/api:dev\n"}`)
+	})
+
+	l, err := newDockerLoader(host)
+	require.NoError(t, err)
+	_, err = l.Write([]byte("image tarball"))
+	require.NoError(t, err)
+	require.NoError(t, l.Close())
+	assert.Equal(t, "image tarball", string(received))
+}
+
+func TestDockerLoaderError(t *testing.T) {
+	host := startFakeDockerDaemon(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.ReadAll(r.Body)
+		fmt.Fprintln(w, `{"errorDetail":{"message":"unexpected EOF"},"error":"unexpected EOF"}`)
+	})
+
+	l, err := newDockerLoader(host)
+	require.NoError(t, err)
+	_, err = l.Write([]byte("image tarball"))
+	require.NoError(t, err)
+	assert.EqualError(t, l.Close(), "docker daemon failed to load the image: unexpected EOF")
+}
+
+func TestNewDockerLoaderUnsupportedHost(t *testing.T) {
+	_, err := newDockerLoader("npipe:////./pipe/docker_engine")
+	assert.Error(t, err)
+}
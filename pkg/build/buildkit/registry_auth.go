@@ -0,0 +1,255 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package buildkit
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	dockerConfig "github.com/docker/cli/cli/config"
+	"github.com/docker/cli/cli/config/configfile"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/session/auth"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/afero"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DockerConfigSecretID is the id of the build secret with the path of a docker config file.
+	// Its credentials are forwarded to the builder through the buildkit session instead of being mounted as a secret
+	DockerConfigSecretID = "dockerconfig"
+
+	dockerHubRegistry = "index.docker.io"
+)
+
+// dockerHubHosts are the hosts requested by buildkit for the images of docker hub
+var dockerHubHosts = []string{"docker.io", dockerHubRegistry, "registry-1.docker.io"}
+
+// registryAuthServer is an auth provider attachable to the buildkit session
+type registryAuthServer interface {
+	session.Attachable
+	auth.AuthServer
+}
+
+// scopedAuthProvider forwards the credentials of the wrapped auth provider only for the allowed registries.
+// Requests for any other registry get no credentials, so the builder pulls from them anonymously
+type scopedAuthProvider struct {
+	provider   auth.AuthServer
+	registries map[string]bool
+}
+
+// newScopedAuthProvider returns an auth provider that only forwards the credentials of the given registries
+func newScopedAuthProvider(provider auth.AuthServer, registries []string) *scopedAuthProvider {
+	allowed := map[string]bool{}
+	for _, r := range registries {
+		allowed[r] = true
+		if isDockerHub(r) {
+			for _, h := range dockerHubHosts {
+				allowed[h] = true
+			}
+		}
+	}
+	return &scopedAuthProvider{
+		provider:   provider,
+		registries: allowed,
+	}
+}
+
+func (ap *scopedAuthProvider) Register(server *grpc.Server) {
+	auth.RegisterAuthServer(server, ap)
+}
+
+func (ap *scopedAuthProvider) isAllowed(host string) bool {
+	return ap.registries[host]
+}
+
+func (ap *scopedAuthProvider) Credentials(ctx context.Context, req *auth.CredentialsRequest) (*auth.CredentialsResponse, error) {
+	if !ap.isAllowed(req.Host) {
+		oktetoLog.Infof("not forwarding credentials for '%s': it's not referenced by the build", req.Host)
+		return &auth.CredentialsResponse{}, nil
+	}
+	return ap.provider.Credentials(ctx, req)
+}
+
+func (ap *scopedAuthProvider) FetchToken(ctx context.Context, req *auth.FetchTokenRequest) (*auth.FetchTokenResponse, error) {
+	if !ap.isAllowed(req.Host) {
+		return nil, status.Errorf(codes.Unimplemented, "method FetchToken not implemented")
+	}
+	return ap.provider.FetchToken(ctx, req)
+}
+
+func (ap *scopedAuthProvider) GetTokenAuthority(ctx context.Context, req *auth.GetTokenAuthorityRequest) (*auth.GetTokenAuthorityResponse, error) {
+	if !ap.isAllowed(req.Host) {
+		return nil, status.Errorf(codes.Unimplemented, "method GetTokenAuthority not implemented")
+	}
+	return ap.provider.GetTokenAuthority(ctx, req)
+}
+
+func (ap *scopedAuthProvider) VerifyTokenAuthority(ctx context.Context, req *auth.VerifyTokenAuthorityRequest) (*auth.VerifyTokenAuthorityResponse, error) {
+	if !ap.isAllowed(req.Host) {
+		return nil, status.Errorf(codes.Unimplemented, "method VerifyTokenAuthority not implemented")
+	}
+	return ap.provider.VerifyTokenAuthority(ctx, req)
+}
+
+func isDockerHub(registry string) bool {
+	for _, h := range dockerHubHosts {
+		if registry == h {
+			return true
+		}
+	}
+	return false
+}
+
+// getImageRegistry returns the registry of an image, or an empty string if it's not a valid reference
+func getImageRegistry(image string) string {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return ""
+	}
+	return ref.Context().RegistryStr()
+}
+
+// getDockerfileRegistries returns the registries of the images referenced by the FROM and COPY --from
+// instructions of a Dockerfile. Build args referenced by the images are expanded
+func getDockerfileRegistries(fs afero.Fs, dockerfile string, buildArgs []string) ([]string, error) {
+	content, err := afero.ReadFile(fs, dockerfile)
+	if err != nil {
+		return nil, err
+	}
+	result, err := parser.Parse(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	stages, metaArgs, err := instructions.Parse(result.AST, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	args := map[string]string{}
+	for _, cmd := range metaArgs {
+		for _, arg := range cmd.Args {
+			if arg.Value != nil {
+				args[arg.Key] = *arg.Value
+			}
+		}
+	}
+	for _, arg := range buildArgs {
+		if k, v, found := strings.Cut(arg, "="); found {
+			args[k] = v
+		}
+	}
+	expand := func(image string) string {
+		return os.Expand(image, func(k string) string {
+			return args[k]
+		})
+	}
+
+	stageNames := map[string]bool{}
+	images := []string{}
+	for _, stage := range stages {
+		base := expand(stage.BaseName)
+		if !stageNames[strings.ToLower(base)] {
+			images = append(images, base)
+		}
+		for _, cmd := range stage.Commands {
+			if c, ok := cmd.(*instructions.CopyCommand); ok && c.From != "" {
+				from := expand(c.From)
+				if !stageNames[strings.ToLower(from)] {
+					images = append(images, from)
+				}
+			}
+		}
+		if stage.Name != "" {
+			stageNames[strings.ToLower(stage.Name)] = true
+		}
+	}
+
+	registries := []string{}
+	seen := map[string]bool{}
+	for _, image := range images {
+		if image == "" || image == "scratch" {
+			continue
+		}
+		registry := getImageRegistry(image)
+		if registry == "" || seen[registry] {
+			continue
+		}
+		seen[registry] = true
+		registries = append(registries, registry)
+	}
+	sort.Strings(registries)
+	return registries, nil
+}
+
+// extractDockerConfigSecret removes the docker config secret from the build secrets and returns the path
+// of its docker config file, or an empty string if it's not defined
+func extractDockerConfigSecret(secrets []string) ([]string, string, error) {
+	result := []string{}
+	path := ""
+	for _, s := range secrets {
+		fields, err := csv.NewReader(strings.NewReader(s)).Read()
+		if err != nil {
+			return nil, "", fmt.Errorf("error reading the csv secret, %w", err)
+		}
+		id := ""
+		src := ""
+		for _, field := range fields {
+			key, value, _ := strings.Cut(field, "=")
+			switch key {
+			case "id":
+				id = value
+			case "src", "source":
+				src = value
+			}
+		}
+		if id != DockerConfigSecretID {
+			result = append(result, s)
+			continue
+		}
+		if src == "" {
+			return nil, "", fmt.Errorf("the secret '%s' must be the path of a docker config file", DockerConfigSecretID)
+		}
+		path = src
+	}
+	return result, path, nil
+}
+
+// loadDockerConfig loads the docker config file at path, or the default docker config file if path is empty
+func loadDockerConfig(fs afero.Fs, path string, stderr io.Writer) (*configfile.ConfigFile, error) {
+	if path == "" {
+		return dockerConfig.LoadDefaultConfigFile(stderr), nil
+	}
+	content, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the docker config of the secret '%s': %w", DockerConfigSecretID, err)
+	}
+	cfg := configfile.New(path)
+	if err := cfg.LoadFromReader(bytes.NewReader(content)); err != nil {
+		return nil, fmt.Errorf("failed to parse the docker config of the secret '%s': %w", DockerConfigSecretID, err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,219 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package buildkit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moby/buildkit/session/auth"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeAuthServer struct {
+	auth.UnimplementedAuthServer
+	requested []string
+}
+
+func (*fakeAuthServer) Register(*grpc.Server) {}
+
+func (f *fakeAuthServer) Credentials(_ context.Context, req *auth.CredentialsRequest) (*auth.CredentialsResponse, error) {
+	f.requested = append(f.requested, req.Host)
+	return &auth.CredentialsResponse{Username: "user", Secret: "secret"}, nil
+}
+
+func (f *fakeAuthServer) FetchToken(_ context.Context, req *auth.FetchTokenRequest) (*auth.FetchTokenResponse, error) {
+	f.requested = append(f.requested, req.Host)
+	return &auth.FetchTokenResponse{Token: "token"}, nil
+}
+
+func TestScopedAuthProvider(t *testing.T) {
+	fake := &fakeAuthServer{}
+	ap := newScopedAuthProvider(fake, []string{"registry.mycorp.com", dockerHubRegistry})
+	ctx := context.Background()
+
+	tests := []struct {
+		host     string
+		expected *auth.CredentialsResponse
+	}{
+		{
+			host:     "registry.mycorp.com",
+			expected: &auth.CredentialsResponse{Username: "user", Secret: "secret"},
+		},
+		{
+			host:     "registry-1.docker.io",
+			expected: &auth.CredentialsResponse{Username: "user", Secret: "secret"},
+		},
+		{
+			host:     "ghcr.io",
+			expected: &auth.CredentialsResponse{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			res, err := ap.Credentials(ctx, &auth.CredentialsRequest{Host: tt.host})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+
+	token, err := ap.FetchToken(ctx, &auth.FetchTokenRequest{Host: "registry.mycorp.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "token", token.Token)
+
+	_, err = ap.FetchToken(ctx, &auth.FetchTokenRequest{Host: "ghcr.io"})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	// the wrapped provider is never asked for the credentials of registries out of the scope
+	assert.Equal(t, []string{"registry.mycorp.com", "registry-1.docker.io", "registry.mycorp.com"}, fake.requested)
+}
+
+func TestScopeAuthProvider(t *testing.T) {
+	tests := []struct {
+		name              string
+		dockerfile        string
+		isLocalDockerfile bool
+		expected          []string
+	}{
+		{
+			name:              "local dockerfile",
+			dockerfile:        "FROM registry.mycorp.com/base:latest\n",
+			isLocalDockerfile: true,
+			expected:          []string{"registry.mycorp.com", "quay.io", "ghcr.io", "cache.mycorp.com"},
+		},
+		{
+			name:              "dockerfile with parse errors",
+			dockerfile:        "RUN make\n",
+			isLocalDockerfile: true,
+			expected:          []string{"quay.io", "ghcr.io", "cache.mycorp.com"},
+		},
+		{
+			name:              "remote dockerfile",
+			dockerfile:        "FROM registry.mycorp.com/base:latest\n",
+			isLocalDockerfile: false,
+			expected:          []string{"quay.io", "ghcr.io", "cache.mycorp.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fs, "Dockerfile", []byte(tt.dockerfile), 0600))
+			b := &SolveOptBuilder{
+				fs:    fs,
+				okCtx: &fakeOktetoContext{},
+			}
+			buildOptions := &types.BuildOptions{
+				File:        "Dockerfile",
+				Tag:         "quay.io/okteto/app:1.0",
+				CacheFrom:   []string{"ghcr.io/okteto/app:cache"},
+				ExportCache: []string{"cache.mycorp.com/okteto/app:cache"},
+			}
+			ap := b.scopeAuthProvider(&fakeAuthServer{}, buildOptions, tt.isLocalDockerfile, "")
+
+			// the credentials of the registries out of the build are never forwarded
+			allowed := []string{}
+			for _, registry := range []string{"registry.mycorp.com", "quay.io", "ghcr.io", "cache.mycorp.com", "private.mycorp.com"} {
+				if ap.isAllowed(registry) {
+					allowed = append(allowed, registry)
+				}
+			}
+			assert.ElementsMatch(t, tt.expected, allowed)
+		})
+	}
+}
+
+func TestGetDockerfileRegistries(t *testing.T) {
+	tests := []struct {
+		name       string
+		dockerfile string
+		buildArgs  []string
+		expected   []string
+	}{
+		{
+			name: "multi-stage",
+			dockerfile: `FROM registry.mycorp.com/base:latest AS builder
+RUN make
+
+FROM builder AS test
+RUN make test
+
+FROM alpine
+COPY --from=builder /app /app
+COPY --from=ghcr.io/okteto/tools:1.0 /bin/tool /bin/tool
+`,
+			expected: []string{"ghcr.io", dockerHubRegistry, "registry.mycorp.com"},
+		},
+		{
+			name: "build args",
+			dockerfile: `ARG REGISTRY=registry.mycorp.com
+ARG BASE
+FROM ${REGISTRY}/base:latest
+FROM $BASE
+`,
+			buildArgs: []string{"BASE=quay.io/okteto/base:1.0"},
+			expected:  []string{"quay.io", "registry.mycorp.com"},
+		},
+		{
+			name:       "scratch",
+			dockerfile: "FROM scratch\nCOPY app /app\n",
+			expected:   []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fs, "Dockerfile", []byte(tt.dockerfile), 0600))
+			registries, err := getDockerfileRegistries(fs, "Dockerfile", tt.buildArgs)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, registries)
+		})
+	}
+}
+
+func TestExtractDockerConfigSecret(t *testing.T) {
+	secrets, path, err := extractDockerConfigSecret([]string{"id=npmrc,src=/tmp/npmrc", "id=dockerconfig,src=/tmp/config.json"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id=npmrc,src=/tmp/npmrc"}, secrets)
+	assert.Equal(t, "/tmp/config.json", path)
+
+	secrets, path, err = extractDockerConfigSecret([]string{"id=npmrc,src=/tmp/npmrc"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id=npmrc,src=/tmp/npmrc"}, secrets)
+	assert.Empty(t, path)
+
+	_, _, err = extractDockerConfigSecret([]string{"id=dockerconfig,env=DOCKER_CONFIG"})
+	assert.Error(t, err)
+}
+
+func TestLoadDockerConfig(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	content := `{"auths": {"registry.mycorp.com": {"auth": "dXNlcjpwYXNzd29yZA=="}}}`
+	require.NoError(t, afero.WriteFile(fs, "/tmp/config.json", []byte(content), 0600))
+
+	cfg, err := loadDockerConfig(fs, "/tmp/config.json", nil)
+	require.NoError(t, err)
+	ac, err := cfg.GetAuthConfig("registry.mycorp.com")
+	require.NoError(t, err)
+	assert.Equal(t, "user", ac.Username)
+	assert.Equal(t, "password", ac.Password)
+
+	_, err = loadDockerConfig(fs, "/tmp/missing.json", nil)
+	assert.Error(t, err)
+}
@@ -57,6 +57,19 @@ func (b *ManifestBuild) Validate() error {
 	return nil
 }
 
+// MergeArgs adds the given args to every build entry. Args defined by a build entry take precedence
+func (b ManifestBuild) MergeArgs(args Args) {
+	if len(args) == 0 {
+		return
+	}
+	for _, info := range b {
+		if info == nil {
+			continue
+		}
+		info.Args = info.Args.Merge(args)
+	}
+}
+
 // GetSvcsToBuildFromList returns the builds from a list and all its dependencies
 func (b *ManifestBuild) GetSvcsToBuildFromList(toBuild []string) []string {
 	initialSvcsToBuild := toBuild
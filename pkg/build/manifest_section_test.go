@@ -183,3 +183,29 @@ func TestIsEmpty(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeArgs(t *testing.T) {
+	b := ManifestBuild{
+		"api": &Info{
+			Args: Args{
+				{Name: "COMMIT_SHA", Value: "api"},
+			},
+		},
+		"frontend": &Info{},
+		"invalid":  nil,
+	}
+	b.MergeArgs(Args{
+		{Name: "COMMIT_SHA", Value: "global"},
+		{Name: "ENV", Value: "dev"},
+	})
+
+	require.Equal(t, Args{
+		{Name: "COMMIT_SHA", Value: "api"},
+		{Name: "ENV", Value: "dev"},
+	}, b["api"].Args)
+	require.Equal(t, Args{
+		{Name: "COMMIT_SHA", Value: "global"},
+		{Name: "ENV", Value: "dev"},
+	}, b["frontend"].Args)
+	require.Nil(t, b["invalid"])
+}
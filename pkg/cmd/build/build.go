@@ -95,10 +95,15 @@ type OktetoRegistryInterface interface {
 // GetBuildkitConnector creates and returns a buildkit connector based on the execution environment.
 //
 // Selection priority:
+//  0. ExternalConnector - when the builder is overridden with the --builder flag or OKTETO_BUILDER
 //  1. InClusterConnector - when running inside Okteto (remote commands, installer, or managed pods)
 //  2. PortForwarder - when build queue is enabled (OKTETO_BUILD_QUEUE_ENABLED=true)
 //  3. IngressConnector - default for local CLI execution
 func GetBuildkitConnector(okCtx OktetoContextInterface, logger *io.Controller, tracker connector.BuildkitConnectionTracker) BuildkitConnector {
+	if builder := getBuilderOverride(okCtx.GetCurrentBuilder()); builder != "" {
+		return connector.NewExternalConnector(builder, logger)
+	}
+
 	if shouldUseInClusterConnector() {
 		return newInClusterConnectorWithFallback(okCtx, logger, tracker)
 	}
@@ -154,6 +159,9 @@ func NewOktetoBuilder(okCtx OktetoContextInterface, fs afero.Fs, logger *io.Cont
 }
 
 func (ob *OktetoBuilder) GetBuilder() string {
+	if builder := getBuilderOverride(ob.OktetoContext.GetCurrentBuilder()); builder != "" {
+		return builder
+	}
 	return ob.OktetoContext.GetCurrentBuilder()
 }
 
@@ -165,6 +173,10 @@ func (ob *OktetoBuilder) Run(ctx context.Context, buildOptions *types.BuildOptio
 }
 
 func setOutputMode(outputMode string) string {
+	if oktetoLog.GetOutputFormat() == oktetoLog.JSONFormat && (outputMode == "" || outputMode == oktetoLog.TTYFormat || outputMode == oktetoLog.PlainFormat) {
+		// the build progress is emitted as json lines, like the rest of the output of the command
+		return oktetoLog.JSONFormat
+	}
 	if outputMode != "" {
 		return outputMode
 	}
@@ -206,6 +218,10 @@ func (ob *OktetoBuilder) buildWithOkteto(ctx context.Context, buildOptions *type
 		repoURL = buildOptions.Manifest.ManifestPath
 	}
 
+	if err := validateBuildContext(ob.Fs, buildOptions); err != nil {
+		return err
+	}
+
 	var err error
 	if buildOptions.File != "" {
 		// Preserve the original user-facing path before translation
@@ -276,6 +292,23 @@ type regInterface interface {
 	GetRepoNameAndTag(repo string) (string, string)
 }
 
+// MergeBuildArgs adds the build args of the '--build-arg' flag to every build entry of the manifest.
+// Args defined by a build entry take precedence over the flag values
+func MergeBuildArgs(manifest *model.Manifest, buildArgs []string) error {
+	if manifest == nil || len(buildArgs) == 0 {
+		return nil
+	}
+	args, err := build.ParseArgs(buildArgs)
+	if err != nil {
+		return oktetoErrors.UserError{
+			E:    err,
+			Hint: "Use the form '--build-arg KEY=VALUE'",
+		}
+	}
+	manifest.Build.MergeArgs(args)
+	return nil
+}
+
 // OptsFromBuildInfo returns the parsed options for the build from the manifest
 func OptsFromBuildInfo(manifest *model.Manifest, svcName string, b *build.Info, o *types.BuildOptions, reg regInterface, okCtx OktetoContextInterface) *types.BuildOptions {
 	if o == nil {
@@ -372,6 +405,7 @@ func OptsFromBuildInfo(manifest *model.Manifest, svcName string, b *build.Info,
 		NoCache:            o.NoCache,
 		ExportCache:        b.ExportCache,
 		Platform:           o.Platform,
+		Output:             o.Output,
 	}
 
 	// if secrets are present at the cmd flag, copy them to opts.Secrets
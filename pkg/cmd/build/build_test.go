@@ -552,6 +552,29 @@ func Test_OptsFromBuildInfo(t *testing.T) {
 	}
 }
 
+func TestMergeBuildArgs(t *testing.T) {
+	t.Setenv("GIT_SHA", "abc123")
+	manifest := &model.Manifest{
+		Build: build.ManifestBuild{
+			"api": &build.Info{
+				Args: build.Args{
+					{Name: "COMMIT_SHA", Value: "pinned"},
+				},
+			},
+			"frontend": &build.Info{},
+		},
+	}
+
+	require.NoError(t, MergeBuildArgs(manifest, []string{"COMMIT_SHA=${GIT_SHA}"}))
+	require.Equal(t, build.Args{{Name: "COMMIT_SHA", Value: "pinned"}}, manifest.Build["api"].Args)
+	require.Equal(t, build.Args{{Name: "COMMIT_SHA", Value: "abc123"}}, manifest.Build["frontend"].Args)
+
+	err := MergeBuildArgs(manifest, []string{"=abc123"})
+	require.ErrorAs(t, err, &oktetoErrors.UserError{})
+
+	require.NoError(t, MergeBuildArgs(nil, []string{"COMMIT_SHA=abc123"}))
+}
+
 func TestOptsFromBuildInfoForRemoteDeploy(t *testing.T) {
 	tests := []struct {
 		buildInfo *build.Info
@@ -869,6 +892,18 @@ func Test_setOutputMode(t *testing.T) {
 	}
 }
 
+func Test_setOutputModeJSON(t *testing.T) {
+	oktetoLog.SetOutputFormat(oktetoLog.JSONFormat)
+	t.Cleanup(func() {
+		oktetoLog.SetOutputFormat(oktetoLog.TTYFormat)
+	})
+
+	assert.Equal(t, oktetoLog.JSONFormat, setOutputMode(""))
+	assert.Equal(t, oktetoLog.JSONFormat, setOutputMode(oktetoLog.TTYFormat))
+	assert.Equal(t, oktetoLog.JSONFormat, setOutputMode(oktetoLog.PlainFormat))
+	assert.Equal(t, DeployOutputModeOnBuild, setOutputMode(DeployOutputModeOnBuild))
+}
+
 func Test_shouldUseInClusterConnector(t *testing.T) {
 	tests := []struct {
 		name                 string
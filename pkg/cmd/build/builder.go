@@ -0,0 +1,174 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/moby/buildkit/client"
+	// registers the docker-container:// scheme used by docker:// builders
+	_ "github.com/moby/buildkit/client/connhelper/dockercontainer"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// OktetoBuilderEnvVar overrides the builder of the okteto context for the current invocation
+	OktetoBuilderEnvVar = "OKTETO_BUILDER"
+
+	// localDockerBuilderContainer is the container running buildkit for the docker://local builder
+	localDockerBuilderContainer = "buildkitd"
+
+	builderProbeTimeout = 5 * time.Second
+
+	builderFlagHint = "Use 'tcp://<host>:<port>', 'unix://<socket>', 'docker://local' or 'docker://<container>' as builder"
+)
+
+// BuilderProbe checks that a builder is reachable
+type BuilderProbe func(ctx context.Context, address string) error
+
+// ResolveBuilder returns the address of the builder used by the current invocation and if it is an external builder.
+// The --builder flag has priority over the OKTETO_BUILDER env var, and both have priority over the builder of the okteto context.
+// External builders are reached without the okteto credentials of the context
+func ResolveBuilder(flagBuilder, envBuilder, contextBuilder string) (string, bool, error) {
+	builder := flagBuilder
+	if builder == "" {
+		builder = envBuilder
+	}
+	if builder == "" || builder == contextBuilder {
+		return contextBuilder, false, nil
+	}
+
+	address, err := normalizeBuilderAddress(builder)
+	if err != nil {
+		return "", false, err
+	}
+	return address, true, nil
+}
+
+func normalizeBuilderAddress(builder string) (string, error) {
+	u, err := url.Parse(builder)
+	if err != nil || u.Host == "" && u.Path == "" {
+		return "", oktetoErrors.UserError{
+			E:    fmt.Errorf("invalid builder '%s'", builder),
+			Hint: builderFlagHint,
+		}
+	}
+
+	switch u.Scheme {
+	case "tcp":
+		if u.Port() == "" {
+			return "", oktetoErrors.UserError{
+				E:    fmt.Errorf("invalid builder '%s': the port is required", builder),
+				Hint: builderFlagHint,
+			}
+		}
+		return builder, nil
+	case "unix", "docker-container":
+		return builder, nil
+	case "docker":
+		container := u.Host
+		if container == "local" {
+			container = localDockerBuilderContainer
+		}
+		return fmt.Sprintf("docker-container://%s", container), nil
+	default:
+		return "", oktetoErrors.UserError{
+			E:    fmt.Errorf("unsupported builder scheme '%s' in '%s'", u.Scheme, builder),
+			Hint: builderFlagHint,
+		}
+	}
+}
+
+// ProbeBuilder checks that the builder accepts connections before the build context is streamed to it
+func ProbeBuilder(ctx context.Context, address string) error {
+	ctx, cancel := context.WithTimeout(ctx, builderProbeTimeout)
+	defer cancel()
+
+	u, err := url.Parse(address)
+	if err != nil {
+		return err
+	}
+	switch u.Scheme {
+	case "tcp", "unix":
+		network, target := u.Scheme, u.Host
+		if u.Scheme == "unix" {
+			target = u.Path
+		}
+		conn, err := (&net.Dialer{}).DialContext(ctx, network, target)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	default:
+		c, err := client.New(ctx, address)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+		_, err = c.ListWorkers(ctx)
+		return err
+	}
+}
+
+// SelectBuilder resolves the builder of the current invocation, checks that external builders are reachable
+// and exports the selection through OKTETO_BUILDER so every build of the command uses it
+func SelectBuilder(ctx context.Context, flagBuilder, contextBuilder string, probe BuilderProbe) error {
+	address, external, err := ResolveBuilder(flagBuilder, os.Getenv(OktetoBuilderEnvVar), contextBuilder)
+	if err != nil {
+		return err
+	}
+	if !external {
+		if flagBuilder != "" {
+			// the flag has priority over a builder exported by a parent command
+			if err := os.Unsetenv(OktetoBuilderEnvVar); err != nil {
+				return err
+			}
+			oktetoLog.Information("Using builder '%s' of your Okteto Context", contextBuilder)
+		}
+		return nil
+	}
+
+	if err := probe(ctx, address); err != nil {
+		oktetoLog.Infof("builder probe failed: %s", err)
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("could not connect to builder '%s'", address),
+			Hint: "Check that the builder is running and reachable from your machine, or remove the '--builder' flag to use the builder of your Okteto Context",
+		}
+	}
+
+	if err := os.Setenv(OktetoBuilderEnvVar, address); err != nil {
+		return err
+	}
+	oktetoLog.Information("Using external builder '%s'", address)
+	return nil
+}
+
+// getBuilderOverride returns the external builder set through OKTETO_BUILDER, if any
+func getBuilderOverride(contextBuilder string) string {
+	address, external, err := ResolveBuilder("", os.Getenv(OktetoBuilderEnvVar), contextBuilder)
+	if err != nil {
+		oktetoLog.Infof("ignoring %s: %s", OktetoBuilderEnvVar, err)
+		return ""
+	}
+	if !external {
+		return ""
+	}
+	return address
+}
@@ -0,0 +1,171 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/log/io"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveBuilder(t *testing.T) {
+	contextBuilder := "https://buildkit.okteto.example.com:443"
+	tests := []struct {
+		name             string
+		flagBuilder      string
+		envBuilder       string
+		expectedBuilder  string
+		expectedExternal bool
+		expectedErr      bool
+	}{
+		{
+			name:            "context builder by default",
+			expectedBuilder: contextBuilder,
+		},
+		{
+			name:             "env builder over context builder",
+			envBuilder:       "tcp://localhost:1234",
+			expectedBuilder:  "tcp://localhost:1234",
+			expectedExternal: true,
+		},
+		{
+			name:             "flag builder over env builder",
+			flagBuilder:      "unix:///run/buildkit/buildkitd.sock",
+			envBuilder:       "tcp://localhost:1234",
+			expectedBuilder:  "unix:///run/buildkit/buildkitd.sock",
+			expectedExternal: true,
+		},
+		{
+			name:            "flag with the context builder",
+			flagBuilder:     contextBuilder,
+			envBuilder:      "tcp://localhost:1234",
+			expectedBuilder: contextBuilder,
+		},
+		{
+			name:             "local docker builder",
+			flagBuilder:      "docker://local",
+			expectedBuilder:  "docker-container://buildkitd",
+			expectedExternal: true,
+		},
+		{
+			name:             "docker container builder",
+			flagBuilder:      "docker://my-buildkit",
+			expectedBuilder:  "docker-container://my-buildkit",
+			expectedExternal: true,
+		},
+		{
+			name:        "tcp builder without port",
+			flagBuilder: "tcp://localhost",
+			expectedErr: true,
+		},
+		{
+			name:        "unsupported scheme",
+			flagBuilder: "ftp://localhost:21",
+			expectedErr: true,
+		},
+		{
+			name:        "builder without scheme",
+			flagBuilder: "localhost:1234",
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder, external, err := ResolveBuilder(tt.flagBuilder, tt.envBuilder, contextBuilder)
+			if tt.expectedErr {
+				var uErr oktetoErrors.UserError
+				require.ErrorAs(t, err, &uErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedBuilder, builder)
+			assert.Equal(t, tt.expectedExternal, external)
+		})
+	}
+}
+
+func TestProbeBuilder(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := fmt.Sprintf("tcp://%s", l.Addr().String())
+
+	require.NoError(t, ProbeBuilder(context.Background(), address))
+
+	require.NoError(t, l.Close())
+	require.Error(t, ProbeBuilder(context.Background(), address))
+}
+
+func TestSelectBuilder(t *testing.T) {
+	contextBuilder := "https://buildkit.okteto.example.com:443"
+
+	t.Run("external builder is probed and exported", func(t *testing.T) {
+		t.Setenv(OktetoBuilderEnvVar, "")
+		probed := ""
+		probe := func(_ context.Context, address string) error {
+			probed = address
+			return nil
+		}
+		require.NoError(t, SelectBuilder(context.Background(), "docker://local", contextBuilder, probe))
+		assert.Equal(t, "docker-container://buildkitd", probed)
+		assert.Equal(t, "docker-container://buildkitd", os.Getenv(OktetoBuilderEnvVar))
+	})
+
+	t.Run("unreachable builder", func(t *testing.T) {
+		t.Setenv(OktetoBuilderEnvVar, "")
+		probe := func(context.Context, string) error {
+			return assert.AnError
+		}
+		err := SelectBuilder(context.Background(), "tcp://localhost:1234", contextBuilder, probe)
+		var uErr oktetoErrors.UserError
+		require.ErrorAs(t, err, &uErr)
+		assert.Empty(t, os.Getenv(OktetoBuilderEnvVar))
+	})
+
+	t.Run("context builder is not probed", func(t *testing.T) {
+		t.Setenv(OktetoBuilderEnvVar, "tcp://localhost:1234")
+		probe := func(context.Context, string) error {
+			t.Fatal("the context builder must not be probed")
+			return nil
+		}
+		require.NoError(t, SelectBuilder(context.Background(), contextBuilder, contextBuilder, probe))
+		assert.Empty(t, os.Getenv(OktetoBuilderEnvVar))
+	})
+}
+
+func TestGetBuildkitConnectorWithBuilderOverride(t *testing.T) {
+	t.Setenv(OktetoBuilderEnvVar, "tcp://localhost:1234")
+	okCtx := &fakeBuilderContext{builder: "https://buildkit.okteto.example.com:443"}
+
+	conn := GetBuildkitConnector(okCtx, io.NewIOController(), nil)
+	assert.Equal(t, "external", conn.GetType())
+
+	ob := NewOktetoBuilder(okCtx, nil, io.NewIOController(), conn)
+	assert.Equal(t, "tcp://localhost:1234", ob.GetBuilder())
+}
+
+type fakeBuilderContext struct {
+	OktetoContextInterface
+	builder string
+}
+
+func (f *fakeBuilderContext) GetCurrentBuilder() string {
+	return f.builder
+}
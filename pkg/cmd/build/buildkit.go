@@ -44,6 +44,7 @@ func SolveBuild(ctx context.Context, c *client.Client, opt *client.SolveOpt, pro
 	}
 	logFilter := NewBuildKitLogsFilter(logFilterRules, errorRules)
 	contextTracker := newContextSyncTracker()
+	cacheTracker := newCacheStatsTracker()
 	ch := make(chan *client.SolveStatus)
 	ttyChannel := make(chan *client.SolveStatus)
 	plainChannel := make(chan *client.SolveStatus)
@@ -66,9 +67,11 @@ func SolveBuild(ctx context.Context, c *client.Client, opt *client.SolveOpt, pro
 				if ok {
 					logFilter.Run(ss, progress)
 					contextTracker.Update(ss)
+					cacheTracker.Update(ss)
 					if metadata != nil {
 						metadata.BuildContextSize = contextTracker.SyncedSize()
 						metadata.ContextTransferDuration = contextTracker.Duration()
+						metadata.CacheSteps = cacheTracker.Steps()
 					}
 					plainChannel <- ss
 					if progress == oktetoLog.TTYFormat {
@@ -132,6 +135,9 @@ func SolveBuild(ctx context.Context, c *client.Client, opt *client.SolveOpt, pro
 				oktetoLog.Infof("could not display build status: %s", err)
 			}
 			return err
+		case oktetoLog.JSONFormat:
+			displayJSONProgress(plainChannel)
+			return nil
 		case DeployOutputModeOnBuild, DestroyOutputModeOnBuild, TestOutputModeOnBuild:
 			err := deployDisplayer(context.TODO(), plainChannel, &types.BuildOptions{OutputMode: progress})
 			commandFailChannel <- err
@@ -0,0 +1,61 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"regexp"
+
+	"github.com/moby/buildkit/client"
+	"github.com/okteto/okteto/pkg/build/buildkit"
+)
+
+// dockerfileStepRegex matches the vertexes of the Dockerfile instructions, like '[2/5] RUN make' or '[builder 1/3] FROM golang'
+var dockerfileStepRegex = regexp.MustCompile(`^\[(\S+ )?\d+/\d+\] `)
+
+// cacheStatsTracker tracks which steps of the Dockerfile are resolved from the build cache
+type cacheStatsTracker struct {
+	steps map[string]*buildkit.CacheStep
+	order []string
+}
+
+func newCacheStatsTracker() *cacheStatsTracker {
+	return &cacheStatsTracker{
+		steps: map[string]*buildkit.CacheStep{},
+	}
+}
+
+func (t *cacheStatsTracker) Update(ss *client.SolveStatus) {
+	for _, v := range ss.Vertexes {
+		if !dockerfileStepRegex.MatchString(v.Name) {
+			continue
+		}
+		digest := v.Digest.String()
+		step, ok := t.steps[digest]
+		if !ok {
+			step = &buildkit.CacheStep{Name: v.Name}
+			t.steps[digest] = step
+			t.order = append(t.order, digest)
+		}
+		step.Cached = step.Cached || v.Cached
+	}
+}
+
+// Steps returns the steps of the build in the order buildkit reported them
+func (t *cacheStatsTracker) Steps() []buildkit.CacheStep {
+	result := make([]buildkit.CacheStep, 0, len(t.order))
+	for _, digest := range t.order {
+		result = append(result, *t.steps[digest])
+	}
+	return result
+}
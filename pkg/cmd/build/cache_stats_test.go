@@ -0,0 +1,74 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"testing"
+
+	"github.com/moby/buildkit/client"
+	"github.com/okteto/okteto/pkg/build/buildkit"
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheStatsTracker(t *testing.T) {
+	tracker := newCacheStatsTracker()
+	from := digest.FromString("from")
+	copyStep := digest.FromString("copy")
+	run := digest.FromString("run")
+
+	// buildkit reports the vertexes several times while they progress
+	tracker.Update(&client.SolveStatus{
+		Vertexes: []*client.Vertex{
+			{Digest: digest.FromString("definition"), Name: "[internal] load build definition from Dockerfile", Cached: true},
+			{Digest: digest.FromString("auth"), Name: "[auth] library/golang:pull token for registry-1.docker.io"},
+			{Digest: from, Name: "[builder 1/3] FROM docker.io/library/golang:1.22"},
+		},
+	})
+	tracker.Update(&client.SolveStatus{
+		Vertexes: []*client.Vertex{
+			{Digest: from, Name: "[builder 1/3] FROM docker.io/library/golang:1.22", Cached: true},
+			{Digest: copyStep, Name: "[builder 2/3] COPY . .", Cached: true},
+			{Digest: run, Name: "[builder 3/3] RUN go build"},
+		},
+	})
+	tracker.Update(&client.SolveStatus{
+		Vertexes: []*client.Vertex{
+			{Digest: run, Name: "[builder 3/3] RUN go build"},
+			{Digest: digest.FromString("export"), Name: "exporting cache to registry"},
+		},
+	})
+
+	expected := []buildkit.CacheStep{
+		{Name: "[builder 1/3] FROM docker.io/library/golang:1.22", Cached: true},
+		{Name: "[builder 2/3] COPY . .", Cached: true},
+		{Name: "[builder 3/3] RUN go build"},
+	}
+	assert.Equal(t, expected, tracker.Steps())
+
+	metadata := &buildkit.BuildMetadata{CacheSteps: tracker.Steps()}
+	cached, total := metadata.CacheHits()
+	assert.Equal(t, 2, cached)
+	assert.Equal(t, 3, total)
+}
+
+func TestCacheStatsTrackerWithoutSteps(t *testing.T) {
+	tracker := newCacheStatsTracker()
+	tracker.Update(&client.SolveStatus{
+		Vertexes: []*client.Vertex{
+			{Digest: digest.FromString("context"), Name: "[internal] load build context"},
+		},
+	})
+	assert.Empty(t, tracker.Steps())
+}
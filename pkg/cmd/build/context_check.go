@@ -0,0 +1,336 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/instructions"
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+	"github.com/moby/patternmatcher"
+	"github.com/moby/patternmatcher/ignorefile"
+	"github.com/okteto/okteto/pkg/env"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
+)
+
+const (
+	// OktetoSkipBuildContextCheckEnvVar disables the check of the Dockerfile sources against the build context
+	OktetoSkipBuildContextCheckEnvVar = "OKTETO_SKIP_BUILD_CONTEXT_CHECK"
+
+	missingSourceReason  = "doesn't exist"
+	excludedSourceReason = "is excluded by '%s'"
+)
+
+// contextSource is a COPY/ADD source that can't be sent to buildkit
+type contextSource struct {
+	instruction string
+	line        int
+	source      string
+	reason      string
+}
+
+// contextChecker checks the COPY/ADD sources of a Dockerfile against the files of the build context
+type contextChecker struct {
+	fs          afero.Fs
+	contextPath string
+	ignoreFile  string
+	matcher     *patternmatcher.PatternMatcher
+}
+
+// checkBuildContext returns an error listing the COPY/ADD sources of the Dockerfile that don't exist
+// in the build context or are excluded by the effective .dockerignore file.
+// Errors parsing the Dockerfile are ignored: buildkit reports them with a better message
+func checkBuildContext(fs afero.Fs, dockerfile, contextPath, target string) error {
+	content, err := afero.ReadFile(fs, dockerfile)
+	if err != nil {
+		oktetoLog.Infof("could not read dockerfile '%s' to check the build context: %s", dockerfile, err)
+		return nil
+	}
+	result, err := parser.Parse(bytes.NewReader(content))
+	if err != nil {
+		oktetoLog.Infof("could not parse dockerfile '%s' to check the build context: %s", dockerfile, err)
+		return nil
+	}
+	stages, _, err := instructions.Parse(result.AST, nil)
+	if err != nil {
+		oktetoLog.Infof("could not parse dockerfile '%s' to check the build context: %s", dockerfile, err)
+		return nil
+	}
+
+	checker, err := newContextChecker(fs, dockerfile, contextPath)
+	if err != nil {
+		oktetoLog.Infof("could not read the ignore rules of the build context '%s': %s", contextPath, err)
+		return nil
+	}
+
+	invalid := []contextSource{}
+	for _, stage := range getBuildStages(stages, target) {
+		for _, cmd := range stage.Commands {
+			var sources []string
+			switch c := cmd.(type) {
+			case *instructions.CopyCommand:
+				if c.From != "" {
+					continue
+				}
+				sources = c.SourcePaths
+			case *instructions.AddCommand:
+				sources = c.SourcePaths
+			default:
+				continue
+			}
+			line := 0
+			if loc := cmd.Location(); len(loc) > 0 {
+				line = loc[0].Start.Line
+			}
+			for _, src := range sources {
+				reason, err := checker.check(src)
+				if err != nil {
+					return err
+				}
+				if reason != "" {
+					invalid = append(invalid, contextSource{instruction: strings.ToUpper(cmd.Name()), line: line, source: src, reason: reason})
+				}
+			}
+		}
+	}
+
+	if len(invalid) == 0 {
+		return nil
+	}
+	lines := []string{}
+	for _, s := range invalid {
+		lines = append(lines, fmt.Sprintf("    - line %d: %s '%s' %s", s.line, s.instruction, s.source, s.reason))
+	}
+	return oktetoErrors.UserError{
+		E: fmt.Errorf("the Dockerfile '%s' references files that are not part of the build context '%s':\n%s", dockerfile, contextPath, strings.Join(lines, "\n")),
+		Hint: fmt.Sprintf(`Check that the files exist in the build context and are not excluded by your ignore rules.
+    Set the environment variable '%s=true' to skip this check`, OktetoSkipBuildContextCheckEnvVar),
+	}
+}
+
+// getBuildStages returns the stages needed to build the target stage, or the last stage if target is empty
+func getBuildStages(stages []instructions.Stage, target string) []instructions.Stage {
+	if len(stages) == 0 {
+		return nil
+	}
+	targetIdx := len(stages) - 1
+	if target != "" {
+		targetIdx = -1
+		for i, s := range stages {
+			if strings.EqualFold(s.Name, target) {
+				targetIdx = i
+				break
+			}
+		}
+		if targetIdx == -1 {
+			// buildkit reports the unknown target
+			return nil
+		}
+	}
+
+	stageIdx := func(name string, before int) int {
+		if i, err := strconv.Atoi(name); err == nil && i >= 0 && i < before {
+			return i
+		}
+		for i := 0; i < before; i++ {
+			if stages[i].Name != "" && strings.EqualFold(stages[i].Name, name) {
+				return i
+			}
+		}
+		return -1
+	}
+
+	needed := map[int]bool{}
+	var visit func(i int)
+	visit = func(i int) {
+		if needed[i] {
+			return
+		}
+		needed[i] = true
+		if dep := stageIdx(stages[i].BaseName, i); dep != -1 {
+			visit(dep)
+		}
+		for _, cmd := range stages[i].Commands {
+			if c, ok := cmd.(*instructions.CopyCommand); ok && c.From != "" {
+				if dep := stageIdx(c.From, i); dep != -1 {
+					visit(dep)
+				}
+			}
+		}
+	}
+	visit(targetIdx)
+
+	result := []instructions.Stage{}
+	for i := range stages {
+		if needed[i] {
+			result = append(result, stages[i])
+		}
+	}
+	return result
+}
+
+func newContextChecker(fs afero.Fs, dockerfile, contextPath string) (*contextChecker, error) {
+	c := &contextChecker{fs: fs, contextPath: contextPath}
+	patterns := []string{}
+	for _, candidate := range []string{
+		fmt.Sprintf("%s%s", dockerfile, defaultDockerIgnore),
+		filepath.Join(filepath.Dir(dockerfile), defaultDockerIgnore),
+		filepath.Join(contextPath, defaultDockerIgnore),
+	} {
+		f, err := fs.Open(candidate)
+		if err != nil {
+			continue
+		}
+		p, err := ignorefile.ReadAll(f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		c.ignoreFile = candidate
+		patterns = p
+		break
+	}
+	matcher, err := patternmatcher.New(patterns)
+	if err != nil {
+		return nil, err
+	}
+	c.matcher = matcher
+	return c, nil
+}
+
+// check returns the reason why a source can't be sent to buildkit, or an empty string if it is valid.
+// Like buildkit, sources are resolved from the root of the build context
+func (c *contextChecker) check(src string) (string, error) {
+	if isRemoteSource(src) || strings.Contains(src, "$") || strings.Contains(src, "**") {
+		return "", nil
+	}
+	rel := strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(src)), "/")
+	if rel == "" {
+		return "", nil
+	}
+
+	matches := []string{filepath.Join(c.contextPath, filepath.FromSlash(rel))}
+	if containsWildcard(rel) {
+		var err error
+		matches, err = afero.Glob(c.fs, matches[0])
+		if err != nil {
+			// buildkit reports the malformed pattern
+			return "", nil
+		}
+	}
+
+	found := false
+	for _, m := range matches {
+		info, err := c.fs.Stat(m)
+		if err != nil {
+			continue
+		}
+		found = true
+		available, err := c.isAvailable(m, info.IsDir())
+		if err != nil {
+			return "", err
+		}
+		if available {
+			return "", nil
+		}
+	}
+	if !found {
+		return missingSourceReason, nil
+	}
+	return fmt.Sprintf(excludedSourceReason, c.ignoreFile), nil
+}
+
+// isAvailable returns if a file, or any file of a folder, is not excluded by the ignore rules
+func (c *contextChecker) isAvailable(p string, isDir bool) (bool, error) {
+	ignored, err := c.isIgnored(p)
+	if err != nil {
+		return false, err
+	}
+	if !ignored {
+		return true, nil
+	}
+	if !isDir || !c.matcher.Exclusions() {
+		return false, nil
+	}
+
+	// an exclusion pattern might include files of an ignored folder
+	errFound := errors.New("found")
+	err = afero.Walk(c.fs, p, func(file string, _ os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		ignored, err := c.isIgnored(file)
+		if err != nil {
+			return err
+		}
+		if !ignored {
+			return errFound
+		}
+		return nil
+	})
+	if errors.Is(err, errFound) {
+		return true, nil
+	}
+	return false, err
+}
+
+func (c *contextChecker) isIgnored(p string) (bool, error) {
+	rel, err := filepath.Rel(c.contextPath, p)
+	if err != nil {
+		return false, err
+	}
+	if rel == "." {
+		return false, nil
+	}
+	return c.matcher.MatchesOrParentMatches(rel)
+}
+
+func containsWildcard(src string) bool {
+	return strings.ContainsAny(src, "*?[")
+}
+
+func isRemoteSource(src string) bool {
+	for _, prefix := range []string{"http://", "https://", "git@", "git://"} {
+		if strings.HasPrefix(src, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateBuildContext checks the Dockerfile sources of a build with a local context before sending it to buildkit
+func validateBuildContext(fs afero.Fs, buildOptions *types.BuildOptions) error {
+	if env.LoadBoolean(OktetoSkipBuildContextCheckEnvVar) {
+		return nil
+	}
+	if uri, err := url.ParseRequestURI(buildOptions.Path); err == nil && uri.Scheme != "" && uri.Host != "" {
+		return nil
+	}
+	dockerfile := buildOptions.File
+	if dockerfile == "" {
+		dockerfile = filepath.Join(buildOptions.Path, "Dockerfile")
+	}
+	return checkBuildContext(fs, dockerfile, buildOptions.Path, buildOptions.Target)
+}
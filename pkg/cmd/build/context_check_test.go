@@ -0,0 +1,211 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"path/filepath"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_checkBuildContext(t *testing.T) {
+	contextFiles := []string{
+		"package.json",
+		"package-lock.json",
+		"src/index.js",
+		"src/lib/util.js",
+		"config/app.yaml",
+		"secrets/token",
+		"docs/README.md",
+	}
+
+	tests := []struct {
+		name             string
+		dockerfile       string
+		dockerignore     string
+		target           string
+		expectedErr      bool
+		expectedMessages []string
+	}{
+		{
+			name: "all sources exist",
+			dockerfile: `FROM node:20
+COPY package.json package-lock.json ./
+COPY src /app/src
+ADD https://example.com/file.tar.gz /tmp/
+COPY . .`,
+		},
+		{
+			name: "missing file",
+			dockerfile: `FROM node:20
+COPY package.json yarn.lock ./`,
+			expectedErr:      true,
+			expectedMessages: []string{"line 2: COPY 'yarn.lock' doesn't exist"},
+		},
+		{
+			name: "file excluded by dockerignore",
+			dockerfile: `FROM node:20
+COPY config/app.yaml /etc/app.yaml`,
+			dockerignore:     "config",
+			expectedErr:      true,
+			expectedMessages: []string{"line 2: COPY 'config/app.yaml' is excluded by 'ctx/.dockerignore'"},
+		},
+		{
+			name: "folder excluded with an exception",
+			dockerfile: `FROM node:20
+COPY src /app/src`,
+			dockerignore: "src\n!src/index.js",
+		},
+		{
+			name: "folder fully excluded",
+			dockerfile: `FROM node:20
+ADD secrets /secrets`,
+			dockerignore:     "secrets",
+			expectedErr:      true,
+			expectedMessages: []string{"line 2: ADD 'secrets' is excluded by 'ctx/.dockerignore'"},
+		},
+		{
+			name: "wildcard with matches",
+			dockerfile: `FROM node:20
+COPY package*.json ./
+COPY src/*/util.js ./`,
+		},
+		{
+			name: "wildcard without matches",
+			dockerfile: `FROM node:20
+COPY *.lock ./`,
+			expectedErr:      true,
+			expectedMessages: []string{"line 2: COPY '*.lock' doesn't exist"},
+		},
+		{
+			name: "wildcard with every match excluded",
+			dockerfile: `FROM node:20
+COPY docs/*.md ./`,
+			dockerignore:     "**/*.md",
+			expectedErr:      true,
+			expectedMessages: []string{"line 2: COPY 'docs/*.md' is excluded by 'ctx/.dockerignore'"},
+		},
+		{
+			name: "copy from other stages and images are skipped",
+			dockerfile: `FROM node:20 AS builder
+COPY package.json ./
+RUN npm run build
+
+FROM nginx
+COPY --from=builder /app/dist /usr/share/nginx/html
+COPY --from=busybox /bin/sh /bin/sh`,
+		},
+		{
+			name: "multi-stage reports every needed stage",
+			dockerfile: `FROM node:20 AS deps
+COPY yarn.lock ./
+
+FROM deps AS builder
+COPY missing.js ./
+
+FROM nginx
+COPY --from=builder /app /app`,
+			expectedErr: true,
+			expectedMessages: []string{
+				"line 2: COPY 'yarn.lock' doesn't exist",
+				"line 5: COPY 'missing.js' doesn't exist",
+			},
+		},
+		{
+			name: "stages not needed by the target are skipped",
+			dockerfile: `FROM node:20 AS dev
+COPY package.json ./
+
+FROM node:20 AS test
+COPY missing.js ./`,
+			target: "dev",
+		},
+		{
+			name: "variables and heredocs are skipped",
+			dockerfile: `# syntax=docker/dockerfile:1
+FROM node:20
+ARG CONFIG=config/missing.yaml
+COPY ${CONFIG} /etc/app.yaml
+COPY <<EOF /etc/motd
+hello
+EOF`,
+		},
+		{
+			name: "parent paths are resolved from the context root",
+			dockerfile: `FROM node:20
+COPY ../package.json ./`,
+		},
+		{
+			name:       "invalid dockerfile is reported by buildkit",
+			dockerfile: `COPY missing.js ./`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			for _, f := range contextFiles {
+				require.NoError(t, afero.WriteFile(fs, filepath.Join("ctx", f), []byte("content"), 0600))
+			}
+			require.NoError(t, afero.WriteFile(fs, filepath.Join("ctx", "Dockerfile"), []byte(tt.dockerfile), 0600))
+			if tt.dockerignore != "" {
+				require.NoError(t, afero.WriteFile(fs, filepath.Join("ctx", ".dockerignore"), []byte(tt.dockerignore), 0600))
+			}
+
+			err := checkBuildContext(fs, filepath.Join("ctx", "Dockerfile"), "ctx", tt.target)
+			if !tt.expectedErr {
+				require.NoError(t, err)
+				return
+			}
+			var uErr oktetoErrors.UserError
+			require.ErrorAs(t, err, &uErr)
+			for _, msg := range tt.expectedMessages {
+				assert.Contains(t, err.Error(), msg)
+			}
+		})
+	}
+}
+
+func Test_checkBuildContextIgnoreFilePrecedence(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, filepath.Join("ctx", "app.yaml"), []byte("content"), 0600))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join("ctx", ".dockerignore"), []byte("*.md"), 0600))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join("build", "Dockerfile"), []byte("FROM alpine\nCOPY app.yaml /"), 0600))
+
+	require.NoError(t, checkBuildContext(fs, filepath.Join("build", "Dockerfile"), "ctx", ""))
+
+	require.NoError(t, afero.WriteFile(fs, filepath.Join("build", "Dockerfile.dockerignore"), []byte("*.yaml"), 0600))
+	err := checkBuildContext(fs, filepath.Join("build", "Dockerfile"), "ctx", "")
+	assert.ErrorContains(t, err, "is excluded by 'build/Dockerfile.dockerignore'")
+}
+
+func Test_validateBuildContext(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, filepath.Join("ctx", "Dockerfile"), []byte("FROM alpine\nCOPY missing /"), 0600))
+
+	err := validateBuildContext(fs, &types.BuildOptions{Path: "ctx"})
+	assert.ErrorContains(t, err, "COPY 'missing' doesn't exist")
+
+	err = validateBuildContext(fs, &types.BuildOptions{Path: "https://github.com/okteto/okteto.git"})
+	assert.NoError(t, err)
+
+	t.Setenv(OktetoSkipBuildContextCheckEnvVar, "true")
+	err = validateBuildContext(fs, &types.BuildOptions{Path: "ctx"})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,192 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/moby/buildkit/client"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/opencontainers/go-digest"
+)
+
+// jsonStep is the state of a step (vertex) of the build
+type jsonStep struct {
+	name      string
+	partial   string
+	started   bool
+	completed bool
+	cached    bool
+}
+
+// jsonProgress translates the buildkit solve status into structured build progress events for the json output mode
+type jsonProgress struct {
+	start  time.Time
+	steps  map[digest.Digest]*jsonStep
+	now    func() time.Time
+	emit   func(message string, event *types.BuildProgressEvent)
+	failed bool
+}
+
+func newJSONProgress() *jsonProgress {
+	return &jsonProgress{
+		start: time.Now(),
+		steps: map[digest.Digest]*jsonStep{},
+		now:   time.Now,
+		emit: func(message string, event *types.BuildProgressEvent) {
+			oktetoLog.JSONEvent(types.BuildProgressKey, message, event)
+		},
+	}
+}
+
+// displayJSONProgress emits the progress of the build until ch is closed, and then the summary of the build
+func displayJSONProgress(ch chan *client.SolveStatus) {
+	p := newJSONProgress()
+	for ss := range ch {
+		p.update(ss)
+	}
+	p.finish()
+}
+
+// update emits the events of a solve status
+func (p *jsonProgress) update(ss *client.SolveStatus) {
+	for _, v := range ss.Vertexes {
+		step, ok := p.steps[v.Digest]
+		if !ok {
+			step = &jsonStep{name: v.Name}
+			p.steps[v.Digest] = step
+		}
+		if v.Cached {
+			step.cached = true
+		}
+		if v.Started != nil && !step.started && v.Completed == nil {
+			step.started = true
+			p.emit(fmt.Sprintf("%s: %s", step.name, types.BuildStepStarted), &types.BuildProgressEvent{
+				Type:   types.BuildProgressStep,
+				Step:   step.name,
+				Status: types.BuildStepStarted,
+			})
+		}
+		if v.Completed != nil && !step.completed {
+			step.completed = true
+			p.flushLogs(step)
+			p.complete(step, v)
+		}
+	}
+
+	for _, l := range ss.Logs {
+		step, ok := p.steps[l.Vertex]
+		if !ok {
+			continue
+		}
+		data := step.partial + string(l.Data)
+		lastNewLine := strings.LastIndex(data, "\n")
+		if lastNewLine == -1 {
+			step.partial = data
+			continue
+		}
+		// the end of the data is kept until its line is completed
+		step.partial = data[lastNewLine+1:]
+		p.emitLogs(step, strings.Split(data[:lastNewLine], "\n"))
+	}
+}
+
+// complete emits the result of a completed step
+func (p *jsonProgress) complete(step *jsonStep, v *client.Vertex) {
+	event := &types.BuildProgressEvent{
+		Type:   types.BuildProgressStep,
+		Step:   step.name,
+		Status: types.BuildStepCompleted,
+		Cached: step.cached,
+	}
+	if v.Started != nil {
+		event.Duration = roundSeconds(v.Completed.Sub(*v.Started))
+	}
+	message := fmt.Sprintf("%s: %s", step.name, types.BuildStepCompleted)
+	if step.cached {
+		message = fmt.Sprintf("%s: CACHED", step.name)
+	}
+	if v.Error != "" {
+		p.failed = true
+		event.Status = types.BuildStepError
+		event.Error = v.Error
+		message = fmt.Sprintf("%s: %s", step.name, v.Error)
+	}
+	p.emit(message, event)
+}
+
+// flushLogs emits the last line of a step, even if it didn't end with a new line
+func (p *jsonProgress) flushLogs(step *jsonStep) {
+	if step.partial == "" {
+		return
+	}
+	lines := []string{step.partial}
+	step.partial = ""
+	p.emitLogs(step, lines)
+}
+
+func (p *jsonProgress) emitLogs(step *jsonStep, lines []string) {
+	logs := make([]string, 0, len(lines))
+	for _, line := range lines {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		logs = append(logs, line)
+	}
+	if len(logs) == 0 {
+		return
+	}
+	p.emit(strings.Join(logs, "\n"), &types.BuildProgressEvent{
+		Type:   types.BuildProgressStep,
+		Step:   step.name,
+		Status: types.BuildStepRunning,
+		Logs:   logs,
+	})
+}
+
+// finish emits the summary of the build
+func (p *jsonProgress) finish() {
+	summary := &types.BuildSummary{
+		Duration: roundSeconds(p.now().Sub(p.start)),
+		Failed:   p.failed,
+	}
+	for _, step := range p.steps {
+		p.flushLogs(step)
+		if !step.completed {
+			continue
+		}
+		summary.Steps++
+		if step.cached {
+			summary.CachedSteps++
+		}
+	}
+	message := fmt.Sprintf("Build finished in %.1fs: %d steps, %d cached", summary.Duration, summary.Steps, summary.CachedSteps)
+	if p.failed {
+		message = fmt.Sprintf("Build failed after %.1fs", summary.Duration)
+	}
+	p.emit(message, &types.BuildProgressEvent{
+		Type:    types.BuildProgressSummary,
+		Summary: summary,
+	})
+}
+
+// roundSeconds returns a duration in seconds, rounded to milliseconds
+func roundSeconds(d time.Duration) float64 {
+	return math.Round(d.Seconds()*1000) / 1000
+}
@@ -0,0 +1,157 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/moby/buildkit/client"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/types"
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildSolveStatuses returns the solve statuses of a build with a cached step, a step with logs and a step still running
+func buildSolveStatuses(start time.Time) []*client.SolveStatus {
+	cachedDigest := digest.FromString("cached")
+	runDigest := digest.FromString("run")
+	started := start
+	completed := start.Add(1500 * time.Millisecond)
+	return []*client.SolveStatus{
+		{
+			Vertexes: []*client.Vertex{
+				{Digest: cachedDigest, Name: "[1/2] FROM alpine", Cached: true, Started: &started, Completed: &started},
+				{Digest: runDigest, Name: "[2/2] RUN make", Started: &started},
+			},
+		},
+		{
+			Logs: []*client.VertexLog{
+				{Vertex: runDigest, Data: []byte("compiling\nlink")},
+			},
+		},
+		{
+			Logs: []*client.VertexLog{
+				{Vertex: runDigest, Data: []byte("ing\n")},
+			},
+		},
+		{
+			Vertexes: []*client.Vertex{
+				{Digest: runDigest, Name: "[2/2] RUN make", Started: &started, Completed: &completed},
+			},
+		},
+	}
+}
+
+func TestJSONProgress(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := []*types.BuildProgressEvent{}
+	messages := []string{}
+	p := &jsonProgress{
+		start: start,
+		steps: map[digest.Digest]*jsonStep{},
+		now:   func() time.Time { return start.Add(2 * time.Second) },
+		emit: func(message string, event *types.BuildProgressEvent) {
+			messages = append(messages, message)
+			events = append(events, event)
+		},
+	}
+
+	for _, ss := range buildSolveStatuses(start) {
+		p.update(ss)
+	}
+	p.finish()
+
+	expected := []*types.BuildProgressEvent{
+		{Type: types.BuildProgressStep, Step: "[1/2] FROM alpine", Status: types.BuildStepCompleted, Cached: true},
+		{Type: types.BuildProgressStep, Step: "[2/2] RUN make", Status: types.BuildStepStarted},
+		{Type: types.BuildProgressStep, Step: "[2/2] RUN make", Status: types.BuildStepRunning, Logs: []string{"compiling"}},
+		{Type: types.BuildProgressStep, Step: "[2/2] RUN make", Status: types.BuildStepRunning, Logs: []string{"linking"}},
+		{Type: types.BuildProgressStep, Step: "[2/2] RUN make", Status: types.BuildStepCompleted, Duration: 1.5},
+		{Type: types.BuildProgressSummary, Summary: &types.BuildSummary{Duration: 2, Steps: 2, CachedSteps: 1}},
+	}
+	assert.Equal(t, expected, events)
+	assert.Equal(t, "[1/2] FROM alpine: CACHED", messages[0])
+	assert.Equal(t, "Build finished in 2.0s: 2 steps, 1 cached", messages[len(messages)-1])
+}
+
+func TestJSONProgressError(t *testing.T) {
+	var summary *types.BuildSummary
+	var stepError string
+	p := newJSONProgress()
+	p.emit = func(_ string, event *types.BuildProgressEvent) {
+		if event.Status == types.BuildStepError {
+			stepError = event.Error
+		}
+		if event.Summary != nil {
+			summary = event.Summary
+		}
+	}
+
+	now := time.Now()
+	p.update(&client.SolveStatus{
+		Vertexes: []*client.Vertex{
+			{Digest: digest.FromString("run"), Name: "[2/2] RUN make", Started: &now, Completed: &now, Error: "exit code: 2"},
+		},
+	})
+	p.finish()
+
+	assert.Equal(t, "exit code: 2", stepError)
+	require.NotNil(t, summary)
+	assert.True(t, summary.Failed)
+}
+
+// TestJSONProgressLines guards the schema of the build progress lines: every line must unmarshal into the published types
+func TestJSONProgressLines(t *testing.T) {
+	var out bytes.Buffer
+	oktetoLog.SetOutput(&out)
+	oktetoLog.SetOutputFormat(oktetoLog.JSONFormat)
+	t.Cleanup(func() {
+		oktetoLog.SetOutput(os.Stdout)
+		oktetoLog.SetOutputFormat(oktetoLog.TTYFormat)
+	})
+
+	ch := make(chan *client.SolveStatus, 10)
+	for _, ss := range buildSolveStatuses(time.Now()) {
+		ch <- ss
+	}
+	close(ch)
+	displayJSONProgress(ch)
+
+	lines := []types.BuildProgressLine{}
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		decoder := json.NewDecoder(bytes.NewReader(scanner.Bytes()))
+		decoder.DisallowUnknownFields()
+		var line types.BuildProgressLine
+		require.NoError(t, decoder.Decode(&line), scanner.Text())
+		lines = append(lines, line)
+	}
+	require.Len(t, lines, 6)
+	for _, line := range lines {
+		assert.Equal(t, oktetoLog.InfoLevel, line.Level)
+		assert.NotEmpty(t, line.Message)
+		assert.NotZero(t, line.Timestamp)
+		require.NotNil(t, line.Build)
+	}
+	assert.Equal(t, types.BuildProgressSummary, lines[5].Build.Type)
+	assert.Equal(t, 2, lines[5].Build.Summary.Steps)
+	assert.Equal(t, []string{"compiling"}, lines[2].Build.Logs)
+}
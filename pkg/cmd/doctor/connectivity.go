@@ -0,0 +1,403 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// ProbePassed is the status of a probe that reached its component
+	ProbePassed = "pass"
+
+	// ProbeFailed is the status of a probe that couldn't reach its component
+	ProbeFailed = "fail"
+
+	// ProbeSkipped is the status of a probe whose component is not configured in the current context
+	ProbeSkipped = "skip"
+
+	// DefaultProbeTimeout is the max time to wait for each probe
+	DefaultProbeTimeout = 10 * time.Second
+)
+
+var errInvalidConnectivityOutput = errors.New("output format is not accepted. Value must be one of: ['json']")
+
+// ConnectivityProbe checks the connectivity with one of the components used by okteto.
+// The errors of Check should be oktetoErrors.UserError with a hint to fix the failure
+type ConnectivityProbe interface {
+	Name() string
+	Check(ctx context.Context) error
+}
+
+// ProbeResult is the result of a connectivity probe
+type ProbeResult struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+	Hint      string `json:"hint,omitempty"`
+	LatencyMs int64  `json:"latencyMs"`
+}
+
+// probeSkippedError is returned by the probes whose component is not configured in the current context
+type probeSkippedError struct {
+	reason string
+}
+
+func (e probeSkippedError) Error() string {
+	return e.reason
+}
+
+// RunConnectivityProbes runs the probes one after the other, each one with its own timeout
+func RunConnectivityProbes(ctx context.Context, probes []ConnectivityProbe, timeout time.Duration) []ProbeResult {
+	results := make([]ProbeResult, 0, len(probes))
+	for _, probe := range probes {
+		results = append(results, runConnectivityProbe(ctx, probe, timeout))
+	}
+	return results
+}
+
+func runConnectivityProbe(ctx context.Context, probe ConnectivityProbe, timeout time.Duration) ProbeResult {
+	probeCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := probe.Check(probeCtx)
+	result := ProbeResult{
+		Name:      probe.Name(),
+		Status:    ProbePassed,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+	if err == nil {
+		return result
+	}
+
+	result.Error = err.Error()
+	var skipped probeSkippedError
+	if errors.As(err, &skipped) {
+		result.Status = ProbeSkipped
+		result.LatencyMs = 0
+		return result
+	}
+	result.Status = ProbeFailed
+	var userErr oktetoErrors.UserError
+	switch {
+	case errors.Is(probeCtx.Err(), context.DeadlineExceeded):
+		result.Error = fmt.Sprintf("timed out after %s", timeout)
+		result.Hint = "Check your network connection, VPNs, proxies and firewalls might be blocking the connection"
+	case errors.As(err, &userErr):
+		result.Error = userErr.E.Error()
+		result.Hint = userErr.Hint
+	}
+	return result
+}
+
+// HasFailedProbes returns if any of the probes failed
+func HasFailedProbes(results []ProbeResult) bool {
+	for _, r := range results {
+		if r.Status == ProbeFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateConnectivityOutput validates the output format of the connectivity results
+func ValidateConnectivityOutput(output string) error {
+	switch output {
+	case "", "json":
+		return nil
+	default:
+		return errInvalidConnectivityOutput
+	}
+}
+
+// PrintConnectivityResults prints the results of the probes as a table with the hints of the failures, or as json
+func PrintConnectivityResults(w io.Writer, results []ProbeResult, output string) error {
+	if output == "json" {
+		b, err := json.MarshalIndent(results, "", " ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(b))
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 1, 1, 2, ' ', 0)
+	fmt.Fprintf(tw, "Check\tStatus\tLatency\tError\n")
+	for _, r := range results {
+		latency := "-"
+		if r.Status != ProbeSkipped {
+			latency = fmt.Sprintf("%dms", r.LatencyMs)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.Name, r.Status, latency, r.Error)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	for _, r := range results {
+		if r.Status == ProbeFailed && r.Hint != "" {
+			fmt.Fprintf(w, "\n%s: %s\n", r.Name, r.Hint)
+		}
+	}
+	return nil
+}
+
+// KubeAPIProbe checks that the kubernetes API is reachable and accepts the credentials of the context
+type KubeAPIProbe struct {
+	Client    kubernetes.Interface
+	Namespace string
+}
+
+// Name returns the name of the probe
+func (*KubeAPIProbe) Name() string {
+	return "kubernetes api"
+}
+
+// Check lists the pods of the namespace to verify that the kubernetes api is reachable and accepts the credentials
+func (p *KubeAPIProbe) Check(ctx context.Context) error {
+	_, err := p.Client.CoreV1().Pods(p.Namespace).List(ctx, metav1.ListOptions{Limit: 1})
+	if err == nil {
+		return nil
+	}
+	var status k8sErrors.APIStatus
+	switch {
+	case k8sErrors.IsUnauthorized(err):
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the credentials of the kubernetes api are not valid: %w", err),
+			Hint: "Run 'okteto context' to refresh your credentials",
+		}
+	case k8sErrors.IsForbidden(err):
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("access to the namespace '%s' is forbidden: %w", p.Namespace, err),
+			Hint: fmt.Sprintf("Ask your administrator for access to the namespace '%s'", p.Namespace),
+		}
+	case errors.As(err, &status):
+		return err
+	default:
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the kubernetes api is not reachable: %w", err),
+			Hint: "Check your network connection and that your cluster is running",
+		}
+	}
+}
+
+// PortForwardProbe checks that the user can open the websocket connections used by port forwarding and exec
+type PortForwardProbe struct {
+	Client    kubernetes.Interface
+	Namespace string
+}
+
+// Name returns the name of the probe
+func (*PortForwardProbe) Name() string {
+	return "port-forward"
+}
+
+// Check verifies that the user can create 'pods/portforward' and 'pods/exec' in the namespace
+func (p *PortForwardProbe) Check(ctx context.Context) error {
+	for _, subresource := range []string{"portforward", "exec"} {
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace:   p.Namespace,
+					Verb:        "create",
+					Resource:    "pods",
+					Subresource: subresource,
+				},
+			},
+		}
+		result, err := p.Client.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to check the permissions of 'pods/%s': %w", subresource, err)
+		}
+		if !result.Status.Allowed {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("'pods/%s' is not allowed in the namespace '%s'", subresource, p.Namespace),
+				Hint: fmt.Sprintf("'okteto up' needs permission to create 'pods/portforward' and 'pods/exec', ask your administrator for access to the namespace '%s'", p.Namespace),
+			}
+		}
+	}
+	return nil
+}
+
+// OktetoAPIProbe checks that the GraphQL endpoint of the okteto API is reachable and accepts the token of the context
+type OktetoAPIProbe struct {
+	HTTPClient *http.Client
+	URL        string
+	Token      string
+}
+
+// Name returns the name of the probe
+func (*OktetoAPIProbe) Name() string {
+	return "okteto api"
+}
+
+// Check sends a minimal query to the GraphQL endpoint of the okteto API
+func (p *OktetoAPIProbe) Check(ctx context.Context) error {
+	if p.URL == "" {
+		return probeSkippedError{reason: "the current context is not an Okteto context"}
+	}
+	endpoint, err := url.JoinPath(p.URL, "graphql")
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewBufferString(`{"query":"{ __typename }"}`))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", p.Token))
+
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the okteto api is not reachable: %w", err),
+			Hint: fmt.Sprintf("Check that '%s' is reachable from your network, VPNs and proxies might be blocking it", p.URL),
+		}
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the okteto api rejected your token: %s", resp.Status),
+			Hint: fmt.Sprintf("Run 'okteto context use %s' to log in again", p.URL),
+		}
+	case resp.StatusCode != http.StatusOK:
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the okteto api returned %s", resp.Status),
+			Hint: "Check the status of your Okteto instance, or contact your administrator",
+		}
+	}
+	return nil
+}
+
+// RegistryProbe pings the v2 API of the registry with the credentials of the context
+type RegistryProbe struct {
+	Transport http.RoundTripper
+	Registry  string
+	Username  string
+	Password  string
+}
+
+// Name returns the name of the probe
+func (*RegistryProbe) Name() string {
+	return "registry"
+}
+
+// Check pings the registry, authenticates with its basic or token auth and gets '/v2/'
+func (p *RegistryProbe) Check(ctx context.Context) error {
+	if p.Registry == "" {
+		return probeSkippedError{reason: "the current context doesn't have a registry"}
+	}
+	reg, err := name.NewRegistry(p.Registry)
+	if err != nil {
+		return err
+	}
+	hint := fmt.Sprintf("Check that '%s' is reachable from your network and run 'okteto context' to refresh your credentials", p.Registry)
+	auth := authn.FromConfig(authn.AuthConfig{Username: p.Username, Password: p.Password})
+	t, err := transport.NewWithContext(ctx, reg, auth, p.Transport, []string{})
+	if err != nil {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("failed to authenticate with the registry: %w", err),
+			Hint: hint,
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s://%s/v2/", reg.Scheme(), reg.RegistryStr()), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := (&http.Client{Transport: t}).Do(req)
+	if err != nil {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the registry is not reachable: %w", err),
+			Hint: hint,
+		}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the registry returned %s", resp.Status),
+			Hint: hint,
+		}
+	}
+	return nil
+}
+
+// BuildkitProbe checks the TLS handshake with the buildkit endpoint of the context
+type BuildkitProbe struct {
+	TLSConfig *tls.Config
+	Address   string
+}
+
+// Name returns the name of the probe
+func (*BuildkitProbe) Name() string {
+	return "buildkit"
+}
+
+// Check opens a TLS connection to the buildkit endpoint
+func (p *BuildkitProbe) Check(ctx context.Context) error {
+	if p.Address == "" {
+		return probeSkippedError{reason: "the current context doesn't have a buildkit endpoint"}
+	}
+	host, err := getBuildkitHost(p.Address)
+	if err != nil {
+		return err
+	}
+	dialer := &tls.Dialer{Config: p.TLSConfig}
+	conn, err := dialer.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the TLS handshake with '%s' failed: %w", host, err),
+			Hint: fmt.Sprintf("Check that '%s' is reachable and that proxies or firewalls don't intercept its TLS traffic", host),
+		}
+	}
+	return conn.Close()
+}
+
+// getBuildkitHost returns the 'host:port' of a buildkit endpoint like 'tcp://buildkit.okteto.example.com:443'
+func getBuildkitHost(address string) (string, error) {
+	if !strings.Contains(address, "://") {
+		address = fmt.Sprintf("tcp://%s", address)
+	}
+	u, err := url.Parse(address)
+	if err != nil {
+		return "", fmt.Errorf("invalid buildkit endpoint '%s': %w", address, err)
+	}
+	if u.Port() == "" {
+		return net.JoinHostPort(u.Hostname(), "443"), nil
+	}
+	return u.Host, nil
+}
@@ -0,0 +1,263 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+type fakeProbe struct {
+	err   error
+	name  string
+	delay time.Duration
+}
+
+func (f *fakeProbe) Name() string {
+	return f.name
+}
+
+func (f *fakeProbe) Check(ctx context.Context) error {
+	if f.delay > 0 {
+		select {
+		case <-time.After(f.delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return f.err
+}
+
+func TestRunConnectivityProbes(t *testing.T) {
+	probes := []ConnectivityProbe{
+		&fakeProbe{name: "pass"},
+		&fakeProbe{name: "fail", err: oktetoErrors.UserError{E: errors.New("unreachable"), Hint: "check your vpn"}},
+		&fakeProbe{name: "skip", err: probeSkippedError{reason: "not configured"}},
+		&fakeProbe{name: "timeout", delay: time.Minute},
+	}
+
+	results := RunConnectivityProbes(context.Background(), probes, 50*time.Millisecond)
+	require.Len(t, results, 4)
+	assert.Equal(t, ProbeResult{Name: "pass", Status: ProbePassed, LatencyMs: results[0].LatencyMs}, results[0])
+	assert.Equal(t, ProbeResult{Name: "fail", Status: ProbeFailed, Error: "unreachable", Hint: "check your vpn", LatencyMs: results[1].LatencyMs}, results[1])
+	assert.Equal(t, ProbeResult{Name: "skip", Status: ProbeSkipped, Error: "not configured"}, results[2])
+	assert.Equal(t, ProbeFailed, results[3].Status)
+	assert.Equal(t, "timed out after 50ms", results[3].Error)
+	assert.NotEmpty(t, results[3].Hint)
+	assert.True(t, HasFailedProbes(results))
+	assert.False(t, HasFailedProbes(results[:1]))
+}
+
+func TestPrintConnectivityResults(t *testing.T) {
+	results := []ProbeResult{
+		{Name: "kubernetes api", Status: ProbePassed, LatencyMs: 12},
+		{Name: "registry", Status: ProbeFailed, Error: "unreachable", Hint: "check your vpn", LatencyMs: 30},
+		{Name: "buildkit", Status: ProbeSkipped, Error: "not configured"},
+	}
+
+	var table bytes.Buffer
+	require.NoError(t, PrintConnectivityResults(&table, results, ""))
+	assert.Equal(t, `Check           Status  Latency  Error
+kubernetes api  pass    12ms     
+registry        fail    30ms     unreachable
+buildkit        skip    -        not configured
+
+registry: check your vpn
+`, table.String())
+
+	var out bytes.Buffer
+	require.NoError(t, PrintConnectivityResults(&out, results, "json"))
+	var decoded []ProbeResult
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, results, decoded)
+
+	require.NoError(t, ValidateConnectivityOutput("json"))
+	require.ErrorIs(t, ValidateConnectivityOutput("yaml"), errInvalidConnectivityOutput)
+}
+
+func TestKubeAPIProbe(t *testing.T) {
+	tests := []struct {
+		err         error
+		name        string
+		errContains string
+		hint        string
+	}{
+		{
+			name: "reachable",
+		},
+		{
+			name:        "unauthorized",
+			err:         k8sErrors.NewUnauthorized("token expired"),
+			errContains: "the credentials of the kubernetes api are not valid",
+			hint:        "Run 'okteto context' to refresh your credentials",
+		},
+		{
+			name:        "forbidden",
+			err:         k8sErrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", errors.New("denied")),
+			errContains: "access to the namespace 'ns' is forbidden",
+			hint:        "Ask your administrator for access to the namespace 'ns'",
+		},
+		{
+			name:        "unreachable",
+			err:         errors.New("dial tcp: i/o timeout"),
+			errContains: "the kubernetes api is not reachable",
+			hint:        "Check your network connection and that your cluster is running",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewSimpleClientset()
+			c.PrependReactor("list", "pods", func(k8sTesting.Action) (bool, runtime.Object, error) {
+				return tt.err != nil, nil, tt.err
+			})
+			err := (&KubeAPIProbe{Client: c, Namespace: "ns"}).Check(context.Background())
+			if tt.errContains == "" {
+				require.NoError(t, err)
+				return
+			}
+			var userErr oktetoErrors.UserError
+			require.ErrorAs(t, err, &userErr)
+			assert.ErrorContains(t, userErr.E, tt.errContains)
+			assert.Equal(t, tt.hint, userErr.Hint)
+		})
+	}
+}
+
+func TestPortForwardProbe(t *testing.T) {
+	newClient := func(denied string) *fake.Clientset {
+		c := fake.NewSimpleClientset()
+		c.PrependReactor("create", "selfsubjectaccessreviews", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+			review := action.(k8sTesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+			review.Status.Allowed = review.Spec.ResourceAttributes.Subresource != denied
+			return true, review, nil
+		})
+		return c
+	}
+
+	require.NoError(t, (&PortForwardProbe{Client: newClient(""), Namespace: "ns"}).Check(context.Background()))
+
+	err := (&PortForwardProbe{Client: newClient("exec"), Namespace: "ns"}).Check(context.Background())
+	require.ErrorContains(t, err, "'pods/exec' is not allowed in the namespace 'ns'")
+}
+
+func TestOktetoAPIProbe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/graphql" || r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer valid" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"data":{"__typename":"Query"}}`))
+	}))
+	defer server.Close()
+
+	probe := &OktetoAPIProbe{HTTPClient: server.Client(), URL: server.URL, Token: "valid"}
+	require.NoError(t, probe.Check(context.Background()))
+
+	probe.Token = "expired"
+	var userErr oktetoErrors.UserError
+	require.ErrorAs(t, probe.Check(context.Background()), &userErr)
+	assert.ErrorContains(t, userErr.E, "the okteto api rejected your token")
+	assert.Contains(t, userErr.Hint, "okteto context use "+server.URL)
+
+	var skipped probeSkippedError
+	require.ErrorAs(t, (&OktetoAPIProbe{}).Check(context.Background()), &skipped)
+}
+
+func TestRegistryProbe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		user, password, ok := r.BasicAuth()
+		if !ok || user != "cindy" || password != "token" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="okteto"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	probe := &RegistryProbe{
+		Transport: server.Client().Transport,
+		Registry:  strings.TrimPrefix(server.URL, "http://"),
+		Username:  "cindy",
+		Password:  "token",
+	}
+	require.NoError(t, probe.Check(context.Background()))
+
+	probe.Password = "expired"
+	var userErr oktetoErrors.UserError
+	require.ErrorAs(t, probe.Check(context.Background()), &userErr)
+	assert.ErrorContains(t, userErr.E, "the registry returned 401 Unauthorized")
+
+	var skipped probeSkippedError
+	require.ErrorAs(t, (&RegistryProbe{}).Check(context.Background()), &skipped)
+}
+
+func TestBuildkitProbe(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+	address := "tcp://" + strings.TrimPrefix(server.URL, "https://")
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	probe := &BuildkitProbe{Address: address, TLSConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}}
+	require.NoError(t, probe.Check(context.Background()))
+
+	probe.TLSConfig = &tls.Config{RootCAs: x509.NewCertPool(), MinVersion: tls.VersionTLS12}
+	var userErr oktetoErrors.UserError
+	require.ErrorAs(t, probe.Check(context.Background()), &userErr)
+	assert.ErrorContains(t, userErr.E, "the TLS handshake with")
+
+	var skipped probeSkippedError
+	require.ErrorAs(t, (&BuildkitProbe{}).Check(context.Background()), &skipped)
+}
+
+func Test_getBuildkitHost(t *testing.T) {
+	tests := map[string]string{
+		"tcp://buildkit.okteto.example.com:443": "buildkit.okteto.example.com:443",
+		"https://buildkit.okteto.example.com":   "buildkit.okteto.example.com:443",
+		"buildkit.okteto.example.com:1234":      "buildkit.okteto.example.com:1234",
+	}
+	for address, expected := range tests {
+		host, err := getBuildkitHost(address)
+		require.NoError(t, err)
+		assert.Equal(t, expected, host)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doctor
+
+import (
+	"fmt"
+
+	"github.com/okteto/okteto/pkg/config"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+)
+
+// imageDigestGetter gets the digest of an image from its registry
+type imageDigestGetter interface {
+	GetImageTagWithDigest(image string) (string, error)
+}
+
+// GetOverriddenDevImages returns the helper images of the development container that override the okteto defaults,
+// either from the 'devImages' section of the manifest or from the OKTETO_BIN_IMAGE environment variable
+func GetOverriddenDevImages(manifest *model.Manifest, dev *model.Dev) []string {
+	images := []string{}
+	if dev.InitContainer.Image != "" && dev.InitContainer.Image != config.NewImageConfig(oktetoLog.GetOutputWriter()).GetCliImage() {
+		images = append(images, dev.InitContainer.Image)
+	}
+	if manifest != nil && manifest.DevImages != nil && manifest.DevImages.Sandbox != "" && manifest.DevImages.Sandbox != dev.InitContainer.Image {
+		images = append(images, manifest.DevImages.Sandbox)
+	}
+	return images
+}
+
+// CheckDevImages verifies that the given helper images can be pulled and returns an error for every image that can't
+func CheckDevImages(images []string, getter imageDigestGetter) []error {
+	var errs []error
+	for _, image := range images {
+		if _, err := getter.GetImageTagWithDigest(image); err != nil {
+			oktetoLog.Infof("failed to get the digest of '%s': %s", image, err)
+			errs = append(errs, fmt.Errorf("the image '%s' can't be pulled: %w", image, err))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doctor
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/log/io"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeImageDigestGetter struct {
+	available map[string]bool
+}
+
+func (f fakeImageDigestGetter) GetImageTagWithDigest(image string) (string, error) {
+	if !f.available[image] {
+		return "", fmt.Errorf("MANIFEST_UNKNOWN")
+	}
+	return image + "@sha256:abc", nil
+}
+
+func TestGetOverriddenDevImages(t *testing.T) {
+	cliImage := config.NewImageConfig(io.NewIOController()).GetCliImage()
+	tests := []struct {
+		manifest *model.Manifest
+		dev      *model.Dev
+		name     string
+		expected []string
+	}{
+		{
+			name:     "default images",
+			manifest: &model.Manifest{},
+			dev:      &model.Dev{InitContainer: model.InitContainer{Image: cliImage}},
+			expected: []string{},
+		},
+		{
+			name:     "overridden images",
+			manifest: &model.Manifest{DevImages: &model.DevImages{Bin: "mirror.mycorp.com/okteto/okteto:3.0.0", Sandbox: "mirror.mycorp.com/okteto/dev:latest"}},
+			dev:      &model.Dev{InitContainer: model.InitContainer{Image: "mirror.mycorp.com/okteto/okteto:3.0.0"}},
+			expected: []string{"mirror.mycorp.com/okteto/okteto:3.0.0", "mirror.mycorp.com/okteto/dev:latest"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GetOverriddenDevImages(tt.manifest, tt.dev))
+		})
+	}
+}
+
+func TestCheckDevImages(t *testing.T) {
+	getter := fakeImageDigestGetter{available: map[string]bool{"mirror.mycorp.com/okteto/okteto:3.0.0": true}}
+	errs := CheckDevImages([]string{"mirror.mycorp.com/okteto/okteto:3.0.0", "mirror.mycorp.com/okteto/dev:latest"}, getter)
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "the image 'mirror.mycorp.com/okteto/dev:latest' can't be pulled: MANIFEST_UNKNOWN")
+}
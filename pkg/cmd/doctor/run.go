@@ -52,7 +52,7 @@ func Run(ctx context.Context, dev *model.Dev, devPath string, namespace string,
 		ContinueOnError:      true,
 	}
 
-	summaryFilename, err := generateSummaryFile()
+	summaryFilename, err := generateSummaryFile(dev)
 	if err != nil {
 		return "", err
 	}
@@ -175,7 +175,7 @@ func Run(ctx context.Context, dev *model.Dev, devPath string, namespace string,
 	return archiveName, nil
 }
 
-func generateSummaryFile() (string, error) {
+func generateSummaryFile(dev *model.Dev) (string, error) {
 	tempdir, err := os.MkdirTemp("", "")
 	if err != nil {
 		return "", fmt.Errorf("error creating temp dir: %w", err)
@@ -191,6 +191,10 @@ func generateSummaryFile() (string, error) {
 		}
 	}()
 	fmt.Fprintf(fileSummary, "version=%s\nos=%s\narch=%s\n", config.VersionString, runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(fileSummary, "reserved-ports:\n")
+	for _, p := range dev.ReservedPorts() {
+		fmt.Fprintf(fileSummary, "  %s\n", p.String())
+	}
 	if err := fileSummary.Sync(); err != nil {
 		return "", err
 	}
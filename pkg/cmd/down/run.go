@@ -15,6 +15,7 @@ package down
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/okteto/okteto/pkg/k8s/apps"
 	"github.com/okteto/okteto/pkg/k8s/secrets"
@@ -38,6 +39,7 @@ func (d *Operation) Run(app apps.App, dev *model.Dev, namespace string, trMap ma
 		return err
 	}
 
+	recreated := []apps.App{}
 	for _, tr := range trMap {
 		if app.ObjectMeta().Annotations[model.OktetoAutoCreateAnnotation] == model.OktetoUpCmd {
 			if err := app.Destroy(ctx, k8sClient); err != nil {
@@ -63,6 +65,9 @@ func (d *Operation) Run(app apps.App, dev *model.Dev, namespace string, trMap ma
 			if err := tr.App.Deploy(ctx, k8sClient); err != nil {
 				return err
 			}
+			if apps.IsRecreateStrategy(tr.App) {
+				recreated = append(recreated, tr.App)
+			}
 		}
 
 		tr.DevApp = tr.App.DevClone()
@@ -87,6 +92,14 @@ func (d *Operation) Run(app apps.App, dev *model.Dev, namespace string, trMap ma
 
 	devPodTerminationRetries := 30
 	waitForDevPodsTermination(ctx, k8sClient, dev, namespace, devPodTerminationRetries)
+
+	// apps using the Recreate strategy have no pods until the original ones are recreated
+	for _, recreatedApp := range recreated {
+		oktetoLog.Spinner(fmt.Sprintf("Waiting for the pods of '%s' to be ready...", recreatedApp.ObjectMeta().Name))
+		if err := apps.WaitForRecreatedPods(ctx, recreatedApp, k8sClient, apps.GetPodReadyTimeout(dev, recreatedApp)); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
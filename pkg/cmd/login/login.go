@@ -69,6 +69,18 @@ func (*Controller) AuthenticateToOktetoCluster(ctx context.Context, oktetoURL, t
 	return &types.User{Token: token}, nil
 }
 
+// RefreshTokenWithBrowser authenticates the user with the browser to replace the expired token of an okteto context
+func RefreshTokenWithBrowser(ctx context.Context, oktetoURL string) (string, error) {
+	user, err := WithBrowser(ctx, oktetoURL)
+	if err != nil {
+		return "", oktetoErrors.UserError{
+			E:    fmt.Errorf("couldn't refresh your token: %w", err),
+			Hint: fmt.Sprintf("Run 'okteto context use %s' to log in again", oktetoURL),
+		}
+	}
+	return user.Token, nil
+}
+
 // WithBrowser authenticates the user with the browser
 func WithBrowser(ctx context.Context, oktetoURL string) (*types.User, error) {
 	h, err := StartWithBrowser(ctx, oktetoURL)
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -38,6 +39,7 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/json"
 	"k8s.io/client-go/kubernetes"
 )
@@ -58,6 +60,9 @@ const (
 	devBranchField   = "dev-branch"
 	PhasesField      = "phases"
 
+	appliedResourcesField = "appliedResources"
+	healthChecksField     = "healthChecks"
+
 	actionDefaultName = "cli"
 
 	// ProgressingStatus indicates that an app is being deployed
@@ -76,6 +81,11 @@ const (
 	// the logs are stored in the configmap.
 	maxLogOutput = 800 << (10 * 1)
 
+	// HealthCheckPassed indicates that a deploy health check succeeded
+	HealthCheckPassed = "passed"
+	// HealthCheckFailed indicates that a deploy health check failed all its attempts
+	HealthCheckFailed = "failed"
+
 	// ConfigmapNamePrefix prefix used by the configmaps created by okteto to handle dev environments information
 	ConfigmapNamePrefix = "okteto-git-"
 )
@@ -104,6 +114,51 @@ type phaseJSON struct {
 	Duration float64 `json:"duration"`
 }
 
+// HealthCheckResult is the result of a health check run after the deploy
+type HealthCheckResult struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	// Duration is the time taken by all the attempts, in seconds
+	Duration float64 `json:"duration"`
+	Attempts int     `json:"attempts"`
+}
+
+// AppliedResource is a resource created or modified through the deploy proxy
+type AppliedResource struct {
+	Group     string `json:"group,omitempty"`
+	Version   string `json:"version"`
+	Resource  string `json:"resource"`
+	Kind      string `json:"kind,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Deleted is true when the resource was deleted through the proxy. It is never stored in the configmap
+	Deleted bool `json:"-"`
+}
+
+// Key identifies the resource regardless of the API version used to apply it
+func (r AppliedResource) Key() string {
+	return fmt.Sprintf("%s/%s/%s/%s", r.Group, r.Resource, r.Namespace, r.Name)
+}
+
+// GVR returns the group, version and resource of the applied resource
+func (r AppliedResource) GVR() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: r.Group, Version: r.Version, Resource: r.Resource}
+}
+
+// TypeName returns the type of the resource as printed by kubectl, like "deployment.apps"
+func (r AppliedResource) TypeName() string {
+	typeName := r.Resource
+	if r.Kind != "" {
+		typeName = strings.ToLower(r.Kind)
+	}
+	if r.Group != "" {
+		typeName = fmt.Sprintf("%s.%s", typeName, r.Group)
+	}
+	return typeName
+}
+
 // GetConfigmapVariablesEncoded returns Data["variables"] content from Configmap
 func GetConfigmapVariablesEncoded(ctx context.Context, name, namespace string, c kubernetes.Interface) (string, error) {
 	cmap, err := configmaps.Get(ctx, TranslatePipelineName(name), namespace, c)
@@ -251,6 +306,102 @@ func AddPhaseDuration(ctx context.Context, name, namespace, phase string, durati
 	return configmaps.Deploy(ctx, cmap, cmap.Namespace, c)
 }
 
+// GetAppliedResources returns the resources applied through the deploy proxy by the dev environment
+func GetAppliedResources(ctx context.Context, name, namespace string, c kubernetes.Interface) ([]AppliedResource, error) {
+	cmap, err := configmaps.Get(ctx, TranslatePipelineName(name), namespace, c)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAppliedResources(cmap)
+}
+
+// UpdateAppliedResources merges the resources applied and deleted in the last deploy into the inventory of the
+// dev environment. Applied resources are added or updated and deleted resources are removed
+func UpdateAppliedResources(ctx context.Context, name, namespace string, changes []AppliedResource, c kubernetes.Interface) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	cmap, err := configmaps.Get(ctx, TranslatePipelineName(name), namespace, c)
+	if err != nil {
+		return err
+	}
+	current, err := decodeAppliedResources(cmap)
+	if err != nil {
+		return err
+	}
+
+	inventory := map[string]AppliedResource{}
+	for _, r := range current {
+		inventory[r.Key()] = r
+	}
+	for _, r := range changes {
+		if r.Deleted {
+			delete(inventory, r.Key())
+			continue
+		}
+		if previous, ok := inventory[r.Key()]; ok && r.Kind == "" {
+			r.Kind = previous.Kind
+		}
+		inventory[r.Key()] = r
+	}
+
+	resources := make([]AppliedResource, 0, len(inventory))
+	for _, r := range inventory {
+		resources = append(resources, r)
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].Key() < resources[j].Key()
+	})
+
+	if cmap.Data == nil {
+		cmap.Data = map[string]string{}
+	}
+	if len(resources) == 0 {
+		delete(cmap.Data, appliedResourcesField)
+	} else {
+		encoded, err := json.Marshal(resources)
+		if err != nil {
+			return fmt.Errorf("failed to encode applied resources: %w", err)
+		}
+		cmap.Data[appliedResourcesField] = string(encoded)
+	}
+	return configmaps.Deploy(ctx, cmap, cmap.Namespace, c)
+}
+
+func decodeAppliedResources(cmap *apiv1.ConfigMap) ([]AppliedResource, error) {
+	val, ok := cmap.Data[appliedResourcesField]
+	if !ok {
+		return nil, nil
+	}
+	resources := []AppliedResource{}
+	if err := json.Unmarshal([]byte(val), &resources); err != nil {
+		return nil, fmt.Errorf("failed to decode applied resources: %w", err)
+	}
+	return resources, nil
+}
+
+// UpdateHealthChecks stores the results of the last run of the deploy health checks
+func UpdateHealthChecks(ctx context.Context, name, namespace string, results []HealthCheckResult, c kubernetes.Interface) error {
+	cmap, err := configmaps.Get(ctx, TranslatePipelineName(name), namespace, c)
+	if err != nil {
+		return err
+	}
+
+	if cmap.Data == nil {
+		cmap.Data = map[string]string{}
+	}
+	if len(results) == 0 {
+		delete(cmap.Data, healthChecksField)
+	} else {
+		encoded, err := json.Marshal(results)
+		if err != nil {
+			return fmt.Errorf("failed to encode health checks: %w", err)
+		}
+		cmap.Data[healthChecksField] = string(encoded)
+	}
+	return configmaps.Deploy(ctx, cmap, cmap.Namespace, c)
+}
+
 func SetBuildEnvVars(ctx context.Context, cmapName, ns string, envVars map[string]map[string]string, c kubernetes.Interface) error {
 	cmap, err := configmaps.Get(ctx, TranslatePipelineName(cmapName), ns, c)
 	if err != nil {
@@ -319,7 +470,7 @@ func translateOutput(output *bytes.Buffer) []byte {
 	} else {
 		data = output.Bytes()
 	}
-	return data
+	return []byte(oktetoLog.MaskSecrets(string(data)))
 }
 
 // translateConfigMapSandBox creates a configmap adding data from a config data
@@ -493,3 +493,70 @@ func TestSetBuildEnvVars(t *testing.T) {
 		assert.False(t, exists)
 	})
 }
+
+func Test_UpdateAppliedResources(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset(&apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TranslatePipelineName("test"),
+			Namespace: "ns",
+		},
+		Data: map[string]string{
+			appliedResourcesField: `[{"group":"apps","version":"v1","resource":"deployments","kind":"Deployment","namespace":"ns","name":"api"},{"version":"v1","resource":"configmaps","kind":"ConfigMap","namespace":"ns","name":"old"}]`,
+		},
+	})
+
+	changes := []AppliedResource{
+		{Group: "apps", Version: "v1", Resource: "deployments", Namespace: "ns", Name: "api"},
+		{Version: "v1", Resource: "configmaps", Namespace: "ns", Name: "old", Deleted: true},
+		{Version: "v1", Resource: "services", Kind: "Service", Namespace: "ns", Name: "api"},
+	}
+	require.NoError(t, UpdateAppliedResources(ctx, "test", "ns", changes, c))
+
+	resources, err := GetAppliedResources(ctx, "test", "ns", c)
+	require.NoError(t, err)
+	expected := []AppliedResource{
+		{Version: "v1", Resource: "services", Kind: "Service", Namespace: "ns", Name: "api"},
+		{Group: "apps", Version: "v1", Resource: "deployments", Kind: "Deployment", Namespace: "ns", Name: "api"},
+	}
+	assert.Equal(t, expected, resources)
+
+	changes = []AppliedResource{
+		{Version: "v1", Resource: "services", Namespace: "ns", Name: "api", Deleted: true},
+		{Group: "apps", Version: "v1", Resource: "deployments", Namespace: "ns", Name: "api", Deleted: true},
+	}
+	require.NoError(t, UpdateAppliedResources(ctx, "test", "ns", changes, c))
+	cmap, err := c.CoreV1().ConfigMaps("ns").Get(ctx, TranslatePipelineName("test"), metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotContains(t, cmap.Data, appliedResourcesField)
+}
+
+func TestAppliedResourceTypeName(t *testing.T) {
+	assert.Equal(t, "deployment.apps", AppliedResource{Group: "apps", Resource: "deployments", Kind: "Deployment"}.TypeName())
+	assert.Equal(t, "configmaps", AppliedResource{Resource: "configmaps"}.TypeName())
+}
+
+func Test_UpdateHealthChecks(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset(&apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TranslatePipelineName("test"),
+			Namespace: "ns",
+		},
+	})
+
+	results := []HealthCheckResult{
+		{Name: "frontend", Type: "http", Status: HealthCheckPassed, Attempts: 1, Duration: 0.5},
+		{Name: "api", Type: "exec", Status: HealthCheckFailed, Attempts: 3, Duration: 12, Error: "exit code 1"},
+	}
+	require.NoError(t, UpdateHealthChecks(ctx, "test", "ns", results, c))
+	cmap, err := c.CoreV1().ConfigMaps("ns").Get(ctx, TranslatePipelineName("test"), metav1.GetOptions{})
+	require.NoError(t, err)
+	expected := `[{"name":"frontend","type":"http","status":"passed","duration":0.5,"attempts":1},{"name":"api","type":"exec","status":"failed","error":"exit code 1","duration":12,"attempts":3}]`
+	assert.JSONEq(t, expected, cmap.Data[healthChecksField])
+
+	require.NoError(t, UpdateHealthChecks(ctx, "test", "ns", nil, c))
+	cmap, err = c.CoreV1().ConfigMaps("ns").Get(ctx, TranslatePipelineName("test"), metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotContains(t, cmap.Data, healthChecksField)
+}
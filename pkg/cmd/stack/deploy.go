@@ -21,18 +21,23 @@ import (
 	"net"
 	"os"
 	"os/signal"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/okteto/okteto/pkg/analytics"
+	"github.com/okteto/okteto/pkg/env"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/format"
 	"github.com/okteto/okteto/pkg/k8s/configmaps"
 	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/k8s/endpoints"
 	forwardK8s "github.com/okteto/okteto/pkg/k8s/forward"
 	"github.com/okteto/okteto/pkg/k8s/jobs"
 	"github.com/okteto/okteto/pkg/k8s/pods"
+	"github.com/okteto/okteto/pkg/k8s/secrets"
+	"github.com/okteto/okteto/pkg/k8s/serviceaccounts"
 	"github.com/okteto/okteto/pkg/k8s/services"
 	"github.com/okteto/okteto/pkg/k8s/statefulsets"
 	"github.com/okteto/okteto/pkg/k8s/volumes"
@@ -47,6 +52,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
 )
 
 // DeployOptions represents the different options available for stack commands
@@ -61,6 +67,12 @@ type DeployOptions struct {
 	Wait             bool
 	NoCache          bool
 	InsidePipeline   bool
+	// ResolveDigests deploys the images of the services with their digest instead of their tag
+	ResolveDigests bool
+	// SkipUnresolvable deploys the images that can't be resolved to a digest with their tag
+	SkipUnresolvable bool
+	// AllowPrivileged deploys the services with 'privileged' or 'devices'
+	AllowPrivileged bool
 }
 
 type buildTrackerInterface interface {
@@ -82,6 +94,8 @@ type Stack struct {
 	IoCtrl           *io.Controller
 	Divert           Divert
 	EndpointDeployer EndpointDeployer
+	// DigestResolver resolves the images to digests when ResolveDigests is set. The okteto registry is used if it's nil
+	DigestResolver DigestResolver
 }
 
 const (
@@ -178,18 +192,45 @@ func (sd *Stack) deployCompose(ctx context.Context, s *model.Stack, options *Dep
 		return err
 	}
 
+	if err := s.ValidatePrivileged(options.ServicesToDeploy, options.AllowPrivileged || env.LoadBoolean(model.OktetoAllowPrivilegedEnvVar)); err != nil {
+		return err
+	}
+
 	if !options.InsidePipeline {
 		if err := buildStackImages(ctx, s, options, sd.AnalyticsTracker, sd.Insights, sd.IoCtrl); err != nil {
 			return err
 		}
 	}
 
+	var digests map[string]string
+	if options.ResolveDigests {
+		resolver := sd.DigestResolver
+		if resolver == nil {
+			resolver = registry.NewOktetoRegistry(okteto.Config{})
+		}
+		var err error
+		digests, err = resolveImageDigests(s, options.ServicesToDeploy, resolver, options.SkipUnresolvable)
+		if err != nil {
+			return err
+		}
+	}
+
+	overrides := getDeployedReplicaOverrides(ctx, s, sd.K8sClient)
+	applyReplicaOverrides(s, overrides)
+
+	unchanged := isDeployedAndUnchanged(ctx, s, sd.K8sClient)
 	cfg := translateConfigMap(s)
+	setImageDigests(cfg, digests)
+	setReplicaOverrides(cfg, overrides)
 	output := fmt.Sprintf("Deploying compose '%s'...", s.Name)
-	cfg.Data[statusField] = progressingStatus
-	cfg.Data[outputField] = base64.StdEncoding.EncodeToString([]byte(output))
-	if err := configmaps.Deploy(ctx, cfg, s.Namespace, sd.K8sClient); err != nil {
-		return err
+	if unchanged {
+		oktetoLog.Infof("compose '%s' has not changed since the last deploy, skipping configmap updates", s.Name)
+	} else {
+		cfg.Data[statusField] = progressingStatus
+		cfg.Data[outputField] = base64.StdEncoding.EncodeToString([]byte(output))
+		if err := deployStackConfigMap(ctx, cfg, s.Namespace, sd.K8sClient); err != nil {
+			return err
+		}
 	}
 
 	err := deploy(ctx, s, sd.K8sClient, sd.Config, options, sd.Divert, sd.EndpointDeployer)
@@ -198,18 +239,45 @@ func (sd *Stack) deployCompose(ctx context.Context, s *model.Stack, options *Dep
 		cfg.Data[statusField] = errorStatus
 		cfg.Data[outputField] = base64.StdEncoding.EncodeToString([]byte(output))
 	} else {
+		if unchanged {
+			return nil
+		}
 		output = fmt.Sprintf("%s\nCompose '%s' successfully deployed", output, s.Name)
 		cfg.Data[statusField] = deployedStatus
 		cfg.Data[outputField] = base64.StdEncoding.EncodeToString([]byte(output))
 	}
 
-	if err := configmaps.Deploy(ctx, cfg, s.Namespace, sd.K8sClient); err != nil {
+	if err := deployStackConfigMap(ctx, cfg, s.Namespace, sd.K8sClient); err != nil {
 		return err
 	}
 
 	return err
 }
 
+// getDeployedReplicaOverrides returns the replicas persisted by 'okteto stack scale --persist' in the live stack configmap
+func getDeployedReplicaOverrides(ctx context.Context, s *model.Stack, c kubernetes.Interface) map[string]int32 {
+	live, err := getLiveStackConfigMap(ctx, s.Name, s.Namespace, c)
+	if err != nil {
+		if !oktetoErrors.IsNotFound(err) {
+			oktetoLog.Infof("error getting configmap of compose '%s': %s", s.Name, err)
+		}
+		return nil
+	}
+	return getReplicaOverrides(live)
+}
+
+// isDeployedAndUnchanged returns true when the last deploy of the stack succeeded and its model hasn't changed since then
+func isDeployedAndUnchanged(ctx context.Context, s *model.Stack, c kubernetes.Interface) bool {
+	live, err := getLiveStackConfigMap(ctx, s.Name, s.Namespace, c)
+	if err != nil {
+		if !oktetoErrors.IsNotFound(err) {
+			oktetoLog.Infof("error getting configmap of compose '%s': %s", s.Name, err)
+		}
+		return false
+	}
+	return live.Data[statusField] == deployedStatus && !HasChanged(live, s)
+}
+
 // deploy deploys a stack to kubernetes
 func deploy(ctx context.Context, s *model.Stack, c kubernetes.Interface, config *rest.Config, options *DeployOptions, divert Divert, endpointDeployer EndpointDeployer) error {
 	DisplayWarnings(s)
@@ -245,11 +313,7 @@ func deploy(ctx context.Context, s *model.Stack, c kubernetes.Interface, config
 			// get the public ports from the compose service - this will be deployed into ingresses/httproutes
 			ingressPortsToDeploy := getSvcPublicPorts(serviceName, s)
 			for _, ingressPort := range ingressPortsToDeploy {
-				ingressName := serviceName
-				// If more than one port, ingressName will have <serviceName>-<PORT>, each port will have an ingress/httproute
-				if len(ingressPortsToDeploy) > 1 {
-					ingressName = fmt.Sprintf("%s-%d", serviceName, ingressPort.ContainerPort)
-				}
+				ingressName := getServiceIngressName(serviceName, ingressPort, len(ingressPortsToDeploy))
 
 				if err := endpointDeployer.DeployServiceEndpoint(ctx, ingressName, serviceName, ingressPort, s); err != nil {
 					exit <- err
@@ -270,6 +334,28 @@ func deploy(ctx context.Context, s *model.Stack, c kubernetes.Interface, config
 			}
 		}
 
+		if err := deployConfigs(ctx, s, servicesToDeploySet, c); err != nil {
+			exit <- err
+			return
+		}
+
+		if err := deploySecrets(ctx, s, servicesToDeploySet, c); err != nil {
+			exit <- err
+			return
+		}
+
+		if err := deployExternalServices(ctx, s, c); err != nil {
+			exit <- err
+			return
+		}
+
+		if err := deployServiceAccounts(ctx, s, servicesToDeploySet, c); err != nil {
+			exit <- err
+			return
+		}
+
+		checkPriorityClasses(ctx, s, servicesToDeploySet, c)
+
 		if err := deployServices(ctx, s, c, config, options, divert); err != nil {
 			exit <- err
 			return
@@ -279,23 +365,7 @@ func deploy(ctx context.Context, s *model.Stack, c kubernetes.Interface, config
 		// each endpoint gets an ingress/httproute when using the endpoints spec at compose
 		// the endpoint would have paths for services as defined at the spec
 		for _, endpointName := range getEndpointsToDeployFromServicesToDeploy(s.Endpoints, servicesToDeploySet) {
-			endpoint := s.Endpoints[endpointName]
-			// initialize the maps for Labels and Annotations if nil
-			if endpoint.Labels == nil {
-				endpoint.Labels = map[string]string{}
-			}
-			if endpoint.Annotations == nil {
-				endpoint.Annotations = map[string]string{}
-			}
-
-			// add specific stack labels
-			if _, ok := endpoint.Labels[model.StackNameLabel]; !ok {
-				endpoint.Labels[model.StackNameLabel] = format.ResourceK8sMetaString(s.Name)
-			}
-			if _, ok := endpoint.Labels[model.StackEndpointNameLabel]; !ok {
-				endpoint.Labels[model.StackEndpointNameLabel] = endpointName
-			}
-
+			endpoint := translateComposeEndpoint(endpointName, s)
 			if err := endpointDeployer.DeployComposeEndpoint(ctx, endpointName, endpoint, s); err != nil {
 				exit <- err
 				return
@@ -313,7 +383,12 @@ func deploy(ctx context.Context, s *model.Stack, c kubernetes.Interface, config
 		}
 
 		oktetoLog.Spinner("Waiting for services to be ready...")
-		exit <- waitForPodsToBeRunning(ctx, s, options.ServicesToDeploy, c)
+		if err := waitForPodsToBeRunning(ctx, s, options.ServicesToDeploy, c); err != nil {
+			reportServiceFailures(ctx, s.Namespace, s.Name, options.ServicesToDeploy, c)
+			exit <- err
+			return
+		}
+		exit <- nil
 	}()
 
 	select {
@@ -381,6 +456,7 @@ func deployServices(ctx context.Context, stack *model.Stack, k8sClient kubernete
 	for {
 		select {
 		case <-to.C:
+			reportServiceFailures(ctx, stack.Namespace, stack.Name, options.ServicesToDeploy, k8sClient)
 			return fmt.Errorf("compose '%s' didn't finish after %s", stack.Name, options.Timeout.String())
 		case <-t.C:
 			for len(deployedSvcs) != len(options.ServicesToDeploy) {
@@ -417,6 +493,7 @@ func deployServices(ctx context.Context, stack *model.Stack, k8sClient kubernete
 							}
 						}
 						if err := getErrorDueToRestartLimit(ctx, stack, svcName, restartsPerSvc, k8sClient); err != nil {
+							reportServiceFailures(ctx, stack.Namespace, stack.Name, options.ServicesToDeploy, k8sClient)
 							return err
 						}
 						continue
@@ -635,6 +712,7 @@ func isAnyPortAvailable(ctx context.Context, svc *model.Service, stack *model.St
 
 func deployK8sService(ctx context.Context, svcName string, s *model.Stack, c kubernetes.Interface) error {
 	svcK8s := translateService(svcName, s)
+	setResourceHash(&svcK8s.ObjectMeta, svcK8s.Spec)
 	old, err := services.Get(ctx, svcName, s.Namespace, c)
 	if err != nil {
 		if !oktetoErrors.IsNotFound(err) {
@@ -657,6 +735,11 @@ func deployK8sService(ctx context.Context, svcName string, s *model.Stack, c kub
 		return nil
 	}
 
+	if isResourceUpToDate(old, svcK8s) {
+		oktetoLog.Infof("kubernetes service '%s' is up to date, skipping update", svcName)
+		return nil
+	}
+
 	svcK8s.ObjectMeta.ResourceVersion = old.ObjectMeta.ResourceVersion
 	if err := services.Deploy(ctx, svcK8s, c); err != nil {
 		return err
@@ -667,6 +750,7 @@ func deployK8sService(ctx context.Context, svcName string, s *model.Stack, c kub
 
 func deployDeployment(ctx context.Context, svcName string, s *model.Stack, c kubernetes.Interface, divert Divert) (bool, error) {
 	d := translateDeployment(svcName, s, divert)
+	setMountedContentHash(&d.Spec.Template.ObjectMeta, svcName, s)
 	old, err := c.AppsV1().Deployments(s.Namespace).Get(ctx, svcName, metav1.GetOptions{})
 	if err != nil && !oktetoErrors.IsNotFound(err) {
 		return false, fmt.Errorf("error getting deployment of service '%s': %w", svcName, err)
@@ -692,6 +776,12 @@ func deployDeployment(ctx context.Context, svcName string, s *model.Stack, c kub
 		}
 	}
 
+	setResourceHash(&d.ObjectMeta, d.Spec)
+	if !isNewDeployment && isResourceUpToDate(old, d) && ptr.Equal(old.Spec.Replicas, d.Spec.Replicas) {
+		oktetoLog.Infof("deployment '%s' is up to date, skipping update", svcName)
+		return false, nil
+	}
+
 	if !isNewDeployment && old.Labels[model.StackNameLabel] == "okteto" {
 		if err := deployments.Destroy(ctx, old.Name, old.Namespace, c); err != nil {
 			return false, fmt.Errorf("error updating deployment of service '%s': %w", svcName, err)
@@ -714,11 +804,13 @@ func deployDeployment(ctx context.Context, svcName string, s *model.Stack, c kub
 
 func deployStatefulSet(ctx context.Context, svcName string, s *model.Stack, c kubernetes.Interface, divert Divert) (bool, error) {
 	sfs := translateStatefulSet(svcName, s, divert)
+	setMountedContentHash(&sfs.Spec.Template.ObjectMeta, svcName, s)
 	old, err := c.AppsV1().StatefulSets(s.Namespace).Get(ctx, svcName, metav1.GetOptions{})
 	if err != nil && !oktetoErrors.IsNotFound(err) {
 		return false, fmt.Errorf("error getting statefulset of service '%s': %w", svcName, err)
 	}
 	if old == nil || old.Name == "" {
+		setResourceHash(&sfs.ObjectMeta, sfs.Spec)
 		if _, err := statefulsets.Deploy(ctx, sfs, c); err != nil {
 			return false, fmt.Errorf("error creating statefulset of service '%s': %w", svcName, err)
 		}
@@ -737,6 +829,11 @@ func deployStatefulSet(ctx context.Context, svcName string, s *model.Stack, c ku
 			sfs.Labels[model.DeployedByLabel] = format.ResourceK8sMetaString(s.Name)
 		}
 	}
+	setResourceHash(&sfs.ObjectMeta, sfs.Spec)
+	if isResourceUpToDate(old, sfs) && ptr.Equal(old.Spec.Replicas, sfs.Spec.Replicas) {
+		oktetoLog.Infof("statefulset '%s' is up to date, skipping update", svcName)
+		return false, nil
+	}
 	if _, err := statefulsets.Deploy(ctx, sfs, c); err != nil {
 		if !strings.Contains(err.Error(), "Forbidden: updates to statefulset spec") {
 			return false, fmt.Errorf("error updating statefulset of service '%s': %w", svcName, err)
@@ -768,10 +865,14 @@ func deployJob(ctx context.Context, svcName string, s *model.Stack, c kubernetes
 		}
 	}
 
+	setMountedContentHash(&job.Spec.Template.ObjectMeta, svcName, s)
+	setResourceHash(&job.ObjectMeta, job.Spec)
 	if isNewJob {
 		if err := jobs.Create(ctx, job, c); err != nil {
 			return false, fmt.Errorf("error creating job of service '%s': %w", svcName, err)
 		}
+	} else if isResourceUpToDate(old, job) {
+		oktetoLog.Infof("job '%s' is up to date, skipping update", svcName)
 	} else {
 		if err := jobs.Update(ctx, job, c); err != nil {
 			return false, fmt.Errorf("error updating job of service '%s': %w", svcName, err)
@@ -824,6 +925,205 @@ func deployVolume(ctx context.Context, volumeName string, s *model.Stack, c kube
 	return nil
 }
 
+// deployConfigs creates or updates the configmaps of the configs mounted by the services to deploy
+func deployConfigs(ctx context.Context, s *model.Stack, servicesToDeploy map[string]bool, c kubernetes.Interface) error {
+	configsToDeploy := map[string]bool{}
+	for svcName := range servicesToDeploy {
+		svc, ok := s.Services[svcName]
+		if !ok {
+			continue
+		}
+		for _, cfg := range svc.Configs {
+			configsToDeploy[cfg.Source] = true
+		}
+	}
+	names := []string{}
+	for name := range configsToDeploy {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		cm, err := translateConfig(name, s)
+		if err != nil {
+			return err
+		}
+		if err := configmaps.Deploy(ctx, cm, s.Namespace, c); err != nil {
+			return fmt.Errorf("error deploying config '%s': %w", name, err)
+		}
+		oktetoLog.Success("Config '%s' deployed", name)
+	}
+	return nil
+}
+
+// deploySecrets creates or updates the secrets mounted by the services being deployed.
+// The values are only stored in the secrets, never in the stack configmap
+func deploySecrets(ctx context.Context, s *model.Stack, servicesToDeploy map[string]bool, c kubernetes.Interface) error {
+	secretsToDeploy := map[string]bool{}
+	for svcName := range servicesToDeploy {
+		svc, ok := s.Services[svcName]
+		if !ok {
+			continue
+		}
+		for _, secret := range svc.Secrets {
+			secretsToDeploy[secret.Source] = true
+		}
+	}
+	names := []string{}
+	for name := range secretsToDeploy {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		secret, err := translateSecret(name, s)
+		if err != nil {
+			return err
+		}
+		if err := secrets.Deploy(ctx, secret, s.Namespace, c); err != nil {
+			return fmt.Errorf("error deploying secret '%s': %w", name, err)
+		}
+		oktetoLog.Success("Secret '%s' deployed", name)
+	}
+	return nil
+}
+
+// deployExternalServices creates or updates the services giving a stable name to the external services of the stack
+func deployExternalServices(ctx context.Context, s *model.Stack, c kubernetes.Interface) error {
+	names := []string{}
+	for name := range s.ExternalServices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		svc, slice := translateExternalService(name, s)
+		old, err := services.Get(ctx, name, s.Namespace, c)
+		if err != nil && !oktetoErrors.IsNotFound(err) {
+			return fmt.Errorf("error getting service '%s': %w", name, err)
+		}
+		if err == nil && old.GetLabels()[model.StackNameLabel] != svc.GetLabels()[model.StackNameLabel] {
+			oktetoLog.Warning("skipping deploy of external service '%s' due to name collision: the service '%s' was running before deploying your compose", name, name)
+			continue
+		}
+		if err := services.Deploy(ctx, svc, c); err != nil {
+			return fmt.Errorf("error deploying external service '%s': %w", name, err)
+		}
+		if slice != nil {
+			if err := endpoints.Deploy(ctx, slice, c); err != nil {
+				return fmt.Errorf("error deploying external service '%s': %w", name, err)
+			}
+		} else if err := endpoints.Destroy(ctx, name, s.Namespace, c); err != nil {
+			return fmt.Errorf("error deploying external service '%s': %w", name, err)
+		}
+		oktetoLog.Success("External service '%s' deployed", name)
+	}
+	return nil
+}
+
+// deployServiceAccounts creates the service accounts flagged with 'x-okteto-create-serviceaccount' and warns
+// about the ones that don't exist yet, as they might be created by the deploy section of the manifest
+func deployServiceAccounts(ctx context.Context, s *model.Stack, servicesToDeploy map[string]bool, c kubernetes.Interface) error {
+	checked := map[string]bool{}
+	for _, svcName := range getServicesWithServiceAccount(s, servicesToDeploy) {
+		svc := s.Services[svcName]
+		if checked[svc.ServiceAccount] {
+			continue
+		}
+		checked[svc.ServiceAccount] = true
+		if !s.IsServiceAccountCreated(svc.ServiceAccount) {
+			_, err := serviceaccounts.Get(ctx, svc.ServiceAccount, s.Namespace, c)
+			if err == nil {
+				continue
+			}
+			if !oktetoErrors.IsNotFound(err) {
+				return fmt.Errorf("error getting service account '%s': %w", svc.ServiceAccount, err)
+			}
+			oktetoLog.Warning("service account '%s' used by service '%s' doesn't exist. Set 'x-okteto-create-serviceaccount: true' to create it with your compose", svc.ServiceAccount, svcName)
+			continue
+		}
+		if err := deployServiceAccount(ctx, svc.ServiceAccount, s, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkPriorityClasses warns about the priority classes used by the services that don't exist.
+// Priority classes are cluster-scoped, so they might be created later by a cluster admin
+func checkPriorityClasses(ctx context.Context, s *model.Stack, servicesToDeploy map[string]bool, c kubernetes.Interface) {
+	svcNames := []string{}
+	for svcName := range servicesToDeploy {
+		if svc, ok := s.Services[svcName]; ok && svc.PriorityClassName != "" {
+			svcNames = append(svcNames, svcName)
+		}
+	}
+	sort.Strings(svcNames)
+
+	checked := map[string]bool{}
+	for _, svcName := range svcNames {
+		className := s.Services[svcName].PriorityClassName
+		if checked[className] {
+			continue
+		}
+		checked[className] = true
+		_, err := c.SchedulingV1().PriorityClasses().Get(ctx, className, metav1.GetOptions{})
+		if err == nil {
+			continue
+		}
+		if !oktetoErrors.IsNotFound(err) {
+			oktetoLog.Infof("could not check priority class '%s': %s", className, err)
+			continue
+		}
+		oktetoLog.Warning("priority class '%s' used by service '%s' doesn't exist. The pods of the service won't be created until the priority class exists", className, svcName)
+	}
+}
+
+func getServicesWithServiceAccount(s *model.Stack, servicesToDeploy map[string]bool) []string {
+	result := []string{}
+	for svcName := range servicesToDeploy {
+		svc, ok := s.Services[svcName]
+		if !ok || svc.ServiceAccount == "" {
+			continue
+		}
+		result = append(result, svcName)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func deployServiceAccount(ctx context.Context, name string, s *model.Stack, c kubernetes.Interface) error {
+	sa := translateServiceAccount(name, s)
+
+	old, err := serviceaccounts.Get(ctx, name, s.Namespace, c)
+	if err != nil && !oktetoErrors.IsNotFound(err) {
+		return fmt.Errorf("error getting service account '%s': %w", name, err)
+	}
+	if old == nil || old.Name == "" {
+		if err := serviceaccounts.Create(ctx, sa, c); err != nil {
+			return fmt.Errorf("error creating service account '%s': %w", name, err)
+		}
+		oktetoLog.Success("Service account '%s' created", name)
+		return nil
+	}
+
+	if old.Labels[model.StackNameLabel] == "" {
+		oktetoLog.Warning("skipping creation of service account '%s' due to name collision with pre-existing service account", name)
+		return nil
+	}
+	if old.Labels[model.StackNameLabel] != format.ResourceK8sMetaString(s.Name) {
+		oktetoLog.Warning("skipping creation of service account '%s' due to name collision with service account in stack '%s'", name, old.Labels[model.StackNameLabel])
+		return nil
+	}
+	for key, value := range sa.Labels {
+		old.Labels[key] = value
+	}
+	if err := serviceaccounts.Update(ctx, old, c); err != nil {
+		return fmt.Errorf("error updating service account '%s': %w", name, err)
+	}
+	return nil
+}
+
 func waitForPodsToBeRunning(ctx context.Context, s *model.Stack, servicesToDeploy []string, c kubernetes.Interface) error {
 	var numPods int32 = 0
 	cacheServicesToDeploy := map[string]bool{}
@@ -870,8 +1170,21 @@ func waitForPodsToBeRunning(ctx context.Context, s *model.Stack, servicesToDeplo
 
 func DisplayWarnings(s *model.Stack) {
 	DisplayNotSupportedFieldsWarnings(model.GroupWarningsBySvc(s.Warnings.NotSupportedFields))
+	DisplayHostAccessWarnings(s.Warnings.HostAccessFields)
 	DisplayVolumeMountWarnings(s.Warnings.VolumeMountWarnings)
 	DisplaySanitizedServicesWarnings(s.Warnings.SanitizedServices)
+	DisplayNewerVersionFieldsWarnings(s.Warnings.NewerVersionFields)
+}
+
+// DisplayNewerVersionFieldsWarnings warns about the fields ignored because they are not understood by this version of okteto
+func DisplayNewerVersionFieldsWarnings(warnings []string) {
+	if len(warnings) == 0 {
+		return
+	}
+	for _, warning := range warnings {
+		oktetoLog.Warning("%s: it will be ignored", warning)
+	}
+	oktetoLog.Hint("    Upgrade okteto to use these fields, or run the command with '--strict' to fail instead of ignoring them")
 }
 
 func DisplayNotSupportedFieldsWarnings(warnings []string) {
@@ -886,6 +1199,15 @@ func DisplayNotSupportedFieldsWarnings(warnings []string) {
 	}
 }
 
+// DisplayHostAccessWarnings explains how to deploy the 'pid', 'ipc' and 'network_mode' fields ignored because host access is not allowed
+func DisplayHostAccessWarnings(fields []string) {
+	if len(fields) == 0 {
+		return
+	}
+	oktetoLog.Warning("'pid: host', 'ipc: host' and 'network_mode: host' give the containers access to the node where they run and are ignored unless explicitly allowed: %s", strings.Join(model.GroupWarningsBySvc(fields), ", "))
+	oktetoLog.Hint("    Run the command with '--allow-host-access' or set '%s=true' to share the namespaces of the node", model.OktetoAllowHostAccessEnvVar)
+}
+
 func DisplayVolumeMountWarnings(warnings []string) {
 	for _, warning := range warnings {
 		oktetoLog.Warning("%s", warning)
@@ -17,10 +17,12 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/okteto/okteto/pkg/build"
 	"github.com/okteto/okteto/pkg/divert"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/format"
 	"github.com/okteto/okteto/pkg/k8s/services"
 	"github.com/okteto/okteto/pkg/model"
@@ -30,9 +32,12 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	apiv1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
 )
 
 func TestMain(m *testing.M) {
@@ -254,6 +259,98 @@ func Test_deployDeployment(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func Test_deployDeploymentRollsOutMountedContentChanges(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	nginxFile := filepath.Join(dir, "nginx.conf")
+	require.NoError(t, os.WriteFile(nginxFile, []byte("worker_processes 1;"), 0600))
+	t.Setenv("DB_PASSWORD", "secret-1")
+
+	stack := &model.Stack{
+		Namespace: "ns",
+		Name:      "stack-test",
+		Configs:   map[string]*model.ConfigSpec{"nginx": {File: nginxFile}},
+		Secrets:   map[string]*model.SecretSpec{"db": {Environment: "DB_PASSWORD"}},
+		Services: map[string]*model.Service{
+			"test": {
+				Image:         "test_image",
+				RestartPolicy: apiv1.RestartPolicyAlways,
+				Configs:       []model.ServiceConfig{{Source: "nginx", Target: "/etc/nginx/nginx.conf"}},
+				Secrets:       []model.ServiceSecret{{Source: "db", Target: "/run/secrets/db"}},
+			},
+		},
+	}
+	client := fake.NewSimpleClientset()
+	getTemplateHash := func() string {
+		d, err := client.AppsV1().Deployments("ns").Get(ctx, "test", metav1.GetOptions{})
+		require.NoError(t, err)
+		return d.Spec.Template.Annotations[model.OktetoComposeMountedContentHashAnnotation]
+	}
+
+	_, err := deployDeployment(ctx, "test", stack, client, divert.NewNoop())
+	require.NoError(t, err)
+	first := getTemplateHash()
+	require.NotEmpty(t, first)
+
+	_, err = deployDeployment(ctx, "test", stack, client, divert.NewNoop())
+	require.NoError(t, err)
+	require.Equal(t, first, getTemplateHash())
+
+	require.NoError(t, os.WriteFile(nginxFile, []byte("worker_processes 2;"), 0600))
+	_, err = deployDeployment(ctx, "test", stack, client, divert.NewNoop())
+	require.NoError(t, err)
+	second := getTemplateHash()
+	require.NotEqual(t, first, second)
+
+	t.Setenv("DB_PASSWORD", "secret-2")
+	_, err = deployDeployment(ctx, "test", stack, client, divert.NewNoop())
+	require.NoError(t, err)
+	require.NotEqual(t, second, getTemplateHash())
+}
+
+func Test_deployJobRerunsOnMountedContentChanges(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	migrationsFile := filepath.Join(dir, "migrations.sql")
+	require.NoError(t, os.WriteFile(migrationsFile, []byte("CREATE TABLE users;"), 0600))
+
+	stack := &model.Stack{
+		Namespace: "ns",
+		Name:      "stack-test",
+		Configs:   map[string]*model.ConfigSpec{"migrations": {File: migrationsFile}},
+		Services: map[string]*model.Service{
+			"migrate": {
+				Image:         "test_image",
+				RestartPolicy: apiv1.RestartPolicyNever,
+				Configs:       []model.ServiceConfig{{Source: "migrations", Target: "/migrations.sql"}},
+			},
+		},
+	}
+	client := fake.NewSimpleClientset()
+	getTemplateHash := func() string {
+		job, err := client.BatchV1().Jobs("ns").Get(ctx, "migrate", metav1.GetOptions{})
+		require.NoError(t, err)
+		return job.Spec.Template.Annotations[model.OktetoComposeMountedContentHashAnnotation]
+	}
+
+	_, err := deployJob(ctx, "migrate", stack, client, divert.NewNoop())
+	require.NoError(t, err)
+	first := getTemplateHash()
+	require.NotEmpty(t, first)
+
+	client.ClearActions()
+	_, err = deployJob(ctx, "migrate", stack, client, divert.NewNoop())
+	require.NoError(t, err)
+	for _, action := range client.Actions() {
+		require.Equal(t, "get", action.GetVerb(), "unexpected %s on %s", action.GetVerb(), action.GetResource().Resource)
+	}
+
+	require.NoError(t, os.WriteFile(migrationsFile, []byte("CREATE TABLE orders;"), 0600))
+	_, err = deployJob(ctx, "migrate", stack, client, divert.NewNoop())
+	require.NoError(t, err)
+	require.NotEqual(t, first, getTemplateHash())
+}
+
 func Test_deployVolumes(t *testing.T) {
 	ctx := context.Background()
 	stack := &model.Stack{
@@ -337,6 +434,218 @@ func Test_deployJob(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func Test_deployServiceAccounts(t *testing.T) {
+	ctx := context.Background()
+	stack := &model.Stack{
+		Namespace: "ns",
+		Name:      "stack-test",
+		Services: map[string]*model.Service{
+			"api": {
+				Image:                "test_image",
+				ServiceAccount:       "created-sa",
+				CreateServiceAccount: true,
+			},
+			"worker": {
+				Image:          "test_image",
+				ServiceAccount: "created-sa",
+			},
+			"db": {
+				Image:          "test_image",
+				ServiceAccount: "missing-sa",
+			},
+			"other": {
+				Image:                "test_image",
+				ServiceAccount:       "not-deployed-sa",
+				CreateServiceAccount: true,
+			},
+		},
+	}
+	client := fake.NewSimpleClientset()
+
+	err := deployServiceAccounts(ctx, stack, map[string]bool{"api": true, "worker": true, "db": true}, client)
+	require.NoError(t, err)
+
+	sa, err := client.CoreV1().ServiceAccounts("ns").Get(ctx, "created-sa", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "stack-test", sa.Labels[model.StackNameLabel])
+	require.Equal(t, "stack-test", sa.Labels[model.DeployedByLabel])
+
+	// missing service accounts only show a warning, as they might be created by the deploy section
+	_, err = client.CoreV1().ServiceAccounts("ns").Get(ctx, "missing-sa", metav1.GetOptions{})
+	require.True(t, oktetoErrors.IsNotFound(err))
+
+	_, err = client.CoreV1().ServiceAccounts("ns").Get(ctx, "not-deployed-sa", metav1.GetOptions{})
+	require.True(t, oktetoErrors.IsNotFound(err))
+}
+
+func Test_deployConfigs(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	nginxFile := filepath.Join(dir, "nginx.conf")
+	require.NoError(t, os.WriteFile(nginxFile, []byte("worker_processes 2;"), 0600))
+
+	stack := &model.Stack{
+		Namespace: "ns",
+		Name:      "stack-test",
+		Configs: map[string]*model.ConfigSpec{
+			"nginx":   {File: nginxFile},
+			"missing": {File: filepath.Join(dir, "missing.conf")},
+		},
+		Services: map[string]*model.Service{
+			"api":   {Image: "test_image", Configs: []model.ServiceConfig{{Source: "nginx", Target: "/nginx"}}},
+			"other": {Image: "test_image", Configs: []model.ServiceConfig{{Source: "missing", Target: "/missing"}}},
+		},
+	}
+	client := fake.NewSimpleClientset(&apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "stack-test-config-nginx", Namespace: "ns"},
+		Data:       map[string]string{configFileKey: "worker_processes 1;"},
+	})
+
+	err := deployConfigs(ctx, stack, map[string]bool{"api": true}, client)
+	require.NoError(t, err)
+
+	cm, err := client.CoreV1().ConfigMaps("ns").Get(ctx, "stack-test-config-nginx", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "worker_processes 2;", cm.Data[configFileKey])
+	require.Equal(t, "stack-test", cm.Labels[model.StackNameLabel])
+
+	// the files of the configs are read when the services mounting them are deployed
+	err = deployConfigs(ctx, stack, map[string]bool{"api": true, "other": true}, client)
+	require.ErrorContains(t, err, "of config 'missing' doesn't exist")
+}
+
+func Test_deploySecrets(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	passwordFile := filepath.Join(dir, "db_password.txt")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("new-password"), 0600))
+
+	stack := &model.Stack{
+		Namespace: "ns",
+		Name:      "stack-test",
+		Secrets: map[string]*model.SecretSpec{
+			"db_password": {File: passwordFile},
+			"missing":     {File: filepath.Join(dir, "missing.txt")},
+		},
+		Services: map[string]*model.Service{
+			"api":   {Image: "test_image", Secrets: []model.ServiceSecret{{Source: "db_password", Target: "/run/secrets/db_password"}}},
+			"other": {Image: "test_image", Secrets: []model.ServiceSecret{{Source: "missing", Target: "/run/secrets/missing"}}},
+		},
+	}
+	client := fake.NewSimpleClientset(&apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "stack-test-secret-db-password", Namespace: "ns"},
+		Data:       map[string][]byte{secretFileKey: []byte("old-password")},
+	})
+
+	err := deploySecrets(ctx, stack, map[string]bool{"api": true}, client)
+	require.NoError(t, err)
+
+	secret, err := client.CoreV1().Secrets("ns").Get(ctx, "stack-test-secret-db-password", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []byte("new-password"), secret.Data[secretFileKey])
+	require.Equal(t, "stack-test", secret.Labels[model.StackNameLabel])
+
+	err = deploySecrets(ctx, stack, map[string]bool{"api": true, "other": true}, client)
+	require.ErrorContains(t, err, "of secret 'missing' doesn't exist")
+}
+
+func Test_deployExternalServices(t *testing.T) {
+	ctx := context.Background()
+	stack := &model.Stack{
+		Namespace: "ns",
+		Name:      "stack-test",
+		ExternalServices: map[string]*model.ExternalService{
+			"db":       {Host: "db.example.com"},
+			"cache":    {Host: "10.0.0.12", Ports: []int32{6379}},
+			"existing": {Host: "existing.example.com"},
+		},
+	}
+	client := fake.NewSimpleClientset(
+		&apiv1.Service{ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "ns"}},
+		&discoveryv1.EndpointSlice{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "ns"}},
+	)
+
+	require.NoError(t, deployExternalServices(ctx, stack, client))
+
+	db, err := client.CoreV1().Services("ns").Get(ctx, "db", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, apiv1.ServiceTypeExternalName, db.Spec.Type)
+	require.Equal(t, "db.example.com", db.Spec.ExternalName)
+	_, err = client.DiscoveryV1().EndpointSlices("ns").Get(ctx, "db", metav1.GetOptions{})
+	require.True(t, oktetoErrors.IsNotFound(err))
+
+	cache, err := client.CoreV1().Services("ns").Get(ctx, "cache", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, apiv1.ClusterIPNone, cache.Spec.ClusterIP)
+	slice, err := client.DiscoveryV1().EndpointSlices("ns").Get(ctx, "cache", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.0.0.12"}, slice.Endpoints[0].Addresses)
+
+	// services not created by the stack are not overridden
+	existing, err := client.CoreV1().Services("ns").Get(ctx, "existing", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Empty(t, existing.Spec.ExternalName)
+
+	// the external name is updated on redeploys
+	stack.ExternalServices["db"].Host = "db2.example.com"
+	require.NoError(t, deployExternalServices(ctx, stack, client))
+	db, err = client.CoreV1().Services("ns").Get(ctx, "db", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "db2.example.com", db.Spec.ExternalName)
+}
+
+func Test_checkPriorityClasses(t *testing.T) {
+	ctx := context.Background()
+	stack := &model.Stack{
+		Namespace: "ns",
+		Name:      "stack-test",
+		Services: map[string]*model.Service{
+			"api":    {Image: "test_image", PriorityClassName: "high-priority"},
+			"worker": {Image: "test_image", PriorityClassName: "high-priority"},
+			"job":    {Image: "test_image", PriorityClassName: "missing-priority"},
+			"other":  {Image: "test_image", PriorityClassName: "not-deployed-priority"},
+			"db":     {Image: "test_image"},
+		},
+	}
+	client := fake.NewSimpleClientset(&schedulingv1.PriorityClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "high-priority"},
+		Value:      1000,
+	})
+
+	checkPriorityClasses(ctx, stack, map[string]bool{"api": true, "worker": true, "job": true, "db": true}, client)
+
+	// each priority class of the deployed services is checked once
+	checked := []string{}
+	for _, action := range client.Actions() {
+		if get, ok := action.(k8sTesting.GetAction); ok && action.GetResource().Resource == "priorityclasses" {
+			checked = append(checked, get.GetName())
+		}
+	}
+	require.ElementsMatch(t, []string{"high-priority", "missing-priority"}, checked)
+}
+
+func Test_deployServiceAccountNameCollision(t *testing.T) {
+	ctx := context.Background()
+	stack := &model.Stack{
+		Namespace: "ns",
+		Name:      "stack-test",
+	}
+	existing := &apiv1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-sa",
+			Namespace: "ns",
+		},
+	}
+	client := fake.NewSimpleClientset(existing)
+
+	err := deployServiceAccount(ctx, "my-sa", stack, client)
+	require.NoError(t, err)
+
+	sa, err := client.CoreV1().ServiceAccounts("ns").Get(ctx, "my-sa", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Empty(t, sa.Labels)
+}
+
 func TestValidateDefinedServices_undefinedService(t *testing.T) {
 	stack := &model.Stack{
 		Services: map[string]*model.Service{
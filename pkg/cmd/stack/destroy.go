@@ -17,10 +17,14 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/okteto/okteto/pkg/k8s/configmaps"
 	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/k8s/endpoints"
 	"github.com/okteto/okteto/pkg/k8s/httproutes"
 	"github.com/okteto/okteto/pkg/k8s/ingresses"
 	"github.com/okteto/okteto/pkg/k8s/jobs"
+	"github.com/okteto/okteto/pkg/k8s/secrets"
+	"github.com/okteto/okteto/pkg/k8s/serviceaccounts"
 	"github.com/okteto/okteto/pkg/k8s/services"
 	"github.com/okteto/okteto/pkg/k8s/statefulsets"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
@@ -42,6 +46,22 @@ func destroyServicesNotInStack(ctx context.Context, s *model.Stack, c kubernetes
 		return err
 	}
 
+	if err := destroyServiceAccounts(ctx, s, c); err != nil {
+		return err
+	}
+
+	if err := destroyConfigs(ctx, s, c); err != nil {
+		return err
+	}
+
+	if err := destroySecrets(ctx, s, c); err != nil {
+		return err
+	}
+
+	if err := destroyExternalServices(ctx, s, c); err != nil {
+		return err
+	}
+
 	// Clean up both Ingress and HTTPRoute resources to handle switching between endpoint types
 	// When using HTTPRoute, destroy ALL ingresses (even for endpoints still in stack)
 	// When using Ingress, destroy ALL httproutes (even for endpoints still in stack)
@@ -132,6 +152,87 @@ func destroyJobs(ctx context.Context, s *model.Stack, c kubernetes.Interface) er
 	return nil
 }
 
+func destroyServiceAccounts(ctx context.Context, s *model.Stack, c kubernetes.Interface) error {
+	saList, err := serviceaccounts.List(ctx, s.Namespace, s.GetLabelSelector(), c)
+	if err != nil {
+		return err
+	}
+	for i := range saList {
+		if s.IsServiceAccountCreated(saList[i].Name) {
+			continue
+		}
+		if err := serviceaccounts.Destroy(ctx, saList[i].Name, saList[i].Namespace, c); err != nil {
+			return fmt.Errorf("error destroying service account '%s': %w", saList[i].Name, err)
+		}
+		oktetoLog.Success("Service account '%s' destroyed", saList[i].Name)
+	}
+	return nil
+}
+
+func destroyConfigs(ctx context.Context, s *model.Stack, c kubernetes.Interface) error {
+	cmList, err := configmaps.List(ctx, s.Namespace, s.GetLabelSelector(), c)
+	if err != nil {
+		return err
+	}
+	inStack := map[string]bool{}
+	for name := range s.Configs {
+		inStack[getConfigMapName(s.Name, name)] = true
+	}
+	for i := range cmList {
+		// the parts of the manifest of the stack are managed by deployStackConfigMap
+		if inStack[cmList[i].Name] || isStackManifestPart(&cmList[i]) {
+			continue
+		}
+		if err := configmaps.Destroy(ctx, cmList[i].Name, cmList[i].Namespace, c); err != nil {
+			return fmt.Errorf("error destroying configmap '%s': %w", cmList[i].Name, err)
+		}
+		oktetoLog.Success("Configmap '%s' destroyed", cmList[i].Name)
+	}
+	return nil
+}
+
+func destroySecrets(ctx context.Context, s *model.Stack, c kubernetes.Interface) error {
+	sList, err := secrets.NewSecrets(c).List(ctx, s.Namespace, s.GetLabelSelector())
+	if err != nil {
+		return err
+	}
+	inStack := map[string]bool{}
+	for name := range s.Secrets {
+		inStack[getSecretName(s.Name, name)] = true
+	}
+	for i := range sList {
+		if inStack[sList[i].Name] {
+			continue
+		}
+		if err := secrets.DestroyByName(ctx, sList[i].Name, sList[i].Namespace, c); err != nil {
+			return fmt.Errorf("error destroying secret '%s': %w", sList[i].Name, err)
+		}
+		oktetoLog.Success("Secret '%s' destroyed", sList[i].Name)
+	}
+	return nil
+}
+
+func destroyExternalServices(ctx context.Context, s *model.Stack, c kubernetes.Interface) error {
+	svcList, err := services.List(ctx, s.Namespace, fmt.Sprintf("%s,%s", s.GetLabelSelector(), model.StackExternalServiceLabel), c)
+	if err != nil {
+		return err
+	}
+	for i := range svcList {
+		name := svcList[i].Labels[model.StackExternalServiceLabel]
+		if _, ok := s.ExternalServices[name]; ok {
+			continue
+		}
+		if err := services.Destroy(ctx, svcList[i].Name, svcList[i].Namespace, c); err != nil {
+			return fmt.Errorf("error destroying external service '%s': %w", svcList[i].Name, err)
+		}
+		if err := endpoints.Destroy(ctx, svcList[i].Name, svcList[i].Namespace, c); err != nil {
+			return fmt.Errorf("error destroying external service '%s': %w", svcList[i].Name, err)
+		}
+		oktetoLog.Success("External service '%s' destroyed", svcList[i].Name)
+	}
+	return nil
+}
+
 func destroyIngresses(ctx context.Context, s *model.Stack, c kubernetes.Interface, destroyAll bool) error {
 	iClient, err := ingresses.GetClient(c)
 	if err != nil {
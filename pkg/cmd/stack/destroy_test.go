@@ -22,9 +22,11 @@ import (
 	"github.com/okteto/okteto/pkg/k8s/jobs"
 	"github.com/okteto/okteto/pkg/k8s/statefulsets"
 	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
 )
@@ -283,3 +285,155 @@ func Test_destroyJobs(t *testing.T) {
 		})
 	}
 }
+
+func Test_destroyServiceAccounts(t *testing.T) {
+	ctx := context.Background()
+	stackSA := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "stack-sa",
+			Namespace: "ns",
+			Labels:    map[string]string{model.StackNameLabel: "stack-test"},
+		},
+	}
+	removedSA := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "removed-sa",
+			Namespace: "ns",
+			Labels:    map[string]string{model.StackNameLabel: "stack-test"},
+		},
+	}
+	externalSA := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "external-sa",
+			Namespace: "ns",
+		},
+	}
+	client := fake.NewSimpleClientset(stackSA, removedSA, externalSA)
+	stack := &model.Stack{
+		Namespace: "ns",
+		Name:      "stack-test",
+		Services: map[string]*model.Service{
+			"api": {
+				Image:                "test_image",
+				ServiceAccount:       "stack-sa",
+				CreateServiceAccount: true,
+			},
+		},
+	}
+
+	err := destroyServiceAccounts(ctx, stack, client)
+	require.NoError(t, err)
+
+	saList, err := client.CoreV1().ServiceAccounts("ns").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	names := []string{}
+	for _, sa := range saList.Items {
+		names = append(names, sa.Name)
+	}
+	require.ElementsMatch(t, []string{"stack-sa", "external-sa"}, names)
+}
+
+func Test_destroyConfigs(t *testing.T) {
+	ctx := context.Background()
+	configMap := func(name string, labels map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns", Labels: labels},
+		}
+	}
+	stackLabels := map[string]string{model.StackNameLabel: "stack-test"}
+	client := fake.NewSimpleClientset(
+		configMap("stack-test-config-nginx", stackLabels),
+		configMap("stack-test-config-removed", stackLabels),
+		configMap("external", nil),
+	)
+	stack := &model.Stack{
+		Namespace: "ns",
+		Name:      "stack-test",
+		Configs:   map[string]*model.ConfigSpec{"nginx": {File: "nginx.conf"}},
+		Services: map[string]*model.Service{
+			"api": {Image: "test_image", Configs: []model.ServiceConfig{{Source: "nginx", Target: "/nginx"}}},
+		},
+	}
+
+	err := destroyConfigs(ctx, stack, client)
+	require.NoError(t, err)
+
+	cmList, err := client.CoreV1().ConfigMaps("ns").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	names := []string{}
+	for _, cm := range cmList.Items {
+		names = append(names, cm.Name)
+	}
+	require.ElementsMatch(t, []string{"stack-test-config-nginx", "external"}, names)
+}
+
+func Test_destroySecrets(t *testing.T) {
+	ctx := context.Background()
+	secret := func(name string, labels map[string]string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns", Labels: labels},
+		}
+	}
+	stackLabels := map[string]string{model.StackNameLabel: "stack-test"}
+	client := fake.NewSimpleClientset(
+		secret("stack-test-secret-db-password", stackLabels),
+		secret("stack-test-secret-removed", stackLabels),
+		secret("external", nil),
+	)
+	stack := &model.Stack{
+		Namespace: "ns",
+		Name:      "stack-test",
+		Secrets:   map[string]*model.SecretSpec{"db_password": {File: "db_password.txt"}},
+		Services: map[string]*model.Service{
+			"api": {Image: "test_image", Secrets: []model.ServiceSecret{{Source: "db_password", Target: "/run/secrets/db_password"}}},
+		},
+	}
+
+	err := destroySecrets(ctx, stack, client)
+	require.NoError(t, err)
+
+	sList, err := client.CoreV1().Secrets("ns").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	names := []string{}
+	for _, s := range sList.Items {
+		names = append(names, s.Name)
+	}
+	require.ElementsMatch(t, []string{"stack-test-secret-db-password", "external"}, names)
+}
+
+func Test_destroyExternalServices(t *testing.T) {
+	ctx := context.Background()
+	service := func(name string, labels map[string]string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns", Labels: labels},
+		}
+	}
+	client := fake.NewSimpleClientset(
+		service("db", map[string]string{model.StackNameLabel: "stack-test", model.StackExternalServiceLabel: "db"}),
+		service("cache", map[string]string{model.StackNameLabel: "stack-test", model.StackExternalServiceLabel: "cache"}),
+		service("api", map[string]string{model.StackNameLabel: "stack-test"}),
+		service("other", nil),
+		&discoveryv1.EndpointSlice{ObjectMeta: metav1.ObjectMeta{Name: "cache", Namespace: "ns"}},
+	)
+	stack := &model.Stack{
+		Namespace:        "ns",
+		Name:             "stack-test",
+		ExternalServices: map[string]*model.ExternalService{"db": {Host: "db.example.com"}},
+		Services:         map[string]*model.Service{"api": {Image: "test_image"}},
+	}
+
+	err := destroyExternalServices(ctx, stack, client)
+	require.NoError(t, err)
+
+	svcList, err := client.CoreV1().Services("ns").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	names := []string{}
+	for _, svc := range svcList.Items {
+		names = append(names, svc.Name)
+	}
+	require.ElementsMatch(t, []string{"db", "api", "other"}, names)
+
+	sliceList, err := client.DiscoveryV1().EndpointSlices("ns").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Empty(t, sliceList.Items)
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+// ImageDigestsField is the field of the stack configmap with the images resolved to digests in the last deploy
+const ImageDigestsField = "imageDigests"
+
+// DigestResolver resolves an image tag to the image reference with its digest
+type DigestResolver interface {
+	GetImageTagWithDigest(image string) (string, error)
+}
+
+// resolveImageDigests replaces the images of the services to deploy with the image references with digest, so the workloads
+// don't depend on floating tags. It returns the image references resolved by image tag.
+// If an image can't be resolved, it fails naming the service, unless skipUnresolvable is set
+func resolveImageDigests(s *model.Stack, servicesToDeploy []string, resolver DigestResolver, skipUnresolvable bool) (map[string]string, error) {
+	services := append([]string{}, servicesToDeploy...)
+	sort.Strings(services)
+
+	resolved := map[string]string{}
+	for _, svcName := range services {
+		svc, ok := s.Services[svcName]
+		if !ok || svc.Image == "" || strings.Contains(svc.Image, "@") {
+			continue
+		}
+
+		tag := svc.Image
+		image, ok := resolved[tag]
+		if !ok {
+			var err error
+			image, err = resolver.GetImageTagWithDigest(tag)
+			if err != nil {
+				if skipUnresolvable {
+					oktetoLog.Warning("Service '%s' is deployed with image '%s': its digest couldn't be resolved: %s", svcName, tag, err)
+					continue
+				}
+				return nil, oktetoErrors.UserError{
+					E:    fmt.Errorf("could not resolve the digest of image '%s' of service '%s': %w", tag, svcName, err),
+					Hint: "Check that the image is pushed to its registry, or use '--skip-unresolvable' to deploy the services that can't be resolved with their image tag",
+				}
+			}
+			resolved[tag] = image
+		}
+		oktetoLog.Infof("service '%s': image '%s' resolved to '%s'", svcName, tag, image)
+		svc.Image = image
+	}
+	return resolved, nil
+}
+
+// setImageDigests records the images resolved to digests in the stack configmap
+func setImageDigests(cfg *apiv1.ConfigMap, digests map[string]string) {
+	if len(digests) == 0 {
+		return
+	}
+	b, err := json.Marshal(digests)
+	if err != nil {
+		oktetoLog.Infof("error encoding the image digests: %s", err)
+		return
+	}
+	cfg.Data[ImageDigestsField] = string(b)
+}
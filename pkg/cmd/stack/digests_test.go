@@ -0,0 +1,108 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"fmt"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+type fakeDigestResolver struct {
+	digests map[string]string
+	calls   int
+}
+
+func (f *fakeDigestResolver) GetImageTagWithDigest(image string) (string, error) {
+	f.calls++
+	if digest, ok := f.digests[image]; ok {
+		return digest, nil
+	}
+	return "", fmt.Errorf("manifest unknown")
+}
+
+func newDigestsTestStack() *model.Stack {
+	return &model.Stack{
+		Name: "movies",
+		Services: map[string]*model.Service{
+			"api":      {Image: "okteto/api:1.0"},
+			"worker":   {Image: "okteto/api:1.0"},
+			"frontend": {Image: "okteto/frontend:latest"},
+			"db":       {Image: "mongo@sha256:1111"},
+			"cache":    {Image: "okteto/cache:dev"},
+		},
+	}
+}
+
+func TestResolveImageDigests(t *testing.T) {
+	resolver := &fakeDigestResolver{
+		digests: map[string]string{
+			"okteto/api:1.0":         "docker.io/okteto/api@sha256:aaaa",
+			"okteto/frontend:latest": "docker.io/okteto/frontend@sha256:bbbb",
+		},
+	}
+	s := newDigestsTestStack()
+
+	digests, err := resolveImageDigests(s, []string{"api", "worker", "frontend", "db"}, resolver, false)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"okteto/api:1.0":         "docker.io/okteto/api@sha256:aaaa",
+		"okteto/frontend:latest": "docker.io/okteto/frontend@sha256:bbbb",
+	}, digests)
+	assert.Equal(t, "docker.io/okteto/api@sha256:aaaa", s.Services["api"].Image)
+	assert.Equal(t, "docker.io/okteto/api@sha256:aaaa", s.Services["worker"].Image)
+	assert.Equal(t, "docker.io/okteto/frontend@sha256:bbbb", s.Services["frontend"].Image)
+	assert.Equal(t, "mongo@sha256:1111", s.Services["db"].Image)
+	// services not deployed are not resolved
+	assert.Equal(t, "okteto/cache:dev", s.Services["cache"].Image)
+	// each tag is resolved once
+	assert.Equal(t, 2, resolver.calls)
+}
+
+func TestResolveImageDigestsUnresolvable(t *testing.T) {
+	resolver := &fakeDigestResolver{
+		digests: map[string]string{
+			"okteto/api:1.0": "docker.io/okteto/api@sha256:aaaa",
+		},
+	}
+
+	s := newDigestsTestStack()
+	_, err := resolveImageDigests(s, []string{"api", "cache"}, resolver, false)
+	var uErr oktetoErrors.UserError
+	require.ErrorAs(t, err, &uErr)
+	assert.ErrorContains(t, err, "service 'cache'")
+	assert.ErrorContains(t, err, "okteto/cache:dev")
+	assert.Contains(t, uErr.Hint, "--skip-unresolvable")
+
+	s = newDigestsTestStack()
+	digests, err := resolveImageDigests(s, []string{"api", "cache"}, resolver, true)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"okteto/api:1.0": "docker.io/okteto/api@sha256:aaaa"}, digests)
+	assert.Equal(t, "docker.io/okteto/api@sha256:aaaa", s.Services["api"].Image)
+	assert.Equal(t, "okteto/cache:dev", s.Services["cache"].Image)
+}
+
+func TestSetImageDigests(t *testing.T) {
+	cfg := &apiv1.ConfigMap{Data: map[string]string{}}
+	setImageDigests(cfg, nil)
+	assert.NotContains(t, cfg.Data, ImageDigestsField)
+
+	setImageDigests(cfg, map[string]string{"okteto/api:1.0": "docker.io/okteto/api@sha256:aaaa"})
+	assert.Equal(t, `{"okteto/api:1.0":"docker.io/okteto/api@sha256:aaaa"}`, cfg.Data[ImageDigestsField])
+}
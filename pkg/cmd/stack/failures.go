@@ -0,0 +1,331 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/format"
+	"github.com/okteto/okteto/pkg/k8s/events"
+	"github.com/okteto/okteto/pkg/k8s/pods"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// failureLogsTailLines is the number of lines of the crashed container logs shown in a diagnosis
+	failureLogsTailLines int64 = 50
+
+	// failureMaxEvents is the number of pod events shown in a diagnosis
+	failureMaxEvents = 10
+
+	// failingWaitingScore is added to the score of pods with containers that can't start
+	failingWaitingScore = 1000
+)
+
+// failingWaitingReasons are the waiting reasons of containers that won't start without user action
+var failingWaitingReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+	"InvalidImageName":           true,
+	"RunContainerError":          true,
+}
+
+// serviceDiagnosis describes the state of the unhealthiest pod of a service
+type serviceDiagnosis struct {
+	service   string
+	pod       string
+	container string
+	reason    string
+	logs      string
+	logsError string
+	events    []string
+	exitCode  int32
+	restarts  int32
+	previous  bool
+}
+
+// reportServiceFailures prints a diagnosis of the services of a stack with unhealthy pods.
+// It's called when waiting for the services fails, so errors getting the diagnosis are only logged
+func reportServiceFailures(ctx context.Context, namespace, stackName string, services []string, c kubernetes.Interface) {
+	for _, svcName := range services {
+		d, err := diagnoseService(ctx, namespace, stackName, svcName, c)
+		if err != nil {
+			oktetoLog.Infof("failed to diagnose service '%s': %s", svcName, err)
+			continue
+		}
+		if d == nil {
+			continue
+		}
+		oktetoLog.StopSpinner()
+		oktetoLog.Fail("Service '%s' is not healthy: %s", svcName, d.summary())
+		oktetoLog.Println(d.details())
+	}
+}
+
+// diagnoseService returns the diagnosis of the unhealthiest pod of a service, or nil if its pods are healthy.
+// The logs are the ones of the previous container when the container has restarted
+func diagnoseService(ctx context.Context, namespace, stackName, svcName string, c kubernetes.Interface) (*serviceDiagnosis, error) {
+	p, err := getUnhealthiestPod(ctx, namespace, stackName, svcName, c)
+	if err != nil || p == nil {
+		return nil, err
+	}
+	if podFailureScore(p) == 0 {
+		return nil, nil
+	}
+	return newServiceDiagnosis(ctx, svcName, p, true, failureLogsTailLines, c), nil
+}
+
+// getUnhealthiestPod returns the pod of a service with the highest failure score, or nil if the service has no pods
+func getUnhealthiestPod(ctx context.Context, namespace, stackName, svcName string, c kubernetes.Interface) (*apiv1.Pod, error) {
+	selector := map[string]string{
+		model.StackNameLabel:        format.ResourceK8sMetaString(stackName),
+		model.StackServiceNameLabel: svcName,
+	}
+	podList, err := pods.ListBySelector(ctx, namespace, selector, c)
+	if err != nil {
+		return nil, err
+	}
+	var result *apiv1.Pod
+	maxScore := -1
+	for i := range podList {
+		if score := podFailureScore(&podList[i]); score > maxScore {
+			result = &podList[i]
+			maxScore = score
+		}
+	}
+	return result, nil
+}
+
+// podFailureScore returns how unhealthy a pod is. Healthy pods score 0
+func podFailureScore(p *apiv1.Pod) int {
+	if p.Status.Phase == apiv1.PodSucceeded {
+		return 0
+	}
+	score := 0
+	if p.Status.Phase == apiv1.PodFailed {
+		score += failingWaitingScore
+	}
+	for _, cs := range p.Status.ContainerStatuses {
+		score += int(cs.RestartCount)
+		if cs.State.Waiting != nil && failingWaitingReasons[cs.State.Waiting.Reason] {
+			score += failingWaitingScore
+		}
+		if !cs.Ready {
+			score++
+		}
+	}
+	return score
+}
+
+// getFailingContainer returns the status of the unhealthiest container of a pod
+func getFailingContainer(p *apiv1.Pod) *apiv1.ContainerStatus {
+	var result *apiv1.ContainerStatus
+	maxScore := -1
+	for i := range p.Status.ContainerStatuses {
+		cs := &p.Status.ContainerStatuses[i]
+		score := int(cs.RestartCount)
+		if cs.State.Waiting != nil && failingWaitingReasons[cs.State.Waiting.Reason] {
+			score += failingWaitingScore
+		}
+		if score > maxScore {
+			result = cs
+			maxScore = score
+		}
+	}
+	return result
+}
+
+func newServiceDiagnosis(ctx context.Context, svcName string, p *apiv1.Pod, previous bool, tailLines int64, c kubernetes.Interface) *serviceDiagnosis {
+	d := &serviceDiagnosis{
+		service: svcName,
+		pod:     p.Name,
+		reason:  string(p.Status.Phase),
+	}
+	if p.Status.Reason != "" {
+		d.reason = p.Status.Reason
+	}
+
+	cs := getFailingContainer(p)
+	if cs != nil {
+		d.container = cs.Name
+		d.restarts = cs.RestartCount
+		if cs.State.Waiting != nil && cs.State.Waiting.Reason != "" {
+			d.reason = cs.State.Waiting.Reason
+		}
+		terminated := cs.State.Terminated
+		if terminated == nil {
+			terminated = cs.LastTerminationState.Terminated
+		}
+		if terminated != nil {
+			d.exitCode = terminated.ExitCode
+			if cs.State.Waiting == nil && terminated.Reason != "" {
+				d.reason = terminated.Reason
+			}
+		}
+
+		// the previous container only exists once the container has restarted
+		d.previous = previous && cs.RestartCount > 0
+		logs, err := pods.ContainerTailLogs(ctx, cs.Name, p.Name, p.Namespace, d.previous, tailLines, c)
+		if err != nil {
+			oktetoLog.Infof("failed to get logs of pod '%s': %s", p.Name, err)
+			d.logsError = err.Error()
+		}
+		d.logs = strings.TrimRight(logs, "\n")
+	}
+
+	podEvents, err := events.List(ctx, p.Namespace, p.Name, c)
+	if err != nil {
+		oktetoLog.Infof("failed to get events of pod '%s': %s", p.Name, err)
+	}
+	d.events = formatPodEvents(podEvents)
+	return d
+}
+
+// formatPodEvents returns the last events of a pod, oldest first
+func formatPodEvents(podEvents []apiv1.Event) []string {
+	sort.SliceStable(podEvents, func(i, j int) bool {
+		return podEvents[i].LastTimestamp.Before(&podEvents[j].LastTimestamp)
+	})
+	if len(podEvents) > failureMaxEvents {
+		podEvents = podEvents[len(podEvents)-failureMaxEvents:]
+	}
+	result := make([]string, 0, len(podEvents))
+	for _, e := range podEvents {
+		line := fmt.Sprintf("%s %s: %s", e.Type, e.Reason, strings.TrimSpace(e.Message))
+		if e.Count > 1 {
+			line = fmt.Sprintf("%s (x%d)", line, e.Count)
+		}
+		result = append(result, line)
+	}
+	return result
+}
+
+// summary returns a one line description of the state of the pod
+func (d *serviceDiagnosis) summary() string {
+	if d.container == "" {
+		return fmt.Sprintf("pod '%s' is %s", d.pod, d.reason)
+	}
+	result := fmt.Sprintf("container '%s' of pod '%s' is %s", d.container, d.pod, d.reason)
+	details := []string{}
+	if d.exitCode != 0 {
+		details = append(details, fmt.Sprintf("exit code %d", d.exitCode))
+	}
+	if d.restarts > 0 {
+		details = append(details, fmt.Sprintf("%d restarts", d.restarts))
+	}
+	if len(details) > 0 {
+		result = fmt.Sprintf("%s (%s)", result, strings.Join(details, ", "))
+	}
+	return result
+}
+
+// details returns the events and logs of the pod, indented to be shown under the summary
+func (d *serviceDiagnosis) details() string {
+	var sb strings.Builder
+	if len(d.events) > 0 {
+		sb.WriteString("    Events:\n")
+		for _, e := range d.events {
+			fmt.Fprintf(&sb, "      %s\n", e)
+		}
+	}
+
+	logsTitle := "Logs"
+	if d.previous {
+		logsTitle = "Logs of the previous container"
+	}
+	switch {
+	case d.logsError != "":
+		fmt.Fprintf(&sb, "    %s are not available: %s\n", logsTitle, d.logsError)
+	case d.logs == "":
+		fmt.Fprintf(&sb, "    %s are empty\n", logsTitle)
+	default:
+		fmt.Fprintf(&sb, "    %s:\n", logsTitle)
+		for _, line := range strings.Split(d.logs, "\n") {
+			fmt.Fprintf(&sb, "      %s\n", line)
+		}
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// LogsOptions defines the options to show the logs of the services of a stack
+type LogsOptions struct {
+	Name      string
+	Namespace string
+	Services  []string
+	Tail      int64
+	Previous  bool
+}
+
+// Logs prints the logs of the unhealthiest pod of each service of a stack.
+// If opts.Previous is true, it prints the logs of the previous container and the pod events to diagnose crashing services
+func Logs(ctx context.Context, opts *LogsOptions, c kubernetes.Interface) error {
+	services := opts.Services
+	if len(services) == 0 {
+		var err error
+		services, err = getStackServicesWithPods(ctx, opts.Namespace, opts.Name, c)
+		if err != nil {
+			return err
+		}
+		if len(services) == 0 {
+			return fmt.Errorf("stack '%s' has no running services", opts.Name)
+		}
+	}
+
+	for _, svcName := range services {
+		p, err := getUnhealthiestPod(ctx, opts.Namespace, opts.Name, svcName, c)
+		if err != nil {
+			return fmt.Errorf("error getting the pods of service '%s': %w", svcName, err)
+		}
+		if p == nil {
+			return fmt.Errorf("service '%s' has no pods in stack '%s'", svcName, opts.Name)
+		}
+		d := newServiceDiagnosis(ctx, svcName, p, opts.Previous, opts.Tail, c)
+		if !opts.Previous {
+			d.events = nil
+		}
+		oktetoLog.Println(fmt.Sprintf("Service '%s': %s", svcName, d.summary()))
+		oktetoLog.Println(d.details())
+	}
+	return nil
+}
+
+// getStackServicesWithPods returns the sorted names of the services of a stack with pods
+func getStackServicesWithPods(ctx context.Context, namespace, stackName string, c kubernetes.Interface) ([]string, error) {
+	selector := map[string]string{model.StackNameLabel: format.ResourceK8sMetaString(stackName)}
+	podList, err := pods.ListBySelector(ctx, namespace, selector, c)
+	if err != nil {
+		return nil, fmt.Errorf("error listing the pods of stack '%s': %w", stackName, err)
+	}
+	services := map[string]bool{}
+	for _, p := range podList {
+		if svcName := p.Labels[model.StackServiceNameLabel]; svcName != "" {
+			services[svcName] = true
+		}
+	}
+	result := make([]string, 0, len(services))
+	for svcName := range services {
+		result = append(result, svcName)
+	}
+	sort.Strings(result)
+	return result, nil
+}
@@ -0,0 +1,209 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newStackPod(name, svcName string, status apiv1.PodStatus) *apiv1.Pod {
+	meta := stackObjectMeta(svcName)
+	meta.Name = name
+	return &apiv1.Pod{ObjectMeta: meta, Status: status}
+}
+
+func runningPodStatus() apiv1.PodStatus {
+	return apiv1.PodStatus{
+		Phase: apiv1.PodRunning,
+		ContainerStatuses: []apiv1.ContainerStatus{
+			{
+				Name:  "api",
+				Ready: true,
+				State: apiv1.ContainerState{Running: &apiv1.ContainerStateRunning{}},
+			},
+		},
+	}
+}
+
+func crashLoopPodStatus() apiv1.PodStatus {
+	return apiv1.PodStatus{
+		Phase: apiv1.PodRunning,
+		ContainerStatuses: []apiv1.ContainerStatus{
+			{
+				Name:         "api",
+				RestartCount: 5,
+				State: apiv1.ContainerState{
+					Waiting: &apiv1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+				},
+				LastTerminationState: apiv1.ContainerState{
+					Terminated: &apiv1.ContainerStateTerminated{Reason: "Error", ExitCode: 1},
+				},
+			},
+		},
+	}
+}
+
+func newPodEvent(name, podName, reason, message string, count int32, lastTimestamp time.Time) *apiv1.Event {
+	return &apiv1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "ns"},
+		InvolvedObject: apiv1.ObjectReference{Kind: "Pod", Name: podName, Namespace: "ns"},
+		Type:           apiv1.EventTypeWarning,
+		Reason:         reason,
+		Message:        message,
+		Count:          count,
+		LastTimestamp:  metav1.NewTime(lastTimestamp),
+	}
+}
+
+func TestDiagnoseServiceCrashLoopBackOff(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	c := fake.NewSimpleClientset(
+		newStackPod("api-healthy", "api", runningPodStatus()),
+		newStackPod("api-crashing", "api", crashLoopPodStatus()),
+		newPodEvent("backoff", "api-crashing", "BackOff", "Back-off restarting failed container api", 12, now),
+		newPodEvent("pulled", "api-crashing", "Pulled", "Container image \"api\" already present on machine", 5, now.Add(-time.Minute)),
+	)
+
+	d, err := diagnoseService(ctx, "ns", "stack", "api", c)
+	require.NoError(t, err)
+	require.NotNil(t, d)
+
+	assert.Equal(t, "api-crashing", d.pod)
+	assert.Equal(t, "api", d.container)
+	assert.Equal(t, "CrashLoopBackOff", d.reason)
+	assert.Equal(t, int32(1), d.exitCode)
+	assert.Equal(t, int32(5), d.restarts)
+	assert.True(t, d.previous)
+	assert.Equal(t, "fake logs", d.logs)
+	assert.Equal(t, []string{
+		"Warning Pulled: Container image \"api\" already present on machine (x5)",
+		"Warning BackOff: Back-off restarting failed container api (x12)",
+	}, d.events)
+
+	assert.Equal(t, "container 'api' of pod 'api-crashing' is CrashLoopBackOff (exit code 1, 5 restarts)", d.summary())
+	assert.Equal(t, `    Events:
+      Warning Pulled: Container image "api" already present on machine (x5)
+      Warning BackOff: Back-off restarting failed container api (x12)
+    Logs of the previous container:
+      fake logs`, d.details())
+}
+
+func TestDiagnoseServiceHealthy(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset(
+		newStackPod("api-healthy", "api", runningPodStatus()),
+	)
+
+	d, err := diagnoseService(ctx, "ns", "stack", "api", c)
+	require.NoError(t, err)
+	assert.Nil(t, d)
+
+	d, err = diagnoseService(ctx, "ns", "stack", "db", c)
+	require.NoError(t, err)
+	assert.Nil(t, d)
+}
+
+func TestPodFailureScore(t *testing.T) {
+	tests := []struct {
+		name   string
+		status apiv1.PodStatus
+		want   int
+	}{
+		{
+			name:   "running and ready",
+			status: runningPodStatus(),
+			want:   0,
+		},
+		{
+			name:   "succeeded",
+			status: apiv1.PodStatus{Phase: apiv1.PodSucceeded},
+			want:   0,
+		},
+		{
+			name:   "crash loop",
+			status: crashLoopPodStatus(),
+			want:   failingWaitingScore + 5 + 1,
+		},
+		{
+			name:   "failed",
+			status: apiv1.PodStatus{Phase: apiv1.PodFailed},
+			want:   failingWaitingScore,
+		},
+		{
+			name: "not ready",
+			status: apiv1.PodStatus{
+				Phase:             apiv1.PodRunning,
+				ContainerStatuses: []apiv1.ContainerStatus{{Name: "api"}},
+			},
+			want: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, podFailureScore(&apiv1.Pod{Status: tt.status}))
+		})
+	}
+}
+
+func TestFormatPodEventsKeepsLastEvents(t *testing.T) {
+	now := time.Now()
+	podEvents := []apiv1.Event{}
+	for i := 0; i < failureMaxEvents+5; i++ {
+		podEvents = append(podEvents, *newPodEvent("event", "api", "BackOff", "restarting", 1, now.Add(time.Duration(i)*time.Second)))
+	}
+	podEvents[len(podEvents)-1].Reason = "Killing"
+
+	result := formatPodEvents(podEvents)
+	require.Len(t, result, failureMaxEvents)
+	assert.Equal(t, "Warning Killing: restarting", result[failureMaxEvents-1])
+}
+
+func TestLogs(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset(
+		newStackPod("api-crashing", "api", crashLoopPodStatus()),
+		newStackPod("db-0", "db", runningPodStatus()),
+	)
+
+	require.NoError(t, Logs(ctx, &LogsOptions{Name: "stack", Namespace: "ns", Tail: 10, Previous: true}, c))
+	require.NoError(t, Logs(ctx, &LogsOptions{Name: "stack", Namespace: "ns", Services: []string{"db"}, Tail: 10}, c))
+
+	err := Logs(ctx, &LogsOptions{Name: "stack", Namespace: "ns", Services: []string{"worker"}}, c)
+	assert.ErrorContains(t, err, "service 'worker' has no pods in stack 'stack'")
+
+	err = Logs(ctx, &LogsOptions{Name: "other", Namespace: "ns"}, c)
+	assert.ErrorContains(t, err, "stack 'other' has no running services")
+}
+
+func TestGetStackServicesWithPods(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset(
+		newStackPod("worker-1", "worker", runningPodStatus()),
+		newStackPod("api-1", "api", runningPodStatus()),
+		newStackPod("api-2", "api", runningPodStatus()),
+	)
+
+	services, err := getStackServicesWithPods(ctx, "ns", "stack", c)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api", "worker"}, services)
+}
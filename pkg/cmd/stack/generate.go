@@ -0,0 +1,193 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/format"
+	"github.com/okteto/okteto/pkg/k8s/ingresses"
+	"github.com/okteto/okteto/pkg/model"
+	apiv1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// generatedVolumesFile is the file containing the persistent volume claims of the stack volumes
+	generatedVolumesFile = "volumes.yaml"
+	// generatedEndpointsFile is the file containing the ingresses of the compose endpoints
+	generatedEndpointsFile = "endpoints.yaml"
+)
+
+// GeneratedFile is a file of the kubernetes manifests generated from a stack
+type GeneratedFile struct {
+	// Path is the path of the file relative to the output directory
+	Path    string
+	Objects []runtime.Object
+}
+
+// GeneratedWorkload identifies the workload generated for a stack service
+type GeneratedWorkload struct {
+	// Kind is the lowercase kind of the workload, as used by kubectl
+	Kind    string
+	Service string
+}
+
+// GeneratedManifests contains the kubernetes manifests generated from a stack
+type GeneratedManifests struct {
+	Files     []GeneratedFile
+	Workloads []GeneratedWorkload
+}
+
+// GenerateK8sManifests translates the services, volumes and endpoints of a stack into kubernetes manifests
+// using the same translations used by 'okteto deploy'. Every service gets its own directory
+func GenerateK8sManifests(s *model.Stack) *GeneratedManifests {
+	result := &GeneratedManifests{}
+
+	svcNames := make([]string, 0, len(s.Services))
+	for svcName := range s.Services {
+		svcNames = append(svcNames, svcName)
+	}
+	sort.Strings(svcNames)
+
+	for _, svcName := range svcNames {
+		svc := s.Services[svcName]
+
+		var workload runtime.Object
+		var kind, fileName string
+		switch {
+		case svc.IsJob():
+			job := translateJob(svcName, s, nil)
+			job.TypeMeta = metav1.TypeMeta{APIVersion: "batch/v1", Kind: "Job"}
+			cleanObjectMeta(&job.ObjectMeta)
+			cleanObjectMeta(&job.Spec.Template.ObjectMeta)
+			setPlaceholderImage(svcName, &job.Spec.Template.Spec)
+			workload, kind, fileName = job, "job", "job.yaml"
+		case len(svc.Volumes) == 0:
+			d := translateDeployment(svcName, s, nil)
+			d.TypeMeta = metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"}
+			cleanObjectMeta(&d.ObjectMeta)
+			cleanObjectMeta(&d.Spec.Template.ObjectMeta)
+			setPlaceholderImage(svcName, &d.Spec.Template.Spec)
+			workload, kind, fileName = d, "deployment", "deployment.yaml"
+		default:
+			sfs := translateStatefulSet(svcName, s, nil)
+			sfs.TypeMeta = metav1.TypeMeta{APIVersion: "apps/v1", Kind: "StatefulSet"}
+			cleanObjectMeta(&sfs.ObjectMeta)
+			cleanObjectMeta(&sfs.Spec.Template.ObjectMeta)
+			setPlaceholderImage(svcName, &sfs.Spec.Template.Spec)
+			for i := range sfs.Spec.VolumeClaimTemplates {
+				cleanObjectMeta(&sfs.Spec.VolumeClaimTemplates[i].ObjectMeta)
+			}
+			workload, kind, fileName = sfs, "statefulset", "statefulset.yaml"
+		}
+		result.Files = append(result.Files, GeneratedFile{
+			Path:    path.Join(svcName, fileName),
+			Objects: []runtime.Object{workload},
+		})
+		result.Workloads = append(result.Workloads, GeneratedWorkload{Kind: kind, Service: svcName})
+
+		if len(svc.Ports) == 0 {
+			continue
+		}
+		k8sSvc := translateService(svcName, s)
+		k8sSvc.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Service"}
+		cleanObjectMeta(&k8sSvc.ObjectMeta)
+		result.Files = append(result.Files, GeneratedFile{
+			Path:    path.Join(svcName, "service.yaml"),
+			Objects: []runtime.Object{k8sSvc},
+		})
+
+		publicPorts := getSvcPublicPorts(svcName, s)
+		ingressObjects := []runtime.Object{}
+		for _, port := range publicPorts {
+			ingressName := getServiceIngressName(svcName, port, len(publicPorts))
+			endpoint := translateServiceEndpoint(ingressName, svcName, port, s)
+			ingressObjects = append(ingressObjects, translateGeneratedIngress(ingressName, endpoint, s))
+		}
+		if len(ingressObjects) > 0 {
+			result.Files = append(result.Files, GeneratedFile{
+				Path:    path.Join(svcName, "ingress.yaml"),
+				Objects: ingressObjects,
+			})
+		}
+	}
+
+	volumeNames := make([]string, 0, len(s.Volumes))
+	for volumeName := range s.Volumes {
+		volumeNames = append(volumeNames, volumeName)
+	}
+	sort.Strings(volumeNames)
+	volumeObjects := []runtime.Object{}
+	for _, volumeName := range volumeNames {
+		pvc := translatePersistentVolumeClaim(volumeName, s)
+		pvc.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "PersistentVolumeClaim"}
+		cleanObjectMeta(&pvc.ObjectMeta)
+		volumeObjects = append(volumeObjects, &pvc)
+	}
+	if len(volumeObjects) > 0 {
+		result.Files = append(result.Files, GeneratedFile{Path: generatedVolumesFile, Objects: volumeObjects})
+	}
+
+	endpointNames := make([]string, 0, len(s.Endpoints))
+	for endpointName := range s.Endpoints {
+		endpointNames = append(endpointNames, endpointName)
+	}
+	sort.Strings(endpointNames)
+	endpointObjects := []runtime.Object{}
+	for _, endpointName := range endpointNames {
+		endpoint := translateComposeEndpoint(endpointName, s)
+		endpointObjects = append(endpointObjects, translateGeneratedIngress(endpointName, endpoint, s))
+	}
+	if len(endpointObjects) > 0 {
+		result.Files = append(result.Files, GeneratedFile{Path: generatedEndpointsFile, Objects: endpointObjects})
+	}
+
+	sort.SliceStable(result.Files, func(i, j int) bool {
+		return strings.Compare(result.Files[i].Path, result.Files[j].Path) < 0
+	})
+	return result
+}
+
+func translateGeneratedIngress(name string, endpoint model.Endpoint, s *model.Stack) *networkingv1.Ingress {
+	ingress := ingresses.Translate(name, endpoint, &ingresses.TranslateOptions{
+		Name: format.ResourceK8sMetaString(s.Name),
+	}).V1
+	ingress.TypeMeta = metav1.TypeMeta{APIVersion: "networking.k8s.io/v1", Kind: "Ingress"}
+	cleanObjectMeta(&ingress.ObjectMeta)
+	return ingress
+}
+
+// cleanObjectMeta removes the fields that only make sense when the stack is deployed by okteto
+func cleanObjectMeta(meta *metav1.ObjectMeta) {
+	meta.Namespace = ""
+	delete(meta.Annotations, model.OktetoSampleAnnotation)
+	if len(meta.Annotations) == 0 {
+		meta.Annotations = nil
+	}
+}
+
+// setPlaceholderImage sets the service name as image of the services that are only defined by a build section.
+// The image is replaced by the built image when the manifests are deployed
+func setPlaceholderImage(svcName string, podSpec *apiv1.PodSpec) {
+	for i := range podSpec.Containers {
+		if podSpec.Containers[i].Image == "" {
+			podSpec.Containers[i].Image = svcName
+		}
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// hashedContent is the subset of a translated resource that is taken into account to compute its content hash
+type hashedContent struct {
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Spec        interface{}       `json:"spec"`
+}
+
+// computeResourceHash returns a deterministic hash of the labels, annotations and spec of a translated resource.
+// Maps are serialized with sorted keys, so logically-equal resources always produce the same hash
+func computeResourceHash(meta metav1.ObjectMeta, spec interface{}) (string, error) {
+	annotations := map[string]string{}
+	for k, v := range meta.Annotations {
+		if k == model.OktetoComposeHashAnnotation {
+			continue
+		}
+		annotations[k] = v
+	}
+	content, err := json.Marshal(hashedContent{
+		Labels:      meta.Labels,
+		Annotations: annotations,
+		Spec:        spec,
+	})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// setResourceHash stores the content hash of the resource in its annotations
+func setResourceHash(meta *metav1.ObjectMeta, spec interface{}) {
+	hash, err := computeResourceHash(*meta, spec)
+	if err != nil {
+		oktetoLog.Infof("error computing hash of '%s': %s", meta.Name, err)
+		return
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[model.OktetoComposeHashAnnotation] = hash
+}
+
+// computeMountedContentHash returns a hash of the content of the configs and secrets mounted by a service,
+// or an empty string if it doesn't mount any
+func computeMountedContentHash(svcName string, s *model.Stack) (string, error) {
+	svc := s.Services[svcName]
+	if len(svc.Configs) == 0 && len(svc.Secrets) == 0 {
+		return "", nil
+	}
+	content := map[string]interface{}{}
+	for _, cfg := range svc.Configs {
+		cm, err := translateConfig(cfg.Source, s)
+		if err != nil {
+			return "", err
+		}
+		content["config/"+cfg.Source] = []interface{}{cm.Data, cm.BinaryData}
+	}
+	for _, secret := range svc.Secrets {
+		k8sSecret, err := translateSecret(secret.Source, s)
+		if err != nil {
+			return "", err
+		}
+		content["secret/"+secret.Source] = k8sSecret.Data
+	}
+	b, err := json.Marshal(content)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// setMountedContentHash stores the hash of the configs and secrets mounted by a service in its pod template.
+// Pods reference them by name, so the hash is what changes the resource hash and rolls out the pods when their content changes
+func setMountedContentHash(meta *metav1.ObjectMeta, svcName string, s *model.Stack) {
+	hash, err := computeMountedContentHash(svcName, s)
+	if err != nil {
+		oktetoLog.Infof("error computing hash of the configs and secrets of service '%s': %s", svcName, err)
+		return
+	}
+	if hash == "" {
+		return
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[model.OktetoComposeMountedContentHashAnnotation] = hash
+}
+
+// isResourceUpToDate returns true when the live resource carries the same content hash as the translated one
+func isResourceUpToDate(old, translated metav1.Object) bool {
+	hash := translated.GetAnnotations()[model.OktetoComposeHashAnnotation]
+	if hash == "" {
+		return false
+	}
+	return old.GetAnnotations()[model.OktetoComposeHashAnnotation] == hash
+}
+
+// stackDigestContent is the subset of a parsed stack that defines what gets deployed.
+// The raw manifest is left out so whitespace, comments and key order don't change the digest
+type stackDigestContent struct {
+	Volumes   map[string]*model.VolumeSpec `json:"volumes,omitempty"`
+	Services  model.ComposeServices        `json:"services,omitempty"`
+	Endpoints model.EndpointSpec           `json:"endpoints,omitempty"`
+	Name      string                       `json:"name"`
+}
+
+// computeStackDigest returns a deterministic digest of the canonical form of the parsed stack
+func computeStackDigest(s *model.Stack) (string, error) {
+	content, err := json.Marshal(stackDigestContent{
+		Volumes:   s.Volumes,
+		Services:  s.Services,
+		Endpoints: s.Endpoints,
+		Name:      s.Name,
+	})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// HasChanged returns true when the stack differs from the one stored in the live stack configmap.
+// Configmaps created before the digest was stored are always considered changed
+func HasChanged(live *apiv1.ConfigMap, s *model.Stack) bool {
+	if live == nil || live.Data[DigestField] == "" {
+		return true
+	}
+	digest, err := computeStackDigest(s)
+	if err != nil {
+		oktetoLog.Infof("error computing digest of compose '%s': %s", s.Name, err)
+		return true
+	}
+	return live.Data[DigestField] != digest
+}
@@ -0,0 +1,314 @@
+// Copyright 2023 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"context"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/build"
+	"github.com/okteto/okteto/pkg/divert"
+	"github.com/okteto/okteto/pkg/env"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_computeResourceHash(t *testing.T) {
+	svcA := &model.Service{
+		Image: "test_image",
+		Environment: env.Environment{
+			{Name: "A", Value: "1"},
+			{Name: "B", Value: "2"},
+		},
+		Ports: []model.Port{{ContainerPort: 8080}, {ContainerPort: 80}},
+	}
+	svcB := &model.Service{
+		Image: "test_image",
+		Environment: env.Environment{
+			{Name: "A", Value: "1"},
+			{Name: "B", Value: "2"},
+		},
+		Ports: []model.Port{{ContainerPort: 80}, {ContainerPort: 8080}},
+	}
+	stackA := &model.Stack{Name: "stack", Namespace: "ns", Services: map[string]*model.Service{"api": svcA}}
+	stackB := &model.Stack{Name: "stack", Namespace: "ns", Services: map[string]*model.Service{"api": svcB}}
+
+	dA := translateDeployment("api", stackA, nil)
+	dB := translateDeployment("api", stackB, nil)
+	hashA, err := computeResourceHash(dA.ObjectMeta, dA.Spec)
+	require.NoError(t, err)
+	hashB, err := computeResourceHash(dB.ObjectMeta, dB.Spec)
+	require.NoError(t, err)
+	require.Equal(t, hashA, hashB)
+
+	// the environment keeps its declaration order, as variables can reference the variables declared before them
+	svcB.Environment = env.Environment{{Name: "B", Value: "2"}, {Name: "A", Value: "1"}}
+	dB = translateDeployment("api", stackB, nil)
+	hashB, err = computeResourceHash(dB.ObjectMeta, dB.Spec)
+	require.NoError(t, err)
+	require.NotEqual(t, hashA, hashB)
+
+	svcB.Image = "other_image"
+	dB = translateDeployment("api", stackB, nil)
+	hashB, err = computeResourceHash(dB.ObjectMeta, dB.Spec)
+	require.NoError(t, err)
+	require.NotEqual(t, hashA, hashB)
+}
+
+func Test_computeResourceHashIgnoresHashAnnotation(t *testing.T) {
+	meta := metav1.ObjectMeta{Annotations: map[string]string{"key": "value"}}
+	expected, err := computeResourceHash(meta, nil)
+	require.NoError(t, err)
+
+	setResourceHash(&meta, nil)
+	require.Equal(t, expected, meta.Annotations[model.OktetoComposeHashAnnotation])
+
+	got, err := computeResourceHash(meta, nil)
+	require.NoError(t, err)
+	require.Equal(t, expected, got)
+}
+
+func Test_isResourceUpToDate(t *testing.T) {
+	tests := []struct {
+		old        metav1.Object
+		translated metav1.Object
+		name       string
+		expected   bool
+	}{
+		{
+			name:       "no hash in translated",
+			old:        &metav1.ObjectMeta{Annotations: map[string]string{model.OktetoComposeHashAnnotation: "a"}},
+			translated: &metav1.ObjectMeta{},
+			expected:   false,
+		},
+		{
+			name:       "no hash in old",
+			old:        &metav1.ObjectMeta{},
+			translated: &metav1.ObjectMeta{Annotations: map[string]string{model.OktetoComposeHashAnnotation: "a"}},
+			expected:   false,
+		},
+		{
+			name:       "different hash",
+			old:        &metav1.ObjectMeta{Annotations: map[string]string{model.OktetoComposeHashAnnotation: "b"}},
+			translated: &metav1.ObjectMeta{Annotations: map[string]string{model.OktetoComposeHashAnnotation: "a"}},
+			expected:   false,
+		},
+		{
+			name:       "same hash",
+			old:        &metav1.ObjectMeta{Annotations: map[string]string{model.OktetoComposeHashAnnotation: "a"}},
+			translated: &metav1.ObjectMeta{Annotations: map[string]string{model.OktetoComposeHashAnnotation: "a"}},
+			expected:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, isResourceUpToDate(tt.old, tt.translated))
+		})
+	}
+}
+
+func Test_noopRedeployDoesNotUpdate(t *testing.T) {
+	tests := []struct {
+		svc  *model.Service
+		name string
+	}{
+		{
+			name: "deployment",
+			svc: &model.Service{
+				Image:         "test_image",
+				RestartPolicy: apiv1.RestartPolicyAlways,
+				Replicas:      1,
+				Ports:         []model.Port{{ContainerPort: 8080, Protocol: apiv1.ProtocolTCP}, {ContainerPort: 80, Protocol: apiv1.ProtocolTCP}},
+				Environment:   env.Environment{{Name: "B", Value: "2"}, {Name: "A", Value: "1"}},
+			},
+		},
+		{
+			name: "statefulset",
+			svc: &model.Service{
+				Image:         "test_image",
+				RestartPolicy: apiv1.RestartPolicyAlways,
+				Replicas:      1,
+				Volumes:       []build.VolumeMounts{{LocalPath: "a", RemotePath: "b"}},
+			},
+		},
+		{
+			name: "job",
+			svc: &model.Service{
+				Image:         "test_image",
+				RestartPolicy: apiv1.RestartPolicyNever,
+				Replicas:      1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			client := fake.NewSimpleClientset()
+			stack := &model.Stack{
+				Name:      "stack-test",
+				Namespace: "ns",
+				Services:  map[string]*model.Service{"test": tt.svc},
+				Volumes:   map[string]*model.VolumeSpec{"a": {}},
+			}
+
+			if len(tt.svc.Ports) > 0 {
+				require.NoError(t, deployK8sService(ctx, "test", stack, client))
+			}
+			require.NoError(t, deploySvc(ctx, stack, "test", client, divert.NewNoop()))
+			client.ClearActions()
+
+			// reorder the ports to check it doesn't trigger an update
+			for i, j := 0, len(tt.svc.Ports)-1; i < j; i, j = i+1, j-1 {
+				tt.svc.Ports[i], tt.svc.Ports[j] = tt.svc.Ports[j], tt.svc.Ports[i]
+			}
+
+			if len(tt.svc.Ports) > 0 {
+				require.NoError(t, deployK8sService(ctx, "test", stack, client))
+			}
+			require.NoError(t, deploySvc(ctx, stack, "test", client, divert.NewNoop()))
+			for _, action := range client.Actions() {
+				require.Equal(t, "get", action.GetVerb(), "unexpected %s on %s", action.GetVerb(), action.GetResource().Resource)
+			}
+
+			tt.svc.Image = "new_image"
+			require.NoError(t, deploySvc(ctx, stack, "test", client, divert.NewNoop()))
+			mutated := false
+			for _, action := range client.Actions() {
+				if action.GetVerb() != "get" {
+					mutated = true
+				}
+			}
+			require.True(t, mutated)
+		})
+	}
+}
+
+func Test_HasChanged(t *testing.T) {
+	manifest := []byte(`services:
+  api:
+    image: okteto/api
+    ports:
+      - 8080
+    environment:
+      DB_HOST: db
+      LOG_LEVEL: info
+  db:
+    image: postgres
+volumes:
+  data: {}
+`)
+	reformatted := []byte(`volumes:
+    data: {}
+services:
+    db:
+        image:   postgres
+
+    api:
+        environment:
+            DB_HOST: db
+            LOG_LEVEL: info
+        ports: [8080]
+        image: okteto/api
+`)
+	changed := []byte(`services:
+  api:
+    image: okteto/api
+    ports:
+      - 8080
+    environment:
+      DB_HOST: db
+      LOG_LEVEL: debug
+  db:
+    image: postgres
+volumes:
+  data: {}
+`)
+
+	readStack := func(bytes []byte) *model.Stack {
+		s, err := model.ReadStack(bytes, true)
+		require.NoError(t, err)
+		s.Name = "stack-test"
+		s.Namespace = "ns"
+		return s
+	}
+	live := translateConfigMap(readStack(manifest))
+	require.NotEmpty(t, live.Data[DigestField])
+
+	tests := []struct {
+		live     *apiv1.ConfigMap
+		name     string
+		manifest []byte
+		expected bool
+	}{
+		{
+			name:     "same manifest",
+			live:     live,
+			manifest: manifest,
+			expected: false,
+		},
+		{
+			name:     "whitespace and key order",
+			live:     live,
+			manifest: reformatted,
+			expected: false,
+		},
+		{
+			name:     "changed value",
+			live:     live,
+			manifest: changed,
+			expected: true,
+		},
+		{
+			name:     "configmap without digest",
+			live:     &apiv1.ConfigMap{Data: map[string]string{NameField: "stack-test"}},
+			manifest: manifest,
+			expected: true,
+		},
+		{
+			name:     "no configmap",
+			manifest: manifest,
+			expected: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, HasChanged(tt.live, readStack(tt.manifest)))
+		})
+	}
+}
+
+func Test_isDeployedAndUnchanged(t *testing.T) {
+	s := &model.Stack{
+		Name:      "stack-test",
+		Namespace: "ns",
+		Services:  map[string]*model.Service{"api": {Image: "okteto/api"}},
+	}
+	ctx := context.Background()
+
+	require.False(t, isDeployedAndUnchanged(ctx, s, fake.NewSimpleClientset()))
+
+	cfg := translateConfigMap(s)
+	cfg.Namespace = s.Namespace
+	cfg.Data[statusField] = progressingStatus
+	require.False(t, isDeployedAndUnchanged(ctx, s, fake.NewSimpleClientset(cfg)))
+
+	cfg.Data[statusField] = deployedStatus
+	require.True(t, isDeployedAndUnchanged(ctx, s, fake.NewSimpleClientset(cfg)))
+
+	s.Services["api"].Image = "okteto/api:v2"
+	require.False(t, isDeployedAndUnchanged(ctx, s, fake.NewSimpleClientset(cfg)))
+}
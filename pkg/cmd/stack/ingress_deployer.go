@@ -15,6 +15,7 @@ package stack
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/okteto/okteto/pkg/format"
 	"github.com/okteto/okteto/pkg/k8s/ingresses"
@@ -76,7 +77,53 @@ func skipIngressDeployForStackNameLabel(ctx context.Context, iClient *ingresses.
 }
 
 func deployK8sEndpoint(ctx context.Context, ingressName, svcName string, port model.Port, s *model.Stack, c *ingresses.Client) error {
-	// create a new endpoint for this port ingress deployment
+	endpoint := translateServiceEndpoint(ingressName, svcName, port, s)
+
+	translateOptions := &ingresses.TranslateOptions{
+		Name:      format.ResourceK8sMetaString(s.Name),
+		Namespace: s.Namespace,
+	}
+	ingress := ingresses.Translate(ingressName, endpoint, translateOptions)
+
+	// check for labels collision in the case of a compose - before creation or update (deploy)
+	if skipIngressDeployForStackNameLabel(ctx, c, ingress) {
+		return nil
+	}
+	return c.Deploy(ctx, ingress)
+}
+
+// getServiceIngressName returns the name of the ingress exposing a public port of a service.
+// If the service has more than one public port, each port gets an ingress named <serviceName>-<PORT>
+func getServiceIngressName(svcName string, port model.Port, publicPorts int) string {
+	if publicPorts > 1 {
+		return fmt.Sprintf("%s-%d", svcName, port.ContainerPort)
+	}
+	return svcName
+}
+
+// translateComposeEndpoint returns the endpoint defined in the compose endpoints spec with the stack labels
+func translateComposeEndpoint(endpointName string, s *model.Stack) model.Endpoint {
+	endpoint := s.Endpoints[endpointName]
+	// initialize the maps for Labels and Annotations if nil
+	if endpoint.Labels == nil {
+		endpoint.Labels = map[string]string{}
+	}
+	if endpoint.Annotations == nil {
+		endpoint.Annotations = map[string]string{}
+	}
+
+	// add specific stack labels
+	if _, ok := endpoint.Labels[model.StackNameLabel]; !ok {
+		endpoint.Labels[model.StackNameLabel] = format.ResourceK8sMetaString(s.Name)
+	}
+	if _, ok := endpoint.Labels[model.StackEndpointNameLabel]; !ok {
+		endpoint.Labels[model.StackEndpointNameLabel] = endpointName
+	}
+	return endpoint
+}
+
+// translateServiceEndpoint creates the endpoint exposing a public port of a service
+func translateServiceEndpoint(ingressName, svcName string, port model.Port, s *model.Stack) model.Endpoint {
 	endpoint := model.Endpoint{
 		Labels:      translateLabels(svcName, s),
 		Annotations: translateAnnotations(s.Services[svcName]),
@@ -95,16 +142,5 @@ func deployK8sEndpoint(ctx context.Context, ingressName, svcName string, port mo
 	if _, ok := endpoint.Labels[model.StackEndpointNameLabel]; !ok {
 		endpoint.Labels[model.StackEndpointNameLabel] = ingressName
 	}
-
-	translateOptions := &ingresses.TranslateOptions{
-		Name:      format.ResourceK8sMetaString(s.Name),
-		Namespace: s.Namespace,
-	}
-	ingress := ingresses.Translate(ingressName, endpoint, translateOptions)
-
-	// check for labels collision in the case of a compose - before creation or update (deploy)
-	if skipIngressDeployForStackNameLabel(ctx, c, ingress) {
-		return nil
-	}
-	return c.Deploy(ctx, ingress)
+	return endpoint
 }
@@ -0,0 +1,543 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/format"
+	"github.com/okteto/okteto/pkg/k8s/deployments"
+	"github.com/okteto/okteto/pkg/k8s/jobs"
+	"github.com/okteto/okteto/pkg/k8s/pods"
+	"github.com/okteto/okteto/pkg/k8s/statefulsets"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+)
+
+var (
+	// scaleWaitInterval is the interval to check the pods of the services when --wait is set
+	scaleWaitInterval = 1 * time.Second
+
+	errScaleTimeout = errors.New("kubernetes is taking too long to scale your services. Please check for errors and try again")
+)
+
+// ReplicaOverridesField is the field of the stack configmap with the replicas persisted by 'okteto stack scale --persist'
+const ReplicaOverridesField = "replicaOverrides"
+
+// ScaleOptions defines the options to stop, start and scale the services of a stack
+type ScaleOptions struct {
+	// Replicas are the replicas of each service to scale
+	Replicas  map[string]int32
+	Name      string
+	Namespace string
+	Services  []string
+	Timeout   time.Duration
+	Wait      bool
+	// Persist records the replicas in the stack configmap, so the next deploys honor them
+	Persist bool
+	// Reset removes the replicas of the services recorded in the stack configmap
+	Reset bool
+}
+
+// stackWorkloads are the workloads of the services of a stack deployed in the cluster
+type stackWorkloads struct {
+	deployments  map[string]*appsv1.Deployment
+	statefulsets map[string]*appsv1.StatefulSet
+	jobs         map[string]bool
+}
+
+// Stop scales the deployments and statefulsets of the stack services to zero, saving their replicas in an annotation
+func Stop(ctx context.Context, opts *ScaleOptions, c kubernetes.Interface) error {
+	w, err := getStackWorkloads(ctx, opts, c)
+	if err != nil {
+		return err
+	}
+	svcs, err := w.getServicesToScale(opts, "stopped")
+	if err != nil {
+		return err
+	}
+
+	for _, svcName := range svcs {
+		if err := w.stop(ctx, svcName, c); err != nil {
+			return err
+		}
+	}
+
+	if !opts.Wait {
+		return nil
+	}
+	err = waitForServices(ctx, opts.Timeout, func() (bool, error) {
+		return areServicesStopped(ctx, opts, svcs, c)
+	})
+	if err != nil {
+		return err
+	}
+	oktetoLog.Success("The pods of the services have been terminated")
+	return nil
+}
+
+// Start scales the deployments and statefulsets of the stack services back to the replicas they had before being stopped
+func Start(ctx context.Context, opts *ScaleOptions, c kubernetes.Interface) error {
+	w, err := getStackWorkloads(ctx, opts, c)
+	if err != nil {
+		return err
+	}
+	svcs, err := w.getServicesToScale(opts, "started")
+	if err != nil {
+		return err
+	}
+
+	for _, svcName := range svcs {
+		if err := w.start(ctx, svcName, c); err != nil {
+			return err
+		}
+	}
+
+	if !opts.Wait {
+		return nil
+	}
+	err = waitForServices(ctx, opts.Timeout, func() (bool, error) {
+		return w.areServicesReady(ctx, svcs, c)
+	})
+	if err != nil {
+		if errors.Is(err, errScaleTimeout) {
+			reportServiceFailures(ctx, opts.Namespace, opts.Name, svcs, c)
+		}
+		return err
+	}
+	oktetoLog.Success("The pods of the services are ready")
+	return nil
+}
+
+// Scale sets the replicas of the deployments and statefulsets of the stack services. The next deploy applies
+// the replicas of the compose file again, unless the replicas are persisted in the stack configmap
+func Scale(ctx context.Context, opts *ScaleOptions, c kubernetes.Interface) error {
+	if opts.Reset {
+		return resetReplicaOverrides(ctx, opts, c)
+	}
+
+	svcs := make([]string, 0, len(opts.Replicas))
+	for svcName := range opts.Replicas {
+		svcs = append(svcs, svcName)
+	}
+	sort.Strings(svcs)
+	if len(svcs) == 0 {
+		return errors.New("no services to scale")
+	}
+
+	w, err := getStackWorkloads(ctx, opts, c)
+	if err != nil {
+		return err
+	}
+	opts.Services = svcs
+	if _, err := w.getServicesToScale(opts, "scaled"); err != nil {
+		return err
+	}
+
+	var cfg *apiv1.ConfigMap
+	if opts.Persist {
+		cfg, err = getStackConfigMap(ctx, opts, c)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, svcName := range svcs {
+		if err := w.scale(ctx, svcName, opts.Replicas[svcName], c); err != nil {
+			return err
+		}
+	}
+
+	if opts.Persist {
+		overrides := getReplicaOverrides(cfg)
+		for _, svcName := range svcs {
+			overrides[svcName] = opts.Replicas[svcName]
+		}
+		setReplicaOverrides(cfg, overrides)
+		if err := deployStackConfigMap(ctx, cfg, opts.Namespace, c); err != nil {
+			return fmt.Errorf("error persisting the replicas of stack '%s': %w", opts.Name, err)
+		}
+		oktetoLog.Information("The replicas will be kept on the next deploys. Run 'okteto stack scale --reset' to use the replicas of your compose file")
+	}
+
+	if !opts.Wait {
+		return nil
+	}
+	err = waitForServices(ctx, opts.Timeout, func() (bool, error) {
+		return w.areServicesReady(ctx, svcs, c)
+	})
+	if err != nil {
+		if errors.Is(err, errScaleTimeout) {
+			reportServiceFailures(ctx, opts.Namespace, opts.Name, svcs, c)
+		}
+		return err
+	}
+	oktetoLog.Success("The pods of the services are ready")
+	return nil
+}
+
+// resetReplicaOverrides removes the replicas persisted for the selected services, or for every service if none is selected
+func resetReplicaOverrides(ctx context.Context, opts *ScaleOptions, c kubernetes.Interface) error {
+	cfg, err := getStackConfigMap(ctx, opts, c)
+	if err != nil {
+		return err
+	}
+	overrides := getReplicaOverrides(cfg)
+	if len(opts.Services) == 0 {
+		overrides = nil
+	}
+	for _, svcName := range opts.Services {
+		if _, ok := overrides[svcName]; !ok {
+			oktetoLog.Information("Service '%s' has no persisted replicas", svcName)
+			continue
+		}
+		delete(overrides, svcName)
+	}
+	setReplicaOverrides(cfg, overrides)
+	if err := deployStackConfigMap(ctx, cfg, opts.Namespace, c); err != nil {
+		return fmt.Errorf("error resetting the replicas of stack '%s': %w", opts.Name, err)
+	}
+	oktetoLog.Success("The persisted replicas have been reset. The replicas of your compose file will be applied on the next deploy")
+	return nil
+}
+
+func getStackConfigMap(ctx context.Context, opts *ScaleOptions, c kubernetes.Interface) (*apiv1.ConfigMap, error) {
+	cfg, err := getLiveStackConfigMap(ctx, opts.Name, opts.Namespace, c)
+	if err != nil {
+		if oktetoErrors.IsNotFound(err) {
+			return nil, fmt.Errorf("stack '%s' is not deployed in namespace '%s'", opts.Name, opts.Namespace)
+		}
+		return nil, fmt.Errorf("error getting the configmap of stack '%s': %w", opts.Name, err)
+	}
+	return cfg, nil
+}
+
+// ParseScaleArgs parses the '<service>=<replicas>' arguments of 'okteto stack scale'
+func ParseScaleArgs(args []string) (map[string]int32, error) {
+	result := map[string]int32{}
+	for _, arg := range args {
+		svcName, value, ok := strings.Cut(arg, "=")
+		if !ok || svcName == "" {
+			return nil, fmt.Errorf("invalid argument '%s': it must be '<service>=<replicas>'", arg)
+		}
+		replicas, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || replicas < 0 {
+			return nil, fmt.Errorf("invalid replicas '%s' of service '%s': it must be zero or a positive number", value, svcName)
+		}
+		result[svcName] = int32(replicas)
+	}
+	return result, nil
+}
+
+// getReplicaOverrides returns the replicas persisted in the stack configmap by service
+func getReplicaOverrides(cfg *apiv1.ConfigMap) map[string]int32 {
+	result := map[string]int32{}
+	if cfg == nil || cfg.Data[ReplicaOverridesField] == "" {
+		return result
+	}
+	if err := json.Unmarshal([]byte(cfg.Data[ReplicaOverridesField]), &result); err != nil {
+		oktetoLog.Infof("invalid value of field '%s' of configmap '%s': %s", ReplicaOverridesField, cfg.Name, err)
+		return map[string]int32{}
+	}
+	return result
+}
+
+// setReplicaOverrides records the replicas by service in the stack configmap
+func setReplicaOverrides(cfg *apiv1.ConfigMap, overrides map[string]int32) {
+	if len(overrides) == 0 {
+		delete(cfg.Data, ReplicaOverridesField)
+		return
+	}
+	b, err := json.Marshal(overrides)
+	if err != nil {
+		oktetoLog.Infof("error encoding the replicas of the services: %s", err)
+		return
+	}
+	if cfg.Data == nil {
+		cfg.Data = map[string]string{}
+	}
+	cfg.Data[ReplicaOverridesField] = string(b)
+}
+
+// applyReplicaOverrides sets the replicas persisted by 'okteto stack scale --persist', which take precedence over the compose file
+func applyReplicaOverrides(s *model.Stack, overrides map[string]int32) {
+	for svcName, replicas := range overrides {
+		svc, ok := s.Services[svcName]
+		if !ok || svc.IsJob() {
+			continue
+		}
+		if svc.Replicas != replicas {
+			oktetoLog.Information("Service '%s' is deployed with the %d replicas persisted by 'okteto stack scale'. Run 'okteto stack scale --reset %s' to use the replicas of your compose file", svcName, replicas, svcName)
+		}
+		svc.Replicas = replicas
+	}
+}
+
+func getStackWorkloads(ctx context.Context, opts *ScaleOptions, c kubernetes.Interface) (*stackWorkloads, error) {
+	selector := fmt.Sprintf("%s=%s", model.StackNameLabel, format.ResourceK8sMetaString(opts.Name))
+	w := &stackWorkloads{
+		deployments:  map[string]*appsv1.Deployment{},
+		statefulsets: map[string]*appsv1.StatefulSet{},
+		jobs:         map[string]bool{},
+	}
+
+	dList, err := deployments.List(ctx, opts.Namespace, selector, c)
+	if err != nil {
+		return nil, fmt.Errorf("error listing the deployments of stack '%s': %w", opts.Name, err)
+	}
+	for i := range dList {
+		w.deployments[dList[i].Labels[model.StackServiceNameLabel]] = &dList[i]
+	}
+
+	sfsList, err := statefulsets.List(ctx, opts.Namespace, selector, c)
+	if err != nil {
+		return nil, fmt.Errorf("error listing the statefulsets of stack '%s': %w", opts.Name, err)
+	}
+	for i := range sfsList {
+		w.statefulsets[sfsList[i].Labels[model.StackServiceNameLabel]] = &sfsList[i]
+	}
+
+	jobsList, err := jobs.List(ctx, opts.Namespace, selector, c)
+	if err != nil {
+		return nil, fmt.Errorf("error listing the jobs of stack '%s': %w", opts.Name, err)
+	}
+	for i := range jobsList {
+		w.jobs[jobsList[i].Labels[model.StackServiceNameLabel]] = true
+	}
+
+	return w, nil
+}
+
+// getServicesToScale returns the services to stop or start. If no services are selected, it returns every
+// deployment and statefulset of the stack. Jobs run to completion, so they can't be stopped or started
+func (w *stackWorkloads) getServicesToScale(opts *ScaleOptions, action string) ([]string, error) {
+	svcs := []string{}
+	if len(opts.Services) == 0 {
+		for svcName := range w.deployments {
+			svcs = append(svcs, svcName)
+		}
+		for svcName := range w.statefulsets {
+			svcs = append(svcs, svcName)
+		}
+		for svcName := range w.jobs {
+			oktetoLog.Information("Skipping service '%s': jobs can't be %s", svcName, action)
+		}
+		if len(svcs) == 0 {
+			return nil, fmt.Errorf("stack '%s' has no services that can be %s", opts.Name, action)
+		}
+		sort.Strings(svcs)
+		return svcs, nil
+	}
+
+	for _, svcName := range opts.Services {
+		if w.jobs[svcName] {
+			return nil, fmt.Errorf("service '%s' is a job and jobs can't be %s", svcName, action)
+		}
+		_, isDeployment := w.deployments[svcName]
+		_, isStatefulset := w.statefulsets[svcName]
+		if !isDeployment && !isStatefulset {
+			return nil, fmt.Errorf("service '%s' is not deployed in stack '%s'", svcName, opts.Name)
+		}
+		svcs = append(svcs, svcName)
+	}
+	return svcs, nil
+}
+
+func (w *stackWorkloads) stop(ctx context.Context, svcName string, c kubernetes.Interface) error {
+	if d, ok := w.deployments[svcName]; ok {
+		if _, ok := d.Annotations[model.StackStoppedReplicasAnnotation]; ok {
+			oktetoLog.Information("Service '%s' is already stopped", svcName)
+			return nil
+		}
+		d.Annotations = setStoppedReplicas(d.Annotations, d.Spec.Replicas)
+		d.Spec.Replicas = ptr.To(int32(0))
+		if _, err := c.AppsV1().Deployments(d.Namespace).Update(ctx, d, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("error stopping service '%s': %w", svcName, err)
+		}
+		oktetoLog.Success("Service '%s' stopped", svcName)
+		return nil
+	}
+
+	sfs := w.statefulsets[svcName]
+	if _, ok := sfs.Annotations[model.StackStoppedReplicasAnnotation]; ok {
+		oktetoLog.Information("Service '%s' is already stopped", svcName)
+		return nil
+	}
+	sfs.Annotations = setStoppedReplicas(sfs.Annotations, sfs.Spec.Replicas)
+	sfs.Spec.Replicas = ptr.To(int32(0))
+	if _, err := c.AppsV1().StatefulSets(sfs.Namespace).Update(ctx, sfs, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("error stopping service '%s': %w", svcName, err)
+	}
+	oktetoLog.Success("Service '%s' stopped", svcName)
+	return nil
+}
+
+func (w *stackWorkloads) start(ctx context.Context, svcName string, c kubernetes.Interface) error {
+	if d, ok := w.deployments[svcName]; ok {
+		replicas, ok := getStoppedReplicas(d.Annotations)
+		if !ok {
+			oktetoLog.Information("Service '%s' is not stopped", svcName)
+			return nil
+		}
+		delete(d.Annotations, model.StackStoppedReplicasAnnotation)
+		d.Spec.Replicas = ptr.To(replicas)
+		if _, err := c.AppsV1().Deployments(d.Namespace).Update(ctx, d, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("error starting service '%s': %w", svcName, err)
+		}
+		oktetoLog.Success("Service '%s' started", svcName)
+		return nil
+	}
+
+	sfs := w.statefulsets[svcName]
+	replicas, ok := getStoppedReplicas(sfs.Annotations)
+	if !ok {
+		oktetoLog.Information("Service '%s' is not stopped", svcName)
+		return nil
+	}
+	delete(sfs.Annotations, model.StackStoppedReplicasAnnotation)
+	sfs.Spec.Replicas = ptr.To(replicas)
+	if _, err := c.AppsV1().StatefulSets(sfs.Namespace).Update(ctx, sfs, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("error starting service '%s': %w", svcName, err)
+	}
+	oktetoLog.Success("Service '%s' started", svcName)
+	return nil
+}
+
+// scale sets the replicas of a service. Scaling a stopped service starts it with the new replicas
+func (w *stackWorkloads) scale(ctx context.Context, svcName string, replicas int32, c kubernetes.Interface) error {
+	if d, ok := w.deployments[svcName]; ok {
+		delete(d.Annotations, model.StackStoppedReplicasAnnotation)
+		d.Spec.Replicas = ptr.To(replicas)
+		if _, err := c.AppsV1().Deployments(d.Namespace).Update(ctx, d, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("error scaling service '%s': %w", svcName, err)
+		}
+		oktetoLog.Success("Service '%s' scaled to %d replicas", svcName, replicas)
+		return nil
+	}
+
+	sfs := w.statefulsets[svcName]
+	delete(sfs.Annotations, model.StackStoppedReplicasAnnotation)
+	sfs.Spec.Replicas = ptr.To(replicas)
+	if _, err := c.AppsV1().StatefulSets(sfs.Namespace).Update(ctx, sfs, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("error scaling service '%s': %w", svcName, err)
+	}
+	oktetoLog.Success("Service '%s' scaled to %d replicas", svcName, replicas)
+	return nil
+}
+
+// setStoppedReplicas saves the current replicas of a service in its annotations
+func setStoppedReplicas(annotations map[string]string, replicas *int32) map[string]string {
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	current := int32(1)
+	if replicas != nil {
+		current = *replicas
+	}
+	annotations[model.StackStoppedReplicasAnnotation] = strconv.Itoa(int(current))
+	return annotations
+}
+
+// getStoppedReplicas returns the replicas of a service before it was stopped and false if the service is not stopped
+func getStoppedReplicas(annotations map[string]string) (int32, bool) {
+	v, ok := annotations[model.StackStoppedReplicasAnnotation]
+	if !ok {
+		return 0, false
+	}
+	replicas, err := strconv.ParseInt(v, 10, 32)
+	if err != nil || replicas < 0 {
+		oktetoLog.Infof("invalid value '%s' for annotation '%s': starting one replica", v, model.StackStoppedReplicasAnnotation)
+		return 1, true
+	}
+	return int32(replicas), true
+}
+
+func areServicesStopped(ctx context.Context, opts *ScaleOptions, svcs []string, c kubernetes.Interface) (bool, error) {
+	for _, svcName := range svcs {
+		selector := map[string]string{
+			model.StackNameLabel:        format.ResourceK8sMetaString(opts.Name),
+			model.StackServiceNameLabel: svcName,
+		}
+		podList, err := pods.ListBySelector(ctx, opts.Namespace, selector, c)
+		if err != nil {
+			return false, err
+		}
+		if len(podList) > 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (w *stackWorkloads) areServicesReady(ctx context.Context, svcs []string, c kubernetes.Interface) (bool, error) {
+	for _, svcName := range svcs {
+		if d, ok := w.deployments[svcName]; ok {
+			d, err := deployments.Get(ctx, d.Name, d.Namespace, c)
+			if err != nil {
+				return false, err
+			}
+			if d.Spec.Replicas != nil && d.Status.ReadyReplicas < *d.Spec.Replicas {
+				return false, nil
+			}
+			continue
+		}
+		sfs, err := statefulsets.Get(ctx, w.statefulsets[svcName].Name, w.statefulsets[svcName].Namespace, c)
+		if err != nil {
+			return false, err
+		}
+		if sfs.Spec.Replicas != nil && sfs.Status.ReadyReplicas < *sfs.Spec.Replicas {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func waitForServices(ctx context.Context, timeout time.Duration, isDone func() (bool, error)) error {
+	ticker := time.NewTicker(scaleWaitInterval)
+	defer ticker.Stop()
+	to := time.Now().Add(timeout)
+
+	for {
+		done, err := isDone()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+		if time.Now().After(to) {
+			return errScaleTimeout
+		}
+
+		select {
+		case <-ticker.C:
+			continue
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
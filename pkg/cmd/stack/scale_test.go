@@ -0,0 +1,273 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+func stackObjectMeta(svcName string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      svcName,
+		Namespace: "ns",
+		Labels: map[string]string{
+			model.StackNameLabel:        "stack",
+			model.StackServiceNameLabel: svcName,
+		},
+	}
+}
+
+func newFakeStackClient() *fake.Clientset {
+	return fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: stackObjectMeta("api"),
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(int32(3))},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: stackObjectMeta("elasticsearch"),
+			Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(int32(2))},
+		},
+		&batchv1.Job{
+			ObjectMeta: stackObjectMeta("migrations"),
+		},
+	)
+}
+
+func TestStopStartRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeStackClient()
+	opts := &ScaleOptions{Name: "stack", Namespace: "ns"}
+
+	require.NoError(t, Stop(ctx, opts, c))
+
+	d, err := c.AppsV1().Deployments("ns").Get(ctx, "api", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), *d.Spec.Replicas)
+	assert.Equal(t, "3", d.Annotations[model.StackStoppedReplicasAnnotation])
+
+	sfs, err := c.AppsV1().StatefulSets("ns").Get(ctx, "elasticsearch", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), *sfs.Spec.Replicas)
+	assert.Equal(t, "2", sfs.Annotations[model.StackStoppedReplicasAnnotation])
+
+	// stopping a stopped service keeps the replicas it had before being stopped
+	require.NoError(t, Stop(ctx, opts, c))
+	d, err = c.AppsV1().Deployments("ns").Get(ctx, "api", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "3", d.Annotations[model.StackStoppedReplicasAnnotation])
+
+	require.NoError(t, Start(ctx, opts, c))
+
+	d, err = c.AppsV1().Deployments("ns").Get(ctx, "api", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), *d.Spec.Replicas)
+	assert.NotContains(t, d.Annotations, model.StackStoppedReplicasAnnotation)
+
+	sfs, err = c.AppsV1().StatefulSets("ns").Get(ctx, "elasticsearch", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), *sfs.Spec.Replicas)
+	assert.NotContains(t, sfs.Annotations, model.StackStoppedReplicasAnnotation)
+}
+
+func TestStopSingleService(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeStackClient()
+
+	require.NoError(t, Stop(ctx, &ScaleOptions{Name: "stack", Namespace: "ns", Services: []string{"elasticsearch"}}, c))
+
+	sfs, err := c.AppsV1().StatefulSets("ns").Get(ctx, "elasticsearch", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), *sfs.Spec.Replicas)
+
+	d, err := c.AppsV1().Deployments("ns").Get(ctx, "api", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), *d.Spec.Replicas)
+	assert.NotContains(t, d.Annotations, model.StackStoppedReplicasAnnotation)
+}
+
+func TestScaleErrors(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeStackClient()
+
+	err := Stop(ctx, &ScaleOptions{Name: "stack", Namespace: "ns", Services: []string{"migrations"}}, c)
+	assert.ErrorContains(t, err, "service 'migrations' is a job and jobs can't be stopped")
+
+	err = Start(ctx, &ScaleOptions{Name: "stack", Namespace: "ns", Services: []string{"migrations"}}, c)
+	assert.ErrorContains(t, err, "service 'migrations' is a job and jobs can't be started")
+
+	err = Stop(ctx, &ScaleOptions{Name: "stack", Namespace: "ns", Services: []string{"db"}}, c)
+	assert.ErrorContains(t, err, "service 'db' is not deployed in stack 'stack'")
+
+	err = Stop(ctx, &ScaleOptions{Name: "other", Namespace: "ns"}, c)
+	assert.ErrorContains(t, err, "stack 'other' has no services that can be stopped")
+}
+
+func TestStopWait(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeStackClient()
+	pod := &apiv1.Pod{ObjectMeta: stackObjectMeta("api")}
+	pod.Name = "api-1234"
+	_, err := c.CoreV1().Pods("ns").Create(ctx, pod, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	err = Stop(ctx, &ScaleOptions{Name: "stack", Namespace: "ns", Services: []string{"api"}, Wait: true, Timeout: 10 * time.Millisecond}, c)
+	assert.ErrorIs(t, err, errScaleTimeout)
+
+	require.NoError(t, c.CoreV1().Pods("ns").Delete(ctx, pod.Name, metav1.DeleteOptions{}))
+	err = Stop(ctx, &ScaleOptions{Name: "stack", Namespace: "ns", Services: []string{"api"}, Wait: true, Timeout: 10 * time.Millisecond}, c)
+	assert.NoError(t, err)
+}
+
+func TestGetStoppedReplicas(t *testing.T) {
+	_, ok := getStoppedReplicas(nil)
+	assert.False(t, ok)
+
+	replicas, ok := getStoppedReplicas(map[string]string{model.StackStoppedReplicasAnnotation: "0"})
+	assert.True(t, ok)
+	assert.Equal(t, int32(0), replicas)
+
+	replicas, ok = getStoppedReplicas(map[string]string{model.StackStoppedReplicasAnnotation: "wrong"})
+	assert.True(t, ok)
+	assert.Equal(t, int32(1), replicas)
+}
+
+func newFakeStackClientWithConfigMap(t *testing.T, data map[string]string) *fake.Clientset {
+	t.Helper()
+	c := newFakeStackClient()
+	_, err := c.CoreV1().ConfigMaps("ns").Create(context.Background(), &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: model.GetStackConfigMapName("stack"), Namespace: "ns"},
+		Data:       data,
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	return c
+}
+
+func TestScale(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeStackClientWithConfigMap(t, map[string]string{NameField: "stack"})
+
+	require.NoError(t, Stop(ctx, &ScaleOptions{Name: "stack", Namespace: "ns", Services: []string{"api"}}, c))
+	require.NoError(t, Scale(ctx, &ScaleOptions{Name: "stack", Namespace: "ns", Replicas: map[string]int32{"api": 5, "elasticsearch": 1}}, c))
+
+	d, err := c.AppsV1().Deployments("ns").Get(ctx, "api", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(5), *d.Spec.Replicas)
+	assert.NotContains(t, d.Annotations, model.StackStoppedReplicasAnnotation)
+
+	sfs, err := c.AppsV1().StatefulSets("ns").Get(ctx, "elasticsearch", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), *sfs.Spec.Replicas)
+
+	cfg, err := c.CoreV1().ConfigMaps("ns").Get(ctx, model.GetStackConfigMapName("stack"), metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotContains(t, cfg.Data, ReplicaOverridesField)
+}
+
+func TestScalePersistAndReset(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeStackClientWithConfigMap(t, map[string]string{NameField: "stack"})
+	getOverrides := func() map[string]int32 {
+		cfg, err := c.CoreV1().ConfigMaps("ns").Get(ctx, model.GetStackConfigMapName("stack"), metav1.GetOptions{})
+		require.NoError(t, err)
+		return getReplicaOverrides(cfg)
+	}
+
+	require.NoError(t, Scale(ctx, &ScaleOptions{Name: "stack", Namespace: "ns", Replicas: map[string]int32{"api": 5}, Persist: true}, c))
+	require.NoError(t, Scale(ctx, &ScaleOptions{Name: "stack", Namespace: "ns", Replicas: map[string]int32{"elasticsearch": 0}, Persist: true}, c))
+	assert.Equal(t, map[string]int32{"api": 5, "elasticsearch": 0}, getOverrides())
+
+	require.NoError(t, Scale(ctx, &ScaleOptions{Name: "stack", Namespace: "ns", Services: []string{"api"}, Reset: true}, c))
+	assert.Equal(t, map[string]int32{"elasticsearch": 0}, getOverrides())
+
+	require.NoError(t, Scale(ctx, &ScaleOptions{Name: "stack", Namespace: "ns", Reset: true}, c))
+	assert.Empty(t, getOverrides())
+}
+
+func TestScaleReplicasErrors(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeStackClient()
+
+	err := Scale(ctx, &ScaleOptions{Name: "stack", Namespace: "ns", Replicas: map[string]int32{"migrations": 2}}, c)
+	assert.ErrorContains(t, err, "service 'migrations' is a job and jobs can't be scaled")
+
+	err = Scale(ctx, &ScaleOptions{Name: "stack", Namespace: "ns", Replicas: map[string]int32{"api": 2}, Persist: true}, c)
+	assert.ErrorContains(t, err, "stack 'stack' is not deployed in namespace 'ns'")
+
+	d, err := c.AppsV1().Deployments("ns").Get(ctx, "api", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), *d.Spec.Replicas)
+}
+
+func TestParseScaleArgs(t *testing.T) {
+	replicas, err := ParseScaleArgs([]string{"api=3", "worker=0"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int32{"api": 3, "worker": 0}, replicas)
+
+	_, err = ParseScaleArgs([]string{"api"})
+	assert.ErrorContains(t, err, "invalid argument 'api'")
+
+	_, err = ParseScaleArgs([]string{"=3"})
+	assert.ErrorContains(t, err, "invalid argument '=3'")
+
+	_, err = ParseScaleArgs([]string{"api=-1"})
+	assert.ErrorContains(t, err, "invalid replicas '-1' of service 'api'")
+}
+
+func TestApplyReplicaOverrides(t *testing.T) {
+	s := &model.Stack{
+		Services: map[string]*model.Service{
+			"api":        {Replicas: 1},
+			"worker":     {Replicas: 2},
+			"migrations": {Replicas: 1, RestartPolicy: apiv1.RestartPolicyNever},
+		},
+	}
+	applyReplicaOverrides(s, map[string]int32{"api": 4, "migrations": 3, "removed": 2})
+
+	assert.Equal(t, int32(4), s.Services["api"].Replicas)
+	assert.Equal(t, int32(2), s.Services["worker"].Replicas)
+	assert.Equal(t, int32(1), s.Services["migrations"].Replicas)
+	assert.NotContains(t, s.Services, "removed")
+}
+
+func TestGetDeployedReplicaOverrides(t *testing.T) {
+	ctx := context.Background()
+	s := &model.Stack{Name: "stack", Namespace: "ns"}
+
+	assert.Empty(t, getDeployedReplicaOverrides(ctx, s, fake.NewSimpleClientset()))
+
+	c := newFakeStackClientWithConfigMap(t, map[string]string{ReplicaOverridesField: `{"api":4}`})
+	overrides := getDeployedReplicaOverrides(ctx, s, c)
+	assert.Equal(t, map[string]int32{"api": 4}, overrides)
+
+	cfg := translateConfigMap(s)
+	setReplicaOverrides(cfg, overrides)
+	assert.Equal(t, `{"api":4}`, cfg.Data[ReplicaOverridesField])
+
+	setReplicaOverrides(cfg, nil)
+	assert.NotContains(t, cfg.Data, ReplicaOverridesField)
+
+	assert.Empty(t, getReplicaOverrides(&apiv1.ConfigMap{Data: map[string]string{ReplicaOverridesField: "wrong"}}))
+}
@@ -0,0 +1,228 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/format"
+	"github.com/okteto/okteto/pkg/k8s/configmaps"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// YamlEncodingAnnotation is set on the configmap of a stack when its manifest is compressed
+	YamlEncodingAnnotation = "dev.okteto.com/yaml-encoding"
+
+	// YamlPartsAnnotation is set on the configmap of a stack when its manifest is split across several configmaps
+	YamlPartsAnnotation = "dev.okteto.com/yaml-parts"
+
+	// yamlPartIndexAnnotation is the index of the manifest chunk stored in a part configmap
+	yamlPartIndexAnnotation = "dev.okteto.com/yaml-part-index"
+
+	// gzipEncoding is the value of YamlEncodingAnnotation for gzipped manifests
+	gzipEncoding = "gzip"
+
+	// maxStackManifestSize is the max size of the encoded manifest stored in a single configmap.
+	// The apiserver rejects configmaps bigger than 1MiB, so room is left for the rest of the fields
+	maxStackManifestSize = 900 * 1024
+)
+
+// encodeStackManifest returns the manifest encoded to be stored in the configmap of a stack and its encoding.
+// Manifests over maxStackManifestSize once base64-encoded are gzipped before encoding them
+func encodeStackManifest(manifest []byte) (string, string) {
+	encoded := base64.StdEncoding.EncodeToString(manifest)
+	if len(encoded) <= maxStackManifestSize {
+		return encoded, ""
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(manifest); err != nil {
+		oktetoLog.Infof("error compressing compose manifest: %s", err)
+		return encoded, ""
+	}
+	if err := gz.Close(); err != nil {
+		oktetoLog.Infof("error compressing compose manifest: %s", err)
+		return encoded, ""
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), gzipEncoding
+}
+
+// splitStackConfigMap moves the manifest of the configmap of a stack to part configmaps when it's still
+// over maxStackManifestSize, and returns them. The number of parts is kept in YamlPartsAnnotation
+func splitStackConfigMap(cfg *apiv1.ConfigMap) []*apiv1.ConfigMap {
+	yaml := cfg.Data[YamlField]
+	if len(yaml) <= maxStackManifestSize {
+		return nil
+	}
+
+	parts := []*apiv1.ConfigMap{}
+	for i := 0; len(yaml) > 0; i++ {
+		size := min(len(yaml), maxStackManifestSize)
+		parts = append(parts, translateConfigMapPart(cfg, i, yaml[:size]))
+		yaml = yaml[size:]
+	}
+	if cfg.Annotations == nil {
+		cfg.Annotations = map[string]string{}
+	}
+	cfg.Annotations[YamlPartsAnnotation] = strconv.Itoa(len(parts))
+	cfg.Data[YamlField] = ""
+	return parts
+}
+
+// translateConfigMapPart returns the configmap storing the chunk i of the manifest of a stack
+func translateConfigMapPart(cfg *apiv1.ConfigMap, i int, chunk string) *apiv1.ConfigMap {
+	return &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: getConfigMapPartName(cfg.Name, i),
+			Labels: map[string]string{
+				model.DeployedByLabel: cfg.Labels[model.DeployedByLabel],
+				model.StackNameLabel:  format.ResourceK8sMetaString(cfg.Data[NameField]),
+			},
+			Annotations: map[string]string{
+				yamlPartIndexAnnotation: strconv.Itoa(i),
+			},
+		},
+		Data: map[string]string{
+			YamlField: chunk,
+		},
+	}
+}
+
+func getConfigMapPartName(name string, i int) string {
+	return fmt.Sprintf("%s-yaml-%d", name, i)
+}
+
+// deployStackConfigMap creates or updates the configmap of a stack, splitting its manifest across part
+// configmaps when it doesn't fit in a single one. Parts left over from a previous deploy are destroyed
+func deployStackConfigMap(ctx context.Context, cfg *apiv1.ConfigMap, namespace string, c kubernetes.Interface) error {
+	stored := cfg.DeepCopy()
+	parts := splitStackConfigMap(stored)
+
+	previousParts := 0
+	if live, err := configmaps.Get(ctx, cfg.Name, namespace, c); err == nil {
+		previousParts, _ = strconv.Atoi(live.Annotations[YamlPartsAnnotation])
+	} else if !oktetoErrors.IsNotFound(err) {
+		oktetoLog.Infof("error getting configmap '%s': %s", cfg.Name, err)
+	}
+
+	for _, part := range parts {
+		if err := configmaps.Deploy(ctx, part, namespace, c); err != nil {
+			return err
+		}
+	}
+	if err := configmaps.Deploy(ctx, stored, namespace, c); err != nil {
+		return err
+	}
+	for i := len(parts); i < previousParts; i++ {
+		if err := configmaps.Destroy(ctx, getConfigMapPartName(cfg.Name, i), namespace, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getLiveStackConfigMap returns the configmap of a stack with the manifest stored in part configmaps joined back
+// into its 'yaml' field, so it can be read and deployed again with deployStackConfigMap like a single configmap
+func getLiveStackConfigMap(ctx context.Context, stackName, namespace string, c kubernetes.Interface) (*apiv1.ConfigMap, error) {
+	cfg, err := configmaps.Get(ctx, model.GetStackConfigMapName(stackName), namespace, c)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Annotations[YamlPartsAnnotation] == "" {
+		return cfg, nil
+	}
+	yaml, err := joinStackManifestParts(ctx, cfg, c)
+	if err != nil {
+		return nil, err
+	}
+	delete(cfg.Annotations, YamlPartsAnnotation)
+	if cfg.Data == nil {
+		cfg.Data = map[string]string{}
+	}
+	cfg.Data[YamlField] = yaml
+	return cfg, nil
+}
+
+// joinStackManifestParts returns the encoded manifest of a stack split across the part configmaps
+func joinStackManifestParts(ctx context.Context, cfg *apiv1.ConfigMap, c kubernetes.Interface) (string, error) {
+	value := cfg.Annotations[YamlPartsAnnotation]
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid number of parts of the manifest of '%s': %s", cfg.Name, value)
+	}
+	var sb strings.Builder
+	for i := 0; i < n; i++ {
+		part, err := configmaps.Get(ctx, getConfigMapPartName(cfg.Name, i), cfg.Namespace, c)
+		if err != nil {
+			return "", fmt.Errorf("error getting part %d of the manifest of '%s': %w", i, cfg.Name, err)
+		}
+		sb.WriteString(part.Data[YamlField])
+	}
+	return sb.String(), nil
+}
+
+// GetStackManifest returns the manifest stored in the configmap of a stack. It supports manifests stored
+// base64-encoded, gzipped and split across several configmaps
+func GetStackManifest(ctx context.Context, cfg *apiv1.ConfigMap, c kubernetes.Interface) ([]byte, error) {
+	yaml := cfg.Data[YamlField]
+	if cfg.Annotations[YamlPartsAnnotation] != "" {
+		var err error
+		yaml, err = joinStackManifestParts(ctx, cfg, c)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	manifest, err := base64.StdEncoding.DecodeString(yaml)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding the manifest of '%s': %w", cfg.Name, err)
+	}
+
+	switch encoding := cfg.Annotations[YamlEncodingAnnotation]; encoding {
+	case "":
+		return manifest, nil
+	case gzipEncoding:
+		gz, err := gzip.NewReader(bytes.NewReader(manifest))
+		if err != nil {
+			return nil, fmt.Errorf("error decompressing the manifest of '%s': %w", cfg.Name, err)
+		}
+		defer gz.Close()
+		result, err := io.ReadAll(gz)
+		if err != nil {
+			return nil, fmt.Errorf("error decompressing the manifest of '%s': %w", cfg.Name, err)
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("unsupported encoding '%s' of the manifest of '%s'", encoding, cfg.Name)
+	}
+}
+
+// isStackManifestPart returns true for the configmaps storing a part of the manifest of a stack
+func isStackManifestPart(cfg *apiv1.ConfigMap) bool {
+	return cfg.Annotations[yamlPartIndexAnnotation] != ""
+}
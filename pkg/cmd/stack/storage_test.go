@@ -0,0 +1,213 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const manifestTestSize = 2 * 1024 * 1024
+
+// newCompressibleManifest returns a multi-document manifest of around 2MB using anchors and aliases
+func newCompressibleManifest() []byte {
+	var sb strings.Builder
+	sb.WriteString("x-common: &common\n  image: okteto/api\n  environment:\n    LOG_LEVEL: debug\n")
+	for i := 0; sb.Len() < manifestTestSize; i++ {
+		fmt.Fprintf(&sb, "---\nservices:\n  api-%d:\n    <<: *common\n    ports:\n      - 8080\n", i)
+	}
+	return []byte(sb.String())
+}
+
+// newIncompressibleManifest returns a manifest of around 2MB that is still too big once gzipped
+func newIncompressibleManifest() []byte {
+	r := rand.New(rand.NewSource(1))
+	var sb strings.Builder
+	sb.WriteString("services:\n  api:\n    image: okteto/api\n    environment:\n")
+	for i := 0; sb.Len() < manifestTestSize; i++ {
+		value := make([]byte, 96)
+		r.Read(value)
+		fmt.Fprintf(&sb, "      VAR_%d: %s\n", i, base64.StdEncoding.EncodeToString(value))
+	}
+	return []byte(sb.String())
+}
+
+func TestStackConfigMapRoundTrip(t *testing.T) {
+	tests := []struct {
+		name             string
+		manifest         []byte
+		expectedEncoding string
+		expectedParts    int
+	}{
+		{
+			name:     "small manifest",
+			manifest: []byte("services:\n  api:\n    image: okteto/api\n"),
+		},
+		{
+			name:             "compressed manifest",
+			manifest:         newCompressibleManifest(),
+			expectedEncoding: gzipEncoding,
+		},
+		{
+			name:             "split manifest",
+			manifest:         newIncompressibleManifest(),
+			expectedEncoding: gzipEncoding,
+			expectedParts:    3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			c := fake.NewSimpleClientset()
+			s := &model.Stack{Name: "test", Namespace: "ns", Manifest: tt.manifest}
+
+			cfg := translateConfigMap(s)
+			require.NoError(t, deployStackConfigMap(ctx, cfg, s.Namespace, c))
+
+			live, err := c.CoreV1().ConfigMaps(s.Namespace).Get(ctx, cfg.Name, metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedEncoding, live.Annotations[YamlEncodingAnnotation])
+			if tt.expectedParts == 0 {
+				assert.Empty(t, live.Annotations[YamlPartsAnnotation])
+			} else {
+				assert.Equal(t, fmt.Sprint(tt.expectedParts), live.Annotations[YamlPartsAnnotation])
+				assert.Empty(t, live.Data[YamlField])
+			}
+
+			list, err := c.CoreV1().ConfigMaps(s.Namespace).List(ctx, metav1.ListOptions{})
+			require.NoError(t, err)
+			assert.Len(t, list.Items, tt.expectedParts+1)
+			for _, item := range list.Items {
+				assert.LessOrEqual(t, len(item.Data[YamlField]), maxStackManifestSize)
+				if isStackManifestPart(&item) {
+					assert.Equal(t, "test", item.Labels[model.StackNameLabel])
+				}
+			}
+
+			manifest, err := GetStackManifest(ctx, live, c)
+			require.NoError(t, err)
+			assert.Equal(t, tt.manifest, manifest)
+		})
+	}
+}
+
+func TestDeployStackConfigMapDestroysStaleParts(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset()
+	s := &model.Stack{Name: "test", Namespace: "ns", Manifest: newIncompressibleManifest()}
+	require.NoError(t, deployStackConfigMap(ctx, translateConfigMap(s), s.Namespace, c))
+
+	s.Manifest = []byte("services:\n  api:\n    image: okteto/api\n")
+	cfg := translateConfigMap(s)
+	require.NoError(t, deployStackConfigMap(ctx, cfg, s.Namespace, c))
+
+	list, err := c.CoreV1().ConfigMaps(s.Namespace).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, list.Items, 1)
+	assert.Empty(t, list.Items[0].Annotations[YamlPartsAnnotation])
+
+	manifest, err := GetStackManifest(ctx, &list.Items[0], c)
+	require.NoError(t, err)
+	assert.Equal(t, s.Manifest, manifest)
+}
+
+func TestGetLiveStackConfigMap(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset()
+	s := &model.Stack{Name: "test", Namespace: "ns", Manifest: newIncompressibleManifest()}
+	require.NoError(t, deployStackConfigMap(ctx, translateConfigMap(s), s.Namespace, c))
+
+	live, err := getLiveStackConfigMap(ctx, s.Name, s.Namespace, c)
+	require.NoError(t, err)
+	assert.Empty(t, live.Annotations[YamlPartsAnnotation])
+	assert.Greater(t, len(live.Data[YamlField]), maxStackManifestSize)
+	manifest, err := GetStackManifest(ctx, live, c)
+	require.NoError(t, err)
+	assert.Equal(t, s.Manifest, manifest)
+
+	// the live configmap is split again when it's deployed back, like 'okteto stack scale --persist' does
+	setReplicaOverrides(live, map[string]int32{"api": 2})
+	require.NoError(t, deployStackConfigMap(ctx, live, s.Namespace, c))
+	stored, err := c.CoreV1().ConfigMaps(s.Namespace).Get(ctx, live.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "3", stored.Annotations[YamlPartsAnnotation])
+	manifest, err = GetStackManifest(ctx, stored, c)
+	require.NoError(t, err)
+	assert.Equal(t, s.Manifest, manifest)
+	assert.Equal(t, map[string]int32{"api": 2}, getReplicaOverrides(stored))
+}
+
+func TestDestroyConfigsKeepsManifestParts(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset()
+	s := &model.Stack{Name: "test", Namespace: "ns", Manifest: newIncompressibleManifest()}
+	require.NoError(t, deployStackConfigMap(ctx, translateConfigMap(s), s.Namespace, c))
+
+	require.NoError(t, destroyConfigs(ctx, s, c))
+
+	list, err := c.CoreV1().ConfigMaps(s.Namespace).List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, list.Items, 4)
+}
+
+func TestGetStackManifestErrors(t *testing.T) {
+	tests := []struct {
+		cfg  *apiv1.ConfigMap
+		name string
+	}{
+		{
+			name: "missing part",
+			cfg: &apiv1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "okteto-test", Namespace: "ns", Annotations: map[string]string{YamlPartsAnnotation: "2"}},
+			},
+		},
+		{
+			name: "invalid number of parts",
+			cfg: &apiv1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "okteto-test", Namespace: "ns", Annotations: map[string]string{YamlPartsAnnotation: "two"}},
+			},
+		},
+		{
+			name: "unsupported encoding",
+			cfg: &apiv1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "okteto-test", Namespace: "ns", Annotations: map[string]string{YamlEncodingAnnotation: "zstd"}},
+				Data:       map[string]string{YamlField: base64.StdEncoding.EncodeToString([]byte("services: {}"))},
+			},
+		},
+		{
+			name: "invalid gzip",
+			cfg: &apiv1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "okteto-test", Namespace: "ns", Annotations: map[string]string{YamlEncodingAnnotation: gzipEncoding}},
+				Data:       map[string]string{YamlField: base64.StdEncoding.EncodeToString([]byte("services: {}"))},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := GetStackManifest(context.Background(), tt.cfg, fake.NewSimpleClientset())
+			assert.Error(t, err)
+		})
+	}
+}
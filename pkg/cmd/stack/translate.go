@@ -15,13 +15,16 @@ package stack
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"os"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	buildv2 "github.com/okteto/okteto/cmd/build/v2"
 	"github.com/okteto/okteto/cmd/utils"
@@ -29,6 +32,7 @@ import (
 	buildCmd "github.com/okteto/okteto/pkg/cmd/build"
 	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/env"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/format"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/log/io"
@@ -38,6 +42,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	apiv1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -49,6 +54,7 @@ const (
 	statusField  = "status"
 	YamlField    = "yaml"
 	ComposeField = "compose"
+	DigestField  = "digest"
 	outputField  = "output"
 
 	progressingStatus = "progressing"
@@ -73,6 +79,21 @@ const (
 	// identityTokenVolumeName is the name of the projected service account token volume created for x-okteto-identity-token
 	identityTokenVolumeName = "okteto-identity-token"
 
+	// deviceVolumeName is the prefix of the hostPath volumes created for the devices of a service
+	deviceVolumeName = "okteto-device"
+
+	// configVolumeName is the prefix of the configmap volumes created for the configs of a service
+	configVolumeName = "okteto-config"
+
+	// configFileKey is the key of the content of a config in its configmap
+	configFileKey = "content"
+
+	// secretVolumeName is the prefix of the secret volumes created for the secrets of a service
+	secretVolumeName = "okteto-secret"
+
+	// secretFileKey is the key of the value of a compose secret in its kubernetes secret
+	secretFileKey = "content"
+
 	// identityTokenFileName is the file name of the projected token inside the mount path
 	identityTokenFileName = "token"
 
@@ -134,7 +155,7 @@ func buildStackImages(ctx context.Context, s *model.Stack, options *DeployOption
 }
 
 func translateConfigMap(s *model.Stack) *apiv1.ConfigMap {
-	return &apiv1.ConfigMap{
+	cfg := &apiv1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: model.GetStackConfigMapName(s.Name),
 			Labels: map[string]string{
@@ -144,10 +165,23 @@ func translateConfigMap(s *model.Stack) *apiv1.ConfigMap {
 		},
 		Data: map[string]string{
 			NameField:    s.Name,
-			YamlField:    base64.StdEncoding.EncodeToString(s.Manifest),
 			ComposeField: strconv.FormatBool(s.IsCompose),
 		},
 	}
+	yaml, encoding := encodeStackManifest(s.Manifest)
+	cfg.Data[YamlField] = yaml
+	if encoding != "" {
+		cfg.Annotations = map[string]string{
+			YamlEncodingAnnotation: encoding,
+		}
+	}
+	digest, err := computeStackDigest(s)
+	if err != nil {
+		oktetoLog.Infof("error computing digest of compose '%s': %s", s.Name, err)
+		return cfg
+	}
+	cfg.Data[DigestField] = digest
+	return cfg
 }
 
 func translateDeployment(svcName string, s *model.Stack, divert Divert) *appsv1.Deployment {
@@ -156,9 +190,20 @@ func translateDeployment(svcName string, s *model.Stack, divert Divert) *appsv1.
 	svcHealthchecks := getSvcHealthProbe(svc)
 
 	podSpec := apiv1.PodSpec{
-		TerminationGracePeriodSeconds: ptr.To(svc.StopGracePeriod),
-		NodeSelector:                  svc.NodeSelector,
+		TerminationGracePeriodSeconds: translateTerminationGracePeriod(svc),
+		Affinity:                      translateAffinity(svcName, s),
+		TopologySpreadConstraints:     translateTopologySpreadConstraints(svcName, s),
+		NodeSelector:                  translateNodeSelector(svc),
+		Tolerations:                   translateTolerations(svc),
+		HostAliases:                   svc.ExtraHosts,
 		EnableServiceLinks:            svc.EnableServiceLinks,
+		ServiceAccountName:            svc.ServiceAccount,
+		PriorityClassName:             svc.PriorityClassName,
+		HostPID:                       svc.HostPID,
+		HostIPC:                       svc.HostIPC,
+		HostNetwork:                   svc.HostNetwork,
+		DNSPolicy:                     translateDNSPolicy(svc),
+		DNSConfig:                     translateDNSConfig(svc),
 		Containers: []apiv1.Container{
 			{
 				Name:            svcName,
@@ -172,6 +217,8 @@ func translateDeployment(svcName string, s *model.Stack, divert Divert) *appsv1.
 				WorkingDir:      svc.Workdir,
 				ReadinessProbe:  svcHealthchecks.readiness,
 				LivenessProbe:   svcHealthchecks.liveness,
+				StartupProbe:    svcHealthchecks.startup,
+				Lifecycle:       translateLifecycle(svc),
 			},
 		},
 	}
@@ -182,6 +229,14 @@ func translateDeployment(svcName string, s *model.Stack, divert Divert) *appsv1.
 		podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, *m)
 	}
 
+	podSpec.Volumes = append(podSpec.Volumes, translateDeviceVolumes(svc)...)
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, translateDeviceVolumeMounts(svc)...)
+
+	podSpec.Volumes = append(podSpec.Volumes, translateConfigVolumes(svc, s)...)
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, translateConfigVolumeMounts(svc)...)
+	podSpec.Volumes = append(podSpec.Volumes, translateSecretVolumes(svc, s)...)
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, translateSecretVolumeMounts(svc)...)
+
 	if divert != nil {
 		podSpec = divert.UpdatePod(podSpec)
 	}
@@ -233,6 +288,100 @@ func translatePersistentVolumeClaim(volumeName string, s *model.Stack) apiv1.Per
 	return pvc
 }
 
+func translateServiceAccount(name string, s *model.Stack) *apiv1.ServiceAccount {
+	return &apiv1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: s.Namespace,
+			Labels: map[string]string{
+				model.StackNameLabel:  format.ResourceK8sMetaString(s.Name),
+				model.DeployedByLabel: format.ResourceK8sMetaString(s.Name),
+			},
+		},
+	}
+}
+
+// translateConfig builds the configmap of a config of the stack with the content of its file
+func translateConfig(name string, s *model.Stack) (*apiv1.ConfigMap, error) {
+	cfg := s.Configs[name]
+	content, err := os.ReadFile(cfg.File)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, oktetoErrors.UserError{
+				E:    fmt.Errorf("the file '%s' of config '%s' doesn't exist", cfg.File, name),
+				Hint: "Create the file or fix the 'file' of the config in your compose file",
+			}
+		}
+		return nil, fmt.Errorf("error reading the file of config '%s': %w", name, err)
+	}
+
+	cm := &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getConfigMapName(s.Name, name),
+			Namespace: s.Namespace,
+			Labels: map[string]string{
+				model.StackNameLabel:  format.ResourceK8sMetaString(s.Name),
+				model.DeployedByLabel: format.ResourceK8sMetaString(s.Name),
+			},
+		},
+	}
+	if utf8.Valid(content) {
+		cm.Data = map[string]string{configFileKey: string(content)}
+	} else {
+		cm.BinaryData = map[string][]byte{configFileKey: content}
+	}
+	return cm, nil
+}
+
+func getConfigMapName(stackName, configName string) string {
+	return format.ResourceK8sMetaString(fmt.Sprintf("%s-config-%s", stackName, configName))
+}
+
+// translateSecret builds the kubernetes secret of a secret of the stack with the content of its file or environment variable
+func translateSecret(name string, s *model.Stack) (*apiv1.Secret, error) {
+	secret := s.Secrets[name]
+	var content []byte
+	if secret.Environment != "" {
+		value, ok := os.LookupEnv(secret.Environment)
+		if !ok {
+			return nil, oktetoErrors.UserError{
+				E:    fmt.Errorf("the environment variable '%s' of secret '%s' is not set", secret.Environment, name),
+				Hint: "Export the environment variable or fix the 'environment' of the secret in your compose file",
+			}
+		}
+		content = []byte(value)
+	} else {
+		var err error
+		content, err = os.ReadFile(secret.File)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil, oktetoErrors.UserError{
+					E:    fmt.Errorf("the file '%s' of secret '%s' doesn't exist", secret.File, name),
+					Hint: "Create the file or fix the 'file' of the secret in your compose file",
+				}
+			}
+			return nil, fmt.Errorf("error reading the file of secret '%s': %w", name, err)
+		}
+	}
+
+	return &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getSecretName(s.Name, name),
+			Namespace: s.Namespace,
+			Labels: map[string]string{
+				model.StackNameLabel:  format.ResourceK8sMetaString(s.Name),
+				model.DeployedByLabel: format.ResourceK8sMetaString(s.Name),
+			},
+		},
+		Type: apiv1.SecretTypeOpaque,
+		Data: map[string][]byte{secretFileKey: content},
+	}, nil
+}
+
+func getSecretName(stackName, secretName string) string {
+	return format.ResourceK8sMetaString(fmt.Sprintf("%s-secret-%s", stackName, secretName))
+}
+
 func translateStatefulSet(svcName string, s *model.Stack, divert Divert) *appsv1.StatefulSet {
 	svc := s.Services[svcName]
 
@@ -240,11 +389,21 @@ func translateStatefulSet(svcName string, s *model.Stack, divert Divert) *appsv1
 	svcHealthchecks := getSvcHealthProbe(svc)
 
 	podSpec := apiv1.PodSpec{
-		TerminationGracePeriodSeconds: ptr.To(svc.StopGracePeriod),
+		TerminationGracePeriodSeconds: translateTerminationGracePeriod(svc),
 		InitContainers:                initContainers,
-		Affinity:                      translateAffinity(svc),
-		NodeSelector:                  svc.NodeSelector,
+		Affinity:                      translateAffinity(svcName, s),
+		TopologySpreadConstraints:     translateTopologySpreadConstraints(svcName, s),
+		NodeSelector:                  translateNodeSelector(svc),
+		Tolerations:                   translateTolerations(svc),
+		HostAliases:                   svc.ExtraHosts,
 		EnableServiceLinks:            svc.EnableServiceLinks,
+		ServiceAccountName:            svc.ServiceAccount,
+		PriorityClassName:             svc.PriorityClassName,
+		HostPID:                       svc.HostPID,
+		HostIPC:                       svc.HostIPC,
+		HostNetwork:                   svc.HostNetwork,
+		DNSPolicy:                     translateDNSPolicy(svc),
+		DNSConfig:                     translateDNSConfig(svc),
 		Volumes:                       translateVolumes(svc),
 		Containers: []apiv1.Container{
 			{
@@ -260,10 +419,17 @@ func translateStatefulSet(svcName string, s *model.Stack, divert Divert) *appsv1
 				WorkingDir:      svc.Workdir,
 				ReadinessProbe:  svcHealthchecks.readiness,
 				LivenessProbe:   svcHealthchecks.liveness,
+				StartupProbe:    svcHealthchecks.startup,
+				Lifecycle:       translateLifecycle(svc),
 			},
 		},
 	}
 
+	podSpec.Volumes = append(podSpec.Volumes, translateConfigVolumes(svc, s)...)
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, translateConfigVolumeMounts(svc)...)
+	podSpec.Volumes = append(podSpec.Volumes, translateSecretVolumes(svc, s)...)
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, translateSecretVolumeMounts(svc)...)
+
 	if divert != nil {
 		podSpec = divert.UpdatePod(podSpec)
 	}
@@ -302,11 +468,21 @@ func translateJob(svcName string, s *model.Stack, divert Divert) *batchv1.Job {
 	svcHealthchecks := getSvcHealthProbe(svc)
 	podSpec := apiv1.PodSpec{
 		RestartPolicy:                 svc.RestartPolicy,
-		TerminationGracePeriodSeconds: ptr.To(svc.StopGracePeriod),
+		TerminationGracePeriodSeconds: translateTerminationGracePeriod(svc),
 		InitContainers:                initContainers,
-		Affinity:                      translateAffinity(svc),
-		NodeSelector:                  svc.NodeSelector,
+		Affinity:                      translateAffinity(svcName, s),
+		TopologySpreadConstraints:     translateTopologySpreadConstraints(svcName, s),
+		NodeSelector:                  translateNodeSelector(svc),
+		Tolerations:                   translateTolerations(svc),
+		HostAliases:                   svc.ExtraHosts,
 		EnableServiceLinks:            svc.EnableServiceLinks,
+		ServiceAccountName:            svc.ServiceAccount,
+		PriorityClassName:             svc.PriorityClassName,
+		HostPID:                       svc.HostPID,
+		HostIPC:                       svc.HostIPC,
+		HostNetwork:                   svc.HostNetwork,
+		DNSPolicy:                     translateDNSPolicy(svc),
+		DNSConfig:                     translateDNSConfig(svc),
 		Containers: []apiv1.Container{
 			{
 				Name:            svcName,
@@ -321,11 +497,18 @@ func translateJob(svcName string, s *model.Stack, divert Divert) *batchv1.Job {
 				WorkingDir:      svc.Workdir,
 				ReadinessProbe:  svcHealthchecks.readiness,
 				LivenessProbe:   svcHealthchecks.liveness,
+				StartupProbe:    svcHealthchecks.startup,
+				Lifecycle:       translateLifecycle(svc),
 			},
 		},
 		Volumes: translateVolumes(svc),
 	}
 
+	podSpec.Volumes = append(podSpec.Volumes, translateConfigVolumes(svc, s)...)
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, translateConfigVolumeMounts(svc)...)
+	podSpec.Volumes = append(podSpec.Volumes, translateSecretVolumes(svc, s)...)
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, translateSecretVolumeMounts(svc)...)
+
 	if divert != nil {
 		podSpec = divert.UpdatePod(podSpec)
 	}
@@ -338,9 +521,11 @@ func translateJob(svcName string, s *model.Stack, divert Divert) *batchv1.Job {
 			Annotations: translateAnnotations(svc),
 		},
 		Spec: batchv1.JobSpec{
-			Completions:  ptr.To(svc.Replicas),
-			Parallelism:  ptr.To(int32(1)),
-			BackoffLimit: &svc.BackOffLimit,
+			Completions:             ptr.To(svc.Replicas),
+			Parallelism:             ptr.To(int32(1)),
+			BackoffLimit:            &svc.BackOffLimit,
+			ActiveDeadlineSeconds:   svc.ActiveDeadlineSeconds,
+			TTLSecondsAfterFinished: svc.TTLSecondsAfterFinished,
 			Template: apiv1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels:      translateLabels(svcName, s),
@@ -372,7 +557,7 @@ func getAddPermissionsInitContainer(svcName string, svc *model.Service) apiv1.Co
 	initContainerCommand, initContainerVolumeMounts := getInitContainerCommandAndVolumeMounts(*svc)
 	initContainer := apiv1.Container{
 		Name:            fmt.Sprintf("init-%s", svcName),
-		Image:           config.NewImageConfig(oktetoLog.GetOutputWriter()).GetCliImage(),
+		Image:           config.NewImageConfig(oktetoLog.GetOutputWriter()).GetBinImage(),
 		ImagePullPolicy: apiv1.PullIfNotPresent,
 		Command:         initContainerCommand,
 		VolumeMounts:    initContainerVolumeMounts,
@@ -485,9 +670,128 @@ func translateVolumes(svc *model.Service) []apiv1.Volume {
 		volumes = append(volumes, *v)
 	}
 
+	volumes = append(volumes, translateDeviceVolumes(svc)...)
+
 	return volumes
 }
 
+// translateConfigVolumes builds a configmap volume for each config mounted by the service, with the mode of the mount
+func translateConfigVolumes(svc *model.Service, s *model.Stack) []apiv1.Volume {
+	result := []apiv1.Volume{}
+	for i, cfg := range svc.Configs {
+		result = append(result, apiv1.Volume{
+			Name: getConfigVolumeName(i),
+			VolumeSource: apiv1.VolumeSource{
+				ConfigMap: &apiv1.ConfigMapVolumeSource{
+					LocalObjectReference: apiv1.LocalObjectReference{
+						Name: getConfigMapName(s.Name, cfg.Source),
+					},
+					Items: []apiv1.KeyToPath{
+						{
+							Key:  configFileKey,
+							Path: configFileKey,
+							Mode: cfg.Mode,
+						},
+					},
+				},
+			},
+		})
+	}
+	return result
+}
+
+// translateConfigVolumeMounts mounts the configs of the service as files at their target paths
+func translateConfigVolumeMounts(svc *model.Service) []apiv1.VolumeMount {
+	result := []apiv1.VolumeMount{}
+	for i, cfg := range svc.Configs {
+		result = append(result, apiv1.VolumeMount{
+			Name:      getConfigVolumeName(i),
+			MountPath: cfg.Target,
+			SubPath:   configFileKey,
+			ReadOnly:  true,
+		})
+	}
+	return result
+}
+
+func getConfigVolumeName(i int) string {
+	return fmt.Sprintf("%s-%d", configVolumeName, i)
+}
+
+// translateSecretVolumes builds a secret volume for each secret mounted by the service, with the mode of the mount
+func translateSecretVolumes(svc *model.Service, s *model.Stack) []apiv1.Volume {
+	result := []apiv1.Volume{}
+	for i, secret := range svc.Secrets {
+		result = append(result, apiv1.Volume{
+			Name: getSecretVolumeName(i),
+			VolumeSource: apiv1.VolumeSource{
+				Secret: &apiv1.SecretVolumeSource{
+					SecretName: getSecretName(s.Name, secret.Source),
+					Items: []apiv1.KeyToPath{
+						{
+							Key:  secretFileKey,
+							Path: secretFileKey,
+							Mode: secret.Mode,
+						},
+					},
+				},
+			},
+		})
+	}
+	return result
+}
+
+// translateSecretVolumeMounts mounts the secrets of the service as files at their target paths, '/run/secrets/<name>' by default
+func translateSecretVolumeMounts(svc *model.Service) []apiv1.VolumeMount {
+	result := []apiv1.VolumeMount{}
+	for i, secret := range svc.Secrets {
+		result = append(result, apiv1.VolumeMount{
+			Name:      getSecretVolumeName(i),
+			MountPath: secret.Target,
+			SubPath:   secretFileKey,
+			ReadOnly:  true,
+		})
+	}
+	return result
+}
+
+func getSecretVolumeName(i int) string {
+	return fmt.Sprintf("%s-%d", secretVolumeName, i)
+}
+
+// translateDeviceVolumes builds the hostPath volumes of the devices of the service
+func translateDeviceVolumes(svc *model.Service) []apiv1.Volume {
+	result := []apiv1.Volume{}
+	for i, d := range svc.Devices {
+		result = append(result, apiv1.Volume{
+			Name: getDeviceVolumeName(i),
+			VolumeSource: apiv1.VolumeSource{
+				HostPath: &apiv1.HostPathVolumeSource{
+					Path: d.HostPath,
+				},
+			},
+		})
+	}
+	return result
+}
+
+// translateDeviceVolumeMounts mounts the devices of the service in the container. Devices without 'w' permission are read only
+func translateDeviceVolumeMounts(svc *model.Service) []apiv1.VolumeMount {
+	result := []apiv1.VolumeMount{}
+	for i, d := range svc.Devices {
+		result = append(result, apiv1.VolumeMount{
+			Name:      getDeviceVolumeName(i),
+			MountPath: d.ContainerPath,
+			ReadOnly:  d.Permissions != "" && !strings.Contains(d.Permissions, "w"),
+		})
+	}
+	return result
+}
+
+func getDeviceVolumeName(i int) string {
+	return fmt.Sprintf("%s-%d", deviceVolumeName, i)
+}
+
 // translateIdentityTokenVolume builds the projected service account token volume for a service with
 // x-okteto-identity-token configured. It returns nil when the directive is not set.
 func translateIdentityTokenVolume(svc *model.Service) *apiv1.Volume {
@@ -555,6 +859,83 @@ func translateService(svcName string, s *model.Stack) *apiv1.Service {
 	}
 }
 
+// externalServiceManagedBy identifies the endpoint slices of the external services, managed by okteto instead of the endpoint slice controller
+const externalServiceManagedBy = "okteto.com"
+
+// translateExternalService builds the service giving a stable name in the cluster to an external service:
+// an ExternalName service for DNS names, or a headless service without selector and its endpoint slice for IPs
+func translateExternalService(name string, s *model.Stack) (*apiv1.Service, *discoveryv1.EndpointSlice) {
+	external := s.ExternalServices[name]
+	labels := map[string]string{
+		model.StackNameLabel:            format.ResourceK8sMetaString(s.Name),
+		model.StackExternalServiceLabel: name,
+		model.DeployedByLabel:           format.ResourceK8sMetaString(s.Name),
+	}
+	ports := []apiv1.ServicePort{}
+	for _, port := range external.Ports {
+		ports = append(ports, apiv1.ServicePort{
+			Name:       fmt.Sprintf("p-%d-%d-tcp", port, port),
+			Port:       port,
+			TargetPort: intstr.IntOrString{IntVal: port},
+			Protocol:   apiv1.ProtocolTCP,
+		})
+	}
+
+	svc := &apiv1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: s.Namespace,
+			Labels:    labels,
+		},
+		Spec: apiv1.ServiceSpec{
+			Type:         apiv1.ServiceTypeExternalName,
+			ExternalName: external.Host,
+			Ports:        ports,
+		},
+	}
+	if !external.IsIP() {
+		return svc, nil
+	}
+
+	svc.Spec = apiv1.ServiceSpec{
+		Type:      apiv1.ServiceTypeClusterIP,
+		ClusterIP: apiv1.ClusterIPNone,
+		Ports:     ports,
+	}
+	addressType := discoveryv1.AddressTypeIPv4
+	if strings.Contains(external.Host, ":") {
+		addressType = discoveryv1.AddressTypeIPv6
+	}
+	sliceLabels := map[string]string{
+		discoveryv1.LabelServiceName: name,
+		discoveryv1.LabelManagedBy:   externalServiceManagedBy,
+	}
+	for k, v := range labels {
+		sliceLabels[k] = v
+	}
+	slicePorts := []discoveryv1.EndpointPort{}
+	for _, port := range ports {
+		slicePorts = append(slicePorts, discoveryv1.EndpointPort{
+			Name:     ptr.To(port.Name),
+			Port:     ptr.To(port.Port),
+			Protocol: ptr.To(port.Protocol),
+		})
+	}
+	slice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: s.Namespace,
+			Labels:    sliceLabels,
+		},
+		AddressType: addressType,
+		Endpoints: []discoveryv1.Endpoint{
+			{Addresses: []string{external.Host}},
+		},
+		Ports: slicePorts,
+	}
+	return svc, slice
+}
+
 func getSvcPublicPorts(svcName string, s *model.Stack) []model.Port {
 	result := []model.Port{}
 	for _, p := range s.Services[svcName].Ports {
@@ -578,7 +959,52 @@ func translateVolumeLabels(volumeName string, s *model.Stack) map[string]string
 	return labels
 }
 
-func translateAffinity(svc *model.Service) *apiv1.Affinity {
+// translateAffinity schedules the service with the pods sharing its host volumes, and away from its own replicas
+// when the service defines an anti-affinity
+func translateAffinity(svcName string, s *model.Stack) *apiv1.Affinity {
+	svc := s.Services[svcName]
+	affinity := &apiv1.Affinity{
+		NodeAffinity:    translatePlacementNodeAffinity(svc),
+		PodAffinity:     translateVolumePodAffinity(svc),
+		PodAntiAffinity: translatePodAntiAffinity(svcName, s),
+	}
+	if affinity.NodeAffinity == nil && affinity.PodAffinity == nil && affinity.PodAntiAffinity == nil {
+		return nil
+	}
+	return affinity
+}
+
+// translatePlacementNodeAffinity requires the nodes matching the placement constraints that can't be expressed as a node selector
+func translatePlacementNodeAffinity(svc *model.Service) *apiv1.NodeAffinity {
+	requirements := make([]apiv1.NodeSelectorRequirement, 0)
+	for _, constraint := range svc.PlacementConstraints {
+		switch constraint.Operator {
+		case model.PlacementConstraintNotEqual:
+			requirements = append(requirements, apiv1.NodeSelectorRequirement{
+				Key:      constraint.Key,
+				Operator: apiv1.NodeSelectorOpNotIn,
+				Values:   []string{constraint.Value},
+			})
+		case model.PlacementConstraintExists:
+			requirements = append(requirements, apiv1.NodeSelectorRequirement{
+				Key:      constraint.Key,
+				Operator: apiv1.NodeSelectorOpExists,
+			})
+		}
+	}
+	if len(requirements) == 0 {
+		return nil
+	}
+	return &apiv1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &apiv1.NodeSelector{
+			NodeSelectorTerms: []apiv1.NodeSelectorTerm{
+				{MatchExpressions: requirements},
+			},
+		},
+	}
+}
+
+func translateVolumePodAffinity(svc *model.Service) *apiv1.PodAffinity {
 	if !env.LoadBooleanOrDefault(oktetoComposeVolumeAffinityEnabledEnvVar, true) {
 		return nil
 	}
@@ -602,16 +1028,59 @@ func translateAffinity(svc *model.Service) *apiv1.Affinity {
 		)
 	}
 	if len(requirements) > 0 {
-		return &apiv1.Affinity{
-			PodAffinity: &apiv1.PodAffinity{
-				RequiredDuringSchedulingIgnoredDuringExecution: requirements,
-			},
+		return &apiv1.PodAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: requirements,
 		}
 	}
 
 	return nil
 }
 
+func translatePodAntiAffinity(svcName string, s *model.Stack) *apiv1.PodAntiAffinity {
+	term := apiv1.PodAffinityTerm{
+		TopologyKey: model.DefaultTopologySpreadKey,
+		LabelSelector: &metav1.LabelSelector{
+			MatchLabels: translateLabelSelector(svcName, s),
+		},
+	}
+	switch s.Services[svcName].AntiAffinity {
+	case model.HardAntiAffinity:
+		return &apiv1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []apiv1.PodAffinityTerm{term},
+		}
+	case model.SoftAntiAffinity:
+		return &apiv1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []apiv1.WeightedPodAffinityTerm{
+				{
+					Weight:          100,
+					PodAffinityTerm: term,
+				},
+			},
+		}
+	default:
+		return nil
+	}
+}
+
+func translateTopologySpreadConstraints(svcName string, s *model.Stack) []apiv1.TopologySpreadConstraint {
+	spreads := s.Services[svcName].TopologySpread
+	if len(spreads) == 0 {
+		return nil
+	}
+	result := make([]apiv1.TopologySpreadConstraint, 0, len(spreads))
+	for _, spread := range spreads {
+		result = append(result, apiv1.TopologySpreadConstraint{
+			MaxSkew:           spread.MaxSkew,
+			TopologyKey:       spread.TopologyKey,
+			WhenUnsatisfiable: spread.WhenUnsatisfiable,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: translateLabelSelector(svcName, s),
+			},
+		})
+	}
+	return result
+}
+
 func translateLabels(svcName string, s *model.Stack) map[string]string {
 	svc := s.Services[svcName]
 	labels := map[string]string{
@@ -686,6 +1155,13 @@ func translateVolumeMounts(svc *model.Service) []apiv1.VolumeMount {
 		result = append(result, *m)
 	}
 
+	result = append(result, translateDeviceVolumeMounts(svc)...)
+
+	// subpaths depend on the declaration order of the volumes, but the mounts are sorted by path
+	// so logically-equal services translate equally and parent paths are mounted before their children
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].MountPath < result[j].MountPath
+	})
 	return result
 }
 
@@ -699,11 +1175,76 @@ func getVolumeClaimName(v *build.VolumeMounts) string {
 	return name
 }
 
+// translateTerminationGracePeriod extends the stop grace period with the preStop sleep, so the sleep doesn't consume the time the container has to stop
+func translateTerminationGracePeriod(svc *model.Service) *int64 {
+	return ptr.To(svc.StopGracePeriod + svc.PreStopSleep)
+}
+
+// translateLifecycle maps the lifecycle hooks of the service to the container.
+// The preStop sleep adds a preStop hook that sleeps before the container is stopped, so the ingress can drain its connections
+func translateLifecycle(svc *model.Service) *apiv1.Lifecycle {
+	var result *apiv1.Lifecycle
+	if svc.Lifecycle != nil {
+		result = &apiv1.Lifecycle{
+			PostStart: translateLifecycleHook(svc.Lifecycle.PostStart),
+			PreStop:   translateLifecycleHook(svc.Lifecycle.PreStop),
+		}
+	}
+	if svc.PreStopSleep <= 0 {
+		return result
+	}
+	if result == nil {
+		result = &apiv1.Lifecycle{}
+	}
+	result.PreStop = &apiv1.LifecycleHandler{
+		Exec: &apiv1.ExecAction{
+			Command: []string{"sh", "-c", fmt.Sprintf("sleep %d", svc.PreStopSleep)},
+		},
+	}
+	return result
+}
+
+func translateLifecycleHook(hook *model.LifecycleHook) *apiv1.LifecycleHandler {
+	if hook == nil {
+		return nil
+	}
+	if hook.Exec != nil {
+		return &apiv1.LifecycleHandler{
+			Exec: &apiv1.ExecAction{
+				Command: hook.Exec.Command.Values,
+			},
+		}
+	}
+	if hook.HTTPGet != nil {
+		return &apiv1.LifecycleHandler{
+			HTTPGet: &apiv1.HTTPGetAction{
+				Path:   hook.HTTPGet.Path,
+				Host:   hook.HTTPGet.Host,
+				Scheme: apiv1.URIScheme(strings.ToUpper(hook.HTTPGet.Scheme)),
+				Port:   intstr.FromInt32(hook.HTTPGet.Port),
+			},
+		}
+	}
+	return nil
+}
+
 func translateSecurityContext(svc *model.Service) *apiv1.SecurityContext {
-	if len(svc.CapAdd) == 0 && len(svc.CapDrop) == 0 && svc.User == nil {
+	if len(svc.CapAdd) == 0 && len(svc.CapDrop) == 0 && svc.User == nil && !svc.Privileged && !svc.ReadOnly && !svc.NoNewPrivileges && !svc.SeccompUnconfined {
 		return nil
 	}
 	result := &apiv1.SecurityContext{Capabilities: &apiv1.Capabilities{}}
+	if svc.Privileged {
+		result.Privileged = ptr.To(true)
+	}
+	if svc.ReadOnly {
+		result.ReadOnlyRootFilesystem = ptr.To(true)
+	}
+	if svc.NoNewPrivileges {
+		result.AllowPrivilegeEscalation = ptr.To(false)
+	}
+	if svc.SeccompUnconfined {
+		result.SeccompProfile = &apiv1.SeccompProfile{Type: apiv1.SeccompProfileTypeUnconfined}
+	}
 	if len(svc.CapAdd) > 0 {
 		result.Capabilities.Add = svc.CapAdd
 	}
@@ -726,28 +1267,51 @@ func translateStorageClass(className string) *string {
 
 func translateServiceEnvironment(svc *model.Service) []apiv1.EnvVar {
 	result := []apiv1.EnvVar{}
+	indexes := map[string]int{}
 	for _, e := range svc.Environment {
-		if e.Name != "" {
-			result = append(result, apiv1.EnvVar{Name: e.Name, Value: e.Value})
+		if e.Name == "" {
+			continue
 		}
+		// the last definition of a variable wins, as it does in the container
+		if i, ok := indexes[e.Name]; ok {
+			result[i].Value = e.Value
+			continue
+		}
+		indexes[e.Name] = len(result)
+		result = append(result, apiv1.EnvVar{Name: e.Name, Value: e.Value})
 	}
+	// the declaration order is kept, as variables can reference the variables declared before them like '$(VAR)'
 	return result
 }
 
 func translateContainerPorts(svc *model.Service) []apiv1.ContainerPort {
 	result := []apiv1.ContainerPort{}
-	sort.Slice(svc.Ports, func(i, j int) bool {
-		return svc.Ports[i].ContainerPort < svc.Ports[j].ContainerPort
-	})
-	for _, p := range svc.Ports {
+	for _, p := range sortPorts(svc.Ports) {
 		result = append(result, apiv1.ContainerPort{ContainerPort: p.ContainerPort})
 	}
 	return result
 }
 
+// sortPorts returns a copy of ports sorted by container port, host port and protocol,
+// so the translation doesn't depend on the order the ports were declared in
+func sortPorts(ports []model.Port) []model.Port {
+	result := make([]model.Port, len(ports))
+	copy(result, ports)
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].ContainerPort != result[j].ContainerPort {
+			return result[i].ContainerPort < result[j].ContainerPort
+		}
+		if result[i].HostPort != result[j].HostPort {
+			return result[i].HostPort < result[j].HostPort
+		}
+		return result[i].Protocol < result[j].Protocol
+	})
+	return result
+}
+
 func translateServicePorts(svc model.Service) []apiv1.ServicePort {
 	result := []apiv1.ServicePort{}
-	for _, p := range svc.Ports {
+	for _, p := range sortPorts(svc.Ports) {
 		if !isServicePortAdded(p.ContainerPort, result) {
 			result = append(
 				result,
@@ -771,6 +1335,9 @@ func translateServicePorts(svc model.Service) []apiv1.ServicePort {
 			)
 		}
 	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Port < result[j].Port
+	})
 	return result
 }
 
@@ -808,51 +1375,187 @@ func translateResources(svc *model.Service) apiv1.ResourceRequirements {
 			}
 			result.Requests[apiv1.ResourceMemory] = svc.Resources.Requests.Memory.Value
 		}
+
+		if len(svc.Resources.GPUs) > 0 {
+			if result.Limits == nil {
+				result.Limits = apiv1.ResourceList{}
+			}
+			if result.Requests == nil {
+				result.Requests = apiv1.ResourceList{}
+			}
+			svc.Resources.GPUs.AddTo(result.Limits, result.Requests)
+		}
 	}
 	return result
 }
 
+// translateNodeSelector returns the node selector of the service including the one of the GPU nodes
+// and the equality placement constraints
+func translateNodeSelector(svc *model.Service) map[string]string {
+	var gpuSelector map[string]string
+	if svc.Resources != nil {
+		gpuSelector = svc.Resources.GPUs.NodeSelector()
+	}
+	placementSelector := map[string]string{}
+	for _, constraint := range svc.PlacementConstraints {
+		if constraint.Operator == model.PlacementConstraintEqual {
+			placementSelector[constraint.Key] = constraint.Value
+		}
+	}
+	if len(gpuSelector) == 0 && len(placementSelector) == 0 {
+		return svc.NodeSelector
+	}
+	result := map[string]string{}
+	for k, v := range gpuSelector {
+		result[k] = v
+	}
+	for k, v := range placementSelector {
+		result[k] = v
+	}
+	for k, v := range svc.NodeSelector {
+		result[k] = v
+	}
+	return result
+}
+
+// translateDNSPolicy only uses the nameservers of 'dns' when they are set,
+// and keeps resolving the services of the namespace when the service uses the network of the node
+func translateDNSPolicy(svc *model.Service) apiv1.DNSPolicy {
+	if len(svc.DNS) > 0 {
+		return apiv1.DNSNone
+	}
+	if svc.HostNetwork {
+		return apiv1.DNSClusterFirstWithHostNet
+	}
+	return ""
+}
+
+// translateDNSConfig translates 'dns' and 'dns_search' into the dns config of the pod
+func translateDNSConfig(svc *model.Service) *apiv1.PodDNSConfig {
+	if len(svc.DNS) == 0 && len(svc.DNSSearch) == 0 {
+		return nil
+	}
+	return &apiv1.PodDNSConfig{
+		Nameservers: svc.DNS,
+		Searches:    svc.DNSSearch,
+	}
+}
+
+// translateTolerations returns the tolerations of the service, and the ones of the GPU nodes if the service requests GPUs
+func translateTolerations(svc *model.Service) []apiv1.Toleration {
+	result := append([]apiv1.Toleration{}, svc.Tolerations...)
+	if svc.Resources != nil {
+		for _, t := range svc.Resources.GPUs.Tolerations() {
+			if !hasTolerationKey(result, t.Key) {
+				result = append(result, t)
+			}
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+func hasTolerationKey(tolerations []apiv1.Toleration, key string) bool {
+	for _, t := range tolerations {
+		if t.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	// defaultProbePeriod and defaultProbeFailureThreshold are the kubernetes defaults of a probe
+	defaultProbePeriod           = 10 * time.Second
+	defaultProbeFailureThreshold = 3
+)
+
 type healthcheckProbes struct {
 	readiness *apiv1.Probe
 	liveness  *apiv1.Probe
+	startup   *apiv1.Probe
 }
 
+// getSvcHealthProbe translates the healthcheck of the service into its probes.
+// The readiness and liveness probes of the service override the healthcheck for their probe
 func getSvcHealthProbe(svc *model.Service) healthcheckProbes {
 	result := healthcheckProbes{}
 	if svc.Healtcheck != nil {
-		var handler apiv1.ProbeHandler
-		if len(svc.Healtcheck.Test) != 0 {
-			handler = apiv1.ProbeHandler{
-				Exec: &apiv1.ExecAction{
-					Command: svc.Healtcheck.Test,
-				},
-			}
-		} else {
-			handler = apiv1.ProbeHandler{
-				HTTPGet: &apiv1.HTTPGetAction{
-					Path: svc.Healtcheck.HTTP.Path,
-					Port: intstr.IntOrString{IntVal: svc.Healtcheck.HTTP.Port},
-				},
-			}
-		}
-		probe := &apiv1.Probe{
-			ProbeHandler:        handler,
-			TimeoutSeconds:      int32(svc.Healtcheck.Timeout.Seconds()),
-			PeriodSeconds:       int32(svc.Healtcheck.Interval.Seconds()),
-			FailureThreshold:    int32(svc.Healtcheck.Retries),
-			InitialDelaySeconds: int32(svc.Healtcheck.StartPeriod.Seconds()),
-		}
-
 		if svc.Healtcheck.Readiness {
-			result.readiness = probe
+			result.readiness = translateProbe(svc.Healtcheck)
 		}
 		if svc.Healtcheck.Liveness {
-			result.liveness = probe
+			result.liveness = translateProbe(svc.Healtcheck)
 		}
+		// the startup probe keeps the liveness probe from killing the container while it starts
+		if svc.Healtcheck.Liveness && svc.Healtcheck.StartPeriod > 0 && svc.ReadinessProbe == nil && svc.LivenessProbe == nil {
+			result.startup = translateStartupProbe(svc.Healtcheck)
+		}
+	}
+	if svc.ReadinessProbe != nil {
+		result.readiness = translateProbe(svc.ReadinessProbe)
+	}
+	if svc.LivenessProbe != nil {
+		result.liveness = translateProbe(svc.LivenessProbe)
 	}
 	return result
 }
 
+func translateProbe(hc *model.HealthCheck) *apiv1.Probe {
+	return &apiv1.Probe{
+		ProbeHandler:        translateProbeHandler(hc),
+		TimeoutSeconds:      int32(hc.Timeout.Seconds()),
+		PeriodSeconds:       int32(hc.Interval.Seconds()),
+		FailureThreshold:    int32(hc.Retries),
+		InitialDelaySeconds: int32(hc.StartPeriod.Seconds()),
+	}
+}
+
+// translateStartupProbe returns a probe that allows the container to fail the healthcheck during its start period
+// and the retries of the healthcheck after it
+func translateStartupProbe(hc *model.HealthCheck) *apiv1.Probe {
+	period := hc.Interval
+	if period < time.Second {
+		period = defaultProbePeriod
+	}
+	retries := hc.Retries
+	if retries == 0 {
+		retries = defaultProbeFailureThreshold
+	}
+	return &apiv1.Probe{
+		ProbeHandler:     translateProbeHandler(hc),
+		TimeoutSeconds:   int32(hc.Timeout.Seconds()),
+		PeriodSeconds:    int32(period.Seconds()),
+		FailureThreshold: int32(math.Ceil(hc.StartPeriod.Seconds()/period.Seconds())) + int32(retries),
+	}
+}
+
+func translateProbeHandler(hc *model.HealthCheck) apiv1.ProbeHandler {
+	if len(hc.Test) != 0 {
+		return apiv1.ProbeHandler{
+			Exec: &apiv1.ExecAction{
+				Command: hc.Test,
+			},
+		}
+	}
+	if hc.TCP != nil {
+		return apiv1.ProbeHandler{
+			TCPSocket: &apiv1.TCPSocketAction{
+				Host: hc.TCP.Host,
+				Port: intstr.IntOrString{IntVal: hc.TCP.Port},
+			},
+		}
+	}
+	return apiv1.ProbeHandler{
+		HTTPGet: &apiv1.HTTPGetAction{
+			Path: hc.HTTP.Path,
+			Port: intstr.IntOrString{IntVal: hc.HTTP.Port},
+		},
+	}
+}
+
 type updateStrategyGetter interface {
 	validate(updateStrategy) error
 	getDefault() updateStrategy
@@ -15,7 +15,11 @@ package stack
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
@@ -23,12 +27,14 @@ import (
 	"github.com/okteto/okteto/pkg/build"
 	"github.com/okteto/okteto/pkg/config"
 	"github.com/okteto/okteto/pkg/env"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	"github.com/okteto/okteto/pkg/log/io"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -732,9 +738,12 @@ func Test_translateJobWithVolumes(t *testing.T) {
 func Test_translateService(t *testing.T) {
 
 	var tests = []struct {
-		stack    *model.Stack
-		expected *apiv1.Service
-		name     string
+		stack       *model.Stack
+		expected    *apiv1.Service
+		name        string
+		svcName     string
+		compose     string
+		publicPorts []model.Port
 	}{
 		{
 			name: "translate svc no public endpoints",
@@ -1185,11 +1194,134 @@ func Test_translateService(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "translate svc with ports and expose",
+			svcName: "api",
+			compose: `name: stackName
+services:
+  api:
+    image: okteto/vote:1
+    ports:
+      - 8080:80
+    expose:
+      - "80"
+      - "8080"
+      - "9090"`,
+			publicPorts: []model.Port{{HostPort: 8080, ContainerPort: 80, Protocol: apiv1.ProtocolTCP}},
+			expected: &apiv1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "api",
+					Labels: map[string]string{
+						model.StackNameLabel:        "stackname",
+						model.StackServiceNameLabel: "api",
+						model.DeployedByLabel:       "stackname",
+					},
+					Annotations: map[string]string{
+						"dev.okteto.com/sample": "true",
+					},
+				},
+				Spec: apiv1.ServiceSpec{
+					Type: apiv1.ServiceTypeClusterIP,
+					Selector: map[string]string{
+						model.StackNameLabel:        "stackname",
+						model.StackServiceNameLabel: "api",
+					},
+					Ports: []apiv1.ServicePort{
+						{
+							Name:       "p-80-80-tcp",
+							Port:       80,
+							TargetPort: intstr.IntOrString{IntVal: 80},
+							Protocol:   apiv1.ProtocolTCP,
+						},
+						{
+							Name:       "p-8080-80-tcp",
+							Port:       8080,
+							TargetPort: intstr.IntOrString{IntVal: 80},
+							Protocol:   apiv1.ProtocolTCP,
+						},
+						{
+							Name:       "p-9090-9090-tcp",
+							Port:       9090,
+							TargetPort: intstr.IntOrString{IntVal: 9090},
+							Protocol:   apiv1.ProtocolTCP,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "translate svc with only expose",
+			svcName: "api",
+			compose: `name: stackName
+services:
+  api:
+    image: okteto/vote:1
+    expose:
+      - "9090"
+      - "3306:3306"
+      - "7000-7001/udp"`,
+			publicPorts: []model.Port{},
+			expected: &apiv1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "api",
+					Labels: map[string]string{
+						model.StackNameLabel:        "stackname",
+						model.StackServiceNameLabel: "api",
+						model.DeployedByLabel:       "stackname",
+					},
+					Annotations: map[string]string{
+						"dev.okteto.com/sample": "true",
+					},
+				},
+				Spec: apiv1.ServiceSpec{
+					Type: apiv1.ServiceTypeClusterIP,
+					Selector: map[string]string{
+						model.StackNameLabel:        "stackname",
+						model.StackServiceNameLabel: "api",
+					},
+					Ports: []apiv1.ServicePort{
+						{
+							Name:       "p-3306-3306-tcp",
+							Port:       3306,
+							TargetPort: intstr.IntOrString{IntVal: 3306},
+							Protocol:   apiv1.ProtocolTCP,
+						},
+						{
+							Name:       "p-7000-7000-udp",
+							Port:       7000,
+							TargetPort: intstr.IntOrString{IntVal: 7000},
+							Protocol:   apiv1.ProtocolUDP,
+						},
+						{
+							Name:       "p-7001-7001-udp",
+							Port:       7001,
+							TargetPort: intstr.IntOrString{IntVal: 7001},
+							Protocol:   apiv1.ProtocolUDP,
+						},
+						{
+							Name:       "p-9090-9090-tcp",
+							Port:       9090,
+							TargetPort: intstr.IntOrString{IntVal: 9090},
+							Protocol:   apiv1.ProtocolTCP,
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := translateService("svcName", tt.stack)
+			svcName, stack := "svcName", tt.stack
+			if tt.compose != "" {
+				var err error
+				svcName = tt.svcName
+				stack, err = model.ReadStack([]byte(tt.compose), true)
+				require.NoError(t, err)
+				// exposed ports never create public endpoints
+				assert.Equal(t, tt.publicPorts, getSvcPublicPorts(svcName, stack))
+			}
+			result := translateService(svcName, stack)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
@@ -1277,6 +1409,47 @@ func Test_translateSvcProbe(t *testing.T) {
 					TimeoutSeconds:      300,
 					PeriodSeconds:       45,
 				},
+				startup: &apiv1.Probe{
+					ProbeHandler: apiv1.ProbeHandler{
+						HTTPGet: &apiv1.HTTPGetAction{
+							Path: "/",
+							Port: intstr.IntOrString{IntVal: 8080},
+						},
+					},
+					FailureThreshold: 6,
+					TimeoutSeconds:   300,
+					PeriodSeconds:    45,
+				},
+			},
+		},
+		{
+			name: "healthcheck tcp",
+			svc: &model.Service{
+				Healtcheck: &model.HealthCheck{
+					TCP: &model.TCPHealthcheck{
+						Host: "127.0.0.1",
+						Port: 6379,
+					},
+					StartPeriod: 20 * time.Second,
+					Retries:     3,
+					Timeout:     2 * time.Second,
+					Interval:    5 * time.Second,
+					Readiness:   true,
+				},
+			},
+			expected: healthcheckProbes{
+				readiness: &apiv1.Probe{
+					ProbeHandler: apiv1.ProbeHandler{
+						TCPSocket: &apiv1.TCPSocketAction{
+							Host: "127.0.0.1",
+							Port: intstr.IntOrString{IntVal: 6379},
+						},
+					},
+					InitialDelaySeconds: 20,
+					FailureThreshold:    3,
+					TimeoutSeconds:      2,
+					PeriodSeconds:       5,
+				},
 			},
 		},
 		{
@@ -1326,6 +1499,168 @@ func Test_translateSvcProbe(t *testing.T) {
 					TimeoutSeconds:      300,
 					PeriodSeconds:       45,
 				},
+				startup: &apiv1.Probe{
+					ProbeHandler: apiv1.ProbeHandler{
+						Exec: &apiv1.ExecAction{
+							Command: []string{"curl", "db-service:8080/readiness"},
+						},
+					},
+					FailureThreshold: 6,
+					TimeoutSeconds:   300,
+					PeriodSeconds:    45,
+				},
+			},
+		},
+		{
+			name: "healthcheck with start period only readiness",
+			svc: &model.Service{
+				Healtcheck: &model.HealthCheck{
+					Test:        model.HealtcheckTest{"pg_isready"},
+					StartPeriod: 30 * time.Second,
+					Readiness:   true,
+				},
+			},
+			expected: healthcheckProbes{
+				readiness: &apiv1.Probe{
+					ProbeHandler: apiv1.ProbeHandler{
+						Exec: &apiv1.ExecAction{
+							Command: []string{"pg_isready"},
+						},
+					},
+					InitialDelaySeconds: 30,
+				},
+			},
+		},
+		{
+			name: "healthcheck liveness with start period and default interval",
+			svc: &model.Service{
+				Healtcheck: &model.HealthCheck{
+					Test:        model.HealtcheckTest{"pg_isready"},
+					StartPeriod: 25 * time.Second,
+					Liveness:    true,
+				},
+			},
+			expected: healthcheckProbes{
+				liveness: &apiv1.Probe{
+					ProbeHandler: apiv1.ProbeHandler{
+						Exec: &apiv1.ExecAction{
+							Command: []string{"pg_isready"},
+						},
+					},
+					InitialDelaySeconds: 25,
+				},
+				startup: &apiv1.Probe{
+					ProbeHandler: apiv1.ProbeHandler{
+						Exec: &apiv1.ExecAction{
+							Command: []string{"pg_isready"},
+						},
+					},
+					PeriodSeconds:    10,
+					FailureThreshold: 6,
+				},
+			},
+		},
+		{
+			name: "readiness probe only",
+			svc: &model.Service{
+				ReadinessProbe: &model.HealthCheck{
+					HTTP:     &model.HTTPHealtcheck{Path: "/ready", Port: 8080},
+					Interval: 5 * time.Second,
+				},
+			},
+			expected: healthcheckProbes{
+				readiness: &apiv1.Probe{
+					ProbeHandler: apiv1.ProbeHandler{
+						HTTPGet: &apiv1.HTTPGetAction{
+							Path: "/ready",
+							Port: intstr.IntOrString{IntVal: 8080},
+						},
+					},
+					PeriodSeconds: 5,
+				},
+			},
+		},
+		{
+			name: "liveness probe only",
+			svc: &model.Service{
+				LivenessProbe: &model.HealthCheck{
+					Test:        model.HealtcheckTest{"cat", "/tmp/healthy"},
+					StartPeriod: 60 * time.Second,
+					Retries:     2,
+				},
+			},
+			expected: healthcheckProbes{
+				liveness: &apiv1.Probe{
+					ProbeHandler: apiv1.ProbeHandler{
+						Exec: &apiv1.ExecAction{
+							Command: []string{"cat", "/tmp/healthy"},
+						},
+					},
+					InitialDelaySeconds: 60,
+					FailureThreshold:    2,
+				},
+			},
+		},
+		{
+			name: "readiness and liveness probes",
+			svc: &model.Service{
+				ReadinessProbe: &model.HealthCheck{
+					HTTP: &model.HTTPHealtcheck{Path: "/ready", Port: 8080},
+				},
+				LivenessProbe: &model.HealthCheck{
+					HTTP:        &model.HTTPHealtcheck{Path: "/live", Port: 8080},
+					StartPeriod: 2 * time.Minute,
+				},
+			},
+			expected: healthcheckProbes{
+				readiness: &apiv1.Probe{
+					ProbeHandler: apiv1.ProbeHandler{
+						HTTPGet: &apiv1.HTTPGetAction{
+							Path: "/ready",
+							Port: intstr.IntOrString{IntVal: 8080},
+						},
+					},
+				},
+				liveness: &apiv1.Probe{
+					ProbeHandler: apiv1.ProbeHandler{
+						HTTPGet: &apiv1.HTTPGetAction{
+							Path: "/live",
+							Port: intstr.IntOrString{IntVal: 8080},
+						},
+					},
+					InitialDelaySeconds: 120,
+				},
+			},
+		},
+		{
+			name: "liveness probe overrides the healthcheck",
+			svc: &model.Service{
+				Healtcheck: &model.HealthCheck{
+					Test:        model.HealtcheckTest{"pg_isready"},
+					StartPeriod: 30 * time.Second,
+					Readiness:   true,
+					Liveness:    true,
+				},
+				LivenessProbe: &model.HealthCheck{
+					Test: model.HealtcheckTest{"pgrep", "postgres"},
+				},
+			},
+			expected: healthcheckProbes{
+				readiness: &apiv1.Probe{
+					ProbeHandler: apiv1.ProbeHandler{
+						Exec: &apiv1.ExecAction{
+							Command: []string{"pg_isready"},
+						},
+					},
+					InitialDelaySeconds: 30,
+				},
+				liveness: &apiv1.Probe{
+					ProbeHandler: apiv1.ProbeHandler{
+						Exec: &apiv1.ExecAction{
+							Command: []string{"pgrep", "postgres"},
+						},
+					},
+				},
 			},
 		},
 	}
@@ -1338,6 +1673,32 @@ func Test_translateSvcProbe(t *testing.T) {
 	}
 }
 
+func Test_translateDeploymentWithProbes(t *testing.T) {
+	s := &model.Stack{
+		Name: "stackName",
+		Services: map[string]*model.Service{
+			"api": {
+				Image:    "api:latest",
+				Replicas: 1,
+				ReadinessProbe: &model.HealthCheck{
+					HTTP: &model.HTTPHealtcheck{Path: "/ready", Port: 8080},
+				},
+				LivenessProbe: &model.HealthCheck{
+					HTTP: &model.HTTPHealtcheck{Path: "/live", Port: 8080},
+				},
+			},
+		},
+	}
+	d := translateDeployment("api", s, nil)
+	container := d.Spec.Template.Spec.Containers[0]
+	require.NotNil(t, container.ReadinessProbe)
+	require.NotNil(t, container.LivenessProbe)
+	assert.NotSame(t, container.ReadinessProbe, container.LivenessProbe)
+	assert.Equal(t, "/ready", container.ReadinessProbe.HTTPGet.Path)
+	assert.Equal(t, "/live", container.LivenessProbe.HTTPGet.Path)
+	assert.Nil(t, container.StartupProbe)
+}
+
 func Test_translateServiceEnvironment(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1394,6 +1755,35 @@ func Test_translateServiceEnvironment(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "duplicated env var",
+			svc: &model.Service{
+				Environment: env.Environment{
+					env.Var{
+						Name:  "PORT",
+						Value: "8080",
+					},
+					env.Var{
+						Name:  "DEBUG",
+						Value: "true",
+					},
+					env.Var{
+						Name:  "PORT",
+						Value: "3000",
+					},
+				},
+			},
+			expected: []apiv1.EnvVar{
+				{
+					Name:  "PORT",
+					Value: "3000",
+				},
+				{
+					Name:  "DEBUG",
+					Value: "true",
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1684,25 +2074,330 @@ func Test_translateAffinity(t *testing.T) {
 			disableVolumeAffinity: true,
 			affinity:              nil,
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if tt.disableVolumeAffinity {
-				t.Setenv(oktetoComposeVolumeAffinityEnabledEnvVar, "false")
-			}
-			aff := translateAffinity(tt.svc)
-			assert.Equal(t, tt.affinity, aff)
-		})
-	}
-}
-
-func TestGetSvcPublicPorts(t *testing.T) {
-	tests := []struct {
-		stack          *model.Stack
-		name           string
-		svcName        string
-		expectedLength int
+		{
+			name: "soft anti-affinity",
+			svc: &model.Service{
+				AntiAffinity: model.SoftAntiAffinity,
+			},
+			affinity: &apiv1.Affinity{
+				PodAntiAffinity: &apiv1.PodAntiAffinity{
+					PreferredDuringSchedulingIgnoredDuringExecution: []apiv1.WeightedPodAffinityTerm{
+						{
+							Weight: 100,
+							PodAffinityTerm: apiv1.PodAffinityTerm{
+								TopologyKey: "kubernetes.io/hostname",
+								LabelSelector: &metav1.LabelSelector{
+									MatchLabels: map[string]string{
+										model.StackNameLabel:        "stack",
+										model.StackServiceNameLabel: "svc",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "hard anti-affinity",
+			svc: &model.Service{
+				AntiAffinity: model.HardAntiAffinity,
+			},
+			affinity: &apiv1.Affinity{
+				PodAntiAffinity: &apiv1.PodAntiAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: []apiv1.PodAffinityTerm{
+						{
+							TopologyKey: "kubernetes.io/hostname",
+							LabelSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{
+									model.StackNameLabel:        "stack",
+									model.StackServiceNameLabel: "svc",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "soft anti-affinity with volume",
+			svc: &model.Service{
+				AntiAffinity: model.SoftAntiAffinity,
+				Volumes: []build.VolumeMounts{
+					{
+						LocalPath:  "test",
+						RemotePath: "/var",
+					},
+				},
+			},
+			affinity: &apiv1.Affinity{
+				PodAffinity: &apiv1.PodAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: []apiv1.PodAffinityTerm{
+						{
+							TopologyKey: "kubernetes.io/hostname",
+							LabelSelector: &metav1.LabelSelector{
+								MatchExpressions: []metav1.LabelSelectorRequirement{
+									{
+										Key:      fmt.Sprintf("%s-test", model.StackVolumeNameLabel),
+										Operator: metav1.LabelSelectorOpExists,
+									},
+								},
+							},
+						},
+					},
+				},
+				PodAntiAffinity: &apiv1.PodAntiAffinity{
+					PreferredDuringSchedulingIgnoredDuringExecution: []apiv1.WeightedPodAffinityTerm{
+						{
+							Weight: 100,
+							PodAffinityTerm: apiv1.PodAffinityTerm{
+								TopologyKey: "kubernetes.io/hostname",
+								LabelSelector: &metav1.LabelSelector{
+									MatchLabels: map[string]string{
+										model.StackNameLabel:        "stack",
+										model.StackServiceNameLabel: "svc",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "hard anti-affinity with volume affinity disabled",
+			svc: &model.Service{
+				AntiAffinity: model.HardAntiAffinity,
+				Volumes: []build.VolumeMounts{
+					{
+						LocalPath:  "test",
+						RemotePath: "/var",
+					},
+				},
+			},
+			disableVolumeAffinity: true,
+			affinity: &apiv1.Affinity{
+				PodAntiAffinity: &apiv1.PodAntiAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: []apiv1.PodAffinityTerm{
+						{
+							TopologyKey: "kubernetes.io/hostname",
+							LabelSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{
+									model.StackNameLabel:        "stack",
+									model.StackServiceNameLabel: "svc",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "equality placement constraints",
+			svc: &model.Service{
+				PlacementConstraints: []model.PlacementConstraint{
+					{Key: "disktype", Operator: model.PlacementConstraintEqual, Value: "ssd"},
+				},
+			},
+			affinity: nil,
+		},
+		{
+			name: "placement constraints with volume",
+			svc: &model.Service{
+				PlacementConstraints: []model.PlacementConstraint{
+					{Key: "disktype", Operator: model.PlacementConstraintEqual, Value: "ssd"},
+					{Key: "zone", Operator: model.PlacementConstraintNotEqual, Value: "us-east-1a"},
+					{Key: "gpu", Operator: model.PlacementConstraintExists},
+				},
+				Volumes: []build.VolumeMounts{
+					{
+						LocalPath:  "test",
+						RemotePath: "/var",
+					},
+				},
+			},
+			affinity: &apiv1.Affinity{
+				NodeAffinity: &apiv1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &apiv1.NodeSelector{
+						NodeSelectorTerms: []apiv1.NodeSelectorTerm{
+							{
+								MatchExpressions: []apiv1.NodeSelectorRequirement{
+									{
+										Key:      "zone",
+										Operator: apiv1.NodeSelectorOpNotIn,
+										Values:   []string{"us-east-1a"},
+									},
+									{
+										Key:      "gpu",
+										Operator: apiv1.NodeSelectorOpExists,
+									},
+								},
+							},
+						},
+					},
+				},
+				PodAffinity: &apiv1.PodAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: []apiv1.PodAffinityTerm{
+						{
+							TopologyKey: "kubernetes.io/hostname",
+							LabelSelector: &metav1.LabelSelector{
+								MatchExpressions: []metav1.LabelSelectorRequirement{
+									{
+										Key:      fmt.Sprintf("%s-test", model.StackVolumeNameLabel),
+										Operator: metav1.LabelSelectorOpExists,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.disableVolumeAffinity {
+				t.Setenv(oktetoComposeVolumeAffinityEnabledEnvVar, "false")
+			}
+			s := &model.Stack{
+				Name:     "stack",
+				Services: model.ComposeServices{"svc": tt.svc},
+			}
+			aff := translateAffinity("svc", s)
+			assert.Equal(t, tt.affinity, aff)
+		})
+	}
+}
+
+func Test_translateNodeSelector(t *testing.T) {
+	svc := &model.Service{
+		NodeSelector: model.Selector{"pool": "default"},
+		PlacementConstraints: []model.PlacementConstraint{
+			{Key: "disktype", Operator: model.PlacementConstraintEqual, Value: "ssd"},
+			{Key: "gpu", Operator: model.PlacementConstraintExists},
+		},
+	}
+	assert.Equal(t, map[string]string{"pool": "default", "disktype": "ssd"}, translateNodeSelector(svc))
+
+	svc.PlacementConstraints = nil
+	assert.Equal(t, map[string]string{"pool": "default"}, translateNodeSelector(svc))
+}
+
+func Test_translateTopologySpreadConstraints(t *testing.T) {
+	selector := &metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			model.StackNameLabel:        "stack",
+			model.StackServiceNameLabel: "svc",
+		},
+	}
+	tests := []struct {
+		name     string
+		spreads  model.TopologySpreads
+		expected []apiv1.TopologySpreadConstraint
+	}{
+		{
+			name: "none",
+		},
+		{
+			name: "nodes and zones",
+			spreads: model.TopologySpreads{
+				{
+					MaxSkew:           1,
+					TopologyKey:       "kubernetes.io/hostname",
+					WhenUnsatisfiable: apiv1.DoNotSchedule,
+				},
+				{
+					MaxSkew:           2,
+					TopologyKey:       "topology.kubernetes.io/zone",
+					WhenUnsatisfiable: apiv1.ScheduleAnyway,
+				},
+			},
+			expected: []apiv1.TopologySpreadConstraint{
+				{
+					MaxSkew:           1,
+					TopologyKey:       "kubernetes.io/hostname",
+					WhenUnsatisfiable: apiv1.DoNotSchedule,
+					LabelSelector:     selector,
+				},
+				{
+					MaxSkew:           2,
+					TopologyKey:       "topology.kubernetes.io/zone",
+					WhenUnsatisfiable: apiv1.ScheduleAnyway,
+					LabelSelector:     selector,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &model.Stack{
+				Name:     "stack",
+				Services: model.ComposeServices{"svc": {TopologySpread: tt.spreads}},
+			}
+			assert.Equal(t, tt.expected, translateTopologySpreadConstraints("svc", s))
+		})
+	}
+}
+
+func Test_translateStatefulSetWithTopologySpreadAndVolumes(t *testing.T) {
+	s := &model.Stack{
+		Name: "stack",
+		Services: model.ComposeServices{
+			"svc": {
+				Image:        "image",
+				Replicas:     3,
+				AntiAffinity: model.SoftAntiAffinity,
+				TopologySpread: model.TopologySpreads{
+					{MaxSkew: 1, TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: apiv1.DoNotSchedule},
+				},
+				Volumes: []build.VolumeMounts{
+					{
+						LocalPath:  "data",
+						RemotePath: "/data",
+					},
+				},
+			},
+		},
+	}
+	sfs := translateStatefulSet("svc", s, nil)
+	podSpec := sfs.Spec.Template.Spec
+	require.NotNil(t, podSpec.Affinity)
+	require.NotNil(t, podSpec.Affinity.PodAffinity)
+	require.NotNil(t, podSpec.Affinity.PodAntiAffinity)
+	assert.Len(t, podSpec.Affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution, 1)
+	assert.Len(t, podSpec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution, 1)
+	require.Len(t, podSpec.TopologySpreadConstraints, 1)
+	assert.Equal(t, "topology.kubernetes.io/zone", podSpec.TopologySpreadConstraints[0].TopologyKey)
+	assert.Equal(t, translateLabelSelector("svc", s), podSpec.TopologySpreadConstraints[0].LabelSelector.MatchLabels)
+}
+
+func Test_translateDeploymentWithAntiAffinity(t *testing.T) {
+	s := &model.Stack{
+		Name: "stack",
+		Services: model.ComposeServices{
+			"svc": {
+				Image:        "image",
+				Replicas:     3,
+				AntiAffinity: model.HardAntiAffinity,
+			},
+		},
+	}
+	d := translateDeployment("svc", s, nil)
+	podSpec := d.Spec.Template.Spec
+	require.NotNil(t, podSpec.Affinity)
+	assert.Nil(t, podSpec.Affinity.PodAffinity)
+	require.NotNil(t, podSpec.Affinity.PodAntiAffinity)
+	assert.Equal(t, d.Spec.Selector.MatchLabels, podSpec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution[0].LabelSelector.MatchLabels)
+	assert.Nil(t, podSpec.TopologySpreadConstraints)
+}
+
+func TestGetSvcPublicPorts(t *testing.T) {
+	tests := []struct {
+		stack          *model.Stack
+		name           string
+		svcName        string
+		expectedLength int
 	}{
 		{
 			name:    "one public port",
@@ -2375,3 +3070,1059 @@ func Test_translateJob_enableServiceLinks(t *testing.T) {
 		})
 	}
 }
+
+func Test_translateServiceAccountName(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceAccount string
+	}{
+		{name: "unset", serviceAccount: ""},
+		{name: "set", serviceAccount: "my-sa"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &model.Stack{
+				Name: "stackName",
+				Services: map[string]*model.Service{
+					"deployment": {
+						Image:          "image",
+						Replicas:       1,
+						ServiceAccount: tt.serviceAccount,
+					},
+					"job": {
+						Image:          "image",
+						Replicas:       1,
+						RestartPolicy:  apiv1.RestartPolicyNever,
+						ServiceAccount: tt.serviceAccount,
+					},
+				},
+			}
+
+			require.Equal(t, tt.serviceAccount, translateDeployment("deployment", s, nil).Spec.Template.Spec.ServiceAccountName)
+			require.Equal(t, tt.serviceAccount, translateStatefulSet("deployment", s, nil).Spec.Template.Spec.ServiceAccountName)
+			require.Equal(t, tt.serviceAccount, translateJob("job", s, nil).Spec.Template.Spec.ServiceAccountName)
+		})
+	}
+}
+
+func Test_translateServiceAccount(t *testing.T) {
+	s := &model.Stack{
+		Name:      "Stack Name",
+		Namespace: "ns",
+	}
+
+	result := translateServiceAccount("my-sa", s)
+
+	require.Equal(t, "my-sa", result.Name)
+	require.Equal(t, "ns", result.Namespace)
+	require.Equal(t, map[string]string{
+		model.StackNameLabel:  "stack-name",
+		model.DeployedByLabel: "stack-name",
+	}, result.Labels)
+}
+
+func Test_translateGPUResources(t *testing.T) {
+	gpus := model.GPUResources{model.NvidiaGPUResource: resource.MustParse("1")}
+	s := &model.Stack{
+		Name: "stackName",
+		Services: map[string]*model.Service{
+			"deployment": {
+				Image:        "image",
+				Replicas:     1,
+				NodeSelector: model.Selector{"pool": "gpu"},
+				Resources: &model.StackResources{
+					GPUs: gpus,
+					Limits: model.ServiceResources{
+						CPU: model.Quantity{Value: resource.MustParse("1")},
+					},
+				},
+			},
+			"job": {
+				Image:         "image",
+				Replicas:      1,
+				RestartPolicy: apiv1.RestartPolicyNever,
+				Resources:     &model.StackResources{GPUs: gpus},
+			},
+		},
+	}
+	expectedResources := apiv1.ResourceRequirements{
+		Limits: apiv1.ResourceList{
+			apiv1.ResourceCPU:       resource.MustParse("1"),
+			model.NvidiaGPUResource: resource.MustParse("1"),
+		},
+		Requests: apiv1.ResourceList{
+			model.NvidiaGPUResource: resource.MustParse("1"),
+		},
+	}
+
+	t.Run("without gpu toleration", func(t *testing.T) {
+		d := translateDeployment("deployment", s, nil)
+		require.Equal(t, expectedResources, d.Spec.Template.Spec.Containers[0].Resources)
+		require.Equal(t, map[string]string{"pool": "gpu"}, d.Spec.Template.Spec.NodeSelector)
+		require.Empty(t, d.Spec.Template.Spec.Tolerations)
+	})
+
+	t.Run("with gpu toleration", func(t *testing.T) {
+		t.Setenv(model.OktetoGPUTolerationEnvVar, "true")
+		expectedTolerations := []apiv1.Toleration{
+			{Key: "nvidia.com/gpu", Operator: apiv1.TolerationOpExists, Effect: apiv1.TaintEffectNoSchedule},
+		}
+
+		d := translateDeployment("deployment", s, nil)
+		require.Equal(t, expectedResources, d.Spec.Template.Spec.Containers[0].Resources)
+		require.Equal(t, map[string]string{"pool": "gpu", "nvidia.com/gpu.present": "true"}, d.Spec.Template.Spec.NodeSelector)
+		require.Equal(t, expectedTolerations, d.Spec.Template.Spec.Tolerations)
+
+		sfs := translateStatefulSet("deployment", s, nil)
+		require.Equal(t, expectedResources, sfs.Spec.Template.Spec.Containers[0].Resources)
+		require.Equal(t, expectedTolerations, sfs.Spec.Template.Spec.Tolerations)
+
+		job := translateJob("job", s, nil)
+		require.Equal(t, apiv1.ResourceList{model.NvidiaGPUResource: resource.MustParse("1")}, job.Spec.Template.Spec.Containers[0].Resources.Limits)
+		require.Equal(t, apiv1.ResourceList{model.NvidiaGPUResource: resource.MustParse("1")}, job.Spec.Template.Spec.Containers[0].Resources.Requests)
+		require.Equal(t, map[string]string{"nvidia.com/gpu.present": "true"}, job.Spec.Template.Spec.NodeSelector)
+		require.Equal(t, expectedTolerations, job.Spec.Template.Spec.Tolerations)
+	})
+}
+
+func Test_translateExternalService(t *testing.T) {
+	s := &model.Stack{
+		Name:      "stack",
+		Namespace: "ns",
+		ExternalServices: map[string]*model.ExternalService{
+			"db":    {Host: "db.abc.us-east-1.rds.amazonaws.com", Ports: []int32{5432}},
+			"cache": {Host: "10.0.0.12", Ports: []int32{6379}},
+		},
+	}
+	labels := map[string]string{
+		model.StackNameLabel:  "stack",
+		model.DeployedByLabel: "stack",
+	}
+	ports := func(port int32) []apiv1.ServicePort {
+		return []apiv1.ServicePort{{
+			Name:       fmt.Sprintf("p-%d-%d-tcp", port, port),
+			Port:       port,
+			TargetPort: intstr.IntOrString{IntVal: port},
+			Protocol:   apiv1.ProtocolTCP,
+		}}
+	}
+
+	t.Run("dns name", func(t *testing.T) {
+		svc, slice := translateExternalService("db", s)
+		require.Nil(t, slice)
+		assert.Equal(t, &apiv1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "db",
+				Namespace: "ns",
+				Labels:    map[string]string{model.StackNameLabel: "stack", model.StackExternalServiceLabel: "db", model.DeployedByLabel: "stack"},
+			},
+			Spec: apiv1.ServiceSpec{
+				Type:         apiv1.ServiceTypeExternalName,
+				ExternalName: "db.abc.us-east-1.rds.amazonaws.com",
+				Ports:        ports(5432),
+			},
+		}, svc)
+	})
+
+	t.Run("ip", func(t *testing.T) {
+		svc, slice := translateExternalService("cache", s)
+		labels[model.StackExternalServiceLabel] = "cache"
+		assert.Equal(t, &apiv1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cache",
+				Namespace: "ns",
+				Labels:    labels,
+			},
+			Spec: apiv1.ServiceSpec{
+				Type:      apiv1.ServiceTypeClusterIP,
+				ClusterIP: apiv1.ClusterIPNone,
+				Ports:     ports(6379),
+			},
+		}, svc)
+		require.NotNil(t, slice)
+		assert.Equal(t, "cache", slice.Name)
+		assert.Equal(t, "cache", slice.Labels[discoveryv1.LabelServiceName])
+		assert.Equal(t, externalServiceManagedBy, slice.Labels[discoveryv1.LabelManagedBy])
+		assert.Equal(t, "stack", slice.Labels[model.StackNameLabel])
+		assert.Equal(t, discoveryv1.AddressTypeIPv4, slice.AddressType)
+		assert.Equal(t, []discoveryv1.Endpoint{{Addresses: []string{"10.0.0.12"}}}, slice.Endpoints)
+		assert.Equal(t, []discoveryv1.EndpointPort{{Name: ptr.To("p-6379-6379-tcp"), Port: ptr.To(int32(6379)), Protocol: ptr.To(apiv1.ProtocolTCP)}}, slice.Ports)
+	})
+}
+
+func Test_translateTolerations(t *testing.T) {
+	tolerations := []apiv1.Toleration{
+		{Key: "nvidia.com/gpu", Operator: apiv1.TolerationOpEqual, Value: "a100", Effect: apiv1.TaintEffectNoSchedule},
+		{Key: "dedicated", Operator: apiv1.TolerationOpExists},
+	}
+	s := &model.Stack{
+		Name: "stack",
+		Services: model.ComposeServices{
+			"sfs": {
+				Image:       "image",
+				Replicas:    1,
+				Tolerations: tolerations,
+				Volumes:     []build.VolumeMounts{{LocalPath: "data", RemotePath: "/data"}},
+				Resources:   &model.StackResources{GPUs: model.GPUResources{model.NvidiaGPUResource: resource.MustParse("1")}},
+			},
+			"job": {
+				Image:         "image",
+				RestartPolicy: apiv1.RestartPolicyNever,
+				Tolerations:   tolerations,
+			},
+		},
+	}
+	t.Setenv(model.OktetoGPUTolerationEnvVar, "true")
+
+	sfs := translateStatefulSet("sfs", s, nil)
+	require.Equal(t, tolerations, sfs.Spec.Template.Spec.Tolerations)
+	require.NotNil(t, sfs.Spec.Template.Spec.Affinity)
+	assert.Len(t, sfs.Spec.Template.Spec.Affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution, 1)
+
+	d := translateDeployment("sfs", s, nil)
+	require.Equal(t, tolerations, d.Spec.Template.Spec.Tolerations)
+
+	job := translateJob("job", s, nil)
+	require.Equal(t, tolerations, job.Spec.Template.Spec.Tolerations)
+
+	assert.Nil(t, translateTolerations(&model.Service{}))
+}
+
+func Test_translateExtraHosts(t *testing.T) {
+	hostAliases := []apiv1.HostAlias{
+		{IP: "10.0.0.5", Hostnames: []string{"internal.api", "api"}},
+	}
+	s := &model.Stack{
+		Name: "stack",
+		Services: model.ComposeServices{
+			"svc": {
+				Image:      "image",
+				Replicas:   1,
+				ExtraHosts: hostAliases,
+			},
+			"job": {
+				Image:         "image",
+				RestartPolicy: apiv1.RestartPolicyNever,
+				ExtraHosts:    hostAliases,
+			},
+		},
+	}
+
+	d := translateDeployment("svc", s, nil)
+	require.Equal(t, hostAliases, d.Spec.Template.Spec.HostAliases)
+
+	sfs := translateStatefulSet("svc", s, nil)
+	require.Equal(t, hostAliases, sfs.Spec.Template.Spec.HostAliases)
+
+	job := translateJob("job", s, nil)
+	require.Equal(t, hostAliases, job.Spec.Template.Spec.HostAliases)
+}
+
+func Test_translateDNS(t *testing.T) {
+	s := &model.Stack{
+		Name: "stack",
+		Services: model.ComposeServices{
+			"svc": {
+				Image:     "image",
+				Replicas:  1,
+				DNS:       []string{"10.0.0.2", "10.0.0.3"},
+				DNSSearch: []string{"corp.example.com"},
+				Volumes:   []build.VolumeMounts{{RemotePath: "/data"}},
+				Resources: &model.StackResources{},
+			},
+			"search": {
+				Image:     "image",
+				Replicas:  1,
+				DNSSearch: []string{"corp.example.com"},
+			},
+			"job": {
+				Image:         "image",
+				RestartPolicy: apiv1.RestartPolicyNever,
+				DNS:           []string{"10.0.0.2"},
+			},
+		},
+	}
+
+	sfs := translateStatefulSet("svc", s, nil)
+	require.Equal(t, apiv1.DNSNone, sfs.Spec.Template.Spec.DNSPolicy)
+	require.Equal(t, &apiv1.PodDNSConfig{Nameservers: []string{"10.0.0.2", "10.0.0.3"}, Searches: []string{"corp.example.com"}}, sfs.Spec.Template.Spec.DNSConfig)
+
+	d := translateDeployment("search", s, nil)
+	require.Equal(t, apiv1.DNSPolicy(""), d.Spec.Template.Spec.DNSPolicy)
+	require.Equal(t, &apiv1.PodDNSConfig{Searches: []string{"corp.example.com"}}, d.Spec.Template.Spec.DNSConfig)
+
+	job := translateJob("job", s, nil)
+	require.Equal(t, apiv1.DNSNone, job.Spec.Template.Spec.DNSPolicy)
+	require.Equal(t, &apiv1.PodDNSConfig{Nameservers: []string{"10.0.0.2"}}, job.Spec.Template.Spec.DNSConfig)
+
+	d = translateDeployment("svc", &model.Stack{Name: "stack", Services: model.ComposeServices{"svc": {Image: "image"}}}, nil)
+	require.Nil(t, d.Spec.Template.Spec.DNSConfig)
+}
+
+func Test_translateSecurityContextUser(t *testing.T) {
+	s := &model.Stack{
+		Name: "stack",
+		Services: model.ComposeServices{
+			"svc": {
+				Image:    "image",
+				Replicas: 1,
+				User:     &model.StackSecurityContext{RunAsUser: ptr.To(int64(1000)), RunAsGroup: ptr.To(int64(2000))},
+				CapAdd:   []apiv1.Capability{"NET_ADMIN"},
+				CapDrop:  []apiv1.Capability{"ALL"},
+			},
+		},
+	}
+	expected := &apiv1.SecurityContext{
+		RunAsUser:  ptr.To(int64(1000)),
+		RunAsGroup: ptr.To(int64(2000)),
+		Capabilities: &apiv1.Capabilities{
+			Add:  []apiv1.Capability{"NET_ADMIN"},
+			Drop: []apiv1.Capability{"ALL"},
+		},
+	}
+
+	d := translateDeployment("svc", s, nil)
+	require.Equal(t, expected, d.Spec.Template.Spec.Containers[0].SecurityContext)
+}
+
+func Test_translateReadOnly(t *testing.T) {
+	s := &model.Stack{
+		Name: "stack",
+		Services: model.ComposeServices{
+			"db": {
+				Image:     "postgres",
+				Replicas:  1,
+				ReadOnly:  true,
+				CapDrop:   []apiv1.Capability{"ALL"},
+				Volumes:   []build.VolumeMounts{{RemotePath: "/var/lib/postgresql/data"}},
+				Resources: &model.StackResources{},
+			},
+		},
+	}
+	expected := &apiv1.SecurityContext{
+		ReadOnlyRootFilesystem: ptr.To(true),
+		Capabilities:           &apiv1.Capabilities{Drop: []apiv1.Capability{"ALL"}},
+	}
+
+	d := translateDeployment("db", s, nil)
+	require.Equal(t, expected, d.Spec.Template.Spec.Containers[0].SecurityContext)
+
+	sfs := translateStatefulSet("db", s, nil)
+	require.Equal(t, expected, sfs.Spec.Template.Spec.Containers[0].SecurityContext)
+	require.NotEmpty(t, sfs.Spec.Template.Spec.Containers[0].VolumeMounts)
+	for _, mount := range sfs.Spec.Template.Spec.Containers[0].VolumeMounts {
+		require.False(t, mount.ReadOnly, mount.Name)
+	}
+	require.NotEmpty(t, sfs.Spec.Template.Spec.InitContainers)
+	for _, c := range sfs.Spec.Template.Spec.InitContainers {
+		require.Nil(t, c.SecurityContext, c.Name)
+	}
+}
+
+func Test_translateSecurityOpt(t *testing.T) {
+	s := &model.Stack{
+		Name: "stack",
+		Services: model.ComposeServices{
+			"dind": {
+				Image:             "docker:dind",
+				Replicas:          1,
+				Privileged:        true,
+				SeccompUnconfined: true,
+				CapAdd:            []apiv1.Capability{"SYS_ADMIN"},
+			},
+			"api": {
+				Image:           "okteto/api",
+				Replicas:        1,
+				NoNewPrivileges: true,
+				CapDrop:         []apiv1.Capability{"ALL"},
+			},
+		},
+	}
+
+	d := translateDeployment("dind", s, nil)
+	require.Equal(t, &apiv1.SecurityContext{
+		Privileged:     ptr.To(true),
+		SeccompProfile: &apiv1.SeccompProfile{Type: apiv1.SeccompProfileTypeUnconfined},
+		Capabilities:   &apiv1.Capabilities{Add: []apiv1.Capability{"SYS_ADMIN"}},
+	}, d.Spec.Template.Spec.Containers[0].SecurityContext)
+
+	d = translateDeployment("api", s, nil)
+	require.Equal(t, &apiv1.SecurityContext{
+		AllowPrivilegeEscalation: ptr.To(false),
+		Capabilities:             &apiv1.Capabilities{Drop: []apiv1.Capability{"ALL"}},
+	}, d.Spec.Template.Spec.Containers[0].SecurityContext)
+}
+
+func Test_translatePrivilegedAndDevices(t *testing.T) {
+	s := &model.Stack{
+		Name: "stackName",
+		Services: map[string]*model.Service{
+			"dind": {
+				Image:      "docker:dind",
+				Replicas:   1,
+				Privileged: true,
+				Devices: []model.Device{
+					{HostPath: "/dev/kvm", ContainerPath: "/dev/kvm"},
+					{HostPath: "/dev/sda", ContainerPath: "/dev/xvda", Permissions: "r"},
+				},
+				Volumes:   []build.VolumeMounts{{RemotePath: "/var/lib/docker"}},
+				Resources: &model.StackResources{},
+			},
+			"job": {
+				Image:         "image",
+				Replicas:      1,
+				RestartPolicy: apiv1.RestartPolicyNever,
+				Devices:       []model.Device{{HostPath: "/dev/fuse", ContainerPath: "/dev/fuse", Permissions: "rwm"}},
+				Resources:     &model.StackResources{},
+			},
+		},
+	}
+	expectedVolumes := []apiv1.Volume{
+		{
+			Name:         "okteto-device-0",
+			VolumeSource: apiv1.VolumeSource{HostPath: &apiv1.HostPathVolumeSource{Path: "/dev/kvm"}},
+		},
+		{
+			Name:         "okteto-device-1",
+			VolumeSource: apiv1.VolumeSource{HostPath: &apiv1.HostPathVolumeSource{Path: "/dev/sda"}},
+		},
+	}
+	expectedMounts := []apiv1.VolumeMount{
+		{Name: "okteto-device-0", MountPath: "/dev/kvm"},
+		{Name: "okteto-device-1", MountPath: "/dev/xvda", ReadOnly: true},
+	}
+
+	d := translateDeployment("dind", s, nil)
+	require.Equal(t, &apiv1.SecurityContext{Capabilities: &apiv1.Capabilities{}, Privileged: ptr.To(true)}, d.Spec.Template.Spec.Containers[0].SecurityContext)
+	require.Equal(t, expectedVolumes, d.Spec.Template.Spec.Volumes)
+	require.Equal(t, expectedMounts, d.Spec.Template.Spec.Containers[0].VolumeMounts)
+
+	sfs := translateStatefulSet("dind", s, nil)
+	require.True(t, *sfs.Spec.Template.Spec.Containers[0].SecurityContext.Privileged)
+	require.Subset(t, sfs.Spec.Template.Spec.Volumes, expectedVolumes)
+	require.Subset(t, sfs.Spec.Template.Spec.Containers[0].VolumeMounts, expectedMounts)
+
+	job := translateJob("job", s, nil)
+	require.Nil(t, job.Spec.Template.Spec.Containers[0].SecurityContext)
+	require.Equal(t, []apiv1.Volume{
+		{
+			Name:         "okteto-device-0",
+			VolumeSource: apiv1.VolumeSource{HostPath: &apiv1.HostPathVolumeSource{Path: "/dev/fuse"}},
+		},
+	}, job.Spec.Template.Spec.Volumes)
+	require.Equal(t, []apiv1.VolumeMount{{Name: "okteto-device-0", MountPath: "/dev/fuse"}}, job.Spec.Template.Spec.Containers[0].VolumeMounts)
+}
+
+func Test_translateConfig(t *testing.T) {
+	dir := t.TempDir()
+	textFile := filepath.Join(dir, "nginx.conf")
+	require.NoError(t, os.WriteFile(textFile, []byte("worker_processes 1;"), 0600))
+	binaryFile := filepath.Join(dir, "cert.der")
+	require.NoError(t, os.WriteFile(binaryFile, []byte{0xff, 0xfe, 0x00}, 0600))
+
+	s := &model.Stack{
+		Name:      "stack_name",
+		Namespace: "ns",
+		Configs: map[string]*model.ConfigSpec{
+			"nginx":   {File: textFile},
+			"cert":    {File: binaryFile},
+			"missing": {File: filepath.Join(dir, "missing.conf")},
+		},
+	}
+
+	cm, err := translateConfig("nginx", s)
+	require.NoError(t, err)
+	require.Equal(t, &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "stack-name-config-nginx",
+			Namespace: "ns",
+			Labels: map[string]string{
+				model.StackNameLabel:  "stack-name",
+				model.DeployedByLabel: "stack-name",
+			},
+		},
+		Data: map[string]string{configFileKey: "worker_processes 1;"},
+	}, cm)
+
+	cm, err = translateConfig("cert", s)
+	require.NoError(t, err)
+	require.Nil(t, cm.Data)
+	require.Equal(t, map[string][]byte{configFileKey: {0xff, 0xfe, 0x00}}, cm.BinaryData)
+
+	_, err = translateConfig("missing", s)
+	var uErr oktetoErrors.UserError
+	require.ErrorAs(t, err, &uErr)
+	require.ErrorContains(t, err, "of config 'missing' doesn't exist")
+}
+
+func Test_translateConfigMounts(t *testing.T) {
+	configs := []model.ServiceConfig{
+		{Source: "nginx", Target: "/etc/nginx/nginx.conf"},
+		{Source: "settings", Target: "/settings.json", Mode: ptr.To(int32(0440))},
+	}
+	s := &model.Stack{
+		Name: "stackName",
+		Services: map[string]*model.Service{
+			"api": {
+				Image:     "image",
+				Replicas:  1,
+				Configs:   configs,
+				Resources: &model.StackResources{},
+			},
+			"db": {
+				Image:     "image",
+				Replicas:  1,
+				Configs:   configs,
+				Volumes:   []build.VolumeMounts{{RemotePath: "/data"}},
+				Resources: &model.StackResources{},
+			},
+			"job": {
+				Image:         "image",
+				Replicas:      1,
+				RestartPolicy: apiv1.RestartPolicyNever,
+				Configs:       configs,
+				Resources:     &model.StackResources{},
+			},
+		},
+	}
+	expectedVolumes := []apiv1.Volume{
+		{
+			Name: "okteto-config-0",
+			VolumeSource: apiv1.VolumeSource{
+				ConfigMap: &apiv1.ConfigMapVolumeSource{
+					LocalObjectReference: apiv1.LocalObjectReference{Name: "stackname-config-nginx"},
+					Items:                []apiv1.KeyToPath{{Key: configFileKey, Path: configFileKey}},
+				},
+			},
+		},
+		{
+			Name: "okteto-config-1",
+			VolumeSource: apiv1.VolumeSource{
+				ConfigMap: &apiv1.ConfigMapVolumeSource{
+					LocalObjectReference: apiv1.LocalObjectReference{Name: "stackname-config-settings"},
+					Items:                []apiv1.KeyToPath{{Key: configFileKey, Path: configFileKey, Mode: ptr.To(int32(0440))}},
+				},
+			},
+		},
+	}
+	expectedMounts := []apiv1.VolumeMount{
+		{Name: "okteto-config-0", MountPath: "/etc/nginx/nginx.conf", SubPath: configFileKey, ReadOnly: true},
+		{Name: "okteto-config-1", MountPath: "/settings.json", SubPath: configFileKey, ReadOnly: true},
+	}
+
+	d := translateDeployment("api", s, nil)
+	require.Equal(t, expectedVolumes, d.Spec.Template.Spec.Volumes)
+	require.Equal(t, expectedMounts, d.Spec.Template.Spec.Containers[0].VolumeMounts)
+
+	sfs := translateStatefulSet("db", s, nil)
+	require.Subset(t, sfs.Spec.Template.Spec.Volumes, expectedVolumes)
+	require.Subset(t, sfs.Spec.Template.Spec.Containers[0].VolumeMounts, expectedMounts)
+
+	job := translateJob("job", s, nil)
+	require.Equal(t, expectedVolumes, job.Spec.Template.Spec.Volumes)
+	require.Equal(t, expectedMounts, job.Spec.Template.Spec.Containers[0].VolumeMounts)
+}
+
+func Test_translateSecret(t *testing.T) {
+	dir := t.TempDir()
+	passwordFile := filepath.Join(dir, "db_password.txt")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("s3cr3t"), 0600))
+	t.Setenv("OKTETO_TEST_API_KEY", "api-key-value")
+
+	s := &model.Stack{
+		Name:      "stack_name",
+		Namespace: "ns",
+		Secrets: map[string]*model.SecretSpec{
+			"db_password": {File: passwordFile},
+			"api_key":     {Environment: "OKTETO_TEST_API_KEY"},
+			"missing":     {File: filepath.Join(dir, "missing.txt")},
+			"unset":       {Environment: "OKTETO_TEST_UNSET_SECRET"},
+		},
+	}
+
+	secret, err := translateSecret("db_password", s)
+	require.NoError(t, err)
+	require.Equal(t, &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "stack-name-secret-db-password",
+			Namespace: "ns",
+			Labels: map[string]string{
+				model.StackNameLabel:  "stack-name",
+				model.DeployedByLabel: "stack-name",
+			},
+		},
+		Type: apiv1.SecretTypeOpaque,
+		Data: map[string][]byte{secretFileKey: []byte("s3cr3t")},
+	}, secret)
+
+	secret, err = translateSecret("api_key", s)
+	require.NoError(t, err)
+	require.Equal(t, map[string][]byte{secretFileKey: []byte("api-key-value")}, secret.Data)
+
+	var uErr oktetoErrors.UserError
+	_, err = translateSecret("missing", s)
+	require.ErrorAs(t, err, &uErr)
+	require.ErrorContains(t, err, "of secret 'missing' doesn't exist")
+
+	_, err = translateSecret("unset", s)
+	require.ErrorAs(t, err, &uErr)
+	require.ErrorContains(t, err, "the environment variable 'OKTETO_TEST_UNSET_SECRET' of secret 'unset' is not set")
+}
+
+func Test_translateConfigMapWithoutSecretValues(t *testing.T) {
+	dir := t.TempDir()
+	passwordFile := filepath.Join(dir, "db_password.txt")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("s3cr3t"), 0600))
+	t.Setenv("OKTETO_TEST_API_KEY", "api-key-value")
+
+	s := &model.Stack{
+		Name: "stack_name",
+		Manifest: []byte(`services:
+  app:
+    image: okteto/vote:1
+    secrets:
+    - db_password
+    - api_key
+secrets:
+  db_password:
+    file: ./db_password.txt
+  api_key:
+    environment: OKTETO_TEST_API_KEY`),
+		Secrets: map[string]*model.SecretSpec{
+			"db_password": {File: passwordFile},
+			"api_key":     {Environment: "OKTETO_TEST_API_KEY"},
+		},
+		Services: map[string]*model.Service{
+			"app": {
+				Image: "okteto/vote:1",
+				Secrets: []model.ServiceSecret{
+					{Source: "db_password", Target: "/run/secrets/db_password"},
+					{Source: "api_key", Target: "/run/secrets/api_key"},
+				},
+			},
+		},
+	}
+
+	cfg := translateConfigMap(s)
+	for key, value := range cfg.Data {
+		require.NotContains(t, value, "s3cr3t", key)
+		require.NotContains(t, value, "api-key-value", key)
+	}
+}
+
+func Test_translateSecretMounts(t *testing.T) {
+	secrets := []model.ServiceSecret{
+		{Source: "db_password", Target: "/run/secrets/db_password"},
+		{Source: "api_key", Target: "/etc/app/api.key", Mode: ptr.To(int32(0400))},
+	}
+	s := &model.Stack{
+		Name: "stackName",
+		Services: map[string]*model.Service{
+			"api": {
+				Image:     "image",
+				Replicas:  1,
+				Secrets:   secrets,
+				Resources: &model.StackResources{},
+			},
+			"db": {
+				Image:     "image",
+				Replicas:  1,
+				Secrets:   secrets,
+				Volumes:   []build.VolumeMounts{{RemotePath: "/data"}},
+				Resources: &model.StackResources{},
+			},
+			"job": {
+				Image:         "image",
+				Replicas:      1,
+				RestartPolicy: apiv1.RestartPolicyNever,
+				Secrets:       secrets,
+				Resources:     &model.StackResources{},
+			},
+		},
+	}
+	expectedVolumes := []apiv1.Volume{
+		{
+			Name: "okteto-secret-0",
+			VolumeSource: apiv1.VolumeSource{
+				Secret: &apiv1.SecretVolumeSource{
+					SecretName: "stackname-secret-db-password",
+					Items:      []apiv1.KeyToPath{{Key: secretFileKey, Path: secretFileKey}},
+				},
+			},
+		},
+		{
+			Name: "okteto-secret-1",
+			VolumeSource: apiv1.VolumeSource{
+				Secret: &apiv1.SecretVolumeSource{
+					SecretName: "stackname-secret-api-key",
+					Items:      []apiv1.KeyToPath{{Key: secretFileKey, Path: secretFileKey, Mode: ptr.To(int32(0400))}},
+				},
+			},
+		},
+	}
+	expectedMounts := []apiv1.VolumeMount{
+		{Name: "okteto-secret-0", MountPath: "/run/secrets/db_password", SubPath: secretFileKey, ReadOnly: true},
+		{Name: "okteto-secret-1", MountPath: "/etc/app/api.key", SubPath: secretFileKey, ReadOnly: true},
+	}
+
+	d := translateDeployment("api", s, nil)
+	require.Equal(t, expectedVolumes, d.Spec.Template.Spec.Volumes)
+	require.Equal(t, expectedMounts, d.Spec.Template.Spec.Containers[0].VolumeMounts)
+
+	sfs := translateStatefulSet("db", s, nil)
+	require.Subset(t, sfs.Spec.Template.Spec.Volumes, expectedVolumes)
+	require.Subset(t, sfs.Spec.Template.Spec.Containers[0].VolumeMounts, expectedMounts)
+
+	job := translateJob("job", s, nil)
+	require.Equal(t, expectedVolumes, job.Spec.Template.Spec.Volumes)
+	require.Equal(t, expectedMounts, job.Spec.Template.Spec.Containers[0].VolumeMounts)
+}
+
+func Test_translatePreStopSleep(t *testing.T) {
+	s := &model.Stack{
+		Name: "stackName",
+		Services: map[string]*model.Service{
+			"api": {
+				Image:           "image",
+				Replicas:        1,
+				StopGracePeriod: 20,
+				PreStopSleep:    5,
+				Resources:       &model.StackResources{},
+			},
+			"db": {
+				Image:           "image",
+				Replicas:        1,
+				StopGracePeriod: 20,
+				Volumes:         []build.VolumeMounts{{RemotePath: "/data"}},
+				Resources:       &model.StackResources{},
+			},
+			"job": {
+				Image:         "image",
+				Replicas:      1,
+				RestartPolicy: apiv1.RestartPolicyNever,
+				PreStopSleep:  5,
+				Resources:     &model.StackResources{},
+			},
+		},
+	}
+	expectedLifecycle := &apiv1.Lifecycle{
+		PreStop: &apiv1.LifecycleHandler{
+			Exec: &apiv1.ExecAction{
+				Command: []string{"sh", "-c", "sleep 5"},
+			},
+		},
+	}
+
+	d := translateDeployment("api", s, nil)
+	require.Equal(t, expectedLifecycle, d.Spec.Template.Spec.Containers[0].Lifecycle)
+	require.Equal(t, int64(25), *d.Spec.Template.Spec.TerminationGracePeriodSeconds)
+
+	sfs := translateStatefulSet("db", s, nil)
+	require.Nil(t, sfs.Spec.Template.Spec.Containers[0].Lifecycle)
+	require.Equal(t, int64(20), *sfs.Spec.Template.Spec.TerminationGracePeriodSeconds)
+
+	job := translateJob("job", s, nil)
+	require.Equal(t, expectedLifecycle, job.Spec.Template.Spec.Containers[0].Lifecycle)
+	require.Equal(t, int64(5), *job.Spec.Template.Spec.TerminationGracePeriodSeconds)
+}
+
+func Test_translateLifecycleHooks(t *testing.T) {
+	lifecycle := &model.ServiceLifecycle{
+		PostStart: &model.LifecycleHook{
+			Exec: &model.LifecycleExec{Command: model.Command{Values: []string{"sh", "-c", "./register.sh"}}},
+		},
+		PreStop: &model.LifecycleHook{
+			HTTPGet: &model.LifecycleHTTPGet{Path: "/deregister", Port: 8080, Scheme: "https"},
+		},
+	}
+	s := &model.Stack{
+		Name: "stackName",
+		Services: map[string]*model.Service{
+			"api": {
+				Image:     "image",
+				Replicas:  1,
+				Ports:     []model.Port{{ContainerPort: 8080}},
+				Lifecycle: lifecycle,
+				Resources: &model.StackResources{},
+			},
+			"db": {
+				Image:     "image",
+				Replicas:  1,
+				Ports:     []model.Port{{ContainerPort: 8080}},
+				Lifecycle: lifecycle,
+				Volumes:   []build.VolumeMounts{{RemotePath: "/data"}},
+				Resources: &model.StackResources{},
+			},
+			"job": {
+				Image:         "image",
+				Replicas:      1,
+				RestartPolicy: apiv1.RestartPolicyNever,
+				Ports:         []model.Port{{ContainerPort: 8080}},
+				Lifecycle:     lifecycle,
+				Resources:     &model.StackResources{},
+			},
+			"sleep": {
+				Image:        "image",
+				Replicas:     1,
+				PreStopSleep: 5,
+				Lifecycle: &model.ServiceLifecycle{
+					PostStart: &model.LifecycleHook{
+						Exec: &model.LifecycleExec{Command: model.Command{Values: []string{"./register.sh"}}},
+					},
+				},
+				Resources: &model.StackResources{},
+			},
+		},
+	}
+	expectedLifecycle := &apiv1.Lifecycle{
+		PostStart: &apiv1.LifecycleHandler{
+			Exec: &apiv1.ExecAction{
+				Command: []string{"sh", "-c", "./register.sh"},
+			},
+		},
+		PreStop: &apiv1.LifecycleHandler{
+			HTTPGet: &apiv1.HTTPGetAction{
+				Path:   "/deregister",
+				Port:   intstr.FromInt32(8080),
+				Scheme: apiv1.URISchemeHTTPS,
+			},
+		},
+	}
+
+	d := translateDeployment("api", s, nil)
+	require.Equal(t, expectedLifecycle, d.Spec.Template.Spec.Containers[0].Lifecycle)
+
+	sfs := translateStatefulSet("db", s, nil)
+	require.Equal(t, expectedLifecycle, sfs.Spec.Template.Spec.Containers[0].Lifecycle)
+
+	job := translateJob("job", s, nil)
+	require.Equal(t, expectedLifecycle, job.Spec.Template.Spec.Containers[0].Lifecycle)
+
+	d = translateDeployment("sleep", s, nil)
+	require.Equal(t, &apiv1.Lifecycle{
+		PostStart: &apiv1.LifecycleHandler{
+			Exec: &apiv1.ExecAction{
+				Command: []string{"./register.sh"},
+			},
+		},
+		PreStop: &apiv1.LifecycleHandler{
+			Exec: &apiv1.ExecAction{
+				Command: []string{"sh", "-c", "sleep 5"},
+			},
+		},
+	}, d.Spec.Template.Spec.Containers[0].Lifecycle)
+}
+
+func Test_translatePriorityClassAndJobLimits(t *testing.T) {
+	s := &model.Stack{
+		Name: "stackName",
+		Services: map[string]*model.Service{
+			"api": {
+				Image:             "image",
+				Replicas:          1,
+				PriorityClassName: "high-priority",
+				Resources:         &model.StackResources{},
+			},
+			"db": {
+				Image:             "image",
+				Replicas:          1,
+				PriorityClassName: "high-priority",
+				Volumes:           []build.VolumeMounts{{RemotePath: "/data"}},
+				Resources:         &model.StackResources{},
+			},
+			"job": {
+				Image:                   "image",
+				Replicas:                1,
+				RestartPolicy:           apiv1.RestartPolicyNever,
+				PriorityClassName:       "low-priority",
+				ActiveDeadlineSeconds:   ptr.To(int64(600)),
+				TTLSecondsAfterFinished: ptr.To(int32(3600)),
+				Resources:               &model.StackResources{},
+			},
+		},
+	}
+
+	d := translateDeployment("api", s, nil)
+	require.Equal(t, "high-priority", d.Spec.Template.Spec.PriorityClassName)
+
+	sfs := translateStatefulSet("db", s, nil)
+	require.Equal(t, "high-priority", sfs.Spec.Template.Spec.PriorityClassName)
+
+	job := translateJob("job", s, nil)
+	require.Equal(t, "low-priority", job.Spec.Template.Spec.PriorityClassName)
+	require.Equal(t, ptr.To(int64(600)), job.Spec.ActiveDeadlineSeconds)
+	require.Equal(t, ptr.To(int32(3600)), job.Spec.TTLSecondsAfterFinished)
+}
+
+func Test_translatePortsDoesNotModifyService(t *testing.T) {
+	svc := &model.Service{
+		Ports: []model.Port{
+			{ContainerPort: 8080, Protocol: apiv1.ProtocolTCP},
+			{ContainerPort: 53, Protocol: apiv1.ProtocolUDP},
+			{ContainerPort: 53, Protocol: apiv1.ProtocolTCP},
+		},
+	}
+	expected := make([]model.Port, len(svc.Ports))
+	copy(expected, svc.Ports)
+
+	assert.Equal(t, []apiv1.ContainerPort{{ContainerPort: 53}, {ContainerPort: 53}, {ContainerPort: 8080}}, translateContainerPorts(svc))
+	assert.Equal(t, []apiv1.ServicePort{
+		{
+			Name:       "p-53-53-tcp",
+			Port:       53,
+			TargetPort: intstr.IntOrString{IntVal: 53},
+			Protocol:   apiv1.ProtocolTCP,
+		},
+		{
+			Name:       "p-8080-8080-tcp",
+			Port:       8080,
+			TargetPort: intstr.IntOrString{IntVal: 8080},
+			Protocol:   apiv1.ProtocolTCP,
+		},
+	}, translateServicePorts(*svc))
+	assert.Equal(t, expected, svc.Ports)
+}
+
+func Test_translateVolumeMountsSortedByPath(t *testing.T) {
+	svc := &model.Service{
+		Volumes: []build.VolumeMounts{
+			{RemotePath: "/var/lib/data/cache"},
+			{LocalPath: "shared", RemotePath: "/var/lib/data"},
+			{RemotePath: "/app"},
+		},
+		Devices: []model.Device{{HostPath: "/dev/fuse", ContainerPath: "/dev/fuse"}},
+	}
+
+	assert.Equal(t, []apiv1.VolumeMount{
+		{Name: pvcName, MountPath: "/app", SubPath: "data-2"},
+		{Name: "okteto-device-0", MountPath: "/dev/fuse"},
+		{Name: "shared", MountPath: "/var/lib/data", SubPath: "shared"},
+		{Name: pvcName, MountPath: "/var/lib/data/cache", SubPath: "data-0"},
+	}, translateVolumeMounts(svc))
+}
+
+// Test_translateIsDeterministic translates the same stack many times, shuffling the declaration
+// order of its environment and ports, and checks that the result is always byte-identical
+func Test_translateIsDeterministic(t *testing.T) {
+	environment := env.Environment{
+		{Name: "DEBUG", Value: "true"},
+		{Name: "DATABASE_URL", Value: "postgres://db:5432"},
+		{Name: "PORT", Value: "8080"},
+		{Name: "LOG_LEVEL", Value: "info"},
+		{Name: "CACHE_URL", Value: "redis://cache:6379"},
+	}
+	ports := []model.Port{
+		{ContainerPort: 8080, Protocol: apiv1.ProtocolTCP},
+		{ContainerPort: 8080, HostPort: 80, Protocol: apiv1.ProtocolTCP},
+		{ContainerPort: 9090, Protocol: apiv1.ProtocolTCP},
+		{ContainerPort: 53, Protocol: apiv1.ProtocolUDP},
+		{ContainerPort: 53, Protocol: apiv1.ProtocolTCP},
+	}
+	newStack := func(r *rand.Rand) *model.Stack {
+		// the environment keeps its declaration order, as variables can reference the variables declared before them
+		svcEnvironment := make(env.Environment, len(environment))
+		copy(svcEnvironment, environment)
+		svcPorts := make([]model.Port, len(ports))
+		copy(svcPorts, ports)
+		r.Shuffle(len(svcPorts), func(i, j int) {
+			svcPorts[i], svcPorts[j] = svcPorts[j], svcPorts[i]
+		})
+		return &model.Stack{
+			Name: "stackName",
+			Services: map[string]*model.Service{
+				"api": {
+					Image:       "api",
+					Replicas:    1,
+					Environment: svcEnvironment,
+					Ports:       svcPorts,
+					Labels:      model.Labels{"label1": "value1", "label2": "value2", "label3": "value3"},
+					Annotations: model.Annotations{"annotation1": "value1", "annotation2": "value2"},
+					Resources:   &model.StackResources{},
+				},
+				"db": {
+					Image:       "db",
+					Replicas:    1,
+					Environment: svcEnvironment,
+					Ports:       svcPorts,
+					Volumes: []build.VolumeMounts{
+						{RemotePath: "/var/lib/data/cache"},
+						{LocalPath: "shared", RemotePath: "/var/lib/data"},
+						{RemotePath: "/backup"},
+					},
+					Devices:   []model.Device{{HostPath: "/dev/fuse", ContainerPath: "/dev/fuse"}},
+					Resources: &model.StackResources{},
+				},
+				"job": {
+					Image:         "job",
+					Replicas:      1,
+					RestartPolicy: apiv1.RestartPolicyNever,
+					Environment:   svcEnvironment,
+					Ports:         svcPorts,
+					Volumes:       []build.VolumeMounts{{RemotePath: "/output"}, {RemotePath: "/input"}},
+					Resources:     &model.StackResources{},
+				},
+			},
+		}
+	}
+	translateAll := func(s *model.Stack) string {
+		result, err := json.Marshal([]interface{}{
+			translateDeployment("api", s, nil),
+			translateService("api", s),
+			translateStatefulSet("db", s, nil),
+			translateJob("job", s, nil),
+		})
+		require.NoError(t, err)
+		return string(result)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	expected := translateAll(newStack(r))
+	for i := 0; i < 100; i++ {
+		require.Equal(t, expected, translateAll(newStack(r)), "translation %d is different", i)
+	}
+}
+
+func Test_translateHostNamespaces(t *testing.T) {
+	s := &model.Stack{
+		Name: "stackName",
+		Services: map[string]*model.Service{
+			"profiler": {
+				Image:       "okteto/profiler",
+				Replicas:    1,
+				HostPID:     true,
+				HostIPC:     true,
+				HostNetwork: true,
+				Resources:   &model.StackResources{},
+			},
+			"app": {
+				Image:     "okteto/app",
+				Replicas:  1,
+				Resources: &model.StackResources{},
+			},
+		},
+	}
+
+	d := translateDeployment("profiler", s, nil)
+	require.True(t, d.Spec.Template.Spec.HostPID)
+	require.True(t, d.Spec.Template.Spec.HostIPC)
+	require.True(t, d.Spec.Template.Spec.HostNetwork)
+	require.Equal(t, apiv1.DNSClusterFirstWithHostNet, d.Spec.Template.Spec.DNSPolicy)
+
+	sfs := translateStatefulSet("profiler", s, nil)
+	require.True(t, sfs.Spec.Template.Spec.HostPID)
+	require.True(t, sfs.Spec.Template.Spec.HostIPC)
+	require.True(t, sfs.Spec.Template.Spec.HostNetwork)
+	require.Equal(t, apiv1.DNSClusterFirstWithHostNet, sfs.Spec.Template.Spec.DNSPolicy)
+
+	s.Services["profiler"].RestartPolicy = apiv1.RestartPolicyNever
+	job := translateJob("profiler", s, nil)
+	require.True(t, job.Spec.Template.Spec.HostPID)
+	require.True(t, job.Spec.Template.Spec.HostIPC)
+	require.True(t, job.Spec.Template.Spec.HostNetwork)
+
+	d = translateDeployment("app", s, nil)
+	require.False(t, d.Spec.Template.Spec.HostPID)
+	require.False(t, d.Spec.Template.Spec.HostIPC)
+	require.False(t, d.Spec.Template.Spec.HostNetwork)
+	require.Empty(t, d.Spec.Template.Spec.DNSPolicy)
+}
@@ -0,0 +1,167 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	storageClassReference   = "storage class"
+	serviceAccountReference = "service account"
+	priorityClassReference  = "priority class"
+
+	// defaultServiceAccount is created by kubernetes in every namespace
+	defaultServiceAccount = "default"
+)
+
+// ExternalReference is a resource of the cluster referenced by a stack that is not created by the stack
+type ExternalReference struct {
+	Kind  string
+	Name  string
+	Field string
+}
+
+// ExternalReferenceResult is the result of checking an external reference in the cluster
+type ExternalReferenceResult struct {
+	// Err is set when the reference couldn't be checked, for example, because of missing permissions
+	Err error
+	ExternalReference
+	Found bool
+}
+
+// GetExternalReferences returns the resources of the cluster referenced by the services and volumes of the stack
+func GetExternalReferences(s *model.Stack) []ExternalReference {
+	result := []ExternalReference{}
+	for name, volume := range s.Volumes {
+		if volume != nil && volume.Class != "" {
+			result = append(result, ExternalReference{
+				Kind:  storageClassReference,
+				Name:  volume.Class,
+				Field: fmt.Sprintf("volumes[%s].class", name),
+			})
+		}
+	}
+	for name, svc := range s.Services {
+		if svc.Resources != nil && svc.Resources.Requests.Storage.Class != "" {
+			result = append(result, ExternalReference{
+				Kind:  storageClassReference,
+				Name:  svc.Resources.Requests.Storage.Class,
+				Field: fmt.Sprintf("services[%s].resources.storage.class", name),
+			})
+		}
+		if svc.ServiceAccount != "" && svc.ServiceAccount != defaultServiceAccount && !s.IsServiceAccountCreated(svc.ServiceAccount) {
+			result = append(result, ExternalReference{
+				Kind:  serviceAccountReference,
+				Name:  svc.ServiceAccount,
+				Field: fmt.Sprintf("services[%s].x-okteto-serviceaccount", name),
+			})
+		}
+		if svc.PriorityClassName != "" {
+			result = append(result, ExternalReference{
+				Kind:  priorityClassReference,
+				Name:  svc.PriorityClassName,
+				Field: fmt.Sprintf("services[%s].x-okteto-priority-class", name),
+			})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Field < result[j].Field
+	})
+	return result
+}
+
+// CheckExternalReferences checks if the external references of the stack exist in the cluster
+func CheckExternalReferences(ctx context.Context, s *model.Stack, namespace string, c kubernetes.Interface) []ExternalReferenceResult {
+	type key struct{ kind, name string }
+	checked := map[key]ExternalReferenceResult{}
+
+	results := []ExternalReferenceResult{}
+	for _, ref := range GetExternalReferences(s) {
+		k := key{kind: ref.Kind, name: ref.Name}
+		result, ok := checked[k]
+		if !ok {
+			result = checkExternalReference(ctx, ref, namespace, c)
+			checked[k] = result
+		}
+		result.ExternalReference = ref
+		results = append(results, result)
+	}
+	return results
+}
+
+func checkExternalReference(ctx context.Context, ref ExternalReference, namespace string, c kubernetes.Interface) ExternalReferenceResult {
+	var err error
+	switch ref.Kind {
+	case storageClassReference:
+		_, err = c.StorageV1().StorageClasses().Get(ctx, ref.Name, metav1.GetOptions{})
+	case serviceAccountReference:
+		_, err = c.CoreV1().ServiceAccounts(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	case priorityClassReference:
+		_, err = c.SchedulingV1().PriorityClasses().Get(ctx, ref.Name, metav1.GetOptions{})
+	}
+
+	switch {
+	case err == nil:
+		return ExternalReferenceResult{ExternalReference: ref, Found: true}
+	case k8sErrors.IsNotFound(err):
+		return ExternalReferenceResult{ExternalReference: ref}
+	default:
+		oktetoLog.Infof("could not check %s '%s': %s", ref.Kind, ref.Name, err)
+		return ExternalReferenceResult{ExternalReference: ref, Err: err}
+	}
+}
+
+// ValidateAgainstCluster checks the external references of the stack and fails if any of them is missing in the cluster
+func ValidateAgainstCluster(ctx context.Context, s *model.Stack, namespace string, c kubernetes.Interface) error {
+	results := CheckExternalReferences(ctx, s, namespace, c)
+	if len(results) == 0 {
+		oktetoLog.Information("The stack doesn't reference resources of the cluster")
+		return nil
+	}
+
+	missing := []string{}
+	unchecked := 0
+	for _, r := range results {
+		switch {
+		case r.Err != nil:
+			unchecked++
+			oktetoLog.Warning("Could not check the %s '%s' referenced by '%s': %s", r.Kind, r.Name, r.Field, r.Err)
+		case !r.Found:
+			missing = append(missing, fmt.Sprintf("'%s': %s '%s' not found", r.Field, r.Kind, r.Name))
+		}
+	}
+	if len(missing) > 0 {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("the stack references resources missing in the cluster:\n    - %s", strings.Join(missing, "\n    - ")),
+			Hint: fmt.Sprintf("Create the missing resources in the namespace '%s' or fix the references of your compose file", namespace),
+		}
+	}
+	if unchecked > 0 {
+		oktetoLog.Information("%d resources of the cluster referenced by the stack couldn't be checked", unchecked)
+		return nil
+	}
+	oktetoLog.Success("The resources of the cluster referenced by the stack exist")
+	return nil
+}
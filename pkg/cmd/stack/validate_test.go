@@ -0,0 +1,168 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stack
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+)
+
+func newExternalReferencesStack() *model.Stack {
+	return &model.Stack{
+		Name: "stack",
+		Volumes: map[string]*model.VolumeSpec{
+			"data":  {Class: "standard"},
+			"cache": {Class: "fast-ssd"},
+			"logs":  {},
+		},
+		Services: map[string]*model.Service{
+			"api": {
+				ServiceAccount:    "api-sa",
+				PriorityClassName: "high-priority",
+				Resources: &model.StackResources{
+					Requests: model.ServiceResources{Storage: model.StorageResource{Class: "standard"}},
+				},
+			},
+			"worker": {
+				ServiceAccount:    "worker-sa",
+				PriorityClassName: "low-priority",
+			},
+			"db": {
+				ServiceAccount:       "db-sa",
+				CreateServiceAccount: true,
+			},
+			"frontend": {
+				ServiceAccount: "default",
+			},
+		},
+	}
+}
+
+func TestGetExternalReferences(t *testing.T) {
+	expected := []ExternalReference{
+		{Kind: storageClassReference, Name: "standard", Field: "services[api].resources.storage.class"},
+		{Kind: priorityClassReference, Name: "high-priority", Field: "services[api].x-okteto-priority-class"},
+		{Kind: serviceAccountReference, Name: "api-sa", Field: "services[api].x-okteto-serviceaccount"},
+		{Kind: priorityClassReference, Name: "low-priority", Field: "services[worker].x-okteto-priority-class"},
+		{Kind: serviceAccountReference, Name: "worker-sa", Field: "services[worker].x-okteto-serviceaccount"},
+		{Kind: storageClassReference, Name: "fast-ssd", Field: "volumes[cache].class"},
+		{Kind: storageClassReference, Name: "standard", Field: "volumes[data].class"},
+	}
+	assert.Equal(t, expected, GetExternalReferences(newExternalReferencesStack()))
+}
+
+func TestCheckExternalReferences(t *testing.T) {
+	c := fake.NewSimpleClientset(
+		&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "standard"}},
+		&apiv1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "api-sa", Namespace: "ns"}},
+		&apiv1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "worker-sa", Namespace: "other"}},
+		&schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: "high-priority"}},
+	)
+	gets := 0
+	c.PrependReactor("get", "*", func(k8sTesting.Action) (bool, runtime.Object, error) {
+		gets++
+		return false, nil, nil
+	})
+
+	results := CheckExternalReferences(context.Background(), newExternalReferencesStack(), "ns", c)
+
+	found := map[string]bool{}
+	for _, r := range results {
+		require.NoError(t, r.Err)
+		found[r.Field] = r.Found
+	}
+	assert.Equal(t, map[string]bool{
+		"services[api].x-okteto-priority-class":    true,
+		"services[api].resources.storage.class":    true,
+		"services[api].x-okteto-serviceaccount":    true,
+		"services[worker].x-okteto-priority-class": false,
+		"services[worker].x-okteto-serviceaccount": false,
+		"volumes[cache].class":                     false,
+		"volumes[data].class":                      true,
+	}, found)
+	// the storage class 'standard' is referenced twice but checked once
+	assert.Equal(t, 6, gets)
+}
+
+func TestValidateAgainstCluster(t *testing.T) {
+	tests := []struct {
+		name        string
+		objects     []runtime.Object
+		expectedErr bool
+	}{
+		{
+			name: "all-found",
+			objects: []runtime.Object{
+				&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "standard"}},
+				&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "fast-ssd"}},
+				&apiv1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "api-sa", Namespace: "ns"}},
+				&apiv1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "worker-sa", Namespace: "ns"}},
+				&schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: "high-priority"}},
+				&schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: "low-priority"}},
+			},
+		},
+		{
+			name: "missing",
+			objects: []runtime.Object{
+				&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "standard"}},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewSimpleClientset(tt.objects...)
+			err := ValidateAgainstCluster(context.Background(), newExternalReferencesStack(), "ns", c)
+			if !tt.expectedErr {
+				require.NoError(t, err)
+				return
+			}
+			var uErr oktetoErrors.UserError
+			require.True(t, errors.As(err, &uErr))
+			assert.Contains(t, uErr.E.Error(), "'volumes[cache].class': storage class 'fast-ssd' not found")
+			assert.Contains(t, uErr.E.Error(), "'services[worker].x-okteto-serviceaccount': service account 'worker-sa' not found")
+			assert.NotContains(t, uErr.E.Error(), "'volumes[data].class'")
+		})
+	}
+}
+
+func TestValidateAgainstClusterCheckErrors(t *testing.T) {
+	c := fake.NewSimpleClientset()
+	c.PrependReactor("get", "*", func(k8sTesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("forbidden")
+	})
+	s := &model.Stack{
+		Services: map[string]*model.Service{"api": {PriorityClassName: "high-priority"}},
+	}
+
+	results := CheckExternalReferences(context.Background(), s, "ns", c)
+	require.Len(t, results, 1)
+	assert.Error(t, results[0].Err)
+	assert.False(t, results[0].Found)
+	assert.NoError(t, ValidateAgainstCluster(context.Background(), s, "ns", c))
+}
@@ -18,7 +18,6 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
-	"strings"
 
 	"github.com/okteto/okteto/pkg/constants"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
@@ -243,22 +242,29 @@ func homedirWindows() (string, error) {
 	return home, nil
 }
 
-// GetKubeconfigPath returns the path to the kubeconfig file, taking the KUBECONFIG env var into consideration
+// GetKubeconfigPath returns the paths to the kubeconfig files, taking the KUBECONFIG env var into consideration.
+// The paths are returned in precedence order, as client-go loads them
 func GetKubeconfigPath() []string {
 	home := GetUserHomeDir()
 	kubeconfig := []string{filepath.Join(home, ".kube", "config")}
-	kubeconfigEnv := os.Getenv(constants.KubeConfigEnvVar)
-	if len(kubeconfigEnv) > 0 {
-		kubeconfig = splitKubeConfigEnv(kubeconfigEnv)
+	if paths := splitKubeConfigEnv(os.Getenv(constants.KubeConfigEnvVar)); len(paths) > 0 {
+		kubeconfig = paths
 	}
 	return kubeconfig
 }
 
+// splitKubeConfigEnv splits the KUBECONFIG env var with the path list separator of the OS, ignoring empty and duplicated paths
 func splitKubeConfigEnv(value string) []string {
-	if runtime.GOOS == "windows" {
-		return strings.Split(value, ";")
+	result := []string{}
+	seen := map[string]bool{}
+	for _, path := range filepath.SplitList(value) {
+		if path == "" || seen[path] {
+			continue
+		}
+		seen[path] = true
+		result = append(result, path)
 	}
-	return strings.Split(value, ":")
+	return result
 }
 
 func GetTokenPathDeprecated() string {
@@ -16,9 +16,11 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/okteto/okteto/pkg/constants"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestGetUserHomeDir(t *testing.T) {
@@ -135,3 +137,17 @@ func TestGetAppHome(t *testing.T) {
 		t.Errorf("expected %s, got %s", expected, got)
 	}
 }
+
+func TestGetKubeconfigPath(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(constants.OktetoHomeEnvVar, dir)
+
+	t.Setenv(constants.KubeConfigEnvVar, "")
+	assert.Equal(t, []string{filepath.Join(dir, ".kube", "config")}, GetKubeconfigPath())
+
+	first := filepath.Join(dir, "config")
+	second := filepath.Join(dir, "work.yaml")
+	value := strings.Join([]string{first, "", second, first}, string(filepath.ListSeparator))
+	t.Setenv(constants.KubeConfigEnvVar, value)
+	assert.Equal(t, []string{first, second}, GetKubeconfigPath())
+}
@@ -40,6 +40,10 @@ const (
 	// Kept for backward compatibility
 	oktetoBinEnvVar = "OKTETO_BIN"
 
+	// oktetoBinImageEnvVar defines the image of the init container that copies the okteto binaries to the development containers.
+	// It defaults to the okteto cli image and allows pulling it from a mirror in air-gapped clusters
+	oktetoBinImageEnvVar = "OKTETO_BIN_IMAGE"
+
 	// oktetoDeployRemoteImageEnvVar defines okteto cli image used to deploy an environment remotely (deprecated, use OKTETO_CLI_IMAGE instead)
 	// Kept for backward compatibility
 	oktetoDeployRemoteImageEnvVar = "OKTETO_REMOTE_CLI_IMAGE"
@@ -119,3 +123,12 @@ func (c *ImageConfig) GetCliImage() string {
 	cachedCliImage = fmt.Sprintf(oktetoCLIImageTemplate, c.cliRepository, "master")
 	return cachedCliImage
 }
+
+// GetBinImage returns the image of the init container that copies the okteto binaries to the development containers
+func (c *ImageConfig) GetBinImage() string {
+	if binImage := c.getEnv(oktetoBinImageEnvVar); binImage != "" {
+		c.ioCtrl.Infof("using okteto bin image (from OKTETO_BIN_IMAGE): %s", binImage)
+		return binImage
+	}
+	return c.GetCliImage()
+}
@@ -166,3 +166,42 @@ func TestClusterCliRepositoryOverridesDefault(t *testing.T) {
 		})
 	}
 }
+
+func TestGetBinImage(t *testing.T) {
+	testCases := []struct {
+		envVars       map[string]string
+		name          string
+		expectedImage string
+	}{
+		{
+			name: "OKTETO_BIN_IMAGE is set",
+			envVars: map[string]string{
+				oktetoBinImageEnvVar: "mirror.mycorp.com/okteto/okteto:1.2.3",
+				oktetoCLIImageEnvVar: "cliimage:tag",
+			},
+			expectedImage: "mirror.mycorp.com/okteto/okteto:1.2.3",
+		},
+		{
+			name: "defaults to the cli image",
+			envVars: map[string]string{
+				oktetoCLIImageEnvVar: "cliimage:tag",
+			},
+			expectedImage: "cliimage:tag",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cachedCliImage = ""
+			c := &ImageConfig{
+				ioCtrl: io.NewIOController(),
+				getEnv: func(s string) string {
+					return tc.envVars[s]
+				},
+				cliRepository: "ghcr.io/okteto/okteto",
+			}
+			assert.Equal(t, tc.expectedImage, c.GetBinImage())
+		})
+	}
+	cachedCliImage = ""
+}
@@ -33,6 +33,22 @@ const (
 	// with the okteto credentials
 	OktetoSkipConfigCredentialsUpdate = "OKTETO_SKIP_CONFIG_CREDENTIALS_UPDATE"
 
+	// OktetoSkipNamespacePreflightEnvVar skips the namespace existence and permissions checks done before up and deploy
+	OktetoSkipNamespacePreflightEnvVar = "OKTETO_SKIP_NAMESPACE_PREFLIGHT"
+
+	// OktetoNonInteractiveEnvVar makes every prompt take its default answer or fail when there is no safe default
+	OktetoNonInteractiveEnvVar = "OKTETO_NON_INTERACTIVE"
+
+	// OktetoPlatformEnvVar forces the platform of the current context, 'okteto' or 'vanilla', instead of detecting it
+	OktetoPlatformEnvVar = "OKTETO_PLATFORM"
+
+	// OktetoNoManifestOverrideEnvVar ignores the override of the okteto manifest, like 'okteto.override.yml'
+	OktetoNoManifestOverrideEnvVar = "OKTETO_NO_MANIFEST_OVERRIDE"
+
+	// OktetoAutoMaskSecretsEnvVar treats the environment variables whose name looks like a credential
+	// (TOKEN, PASSWORD, KEY or SECRET) as secrets, masking their values in the logs
+	OktetoAutoMaskSecretsEnvVar = "OKTETO_AUTO_MASK_SECRETS"
+
 	// OktetoHomeEnvVar defines the path of okteto folder
 	OktetoHomeEnvVar = "OKTETO_HOME"
 
@@ -74,6 +90,9 @@ const (
 	// OktetoForceRemote defines whether a deploy/destroy operation is to be executed remotely
 	OktetoForceRemote = "OKTETO_FORCE_REMOTE"
 
+	// OktetoDisableCommandPTYEnvVar disables the pseudo-terminal allocated to the deploy commands when running in a terminal
+	OktetoDisableCommandPTYEnvVar = "OKTETO_DISABLE_COMMAND_PTY"
+
 	// OktetoTlsCertBase64EnvVar defines the TLS certificate in base64 for --remote
 	OktetoTlsCertBase64EnvVar = "OKTETO_TLS_CERT_BASE64"
 
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/okteto/okteto/cmd/utils/executor"
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
 	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/devenvironment"
 	"github.com/okteto/okteto/pkg/divert"
@@ -51,6 +52,7 @@ type ProxyInterface interface {
 	SetName(name string)
 	SetDivert(driver divert.Driver)
 	InitTranslator()
+	GetAppliedResources() []pipeline.AppliedResource
 }
 
 // KubeConfigHandler defines the operations to handle the kubeconfig file
@@ -65,6 +67,7 @@ type KubeConfigHandler interface {
 type ConfigMapHandler interface {
 	UpdateEnvsFromCommands(context.Context, string, string, []string) error
 	AddPhaseDuration(context.Context, string, string, string, time.Duration) error
+	UpdateAppliedResources(context.Context, string, string, []pipeline.AppliedResource) error
 }
 
 // ExternalResourceInterface defines the operations to work with external resources
@@ -285,6 +288,11 @@ func (r *DeployRunner) RunDeploy(ctx context.Context, params DeployParameters) e
 
 	oktetoLog.EnableMasking()
 	err = r.runCommandsSection(ctx, params)
+
+	// The resources applied before a failure are also stored, so destroy can delete them
+	if errInventory := r.ConfigMapHandler.UpdateAppliedResources(ctx, params.Name, params.Namespace, r.Proxy.GetAppliedResources()); errInventory != nil {
+		oktetoLog.Infof("could not store the resources applied through the proxy: %s", errInventory)
+	}
 	return err
 }
 
@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/okteto/okteto/internal/test"
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
 	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/divert"
 	"github.com/okteto/okteto/pkg/externalresource"
@@ -35,6 +36,7 @@ import (
 type fakeCmapHandler struct {
 	errUpdatingWithEnvs error
 	errAddingPhase      error
+	appliedResources    []pipeline.AppliedResource
 }
 
 func (f *fakeCmapHandler) UpdateEnvsFromCommands(context.Context, string, string, []string) error {
@@ -45,6 +47,11 @@ func (f *fakeCmapHandler) AddPhaseDuration(context.Context, string, string, stri
 	return f.errAddingPhase
 }
 
+func (f *fakeCmapHandler) UpdateAppliedResources(_ context.Context, _, _ string, resources []pipeline.AppliedResource) error {
+	f.appliedResources = append(f.appliedResources, resources...)
+	return nil
+}
+
 type fakeKubeconfigHandler struct {
 	mock.Mock
 }
@@ -91,6 +98,11 @@ func (f *fakeProxy) SetDivert(driver divert.Driver) {
 }
 func (f *fakeProxy) InitTranslator() {}
 
+func (f *fakeProxy) GetAppliedResources() []pipeline.AppliedResource {
+	args := f.Called()
+	return args.Get(0).([]pipeline.AppliedResource)
+}
+
 type fakeExecutor struct {
 	mock.Mock
 }
@@ -208,16 +220,19 @@ func TestRunDeployWithEmptyDeployable(t *testing.T) {
 	proxy.On("Start").Return().Once()
 	proxy.On("Shutdown", mock.Anything).Return(nil).Once()
 	proxy.On("SetDivert", mock.Anything).Return().Once()
+	applied := []pipeline.AppliedResource{{Version: "v1", Resource: "configmaps", Namespace: "test", Name: "cfg"}}
+	proxy.On("GetAppliedResources").Return(applied).Once()
 
 	kubeconfigHandler.On("Modify", 80, "fake-token", "temp-kubeconfig").Return(nil)
 
+	cmapHandler := &fakeCmapHandler{}
 	r := DeployRunner{
 		K8sClientProvider:  k8sProvider,
 		Proxy:              proxy,
 		Kubeconfig:         kubeconfigHandler,
 		TempKubeconfigFile: "temp-kubeconfig",
 		Fs:                 afero.NewMemMapFs(),
-		ConfigMapHandler:   &fakeCmapHandler{},
+		ConfigMapHandler:   cmapHandler,
 		IOCtrl:             io.NewIOController(),
 	}
 
@@ -236,6 +251,7 @@ func TestRunDeployWithEmptyDeployable(t *testing.T) {
 	err := r.RunDeploy(context.Background(), params)
 
 	require.NoError(t, err)
+	assert.Equal(t, applied, cmapHandler.appliedResources)
 
 	proxy.AssertExpectations(t)
 	kubeconfigHandler.AssertExpectations(t)
@@ -0,0 +1,165 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployable
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ignoredAPIGroups are the API groups whose requests never create resources in the namespace,
+// like the access reviews sent by kubectl and helm
+var ignoredAPIGroups = map[string]bool{
+	"authorization.k8s.io":  true,
+	"authentication.k8s.io": true,
+}
+
+// inventory records the resources applied and deleted through the proxy during a deploy
+type inventory struct {
+	resources map[string]pipeline.AppliedResource
+	mu        sync.Mutex
+}
+
+func newInventory() *inventory {
+	return &inventory{
+		resources: map[string]pipeline.AppliedResource{},
+	}
+}
+
+// record registers the last operation done over a resource
+func (i *inventory) record(r pipeline.AppliedResource) {
+	if r.Name == "" {
+		return
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if previous, ok := i.resources[r.Key()]; ok && r.Kind == "" {
+		r.Kind = previous.Kind
+	}
+	i.resources[r.Key()] = r
+}
+
+// list returns the recorded resources sorted by group, resource, namespace and name
+func (i *inventory) list() []pipeline.AppliedResource {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	result := make([]pipeline.AppliedResource, 0, len(i.resources))
+	for _, r := range i.resources {
+		result = append(result, r)
+	}
+	sort.Slice(result, func(a, b int) bool {
+		return result[a].Key() < result[b].Key()
+	})
+	return result
+}
+
+// parseResourceRequest returns the resource modified by a request to the Kubernetes API.
+// It returns false for requests that don't modify a single resource: reads, subresources,
+// collections, dry runs and access reviews.
+// The name of the resource created by a POST request is not part of the path, so it is empty
+// until it is read from the request body
+func parseResourceRequest(r *http.Request) (pipeline.AppliedResource, bool) {
+	result := pipeline.AppliedResource{}
+	switch r.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return result, false
+	}
+	if r.URL.Query().Has("dryRun") {
+		return result, false
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	var rest []string
+	switch {
+	case len(parts) >= 3 && parts[0] == "api":
+		result.Version = parts[1]
+		rest = parts[2:]
+	case len(parts) >= 4 && parts[0] == "apis":
+		result.Group = parts[1]
+		result.Version = parts[2]
+		rest = parts[3:]
+	default:
+		return result, false
+	}
+	if ignoredAPIGroups[result.Group] {
+		return result, false
+	}
+
+	if len(rest) >= 3 && rest[0] == "namespaces" {
+		result.Namespace = rest[1]
+		rest = rest[2:]
+	}
+	switch len(rest) {
+	case 1:
+		// Only creations are sent to the collection path
+		if r.Method != http.MethodPost {
+			return result, false
+		}
+		result.Resource = rest[0]
+	case 2:
+		if r.Method == http.MethodPost {
+			return result, false
+		}
+		result.Resource = rest[0]
+		result.Name = rest[1]
+	default:
+		return result, false
+	}
+	result.Deleted = r.Method == http.MethodDelete
+	return result, true
+}
+
+// setObjectInfo completes the resource with the kind and, for creations, the name of the object sent in the request
+func setObjectInfo(resource *pipeline.AppliedResource, obj runtime.Object) {
+	if kind := obj.GetObjectKind().GroupVersionKind().Kind; kind != "" {
+		resource.Kind = kind
+	}
+	if resource.Name != "" {
+		return
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		oktetoLog.Infof("could not read the name of the %s created through the proxy: %s", resource.Resource, err)
+		return
+	}
+	resource.Name = accessor.GetName()
+}
+
+// statusRecorder keeps the status code of the response sent by the Kubernetes API
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	s.status = status
+	s.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap allows the reverse proxy to flush and hijack the original response writer
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
+
+func (s *statusRecorder) succeeded() bool {
+	return s.status >= http.StatusOK && s.status < http.StatusMultipleChoices
+}
@@ -0,0 +1,235 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployable
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+)
+
+// recordedInteraction is a request sent by kubectl or helm to the proxy and the status returned by the cluster
+type recordedInteraction struct {
+	method      string
+	path        string
+	contentType string
+	body        string
+	status      int
+}
+
+const (
+	configMapJSON = `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"settings","namespace":"ns"},"data":{"key":"value"}}`
+
+	deploymentApplyYAML = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: api
+  namespace: ns
+`
+
+	crdApplyJSON = `{"apiVersion":"example.com/v1","kind":"Widget","metadata":{"name":"gadget","namespace":"ns"}}`
+)
+
+// replayInteractions sends the interactions through the proxy handler to a fake cluster that answers
+// each request with its recorded status, and returns the resources recorded by the proxy
+func replayInteractions(t *testing.T, interactions []recordedInteraction) []pipeline.AppliedResource {
+	t.Helper()
+
+	statuses := map[string]int{}
+	for _, i := range interactions {
+		path, _, _ := strings.Cut(i.path, "?")
+		statuses[i.method+" "+path] = i.status
+	}
+	cluster := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(statuses[r.Method+" "+r.URL.Path])
+	}))
+	defer cluster.Close()
+
+	ph := &proxyHandler{
+		inventory:  newInventory(),
+		translator: newTranslator("test", nil),
+	}
+	handler, err := ph.getProxyHandler("token", &rest.Config{
+		Host:            cluster.URL,
+		TLSClientConfig: rest.TLSClientConfig{Insecure: true},
+	})
+	require.NoError(t, err)
+
+	for _, i := range interactions {
+		req := httptest.NewRequest(i.method, i.path, strings.NewReader(i.body))
+		req.Header.Set("Authorization", "Bearer token")
+		if i.contentType != "" {
+			req.Header.Set("Content-Type", i.contentType)
+		}
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, req)
+		require.Equal(t, i.status, rw.Code, "%s %s", i.method, i.path)
+	}
+	return ph.inventory.list()
+}
+
+func TestProxyRecordsAppliedResources(t *testing.T) {
+	tests := []struct {
+		name         string
+		interactions []recordedInteraction
+		expected     []pipeline.AppliedResource
+	}{
+		{
+			name: "kubectl create",
+			interactions: []recordedInteraction{
+				{method: "GET", path: "/api/v1/namespaces/ns/configmaps/settings", status: http.StatusNotFound},
+				{method: "POST", path: "/api/v1/namespaces/ns/configmaps", contentType: "application/json", body: configMapJSON, status: http.StatusCreated},
+			},
+			expected: []pipeline.AppliedResource{
+				{Version: "v1", Resource: "configmaps", Kind: "ConfigMap", Namespace: "ns", Name: "settings"},
+			},
+		},
+		{
+			name: "server-side apply",
+			interactions: []recordedInteraction{
+				{method: "PATCH", path: "/apis/apps/v1/namespaces/ns/deployments/api", contentType: "application/apply-patch+yaml", body: deploymentApplyYAML, status: http.StatusOK},
+				{method: "PATCH", path: "/apis/example.com/v1/namespaces/ns/widgets/gadget", contentType: "application/apply-patch+yaml", body: crdApplyJSON, status: http.StatusCreated},
+			},
+			expected: []pipeline.AppliedResource{
+				{Group: "apps", Version: "v1", Resource: "deployments", Kind: "Deployment", Namespace: "ns", Name: "api"},
+				{Group: "example.com", Version: "v1", Resource: "widgets", Kind: "Widget", Namespace: "ns", Name: "gadget"},
+			},
+		},
+		{
+			name: "kubectl apply of an existing resource",
+			interactions: []recordedInteraction{
+				{method: "GET", path: "/apis/apps/v1/namespaces/ns/deployments/api", status: http.StatusOK},
+				{method: "PATCH", path: "/apis/apps/v1/namespaces/ns/deployments/api", contentType: "application/strategic-merge-patch+json", body: `{"spec":{"replicas":2}}`, status: http.StatusOK},
+			},
+			expected: []pipeline.AppliedResource{
+				{Group: "apps", Version: "v1", Resource: "deployments", Namespace: "ns", Name: "api"},
+			},
+		},
+		{
+			name: "helm upgrade deleting a resource",
+			interactions: []recordedInteraction{
+				{method: "POST", path: "/api/v1/namespaces/ns/configmaps", contentType: "application/json", body: configMapJSON, status: http.StatusCreated},
+				{method: "PATCH", path: "/apis/apps/v1/namespaces/ns/deployments/api", contentType: "application/apply-patch+yaml", body: deploymentApplyYAML, status: http.StatusOK},
+				{method: "DELETE", path: "/api/v1/namespaces/ns/configmaps/settings", status: http.StatusOK},
+			},
+			expected: []pipeline.AppliedResource{
+				{Version: "v1", Resource: "configmaps", Kind: "ConfigMap", Namespace: "ns", Name: "settings", Deleted: true},
+				{Group: "apps", Version: "v1", Resource: "deployments", Kind: "Deployment", Namespace: "ns", Name: "api"},
+			},
+		},
+		{
+			name: "failed requests",
+			interactions: []recordedInteraction{
+				{method: "POST", path: "/api/v1/namespaces/ns/configmaps", contentType: "application/json", body: configMapJSON, status: http.StatusConflict},
+				{method: "PATCH", path: "/apis/apps/v1/namespaces/ns/deployments/api", contentType: "application/apply-patch+yaml", body: deploymentApplyYAML, status: http.StatusForbidden},
+			},
+			expected: []pipeline.AppliedResource{},
+		},
+		{
+			name: "requests that don't modify resources",
+			interactions: []recordedInteraction{
+				{method: "POST", path: "/apis/authorization.k8s.io/v1/selfsubjectaccessreviews", contentType: "application/json", body: `{"apiVersion":"authorization.k8s.io/v1","kind":"SelfSubjectAccessReview","spec":{}}`, status: http.StatusCreated},
+				{method: "PATCH", path: "/apis/apps/v1/namespaces/ns/deployments/api/scale", contentType: "application/merge-patch+json", body: `{"spec":{"replicas":3}}`, status: http.StatusOK},
+				{method: "PATCH", path: "/apis/apps/v1/namespaces/ns/deployments/api?dryRun=All", contentType: "application/apply-patch+yaml", body: deploymentApplyYAML, status: http.StatusOK},
+				{method: "DELETE", path: "/api/v1/namespaces/ns/configmaps", status: http.StatusOK},
+				{method: "GET", path: "/api/v1/namespaces/ns/configmaps", status: http.StatusOK},
+			},
+			expected: []pipeline.AppliedResource{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, replayInteractions(t, tt.interactions))
+		})
+	}
+}
+
+func TestParseResourceRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		expected pipeline.AppliedResource
+		ok       bool
+	}{
+		{
+			name:     "namespaced core resource",
+			method:   "PUT",
+			path:     "/api/v1/namespaces/ns/services/api",
+			expected: pipeline.AppliedResource{Version: "v1", Resource: "services", Namespace: "ns", Name: "api"},
+			ok:       true,
+		},
+		{
+			name:     "creation of a namespaced resource",
+			method:   "POST",
+			path:     "/apis/batch/v1/namespaces/ns/jobs",
+			expected: pipeline.AppliedResource{Group: "batch", Version: "v1", Resource: "jobs", Namespace: "ns"},
+			ok:       true,
+		},
+		{
+			name:     "cluster-scoped resource",
+			method:   "DELETE",
+			path:     "/apis/rbac.authorization.k8s.io/v1/clusterroles/reader",
+			expected: pipeline.AppliedResource{Group: "rbac.authorization.k8s.io", Version: "v1", Resource: "clusterroles", Name: "reader", Deleted: true},
+			ok:       true,
+		},
+		{
+			name:     "namespace",
+			method:   "PATCH",
+			path:     "/api/v1/namespaces/ns",
+			expected: pipeline.AppliedResource{Version: "v1", Resource: "namespaces", Name: "ns"},
+			ok:       true,
+		},
+		{
+			name:   "read",
+			method: "GET",
+			path:   "/api/v1/namespaces/ns/pods/api",
+		},
+		{
+			name:   "subresource",
+			method: "POST",
+			path:   "/api/v1/namespaces/ns/pods/api/exec",
+		},
+		{
+			name:   "namespace subresource",
+			method: "PUT",
+			path:   "/api/v1/namespaces/ns/finalize",
+		},
+		{
+			name:   "discovery",
+			method: "POST",
+			path:   "/apis",
+		},
+		{
+			name:   "token review",
+			method: "POST",
+			path:   "/apis/authentication.k8s.io/v1/tokenreviews",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := parseResourceRequest(httptest.NewRequest(tt.method, tt.path, nil))
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
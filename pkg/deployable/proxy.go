@@ -29,6 +29,7 @@ import (
 	"syscall"
 
 	"github.com/google/uuid"
+	"github.com/okteto/okteto/pkg/cmd/pipeline"
 	"github.com/okteto/okteto/pkg/divert"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
@@ -62,6 +63,7 @@ type proxyHandler struct {
 	// Name is sanitized version of the pipeline name
 	Name       string
 	translator *Translator
+	inventory  *inventory
 }
 
 // NewProxy creates a new proxy
@@ -89,7 +91,9 @@ func NewProxy(kubeconfig KubeConfigHandler, portGetter PortGetterFunc) (*Proxy,
 		return nil, err
 	}
 
-	ph := &proxyHandler{}
+	ph := &proxyHandler{
+		inventory: newInventory(),
+	}
 	handler, err := ph.getProxyHandler(sessionToken, clusterConfig)
 	if err != nil {
 		oktetoLog.Errorf("could not configure local proxy: %s", err)
@@ -183,6 +187,11 @@ func (p *Proxy) InitTranslator() {
 	p.proxyHandler.translator = newTranslator(p.proxyHandler.Name, p.proxyHandler.DivertDriver)
 }
 
+// GetAppliedResources returns the resources created, modified or deleted through the proxy
+func (p *Proxy) GetAppliedResources() []pipeline.AppliedResource {
+	return p.proxyHandler.inventory.list()
+}
+
 // shouldInterceptRequest returns true if the request should be intercepted to inject labels and transformations.
 // PUT and POST requests are always intercepted.
 // PATCH requests are only intercepted for server-side apply operations to avoid issues with partial objects.
@@ -292,6 +301,21 @@ func (ph *proxyHandler) getProxyHandler(token string, clusterConfig *rest.Config
 		}
 
 		r.Host = destinationURL.Host
+
+		// Record the resources modified by successful requests, so destroy can delete exactly what was deployed
+		applied, isResourceRequest := parseResourceRequest(r)
+		forward := func() {
+			if !isResourceRequest {
+				reverseProxy.ServeHTTP(rw, r)
+				return
+			}
+			recorder := &statusRecorder{ResponseWriter: rw, status: http.StatusOK}
+			reverseProxy.ServeHTTP(recorder, r)
+			if recorder.succeeded() {
+				ph.inventory.record(applied)
+			}
+		}
+
 		// Modify all resources updated or created to include the label.
 		if shouldInterceptRequest(r) {
 			b, err := io.ReadAll(r.Body)
@@ -302,7 +326,7 @@ func (ph *proxyHandler) getProxyHandler(token string, clusterConfig *rest.Config
 			}
 			defer r.Body.Close()
 			if len(b) == 0 {
-				reverseProxy.ServeHTTP(rw, r)
+				forward()
 				return
 			}
 
@@ -315,9 +339,10 @@ func (ph *proxyHandler) getProxyHandler(token string, clusterConfig *rest.Config
 				// Restore the request body for the reverse proxy since we already consumed it
 				r.Body = io.NopCloser(bytes.NewReader(b))
 				r.ContentLength = int64(len(b))
-				reverseProxy.ServeHTTP(rw, r)
+				forward()
 				return
 			}
+			setObjectInfo(&applied, obj)
 
 			// Modify the object (works for both typed and unstructured objects)
 			if err := ph.translator.Modify(obj); err != nil {
@@ -341,7 +366,7 @@ func (ph *proxyHandler) getProxyHandler(token string, clusterConfig *rest.Config
 		}
 
 		// Redirect request to the k8s server (based on the transport HTTP generated from the config)
-		reverseProxy.ServeHTTP(rw, r)
+		forward()
 	})
 
 	return handler, nil
@@ -40,7 +40,7 @@ type Driver interface {
 }
 
 func New(divert *model.DivertDeploy, name, namespace string, c kubernetes.Interface, ioCtrl *io.Controller) (Driver, error) {
-	if !okteto.IsOkteto() {
+	if !okteto.GetCapabilities().SupportsDivert {
 		return nil, oktetoErrors.ErrDivertNotSupported
 	}
 
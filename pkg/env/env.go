@@ -16,13 +16,16 @@ package env
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/a8m/envsubst"
+	"github.com/okteto/okteto/pkg/constants"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"gopkg.in/yaml.v2"
 )
 
 type Environment []Var
@@ -59,14 +62,10 @@ func ExpandEnvIfNotEmpty(value string) (string, error) {
 }
 
 func (e *Environment) UnmarshalYAML(unmarshal func(interface{}) error) error {
-	envs := make(Environment, 0)
-	result, err := getKeyValue(unmarshal)
+	envs, err := unmarshalVars(unmarshal)
 	if err != nil {
 		return err
 	}
-	for key, value := range result {
-		envs = append(envs, Var{Name: key, Value: value})
-	}
 	sort.SliceStable(envs, func(i, j int) bool {
 		return strings.Compare(envs[i].Name, envs[j].Name) < 0
 	})
@@ -74,30 +73,125 @@ func (e *Environment) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return nil
 }
 
-func getKeyValue(unmarshal func(interface{}) error) (map[string]string, error) {
-	result := make(map[string]string)
+// OrderedEnvironment is a list of environment variables that keeps their declaration order,
+// as the variables of a container can reference the variables declared before them like '$(VAR)'
+type OrderedEnvironment Environment
 
-	var rawList []Var
-	err := unmarshal(&rawList)
-	if err == nil {
-		for _, label := range rawList {
-			result[label.Name] = label.Value
-		}
-		return result, nil
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+func (e *OrderedEnvironment) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	envs, err := unmarshalVars(unmarshal)
+	if err != nil {
+		return err
 	}
-	var rawMap map[string]string
-	err = unmarshal(&rawMap)
+	*e = OrderedEnvironment(envs)
+	return nil
+}
+
+func unmarshalVars(unmarshal func(interface{}) error) (Environment, error) {
+	envs, err := getVars(unmarshal)
 	if err != nil {
 		return nil, err
 	}
-	for key, value := range rawMap {
-		value, err = ExpandEnv(value)
-		if err != nil {
-			return nil, err
+	autoMask := LoadBoolean(constants.OktetoAutoMaskSecretsEnvVar)
+	for i := range envs {
+		if autoMask && IsSecretName(envs[i].Name) {
+			envs[i].Secret = true
+		}
+		if envs[i].Secret {
+			oktetoLog.AddSecret(envs[i].Value)
 		}
-		result[key] = value
 	}
-	return result, nil
+	return envs, nil
+}
+
+// secretNameRegex matches the names of the environment variables that usually hold credentials, like GITHUB_TOKEN or DB_PASSWORD
+var secretNameRegex = regexp.MustCompile(`(?i)(^|_)(TOKEN|PASSWORD|KEY|SECRET)S?(_|$)`)
+
+// IsSecretName returns if the name of an environment variable looks like a credential
+func IsSecretName(name string) bool {
+	return secretNameRegex.MatchString(name)
+}
+
+// varSource is the value of an environment variable in the map notation: a string, a secret value
+// or a reference to the cluster state
+type varSource struct {
+	fromService *ServiceRef
+	value       string
+	secret      bool
+}
+
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+func (v *varSource) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var raw string
+	if err := unmarshal(&raw); err == nil {
+		v.value, err = ExpandEnv(raw)
+		return err
+	}
+
+	var source struct {
+		FromService *ServiceRef `yaml:"fromService"`
+		Value       *string     `yaml:"value"`
+		Secret      bool        `yaml:"secret"`
+	}
+	if err := unmarshal(&source); err != nil {
+		return err
+	}
+	v.secret = source.Secret
+	if source.Value != nil {
+		if source.FromService != nil {
+			return fmt.Errorf("environment variables can't define both 'value' and 'fromService'")
+		}
+		var err error
+		v.value, err = ExpandEnv(*source.Value)
+		return err
+	}
+	if source.FromService == nil || source.FromService.Name == "" {
+		return fmt.Errorf("environment variables must be a string or define 'value' or 'fromService.name'")
+	}
+	if ref := source.FromService.SecretRef; ref != nil && (ref.Name == "" || ref.Key == "") {
+		return fmt.Errorf("'fromService.secretRef' must define 'name' and 'key'")
+	}
+	v.fromService = source.FromService
+	return nil
+}
+
+// getVars returns the variables of the list or the map notation in declaration order, as variables can reference
+// the variables declared before them. A variable declared more than once keeps its first position and its last value
+func getVars(unmarshal func(interface{}) error) (Environment, error) {
+	var rawList []Var
+	if err := unmarshal(&rawList); err == nil {
+		return dedupeVars(rawList), nil
+	}
+
+	var keys yaml.MapSlice
+	if err := unmarshal(&keys); err != nil {
+		return nil, err
+	}
+	var sources map[string]varSource
+	if err := unmarshal(&sources); err != nil {
+		return nil, err
+	}
+	envs := make(Environment, 0, len(keys))
+	for _, item := range keys {
+		name := fmt.Sprint(item.Key)
+		source := sources[name]
+		envs = append(envs, Var{Name: name, Value: source.value, FromService: source.fromService, Secret: source.secret})
+	}
+	return dedupeVars(envs), nil
+}
+
+func dedupeVars(vars []Var) Environment {
+	result := make(Environment, 0, len(vars))
+	indexes := map[string]int{}
+	for _, v := range vars {
+		if i, ok := indexes[v.Name]; ok {
+			result[i] = v
+			continue
+		}
+		indexes[v.Name] = len(result)
+		result = append(result, v)
+	}
+	return result
 }
 
 // LoadBoolean loads a boolean environment variable and returns it value
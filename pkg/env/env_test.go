@@ -17,6 +17,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/okteto/okteto/pkg/constants"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
 )
@@ -167,6 +169,75 @@ unit: "unit-$VALUE"`),
 				{Name: "unit", Value: "unit-test"},
 			},
 		},
+		{
+			name: "deserialized successfully with fromService",
+			yaml: []byte(`
+DATABASE_URL:
+  fromService:
+    name: postgres
+    port: 5432
+    scheme: postgres
+    secretRef:
+      name: pg-creds
+      key: password
+LOG_LEVEL: debug`),
+			expected: Environment{
+				{
+					Name: "DATABASE_URL",
+					FromService: &ServiceRef{
+						Name:      "postgres",
+						Port:      5432,
+						Scheme:    "postgres",
+						SecretRef: &SecretKeyRef{Name: "pg-creds", Key: "password"},
+					},
+				},
+				{Name: "LOG_LEVEL", Value: "debug"},
+			},
+		},
+		{
+			name: "deserialized successfully with secret",
+			yaml: []byte(`
+API_TOKEN:
+  value: "token-$VALUE"
+  secret: true
+DATABASE_URL:
+  fromService:
+    name: postgres
+  secret: true
+LOG_LEVEL: debug`),
+			expected: Environment{
+				{Name: "API_TOKEN", Value: "token-test", Secret: true},
+				{Name: "DATABASE_URL", FromService: &ServiceRef{Name: "postgres"}, Secret: true},
+				{Name: "LOG_LEVEL", Value: "debug"},
+			},
+		},
+		{
+			name: "value and fromService",
+			yaml: []byte(`
+DATABASE_URL:
+  value: postgres://postgres:5432
+  fromService:
+    name: postgres`),
+			expectedErr: true,
+		},
+		{
+			name: "fromService without name",
+			yaml: []byte(`
+DATABASE_URL:
+  fromService:
+    port: 5432`),
+			expectedErr: true,
+		},
+		{
+			name: "fromService with incomplete secretRef",
+			yaml: []byte(`
+DATABASE_URL:
+  fromService:
+    name: postgres
+    secretRef:
+      name: pg-creds`),
+			expectedErr: true,
+		},
 		{
 			name:        "fail to deserialize",
 			yaml:        []byte(`foo`),
@@ -188,6 +259,51 @@ unit: "unit-$VALUE"`),
 	}
 }
 
+func Test_Env_UnmarshalYAMLAutoMaskSecrets(t *testing.T) {
+	yamlEnv := []byte(`
+GITHUB_TOKEN: ghp-abcdef
+DB_PASSWORD: p4ssw0rd
+KEYBOARD: us
+LOG_LEVEL: debug`)
+
+	var e Environment
+	assert.NoError(t, yaml.Unmarshal(yamlEnv, &e))
+	for _, v := range e {
+		assert.False(t, v.Secret, v.Name)
+	}
+
+	t.Setenv(constants.OktetoAutoMaskSecretsEnvVar, "true")
+	e = nil
+	assert.NoError(t, yaml.Unmarshal(yamlEnv, &e))
+	assert.Equal(t, Environment{
+		{Name: "DB_PASSWORD", Value: "p4ssw0rd", Secret: true},
+		{Name: "GITHUB_TOKEN", Value: "ghp-abcdef", Secret: true},
+		{Name: "KEYBOARD", Value: "us"},
+		{Name: "LOG_LEVEL", Value: "debug"},
+	}, e)
+	assert.Equal(t, "password=***", oktetoLog.MaskSecrets("password=p4ssw0rd"))
+}
+
+func TestIsSecretName(t *testing.T) {
+	tests := map[string]bool{
+		"GITHUB_TOKEN":  true,
+		"DB_PASSWORD":   true,
+		"API_KEY":       true,
+		"AWS_KEYS":      true,
+		"CLIENT_SECRET": true,
+		"secret":        true,
+		"KEYBOARD":      false,
+		"MONKEY":        false,
+		"TOKENIZER":     false,
+		"LOG_LEVEL":     false,
+	}
+	for name, expected := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, expected, IsSecretName(name))
+		})
+	}
+}
+
 func TestLoadBoolean(t *testing.T) {
 	tests := []struct {
 		name      string
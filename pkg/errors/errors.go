@@ -44,6 +44,24 @@ func (u CommandError) Error() string {
 	return fmt.Sprintf("%s: %s", u.E.Error(), strings.ToLower(u.Reason.Error()))
 }
 
+// ExitCodeError is returned by commands whose exit code mirrors the exit code of a process run in the cluster
+type ExitCodeError struct {
+	E    error
+	Code int
+}
+
+// Error returns the error message
+func (e ExitCodeError) Error() string {
+	return e.E.Error()
+}
+
+func (e ExitCodeError) Unwrap() error {
+	return e.E
+}
+
+// TokenExpiredExitCode is the exit code of the commands that fail because the token of the okteto context has expired
+const TokenExpiredExitCode = 4
+
 // NotLoggedError is raised when the user is not logged in okteto
 type NotLoggedError struct {
 	Context string
@@ -233,6 +251,22 @@ func IsX509(err error) bool {
 	return err != nil && strings.Contains(err.Error(), "x509")
 }
 
+// IsClientCertificateExpired returns true if err is caused by the cluster rejecting the client certificate because it has expired.
+// Expired server certificates are not included, as they can't be fixed by reloading the client credentials
+func IsClientCertificateExpired(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "tls: expired certificate")
+}
+
+// IsImagePull returns true if err is caused by kubernetes failing to pull the image of a container
+func IsImagePull(err error) bool {
+	if err == nil {
+		return false
+	}
+	return strings.Contains(err.Error(), "ErrImagePull") ||
+		strings.Contains(err.Error(), "ImagePullBackOff") ||
+		strings.Contains(err.Error(), "Failed to pull image")
+}
+
 // IsNotFound returns true if err is of the type not found
 func IsNotFound(err error) bool {
 	return err != nil && (strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "doesn't exist") || strings.Contains(err.Error(), "not-found"))
@@ -14,7 +14,10 @@
 package errors
 
 import (
+	"crypto/x509"
 	"errors"
+	"fmt"
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -175,3 +178,66 @@ func TestIsTransient(t *testing.T) {
 		})
 	}
 }
+
+func TestIsClientCertificateExpired(t *testing.T) {
+	tests := []struct {
+		err      error
+		name     string
+		expected bool
+	}{
+		{
+			name:     "nil error",
+			err:      nil,
+			expected: false,
+		},
+		{
+			name:     "generic error",
+			err:      assert.AnError,
+			expected: false,
+		},
+		{
+			name:     "remote tls alert",
+			err:      fmt.Errorf("error upgrading connection: remote error: tls: expired certificate"),
+			expected: true,
+		},
+		{
+			name: "wrapped remote tls alert",
+			err: &url.Error{
+				Op:  "Get",
+				URL: "https://127.0.0.1:6443/api/v1/namespaces/test/pods",
+				Err: errors.New("remote error: tls: expired certificate"),
+			},
+			expected: true,
+		},
+		{
+			name: "expired server certificate",
+			err: &url.Error{
+				Op:  "Get",
+				URL: "https://127.0.0.1:6443/api/v1/namespaces/test/pods",
+				Err: x509.CertificateInvalidError{Reason: x509.Expired, Detail: "current time is after 2024-01-01T00:00:00Z"},
+			},
+			expected: false,
+		},
+		{
+			name:     "expired server certificate message",
+			err:      fmt.Errorf("tls: failed to verify certificate: x509: certificate has expired or is not yet valid"),
+			expected: false,
+		},
+		{
+			name:     "x509 unknown authority",
+			err:      fmt.Errorf("x509: certificate signed by unknown authority"),
+			expected: false,
+		},
+		{
+			name:     "TLS handshake timeout",
+			err:      fmt.Errorf("net/http: TLS handshake timeout"),
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsClientCertificateExpired(tt.err))
+		})
+	}
+}
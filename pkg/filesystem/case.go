@@ -0,0 +1,87 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// FindCaseCollisions returns the groups of files under root whose paths only differ in case, like 'Foo.go' and 'foo.go'.
+// They can't coexist in a case-insensitive filesystem, so they conflict when synchronized with one.
+// The paths are relative to root, and the directories in skipDirs are not scanned
+func FindCaseCollisions(root string, skipDirs []string) ([][]string, error) {
+	skip := map[string]bool{}
+	for _, d := range skipDirs {
+		skip[d] = true
+	}
+
+	result := [][]string{}
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != root && skip[d.Name()] {
+			return filepath.SkipDir
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return err
+		}
+		result = append(result, findDirCaseCollisions(root, path, entries)...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i][0] < result[j][0]
+	})
+	return result, nil
+}
+
+// findDirCaseCollisions returns the groups of entries of a directory whose names only differ in case
+func findDirCaseCollisions(root, dir string, entries []fs.DirEntry) [][]string {
+	byName := map[string][]string{}
+	for _, e := range entries {
+		key := strings.ToLower(e.Name())
+		byName[key] = append(byName[key], e.Name())
+	}
+
+	result := [][]string{}
+	for _, names := range byName {
+		if len(names) < 2 {
+			continue
+		}
+		group := make([]string, 0, len(names))
+		for _, name := range names {
+			rel, err := filepath.Rel(root, filepath.Join(dir, name))
+			if err != nil {
+				rel = filepath.Join(dir, name)
+			}
+			group = append(group, filepath.ToSlash(rel))
+		}
+		sort.Strings(group)
+		result = append(result, group)
+	}
+	return result
+}
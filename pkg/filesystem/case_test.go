@@ -0,0 +1,75 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createCaseFixture creates the files in a temp dir, skipping the test if the filesystem is case-insensitive
+func createCaseFixture(t *testing.T, files ...string) string {
+	dir := t.TempDir()
+	for _, f := range files {
+		path := filepath.Join(dir, filepath.FromSlash(f))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(f), 0600))
+	}
+	content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(files[0])))
+	require.NoError(t, err)
+	if string(content) != files[0] {
+		t.Skip("the filesystem is case-insensitive")
+	}
+	return dir
+}
+
+func TestFindCaseCollisions(t *testing.T) {
+	dir := createCaseFixture(t,
+		"Foo.go",
+		"foo.go",
+		"bar.go",
+		"pkg/README.md",
+		"pkg/readme.md",
+		"pkg/Readme.md",
+		"Pkg/main.go",
+		"docs/api.md",
+		".git/HEAD",
+		".git/head",
+	)
+
+	collisions, err := FindCaseCollisions(dir, []string{".git"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Foo.go", "foo.go"},
+		{"Pkg", "pkg"},
+		{"pkg/README.md", "pkg/Readme.md", "pkg/readme.md"},
+	}, collisions)
+}
+
+func TestFindCaseCollisionsNone(t *testing.T) {
+	dir := createCaseFixture(t, "main.go", "pkg/main.go", "pkg/Main_test.go")
+
+	collisions, err := FindCaseCollisions(dir, nil)
+	require.NoError(t, err)
+	assert.Empty(t, collisions)
+}
+
+func TestFindCaseCollisionsMissingRoot(t *testing.T) {
+	_, err := FindCaseCollisions(filepath.Join(t.TempDir(), "missing"), nil)
+	assert.Error(t, err)
+}
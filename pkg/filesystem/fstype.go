@@ -0,0 +1,87 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// ErrFilesystemTypeNotSupported is returned when the filesystem type can't be detected in the current platform
+var ErrFilesystemTypeNotSupported = errors.New("filesystem type detection is not supported")
+
+// problematicFilesystems are the filesystems known to break file synchronization, because they don't
+// report file changes, they don't keep modification times or they are synchronized by other tools
+var problematicFilesystems = map[string]bool{
+	"nfs":    true,
+	"nfs4":   true,
+	"smb":    true,
+	"smb2":   true,
+	"smbfs":  true,
+	"cifs":   true,
+	"afpfs":  true,
+	"webdav": true,
+	"9p":     true,
+	"sshfs":  true,
+	"fuse":   true,
+	"remote": true,
+}
+
+// GetFilesystemType returns the type of the filesystem where path is, like 'ext4', 'apfs', 'nfs' or 'NTFS'
+func GetFilesystemType(path string) (string, error) {
+	return getFilesystemType(path)
+}
+
+// IsProblematicFilesystem returns true if fsType is a network or virtual filesystem known to break file synchronization
+func IsProblematicFilesystem(fsType string) bool {
+	fsType = strings.ToLower(fsType)
+	if problematicFilesystems[fsType] {
+		return true
+	}
+	return strings.HasPrefix(fsType, "fuse.") || strings.HasPrefix(fsType, "nfs")
+}
+
+// GetCloudSyncProvider returns the name of the cloud storage provider synchronizing path, like 'OneDrive' or 'Dropbox', or an empty string
+func GetCloudSyncProvider(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return ""
+	}
+
+	for _, envVar := range []string{"OneDrive", "OneDriveCommercial", "OneDriveConsumer"} {
+		if root := os.Getenv(envVar); root != "" && isSubpath(filepath.Clean(root), abs) {
+			return "OneDrive"
+		}
+	}
+
+	parts := strings.Split(filepath.ToSlash(abs), "/")
+	for i, part := range parts {
+		switch {
+		case part == "OneDrive" || strings.HasPrefix(part, "OneDrive - "):
+			return "OneDrive"
+		case part == "Dropbox":
+			return "Dropbox"
+		case part == "Google Drive" || strings.HasPrefix(part, "GoogleDrive"):
+			return "Google Drive"
+		case runtime.GOOS == "darwin" && part == "CloudStorage" && i > 0 && parts[i-1] == "Library":
+			return "a cloud storage provider"
+		case runtime.GOOS == "darwin" && part == "Mobile Documents" && i > 0 && parts[i-1] == "Library":
+			return "iCloud Drive"
+		}
+	}
+	return ""
+}
@@ -0,0 +1,38 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem
+
+import (
+	"syscall"
+)
+
+func getFilesystemType(path string) (string, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return "", err
+	}
+	return darwinFilesystemTypeName(stat.Fstypename[:]), nil
+}
+
+// darwinFilesystemTypeName returns the name stored in the fstypename field of statfs, like 'apfs' or 'smbfs'
+func darwinFilesystemTypeName(fstypename []int8) string {
+	name := make([]byte, 0, len(fstypename))
+	for _, c := range fstypename {
+		if c == 0 {
+			break
+		}
+		name = append(name, byte(c))
+	}
+	return string(name)
+}
@@ -0,0 +1,36 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFilesystemTypeDarwin(t *testing.T) {
+	fsType, err := GetFilesystemType(t.TempDir())
+	require.NoError(t, err)
+	assert.NotEmpty(t, fsType)
+}
+
+func TestDarwinFilesystemTypeName(t *testing.T) {
+	name := make([]int8, 16)
+	for i, c := range "smbfs" {
+		name[i] = int8(c)
+	}
+	assert.Equal(t, "smbfs", darwinFilesystemTypeName(name))
+	assert.True(t, IsProblematicFilesystem(darwinFilesystemTypeName(name)))
+}
@@ -0,0 +1,53 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// linuxFilesystemTypes are the names of the magic numbers returned by statfs, see 'man 2 statfs'
+var linuxFilesystemTypes = map[int64]string{
+	0xEF53:     "ext4",
+	0x9123683E: "btrfs",
+	0x58465342: "xfs",
+	0x01021994: "tmpfs",
+	0x794C7630: "overlay",
+	0x2FC12FC1: "zfs",
+	0x4D44:     "vfat",
+	0x5346544E: "ntfs",
+	0x6969:     "nfs",
+	0x517B:     "smb",
+	0xFE534D42: "smb2",
+	0xFF534D42: "cifs",
+	0x01021997: "9p",
+	0x65735546: "fuse",
+	0x6A656A63: "virtiofs",
+}
+
+func getFilesystemType(path string) (string, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return "", err
+	}
+	return linuxFilesystemTypeName(int64(stat.Type)), nil
+}
+
+func linuxFilesystemTypeName(magic int64) string {
+	if name, ok := linuxFilesystemTypes[magic]; ok {
+		return name
+	}
+	return fmt.Sprintf("0x%x", magic)
+}
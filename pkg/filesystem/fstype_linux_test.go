@@ -0,0 +1,37 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFilesystemTypeLinux(t *testing.T) {
+	fsType, err := GetFilesystemType(t.TempDir())
+	require.NoError(t, err)
+	assert.NotEmpty(t, fsType)
+
+	_, err = GetFilesystemType("/does/not/exist")
+	assert.Error(t, err)
+}
+
+func TestLinuxFilesystemTypeName(t *testing.T) {
+	assert.Equal(t, "nfs", linuxFilesystemTypeName(0x6969))
+	assert.Equal(t, "cifs", linuxFilesystemTypeName(0xFF534D42))
+	assert.Equal(t, "ext4", linuxFilesystemTypeName(0xEF53))
+	assert.Equal(t, "0x1234", linuxFilesystemTypeName(0x1234))
+}
@@ -0,0 +1,61 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsProblematicFilesystem(t *testing.T) {
+	tests := []struct {
+		fsType   string
+		expected bool
+	}{
+		{fsType: "nfs", expected: true},
+		{fsType: "nfs4", expected: true},
+		{fsType: "smbfs", expected: true},
+		{fsType: "cifs", expected: true},
+		{fsType: "fuse.sshfs", expected: true},
+		{fsType: "remote", expected: true},
+		{fsType: "ext4", expected: false},
+		{fsType: "apfs", expected: false},
+		{fsType: "NTFS", expected: false},
+		{fsType: "", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fsType, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsProblematicFilesystem(tt.fsType))
+		})
+	}
+}
+
+func TestGetCloudSyncProvider(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("OneDrive", filepath.Join(root, "work"))
+	t.Setenv("OneDriveCommercial", "")
+	t.Setenv("OneDriveConsumer", "")
+
+	assert.Equal(t, "OneDrive", GetCloudSyncProvider(filepath.Join(root, "work", "api")))
+	assert.Equal(t, "OneDrive", GetCloudSyncProvider(filepath.Join(root, "OneDrive - Okteto", "api")))
+	assert.Equal(t, "Dropbox", GetCloudSyncProvider(filepath.Join(root, "Dropbox", "api")))
+	assert.Equal(t, "", GetCloudSyncProvider(filepath.Join(root, "src", "OneDriveTools")))
+	assert.Equal(t, "", GetCloudSyncProvider(filepath.Join(root, "src", "api")))
+	if runtime.GOOS == "darwin" {
+		assert.Equal(t, "iCloud Drive", GetCloudSyncProvider(filepath.Join(root, "Library", "Mobile Documents", "api")))
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem
+
+import (
+	"path/filepath"
+
+	"golang.org/x/sys/windows"
+)
+
+func getFilesystemType(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	pathPtr, err := windows.UTF16PtrFromString(abs)
+	if err != nil {
+		return "", err
+	}
+
+	volume := make([]uint16, windows.MAX_PATH+1)
+	if err := windows.GetVolumePathName(pathPtr, &volume[0], uint32(len(volume))); err != nil {
+		return "", err
+	}
+
+	// mapped network drives are reported by their drive type, as their filesystem is usually reported as NTFS
+	if windows.GetDriveType(&volume[0]) == windows.DRIVE_REMOTE {
+		return "remote", nil
+	}
+
+	fsName := make([]uint16, windows.MAX_PATH+1)
+	if err := windows.GetVolumeInformation(&volume[0], nil, 0, nil, nil, nil, &fsName[0], uint32(len(fsName))); err != nil {
+		return "", err
+	}
+	return windows.UTF16ToString(fsName), nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filesystem
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathsOverlap(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "app", "api", "src"), 0700))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "app", "frontend"), 0700))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "shared"), 0700))
+	require.NoError(t, os.Symlink(filepath.Join(root, "app", "api"), filepath.Join(root, "api-link")))
+
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		expected bool
+	}{
+		{
+			name:     "same relative path",
+			a:        "app/api",
+			b:        "./app/api/",
+			expected: true,
+		},
+		{
+			name:     "relative and absolute path",
+			a:        filepath.Join(root, "app", "api"),
+			b:        "app/api",
+			expected: true,
+		},
+		{
+			name:     "parent contains child",
+			a:        "app",
+			b:        "app/api/src",
+			expected: true,
+		},
+		{
+			name:     "child inside parent",
+			a:        "app/api/src",
+			b:        ".",
+			expected: true,
+		},
+		{
+			name:     "siblings",
+			a:        "app/api",
+			b:        "app/frontend",
+			expected: false,
+		},
+		{
+			name:     "common prefix is not containment",
+			a:        "app/api",
+			b:        "app/api-v2",
+			expected: false,
+		},
+		{
+			name:     "symlink to the same folder",
+			a:        "api-link",
+			b:        "app/api/src",
+			expected: true,
+		},
+		{
+			name:     "symlink to another folder",
+			a:        "api-link",
+			b:        "shared",
+			expected: false,
+		},
+		{
+			name:     "parent traversal",
+			a:        "app/frontend/../api",
+			b:        "app/api/src",
+			expected: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, PathsOverlap(root, tt.a, tt.b))
+		})
+	}
+}
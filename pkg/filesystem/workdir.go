@@ -45,3 +45,19 @@ func UpdateCWDtoManifestPath(manifestPath string) (string, error) {
 	updatedManifestPath := GetManifestPathFromWorkdir(manifestPath, workdir)
 	return updatedManifestPath, nil
 }
+
+// UpdateCWDtoWorkdir sets the current working directory to workdir and returns the manifest path relative to it
+func UpdateCWDtoWorkdir(manifestPath, workdir string) (string, error) {
+	absManifestPath, err := filepath.Abs(manifestPath)
+	if err != nil {
+		return "", err
+	}
+	absWorkdir, err := filepath.Abs(workdir)
+	if err != nil {
+		return "", err
+	}
+	if err := os.Chdir(absWorkdir); err != nil {
+		return "", err
+	}
+	return filepath.Rel(absWorkdir, absManifestPath)
+}
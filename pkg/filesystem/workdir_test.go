@@ -190,3 +190,66 @@ func Test_UpdateCWDtoManifestPath(t *testing.T) {
 		})
 	}
 }
+
+func Test_UpdateCWDtoWorkdir(t *testing.T) {
+	root := t.TempDir()
+	var tests = []struct {
+		name         string
+		path         string
+		workdir      string
+		expectedPath string
+	}{
+		{
+			name:         "repository root of a service manifest",
+			path:         filepath.Join(root, "services", "api", "okteto.yml"),
+			workdir:      root,
+			expectedPath: filepath.Join("services", "api", "okteto.yml"),
+		},
+		{
+			name:         "folder of the manifest",
+			path:         filepath.Join(root, "services", "api", "okteto.yml"),
+			workdir:      filepath.Join(root, "services", "api"),
+			expectedPath: "okteto.yml",
+		},
+		{
+			name:         "sibling folder",
+			path:         filepath.Join(root, "services", "api", "okteto.yml"),
+			workdir:      filepath.Join(root, "services", "web"),
+			expectedPath: filepath.Join("..", "api", "okteto.yml"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initialCWD, err := os.Getwd()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() {
+				if err := os.Chdir(initialCWD); err != nil {
+					t.Fatal(err)
+				}
+			}()
+
+			if err := os.MkdirAll(tt.workdir, 0700); err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := UpdateCWDtoWorkdir(tt.path, tt.workdir)
+			if err != nil {
+				t.Fatalf("not expected error, got %v", err)
+			}
+			assert.Equal(t, tt.expectedPath, res)
+
+			cwd, err := os.Getwd()
+			if err != nil {
+				t.Fatal(err)
+			}
+			expectedCWD, err := filepath.EvalSymlinks(tt.workdir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, expectedCWD, cwd)
+		})
+	}
+}
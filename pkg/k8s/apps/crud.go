@@ -63,11 +63,11 @@ func IsDevModeOn(app App) bool {
 	return app.ObjectMeta().Labels[constants.DevLabel] == "true" || app.ObjectMeta().Labels[model.DevCloneLabel] != ""
 }
 
-// GetRunningPodInLoop returns the dev pod for an app and loops until it success
-func GetRunningPodInLoop(ctx context.Context, dev *model.Dev, app App, c kubernetes.Interface) (*apiv1.Pod, error) {
+// GetRunningPodInLoop returns the dev pod for an app and loops until it success or the timeout expires
+func GetRunningPodInLoop(ctx context.Context, dev *model.Dev, app App, c kubernetes.Interface, timeout time.Duration) (*apiv1.Pod, error) {
 	ticker := time.NewTicker(500 * time.Millisecond)
 	start := time.Now()
-	to := start.Add(dev.Timeout.Resources)
+	to := start.Add(timeout)
 
 	for retries := 0; ; retries++ {
 		err := app.Refresh(ctx, c)
@@ -130,7 +130,9 @@ func GetTranslations(ctx context.Context, namespace, manifestName string, dev *m
 				rule.Image = devContainer.Image
 			}
 
-			if env.LoadBooleanOrDefault(model.OktetoInheritKubernetesResourcesEnvVar, false) && tr.Dev.Resources.HasEmptyResources() {
+			if tr.Dev.Resources.IsInherited() {
+				rule.Resources = getScaledResourcesFromContainer(devContainer, tr.Dev.Resources)
+			} else if env.LoadBooleanOrDefault(model.OktetoInheritKubernetesResourcesEnvVar, false) && tr.Dev.Resources.HasEmptyResources() {
 				rule.Resources = getInheritedResourcesFromContainer(devContainer)
 			}
 
@@ -0,0 +1,92 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// recreatePodsPollInterval is the time between checks of the pods of an app using the Recreate strategy
+var recreatePodsPollInterval = time.Second
+
+// IsRecreateStrategy returns if the app is a deployment that terminates all its pods before creating new ones
+func IsRecreateStrategy(app App) bool {
+	d, ok := app.(*DeploymentApp)
+	return ok && d.d.Spec.Strategy.Type == appsv1.RecreateDeploymentStrategyType
+}
+
+// GetPodReadyTimeout returns the time to wait for the pod of the development container of an app.
+// Apps using the Recreate strategy wait for their pods to terminate first, so their termination grace period is added
+func GetPodReadyTimeout(dev *model.Dev, app App) time.Duration {
+	timeout := dev.Timeout.Resources
+	if !IsRecreateStrategy(app) {
+		return timeout
+	}
+	gracePeriod := int64(apiv1.DefaultTerminationGracePeriodSeconds)
+	if app.PodSpec().TerminationGracePeriodSeconds != nil {
+		gracePeriod = *app.PodSpec().TerminationGracePeriodSeconds
+	}
+	return timeout + time.Duration(gracePeriod)*time.Second
+}
+
+// WaitForRecreatedPods waits until the pods of an app using the Recreate strategy are updated and ready
+func WaitForRecreatedPods(ctx context.Context, app App, c kubernetes.Interface, timeout time.Duration) error {
+	ticker := time.NewTicker(recreatePodsPollInterval)
+	defer ticker.Stop()
+	to := time.Now().Add(timeout)
+
+	for {
+		if err := app.Refresh(ctx, c); err != nil {
+			return err
+		}
+		d, ok := app.(*DeploymentApp)
+		if !ok || areDeploymentPodsReady(d.d) {
+			return nil
+		}
+
+		if time.Now().After(to) {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("the pods of '%s' are not ready after %s", d.d.Name, timeout),
+				Hint: fmt.Sprintf("Check the status of the pods with 'kubectl get pods -n %s'", d.d.Namespace),
+			}
+		}
+
+		select {
+		case <-ticker.C:
+			oktetoLog.Infof("waiting for the pods of '%s' to be ready", d.d.Name)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func areDeploymentPodsReady(d *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	return d.Status.ObservedGeneration >= d.Generation &&
+		d.Status.UpdatedReplicas == replicas &&
+		d.Status.ReadyReplicas == replicas &&
+		d.Status.Replicas == replicas
+}
@@ -0,0 +1,167 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+func newStrategyDeployment(strategy appsv1.DeploymentStrategyType, gracePeriod *int64) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "api",
+			Namespace:   "test",
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(int32(2)),
+			Strategy: appsv1.DeploymentStrategy{Type: strategy},
+			Template: apiv1.PodTemplateSpec{
+				Spec: apiv1.PodSpec{
+					TerminationGracePeriodSeconds: gracePeriod,
+				},
+			},
+		},
+	}
+}
+
+func TestIsRecreateStrategy(t *testing.T) {
+	tests := []struct {
+		app      App
+		name     string
+		expected bool
+	}{
+		{
+			name:     "recreate deployment",
+			app:      NewDeploymentApp(newStrategyDeployment(appsv1.RecreateDeploymentStrategyType, nil)),
+			expected: true,
+		},
+		{
+			name:     "rolling update deployment",
+			app:      NewDeploymentApp(newStrategyDeployment(appsv1.RollingUpdateDeploymentStrategyType, nil)),
+			expected: false,
+		},
+		{
+			name:     "statefulset",
+			app:      NewStatefulSetApp(&appsv1.StatefulSet{}),
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsRecreateStrategy(tt.app))
+		})
+	}
+}
+
+func TestGetPodReadyTimeout(t *testing.T) {
+	dev := &model.Dev{Timeout: model.Timeout{Resources: 2 * time.Minute}}
+	tests := []struct {
+		app      App
+		name     string
+		expected time.Duration
+	}{
+		{
+			name:     "rolling update deployment",
+			app:      NewDeploymentApp(newStrategyDeployment(appsv1.RollingUpdateDeploymentStrategyType, ptr.To(int64(120)))),
+			expected: 2 * time.Minute,
+		},
+		{
+			name:     "recreate deployment with default grace period",
+			app:      NewDeploymentApp(newStrategyDeployment(appsv1.RecreateDeploymentStrategyType, nil)),
+			expected: 2*time.Minute + 30*time.Second,
+		},
+		{
+			name:     "recreate deployment with grace period",
+			app:      NewDeploymentApp(newStrategyDeployment(appsv1.RecreateDeploymentStrategyType, ptr.To(int64(300)))),
+			expected: 7 * time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GetPodReadyTimeout(dev, tt.app))
+		})
+	}
+}
+
+func TestWaitForRecreatedPods(t *testing.T) {
+	recreatePodsPollInterval = 10 * time.Millisecond
+	tests := []struct {
+		name        string
+		status      appsv1.DeploymentStatus
+		expectedErr bool
+	}{
+		{
+			name:   "pods ready",
+			status: appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, ReadyReplicas: 2},
+		},
+		{
+			name:        "pods not ready",
+			status:      appsv1.DeploymentStatus{Replicas: 2, UpdatedReplicas: 2, ReadyReplicas: 1},
+			expectedErr: true,
+		},
+		{
+			name:        "old pods still running",
+			status:      appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 2, ReadyReplicas: 2},
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newStrategyDeployment(appsv1.RecreateDeploymentStrategyType, nil)
+			d.Status = tt.status
+			c := fake.NewSimpleClientset(d)
+
+			err := WaitForRecreatedPods(context.Background(), NewDeploymentApp(d), c, 50*time.Millisecond)
+			if tt.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDevModeOffRestoresStrategy(t *testing.T) {
+	d := newStrategyDeployment(appsv1.RollingUpdateDeploymentStrategyType, nil)
+	d.Spec.Strategy.RollingUpdate = &appsv1.RollingUpdateDeployment{
+		MaxSurge:       ptr.To(intstr.FromString("50%")),
+		MaxUnavailable: ptr.To(intstr.FromInt32(0)),
+	}
+	original := d.Spec.Strategy.DeepCopy()
+	d.Spec.Template.ObjectMeta = metav1.ObjectMeta{Labels: map[string]string{}, Annotations: map[string]string{}}
+	dev := model.NewDev()
+	dev.Name = "api"
+	tr := &Translation{MainDev: dev, Dev: dev, App: NewDeploymentApp(d)}
+
+	require.NoError(t, tr.translate())
+	assert.Equal(t, appsv1.RecreateDeploymentStrategyType, tr.DevApp.(*DeploymentApp).d.Spec.Strategy.Type)
+
+	require.NoError(t, tr.DevModeOff())
+	assert.Equal(t, *original, d.Spec.Strategy)
+	assert.Equal(t, int32(2), *d.Spec.Replicas)
+}
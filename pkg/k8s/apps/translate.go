@@ -17,6 +17,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"math"
 	"path"
 	"strconv"
 	"strings"
@@ -196,6 +197,7 @@ func TranslatePodSpec(podSpec *apiv1.PodSpec, rule *model.TranslationRule, devNa
 	TranslatePodSecurityContext(podSpec, rule.SecurityContext)
 	TranslatePodServiceAccount(podSpec, rule.ServiceAccount)
 	TranslatePodPriorityClassName(podSpec, rule.PriorityClassName)
+	TranslatePodHostAliases(podSpec, rule.HostAliases)
 
 	TranslateOktetoNodeSelector(podSpec, rule.NodeSelector)
 	TranslateOktetoAffinity(podSpec, rule, devName)
@@ -410,7 +412,7 @@ func TranslateOktetoVolumes(spec *apiv1.PodSpec, rule *model.TranslationRule) {
 		}
 
 		if !rule.PersistentVolume && rV.IsSyncthing() {
-			v.VolumeSource.EmptyDir = &apiv1.EmptyDirVolumeSource{}
+			v.VolumeSource.EmptyDir = translateEmptyDir(rule.VolumeSizeLimit)
 		} else {
 			v.VolumeSource.PersistentVolumeClaim = &apiv1.PersistentVolumeClaimVolumeSource{
 				ClaimName: rV.Name,
@@ -422,6 +424,21 @@ func TranslateOktetoVolumes(spec *apiv1.PodSpec, rule *model.TranslationRule) {
 	}
 }
 
+// translateEmptyDir returns the ephemeral volume used when persistent volumes are disabled
+func translateEmptyDir(sizeLimit string) *apiv1.EmptyDirVolumeSource {
+	emptyDir := &apiv1.EmptyDirVolumeSource{}
+	if sizeLimit == "" {
+		return emptyDir
+	}
+	q, err := resource.ParseQuantity(sizeLimit)
+	if err != nil {
+		oktetoLog.Infof("ignoring invalid size limit '%s': %s", sizeLimit, err)
+		return emptyDir
+	}
+	emptyDir.SizeLimit = &q
+	return emptyDir
+}
+
 // TranslateOktetoBinVolume translates the binaries volume attached to a container
 func TranslateOktetoBinVolume(spec *apiv1.PodSpec) {
 	if spec.Volumes == nil {
@@ -471,6 +488,48 @@ func TranslatePodPriorityClassName(spec *apiv1.PodSpec, pc string) {
 	}
 }
 
+// TranslatePodHostAliases merges the host aliases of the development container into the host aliases of the pod.
+// A hostname already defined in the pod is resolved to the IP of the development container host alias
+func TranslatePodHostAliases(spec *apiv1.PodSpec, aliases []apiv1.HostAlias) {
+	if len(aliases) == 0 {
+		return
+	}
+	overridden := map[string]bool{}
+	for _, alias := range aliases {
+		for _, hostname := range alias.Hostnames {
+			overridden[hostname] = true
+		}
+	}
+
+	result := []apiv1.HostAlias{}
+	index := map[string]int{}
+	add := func(ip string, hostnames []string) {
+		if len(hostnames) == 0 {
+			return
+		}
+		i, ok := index[ip]
+		if !ok {
+			i = len(result)
+			index[ip] = i
+			result = append(result, apiv1.HostAlias{IP: ip})
+		}
+		result[i].Hostnames = append(result[i].Hostnames, hostnames...)
+	}
+	for _, alias := range spec.HostAliases {
+		hostnames := []string{}
+		for _, hostname := range alias.Hostnames {
+			if !overridden[hostname] {
+				hostnames = append(hostnames, hostname)
+			}
+		}
+		add(alias.IP, hostnames)
+	}
+	for _, alias := range aliases {
+		add(alias.IP, alias.Hostnames)
+	}
+	spec.HostAliases = result
+}
+
 // TranslateContainerSecurityContext translates the security context attached to a container
 func TranslateContainerSecurityContext(c *apiv1.Container, s *model.SecurityContext) {
 	if s == nil {
@@ -791,3 +850,70 @@ func getInheritedResourcesFromContainer(container *apiv1.Container) model.Resour
 
 	return rr
 }
+
+// getScaledResourcesFromContainer returns the resources of the original Kubernetes container with the cpu and memory
+// multiplied by the dev scale factor and capped by the dev max values. Requests and limits defined in the dev act as overrides
+func getScaledResourcesFromContainer(container *apiv1.Container, devResources model.ResourceRequirements) model.ResourceRequirements {
+	rr := getInheritedResourcesFromContainer(container)
+
+	scale := devResources.Scale
+	if scale == 0 {
+		scale = 1
+	}
+	rr.Requests = scaleResourceList(rr.Requests, scale, devResources.Max)
+	rr.Limits = scaleResourceList(rr.Limits, scale, devResources.Max)
+
+	rr.Requests = overrideResourceList(rr.Requests, devResources.Requests)
+	rr.Limits = overrideResourceList(rr.Limits, devResources.Limits)
+
+	if devResources.Unlimited {
+		rr.Limits = nil
+	}
+
+	// requests greater than limits are rejected by the Kubernetes API
+	for k, request := range rr.Requests {
+		if limit, ok := rr.Limits[k]; ok && request.Cmp(limit) > 0 {
+			rr.Requests[k] = limit.DeepCopy()
+		}
+	}
+	return rr
+}
+
+// scaleResourceList multiplies the cpu and memory of a resource list by the scale factor, capping the result by the max values
+func scaleResourceList(resources model.ResourceList, scale float64, maxResources model.ResourceList) model.ResourceList {
+	if len(resources) == 0 {
+		return nil
+	}
+	result := model.ResourceList{}
+	for k, v := range resources {
+		if k == apiv1.ResourceCPU || k == apiv1.ResourceMemory {
+			v = scaleQuantity(k, v, scale)
+		}
+		if maxValue, ok := maxResources[k]; ok && v.Cmp(maxValue) > 0 {
+			v = maxValue.DeepCopy()
+		}
+		result[k] = v
+	}
+	return result
+}
+
+// scaleQuantity multiplies a quantity by the scale factor, using millicores for cpu to keep fractional values
+func scaleQuantity(name apiv1.ResourceName, q resource.Quantity, scale float64) resource.Quantity {
+	if name == apiv1.ResourceCPU {
+		return *resource.NewMilliQuantity(int64(math.Ceil(float64(q.MilliValue())*scale)), q.Format)
+	}
+	return *resource.NewQuantity(int64(math.Ceil(float64(q.Value())*scale)), q.Format)
+}
+
+func overrideResourceList(resources, overrides model.ResourceList) model.ResourceList {
+	if len(overrides) == 0 {
+		return resources
+	}
+	if resources == nil {
+		resources = model.ResourceList{}
+	}
+	for k, v := range overrides {
+		resources[k] = v
+	}
+	return resources
+}
@@ -411,7 +411,7 @@ dev:
 	if tr1.App.Replicas() != 0 {
 		t.Fatalf("d1 is running %d replicas", tr1.App.Replicas())
 	}
-	expectedLabels := map[string]string{constants.DevLabel: "true"}
+	expectedLabels := map[string]string{"app": "web", constants.DevLabel: "true"}
 	if !reflect.DeepEqual(tr1.App.ObjectMeta().Labels, expectedLabels) {
 		t.Fatalf("Wrong d1 labels: '%v'", tr1.App.ObjectMeta().Labels)
 	}
@@ -544,6 +544,7 @@ dev:
 				ImagePullPolicy: apiv1.PullAlways,
 				Command:         []string{"./run_worker.sh"},
 				Args:            []string{},
+				WorkingDir:      "/src",
 				Env: []apiv1.EnvVar{
 					{Name: "HISTSIZE", Value: "10000000"},
 					{Name: "HISTFILESIZE", Value: "10000000"},
@@ -970,7 +971,7 @@ func Test_translateWithoutVolumes(t *testing.T) {
 				ImagePullPolicy: apiv1.PullAlways,
 				Command:         []string{"/var/okteto/bin/start.sh"},
 				Args:            []string{"-r", "-e"},
-				WorkingDir:      "",
+				WorkingDir:      "/okteto",
 				Env: []apiv1.EnvVar{
 					{
 						Name:  "OKTETO_NAMESPACE",
@@ -1415,6 +1416,198 @@ func Test_translateSecurityContextReadOnlyRootFilesystem(t *testing.T) {
 	}
 }
 
+func Test_translateRunAs(t *testing.T) {
+	manifest, err := model.Read([]byte(`
+dev:
+  web:
+    image: web:latest
+    runAs: "1000:1001"
+    sync:
+      - .:/app
+    volumes:
+      - /root/.cache`))
+	require.NoError(t, err)
+	dev := manifest.Dev["web"]
+
+	d := deployments.Sandbox(dev, "n")
+	tr := &Translation{
+		MainDev: dev,
+		Dev:     dev,
+		App:     NewDeploymentApp(d),
+		Rules:   []*model.TranslationRule{dev.ToTranslationRule(dev, "n", "test-manifest", "cindy", false)},
+	}
+	require.NoError(t, tr.translate())
+
+	spec := tr.DevApp.PodSpec()
+	require.NotNil(t, spec.SecurityContext)
+	assert.Equal(t, ptr.To(int64(1001)), spec.SecurityContext.FSGroup)
+
+	require.NotEmpty(t, spec.Containers)
+	assert.Equal(t, ptr.To(int64(0)), spec.Containers[0].SecurityContext.RunAsUser)
+	assert.Equal(t, ptr.To(int64(0)), spec.Containers[0].SecurityContext.RunAsGroup)
+	assert.Equal(t, []string{"/var/okteto/bin/start.sh"}, spec.Containers[0].Command)
+
+	initVolumeFound := false
+	for _, c := range spec.InitContainers {
+		if c.Name != OktetoInitVolumeContainerName {
+			continue
+		}
+		initVolumeFound = true
+		assert.Equal(t, ptr.To(int64(0)), c.SecurityContext.RunAsUser)
+	}
+	assert.True(t, initVolumeFound)
+}
+
+func Test_translateDevImages(t *testing.T) {
+	t.Setenv("OKTETO_BIN_IMAGE", "env.mycorp.com/okteto/okteto:3.0.0")
+	tests := []struct {
+		name              string
+		manifest          string
+		expectedBinImage  string
+		expectedMainImage string
+	}{
+		{
+			name: "bin image from the environment",
+			manifest: `
+dev:
+  web:
+    image: web:latest
+    sync:
+      - .:/app`,
+			expectedBinImage:  "env.mycorp.com/okteto/okteto:3.0.0",
+			expectedMainImage: "web:latest",
+		},
+		{
+			name: "helper images from the manifest",
+			manifest: `
+devImages:
+  bin: mirror.mycorp.com/okteto/okteto:3.0.0
+  sandbox: mirror.mycorp.com/okteto/dev:latest
+dev:
+  web:
+    autocreate: true
+    sync:
+      - .:/app`,
+			expectedBinImage:  "mirror.mycorp.com/okteto/okteto:3.0.0",
+			expectedMainImage: "mirror.mycorp.com/okteto/dev:latest",
+		},
+		{
+			name: "init container image of the dev takes precedence",
+			manifest: `
+devImages:
+  bin: mirror.mycorp.com/okteto/okteto:3.0.0
+dev:
+  web:
+    image: web:latest
+    initContainer:
+      image: custom.mycorp.com/okteto/bin:1.0.0
+    sync:
+      - .:/app`,
+			expectedBinImage:  "custom.mycorp.com/okteto/bin:1.0.0",
+			expectedMainImage: "web:latest",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest, err := model.Read([]byte(tt.manifest))
+			require.NoError(t, err)
+			dev := manifest.Dev["web"]
+
+			d := deployments.Sandbox(dev, "n")
+			tr := &Translation{
+				MainDev: dev,
+				Dev:     dev,
+				App:     NewDeploymentApp(d),
+				Rules:   []*model.TranslationRule{dev.ToTranslationRule(dev, "n", "test-manifest", "cindy", false)},
+			}
+			require.NoError(t, tr.translate())
+
+			spec := tr.DevApp.PodSpec()
+			require.NotEmpty(t, spec.Containers)
+			assert.Equal(t, tt.expectedMainImage, spec.Containers[0].Image)
+
+			binFound := false
+			for _, c := range spec.InitContainers {
+				if c.Name != OktetoBinName {
+					continue
+				}
+				binFound = true
+				assert.Equal(t, tt.expectedBinImage, c.Image)
+			}
+			assert.True(t, binFound)
+		})
+	}
+}
+
+func TestReadInvalidDevImages(t *testing.T) {
+	_, err := model.Read([]byte(`
+devImages:
+  bin: "mirror.mycorp.com/okteto/okteto:3.0.0:latest"
+dev:
+  web:
+    image: web:latest`))
+	require.ErrorContains(t, err, "the field 'devImages.bin' is not a valid image reference")
+}
+
+func Test_translateHostAliases(t *testing.T) {
+	manifest, err := model.Read([]byte(`
+dev:
+  web:
+    image: web:latest
+    environment:
+      API_SERVICE_PORT: "9000"
+    hostAliases:
+      - ip: 127.0.0.1
+        hostnames:
+          - db
+          - api
+        port: 8080
+    sync:
+      - .:/app`))
+	require.NoError(t, err)
+	dev := manifest.Dev["web"]
+
+	d := deployments.Sandbox(dev, "n")
+	d.Spec.Template.Spec.HostAliases = []apiv1.HostAlias{
+		{IP: "10.0.0.1", Hostnames: []string{"db", "cache"}},
+		{IP: "127.0.0.1", Hostnames: []string{"localhost.test"}},
+	}
+	tr := &Translation{
+		MainDev: dev,
+		Dev:     dev,
+		App:     NewDeploymentApp(d),
+		Rules:   []*model.TranslationRule{dev.ToTranslationRule(dev, "n", "test-manifest", "cindy", false)},
+	}
+	require.NoError(t, tr.translate())
+
+	spec := tr.DevApp.PodSpec()
+	assert.Equal(t, []apiv1.HostAlias{
+		{IP: "10.0.0.1", Hostnames: []string{"cache"}},
+		{IP: "127.0.0.1", Hostnames: []string{"localhost.test", "db", "api"}},
+	}, spec.HostAliases)
+
+	envs := map[string]string{}
+	for _, e := range spec.Containers[0].Env {
+		envs[e.Name] = e.Value
+	}
+	assert.Equal(t, "127.0.0.1", envs["DB_SERVICE_HOST"])
+	assert.Equal(t, "8080", envs["DB_SERVICE_PORT"])
+	assert.Equal(t, "127.0.0.1", envs["API_SERVICE_HOST"])
+	assert.Equal(t, "9000", envs["API_SERVICE_PORT"])
+}
+
+func TestTranslatePodHostAliases(t *testing.T) {
+	spec := &apiv1.PodSpec{}
+	TranslatePodHostAliases(spec, nil)
+	assert.Nil(t, spec.HostAliases)
+
+	spec = &apiv1.PodSpec{
+		HostAliases: []apiv1.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"db"}}},
+	}
+	TranslatePodHostAliases(spec, []apiv1.HostAlias{{IP: "127.0.0.1", Hostnames: []string{"db"}}})
+	assert.Equal(t, []apiv1.HostAlias{{IP: "127.0.0.1", Hostnames: []string{"db"}}}, spec.HostAliases)
+}
+
 func TestTranslateOktetoVolumes(t *testing.T) {
 	var tests = []struct {
 		name     string
@@ -1463,6 +1656,29 @@ func TestTranslateOktetoVolumes(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "single-persistence-disabled-with-size-limit",
+			spec: &apiv1.PodSpec{},
+			rule: &model.TranslationRule{
+				PersistentVolume: false,
+				VolumeSizeLimit:  "2Gi",
+				Volumes: []model.VolumeMount{
+					{
+						Name:      "okteto",
+						SubPath:   model.SyncthingSubPath,
+						MountPath: model.OktetoSyncthingMountPath,
+					},
+				},
+			},
+			expected: []apiv1.Volume{
+				{
+					Name: "okteto",
+					VolumeSource: apiv1.VolumeSource{
+						EmptyDir: &apiv1.EmptyDirVolumeSource{SizeLimit: ptr.To(resource.MustParse("2Gi"))},
+					},
+				},
+			},
+		},
 		{
 			name: "external-volume",
 			spec: &apiv1.PodSpec{},
@@ -2044,6 +2260,7 @@ func Test_translateSfsWithVolumes(t *testing.T) {
 				ImagePullPolicy: apiv1.PullAlways,
 				Command:         []string{"./run_worker.sh"},
 				Args:            []string{},
+				WorkingDir:      "/src",
 				SecurityContext: &apiv1.SecurityContext{
 					RunAsUser:  ptr.To(int64(0)),
 					RunAsGroup: ptr.To(int64(0)),
@@ -2417,6 +2634,30 @@ func TestTranslateLifecycle(t *testing.T) {
 	}
 }
 
+func Test_translateResourcesWithGPUs(t *testing.T) {
+	manifest := []byte(`name: test
+image: okteto/test
+resources:
+  gpus: 2
+  limits:
+    memory: 1Gi`)
+	dev := &model.Dev{}
+	require.NoError(t, yaml.UnmarshalStrict(manifest, dev))
+	require.NoError(t, dev.SetDefaults())
+
+	rule := dev.ToTranslationRule(dev, "ns", "manifest", "user", false)
+	c := &apiv1.Container{}
+	TranslateResources(c, rule.Resources)
+
+	assert.Equal(t, apiv1.ResourceList{
+		apiv1.ResourceMemory:    resource.MustParse("1Gi"),
+		model.NvidiaGPUResource: resource.MustParse("2"),
+	}, c.Resources.Limits)
+	assert.Equal(t, apiv1.ResourceList{
+		model.NvidiaGPUResource: resource.MustParse("2"),
+	}, c.Resources.Requests)
+}
+
 func TestDev_GetInheritedResourcesFromContainer(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -2577,3 +2818,173 @@ func TestGetVolumeLabelKey(t *testing.T) {
 		assert.NotEqual(t, result1, result2, "Different inputs should produce different hashes")
 	})
 }
+
+func TestGetScaledResourcesFromContainer(t *testing.T) {
+	toStrings := func(rl model.ResourceList) map[apiv1.ResourceName]string {
+		if rl == nil {
+			return nil
+		}
+		result := map[apiv1.ResourceName]string{}
+		for k, v := range rl {
+			result[k] = v.String()
+		}
+		return result
+	}
+
+	tests := []struct {
+		container        *apiv1.Container
+		expectedRequests map[apiv1.ResourceName]string
+		expectedLimits   map[apiv1.ResourceName]string
+		name             string
+		devResources     model.ResourceRequirements
+	}{
+		{
+			name: "scale millicores and binary memory",
+			container: &apiv1.Container{
+				Resources: apiv1.ResourceRequirements{
+					Requests: apiv1.ResourceList{
+						apiv1.ResourceCPU:    resource.MustParse("250m"),
+						apiv1.ResourceMemory: resource.MustParse("512Mi"),
+					},
+					Limits: apiv1.ResourceList{
+						apiv1.ResourceCPU:    resource.MustParse("1"),
+						apiv1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+				},
+			},
+			devResources: model.ResourceRequirements{Scale: 2},
+			expectedRequests: map[apiv1.ResourceName]string{
+				apiv1.ResourceCPU:    "500m",
+				apiv1.ResourceMemory: "1Gi",
+			},
+			expectedLimits: map[apiv1.ResourceName]string{
+				apiv1.ResourceCPU:    "2",
+				apiv1.ResourceMemory: "2Gi",
+			},
+		},
+		{
+			name: "fractional scale with decimal memory",
+			container: &apiv1.Container{
+				Resources: apiv1.ResourceRequirements{
+					Requests: apiv1.ResourceList{
+						apiv1.ResourceCPU:    resource.MustParse("100m"),
+						apiv1.ResourceMemory: resource.MustParse("500M"),
+					},
+				},
+			},
+			devResources: model.ResourceRequirements{Scale: 1.5},
+			expectedRequests: map[apiv1.ResourceName]string{
+				apiv1.ResourceCPU:    "150m",
+				apiv1.ResourceMemory: "750M",
+			},
+		},
+		{
+			name: "scale capped by max",
+			container: &apiv1.Container{
+				Resources: apiv1.ResourceRequirements{
+					Requests: apiv1.ResourceList{
+						apiv1.ResourceCPU:    resource.MustParse("1500m"),
+						apiv1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+					Limits: apiv1.ResourceList{
+						apiv1.ResourceCPU:    resource.MustParse("2"),
+						apiv1.ResourceMemory: resource.MustParse("2Gi"),
+					},
+				},
+			},
+			devResources: model.ResourceRequirements{
+				Scale: 3,
+				Max: model.ResourceList{
+					apiv1.ResourceCPU:    resource.MustParse("4"),
+					apiv1.ResourceMemory: resource.MustParse("4Gi"),
+				},
+			},
+			expectedRequests: map[apiv1.ResourceName]string{
+				apiv1.ResourceCPU:    "4",
+				apiv1.ResourceMemory: "3Gi",
+			},
+			expectedLimits: map[apiv1.ResourceName]string{
+				apiv1.ResourceCPU:    "4",
+				apiv1.ResourceMemory: "4Gi",
+			},
+		},
+		{
+			name: "absolute overrides win over scaled values",
+			container: &apiv1.Container{
+				Resources: apiv1.ResourceRequirements{
+					Requests: apiv1.ResourceList{
+						apiv1.ResourceCPU:    resource.MustParse("500m"),
+						apiv1.ResourceMemory: resource.MustParse("256Mi"),
+					},
+					Limits: apiv1.ResourceList{
+						apiv1.ResourceCPU:    resource.MustParse("1"),
+						apiv1.ResourceMemory: resource.MustParse("512Mi"),
+					},
+				},
+			},
+			devResources: model.ResourceRequirements{
+				Scale: 2,
+				Limits: model.ResourceList{
+					apiv1.ResourceMemory: resource.MustParse("256Mi"),
+				},
+			},
+			expectedRequests: map[apiv1.ResourceName]string{
+				apiv1.ResourceCPU:    "1",
+				apiv1.ResourceMemory: "256Mi",
+			},
+			expectedLimits: map[apiv1.ResourceName]string{
+				apiv1.ResourceCPU:    "2",
+				apiv1.ResourceMemory: "256Mi",
+			},
+		},
+		{
+			name: "unlimited removes limits",
+			container: &apiv1.Container{
+				Resources: apiv1.ResourceRequirements{
+					Requests: apiv1.ResourceList{
+						apiv1.ResourceCPU:    resource.MustParse("250m"),
+						apiv1.ResourceMemory: resource.MustParse("128Mi"),
+					},
+					Limits: apiv1.ResourceList{
+						apiv1.ResourceCPU:    resource.MustParse("500m"),
+						apiv1.ResourceMemory: resource.MustParse("256Mi"),
+					},
+				},
+			},
+			devResources: model.ResourceRequirements{Unlimited: true},
+			expectedRequests: map[apiv1.ResourceName]string{
+				apiv1.ResourceCPU:    "250m",
+				apiv1.ResourceMemory: "128Mi",
+			},
+		},
+		{
+			name: "other resources are not scaled",
+			container: &apiv1.Container{
+				Resources: apiv1.ResourceRequirements{
+					Limits: apiv1.ResourceList{
+						apiv1.ResourceCPU:              resource.MustParse("300m"),
+						apiv1.ResourceEphemeralStorage: resource.MustParse("1Gi"),
+					},
+				},
+			},
+			devResources: model.ResourceRequirements{Scale: 2},
+			expectedLimits: map[apiv1.ResourceName]string{
+				apiv1.ResourceCPU:              "600m",
+				apiv1.ResourceEphemeralStorage: "1Gi",
+			},
+		},
+		{
+			name:         "container without resources",
+			container:    &apiv1.Container{},
+			devResources: model.ResourceRequirements{Scale: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resources := getScaledResourcesFromContainer(tt.container, tt.devResources)
+			assert.Equal(t, tt.expectedRequests, toStrings(resources.Requests))
+			assert.Equal(t, tt.expectedLimits, toStrings(resources.Limits))
+		})
+	}
+}
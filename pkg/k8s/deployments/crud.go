@@ -44,16 +44,18 @@ func Sandbox(dev *model.Dev, namespace string) *appsv1.Deployment {
 	if image == "" {
 		image = model.DefaultImage
 	}
+	labels := dev.ResourceLabels()
+	labels[constants.DevLabel] = "true"
+	annotations := dev.ResourceAnnotations()
+	annotations[model.OktetoAutoCreateAnnotation] = model.OktetoUpCmd
+	templateLabels := dev.ResourceLabels()
+	templateLabels["app"] = dev.Name
 	return &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      dev.Name,
-			Namespace: namespace,
-			Labels: model.Labels{
-				constants.DevLabel: "true",
-			},
-			Annotations: model.Annotations{
-				model.OktetoAutoCreateAnnotation: model.OktetoUpCmd,
-			},
+			Name:        dev.Name,
+			Namespace:   namespace,
+			Labels:      labels,
+			Annotations: annotations,
 		},
 		Spec: appsv1.DeploymentSpec{
 			Replicas: ptr.To(int32(1)),
@@ -67,9 +69,7 @@ func Sandbox(dev *model.Dev, namespace string) *appsv1.Deployment {
 			},
 			Template: apiv1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{
-						"app": dev.Name,
-					},
+					Labels:      templateLabels,
 					Annotations: map[string]string{},
 				},
 				Spec: apiv1.PodSpec{
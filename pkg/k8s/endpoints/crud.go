@@ -0,0 +1,106 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+)
+
+// List returns the endpoint slices of a service, sorted by name
+func List(ctx context.Context, service, namespace string, c kubernetes.Interface) ([]discoveryv1.EndpointSlice, error) {
+	sliceList, err := c.DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", discoveryv1.LabelServiceName, service),
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices := sliceList.Items
+	sort.Slice(slices, func(i, j int) bool {
+		return slices[i].Name < slices[j].Name
+	})
+	return slices, nil
+}
+
+// Watch watches the endpoint slices of a service
+func Watch(ctx context.Context, service, namespace string, c kubernetes.Interface) (watch.Interface, error) {
+	return c.DiscoveryV1().EndpointSlices(namespace).Watch(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", discoveryv1.LabelServiceName, service),
+	})
+}
+
+// GetReadyPods returns the names of the pods backing a service with a ready address, in the order they are listed by its endpoint slices.
+// It works for headless services too, as their endpoint slices are managed the same way
+func GetReadyPods(ctx context.Context, service, namespace string, c kubernetes.Interface) ([]string, error) {
+	slices, err := List(ctx, service, namespace, c)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []string{}
+	found := map[string]bool{}
+	for _, slice := range slices {
+		for _, endpoint := range slice.Endpoints {
+			if !isReady(endpoint) || endpoint.TargetRef == nil || endpoint.TargetRef.Kind != "Pod" {
+				continue
+			}
+			if found[endpoint.TargetRef.Name] {
+				continue
+			}
+			found[endpoint.TargetRef.Name] = true
+			result = append(result, endpoint.TargetRef.Name)
+		}
+	}
+	return result, nil
+}
+
+// isReady returns if an endpoint is ready. A nil ready condition must be interpreted as ready
+func isReady(endpoint discoveryv1.Endpoint) bool {
+	return endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready
+}
+
+// Deploy creates or updates an endpoint slice managed by okteto, like the ones of services without selector
+func Deploy(ctx context.Context, slice *discoveryv1.EndpointSlice, c kubernetes.Interface) error {
+	old, err := c.DiscoveryV1().EndpointSlices(slice.Namespace).Get(ctx, slice.Name, metav1.GetOptions{})
+	if err != nil {
+		if !oktetoErrors.IsNotFound(err) {
+			return fmt.Errorf("error getting endpoint slice '%s': %w", slice.Name, err)
+		}
+		if _, err := c.DiscoveryV1().EndpointSlices(slice.Namespace).Create(ctx, slice, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("error creating endpoint slice '%s': %w", slice.Name, err)
+		}
+		return nil
+	}
+	slice.ResourceVersion = old.ResourceVersion
+	if _, err := c.DiscoveryV1().EndpointSlices(slice.Namespace).Update(ctx, slice, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("error updating endpoint slice '%s': %w", slice.Name, err)
+	}
+	return nil
+}
+
+// Destroy deletes an endpoint slice, it doesn't fail if it doesn't exist
+func Destroy(ctx context.Context, name, namespace string, c kubernetes.Interface) error {
+	err := c.DiscoveryV1().EndpointSlices(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !oktetoErrors.IsNotFound(err) {
+		return fmt.Errorf("error deleting endpoint slice '%s': %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoints
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newEndpoint(pod string, ready *bool) discoveryv1.Endpoint {
+	return discoveryv1.Endpoint{
+		Addresses:  []string{"10.0.0.1"},
+		Conditions: discoveryv1.EndpointConditions{Ready: ready},
+		TargetRef:  &apiv1.ObjectReference{Kind: "Pod", Name: pod, Namespace: "test"},
+	}
+}
+
+func newEndpointSlice(name, service string, endpoints ...discoveryv1.Endpoint) *discoveryv1.EndpointSlice {
+	return &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: service},
+		},
+		Endpoints: endpoints,
+	}
+}
+
+func TestGetReadyPods(t *testing.T) {
+	ready := true
+	notReady := false
+	c := fake.NewSimpleClientset(
+		newEndpointSlice("db-b", "db",
+			newEndpoint("db-2", &ready),
+			newEndpoint("db-0", &ready),
+		),
+		newEndpointSlice("db-a", "db",
+			newEndpoint("db-1", &notReady),
+			newEndpoint("db-0", nil),
+			discoveryv1.Endpoint{Addresses: []string{"10.0.0.5"}},
+		),
+		newEndpointSlice("api-a", "api",
+			newEndpoint("api-0", &ready),
+		),
+	)
+
+	result, err := GetReadyPods(context.Background(), "db", "test", c)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"db-0", "db-2"}, result)
+
+	result, err = GetReadyPods(context.Background(), "worker", "test", c)
+	require.NoError(t, err)
+	assert.Empty(t, result)
+}
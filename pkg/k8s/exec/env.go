@@ -0,0 +1,76 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exec
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"al.essio.dev/pkg/shellescape"
+	"github.com/google/uuid"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+var envNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// execFn runs the exec requests of ExecWithEnv, it's replaced in tests
+var execFn = Exec
+
+// ExecWithEnv executes the command in the development container with the environment variables envs, with the format 'NAME=value'.
+// The exec API doesn't support environment variables, and the command of an exec request can be logged by the apiserver, so the
+// variables are sent through stdin to a file only readable by the user, which is sourced and removed before running the command
+func ExecWithEnv(ctx context.Context, c kubernetes.Interface, config *rest.Config, podNamespace, podName, container string, tty bool, stdin io.Reader, stdout, stderr io.Writer, command, envs []string) error {
+	if len(envs) == 0 {
+		return execFn(ctx, c, config, podNamespace, podName, container, tty, stdin, stdout, stderr, command)
+	}
+
+	envFile := fmt.Sprintf("/tmp/.okteto-env-%s", uuid.NewString())
+	writeCmd := []string{"sh", "-c", `umask 077 && cat > "$0"`, envFile}
+	if err := execFn(ctx, c, config, podNamespace, podName, container, false, strings.NewReader(formatEnvFile(envs)), io.Discard, stderr, writeCmd); err != nil {
+		return fmt.Errorf("failed to set the environment variables of the command: %w", err)
+	}
+	err := execFn(ctx, c, config, podNamespace, podName, container, tty, stdin, stdout, stderr, sourceEnvFileCommand(envFile, command))
+	if err != nil {
+		// the command might have failed before sourcing the environment file, which must not be left in the container
+		rmCmd := []string{"rm", "-f", envFile}
+		if rmErr := execFn(context.WithoutCancel(ctx), c, config, podNamespace, podName, container, false, strings.NewReader(""), io.Discard, io.Discard, rmCmd); rmErr != nil {
+			oktetoLog.Infof("failed to remove the environment file '%s': %s", envFile, rmErr)
+		}
+	}
+	return err
+}
+
+// formatEnvFile returns the shell script exporting the environment variables envs
+func formatEnvFile(envs []string) string {
+	var sb strings.Builder
+	for _, kv := range envs {
+		name, value, _ := strings.Cut(kv, "=")
+		if !envNameRegex.MatchString(name) {
+			oktetoLog.Infof("'%s' is not a valid name for an environment variable, skipping it", name)
+			continue
+		}
+		fmt.Fprintf(&sb, "export %s=%s\n", name, shellescape.Quote(value))
+	}
+	return sb.String()
+}
+
+// sourceEnvFileCommand wraps command to source the environment file and remove it before running the command
+func sourceEnvFileCommand(envFile string, command []string) []string {
+	return append([]string{"sh", "-c", `. "$0"; rm -f "$0"; exec "$@"`, envFile}, command...)
+}
@@ -0,0 +1,85 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exec
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+func TestFormatEnvFile(t *testing.T) {
+	envs := []string{
+		"AWS_SECRET_ACCESS_KEY=aws-secret-value",
+		"EMPTY=",
+		"QUOTED=it's $HOME",
+		"INVALID-NAME=value",
+	}
+	expected := "export AWS_SECRET_ACCESS_KEY=aws-secret-value\nexport EMPTY=''\nexport QUOTED='it'\"'\"'s $HOME'\n"
+	assert.Equal(t, expected, formatEnvFile(envs))
+}
+
+func TestSourceEnvFileCommand(t *testing.T) {
+	assert.Equal(t,
+		[]string{"sh", "-c", `. "$0"; rm -f "$0"; exec "$@"`, "/tmp/.okteto-env", "bash", "-l"},
+		sourceEnvFileCommand("/tmp/.okteto-env", []string{"bash", "-l"}))
+}
+
+func TestExecWithEnvRemovesEnvFileOnFailure(t *testing.T) {
+	errExec := errors.New("connection lost")
+	tests := []struct {
+		commandErr error
+		name       string
+		expected   int
+	}{
+		{
+			name:     "command succeeds",
+			expected: 2,
+		},
+		{
+			name:       "command fails",
+			commandErr: errExec,
+			expected:   3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var commands [][]string
+			execFn = func(_ context.Context, _ kubernetes.Interface, _ *rest.Config, _, _, _ string, _ bool, _ io.Reader, _, _ io.Writer, command []string) error {
+				commands = append(commands, command)
+				if len(commands) == 2 {
+					return tt.commandErr
+				}
+				return nil
+			}
+			t.Cleanup(func() { execFn = Exec })
+
+			err := ExecWithEnv(context.Background(), nil, nil, "ns", "pod", "dev", false, nil, io.Discard, io.Discard, []string{"bash"}, []string{"TOKEN=secret"})
+			require.ErrorIs(t, err, tt.commandErr)
+			require.Len(t, commands, tt.expected)
+
+			envFile := commands[0][3]
+			assert.Equal(t, envFile, commands[1][3])
+			if tt.commandErr != nil {
+				assert.Equal(t, []string{"rm", "-f", envFile}, commands[2])
+			}
+		})
+	}
+}
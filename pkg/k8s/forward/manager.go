@@ -21,30 +21,48 @@ import (
 	"io"
 	"net/http"
 	"runtime"
+	"slices"
+	"sync"
 	"time"
 
+	"github.com/okteto/okteto/pkg/k8s/endpoints"
 	"github.com/okteto/okteto/pkg/k8s/labels"
-	"github.com/okteto/okteto/pkg/k8s/pods"
 	"github.com/okteto/okteto/pkg/k8s/services"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/okteto/okteto/pkg/reconnect"
 	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/portforward"
 	"k8s.io/client-go/transport/spdy"
 )
 
+// serviceBackoff is the backoff between the reconnections of a forward to a service
+var serviceBackoff = reconnect.Backoff{Initial: 3 * time.Second, Max: 30 * time.Second}
+
+// Forwarder is an interface for the port-forwarding features
+type Forwarder interface {
+	Add(forward.Forward) error
+	AddReverse(model.Reverse) error
+	Start(string, string) error
+	StartGlobalForwarding() error
+	Stop()
+	TransformLabelsToServiceName(forward.Forward) (forward.Forward, error)
+}
+
 // PortForwardManager keeps a list of all the active port forwards
 type PortForwardManager struct {
 	ctx            context.Context
 	client         kubernetes.Interface
 	ports          map[int]forward.Forward
 	services       map[string]struct{}
-	activeDev      *active
+	activeDevs     []*active
 	activeServices map[string]*active
 	restConfig     *rest.Config
+	reconnects     *reconnect.Coordinator
 	iface          string
 	namespace      string
 	stopped        bool
@@ -55,10 +73,16 @@ type active struct {
 	stopChan  chan struct{}
 	out       *bytes.Buffer
 	err       error
+	lock      sync.Mutex
 }
 
 func (a *active) stop() {
-	if a != nil && a.stopChan != nil {
+	if a == nil {
+		return
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.stopChan != nil {
 		close(a.stopChan)
 		a.stopChan = nil
 	}
@@ -89,6 +113,7 @@ func NewPortForwardManager(ctx context.Context, iface string, restConfig *rest.C
 		restConfig: restConfig,
 		client:     c,
 		namespace:  namespace,
+		reconnects: reconnect.Default(),
 	}
 }
 
@@ -98,7 +123,7 @@ func (p *PortForwardManager) Add(f forward.Forward) error {
 		return fmt.Errorf("port %d is listed multiple times, please check your configuration", f.Local)
 	}
 
-	if !model.IsPortAvailable(p.iface, f.Local) {
+	if !model.IsPortAvailable(f.GetInterface(p.iface), f.Local) {
 		maxSystemPorts := 1024
 		if f.Local <= maxSystemPorts {
 			os := runtime.GOOS
@@ -130,57 +155,99 @@ func (*PortForwardManager) AddReverse(_ model.Reverse) error {
 	return fmt.Errorf("not implemented")
 }
 
-// Start starts all the port forwarders to the development container
+// Start starts all the port forwarders to the development container.
+// The development container is not required if all the forwards target services
 func (p *PortForwardManager) Start(devPod, namespace string) error {
 	p.stopped = false
-	a, devPF, err := p.buildForwarderToDevPod(namespace, devPod)
-	if err != nil {
-		return fmt.Errorf("failed to k8s forward to development container: %w", err)
+	if !p.hasDevPorts() {
+		p.startServices(namespace)
+		oktetoLog.Infof("all k8s port-forwards to services are started")
+		return nil
 	}
 
-	p.activeDev = a
-	go func() {
-		err := devPF.ForwardPorts()
+	// the forwards listening in the same interface share the port forwarder
+	p.activeDevs = nil
+	for iface, ports := range getDevPorts(p.iface, p.ports) {
+		a, devPF, err := p.buildForwarder(namespace, devPod, iface, ports)
 		if err != nil {
-			oktetoLog.Infof("k8s forwarding to dev pod finished with errors: %s", err)
-			if p.activeDev != nil {
+			p.stopDev()
+			return fmt.Errorf("failed to k8s forward to development container: %w", err)
+		}
+
+		p.activeDevs = append(p.activeDevs, a)
+		go func() {
+			err := devPF.ForwardPorts()
+			if err != nil {
+				oktetoLog.Infof("k8s forwarding to dev pod finished with errors: %s", err)
 				if !errors.Is(err, portforward.ErrLostConnectionToPod) {
-					p.activeDev.closeReady()
+					a.closeReady()
 				}
-				p.activeDev.err = err
+				a.err = err
 			}
+		}()
+	}
+
+	p.startServices(namespace)
+
+	for _, a := range p.activeDevs {
+		<-a.readyChan
+		if err := a.error(); err != nil {
+			return err
 		}
-	}()
+	}
+
+	oktetoLog.Infof("all k8s port-forwards are connected")
+	return nil
+}
 
+func (p *PortForwardManager) startServices(namespace string) {
 	p.activeServices = map[string]*active{}
 	for svc := range p.services {
-		go p.forwardService(p.ctx, namespace, svc)
+		for iface := range getServicePorts(svc, p.iface, p.ports) {
+			go p.forwardService(p.ctx, namespace, svc, iface)
+		}
 	}
+}
 
-	<-p.activeDev.readyChan
-
-	if err := p.activeDev.error(); err != nil {
-		return err
+func (p *PortForwardManager) hasDevPorts() bool {
+	for _, f := range p.ports {
+		if !f.Service {
+			return true
+		}
 	}
+	return false
+}
 
-	oktetoLog.Infof("all k8s port-forwards are connected")
+// DevPodError returns the error that finished the port forward to the development container, if any
+func (p *PortForwardManager) DevPodError() error {
+	for _, a := range p.activeDevs {
+		if err := a.error(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 // Stop stops all the port forwarders
 func (p *PortForwardManager) Stop() {
 	p.stopped = true
-	p.activeDev.stop()
+	p.stopDev()
 
 	for _, a := range p.activeServices {
 		a.stop()
 	}
 
 	p.activeServices = nil
-	p.activeDev = nil
 	oktetoLog.Infof("stopped k8s forwarder")
 }
 
+func (p *PortForwardManager) stopDev() {
+	for _, a := range p.activeDevs {
+		a.stop()
+	}
+	p.activeDevs = nil
+}
+
 func (fm *PortForwardManager) TransformLabelsToServiceName(f forward.Forward) (forward.Forward, error) {
 	serviceName, err := fm.GetServiceNameByLabel(fm.namespace, f.Labels)
 	if err != nil {
@@ -190,18 +257,20 @@ func (fm *PortForwardManager) TransformLabelsToServiceName(f forward.Forward) (f
 	return f, nil
 }
 
-func (p *PortForwardManager) buildForwarderToDevPod(namespace, pod string) (*active, *portforward.PortForwarder, error) {
-	ports := []string{}
-	for _, f := range p.ports {
+// getDevPorts returns the ports of the forwards to the development container, grouped by the interface where they listen
+func getDevPorts(defaultIface string, forwards map[int]forward.Forward) map[string][]string {
+	ports := map[string][]string{}
+	for _, f := range forwards {
 		if !f.Service {
-			ports = append(ports, fmt.Sprintf("%d:%d", f.Local, f.Remote))
+			iface := f.GetInterface(defaultIface)
+			ports[iface] = append(ports[iface], fmt.Sprintf("%d:%d", f.Local, f.Remote))
 		}
 	}
 
-	return p.buildForwarder(namespace, pod, ports)
+	return ports
 }
 
-func (p *PortForwardManager) buildForwarder(namespace, pod string, ports []string) (*active, *portforward.PortForwarder, error) {
+func (p *PortForwardManager) buildForwarder(namespace, pod, iface string, ports []string) (*active, *portforward.PortForwarder, error) {
 	dialer, err := p.buildDialer(namespace, pod)
 	if err != nil {
 		return nil, nil, err
@@ -215,7 +284,7 @@ func (p *PortForwardManager) buildForwarder(namespace, pod string, ports []strin
 
 	pf, err := portforward.NewOnAddresses(
 		dialer,
-		[]string{p.iface},
+		[]string{iface},
 		ports,
 		a.stopChan,
 		a.readyChan,
@@ -229,31 +298,37 @@ func (p *PortForwardManager) buildForwarder(namespace, pod string, ports []strin
 	return a, pf, nil
 }
 
-func (p *PortForwardManager) buildForwarderToService(ctx context.Context, namespace, service string) (*active, *portforward.PortForwarder, error) {
+// buildForwarderToService builds a forwarder to the first ready pod of the service endpoints, and returns the name of the pod
+func (p *PortForwardManager) buildForwarderToService(ctx context.Context, namespace, service, iface string) (*active, *portforward.PortForwarder, string, error) {
 	svc, err := services.Get(ctx, service, namespace, p.client)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, "", err
 	}
 
 	if len(svc.Spec.Ports) == 0 {
-		return nil, nil, fmt.Errorf("service/%s doesn't have ports", svc.GetName())
+		return nil, nil, "", fmt.Errorf("service/%s doesn't have ports", svc.GetName())
 	}
 
-	pod, err := pods.GetBySelector(ctx, namespace, svc.Spec.Selector, p.client)
+	readyPods, err := endpoints.GetReadyPods(ctx, svc.GetName(), namespace, p.client)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get pod mapped to service/%s: %w", svc.GetName(), err)
+		return nil, nil, "", fmt.Errorf("failed to get endpoints of service/%s: %w", svc.GetName(), err)
+	}
+	if len(readyPods) == 0 {
+		return nil, nil, "", fmt.Errorf("service/%s doesn't have ready endpoints", svc.GetName())
 	}
 
-	ports := getServicePorts(svc.GetName(), p.ports)
-	return p.buildForwarder(pod.GetNamespace(), pod.GetName(), ports)
+	ports := getServicePorts(svc.GetName(), p.iface, p.ports)
+	a, pf, err := p.buildForwarder(namespace, readyPods[0], iface, ports[iface])
+	return a, pf, readyPods[0], err
 }
 
-func getServicePorts(service string, forwards map[int]forward.Forward) []string {
-	ports := []string{}
+// getServicePorts returns the ports of the forwards to the service, grouped by the interface where they listen
+func getServicePorts(service, defaultIface string, forwards map[int]forward.Forward) map[string][]string {
+	ports := map[string][]string{}
 	for _, f := range forwards {
 		if f.Service && f.ServiceName == service {
-			remote := f.Remote
-			ports = append(ports, fmt.Sprintf("%d:%d", f.Local, remote))
+			iface := f.GetInterface(defaultIface)
+			ports[iface] = append(ports[iface], fmt.Sprintf("%d:%d", f.Local, f.Remote))
 		}
 	}
 
@@ -279,22 +354,51 @@ func (p *PortForwardManager) buildDialer(namespace, pod string) (httpstream.Dial
 	return spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", url), nil
 }
 
-func (p *PortForwardManager) forwardService(ctx context.Context, namespace, service string) {
-	t := time.NewTicker(3 * time.Second)
+func (p *PortForwardManager) forwardService(ctx context.Context, namespace, service, iface string) {
+	id := fmt.Sprintf("k8s forward service/%s on %s", service, iface)
+	defer p.reconnects.Forget(id)
 
+	retry := false
 	for {
 		if p.stopped {
 			return
 		}
 
+		release := func() {}
+		if retry {
+			var err error
+			release, err = p.reconnects.Wait(ctx, id, serviceBackoff)
+			if err != nil {
+				return
+			}
+		}
+		retry = true
+
 		oktetoLog.Infof("k8s forwarding ports for service/%s", service)
-		a, pf, err := p.buildForwarderToService(ctx, namespace, service)
+		a, pf, pod, err := p.buildForwarderToService(ctx, namespace, service, iface)
 		if err != nil {
 			oktetoLog.Infof("failed to k8s forward ports to service/%s: %s", service, err)
-			<-t.C
+			release()
 			continue
 		}
 
+		// the forward is restarted as soon as the pod stops backing the service
+		watchCtx, cancel := context.WithCancel(ctx)
+		retargeted := make(chan bool, 1)
+		go func() {
+			retargeted <- p.stopOnTargetChange(watchCtx, namespace, service, pod, a)
+		}()
+
+		// the reconnection slot is held until the forward is ready or fails
+		go func(ready chan struct{}) {
+			defer release()
+			select {
+			case <-ready:
+				p.reconnects.Reconnected(id)
+			case <-watchCtx.Done():
+			}
+		}(a.readyChan)
+
 		if err := pf.ForwardPorts(); err != nil {
 			oktetoLog.Infof("k8s forwarding to service/%s finished with errors: %s", service, err)
 			a.stop()
@@ -302,7 +406,81 @@ func (p *PortForwardManager) forwardService(ctx context.Context, namespace, serv
 			oktetoLog.Infof("k8s forwarding to service/%s finished", service)
 		}
 
-		<-t.C
+		cancel()
+		// re-targeted forwards reconnect right away to the new endpoint
+		retry = !<-retargeted
+	}
+}
+
+// stopOnTargetChange stops the forward to a pod of a service when the pod is no longer a ready endpoint of the service.
+// It returns true if the forward was stopped
+func (p *PortForwardManager) stopOnTargetChange(ctx context.Context, namespace, service, pod string, a *active) bool {
+	target, err := waitForTargetChange(ctx, namespace, service, pod, p.client)
+	if err != nil {
+		return false
+	}
+
+	if target == "" {
+		oktetoLog.Infof("pod/%s is no longer an endpoint of service/%s, re-targeting forward", pod, service)
+	} else {
+		oktetoLog.Infof("pod/%s is no longer an endpoint of service/%s, re-targeting forward to pod/%s", pod, service, target)
+	}
+	a.stop()
+	return true
+}
+
+// waitForTargetChange waits until pod is no longer a ready endpoint of the service, and returns the new first ready pod of the service.
+// It returns an error if ctx is done before
+func waitForTargetChange(ctx context.Context, namespace, service, pod string, c kubernetes.Interface) (string, error) {
+	t := time.NewTicker(3 * time.Second)
+	defer t.Stop()
+
+	for {
+		w, err := endpoints.Watch(ctx, service, namespace, c)
+		if err != nil {
+			oktetoLog.Infof("failed to watch endpoints of service/%s: %s", service, err)
+			select {
+			case <-t.C:
+				continue
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+		}
+
+		target, changed, err := waitForTargetChangeEvents(ctx, namespace, service, pod, w, c)
+		w.Stop()
+		if err != nil {
+			return "", err
+		}
+		if changed {
+			return target, nil
+		}
+	}
+}
+
+// waitForTargetChangeEvents checks the endpoints of the service on every event of the watch.
+// It returns false when the watch is closed before the target changes
+func waitForTargetChangeEvents(ctx context.Context, namespace, service, pod string, w watch.Interface, c kubernetes.Interface) (string, bool, error) {
+	for {
+		// the endpoints are checked once the watch is established to not miss the changes done before
+		readyPods, err := endpoints.GetReadyPods(ctx, service, namespace, c)
+		if err != nil {
+			oktetoLog.Infof("failed to get endpoints of service/%s: %s", service, err)
+		} else if !slices.Contains(readyPods, pod) {
+			if len(readyPods) == 0 {
+				return "", true, nil
+			}
+			return readyPods[0], true, nil
+		}
+
+		select {
+		case _, ok := <-w.ResultChan():
+			if !ok {
+				return "", false, nil
+			}
+		case <-ctx.Done():
+			return "", false, ctx.Err()
+		}
 	}
 }
 
@@ -18,9 +18,16 @@ import (
 	"reflect"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/okteto/okteto/pkg/model"
 	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 func TestAdd(t *testing.T) {
@@ -53,9 +60,11 @@ func TestAdd(t *testing.T) {
 
 func TestStop(t *testing.T) {
 	pf := NewPortForwardManager(context.Background(), model.Localhost, nil, nil, "")
-	pf.activeDev = &active{
-		readyChan: make(chan struct{}, 1),
-		stopChan:  make(chan struct{}, 1),
+	pf.activeDevs = []*active{
+		{
+			readyChan: make(chan struct{}, 1),
+			stopChan:  make(chan struct{}, 1),
+		},
 	}
 
 	pf.activeServices = map[string]*active{
@@ -70,8 +79,8 @@ func TestStop(t *testing.T) {
 		t.Error("pf wasn't marked as stopped")
 	}
 
-	if pf.activeDev != nil {
-		t.Error("pf.activeDev wasn't set to nil")
+	if pf.activeDevs != nil {
+		t.Error("pf.activeDevs wasn't set to nil")
 	}
 
 	if pf.activeServices != nil {
@@ -151,7 +160,7 @@ func Test_getServicePorts(t *testing.T) {
 	tests := []struct {
 		name     string
 		forwards map[int]forward.Forward
-		expected []string
+		expected map[string][]string
 	}{
 		{
 			name: "services-with-port",
@@ -160,7 +169,7 @@ func Test_getServicePorts(t *testing.T) {
 				8080: {Local: 8080, Remote: 8090, ServiceName: "svc", Service: true},
 				22:   {Local: 22000, Remote: 22},
 			},
-			expected: []string{"8080:8090"},
+			expected: map[string][]string{model.Localhost: {"8080:8090"}},
 		},
 		{
 			name: "services-with-multiple-ports",
@@ -170,17 +179,139 @@ func Test_getServicePorts(t *testing.T) {
 				22:   {Local: 22000, Remote: 22},
 				8089: {Local: 8089, Remote: 80890, ServiceName: "svc", Service: true},
 			},
-			expected: []string{"8080:8090", "8089:80890"},
+			expected: map[string][]string{model.Localhost: {"8080:8090", "8089:80890"}},
+		},
+		{
+			name: "services-with-interface",
+			forwards: map[int]forward.Forward{
+				8080: {Local: 8080, Remote: 8090, ServiceName: "svc", Service: true},
+				8089: {Local: 8089, Remote: 80890, ServiceName: "svc", Service: true, Interface: "0.0.0.0"},
+			},
+			expected: map[string][]string{model.Localhost: {"8080:8090"}, "0.0.0.0": {"8089:80890"}},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ports := getServicePorts("svc", tt.forwards)
-			sort.Strings(ports)
+			ports := getServicePorts("svc", model.Localhost, tt.forwards)
+			for _, p := range ports {
+				sort.Strings(p)
+			}
 			if !reflect.DeepEqual(ports, tt.expected) {
 				t.Errorf("Expected: %+v, Got: %+v", tt.expected, ports)
 			}
 		})
 	}
 }
+
+func Test_getDevPorts(t *testing.T) {
+	forwards := map[int]forward.Forward{
+		80:    {Local: 80, Remote: 8090},
+		8080:  {Local: 8080, Remote: 8090, ServiceName: "svc", Service: true},
+		22000: {Local: 22000, Remote: 22, Interface: "0.0.0.0"},
+		3000:  {Local: 3000, Remote: 3000, Interface: "0.0.0.0"},
+	}
+	expected := map[string][]string{
+		model.Localhost: {"80:8090"},
+		"0.0.0.0":       {"22000:22", "3000:3000"},
+	}
+
+	ports := getDevPorts(model.Localhost, forwards)
+	for _, p := range ports {
+		sort.Strings(p)
+	}
+	if !reflect.DeepEqual(ports, expected) {
+		t.Errorf("Expected: %+v, Got: %+v", expected, ports)
+	}
+}
+
+func newEndpointSlice(pods map[string]bool) *discoveryv1.EndpointSlice {
+	slice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "db-abcde",
+			Namespace: "test",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: "db"},
+		},
+	}
+	names := []string{}
+	for name := range pods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ready := pods[name]
+		slice.Endpoints = append(slice.Endpoints, discoveryv1.Endpoint{
+			Addresses:  []string{"10.0.0.1"},
+			Conditions: discoveryv1.EndpointConditions{Ready: &ready},
+			TargetRef:  &apiv1.ObjectReference{Kind: "Pod", Name: name, Namespace: "test"},
+		})
+	}
+	return slice
+}
+
+func Test_waitForTargetChange(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewSimpleClientset(newEndpointSlice(map[string]bool{"db-0": true}))
+
+	type result struct {
+		err    error
+		target string
+	}
+	results := make(chan result, 1)
+	go func() {
+		target, err := waitForTargetChange(ctx, "test", "db", "db-0", c)
+		results <- result{target: target, err: err}
+	}()
+
+	// a new ready pod doesn't change the target while db-0 is ready
+	time.Sleep(100 * time.Millisecond)
+	_, err := c.DiscoveryV1().EndpointSlices("test").Update(ctx, newEndpointSlice(map[string]bool{"db-0": true, "db-1": true}), metav1.UpdateOptions{})
+	require.NoError(t, err)
+	select {
+	case r := <-results:
+		t.Fatalf("target changed to '%s' while the pod was ready", r.target)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	_, err = c.DiscoveryV1().EndpointSlices("test").Update(ctx, newEndpointSlice(map[string]bool{"db-0": false, "db-1": true}), metav1.UpdateOptions{})
+	require.NoError(t, err)
+	select {
+	case r := <-results:
+		require.NoError(t, r.err)
+		assert.Equal(t, "db-1", r.target)
+	case <-time.After(5 * time.Second):
+		t.Fatal("target change wasn't detected")
+	}
+}
+
+func Test_waitForTargetChangeMissingPod(t *testing.T) {
+	c := fake.NewSimpleClientset(newEndpointSlice(map[string]bool{"db-1": true}))
+
+	target, err := waitForTargetChange(context.Background(), "test", "db", "db-0", c)
+	require.NoError(t, err)
+	assert.Equal(t, "db-1", target)
+}
+
+func Test_waitForTargetChangeCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := fake.NewSimpleClientset(newEndpointSlice(map[string]bool{"db-0": true}))
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		cancel()
+	}()
+	_, err := waitForTargetChange(ctx, "test", "db", "db-0", c)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func Test_stopOnTargetChange(t *testing.T) {
+	c := fake.NewSimpleClientset(newEndpointSlice(map[string]bool{"db-0": false}))
+	pf := NewPortForwardManager(context.Background(), model.Localhost, nil, c, "test")
+	a := &active{stopChan: make(chan struct{}, 1)}
+	stopChan := a.stopChan
+
+	assert.True(t, pf.stopOnTargetChange(context.Background(), "test", "db", "db-0", a))
+	assert.Nil(t, a.stopChan)
+	_, open := <-stopChan
+	assert.False(t, open)
+}
@@ -15,6 +15,7 @@ package kubeconfig
 
 import (
 	"log"
+	"os"
 
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -49,6 +50,87 @@ func Write(cfg *clientcmdapi.Config, kubeconfigPath string) error {
 	return clientcmd.WriteToFile(*cfg, kubeconfigPath)
 }
 
+// Modify writes the clusters, users and contexts of cfg to the kubeconfig files following the client-go precedence rules:
+// a stanza that already exists is updated in the file that defines it, and a new stanza is added to the first file
+// that exists, or to the last one if none of them exist. Files without stanzas of cfg are not modified, and the
+// stanzas of the kubeconfig files that are not in cfg are kept
+func Modify(cfg *clientcmdapi.Config, kubeconfigPaths []string) error {
+	access := &pathsConfigAccess{paths: kubeconfigPaths}
+	startingConfig, err := access.GetStartingConfig()
+	if err != nil {
+		return err
+	}
+
+	newConfig := startingConfig.DeepCopy()
+	for name, cluster := range cfg.Clusters {
+		cluster = cluster.DeepCopy()
+		if existing, ok := startingConfig.Clusters[name]; ok && cluster.LocationOfOrigin == "" {
+			cluster.LocationOfOrigin = existing.LocationOfOrigin
+		}
+		newConfig.Clusters[name] = cluster
+	}
+	for name, authInfo := range cfg.AuthInfos {
+		authInfo = authInfo.DeepCopy()
+		if existing, ok := startingConfig.AuthInfos[name]; ok && authInfo.LocationOfOrigin == "" {
+			authInfo.LocationOfOrigin = existing.LocationOfOrigin
+		}
+		newConfig.AuthInfos[name] = authInfo
+	}
+	for name, kubeCtx := range cfg.Contexts {
+		kubeCtx = kubeCtx.DeepCopy()
+		if existing, ok := startingConfig.Contexts[name]; ok && kubeCtx.LocationOfOrigin == "" {
+			kubeCtx.LocationOfOrigin = existing.LocationOfOrigin
+		}
+		newConfig.Contexts[name] = kubeCtx
+	}
+	if cfg.CurrentContext != "" {
+		newConfig.CurrentContext = cfg.CurrentContext
+	}
+	return clientcmd.ModifyConfig(access, *newConfig, true)
+}
+
+// DefaultFilename returns the kubeconfig file where new stanzas are written: the first file that exists, or the last one if none of them exist
+func DefaultFilename(kubeconfigPaths []string) string {
+	if len(kubeconfigPaths) == 0 {
+		return ""
+	}
+	for _, path := range kubeconfigPaths {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return kubeconfigPaths[len(kubeconfigPaths)-1]
+}
+
+// pathsConfigAccess gives access to the kubeconfig files of a KUBECONFIG list to clientcmd.ModifyConfig
+type pathsConfigAccess struct {
+	paths []string
+}
+
+// GetLoadingPrecedence returns a copy of the paths: clientcmd.ModifyConfig sorts them to lock the files
+func (a *pathsConfigAccess) GetLoadingPrecedence() []string {
+	return append([]string{}, a.paths...)
+}
+
+func (a *pathsConfigAccess) GetStartingConfig() (*clientcmdapi.Config, error) {
+	loadingRules := clientcmd.ClientConfigLoadingRules{
+		Precedence: a.paths,
+	}
+	return loadingRules.Load()
+}
+
+func (a *pathsConfigAccess) GetDefaultFilename() string {
+	return DefaultFilename(a.paths)
+}
+
+func (*pathsConfigAccess) IsExplicitFile() bool {
+	return false
+}
+
+func (*pathsConfigAccess) GetExplicitFile() string {
+	return ""
+}
+
 // CurrentContext returns the name of the current context
 func CurrentContext(kubeconfigPath []string) string {
 	cfg := Get(kubeconfigPath)
@@ -15,9 +15,11 @@ package kubeconfig
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
@@ -149,3 +151,97 @@ func createKubeconfig(kubeconfigFields kubeconfigFields) (string, error) {
 	}
 	return dir.Name(), nil
 }
+
+func writeTestKubeconfig(t *testing.T, path, name, namespace string) {
+	t.Helper()
+	cfg := &clientcmdapi.Config{
+		Clusters:       map[string]*clientcmdapi.Cluster{name: {Server: "https://" + name}},
+		AuthInfos:      map[string]*clientcmdapi.AuthInfo{name: {Token: name}},
+		Contexts:       map[string]*clientcmdapi.Context{name: {Cluster: name, AuthInfo: name, Namespace: namespace}},
+		CurrentContext: name,
+	}
+	require.NoError(t, Write(cfg, path))
+}
+
+func TestModifyMultipleKubeconfigFiles(t *testing.T) {
+	dir := t.TempDir()
+	// the files are not sorted alphabetically, to check that the precedence order is kept
+	personal := filepath.Join(dir, "config")
+	work := filepath.Join(dir, "admin.yaml")
+	writeTestKubeconfig(t, personal, "personal", "default")
+	writeTestKubeconfig(t, work, "work", "team")
+	paths := []string{personal, work}
+
+	personalContent, err := os.ReadFile(personal)
+	require.NoError(t, err)
+
+	// the merged config reads both files, the first file wins the current context
+	cfg := Get(paths)
+	require.Contains(t, cfg.Contexts, "personal")
+	require.Contains(t, cfg.Contexts, "work")
+	require.Equal(t, "personal", cfg.CurrentContext)
+
+	// an existing context is modified in the file that defines it
+	cfg.Contexts["work"].Namespace = "cindy"
+	require.NoError(t, Modify(cfg, paths))
+
+	afterContent, err := os.ReadFile(personal)
+	require.NoError(t, err)
+	assert.Equal(t, string(personalContent), string(afterContent))
+
+	workCfg := Get([]string{work})
+	assert.Equal(t, "cindy", workCfg.Contexts["work"].Namespace)
+	assert.NotContains(t, workCfg.Contexts, "personal")
+
+	// a new context is added to the first file that exists, keeping the rest of the stanzas
+	newCfg := &clientcmdapi.Config{
+		Clusters:       map[string]*clientcmdapi.Cluster{"okteto": {Server: "https://okteto"}},
+		AuthInfos:      map[string]*clientcmdapi.AuthInfo{"okteto": {Token: "okteto"}},
+		Contexts:       map[string]*clientcmdapi.Context{"okteto": {Cluster: "okteto", AuthInfo: "okteto", Namespace: "cindy"}},
+		CurrentContext: "okteto",
+	}
+	require.NoError(t, Modify(newCfg, paths))
+
+	personalCfg := Get([]string{personal})
+	assert.Contains(t, personalCfg.Contexts, "personal")
+	assert.Contains(t, personalCfg.Contexts, "okteto")
+	assert.Equal(t, "okteto", personalCfg.CurrentContext)
+	workCfg = Get([]string{work})
+	assert.NotContains(t, workCfg.Contexts, "okteto")
+	assert.Equal(t, "work", workCfg.CurrentContext)
+}
+
+func TestModifyContextWithoutOrigin(t *testing.T) {
+	dir := t.TempDir()
+	personal := filepath.Join(dir, "config")
+	work := filepath.Join(dir, "work.yaml")
+	writeTestKubeconfig(t, personal, "personal", "default")
+	writeTestKubeconfig(t, work, "work", "team")
+	paths := []string{personal, work}
+
+	// a context built from scratch is written to the file that already defines it
+	cfg := &clientcmdapi.Config{
+		Contexts: map[string]*clientcmdapi.Context{"work": {Cluster: "work", AuthInfo: "work", Namespace: "cindy"}},
+	}
+	require.NoError(t, Modify(cfg, paths))
+
+	assert.NotContains(t, Get([]string{personal}).Contexts, "work")
+	assert.Equal(t, "cindy", Get([]string{work}).Contexts["work"].Namespace)
+}
+
+func TestDefaultFilename(t *testing.T) {
+	dir := t.TempDir()
+	personal := filepath.Join(dir, "config")
+	work := filepath.Join(dir, "work.yaml")
+	paths := []string{personal, work}
+
+	assert.Equal(t, work, DefaultFilename(paths))
+
+	writeTestKubeconfig(t, work, "work", "team")
+	assert.Equal(t, work, DefaultFilename(paths))
+
+	writeTestKubeconfig(t, personal, "personal", "default")
+	assert.Equal(t, personal, DefaultFilename(paths))
+
+	assert.Empty(t, DefaultFilename(nil))
+}
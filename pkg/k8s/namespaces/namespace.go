@@ -26,6 +26,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/sync/semaphore"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -184,6 +185,54 @@ func (n *Namespaces) DestroyWithLabel(ctx context.Context, ns string, opts Delet
 	}))
 }
 
+// Resource identifies a single resource to be deleted by DestroyResources
+type Resource struct {
+	GVR       schema.GroupVersionResource
+	Namespace string
+	Name      string
+}
+
+// DestroyResources deletes the given resources of the namespace ns. Resources of other namespaces, cluster-scoped
+// resources and resources that no longer exist are skipped. Volumes are only deleted if opts.IncludeVolumes is set
+func (n *Namespaces) DestroyResources(ctx context.Context, ns string, resources []Resource, opts DeleteAllOptions) error {
+	for _, r := range resources {
+		if r.Namespace != ns {
+			oktetoLog.Debugf("skipping deletion of %s '%s' because it is not in namespace '%s'", r.GVR.Resource, r.Name, ns)
+			continue
+		}
+		client := n.dynClient.Resource(r.GVR).Namespace(ns)
+		obj, err := client.Get(ctx, r.Name, metav1.GetOptions{})
+		if err != nil {
+			if k8sErrors.IsNotFound(err) {
+				oktetoLog.Debugf("%s '%s' was already deleted", r.GVR.Resource, r.Name)
+				continue
+			}
+			return err
+		}
+
+		if isStorage(obj.GetKind()) && !opts.IncludeVolumes {
+			oktetoLog.Debugf("skipping deletion of '%s' '%s' because of volume flag", obj.GetKind(), r.Name)
+			continue
+		}
+		if obj.GetAnnotations()[resourcePolicyAnnotation] == keepPolicy {
+			oktetoLog.Debugf("skipping deletion of %s '%s' because of policy annotation", obj.GetKind(), r.Name)
+			continue
+		}
+
+		deleteOpts := metav1.DeleteOptions{}
+		if obj.GetKind() == jobKind {
+			deletePropagation := metav1.DeletePropagationBackground
+			deleteOpts.PropagationPolicy = &deletePropagation
+		}
+		if err := client.Delete(ctx, r.Name, deleteOpts); err != nil && !k8sErrors.IsNotFound(err) {
+			oktetoLog.Debugf("error deleting '%s' '%s': %s", obj.GetKind(), r.Name, err)
+			return err
+		}
+		oktetoLog.Debugf("successfully deleted '%s' '%s'", obj.GetKind(), r.Name)
+	}
+	return nil
+}
+
 // DestroySFSVolumes This function deletes volumes for any statefulset that matches with opts.LabelSelector but it doesn't have any
 // dev.okteto.com/deployed-by label. This is to avoid to left PVCs behind when everything deployed with okteto deploy
 // command is deleted
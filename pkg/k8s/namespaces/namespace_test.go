@@ -566,3 +566,74 @@ func Test_wander(t *testing.T) {
 		})
 	}
 }
+
+func TestDestroyResources(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiv1.AddToScheme(scheme))
+	require.NoError(t, appsv1.AddToScheme(scheme))
+
+	newObjects := func() []runtime.Object {
+		return []runtime.Object{
+			&appsv1.Deployment{
+				TypeMeta:   metav1.TypeMeta{Kind: "Deployment", APIVersion: "apps/v1"},
+				ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "test"},
+			},
+			&apiv1.ConfigMap{
+				TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+				ObjectMeta: metav1.ObjectMeta{Name: "kept", Namespace: "test", Annotations: map[string]string{resourcePolicyAnnotation: keepPolicy}},
+			},
+			&apiv1.PersistentVolumeClaim{
+				TypeMeta:   metav1.TypeMeta{Kind: "PersistentVolumeClaim", APIVersion: "v1"},
+				ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "test"},
+			},
+			&apiv1.ConfigMap{
+				TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+				ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "other"},
+			},
+		}
+	}
+	deployments := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+	configmaps := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	pvcs := schema.GroupVersionResource{Version: "v1", Resource: "persistentvolumeclaims"}
+	resources := []Resource{
+		{GVR: deployments, Namespace: "test", Name: "api"},
+		{GVR: deployments, Namespace: "test", Name: "already-deleted"},
+		{GVR: configmaps, Namespace: "test", Name: "kept"},
+		{GVR: pvcs, Namespace: "test", Name: "data"},
+		{GVR: configmaps, Namespace: "other", Name: "other"},
+	}
+
+	tests := []struct {
+		name           string
+		expectedLeft   []string
+		includeVolumes bool
+	}{
+		{
+			name:         "without volumes",
+			expectedLeft: []string{"configmaps/kept", "persistentvolumeclaims/data", "configmaps/other"},
+		},
+		{
+			name:           "with volumes",
+			includeVolumes: true,
+			expectedLeft:   []string{"configmaps/kept", "configmaps/other"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dynClient := dynamicfake.NewSimpleDynamicClient(scheme, newObjects()...)
+			n := &Namespaces{dynClient: dynClient}
+
+			err := n.DestroyResources(ctx, "test", resources, DeleteAllOptions{IncludeVolumes: tt.includeVolumes})
+			require.NoError(t, err)
+
+			left := []string{}
+			for _, r := range resources {
+				if _, err := dynClient.Resource(r.GVR).Namespace(r.Namespace).Get(ctx, r.Name, metav1.GetOptions{}); err == nil {
+					left = append(left, fmt.Sprintf("%s/%s", r.GVR.Resource, r.Name))
+				}
+			}
+			assert.Equal(t, tt.expectedLeft, left)
+		})
+	}
+}
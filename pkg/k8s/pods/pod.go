@@ -201,12 +201,28 @@ func parseUserID(output string) int64 {
 
 // ContainerLogs retrieves the logs of a container in a pod
 func ContainerLogs(ctx context.Context, containerName, podName, namespace string, timestamps bool, c kubernetes.Interface) (string, error) {
-	podLogOpts := apiv1.PodLogOptions{
+	podLogOpts := &apiv1.PodLogOptions{
 		Container:  containerName,
 		LimitBytes: &limitBytes,
 		Timestamps: timestamps,
 	}
-	req := c.CoreV1().Pods(namespace).GetLogs(podName, &podLogOpts)
+	return getLogs(ctx, podName, namespace, podLogOpts, c)
+}
+
+// ContainerTailLogs retrieves the last lines of the logs of a container in a pod.
+// If previous is true, it retrieves the logs of the previous instance of the container
+func ContainerTailLogs(ctx context.Context, containerName, podName, namespace string, previous bool, tailLines int64, c kubernetes.Interface) (string, error) {
+	podLogOpts := &apiv1.PodLogOptions{
+		Container:  containerName,
+		LimitBytes: &limitBytes,
+		Previous:   previous,
+		TailLines:  &tailLines,
+	}
+	return getLogs(ctx, podName, namespace, podLogOpts, c)
+}
+
+func getLogs(ctx context.Context, podName, namespace string, podLogOpts *apiv1.PodLogOptions, c kubernetes.Interface) (string, error) {
+	req := c.CoreV1().Pods(namespace).GetLogs(podName, podLogOpts)
 	logsStream, err := req.Stream(ctx)
 	if err != nil {
 		return "", err
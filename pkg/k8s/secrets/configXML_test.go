@@ -0,0 +1,39 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/syncthing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetConfigXMLFolderOptions(t *testing.T) {
+	s := &syncthing.Syncthing{
+		RescanInterval: "300",
+		Folders: []*syncthing.Folder{
+			{Name: "1", LocalPath: "/src/api", RemotePath: "/app"},
+			{Name: "2", LocalPath: "/src/scripts", RemotePath: "/scripts", IgnorePerms: true, ModTimeWindowS: 2},
+		},
+	}
+
+	config, err := getConfigXML(s)
+	require.NoError(t, err)
+	assert.Contains(t, string(config), `path="/app" type="sendreceive" rescanIntervalS="300" fsWatcherEnabled="true" fsWatcherDelayS="1" ignorePerms="false"`)
+	assert.Contains(t, string(config), `path="/scripts" type="sendreceive" rescanIntervalS="300" fsWatcherEnabled="true" fsWatcherDelayS="1" ignorePerms="true"`)
+	assert.Contains(t, string(config), "<modTimeWindowS>0</modTimeWindowS>")
+	assert.Contains(t, string(config), "<modTimeWindowS>2</modTimeWindowS>")
+}
@@ -66,12 +66,13 @@ func Create(ctx context.Context, dev *model.Dev, namespace string, c kubernetes.
 	if err != nil {
 		return fmt.Errorf("error generating syncthing configuration: %w", err)
 	}
+	labels := dev.ResourceLabels()
+	labels[constants.DevLabel] = "true"
 	data := &v1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: secretName,
-			Labels: map[string]string{
-				constants.DevLabel: "true",
-			},
+			Name:        secretName,
+			Labels:      labels,
+			Annotations: dev.ResourceAnnotations(),
 		},
 		Type: v1.SecretTypeOpaque,
 		Data: map[string][]byte{
@@ -126,6 +127,36 @@ func Destroy(ctx context.Context, dev *model.Dev, namespace string, c kubernetes
 	return nil
 }
 
+// Deploy creates or updates a secret
+func Deploy(ctx context.Context, secret *v1.Secret, namespace string, c kubernetes.Interface) error {
+	_, err := c.CoreV1().Secrets(namespace).Get(ctx, secret.Name, metav1.GetOptions{})
+	if err != nil {
+		if !strings.Contains(err.Error(), "not found") {
+			return fmt.Errorf("error getting kubernetes secret: %w", err)
+		}
+		if _, err := c.CoreV1().Secrets(namespace).Create(ctx, secret, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("error creating kubernetes secret: %w", err)
+		}
+		return nil
+	}
+	if _, err := c.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("error updating kubernetes secret: %w", err)
+	}
+	return nil
+}
+
+// DestroyByName deletes a secret by its name
+func DestroyByName(ctx context.Context, name, namespace string, c kubernetes.Interface) error {
+	err := c.CoreV1().Secrets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil
+		}
+		return fmt.Errorf("error deleting kubernetes secret: %w", err)
+	}
+	return nil
+}
+
 // GetSecretName returns the okteto secret name for a given development container
 func GetSecretName(dev *model.Dev) string {
 	return fmt.Sprintf(oktetoSecretTemplate, dev.Name)
@@ -0,0 +1,63 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceaccounts
+
+import (
+	"context"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Get returns a service account
+func Get(ctx context.Context, name, namespace string, c kubernetes.Interface) (*apiv1.ServiceAccount, error) {
+	return c.CoreV1().ServiceAccounts(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+// List returns the list of service accounts that match the label selector
+func List(ctx context.Context, namespace, labelSelector string, c kubernetes.Interface) ([]apiv1.ServiceAccount, error) {
+	saList, err := c.CoreV1().ServiceAccounts(namespace).List(
+		ctx,
+		metav1.ListOptions{
+			LabelSelector: labelSelector,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return saList.Items, nil
+}
+
+// Create creates a service account
+func Create(ctx context.Context, sa *apiv1.ServiceAccount, c kubernetes.Interface) error {
+	_, err := c.CoreV1().ServiceAccounts(sa.Namespace).Create(ctx, sa, metav1.CreateOptions{})
+	return err
+}
+
+// Update updates a service account
+func Update(ctx context.Context, sa *apiv1.ServiceAccount, c kubernetes.Interface) error {
+	_, err := c.CoreV1().ServiceAccounts(sa.Namespace).Update(ctx, sa, metav1.UpdateOptions{})
+	return err
+}
+
+// Destroy deletes a service account
+func Destroy(ctx context.Context, name, namespace string, c kubernetes.Interface) error {
+	err := c.CoreV1().ServiceAccounts(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !oktetoErrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
@@ -26,9 +26,10 @@ import (
 	"k8s.io/client-go/kubernetes"
 )
 
-// CreateDev deploys a default k8s service for a development container
-func CreateDev(ctx context.Context, dev *model.Dev, namespace string, c kubernetes.Interface) error {
-	s := translate(dev, namespace)
+// CreateDev deploys a default k8s service for a development container.
+// The service gets an endpoint when the platform supports auto-ingress
+func CreateDev(ctx context.Context, dev *model.Dev, namespace string, autoIngress bool, c kubernetes.Interface) error {
+	s := translate(dev, namespace, autoIngress)
 	return Deploy(ctx, s, c)
 }
 
@@ -72,6 +73,9 @@ func Deploy(ctx context.Context, s *apiv1.Service, c kubernetes.Interface) error
 				old.Spec.ExternalName = s.Spec.ExternalName
 			}
 		}
+		if s.Spec.Type == apiv1.ServiceTypeExternalName && old.Spec.Type == apiv1.ServiceTypeExternalName {
+			old.Spec.ExternalName = s.Spec.ExternalName
+		}
 
 		switch op {
 		case "replace":
@@ -150,11 +150,17 @@ func TestCreateDev(t *testing.T) {
 	namespace := "test-namespace"
 	client := fake.NewSimpleClientset()
 
-	err := CreateDev(ctx, dev, namespace, client)
+	err := CreateDev(ctx, dev, namespace, true, client)
 	require.NoError(t, err, "CreateDev failed")
 
 	svc, err := client.CoreV1().Services(namespace).Get(ctx, dev.Name, metav1.GetOptions{})
 	require.NoError(t, err, "failed to get created service")
+	require.Equal(t, "true", svc.Annotations[oktetoAutoIngressAnnotation])
+
+	require.NoError(t, CreateDev(ctx, dev, "vanilla", false, client))
+	vanillaSvc, err := client.CoreV1().Services("vanilla").Get(ctx, dev.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NotContains(t, vanillaSvc.Annotations, oktetoAutoIngressAnnotation)
 
 	require.Equal(t, dev.Name, svc.Name, "expected service name to match")
 	require.Equal(t, namespace, svc.Namespace, "expected service namespace to match")
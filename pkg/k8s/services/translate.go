@@ -25,21 +25,21 @@ const (
 	oktetoAutoIngressAnnotation = "dev.okteto.com/auto-ingress"
 )
 
-func translate(dev *model.Dev, namespace string) *apiv1.Service {
+func translate(dev *model.Dev, namespace string, autoIngress bool) *apiv1.Service {
 	annotations := model.Annotations{}
-	if len(dev.Services) == 0 {
+	if autoIngress && len(dev.Services) == 0 {
 		annotations[oktetoAutoIngressAnnotation] = "true"
 	}
-	for k, v := range dev.Metadata.Annotations {
+	for k, v := range dev.ResourceAnnotations() {
 		annotations[k] = v
 	}
+	labels := dev.ResourceLabels()
+	labels[constants.DevLabel] = "true"
 	return &apiv1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      dev.Name,
-			Namespace: namespace,
-			Labels: map[string]string{
-				constants.DevLabel: "true",
-			},
+			Name:        dev.Name,
+			Namespace:   namespace,
+			Labels:      labels,
 			Annotations: annotations,
 		},
 		Spec: apiv1.ServiceSpec{
@@ -43,12 +43,14 @@ func Sandbox(dev *model.Dev, namespace string) *appsv1.StatefulSet {
 	if image == "" {
 		image = model.DefaultImage
 	}
+	templateLabels := dev.ResourceLabels()
+	templateLabels["app"] = dev.Name
 	return &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        dev.Name,
 			Namespace:   namespace,
-			Labels:      model.Labels{},
-			Annotations: model.Annotations{},
+			Labels:      dev.ResourceLabels(),
+			Annotations: dev.ResourceAnnotations(),
 		},
 		Spec: appsv1.StatefulSetSpec{
 			Replicas: ptr.To(int32(1)),
@@ -62,9 +64,7 @@ func Sandbox(dev *model.Dev, namespace string) *appsv1.StatefulSet {
 			},
 			Template: apiv1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{
-						"app": dev.Name,
-					},
+					Labels:      templateLabels,
 					Annotations: map[string]string{},
 				},
 				Spec: apiv1.PodSpec{
@@ -15,6 +15,7 @@ package volumes
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -27,6 +28,7 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -105,6 +107,23 @@ func Update(ctx context.Context, pvc *apiv1.PersistentVolumeClaim, c kubernetes.
 	return nil
 }
 
+// PatchAnnotations adds or overwrites the given annotations of a volume
+func PatchAnnotations(ctx context.Context, name, namespace string, annotations map[string]string, c kubernetes.Interface) error {
+	payload := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": annotations,
+		},
+	}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	if _, err := c.CoreV1().PersistentVolumeClaims(namespace).Patch(ctx, name, types.MergePatchType, payloadBytes, metav1.PatchOptions{}); err != nil {
+		return err
+	}
+	return nil
+}
+
 func checkPVCValues(pvc *apiv1.PersistentVolumeClaim, dev *model.Dev, devPath string) error {
 	currentSize, ok := pvc.Spec.Resources.Requests["storage"]
 	if !ok {
@@ -0,0 +1,109 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package volumes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/model"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const betaDefaultStorageClassAnnotation = "storageclass.beta.kubernetes.io/is-default-class"
+
+var (
+	// ErrNoDefaultStorageClass is returned when the dev doesn't set a storage class and the cluster has no default storage class
+	ErrNoDefaultStorageClass = errors.New("your cluster doesn't have a default storage class")
+
+	// ErrVolumeNotProvisioned is returned when the volume claim of the dev is pending without a storage class
+	ErrVolumeNotProvisioned = errors.New("the volume claim is pending without a storage class")
+
+	// provisioningPollInterval is the interval to check the status of the volume claim
+	provisioningPollInterval = 1 * time.Second
+)
+
+// CheckProvisioner checks that the cluster can provision the volume of the dev.
+// If the storage classes can't be listed (e.g. the user has no permissions), it assumes the volume can be provisioned
+func CheckProvisioner(ctx context.Context, dev *model.Dev, namespace string, c kubernetes.Interface) error {
+	if dev.PersistentVolumeStorageClass() != "" {
+		return nil
+	}
+
+	_, err := c.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, dev.GetVolumeName(), metav1.GetOptions{})
+	if err == nil {
+		return nil
+	}
+	if !oktetoErrors.IsNotFound(err) {
+		return fmt.Errorf("error getting kubernetes volume claim: %w", err)
+	}
+
+	scList, err := c.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		oktetoLog.Infof("failed to list storage classes: %s", err)
+		return nil
+	}
+	for _, sc := range scList.Items {
+		if sc.Annotations[model.DefaultStorageClassAnnotation] == "true" || sc.Annotations[betaDefaultStorageClassAnnotation] == "true" {
+			return nil
+		}
+	}
+	return ErrNoDefaultStorageClass
+}
+
+// WaitForProvisioning waits until the volume claim of the dev is bound or has a storage class assigned.
+// Claims with a storage class might stay pending until the pod is scheduled, but claims without a storage class
+// are only bound to existing volumes: if they are still pending after the timeout, it returns ErrVolumeNotProvisioned
+func WaitForProvisioning(ctx context.Context, dev *model.Dev, namespace string, c kubernetes.Interface, timeout time.Duration) error {
+	vClient := c.CoreV1().PersistentVolumeClaims(namespace)
+	ticker := time.NewTicker(provisioningPollInterval)
+	defer ticker.Stop()
+	to := time.Now().Add(timeout)
+
+	for {
+		pvc, err := vClient.Get(ctx, dev.GetVolumeName(), metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("error getting kubernetes volume claim: %w", err)
+		}
+		if isProvisioned(pvc) {
+			return nil
+		}
+
+		if time.Now().After(to) {
+			oktetoLog.Infof("volume claim '%s' is still pending after %s", pvc.Name, timeout.String())
+			return ErrVolumeNotProvisioned
+		}
+
+		select {
+		case <-ticker.C:
+			continue
+		case <-ctx.Done():
+			oktetoLog.Info("call to volumes.WaitForProvisioning cancelled")
+			return ctx.Err()
+		}
+	}
+}
+
+func isProvisioned(pvc *apiv1.PersistentVolumeClaim) bool {
+	if pvc.Status.Phase == apiv1.ClaimBound {
+		return true
+	}
+	return pvc.Spec.StorageClassName != nil && *pvc.Spec.StorageClassName != ""
+}
@@ -0,0 +1,150 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package volumes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+)
+
+func TestCheckProvisioner(t *testing.T) {
+	dev := &model.Dev{Name: "test"}
+	tests := []struct {
+		expected error
+		dev      *model.Dev
+		name     string
+		objects  []runtime.Object
+	}{
+		{
+			name:     "no default storage class",
+			dev:      dev,
+			objects:  []runtime.Object{&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "standard"}}},
+			expected: ErrNoDefaultStorageClass,
+		},
+		{
+			name: "default storage class",
+			dev:  dev,
+			objects: []runtime.Object{
+				&storagev1.StorageClass{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "standard",
+						Annotations: map[string]string{model.DefaultStorageClassAnnotation: "true"},
+					},
+				},
+			},
+		},
+		{
+			name: "beta default storage class",
+			dev:  dev,
+			objects: []runtime.Object{
+				&storagev1.StorageClass{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "standard",
+						Annotations: map[string]string{betaDefaultStorageClassAnnotation: "true"},
+					},
+				},
+			},
+		},
+		{
+			name: "storage class set in the manifest",
+			dev: &model.Dev{
+				Name:                 "test",
+				PersistentVolumeInfo: &model.PersistentVolumeInfo{Enabled: true, StorageClass: "standard"},
+			},
+		},
+		{
+			name: "volume claim already exists",
+			dev:  dev,
+			objects: []runtime.Object{
+				&apiv1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: dev.GetVolumeName(), Namespace: "ns"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewSimpleClientset(tt.objects...)
+			err := CheckProvisioner(context.Background(), tt.dev, "ns", c)
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestCheckProvisionerForbidden(t *testing.T) {
+	c := fake.NewSimpleClientset()
+	c.PrependReactor("list", "storageclasses", func(k8sTesting.Action) (bool, runtime.Object, error) {
+		return true, nil, assert.AnError
+	})
+
+	err := CheckProvisioner(context.Background(), &model.Dev{Name: "test"}, "ns", c)
+	assert.NoError(t, err)
+}
+
+func TestWaitForProvisioning(t *testing.T) {
+	dev := &model.Dev{Name: "test"}
+	tests := []struct {
+		expected error
+		pvc      *apiv1.PersistentVolumeClaim
+		name     string
+	}{
+		{
+			name: "bound",
+			pvc: &apiv1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: dev.GetVolumeName(), Namespace: "ns"},
+				Status:     apiv1.PersistentVolumeClaimStatus{Phase: apiv1.ClaimBound},
+			},
+		},
+		{
+			name: "pending with storage class",
+			pvc: &apiv1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: dev.GetVolumeName(), Namespace: "ns"},
+				Spec:       apiv1.PersistentVolumeClaimSpec{StorageClassName: ptr.To("standard")},
+				Status:     apiv1.PersistentVolumeClaimStatus{Phase: apiv1.ClaimPending},
+			},
+		},
+		{
+			name: "pending without storage class",
+			pvc: &apiv1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: dev.GetVolumeName(), Namespace: "ns"},
+				Status:     apiv1.PersistentVolumeClaimStatus{Phase: apiv1.ClaimPending},
+			},
+			expected: ErrVolumeNotProvisioned,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewSimpleClientset(tt.pvc)
+			err := WaitForProvisioning(context.Background(), dev, "ns", c, 10*time.Millisecond)
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestWaitForProvisioningMissingClaim(t *testing.T) {
+	c := fake.NewSimpleClientset()
+	err := WaitForProvisioning(context.Background(), &model.Dev{Name: "test"}, "ns", c, 10*time.Millisecond)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrVolumeNotProvisioned)
+}
@@ -0,0 +1,175 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package volumes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// resizePollInterval is the interval to check the status of a volume claim being resized
+var resizePollInterval = 1 * time.Second
+
+// ExpansionNotSupportedError is returned when the volume claim can't be expanded
+type ExpansionNotSupportedError struct {
+	Name   string
+	Reason string
+}
+
+func (e ExpansionNotSupportedError) Error() string {
+	return fmt.Sprintf("the volume '%s' can't be expanded: %s", e.Name, e.Reason)
+}
+
+// Resize expands the volume claim to the given size and waits until the new capacity is available
+func Resize(ctx context.Context, name, namespace string, size resource.Quantity, c kubernetes.Interface, timeout time.Duration) error {
+	pvc, err := c.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting kubernetes volume claim: %w", err)
+	}
+
+	currentSize := pvc.Spec.Resources.Requests[apiv1.ResourceStorage]
+	if size.Cmp(currentSize) <= 0 {
+		return fmt.Errorf("the new size '%s' of the volume '%s' must be greater than its current size '%s'", size.String(), name, currentSize.String())
+	}
+
+	if err := CheckExpansion(ctx, pvc, c); err != nil {
+		return err
+	}
+
+	oktetoLog.Infof("resizing volume claim '%s' from %s to %s", name, currentSize.String(), size.String())
+	if err := patchSize(ctx, name, namespace, size, c); err != nil {
+		if isDynamicallyProvisionedPVCError(err, name) {
+			return ExpansionNotSupportedError{
+				Name:   name,
+				Reason: "only dynamically provisioned volumes can be resized and their storage class must set 'allowVolumeExpansion: true'",
+			}
+		}
+		return fmt.Errorf("error resizing kubernetes volume claim: %w", err)
+	}
+
+	return WaitForResize(ctx, name, namespace, size, c, timeout)
+}
+
+// CheckExpansion checks that the storage class of the volume claim allows volume expansion.
+// If the storage class can't be read (e.g. the user has no permissions), it assumes the volume can be expanded
+func CheckExpansion(ctx context.Context, pvc *apiv1.PersistentVolumeClaim, c kubernetes.Interface) error {
+	if pvc.Spec.VolumeName == "" {
+		return ExpansionNotSupportedError{
+			Name:   pvc.Name,
+			Reason: "the volume claim is not bound to a persistent volume",
+		}
+	}
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return ExpansionNotSupportedError{
+			Name:   pvc.Name,
+			Reason: "the volume claim has no storage class and only dynamically provisioned volumes can be resized",
+		}
+	}
+
+	storageClass := *pvc.Spec.StorageClassName
+	sc, err := c.StorageV1().StorageClasses().Get(ctx, storageClass, metav1.GetOptions{})
+	if err != nil {
+		if oktetoErrors.IsNotFound(err) {
+			return ExpansionNotSupportedError{
+				Name:   pvc.Name,
+				Reason: fmt.Sprintf("the storage class '%s' doesn't exist", storageClass),
+			}
+		}
+		oktetoLog.Infof("failed to get storage class '%s': %s", storageClass, err)
+		return nil
+	}
+	if sc.AllowVolumeExpansion == nil || !*sc.AllowVolumeExpansion {
+		return ExpansionNotSupportedError{
+			Name:   pvc.Name,
+			Reason: fmt.Sprintf("the storage class '%s' doesn't set 'allowVolumeExpansion: true'", storageClass),
+		}
+	}
+	return nil
+}
+
+// WaitForResize waits until the capacity of the volume claim is at least the given size.
+// Volumes pending a file system resize are expanded online by the node while the pod is running
+func WaitForResize(ctx context.Context, name, namespace string, size resource.Quantity, c kubernetes.Interface, timeout time.Duration) error {
+	vClient := c.CoreV1().PersistentVolumeClaims(namespace)
+	ticker := time.NewTicker(resizePollInterval)
+	defer ticker.Stop()
+	to := time.Now().Add(timeout)
+	pendingReported := false
+
+	for {
+		pvc, err := vClient.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("error getting kubernetes volume claim: %w", err)
+		}
+		capacity, ok := pvc.Status.Capacity[apiv1.ResourceStorage]
+		if ok && capacity.Cmp(size) >= 0 {
+			oktetoLog.Infof("volume claim '%s' resized to %s", name, capacity.String())
+			return nil
+		}
+
+		if !pendingReported && hasClaimCondition(pvc, apiv1.PersistentVolumeClaimFileSystemResizePending) {
+			oktetoLog.Infof("volume claim '%s' is waiting for the node to resize its file system", name)
+			pendingReported = true
+		}
+
+		if time.Now().After(to) {
+			return fmt.Errorf("volume claim '%s' wasn't resized to %s after %s", name, size.String(), timeout.String())
+		}
+
+		select {
+		case <-ticker.C:
+			continue
+		case <-ctx.Done():
+			oktetoLog.Info("call to volumes.WaitForResize cancelled")
+			return ctx.Err()
+		}
+	}
+}
+
+func patchSize(ctx context.Context, name, namespace string, size resource.Quantity, c kubernetes.Interface) error {
+	payload := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"resources": map[string]interface{}{
+				"requests": map[string]string{
+					string(apiv1.ResourceStorage): size.String(),
+				},
+			},
+		},
+	}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = c.CoreV1().PersistentVolumeClaims(namespace).Patch(ctx, name, types.MergePatchType, payloadBytes, metav1.PatchOptions{})
+	return err
+}
+
+func hasClaimCondition(pvc *apiv1.PersistentVolumeClaim, conditionType apiv1.PersistentVolumeClaimConditionType) bool {
+	for _, condition := range pvc.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == apiv1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
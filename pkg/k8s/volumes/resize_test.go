@@ -0,0 +1,150 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package volumes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8sTesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+)
+
+func newResizePVC(storageClass *string) *apiv1.PersistentVolumeClaim {
+	return &apiv1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "okteto-test", Namespace: "ns"},
+		Spec: apiv1.PersistentVolumeClaimSpec{
+			StorageClassName: storageClass,
+			VolumeName:       "pv-test",
+			Resources: apiv1.VolumeResourceRequirements{
+				Requests: apiv1.ResourceList{apiv1.ResourceStorage: resource.MustParse("5Gi")},
+			},
+		},
+		Status: apiv1.PersistentVolumeClaimStatus{
+			Phase:    apiv1.ClaimBound,
+			Capacity: apiv1.ResourceList{apiv1.ResourceStorage: resource.MustParse("5Gi")},
+		},
+	}
+}
+
+func newStorageClass(allowVolumeExpansion *bool) *storagev1.StorageClass {
+	return &storagev1.StorageClass{
+		ObjectMeta:           metav1.ObjectMeta{Name: "standard"},
+		AllowVolumeExpansion: allowVolumeExpansion,
+	}
+}
+
+func TestResize(t *testing.T) {
+	resizePollInterval = time.Millisecond
+	ctx := context.Background()
+	c := fake.NewSimpleClientset(newResizePVC(ptr.To("standard")), newStorageClass(ptr.To(true)))
+
+	// simulates the resizer: the capacity is updated once the node has expanded the file system
+	gets := 0
+	c.PrependReactor("get", "persistentvolumeclaims", func(action k8sTesting.Action) (bool, runtime.Object, error) {
+		obj, err := c.Tracker().Get(action.GetResource(), "ns", "okteto-test")
+		if err != nil {
+			return true, nil, err
+		}
+		pvc := obj.(*apiv1.PersistentVolumeClaim).DeepCopy()
+		requested := pvc.Spec.Resources.Requests[apiv1.ResourceStorage]
+		if requested.Cmp(pvc.Status.Capacity[apiv1.ResourceStorage]) > 0 {
+			gets++
+			if gets < 3 {
+				pvc.Status.Conditions = []apiv1.PersistentVolumeClaimCondition{
+					{Type: apiv1.PersistentVolumeClaimFileSystemResizePending, Status: apiv1.ConditionTrue},
+				}
+			} else {
+				pvc.Status.Capacity[apiv1.ResourceStorage] = requested
+			}
+		}
+		return true, pvc, nil
+	})
+
+	require.NoError(t, Resize(ctx, "okteto-test", "ns", resource.MustParse("10Gi"), c, time.Second))
+
+	obj, err := c.Tracker().Get(apiv1.SchemeGroupVersion.WithResource("persistentvolumeclaims"), "ns", "okteto-test")
+	require.NoError(t, err)
+	requested := obj.(*apiv1.PersistentVolumeClaim).Spec.Resources.Requests[apiv1.ResourceStorage]
+	assert.Equal(t, "10Gi", requested.String())
+	assert.Equal(t, 3, gets)
+}
+
+func TestResizeTimeout(t *testing.T) {
+	resizePollInterval = time.Millisecond
+	c := fake.NewSimpleClientset(newResizePVC(ptr.To("standard")), newStorageClass(ptr.To(true)))
+
+	err := Resize(context.Background(), "okteto-test", "ns", resource.MustParse("10Gi"), c, 10*time.Millisecond)
+	require.EqualError(t, err, "volume claim 'okteto-test' wasn't resized to 10Gi after 10ms")
+}
+
+func TestResizeNotSupported(t *testing.T) {
+	tests := []struct {
+		pvc         *apiv1.PersistentVolumeClaim
+		name        string
+		expectedErr string
+		objects     []runtime.Object
+	}{
+		{
+			name:        "storage class without volume expansion",
+			pvc:         newResizePVC(ptr.To("standard")),
+			objects:     []runtime.Object{newStorageClass(nil)},
+			expectedErr: "the volume 'okteto-test' can't be expanded: the storage class 'standard' doesn't set 'allowVolumeExpansion: true'",
+		},
+		{
+			name:        "storage class disables volume expansion",
+			pvc:         newResizePVC(ptr.To("standard")),
+			objects:     []runtime.Object{newStorageClass(ptr.To(false))},
+			expectedErr: "the volume 'okteto-test' can't be expanded: the storage class 'standard' doesn't set 'allowVolumeExpansion: true'",
+		},
+		{
+			name:        "missing storage class",
+			pvc:         newResizePVC(ptr.To("standard")),
+			expectedErr: "the volume 'okteto-test' can't be expanded: the storage class 'standard' doesn't exist",
+		},
+		{
+			name:        "statically provisioned volume",
+			pvc:         newResizePVC(nil),
+			expectedErr: "the volume 'okteto-test' can't be expanded: the volume claim has no storage class and only dynamically provisioned volumes can be resized",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewSimpleClientset(append(tt.objects, tt.pvc)...)
+			err := Resize(context.Background(), "okteto-test", "ns", resource.MustParse("10Gi"), c, time.Second)
+			require.EqualError(t, err, tt.expectedErr)
+			assert.ErrorAs(t, err, &ExpansionNotSupportedError{})
+
+			// the volume claim is not patched
+			for _, action := range c.Actions() {
+				assert.NotEqual(t, "patch", action.GetVerb())
+			}
+		})
+	}
+}
+
+func TestResizeSmallerSize(t *testing.T) {
+	c := fake.NewSimpleClientset(newResizePVC(ptr.To("standard")), newStorageClass(ptr.To(true)))
+	err := Resize(context.Background(), "okteto-test", "ns", resource.MustParse("5Gi"), c, time.Second)
+	require.EqualError(t, err, "the new size '5Gi' of the volume 'okteto-test' must be greater than its current size '5Gi'")
+}
@@ -23,17 +23,23 @@ import (
 
 func translate(dev *model.Dev) *apiv1.PersistentVolumeClaim {
 	volumeMode := dev.PersistentVolumeMode()
-	labels := map[string]string{
-		constants.DevLabel: "true",
-	}
+	labels := dev.ResourceLabels()
 	for k, v := range dev.PersistentVolumeLabels() {
 		labels[k] = v
 	}
+	labels[constants.DevLabel] = "true"
+	annotations := dev.ResourceAnnotations()
+	for k, v := range dev.PersistentVolumeAnnotations() {
+		annotations[k] = v
+	}
+	if len(annotations) == 0 {
+		annotations = nil
+	}
 	pvc := &apiv1.PersistentVolumeClaim{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        dev.GetVolumeName(),
 			Labels:      labels,
-			Annotations: dev.PersistentVolumeAnnotations(),
+			Annotations: annotations,
 		},
 		Spec: apiv1.PersistentVolumeClaimSpec{
 			AccessModes: []apiv1.PersistentVolumeAccessMode{dev.PersistentVolumeAccessMode()},
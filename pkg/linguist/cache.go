@@ -0,0 +1,143 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linguist
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/okteto/okteto/pkg/config"
+)
+
+const (
+	cacheFileName = ".linguist-cache.json"
+
+	// cacheVersion invalidates the cached results when the detection changes
+	cacheVersion = 1
+)
+
+// languageCache stores the language detected for each folder in a file
+type languageCache struct {
+	path string
+}
+
+type cacheFile struct {
+	Entries map[string]cacheEntry `json:"entries"`
+	Version int                   `json:"version"`
+}
+
+type cacheEntry struct {
+	Fingerprint string `json:"fingerprint"`
+	Language    string `json:"language"`
+}
+
+func newLanguageCache(path string) *languageCache {
+	return &languageCache{path: path}
+}
+
+// getCachePath returns the path of the cache file in the okteto home
+func getCachePath() string {
+	return filepath.Join(config.GetOktetoHome(), cacheFileName)
+}
+
+// getCacheKey returns the absolute path of the folder
+func getCacheKey(root string) string {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return root
+	}
+	return abs
+}
+
+// getFingerprint returns a hash of the top level entries of the folder, with their size and modification time
+func getFingerprint(root string) (string, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil {
+			return "", err
+		}
+		if _, err := fmt.Fprintf(h, "%s|%t|%d|%d\n", e.Name(), e.IsDir(), info.Size(), info.ModTime().UnixNano()); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// get returns the cached language of the folder if its fingerprint didn't change
+func (c *languageCache) get(key, fingerprint string) (string, bool) {
+	f, err := c.load()
+	if err != nil {
+		return "", false
+	}
+	entry, ok := f.Entries[key]
+	if !ok || entry.Fingerprint != fingerprint {
+		return "", false
+	}
+	return entry.Language, true
+}
+
+// set stores the language of the folder
+func (c *languageCache) set(key, fingerprint, language string) error {
+	f, err := c.load()
+	if err != nil {
+		f = &cacheFile{Version: cacheVersion, Entries: map[string]cacheEntry{}}
+	}
+	f.Entries[key] = cacheEntry{Fingerprint: fingerprint, Language: language}
+
+	b, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first so concurrent commands never read a partial cache
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), cacheFileName)
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path)
+}
+
+func (c *languageCache) load() (*cacheFile, error) {
+	b, err := os.ReadFile(c.path)
+	if err != nil {
+		return nil, err
+	}
+	f := &cacheFile{}
+	if err := json.Unmarshal(b, f); err != nil {
+		return nil, err
+	}
+	if f.Version != cacheVersion || f.Entries == nil {
+		return nil, errors.New("invalid cache version")
+	}
+	return f, nil
+}
@@ -0,0 +1,178 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linguist
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t testing.TB, root string, files ...string) {
+	t.Helper()
+	for _, f := range files {
+		p := filepath.Join(root, f)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0700))
+		require.NoError(t, os.WriteFile(p, []byte(""), 0600))
+	}
+}
+
+func TestProcessDirectoryCache(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv(constants.OktetoFolderEnvVar, home)
+	root := t.TempDir()
+	writeFiles(t, root, "main.go", "server.go")
+
+	// cache miss
+	got, err := ProcessDirectory(root)
+	require.NoError(t, err)
+	assert.Equal(t, golang, got)
+
+	cache := newLanguageCache(filepath.Join(home, cacheFileName))
+	fingerprint, err := getFingerprint(root)
+	require.NoError(t, err)
+	cached, ok := cache.get(getCacheKey(root), fingerprint)
+	require.True(t, ok)
+	assert.Equal(t, golang, cached)
+
+	// cache hit: the cached value is returned without walking the folder
+	require.NoError(t, cache.set(getCacheKey(root), fingerprint, Ruby))
+	got, err = ProcessDirectory(root)
+	require.NoError(t, err)
+	assert.Equal(t, Ruby, got)
+
+	// invalidation: a change in the top level of the folder triggers a new detection
+	writeFiles(t, root, "api.go")
+	got, err = ProcessDirectory(root)
+	require.NoError(t, err)
+	assert.Equal(t, golang, got)
+}
+
+func TestLanguageCacheInvalidation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), cacheFileName)
+	cache := newLanguageCache(path)
+
+	_, ok := cache.get("/app", "fingerprint")
+	assert.False(t, ok)
+
+	require.NoError(t, cache.set("/app", "fingerprint", Python))
+	require.NoError(t, cache.set("/api", "other", golang))
+
+	language, ok := cache.get("/app", "fingerprint")
+	assert.True(t, ok)
+	assert.Equal(t, Python, language)
+
+	_, ok = cache.get("/app", "changed")
+	assert.False(t, ok)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"version":0,"entries":{"/app":{"fingerprint":"fingerprint","language":"python"}}}`), 0600))
+	_, ok = cache.get("/app", "fingerprint")
+	assert.False(t, ok)
+
+	require.NoError(t, os.WriteFile(path, []byte(`not json`), 0600))
+	_, ok = cache.get("/app", "fingerprint")
+	assert.False(t, ok)
+	require.NoError(t, cache.set("/app", "fingerprint", Python))
+	language, ok = cache.get("/app", "fingerprint")
+	assert.True(t, ok)
+	assert.Equal(t, Python, language)
+}
+
+func TestGetFingerprint(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, "main.go", "pkg/server.go")
+
+	first, err := getFingerprint(root)
+	require.NoError(t, err)
+
+	// changes in nested files don't modify the top level listing
+	require.NoError(t, os.WriteFile(filepath.Join(root, "pkg/server.go"), []byte("package pkg"), 0600))
+	second, err := getFingerprint(root)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+
+	writeFiles(t, root, "index.js")
+	third, err := getFingerprint(root)
+	require.NoError(t, err)
+	assert.NotEqual(t, first, third)
+
+	_, err = getFingerprint(filepath.Join(root, "not-found"))
+	assert.Error(t, err)
+}
+
+func TestDetectLanguageMaxDepth(t *testing.T) {
+	root := t.TempDir()
+	deep := strings.Repeat("a/", maxWalkDepth)
+	writeFiles(t, root, "main.py", deep+"main.go", deep+"server.go")
+
+	got, err := detectLanguage(root)
+	require.NoError(t, err)
+	assert.Equal(t, Python, got)
+}
+
+func TestDetectLanguageMaxFiles(t *testing.T) {
+	root := t.TempDir()
+	files := []string{}
+	for i := 0; i < maxWalkFiles; i++ {
+		files = append(files, fmt.Sprintf("a/main%d.go", i))
+	}
+	files = append(files, "b/main1.py", "b/main2.py")
+	writeFiles(t, root, files...)
+
+	got, err := detectLanguage(root)
+	require.NoError(t, err)
+	assert.Equal(t, golang, got)
+}
+
+func benchmarkRepository(b *testing.B) string {
+	root := b.TempDir()
+	files := []string{}
+	for i := 0; i < 50; i++ {
+		for j := 0; j < 20; j++ {
+			files = append(files, fmt.Sprintf("pkg%d/sub%d/file%d.go", i, j%4, j))
+		}
+	}
+	writeFiles(b, root, files...)
+	return root
+}
+
+func BenchmarkProcessDirectoryCacheMiss(b *testing.B) {
+	root := benchmarkRepository(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := detectLanguage(root); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkProcessDirectoryCacheHit(b *testing.B) {
+	b.Setenv(constants.OktetoFolderEnvVar, b.TempDir())
+	root := benchmarkRepository(b)
+	if _, err := ProcessDirectory(root); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ProcessDirectory(root); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
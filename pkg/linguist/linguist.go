@@ -15,13 +15,17 @@ package linguist
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"runtime"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	oktetoLog "github.com/okteto/okteto/pkg/log"
@@ -30,6 +34,15 @@ import (
 
 const (
 	readFileLimit = 16 * 1024 * 1024
+
+	// analysisTimeout is the maximum time to walk a directory
+	analysisTimeout = 5 * time.Second
+
+	// maxWalkDepth is the maximum depth of the folders analyzed
+	maxWalkDepth = 8
+
+	// maxWalkFiles is the maximum number of files analyzed
+	maxWalkFiles = 10000
 )
 
 var (
@@ -38,19 +51,88 @@ var (
 
 // this is all based on enry's main command https://github.com/src-d/enry
 
-// ProcessDirectory walks a directory and returns a list of guess for the programming language
+// ProcessDirectory returns the programming language of a directory.
+// The result is cached in the okteto home until the top level of the directory changes
 func ProcessDirectory(root string) (string, error) {
+	fingerprint, err := getFingerprint(root)
+	if err != nil {
+		oktetoLog.Infof("failed to calculate the fingerprint of '%s': %s", root, err)
+		return detectLanguage(root)
+	}
+
+	cache := newLanguageCache(getCachePath())
+	key := getCacheKey(root)
+	if language, ok := cache.get(key, fingerprint); ok {
+		oktetoLog.Infof("language '%s' of '%s' loaded from cache", language, root)
+		return language, nil
+	}
+
+	language, err := detectLanguage(root)
+	if err != nil {
+		return language, err
+	}
+	if err := cache.set(key, fingerprint, language); err != nil {
+		oktetoLog.Infof("failed to cache the language of '%s': %s", root, err)
+	}
+	return language, nil
+}
+
+// detectLanguage walks a directory and returns the most used programming language.
+// The files are analyzed in parallel, and the walk stops after maxWalkFiles files or analysisTimeout
+func detectLanguage(root string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), analysisTimeout)
+	defer cancel()
+
 	out := make(map[string][]string)
-	analysisTimeout := false
+	mu := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	files := make(chan string)
+	for i := 0; i < runtime.NumCPU(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for relativePath := range files {
+				language, ok := detectFileLanguage(filepath.Join(root, relativePath))
+				if !ok {
+					continue
+				}
+				mu.Lock()
+				out[language] = append(out[language], relativePath)
+				mu.Unlock()
+			}
+		}()
+	}
 
-	timer := time.AfterFunc(5*time.Second, func() {
-		analysisTimeout = true
-	})
+	err := walkDirectory(ctx, root, files)
+	close(files)
+	wg.Wait()
+
+	if err != nil && err != errAnalysisTimeOut {
+		return Unrecognized, err
+	}
+
+	if len(out) == 0 {
+		return Unrecognized, nil
+	}
 
-	defer timer.Stop()
+	sorted := sortLanguagesByUsage(out)
+	if len(sorted) == 0 {
+		return Unrecognized, nil
+	}
+	chosen := strings.ToLower(sorted[0])
 
-	err := filepath.Walk(root, func(path string, f os.FileInfo, inErr error) error {
-		if analysisTimeout {
+	if chosen == Java {
+		return refineJavaChoice(root), nil
+	}
+
+	return NormalizeLanguage(chosen), nil
+}
+
+// walkDirectory sends the relative path of the files to analyze, skipping vendor, dot, documentation and configuration files
+func walkDirectory(ctx context.Context, root string, files chan<- string) error {
+	count := 0
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, inErr error) error {
+		if ctx.Err() != nil {
 			return errAnalysisTimeOut
 		}
 
@@ -58,7 +140,7 @@ func ProcessDirectory(root string) (string, error) {
 			return inErr
 		}
 
-		if !f.Mode().IsDir() && !f.Mode().IsRegular() {
+		if !d.IsDir() && !d.Type().IsRegular() {
 			return nil
 		}
 
@@ -72,66 +154,63 @@ func ProcessDirectory(root string) (string, error) {
 			return nil
 		}
 
-		if f.IsDir() {
+		if d.IsDir() {
+			if strings.Count(relativePath, string(filepath.Separator)) >= maxWalkDepth-1 {
+				return filepath.SkipDir
+			}
 			relativePath = relativePath + "/"
 		}
 
 		if enry.IsVendor(relativePath) || enry.IsDotFile(relativePath) ||
 			enry.IsDocumentation(relativePath) || enry.IsConfiguration(relativePath) {
-			if f.IsDir() {
+			if d.IsDir() {
 				return filepath.SkipDir
 			}
 
 			return nil
 		}
 
-		if f.IsDir() {
+		if d.IsDir() {
 			return nil
 		}
 
-		language, ok := enry.GetLanguageByExtension(path)
-		if !ok {
-			if language, ok = enry.GetLanguageByFilename(path); !ok {
-				content, err := readFile(path, readFileLimit)
-				if err != nil {
-					oktetoLog.Infof("failed to read %s: %s", path, err)
-					return nil
-				}
-
-				language = enry.GetLanguage(filepath.Base(path), content)
-				if language == enry.OtherLanguage {
-					return nil
-				}
-			}
+		if count >= maxWalkFiles {
+			oktetoLog.Infof("analysis of '%s' stopped after %d files", root, maxWalkFiles)
+			return filepath.SkipAll
 		}
+		count++
 
-		if enry.GetLanguageType(language) != enry.Programming {
+		select {
+		case files <- relativePath:
 			return nil
+		case <-ctx.Done():
+			return errAnalysisTimeOut
 		}
-
-		out[language] = append(out[language], relativePath)
-		return nil
 	})
+}
 
-	if err != nil && err != errAnalysisTimeOut {
-		return Unrecognized, err
-	}
-
-	if len(out) == 0 {
-		return Unrecognized, nil
-	}
+// detectFileLanguage returns the programming language of a file
+func detectFileLanguage(path string) (string, bool) {
+	language, ok := enry.GetLanguageByExtension(path)
+	if !ok {
+		if language, ok = enry.GetLanguageByFilename(path); !ok {
+			content, err := readFile(path, readFileLimit)
+			if err != nil {
+				oktetoLog.Infof("failed to read %s: %s", path, err)
+				return "", false
+			}
 
-	sorted := sortLanguagesByUsage(out)
-	if len(sorted) == 0 {
-		return Unrecognized, nil
+			language = enry.GetLanguage(filepath.Base(path), content)
+			if language == enry.OtherLanguage {
+				return "", false
+			}
+		}
 	}
-	chosen := strings.ToLower(sorted[0])
 
-	if chosen == Java {
-		return refineJavaChoice(root), nil
+	if enry.GetLanguageType(language) != enry.Programming {
+		return "", false
 	}
-
-	return NormalizeLanguage(chosen), nil
+	return language, true
 }
 
 func refineJavaChoice(root string) string {
@@ -17,9 +17,12 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
 )
 
 func TestProcessDirectory(t *testing.T) {
+	t.Setenv(constants.OktetoFolderEnvVar, t.TempDir())
 	tests := []struct {
 		name  string
 		want  string
@@ -0,0 +1,80 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localcluster
+
+import "strings"
+
+const (
+	// ProviderKind is a cluster created by kind, whose nodes are docker containers
+	ProviderKind = "kind"
+
+	// ProviderK3d is a cluster created by k3d, whose nodes are docker containers
+	ProviderK3d = "k3d"
+)
+
+// provider describes how to find the node containers of the clusters of a provider
+type provider struct {
+	// clusterLabel is the label of the containers with the name of their cluster
+	clusterLabel string
+
+	// roleLabel is the label of the containers with their role in the cluster
+	roleLabel string
+
+	// nodeRoles are the roles of the containers running kubernetes. Load balancers or registries are skipped
+	nodeRoles []string
+}
+
+var providers = map[string]provider{
+	ProviderKind: {
+		clusterLabel: "io.x-k8s.kind.cluster",
+		roleLabel:    "io.x-k8s.kind.role",
+		nodeRoles:    []string{"control-plane", "worker"},
+	},
+	ProviderK3d: {
+		clusterLabel: "k3d.cluster",
+		roleLabel:    "k3d.role",
+		nodeRoles:    []string{"server", "agent"},
+	},
+}
+
+// Cluster is a local kubernetes cluster without a registry, whose nodes run as docker containers
+type Cluster struct {
+	// Provider is the tool that created the cluster: kind or k3d
+	Provider string
+
+	// Name is the name of the cluster for its provider
+	Name string
+}
+
+// Detect returns the local cluster of a kubernetes context, or nil if the context is not a kind or k3d cluster.
+// kind and k3d name the contexts of their clusters as 'kind-<cluster>' and 'k3d-<cluster>'
+func Detect(k8sContext string) *Cluster {
+	for name := range providers {
+		cluster, found := strings.CutPrefix(k8sContext, name+"-")
+		if found && cluster != "" {
+			return &Cluster{Provider: name, Name: cluster}
+		}
+	}
+	return nil
+}
+
+// isNodeRole returns if a container with the given role is a node of the cluster
+func (p provider) isNodeRole(role string) bool {
+	for _, r := range p.nodeRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localcluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		expected   *Cluster
+		name       string
+		k8sContext string
+	}{
+		{
+			name:       "kind",
+			k8sContext: "kind-dev",
+			expected:   &Cluster{Provider: ProviderKind, Name: "dev"},
+		},
+		{
+			name:       "k3d",
+			k8sContext: "k3d-my-cluster",
+			expected:   &Cluster{Provider: ProviderK3d, Name: "my-cluster"},
+		},
+		{
+			name:       "prefix without cluster",
+			k8sContext: "kind-",
+		},
+		{
+			name:       "okteto context",
+			k8sContext: "cloud_okteto_com",
+		},
+		{
+			name:       "docker desktop",
+			k8sContext: "docker-desktop",
+		},
+		{
+			name:       "prefix in the middle",
+			k8sContext: "my-kind-dev",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Detect(tt.k8sContext))
+		})
+	}
+}
+
+func TestParseNodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		out      string
+		expected []string
+	}{
+		{
+			name:     "kind",
+			provider: ProviderKind,
+			out:      "dev-worker\tworker\ndev-control-plane\tcontrol-plane\ndev-external-load-balancer\texternal-load-balancer\n",
+			expected: []string{"dev-control-plane", "dev-worker"},
+		},
+		{
+			name:     "k3d",
+			provider: ProviderK3d,
+			out:      "k3d-dev-serverlb\tloadbalancer\nk3d-dev-agent-0\tagent\nk3d-dev-server-0\tserver\n",
+			expected: []string{"k3d-dev-agent-0", "k3d-dev-server-0"},
+		},
+		{
+			name:     "no containers",
+			provider: ProviderKind,
+			out:      "",
+			expected: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseNodes(providers[tt.provider], tt.out))
+		})
+	}
+}
+
+func TestClusterString(t *testing.T) {
+	assert.Equal(t, "kind cluster 'dev'", (&Cluster{Provider: ProviderKind, Name: "dev"}).String())
+}
@@ -0,0 +1,95 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localcluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// importCommand imports an image tarball from the standard input into the containerd namespace used by kubernetes
+var importCommand = []string{"ctr", "--namespace=k8s.io", "images", "import", "--digests", "-"}
+
+// String returns a description of the cluster, like "kind cluster 'dev'"
+func (c *Cluster) String() string {
+	return fmt.Sprintf("%s cluster '%s'", c.Provider, c.Name)
+}
+
+// imageLoader streams an image tarball to every node of a cluster, which import it into their containerd image store
+type imageLoader struct {
+	w     io.Writer
+	done  chan error
+	pipes []*io.PipeWriter
+}
+
+// NewImageLoader starts loading an image into the nodes of cluster. The tarball written to the returned writer
+// is imported by all the nodes at once, and the image is loaded when the writer is closed
+func NewImageLoader(ctx context.Context, cluster *Cluster, executor NodeExecutor) (io.WriteCloser, error) {
+	nodes, err := executor.ListNodes(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, oktetoErrors.UserError{
+			E:    fmt.Errorf("no nodes found for %s", cluster),
+			Hint: fmt.Sprintf("Check that the %s is running with '%s get clusters'", cluster, cluster.Provider),
+		}
+	}
+	oktetoLog.Infof("loading image into the nodes of %s: %v", cluster, nodes)
+
+	l := &imageLoader{
+		done: make(chan error, len(nodes)),
+	}
+	writers := make([]io.Writer, 0, len(nodes))
+	for _, node := range nodes {
+		pr, pw := io.Pipe()
+		l.pipes = append(l.pipes, pw)
+		writers = append(writers, pw)
+		go func(node string) {
+			err := executor.Exec(ctx, node, pr, importCommand...)
+			if err != nil {
+				err = fmt.Errorf("failed to load the image into node '%s': %w", node, err)
+			}
+			// unblock the writer if the node fails before reading the whole tarball
+			pr.CloseWithError(err)
+			l.done <- err
+		}(node)
+	}
+	l.w = io.MultiWriter(writers...)
+	return l, nil
+}
+
+// Write writes a chunk of the image tarball to every node
+func (l *imageLoader) Write(p []byte) (int, error) {
+	return l.w.Write(p)
+}
+
+// Close finishes the image tarball and waits for every node to import it
+func (l *imageLoader) Close() error {
+	for _, pw := range l.pipes {
+		_ = pw.Close()
+	}
+	var errs []error
+	for range l.pipes {
+		if err := <-l.done; err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,114 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localcluster
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNodeExecutor records the standard input and the command run in every node
+type fakeNodeExecutor struct {
+	listErr  error
+	execErrs map[string]error
+	stdin    map[string]string
+	commands map[string][]string
+	nodes    []string
+	lock     sync.Mutex
+}
+
+func (f *fakeNodeExecutor) ListNodes(_ context.Context, _ *Cluster) ([]string, error) {
+	return f.nodes, f.listErr
+}
+
+func (f *fakeNodeExecutor) Exec(_ context.Context, node string, stdin io.Reader, command ...string) error {
+	if err := f.execErrs[node]; err != nil {
+		return err
+	}
+	content, err := io.ReadAll(stdin)
+	if err != nil {
+		return err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.stdin[node] = string(content)
+	f.commands[node] = command
+	return nil
+}
+
+func newFakeNodeExecutor(nodes ...string) *fakeNodeExecutor {
+	return &fakeNodeExecutor{
+		nodes:    nodes,
+		execErrs: map[string]error{},
+		stdin:    map[string]string{},
+		commands: map[string][]string{},
+	}
+}
+
+func TestImageLoader(t *testing.T) {
+	executor := newFakeNodeExecutor("dev-control-plane", "dev-worker")
+	cluster := &Cluster{Provider: ProviderKind, Name: "dev"}
+
+	loader, err := NewImageLoader(context.Background(), cluster, executor)
+	require.NoError(t, err)
+
+	tarball := bytes.Repeat([]byte("layer"), 64*1024)
+	_, err = io.Copy(loader, bytes.NewReader(tarball))
+	require.NoError(t, err)
+	require.NoError(t, loader.Close())
+
+	for _, node := range executor.nodes {
+		assert.Equal(t, string(tarball), executor.stdin[node], node)
+		assert.Equal(t, []string{"ctr", "--namespace=k8s.io", "images", "import", "--digests", "-"}, executor.commands[node], node)
+	}
+}
+
+func TestImageLoaderNodeFails(t *testing.T) {
+	executor := newFakeNodeExecutor("dev-control-plane", "dev-worker")
+	executor.execErrs["dev-worker"] = assert.AnError
+	cluster := &Cluster{Provider: ProviderKind, Name: "dev"}
+
+	loader, err := NewImageLoader(context.Background(), cluster, executor)
+	require.NoError(t, err)
+
+	// the writes fail once the failed node stops reading the tarball
+	_, _ = io.Copy(loader, bytes.NewReader(bytes.Repeat([]byte("layer"), 64*1024)))
+	err = loader.Close()
+	require.ErrorIs(t, err, assert.AnError)
+	assert.ErrorContains(t, err, "failed to load the image into node 'dev-worker'")
+}
+
+func TestImageLoaderNoNodes(t *testing.T) {
+	cluster := &Cluster{Provider: ProviderK3d, Name: "dev"}
+
+	_, err := NewImageLoader(context.Background(), cluster, newFakeNodeExecutor())
+	assert.EqualError(t, err, "no nodes found for k3d cluster 'dev'")
+	var userErr oktetoErrors.UserError
+	assert.ErrorAs(t, err, &userErr)
+}
+
+func TestImageLoaderListNodesError(t *testing.T) {
+	executor := newFakeNodeExecutor()
+	executor.listErr = assert.AnError
+
+	_, err := NewImageLoader(context.Background(), &Cluster{Provider: ProviderKind, Name: "dev"}, executor)
+	assert.ErrorIs(t, err, assert.AnError)
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localcluster
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"sort"
+	"strings"
+)
+
+// NodeExecutor lists the nodes of a local cluster and runs commands in them
+type NodeExecutor interface {
+	// ListNodes returns the names of the node containers of the cluster
+	ListNodes(ctx context.Context, cluster *Cluster) ([]string, error)
+
+	// Exec runs a command in a node, reading its standard input from stdin
+	Exec(ctx context.Context, node string, stdin io.Reader, command ...string) error
+}
+
+// dockerNodeExecutor reaches the node containers with the docker CLI, honoring DOCKER_HOST and the docker context
+type dockerNodeExecutor struct{}
+
+// NewDockerNodeExecutor returns a NodeExecutor using the docker CLI
+func NewDockerNodeExecutor() NodeExecutor {
+	return dockerNodeExecutor{}
+}
+
+// ListNodes returns the containers labeled as nodes of the cluster
+func (dockerNodeExecutor) ListNodes(ctx context.Context, cluster *Cluster) ([]string, error) {
+	p, ok := providers[cluster.Provider]
+	if !ok {
+		return nil, fmt.Errorf("unsupported local cluster provider '%s'", cluster.Provider)
+	}
+
+	format := fmt.Sprintf(`{{.Names}}\t{{.Label "%s"}}`, p.roleLabel)
+	cmd := exec.CommandContext(ctx, "docker", "ps", "--filter", fmt.Sprintf("label=%s=%s", p.clusterLabel, cluster.Name), "--format", format)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the nodes of %s: %w: %s", cluster, err, strings.TrimSpace(stderr.String()))
+	}
+	return parseNodes(p, string(out)), nil
+}
+
+// parseNodes returns the nodes in the output of 'docker ps', one '<name>\t<role>' line per container
+func parseNodes(p provider, out string) []string {
+	nodes := []string{}
+	for _, line := range strings.Split(out, "\n") {
+		name, role, _ := strings.Cut(strings.TrimSpace(line), "\t")
+		if name == "" || !p.isNodeRole(role) {
+			continue
+		}
+		nodes = append(nodes, name)
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// Exec runs a command in a node container with 'docker exec'
+func (dockerNodeExecutor) Exec(ctx context.Context, node string, stdin io.Reader, command ...string) error {
+	args := append([]string{"exec", "-i", node}, command...)
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Stdin = stdin
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(output.String()))
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package io
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefixWriter(t *testing.T) {
+	out := &bytes.Buffer{}
+	w := NewPrefixWriter(out, &sync.Mutex{}, "[unit] ")
+
+	_, err := w.Write([]byte("ok  \tpkg/a\nok  "))
+	require.NoError(t, err)
+	assert.Equal(t, "[unit] ok  \tpkg/a\n", out.String())
+
+	_, err = w.Write([]byte("\tpkg/b\nFAIL"))
+	require.NoError(t, err)
+	require.NoError(t, w.Flush())
+	assert.Equal(t, "[unit] ok  \tpkg/a\n[unit] ok  \tpkg/b\n[unit] FAIL\n", out.String())
+}
@@ -288,6 +288,38 @@ func convertToJSON(level, stage, message string) string {
 	return string(messageJSON)
 }
 
+// JSONEvent writes a structured event in the json output mode. The line has the level, stage, message and timestamp
+// fields of the rest of json lines, and the event in the key field. It does nothing in other output modes
+func JSONEvent(key, message string, event interface{}) {
+	if log.outputMode != JSONFormat {
+		return
+	}
+	line, err := marshalJSONEvent(key, log.stage, message, event)
+	if err != nil {
+		Infof("error marshalling event: %s", err)
+		return
+	}
+	line = MaskSecrets(line)
+	log.buf.WriteString(line)
+	log.buf.WriteString("\n")
+	fmt.Fprintln(log.out.Out, line)
+}
+
+func marshalJSONEvent(key, stage, message string, event interface{}) (string, error) {
+	line := map[string]interface{}{
+		"level":     InfoLevel,
+		"stage":     stage,
+		"message":   ansiRegex.ReplaceAllString(strings.TrimRightFunc(message, unicode.IsSpace), ""),
+		"timestamp": time.Now().Unix(),
+		key:         event,
+	}
+	result, err := json.Marshal(line)
+	if err != nil {
+		return "", err
+	}
+	return string(result), nil
+}
+
 // AddToBuffer logs into the buffer and writes to stdout if its a json writer
 func (w *JSONWriter) AddToBuffer(level, format string, a ...interface{}) {
 	msg := fmt.Sprintf(format, a...)
@@ -88,6 +88,7 @@ func init() {
 	if runtime.GOOS == "windows" {
 		successSymbol = color.New(color.BgGreen, color.FgBlack).Sprint(" + ")
 	}
+	log.out.AddHook(maskingHook{})
 	Init(logrus.WarnLevel)
 }
 
@@ -116,6 +117,7 @@ func ConfigureFileLogger(dir, version string) {
 	rolling := getRollingLog(logPath)
 	fileLogger.SetOutput(rolling)
 	fileLogger.SetLevel(logrus.DebugLevel)
+	fileLogger.AddHook(maskingHook{})
 
 	actionID := uuid.New().String()
 	log.file = fileLogger.WithFields(logrus.Fields{"action": actionID, "version": version})
@@ -218,12 +220,12 @@ func Fatalf(format string, args ...interface{}) {
 
 // Yellow writes a line in yellow
 func Yellow(format string, args ...interface{}) {
-	log.writer.Yellow(format, args...)
+	log.writer.Yellow("%s", redactMessage(fmt.Sprintf(format, args...)))
 }
 
 // Green writes a line in green
 func Green(format string, args ...interface{}) {
-	log.writer.Green(format, args...)
+	log.writer.Green("%s", redactMessage(fmt.Sprintf(format, args...)))
 }
 
 // BlueString returns a string in blue
@@ -243,32 +245,32 @@ func BlueBackgroundString(format string, args ...interface{}) string {
 
 // Success prints a message with the success symbol first, and the text in green
 func Success(format string, args ...interface{}) {
-	log.writer.Success(format, args...)
+	log.writer.Success("%s", redactMessage(fmt.Sprintf(format, args...)))
 }
 
 // Information prints a message with the information symbol first, and the text in blue
 func Information(format string, args ...interface{}) {
-	log.writer.Information(format, args...)
+	log.writer.Information("%s", redactMessage(fmt.Sprintf(format, args...)))
 }
 
 // Question prints a message with the question symbol first, and the text in magenta
 func Question(format string, args ...interface{}) error {
-	return log.writer.Question(format, args...)
+	return log.writer.Question("%s", redactMessage(fmt.Sprintf(format, args...)))
 }
 
 // Warning prints a message with the warning symbol first, and the text in yellow
 func Warning(format string, args ...interface{}) {
-	log.writer.Warning(format, args...)
+	log.writer.Warning("%s", redactMessage(fmt.Sprintf(format, args...)))
 }
 
 // FWarning prints a message with the warning symbol first, and the text in yellow to a specific writer
 func FWarning(w io.Writer, format string, args ...interface{}) {
-	log.writer.FWarning(w, format, args...)
+	log.writer.FWarning(w, "%s", redactMessage(fmt.Sprintf(format, args...)))
 }
 
 // Hint prints a message with the text in blue
 func Hint(format string, args ...interface{}) {
-	log.writer.Hint(format, args...)
+	log.writer.Hint("%s", redactMessage(fmt.Sprintf(format, args...)))
 }
 
 // Fail prints a message with the error symbol first, and the text in red
@@ -354,9 +356,9 @@ func DisableMasking() {
 
 func redactMessage(message string) string {
 	if log.isMasked {
-		return log.replacer.Replace(message)
+		message = log.replacer.Replace(message)
 	}
-	return message
+	return MaskSecrets(message)
 }
 
 // GetOutputBuffer returns the buffer of the running command
@@ -366,7 +368,7 @@ func GetOutputBuffer() *bytes.Buffer {
 
 // AddToBuffer logs into the buffer but does not print anything
 func AddToBuffer(level, format string, args ...interface{}) {
-	log.writer.AddToBuffer(level, format, args...)
+	log.writer.AddToBuffer(level, "%s", redactMessage(fmt.Sprintf(format, args...)))
 }
 
 func loadBool(env string) bool {
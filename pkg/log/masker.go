@@ -0,0 +1,124 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// maskedValue replaces the secret values in the logs
+	maskedValue = "***"
+
+	// minSecretLength is the minimum length of the secrets to mask, so that values like "1" or "on"
+	// don't garble every log line
+	minSecretLength = 4
+)
+
+// secretMasker replaces the registered secret values with "***". Unlike the masked words, secrets are
+// masked as soon as they are registered, on every log line.
+// Reads are lock-free: the replacer is rebuilt when a secret is added, which only happens while loading the manifest
+type secretMasker struct {
+	replacer atomic.Pointer[strings.Replacer]
+	secrets  map[string]struct{}
+	lock     sync.Mutex
+}
+
+var secrets = newSecretMasker()
+
+func newSecretMasker() *secretMasker {
+	return &secretMasker{secrets: map[string]struct{}{}}
+}
+
+// add registers a secret value. Multi-line values are registered as a whole and line by line,
+// so the lines are masked too when they are logged separately
+func (m *secretMasker) add(value string) {
+	candidates := []string{strings.TrimSpace(value)}
+	for _, line := range strings.Split(strings.ReplaceAll(value, "\r\n", "\n"), "\n") {
+		candidates = append(candidates, strings.TrimSpace(line))
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	added := false
+	for _, c := range candidates {
+		if len(c) < minSecretLength {
+			continue
+		}
+		if _, ok := m.secrets[c]; ok {
+			continue
+		}
+		m.secrets[c] = struct{}{}
+		added = true
+	}
+	if !added {
+		return
+	}
+
+	values := make([]string, 0, len(m.secrets))
+	for s := range m.secrets {
+		values = append(values, s)
+	}
+	// longest first, so a secret containing another one is fully masked
+	sort.Slice(values, func(i, j int) bool {
+		if len(values[i]) != len(values[j]) {
+			return len(values[i]) > len(values[j])
+		}
+		return values[i] < values[j]
+	})
+	oldnew := make([]string, 0, 2*len(values))
+	for _, s := range values {
+		oldnew = append(oldnew, s, maskedValue)
+	}
+	m.replacer.Store(strings.NewReplacer(oldnew...))
+}
+
+// mask replaces the secret values in s
+func (m *secretMasker) mask(s string) string {
+	r := m.replacer.Load()
+	if r == nil {
+		return s
+	}
+	return r.Replace(s)
+}
+
+// AddSecret registers a value that must never be shown. Any occurrence of it is replaced by "***" in every log line,
+// the log file and the command output stored in the cluster
+func AddSecret(value string) {
+	secrets.add(value)
+}
+
+// MaskSecrets replaces the registered secret values in s
+func MaskSecrets(s string) string {
+	return secrets.mask(s)
+}
+
+// maskingHook masks the secret values of the entries of a logrus logger
+type maskingHook struct{}
+
+// Levels returns the levels of the hook
+func (maskingHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire masks the secret values of the message of the entry
+func (maskingHook) Fire(entry *logrus.Entry) error {
+	entry.Message = MaskSecrets(entry.Message)
+	return nil
+}
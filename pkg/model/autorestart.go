@@ -0,0 +1,73 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/moby/patternmatcher"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+)
+
+const (
+	// DefaultAutoRestartDebounce is the time okteto up waits for more file changes before restarting the dev command
+	DefaultAutoRestartDebounce = 500 * time.Millisecond
+
+	// defaultAutoRestartPath matches every synchronized file
+	defaultAutoRestartPath = "**"
+)
+
+// AutoRestart re-runs the command of the development container when the synchronized files change
+type AutoRestart struct {
+	Paths    []string      `json:"paths,omitempty" yaml:"paths,omitempty"`
+	Debounce time.Duration `json:"debounce,omitempty" yaml:"debounce,omitempty"`
+	Enabled  bool          `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+}
+
+// IsAutoRestartEnabled returns true when the command of the development container is restarted on file changes
+func (dev *Dev) IsAutoRestartEnabled() bool {
+	return dev.AutoRestart != nil && dev.AutoRestart.Enabled
+}
+
+func (dev *Dev) setAutoRestartDefaults() {
+	if !dev.IsAutoRestartEnabled() {
+		return
+	}
+	if len(dev.AutoRestart.Paths) == 0 {
+		dev.AutoRestart.Paths = []string{defaultAutoRestartPath}
+	}
+	if dev.AutoRestart.Debounce == 0 {
+		dev.AutoRestart.Debounce = DefaultAutoRestartDebounce
+	}
+}
+
+func (dev *Dev) validateAutoRestart() error {
+	if !dev.IsAutoRestartEnabled() {
+		return nil
+	}
+	if dev.IsHybridModeEnabled() {
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("'autoRestart' is not supported in hybrid mode"),
+			Hint: "Remove the 'autoRestart' field or the 'mode: hybrid' field of your okteto manifest",
+		}
+	}
+	if dev.AutoRestart.Debounce < 0 {
+		return fmt.Errorf("'autoRestart.debounce' must be a positive duration, like '500ms'")
+	}
+	if _, err := patternmatcher.New(dev.AutoRestart.Paths); err != nil {
+		return fmt.Errorf("'autoRestart.paths' is not valid: %w", err)
+	}
+	return nil
+}
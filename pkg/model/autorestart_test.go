@@ -0,0 +1,120 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetAutoRestartDefaults(t *testing.T) {
+	tests := []struct {
+		dev      *Dev
+		expected *AutoRestart
+		name     string
+	}{
+		{
+			name:     "not set",
+			dev:      &Dev{},
+			expected: nil,
+		},
+		{
+			name:     "disabled",
+			dev:      &Dev{AutoRestart: &AutoRestart{}},
+			expected: &AutoRestart{},
+		},
+		{
+			name:     "enabled",
+			dev:      &Dev{AutoRestart: &AutoRestart{Enabled: true}},
+			expected: &AutoRestart{Enabled: true, Paths: []string{"**"}, Debounce: DefaultAutoRestartDebounce},
+		},
+		{
+			name:     "enabled with values",
+			dev:      &Dev{AutoRestart: &AutoRestart{Enabled: true, Paths: []string{"**/*.go"}, Debounce: time.Second}},
+			expected: &AutoRestart{Enabled: true, Paths: []string{"**/*.go"}, Debounce: time.Second},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.dev.setAutoRestartDefaults()
+			assert.Equal(t, tt.expected, tt.dev.AutoRestart)
+		})
+	}
+}
+
+func TestValidateAutoRestart(t *testing.T) {
+	tests := []struct {
+		dev     *Dev
+		name    string
+		wantErr bool
+	}{
+		{
+			name: "disabled",
+			dev:  &Dev{AutoRestart: &AutoRestart{Debounce: -time.Second}},
+		},
+		{
+			name: "valid",
+			dev:  &Dev{AutoRestart: &AutoRestart{Enabled: true, Paths: []string{"**/*.go", "!vendor"}, Debounce: time.Second}},
+		},
+		{
+			name:    "negative debounce",
+			dev:     &Dev{AutoRestart: &AutoRestart{Enabled: true, Paths: []string{"**"}, Debounce: -time.Second}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid path",
+			dev:     &Dev{AutoRestart: &AutoRestart{Enabled: true, Paths: []string{"[a-"}}},
+			wantErr: true,
+		},
+		{
+			name:    "hybrid mode",
+			dev:     &Dev{Mode: constants.OktetoHybridModeFieldValue, AutoRestart: &AutoRestart{Enabled: true, Paths: []string{"**"}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.dev.validateAutoRestart()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestAutoRestartManifest(t *testing.T) {
+	manifest, err := Read([]byte(`
+dev:
+  api:
+    image: golang:1
+    command: ["go", "run", "main.go"]
+    autoRestart:
+      enabled: true
+      paths:
+        - "**/*.go"
+      debounce: 2s
+    sync:
+      - .:/app`))
+	require.NoError(t, err)
+
+	dev := manifest.Dev["api"]
+	assert.True(t, dev.IsAutoRestartEnabled())
+	assert.Equal(t, &AutoRestart{Enabled: true, Paths: []string{"**/*.go"}, Debounce: 2 * time.Second}, dev.AutoRestart)
+}
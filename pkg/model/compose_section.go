@@ -0,0 +1,102 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"sort"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/afero"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// GetServicesToDeploy returns the services selected by the entries of the compose section.
+// An entry without 'services' selects every service defined in its file.
+// It returns nil when no entry selects services, meaning that every service of the merged stack is deployed
+func (c *ComposeSectionInfo) GetServicesToDeploy(fs afero.Fs) ([]string, error) {
+	hasSelection := false
+	for _, composeInfo := range c.ComposesInfo {
+		if len(composeInfo.ServicesToDeploy) > 0 {
+			hasSelection = true
+			break
+		}
+	}
+	if !hasSelection {
+		return nil, nil
+	}
+
+	result := []string{}
+	added := map[string]bool{}
+	for _, composeInfo := range c.ComposesInfo {
+		svcs := []string(composeInfo.ServicesToDeploy)
+		if len(svcs) == 0 {
+			var err error
+			svcs, err = getComposeFileServices(composeInfo.File, fs)
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, svc := range svcs {
+			if added[svc] {
+				continue
+			}
+			added[svc] = true
+			result = append(result, svc)
+		}
+	}
+	return result, nil
+}
+
+// getComposeFileServices returns the names of the services defined in a single compose file, before merging it with the other files
+func getComposeFileServices(path string, fs afero.Fs) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	b, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading compose file '%s': %w", path, err)
+	}
+	composeFile := struct {
+		Services map[string]interface{} `yaml:"services"`
+	}{}
+	if err := yaml.Unmarshal(b, &composeFile); err != nil {
+		return nil, fmt.Errorf("error reading services of compose file '%s': %w", path, err)
+	}
+	result := []string{}
+	for name := range composeFile.Services {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// mergeEndpoints returns the endpoints of the stack with the endpoints defined in the manifest.
+// An endpoint defined in both is replaced by the one of the manifest
+func mergeEndpoints(stackEndpoints, manifestEndpoints EndpointSpec) EndpointSpec {
+	if len(manifestEndpoints) == 0 {
+		return stackEndpoints
+	}
+	result := EndpointSpec{}
+	for name, endpoint := range stackEndpoints {
+		result[name] = endpoint
+	}
+	for name, endpoint := range manifestEndpoints {
+		if _, ok := result[name]; ok {
+			oktetoLog.Infof("endpoint '%s' is defined in the compose file and in the manifest, using the one of the manifest", name)
+		}
+		result[name] = endpoint
+	}
+	return result
+}
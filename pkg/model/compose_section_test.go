@@ -0,0 +1,110 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComposeSectionGetServicesToDeploy(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "infra.yml", []byte(`services:
+  db:
+    image: postgres
+  cache:
+    image: redis
+`), 0600))
+	require.NoError(t, afero.WriteFile(fs, "app.yml", []byte(`services:
+  api:
+    image: okteto/api
+  worker:
+    image: okteto/worker
+`), 0600))
+
+	tests := []struct {
+		name        string
+		composes    ComposeInfoList
+		expected    []string
+		expectedErr bool
+	}{
+		{
+			name: "no selection",
+			composes: ComposeInfoList{
+				{File: "infra.yml"},
+				{File: "app.yml"},
+			},
+			expected: nil,
+		},
+		{
+			name: "selection in one file",
+			composes: ComposeInfoList{
+				{File: "infra.yml", ServicesToDeploy: ServicesToDeploy{"db"}},
+				{File: "app.yml"},
+			},
+			expected: []string{"db", "api", "worker"},
+		},
+		{
+			name: "selection in every file",
+			composes: ComposeInfoList{
+				{File: "infra.yml", ServicesToDeploy: ServicesToDeploy{"cache"}},
+				{File: "app.yml", ServicesToDeploy: ServicesToDeploy{"worker", "cache"}},
+			},
+			expected: []string{"cache", "worker"},
+		},
+		{
+			name: "missing file without selection",
+			composes: ComposeInfoList{
+				{File: "infra.yml", ServicesToDeploy: ServicesToDeploy{"db"}},
+				{File: "missing.yml"},
+			},
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &ComposeSectionInfo{ComposesInfo: tt.composes}
+			svcs, err := c.GetServicesToDeploy(fs)
+			if tt.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, svcs)
+		})
+	}
+}
+
+func TestMergeEndpoints(t *testing.T) {
+	stackEndpoints := EndpointSpec{
+		"api": Endpoint{Rules: []EndpointRule{{Path: "/", Service: "api", Port: 8080}}},
+		"web": Endpoint{Rules: []EndpointRule{{Path: "/", Service: "web", Port: 80}}},
+	}
+	manifestEndpoints := EndpointSpec{
+		"api":   Endpoint{Rules: []EndpointRule{{Path: "/api", Service: "api", Port: 9090}}},
+		"admin": Endpoint{Rules: []EndpointRule{{Path: "/", Service: "admin", Port: 3000}}},
+	}
+
+	result := mergeEndpoints(stackEndpoints, manifestEndpoints)
+	assert.Equal(t, EndpointSpec{
+		"api":   manifestEndpoints["api"],
+		"web":   stackEndpoints["web"],
+		"admin": manifestEndpoints["admin"],
+	}, result)
+
+	assert.Equal(t, stackEndpoints, mergeEndpoints(stackEndpoints, nil))
+}
@@ -30,6 +30,12 @@ const (
 	// OktetoComposeUpdateStrategyAnnotation indicates how a compose service must be updated
 	OktetoComposeUpdateStrategyAnnotation = "dev.okteto.com/update"
 
+	// OktetoComposeHashAnnotation stores the content hash of a translated compose resource to skip no-op updates
+	OktetoComposeHashAnnotation = "dev.okteto.com/compose-hash"
+
+	// OktetoComposeMountedContentHashAnnotation stores the hash of the content of the configs and secrets mounted by the pods of a compose service
+	OktetoComposeMountedContentHashAnnotation = "dev.okteto.com/compose-mounted-content-hash"
+
 	// DetachedDevLabel indicates the detached dev pods
 	DetachedDevLabel = "detached.dev.okteto.com"
 
@@ -81,6 +87,9 @@ const (
 	// StackServiceNameLabel indicates the name of the stack service an object belongs to
 	StackServiceNameLabel = "stack.okteto.com/service"
 
+	// StackStoppedReplicasAnnotation indicates the replicas of a stack service before it was stopped
+	StackStoppedReplicasAnnotation = "stack.okteto.com/stopped-replicas"
+
 	// StackEndpointNameLabel indicates the name of the endpoint an object belongs to
 	StackEndpointNameLabel = "stack.okteto.com/endpoint"
 
@@ -99,6 +108,9 @@ const (
 	// StackVolumeNameLabel indicates the name of the stack volume an object belongs to
 	StackVolumeNameLabel = "stack.okteto.com/volume"
 
+	// StackExternalServiceLabel indicates the name of the external service of the stack an object belongs to
+	StackExternalServiceLabel = "stack.okteto.com/external-service"
+
 	// Localhost localhost
 	Localhost = "localhost"
 	// PrivilegedLocalhost localhost
@@ -143,6 +155,14 @@ const (
 	OktetoSyncAnnotation = "dev.okteto.com/sync"
 	// OktetoStignoreAnnotation indicates the hash of the stignore files to force redeployment
 	OktetoStignoreAnnotation = "dev.okteto.com/stignore"
+	// OktetoHolderAnnotation indicates the okteto up session holding the dev clone, with the format <hostname>/<pid>
+	OktetoHolderAnnotation = "dev.okteto.com/holder"
+	// OktetoHolderReleased is the value of OktetoHolderAnnotation once the okteto up session stops holding the dev clone
+	OktetoHolderReleased = "none"
+	// OktetoHolderTimestampAnnotation indicates when the okteto up session started holding the dev clone
+	OktetoHolderTimestampAnnotation = "dev.okteto.com/holder-timestamp"
+	// OktetoLastActivityAnnotation indicates when an okteto up session last started or stopped using the resource
+	OktetoLastActivityAnnotation = "dev.okteto.com/last-activity"
 
 	// DefaultImage default image for sandboxes
 	DefaultImage = "okteto/dev:latest"
@@ -216,6 +236,15 @@ const (
 	// to a preview environment
 	DeprecatedOktetoCurrentDeployBelongsToPreviewEnvVar = "OKTETO_CURRENT_DEPLOY_BELONGS_TO_PREVIEW"
 
+	// OktetoGPUTolerationEnvVar adds the tolerations and node selector of the GPU nodes to the workloads requesting GPUs
+	OktetoGPUTolerationEnvVar = "OKTETO_GPU_TOLERATION"
+
+	// OktetoAllowPrivilegedEnvVar allows compose services with 'privileged' or 'devices'
+	OktetoAllowPrivilegedEnvVar = "OKTETO_ALLOW_PRIVILEGED"
+
+	// OktetoAllowHostAccessEnvVar allows compose services with 'pid', 'ipc' or 'network_mode' set to 'host'
+	OktetoAllowHostAccessEnvVar = "OKTETO_ALLOW_HOST_ACCESS"
+
 	// OktetoTimeoutEnvVar defines the timeout for okteto commands
 	OktetoTimeoutEnvVar = "OKTETO_TIMEOUT"
 
@@ -0,0 +1,115 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultDeployHealthCheckTimeout is the time a health check attempt waits for a response
+	DefaultDeployHealthCheckTimeout = 10 * time.Second
+)
+
+// DeployHealthCheck is a smoke test run by 'okteto deploy --health-checks' once the deployed resources are healthy
+type DeployHealthCheck struct {
+	HTTP    *HTTPDeployHealthCheck `json:"http,omitempty" yaml:"http,omitempty"`
+	Exec    *ExecDeployHealthCheck `json:"exec,omitempty" yaml:"exec,omitempty"`
+	Name    string                 `json:"name,omitempty" yaml:"name,omitempty"`
+	Timeout time.Duration          `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	// Retries is the number of attempts after the first one fails
+	Retries int `json:"retries,omitempty" yaml:"retries,omitempty"`
+}
+
+// HTTPDeployHealthCheck requests an url and checks the status code of the response
+type HTTPDeployHealthCheck struct {
+	URL    string `json:"url,omitempty" yaml:"url,omitempty"`
+	Status int    `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+// ExecDeployHealthCheck runs a command in a pod of a service and checks that it succeeds
+type ExecDeployHealthCheck struct {
+	Service string  `json:"service,omitempty" yaml:"service,omitempty"`
+	Command Command `json:"command,omitempty" yaml:"command,omitempty"`
+}
+
+func (hc *DeployHealthCheck) setDefaults() {
+	if hc.Timeout == 0 {
+		hc.Timeout = DefaultDeployHealthCheckTimeout
+	}
+	if hc.HTTP != nil && hc.HTTP.Status == 0 {
+		hc.HTTP.Status = http.StatusOK
+	}
+	if hc.Name != "" {
+		return
+	}
+	switch {
+	case hc.HTTP != nil:
+		hc.Name = hc.HTTP.URL
+	case hc.Exec != nil:
+		hc.Name = hc.Exec.Service
+	}
+}
+
+func (hc *DeployHealthCheck) validate(idx int) error {
+	field := fmt.Sprintf("deploy.healthchecks[%d]", idx)
+	if (hc.HTTP == nil) == (hc.Exec == nil) {
+		return fmt.Errorf("'%s' must define either 'http' or 'exec'", field)
+	}
+	if hc.Timeout < 0 {
+		return fmt.Errorf("'%s.timeout' must be positive", field)
+	}
+	if hc.Retries < 0 {
+		return fmt.Errorf("'%s.retries' must be positive", field)
+	}
+	if hc.HTTP != nil {
+		if hc.HTTP.URL == "" {
+			return fmt.Errorf("the field '%s.http.url' is mandatory", field)
+		}
+		if hc.HTTP.Status < 100 || hc.HTTP.Status > 599 {
+			return fmt.Errorf("'%s.http.status' must be a valid HTTP status code", field)
+		}
+		return nil
+	}
+	if hc.Exec.Service == "" {
+		return fmt.Errorf("the field '%s.exec.service' is mandatory", field)
+	}
+	if len(hc.Exec.Command.Values) == 0 {
+		return fmt.Errorf("the field '%s.exec.command' is mandatory", field)
+	}
+	return nil
+}
+
+func (m *Manifest) setDeployHealthChecksDefaults() {
+	if m.Deploy == nil {
+		return
+	}
+	for i := range m.Deploy.HealthChecks {
+		m.Deploy.HealthChecks[i].setDefaults()
+	}
+}
+
+func (m *Manifest) validateDeployHealthChecks() error {
+	if m.Deploy == nil {
+		return nil
+	}
+	for i := range m.Deploy.HealthChecks {
+		if err := m.Deploy.HealthChecks[i].validate(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadDeployHealthChecks(t *testing.T) {
+	manifest, err := Read([]byte(`
+deploy:
+  commands:
+    - kubectl apply -f k8s
+  healthchecks:
+    - http:
+        url: https://frontend.example.com/healthz
+    - name: api
+      exec:
+        service: api
+        command: curl -f localhost:8080
+      timeout: 30s
+      retries: 3
+    - http:
+        url: https://frontend.example.com/missing
+        status: 404
+`))
+	require.NoError(t, err)
+
+	expected := []DeployHealthCheck{
+		{
+			Name:    "https://frontend.example.com/healthz",
+			HTTP:    &HTTPDeployHealthCheck{URL: "https://frontend.example.com/healthz", Status: 200},
+			Timeout: DefaultDeployHealthCheckTimeout,
+		},
+		{
+			Name:    "api",
+			Exec:    &ExecDeployHealthCheck{Service: "api", Command: Command{Values: []string{"sh", "-c", "curl -f localhost:8080"}}},
+			Timeout: 30 * time.Second,
+			Retries: 3,
+		},
+		{
+			Name:    "https://frontend.example.com/missing",
+			HTTP:    &HTTPDeployHealthCheck{URL: "https://frontend.example.com/missing", Status: 404},
+			Timeout: DefaultDeployHealthCheckTimeout,
+		},
+	}
+	assert.Equal(t, expected, manifest.Deploy.HealthChecks)
+}
+
+func TestDeployHealthCheckValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		hc          DeployHealthCheck
+		expectedErr string
+	}{
+		{
+			name: "http",
+			hc:   DeployHealthCheck{HTTP: &HTTPDeployHealthCheck{URL: "https://example.com", Status: 200}},
+		},
+		{
+			name: "exec",
+			hc:   DeployHealthCheck{Exec: &ExecDeployHealthCheck{Service: "api", Command: Command{Values: []string{"true"}}}},
+		},
+		{
+			name:        "none",
+			hc:          DeployHealthCheck{Name: "empty"},
+			expectedErr: "'deploy.healthchecks[0]' must define either 'http' or 'exec'",
+		},
+		{
+			name: "both",
+			hc: DeployHealthCheck{
+				HTTP: &HTTPDeployHealthCheck{URL: "https://example.com", Status: 200},
+				Exec: &ExecDeployHealthCheck{Service: "api", Command: Command{Values: []string{"true"}}},
+			},
+			expectedErr: "'deploy.healthchecks[0]' must define either 'http' or 'exec'",
+		},
+		{
+			name:        "missing url",
+			hc:          DeployHealthCheck{HTTP: &HTTPDeployHealthCheck{Status: 200}},
+			expectedErr: "the field 'deploy.healthchecks[0].http.url' is mandatory",
+		},
+		{
+			name:        "invalid status",
+			hc:          DeployHealthCheck{HTTP: &HTTPDeployHealthCheck{URL: "https://example.com", Status: 1000}},
+			expectedErr: "'deploy.healthchecks[0].http.status' must be a valid HTTP status code",
+		},
+		{
+			name:        "missing service",
+			hc:          DeployHealthCheck{Exec: &ExecDeployHealthCheck{Command: Command{Values: []string{"true"}}}},
+			expectedErr: "the field 'deploy.healthchecks[0].exec.service' is mandatory",
+		},
+		{
+			name:        "missing command",
+			hc:          DeployHealthCheck{Exec: &ExecDeployHealthCheck{Service: "api"}},
+			expectedErr: "the field 'deploy.healthchecks[0].exec.command' is mandatory",
+		},
+		{
+			name:        "negative retries",
+			hc:          DeployHealthCheck{HTTP: &HTTPDeployHealthCheck{URL: "https://example.com", Status: 200}, Retries: -1},
+			expectedErr: "'deploy.healthchecks[0].retries' must be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.hc.validate(0)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
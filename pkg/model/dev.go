@@ -53,12 +53,14 @@ type Dev struct {
 	Selector             Selector              `json:"selector,omitempty" yaml:"selector,omitempty"`
 	PersistentVolumeInfo *PersistentVolumeInfo `json:"persistentVolume,omitempty" yaml:"persistentVolume,omitempty"`
 	SecurityContext      *SecurityContext      `json:"securityContext,omitempty" yaml:"securityContext,omitempty"`
+	RunAs                *RunAs                `json:"runAs,omitempty" yaml:"runAs,omitempty"`
 	Probes               *Probes               `json:"probes,omitempty" yaml:"probes,omitempty"`
 	NodeSelector         map[string]string     `json:"nodeSelector,omitempty" yaml:"nodeSelector,omitempty"`
 	Metadata             *Metadata             `json:"metadata,omitempty" yaml:"metadata,omitempty"`
 	Affinity             *Affinity             `json:"affinity,omitempty" yaml:"affinity,omitempty"`
 	Image                string                `json:"image,omitempty" yaml:"image,omitempty"`
 	Lifecycle            *Lifecycle            `json:"lifecycle,omitempty" yaml:"lifecycle,omitempty"`
+	AutoRestart          *AutoRestart          `json:"autoRestart,omitempty" yaml:"autoRestart,omitempty"`
 	Replicas             *int                  `json:"replicas,omitempty" yaml:"replicas,omitempty"`
 	InitContainer        InitContainer         `json:"initContainer,omitempty" yaml:"initContainer,omitempty"`
 	Workdir              string                `json:"workdir,omitempty" yaml:"workdir,omitempty"`
@@ -72,6 +74,7 @@ type Dev struct {
 	ImagePullPolicy      apiv1.PullPolicy `json:"imagePullPolicy,omitempty" yaml:"imagePullPolicy,omitempty"`
 
 	Tolerations     []apiv1.Toleration `json:"tolerations,omitempty" yaml:"tolerations,omitempty"`
+	HostAliases     []HostAlias        `json:"hostAliases,omitempty" yaml:"hostAliases,omitempty"`
 	Command         Command            `json:"command,omitempty" yaml:"command,omitempty"`
 	Forward         []forward.Forward  `json:"forward,omitempty" yaml:"forward,omitempty"`
 	Reverse         []Reverse          `json:"reverse,omitempty" yaml:"reverse,omitempty"`
@@ -87,7 +90,11 @@ type Dev struct {
 	RemotePort      int                `json:"remote,omitempty" yaml:"remote,omitempty"`
 	SSHServerPort   int                `json:"sshServerPort,omitempty" yaml:"sshServerPort,omitempty"`
 
-	Autocreate bool `json:"autocreate,omitempty" yaml:"autocreate,omitempty"`
+	// EnvironmentPassthrough are the patterns of the local variables set in the sessions of 'okteto up' and 'okteto exec'
+	EnvironmentPassthrough []string `json:"environmentPassthrough,omitempty" yaml:"environmentPassthrough,omitempty"`
+
+	Autocreate           bool `json:"autocreate,omitempty" yaml:"autocreate,omitempty"`
+	AllowPrivilegedPorts bool `json:"allowPrivilegedPorts,omitempty" yaml:"allowPrivilegedPorts,omitempty"`
 }
 
 type Affinity apiv1.Affinity
@@ -113,20 +120,38 @@ type Volume struct {
 	RemotePath string
 }
 
-// Sync represents a sync info in the development container
+// Sync represents a sync info in the development container.
+// RemoteExcludes are ignored only by the remote side of the synchronization, so files generated in the
+// development container never travel back to the local folders
 type Sync struct {
 	LocalPath      string       `json:"-" yaml:"-"`
 	RemotePath     string       `json:"-" yaml:"-"`
 	Folders        []SyncFolder `json:"folders,omitempty" yaml:"folders,omitempty"`
+	RemoteExcludes []string     `json:"remoteExcludes,omitempty" yaml:"remoteExcludes,omitempty"`
 	RescanInterval int          `json:"rescanInterval,omitempty" yaml:"rescanInterval,omitempty"`
+	ModTimeWindow  int          `json:"modTimeWindow,omitempty" yaml:"modTimeWindow,omitempty"`
 	Compression    bool         `json:"compression" yaml:"compression"`
 	Verbose        bool         `json:"verbose" yaml:"verbose"`
+	IgnorePerms    bool         `json:"ignorePerms,omitempty" yaml:"ignorePerms,omitempty"`
+	// PreservePermissions synchronizes the permissions of the files. It defaults to false on Windows and to true otherwise
+	PreservePermissions *bool `json:"preservePermissions,omitempty" yaml:"preservePermissions,omitempty"`
+	// UIDMap is the owner of the files synchronized to the development container
+	UIDMap *SyncUIDMap `json:"uidMap,omitempty" yaml:"uidMap,omitempty"`
+}
+
+// SyncUIDMap is the user and group of the development container that own the files synchronized from the local folders
+type SyncUIDMap struct {
+	UID *int64 `json:"uid,omitempty" yaml:"uid,omitempty"`
+	GID *int64 `json:"gid,omitempty" yaml:"gid,omitempty"`
 }
 
-// SyncFolder represents a sync folder in the development container
+// SyncFolder represents a sync folder in the development container.
+// IgnorePerms and ModTimeWindow override the values of the sync field when they are set
 type SyncFolder struct {
-	LocalPath  string `json:"localPath,omitempty" yaml:"localPath,omitempty"`
-	RemotePath string `json:"remotePath,omitempty" yaml:"remotePath,omitempty"`
+	IgnorePerms   *bool  `json:"ignorePerms,omitempty" yaml:"ignorePerms,omitempty"`
+	ModTimeWindow *int   `json:"modTimeWindow,omitempty" yaml:"modTimeWindow,omitempty"`
+	LocalPath     string `json:"localPath,omitempty" yaml:"localPath,omitempty"`
+	RemotePath    string `json:"remotePath,omitempty" yaml:"remotePath,omitempty"`
 }
 
 // ExternalVolume represents a external volume in the development container
@@ -145,6 +170,7 @@ type PersistentVolumeInfo struct {
 	StorageClass string                           `json:"storageClass,omitempty" yaml:"storageClass,omitempty"`
 	VolumeMode   apiv1.PersistentVolumeMode       `json:"volumeMode,omitempty" yaml:"volumeMode,omitempty"`
 	Enabled      bool                             `json:"enabled,omitempty" yaml:"enabled"`
+	AutoExpand   bool                             `json:"autoExpand,omitempty" yaml:"autoExpand,omitempty"`
 }
 
 // InitContainer represents the initial container
@@ -198,9 +224,15 @@ type Reverse struct {
 }
 
 // ResourceRequirements describes the compute resource requirements.
+// When Scale, Max or Unlimited are set, the requests and limits of the original container are inherited,
+// multiplied by Scale and capped by Max. Requests and Limits act as absolute overrides of the inherited values
 type ResourceRequirements struct {
-	Limits   ResourceList `json:"limits,omitempty" yaml:"limits,omitempty"`
-	Requests ResourceList `json:"requests,omitempty" yaml:"requests,omitempty"`
+	Limits    ResourceList `json:"limits,omitempty" yaml:"limits,omitempty"`
+	Requests  ResourceList `json:"requests,omitempty" yaml:"requests,omitempty"`
+	Max       ResourceList `json:"max,omitempty" yaml:"max,omitempty"`
+	GPUs      GPUResources `json:"gpus,omitempty" yaml:"gpus,omitempty"`
+	Scale     float64      `json:"scale,omitempty" yaml:"scale,omitempty"`
+	Unlimited bool         `json:"unlimited,omitempty" yaml:"unlimited,omitempty"`
 }
 
 // Probes defines probes for containers
@@ -247,7 +279,7 @@ func NewDev() *Dev {
 		PersistentVolumeInfo: &PersistentVolumeInfo{Enabled: true},
 		Probes:               &Probes{},
 		Lifecycle:            &Lifecycle{},
-		InitContainer:        InitContainer{Image: config.NewImageConfig(oktetoLog.GetOutputWriter()).GetCliImage()},
+		InitContainer:        InitContainer{Image: config.NewImageConfig(oktetoLog.GetOutputWriter()).GetBinImage()},
 		Metadata: &Metadata{
 			Labels:      Labels{},
 			Annotations: Annotations{},
@@ -379,7 +411,7 @@ func (dev *Dev) SetDefaults() error {
 	}
 
 	if dev.InitContainer.Image == "" {
-		dev.InitContainer.Image = config.NewImageConfig(oktetoLog.GetOutputWriter()).GetCliImage()
+		dev.InitContainer.Image = config.NewImageConfig(oktetoLog.GetOutputWriter()).GetBinImage()
 	}
 
 	if dev.Probes == nil {
@@ -392,10 +424,14 @@ func (dev *Dev) SetDefaults() error {
 		dev.Interface = Localhost
 	}
 	if dev.SSHServerPort == 0 {
-		dev.SSHServerPort = oktetoDefaultSSHServerPort
+		dev.SSHServerPort = dev.defaultSSHServerPort()
 	}
 
+	dev.setRunAsDefaults()
 	dev.setRunAsUserDefaults(dev)
+	dev.setPrivilegedPortsDefaults()
+	dev.setAutoRestartDefaults()
+	dev.setGPUDefaults()
 
 	if os.Getenv(OktetoRescanIntervalEnvVar) != "" {
 		rescanInterval, err := strconv.Atoi(os.Getenv(OktetoRescanIntervalEnvVar))
@@ -427,6 +463,7 @@ func (dev *Dev) SetDefaults() error {
 			s.Selector = map[string]string{}
 		}
 		s.setRunAsUserDefaults(dev)
+		s.setGPUDefaults()
 		s.Forward = make([]forward.Forward, 0)
 		s.Reverse = make([]Reverse, 0)
 		s.Secrets = make([]Secret, 0)
@@ -448,6 +485,44 @@ func (dev *Dev) SetDefaults() error {
 	return nil
 }
 
+// setGPUDefaults adds the GPUs to the resource limits and requests, and the tolerations and node selector of the GPU nodes
+func (dev *Dev) setGPUDefaults() {
+	gpus := dev.Resources.GPUs
+	if len(gpus) == 0 {
+		return
+	}
+	if dev.Resources.Limits == nil {
+		dev.Resources.Limits = ResourceList{}
+	}
+	if dev.Resources.Requests == nil {
+		dev.Resources.Requests = ResourceList{}
+	}
+	gpus.AddTo(apiv1.ResourceList(dev.Resources.Limits), apiv1.ResourceList(dev.Resources.Requests))
+
+	for _, t := range gpus.Tolerations() {
+		if !hasTolerationKey(dev.Tolerations, t.Key) {
+			dev.Tolerations = append(dev.Tolerations, t)
+		}
+	}
+	for k, v := range gpus.NodeSelector() {
+		if dev.NodeSelector == nil {
+			dev.NodeSelector = map[string]string{}
+		}
+		if _, ok := dev.NodeSelector[k]; !ok {
+			dev.NodeSelector[k] = v
+		}
+	}
+}
+
+func hasTolerationKey(tolerations []apiv1.Toleration, key string) bool {
+	for _, t := range tolerations {
+		if t.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
 func (dev *Dev) setRunAsUserDefaults(main *Dev) {
 	if !main.PersistentVolumeEnabled() {
 		return
@@ -557,6 +632,12 @@ func (dev *Dev) Validate() error {
 	if err := dev.validateSecurityContext(); err != nil {
 		return err
 	}
+	if err := dev.validateRunAs(); err != nil {
+		return err
+	}
+	if err := dev.validateHostAliases(); err != nil {
+		return err
+	}
 	if err := dev.validatePersistentVolume(); err != nil {
 		return err
 	}
@@ -572,6 +653,19 @@ func (dev *Dev) Validate() error {
 	if err := dev.validateSync(); err != nil {
 		return err
 	}
+	dev.warnWorkdirNotMounted()
+
+	if err := dev.validateInterfaces(); err != nil {
+		return err
+	}
+
+	if err := dev.validateAutoRestart(); err != nil {
+		return err
+	}
+
+	if err := ValidateEnvPassthrough(dev.EnvironmentPassthrough); err != nil {
+		return err
+	}
 
 	if _, err := resource.ParseQuantity(dev.PersistentVolumeSize()); err != nil {
 		return fmt.Errorf("'persistentVolume.size' is not valid. A sample value would be '10Gi'")
@@ -581,10 +675,21 @@ func (dev *Dev) Validate() error {
 		return fmt.Errorf("'sshServerPort' must be > 0")
 	}
 
+	if err := dev.validateReservedPorts(); err != nil {
+		return err
+	}
+
+	if err := dev.Resources.validate(); err != nil {
+		return err
+	}
+
 	for _, s := range dev.Services {
 		if err := validatePullPolicy(s.ImagePullPolicy); err != nil {
 			return err
 		}
+		if err := s.Resources.validate(); err != nil {
+			return err
+		}
 		if err := s.validateVolumes(dev); err != nil {
 			return err
 		}
@@ -609,7 +714,27 @@ func (dev *Dev) PreparePathsAndExpandEnvFiles(manifestPath string, fs afero.Fs)
 }
 
 func (dev *Dev) validateSync() error {
+	if dev.Sync.ModTimeWindow < 0 {
+		return fmt.Errorf("'sync.modTimeWindow' must be >= 0")
+	}
+	if dev.Sync.IgnorePerms && dev.Sync.PreservePermissions != nil && *dev.Sync.PreservePermissions {
+		return fmt.Errorf("'sync.ignorePerms' and 'sync.preservePermissions' can't be set to true together")
+	}
+	if uidMap := dev.Sync.UIDMap; uidMap != nil {
+		if uidMap.UID == nil {
+			return fmt.Errorf("'sync.uidMap.uid' is required")
+		}
+		if *uidMap.UID < 0 {
+			return fmt.Errorf("'sync.uidMap.uid' must be >= 0")
+		}
+		if uidMap.GID != nil && *uidMap.GID < 0 {
+			return fmt.Errorf("'sync.uidMap.gid' must be >= 0")
+		}
+	}
 	for _, folder := range dev.Sync.Folders {
+		if folder.ModTimeWindow != nil && *folder.ModTimeWindow < 0 {
+			return fmt.Errorf("'modTimeWindow' of sync folder '%s' must be >= 0", folder.LocalPath)
+		}
 		validPath, err := os.Stat(folder.LocalPath)
 
 		if err != nil {
@@ -637,6 +762,24 @@ func (dev *Dev) validateSync() error {
 	return nil
 }
 
+func (dev *Dev) validateInterfaces() error {
+	if err := forward.ValidateInterface(dev.Interface); err != nil {
+		return oktetoErrors.UserError{
+			E:    err,
+			Hint: "Update the 'interface' field in your okteto manifest file to 'localhost', '0.0.0.0' or an IP address of your local machine",
+		}
+	}
+	for _, f := range dev.Forward {
+		if err := forward.ValidateInterface(f.Interface); err != nil {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("invalid forward '%s': %w", f, err),
+				Hint: "Update the 'interface' field of the forward in your okteto manifest file to 'localhost', '0.0.0.0' or an IP address of your local machine",
+			}
+		}
+	}
+	return nil
+}
+
 func validatePullPolicy(pullPolicy apiv1.PullPolicy) error {
 	switch pullPolicy {
 	case apiv1.PullAlways:
@@ -704,7 +847,7 @@ func (dev *Dev) AssignRemotePort() {
 		return
 	}
 
-	p, err := GetAvailablePort(dev.Interface)
+	p, err := dev.GetAvailableLocalPort()
 	if err != nil {
 		oktetoLog.Infof("failed to get random port for SSH connection: %s", err)
 		p = oktetoDefaultSSHServerPort
@@ -819,7 +962,7 @@ func (dev *Dev) ToTranslationRule(main *Dev, namespace, manifestName, username s
 		ImagePullPolicy:   dev.ImagePullPolicy,
 		Environment:       dev.Environment,
 		Secrets:           dev.Secrets,
-		WorkDir:           dev.Workdir,
+		WorkDir:           dev.GetWorkdir(),
 		PersistentVolume:  main.PersistentVolumeEnabled(),
 		Volumes:           []VolumeMount{},
 		SecurityContext:   dev.SecurityContext,
@@ -831,13 +974,17 @@ func (dev *Dev) ToTranslationRule(main *Dev, namespace, manifestName, username s
 		Lifecycle:         dev.Lifecycle,
 		NodeSelector:      dev.NodeSelector,
 		Affinity:          (*apiv1.Affinity)(dev.Affinity),
+		HostAliases:       hostAliasesToPodSpec(dev.HostAliases),
 	}
+	rule.Environment = append(rule.Environment, hostAliasesEnvironment(dev.HostAliases, dev.Environment)...)
 
 	if main.PersistentVolumeEnabled() {
 		rule.MainVolumeName = main.GetVolumeName()
 		rule.VolumeAccessMode = main.PersistentVolumeAccessMode()
 		rule.Namespace = namespace
 		rule.ManifestName = manifestName
+	} else {
+		rule.VolumeSizeLimit = main.EphemeralVolumeSizeLimit()
 	}
 
 	if dev.IsHybridModeEnabled() {
@@ -958,15 +1105,31 @@ func (dev *Dev) ToTranslationRule(main *Dev, namespace, manifestName, username s
 			)
 		}
 		for _, sync := range dev.Sync.Folders {
+			subPath := main.getSourceSubPath(sync.LocalPath)
+			if main != dev && main.isServiceSyncFolder(sync.LocalPath) {
+				subPath = getServiceSyncSubPath(sync.LocalPath)
+			}
 			rule.Volumes = append(
 				rule.Volumes,
 				VolumeMount{
 					Name:      main.GetVolumeName(),
 					MountPath: sync.RemotePath,
-					SubPath:   main.getSourceSubPath(sync.LocalPath),
+					SubPath:   subPath,
 				},
 			)
 		}
+		if main == dev {
+			for _, sync := range dev.GetServicesSyncFolders() {
+				rule.Volumes = append(
+					rule.Volumes,
+					VolumeMount{
+						Name:      main.GetVolumeName(),
+						MountPath: sync.RemotePath,
+						SubPath:   getServiceSyncSubPath(sync.LocalPath),
+					},
+				)
+			}
+		}
 		enableHistoryVolume(rule, main)
 	}
 
@@ -1058,7 +1221,19 @@ func (s *Secret) GetFileName() string {
 
 // HasEmptyResources returns true if the dev resources are empty (no resources specified in okteto manifest)
 func (r *ResourceRequirements) HasEmptyResources() bool {
-	return len(r.Requests) == 0 && len(r.Limits) == 0
+	return len(r.Requests) == 0 && len(r.Limits) == 0 && !r.IsInherited()
+}
+
+// IsInherited returns true if the dev resources are computed from the resources of the original container
+func (r *ResourceRequirements) IsInherited() bool {
+	return r.Scale != 0 || len(r.Max) > 0 || r.Unlimited
+}
+
+func (r *ResourceRequirements) validate() error {
+	if r.Scale < 0 {
+		return fmt.Errorf("'resources.scale' must be greater than 0")
+	}
+	return nil
 }
 
 // HasEmptyNodeSelector checks if the dev configuration has an empty nodeSelector
@@ -1147,6 +1322,11 @@ func DevCloneName(name string) string {
 	return fmt.Sprintf("%s-okteto", name)
 }
 
+// DevCloneOriginalName returns the name of the app cloned by the dev clone with the given name
+func DevCloneOriginalName(cloneName string) (string, bool) {
+	return strings.CutSuffix(cloneName, DevCloneName(""))
+}
+
 func (dev *Dev) IsInteractive() bool {
 	if len(dev.Command.Values) == 0 {
 		return true
@@ -0,0 +1,77 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/okteto/okteto/pkg/env"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// DevImages overrides the helper images injected by okteto up, e.g. to pull them from a mirror in air-gapped clusters
+type DevImages struct {
+	// Bin is the image of the init container that copies the okteto binaries to the development container
+	Bin string `json:"bin,omitempty" yaml:"bin,omitempty"`
+	// Sandbox is the image of the development containers created by okteto up when they don't define one
+	Sandbox string `json:"sandbox,omitempty" yaml:"sandbox,omitempty"`
+}
+
+func (d *DevImages) expandEnvVars() error {
+	var err error
+	d.Bin, err = env.ExpandEnvIfNotEmpty(d.Bin)
+	if err != nil {
+		return err
+	}
+	d.Sandbox, err = env.ExpandEnvIfNotEmpty(d.Sandbox)
+	return err
+}
+
+func (d *DevImages) validate() error {
+	if d == nil {
+		return nil
+	}
+	images := []struct {
+		field string
+		image string
+	}{
+		{field: "bin", image: d.Bin},
+		{field: "sandbox", image: d.Sandbox},
+	}
+	for _, i := range images {
+		if i.image == "" {
+			continue
+		}
+		if _, err := name.ParseReference(i.image); err != nil {
+			return fmt.Errorf("the field 'devImages.%s' is not a valid image reference: %w", i.field, err)
+		}
+	}
+	return nil
+}
+
+// mergeDevImages sets the helper images of the manifest to the dev.
+// The init container image defined by the dev takes precedence
+func (dev *Dev) mergeDevImages(d *DevImages) {
+	if d == nil || dev == nil {
+		return
+	}
+	if d.Bin != "" && (dev.InitContainer.Image == "" || dev.InitContainer.Image == config.NewImageConfig(oktetoLog.GetOutputWriter()).GetBinImage()) {
+		dev.InitContainer.Image = d.Bin
+	}
+	if d.Sandbox != "" && dev.Autocreate && dev.Image == "" {
+		dev.Image = d.Sandbox
+	}
+}
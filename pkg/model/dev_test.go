@@ -934,6 +934,52 @@ func Test_validate(t *testing.T) {
         runAsGroup: 0`),
 			expectErr: false,
 		},
+		{
+			name: "interface-all-addresses",
+			manifest: []byte(`dev:
+    deployment:
+      sync:
+        - .:/app
+      interface: 0.0.0.0
+      forward:
+        - localPort: 8080
+          remotePort: 8080
+          interface: 127.0.0.1`),
+			expectErr: false,
+		},
+		{
+			name: "interface-not-local",
+			manifest: []byte(`dev:
+    deployment:
+      sync:
+        - .:/app
+      interface: 203.0.113.10`),
+			expectErr: true,
+		},
+		{
+			name: "forward-interface-not-local",
+			manifest: []byte(`dev:
+    deployment:
+      sync:
+        - .:/app
+      forward:
+        - localPort: 8080
+          remotePort: 8080
+          interface: 203.0.113.10`),
+			expectErr: true,
+		},
+		{
+			name: "forward-interface-not-ip",
+			manifest: []byte(`dev:
+    deployment:
+      sync:
+        - .:/app
+      forward:
+        - localPort: 8080
+          remotePort: 8080
+          interface: my-laptop`),
+			expectErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1590,3 +1636,47 @@ func TestDev_HasEmptyNodeSelector(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateSyncOwnership(t *testing.T) {
+	uid := int64(1001)
+	negative := int64(-1)
+	tests := []struct {
+		name        string
+		sync        Sync
+		expectedErr string
+	}{
+		{
+			name: "default",
+		},
+		{
+			name: "uid and gid",
+			sync: Sync{UIDMap: &SyncUIDMap{UID: &uid, GID: &uid}},
+		},
+		{
+			name:        "missing uid",
+			sync:        Sync{UIDMap: &SyncUIDMap{GID: &uid}},
+			expectedErr: "'sync.uidMap.uid' is required",
+		},
+		{
+			name:        "negative gid",
+			sync:        Sync{UIDMap: &SyncUIDMap{UID: &uid, GID: &negative}},
+			expectedErr: "'sync.uidMap.gid' must be >= 0",
+		},
+		{
+			name:        "ignore and preserve permissions",
+			sync:        Sync{IgnorePerms: true, PreservePermissions: &[]bool{true}[0]},
+			expectedErr: "'sync.ignorePerms' and 'sync.preservePermissions' can't be set to true together",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev := &Dev{Sync: tt.sync}
+			err := dev.validateSync()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
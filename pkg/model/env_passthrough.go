@@ -0,0 +1,75 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// ValidateEnvPassthrough checks that the patterns of 'environmentPassthrough' are valid, like 'AWS_*' or 'GITHUB_TOKEN'
+func ValidateEnvPassthrough(patterns []string) error {
+	for _, pattern := range patterns {
+		if pattern == "" || strings.Contains(pattern, "=") {
+			return fmt.Errorf("'environmentPassthrough' pattern '%s' must be the name of a variable or a pattern like 'AWS_*'", pattern)
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("'environmentPassthrough' pattern '%s' is not valid: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// GetPassthroughEnvironment returns the variables of environ whose names match the patterns of 'environmentPassthrough',
+// sorted by name. The variables defined in the 'environment' of the development container take precedence and are skipped.
+// They are only set in the session running the command, never in the spec of the development container nor in its command line.
+// The values are masked in the logs
+func (dev *Dev) GetPassthroughEnvironment(environ []string) []string {
+	if len(dev.EnvironmentPassthrough) == 0 {
+		return nil
+	}
+	defined := map[string]bool{}
+	for _, e := range dev.Environment {
+		defined[e.Name] = true
+	}
+
+	result := []string{}
+	for _, kv := range environ {
+		name, value, found := strings.Cut(kv, "=")
+		if !found || name == "" || !matchesEnvPassthrough(dev.EnvironmentPassthrough, name) {
+			continue
+		}
+		if defined[name] {
+			oktetoLog.Infof("'%s' is defined in the environment of '%s' and it is not passed through", name, dev.Name)
+			continue
+		}
+		oktetoLog.AddSecret(value)
+		result = append(result, kv)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func matchesEnvPassthrough(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, err := path.Match(pattern, name); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
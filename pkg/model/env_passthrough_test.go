@@ -0,0 +1,116 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/env"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateEnvPassthrough(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		wantErr  bool
+	}{
+		{
+			name:     "names and patterns",
+			patterns: []string{"AWS_*", "GITHUB_TOKEN", "NPM_?OKEN", "[AB]_KEY"},
+		},
+		{
+			name:     "empty pattern",
+			patterns: []string{""},
+			wantErr:  true,
+		},
+		{
+			name:     "variable with value",
+			patterns: []string{"AWS_REGION=us-east-1"},
+			wantErr:  true,
+		},
+		{
+			name:     "malformed pattern",
+			patterns: []string{"AWS_[*"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEnvPassthrough(tt.patterns)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGetPassthroughEnvironment(t *testing.T) {
+	environ := []string{
+		"AWS_SECRET_ACCESS_KEY=aws-secret-value",
+		"AWS_REGION=eu-west-1",
+		"GITHUB_TOKEN=github-token-value",
+		"GITHUB_USER=okteto",
+		"HOME=/home/okteto",
+		"EMPTY=",
+	}
+	tests := []struct {
+		name     string
+		dev      *Dev
+		expected []string
+	}{
+		{
+			name:     "no patterns",
+			dev:      &Dev{},
+			expected: nil,
+		},
+		{
+			name:     "pattern and exact name",
+			dev:      &Dev{EnvironmentPassthrough: []string{"AWS_*", "GITHUB_TOKEN"}},
+			expected: []string{"AWS_REGION=eu-west-1", "AWS_SECRET_ACCESS_KEY=aws-secret-value", "GITHUB_TOKEN=github-token-value"},
+		},
+		{
+			name: "environment of the manifest takes precedence",
+			dev: &Dev{
+				EnvironmentPassthrough: []string{"AWS_*"},
+				Environment:            env.Environment{{Name: "AWS_REGION", Value: "us-east-1"}},
+			},
+			expected: []string{"AWS_SECRET_ACCESS_KEY=aws-secret-value"},
+		},
+		{
+			name:     "empty values are passed through",
+			dev:      &Dev{EnvironmentPassthrough: []string{"EMPTY"}},
+			expected: []string{"EMPTY="},
+		},
+		{
+			name:     "no matches",
+			dev:      &Dev{EnvironmentPassthrough: []string{"GCP_*"}},
+			expected: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.dev.GetPassthroughEnvironment(environ))
+		})
+	}
+}
+
+func TestGetPassthroughEnvironmentMasksValues(t *testing.T) {
+	dev := &Dev{EnvironmentPassthrough: []string{"PASSTHROUGH_*"}}
+	dev.GetPassthroughEnvironment([]string{"PASSTHROUGH_TOKEN=passthrough-secret"})
+	assert.Equal(t, "token: ***", oktetoLog.MaskSecrets("token: passthrough-secret"))
+}
@@ -23,6 +23,7 @@ const MalformedPortForward = "wrong port-forward syntax '%s', must be of the for
 type Forward struct {
 	Labels      map[string]string `json:"labels" yaml:"labels"`
 	ServiceName string            `json:"name" yaml:"name"`
+	Interface   string            `json:"interface,omitempty" yaml:"interface,omitempty"`
 	Local       int               `json:"localPort" yaml:"localPort"`
 	Remote      int               `json:"remotePort" yaml:"remotePort"`
 	Service     bool              `json:"-" yaml:"-"`
@@ -37,6 +38,14 @@ func (f Forward) String() string {
 	return fmt.Sprintf("%d:%d", f.Local, f.Remote)
 }
 
+// GetInterface returns the interface where the forward listens, or the given default one if it's not defined
+func (f Forward) GetInterface(defaultInterface string) string {
+	if f.Interface != "" {
+		return f.Interface
+	}
+	return defaultInterface
+}
+
 func (f *Forward) Less(c *Forward) bool {
 	if !f.Service && !c.Service {
 		return f.Local < c.Local
@@ -22,6 +22,7 @@ import (
 type Raw struct {
 	Labels      map[string]string `json:"labels" yaml:"labels"`
 	ServiceName string            `json:"name" yaml:"name"`
+	Interface   string            `json:"interface,omitempty" yaml:"interface,omitempty"`
 	Local       int               `json:"localPort" yaml:"localPort"`
 	Remote      int               `json:"remotePort" yaml:"remotePort"`
 	Service     bool              `json:"-" yaml:"-"`
@@ -88,6 +89,7 @@ func (f *Forward) UnmarshalExtendedForm(unmarshal func(interface{}) error) error
 	f.Remote = rawForward.Remote
 	f.ServiceName = rawForward.ServiceName
 	f.Labels = rawForward.Labels
+	f.Interface = rawForward.Interface
 	if len(rawForward.Labels) != 0 || rawForward.ServiceName != "" {
 		f.Service = true
 	}
@@ -177,3 +177,20 @@ func TestForwardExtended_MarshalYAML(t *testing.T) {
 		})
 	}
 }
+
+func TestForwardExtended_UnmarshalYAMLWithInterface(t *testing.T) {
+	data := `
+localPort: 8080
+remotePort: 9090
+interface: 0.0.0.0`
+
+	var result Forward
+	if err := yaml.Unmarshal([]byte(data), &result); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Forward{Local: 8080, Remote: 9090, Interface: "0.0.0.0"}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("didn't unmarshal correctly. Actual '%+v', Expected '%+v'", result, expected)
+	}
+}
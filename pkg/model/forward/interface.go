@@ -0,0 +1,63 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forward
+
+import (
+	"fmt"
+	"net"
+)
+
+const localhostInterface = "localhost"
+
+// getInterfaceAddrs returns the addresses of the local machine
+var getInterfaceAddrs = net.InterfaceAddrs
+
+// ValidateInterface checks that the forwards can listen in the interface: it must be localhost,
+// all the interfaces (0.0.0.0 or ::) or one of the addresses of the local machine
+func ValidateInterface(iface string) error {
+	if iface == "" || iface == localhostInterface {
+		return nil
+	}
+
+	ip := net.ParseIP(iface)
+	if ip == nil {
+		return fmt.Errorf("interface '%s' is not a valid IP address", iface)
+	}
+
+	if ip.IsUnspecified() || ip.IsLoopback() {
+		return nil
+	}
+
+	addrs, err := getInterfaceAddrs()
+	if err != nil {
+		return fmt.Errorf("failed to get the addresses of your local machine: %w", err)
+	}
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if ok && ipNet.IP.Equal(ip) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("interface '%s' is not an address of your local machine", iface)
+}
+
+// IsExposedInterface returns true if the interface accepts connections from other machines
+func IsExposedInterface(iface string) bool {
+	if iface == "" || iface == localhostInterface {
+		return false
+	}
+	ip := net.ParseIP(iface)
+	return ip == nil || !ip.IsLoopback()
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forward
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateInterface(t *testing.T) {
+	getInterfaceAddrs = func() ([]net.Addr, error) {
+		return []net.Addr{
+			&net.IPNet{IP: net.ParseIP("127.0.0.1"), Mask: net.CIDRMask(8, 32)},
+			&net.IPNet{IP: net.ParseIP("192.168.1.20"), Mask: net.CIDRMask(24, 32)},
+		}, nil
+	}
+	t.Cleanup(func() { getInterfaceAddrs = net.InterfaceAddrs })
+
+	tests := []struct {
+		name        string
+		iface       string
+		expectedErr string
+	}{
+		{name: "empty", iface: ""},
+		{name: "localhost", iface: "localhost"},
+		{name: "loopback", iface: "127.0.0.1"},
+		{name: "all interfaces", iface: "0.0.0.0"},
+		{name: "all ipv6 interfaces", iface: "::"},
+		{name: "local address", iface: "192.168.1.20"},
+		{name: "remote address", iface: "192.168.1.21", expectedErr: "interface '192.168.1.21' is not an address of your local machine"},
+		{name: "hostname", iface: "example.com", expectedErr: "interface 'example.com' is not a valid IP address"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateInterface(tt.iface)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateInterfaceAddrsError(t *testing.T) {
+	getInterfaceAddrs = func() ([]net.Addr, error) {
+		return nil, errors.New("permission denied")
+	}
+	t.Cleanup(func() { getInterfaceAddrs = net.InterfaceAddrs })
+
+	assert.ErrorContains(t, ValidateInterface("192.168.1.20"), "permission denied")
+}
+
+func TestIsExposedInterface(t *testing.T) {
+	assert.False(t, IsExposedInterface(""))
+	assert.False(t, IsExposedInterface("localhost"))
+	assert.False(t, IsExposedInterface("127.0.0.1"))
+	assert.True(t, IsExposedInterface("0.0.0.0"))
+	assert.True(t, IsExposedInterface("192.168.1.20"))
+}
+
+func TestForwardGetInterface(t *testing.T) {
+	assert.Equal(t, "localhost", Forward{Local: 8080, Remote: 8080}.GetInterface("localhost"))
+	assert.Equal(t, "0.0.0.0", Forward{Local: 8080, Remote: 8080, Interface: "0.0.0.0"}.GetInterface("localhost"))
+}
@@ -0,0 +1,117 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/okteto/okteto/pkg/env"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// NvidiaGPUResource is the extended resource exposed by the nvidia device plugin
+	NvidiaGPUResource apiv1.ResourceName = "nvidia.com/gpu"
+
+	// nvidiaGPUNodeLabel is the label set by the nvidia GPU feature discovery on nodes with GPUs
+	nvidiaGPUNodeLabel = "nvidia.com/gpu.present"
+)
+
+// GPUResources is the shorthand to request GPUs. It unmarshals from a count of nvidia.com/gpu
+// or from a map of extended resource names to counts
+type GPUResources map[apiv1.ResourceName]resource.Quantity
+
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+func (g *GPUResources) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	raw := map[apiv1.ResourceName]string{}
+	if err := unmarshal(&raw); err != nil {
+		var count string
+		if err := unmarshal(&count); err != nil {
+			return fmt.Errorf("'gpus' must be a number or a map of resource names to numbers")
+		}
+		raw = map[apiv1.ResourceName]string{NvidiaGPUResource: count}
+	}
+
+	result := GPUResources{}
+	for name, value := range raw {
+		q, err := resource.ParseQuantity(value)
+		if err != nil {
+			return fmt.Errorf("invalid 'gpus' value '%s' for '%s': %w", value, name, err)
+		}
+		if q.Sign() < 0 || q.MilliValue()%1000 != 0 {
+			return fmt.Errorf("invalid 'gpus' value '%s' for '%s': GPUs must be a whole number", value, name)
+		}
+		result[name] = q
+	}
+	*g = result
+	return nil
+}
+
+// MarshalYAML Implements the marshaler interface of the yaml pkg.
+func (g GPUResources) MarshalYAML() (interface{}, error) {
+	return ResourceList(g).MarshalYAML()
+}
+
+// names returns the sorted resource names of the GPUs
+func (g GPUResources) names() []apiv1.ResourceName {
+	result := make([]apiv1.ResourceName, 0, len(g))
+	for name := range g {
+		result = append(result, name)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+// gpuSchedulingEnabled returns true if OKTETO_GPU_TOLERATION is set, so workloads with GPUs are scheduled on GPU nodes
+func gpuSchedulingEnabled() bool {
+	return env.LoadBoolean(OktetoGPUTolerationEnvVar)
+}
+
+// Tolerations returns the tolerations to schedule the GPUs on tainted GPU nodes when OKTETO_GPU_TOLERATION is set
+func (g GPUResources) Tolerations() []apiv1.Toleration {
+	if len(g) == 0 || !gpuSchedulingEnabled() {
+		return nil
+	}
+	result := []apiv1.Toleration{}
+	for _, name := range g.names() {
+		result = append(result, apiv1.Toleration{
+			Key:      string(name),
+			Operator: apiv1.TolerationOpExists,
+			Effect:   apiv1.TaintEffectNoSchedule,
+		})
+	}
+	return result
+}
+
+// NodeSelector returns the node selector of the nvidia GPU nodes when OKTETO_GPU_TOLERATION is set
+func (g GPUResources) NodeSelector() map[string]string {
+	if _, ok := g[NvidiaGPUResource]; !ok || !gpuSchedulingEnabled() {
+		return nil
+	}
+	return map[string]string{nvidiaGPUNodeLabel: "true"}
+}
+
+// AddTo sets the GPUs in the limits and requests of the resource lists, as extended resources
+// must have the same value in both. GPUs already defined in the limits are not overridden
+func (g GPUResources) AddTo(limits, requests apiv1.ResourceList) {
+	for name, q := range g {
+		if v, ok := limits[name]; ok {
+			q = v
+		}
+		limits[name] = q
+		requests[name] = q
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestGPUResourcesUnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		expected    GPUResources
+		name        string
+		data        string
+		expectedErr bool
+	}{
+		{
+			name:     "count",
+			data:     `1`,
+			expected: GPUResources{NvidiaGPUResource: resource.MustParse("1")},
+		},
+		{
+			name:     "count as string",
+			data:     `"2"`,
+			expected: GPUResources{NvidiaGPUResource: resource.MustParse("2")},
+		},
+		{
+			name: "map of resources",
+			data: "nvidia.com/gpu: 2\namd.com/gpu: \"1\"",
+			expected: GPUResources{
+				NvidiaGPUResource: resource.MustParse("2"),
+				"amd.com/gpu":     resource.MustParse("1"),
+			},
+		},
+		{
+			name:        "fractional count",
+			data:        `0.5`,
+			expectedErr: true,
+		},
+		{
+			name:        "fractional quantity in map",
+			data:        `nvidia.com/gpu: 500m`,
+			expectedErr: true,
+		},
+		{
+			name:        "negative count",
+			data:        `-1`,
+			expectedErr: true,
+		},
+		{
+			name:        "invalid count",
+			data:        `one`,
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result GPUResources
+			err := yaml.Unmarshal([]byte(tt.data), &result)
+			if tt.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestGPUResourcesMarshalYAML(t *testing.T) {
+	gpus := GPUResources{NvidiaGPUResource: resource.MustParse("2")}
+
+	out, err := yaml.Marshal(gpus)
+	require.NoError(t, err)
+
+	var result GPUResources
+	require.NoError(t, yaml.Unmarshal(out, &result))
+	require.Equal(t, gpus, result)
+}
+
+func TestGPUResourcesScheduling(t *testing.T) {
+	gpus := GPUResources{
+		NvidiaGPUResource: resource.MustParse("1"),
+		"amd.com/gpu":     resource.MustParse("1"),
+	}
+
+	assert.Nil(t, gpus.Tolerations())
+	assert.Nil(t, gpus.NodeSelector())
+
+	t.Setenv(OktetoGPUTolerationEnvVar, "true")
+	assert.Equal(t, []apiv1.Toleration{
+		{Key: "amd.com/gpu", Operator: apiv1.TolerationOpExists, Effect: apiv1.TaintEffectNoSchedule},
+		{Key: "nvidia.com/gpu", Operator: apiv1.TolerationOpExists, Effect: apiv1.TaintEffectNoSchedule},
+	}, gpus.Tolerations())
+	assert.Equal(t, map[string]string{"nvidia.com/gpu.present": "true"}, gpus.NodeSelector())
+
+	assert.Nil(t, GPUResources{"amd.com/gpu": resource.MustParse("1")}.NodeSelector())
+	assert.Nil(t, GPUResources{}.Tolerations())
+}
+
+func TestDevSetGPUDefaults(t *testing.T) {
+	tests := []struct {
+		expectedLimits      ResourceList
+		expectedRequests    ResourceList
+		expectedSelector    map[string]string
+		name                string
+		manifest            string
+		expectedTolerations []apiv1.Toleration
+		tolerationEnabled   bool
+	}{
+		{
+			name: "gpus count",
+			manifest: `name: test
+image: okteto/test
+resources:
+  gpus: 1
+  limits:
+    cpu: 1`,
+			expectedLimits: ResourceList{
+				apiv1.ResourceCPU: resource.MustParse("1"),
+				NvidiaGPUResource: resource.MustParse("1"),
+			},
+			expectedRequests: ResourceList{
+				NvidiaGPUResource: resource.MustParse("1"),
+			},
+		},
+		{
+			name: "explicit limit wins",
+			manifest: `name: test
+image: okteto/test
+resources:
+  gpus: 1
+  limits:
+    nvidia.com/gpu: 2`,
+			expectedLimits: ResourceList{
+				NvidiaGPUResource: resource.MustParse("2"),
+			},
+			expectedRequests: ResourceList{
+				NvidiaGPUResource: resource.MustParse("2"),
+			},
+		},
+		{
+			name: "gpu toleration",
+			manifest: `name: test
+image: okteto/test
+resources:
+  gpus: 1`,
+			tolerationEnabled: true,
+			expectedLimits: ResourceList{
+				NvidiaGPUResource: resource.MustParse("1"),
+			},
+			expectedRequests: ResourceList{
+				NvidiaGPUResource: resource.MustParse("1"),
+			},
+			expectedTolerations: []apiv1.Toleration{
+				{Key: "nvidia.com/gpu", Operator: apiv1.TolerationOpExists, Effect: apiv1.TaintEffectNoSchedule},
+			},
+			expectedSelector: map[string]string{"nvidia.com/gpu.present": "true"},
+		},
+		{
+			name: "fractional gpus",
+			manifest: `name: test
+image: okteto/test
+resources:
+  gpus: 1.5`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.tolerationEnabled {
+				t.Setenv(OktetoGPUTolerationEnvVar, "true")
+			}
+			dev := &Dev{}
+			err := yaml.UnmarshalStrict([]byte(tt.manifest), dev)
+			if tt.expectedLimits == nil {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			dev.setGPUDefaults()
+			dev.setGPUDefaults()
+
+			assert.Equal(t, tt.expectedLimits, dev.Resources.Limits)
+			assert.Equal(t, tt.expectedRequests, dev.Resources.Requests)
+			assert.Equal(t, tt.expectedTolerations, dev.Tolerations)
+			assert.Equal(t, tt.expectedSelector, dev.NodeSelector)
+		})
+	}
+}
+
+func TestStackGPUResourcesUnmarshalling(t *testing.T) {
+	tests := []struct {
+		expected GPUResources
+		name     string
+		manifest string
+	}{
+		{
+			name: "resources with limits",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+    resources:
+      gpus: 1
+      limits:
+        cpu: 1`,
+			expected: GPUResources{NvidiaGPUResource: resource.MustParse("1")},
+		},
+		{
+			name: "flat resources",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+    resources:
+      cpu: 1
+      gpus:
+        amd.com/gpu: 2`,
+			expected: GPUResources{"amd.com/gpu": resource.MustParse("2")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ReadStack([]byte(tt.manifest), true)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, s.Services["app"].Resources.GPUs)
+		})
+	}
+
+	_, err := ReadStack([]byte(`services:
+  app:
+    image: okteto/vote:1
+    resources:
+      gpus: 0.5`), true)
+	require.Error(t, err)
+}
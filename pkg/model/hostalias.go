@@ -0,0 +1,165 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/env"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// HostAlias resolves the hostnames of the development container to an IP.
+// When the port is set, the development container also gets the <HOSTNAME>_SERVICE_HOST and <HOSTNAME>_SERVICE_PORT env vars
+type HostAlias struct {
+	IP        string   `json:"ip,omitempty" yaml:"ip,omitempty"`
+	Hostnames []string `json:"hostnames,omitempty" yaml:"hostnames,omitempty"`
+	Port      int      `json:"port,omitempty" yaml:"port,omitempty"`
+}
+
+// ParseHostAlias parses a host alias with the format 'hostname=ip[:port]'
+func ParseHostAlias(value string) (HostAlias, error) {
+	hostname, target, found := strings.Cut(value, "=")
+	if !found || hostname == "" || target == "" {
+		return HostAlias{}, fmt.Errorf("invalid alias '%s': the format must be 'hostname=ip[:port]'", value)
+	}
+
+	alias := HostAlias{IP: target, Hostnames: []string{hostname}}
+	if host, port, err := net.SplitHostPort(target); err == nil {
+		p, err := strconv.Atoi(port)
+		if err != nil {
+			return HostAlias{}, fmt.Errorf("invalid alias '%s': '%s' is not a valid port", value, port)
+		}
+		alias.IP = host
+		alias.Port = p
+	}
+
+	if err := alias.validate(); err != nil {
+		return HostAlias{}, err
+	}
+	return alias, nil
+}
+
+func (a HostAlias) validate() error {
+	if net.ParseIP(a.IP) == nil {
+		return fmt.Errorf("invalid host alias: '%s' is not a valid IP address", a.IP)
+	}
+	if len(a.Hostnames) == 0 {
+		return fmt.Errorf("invalid host alias: the hostnames of '%s' cannot be empty", a.IP)
+	}
+	for _, hostname := range a.Hostnames {
+		if errs := validation.IsDNS1123Subdomain(hostname); len(errs) > 0 {
+			return fmt.Errorf("invalid host alias: '%s' is not a valid hostname: %s", hostname, strings.Join(errs, ", "))
+		}
+	}
+	if a.Port < 0 || a.Port > 65535 {
+		return fmt.Errorf("invalid host alias: port %d of '%s' is out of range", a.Port, a.IP)
+	}
+	return nil
+}
+
+func (dev *Dev) validateHostAliases() error {
+	for _, alias := range dev.HostAliases {
+		if err := alias.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type hostAliasTarget struct {
+	ip   string
+	port int
+}
+
+// MergeHostAliases returns the host aliases of base with the host aliases of overrides.
+// A hostname defined in both lists is resolved with the value of overrides
+func MergeHostAliases(base, overrides []HostAlias) []HostAlias {
+	targets := map[string]hostAliasTarget{}
+	hostnames := []string{}
+	for _, aliases := range [][]HostAlias{base, overrides} {
+		for _, alias := range aliases {
+			for _, hostname := range alias.Hostnames {
+				if _, ok := targets[hostname]; !ok {
+					hostnames = append(hostnames, hostname)
+				}
+				targets[hostname] = hostAliasTarget{ip: alias.IP, port: alias.Port}
+			}
+		}
+	}
+
+	result := []HostAlias{}
+	index := map[hostAliasTarget]int{}
+	for _, hostname := range hostnames {
+		target := targets[hostname]
+		i, ok := index[target]
+		if !ok {
+			i = len(result)
+			index[target] = i
+			result = append(result, HostAlias{IP: target.ip, Port: target.port})
+		}
+		result[i].Hostnames = append(result[i].Hostnames, hostname)
+	}
+	return result
+}
+
+// hostAliasesToPodSpec returns the host aliases of the pod spec, grouped by IP
+func hostAliasesToPodSpec(aliases []HostAlias) []apiv1.HostAlias {
+	var result []apiv1.HostAlias
+	index := map[string]int{}
+	for _, alias := range aliases {
+		i, ok := index[alias.IP]
+		if !ok {
+			i = len(result)
+			index[alias.IP] = i
+			result = append(result, apiv1.HostAlias{IP: alias.IP})
+		}
+		result[i].Hostnames = append(result[i].Hostnames, alias.Hostnames...)
+	}
+	return result
+}
+
+// hostAliasesEnvironment returns the env vars pointing to the host aliases with a port.
+// Env vars already defined in the environment of the development container are not overridden
+func hostAliasesEnvironment(aliases []HostAlias, environment env.Environment) env.Environment {
+	defined := map[string]bool{}
+	for _, v := range environment {
+		defined[v.Name] = true
+	}
+
+	result := env.Environment{}
+	for _, alias := range aliases {
+		if alias.Port == 0 {
+			continue
+		}
+		for _, hostname := range alias.Hostnames {
+			prefix := strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(hostname))
+			for _, v := range []env.Var{
+				{Name: fmt.Sprintf("%s_SERVICE_HOST", prefix), Value: alias.IP},
+				{Name: fmt.Sprintf("%s_SERVICE_PORT", prefix), Value: strconv.Itoa(alias.Port)},
+			} {
+				if defined[v.Name] {
+					continue
+				}
+				defined[v.Name] = true
+				result = append(result, v)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,121 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/env"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHostAlias(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    HostAlias
+		expectedErr bool
+	}{
+		{
+			name:     "ip with port",
+			value:    "db=127.0.0.1:5432",
+			expected: HostAlias{IP: "127.0.0.1", Hostnames: []string{"db"}, Port: 5432},
+		},
+		{
+			name:     "ip without port",
+			value:    "db=10.0.0.1",
+			expected: HostAlias{IP: "10.0.0.1", Hostnames: []string{"db"}},
+		},
+		{
+			name:     "ipv6 with port",
+			value:    "db=[::1]:5432",
+			expected: HostAlias{IP: "::1", Hostnames: []string{"db"}, Port: 5432},
+		},
+		{
+			name:        "missing hostname",
+			value:       "=127.0.0.1",
+			expectedErr: true,
+		},
+		{
+			name:        "hostname instead of ip",
+			value:       "db=localhost:5432",
+			expectedErr: true,
+		},
+		{
+			name:        "invalid port",
+			value:       "db=127.0.0.1:70000",
+			expectedErr: true,
+		},
+		{
+			name:        "invalid hostname",
+			value:       "Db_1=127.0.0.1",
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseHostAlias(tt.value)
+			if tt.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestMergeHostAliases(t *testing.T) {
+	base := []HostAlias{
+		{IP: "10.0.0.1", Hostnames: []string{"db", "cache"}},
+		{IP: "10.0.0.2", Hostnames: []string{"queue"}},
+	}
+	overrides := []HostAlias{
+		{IP: "127.0.0.1", Hostnames: []string{"db"}, Port: 5432},
+		{IP: "127.0.0.1", Hostnames: []string{"queue"}, Port: 5672},
+	}
+	expected := []HostAlias{
+		{IP: "127.0.0.1", Hostnames: []string{"db"}, Port: 5432},
+		{IP: "10.0.0.1", Hostnames: []string{"cache"}},
+		{IP: "127.0.0.1", Hostnames: []string{"queue"}, Port: 5672},
+	}
+	assert.Equal(t, expected, MergeHostAliases(base, overrides))
+	assert.Equal(t, base, MergeHostAliases(base, nil))
+}
+
+func TestHostAliasesEnvironment(t *testing.T) {
+	aliases := []HostAlias{
+		{IP: "127.0.0.1", Hostnames: []string{"db", "my-cache.local"}, Port: 6379},
+		{IP: "10.0.0.1", Hostnames: []string{"api"}},
+	}
+	environment := env.Environment{{Name: "DB_SERVICE_HOST", Value: "postgres"}}
+	expected := env.Environment{
+		{Name: "DB_SERVICE_PORT", Value: "6379"},
+		{Name: "MY_CACHE_LOCAL_SERVICE_HOST", Value: "127.0.0.1"},
+		{Name: "MY_CACHE_LOCAL_SERVICE_PORT", Value: "6379"},
+	}
+	assert.Equal(t, expected, hostAliasesEnvironment(aliases, environment))
+}
+
+func TestDevValidateHostAliases(t *testing.T) {
+	dev := &Dev{HostAliases: []HostAlias{{IP: "127.0.0.1", Hostnames: []string{"db"}}}}
+	require.NoError(t, dev.validateHostAliases())
+
+	dev = &Dev{HostAliases: []HostAlias{{IP: "not-an-ip", Hostnames: []string{"db"}}}}
+	require.Error(t, dev.validateHostAliases())
+
+	dev = &Dev{HostAliases: []HostAlias{{IP: "127.0.0.1"}}}
+	require.Error(t, dev.validateHostAliases())
+}
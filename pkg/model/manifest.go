@@ -21,6 +21,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/a8m/envsubst"
 	"github.com/okteto/okteto/pkg/build"
@@ -96,6 +97,7 @@ type Manifest struct {
 	Deploy       *DeployInfo              `json:"deploy,omitempty" yaml:"deploy,omitempty"`
 	Dev          ManifestDevs             `json:"dev,omitempty" yaml:"dev,omitempty"`
 	Name         string                   `json:"name,omitempty" yaml:"name,omitempty"`
+	Namespace    string                   `json:"namespace,omitempty" yaml:"namespace,omitempty"`
 	Icon         string                   `json:"icon,omitempty" yaml:"icon,omitempty"`
 	ManifestPath string                   `json:"-" yaml:"-"`
 	Destroy      *DestroyInfo             `json:"destroy,omitempty" yaml:"destroy,omitempty"`
@@ -104,6 +106,16 @@ type Manifest struct {
 	Type          Archetype               `json:"-" yaml:"-"`
 	GlobalForward []forward.GlobalForward `json:"forward,omitempty" yaml:"forward,omitempty"`
 	Manifest      []byte                  `json:"-" yaml:"-"`
+	Metadata      *Metadata               `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	DevImages     *DevImages              `json:"devImages,omitempty" yaml:"devImages,omitempty"`
+
+	// Context is the working directory of the commands, relative to the folder of the manifest
+	Context string `json:"context,omitempty" yaml:"context,omitempty"`
+	// Workdir is the absolute working directory of the commands, when it is not the folder of the manifest
+	Workdir string `json:"-" yaml:"-"`
+
+	// NewerVersionFields are the fields ignored because they are not understood by this version of okteto
+	NewerVersionFields []string `json:"-" yaml:"-"`
 }
 
 // ManifestDevs defines all the dev section
@@ -174,6 +186,9 @@ type DeployInfo struct {
 	Image          string              `json:"image,omitempty" yaml:"image,omitempty"`
 	Context        string              `yaml:"context,omitempty"`
 	Commands       []DeployCommand     `json:"commands,omitempty" yaml:"commands,omitempty"`
+	HealthChecks   []DeployHealthCheck `json:"healthchecks,omitempty" yaml:"healthchecks,omitempty"`
+	// Timeout is the maximum time to deploy the dependencies and run the deploy commands, unless it's set by the '--timeout' flag
+	Timeout time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
 }
 
 // DestroyInfo represents what must be destroyed for the app
@@ -404,9 +419,7 @@ func getManifestFromFile(cwd, manifestPath string, fs afero.Fs) (*Manifest, erro
 		}
 		devManifest.Deploy.ComposeSection.Stack = s
 		devManifest, err = devManifest.InferFromStack(cwd)
-		if devManifest.Deploy.Endpoints != nil {
-			s.Endpoints = devManifest.Deploy.Endpoints
-		}
+		s.Endpoints = mergeEndpoints(s.Endpoints, devManifest.Deploy.Endpoints)
 		if err != nil {
 			return nil, err
 		}
@@ -488,6 +501,11 @@ func getOktetoManifest(devPath string) (*Manifest, error) {
 		return nil, fmt.Errorf("%s: %w", oktetoErrors.ErrInvalidManifest, oktetoErrors.ErrEmptyManifest)
 	}
 
+	b, err = applyManifestOverride(devPath, b)
+	if err != nil {
+		return nil, err
+	}
+
 	manifest, err := Read(b)
 	if err != nil {
 		if errors.Is(err, oktetoErrors.ErrNotManifestContentDetected) {
@@ -496,11 +514,18 @@ func getOktetoManifest(devPath string) (*Manifest, error) {
 		return nil, NewManifestFriendlyError(err)
 	}
 
+	for _, warning := range manifest.NewerVersionFields {
+		oktetoLog.Warning("%s: it will be ignored", warning)
+	}
+
 	for name, external := range manifest.External {
 		external.SetDefaults(name)
 	}
 
 	manifest.ManifestPath = devPath
+	if err := manifest.validateContext(); err != nil {
+		return nil, err
+	}
 
 	return manifest, nil
 }
@@ -518,7 +543,16 @@ func Read(bytes []byte) (*Manifest, error) {
 
 	if bytes != nil {
 		if err := yaml.UnmarshalStrict(bytes, manifest); err != nil {
-			return nil, err
+			// the fields not understood by this version are ignored with a warning instead of failing
+			withoutNewerFields, newerVersionFields, removeErr := removeUnknownManifestFields(bytes, getNewerFieldsTables().Manifest)
+			if removeErr != nil || len(newerVersionFields) == 0 {
+				return nil, err
+			}
+			manifest = NewManifest()
+			if err := yaml.UnmarshalStrict(withoutNewerFields, manifest); err != nil {
+				return nil, err
+			}
+			manifest.NewerVersionFields = newerVersionFields
 		}
 	}
 
@@ -539,6 +573,15 @@ func (m *Manifest) validate() error {
 	if err := m.Build.Validate(); err != nil {
 		return err
 	}
+	if err := m.DevImages.validate(); err != nil {
+		return err
+	}
+	if err := m.validateDeployHealthChecks(); err != nil {
+		return err
+	}
+	if m.Deploy != nil && m.Deploy.Timeout < 0 {
+		return fmt.Errorf("the field 'deploy.timeout' must be a positive duration")
+	}
 	return m.validateDivert()
 }
 
@@ -654,6 +697,7 @@ func (m *Manifest) validateDivert() error {
 }
 
 func (m *Manifest) setDefaults() error {
+	m.setDeployHealthChecksDefaults()
 	if m.Deploy != nil && m.Deploy.Divert != nil {
 		var err error
 		if m.Deploy.Divert.Driver == "" {
@@ -766,12 +810,7 @@ func (manifest *Manifest) ExpandEnvVars() error {
 				}
 			}
 			manifest.Deploy.ComposeSection.Stack = s
-			if len(manifest.Deploy.Endpoints) > 0 {
-				if len(manifest.Deploy.ComposeSection.Stack.Endpoints) > 0 {
-					oktetoLog.Warning("Endpoints are defined in the stack and in the manifest. The endpoints defined in the manifest will be used")
-				}
-				manifest.Deploy.ComposeSection.Stack.Endpoints = manifest.Deploy.Endpoints
-			}
+			manifest.Deploy.ComposeSection.Stack.Endpoints = mergeEndpoints(manifest.Deploy.ComposeSection.Stack.Endpoints, manifest.Deploy.Endpoints)
 			cwd, err := os.Getwd()
 			if err != nil {
 				oktetoLog.Info("could not detect working directory")
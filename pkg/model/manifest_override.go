@@ -0,0 +1,220 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/env"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"gopkg.in/yaml.v2"
+)
+
+const overrideManifestSuffix = ".override"
+
+// overrideListKey returns the key identifying an item of an appendable list, and false if the item has no key
+type overrideListKey func(item interface{}) (string, bool)
+
+// appendableOverrideLists are the lists of the manifest that an override appends to instead of replacing.
+// Their items are identified by their key, so an override can change an existing item
+var appendableOverrideLists = map[string]overrideListKey{
+	// the local port
+	"forward": forwardKey,
+	// the remote port
+	"reverse": stringKey(firstSegment),
+	// the name of the variable
+	"environment": stringKey(func(item string) string {
+		return strings.SplitN(item, "=", 2)[0]
+	}),
+	// the remote path
+	"sync":    stringKey(lastSegment),
+	"folders": stringKey(lastSegment),
+}
+
+func stringKey(fn func(string) string) overrideListKey {
+	return func(item interface{}) (string, bool) {
+		s, ok := item.(string)
+		if !ok {
+			return "", false
+		}
+		return fn(s), true
+	}
+}
+
+// forwardKey returns the local port of a forward, both in the short form like '8080:80' and in the object form
+func forwardKey(item interface{}) (string, bool) {
+	switch f := item.(type) {
+	case string:
+		return firstSegment(f), true
+	case yaml.MapSlice:
+		for _, field := range f {
+			if field.Key == "localPort" {
+				return fmt.Sprintf("%v", field.Value), true
+			}
+		}
+	}
+	return "", false
+}
+
+func firstSegment(item string) string {
+	return strings.SplitN(item, ":", 2)[0]
+}
+
+func lastSegment(item string) string {
+	return item[strings.LastIndex(item, ":")+1:]
+}
+
+// getOverrideManifestPath returns the path of the override of a manifest, like 'okteto.override.yml' for 'okteto.yml',
+// or an empty string if there is none or overrides are disabled
+func getOverrideManifestPath(manifestPath string) string {
+	if env.LoadBoolean(constants.OktetoNoManifestOverrideEnvVar) {
+		return ""
+	}
+	ext := filepath.Ext(manifestPath)
+	base := strings.TrimSuffix(manifestPath, ext)
+	for _, ext := range []string{".yml", ".yaml"} {
+		overridePath := base + overrideManifestSuffix + ext
+		info, err := os.Stat(overridePath)
+		if err == nil && !info.IsDir() {
+			return overridePath
+		}
+	}
+	return ""
+}
+
+// applyManifestOverride merges the override of a manifest over its content, if there is one
+func applyManifestOverride(manifestPath string, b []byte) ([]byte, error) {
+	overridePath := getOverrideManifestPath(manifestPath)
+	if overridePath == "" {
+		return b, nil
+	}
+	override, err := os.ReadFile(overridePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read '%s': %w", overridePath, err)
+	}
+	merged, conflicts, err := mergeManifestOverride(b, override)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge '%s': %w", overridePath, err)
+	}
+	oktetoLog.Information("Using the overrides of '%s'", filepath.Base(overridePath))
+	for _, conflict := range conflicts {
+		oktetoLog.Warning("'%s' overrides the value of '%s' of the okteto manifest", filepath.Base(overridePath), conflict)
+	}
+	return merged, nil
+}
+
+// mergeManifestOverride merges an override over the content of a manifest: maps are merged, the appendable
+// lists are appended without duplicates, and the rest of values are replaced.
+// It returns the paths of the values of the manifest changed by the override
+func mergeManifestOverride(base, override []byte) ([]byte, []string, error) {
+	var baseValue, overrideValue yaml.MapSlice
+	if err := yaml.Unmarshal(base, &baseValue); err != nil {
+		return nil, nil, err
+	}
+	if err := yaml.Unmarshal(override, &overrideValue); err != nil {
+		return nil, nil, err
+	}
+	conflicts := []string{}
+	merged := mergeOverrideValue("", baseValue, overrideValue, &conflicts)
+	result, err := yaml.Marshal(merged)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, conflicts, nil
+}
+
+func mergeOverrideValue(path string, base, override interface{}, conflicts *[]string) interface{} {
+	if base == nil {
+		return override
+	}
+	if override == nil {
+		return base
+	}
+	switch o := override.(type) {
+	case yaml.MapSlice:
+		if b, ok := base.(yaml.MapSlice); ok {
+			return mergeOverrideMap(path, b, o, conflicts)
+		}
+	case []interface{}:
+		if b, ok := base.([]interface{}); ok {
+			if keyFn, ok := appendableOverrideLists[lastPathElement(path)]; ok {
+				return mergeOverrideList(path, b, o, keyFn, conflicts)
+			}
+		}
+	}
+	if !reflect.DeepEqual(base, override) {
+		*conflicts = append(*conflicts, path)
+	}
+	return override
+}
+
+func mergeOverrideMap(path string, base, override yaml.MapSlice, conflicts *[]string) yaml.MapSlice {
+	result := make(yaml.MapSlice, len(base))
+	copy(result, base)
+	for _, item := range override {
+		itemPath := fmt.Sprintf("%v", item.Key)
+		if path != "" {
+			itemPath = fmt.Sprintf("%s.%v", path, item.Key)
+		}
+		found := false
+		for i := range result {
+			if result[i].Key == item.Key {
+				result[i].Value = mergeOverrideValue(itemPath, result[i].Value, item.Value, conflicts)
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// mergeOverrideList appends the items of the override that are not in the list. Items with the same key
+// as an item of the list replace it
+func mergeOverrideList(path string, base, override []interface{}, keyFn overrideListKey, conflicts *[]string) []interface{} {
+	result := make([]interface{}, len(base))
+	copy(result, base)
+	for _, item := range override {
+		found := false
+		for i := range result {
+			if reflect.DeepEqual(result[i], item) {
+				found = true
+				break
+			}
+			baseKey, okBase := keyFn(result[i])
+			overrideKey, okOverride := keyFn(item)
+			if okBase && okOverride && baseKey == overrideKey {
+				*conflicts = append(*conflicts, fmt.Sprintf("%s[%s]", path, baseKey))
+				result[i] = item
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func lastPathElement(path string) string {
+	return path[strings.LastIndex(path, ".")+1:]
+}
@@ -0,0 +1,273 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeManifestOverride(t *testing.T) {
+	tests := []struct {
+		name              string
+		base              string
+		override          string
+		expected          string
+		expectedConflicts []string
+	}{
+		{
+			name: "forwards are appended without duplicates",
+			base: `
+dev:
+  api:
+    forward:
+      - 8080:80
+      - 5432:postgres:5432`,
+			override: `
+dev:
+  api:
+    forward:
+      - 8080:80
+      - 6379:redis:6379`,
+			expected: `dev:
+  api:
+    forward:
+    - 8080:80
+    - 5432:postgres:5432
+    - 6379:redis:6379
+`,
+			expectedConflicts: []string{},
+		},
+		{
+			name: "forwards with the same local port are replaced",
+			base: `
+dev:
+  api:
+    forward:
+      - 8080:80`,
+			override: `
+dev:
+  api:
+    forward:
+      - 8080:8081`,
+			expected: `dev:
+  api:
+    forward:
+    - 8080:8081
+`,
+			expectedConflicts: []string{"dev.api.forward[8080]"},
+		},
+		{
+			name: "forwards in object form with the same local port are replaced",
+			base: `
+dev:
+  api:
+    forward:
+      - 8080:80
+      - localPort: 9090
+        remotePort: 9090
+        name: worker`,
+			override: `
+dev:
+  api:
+    forward:
+      - localPort: 8080
+        remotePort: 8081
+      - localPort: 9090
+        remotePort: 9091
+        name: worker`,
+			expected: `dev:
+  api:
+    forward:
+    - localPort: 8080
+      remotePort: 8081
+    - localPort: 9090
+      remotePort: 9091
+      name: worker
+`,
+			expectedConflicts: []string{"dev.api.forward[8080]", "dev.api.forward[9090]"},
+		},
+		{
+			name: "environment and sync lists are appended",
+			base: `
+dev:
+  api:
+    environment:
+      - LOG_LEVEL=info
+      - PORT=8080
+    sync:
+      - .:/usr/src/app`,
+			override: `
+dev:
+  api:
+    environment:
+      - LOG_LEVEL=debug
+      - FEATURE_FLAG=true
+    sync:
+      - ../lib:/usr/src/lib`,
+			expected: `dev:
+  api:
+    environment:
+    - LOG_LEVEL=debug
+    - PORT=8080
+    - FEATURE_FLAG=true
+    sync:
+    - .:/usr/src/app
+    - ../lib:/usr/src/lib
+`,
+			expectedConflicts: []string{"dev.api.environment[LOG_LEVEL]"},
+		},
+		{
+			name: "maps are merged and scalars replaced",
+			base: `
+name: app
+dev:
+  api:
+    image: okteto/api:latest
+    command: bash
+    environment:
+      LOG_LEVEL: info`,
+			override: `
+dev:
+  api:
+    image: okteto/api:feature
+    environment:
+      LOG_LEVEL: info
+      FEATURE: "true"
+  worker:
+    image: okteto/worker`,
+			expected: `name: app
+dev:
+  api:
+    image: okteto/api:feature
+    command: bash
+    environment:
+      LOG_LEVEL: info
+      FEATURE: "true"
+  worker:
+    image: okteto/worker
+`,
+			expectedConflicts: []string{"dev.api.image"},
+		},
+		{
+			name: "other lists are replaced",
+			base: `
+dev:
+  api:
+    command: ["yarn", "start"]`,
+			override: `
+dev:
+  api:
+    command: ["yarn", "dev"]`,
+			expected: `dev:
+  api:
+    command:
+    - yarn
+    - dev
+`,
+			expectedConflicts: []string{"dev.api.command"},
+		},
+		{
+			name: "different types are replaced",
+			base: `
+dev:
+  api:
+    sync:
+      - .:/app`,
+			override: `
+dev:
+  api:
+    sync:
+      folders:
+        - .:/app`,
+			expected: `dev:
+  api:
+    sync:
+      folders:
+      - .:/app
+`,
+			expectedConflicts: []string{"dev.api.sync"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, conflicts, err := mergeManifestOverride([]byte(tt.base), []byte(tt.override))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(merged))
+			assert.Equal(t, tt.expectedConflicts, conflicts)
+		})
+	}
+}
+
+func TestApplyManifestOverride(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "okteto.yml")
+	base := []byte(`dev:
+  api:
+    forward:
+    - 8080:80
+`)
+	require.NoError(t, os.WriteFile(manifestPath, base, 0600))
+
+	merged, err := applyManifestOverride(manifestPath, base)
+	require.NoError(t, err)
+	assert.Equal(t, string(base), string(merged))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "okteto.override.yaml"), []byte(`dev:
+  api:
+    forward:
+    - 9090:90
+`), 0600))
+	merged, err = applyManifestOverride(manifestPath, base)
+	require.NoError(t, err)
+	assert.Equal(t, `dev:
+  api:
+    forward:
+    - 8080:80
+    - 9090:90
+`, string(merged))
+
+	t.Setenv(constants.OktetoNoManifestOverrideEnvVar, "true")
+	merged, err = applyManifestOverride(manifestPath, base)
+	require.NoError(t, err)
+	assert.Equal(t, string(base), string(merged))
+}
+
+func TestGetManifestWithOverride(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "okteto.yml")
+	require.NoError(t, os.WriteFile(manifestPath, []byte(`dev:
+  api:
+    image: okteto/api
+    forward:
+    - 8080:80
+`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "okteto.override.yml"), []byte(`dev:
+  api:
+    forward:
+    - 6379:redis:6379
+`), 0600))
+
+	manifest, err := getOktetoManifest(manifestPath)
+	require.NoError(t, err)
+	require.Contains(t, manifest.Dev, "api")
+	require.Len(t, manifest.Dev["api"].Forward, 2)
+	assert.Equal(t, 6379, manifest.Dev["api"].Forward[1].Local)
+	assert.Equal(t, "redis", manifest.Dev["api"].Forward[1].ServiceName)
+}
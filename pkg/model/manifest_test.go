@@ -252,6 +252,22 @@ deploy:
 	}
 }
 
+func TestReadDeployTimeout(t *testing.T) {
+	m, err := Read([]byte(`deploy:
+  timeout: 15m
+  commands:
+  - helm upgrade --install app chart`))
+	require.NoError(t, err)
+	require.Equal(t, 15*time.Minute, m.Deploy.Timeout)
+	require.Empty(t, m.NewerVersionFields)
+
+	_, err = Read([]byte(`deploy:
+  timeout: -5m
+  commands:
+  - helm upgrade --install app chart`))
+	require.ErrorContains(t, err, "the field 'deploy.timeout' must be a positive duration")
+}
+
 func Test_validateDivert(t *testing.T) {
 	tests := []struct {
 		expectedErr error
@@ -2201,3 +2217,55 @@ func TestDivertDeployIsEmpty(t *testing.T) {
 		})
 	}
 }
+
+func TestManifestNamespace(t *testing.T) {
+	t.Setenv("TEAM", "team-a")
+	tests := []struct {
+		name        string
+		manifest    string
+		expected    string
+		expectedErr bool
+	}{
+		{
+			name: "not defined",
+			manifest: `dev:
+  api:
+    image: okteto/golang:1`,
+		},
+		{
+			name: "namespace",
+			manifest: `namespace: team-a
+dev:
+  api:
+    image: okteto/golang:1`,
+			expected: "team-a",
+		},
+		{
+			name: "namespace with env vars",
+			manifest: `namespace: ${TEAM}
+dev:
+  api:
+    image: okteto/golang:1`,
+			expected: "team-a",
+		},
+		{
+			name: "invalid namespace",
+			manifest: `namespace: Team_A
+dev:
+  api:
+    image: okteto/golang:1`,
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := Read([]byte(tt.manifest))
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, m.Namespace)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/okteto/okteto/pkg/filesystem"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/spf13/afero"
+)
+
+// ChangeWorkdir moves the current working directory to the directory where builds, deploy commands and sync folders
+// are resolved: workdir if it is not empty, or the 'context' of the manifest, relative to the folder of the manifest.
+// It does nothing if none of them is defined
+func (m *Manifest) ChangeWorkdir(workdir string, fs afero.Fs) error {
+	if workdir == "" {
+		if m.Context == "" || m.ManifestPath == "" {
+			return nil
+		}
+		workdir = m.getContextDir()
+	}
+	absWorkdir, err := filepath.Abs(workdir)
+	if err != nil {
+		return err
+	}
+	if info, err := fs.Stat(absWorkdir); err != nil || !info.IsDir() {
+		return fmt.Errorf("the working directory '%s' is not a directory", workdir)
+	}
+
+	// the sync folders and volumes are resolved from the working directory instead of the folder of the manifest
+	for _, dev := range m.Dev {
+		dev.loadVolumeAbsPaths(absWorkdir, fs)
+		for _, s := range dev.Services {
+			s.loadVolumeAbsPaths(absWorkdir, fs)
+		}
+	}
+
+	if m.ManifestPath == "" {
+		if err := os.Chdir(absWorkdir); err != nil {
+			return err
+		}
+	} else {
+		manifestPath, err := filesystem.UpdateCWDtoWorkdir(m.ManifestPath, absWorkdir)
+		if err != nil {
+			return err
+		}
+		m.ManifestPath = manifestPath
+	}
+	m.Workdir = absWorkdir
+	oktetoLog.Infof("using '%s' as working directory", absWorkdir)
+	return nil
+}
+
+// getContextDir returns the directory of the 'context' of the manifest
+func (m *Manifest) getContextDir() string {
+	if filepath.IsAbs(m.Context) {
+		return m.Context
+	}
+	return filepath.Join(filesystem.GetWorkdirFromManifestPath(m.ManifestPath), m.Context)
+}
+
+// validateContext checks that the 'context' of the manifest is a directory
+func (m *Manifest) validateContext() error {
+	if m.Context == "" {
+		return nil
+	}
+	info, err := os.Stat(m.getContextDir())
+	if err != nil || !info.IsDir() {
+		return fmt.Errorf("the 'context' of your okteto manifest '%s' is not a directory", m.Context)
+	}
+	return nil
+}
+
+// GetWorkdir returns the directory where the relative paths of the manifest are resolved
+func (m *Manifest) GetWorkdir() string {
+	if m.Workdir != "" {
+		return m.Workdir
+	}
+	return filesystem.GetWorkdirFromManifestPath(m.ManifestPath)
+}
@@ -0,0 +1,161 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManifestChangeWorkdir(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	apiDir := filepath.Join(root, "services", "api")
+	require.NoError(t, os.MkdirAll(apiDir, 0700))
+	manifestPath := filepath.Join(apiDir, "okteto.yml")
+
+	tests := []struct {
+		name                 string
+		workdir              string
+		context              string
+		expectedCWD          string
+		expectedManifestPath string
+		expectedSync         string
+		expectedWorkdir      string
+		expectedErr          bool
+	}{
+		{
+			name:                 "no workdir",
+			expectedManifestPath: manifestPath,
+			expectedSync:         "services/api",
+		},
+		{
+			name:                 "manifest context",
+			context:              "../..",
+			expectedCWD:          root,
+			expectedManifestPath: filepath.Join("services", "api", "okteto.yml"),
+			expectedSync:         apiDir,
+			expectedWorkdir:      root,
+		},
+		{
+			name:                 "workdir flag takes precedence over the manifest context",
+			workdir:              filepath.Join(root, "services"),
+			context:              "../..",
+			expectedCWD:          filepath.Join(root, "services"),
+			expectedManifestPath: filepath.Join("api", "okteto.yml"),
+			expectedSync:         filepath.Join(root, "services", "services", "api"),
+			expectedWorkdir:      filepath.Join(root, "services"),
+		},
+		{
+			name:        "workdir not found",
+			workdir:     filepath.Join(root, "not-found"),
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			initialCWD, err := os.Getwd()
+			require.NoError(t, err)
+			defer func() {
+				require.NoError(t, os.Chdir(initialCWD))
+			}()
+
+			m := &Manifest{
+				ManifestPath: manifestPath,
+				Context:      tt.context,
+				Dev: ManifestDevs{
+					"api": &Dev{
+						Sync: Sync{
+							Folders: []SyncFolder{{LocalPath: "services/api", RemotePath: "/app"}},
+						},
+					},
+				},
+			}
+			err = m.ChangeWorkdir(tt.workdir, afero.NewOsFs())
+			if tt.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedManifestPath, m.ManifestPath)
+			assert.Equal(t, tt.expectedWorkdir, m.Workdir)
+			assert.Equal(t, tt.expectedSync, m.Dev["api"].Sync.Folders[0].LocalPath)
+			if tt.expectedCWD != "" {
+				cwd, err := os.Getwd()
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedCWD, cwd)
+			}
+		})
+	}
+}
+
+func TestManifestGetWorkdir(t *testing.T) {
+	m := &Manifest{ManifestPath: filepath.Join("services", "api", "okteto.yml")}
+	assert.Equal(t, filepath.Join("services", "api"), m.GetWorkdir())
+
+	m.Workdir = "/repo"
+	assert.Equal(t, "/repo", m.GetWorkdir())
+}
+
+func TestManifestContextIsRead(t *testing.T) {
+	manifest, err := Read([]byte("context: ../..\ndeploy:\n  - make deploy\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "../..", manifest.Context)
+}
+
+func TestManifestValidateContext(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "services", "api"), 0700))
+	manifestPath := filepath.Join(root, "services", "api", "okteto.yml")
+
+	tests := []struct {
+		name        string
+		context     string
+		expectedErr bool
+	}{
+		{
+			name: "no context",
+		},
+		{
+			name:    "relative to the manifest folder",
+			context: "../..",
+		},
+		{
+			name:    "absolute",
+			context: root,
+		},
+		{
+			name:        "not found",
+			context:     "not-found",
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Manifest{ManifestPath: manifestPath, Context: tt.context}
+			err := m.validateContext()
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
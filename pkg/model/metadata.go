@@ -0,0 +1,80 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// oktetoManagedAnnotations are the dev annotations set by okteto to track the state of the development container.
+// They are not propagated to the resources created for the dev
+var oktetoManagedAnnotations = map[string]bool{
+	LastBuiltAnnotation:      true,
+	OktetoRestartAnnotation:  true,
+	OktetoSyncAnnotation:     true,
+	OktetoStignoreAnnotation: true,
+}
+
+// mergeMetadata adds the labels and annotations of the manifest metadata to the dev and its services.
+// The values defined by the dev take precedence
+func (dev *Dev) mergeMetadata(m *Metadata) {
+	if m == nil || dev == nil {
+		return
+	}
+	if dev.Metadata == nil {
+		dev.Metadata = &Metadata{}
+	}
+	if len(m.Labels) > 0 && dev.Metadata.Labels == nil {
+		dev.Metadata.Labels = Labels{}
+	}
+	for k, v := range m.Labels {
+		if _, ok := dev.Metadata.Labels[k]; !ok {
+			dev.Metadata.Labels[k] = v
+		}
+	}
+	if len(m.Annotations) > 0 && dev.Metadata.Annotations == nil {
+		dev.Metadata.Annotations = Annotations{}
+	}
+	for k, v := range m.Annotations {
+		if _, ok := dev.Metadata.Annotations[k]; !ok {
+			dev.Metadata.Annotations[k] = v
+		}
+	}
+	for _, s := range dev.Services {
+		s.mergeMetadata(m)
+	}
+}
+
+// ResourceLabels returns the labels to set in the resources created for the dev
+func (dev *Dev) ResourceLabels() Labels {
+	result := Labels{}
+	if dev.Metadata == nil {
+		return result
+	}
+	for k, v := range dev.Metadata.Labels {
+		result[k] = v
+	}
+	return result
+}
+
+// ResourceAnnotations returns the annotations to set in the resources created for the dev
+func (dev *Dev) ResourceAnnotations() Annotations {
+	result := Annotations{}
+	if dev.Metadata == nil {
+		return result
+	}
+	for k, v := range dev.Metadata.Annotations {
+		if oktetoManagedAnnotations[k] {
+			continue
+		}
+		result[k] = v
+	}
+	return result
+}
@@ -0,0 +1,77 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManifestMetadataIsMergedIntoDevs(t *testing.T) {
+	manifest, err := Read([]byte(`
+metadata:
+  labels:
+    team: payments
+    tier: backend
+  annotations:
+    owner: payments@example.com
+dev:
+  api:
+    image: okteto/golang:1
+    metadata:
+      labels:
+        tier: api
+    services:
+      - name: worker
+        sync:
+          - .:/app
+  frontend:
+    image: okteto/node:20
+`))
+	require.NoError(t, err)
+
+	api := manifest.Dev["api"]
+	assert.Equal(t, Labels{"team": "payments", "tier": "api"}, api.Metadata.Labels)
+	assert.Equal(t, Annotations{"owner": "payments@example.com"}, api.Metadata.Annotations)
+	assert.Equal(t, Labels{"team": "payments", "tier": "backend"}, api.Services[0].Metadata.Labels)
+
+	frontend := manifest.Dev["frontend"]
+	assert.Equal(t, Labels{"team": "payments", "tier": "backend"}, frontend.Metadata.Labels)
+	assert.Equal(t, Annotations{"owner": "payments@example.com"}, frontend.Metadata.Annotations)
+}
+
+func TestResourceMetadata(t *testing.T) {
+	dev := &Dev{
+		Metadata: &Metadata{
+			Labels: Labels{"team": "payments"},
+			Annotations: Annotations{
+				"owner":                  "payments@example.com",
+				OktetoRestartAnnotation:  "1",
+				OktetoStignoreAnnotation: "abc",
+			},
+		},
+	}
+	labels := dev.ResourceLabels()
+	assert.Equal(t, Labels{"team": "payments"}, labels)
+	labels["other"] = "value"
+	assert.NotContains(t, dev.Metadata.Labels, "other")
+
+	assert.Equal(t, Annotations{"owner": "payments@example.com"}, dev.ResourceAnnotations())
+
+	empty := &Dev{}
+	assert.Empty(t, empty.ResourceLabels())
+	assert.Empty(t, empty.ResourceAnnotations())
+}
@@ -0,0 +1,268 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	_ "embed"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/okteto/okteto/pkg/config"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	// oktetoExtensionPrefix is the prefix of the compose extensions implemented by okteto
+	oktetoExtensionPrefix = "x-okteto-"
+
+	// newerServiceFieldPrefix is the prefix of the service fields in the compose table of newer fields
+	newerServiceFieldPrefix = "services."
+
+	// newerDeployFieldPrefix is the prefix of the deploy fields in the manifest table of newer fields
+	newerDeployFieldPrefix = "deploy."
+)
+
+//go:embed newer_fields.yaml
+var newerFieldsTable []byte
+
+// wellFormedFieldRegex matches the keys that can be a field of a newer version of the manifest
+var wellFormedFieldRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
+
+// newerFields maps the fields of the manifest to the version of the cli introducing them
+type newerFields map[string]string
+
+// newerFieldsTables are the tables of fields of the compose files and the okteto manifest
+type newerFieldsTables struct {
+	Compose  newerFields `yaml:"compose"`
+	Manifest newerFields `yaml:"manifest"`
+}
+
+// getNewerFieldsTables returns the tables of fields embedded in the cli
+func getNewerFieldsTables() newerFieldsTables {
+	var tables newerFieldsTables
+	if err := yaml.Unmarshal(newerFieldsTable, &tables); err != nil {
+		oktetoLog.Infof("error reading the table of newer fields: %s", err)
+	}
+	if tables.Compose == nil {
+		tables.Compose = newerFields{}
+	}
+	if tables.Manifest == nil {
+		tables.Manifest = newerFields{}
+	}
+	return tables
+}
+
+// newerVersion returns the version introducing a field, if the field is in the table and the version is newer than the cli
+func (n newerFields) newerVersion(field string) (string, bool) {
+	version, ok := n[field]
+	if !ok || !isNewerThanCurrentVersion(version) {
+		return "", false
+	}
+	return version, true
+}
+
+// serviceVersion returns the version introducing a field of a compose service, if it's newer than the cli
+func (n newerFields) serviceVersion(field string) (string, bool) {
+	return n.newerVersion(newerServiceFieldPrefix + field)
+}
+
+// isNewerThanCurrentVersion returns if a version is newer than the version of the cli.
+// Development versions are not semantic versions and they are always considered older
+func isNewerThanCurrentVersion(version string) bool {
+	required, err := semver.NewVersion(version)
+	if err != nil {
+		oktetoLog.Infof("invalid version '%s' in the table of newer fields: %s", version, err)
+		return false
+	}
+	current, err := semver.NewVersion(config.VersionString)
+	if err != nil {
+		return true
+	}
+	return required.GreaterThan(current)
+}
+
+// getCurrentVersion returns the version of the cli to display in the warnings about newer fields
+func getCurrentVersion() string {
+	if config.VersionString == "" {
+		return "a development version"
+	}
+	return config.VersionString
+}
+
+func newerFieldWarning(field, version string) string {
+	return fmt.Sprintf("field '%s' requires okteto >= %s, you are on %s", field, version, getCurrentVersion())
+}
+
+func notSupportedFieldWarning(field string) string {
+	return fmt.Sprintf("field '%s' is not supported by okteto %s", field, getCurrentVersion())
+}
+
+// getNewerVersionFields returns a warning for each okteto extension of the compose file that this version of the cli doesn't understand.
+// These extensions are ignored instead of failing the validation of the compose file
+func getNewerVersionFields(stack *StackRaw, table newerFields) []string {
+	var warnings []string
+	for extension := range stack.Extensions {
+		if !strings.HasPrefix(extension, oktetoExtensionPrefix) {
+			continue
+		}
+		if version, ok := table.newerVersion(extension); ok {
+			warnings = append(warnings, newerFieldWarning(extension, version))
+			continue
+		}
+		warnings = append(warnings, notSupportedFieldWarning(extension))
+	}
+	for svcName, svc := range stack.Services {
+		if svc == nil {
+			continue
+		}
+		for extension := range svc.Extensions {
+			field := fmt.Sprintf("services[%s].%s", svcName, extension)
+			if version, ok := table.serviceVersion(extension); ok {
+				warnings = append(warnings, newerFieldWarning(field, version))
+				continue
+			}
+			if strings.HasPrefix(extension, oktetoExtensionPrefix) {
+				warnings = append(warnings, notSupportedFieldWarning(field))
+			}
+		}
+	}
+	sort.Strings(warnings)
+	return warnings
+}
+
+// isIgnoredServiceExtension returns if an unknown field of a compose service is ignored with a warning instead of failing
+func isIgnoredServiceExtension(extension string, table newerFields) bool {
+	if strings.HasPrefix(extension, oktetoExtensionPrefix) {
+		return true
+	}
+	_, ok := table.serviceVersion(extension)
+	return ok
+}
+
+// removeUnknownManifestFields removes from an okteto manifest the well-formed fields that this version of the cli doesn't understand,
+// returning the manifest without them and a warning for each removed field.
+// Only the top-level fields and the fields of the deploy section are removed, and the manifest is returned unchanged
+// if it doesn't have any of them, if none of its top-level fields is understood or if it has top-level fields of a
+// development container, as it's not an okteto manifest or it uses the format of the v1 manifests
+func removeUnknownManifestFields(b []byte, table newerFields) ([]byte, []string, error) {
+	var doc yaml.MapSlice
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return b, nil, nil
+	}
+
+	manifestFields := getKnownFields(manifestRaw{}, "model.manifestRaw")
+	deployFields := getKnownFields(DeployInfo{}, "model.DeployInfo")
+	devFields := getKnownFields(Dev{}, "model.Dev")
+	for _, item := range doc {
+		if key, ok := item.Key.(string); ok && !manifestFields[key] && devFields[key] {
+			return b, nil, nil
+		}
+	}
+
+	var warnings []string
+	result, isManifest := removeUnknownFields(doc, "", manifestFields, table, &warnings)
+	if !isManifest {
+		return b, nil, nil
+	}
+	for i := range result {
+		if result[i].Key != "deploy" {
+			continue
+		}
+		if deploy, ok := result[i].Value.(yaml.MapSlice); ok {
+			result[i].Value, _ = removeUnknownFields(deploy, newerDeployFieldPrefix, deployFields, table, &warnings)
+		}
+	}
+	if len(warnings) == 0 {
+		return b, nil, nil
+	}
+
+	out, err := yaml.Marshal(result)
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Strings(warnings)
+	return out, warnings, nil
+}
+
+// getKnownFields returns the fields of a section of the manifest understood by this version of the cli
+func getKnownFields(section interface{}, name string) map[string]bool {
+	result := map[string]bool{}
+	for _, field := range GetStructKeys(section)[name] {
+		result[field] = true
+	}
+	return result
+}
+
+// removeUnknownFields removes the well-formed fields of a section that are not known, adding a warning for each of them.
+// It also returns if any of the fields of the section is known
+func removeUnknownFields(section yaml.MapSlice, prefix string, known map[string]bool, table newerFields, warnings *[]string) (yaml.MapSlice, bool) {
+	result := yaml.MapSlice{}
+	hasKnownFields := false
+	for _, item := range section {
+		key, ok := item.Key.(string)
+		if !ok || known[key] || !wellFormedFieldRegex.MatchString(key) {
+			hasKnownFields = hasKnownFields || known[key]
+			result = append(result, item)
+			continue
+		}
+		if version, found := table.newerVersion(prefix + key); found {
+			*warnings = append(*warnings, newerFieldWarning(prefix+key, version))
+			continue
+		}
+		*warnings = append(*warnings, notSupportedFieldWarning(prefix+key))
+	}
+	return result, hasKnownFields
+}
+
+// mergeNewerVersionFields merges two lists of warnings about newer fields, without duplicates
+func mergeNewerVersionFields(warnings, others []string) []string {
+	if len(others) == 0 {
+		return warnings
+	}
+	seen := make(map[string]bool, len(warnings))
+	for _, warning := range warnings {
+		seen[warning] = true
+	}
+	for _, warning := range others {
+		if seen[warning] {
+			continue
+		}
+		seen[warning] = true
+		warnings = append(warnings, warning)
+	}
+	return warnings
+}
+
+// GetNewerVersionFields returns the fields of the okteto manifest and its compose files that this version of the cli doesn't understand
+func (m *Manifest) GetNewerVersionFields() []string {
+	warnings := m.NewerVersionFields
+	if m.Deploy != nil && m.Deploy.ComposeSection != nil && m.Deploy.ComposeSection.Stack != nil {
+		warnings = mergeNewerVersionFields(warnings, m.Deploy.ComposeSection.Stack.Warnings.NewerVersionFields)
+	}
+	return warnings
+}
+
+// CheckNewerVersionFields fails in strict mode when the manifest has fields that this version of the cli doesn't understand
+func CheckNewerVersionFields(warnings []string, strict bool) error {
+	if len(warnings) == 0 || !strict {
+		return nil
+	}
+	return fmt.Errorf(`invalid manifest: the following fields are not understood by this version of okteto:
+    - %s
+    Upgrade okteto or remove the fields from the manifest`, strings.Join(warnings, "\n    - "))
+}
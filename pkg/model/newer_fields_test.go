@@ -0,0 +1,225 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/okteto/okteto/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_getNewerFieldsTables(t *testing.T) {
+	tables := getNewerFieldsTables()
+	for field, version := range tables.Compose {
+		_, err := semver.StrictNewVersion(version)
+		require.NoError(t, err, field)
+	}
+	for field, version := range tables.Manifest {
+		_, err := semver.StrictNewVersion(version)
+		require.NoError(t, err, field)
+	}
+}
+
+func Test_getNewerFieldsTablesAreUnderstood(t *testing.T) {
+	// the table is published with every release, so this version of the cli understands all of its fields
+	tables := getNewerFieldsTables()
+	composeFields := getKnownFields(StackRaw{}, "model.StackRaw")
+	serviceFields := getKnownFields(ServiceRaw{}, "model.ServiceRaw")
+	for field := range tables.Compose {
+		if name, ok := strings.CutPrefix(field, newerServiceFieldPrefix); ok {
+			require.True(t, serviceFields[name], field)
+			continue
+		}
+		require.True(t, composeFields[field], field)
+	}
+
+	manifestFields := getKnownFields(manifestRaw{}, "model.manifestRaw")
+	deployFields := getKnownFields(DeployInfo{}, "model.DeployInfo")
+	for field := range tables.Manifest {
+		if name, ok := strings.CutPrefix(field, newerDeployFieldPrefix); ok {
+			require.True(t, deployFields[name], field)
+			continue
+		}
+		require.True(t, manifestFields[field], field)
+	}
+}
+
+func Test_isNewerThanCurrentVersion(t *testing.T) {
+	previous := config.VersionString
+	t.Cleanup(func() { config.VersionString = previous })
+
+	config.VersionString = "3.2.0"
+	require.True(t, isNewerThanCurrentVersion("3.4.0"))
+	require.False(t, isNewerThanCurrentVersion("3.2.0"))
+	require.False(t, isNewerThanCurrentVersion("3.0.0"))
+	require.False(t, isNewerThanCurrentVersion("invalid"))
+
+	config.VersionString = "a1b2c3d"
+	require.True(t, isNewerThanCurrentVersion("3.4.0"))
+}
+
+func Test_getNewerVersionFields(t *testing.T) {
+	previous := config.VersionString
+	config.VersionString = "3.2.0"
+	t.Cleanup(func() { config.VersionString = previous })
+
+	table := newerFields{
+		"x-okteto-preview":          "3.4.0",
+		"x-okteto-legacy":           "3.0.0",
+		"services.x-okteto-sidecar": "3.5.0",
+		"services.healthcheck-port": "3.5.0",
+	}
+	stack := &StackRaw{
+		Extensions: map[string]interface{}{
+			"x-okteto-preview": true,
+			"x-okteto-legacy":  true,
+			"x-okteto-unknown": "value",
+			"x-common":         "value",
+		},
+		Services: map[string]*ServiceRaw{
+			"api": {Extensions: map[string]interface{}{
+				"x-okteto-sidecar": "proxy",
+				"x-okteto-unknown": true,
+				"healthcheck-port": 8080,
+			}},
+		},
+	}
+
+	require.Equal(t, []string{
+		"field 'services[api].healthcheck-port' requires okteto >= 3.5.0, you are on 3.2.0",
+		"field 'services[api].x-okteto-sidecar' requires okteto >= 3.5.0, you are on 3.2.0",
+		"field 'services[api].x-okteto-unknown' is not supported by okteto 3.2.0",
+		"field 'x-okteto-legacy' is not supported by okteto 3.2.0",
+		"field 'x-okteto-preview' requires okteto >= 3.4.0, you are on 3.2.0",
+		"field 'x-okteto-unknown' is not supported by okteto 3.2.0",
+	}, getNewerVersionFields(stack, table))
+
+	// the newer fields and the okteto extensions of the services are ignored instead of failing
+	require.NoError(t, validateExtensions(*stack, table))
+	err := validateExtensions(*stack, newerFields{})
+	require.ErrorContains(t, err, "services[api].healthcheck-port")
+	require.NotContains(t, err.Error(), "x-okteto-")
+
+	// the fields that are not newer than the cli still fail
+	require.ErrorContains(t, validateExtensions(*stack, newerFields{"services.healthcheck-port": "3.0.0"}), "services[api].healthcheck-port")
+}
+
+func setNewerFieldsTable(t *testing.T, table string) {
+	t.Helper()
+	original := newerFieldsTable
+	newerFieldsTable = []byte(table)
+	t.Cleanup(func() {
+		newerFieldsTable = original
+	})
+}
+
+func Test_ReadStackNewerVersionFields(t *testing.T) {
+	setNewerFieldsTable(t, `compose:
+  services.x-okteto-sidecar: 99.0.0`)
+	manifest := []byte(`x-okteto-unknown: true
+services:
+  app:
+    image: okteto/vote:1
+    x-okteto-sidecar:
+      image: busybox`)
+
+	s, err := ReadStack(manifest, true)
+	require.NoError(t, err)
+	require.Len(t, s.Warnings.NewerVersionFields, 2)
+	require.Contains(t, s.Warnings.NewerVersionFields[0], "field 'services[app].x-okteto-sidecar' requires okteto >= 99.0.0")
+	require.Contains(t, s.Warnings.NewerVersionFields[1], "field 'x-okteto-unknown' is not supported by okteto")
+
+	require.NoError(t, CheckNewerVersionFields(s.Warnings.NewerVersionFields, false))
+	err = CheckNewerVersionFields(s.Warnings.NewerVersionFields, true)
+	require.ErrorContains(t, err, "the following fields are not understood by this version of okteto")
+	require.ErrorContains(t, err, "x-okteto-unknown")
+}
+
+func Test_ReadManifestNewerVersionFields(t *testing.T) {
+	setNewerFieldsTable(t, `manifest:
+  deploy.retries: 99.0.0`)
+	manifest := []byte(`preview: true
+deploy:
+  retries: 3
+  commands:
+  - name: deploy
+    command: helm upgrade --install app chart`)
+
+	m, err := Read(manifest)
+	require.NoError(t, err)
+	require.Len(t, m.NewerVersionFields, 2)
+	require.Contains(t, m.NewerVersionFields[0], "field 'deploy.retries' requires okteto >= 99.0.0")
+	require.Contains(t, m.NewerVersionFields[1], "field 'preview' is not supported by okteto")
+	require.Len(t, m.Deploy.Commands, 1)
+	require.Equal(t, manifest, m.Manifest)
+
+	err = CheckNewerVersionFields(m.GetNewerVersionFields(), true)
+	require.ErrorContains(t, err, "deploy.retries")
+	require.ErrorContains(t, err, "preview")
+
+	// invalid values of known fields still fail
+	_, err = Read([]byte(`deploy:
+  retries: 3
+  commands: true`))
+	require.Error(t, err)
+}
+
+func Test_removeUnknownManifestFields(t *testing.T) {
+	previous := config.VersionString
+	config.VersionString = "3.2.0"
+	t.Cleanup(func() { config.VersionString = previous })
+
+	table := newerFields{
+		"preview":        "3.4.0",
+		"deploy.retries": "3.5.0",
+		"deploy.legacy":  "3.0.0",
+	}
+	manifest := []byte(`preview: true
+deploy:
+  retries: 3
+  legacy: true
+  commands:
+  - echo
+`)
+	result, warnings, err := removeUnknownManifestFields(manifest, table)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"field 'deploy.legacy' is not supported by okteto 3.2.0",
+		"field 'deploy.retries' requires okteto >= 3.5.0, you are on 3.2.0",
+		"field 'preview' requires okteto >= 3.4.0, you are on 3.2.0",
+	}, warnings)
+	require.Equal(t, "deploy:\n  commands:\n  - echo\n", string(result))
+
+	unchanged := []byte("deploy:\n  - echo\n")
+	result, warnings, err = removeUnknownManifestFields(unchanged, table)
+	require.NoError(t, err)
+	require.Empty(t, warnings)
+	require.Equal(t, unchanged, result)
+
+	// documents without any field of the okteto manifest are not okteto manifests
+	compose := []byte("services:\n  app:\n    image: nginx\n")
+	result, warnings, err = removeUnknownManifestFields(compose, table)
+	require.NoError(t, err)
+	require.Empty(t, warnings)
+	require.Equal(t, compose, result)
+}
+
+func Test_mergeNewerVersionFields(t *testing.T) {
+	s := &Stack{Warnings: StackWarnings{NewerVersionFields: []string{"a", "b"}}}
+	s = s.Merge(&Stack{Warnings: StackWarnings{NewerVersionFields: []string{"b", "c"}}})
+	require.Equal(t, []string{"a", "b", "c"}, s.Warnings.NewerVersionFields)
+}
@@ -0,0 +1,66 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"slices"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+const (
+	// MaxPrivilegedPort is the highest port that requires the NET_BIND_SERVICE capability to be bound
+	MaxPrivilegedPort = 1023
+
+	netBindServiceCapability apiv1.Capability = "NET_BIND_SERVICE"
+)
+
+// privilegedReverses returns the reverse forwards that bind a privileged port in the development container
+func (dev *Dev) privilegedReverses() []Reverse {
+	result := []Reverse{}
+	for _, r := range dev.Reverse {
+		if r.Remote > 0 && r.Remote <= MaxPrivilegedPort {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// setPrivilegedPortsDefaults adds the NET_BIND_SERVICE capability to the development container when
+// 'allowPrivilegedPorts' is set and a reverse forward binds a privileged port.
+// Without the opt-in, the reverse forward is relayed from an unprivileged port if the bind fails
+func (dev *Dev) setPrivilegedPortsDefaults() {
+	reverses := dev.privilegedReverses()
+	if len(reverses) == 0 {
+		return
+	}
+
+	if !dev.AllowPrivilegedPorts {
+		for _, r := range reverses {
+			oktetoLog.Warning("Reverse '%d:%d' binds the privileged port %d in your development container. If it can't be bound, okteto will relay it from an unprivileged port. Set 'allowPrivilegedPorts: true' to add the NET_BIND_SERVICE capability to your development container", r.Remote, r.Local, r.Remote)
+		}
+		return
+	}
+
+	if dev.SecurityContext == nil {
+		dev.SecurityContext = &SecurityContext{}
+	}
+	if dev.SecurityContext.Capabilities == nil {
+		dev.SecurityContext.Capabilities = &Capabilities{}
+	}
+	if !slices.Contains(dev.SecurityContext.Capabilities.Add, netBindServiceCapability) {
+		dev.SecurityContext.Capabilities.Add = append(dev.SecurityContext.Capabilities.Add, netBindServiceCapability)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+func TestSetPrivilegedPortsDefaults(t *testing.T) {
+	tests := []struct {
+		dev      *Dev
+		expected *SecurityContext
+		name     string
+	}{
+		{
+			name:     "unprivileged reverse",
+			dev:      &Dev{AllowPrivilegedPorts: true, Reverse: []Reverse{{Remote: 8080, Local: 3000}}},
+			expected: nil,
+		},
+		{
+			name:     "privileged reverse without opt-in",
+			dev:      &Dev{Reverse: []Reverse{{Remote: 80, Local: 3000}}},
+			expected: nil,
+		},
+		{
+			name: "privileged reverse with opt-in",
+			dev:  &Dev{AllowPrivilegedPorts: true, Reverse: []Reverse{{Remote: 80, Local: 3000}}},
+			expected: &SecurityContext{
+				Capabilities: &Capabilities{Add: []apiv1.Capability{"NET_BIND_SERVICE"}},
+			},
+		},
+		{
+			name: "capability already added",
+			dev: &Dev{
+				AllowPrivilegedPorts: true,
+				Reverse:              []Reverse{{Remote: 443, Local: 3000}},
+				SecurityContext: &SecurityContext{
+					Capabilities: &Capabilities{Add: []apiv1.Capability{"SYS_PTRACE", "NET_BIND_SERVICE"}},
+				},
+			},
+			expected: &SecurityContext{
+				Capabilities: &Capabilities{Add: []apiv1.Capability{"SYS_PTRACE", "NET_BIND_SERVICE"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.dev.setPrivilegedPortsDefaults()
+			assert.Equal(t, tt.expected, tt.dev.SecurityContext)
+		})
+	}
+}
+
+func TestAllowPrivilegedPortsManifest(t *testing.T) {
+	manifest, err := Read([]byte(`
+dev:
+  web:
+    image: web:latest
+    allowPrivilegedPorts: true
+    reverse:
+      - 80:3000
+      - 9000:9001
+    sync:
+      - .:/app`))
+	require.NoError(t, err)
+	dev := manifest.Dev["web"]
+
+	assert.Equal(t, []Reverse{{Remote: 80, Local: 3000}}, dev.privilegedReverses())
+	require.NotNil(t, dev.SecurityContext)
+	assert.Equal(t, []apiv1.Capability{"NET_BIND_SERVICE"}, dev.SecurityContext.Capabilities.Add)
+}
@@ -0,0 +1,147 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// SyncthingGUIPort is the port of the syncthing http endpoint in the development container
+	SyncthingGUIPort = 8384
+
+	// SyncthingDataPort is the port used by syncthing to synchronize files in the development container
+	SyncthingDataPort = 22000
+
+	// maxLocalPortAttempts is the number of random ports tried to find one not declared in a forward
+	maxLocalPortAttempts = 10
+)
+
+// ReservedPort is a port used internally by okteto
+type ReservedPort struct {
+	Name string
+	// Port is 0 when a random available port is assigned on each okteto up session
+	Port int
+	// Remote is true for ports of the development container, false for ports of the local machine
+	Remote bool
+}
+
+// ReservedPorts returns the ports used internally by okteto for the development container.
+// Forwards and reverses can't use them
+func (dev *Dev) ReservedPorts() []ReservedPort {
+	return []ReservedPort{
+		{Name: "syncthing GUI", Port: SyncthingGUIPort, Remote: true},
+		{Name: "syncthing data", Port: SyncthingDataPort, Remote: true},
+		{Name: "SSH server", Port: dev.SSHServerPort, Remote: true},
+		{Name: "SSH tunnel", Port: dev.RemotePort},
+		{Name: "syncthing tunnels"},
+		{Name: "deploy proxy"},
+	}
+}
+
+// String returns a description of the reserved port, like "SSH server: 2222 (development container)"
+func (p ReservedPort) String() string {
+	port := "random"
+	if p.Port > 0 {
+		port = fmt.Sprintf("%d", p.Port)
+	}
+	location := "local"
+	if p.Remote {
+		location = "development container"
+	}
+	return fmt.Sprintf("%s: %s (%s)", p.Name, port, location)
+}
+
+// reservedRemotePorts returns the ports of the development container reserved by okteto and what they are used for
+func (dev *Dev) reservedRemotePorts() map[int]string {
+	result := map[int]string{}
+	for _, p := range dev.ReservedPorts() {
+		if p.Remote && p.Port > 0 {
+			result[p.Port] = p.Name
+		}
+	}
+	return result
+}
+
+// declaredRemotePorts returns the ports of the development container used by forwards and reverses
+func (dev *Dev) declaredRemotePorts() map[int]bool {
+	result := map[int]bool{}
+	for _, f := range dev.Forward {
+		if f.ServiceName == "" {
+			result[f.Remote] = true
+		}
+	}
+	for _, r := range dev.Reverse {
+		result[r.Remote] = true
+	}
+	return result
+}
+
+// defaultSSHServerPort returns the default port of the SSH server, shifted to the next port
+// when a forward or reverse of the development container already uses it
+func (dev *Dev) defaultSSHServerPort() int {
+	declared := dev.declaredRemotePorts()
+	port := oktetoDefaultSSHServerPort
+	for declared[port] || port == SyncthingGUIPort || port == SyncthingDataPort {
+		port++
+	}
+	if port != oktetoDefaultSSHServerPort {
+		oktetoLog.Infof("port %d is used by a forward or reverse, using %d for the SSH server", oktetoDefaultSSHServerPort, port)
+	}
+	return port
+}
+
+// validateReservedPorts checks that forwards and reverses don't use the ports reserved by okteto
+func (dev *Dev) validateReservedPorts() error {
+	reserved := dev.reservedRemotePorts()
+	for _, f := range dev.Forward {
+		if dev.RemotePort > 0 && f.Local == dev.RemotePort {
+			return fmt.Errorf("forward '%s' uses the local port %d, reserved by okteto for the SSH tunnel set in 'remote'", f.String(), f.Local)
+		}
+		if f.ServiceName != "" {
+			continue
+		}
+		if name, ok := reserved[f.Remote]; ok {
+			return fmt.Errorf("forward '%s' uses the port %d of the development container, reserved by okteto (%s)", f.String(), f.Remote, name)
+		}
+	}
+	for _, r := range dev.Reverse {
+		if name, ok := reserved[r.Remote]; ok {
+			return fmt.Errorf("reverse '%d:%d' uses the port %d of the development container, reserved by okteto (%s)", r.Remote, r.Local, r.Remote, name)
+		}
+	}
+	return nil
+}
+
+// GetAvailableLocalPort returns a random available local port that isn't the local port of a forward,
+// so okteto's internal tunnels never take a port declared by the user
+func (dev *Dev) GetAvailableLocalPort() (int, error) {
+	declared := map[int]bool{}
+	for _, f := range dev.Forward {
+		declared[f.Local] = true
+	}
+	for i := 0; i < maxLocalPortAttempts; i++ {
+		port, err := GetAvailablePort(dev.Interface)
+		if err != nil {
+			return 0, err
+		}
+		if !declared[port] {
+			return port, nil
+		}
+		oktetoLog.Infof("port %d is declared in a forward, choosing another one", port)
+	}
+	return 0, fmt.Errorf("failed to find an available port not declared in 'forward'")
+}
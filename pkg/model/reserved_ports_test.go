@@ -0,0 +1,170 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/model/forward"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateReservedPorts(t *testing.T) {
+	tests := []struct {
+		dev     *Dev
+		name    string
+		wantErr string
+	}{
+		{
+			name: "no collisions",
+			dev: &Dev{
+				SSHServerPort: 2222,
+				RemotePort:    22100,
+				Forward:       []forward.Forward{{Local: 8080, Remote: 8080}},
+				Reverse:       []Reverse{{Remote: 9000, Local: 9001}},
+			},
+		},
+		{
+			name:    "forward to the syncthing GUI port",
+			dev:     &Dev{SSHServerPort: 2222, Forward: []forward.Forward{{Local: 8384, Remote: 8384}}},
+			wantErr: "forward '8384:8384' uses the port 8384 of the development container, reserved by okteto (syncthing GUI)",
+		},
+		{
+			name:    "forward to the syncthing data port",
+			dev:     &Dev{SSHServerPort: 2222, Forward: []forward.Forward{{Local: 9000, Remote: 22000}}},
+			wantErr: "forward '9000:22000' uses the port 22000 of the development container, reserved by okteto (syncthing data)",
+		},
+		{
+			name:    "forward to the SSH server port",
+			dev:     &Dev{SSHServerPort: 2233, Forward: []forward.Forward{{Local: 2233, Remote: 2233}}},
+			wantErr: "forward '2233:2233' uses the port 2233 of the development container, reserved by okteto (SSH server)",
+		},
+		{
+			name:    "forward from the SSH tunnel port",
+			dev:     &Dev{SSHServerPort: 2222, RemotePort: 22100, Forward: []forward.Forward{{Local: 22100, Remote: 8080}}},
+			wantErr: "forward '22100:8080' uses the local port 22100, reserved by okteto for the SSH tunnel set in 'remote'",
+		},
+		{
+			name: "forward to a service on a reserved port",
+			dev: &Dev{
+				SSHServerPort: 2222,
+				Forward:       []forward.Forward{{Local: 8384, Remote: 8384, ServiceName: "gui", Service: true}},
+			},
+		},
+		{
+			name:    "reverse on the syncthing GUI port",
+			dev:     &Dev{SSHServerPort: 2222, Reverse: []Reverse{{Remote: 8384, Local: 8384}}},
+			wantErr: "reverse '8384:8384' uses the port 8384 of the development container, reserved by okteto (syncthing GUI)",
+		},
+		{
+			name:    "reverse on the SSH server port",
+			dev:     &Dev{SSHServerPort: 2222, Reverse: []Reverse{{Remote: 2222, Local: 3000}}},
+			wantErr: "reverse '2222:3000' uses the port 2222 of the development container, reserved by okteto (SSH server)",
+		},
+		{
+			name: "reverse on the local SSH tunnel port",
+			dev:  &Dev{SSHServerPort: 2222, RemotePort: 22100, Reverse: []Reverse{{Remote: 9000, Local: 22100}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.dev.validateReservedPorts()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestDefaultSSHServerPort(t *testing.T) {
+	tests := []struct {
+		dev      *Dev
+		name     string
+		expected int
+	}{
+		{
+			name:     "no forwards",
+			dev:      &Dev{},
+			expected: 2222,
+		},
+		{
+			name:     "forward to the default port",
+			dev:      &Dev{Forward: []forward.Forward{{Local: 2222, Remote: 2222}}},
+			expected: 2223,
+		},
+		{
+			name:     "forward and reverse on consecutive ports",
+			dev:      &Dev{Forward: []forward.Forward{{Local: 2222, Remote: 2222}}, Reverse: []Reverse{{Remote: 2223, Local: 3000}}},
+			expected: 2224,
+		},
+		{
+			name:     "forward from the default port",
+			dev:      &Dev{Forward: []forward.Forward{{Local: 2222, Remote: 8080}}},
+			expected: 2222,
+		},
+		{
+			name:     "forward to a service on the default port",
+			dev:      &Dev{Forward: []forward.Forward{{Local: 2222, Remote: 2222, ServiceName: "db", Service: true}}},
+			expected: 2222,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.dev.defaultSSHServerPort())
+		})
+	}
+}
+
+func TestReservedPortsManifest(t *testing.T) {
+	manifest, err := Read([]byte(`
+dev:
+  web:
+    image: web:latest
+    forward:
+      - 2222:2222
+    sync:
+      - .:/app`))
+	require.NoError(t, err)
+	dev := manifest.Dev["web"]
+	assert.Equal(t, 2223, dev.SSHServerPort)
+	assert.NoError(t, dev.validateReservedPorts())
+
+	manifest, err = Read([]byte(`
+dev:
+  web:
+    image: web:latest
+    sshServerPort: 2222
+    forward:
+      - 2222:2222
+    sync:
+      - .:/app`))
+	require.NoError(t, err)
+	assert.ErrorContains(t, manifest.Dev["web"].validateReservedPorts(), "reserved by okteto (SSH server)")
+}
+
+func TestGetAvailableLocalPort(t *testing.T) {
+	dev := &Dev{Interface: Localhost, Forward: []forward.Forward{{Local: 8080, Remote: 8080}}}
+	port, err := dev.GetAvailableLocalPort()
+	require.NoError(t, err)
+	assert.NotZero(t, port)
+	assert.NotEqual(t, 8080, port)
+}
+
+func TestReservedPortString(t *testing.T) {
+	assert.Equal(t, "SSH server: 2222 (development container)", ReservedPort{Name: "SSH server", Port: 2222, Remote: true}.String())
+	assert.Equal(t, "deploy proxy: random (local)", ReservedPort{Name: "deploy proxy"}.String())
+}
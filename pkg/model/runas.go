@@ -0,0 +1,155 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"al.essio.dev/pkg/shellescape"
+	"k8s.io/utils/ptr"
+)
+
+// runAsScriptName is the value of $0 in the script that switches the user of the dev command
+const runAsScriptName = "okteto-run-as"
+
+// RunAs is the user and group that run the command of the development container.
+// The bootstrap of the development container runs as root, and the command switches to this user
+type RunAs struct {
+	User  int64
+	Group int64
+}
+
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+func (r *RunAs) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var raw string
+	if err := unmarshal(&raw); err != nil {
+		return fmt.Errorf("'runAs' must be a string with the format 'UID' or 'UID:GID'")
+	}
+	result, err := parseRunAs(raw)
+	if err != nil {
+		return err
+	}
+	*r = *result
+	return nil
+}
+
+// MarshalYAML Implements the marshaler interface of the yaml pkg.
+func (r RunAs) MarshalYAML() (interface{}, error) {
+	return r.String(), nil
+}
+
+func (r RunAs) String() string {
+	return fmt.Sprintf("%d:%d", r.User, r.Group)
+}
+
+func parseRunAs(value string) (*RunAs, error) {
+	user, group, hasGroup := strings.Cut(strings.TrimSpace(value), ":")
+	uid, err := strconv.ParseInt(user, 10, 64)
+	if err != nil || uid < 0 {
+		return nil, fmt.Errorf("invalid 'runAs' value '%s': the format is 'UID' or 'UID:GID'", value)
+	}
+	gid := uid
+	if hasGroup {
+		gid, err = strconv.ParseInt(group, 10, 64)
+		if err != nil || gid < 0 {
+			return nil, fmt.Errorf("invalid 'runAs' value '%s': the format is 'UID' or 'UID:GID'", value)
+		}
+	}
+	return &RunAs{User: uid, Group: gid}, nil
+}
+
+// setRunAsDefaults runs the development container as root, so the bootstrap can fix the permissions of the volumes
+func (dev *Dev) setRunAsDefaults() {
+	if dev.RunAs == nil {
+		return
+	}
+	if dev.SecurityContext == nil {
+		dev.SecurityContext = &SecurityContext{}
+	}
+	if dev.SecurityContext.RunAsUser == nil {
+		dev.SecurityContext.RunAsUser = ptr.To(int64(0))
+	}
+	if dev.SecurityContext.RunAsGroup == nil {
+		dev.SecurityContext.RunAsGroup = ptr.To(int64(0))
+	}
+	if dev.SecurityContext.FSGroup == nil {
+		dev.SecurityContext.FSGroup = ptr.To(dev.RunAs.Group)
+	}
+}
+
+// validateRunAs checks that the development container starts as root when 'runAs' is set
+func (dev *Dev) validateRunAs() error {
+	if dev.RunAs == nil {
+		return nil
+	}
+	if dev.RunAsNonRoot() {
+		return fmt.Errorf("'runAs' requires the development container to start as root: remove 'securityContext.runAsNonRoot'")
+	}
+	if dev.SecurityContext != nil && dev.SecurityContext.RunAsUser != nil && *dev.SecurityContext.RunAsUser != 0 {
+		return fmt.Errorf("'runAs' requires the development container to start as root: remove 'securityContext.runAsUser'")
+	}
+	return nil
+}
+
+// RunAsCommand returns the command to run in the development container as the 'runAs' user.
+// The command changes the owner of the synced and persistent folders, and then runs the command
+// with the first available tool among su-exec, gosu and setpriv
+func (dev *Dev) RunAsCommand(cmd []string) []string {
+	if dev.RunAs == nil {
+		return cmd
+	}
+	owner := dev.RunAs.String()
+
+	lines := []string{}
+	if paths := dev.runAsOwnedPaths(); len(paths) > 0 {
+		quoted := make([]string, 0, len(paths))
+		for _, p := range paths {
+			quoted = append(quoted, shellescape.Quote(p))
+		}
+		lines = append(lines, fmt.Sprintf("chown -R %s %s 2>/dev/null || true", owner, strings.Join(quoted, " ")))
+	}
+	lines = append(lines,
+		fmt.Sprintf(`for bin in su-exec gosu; do if command -v "$bin" >/dev/null 2>&1; then exec "$bin" %s "$@"; fi; done`, owner),
+		fmt.Sprintf(`if command -v setpriv >/dev/null 2>&1; then exec setpriv --reuid=%d --regid=%d --clear-groups "$@"; fi`, dev.RunAs.User, dev.RunAs.Group),
+		`echo "'runAs' requires su-exec, gosu or setpriv in your development container image" >&2`,
+		"exit 1",
+	)
+
+	result := []string{"sh", "-c", strings.Join(lines, "\n"), runAsScriptName}
+	return append(result, cmd...)
+}
+
+// runAsOwnedPaths returns the remote paths of the synced folders and the persistent volumes
+func (dev *Dev) runAsOwnedPaths() []string {
+	result := []string{}
+	seen := map[string]bool{}
+	add := func(p string) {
+		if p == "" || seen[p] {
+			return
+		}
+		seen[p] = true
+		result = append(result, p)
+	}
+	for _, f := range dev.Sync.Folders {
+		add(f.RemotePath)
+	}
+	if dev.PersistentVolumeEnabled() {
+		for _, v := range dev.Volumes {
+			add(v.RemotePath)
+		}
+	}
+	return result
+}
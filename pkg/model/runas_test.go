@@ -0,0 +1,169 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+	"k8s.io/utils/ptr"
+)
+
+func TestRunAsUnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    RunAs
+		expectedErr bool
+	}{
+		{
+			name:     "user and group",
+			value:    `"1000:1001"`,
+			expected: RunAs{User: 1000, Group: 1001},
+		},
+		{
+			name:     "user as number",
+			value:    `1000`,
+			expected: RunAs{User: 1000, Group: 1000},
+		},
+		{
+			name:        "user name",
+			value:       `app`,
+			expectedErr: true,
+		},
+		{
+			name:        "negative group",
+			value:       `"1000:-1"`,
+			expectedErr: true,
+		},
+		{
+			name:        "object",
+			value:       `{user: 1000}`,
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result RunAs
+			err := yaml.Unmarshal([]byte(tt.value), &result)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+
+			out, err := yaml.Marshal(result)
+			require.NoError(t, err)
+			assert.Contains(t, string(out), result.String())
+		})
+	}
+}
+
+func TestRunAsDefaults(t *testing.T) {
+	manifest, err := Read([]byte(`
+dev:
+  api:
+    image: okteto/golang:1
+    runAs: "1000:1001"
+    sync:
+      - .:/app`))
+	require.NoError(t, err)
+
+	dev := manifest.Dev["api"]
+	require.NotNil(t, dev.RunAs)
+	assert.Equal(t, ptr.To(int64(0)), dev.SecurityContext.RunAsUser)
+	assert.Equal(t, ptr.To(int64(0)), dev.SecurityContext.RunAsGroup)
+	assert.Equal(t, ptr.To(int64(1001)), dev.SecurityContext.FSGroup)
+}
+
+func TestValidateRunAs(t *testing.T) {
+	tests := []struct {
+		dev         *Dev
+		name        string
+		expectedErr string
+	}{
+		{
+			name: "not set",
+			dev:  &Dev{SecurityContext: &SecurityContext{RunAsNonRoot: ptr.To(true)}},
+		},
+		{
+			name: "root container",
+			dev: &Dev{
+				RunAs:           &RunAs{User: 1000, Group: 1000},
+				SecurityContext: &SecurityContext{RunAsUser: ptr.To(int64(0))},
+			},
+		},
+		{
+			name: "run as non root",
+			dev: &Dev{
+				RunAs:           &RunAs{User: 1000, Group: 1000},
+				SecurityContext: &SecurityContext{RunAsNonRoot: ptr.To(true)},
+			},
+			expectedErr: "remove 'securityContext.runAsNonRoot'",
+		},
+		{
+			name: "non root user",
+			dev: &Dev{
+				RunAs:           &RunAs{User: 1000, Group: 1000},
+				SecurityContext: &SecurityContext{RunAsUser: ptr.To(int64(1000))},
+			},
+			expectedErr: "remove 'securityContext.runAsUser'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.dev.validateRunAs()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestRunAsCommand(t *testing.T) {
+	cmd := []string{"bash", "-c", "echo hello"}
+
+	dev := &Dev{}
+	assert.Equal(t, cmd, dev.RunAsCommand(cmd))
+
+	dev = &Dev{
+		RunAs: &RunAs{User: 1000, Group: 1001},
+		Sync: Sync{
+			Folders: []SyncFolder{
+				{LocalPath: ".", RemotePath: "/app"},
+				{LocalPath: "docs", RemotePath: "/app"},
+				{LocalPath: "data", RemotePath: "/my data"},
+			},
+		},
+		PersistentVolumeInfo: &PersistentVolumeInfo{Enabled: true},
+		Volumes:              []Volume{{RemotePath: "/root/.cache"}},
+	}
+	expectedScript := `chown -R 1000:1001 /app '/my data' /root/.cache 2>/dev/null || true
+for bin in su-exec gosu; do if command -v "$bin" >/dev/null 2>&1; then exec "$bin" 1000:1001 "$@"; fi; done
+if command -v setpriv >/dev/null 2>&1; then exec setpriv --reuid=1000 --regid=1001 --clear-groups "$@"; fi
+echo "'runAs' requires su-exec, gosu or setpriv in your development container image" >&2
+exit 1`
+	assert.Equal(t, []string{"sh", "-c", expectedScript, "okteto-run-as", "bash", "-c", "echo hello"}, dev.RunAsCommand(cmd))
+
+	// the ownership fix is limited to the synced folders when the persistent volume is disabled
+	dev.PersistentVolumeInfo.Enabled = false
+	result := dev.RunAsCommand(cmd)
+	assert.Contains(t, result[2], "chown -R 1000:1001 /app '/my data' 2>/dev/null || true\n")
+	assert.NotContains(t, result[2], "/root/.cache")
+}
@@ -185,43 +185,64 @@ func Test_getStructKeys(t *testing.T) {
 				"build.Info":                        {"secrets", "context", "dockerfile", "target", "image", "cache_from", "args", "export_cache", "depends_on"},
 				"build.VolumeMounts":                {"local_path", "remote_path"},
 				"deps.Dependency":                   {"repository", "manifest", "branch", "variables", "timeout", "wait"},
-				"env.Var":                           {"name", "value"},
+				"env.SecretKeyRef":                  {"name", "key"},
+				"env.ServiceRef":                    {"secretRef", "name", "scheme", "user", "path", "port"},
+				"env.Var":                           {"fromService", "name", "value", "secret"},
 				"externalresource.ExternalResource": {"icon", "notes", "endpoints"},
-				"forward.Forward":                   {"labels", "name", "localPort", "remotePort"},
+				"forward.Forward":                   {"labels", "name", "interface", "localPort", "remotePort"},
 				"forward.GlobalForward":             {"labels", "name", "localPort", "remotePort"},
 				"model.Artifact":                    {"path", "destination"},
+				"model.AutoRestart":                 {"paths", "debounce", "enabled"},
 				"model.Capabilities":                {"add", "drop"},
 				"model.ComposeInfo":                 {"file", "services"},
 				"model.ComposeSectionInfo":          {"manifest"},
 				"model.DeployCommand":               {"name", "command"},
-				"model.DeployInfo":                  {"compose", "endpoints", "divert", "image", "commands", "remote", "context"},
+				"model.DeployHealthCheck":           {"http", "exec", "name", "timeout", "retries"},
+				"model.DeployInfo":                  {"compose", "endpoints", "divert", "image", "commands", "remote", "context", "healthchecks", "timeout"},
 				"model.DestroyInfo":                 {"image", "commands", "remote", "context"},
-				"model.Dev":                         {"resources", "selector", "persistentVolume", "securityContext", "probes", "nodeSelector", "metadata", "affinity", "image", "lifecycle", "replicas", "initContainer", "workdir", "name", "container", "serviceAccount", "priorityClassName", "interface", "mode", "imagePullPolicy", "tolerations", "command", "forward", "reverse", "externalVolumes", "secrets", "volumes", "envFiles", "environment", "services", "args", "sync", "timeout", "remote", "sshServerPort", "autocreate"},
+				"model.Dev":                         {"resources", "selector", "persistentVolume", "securityContext", "runAs", "probes", "nodeSelector", "metadata", "affinity", "image", "lifecycle", "autoRestart", "replicas", "initContainer", "workdir", "name", "container", "serviceAccount", "priorityClassName", "interface", "mode", "imagePullPolicy", "tolerations", "hostAliases", "command", "forward", "reverse", "externalVolumes", "secrets", "volumes", "envFiles", "environment", "services", "args", "sync", "timeout", "remote", "sshServerPort", "environmentPassthrough", "autocreate", "allowPrivilegedPorts"},
+				"model.DevImages":                   {"bin", "sandbox"},
+				"model.ConfigSpec":                  {"file"},
+				"model.ExternalService":             {"host", "ports"},
+				"model.Device":                      {"source", "target", "permissions"},
 				"model.DivertDeploy":                {"driver", "namespace", "service", "deployment", "virtualServices", "hosts", "port"},
 				"model.DivertHost":                  {"virtualService", "namespace"},
 				"model.DivertVirtualService":        {"name", "namespace", "routes"},
-				"model.HealthCheck":                 {"http", "test", "interval", "timeout", "retries", "start_period", "disable", "x-okteto-liveness", "x-okteto-readiness"},
+				"model.ExecDeployHealthCheck":       {"service", "command"},
+				"model.HealthCheck":                 {"http", "tcp", "test", "interval", "timeout", "retries", "start_period", "disable", "x-okteto-liveness", "x-okteto-readiness"},
+				"model.HostAlias":                   {"ip", "hostnames", "port"},
 				"model.Host":                        {"hostname", "ip"},
 				"model.HTTPHealtcheck":              {"path", "port"},
+				"model.TCPHealthcheck":              {"host", "port"},
+				"model.HTTPDeployHealthCheck":       {"url", "status"},
 				"model.InitContainer":               {"resources", "image"},
 				"model.Lifecycle":                   {"postStart", "preStop"},
 				"model.LifecycleHandler":            {"command", "enabled"},
-				"model.Manifest":                    {"name", "icon", "dev", "build", "deploy", "destroy", "dependencies", "external", "forward", "test"},
+				"model.Manifest":                    {"name", "namespace", "icon", "dev", "build", "deploy", "destroy", "dependencies", "external", "forward", "test", "metadata", "devImages", "context"},
 				"model.Metadata":                    {"labels", "annotations"},
-				"model.PersistentVolumeInfo":        {"accessMode", "volumeMode", "annotations", "labels", "storageClass", "size", "enabled"},
+				"model.PersistentVolumeInfo":        {"accessMode", "volumeMode", "annotations", "labels", "storageClass", "size", "enabled", "autoExpand"},
 				"model.Probes":                      {"liveness", "readiness", "startup"},
-				"model.ResourceRequirements":        {"limits", "requests"},
+				"model.ResourceRequirements":        {"limits", "requests", "max", "gpus", "scale", "unlimited"},
 				"model.SecurityContext":             {"runAsUser", "runAsGroup", "fsGroup", "capabilities", "runAsNonRoot", "allowPrivilegeEscalation", "readOnlyRootFilesystem"},
-				"model.Service":                     {"healthcheck", "labels", "resources", "x-node-selector", "x-enable-service-links", "user", "depends_on", "build", "x-okteto-identity-token", "workdir", "image", "restart", "environment", "ports", "volumes", "cap_add", "cap_drop", "env_file", "command", "annotations", "entrypoint", "stop_grace_period", "replicas", "max_attempts", "public", "endpoint_mode"},
+				"model.Service":                     {"healthcheck", "labels", "resources", "x-node-selector", "tolerations", "extra_hosts", "dns", "dns_search", "x-enable-service-links", "user", "depends_on", "build", "x-okteto-identity-token", "x-okteto-serviceaccount", "x-okteto-priority-class", "workdir", "image", "restart", "environment", "ports", "volumes", "cap_add", "cap_drop", "devices", "configs", "secrets", "env_file", "command", "annotations", "entrypoint", "stop_grace_period", "replicas", "max_attempts", "public", "privileged", "read_only", "x-okteto-create-serviceaccount", "endpoint_mode", "x-okteto-prestop-sleep", "x-okteto-lifecycle", "x-okteto-readiness-probe", "x-okteto-liveness-probe", "x-okteto-topology-spread", "x-okteto-anti-affinity", "x-okteto-active-deadline-seconds", "x-okteto-ttl-seconds-after-finished"},
+				"model.ServiceConfig":               {"mode", "source", "target"},
+				"model.ServiceSecret":               {"mode", "source", "target"},
+				"model.SecretSpec":                  {"file", "environment"},
 				"model.ServiceIdentityToken":        {"expiration_seconds", "audience", "mount_path"},
+				"model.ServiceLifecycle":            {"postStart", "preStop"},
+				"model.TopologySpread":              {"topologyKey", "whenUnsatisfiable", "maxSkew"},
+				"model.LifecycleHook":               {"exec", "httpGet"},
+				"model.LifecycleExec":               {"command"},
+				"model.LifecycleHTTPGet":            {"path", "host", "scheme", "port"},
 				"model.ServiceResources":            {"cpu", "memory", "storage"},
-				"model.Stack":                       {"volumes", "services", "endpoints", "name", "namespace", "context"},
-				"model.StackResources":              {"limits", "requests"},
+				"model.Stack":                       {"volumes", "configs", "secrets", "services", "x-okteto-external-service", "endpoints", "name", "namespace", "context"},
+				"model.StackResources":              {"gpus", "limits", "requests"},
 				"model.StackSecurityContext":        {"runAsUser", "runAsGroup"},
 				"model.StorageResource":             {"size", "class"},
-				"model.Sync":                        {"folders", "rescanInterval", "compression", "verbose"},
-				"model.SyncFolder":                  {"localPath", "remotePath"},
-				"model.Test":                        {"image", "context", "commands", "depends_on", "caches", "artifacts", "hosts", "skipIfNoFileChanges"},
+				"model.Sync":                        {"folders", "remoteExcludes", "rescanInterval", "modTimeWindow", "compression", "verbose", "ignorePerms", "preservePermissions", "uidMap"},
+				"model.SyncFolder":                  {"ignorePerms", "modTimeWindow", "localPath", "remotePath"},
+				"model.SyncUIDMap":                  {"uid", "gid"},
+				"model.Test":                        {"image", "context", "commands", "depends_on", "caches", "artifacts", "hosts", "environment", "skipIfNoFileChanges"},
 				"model.TestCommand":                 {"name", "command"},
 				"model.Timeout":                     {"default", "resources"},
 				"model.VolumeSpec":                  {"labels", "annotations", "size", "class"},
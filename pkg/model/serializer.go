@@ -37,6 +37,7 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 const (
@@ -44,6 +45,7 @@ const (
 	volumeParamsWithSubPath    = 3
 	volumeParamsWithoutSubpath = 2
 	defaultSecretMode          = 420
+	unlimitedResources         = "unlimited"
 )
 
 var (
@@ -56,9 +58,23 @@ type syncRaw struct {
 	LocalPath      string
 	RemotePath     string
 	Folders        []SyncFolder `json:"folders,omitempty" yaml:"folders,omitempty"`
+	RemoteExcludes []string     `json:"remoteExcludes,omitempty" yaml:"remoteExcludes,omitempty"`
 	RescanInterval int          `json:"rescanInterval,omitempty" yaml:"rescanInterval,omitempty"`
+	ModTimeWindow  int          `json:"modTimeWindow,omitempty" yaml:"modTimeWindow,omitempty"`
 	Compression    bool         `json:"compression" yaml:"compression"`
 	Verbose        bool         `json:"verbose" yaml:"verbose"`
+	IgnorePerms    bool         `json:"ignorePerms,omitempty" yaml:"ignorePerms,omitempty"`
+	// PreservePermissions synchronizes the permissions of the files. It defaults to false on Windows and to true otherwise
+	PreservePermissions *bool `json:"preservePermissions,omitempty" yaml:"preservePermissions,omitempty"`
+	// UIDMap is the owner of the files synchronized to the development container
+	UIDMap *SyncUIDMap `json:"uidMap,omitempty" yaml:"uidMap,omitempty"`
+}
+
+type syncFolderRaw struct {
+	IgnorePerms   *bool  `json:"ignorePerms,omitempty" yaml:"ignorePerms,omitempty"`
+	ModTimeWindow *int   `json:"modTimeWindow,omitempty" yaml:"modTimeWindow,omitempty"`
+	LocalPath     string `json:"localPath,omitempty" yaml:"localPath,omitempty"`
+	RemotePath    string `json:"remotePath,omitempty" yaml:"remotePath,omitempty"`
 }
 
 type storageResourceRaw struct {
@@ -247,13 +263,18 @@ func (sync *Sync) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	sync.Compression = rawSync.Compression
 	sync.Verbose = rawSync.Verbose
 	sync.RescanInterval = rawSync.RescanInterval
+	sync.ModTimeWindow = rawSync.ModTimeWindow
+	sync.IgnorePerms = rawSync.IgnorePerms
+	sync.PreservePermissions = rawSync.PreservePermissions
+	sync.UIDMap = rawSync.UIDMap
 	sync.Folders = rawSync.Folders
+	sync.RemoteExcludes = rawSync.RemoteExcludes
 	return nil
 }
 
 // MarshalYAML Implements the marshaler interface of the yaml pkg.
 func (sync Sync) MarshalYAML() (interface{}, error) {
-	if !sync.Compression && sync.RescanInterval == DefaultSyncthingRescanInterval {
+	if !sync.Compression && sync.RescanInterval == DefaultSyncthingRescanInterval && !sync.IgnorePerms && sync.PreservePermissions == nil && sync.UIDMap == nil && sync.ModTimeWindow == 0 && len(sync.RemoteExcludes) == 0 {
 		return sync.Folders, nil
 	}
 	return syncRaw(sync), nil
@@ -393,6 +414,27 @@ func (f Reverse) MarshalYAML() (interface{}, error) {
 	return fmt.Sprintf("%d:%d", f.Remote, f.Local), nil
 }
 
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+// Besides the extended notation, it supports 'resources: unlimited' to inherit the original resources without limits
+func (r *ResourceRequirements) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var reducedNotation string
+	if err := unmarshal(&reducedNotation); err == nil {
+		if reducedNotation != unlimitedResources {
+			return fmt.Errorf("'resources' must be an object or '%s'", unlimitedResources)
+		}
+		r.Unlimited = true
+		return nil
+	}
+
+	type resourceRequirements ResourceRequirements // prevent recursion
+	var extendedNotation resourceRequirements
+	if err := unmarshal(&extendedNotation); err != nil {
+		return err
+	}
+	*r = ResourceRequirements(extendedNotation)
+	return nil
+}
+
 // UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
 func (r *ResourceList) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	var raw map[apiv1.ResourceName]string
@@ -460,7 +502,11 @@ func (s *SyncFolder) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	var raw string
 	err := unmarshal(&raw)
 	if err != nil {
-		return err
+		var rawFolder syncFolderRaw
+		if err := unmarshal(&rawFolder); err != nil {
+			return fmt.Errorf("each element in the 'sync' field must follow the syntax 'localPath:remotePath' or be an object with the fields 'localPath' and 'remotePath'")
+		}
+		return s.fromRaw(rawFolder)
 	}
 
 	windowsSyncFolderParts := 3
@@ -493,17 +539,41 @@ func (s *SyncFolder) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return fmt.Errorf("each element in the 'sync' field must follow the syntax 'localPath:remotePath'")
 }
 
-// MarshalYAML Implements the marshaler interface of the yaml pkg.
-func (s SyncFolder) MarshalYAML() (interface{}, error) {
-	cwd, err := os.Getwd()
+func (s *SyncFolder) fromRaw(raw syncFolderRaw) error {
+	if raw.LocalPath == "" || raw.RemotePath == "" {
+		return fmt.Errorf("the fields 'localPath' and 'remotePath' are required in each element of the 'sync' field")
+	}
+	var err error
+	s.LocalPath, err = env.ExpandEnv(raw.LocalPath)
 	if err != nil {
-		return s.LocalPath + ":" + s.RemotePath, nil
+		return err
 	}
-	relPath, err := filepath.Rel(cwd, s.LocalPath)
+	s.RemotePath, err = env.ExpandEnv(raw.RemotePath)
 	if err != nil {
-		return s.LocalPath + ":" + s.RemotePath, nil
+		return err
 	}
-	return relPath + ":" + s.RemotePath, nil
+	s.IgnorePerms = raw.IgnorePerms
+	s.ModTimeWindow = raw.ModTimeWindow
+	return nil
+}
+
+// MarshalYAML Implements the marshaler interface of the yaml pkg.
+func (s SyncFolder) MarshalYAML() (interface{}, error) {
+	localPath := s.LocalPath
+	if cwd, err := os.Getwd(); err == nil {
+		if relPath, err := filepath.Rel(cwd, s.LocalPath); err == nil {
+			localPath = relPath
+		}
+	}
+	if s.IgnorePerms == nil && s.ModTimeWindow == nil {
+		return localPath + ":" + s.RemotePath, nil
+	}
+	return syncFolderRaw{
+		LocalPath:     localPath,
+		RemotePath:    s.RemotePath,
+		IgnorePerms:   s.IgnorePerms,
+		ModTimeWindow: s.ModTimeWindow,
+	}, nil
 }
 
 // UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
@@ -672,6 +742,7 @@ var hybridUnsupportedFields = []string{
 	"affinity",
 	"context",
 	"externalVolumes",
+	"hostAliases",
 	"image",
 	"imagePullPolicy",
 	"initContainer",
@@ -680,6 +751,7 @@ var hybridUnsupportedFields = []string{
 	"nodeSelector",
 	"persistentVolume",
 	"replicas",
+	"runAs",
 	"secrets",
 	"securityContext",
 	"serviceAccount",
@@ -763,7 +835,7 @@ func (d *Dev) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			return fmt.Errorf("dev workdir is not a dir")
 		}
 		dev.Workdir = localDir
-		dev.Image = config.NewImageConfig(oktetoLog.GetOutputWriter()).GetCliImage()
+		dev.Image = config.NewImageConfig(oktetoLog.GetOutputWriter()).GetBinImage()
 		dev.ImagePullPolicy = apiv1.PullIfNotPresent
 
 	} else {
@@ -776,16 +848,21 @@ func (d *Dev) UnmarshalYAML(unmarshal func(interface{}) error) error {
 }
 
 type manifestRaw struct {
-	Deploy        *DeployInfo              `json:"deploy,omitempty" yaml:"deploy,omitempty"`
-	Dev           ManifestDevs             `json:"dev,omitempty" yaml:"dev,omitempty"`
-	Test          ManifestTests            `json:"test,omitempty" yaml:"test,omitempty"`
-	Destroy       *DestroyInfo             `json:"destroy,omitempty" yaml:"destroy,omitempty"`
-	Build         build.ManifestBuild      `json:"build,omitempty" yaml:"build,omitempty"`
-	Dependencies  deps.ManifestSection     `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
-	External      externalresource.Section `json:"external,omitempty" yaml:"external,omitempty"`
-	Name          string                   `json:"name,omitempty" yaml:"name,omitempty"`
-	Icon          string                   `json:"icon,omitempty" yaml:"icon,omitempty"`
-	GlobalForward []forward.GlobalForward  `json:"forward,omitempty" yaml:"forward,omitempty"`
+	Deploy               *DeployInfo              `json:"deploy,omitempty" yaml:"deploy,omitempty"`
+	Dev                  ManifestDevs             `json:"dev,omitempty" yaml:"dev,omitempty"`
+	Test                 ManifestTests            `json:"test,omitempty" yaml:"test,omitempty"`
+	Destroy              *DestroyInfo             `json:"destroy,omitempty" yaml:"destroy,omitempty"`
+	Build                build.ManifestBuild      `json:"build,omitempty" yaml:"build,omitempty"`
+	Dependencies         deps.ManifestSection     `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
+	External             externalresource.Section `json:"external,omitempty" yaml:"external,omitempty"`
+	Name                 string                   `json:"name,omitempty" yaml:"name,omitempty"`
+	Namespace            string                   `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Icon                 string                   `json:"icon,omitempty" yaml:"icon,omitempty"`
+	GlobalForward        []forward.GlobalForward  `json:"forward,omitempty" yaml:"forward,omitempty"`
+	GlobalForwardSection []forward.GlobalForward  `json:"global_forward,omitempty" yaml:"global_forward,omitempty"`
+	Metadata             *Metadata                `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	DevImages            *DevImages               `json:"devImages,omitempty" yaml:"devImages,omitempty"`
+	Context              string                   `json:"context,omitempty" yaml:"context,omitempty"`
 }
 
 func (m *Manifest) UnmarshalYAML(unmarshal func(interface{}) error) error {
@@ -808,13 +885,40 @@ func (m *Manifest) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	m.Build = manifest.Build
 	m.Dependencies = manifest.Dependencies
 	m.Name = manifest.Name
+	m.Namespace, err = env.ExpandEnvIfNotEmpty(manifest.Namespace)
+	if err != nil {
+		return fmt.Errorf("could not parse env vars of the 'namespace' field: %w", err)
+	}
+	if m.Namespace != "" {
+		if errs := validation.IsDNS1123Label(m.Namespace); len(errs) > 0 {
+			return fmt.Errorf("invalid 'namespace' value '%s': %s", m.Namespace, strings.Join(errs, ", "))
+		}
+	}
+	if manifest.GlobalForward != nil && manifest.GlobalForwardSection != nil {
+		return fmt.Errorf("only one of 'forward' and 'global_forward' can be defined at the manifest level")
+	}
 	if manifest.GlobalForward != nil {
 		m.GlobalForward = manifest.GlobalForward
 	}
+	if manifest.GlobalForwardSection != nil {
+		m.GlobalForward = manifest.GlobalForwardSection
+	}
 	m.External = manifest.External
 	if manifest.Test != nil {
 		m.Test = manifest.Test
 	}
+	m.Metadata = manifest.Metadata
+	m.DevImages = manifest.DevImages
+	m.Context = manifest.Context
+	if m.DevImages != nil {
+		if err := m.DevImages.expandEnvVars(); err != nil {
+			return err
+		}
+	}
+	for _, d := range m.Dev {
+		d.mergeMetadata(m.Metadata)
+		d.mergeDevImages(m.DevImages)
+	}
 	err = m.SanitizeSvcNames()
 	if err != nil {
 		return err
@@ -847,7 +951,7 @@ func (d *DeployInfo) MarshalYAML() (interface{}, error) {
 	if d.ComposeSection != nil && len(d.ComposeSection.ComposesInfo) != 0 {
 		return d, nil
 	}
-	isCommandList := true
+	isCommandList := len(d.HealthChecks) == 0 && d.Timeout == 0
 	for _, cmd := range d.Commands {
 		if cmd.Command != cmd.Name {
 			isCommandList = false
@@ -1097,7 +1201,7 @@ func (d *Dev) MarshalYAML() (interface{}, error) {
 	if toMarshall.Metadata != nil && len(toMarshall.Metadata.Annotations) == 0 && len(toMarshall.Metadata.Labels) == 0 {
 		toMarshall.Metadata = nil
 	}
-	if toMarshall.InitContainer.Image == config.NewImageConfig(oktetoLog.GetOutputWriter()).GetCliImage() {
+	if toMarshall.InitContainer.Image == config.NewImageConfig(oktetoLog.GetOutputWriter()).GetBinImage() {
 		toMarshall.InitContainer.Image = ""
 	}
 	if toMarshall.Timeout.Default == 1*time.Minute && toMarshall.Timeout.Resources == 2*time.Minute {
@@ -16,6 +16,7 @@ package model
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -34,6 +35,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v2"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/utils/ptr"
 )
 
@@ -931,6 +933,77 @@ resources: 30s
 	}
 }
 
+func TestResourceRequirementsUnmarshalling(t *testing.T) {
+	tests := []struct {
+		expected    ResourceRequirements
+		name        string
+		data        []byte
+		expectedErr bool
+	}{
+		{
+			name:     "unlimited",
+			data:     []byte(`unlimited`),
+			expected: ResourceRequirements{Unlimited: true},
+		},
+		{
+			name:        "invalid reduced notation",
+			data:        []byte(`unbounded`),
+			expectedErr: true,
+		},
+		{
+			name: "scale and max",
+			data: []byte(`
+scale: 2.5
+max:
+  cpu: 4
+  memory: 8Gi
+limits:
+  memory: 4Gi
+`),
+			expected: ResourceRequirements{
+				Scale: 2.5,
+				Max: ResourceList{
+					v1.ResourceCPU:    resource.MustParse("4"),
+					v1.ResourceMemory: resource.MustParse("8Gi"),
+				},
+				Limits: ResourceList{
+					v1.ResourceMemory: resource.MustParse("4Gi"),
+				},
+			},
+		},
+		{
+			name: "requests and limits",
+			data: []byte(`
+requests:
+  cpu: 250m
+limits:
+  cpu: 500m
+`),
+			expected: ResourceRequirements{
+				Requests: ResourceList{
+					v1.ResourceCPU: resource.MustParse("250m"),
+				},
+				Limits: ResourceList{
+					v1.ResourceCPU: resource.MustParse("500m"),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ResourceRequirements{}
+			err := yaml.UnmarshalStrict(tt.data, &result)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
 func TestSyncUnmarshalling(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -969,6 +1042,66 @@ rescanInterval: 10`),
 				RescanInterval: 10,
 			},
 		},
+		{
+			name: "permissions and modification time options",
+			data: []byte(`folders:
+  - .:/usr/src/app
+  - localPath: ./scripts
+    remotePath: /scripts
+    ignorePerms: false
+    modTimeWindow: 2
+ignorePerms: true
+modTimeWindow: 1`),
+			expected: Sync{
+				Folders: []SyncFolder{
+					{
+						LocalPath:  ".",
+						RemotePath: "/usr/src/app"},
+					{
+						LocalPath:     "./scripts",
+						RemotePath:    "/scripts",
+						IgnorePerms:   ptr.To(false),
+						ModTimeWindow: ptr.To(2),
+					},
+				},
+				IgnorePerms:   true,
+				ModTimeWindow: 1,
+			},
+		},
+		{
+			name: "remote excludes",
+			data: []byte(`folders:
+  - .:/usr/src/app
+remoteExcludes:
+  - target/
+  - .next/`),
+			expected: Sync{
+				Folders: []SyncFolder{
+					{
+						LocalPath:  ".",
+						RemotePath: "/usr/src/app"},
+				},
+				RemoteExcludes: []string{"target/", ".next/"},
+			},
+		},
+		{
+			name: "ownership options",
+			data: []byte(`folders:
+  - .:/usr/src/app
+preservePermissions: false
+uidMap:
+  uid: 1001
+  gid: 1001`),
+			expected: Sync{
+				Folders: []SyncFolder{
+					{
+						LocalPath:  ".",
+						RemotePath: "/usr/src/app"},
+				},
+				PreservePermissions: ptr.To(false),
+				UIDMap:              &SyncUIDMap{UID: ptr.To[int64](1001), GID: ptr.To[int64](1001)},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1018,6 +1151,20 @@ func TestSyncFoldersUnmarshalling(t *testing.T) {
 			data:     []byte(`C:/Users/src/test:/usr/src/app`),
 			expected: SyncFolder{LocalPath: "C:/Users/src/test", RemotePath: "/usr/src/app"},
 		},
+		{
+			name: "object",
+			data: []byte(`localPath: .
+remotePath: ${REMOTE_PATH}
+ignorePerms: true
+modTimeWindow: 2`),
+			expected: SyncFolder{LocalPath: ".", RemotePath: "/usr/src/app", IgnorePerms: ptr.To(true), ModTimeWindow: ptr.To(2)},
+		},
+		{
+			name: "object without options",
+			data: []byte(`localPath: .
+remotePath: /usr/src/app`),
+			expected: SyncFolder{LocalPath: ".", RemotePath: "/usr/src/app"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1035,6 +1182,67 @@ func TestSyncFoldersUnmarshalling(t *testing.T) {
 	}
 }
 
+func TestSyncFoldersUnmarshallingErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{
+			name: "no remote path",
+			data: []byte(`app`),
+		},
+		{
+			name: "object without remote path",
+			data: []byte(`localPath: .
+ignorePerms: true`),
+		},
+		{
+			name: "object with unknown field",
+			data: []byte(`localPath: .
+remotePath: /app
+permissions: false`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := SyncFolder{}
+			assert.Error(t, yaml.UnmarshalStrict(tt.data, &result))
+		})
+	}
+}
+
+func TestSyncFoldersMarshalling(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	localPath := filepath.Join(wd, "src")
+
+	tests := []struct {
+		name     string
+		expected string
+		folder   SyncFolder
+	}{
+		{
+			name:     "without options",
+			folder:   SyncFolder{LocalPath: localPath, RemotePath: "/app"},
+			expected: "src:/app\n",
+		},
+		{
+			name:     "with options",
+			folder:   SyncFolder{LocalPath: localPath, RemotePath: "/app", IgnorePerms: ptr.To(true), ModTimeWindow: ptr.To(2)},
+			expected: "ignorePerms: true\nmodTimeWindow: 2\nlocalPath: src\nremotePath: /app\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := yaml.Marshal(tt.folder)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(result))
+		})
+	}
+}
+
 func TestManifestUnmarshalling(t *testing.T) {
 	tests := []struct {
 		expected        *Manifest
@@ -2606,3 +2814,54 @@ timeout: 15m`),
 		})
 	}
 }
+
+func TestManifestGlobalForwardSection(t *testing.T) {
+	tests := []struct {
+		name     string
+		wantErr  string
+		input    string
+		expected []forward.GlobalForward
+	}{
+		{
+			name: "forward",
+			input: `
+forward:
+  - 5432:db:5432`,
+			expected: []forward.GlobalForward{{Local: 5432, Remote: 5432, ServiceName: "db"}},
+		},
+		{
+			name: "global_forward",
+			input: `
+global_forward:
+  - 5432:db:5432
+  - localPort: 6379
+    remotePort: 6379
+    name: redis`,
+			expected: []forward.GlobalForward{
+				{Local: 5432, Remote: 5432, ServiceName: "db"},
+				{Local: 6379, Remote: 6379, ServiceName: "redis"},
+			},
+		},
+		{
+			name: "forward and global_forward",
+			input: `
+forward:
+  - 5432:db:5432
+global_forward:
+  - 6379:redis:6379`,
+			wantErr: "only one of 'forward' and 'global_forward' can be defined at the manifest level",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest := &Manifest{}
+			err := yaml.UnmarshalStrict([]byte(tt.input), manifest)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, manifest.GlobalForward)
+		})
+	}
+}
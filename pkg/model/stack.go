@@ -16,6 +16,7 @@ package model
 import (
 	"errors"
 	"fmt"
+	"net"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -39,6 +40,7 @@ import (
 	"gopkg.in/yaml.v2"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 const (
@@ -46,6 +48,10 @@ const (
 	stackSupportEnabledEnvVar = "OKTETO_SUPPORT_STACKS_ENABLED"
 	// defaultValueStackSupportEnabledEnvVar is the default value for stackSupportEnabledEnvVar
 	defaultValueStackSupportEnabledEnvVar = false
+
+	// maxDNSNameservers and maxDNSSearchDomains are the limits of the dns config of a pod in kubernetes
+	maxDNSNameservers   = 3
+	maxDNSSearchDomains = 32
 )
 
 var (
@@ -58,16 +64,22 @@ var (
 
 // Stack represents an okteto stack
 type Stack struct {
-	Volumes   map[string]*VolumeSpec `yaml:"volumes,omitempty"`
-	Services  ComposeServices        `yaml:"services,omitempty"`
-	Endpoints EndpointSpec           `yaml:"endpoints,omitempty"`
-	Name      string                 `yaml:"name"`
-	Namespace string                 `yaml:"namespace,omitempty"`
-	Context   string                 `yaml:"context,omitempty"`
-	Warnings  StackWarnings          `yaml:"-"`
-	Manifest  []byte                 `yaml:"-"`
-	Paths     []string               `yaml:"-"`
-	IsCompose bool                   `yaml:"-"`
+	Volumes  map[string]*VolumeSpec `yaml:"volumes,omitempty"`
+	Configs  map[string]*ConfigSpec `yaml:"configs,omitempty"`
+	Secrets  map[string]*SecretSpec `yaml:"secrets,omitempty"`
+	Services ComposeServices        `yaml:"services,omitempty"`
+
+	// ExternalServices give a stable name in the cluster to services running outside of it
+	ExternalServices map[string]*ExternalService `yaml:"x-okteto-external-service,omitempty"`
+
+	Endpoints EndpointSpec  `yaml:"endpoints,omitempty"`
+	Name      string        `yaml:"name"`
+	Namespace string        `yaml:"namespace,omitempty"`
+	Context   string        `yaml:"context,omitempty"`
+	Warnings  StackWarnings `yaml:"-"`
+	Manifest  []byte        `yaml:"-"`
+	Paths     []string      `yaml:"-"`
+	IsCompose bool          `yaml:"-"`
 }
 
 // ComposeServices represents the services declared in the compose
@@ -100,11 +112,17 @@ type Service struct {
 	Labels             Labels                `json:"labels,omitempty" yaml:"labels,omitempty"`
 	Resources          *StackResources       `yaml:"resources,omitempty"` // For okteto stack only
 	NodeSelector       Selector              `json:"x-node-selector,omitempty" yaml:"x-node-selector,omitempty"`
+	Tolerations        []apiv1.Toleration    `json:"tolerations,omitempty" yaml:"tolerations,omitempty"`
+	ExtraHosts         []apiv1.HostAlias     `json:"extra_hosts,omitempty" yaml:"extra_hosts,omitempty"`
+	DNS                []string              `json:"dns,omitempty" yaml:"dns,omitempty"`
+	DNSSearch          []string              `json:"dns_search,omitempty" yaml:"dns_search,omitempty"`
 	EnableServiceLinks *bool                 `json:"x-enable-service-links,omitempty" yaml:"x-enable-service-links,omitempty"`
 	User               *StackSecurityContext `yaml:"user,omitempty"`
 	DependsOn          DependsOn             `yaml:"depends_on,omitempty"`
 	Build              *build.Info           `yaml:"build,omitempty"`
 	IdentityToken      *ServiceIdentityToken `json:"x-okteto-identity-token,omitempty" yaml:"x-okteto-identity-token,omitempty"`
+	ServiceAccount     string                `json:"x-okteto-serviceaccount,omitempty" yaml:"x-okteto-serviceaccount,omitempty"`
+	PriorityClassName  string                `json:"x-okteto-priority-class,omitempty" yaml:"x-okteto-priority-class,omitempty"`
 	Workdir            string                `yaml:"workdir,omitempty"`
 	Image              string                `yaml:"image,omitempty"`
 	RestartPolicy      apiv1.RestartPolicy   `yaml:"restart,omitempty"`
@@ -114,21 +132,134 @@ type Service struct {
 	Volumes         []build.VolumeMounts `yaml:"volumes,omitempty"`
 	CapAdd          []apiv1.Capability   `yaml:"cap_add,omitempty"`
 	CapDrop         []apiv1.Capability   `yaml:"cap_drop,omitempty"`
+	Devices         []Device             `yaml:"devices,omitempty"`
+	Configs         []ServiceConfig      `yaml:"configs,omitempty"`
+	Secrets         []ServiceSecret      `yaml:"secrets,omitempty"`
 	VolumeMounts    []build.VolumeMounts `yaml:"-"`
 	EnvFiles        env.Files            `yaml:"env_file,omitempty"`
 	Command         Command              `yaml:"command,omitempty"`
 	Annotations     Annotations          `json:"annotations,omitempty" yaml:"annotations,omitempty"`
 	Entrypoint      Entrypoint           `yaml:"entrypoint,omitempty"`
 	StopGracePeriod int64                `yaml:"stop_grace_period,omitempty"`
+	PreStopSleep    int64                `json:"x-okteto-prestop-sleep,omitempty" yaml:"x-okteto-prestop-sleep,omitempty"`
+	Lifecycle       *ServiceLifecycle    `json:"x-okteto-lifecycle,omitempty" yaml:"x-okteto-lifecycle,omitempty"`
+
+	// ReadinessProbe and LivenessProbe define each probe independently, overriding the healthcheck for that probe
+	ReadinessProbe *HealthCheck `json:"x-okteto-readiness-probe,omitempty" yaml:"x-okteto-readiness-probe,omitempty"`
+	LivenessProbe  *HealthCheck `json:"x-okteto-liveness-probe,omitempty" yaml:"x-okteto-liveness-probe,omitempty"`
+
+	// TopologySpread and AntiAffinity spread the replicas of the service across nodes or zones
+	TopologySpread TopologySpreads `json:"x-okteto-topology-spread,omitempty" yaml:"x-okteto-topology-spread,omitempty"`
+	AntiAffinity   AntiAffinity    `json:"x-okteto-anti-affinity,omitempty" yaml:"x-okteto-anti-affinity,omitempty"`
+
+	// PlacementConstraints schedule the service in the nodes matching 'deploy.placement.constraints'
+	PlacementConstraints []PlacementConstraint `json:"-" yaml:"-"`
+
+	// ActiveDeadlineSeconds and TTLSecondsAfterFinished are only supported by jobs
+	ActiveDeadlineSeconds   *int64 `json:"x-okteto-active-deadline-seconds,omitempty" yaml:"x-okteto-active-deadline-seconds,omitempty"`
+	TTLSecondsAfterFinished *int32 `json:"x-okteto-ttl-seconds-after-finished,omitempty" yaml:"x-okteto-ttl-seconds-after-finished,omitempty"`
 
 	Replicas     int32 `yaml:"replicas,omitempty"` // For okteto stack only
 	BackOffLimit int32 `yaml:"max_attempts,omitempty"`
 
 	Public bool `yaml:"public,omitempty"` // For okteto stack only
 
+	Privileged bool `yaml:"privileged,omitempty"`
+
+	// ReadOnly mounts the root filesystem of the container as read-only. Volumes are still mounted read-write
+	ReadOnly bool `yaml:"read_only,omitempty"`
+
+	// NoNewPrivileges and SeccompUnconfined are translated from 'security_opt'
+	NoNewPrivileges   bool `yaml:"-"`
+	SeccompUnconfined bool `yaml:"-"`
+
+	// HostPID, HostIPC and HostNetwork share the namespaces of the node, from 'pid', 'ipc' and 'network_mode' set to 'host'
+	HostPID     bool `yaml:"-"`
+	HostIPC     bool `yaml:"-"`
+	HostNetwork bool `yaml:"-"`
+
+	CreateServiceAccount bool `json:"x-okteto-create-serviceaccount,omitempty" yaml:"x-okteto-create-serviceaccount,omitempty"`
+
 	EndpointMode EndpointMode `yaml:"endpoint_mode,omitempty"` // For compose services.deploy.endpoint_mode
 }
 
+// ServiceLifecycle defines the hooks run after the service container starts and before it stops,
+// e.g. to register the service with a discovery service and deregister it
+type ServiceLifecycle struct {
+	PostStart *LifecycleHook `json:"postStart,omitempty" yaml:"postStart,omitempty"`
+	PreStop   *LifecycleHook `json:"preStop,omitempty" yaml:"preStop,omitempty"`
+}
+
+// AntiAffinity is the policy to schedule the replicas of a service in different nodes
+type AntiAffinity string
+
+const (
+	// SoftAntiAffinity prefers the nodes without replicas of the service, but schedules them together if there are none
+	SoftAntiAffinity AntiAffinity = "soft"
+
+	// HardAntiAffinity never schedules two replicas of the service in the same node
+	HardAntiAffinity AntiAffinity = "hard"
+)
+
+const (
+	// DefaultTopologySpreadKey spreads the replicas of a service across nodes
+	DefaultTopologySpreadKey = "kubernetes.io/hostname"
+
+	// DefaultTopologySpreadMaxSkew is the max difference of replicas of a service between two domains of the topology
+	DefaultTopologySpreadMaxSkew int32 = 1
+)
+
+// TopologySpreads is the list of topology spread constraints of a service. It unmarshals from a single constraint or a list
+type TopologySpreads []TopologySpread
+
+// TopologySpread spreads the replicas of a service across the domains of a topology key, like nodes or zones
+type TopologySpread struct {
+	TopologyKey string `json:"topologyKey,omitempty" yaml:"topologyKey,omitempty"`
+	// WhenUnsatisfiable is 'DoNotSchedule' (default) or 'ScheduleAnyway'
+	WhenUnsatisfiable apiv1.UnsatisfiableConstraintAction `json:"whenUnsatisfiable,omitempty" yaml:"whenUnsatisfiable,omitempty"`
+	MaxSkew           int32                               `json:"maxSkew,omitempty" yaml:"maxSkew,omitempty"`
+}
+
+// PlacementConstraintOperator is the operator of a placement constraint
+type PlacementConstraintOperator string
+
+const (
+	// PlacementConstraintEqual matches the nodes with a label set to a value, like 'node.labels.disktype == ssd'
+	PlacementConstraintEqual PlacementConstraintOperator = "=="
+
+	// PlacementConstraintNotEqual matches the nodes without a label set to a value, like 'node.labels.disktype != ssd'
+	PlacementConstraintNotEqual PlacementConstraintOperator = "!="
+
+	// PlacementConstraintExists matches the nodes with a label, like 'node.labels.gpu'
+	PlacementConstraintExists PlacementConstraintOperator = "exists"
+)
+
+// PlacementConstraint is a compose placement constraint translated to the label of the kubernetes nodes it matches
+type PlacementConstraint struct {
+	Key      string
+	Operator PlacementConstraintOperator
+	Value    string
+}
+
+// LifecycleHook runs either a command in the service container or an HTTP GET request against it
+type LifecycleHook struct {
+	Exec    *LifecycleExec    `json:"exec,omitempty" yaml:"exec,omitempty"`
+	HTTPGet *LifecycleHTTPGet `json:"httpGet,omitempty" yaml:"httpGet,omitempty"`
+}
+
+// LifecycleExec is a command executed in the service container
+type LifecycleExec struct {
+	Command Command `json:"command,omitempty" yaml:"command,omitempty"`
+}
+
+// LifecycleHTTPGet is an HTTP GET request sent to a port of the service container
+type LifecycleHTTPGet struct {
+	Path   string `json:"path,omitempty" yaml:"path,omitempty"`
+	Host   string `json:"host,omitempty" yaml:"host,omitempty"`
+	Scheme string `json:"scheme,omitempty" yaml:"scheme,omitempty"`
+	Port   int32  `json:"port,omitempty" yaml:"port,omitempty"`
+}
+
 // minIdentityTokenExpirationSeconds is the minimum expiration (in seconds) the kubelet accepts for a projected service account token
 const minIdentityTokenExpirationSeconds int64 = 600
 
@@ -148,6 +279,50 @@ type ServiceIdentityToken struct {
 // a string after manifest expansion — is parsed correctly.
 type IdentityTokenExpiration int64
 
+// Device is a device of the node mapped into the service container
+type Device struct {
+	HostPath      string `json:"source,omitempty" yaml:"source,omitempty"`
+	ContainerPath string `json:"target,omitempty" yaml:"target,omitempty"`
+	Permissions   string `json:"permissions,omitempty" yaml:"permissions,omitempty"`
+}
+
+// ConfigSpec represents a top-level config of a compose file, deployed as a configmap
+type ConfigSpec struct {
+	File string `json:"file,omitempty" yaml:"file,omitempty"`
+}
+
+// ServiceConfig mounts a top-level config as a file in the service container
+type ServiceConfig struct {
+	Mode   *int32 `json:"mode,omitempty" yaml:"mode,omitempty"`
+	Source string `json:"source,omitempty" yaml:"source,omitempty"`
+	Target string `json:"target,omitempty" yaml:"target,omitempty"`
+}
+
+// SecretSpec represents a top-level secret of a compose file, deployed as a secret.
+// Only the source of the value is stored, the value is read when the stack is deployed
+type SecretSpec struct {
+	File        string `json:"file,omitempty" yaml:"file,omitempty"`
+	Environment string `json:"environment,omitempty" yaml:"environment,omitempty"`
+}
+
+// ExternalService is a service running outside of the cluster, like a managed database, reachable by the services of the stack by its name
+type ExternalService struct {
+	Host  string  `json:"host,omitempty" yaml:"host,omitempty"`
+	Ports []int32 `json:"ports,omitempty" yaml:"ports,omitempty"`
+}
+
+// IsIP returns if the host of the external service is an IP address instead of a DNS name
+func (e *ExternalService) IsIP() bool {
+	return net.ParseIP(e.Host) != nil
+}
+
+// ServiceSecret mounts a top-level secret as a file in the service container, by default at '/run/secrets/<source>'
+type ServiceSecret struct {
+	Mode   *int32 `json:"mode,omitempty" yaml:"mode,omitempty"`
+	Source string `json:"source,omitempty" yaml:"source,omitempty"`
+	Target string `json:"target,omitempty" yaml:"target,omitempty"`
+}
+
 // StackSecurityContext defines which user and group use
 type StackSecurityContext struct {
 	RunAsUser  *int64 `json:"runAsUser,omitempty" yaml:"runAsUser,omitempty"`
@@ -165,6 +340,7 @@ type Envs struct {
 }
 type HealthCheck struct {
 	HTTP        *HTTPHealtcheck `yaml:"http,omitempty"`
+	TCP         *TCPHealthcheck `yaml:"tcp,omitempty"`
 	Test        HealtcheckTest  `yaml:"test,omitempty"`
 	Interval    time.Duration   `yaml:"interval,omitempty"`
 	Timeout     time.Duration   `yaml:"timeout,omitempty"`
@@ -180,10 +356,17 @@ type HTTPHealtcheck struct {
 	Port int32  `yaml:"port,omitempty"`
 }
 
+// TCPHealthcheck checks that a TCP connection can be opened to a port of the service
+type TCPHealthcheck struct {
+	Host string `yaml:"host,omitempty"`
+	Port int32  `yaml:"port,omitempty"`
+}
+
 type HealtcheckTest []string
 
 // StackResources represents an okteto stack resources
 type StackResources struct {
+	GPUs     GPUResources     `json:"gpus,omitempty" yaml:"gpus,omitempty"`
 	Limits   ServiceResources `json:"limits,omitempty" yaml:"limits,omitempty"`
 	Requests ServiceResources `json:"requests,omitempty" yaml:"requests,omitempty"`
 }
@@ -249,9 +432,13 @@ type EndpointRule struct {
 }
 
 type StackWarnings struct {
-	NotSupportedFields  []string          `yaml:"-"`
-	SanitizedServices   map[string]string `yaml:"-"`
-	VolumeMountWarnings []string          `yaml:"-"`
+	NotSupportedFields []string          `yaml:"-"`
+	SanitizedServices  map[string]string `yaml:"-"`
+	// HostAccessFields are the not supported fields ignored because host access is not allowed
+	HostAccessFields    []string `yaml:"-"`
+	VolumeMountWarnings []string `yaml:"-"`
+	// NewerVersionFields are the okteto extensions ignored because they are not understood by this version of okteto
+	NewerVersionFields []string `yaml:"-"`
 }
 type DependsOn map[string]DependsOnConditionSpec
 
@@ -317,6 +504,15 @@ func GetStackFromPath(name, stackPath string, isCompose bool, fs afero.Fs) (*Sta
 		return nil, err
 	}
 
+	for _, cfg := range s.Configs {
+		cfg.File = loadAbsPath(stackDir, cfg.File, fs)
+	}
+	for _, secret := range s.Secrets {
+		if secret.File != "" {
+			secret.File = loadAbsPath(stackDir, secret.File, fs)
+		}
+	}
+
 	for svcName, svc := range s.Services {
 		if err := loadEnvFiles(svc, svcName); err != nil {
 			return nil, err
@@ -482,7 +678,7 @@ func (svc *Service) ToDev(svcName string) (*Dev, error) {
 	}
 	for _, v := range svc.VolumeMounts {
 		if pathExistsAndDir(v.LocalPath) {
-			d.Sync.Folders = append(d.Sync.Folders, SyncFolder(v))
+			d.Sync.Folders = append(d.Sync.Folders, SyncFolder{LocalPath: v.LocalPath, RemotePath: v.RemotePath})
 		}
 	}
 	d.Command = svc.Command
@@ -515,6 +711,12 @@ func (s *Stack) Validate() error {
 		}
 	}
 
+	for name := range s.ExternalServices {
+		if _, ok := s.Services[name]; ok {
+			return fmt.Errorf("invalid external service '%s': the name is already used by a service of the stack", name)
+		}
+	}
+
 	wd, err := os.Getwd()
 	if err != nil {
 		return err
@@ -528,6 +730,40 @@ func (s *Stack) Validate() error {
 			return fmt.Errorf("invalid service '%s': image cannot be empty", name)
 		}
 
+		if err := validatePrivileged(name, svc); err != nil {
+			return err
+		}
+
+		if err := validateJobFields(name, svc); err != nil {
+			return err
+		}
+
+		if err := validateAntiAffinity(name, svc); err != nil {
+			return err
+		}
+
+		if err := validateTolerations(name, svc); err != nil {
+			return err
+		}
+
+		if err := validateDNS(name, svc); err != nil {
+			return err
+		}
+
+		if err := s.validateServiceConfigs(name, svc); err != nil {
+			return err
+		}
+
+		if err := s.validateServiceSecrets(name, svc); err != nil {
+			return err
+		}
+
+		if svc.PriorityClassName != "" {
+			if errs := validation.IsDNS1123Subdomain(svc.PriorityClassName); len(errs) > 0 {
+				return fmt.Errorf("invalid 'x-okteto-priority-class' for service '%s': %s", name, strings.Join(errs, ", "))
+			}
+		}
+
 		for _, v := range svc.VolumeMounts {
 			if svc.Build == nil && filesystem.FileExists(v.LocalPath) {
 				continue
@@ -544,6 +780,162 @@ func (s *Stack) Validate() error {
 	return s.Services.ValidateDependsOn(s.Services.getNames())
 }
 
+// validatePrivileged checks that a 'privileged' service doesn't also drop its privileges with 'no-new-privileges'
+func validatePrivileged(name string, svc *Service) error {
+	if svc.Privileged && svc.NoNewPrivileges {
+		return fmt.Errorf("invalid service '%s': 'security_opt' can't set 'no-new-privileges' on a 'privileged' service", name)
+	}
+	return nil
+}
+
+// ValidatePrivileged checks that the services to deploy only use 'privileged' and 'devices' when they are explicitly allowed,
+// as they give the service container access to the node
+func (s *Stack) ValidatePrivileged(servicesToDeploy []string, allowed bool) error {
+	if allowed {
+		return nil
+	}
+	names := make([]string, len(servicesToDeploy))
+	copy(names, servicesToDeploy)
+	sort.Strings(names)
+	for _, name := range names {
+		svc, ok := s.Services[name]
+		if !ok || (!svc.Privileged && len(svc.Devices) == 0) {
+			continue
+		}
+		field := "privileged"
+		if !svc.Privileged {
+			field = "devices"
+		}
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("invalid service '%s': '%s' gives the container access to the node where it runs and must be explicitly allowed", name, field),
+			Hint: fmt.Sprintf("Run the command with '--allow-privileged' or set '%s=true' to deploy services with 'privileged' or 'devices'", OktetoAllowPrivilegedEnvVar),
+		}
+	}
+	return nil
+}
+
+// validateAntiAffinity checks that the replicas of a service with a hard anti-affinity can be scheduled:
+// the replicas of a service with host volumes are always scheduled in the same node
+func validateAntiAffinity(name string, svc *Service) error {
+	if svc.AntiAffinity != HardAntiAffinity || svc.Replicas <= 1 {
+		return nil
+	}
+	for _, volume := range svc.Volumes {
+		if volume.LocalPath == "" {
+			continue
+		}
+		return oktetoErrors.UserError{
+			E:    fmt.Errorf("invalid service '%s': 'x-okteto-anti-affinity: hard' can't schedule %d replicas sharing the volume '%s'", name, svc.Replicas, volume.LocalPath),
+			Hint: "Set 'x-okteto-anti-affinity: soft' to prefer different nodes for the replicas of the service",
+		}
+	}
+	return nil
+}
+
+// validateTolerations checks that the tolerations of a service are accepted by kubernetes
+func validateTolerations(name string, svc *Service) error {
+	for i, t := range svc.Tolerations {
+		switch t.Effect {
+		case "", apiv1.TaintEffectNoSchedule, apiv1.TaintEffectPreferNoSchedule, apiv1.TaintEffectNoExecute:
+		default:
+			return fmt.Errorf("invalid 'tolerations[%d]' for service '%s': effect must be '%s', '%s' or '%s'", i, name, apiv1.TaintEffectNoSchedule, apiv1.TaintEffectPreferNoSchedule, apiv1.TaintEffectNoExecute)
+		}
+		switch t.Operator {
+		case "", apiv1.TolerationOpEqual:
+		case apiv1.TolerationOpExists:
+			if t.Value != "" {
+				return fmt.Errorf("invalid 'tolerations[%d]' for service '%s': value must be empty when operator is '%s'", i, name, apiv1.TolerationOpExists)
+			}
+		default:
+			return fmt.Errorf("invalid 'tolerations[%d]' for service '%s': operator must be '%s' or '%s'", i, name, apiv1.TolerationOpEqual, apiv1.TolerationOpExists)
+		}
+		if t.Key == "" && t.Operator != apiv1.TolerationOpExists {
+			return fmt.Errorf("invalid 'tolerations[%d]' for service '%s': operator must be '%s' when key is empty", i, name, apiv1.TolerationOpExists)
+		}
+		if t.TolerationSeconds != nil && t.Effect != apiv1.TaintEffectNoExecute {
+			return fmt.Errorf("invalid 'tolerations[%d]' for service '%s': 'tolerationSeconds' requires effect '%s'", i, name, apiv1.TaintEffectNoExecute)
+		}
+	}
+	return nil
+}
+
+// validateDNS checks that the nameservers and search domains of a service are accepted by kubernetes
+func validateDNS(name string, svc *Service) error {
+	if len(svc.DNS) > maxDNSNameservers {
+		return fmt.Errorf("invalid 'dns' for service '%s': kubernetes supports at most %d nameservers, found %d", name, maxDNSNameservers, len(svc.DNS))
+	}
+	for _, nameserver := range svc.DNS {
+		if net.ParseIP(nameserver) == nil {
+			return fmt.Errorf("invalid 'dns' for service '%s': '%s' is not a valid IP address", name, nameserver)
+		}
+	}
+	if len(svc.DNSSearch) > maxDNSSearchDomains {
+		return fmt.Errorf("invalid 'dns_search' for service '%s': kubernetes supports at most %d search domains, found %d", name, maxDNSSearchDomains, len(svc.DNSSearch))
+	}
+	for _, domain := range svc.DNSSearch {
+		if errs := validation.IsDNS1123Subdomain(strings.TrimSuffix(domain, ".")); len(errs) > 0 {
+			return fmt.Errorf("invalid 'dns_search' for service '%s': '%s' is not a valid domain", name, domain)
+		}
+	}
+	return nil
+}
+
+// validateServiceConfigs checks that the configs mounted by a service are declared in the top-level 'configs'
+func (s *Stack) validateServiceConfigs(name string, svc *Service) error {
+	for _, cfg := range svc.Configs {
+		if _, ok := s.Configs[cfg.Source]; !ok {
+			return fmt.Errorf("invalid service '%s': config '%s' is not declared in the top-level 'configs'", name, cfg.Source)
+		}
+		if !strings.HasPrefix(cfg.Target, "/") {
+			return fmt.Errorf("invalid config '%s' in service '%s': target must be an absolute path", cfg.Source, name)
+		}
+		if cfg.Mode != nil && (*cfg.Mode < 0 || *cfg.Mode > 0777) {
+			return fmt.Errorf("invalid config '%s' in service '%s': mode must be between 0000 and 0777", cfg.Source, name)
+		}
+	}
+	return nil
+}
+
+// validateServiceSecrets checks that the secrets mounted by a service are declared in the top-level 'secrets' with a single source
+func (s *Stack) validateServiceSecrets(name string, svc *Service) error {
+	for _, secret := range svc.Secrets {
+		def, ok := s.Secrets[secret.Source]
+		if !ok {
+			return fmt.Errorf("invalid service '%s': secret '%s' is not declared in the top-level 'secrets'", name, secret.Source)
+		}
+		if def.File == "" && def.Environment == "" {
+			return fmt.Errorf("invalid secret '%s' in service '%s': the secret has no 'file' or 'environment' defined", secret.Source, name)
+		}
+		if def.File != "" && def.Environment != "" {
+			return fmt.Errorf("invalid secret '%s' in service '%s': the secret cannot define both 'file' and 'environment'", secret.Source, name)
+		}
+		if secret.Mode != nil && (*secret.Mode < 0 || *secret.Mode > 0777) {
+			return fmt.Errorf("invalid secret '%s' in service '%s': mode must be between 0000 and 0777", secret.Source, name)
+		}
+	}
+	return nil
+}
+
+// validateJobFields checks that the fields that only apply to jobs are not set in deployments or statefulsets
+func validateJobFields(name string, svc *Service) error {
+	if svc.IsJob() {
+		return nil
+	}
+	field := ""
+	switch {
+	case svc.ActiveDeadlineSeconds != nil:
+		field = "x-okteto-active-deadline-seconds"
+	case svc.TTLSecondsAfterFinished != nil:
+		field = "x-okteto-ttl-seconds-after-finished"
+	default:
+		return nil
+	}
+	return oktetoErrors.UserError{
+		E:    fmt.Errorf("invalid service '%s': '%s' is only supported by jobs", name, field),
+		Hint: "Set 'restart: never' or 'restart: on-failure' with 'max_attempts' to deploy the service as a job",
+	}
+}
+
 // validateStackName checks if the name is compliant
 // name param is sanitized
 func validateStackName(name string) error {
@@ -611,6 +1003,16 @@ func (cs ComposeServices) ValidateDependsOn(svcs []string) error {
 	return nil
 }
 
+// IsServiceAccountCreated returns true if any service of the stack creates the service account
+func (s *Stack) IsServiceAccountCreated(name string) bool {
+	for _, svc := range s.Services {
+		if svc.ServiceAccount == name && svc.CreateServiceAccount {
+			return true
+		}
+	}
+	return false
+}
+
 // GetLabelSelector returns the label selector for the stack name
 func (s *Stack) GetLabelSelector() string {
 	// we need to sanitize the stack name in case this is overridden by the deploy options name
@@ -718,12 +1120,31 @@ func (stack *Stack) Merge(otherStack *Stack) *Stack {
 	if otherStack.Namespace != "" {
 		stack.Namespace = otherStack.Namespace
 	}
+	stack.Warnings.NewerVersionFields = mergeNewerVersionFields(stack.Warnings.NewerVersionFields, otherStack.Warnings.NewerVersionFields)
 	if len(otherStack.Endpoints) > 0 {
 		stack.Endpoints = otherStack.Endpoints
 	}
 	if len(otherStack.Volumes) > 0 {
 		stack.Volumes = otherStack.Volumes
 	}
+	for name, cfg := range otherStack.Configs {
+		if stack.Configs == nil {
+			stack.Configs = map[string]*ConfigSpec{}
+		}
+		stack.Configs[name] = cfg
+	}
+	for name, secret := range otherStack.Secrets {
+		if stack.Secrets == nil {
+			stack.Secrets = map[string]*SecretSpec{}
+		}
+		stack.Secrets[name] = secret
+	}
+	for name, external := range otherStack.ExternalServices {
+		if stack.ExternalServices == nil {
+			stack.ExternalServices = map[string]*ExternalService{}
+		}
+		stack.ExternalServices[name] = external
+	}
 	stack.Paths = append(stack.Paths, otherStack.Paths...)
 	stack = stack.mergeServices(otherStack)
 	return stack
@@ -751,6 +1172,30 @@ func (stack *Stack) mergeServices(otherStack *Stack) *Stack {
 		if svc.StopGracePeriod != 0 {
 			resultSvc.StopGracePeriod = svc.StopGracePeriod
 		}
+		if svc.PreStopSleep != 0 {
+			resultSvc.PreStopSleep = svc.PreStopSleep
+		}
+		if svc.Lifecycle != nil {
+			resultSvc.Lifecycle = svc.Lifecycle
+		}
+		if svc.ReadinessProbe != nil {
+			resultSvc.ReadinessProbe = svc.ReadinessProbe
+		}
+		if svc.LivenessProbe != nil {
+			resultSvc.LivenessProbe = svc.LivenessProbe
+		}
+		if len(svc.TopologySpread) > 0 {
+			resultSvc.TopologySpread = svc.TopologySpread
+		}
+		if svc.AntiAffinity != "" {
+			resultSvc.AntiAffinity = svc.AntiAffinity
+		}
+		if svc.ActiveDeadlineSeconds != nil {
+			resultSvc.ActiveDeadlineSeconds = svc.ActiveDeadlineSeconds
+		}
+		if svc.TTLSecondsAfterFinished != nil {
+			resultSvc.TTLSecondsAfterFinished = svc.TTLSecondsAfterFinished
+		}
 		if svc.BackOffLimit != 0 {
 			resultSvc.BackOffLimit = svc.BackOffLimit
 		}
@@ -767,6 +1212,36 @@ func (stack *Stack) mergeServices(otherStack *Stack) *Stack {
 		if len(svc.CapDrop) > 0 {
 			resultSvc.CapDrop = svc.CapDrop
 		}
+		if svc.Privileged {
+			resultSvc.Privileged = svc.Privileged
+		}
+		if svc.ReadOnly {
+			resultSvc.ReadOnly = svc.ReadOnly
+		}
+		if len(svc.Devices) > 0 {
+			resultSvc.Devices = svc.Devices
+		}
+		if len(svc.Configs) > 0 {
+			resultSvc.Configs = svc.Configs
+		}
+		if len(svc.Secrets) > 0 {
+			resultSvc.Secrets = svc.Secrets
+		}
+		if svc.HostPID {
+			resultSvc.HostPID = svc.HostPID
+		}
+		if svc.NoNewPrivileges {
+			resultSvc.NoNewPrivileges = svc.NoNewPrivileges
+		}
+		if svc.SeccompUnconfined {
+			resultSvc.SeccompUnconfined = svc.SeccompUnconfined
+		}
+		if svc.HostIPC {
+			resultSvc.HostIPC = svc.HostIPC
+		}
+		if svc.HostNetwork {
+			resultSvc.HostNetwork = svc.HostNetwork
+		}
 
 		if len(svc.Entrypoint.Values) > 0 {
 			resultSvc.Entrypoint = svc.Entrypoint
@@ -792,9 +1267,31 @@ func (stack *Stack) mergeServices(otherStack *Stack) *Stack {
 		if len(svc.NodeSelector) > 0 {
 			resultSvc.NodeSelector = svc.NodeSelector
 		}
+		if len(svc.PlacementConstraints) > 0 {
+			resultSvc.PlacementConstraints = svc.PlacementConstraints
+		}
+		if len(svc.Tolerations) > 0 {
+			resultSvc.Tolerations = svc.Tolerations
+		}
+		if len(svc.ExtraHosts) > 0 {
+			resultSvc.ExtraHosts = svc.ExtraHosts
+		}
+		if len(svc.DNS) > 0 {
+			resultSvc.DNS = svc.DNS
+		}
+		if len(svc.DNSSearch) > 0 {
+			resultSvc.DNSSearch = svc.DNSSearch
+		}
 		if svc.IdentityToken != nil {
 			resultSvc.IdentityToken = svc.IdentityToken
 		}
+		if svc.PriorityClassName != "" {
+			resultSvc.PriorityClassName = svc.PriorityClassName
+		}
+		if svc.ServiceAccount != "" {
+			resultSvc.ServiceAccount = svc.ServiceAccount
+			resultSvc.CreateServiceAccount = svc.CreateServiceAccount
+		}
 		if len(svc.Ports) > 0 {
 			resultSvc.Ports = svc.Ports
 		}
@@ -813,7 +1310,7 @@ func (r *StackResources) IsDefaultValue() bool {
 	if r == nil {
 		return true
 	}
-	if r.Limits.IsDefaultValue() && r.Requests.IsDefaultValue() {
+	if r.Limits.IsDefaultValue() && r.Requests.IsDefaultValue() && len(r.GPUs) == 0 {
 		return true
 	}
 	return false
@@ -960,9 +1457,6 @@ func loadEnvFiles(svc *Service, svcName string) error {
 			return err
 		}
 	}
-	sort.SliceStable(svc.Environment, func(i, j int) bool {
-		return strings.Compare(svc.Environment[i].Name, svc.Environment[j].Name) < 0
-	})
 	svc.EnvFiles = nil
 	return nil
 }
@@ -993,7 +1487,14 @@ func setEnvironmentFromFile(svc *Service, filename string) error {
 		delete(envMap, e.Name)
 	}
 
-	for name, value := range envMap {
+	// the variables of env files are unordered, they are added after the declared ones sorted by name
+	names := make([]string, 0, len(envMap))
+	for name := range envMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := envMap[name]
 		if value == "" {
 			value = os.Getenv(name)
 		}
@@ -0,0 +1,388 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agext/levenshtein"
+	"github.com/okteto/okteto/pkg/env"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// maxOverrideSuggestionDistance is the maximum levenshtein distance of a field suggested for an unknown path
+const maxOverrideSuggestionDistance = 3
+
+var (
+	// stackOverrideExcludedFields are the fields of the compose model controlled by the okteto context and flags
+	stackOverrideExcludedFields = map[string]bool{
+		"name":      true,
+		"namespace": true,
+		"context":   true,
+	}
+
+	environmentType  = reflect.TypeOf(env.Environment{})
+	durationType     = reflect.TypeOf(time.Duration(0))
+	yamlUnmarshaller = reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()
+)
+
+// StackOverride is a value set in a path of the compose model, like 'services.api.replicas=3'
+type StackOverride struct {
+	Value string
+	Path  []OverridePathSegment
+}
+
+// OverridePathSegment is a map key, a struct field or a list index of an override path
+type OverridePathSegment struct {
+	Key     string
+	Index   int
+	IsIndex bool
+}
+
+func (s OverridePathSegment) String() string {
+	if s.IsIndex {
+		return fmt.Sprintf("[%d]", s.Index)
+	}
+	return s.Key
+}
+
+// ParseStackOverrides parses a list of 'path=value' overrides of the compose model
+func ParseStackOverrides(values []string) ([]StackOverride, error) {
+	result := make([]StackOverride, 0, len(values))
+	for _, v := range values {
+		path, value, found := strings.Cut(v, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid override '%s': the format is 'path=value'", v)
+		}
+		segments, err := parseOverridePath(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid override '%s': %w", v, err)
+		}
+		result = append(result, StackOverride{Path: segments, Value: value})
+	}
+	return result, nil
+}
+
+// parseOverridePath splits a path like 'services.api.ports[0].hostport' in segments.
+// Dots in map keys are escaped with a backslash, like 'services.api.labels.app\.kubernetes\.io/name'
+func parseOverridePath(path string) ([]OverridePathSegment, error) {
+	if path == "" {
+		return nil, fmt.Errorf("the path cannot be empty")
+	}
+	result := []OverridePathSegment{}
+	for _, part := range splitOverridePath(path) {
+		name, indexes, hasIndex := strings.Cut(part, "[")
+		if name == "" {
+			return nil, fmt.Errorf("path '%s' has an empty segment", path)
+		}
+		result = append(result, OverridePathSegment{Key: name})
+		if !hasIndex {
+			continue
+		}
+		indexes = "[" + indexes
+		for indexes != "" {
+			end := strings.IndexByte(indexes, ']')
+			if !strings.HasPrefix(indexes, "[") || end == -1 {
+				return nil, fmt.Errorf("path '%s' has an invalid list index in '%s'", path, part)
+			}
+			idx, err := strconv.Atoi(indexes[1:end])
+			if err != nil || idx < 0 {
+				return nil, fmt.Errorf("path '%s' has an invalid list index '%s'", path, indexes[1:end])
+			}
+			result = append(result, OverridePathSegment{Index: idx, IsIndex: true})
+			indexes = indexes[end+1:]
+		}
+	}
+	return result, nil
+}
+
+// splitOverridePath splits a path by the dots that are not escaped with a backslash
+func splitOverridePath(path string) []string {
+	result := []string{}
+	var current strings.Builder
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '\\' && i+1 < len(path) && path[i+1] == '.':
+			current.WriteByte('.')
+			i++
+		case path[i] == '.':
+			result = append(result, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(path[i])
+		}
+	}
+	return append(result, current.String())
+}
+
+// ApplyOverrides sets the values of the overrides in the compose model
+func (s *Stack) ApplyOverrides(overrides []StackOverride) error {
+	for _, o := range overrides {
+		if !o.Path[0].IsIndex && stackOverrideExcludedFields[o.Path[0].Key] {
+			return oktetoErrors.UserError{
+				E:    fmt.Errorf("invalid override '%s': '%s' can't be overridden", formatOverridePath(o.Path), o.Path[0].Key),
+				Hint: "Use the '--name' and '--namespace' flags instead",
+			}
+		}
+		if err := setOverride(reflect.ValueOf(s).Elem(), o.Path, 0, o.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setOverride(v reflect.Value, path []OverridePathSegment, i int, value string) error {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return setOverride(v.Elem(), path, i, value)
+	}
+
+	if i == len(path) {
+		return setOverrideValue(v, path, value)
+	}
+
+	segment := path[i]
+	switch {
+	case v.Type() == environmentType && !segment.IsIndex:
+		return setEnvironmentOverride(v, path, i, value)
+	case v.Kind() == reflect.Struct:
+		if segment.IsIndex {
+			return newOverrideError(path, fmt.Errorf("'%s' is not a list", formatOverridePath(path[:i])))
+		}
+		fields := overrideFields(v.Type())
+		if i == 0 {
+			for name := range stackOverrideExcludedFields {
+				delete(fields, name)
+			}
+		}
+		idx, ok := fields[segment.Key]
+		if !ok {
+			return newUnknownOverrideError(path, i, sortedKeys(fields))
+		}
+		return setOverride(v.Field(idx), path, i+1, value)
+	case v.Kind() == reflect.Map:
+		if segment.IsIndex {
+			return newOverrideError(path, fmt.Errorf("'%s' is not a list", formatOverridePath(path[:i])))
+		}
+		return setMapOverride(v, path, i, value)
+	case v.Kind() == reflect.Slice:
+		if !segment.IsIndex {
+			return newOverrideError(path, fmt.Errorf("'%s' is a list: use an index like '%s[0]'", formatOverridePath(path[:i]), formatOverridePath(path[:i])))
+		}
+		if segment.Index > v.Len() {
+			return newOverrideError(path, fmt.Errorf("index %d is out of range: '%s' has %d elements", segment.Index, formatOverridePath(path[:i]), v.Len()))
+		}
+		if segment.Index == v.Len() {
+			v.Set(reflect.Append(v, reflect.Zero(v.Type().Elem())))
+		}
+		return setOverride(v.Index(segment.Index), path, i+1, value)
+	default:
+		return newOverrideError(path, fmt.Errorf("'%s' is a %s value and has no fields", formatOverridePath(path[:i]), v.Kind()))
+	}
+}
+
+// setMapOverride sets a value in a map. Keys must exist in maps of objects, like the services of the compose
+func setMapOverride(v reflect.Value, path []OverridePathSegment, i int, value string) error {
+	segment := path[i]
+	key := reflect.ValueOf(segment.Key).Convert(v.Type().Key())
+	existing := v.MapIndex(key)
+	elemType := v.Type().Elem()
+	if !existing.IsValid() && isOverrideObject(elemType) {
+		keys := []string{}
+		for _, k := range v.MapKeys() {
+			keys = append(keys, k.String())
+		}
+		sort.Strings(keys)
+		return newUnknownOverrideError(path, i, keys)
+	}
+
+	// map values are not addressable, so the value is modified in a copy that replaces the original one
+	elem := reflect.New(elemType).Elem()
+	if existing.IsValid() {
+		elem.Set(existing)
+	}
+	if err := setOverride(elem, path, i+1, value); err != nil {
+		return err
+	}
+	if v.IsNil() {
+		v.Set(reflect.MakeMap(v.Type()))
+	}
+	v.SetMapIndex(key, elem)
+	return nil
+}
+
+// setEnvironmentOverride sets the value of an environment variable by its name
+func setEnvironmentOverride(v reflect.Value, path []OverridePathSegment, i int, value string) error {
+	if i != len(path)-1 {
+		return newOverrideError(path, fmt.Errorf("'%s' is a string value and has no fields", formatOverridePath(path[:i+1])))
+	}
+	environment := v.Interface().(env.Environment)
+	name := path[i].Key
+	for j := range environment {
+		if environment[j].Name == name {
+			environment[j].Value = value
+			return nil
+		}
+	}
+	v.Set(reflect.ValueOf(append(environment, env.Var{Name: name, Value: value})))
+	return nil
+}
+
+func setOverrideValue(v reflect.Value, path []OverridePathSegment, value string) error {
+	if reflect.PointerTo(v.Type()).Implements(yamlUnmarshaller) {
+		target := reflect.New(v.Type())
+		if err := yaml.Unmarshal([]byte(value), target.Interface()); err != nil {
+			return newOverrideValueError(path, value, err.Error())
+		}
+		v.Set(target.Elem())
+		return nil
+	}
+
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return newOverrideValueError(path, value, "must be a duration like '30s' or '1m'")
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return newOverrideValueError(path, value, "must be 'true' or 'false'")
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return newOverrideValueError(path, value, "must be an integer")
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			return newOverrideValueError(path, value, "must be a positive integer")
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return newOverrideValueError(path, value, "must be a number")
+		}
+		v.SetFloat(n)
+	case reflect.Struct:
+		return newOverrideError(path, fmt.Errorf("'%s' is an object: set one of its fields: %s", formatOverridePath(path), strings.Join(sortedKeys(overrideFields(v.Type())), ", ")))
+	default:
+		return newOverrideError(path, fmt.Errorf("'%s' is a %s: set one of its elements", formatOverridePath(path), v.Kind()))
+	}
+	return nil
+}
+
+// overrideFields returns the index of the fields of a struct by their yaml name
+func overrideFields(t reflect.Type) map[string]int {
+	result := map[string]int{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(f.Tag.Get("yaml"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		result[name] = i
+	}
+	return result
+}
+
+// isOverrideObject returns if the values of a map are objects that can't be created by an override
+func isOverrideObject(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && !reflect.PointerTo(t).Implements(yamlUnmarshaller)
+}
+
+func sortedKeys(m map[string]int) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func formatOverridePath(path []OverridePathSegment) string {
+	var result strings.Builder
+	for i, s := range path {
+		if i > 0 && !s.IsIndex {
+			result.WriteByte('.')
+		}
+		result.WriteString(strings.ReplaceAll(s.String(), ".", "\\."))
+	}
+	return result.String()
+}
+
+func newOverrideError(path []OverridePathSegment, err error) error {
+	return oktetoErrors.UserError{
+		E: fmt.Errorf("invalid override '%s': %w", formatOverridePath(path), err),
+	}
+}
+
+func newOverrideValueError(path []OverridePathSegment, value, reason string) error {
+	return oktetoErrors.UserError{
+		E: fmt.Errorf("invalid override '%s': value '%s' %s", formatOverridePath(path), value, reason),
+	}
+}
+
+// newUnknownOverrideError returns an error suggesting the valid siblings of an unknown segment of the path
+func newUnknownOverrideError(path []OverridePathSegment, i int, siblings []string) error {
+	parent := "the compose file"
+	if i > 0 {
+		parent = fmt.Sprintf("'%s'", formatOverridePath(path[:i]))
+	}
+	err := fmt.Errorf("invalid override '%s': '%s' is not defined in %s", formatOverridePath(path), path[i].Key, parent)
+	if len(siblings) == 0 {
+		return oktetoErrors.UserError{E: err}
+	}
+
+	closest := ""
+	closestDistance := maxOverrideSuggestionDistance + 1
+	for _, s := range siblings {
+		if d := levenshtein.Distance(path[i].Key, s, nil); d < closestDistance {
+			closest = s
+			closestDistance = d
+		}
+	}
+	hint := fmt.Sprintf("Valid values are: %s", strings.Join(siblings, ", "))
+	if closest != "" {
+		hint = fmt.Sprintf("Did you mean '%s'? %s", closest, hint)
+	}
+	return oktetoErrors.UserError{E: err, Hint: hint}
+}
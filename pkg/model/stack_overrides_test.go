@@ -0,0 +1,374 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/okteto/okteto/pkg/env"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+)
+
+func Test_parseOverridePath(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		expected    []OverridePathSegment
+		expectedErr string
+	}{
+		{
+			name: "fields",
+			path: "services.api.replicas",
+			expected: []OverridePathSegment{
+				{Key: "services"}, {Key: "api"}, {Key: "replicas"},
+			},
+		},
+		{
+			name: "list index",
+			path: "services.api.ports[0].hostport",
+			expected: []OverridePathSegment{
+				{Key: "services"}, {Key: "api"}, {Key: "ports"}, {Index: 0, IsIndex: true}, {Key: "hostport"},
+			},
+		},
+		{
+			name: "nested list indexes",
+			path: "services.api.matrix[1][2]",
+			expected: []OverridePathSegment{
+				{Key: "services"}, {Key: "api"}, {Key: "matrix"}, {Index: 1, IsIndex: true}, {Index: 2, IsIndex: true},
+			},
+		},
+		{
+			name: "escaped dots",
+			path: `services.api.labels.app\.kubernetes\.io/name`,
+			expected: []OverridePathSegment{
+				{Key: "services"}, {Key: "api"}, {Key: "labels"}, {Key: "app.kubernetes.io/name"},
+			},
+		},
+		{
+			name:        "empty",
+			path:        "",
+			expectedErr: "the path cannot be empty",
+		},
+		{
+			name:        "empty segment",
+			path:        "services..replicas",
+			expectedErr: "has an empty segment",
+		},
+		{
+			name:        "trailing dot",
+			path:        "services.api.",
+			expectedErr: "has an empty segment",
+		},
+		{
+			name:        "leading index",
+			path:        "[0].image",
+			expectedErr: "has an empty segment",
+		},
+		{
+			name:        "unclosed index",
+			path:        "services.api.ports[0",
+			expectedErr: "has an invalid list index in 'ports[0'",
+		},
+		{
+			name:        "text after index",
+			path:        "services.api.ports[0]hostport",
+			expectedErr: "has an invalid list index in 'ports[0]hostport'",
+		},
+		{
+			name:        "negative index",
+			path:        "services.api.ports[-1]",
+			expectedErr: "has an invalid list index '-1'",
+		},
+		{
+			name:        "non numeric index",
+			path:        "services.api.ports[first]",
+			expectedErr: "has an invalid list index 'first'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseOverridePath(tt.path)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, tt.path, formatOverridePath(result))
+		})
+	}
+}
+
+func Test_ParseStackOverrides(t *testing.T) {
+	result, err := ParseStackOverrides([]string{"services.api.image=okteto/api:1", "services.api.environment.QUERY=a=b", "services.api.workdir="})
+	require.NoError(t, err)
+	assert.Equal(t, []StackOverride{
+		{Path: []OverridePathSegment{{Key: "services"}, {Key: "api"}, {Key: "image"}}, Value: "okteto/api:1"},
+		{Path: []OverridePathSegment{{Key: "services"}, {Key: "api"}, {Key: "environment"}, {Key: "QUERY"}}, Value: "a=b"},
+		{Path: []OverridePathSegment{{Key: "services"}, {Key: "api"}, {Key: "workdir"}}, Value: ""},
+	}, result)
+
+	_, err = ParseStackOverrides([]string{"services.api.replicas"})
+	assert.ErrorContains(t, err, "invalid override 'services.api.replicas': the format is 'path=value'")
+
+	_, err = ParseStackOverrides([]string{"services..replicas=3"})
+	assert.ErrorContains(t, err, "invalid override 'services..replicas=3'")
+}
+
+func newOverridesTestStack() *Stack {
+	return &Stack{
+		Name: "test",
+		Services: ComposeServices{
+			"api": {
+				Image:       "okteto/api:1",
+				Replicas:    1,
+				Environment: env.Environment{{Name: "DEBUG", Value: "false"}},
+				Ports:       []Port{{ContainerPort: 8080, HostPort: 8080, Protocol: apiv1.ProtocolTCP}},
+				CapAdd:      []apiv1.Capability{"NET_ADMIN"},
+				DependsOn:   DependsOn{"db": {Condition: DependsOnServiceRunning}},
+				Resources:   &StackResources{},
+			},
+			"db": {
+				Image:    "postgres:16",
+				Replicas: 1,
+			},
+		},
+		Volumes: map[string]*VolumeSpec{
+			"data": {},
+		},
+	}
+}
+
+func Test_ApplyOverrides(t *testing.T) {
+	tests := []struct {
+		check     func(t *testing.T, s *Stack)
+		name      string
+		overrides []string
+	}{
+		{
+			name:      "int",
+			overrides: []string{"services.api.replicas=3"},
+			check: func(t *testing.T, s *Stack) {
+				assert.Equal(t, int32(3), s.Services["api"].Replicas)
+			},
+		},
+		{
+			name:      "string",
+			overrides: []string{"services.api.image=okteto/api:2", "services.api.restart=Never"},
+			check: func(t *testing.T, s *Stack) {
+				assert.Equal(t, "okteto/api:2", s.Services["api"].Image)
+				assert.Equal(t, apiv1.RestartPolicyNever, s.Services["api"].RestartPolicy)
+			},
+		},
+		{
+			name:      "bool",
+			overrides: []string{"services.api.public=true"},
+			check: func(t *testing.T, s *Stack) {
+				assert.True(t, s.Services["api"].Public)
+			},
+		},
+		{
+			name:      "nil pointers are created",
+			overrides: []string{"services.db.x-enable-service-links=false", "services.db.healthcheck.interval=10s"},
+			check: func(t *testing.T, s *Stack) {
+				assert.Equal(t, ptr.To(false), s.Services["db"].EnableServiceLinks)
+				assert.Equal(t, 10*time.Second, s.Services["db"].Healtcheck.Interval)
+			},
+		},
+		{
+			name:      "existing environment variable",
+			overrides: []string{"services.api.environment.DEBUG=true"},
+			check: func(t *testing.T, s *Stack) {
+				assert.Equal(t, env.Environment{{Name: "DEBUG", Value: "true"}}, s.Services["api"].Environment)
+			},
+		},
+		{
+			name:      "new environment variable",
+			overrides: []string{"services.api.environment.LOG_LEVEL=info", "services.db.environment.POSTGRES_DB=app"},
+			check: func(t *testing.T, s *Stack) {
+				assert.Equal(t, env.Environment{{Name: "DEBUG", Value: "false"}, {Name: "LOG_LEVEL", Value: "info"}}, s.Services["api"].Environment)
+				assert.Equal(t, env.Environment{{Name: "POSTGRES_DB", Value: "app"}}, s.Services["db"].Environment)
+			},
+		},
+		{
+			name:      "map of strings",
+			overrides: []string{`services.db.labels.app\.kubernetes\.io/name=db`, "services.db.x-node-selector.disk=ssd"},
+			check: func(t *testing.T, s *Stack) {
+				assert.Equal(t, Labels{"app.kubernetes.io/name": "db"}, s.Services["db"].Labels)
+				assert.Equal(t, Selector{"disk": "ssd"}, s.Services["db"].NodeSelector)
+			},
+		},
+		{
+			name:      "map of objects",
+			overrides: []string{"services.api.depends_on.db.condition=service_healthy", "volumes.data.class=standard"},
+			check: func(t *testing.T, s *Stack) {
+				assert.Equal(t, DependsOnServiceHealthy, s.Services["api"].DependsOn["db"].Condition)
+				assert.Equal(t, "standard", s.Volumes["data"].Class)
+			},
+		},
+		{
+			name:      "list indexes",
+			overrides: []string{"services.api.ports[0].hostport=9090", "services.api.cap_add[0]=SYS_ADMIN", "services.api.cap_add[1]=NET_RAW"},
+			check: func(t *testing.T, s *Stack) {
+				assert.Equal(t, int32(9090), s.Services["api"].Ports[0].HostPort)
+				assert.Equal(t, int32(8080), s.Services["api"].Ports[0].ContainerPort)
+				assert.Equal(t, []apiv1.Capability{"SYS_ADMIN", "NET_RAW"}, s.Services["api"].CapAdd)
+			},
+		},
+		{
+			name:      "values parsed as yaml",
+			overrides: []string{"services.api.command=npm run start", "services.api.resources.limits.memory=1Gi"},
+			check: func(t *testing.T, s *Stack) {
+				assert.Equal(t, []string{"sh", "-c", "npm run start"}, s.Services["api"].Command.Values)
+				assert.Equal(t, resource.MustParse("1Gi"), s.Services["api"].Resources.Limits.Memory.Value)
+			},
+		},
+		{
+			name:      "last override wins",
+			overrides: []string{"services.api.replicas=2", "services.api.replicas=4"},
+			check: func(t *testing.T, s *Stack) {
+				assert.Equal(t, int32(4), s.Services["api"].Replicas)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newOverridesTestStack()
+			overrides, err := ParseStackOverrides(tt.overrides)
+			require.NoError(t, err)
+			require.NoError(t, s.ApplyOverrides(overrides))
+			tt.check(t, s)
+		})
+	}
+}
+
+func Test_ApplyOverridesErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		override     string
+		expectedErr  string
+		expectedHint string
+	}{
+		{
+			name:         "unknown service",
+			override:     "services.apii.replicas=3",
+			expectedErr:  "invalid override 'services.apii.replicas': 'apii' is not defined in 'services'",
+			expectedHint: "Did you mean 'api'? Valid values are: api, db",
+		},
+		{
+			name:         "unknown field",
+			override:     "services.api.replica=3",
+			expectedErr:  "invalid override 'services.api.replica': 'replica' is not defined in 'services.api'",
+			expectedHint: "Did you mean 'replicas'?",
+		},
+		{
+			name:         "unknown field without close matches",
+			override:     "services.api.storage=3",
+			expectedErr:  "'storage' is not defined in 'services.api'",
+			expectedHint: "Valid values are: annotations, build,",
+		},
+		{
+			name:         "unknown root field",
+			override:     "service.api.replicas=3",
+			expectedErr:  "'service' is not defined in the compose file",
+			expectedHint: "Did you mean 'services'? Valid values are: configs, endpoints, secrets, services, volumes",
+		},
+		{
+			name:         "fields controlled by flags",
+			override:     "namespace=other",
+			expectedErr:  "invalid override 'namespace': 'namespace' can't be overridden",
+			expectedHint: "--namespace",
+		},
+		{
+			name:        "invalid int",
+			override:    "services.api.replicas=three",
+			expectedErr: "invalid override 'services.api.replicas': value 'three' must be an integer",
+		},
+		{
+			name:        "int overflow",
+			override:    "services.api.replicas=3000000000",
+			expectedErr: "invalid override 'services.api.replicas': value '3000000000' must be an integer",
+		},
+		{
+			name:        "invalid bool",
+			override:    "services.api.public=yes please",
+			expectedErr: "invalid override 'services.api.public': value 'yes please' must be 'true' or 'false'",
+		},
+		{
+			name:        "invalid duration",
+			override:    "services.api.healthcheck.interval=10",
+			expectedErr: "invalid override 'services.api.healthcheck.interval': value '10' must be a duration",
+		},
+		{
+			name:        "invalid yaml value",
+			override:    "services.api.resources.limits.memory=lots",
+			expectedErr: "invalid override 'services.api.resources.limits.memory': value 'lots'",
+		},
+		{
+			name:        "index out of range",
+			override:    "services.api.ports[2].hostport=80",
+			expectedErr: "invalid override 'services.api.ports[2].hostport': index 2 is out of range: 'services.api.ports' has 1 elements",
+		},
+		{
+			name:        "list without index",
+			override:    "services.api.ports.hostport=80",
+			expectedErr: "'services.api.ports' is a list: use an index like 'services.api.ports[0]'",
+		},
+		{
+			name:        "index of a field that is not a list",
+			override:    "services.api.image[0]=foo",
+			expectedErr: "'services.api.image' is a string value and has no fields",
+		},
+		{
+			name:        "index of a map",
+			override:    "services[0].image=foo",
+			expectedErr: "'services' is not a list",
+		},
+		{
+			name:        "object value",
+			override:    "services.api=foo",
+			expectedErr: "'services.api' is an object: set one of its fields:",
+		},
+		{
+			name:        "list value",
+			override:    "services.api.ports=80",
+			expectedErr: "'services.api.ports' is a slice: set one of its elements",
+		},
+		{
+			name:        "fields of an environment variable",
+			override:    "services.api.environment.DEBUG.value=true",
+			expectedErr: "'services.api.environment.DEBUG' is a string value and has no fields",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newOverridesTestStack()
+			overrides, err := ParseStackOverrides([]string{tt.override})
+			require.NoError(t, err)
+			err = s.ApplyOverrides(overrides)
+			require.ErrorContains(t, err, tt.expectedErr)
+			var uErr oktetoErrors.UserError
+			require.ErrorAs(t, err, &uErr)
+			if tt.expectedHint != "" {
+				assert.Contains(t, uErr.Hint, tt.expectedHint)
+			}
+		})
+	}
+}
@@ -15,10 +15,14 @@ package model
 
 import (
 	"fmt"
+	"math"
+	"net"
 	"net/url"
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -33,10 +37,22 @@ import (
 	"github.com/okteto/okteto/pkg/model/forward"
 	apiv1 "k8s.io/api/core/v1"
 	resource "k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/ptr"
 )
 
 const (
 	DefaultReplicasNumber = 1
+
+	// hostNamespace is the value of 'pid', 'ipc' and 'network_mode' to share the namespace of the node
+	hostNamespace = "host"
+
+	// securityOptNoNewPrivileges and securityOptSeccomp are the keys of the supported 'security_opt' values
+	securityOptNoNewPrivileges = "no-new-privileges"
+	securityOptSeccomp         = "seccomp"
+
+	// secretsMountPath is the folder where the secrets of the services are mounted, as in docker compose
+	secretsMountPath = "/run/secrets"
 )
 
 // StackRaw represents an okteto stack
@@ -53,12 +69,20 @@ type StackRaw struct {
 	// Docker-compose not implemented
 	Networks *WarningType `yaml:"networks,omitempty"`
 
-	Configs *WarningType               `yaml:"configs,omitempty"`
+	Configs map[string]*configTopLevel `yaml:"configs,omitempty"`
 	Secrets map[string]*secretTopLevel `yaml:"secrets,omitempty"`
 
+	ExternalServices map[string]*externalServiceRaw `yaml:"x-okteto-external-service,omitempty"`
+
 	Warnings StackWarnings
 }
 
+// externalServiceRaw represents the short syntax, 'host' or 'host:port', and the long syntax of an external service
+type externalServiceRaw struct {
+	Host  string  `yaml:"host,omitempty"`
+	Ports []int32 `yaml:"ports,omitempty"`
+}
+
 // secretTopLevel represents a top-level secret definition in a Docker Compose file.
 type secretTopLevel struct {
 	File           string                 `yaml:"file,omitempty"`
@@ -72,6 +96,35 @@ type secretTopLevel struct {
 	Extensions     map[string]interface{} `yaml:",inline" json:"-"`
 }
 
+// configTopLevel represents a top-level config definition in a Docker Compose file.
+type configTopLevel struct {
+	File           string       `yaml:"file,omitempty"`
+	Content        *WarningType `yaml:"content,omitempty"`
+	Environment    *WarningType `yaml:"environment,omitempty"`
+	Name           *WarningType `yaml:"name,omitempty"`
+	External       *WarningType `yaml:"external,omitempty"`
+	Labels         *WarningType `yaml:"labels,omitempty"`
+	TemplateDriver *WarningType `yaml:"template_driver,omitempty"`
+}
+
+// serviceConfigRaw represents the short and long syntax of the configs of a service
+type serviceConfigRaw struct {
+	Mode   *int32       `yaml:"mode,omitempty"`
+	UID    *WarningType `yaml:"uid,omitempty"`
+	GID    *WarningType `yaml:"gid,omitempty"`
+	Source string       `yaml:"source,omitempty"`
+	Target string       `yaml:"target,omitempty"`
+}
+
+// serviceSecretRaw represents the short and long syntax of the secrets of a service
+type serviceSecretRaw struct {
+	Mode   *int32       `yaml:"mode,omitempty"`
+	UID    *WarningType `yaml:"uid,omitempty"`
+	GID    *WarningType `yaml:"gid,omitempty"`
+	Source string       `yaml:"source,omitempty"`
+	Target string       `yaml:"target,omitempty"`
+}
+
 // ServiceRaw represents an okteto stack service
 type ServiceRaw struct {
 	MemSwappiness            *WarningType           `yaml:"mem_swappiness,omitempty"`
@@ -87,28 +140,40 @@ type ServiceRaw struct {
 	StopSignal               *WarningType           `yaml:"stop_signal,omitempty"`
 	StdinOpen                *WarningType           `yaml:"stdin_open,omitempty"`
 	ShmSize                  *WarningType           `yaml:"shm_size,omitempty"`
-	SecurityOpt              *WarningType           `yaml:"security_opt,omitempty"`
-	Secrets                  *WarningType           `yaml:"secrets,omitempty"`
+	SecurityOpt              []string               `yaml:"security_opt,omitempty"`
+	Secrets                  []serviceSecretRaw     `yaml:"secrets,omitempty"`
 	Healthcheck              *HealthCheck           `yaml:"healthcheck,omitempty"`
 	IdentityToken            *ServiceIdentityToken  `json:"x-okteto-identity-token,omitempty" yaml:"x-okteto-identity-token,omitempty"`
+	ServiceAccount           string                 `json:"x-okteto-serviceaccount,omitempty" yaml:"x-okteto-serviceaccount,omitempty"`
+	PriorityClassName        string                 `json:"x-okteto-priority-class,omitempty" yaml:"x-okteto-priority-class,omitempty"`
+	CreateServiceAccount     bool                   `json:"x-okteto-create-serviceaccount,omitempty" yaml:"x-okteto-create-serviceaccount,omitempty"`
 	Runtime                  *WarningType           `yaml:"runtime,omitempty"`
 	Labels                   Labels                 `json:"labels,omitempty" yaml:"labels,omitempty"`
 	Annotations              Annotations            `json:"annotations,omitempty" yaml:"annotations,omitempty"`
 	NodeSelector             Selector               `json:"x-node-selector,omitempty" yaml:"x-node-selector,omitempty"`
+	Tolerations              []apiv1.Toleration     `json:"tolerations,omitempty" yaml:"tolerations,omitempty"`
 	EnableServiceLinks       *bool                  `json:"x-enable-service-links,omitempty" yaml:"x-enable-service-links,omitempty"`
-	ReadOnly                 *WarningType           `yaml:"read_only,omitempty"`
 	PullPolicy               *WarningType           `yaml:"pull_policy,omitempty"`
 	ContainerName            *WarningType           `yaml:"container_name,omitempty"`
 	Profiles                 *WarningType           `yaml:"profiles,omitempty"`
 	Scale                    *int32                 `yaml:"scale"`
 	StopGracePeriodSneakCase *RawMessage            `yaml:"stop_grace_period,omitempty"`
 	StopGracePeriod          *RawMessage            `yaml:"stopGracePeriod,omitempty"`
+	PreStopSleep             *RawMessage            `yaml:"x-okteto-prestop-sleep,omitempty"`
+	Lifecycle                *ServiceLifecycle      `yaml:"x-okteto-lifecycle,omitempty"`
+	ReadinessProbe           *HealthCheck           `yaml:"x-okteto-readiness-probe,omitempty"`
+	LivenessProbe            *HealthCheck           `yaml:"x-okteto-liveness-probe,omitempty"`
+	TopologySpread           TopologySpreads        `yaml:"x-okteto-topology-spread,omitempty"`
+	AntiAffinity             AntiAffinity           `yaml:"x-okteto-anti-affinity,omitempty"`
+	ActiveDeadlineSeconds    *RawMessage            `yaml:"x-okteto-active-deadline-seconds,omitempty"`
+	TTLSecondsAfterFinished  *RawMessage            `yaml:"x-okteto-ttl-seconds-after-finished,omitempty"`
 	User                     *StackSecurityContext  `yaml:"user,omitempty"`
-	Privileged               *WarningType           `yaml:"privileged,omitempty"`
+	Privileged               bool                   `yaml:"privileged,omitempty"`
+	ReadOnly                 bool                   `yaml:"read_only,omitempty"`
 	Platform                 *WarningType           `yaml:"platform,omitempty"`
 	PidLimit                 *WarningType           `yaml:"pid_limit,omitempty"`
 	DependsOn                DependsOn              `yaml:"depends_on,omitempty"`
-	Pid                      *WarningType           `yaml:"pid,omitempty"`
+	Pid                      string                 `yaml:"pid,omitempty"`
 	Replicas                 *int32                 `yaml:"replicas"`
 	Resources                *StackResources        `yaml:"resources,omitempty"`
 	BlkioConfig              *WarningType           `yaml:"blkio_config,omitempty"`
@@ -124,23 +189,23 @@ type ServiceRaw struct {
 	Build                    *composeBuildInfo      `yaml:"build,omitempty"`
 	OomScoreAdj              *WarningType           `yaml:"oom_score_adj,omitempty"`
 	DeviceCgroupRules        *WarningType           `yaml:"device_cgroup_rules,omitempty"`
-	Devices                  *WarningType           `yaml:"devices,omitempty"`
-	Dns                      *WarningType           `yaml:"dns,omitempty"`
+	Devices                  []Device               `yaml:"devices,omitempty"`
+	Dns                      stringOrListRaw        `yaml:"dns,omitempty"`
 	DnsOpt                   *WarningType           `yaml:"dns_opt,omitempty"`
-	DnsSearch                *WarningType           `yaml:"dns_search,omitempty"`
+	DnsSearch                stringOrListRaw        `yaml:"dns_search,omitempty"`
 	DomainName               *WarningType           `yaml:"domainname,omitempty"`
 	Extends                  *WarningType           `yaml:"extends,omitempty"`
 	ExternalLinks            *WarningType           `yaml:"external_links,omitempty"`
-	ExtraHosts               *WarningType           `yaml:"extra_hosts,omitempty"`
+	ExtraHosts               extraHostsRaw          `yaml:"extra_hosts,omitempty"`
 	GroupAdd                 *WarningType           `yaml:"group_add,omitempty"`
 	Hostname                 *WarningType           `yaml:"hostname,omitempty"`
 	Init                     *WarningType           `yaml:"init,omitempty"`
-	Ipc                      *WarningType           `yaml:"ipc,omitempty"`
+	Ipc                      string                 `yaml:"ipc,omitempty"`
 	Isolation                *WarningType           `yaml:"isolation,omitempty"`
 	Links                    *WarningType           `yaml:"links,omitempty"`
 	Logging                  *WarningType           `yaml:"logging,omitempty"`
-	Network_mode             *WarningType           `yaml:"network_mode,omitempty"`
-	Configs                  *WarningType           `yaml:"configs,omitempty"`
+	NetworkMode              string                 `yaml:"network_mode,omitempty"`
+	Configs                  []serviceConfigRaw     `yaml:"configs,omitempty"`
 	MacAddress               *WarningType           `yaml:"mac_address,omitempty"`
 	Deploy                   *DeployInfoRaw         `yaml:"deploy,omitempty"`
 	MemswapLimit             *WarningType           `yaml:"memswap_limit,omitempty"`
@@ -160,7 +225,7 @@ type ServiceRaw struct {
 	EnvFilesSneakCase        env.Files              `yaml:"env_file,omitempty"`
 	Args                     ArgsStack              `yaml:"args,omitempty"`
 	Entrypoint               CommandStack           `yaml:"entrypoint,omitempty"`
-	Environment              env.Environment        `yaml:"environment,omitempty"`
+	Environment              env.OrderedEnvironment `yaml:"environment,omitempty"`
 	Expose                   []PortRaw              `yaml:"expose,omitempty"`
 	Ports                    []PortRaw              `yaml:"ports,omitempty"`
 	CapDrop                  []apiv1.Capability     `yaml:"capDrop,omitempty"`
@@ -175,18 +240,25 @@ type DeployInfoRaw struct {
 	RestartPolicy *RestartPolicyRaw `yaml:"restart_policy,omitempty"`
 	EndpointMode  string            `yaml:"endpoint_mode,omitempty"`
 
-	Mode           *WarningType `yaml:"mode,omitempty"`
-	Placement      *WarningType `yaml:"placement,omitempty"`
-	Constraints    *WarningType `yaml:"constraints,omitempty"`
-	Preferences    *WarningType `yaml:"preferences,omitempty"`
-	RollbackConfig *WarningType `yaml:"rollback_config,omitempty"`
-	UpdateConfig   *WarningType `yaml:"update_config,omitempty"`
+	Mode           *WarningType  `yaml:"mode,omitempty"`
+	Placement      *PlacementRaw `yaml:"placement,omitempty"`
+	Constraints    *WarningType  `yaml:"constraints,omitempty"`
+	Preferences    *WarningType  `yaml:"preferences,omitempty"`
+	RollbackConfig *WarningType  `yaml:"rollback_config,omitempty"`
+	UpdateConfig   *WarningType  `yaml:"update_config,omitempty"`
 
 	Extensions map[string]interface{} `yaml:",inline" json:"-"`
 
 	Resources ResourcesRaw `yaml:"resources,omitempty"`
 }
 
+// PlacementRaw represents the compose 'deploy.placement' section
+type PlacementRaw struct {
+	Preferences        *WarningType `yaml:"preferences,omitempty"`
+	MaxReplicasPerNode *WarningType `yaml:"max_replicas_per_node,omitempty"`
+	Constraints        []string     `yaml:"constraints,omitempty"`
+}
+
 type RestartPolicyRaw struct {
 	Delay       *WarningType           `yaml:"delay,omitempty"`
 	Window      *WarningType           `yaml:"window,omitempty"`
@@ -310,7 +382,8 @@ func (s *Stack) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		return err
 	}
 
-	if err := validateExtensions(stackRaw); err != nil {
+	newer := getNewerFieldsTables().Compose
+	if err := validateExtensions(stackRaw, newer); err != nil {
 		return err
 	}
 	s.Name = stackRaw.Name
@@ -326,6 +399,38 @@ func (s *Stack) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		s.Volumes[sanitizeName(volumeName)] = volumeSpec
 	}
 
+	for configName, config := range stackRaw.Configs {
+		if config == nil || config.File == "" {
+			return fmt.Errorf("invalid config '%s': 'file' is required", configName)
+		}
+		if s.Configs == nil {
+			s.Configs = make(map[string]*ConfigSpec)
+		}
+		s.Configs[configName] = &ConfigSpec{File: config.File}
+	}
+
+	for secretName, secret := range stackRaw.Secrets {
+		if s.Secrets == nil {
+			s.Secrets = make(map[string]*SecretSpec)
+		}
+		if secret == nil {
+			s.Secrets[secretName] = &SecretSpec{}
+			continue
+		}
+		s.Secrets[secretName] = &SecretSpec{File: secret.File, Environment: secret.Environment}
+	}
+
+	for name, external := range stackRaw.ExternalServices {
+		externalSvc, err := external.toExternalService(name)
+		if err != nil {
+			return err
+		}
+		if s.ExternalServices == nil {
+			s.ExternalServices = make(map[string]*ExternalService)
+		}
+		s.ExternalServices[name] = externalSvc
+	}
+
 	sanitizedServicesNames := make(map[string]string)
 	s.Services = make(map[string]*Service)
 	for svcName, svcRaw := range stackRaw.Services {
@@ -341,11 +446,146 @@ func (s *Stack) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	}
 
 	s.Warnings.NotSupportedFields = getNotSupportedFields(&stackRaw)
+	s.Warnings.HostAccessFields = getHostAccessNotAllowedFields(&stackRaw)
 	s.Warnings.SanitizedServices = sanitizedServicesNames
 	s.Warnings.VolumeMountWarnings = make([]string, 0)
+	s.Warnings.NewerVersionFields = getNewerVersionFields(&stackRaw, newer)
+	return nil
+}
+
+// stringOrListRaw represents the fields of compose accepting a single value or a list of values, like 'dns'
+type stringOrListRaw []string
+
+// UnmarshalYAML implements the Unmarshaler interface of the yaml pkg.
+func (l *stringOrListRaw) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var rawString string
+	if err := unmarshal(&rawString); err == nil {
+		*l = stringOrListRaw{rawString}
+		return nil
+	}
+
+	var rawList []string
+	if err := unmarshal(&rawList); err != nil {
+		return err
+	}
+	*l = rawList
+	return nil
+}
+
+// extraHostsRaw represents the list syntax, 'hostname:ip' or 'hostname=ip', and the map syntax of 'extra_hosts'
+type extraHostsRaw []string
+
+// UnmarshalYAML implements the Unmarshaler interface of the yaml pkg.
+func (e *extraHostsRaw) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var rawList []string
+	if err := unmarshal(&rawList); err == nil {
+		*e = rawList
+		return nil
+	}
+
+	var rawMap map[string]string
+	if err := unmarshal(&rawMap); err != nil {
+		return err
+	}
+	hostnames := make([]string, 0, len(rawMap))
+	for hostname := range rawMap {
+		hostnames = append(hostnames, hostname)
+	}
+	sort.Strings(hostnames)
+	result := extraHostsRaw{}
+	for _, hostname := range hostnames {
+		result = append(result, fmt.Sprintf("%s=%s", hostname, rawMap[hostname]))
+	}
+	*e = result
+	return nil
+}
+
+// translateExtraHosts translates 'extra_hosts' into the host aliases of the pod, with one host alias for each IP
+func translateExtraHosts(extraHosts extraHostsRaw) ([]apiv1.HostAlias, error) {
+	var result []apiv1.HostAlias
+	index := map[string]int{}
+	for _, entry := range extraHosts {
+		separator := ":"
+		if strings.Contains(entry, "=") {
+			separator = "="
+		}
+		hostname, ip, found := strings.Cut(entry, separator)
+		if !found || hostname == "" || ip == "" {
+			return nil, fmt.Errorf("'%s' must have the format 'hostname:ip'", entry)
+		}
+		ip = strings.TrimSuffix(strings.TrimPrefix(ip, "["), "]")
+		if net.ParseIP(ip) == nil {
+			return nil, fmt.Errorf("'%s': '%s' is not a valid IP address", entry, ip)
+		}
+		if errs := validation.IsDNS1123Subdomain(hostname); len(errs) > 0 {
+			return nil, fmt.Errorf("'%s': '%s' is not a valid hostname", entry, hostname)
+		}
+
+		i, ok := index[ip]
+		if !ok {
+			i = len(result)
+			index[ip] = i
+			result = append(result, apiv1.HostAlias{IP: ip})
+		}
+		if !slices.Contains(result[i].Hostnames, hostname) {
+			result[i].Hostnames = append(result[i].Hostnames, hostname)
+		}
+	}
+	return result, nil
+}
+
+// UnmarshalYAML implements the Unmarshaler interface of the yaml pkg.
+func (e *externalServiceRaw) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var rawString string
+	if err := unmarshal(&rawString); err == nil {
+		host, port, err := net.SplitHostPort(rawString)
+		if err != nil {
+			e.Host = rawString
+			return nil
+		}
+		p, err := strconv.ParseInt(port, 10, 32)
+		if err != nil {
+			return fmt.Errorf("'%s' is not a valid port", port)
+		}
+		e.Host = host
+		e.Ports = []int32{int32(p)}
+		return nil
+	}
+
+	type externalServiceAlias externalServiceRaw
+	var alias externalServiceAlias
+	if err := unmarshal(&alias); err != nil {
+		return err
+	}
+	*e = externalServiceRaw(alias)
 	return nil
 }
 
+// toExternalService validates an external service: its name is the name of a kubernetes service,
+// the host is a DNS name or an IP and the services reached by IP need a port
+func (e *externalServiceRaw) toExternalService(name string) (*ExternalService, error) {
+	if errs := validation.IsDNS1035Label(name); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid external service '%s': %s", name, strings.Join(errs, ", "))
+	}
+	if e == nil || e.Host == "" {
+		return nil, fmt.Errorf("invalid external service '%s': 'host' is required", name)
+	}
+	result := &ExternalService{Host: e.Host, Ports: e.Ports}
+	if !result.IsIP() {
+		if errs := validation.IsDNS1123Subdomain(e.Host); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid external service '%s': host '%s' is not a valid IP or DNS name", name, e.Host)
+		}
+	} else if len(e.Ports) == 0 {
+		return nil, fmt.Errorf("invalid external service '%s': 'ports' is required when the host is an IP", name)
+	}
+	for _, port := range e.Ports {
+		if port < 1 || port > 65535 {
+			return nil, fmt.Errorf("invalid external service '%s': port %d must be between 1 and 65535", name, port)
+		}
+	}
+	return result, nil
+}
+
 func unmarshalVolume(volume *VolumeTopLevel, isCompose bool) (*VolumeSpec, error) {
 	result := &VolumeSpec{
 		Labels:      make(Labels),
@@ -430,6 +670,32 @@ func (serviceRaw *ServiceRaw) toService(svcName string, stack *Stack, topLevelSe
 		svc.CapDrop = serviceRaw.CapDropSneakCase
 	}
 
+	svc.Privileged = serviceRaw.Privileged
+	svc.ReadOnly = serviceRaw.ReadOnly
+	svc.Devices = serviceRaw.Devices
+	for _, cfg := range serviceRaw.Configs {
+		svc.Configs = append(svc.Configs, ServiceConfig{Source: cfg.Source, Target: cfg.Target, Mode: cfg.Mode})
+	}
+	for _, secret := range serviceRaw.Secrets {
+		svc.Secrets = append(svc.Secrets, ServiceSecret{Source: secret.Source, Target: secret.Target, Mode: secret.Mode})
+	}
+
+	svc.HostPID = isAllowedHostNamespace(serviceRaw.Pid)
+	svc.HostIPC = isAllowedHostNamespace(serviceRaw.Ipc)
+	for _, opt := range serviceRaw.SecurityOpt {
+		if !isSupportedSecurityOpt(opt) {
+			continue
+		}
+		key, value := parseSecurityOpt(opt)
+		switch key {
+		case securityOptNoNewPrivileges:
+			svc.NoNewPrivileges = value != "false"
+		case securityOptSeccomp:
+			svc.SeccompUnconfined = true
+		}
+	}
+	svc.HostNetwork = isAllowedHostNamespace(serviceRaw.NetworkMode)
+
 	if err := validateHealthcheck(serviceRaw.Healthcheck); err != nil {
 		return nil, err
 	}
@@ -438,7 +704,24 @@ func (serviceRaw *ServiceRaw) toService(svcName string, stack *Stack, topLevelSe
 		translateHealtcheckCurlToHTTP(svc.Healtcheck)
 	}
 
+	svc.ReadinessProbe, err = translateServiceProbe(serviceRaw.ReadinessProbe)
+	if err != nil {
+		return nil, fmt.Errorf("invalid 'x-okteto-readiness-probe' for service '%s': %w", svcName, err)
+	}
+	svc.LivenessProbe, err = translateServiceProbe(serviceRaw.LivenessProbe)
+	if err != nil {
+		return nil, fmt.Errorf("invalid 'x-okteto-liveness-probe' for service '%s': %w", svcName, err)
+	}
+
 	svc.NodeSelector = serviceRaw.NodeSelector
+	svc.Tolerations = serviceRaw.Tolerations
+
+	svc.ExtraHosts, err = translateExtraHosts(serviceRaw.ExtraHosts)
+	if err != nil {
+		return nil, fmt.Errorf("invalid 'extra_hosts' for service '%s': %w", svcName, err)
+	}
+	svc.DNS = serviceRaw.Dns
+	svc.DNSSearch = serviceRaw.DnsSearch
 
 	svc.EnableServiceLinks = serviceRaw.EnableServiceLinks
 
@@ -449,6 +732,13 @@ func (serviceRaw *ServiceRaw) toService(svcName string, stack *Stack, topLevelSe
 		svc.IdentityToken = serviceRaw.IdentityToken
 	}
 
+	if serviceRaw.CreateServiceAccount && serviceRaw.ServiceAccount == "" {
+		return nil, fmt.Errorf("'x-okteto-create-serviceaccount' requires 'x-okteto-serviceaccount' to be set for service '%s'", svcName)
+	}
+	svc.ServiceAccount = serviceRaw.ServiceAccount
+	svc.CreateServiceAccount = serviceRaw.CreateServiceAccount
+	svc.PriorityClassName = serviceRaw.PriorityClassName
+
 	if svc.Labels == nil {
 		svc.Labels = make(Labels)
 	}
@@ -514,14 +804,67 @@ func (serviceRaw *ServiceRaw) toService(svcName string, stack *Stack, topLevelSe
 
 	svc.StopGracePeriod, err = unmarshalDuration(serviceRaw.StopGracePeriod)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("invalid 'stop_grace_period' for service '%s': %w", svcName, err)
 	}
 
 	if serviceRaw.StopGracePeriodSneakCase != nil {
 		svc.StopGracePeriod, err = unmarshalDuration(serviceRaw.StopGracePeriodSneakCase)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("invalid 'stop_grace_period' for service '%s': %w", svcName, err)
+		}
+	}
+
+	svc.PreStopSleep, err = unmarshalDuration(serviceRaw.PreStopSleep)
+	if err != nil {
+		return nil, fmt.Errorf("invalid 'x-okteto-prestop-sleep' for service '%s': %w", svcName, err)
+	}
+
+	if serviceRaw.Lifecycle != nil {
+		if err := validateLifecycle(serviceRaw.Lifecycle, svc.Ports); err != nil {
+			return nil, fmt.Errorf("invalid 'x-okteto-lifecycle' for service '%s': %w", svcName, err)
+		}
+		if serviceRaw.Lifecycle.PreStop != nil && svc.PreStopSleep > 0 {
+			return nil, fmt.Errorf("'x-okteto-prestop-sleep' and 'x-okteto-lifecycle.preStop' can't be set together for service '%s'", svcName)
+		}
+		svc.Lifecycle = serviceRaw.Lifecycle
+	}
+
+	svc.TopologySpread, err = translateTopologySpread(serviceRaw.TopologySpread)
+	if err != nil {
+		return nil, fmt.Errorf("invalid 'x-okteto-topology-spread' for service '%s': %w", svcName, err)
+	}
+
+	if serviceRaw.Deploy != nil && serviceRaw.Deploy.Placement != nil {
+		svc.PlacementConstraints, _ = translatePlacementConstraints(serviceRaw.Deploy.Placement.Constraints)
+	}
+
+	switch serviceRaw.AntiAffinity {
+	case "", SoftAntiAffinity, HardAntiAffinity:
+		svc.AntiAffinity = serviceRaw.AntiAffinity
+	default:
+		return nil, fmt.Errorf("invalid 'x-okteto-anti-affinity' for service '%s': it must be '%s' or '%s'", svcName, SoftAntiAffinity, HardAntiAffinity)
+	}
+
+	if serviceRaw.ActiveDeadlineSeconds != nil {
+		deadline, err := unmarshalDuration(serviceRaw.ActiveDeadlineSeconds)
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'x-okteto-active-deadline-seconds' for service '%s': %w", svcName, err)
+		}
+		if deadline == 0 {
+			return nil, fmt.Errorf("invalid 'x-okteto-active-deadline-seconds' for service '%s': it must be greater than zero", svcName)
+		}
+		svc.ActiveDeadlineSeconds = &deadline
+	}
+
+	if serviceRaw.TTLSecondsAfterFinished != nil {
+		ttl, err := unmarshalDuration(serviceRaw.TTLSecondsAfterFinished)
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'x-okteto-ttl-seconds-after-finished' for service '%s': %w", svcName, err)
+		}
+		if ttl > math.MaxInt32 {
+			return nil, fmt.Errorf("invalid 'x-okteto-ttl-seconds-after-finished' for service '%s': it must be lower than %d seconds", svcName, math.MaxInt32)
 		}
+		svc.TTLSecondsAfterFinished = ptr.To(int32(ttl))
 	}
 
 	svc.Volumes, svc.VolumeMounts = splitVolumesByType(serviceRaw.Volumes, stack)
@@ -571,6 +914,7 @@ func (serviceRaw *ServiceRaw) toService(svcName string, stack *Stack, topLevelSe
 
 type healthCheckunmarshaller struct {
 	HTTP        *HTTPHealtcheck `yaml:"http,omitempty"`
+	TCP         *TCPHealthcheck `yaml:"tcp,omitempty"`
 	Readiness   *bool           `yaml:"x-okteto-readiness,omitempty"`
 	Test        HealtcheckTest  `yaml:"test,omitempty"`
 	Interval    time.Duration   `yaml:"interval,omitempty"`
@@ -602,6 +946,7 @@ func (hc *HealthCheck) UnmarshalYAML(unmarshal func(interface{}) error) error {
 
 	*hc = HealthCheck{
 		HTTP:        rawHealthcheck.HTTP,
+		TCP:         rawHealthcheck.TCP,
 		Test:        rawHealthcheck.Test,
 		Interval:    rawHealthcheck.Interval,
 		Timeout:     rawHealthcheck.Timeout,
@@ -619,15 +964,38 @@ func validateHealthcheck(healthcheck *HealthCheck) error {
 		healthcheck.Test = make(HealtcheckTest, 0)
 		healthcheck.Disable = true
 	}
-	if healthcheck != nil && healthcheck.HTTP == nil && len(healthcheck.Test) == 0 && !healthcheck.Disable {
+	if healthcheck != nil && healthcheck.HTTP == nil && healthcheck.TCP == nil && len(healthcheck.Test) == 0 && !healthcheck.Disable {
 		return fmt.Errorf("Healthcheck.test must be set")
 	}
 	if healthcheck != nil && healthcheck.HTTP != nil && len(healthcheck.Test) != 0 && !healthcheck.Disable {
 		return fmt.Errorf("healthcheck.test can not be set along with healthcheck.http")
 	}
+	if healthcheck != nil && healthcheck.TCP != nil && !healthcheck.Disable {
+		if healthcheck.HTTP != nil {
+			return fmt.Errorf("healthcheck.tcp can not be set along with healthcheck.http")
+		}
+		if len(healthcheck.Test) != 0 {
+			return fmt.Errorf("healthcheck.test can not be set along with healthcheck.tcp")
+		}
+		if healthcheck.TCP.Port <= 0 {
+			return fmt.Errorf("healthcheck.tcp.port must be set")
+		}
+	}
 	return nil
 }
 
+// translateServiceProbe validates a probe defined with the fields of a healthcheck. Disabled probes are ignored
+func translateServiceProbe(probe *HealthCheck) (*HealthCheck, error) {
+	if err := validateHealthcheck(probe); err != nil {
+		return nil, err
+	}
+	if probe == nil || probe.Disable {
+		return nil, nil
+	}
+	translateHealtcheckCurlToHTTP(probe)
+	return probe, nil
+}
+
 func translateHealtcheckCurlToHTTP(healthcheck *HealthCheck) {
 	// Join and then split the strings by space to ensure that
 	// each element in the string slice is a contiguous string with
@@ -712,17 +1080,13 @@ func getSvcPorts(public bool, rawPorts, rawExpose []PortRaw) (bool, []Port, erro
 			return false, ports, err
 		}
 	}
+	// exposed ports are only reachable by the rest of services of the stack, so the host port is
+	// discarded: they are translated into service ports but never into public endpoints
 	rawExpose = expandRangePorts(rawExpose)
 	for _, p := range rawExpose {
-		newPort := Port{HostPort: p.HostPort, ContainerPort: p.ContainerPort, Protocol: p.Protocol}
-		if p.ContainerPort == 0 {
-			if !IsAlreadyAdded(newPort, ports) {
-				ports = append(ports, newPort)
-			}
-		} else {
-			if !IsAlreadyAddedExpose(newPort, ports) {
-				ports = append(ports, newPort)
-			}
+		newPort := Port{ContainerPort: p.ContainerPort, Protocol: p.Protocol}
+		if !IsAlreadyAddedExpose(newPort, ports) {
+			ports = append(ports, newPort)
 		}
 	}
 	return public, ports, nil
@@ -759,9 +1123,9 @@ func expandRangePorts(ports []PortRaw) []PortRaw {
 			aux := 0
 			for portStart+int32(aux) != portFinish+1 {
 				if p.HostFrom != 0 {
-					newPortList = append(newPortList, PortRaw{ContainerPort: p.ContainerFrom + int32(aux)})
+					newPortList = append(newPortList, PortRaw{ContainerPort: p.ContainerFrom + int32(aux), Protocol: p.Protocol})
 				} else {
-					newPortList = append(newPortList, PortRaw{ContainerPort: p.ContainerFrom + int32(aux), HostPort: 0})
+					newPortList = append(newPortList, PortRaw{ContainerPort: p.ContainerFrom + int32(aux), HostPort: 0, Protocol: p.Protocol})
 				}
 				if portStart > portFinish {
 					aux--
@@ -863,33 +1227,116 @@ func (warning *WarningType) UnmarshalYAML(unmarshal func(interface{}) error) err
 	return nil
 }
 
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+// It supports the short syntax 'HOST_PATH[:CONTAINER_PATH[:PERMISSIONS]]' and the long syntax with source, target and permissions
+func (d *Device) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var raw string
+	if err := unmarshal(&raw); err == nil {
+		parts := strings.Split(raw, ":")
+		maxDeviceParts := 3
+		if len(parts) > maxDeviceParts {
+			return fmt.Errorf("device '%s' is malformed. Only 'HOST_PATH[:CONTAINER_PATH[:PERMISSIONS]]' is supported", raw)
+		}
+		d.HostPath = parts[0]
+		d.ContainerPath = parts[0]
+		if len(parts) > 1 {
+			d.ContainerPath = parts[1]
+		}
+		if len(parts) > 2 {
+			d.Permissions = parts[2]
+		}
+	} else {
+		type device Device // prevent recursion
+		var expanded device
+		if err := unmarshal(&expanded); err != nil {
+			return err
+		}
+		*d = Device(expanded)
+		if d.ContainerPath == "" {
+			d.ContainerPath = d.HostPath
+		}
+	}
+
+	if !strings.HasPrefix(d.HostPath, "/") || !strings.HasPrefix(d.ContainerPath, "/") {
+		return fmt.Errorf("invalid device '%s:%s': paths must be absolute", d.HostPath, d.ContainerPath)
+	}
+	if strings.Trim(d.Permissions, "rwm") != "" {
+		return fmt.Errorf("invalid device permissions '%s': only 'r', 'w' and 'm' are supported", d.Permissions)
+	}
+	return nil
+}
+
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+// The short syntax mounts the config at '/<source>'
+func (c *serviceConfigRaw) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var source string
+	if err := unmarshal(&source); err == nil {
+		c.Source = source
+	} else {
+		type serviceConfig serviceConfigRaw // prevent recursion
+		var expanded serviceConfig
+		if err := unmarshal(&expanded); err != nil {
+			return err
+		}
+		*c = serviceConfigRaw(expanded)
+	}
+
+	if c.Source == "" {
+		return fmt.Errorf("invalid service config: 'source' is required")
+	}
+	if c.Target == "" {
+		c.Target = fmt.Sprintf("/%s", c.Source)
+	}
+	return nil
+}
+
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+// Secrets are mounted at '/run/secrets/<source>' by default, and relative targets are mounted in '/run/secrets'
+func (s *serviceSecretRaw) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var source string
+	if err := unmarshal(&source); err == nil {
+		s.Source = source
+	} else {
+		type serviceSecret serviceSecretRaw // prevent recursion
+		var expanded serviceSecret
+		if err := unmarshal(&expanded); err != nil {
+			return err
+		}
+		*s = serviceSecretRaw(expanded)
+	}
+
+	if s.Source == "" {
+		return fmt.Errorf("invalid service secret: 'source' is required")
+	}
+	if s.Target == "" {
+		s.Target = s.Source
+	}
+	if !strings.HasPrefix(s.Target, "/") {
+		s.Target = path.Join(secretsMountPath, s.Target)
+	}
+	return nil
+}
+
 // UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
 func (sc *StackSecurityContext) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	var rawSecurityContext string
 	err := unmarshal(&rawSecurityContext)
 	if err == nil {
-		if strings.Contains(rawSecurityContext, ":") {
-			securityContextLength := 2
-			parts := strings.Split(rawSecurityContext, ":")
-			if len(parts) != securityContextLength {
-				return fmt.Errorf("securityContext '%s' is malformed. Only 'dddd:dddd' is supported", rawSecurityContext)
-			}
-			runAsUser, err := strconv.ParseInt(parts[0], 10, 64)
-			if err != nil {
-				return fmt.Errorf("cannot obtain UID from '%s'", parts[0])
-			}
-			runAsGroup, err := strconv.ParseInt(parts[1], 10, 64)
+		user, group, hasGroup := strings.Cut(rawSecurityContext, ":")
+		if strings.Contains(group, ":") {
+			return fmt.Errorf("user '%s' is malformed. Only 'uid' or 'uid:gid' are supported", rawSecurityContext)
+		}
+		runAsUser, err := parseStackUserID(rawSecurityContext, "UID", user)
+		if err != nil {
+			return err
+		}
+		sc.RunAsUser = &runAsUser
+		if hasGroup {
+			runAsGroup, err := parseStackUserID(rawSecurityContext, "GID", group)
 			if err != nil {
-				return fmt.Errorf("cannot obtain GID from '%s'", parts[1])
+				return err
 			}
-			sc.RunAsUser = &runAsUser
 			sc.RunAsGroup = &runAsGroup
-		} else {
-			runAsUser, err := strconv.ParseInt(rawSecurityContext, 10, 64)
-			if err != nil {
-				return fmt.Errorf("cannot obtain UID from '%s'", rawSecurityContext)
-			}
-			sc.RunAsUser = &runAsUser
 		}
 		return nil
 	}
@@ -904,6 +1351,22 @@ func (sc *StackSecurityContext) UnmarshalYAML(unmarshal func(interface{}) error)
 	return nil
 }
 
+// parseStackUserID parses the UID or GID of 'user'. Kubernetes can't resolve user or group names
+// because it doesn't read the '/etc/passwd' file of the image
+func parseStackUserID(user, kind, id string) (int64, error) {
+	result, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		if id != "" && strings.Trim(id, "0123456789-") != "" {
+			return 0, fmt.Errorf("user '%s' is not supported: kubernetes requires a numeric %s instead of the name '%s'. Use the %s defined in the image, e.g. 'user: \"1000:1000\"'", user, kind, id, kind)
+		}
+		return 0, fmt.Errorf("cannot obtain %s from '%s'", kind, id)
+	}
+	if result < 0 {
+		return 0, fmt.Errorf("user '%s' is malformed: %s must be a positive number", user, kind)
+	}
+	return result, nil
+}
+
 // UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
 func (dependsOn *DependsOn) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	result := make(DependsOn)
@@ -1189,22 +1652,29 @@ func (s *StackResources) UnmarshalYAML(unmarshal func(interface{}) error) error
 	var r stackResources
 	err := unmarshal(&r)
 	if err == nil {
+		s.GPUs = r.GPUs
 		s.Limits = r.Limits
 		s.Requests = r.Requests
 		return nil
 	}
 
-	var resources ServiceResources
+	var resources struct {
+		GPUs             GPUResources `yaml:"gpus,omitempty"`
+		ServiceResources `yaml:",inline"`
+	}
 	err = unmarshal(&resources)
 	if err != nil {
 		return err
 	}
+	s.GPUs = resources.GPUs
 	s.Limits.CPU = resources.CPU
 	s.Limits.Memory = resources.Memory
 	s.Requests.Storage = resources.Storage
 	return nil
 }
 
+// unmarshalDuration returns the seconds of a duration defined as a number of seconds or as a duration string like "1m30s".
+// Fractions of a second are rounded up
 func unmarshalDuration(raw *RawMessage) (int64, error) {
 	var duration int64
 	if raw == nil {
@@ -1217,16 +1687,21 @@ func unmarshalDuration(raw *RawMessage) (int64, error) {
 		return duration, err
 	}
 	seconds, err := strconv.Atoi(durationString)
-	if err != nil {
-		var d time.Duration
-		err = raw.unmarshal(&d)
-		if err != nil {
-			return duration, err
+	if err == nil {
+		if seconds < 0 {
+			return duration, fmt.Errorf("duration '%s' cannot be negative", durationString)
 		}
-		return int64(d.Seconds()), nil
+		return int64(seconds), nil
 	}
-	return int64(seconds), nil
 
+	d, err := time.ParseDuration(durationString)
+	if err != nil {
+		return duration, fmt.Errorf("'%s' is not a valid duration. Use a number of seconds or a duration like '1m30s'", durationString)
+	}
+	if d < 0 {
+		return duration, fmt.Errorf("duration '%s' cannot be negative", durationString)
+	}
+	return int64(math.Ceil(d.Seconds())), nil
 }
 func (httpHealtcheck *HTTPHealtcheck) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	type httpHealtCheck HTTPHealtcheck // prevent recursion
@@ -1346,8 +1821,28 @@ func getTopLevelNotSupportedFields(s *StackRaw) []string {
 	if s.Networks != nil {
 		notSupported = append(notSupported, "networks")
 	}
-	if s.Configs != nil {
-		notSupported = append(notSupported, "configs")
+	for configName, configDef := range s.Configs {
+		if configDef == nil {
+			continue
+		}
+		if configDef.Content != nil {
+			notSupported = append(notSupported, fmt.Sprintf("configs[%s].content", configName))
+		}
+		if configDef.Environment != nil {
+			notSupported = append(notSupported, fmt.Sprintf("configs[%s].environment", configName))
+		}
+		if configDef.Name != nil {
+			notSupported = append(notSupported, fmt.Sprintf("configs[%s].name", configName))
+		}
+		if configDef.External != nil {
+			notSupported = append(notSupported, fmt.Sprintf("configs[%s].external", configName))
+		}
+		if configDef.Labels != nil {
+			notSupported = append(notSupported, fmt.Sprintf("configs[%s].labels", configName))
+		}
+		if configDef.TemplateDriver != nil {
+			notSupported = append(notSupported, fmt.Sprintf("configs[%s].template_driver", configName))
+		}
 	}
 	for secretName, secretDef := range s.Secrets {
 		if secretDef == nil {
@@ -1408,8 +1903,13 @@ func getServiceNotSupportedFields(svcName string, svcInfo *ServiceRaw) []string
 	if svcInfo.CgroupParent != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].cgroup_parent", svcName))
 	}
-	if svcInfo.Configs != nil {
-		notSupported = append(notSupported, fmt.Sprintf("services[%s].configs", svcName))
+	for i, cfg := range svcInfo.Configs {
+		if cfg.UID != nil {
+			notSupported = append(notSupported, fmt.Sprintf("services[%s].configs[%d].uid", svcName, i))
+		}
+		if cfg.GID != nil {
+			notSupported = append(notSupported, fmt.Sprintf("services[%s].configs[%d].gid", svcName, i))
+		}
 	}
 	if svcInfo.CredentialSpec != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].credential_spec", svcName))
@@ -1417,18 +1917,9 @@ func getServiceNotSupportedFields(svcName string, svcInfo *ServiceRaw) []string
 	if svcInfo.DeviceCgroupRules != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].device_cgroup_rules", svcName))
 	}
-	if svcInfo.Devices != nil {
-		notSupported = append(notSupported, fmt.Sprintf("services[%s].devices", svcName))
-	}
-	if svcInfo.Dns != nil {
-		notSupported = append(notSupported, fmt.Sprintf("services[%s].dns", svcName))
-	}
 	if svcInfo.DnsOpt != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].dns_opt", svcName))
 	}
-	if svcInfo.DnsSearch != nil {
-		notSupported = append(notSupported, fmt.Sprintf("services[%s].dns_search", svcName))
-	}
 	if svcInfo.DomainName != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].domainname", svcName))
 	}
@@ -1438,9 +1929,6 @@ func getServiceNotSupportedFields(svcName string, svcInfo *ServiceRaw) []string
 	if svcInfo.ExternalLinks != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].external_links", svcName))
 	}
-	if svcInfo.ExtraHosts != nil {
-		notSupported = append(notSupported, fmt.Sprintf("services[%s].extra_hosts", svcName))
-	}
 	if svcInfo.GroupAdd != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].group_add", svcName))
 	}
@@ -1450,7 +1938,7 @@ func getServiceNotSupportedFields(svcName string, svcInfo *ServiceRaw) []string
 	if svcInfo.Init != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].init", svcName))
 	}
-	if svcInfo.Ipc != nil {
+	if svcInfo.Ipc != "" && !isAllowedHostNamespace(svcInfo.Ipc) {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].ipc", svcName))
 	}
 	if svcInfo.Isolation != nil {
@@ -1462,7 +1950,7 @@ func getServiceNotSupportedFields(svcName string, svcInfo *ServiceRaw) []string
 	if svcInfo.Logging != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].logging", svcName))
 	}
-	if svcInfo.Network_mode != nil {
+	if svcInfo.NetworkMode != "" && !isAllowedHostNamespace(svcInfo.NetworkMode) {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].network_mode", svcName))
 	}
 	if svcInfo.Networks != nil {
@@ -1483,7 +1971,7 @@ func getServiceNotSupportedFields(svcName string, svcInfo *ServiceRaw) []string
 	if svcInfo.OomScoreAdj != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].oom_score_adj", svcName))
 	}
-	if svcInfo.Pid != nil {
+	if svcInfo.Pid != "" && !isAllowedHostNamespace(svcInfo.Pid) {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].pid", svcName))
 	}
 	if svcInfo.PidLimit != nil {
@@ -1492,26 +1980,27 @@ func getServiceNotSupportedFields(svcName string, svcInfo *ServiceRaw) []string
 	if svcInfo.Platform != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].platform", svcName))
 	}
-	if svcInfo.Privileged != nil {
-		notSupported = append(notSupported, fmt.Sprintf("services[%s].privileged", svcName))
-	}
 	if svcInfo.Profiles != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].profiles", svcName))
 	}
 	if svcInfo.PullPolicy != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].pull_policy", svcName))
 	}
-	if svcInfo.ReadOnly != nil {
-		notSupported = append(notSupported, fmt.Sprintf("services[%s].read_only", svcName))
-	}
 	if svcInfo.Runtime != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].runtime", svcName))
 	}
-	if svcInfo.Secrets != nil {
-		notSupported = append(notSupported, fmt.Sprintf("services[%s].secrets", svcName))
+	for i, secret := range svcInfo.Secrets {
+		if secret.UID != nil {
+			notSupported = append(notSupported, fmt.Sprintf("services[%s].secrets[%d].uid", svcName, i))
+		}
+		if secret.GID != nil {
+			notSupported = append(notSupported, fmt.Sprintf("services[%s].secrets[%d].gid", svcName, i))
+		}
 	}
-	if svcInfo.SecurityOpt != nil {
-		notSupported = append(notSupported, fmt.Sprintf("services[%s].security_opt", svcName))
+	for i, opt := range svcInfo.SecurityOpt {
+		if !isSupportedSecurityOpt(opt) {
+			notSupported = append(notSupported, fmt.Sprintf("services[%s].security_opt[%d]", svcName, i))
+		}
 	}
 	if svcInfo.ShmSize != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].shm_size", svcName))
@@ -1546,6 +2035,54 @@ func getServiceNotSupportedFields(svcName string, svcInfo *ServiceRaw) []string
 	return notSupported
 }
 
+// parseSecurityOpt splits a 'security_opt' value in its key and value, accepting ':' and '=' as separator
+func parseSecurityOpt(opt string) (string, string) {
+	if i := strings.IndexAny(opt, ":="); i >= 0 {
+		return opt[:i], opt[i+1:]
+	}
+	return opt, ""
+}
+
+// isSupportedSecurityOpt returns if a 'security_opt' value is translated to the container: only 'no-new-privileges'
+// and 'seccomp=unconfined' are supported
+func isSupportedSecurityOpt(opt string) bool {
+	key, value := parseSecurityOpt(opt)
+	switch key {
+	case securityOptNoNewPrivileges:
+		return value == "" || value == "true" || value == "false"
+	case securityOptSeccomp:
+		return value == "unconfined"
+	default:
+		return false
+	}
+}
+
+// isAllowedHostNamespace returns if a service can share the 'pid', 'ipc' or network namespace of the node
+func isAllowedHostNamespace(value string) bool {
+	return value == hostNamespace && env.LoadBoolean(OktetoAllowHostAccessEnvVar)
+}
+
+// getHostAccessNotAllowedFields returns the 'pid', 'ipc' and 'network_mode' fields set to 'host' that are ignored
+// because host access is not allowed
+func getHostAccessNotAllowedFields(s *StackRaw) []string {
+	if env.LoadBoolean(OktetoAllowHostAccessEnvVar) {
+		return nil
+	}
+	var fields []string
+	for name, svcInfo := range s.Services {
+		if svcInfo.Pid == hostNamespace {
+			fields = append(fields, fmt.Sprintf("services[%s].pid", name))
+		}
+		if svcInfo.Ipc == hostNamespace {
+			fields = append(fields, fmt.Sprintf("services[%s].ipc", name))
+		}
+		if svcInfo.NetworkMode == hostNamespace {
+			fields = append(fields, fmt.Sprintf("services[%s].network_mode", name))
+		}
+	}
+	return fields
+}
+
 func getDeployNotSupportedFields(svcName string, deploy *DeployInfoRaw) []string {
 	notSupported := make([]string, 0)
 
@@ -1568,7 +2105,16 @@ func getDeployNotSupportedFields(svcName string, deploy *DeployInfoRaw) []string
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].deploy.mode", svcName))
 	}
 	if deploy.Placement != nil {
-		notSupported = append(notSupported, fmt.Sprintf("services[%s].deploy.placement", svcName))
+		if deploy.Placement.Preferences != nil {
+			notSupported = append(notSupported, fmt.Sprintf("services[%s].deploy.placement.preferences", svcName))
+		}
+		if deploy.Placement.MaxReplicasPerNode != nil {
+			notSupported = append(notSupported, fmt.Sprintf("services[%s].deploy.placement.max_replicas_per_node", svcName))
+		}
+		_, unsupported := translatePlacementConstraints(deploy.Placement.Constraints)
+		for _, constraint := range unsupported {
+			notSupported = append(notSupported, fmt.Sprintf("services[%s].deploy.placement.constraints '%s'", svcName, constraint))
+		}
 	}
 	if deploy.Constraints != nil {
 		notSupported = append(notSupported, fmt.Sprintf("services[%s].deploy.constraints", svcName))
@@ -1617,20 +2163,44 @@ func (c *CommandStack) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		if err != nil {
 			return err
 		}
-		if strings.Contains(single, " && ") {
+		values, err := shellquote.Split(single)
+		if err != nil {
+			return fmt.Errorf("invalid command '%s': %w", single, err)
+		}
+		if hasShellOperators(values) {
+			// docker compose would pass the operators as arguments, we keep running them in a shell for backwards compatibility
 			c.Values = []string{"sh", "-c", single}
-		} else {
-			c.Values, err = shellquote.Split(single)
-			if err != nil {
-				return err
-			}
+			return nil
 		}
+		c.Values = values
 	} else {
 		c.Values = multi
 	}
 	return nil
 }
 
+// shellOperators are the control and redirection operators that only work when the command runs in a shell
+var shellOperators = map[string]bool{
+	"&&": true,
+	"||": true,
+	"|":  true,
+	";":  true,
+	"&":  true,
+	">":  true,
+	">>": true,
+	"<":  true,
+}
+
+// hasShellOperators returns true if any of the words split from a command is an unquoted shell operator
+func hasShellOperators(words []string) bool {
+	for _, w := range words {
+		if shellOperators[w] {
+			return true
+		}
+	}
+	return false
+}
+
 // UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
 func (a *ArgsStack) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	var multi []string
@@ -1672,6 +2242,21 @@ func (e *IdentityTokenExpiration) UnmarshalYAML(unmarshal func(interface{}) erro
 	return nil
 }
 
+// UnmarshalYAML Implements the Unmarshaler interface of the yaml pkg.
+func (t *TopologySpreads) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var multi []TopologySpread
+	if err := unmarshal(&multi); err == nil {
+		*t = multi
+		return nil
+	}
+	var single TopologySpread
+	if err := unmarshal(&single); err != nil {
+		return err
+	}
+	*t = TopologySpreads{single}
+	return nil
+}
+
 func validateIdentityToken(token *ServiceIdentityToken) error {
 	if token.Audience == "" {
 		return fmt.Errorf("'audience' is required")
@@ -1690,7 +2275,128 @@ func validateIdentityToken(token *ServiceIdentityToken) error {
 	return nil
 }
 
-func validateExtensions(stack StackRaw) error {
+// translateTopologySpread sets the default values of the topology spread constraints and validates them
+func translateTopologySpread(spreads TopologySpreads) (TopologySpreads, error) {
+	if len(spreads) == 0 {
+		return nil, nil
+	}
+	result := make(TopologySpreads, 0, len(spreads))
+	for _, spread := range spreads {
+		if spread.TopologyKey == "" {
+			spread.TopologyKey = DefaultTopologySpreadKey
+		}
+		if spread.MaxSkew == 0 {
+			spread.MaxSkew = DefaultTopologySpreadMaxSkew
+		}
+		if spread.MaxSkew < 0 {
+			return nil, fmt.Errorf("'maxSkew' must be greater than zero")
+		}
+		switch spread.WhenUnsatisfiable {
+		case "":
+			spread.WhenUnsatisfiable = apiv1.DoNotSchedule
+		case apiv1.DoNotSchedule, apiv1.ScheduleAnyway:
+		default:
+			return nil, fmt.Errorf("'whenUnsatisfiable' must be '%s' or '%s'", apiv1.DoNotSchedule, apiv1.ScheduleAnyway)
+		}
+		result = append(result, spread)
+	}
+	return result, nil
+}
+
+// placementConstraintNodeLabels are the node attributes of the compose placement constraints with a well-known kubernetes label
+var placementConstraintNodeLabels = map[string]string{
+	"node.hostname":      "kubernetes.io/hostname",
+	"node.platform.os":   "kubernetes.io/os",
+	"node.platform.arch": "kubernetes.io/arch",
+}
+
+// translatePlacementConstraints translates the compose placement constraints to the labels of the nodes they match.
+// It returns the expressions that can't be translated, like the ones using 'node.role' or 'engine.labels'
+func translatePlacementConstraints(constraints []string) ([]PlacementConstraint, []string) {
+	var result []PlacementConstraint
+	var unsupported []string
+	for _, constraint := range constraints {
+		translated, ok := translatePlacementConstraint(constraint)
+		if !ok {
+			unsupported = append(unsupported, constraint)
+			continue
+		}
+		result = append(result, translated)
+	}
+	return result, unsupported
+}
+
+func translatePlacementConstraint(constraint string) (PlacementConstraint, bool) {
+	attribute, value := strings.TrimSpace(constraint), ""
+	operator := PlacementConstraintExists
+	for _, op := range []PlacementConstraintOperator{PlacementConstraintEqual, PlacementConstraintNotEqual} {
+		if before, after, found := strings.Cut(constraint, string(op)); found {
+			attribute, value, operator = strings.TrimSpace(before), strings.TrimSpace(after), op
+			break
+		}
+	}
+
+	key, ok := placementConstraintNodeLabels[attribute]
+	if !ok {
+		key, ok = strings.CutPrefix(attribute, "node.labels.")
+	}
+	if !ok || key == "" || strings.ContainsAny(key, " =!") {
+		return PlacementConstraint{}, false
+	}
+	if operator == PlacementConstraintExists {
+		// only node labels can be matched by its existence
+		if _, isAttribute := placementConstraintNodeLabels[attribute]; isAttribute {
+			return PlacementConstraint{}, false
+		}
+		return PlacementConstraint{Key: key, Operator: operator}, true
+	}
+	if value == "" || strings.ContainsAny(value, " =!") {
+		return PlacementConstraint{}, false
+	}
+	return PlacementConstraint{Key: key, Operator: operator, Value: value}, true
+}
+
+func validateLifecycle(lifecycle *ServiceLifecycle, ports []Port) error {
+	hooks := []struct {
+		hook *LifecycleHook
+		name string
+	}{
+		{name: "postStart", hook: lifecycle.PostStart},
+		{name: "preStop", hook: lifecycle.PreStop},
+	}
+	for _, h := range hooks {
+		if h.hook == nil {
+			continue
+		}
+		if err := validateLifecycleHook(h.hook, ports); err != nil {
+			return fmt.Errorf("'%s': %w", h.name, err)
+		}
+	}
+	return nil
+}
+
+func validateLifecycleHook(hook *LifecycleHook, ports []Port) error {
+	if (hook.Exec == nil) == (hook.HTTPGet == nil) {
+		return fmt.Errorf("exactly one of 'exec' or 'httpGet' must be set")
+	}
+	if hook.Exec != nil {
+		if len(hook.Exec.Command.Values) == 0 {
+			return fmt.Errorf("'exec.command' can't be empty")
+		}
+		return nil
+	}
+	if scheme := strings.ToLower(hook.HTTPGet.Scheme); scheme != "" && scheme != "http" && scheme != "https" {
+		return fmt.Errorf("'httpGet.scheme' must be 'http' or 'https'")
+	}
+	for _, p := range ports {
+		if p.ContainerPort == hook.HTTPGet.Port {
+			return nil
+		}
+	}
+	return fmt.Errorf("'httpGet.port' %d is not a port of the service", hook.HTTPGet.Port)
+}
+
+func validateExtensions(stack StackRaw, newer newerFields) error {
 	nonValidFields := make([]string, 0)
 	for extension := range stack.Extensions {
 		if !strings.HasPrefix(extension, "x-") {
@@ -1703,6 +2409,9 @@ func validateExtensions(stack StackRaw) error {
 			return fmt.Errorf("%w: %w", oktetoErrors.ErrInvalidManifest, oktetoErrors.ErrServiceEmpty)
 		}
 		for extension := range svc.Extensions {
+			if isIgnoredServiceExtension(extension, newer) {
+				continue
+			}
 			nonValidFields = append(nonValidFields, fmt.Sprintf("services[%s].%s", svcName, extension))
 		}
 		if svc.Deploy != nil {
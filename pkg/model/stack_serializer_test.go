@@ -353,6 +353,36 @@ func Test_HealthcheckUnmarshalling(t *testing.T) {
 			expected:      nil,
 			expectedError: true,
 		},
+		{
+			name:          "healthcheck tcp",
+			manifest:      []byte("services:\n  app:\n    healthcheck:\n      interval: 10s\n      timeout: 10m\n      retries: 5\n      start_period: 30s\n      tcp:\n        port: 6379\n    image: redis"),
+			expected:      &HealthCheck{TCP: &TCPHealthcheck{Port: 6379}, Interval: 10 * time.Second, Timeout: 10 * time.Minute, Retries: 5, StartPeriod: 30 * time.Second, Readiness: true},
+			expectedError: false,
+		},
+		{
+			name:          "healthcheck tcp with host",
+			manifest:      []byte("services:\n  app:\n    healthcheck:\n      tcp:\n        host: 127.0.0.1\n        port: 5432\n    image: postgres"),
+			expected:      &HealthCheck{TCP: &TCPHealthcheck{Host: "127.0.0.1", Port: 5432}, Readiness: true},
+			expectedError: false,
+		},
+		{
+			name:          "healthcheck with tcp and http",
+			manifest:      []byte("services:\n  app:\n    healthcheck:\n      tcp:\n        port: 8080\n      http:\n        path: /\n        port: 8080\n    image: okteto/vote:1"),
+			expected:      nil,
+			expectedError: true,
+		},
+		{
+			name:          "healthcheck with tcp and test",
+			manifest:      []byte("services:\n  app:\n    healthcheck:\n      tcp:\n        port: 6379\n      test: redis-cli ping\n    image: redis"),
+			expected:      nil,
+			expectedError: true,
+		},
+		{
+			name:          "healthcheck tcp without port",
+			manifest:      []byte("services:\n  app:\n    healthcheck:\n      tcp:\n        host: localhost\n    image: redis"),
+			expected:      nil,
+			expectedError: true,
+		},
 		{
 			name:          "healthcheck http path not starting with /",
 			manifest:      []byte("services:\n  app:\n    healthcheck:\n      interval: 10s\n      timeout: 10m\n      retries: 5\n      start_period: 30s\n      http:\n        path: db\n        port: 8080\n    image: okteto/vote:1"),
@@ -768,9 +798,10 @@ func Test_PortUnmarshalling(t *testing.T) {
 func Test_DurationUnmarshalling(t *testing.T) {
 
 	tests := []struct {
-		name     string
-		duration []byte
-		expected int64
+		name      string
+		duration  []byte
+		expected  int64
+		expectErr bool
 	}{
 		{
 			name:     "string-no-units",
@@ -782,6 +813,36 @@ func Test_DurationUnmarshalling(t *testing.T) {
 			duration: []byte("12s"),
 			expected: 12,
 		},
+		{
+			name:     "string-with-minutes",
+			duration: []byte("1m30s"),
+			expected: 90,
+		},
+		{
+			name:     "quoted-string",
+			duration: []byte(`"2m"`),
+			expected: 120,
+		},
+		{
+			name:     "fractions-are-rounded-up",
+			duration: []byte("1500ms"),
+			expected: 2,
+		},
+		{
+			name:      "negative-seconds",
+			duration:  []byte("-5"),
+			expectErr: true,
+		},
+		{
+			name:      "negative-duration",
+			duration:  []byte("-5s"),
+			expectErr: true,
+		},
+		{
+			name:      "invalid-duration",
+			duration:  []byte("5 minutes"),
+			expectErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -792,6 +853,12 @@ func Test_DurationUnmarshalling(t *testing.T) {
 
 			duration, err := unmarshalDuration(msg)
 
+			if tt.expectErr {
+				if err == nil {
+					t.Fatal("didn't get the expected error")
+				}
+				return
+			}
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -923,6 +990,125 @@ func TestStackResourcesUnmarshalling(t *testing.T) {
 	}
 }
 
+func Test_CommandStackUnmarshalling(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		expected    []string
+		expectedErr bool
+	}{
+		{
+			name:     "list form is not parsed",
+			data:     `["bash", "-c", "sleep 10 && run", "'quoted'"]`,
+			expected: []string{"bash", "-c", "sleep 10 && run", "'quoted'"},
+		},
+		{
+			name:     "words",
+			data:     `npm run start`,
+			expected: []string{"npm", "run", "start"},
+		},
+		{
+			name:     "extra whitespace",
+			data:     `"  npm   start  "`,
+			expected: []string{"npm", "start"},
+		},
+		{
+			name:     "double quotes",
+			data:     `bash -c "sleep 10 && run"`,
+			expected: []string{"bash", "-c", "sleep 10 && run"},
+		},
+		{
+			name:     "single quotes",
+			data:     `bash -c 'echo "hello world"'`,
+			expected: []string{"bash", "-c", `echo "hello world"`},
+		},
+		{
+			name:     "nested quotes",
+			data:     `/bin/bash -c "envsubst < /tmp/nginx.conf > /etc/nginx/conf.d/default.conf && nginx -g 'daemon off;'"`,
+			expected: []string{"/bin/bash", "-c", "envsubst < /tmp/nginx.conf > /etc/nginx/conf.d/default.conf && nginx -g 'daemon off;'"},
+		},
+		{
+			name:     "escaped quotes inside double quotes",
+			data:     `'echo "say \"hi\""'`,
+			expected: []string{"echo", `say "hi"`},
+		},
+		{
+			name:     "backslashes are literal inside single quotes",
+			data:     `"echo 'a\\b'"`,
+			expected: []string{"echo", `a\b`},
+		},
+		{
+			name:     "escaped whitespace",
+			data:     `'echo hello\ world'`,
+			expected: []string{"echo", "hello world"},
+		},
+		{
+			name:     "quotes joined to words",
+			data:     `--name="my app"`,
+			expected: []string{"--name=my app"},
+		},
+		{
+			name:     "empty quoted argument",
+			data:     `echo ""`,
+			expected: []string{"echo", ""},
+		},
+		{
+			name:     "variables are not expanded",
+			data:     `echo $HOME "${USER}"`,
+			expected: []string{"echo", "$HOME", "${USER}"},
+		},
+		{
+			name:     "empty string",
+			data:     `""`,
+			expected: []string{},
+		},
+		{
+			name:     "unquoted shell operators run in a shell",
+			data:     `echo value1 > var.html && python -m http.server 8080`,
+			expected: []string{"sh", "-c", "echo value1 > var.html && python -m http.server 8080"},
+		},
+		{
+			name:     "unquoted pipe runs in a shell",
+			data:     `cat file | grep "a b"`,
+			expected: []string{"sh", "-c", `cat file | grep "a b"`},
+		},
+		{
+			name:        "unterminated quote",
+			data:        `bash -c "sleep 10`,
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cmd CommandStack
+			err := yaml.Unmarshal([]byte(tt.data), &cmd)
+			if tt.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if len(tt.expected) == 0 {
+				require.Empty(t, cmd.Values)
+				return
+			}
+			require.Equal(t, tt.expected, cmd.Values)
+		})
+	}
+}
+
+func Test_ComposeCommandDollarEscaping(t *testing.T) {
+	manifest := []byte(`services:
+  app:
+    image: okteto/vote:1
+    entrypoint: sh -c "echo $$HOME"
+    command: [echo, "$${USER}"]`)
+
+	s, err := ReadStack(manifest, true)
+	require.NoError(t, err)
+	require.Equal(t, []string{"sh", "-c", "echo $HOME"}, s.Services["app"].Entrypoint.Values)
+	require.Equal(t, []string{"echo", "${USER}"}, s.Services["app"].Command.Values)
+}
+
 func Test_validateCommandArgs(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -1991,6 +2177,16 @@ func Test_Environment(t *testing.T) {
 			manifest:    []byte("services:\n  app:\n    image: okteto/vote:1"),
 			environment: env.Environment{},
 		},
+		{
+			name:        "map envs keep declaration order",
+			manifest:    []byte("services:\n  app:\n    environment:\n        ZONE: eu\n        URL: http://$(ZONE)\n    image: okteto/vote:1"),
+			environment: env.Environment{env.Var{Name: "ZONE", Value: "eu"}, env.Var{Name: "URL", Value: "http://$(ZONE)"}},
+		},
+		{
+			name:        "list envs keep declaration order",
+			manifest:    []byte("services:\n  app:\n    environment:\n      - ZONE=eu\n      - URL=http://$(ZONE)\n    image: okteto/vote:1"),
+			environment: env.Environment{env.Var{Name: "ZONE", Value: "eu"}, env.Var{Name: "URL", Value: "http://$(ZONE)"}},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2215,6 +2411,7 @@ func Test_UnmarshalStackUser(t *testing.T) {
 	tests := []struct {
 		expected      *StackSecurityContext
 		name          string
+		errContains   string
 		manifest      []byte
 		errorExpected bool
 	}{
@@ -2239,6 +2436,30 @@ func Test_UnmarshalStackUser(t *testing.T) {
 			errorExpected: true,
 			expected:      nil,
 		},
+		{
+			name:          "named user",
+			manifest:      []byte("nginx"),
+			errorExpected: true,
+			errContains:   "user 'nginx' is not supported: kubernetes requires a numeric UID instead of the name 'nginx'",
+		},
+		{
+			name:          "named group",
+			manifest:      []byte("1000:staff"),
+			errorExpected: true,
+			errContains:   "user '1000:staff' is not supported: kubernetes requires a numeric GID instead of the name 'staff'",
+		},
+		{
+			name:          "negative uid",
+			manifest:      []byte("-1"),
+			errorExpected: true,
+			errContains:   "user '-1' is malformed: UID must be a positive number",
+		},
+		{
+			name:          "too many parts",
+			manifest:      []byte("1000:1000:1000"),
+			errorExpected: true,
+			errContains:   "user '1000:1000:1000' is malformed",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2247,6 +2468,7 @@ func Test_UnmarshalStackUser(t *testing.T) {
 				if !tt.errorExpected {
 					t.Fatalf("unexpected error unmarshaling %s: %s", tt.name, err.Error())
 				}
+				assert.ErrorContains(t, err, tt.errContains)
 				return
 			}
 			if tt.errorExpected {
@@ -2697,6 +2919,55 @@ services:
 	}
 }
 
+func Test_ServiceAccountUnmarshalling(t *testing.T) {
+	tests := []struct {
+		name           string
+		manifest       string
+		serviceAccount string
+		create         bool
+		expectErr      bool
+	}{
+		{
+			name: "service account",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+    x-okteto-serviceaccount: my-sa`,
+			serviceAccount: "my-sa",
+		},
+		{
+			name: "service account created by the stack",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+    x-okteto-serviceaccount: my-sa
+    x-okteto-create-serviceaccount: true`,
+			serviceAccount: "my-sa",
+			create:         true,
+		},
+		{
+			name: "create without service account",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+    x-okteto-create-serviceaccount: true`,
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ReadStack([]byte(tt.manifest), true)
+			if tt.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.serviceAccount, s.Services["app"].ServiceAccount)
+			require.Equal(t, tt.create, s.Services["app"].CreateServiceAccount)
+		})
+	}
+}
+
 func Test_IdentityTokenUnmarshalling_Valid(t *testing.T) {
 	tests := []struct {
 		expected *ServiceIdentityToken
@@ -3023,3 +3294,925 @@ func Test_validateIdentityToken(t *testing.T) {
 		})
 	}
 }
+
+func Test_PrivilegedAndDevicesUnmarshalling(t *testing.T) {
+	tests := []struct {
+		name       string
+		manifest   string
+		devices    []Device
+		privileged bool
+		expectErr  bool
+	}{
+		{
+			name: "privileged",
+			manifest: `services:
+  app:
+    image: docker:dind
+    privileged: true`,
+			privileged: true,
+		},
+		{
+			name: "devices short syntax",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+    devices:
+    - /dev/kvm
+    - /dev/fuse:/dev/custom-fuse
+    - /dev/sda:/dev/xvda:r`,
+			devices: []Device{
+				{HostPath: "/dev/kvm", ContainerPath: "/dev/kvm"},
+				{HostPath: "/dev/fuse", ContainerPath: "/dev/custom-fuse"},
+				{HostPath: "/dev/sda", ContainerPath: "/dev/xvda", Permissions: "r"},
+			},
+		},
+		{
+			name: "devices long syntax",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+    devices:
+    - source: /dev/kvm
+      permissions: rwm`,
+			devices: []Device{
+				{HostPath: "/dev/kvm", ContainerPath: "/dev/kvm", Permissions: "rwm"},
+			},
+		},
+		{
+			name: "relative device",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+    devices:
+    - dev/kvm`,
+			expectErr: true,
+		},
+		{
+			name: "invalid permissions",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+    devices:
+    - /dev/kvm:/dev/kvm:rx`,
+			expectErr: true,
+		},
+		{
+			name: "malformed device",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+    devices:
+    - /dev/kvm:/dev/kvm:rwm:extra`,
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ReadStack([]byte(tt.manifest), true)
+			if tt.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.privileged, s.Services["app"].Privileged)
+			assert.Equal(t, tt.devices, s.Services["app"].Devices)
+			assert.Empty(t, s.Warnings.NotSupportedFields)
+		})
+	}
+}
+
+func Test_ConfigsUnmarshalling(t *testing.T) {
+	tests := []struct {
+		name                string
+		manifest            string
+		expectedErr         string
+		configs             map[string]*ConfigSpec
+		svcConfigs          []ServiceConfig
+		notSupportedWarning []string
+	}{
+		{
+			name: "short and long syntax",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+    configs:
+    - nginx
+    - source: settings
+      target: /etc/app/settings.json
+      mode: 0440
+configs:
+  nginx:
+    file: ./nginx.conf
+  settings:
+    file: ./settings.json`,
+			configs: map[string]*ConfigSpec{
+				"nginx":    {File: "./nginx.conf"},
+				"settings": {File: "./settings.json"},
+			},
+			svcConfigs: []ServiceConfig{
+				{Source: "nginx", Target: "/nginx"},
+				{Source: "settings", Target: "/etc/app/settings.json", Mode: ptr.To(int32(0440))},
+			},
+		},
+		{
+			name: "not supported fields",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+    configs:
+    - source: nginx
+      uid: "103"
+configs:
+  nginx:
+    file: ./nginx.conf
+    labels:
+      app: nginx`,
+			configs: map[string]*ConfigSpec{
+				"nginx": {File: "./nginx.conf"},
+			},
+			svcConfigs: []ServiceConfig{
+				{Source: "nginx", Target: "/nginx"},
+			},
+			notSupportedWarning: []string{"configs[nginx].labels", "services[app].configs[0].uid"},
+		},
+		{
+			name: "config without file",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+configs:
+  nginx:
+    external: true`,
+			expectedErr: "invalid config 'nginx': 'file' is required",
+		},
+		{
+			name: "service config without source",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+    configs:
+    - target: /etc/nginx.conf
+configs:
+  nginx:
+    file: ./nginx.conf`,
+			expectedErr: "invalid service config: 'source' is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ReadStack([]byte(tt.manifest), true)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.configs, s.Configs)
+			assert.Equal(t, tt.svcConfigs, s.Services["app"].Configs)
+			assert.ElementsMatch(t, tt.notSupportedWarning, s.Warnings.NotSupportedFields)
+		})
+	}
+}
+
+func Test_SecretsUnmarshalling(t *testing.T) {
+	tests := []struct {
+		name                string
+		manifest            string
+		expectedErr         string
+		secrets             map[string]*SecretSpec
+		svcSecrets          []ServiceSecret
+		notSupportedWarning []string
+	}{
+		{
+			name: "short and long syntax",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+    secrets:
+    - db_password
+    - source: api_key
+      target: api.key
+      mode: 0400
+    - source: tls
+      target: /etc/tls/cert.pem
+secrets:
+  db_password:
+    file: ./db_password.txt
+  api_key:
+    environment: API_KEY
+  tls:
+    file: ./cert.pem`,
+			secrets: map[string]*SecretSpec{
+				"db_password": {File: "./db_password.txt"},
+				"api_key":     {Environment: "API_KEY"},
+				"tls":         {File: "./cert.pem"},
+			},
+			svcSecrets: []ServiceSecret{
+				{Source: "db_password", Target: "/run/secrets/db_password"},
+				{Source: "api_key", Target: "/run/secrets/api.key", Mode: ptr.To(int32(0400))},
+				{Source: "tls", Target: "/etc/tls/cert.pem"},
+			},
+		},
+		{
+			name: "not supported fields",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+    secrets:
+    - source: db_password
+      gid: "103"
+secrets:
+  db_password:
+    file: ./db_password.txt`,
+			secrets: map[string]*SecretSpec{
+				"db_password": {File: "./db_password.txt"},
+			},
+			svcSecrets: []ServiceSecret{
+				{Source: "db_password", Target: "/run/secrets/db_password"},
+			},
+			notSupportedWarning: []string{"services[app].secrets[0].gid"},
+		},
+		{
+			name: "service secret without source",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+    secrets:
+    - target: /run/secrets/db
+secrets:
+  db_password:
+    file: ./db_password.txt`,
+			expectedErr: "invalid service secret: 'source' is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ReadStack([]byte(tt.manifest), true)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.secrets, s.Secrets)
+			assert.Equal(t, tt.svcSecrets, s.Services["app"].Secrets)
+			assert.ElementsMatch(t, tt.notSupportedWarning, s.Warnings.NotSupportedFields)
+		})
+	}
+}
+
+func Test_StopGracePeriodAndPreStopSleepUnmarshalling(t *testing.T) {
+	tests := []struct {
+		name            string
+		manifest        string
+		expectedErr     string
+		stopGracePeriod int64
+		preStopSleep    int64
+	}{
+		{
+			name: "durations",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+    stop_grace_period: 1m30s
+    x-okteto-prestop-sleep: 5s`,
+			stopGracePeriod: 90,
+			preStopSleep:    5,
+		},
+		{
+			name: "seconds",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+    stop_grace_period: 20
+    x-okteto-prestop-sleep: 3`,
+			stopGracePeriod: 20,
+			preStopSleep:    3,
+		},
+		{
+			name: "not defined",
+			manifest: `services:
+  app:
+    image: okteto/vote:1`,
+		},
+		{
+			name: "invalid stop_grace_period",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+    stop_grace_period: 1 minute`,
+			expectedErr: "invalid 'stop_grace_period' for service 'app'",
+		},
+		{
+			name: "invalid prestop sleep",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+    x-okteto-prestop-sleep: -5s`,
+			expectedErr: "invalid 'x-okteto-prestop-sleep' for service 'app'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ReadStack([]byte(tt.manifest), true)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.stopGracePeriod, s.Services["app"].StopGracePeriod)
+			assert.Equal(t, tt.preStopSleep, s.Services["app"].PreStopSleep)
+		})
+	}
+}
+
+func Test_LifecycleUnmarshalling(t *testing.T) {
+	tests := []struct {
+		expected    *ServiceLifecycle
+		name        string
+		manifest    string
+		expectedErr string
+	}{
+		{
+			name: "exec and httpGet hooks",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+    ports:
+      - 8080
+    x-okteto-lifecycle:
+      postStart:
+        exec:
+          command: ./register.sh
+      preStop:
+        httpGet:
+          path: /deregister
+          port: 8080`,
+			expected: &ServiceLifecycle{
+				PostStart: &LifecycleHook{Exec: &LifecycleExec{Command: Command{Values: []string{"./register.sh"}}}},
+				PreStop:   &LifecycleHook{HTTPGet: &LifecycleHTTPGet{Path: "/deregister", Port: 8080}},
+			},
+		},
+		{
+			name: "not defined",
+			manifest: `services:
+  app:
+    image: okteto/vote:1`,
+		},
+		{
+			name: "empty exec command",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+    x-okteto-lifecycle:
+      postStart:
+        exec:
+          command: []`,
+			expectedErr: "invalid 'x-okteto-lifecycle' for service 'app': 'postStart': 'exec.command' can't be empty",
+		},
+		{
+			name: "exec and httpGet in the same hook",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+    ports:
+      - 8080
+    x-okteto-lifecycle:
+      preStop:
+        exec:
+          command: ./deregister.sh
+        httpGet:
+          port: 8080`,
+			expectedErr: "invalid 'x-okteto-lifecycle' for service 'app': 'preStop': exactly one of 'exec' or 'httpGet' must be set",
+		},
+		{
+			name: "httpGet port not declared",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+    ports:
+      - 8080
+    x-okteto-lifecycle:
+      preStop:
+        httpGet:
+          path: /deregister
+          port: 9090`,
+			expectedErr: "invalid 'x-okteto-lifecycle' for service 'app': 'preStop': 'httpGet.port' 9090 is not a port of the service",
+		},
+		{
+			name: "invalid httpGet scheme",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+    ports:
+      - 8080
+    x-okteto-lifecycle:
+      postStart:
+        httpGet:
+          port: 8080
+          scheme: ftp`,
+			expectedErr: "invalid 'x-okteto-lifecycle' for service 'app': 'postStart': 'httpGet.scheme' must be 'http' or 'https'",
+		},
+		{
+			name: "preStop with prestop sleep",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+    x-okteto-prestop-sleep: 5s
+    x-okteto-lifecycle:
+      preStop:
+        exec:
+          command: ./deregister.sh`,
+			expectedErr: "'x-okteto-prestop-sleep' and 'x-okteto-lifecycle.preStop' can't be set together for service 'app'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ReadStack([]byte(tt.manifest), true)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, s.Services["app"].Lifecycle)
+		})
+	}
+}
+
+func Test_PriorityClassAndJobLimitsUnmarshalling(t *testing.T) {
+	tests := []struct {
+		activeDeadlineSeconds   *int64
+		ttlSecondsAfterFinished *int32
+		name                    string
+		manifest                string
+		expectedErr             string
+		priorityClass           string
+	}{
+		{
+			name: "durations",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+    restart: never
+    x-okteto-priority-class: low-priority
+    x-okteto-active-deadline-seconds: 10m
+    x-okteto-ttl-seconds-after-finished: 1h`,
+			priorityClass:           "low-priority",
+			activeDeadlineSeconds:   ptr.To(int64(600)),
+			ttlSecondsAfterFinished: ptr.To(int32(3600)),
+		},
+		{
+			name: "seconds",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+    restart: never
+    x-okteto-active-deadline-seconds: 30
+    x-okteto-ttl-seconds-after-finished: 0`,
+			activeDeadlineSeconds:   ptr.To(int64(30)),
+			ttlSecondsAfterFinished: ptr.To(int32(0)),
+		},
+		{
+			name: "not defined",
+			manifest: `services:
+  app:
+    image: okteto/vote:1`,
+		},
+		{
+			name: "zero active deadline",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+    restart: never
+    x-okteto-active-deadline-seconds: 0`,
+			expectedErr: "invalid 'x-okteto-active-deadline-seconds' for service 'app': it must be greater than zero",
+		},
+		{
+			name: "invalid ttl",
+			manifest: `services:
+  app:
+    image: okteto/vote:1
+    restart: never
+    x-okteto-ttl-seconds-after-finished: -1h`,
+			expectedErr: "invalid 'x-okteto-ttl-seconds-after-finished' for service 'app'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ReadStack([]byte(tt.manifest), true)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.priorityClass, s.Services["app"].PriorityClassName)
+			assert.Equal(t, tt.activeDeadlineSeconds, s.Services["app"].ActiveDeadlineSeconds)
+			assert.Equal(t, tt.ttlSecondsAfterFinished, s.Services["app"].TTLSecondsAfterFinished)
+		})
+	}
+}
+
+func TestComposeHostNamespaces(t *testing.T) {
+	manifest := []byte(`services:
+  profiler:
+    image: okteto/profiler
+    pid: host
+    ipc: host
+    network_mode: host
+  app:
+    image: okteto/app
+    ipc: shareable
+    network_mode: bridge`)
+	tests := []struct {
+		name                 string
+		expectedNotSupported []string
+		expectedHostAccess   []string
+		allowed              bool
+		expectedHost         bool
+	}{
+		{
+			name:                 "host access not allowed",
+			expectedNotSupported: []string{"services[profiler].pid", "services[profiler].ipc", "services[profiler].network_mode", "services[app].ipc", "services[app].network_mode"},
+			expectedHostAccess:   []string{"services[profiler].pid", "services[profiler].ipc", "services[profiler].network_mode"},
+		},
+		{
+			name:                 "host access allowed",
+			allowed:              true,
+			expectedHost:         true,
+			expectedNotSupported: []string{"services[app].ipc", "services[app].network_mode"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.allowed {
+				t.Setenv(OktetoAllowHostAccessEnvVar, "true")
+			}
+			s, err := ReadStack(manifest, false)
+			require.NoError(t, err)
+
+			profiler := s.Services["profiler"]
+			assert.Equal(t, tt.expectedHost, profiler.HostPID)
+			assert.Equal(t, tt.expectedHost, profiler.HostIPC)
+			assert.Equal(t, tt.expectedHost, profiler.HostNetwork)
+			app := s.Services["app"]
+			assert.False(t, app.HostPID)
+			assert.False(t, app.HostIPC)
+			assert.False(t, app.HostNetwork)
+
+			assert.ElementsMatch(t, tt.expectedNotSupported, s.Warnings.NotSupportedFields)
+			assert.ElementsMatch(t, tt.expectedHostAccess, s.Warnings.HostAccessFields)
+		})
+	}
+}
+
+func TestComposeTopologySpreadAndAntiAffinity(t *testing.T) {
+	tests := []struct {
+		name                 string
+		manifest             string
+		expectedSpread       TopologySpreads
+		expectedAntiAffinity AntiAffinity
+		expectedErr          string
+	}{
+		{
+			name: "single topology spread with defaults",
+			manifest: `services:
+  app:
+    image: okteto/app
+    x-okteto-anti-affinity: soft
+    x-okteto-topology-spread:
+      topologyKey: topology.kubernetes.io/zone`,
+			expectedSpread: TopologySpreads{
+				{TopologyKey: "topology.kubernetes.io/zone", MaxSkew: 1, WhenUnsatisfiable: apiv1.DoNotSchedule},
+			},
+			expectedAntiAffinity: SoftAntiAffinity,
+		},
+		{
+			name: "list of topology spreads",
+			manifest: `services:
+  app:
+    image: okteto/app
+    x-okteto-anti-affinity: hard
+    x-okteto-topology-spread:
+      - maxSkew: 2
+      - topologyKey: topology.kubernetes.io/zone
+        whenUnsatisfiable: ScheduleAnyway`,
+			expectedSpread: TopologySpreads{
+				{TopologyKey: "kubernetes.io/hostname", MaxSkew: 2, WhenUnsatisfiable: apiv1.DoNotSchedule},
+				{TopologyKey: "topology.kubernetes.io/zone", MaxSkew: 1, WhenUnsatisfiable: apiv1.ScheduleAnyway},
+			},
+			expectedAntiAffinity: HardAntiAffinity,
+		},
+		{
+			name: "invalid anti-affinity",
+			manifest: `services:
+  app:
+    image: okteto/app
+    x-okteto-anti-affinity: always`,
+			expectedErr: "invalid 'x-okteto-anti-affinity' for service 'app'",
+		},
+		{
+			name: "invalid when unsatisfiable",
+			manifest: `services:
+  app:
+    image: okteto/app
+    x-okteto-topology-spread:
+      whenUnsatisfiable: Never`,
+			expectedErr: "invalid 'x-okteto-topology-spread' for service 'app'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ReadStack([]byte(tt.manifest), false)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedSpread, s.Services["app"].TopologySpread)
+			assert.Equal(t, tt.expectedAntiAffinity, s.Services["app"].AntiAffinity)
+		})
+	}
+}
+
+func TestComposeReadinessAndLivenessProbes(t *testing.T) {
+	tests := []struct {
+		name              string
+		manifest          string
+		expectedReadiness *HealthCheck
+		expectedLiveness  *HealthCheck
+		expectedErr       string
+	}{
+		{
+			name: "readiness and liveness probes",
+			manifest: `services:
+  app:
+    image: okteto/app
+    x-okteto-readiness-probe:
+      test: curl -f http://localhost:8080/ready
+      interval: 5s
+    x-okteto-liveness-probe:
+      test: ["CMD", "pgrep", "app"]
+      start_period: 1m`,
+			expectedReadiness: &HealthCheck{
+				HTTP:      &HTTPHealtcheck{Path: "/ready", Port: 8080},
+				Test:      HealtcheckTest{},
+				Interval:  5 * time.Second,
+				Readiness: true,
+			},
+			expectedLiveness: &HealthCheck{
+				Test:        HealtcheckTest{"pgrep", "app"},
+				StartPeriod: time.Minute,
+				Readiness:   true,
+			},
+		},
+		{
+			name: "disabled probe",
+			manifest: `services:
+  app:
+    image: okteto/app
+    x-okteto-liveness-probe:
+      disable: true`,
+		},
+		{
+			name: "probe without test",
+			manifest: `services:
+  app:
+    image: okteto/app
+    x-okteto-readiness-probe:
+      interval: 5s`,
+			expectedErr: "invalid 'x-okteto-readiness-probe' for service 'app'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ReadStack([]byte(tt.manifest), false)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedReadiness, s.Services["app"].ReadinessProbe)
+			assert.Equal(t, tt.expectedLiveness, s.Services["app"].LivenessProbe)
+		})
+	}
+}
+
+func TestComposePlacementConstraints(t *testing.T) {
+	manifest := []byte(`services:
+  app:
+    image: okteto/app
+    deploy:
+      placement:
+        constraints:
+          - node.labels.disktype == ssd
+          - node.labels.zone!=us-east-1a
+          - node.labels.gpu
+          - node.platform.os == linux
+          - node.role == manager
+          - engine.labels.operatingsystem == ubuntu`)
+
+	s, err := ReadStack(manifest, true)
+	require.NoError(t, err)
+	assert.Equal(t, []PlacementConstraint{
+		{Key: "disktype", Operator: PlacementConstraintEqual, Value: "ssd"},
+		{Key: "zone", Operator: PlacementConstraintNotEqual, Value: "us-east-1a"},
+		{Key: "gpu", Operator: PlacementConstraintExists},
+		{Key: "kubernetes.io/os", Operator: PlacementConstraintEqual, Value: "linux"},
+	}, s.Services["app"].PlacementConstraints)
+	assert.Equal(t, []string{
+		"services[app].deploy.placement.constraints 'node.role == manager'",
+		"services[app].deploy.placement.constraints 'engine.labels.operatingsystem == ubuntu'",
+	}, s.Warnings.NotSupportedFields)
+}
+
+func TestComposeTolerations(t *testing.T) {
+	manifest := []byte(`services:
+  app:
+    image: okteto/app
+    tolerations:
+      - key: nvidia.com/gpu
+        operator: Exists
+        effect: NoSchedule
+      - key: dedicated
+        value: ml`)
+
+	s, err := ReadStack(manifest, true)
+	require.NoError(t, err)
+	assert.Equal(t, []apiv1.Toleration{
+		{Key: "nvidia.com/gpu", Operator: apiv1.TolerationOpExists, Effect: apiv1.TaintEffectNoSchedule},
+		{Key: "dedicated", Value: "ml"},
+	}, s.Services["app"].Tolerations)
+}
+
+func TestComposeExternalServices(t *testing.T) {
+	manifest := []byte(`x-okteto-external-service:
+  db: db.abc.us-east-1.rds.amazonaws.com
+  cache: 10.0.0.12:6379
+  queue:
+    host: 10.0.0.13
+    ports: [5672, 15672]
+services:
+  app:
+    image: okteto/app`)
+
+	s, err := ReadStack(manifest, true)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*ExternalService{
+		"db":    {Host: "db.abc.us-east-1.rds.amazonaws.com"},
+		"cache": {Host: "10.0.0.12", Ports: []int32{6379}},
+		"queue": {Host: "10.0.0.13", Ports: []int32{5672, 15672}},
+	}, s.ExternalServices)
+	assert.Empty(t, s.Warnings.NewerVersionFields)
+
+	tests := []struct {
+		name        string
+		external    string
+		errContains string
+	}{
+		{name: "ip without port", external: "db: 10.0.0.12", errContains: "'ports' is required when the host is an IP"},
+		{name: "invalid host", external: "db: db_1.example.com", errContains: "host 'db_1.example.com' is not a valid IP or DNS name"},
+		{name: "invalid port", external: "db: db.example.com:postgres", errContains: "'postgres' is not a valid port"},
+		{name: "out of range port", external: "db: {host: db.example.com, ports: [70000]}", errContains: "port 70000 must be between 1 and 65535"},
+		{name: "missing host", external: "db: {ports: [5432]}", errContains: "'host' is required"},
+		{name: "invalid name", external: "DB: db.example.com", errContains: "invalid external service 'DB'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadStack([]byte(fmt.Sprintf("x-okteto-external-service:\n  %s\nservices:\n  app:\n    image: okteto/app", tt.external)), true)
+			require.ErrorContains(t, err, tt.errContains)
+		})
+	}
+}
+
+func TestComposeExtraHosts(t *testing.T) {
+	tests := []struct {
+		name        string
+		extraHosts  string
+		errContains string
+		expected    []apiv1.HostAlias
+	}{
+		{
+			name:       "list",
+			extraHosts: `["internal.api:10.0.0.5", "internal.db=10.0.0.6", "api:10.0.0.5", "api:10.0.0.5", "ipv6=[::1]"]`,
+			expected: []apiv1.HostAlias{
+				{IP: "10.0.0.5", Hostnames: []string{"internal.api", "api"}},
+				{IP: "10.0.0.6", Hostnames: []string{"internal.db"}},
+				{IP: "::1", Hostnames: []string{"ipv6"}},
+			},
+		},
+		{
+			name:       "map",
+			extraHosts: `{internal.db: 10.0.0.6, internal.api: 10.0.0.5, api: 10.0.0.5}`,
+			expected: []apiv1.HostAlias{
+				{IP: "10.0.0.5", Hostnames: []string{"api", "internal.api"}},
+				{IP: "10.0.0.6", Hostnames: []string{"internal.db"}},
+			},
+		},
+		{
+			name:        "missing colon",
+			extraHosts:  `["internal.api 10.0.0.5"]`,
+			errContains: "invalid 'extra_hosts' for service 'app': 'internal.api 10.0.0.5' must have the format 'hostname:ip'",
+		},
+		{
+			name:        "bad ip",
+			extraHosts:  `["internal.api:10.0.0.500"]`,
+			errContains: "invalid 'extra_hosts' for service 'app': 'internal.api:10.0.0.500': '10.0.0.500' is not a valid IP address",
+		},
+		{
+			name:        "bad hostname",
+			extraHosts:  `["internal_api:10.0.0.5"]`,
+			errContains: "'internal_api' is not a valid hostname",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest := []byte(fmt.Sprintf("services:\n  app:\n    image: okteto/app\n    extra_hosts: %s", tt.extraHosts))
+			s, err := ReadStack(manifest, true)
+			if tt.errContains != "" {
+				require.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, s.Services["app"].ExtraHosts)
+			assert.Empty(t, s.Warnings.NotSupportedFields)
+		})
+	}
+}
+
+func TestComposeDNS(t *testing.T) {
+	manifest := []byte(`services:
+  app:
+    image: okteto/app
+    dns: 10.0.0.2
+    dns_search: [corp.example.com, example.com]
+  worker:
+    image: okteto/worker
+    dns: [10.0.0.2, 10.0.0.3]
+    dns_search: corp.example.com`)
+	s, err := ReadStack(manifest, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.2"}, s.Services["app"].DNS)
+	assert.Equal(t, []string{"corp.example.com", "example.com"}, s.Services["app"].DNSSearch)
+	assert.Equal(t, []string{"10.0.0.2", "10.0.0.3"}, s.Services["worker"].DNS)
+	assert.Equal(t, []string{"corp.example.com"}, s.Services["worker"].DNSSearch)
+	assert.Empty(t, s.Warnings.NotSupportedFields)
+}
+
+func TestComposeReadOnly(t *testing.T) {
+	manifest := []byte(`services:
+  app:
+    image: okteto/app
+    read_only: true
+  worker:
+    image: okteto/worker`)
+	s, err := ReadStack(manifest, true)
+	require.NoError(t, err)
+	assert.True(t, s.Services["app"].ReadOnly)
+	assert.False(t, s.Services["worker"].ReadOnly)
+	assert.Empty(t, s.Warnings.NotSupportedFields)
+}
+
+func TestComposeSecurityOpt(t *testing.T) {
+	manifest := []byte(`services:
+  app:
+    image: okteto/app
+    security_opt:
+      - no-new-privileges:true
+      - seccomp=unconfined
+      - apparmor:unconfined
+  worker:
+    image: okteto/worker
+    security_opt: ["no-new-privileges=false", "seccomp:/path/profile.json"]
+  api:
+    image: okteto/api
+    security_opt: [no-new-privileges]`)
+	s, err := ReadStack(manifest, true)
+	require.NoError(t, err)
+	assert.True(t, s.Services["app"].NoNewPrivileges)
+	assert.True(t, s.Services["app"].SeccompUnconfined)
+	assert.False(t, s.Services["worker"].NoNewPrivileges)
+	assert.False(t, s.Services["worker"].SeccompUnconfined)
+	assert.True(t, s.Services["api"].NoNewPrivileges)
+	assert.ElementsMatch(t, []string{"services[app].security_opt[2]", "services[worker].security_opt[1]"}, s.Warnings.NotSupportedFields)
+}
+
+func Test_translatePlacementConstraint(t *testing.T) {
+	tests := []struct {
+		constraint string
+		expected   PlacementConstraint
+		ok         bool
+	}{
+		{constraint: "node.labels.disktype == ssd", expected: PlacementConstraint{Key: "disktype", Operator: PlacementConstraintEqual, Value: "ssd"}, ok: true},
+		{constraint: "node.hostname != node-1", expected: PlacementConstraint{Key: "kubernetes.io/hostname", Operator: PlacementConstraintNotEqual, Value: "node-1"}, ok: true},
+		{constraint: " node.labels.gpu ", expected: PlacementConstraint{Key: "gpu", Operator: PlacementConstraintExists}, ok: true},
+		{constraint: "node.hostname"},
+		{constraint: "node.labels.disktype =="},
+		{constraint: "node.labels. == ssd"},
+		{constraint: "node.id == 2ivku8v2gvtg4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			result, ok := translatePlacementConstraint(tt.constraint)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/okteto/okteto/pkg/build"
 	"github.com/okteto/okteto/pkg/constants"
 	"github.com/okteto/okteto/pkg/env"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model/utils"
 	"github.com/spf13/afero"
@@ -32,6 +33,7 @@ import (
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
 )
 
 const (
@@ -1624,3 +1626,353 @@ func Test_warnAboutComposeFileName(t *testing.T) {
 	assert.Equal(t, writer.String(), " !  Okteto Stack syntax is deprecated.\n    Please consider migrating to Docker Compose syntax: https://community.okteto.com/t/important-update-migrating-from-okteto-stacks-to-docker-compose/1262\n")
 	writer.Reset()
 }
+
+func Test_ValidatePrivileged(t *testing.T) {
+	tests := []struct {
+		svc         *Service
+		name        string
+		errContains string
+		allowed     bool
+	}{
+		{
+			name: "not privileged",
+			svc:  &Service{Image: "okteto/vote:1"},
+		},
+		{
+			name:        "privileged not allowed",
+			svc:         &Service{Image: "docker:dind", Privileged: true},
+			errContains: "invalid service 'app': 'privileged' gives the container access to the node",
+		},
+		{
+			name:        "devices not allowed",
+			svc:         &Service{Image: "okteto/vote:1", Devices: []Device{{HostPath: "/dev/kvm", ContainerPath: "/dev/kvm"}}},
+			errContains: "invalid service 'app': 'devices' gives the container access to the node",
+		},
+		{
+			name:    "privileged allowed",
+			svc:     &Service{Image: "docker:dind", Privileged: true, Devices: []Device{{HostPath: "/dev/kvm", ContainerPath: "/dev/kvm"}}},
+			allowed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Stack{Name: "test", Services: ComposeServices{"app": tt.svc}}
+			require.NoError(t, s.Validate())
+			err := s.ValidatePrivileged([]string{"app"}, tt.allowed)
+			if tt.errContains == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.errContains)
+			var uErr oktetoErrors.UserError
+			require.ErrorAs(t, err, &uErr)
+			assert.Contains(t, uErr.Hint, "--allow-privileged")
+		})
+	}
+}
+
+func Test_ValidatePrivilegedOnlyChecksServicesToDeploy(t *testing.T) {
+	s := &Stack{Name: "test", Services: ComposeServices{
+		"api":  {Image: "okteto/vote:1"},
+		"dind": {Image: "docker:dind", Privileged: true},
+	}}
+	require.NoError(t, s.ValidatePrivileged([]string{"api"}, false))
+	require.ErrorContains(t, s.ValidatePrivileged([]string{"api", "dind"}, false), "invalid service 'dind'")
+}
+
+func Test_validatePrivilegedWithNoNewPrivileges(t *testing.T) {
+	s := &Stack{Name: "test", Services: ComposeServices{"app": {Image: "docker:dind", Privileged: true, NoNewPrivileges: true}}}
+	require.ErrorContains(t, s.Validate(), "invalid service 'app': 'security_opt' can't set 'no-new-privileges' on a 'privileged' service")
+}
+
+func Test_validateJobFieldsAndPriorityClass(t *testing.T) {
+	tests := []struct {
+		svc         *Service
+		name        string
+		errContains string
+	}{
+		{
+			name: "job with deadline and ttl",
+			svc:  &Service{Image: "okteto/vote:1", RestartPolicy: corev1.RestartPolicyNever, ActiveDeadlineSeconds: ptr.To(int64(60)), TTLSecondsAfterFinished: ptr.To(int32(60))},
+		},
+		{
+			name:        "deployment with deadline",
+			svc:         &Service{Image: "okteto/vote:1", RestartPolicy: corev1.RestartPolicyAlways, ActiveDeadlineSeconds: ptr.To(int64(60))},
+			errContains: "invalid service 'app': 'x-okteto-active-deadline-seconds' is only supported by jobs",
+		},
+		{
+			name:        "deployment with ttl",
+			svc:         &Service{Image: "okteto/vote:1", RestartPolicy: corev1.RestartPolicyAlways, TTLSecondsAfterFinished: ptr.To(int32(60))},
+			errContains: "invalid service 'app': 'x-okteto-ttl-seconds-after-finished' is only supported by jobs",
+		},
+		{
+			name: "valid priority class",
+			svc:  &Service{Image: "okteto/vote:1", PriorityClassName: "high-priority"},
+		},
+		{
+			name:        "invalid priority class",
+			svc:         &Service{Image: "okteto/vote:1", PriorityClassName: "High_Priority"},
+			errContains: "invalid 'x-okteto-priority-class' for service 'app'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Stack{Name: "test", Services: ComposeServices{"app": tt.svc}}
+			err := s.Validate()
+			if tt.errContains == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.errContains)
+		})
+	}
+}
+
+func Test_validateAntiAffinity(t *testing.T) {
+	volumes := []build.VolumeMounts{{LocalPath: "data", RemotePath: "/data"}}
+	tests := []struct {
+		svc         *Service
+		name        string
+		errContains string
+	}{
+		{
+			name: "hard anti-affinity without volumes",
+			svc:  &Service{Image: "okteto/vote:1", Replicas: 3, AntiAffinity: HardAntiAffinity},
+		},
+		{
+			name: "soft anti-affinity with volumes",
+			svc:  &Service{Image: "okteto/vote:1", Replicas: 3, AntiAffinity: SoftAntiAffinity, Volumes: volumes},
+		},
+		{
+			name: "hard anti-affinity with volumes and one replica",
+			svc:  &Service{Image: "okteto/vote:1", Replicas: 1, AntiAffinity: HardAntiAffinity, Volumes: volumes},
+		},
+		{
+			name:        "hard anti-affinity with volumes and replicas",
+			svc:         &Service{Image: "okteto/vote:1", Replicas: 3, AntiAffinity: HardAntiAffinity, Volumes: volumes},
+			errContains: "invalid service 'app': 'x-okteto-anti-affinity: hard' can't schedule 3 replicas sharing the volume 'data'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Stack{Name: "test", Services: ComposeServices{"app": tt.svc}}
+			err := s.Validate()
+			if tt.errContains == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.errContains)
+		})
+	}
+}
+
+func Test_validateTolerations(t *testing.T) {
+	seconds := int64(60)
+	tests := []struct {
+		name        string
+		errContains string
+		toleration  corev1.Toleration
+	}{
+		{
+			name:       "equal",
+			toleration: corev1.Toleration{Key: "gpu", Operator: corev1.TolerationOpEqual, Value: "true", Effect: corev1.TaintEffectNoSchedule},
+		},
+		{
+			name:       "exists without key",
+			toleration: corev1.Toleration{Operator: corev1.TolerationOpExists},
+		},
+		{
+			name:       "toleration seconds",
+			toleration: corev1.Toleration{Key: "gpu", Effect: corev1.TaintEffectNoExecute, TolerationSeconds: &seconds},
+		},
+		{
+			name:        "wrong effect",
+			toleration:  corev1.Toleration{Key: "gpu", Effect: "NoScheduled"},
+			errContains: "invalid 'tolerations[0]' for service 'app': effect must be 'NoSchedule', 'PreferNoSchedule' or 'NoExecute'",
+		},
+		{
+			name:        "wrong operator",
+			toleration:  corev1.Toleration{Key: "gpu", Operator: "In"},
+			errContains: "invalid 'tolerations[0]' for service 'app': operator must be 'Equal' or 'Exists'",
+		},
+		{
+			name:        "exists with value",
+			toleration:  corev1.Toleration{Key: "gpu", Operator: corev1.TolerationOpExists, Value: "true"},
+			errContains: "value must be empty when operator is 'Exists'",
+		},
+		{
+			name:        "equal without key",
+			toleration:  corev1.Toleration{Value: "true"},
+			errContains: "operator must be 'Exists' when key is empty",
+		},
+		{
+			name:        "toleration seconds without NoExecute",
+			toleration:  corev1.Toleration{Key: "gpu", Effect: corev1.TaintEffectNoSchedule, TolerationSeconds: &seconds},
+			errContains: "'tolerationSeconds' requires effect 'NoExecute'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Stack{Name: "test", Services: ComposeServices{"app": {Image: "okteto/vote:1", Tolerations: []corev1.Toleration{tt.toleration}}}}
+			err := s.Validate()
+			if tt.errContains == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.errContains)
+		})
+	}
+}
+
+func Test_validateDNS(t *testing.T) {
+	tests := []struct {
+		name        string
+		errContains string
+		dns         []string
+		dnsSearch   []string
+	}{
+		{
+			name:      "nameservers and search domains",
+			dns:       []string{"10.0.0.2", "10.0.0.3", "fd00::2"},
+			dnsSearch: []string{"corp.example.com", "example.com."},
+		},
+		{
+			name:        "too many nameservers",
+			dns:         []string{"10.0.0.2", "10.0.0.3", "10.0.0.4", "10.0.0.5"},
+			errContains: "invalid 'dns' for service 'app': kubernetes supports at most 3 nameservers, found 4",
+		},
+		{
+			name:        "nameserver is not an ip",
+			dns:         []string{"dns.example.com"},
+			errContains: "invalid 'dns' for service 'app': 'dns.example.com' is not a valid IP address",
+		},
+		{
+			name:        "wrong search domain",
+			dnsSearch:   []string{"corp_example.com"},
+			errContains: "invalid 'dns_search' for service 'app': 'corp_example.com' is not a valid domain",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Stack{Name: "test", Services: ComposeServices{"app": {Image: "okteto/vote:1", DNS: tt.dns, DNSSearch: tt.dnsSearch}}}
+			err := s.Validate()
+			if tt.errContains == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.errContains)
+		})
+	}
+}
+
+func Test_validateExternalServices(t *testing.T) {
+	s := &Stack{
+		Name:             "test",
+		Services:         ComposeServices{"db": {Image: "postgres"}},
+		ExternalServices: map[string]*ExternalService{"db": {Host: "db.example.com"}},
+	}
+	require.ErrorContains(t, s.Validate(), "invalid external service 'db': the name is already used by a service of the stack")
+
+	s.ExternalServices = map[string]*ExternalService{"rds": {Host: "db.example.com"}}
+	require.NoError(t, s.Validate())
+}
+
+func Test_validateServiceConfigs(t *testing.T) {
+	tests := []struct {
+		name        string
+		errContains string
+		configs     []ServiceConfig
+	}{
+		{
+			name:    "declared config",
+			configs: []ServiceConfig{{Source: "nginx", Target: "/etc/nginx/nginx.conf", Mode: ptr.To(int32(0440))}},
+		},
+		{
+			name:        "undeclared config",
+			configs:     []ServiceConfig{{Source: "missing", Target: "/missing"}},
+			errContains: "invalid service 'app': config 'missing' is not declared in the top-level 'configs'",
+		},
+		{
+			name:        "relative target",
+			configs:     []ServiceConfig{{Source: "nginx", Target: "etc/nginx.conf"}},
+			errContains: "invalid config 'nginx' in service 'app': target must be an absolute path",
+		},
+		{
+			name:        "invalid mode",
+			configs:     []ServiceConfig{{Source: "nginx", Target: "/nginx", Mode: ptr.To(int32(01777))}},
+			errContains: "invalid config 'nginx' in service 'app': mode must be between 0000 and 0777",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Stack{
+				Name:     "test",
+				Configs:  map[string]*ConfigSpec{"nginx": {File: "nginx.conf"}},
+				Services: ComposeServices{"app": {Image: "okteto/vote:1", Configs: tt.configs}},
+			}
+			err := s.Validate()
+			if tt.errContains == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.errContains)
+		})
+	}
+}
+
+func Test_validateServiceSecrets(t *testing.T) {
+	tests := []struct {
+		name        string
+		errContains string
+		secrets     []ServiceSecret
+	}{
+		{
+			name:    "secret from file",
+			secrets: []ServiceSecret{{Source: "db", Target: "/run/secrets/db", Mode: ptr.To(int32(0400))}},
+		},
+		{
+			name:    "secret from environment",
+			secrets: []ServiceSecret{{Source: "token", Target: "/run/secrets/token"}},
+		},
+		{
+			name:        "undeclared secret",
+			secrets:     []ServiceSecret{{Source: "missing", Target: "/run/secrets/missing"}},
+			errContains: "invalid service 'app': secret 'missing' is not declared in the top-level 'secrets'",
+		},
+		{
+			name:        "secret without source",
+			secrets:     []ServiceSecret{{Source: "empty", Target: "/run/secrets/empty"}},
+			errContains: "invalid secret 'empty' in service 'app': the secret has no 'file' or 'environment' defined",
+		},
+		{
+			name:        "secret with file and environment",
+			secrets:     []ServiceSecret{{Source: "both", Target: "/run/secrets/both"}},
+			errContains: "invalid secret 'both' in service 'app': the secret cannot define both 'file' and 'environment'",
+		},
+		{
+			name:        "invalid mode",
+			secrets:     []ServiceSecret{{Source: "db", Target: "/run/secrets/db", Mode: ptr.To(int32(01777))}},
+			errContains: "invalid secret 'db' in service 'app': mode must be between 0000 and 0777",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Stack{
+				Name: "test",
+				Secrets: map[string]*SecretSpec{
+					"db":    {File: "db.txt"},
+					"token": {Environment: "TOKEN"},
+					"empty": {},
+					"both":  {File: "both.txt", Environment: "BOTH"},
+				},
+				Services: ComposeServices{"app": {Image: "okteto/vote:1", Secrets: tt.secrets}},
+			}
+			err := s.Validate()
+			if tt.errContains == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.errContains)
+		})
+	}
+}
@@ -21,14 +21,15 @@ import (
 )
 
 type Test struct {
-	Image               string        `yaml:"image,omitempty"`
-	Context             string        `yaml:"context,omitempty"`
-	Commands            []TestCommand `yaml:"commands,omitempty"`
-	DependsOn           []string      `yaml:"depends_on,omitempty"`
-	Caches              []string      `yaml:"caches,omitempty"`
-	Artifacts           []Artifact    `yaml:"artifacts,omitempty"`
-	Hosts               []Host        `yaml:"hosts,omitempty"`
-	SkipIfNoFileChanges bool          `yaml:"skipIfNoFileChanges,omitempty"`
+	Image               string          `yaml:"image,omitempty"`
+	Context             string          `yaml:"context,omitempty"`
+	Commands            []TestCommand   `yaml:"commands,omitempty"`
+	DependsOn           []string        `yaml:"depends_on,omitempty"`
+	Caches              []string        `yaml:"caches,omitempty"`
+	Artifacts           []Artifact      `yaml:"artifacts,omitempty"`
+	Hosts               []Host          `yaml:"hosts,omitempty"`
+	Environment         env.Environment `yaml:"environment,omitempty"`
+	SkipIfNoFileChanges bool            `yaml:"skipIfNoFileChanges,omitempty"`
 }
 
 type Host struct {
@@ -57,6 +58,11 @@ func (test ManifestTests) Validate() error {
 		if t == nil || len(t.Commands) == 0 {
 			return fmt.Errorf("test '%s' is invalid: no commands defined", k)
 		}
+		for _, v := range t.Environment {
+			if v.FromService != nil {
+				return fmt.Errorf("test '%s' is invalid: 'fromService' is not supported in the environment of tests", k)
+			}
+		}
 	}
 	return nil
 }
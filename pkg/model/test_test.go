@@ -16,6 +16,7 @@ package model
 import (
 	"testing"
 
+	"github.com/okteto/okteto/pkg/env"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	yaml "gopkg.in/yaml.v3"
@@ -55,6 +56,20 @@ func TestValidate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "environment from service",
+			tests: ManifestTests{
+				"one": &Test{
+					Commands: []TestCommand{
+						{Command: "echo 'hello'"},
+					},
+					Environment: env.Environment{
+						{Name: "DB_HOST", FromService: &env.ServiceRef{Name: "db"}},
+					},
+				},
+			},
+			expectAnError: true,
+		},
 	}
 
 	for _, tt := range tests {
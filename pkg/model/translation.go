@@ -28,6 +28,7 @@ type TranslationRule struct {
 	Labels            Labels                           `json:"labels,omitempty"`
 	NodeSelector      map[string]string                `json:"nodeSelector" yaml:"nodeSelector"`
 	Affinity          *apiv1.Affinity                  `json:"affinity" yaml:"affinity"`
+	HostAliases       []apiv1.HostAlias                `json:"hostAliases,omitempty" yaml:"hostAliases,omitempty"`
 	ServiceAccount    string                           `json:"serviceAccount,omitempty" yaml:"serviceAccount,omitempty"`
 	PriorityClassName string                           `json:"priorityClassName,omitempty" yaml:"priorityClassName,omitempty"`
 	WorkDir           string                           `json:"workdir"`
@@ -45,6 +46,7 @@ type TranslationRule struct {
 	Healthchecks      bool                             `json:"healthchecks" yaml:"healthchecks"`
 	PersistentVolume  bool                             `json:"persistentVolume" yaml:"persistentVolume"`
 	MainVolumeName    string                           `json:"mainVolumeName,omitempty"`
+	VolumeSizeLimit   string                           `json:"volumeSizeLimit,omitempty"`
 	VolumeAccessMode  apiv1.PersistentVolumeAccessMode `json:"volumeAccessMode,omitempty"`
 	Namespace         string                           `json:"namespace,omitempty"`
 	ManifestName      string                           `json:"manifestName,omitempty"`
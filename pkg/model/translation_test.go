@@ -104,6 +104,7 @@ func TestDevToTranslationRule(t *testing.T) {
 			{Name: "PROMPT_COMMAND", Value: "history -a ; history -c ; history -r"},
 		},
 		PriorityClassName: "class",
+		WorkDir:           "/app",
 		SecurityContext: &SecurityContext{
 			RunAsUser:  ptr.To(int64(0)),
 			RunAsGroup: ptr.To(int64(0)),
@@ -204,6 +205,7 @@ func TestDevToTranslationRule(t *testing.T) {
 			FSGroup:    ptr.To(int64(0)),
 		},
 		PriorityClassName: "class",
+		WorkDir:           "/src",
 		Affinity: &apiv1.Affinity{
 			PodAffinity: &apiv1.PodAffinity{
 				RequiredDuringSchedulingIgnoredDuringExecution: []apiv1.PodAffinityTerm{},
@@ -321,6 +323,7 @@ func TestDevToTranslationRuleInitContainer(t *testing.T) {
 			FSGroup:    ptr.To(int64(0)),
 		},
 		Resources:        ResourceRequirements{},
+		WorkDir:          "/app",
 		PersistentVolume: true,
 		MainVolumeName:   dev.GetVolumeName(),
 		VolumeAccessMode: dev.PersistentVolumeAccessMode(),
@@ -423,6 +426,7 @@ func TestDevToTranslationDebugEnabled(t *testing.T) {
 			RunAsGroup: ptr.To(int64(0)),
 			FSGroup:    ptr.To(int64(0)),
 		},
+		WorkDir:          "/app",
 		PersistentVolume: true,
 		MainVolumeName:   dev.GetVolumeName(),
 		VolumeAccessMode: dev.PersistentVolumeAccessMode(),
@@ -638,3 +642,42 @@ func TestDevToTranslationRuleRunAsNonRoot(t *testing.T) {
 	}
 
 }
+
+func TestDevToTranslationRuleServiceSyncFolders(t *testing.T) {
+	main := &Dev{
+		Name:                 "api",
+		Image:                "okteto/api",
+		PersistentVolumeInfo: &PersistentVolumeInfo{Enabled: true},
+		Sync: Sync{
+			Folders: []SyncFolder{
+				{LocalPath: "/src/api", RemotePath: "/app"},
+			},
+		},
+	}
+	service := &Dev{
+		Name: "worker",
+		Sync: Sync{
+			Folders: []SyncFolder{
+				{LocalPath: "/src/protos", RemotePath: "/protos"},
+			},
+		},
+	}
+	main.Services = []*Dev{service}
+	main.computeParentSyncFolder()
+
+	subPath := "services-src/" + getServiceSyncFolderID("/src/protos")
+
+	mainRule := main.ToTranslationRule(main, "n", "manifest", "username", false)
+	assert.Contains(t, mainRule.Volumes, VolumeMount{
+		Name:      main.GetVolumeName(),
+		MountPath: "/var/okteto/services/" + getServiceSyncFolderID("/src/protos"),
+		SubPath:   subPath,
+	})
+
+	serviceRule := service.ToTranslationRule(main, "n", "manifest", "username", false)
+	assert.Contains(t, serviceRule.Volumes, VolumeMount{
+		Name:      main.GetVolumeName(),
+		MountPath: "/protos",
+		SubPath:   subPath,
+	})
+}
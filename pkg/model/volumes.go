@@ -14,14 +14,21 @@
 package model
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
 
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 )
 
 const (
@@ -29,6 +36,12 @@ const (
 
 	// devPersistentVolumeEnabledEnvVar is the name of the environment variable to change the defaultVolumeSize value
 	devPersistentVolumeSizeEnvVar = "OKTETO_DEV_PERSISTENT_VOLUME_SIZE"
+
+	// servicesSyncMountPath is the path where the sync folders only defined by services are mounted in the main development container
+	servicesSyncMountPath = "/var/okteto/services"
+
+	// servicesSyncSubPath is the subpath in the development container persistent volume for the sync folders only defined by services
+	servicesSyncSubPath = "services-src"
 )
 
 func (dev *Dev) translateDeprecatedVolumeFields() error {
@@ -73,7 +86,7 @@ func (dev *Dev) translateDeprecatedVolumes() {
 			volumes = append(volumes, v)
 			continue
 		}
-		dev.Sync.Folders = append(dev.Sync.Folders, SyncFolder(v))
+		dev.Sync.Folders = append(dev.Sync.Folders, SyncFolder{LocalPath: v.LocalPath, RemotePath: v.RemotePath})
 	}
 	dev.Volumes = volumes
 }
@@ -171,6 +184,32 @@ func (dev *Dev) PersistentVolumeEnabled() bool {
 	return dev.PersistentVolumeInfo.Enabled
 }
 
+// DisablePersistentVolume makes the development container use an ephemeral volume instead of a persistent volume.
+// It returns an error if the dev requires a persistent volume
+func (dev *Dev) DisablePersistentVolume() error {
+	prev := dev.PersistentVolumeInfo
+	info := PersistentVolumeInfo{}
+	if prev != nil {
+		info = *prev
+	}
+	info.Enabled = false
+	dev.PersistentVolumeInfo = &info
+	if err := dev.validatePersistentVolume(); err != nil {
+		dev.PersistentVolumeInfo = prev
+		return err
+	}
+	return nil
+}
+
+// EphemeralVolumeSizeLimit returns the size limit of the ephemeral volume used when persistent volumes are disabled.
+// It's only limited if the persistent volume size is explicitly set
+func (dev *Dev) EphemeralVolumeSizeLimit() string {
+	if dev.PersistentVolumeInfo != nil && dev.PersistentVolumeInfo.Size != "" {
+		return dev.PersistentVolumeInfo.Size
+	}
+	return os.Getenv(devPersistentVolumeSizeEnvVar)
+}
+
 // PersistentVolumeAccessMode returns the persistent volume accessMode
 func (dev *Dev) PersistentVolumeAccessMode() apiv1.PersistentVolumeAccessMode {
 	if dev.PersistentVolumeInfo == nil {
@@ -231,6 +270,14 @@ func (dev *Dev) PersistentVolumeSize() string {
 	return dev.PersistentVolumeInfo.Size
 }
 
+// PersistentVolumeAutoExpand returns if the persistent volume must be expanded when it runs out of space
+func (dev *Dev) PersistentVolumeAutoExpand() bool {
+	if dev.PersistentVolumeInfo == nil {
+		return false
+	}
+	return dev.PersistentVolumeInfo.AutoExpand
+}
+
 func (dev *Dev) HasDefaultPersistentVolumeSize() bool {
 	return dev.PersistentVolumeSize() == defaultVolumeSize
 }
@@ -312,7 +359,7 @@ func (dev *Dev) validateDuplicatedSyncFolders() error {
 	for _, sync := range dev.Sync.Folders {
 		key := sync.LocalPath + ":" + sync.RemotePath
 		if seen[key] {
-			return fmt.Errorf("duplicated sync '%s'", sync)
+			return fmt.Errorf("duplicated sync '%s'", key)
 		}
 		seen[key] = true
 		result, err := dev.IsSubPathFolder(sync.LocalPath)
@@ -333,16 +380,99 @@ func (dev *Dev) validateDuplicatedSyncFolders() error {
 func (dev *Dev) validateServiceSyncFolders(main *Dev) error {
 	for _, sync := range dev.Sync.Folders {
 		_, err := main.IsSubPathFolder(sync.LocalPath)
-		if err != nil {
-			if err == oktetoErrors.ErrNotFound {
-				return fmt.Errorf("LocalPath '%s' in 'services' not defined in the field 'sync' of the main development container", sync.LocalPath)
-			}
+		if err != nil && !errors.Is(err, oktetoErrors.ErrNotFound) {
 			return err
 		}
 	}
 	return nil
 }
 
+// GetServicesSyncFolders returns the sync folders of the services that are not included in the sync folders of the main development container.
+// They are synchronized by the main development container into its persistent volume, and shared with the services from there.
+// The remote path of each folder is its path in the main development container
+func (dev *Dev) GetServicesSyncFolders() []SyncFolder {
+	result := []SyncFolder{}
+	seen := map[string]bool{}
+	for _, s := range dev.Services {
+		for _, sync := range s.Sync.Folders {
+			if seen[sync.LocalPath] {
+				continue
+			}
+			if _, err := dev.IsSubPathFolder(sync.LocalPath); !errors.Is(err, oktetoErrors.ErrNotFound) {
+				continue
+			}
+			seen[sync.LocalPath] = true
+			// the options of the service apply to its folders, the ones of the main development container are used otherwise
+			ignorePerms := sync.IgnorePerms
+			if ignorePerms == nil && s.Sync.IgnorePerms {
+				ignorePerms = ptr.To(true)
+			}
+			modTimeWindow := sync.ModTimeWindow
+			if modTimeWindow == nil && s.Sync.ModTimeWindow != 0 {
+				modTimeWindow = ptr.To(s.Sync.ModTimeWindow)
+			}
+			result = append(result, SyncFolder{
+				LocalPath:     sync.LocalPath,
+				RemotePath:    path.Join(servicesSyncMountPath, getServiceSyncFolderID(sync.LocalPath)),
+				IgnorePerms:   ignorePerms,
+				ModTimeWindow: modTimeWindow,
+			})
+		}
+	}
+	return result
+}
+
+// IsIgnorePermsEnabled returns if the permissions of the files of a sync folder are not synchronized
+func (sync *Sync) IsIgnorePermsEnabled(folder SyncFolder) bool {
+	if folder.IgnorePerms != nil {
+		return *folder.IgnorePerms
+	}
+	if sync.IgnorePerms {
+		return true
+	}
+	if sync.PreservePermissions != nil {
+		return !*sync.PreservePermissions
+	}
+	// NTFS doesn't store the permissions of the files, synchronizing them from Windows breaks the executable bits
+	return runtime.GOOS == "windows"
+}
+
+// GetChownOwner returns the owner for chown of the files synchronized to the development container, or an empty string if they keep the owner set by syncthing
+func (sync *Sync) GetChownOwner() string {
+	if sync.UIDMap == nil || sync.UIDMap.UID == nil {
+		return ""
+	}
+	if sync.UIDMap.GID == nil {
+		return strconv.FormatInt(*sync.UIDMap.UID, 10)
+	}
+	return fmt.Sprintf("%d:%d", *sync.UIDMap.UID, *sync.UIDMap.GID)
+}
+
+// GetModTimeWindow returns the maximum difference in seconds between the modification times of a file of a sync folder to consider them equal
+func (sync *Sync) GetModTimeWindow(folder SyncFolder) int {
+	if folder.ModTimeWindow != nil {
+		return *folder.ModTimeWindow
+	}
+	return sync.ModTimeWindow
+}
+
+// isServiceSyncFolder returns if a sync folder of a service is not included in the sync folders of the main development container
+func (dev *Dev) isServiceSyncFolder(localPath string) bool {
+	_, err := dev.IsSubPathFolder(localPath)
+	return errors.Is(err, oktetoErrors.ErrNotFound)
+}
+
+// getServiceSyncFolderID returns a stable identifier for a sync folder only defined by services
+func getServiceSyncFolderID(localPath string) string {
+	hash := sha256.Sum256([]byte(filepath.ToSlash(localPath)))
+	return hex.EncodeToString(hash[:])[:8]
+}
+
+// getServiceSyncSubPath returns the subpath of a sync folder only defined by services in the persistent volume
+func getServiceSyncSubPath(localPath string) string {
+	return path.Join(servicesSyncSubPath, getServiceSyncFolderID(localPath))
+}
+
 func (dev *Dev) validateVolumes(main *Dev) error {
 	if len(dev.Sync.Folders) == 0 {
 		return fmt.Errorf("the 'sync' field is mandatory. More info at %s", syncFieldDocsURL)
@@ -20,7 +20,9 @@ import (
 
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
 	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 )
 
 func TestDev_translateDeprecatedVolumeFields(t *testing.T) {
@@ -772,7 +774,7 @@ func Test_validateVolumes(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "wrong-service-sync-folder",
+			name: "service-only-sync-folder",
 			dev: &Dev{
 				Sync: Sync{
 					Folders: []SyncFolder{
@@ -795,7 +797,7 @@ func Test_validateVolumes(t *testing.T) {
 					},
 				},
 			},
-			wantErr: true,
+			wantErr: false,
 		},
 	}
 
@@ -1028,3 +1030,130 @@ func Test_PersistentVolumeSize(t *testing.T) {
 		})
 	}
 }
+
+func Test_EphemeralVolumeSizeLimit(t *testing.T) {
+	var tests = []struct {
+		name                            string
+		dev                             *Dev
+		devPersistentVolumeSizeEnvValue string
+		want                            string
+	}{
+		{
+			name: "PersistentVolumeInfo nil - unlimited",
+			dev:  &Dev{},
+			want: "",
+		},
+		{
+			name: "PersistentVolumeInfo exists",
+			dev:  &Dev{PersistentVolumeInfo: &PersistentVolumeInfo{Size: "15Gi"}},
+			want: "15Gi",
+		},
+		{
+			name:                            "PersistentVolumeInfo empty - ENV",
+			devPersistentVolumeSizeEnvValue: "6Gi",
+			dev:                             &Dev{PersistentVolumeInfo: &PersistentVolumeInfo{}},
+			want:                            "6Gi",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(devPersistentVolumeSizeEnvVar, tt.devPersistentVolumeSizeEnvValue)
+			result := tt.dev.EphemeralVolumeSizeLimit()
+			if result != tt.want {
+				t.Errorf("'%s' did get an expected result '%s' vs '%s'", tt.name, tt.want, result)
+			}
+		})
+	}
+}
+
+func Test_DisablePersistentVolume(t *testing.T) {
+	dev := &Dev{PersistentVolumeInfo: &PersistentVolumeInfo{Enabled: true, Size: "2Gi"}}
+	if err := dev.DisablePersistentVolume(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if dev.PersistentVolumeEnabled() {
+		t.Errorf("persistent volume is still enabled")
+	}
+	if dev.PersistentVolumeInfo.Size != "2Gi" {
+		t.Errorf("persistent volume size was not kept: %s", dev.PersistentVolumeInfo.Size)
+	}
+
+	dev = &Dev{Volumes: []Volume{{RemotePath: "/cache"}}}
+	if err := dev.DisablePersistentVolume(); err == nil {
+		t.Errorf("expected error disabling the persistent volume of a dev with volumes")
+	}
+	if !dev.PersistentVolumeEnabled() {
+		t.Errorf("persistent volume was disabled after an error")
+	}
+}
+
+func Test_GetServicesSyncFolders(t *testing.T) {
+	dev := &Dev{
+		Sync: Sync{
+			Folders: []SyncFolder{
+				{LocalPath: "/src/api", RemotePath: "/app"},
+			},
+		},
+		Services: []*Dev{
+			{
+				Name: "worker",
+				Sync: Sync{
+					Folders: []SyncFolder{
+						{LocalPath: "/src/api/worker", RemotePath: "/worker"},
+						{LocalPath: "/src/protos", RemotePath: "/protos"},
+					},
+				},
+			},
+			{
+				Name: "grpc",
+				Sync: Sync{
+					Folders: []SyncFolder{
+						{LocalPath: "/src/protos", RemotePath: "/app/protos"},
+					},
+				},
+			},
+		},
+	}
+
+	expected := []SyncFolder{
+		{
+			LocalPath:  "/src/protos",
+			RemotePath: "/var/okteto/services/" + getServiceSyncFolderID("/src/protos"),
+		},
+	}
+	if !reflect.DeepEqual(expected, dev.GetServicesSyncFolders()) {
+		t.Fatalf("expected %v, got %v", expected, dev.GetServicesSyncFolders())
+	}
+	if dev.isServiceSyncFolder("/src/api/worker") {
+		t.Fatal("'/src/api/worker' is synchronized by the main development container")
+	}
+	if !dev.isServiceSyncFolder("/src/protos") {
+		t.Fatal("'/src/protos' is only synchronized by services")
+	}
+}
+
+func TestSyncFolderOptions(t *testing.T) {
+	sync := &Sync{IgnorePerms: true, ModTimeWindow: 1}
+
+	inherited := SyncFolder{LocalPath: "/src/api", RemotePath: "/app"}
+	assert.True(t, sync.IsIgnorePermsEnabled(inherited))
+	assert.Equal(t, 1, sync.GetModTimeWindow(inherited))
+
+	overridden := SyncFolder{LocalPath: "/src/scripts", RemotePath: "/scripts", IgnorePerms: ptr.To(false), ModTimeWindow: ptr.To(0)}
+	assert.False(t, sync.IsIgnorePermsEnabled(overridden))
+	assert.Equal(t, 0, sync.GetModTimeWindow(overridden))
+
+	assert.Equal(t, runtime.GOOS == "windows", (&Sync{}).IsIgnorePermsEnabled(inherited))
+	assert.Equal(t, 0, (&Sync{}).GetModTimeWindow(inherited))
+
+	assert.False(t, (&Sync{PreservePermissions: ptr.To(true)}).IsIgnorePermsEnabled(inherited))
+	assert.True(t, (&Sync{PreservePermissions: ptr.To(false)}).IsIgnorePermsEnabled(inherited))
+	assert.False(t, (&Sync{PreservePermissions: ptr.To(false)}).IsIgnorePermsEnabled(overridden))
+}
+
+func TestSyncGetChownOwner(t *testing.T) {
+	assert.Empty(t, (&Sync{}).GetChownOwner())
+	assert.Equal(t, "1001", (&Sync{UIDMap: &SyncUIDMap{UID: ptr.To[int64](1001)}}).GetChownOwner())
+	assert.Equal(t, "1001:2000", (&Sync{UIDMap: &SyncUIDMap{UID: ptr.To[int64](1001), GID: ptr.To[int64](2000)}}).GetChownOwner())
+}
@@ -0,0 +1,95 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"path"
+	"strings"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// workdirScriptName is the value of $0 in the script that runs the commands from the working directory
+const workdirScriptName = "okteto-workdir"
+
+// GetWorkdir returns the working directory of the development container: 'workdir' if defined,
+// or the remote path of the first synchronized folder
+func (dev *Dev) GetWorkdir() string {
+	if dev.Workdir != "" {
+		return dev.Workdir
+	}
+	if len(dev.Sync.Folders) > 0 {
+		return dev.Sync.Folders[0].RemotePath
+	}
+	return ""
+}
+
+// InWorkdirCommand wraps cmd to run it from the working directory of the development container,
+// so the shells opened by 'okteto up' and 'okteto exec' don't depend on the directory of the SSH server.
+// The command runs anyway if the working directory doesn't exist
+func (dev *Dev) InWorkdirCommand(cmd []string) []string {
+	workdir := dev.GetWorkdir()
+	if workdir == "" || dev.IsHybridModeEnabled() {
+		return cmd
+	}
+	result := []string{"sh", "-c", `cd "$1" 2>/dev/null; shift; exec "$@"`, workdirScriptName, workdir}
+	return append(result, cmd...)
+}
+
+// isWorkdirMounted returns if the working directory is under a synchronized folder or a volume of the development container
+func (dev *Dev) isWorkdirMounted() bool {
+	workdir := dev.GetWorkdir()
+	if workdir == "" {
+		return true
+	}
+	mounts := []string{}
+	for _, f := range dev.Sync.Folders {
+		mounts = append(mounts, f.RemotePath)
+	}
+	if dev.PersistentVolumeEnabled() {
+		for _, v := range dev.Volumes {
+			mounts = append(mounts, v.RemotePath)
+		}
+	}
+	for _, v := range dev.ExternalVolumes {
+		mounts = append(mounts, v.MountPath)
+	}
+	for _, m := range mounts {
+		if isSubPath(m, workdir) {
+			return true
+		}
+	}
+	return false
+}
+
+// warnWorkdirNotMounted warns when the files of the working directory aren't synchronized nor persisted
+func (dev *Dev) warnWorkdirNotMounted() {
+	if dev.IsHybridModeEnabled() || dev.isWorkdirMounted() {
+		return
+	}
+	oktetoLog.Warning("'workdir' %s is not under a synchronized folder or a volume of your development container: the files created there are lost when the development container restarts", dev.Workdir)
+}
+
+// isSubPath returns if child is parent or one of its subdirectories
+func isSubPath(parent, child string) bool {
+	if parent == "" {
+		return false
+	}
+	parent = path.Clean(parent)
+	child = path.Clean(child)
+	if parent == child || parent == "/" {
+		return true
+	}
+	return strings.HasPrefix(child, parent+"/")
+}
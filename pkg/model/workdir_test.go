@@ -0,0 +1,191 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetWorkdir(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		dev      Dev
+	}{
+		{
+			name:     "workdir",
+			dev:      Dev{Workdir: "/usr/src/app/api", Sync: Sync{Folders: []SyncFolder{{LocalPath: ".", RemotePath: "/usr/src/app"}}}},
+			expected: "/usr/src/app/api",
+		},
+		{
+			name:     "first sync folder",
+			dev:      Dev{Sync: Sync{Folders: []SyncFolder{{LocalPath: ".", RemotePath: "/usr/src/app"}, {LocalPath: "docs", RemotePath: "/docs"}}}},
+			expected: "/usr/src/app",
+		},
+		{
+			name: "no sync folders",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.dev.GetWorkdir())
+		})
+	}
+}
+
+func TestWorkdirFromManifest(t *testing.T) {
+	manifest, err := Read([]byte(`
+dev:
+  api:
+    image: okteto/golang:1
+    sync:
+      - .:/usr/src/app
+  web:
+    image: okteto/node:20
+    workdir: /usr/src/app/web
+    sync:
+      - .:/usr/src/app`))
+	require.NoError(t, err)
+
+	api := manifest.Dev["api"].ToTranslationRule(manifest.Dev["api"], "ns", "movies", "cindy", false)
+	assert.Equal(t, "/usr/src/app", api.WorkDir)
+	web := manifest.Dev["web"].ToTranslationRule(manifest.Dev["web"], "ns", "movies", "cindy", false)
+	assert.Equal(t, "/usr/src/app/web", web.WorkDir)
+}
+
+func TestInWorkdirCommand(t *testing.T) {
+	cmd := []string{"bash"}
+
+	dev := &Dev{}
+	assert.Equal(t, cmd, dev.InWorkdirCommand(cmd))
+
+	dev = &Dev{Sync: Sync{Folders: []SyncFolder{{LocalPath: ".", RemotePath: "/usr/src/app"}}}}
+	assert.Equal(t, []string{"sh", "-c", `cd "$1" 2>/dev/null; shift; exec "$@"`, "okteto-workdir", "/usr/src/app", "bash"}, dev.InWorkdirCommand(cmd))
+
+	dev.Mode = constants.OktetoHybridModeFieldValue
+	assert.Equal(t, cmd, dev.InWorkdirCommand(cmd))
+}
+
+func TestInWorkdirCommandRunsFromWorkdir(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the command runs in the linux development container")
+	}
+	dir := t.TempDir()
+	workdir := filepath.Join(dir, "my app")
+	require.NoError(t, os.Mkdir(workdir, 0755))
+
+	tests := []struct {
+		name     string
+		workdir  string
+		expected string
+	}{
+		{
+			name:     "existing workdir",
+			workdir:  workdir,
+			expected: workdir,
+		},
+		{
+			name:     "missing workdir",
+			workdir:  filepath.Join(dir, "missing"),
+			expected: dir,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dev := &Dev{Workdir: tt.workdir}
+			args := dev.InWorkdirCommand([]string{"sh", "-c", `pwd; echo "$@"`, "sh", "first arg", "second"})
+			c := exec.Command(args[0], args[1:]...)
+			c.Dir = dir
+			out, err := c.Output()
+			require.NoError(t, err)
+			resolved, err := filepath.EvalSymlinks(tt.expected)
+			require.NoError(t, err)
+			lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+			require.Len(t, lines, 2)
+			actual, err := filepath.EvalSymlinks(lines[0])
+			require.NoError(t, err)
+			assert.Equal(t, resolved, actual)
+			assert.Equal(t, "first arg second", lines[1])
+		})
+	}
+}
+
+func TestIsWorkdirMounted(t *testing.T) {
+	tests := []struct {
+		name     string
+		dev      Dev
+		expected bool
+	}{
+		{
+			name:     "default workdir",
+			dev:      Dev{Sync: Sync{Folders: []SyncFolder{{RemotePath: "/usr/src/app"}}}},
+			expected: true,
+		},
+		{
+			name:     "under a sync folder",
+			dev:      Dev{Workdir: "/usr/src/app/api", Sync: Sync{Folders: []SyncFolder{{RemotePath: "/usr/src/app/"}}}},
+			expected: true,
+		},
+		{
+			name: "sibling of a sync folder",
+			dev:  Dev{Workdir: "/usr/src/application", Sync: Sync{Folders: []SyncFolder{{RemotePath: "/usr/src/app"}}}},
+		},
+		{
+			name: "under a volume",
+			dev: Dev{
+				Workdir:              "/data/work",
+				Sync:                 Sync{Folders: []SyncFolder{{RemotePath: "/usr/src/app"}}},
+				Volumes:              []Volume{{RemotePath: "/data"}},
+				PersistentVolumeInfo: &PersistentVolumeInfo{Enabled: true},
+			},
+			expected: true,
+		},
+		{
+			name: "under a volume without persistent volume",
+			dev: Dev{
+				Workdir:              "/data/work",
+				Sync:                 Sync{Folders: []SyncFolder{{RemotePath: "/usr/src/app"}}},
+				Volumes:              []Volume{{RemotePath: "/data"}},
+				PersistentVolumeInfo: &PersistentVolumeInfo{Enabled: false},
+			},
+		},
+		{
+			name: "under an external volume",
+			dev: Dev{
+				Workdir:         "/cache",
+				Sync:            Sync{Folders: []SyncFolder{{RemotePath: "/usr/src/app"}}},
+				ExternalVolumes: []ExternalVolume{{Name: "cache", MountPath: "/cache"}},
+			},
+			expected: true,
+		},
+		{
+			name: "not mounted",
+			dev:  Dev{Workdir: "/", Sync: Sync{Folders: []SyncFolder{{RemotePath: "/usr/src/app"}}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.dev.isWorkdirMounted())
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/okteto/okteto/pkg/constants"
+)
+
+const (
+	// PlatformOkteto is the platform of the clusters with Okteto installed
+	PlatformOkteto = "okteto"
+
+	// PlatformVanilla is the platform of the kubernetes clusters without Okteto installed
+	PlatformVanilla = "vanilla"
+)
+
+// Capabilities are the features of the platform of the current context. They are resolved from,
+// in order of precedence, the '--platform' flag, the platform of the context and the detected one
+type Capabilities struct {
+	// Platform is the resolved platform, 'okteto' or 'vanilla'
+	Platform string
+
+	// SupportsAutoIngress is true when the services annotated with 'dev.okteto.com/auto-ingress' get an endpoint
+	SupportsAutoIngress bool
+
+	// SupportsDivert is true when the traffic of a namespace can be diverted to another one
+	SupportsDivert bool
+
+	// SupportsPreviews is true when preview environments can be deployed
+	SupportsPreviews bool
+}
+
+// ValidatePlatform checks that platform is 'okteto' or 'vanilla'. Empty means the platform is detected
+func ValidatePlatform(platform string) error {
+	switch platform {
+	case "", PlatformOkteto, PlatformVanilla:
+		return nil
+	default:
+		return fmt.Errorf("invalid platform '%s': must be '%s' or '%s'", platform, PlatformOkteto, PlatformVanilla)
+	}
+}
+
+// ResolveCapabilities returns the capabilities of a platform. The platform forced by the flag takes precedence
+// over the platform of the context, and both over the detected one
+func ResolveCapabilities(detectedOkteto bool, contextPlatform, flagPlatform string) Capabilities {
+	platform := PlatformVanilla
+	if detectedOkteto {
+		platform = PlatformOkteto
+	}
+	if contextPlatform != "" {
+		platform = contextPlatform
+	}
+	if flagPlatform != "" {
+		platform = flagPlatform
+	}
+
+	isOkteto := platform == PlatformOkteto
+	return Capabilities{
+		Platform:            platform,
+		SupportsAutoIngress: isOkteto,
+		SupportsDivert:      isOkteto,
+		SupportsPreviews:    isOkteto,
+	}
+}
+
+// GetCapabilities returns the capabilities of the current context
+func GetCapabilities() Capabilities {
+	okCtx := GetContext()
+	return ResolveCapabilities(okCtx.IsOkteto, okCtx.Platform, os.Getenv(constants.OktetoPlatformEnvVar))
+}
@@ -0,0 +1,97 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"testing"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveCapabilities(t *testing.T) {
+	okteto := Capabilities{Platform: PlatformOkteto, SupportsAutoIngress: true, SupportsDivert: true, SupportsPreviews: true}
+	vanilla := Capabilities{Platform: PlatformVanilla}
+
+	tests := []struct {
+		name            string
+		contextPlatform string
+		flagPlatform    string
+		expected        Capabilities
+		detectedOkteto  bool
+	}{
+		{
+			name:           "detected okteto",
+			detectedOkteto: true,
+			expected:       okteto,
+		},
+		{
+			name:     "detected vanilla",
+			expected: vanilla,
+		},
+		{
+			name:            "context forces vanilla",
+			detectedOkteto:  true,
+			contextPlatform: PlatformVanilla,
+			expected:        vanilla,
+		},
+		{
+			name:            "context forces okteto",
+			contextPlatform: PlatformOkteto,
+			expected:        okteto,
+		},
+		{
+			name:           "flag forces vanilla",
+			detectedOkteto: true,
+			flagPlatform:   PlatformVanilla,
+			expected:       vanilla,
+		},
+		{
+			name:            "flag takes precedence over the context",
+			contextPlatform: PlatformVanilla,
+			flagPlatform:    PlatformOkteto,
+			expected:        okteto,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ResolveCapabilities(tt.detectedOkteto, tt.contextPlatform, tt.flagPlatform))
+		})
+	}
+}
+
+func TestGetCapabilities(t *testing.T) {
+	CurrentStore = &ContextStore{
+		CurrentContext: "test",
+		Contexts: map[string]*Context{
+			"test": {Name: "test", IsOkteto: true, Platform: PlatformVanilla},
+		},
+	}
+	defer func() { CurrentStore = nil }()
+
+	assert.False(t, GetCapabilities().SupportsPreviews)
+	assert.False(t, IsOkteto())
+
+	t.Setenv(constants.OktetoPlatformEnvVar, PlatformOkteto)
+	assert.True(t, GetCapabilities().SupportsPreviews)
+	assert.True(t, IsOkteto())
+}
+
+func TestValidatePlatform(t *testing.T) {
+	require.NoError(t, ValidatePlatform(""))
+	require.NoError(t, ValidatePlatform(PlatformOkteto))
+	require.NoError(t, ValidatePlatform(PlatformVanilla))
+	require.ErrorContains(t, ValidatePlatform("openshift"), "invalid platform 'openshift'")
+}
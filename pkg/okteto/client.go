@@ -178,6 +178,10 @@ func newOktetoHttpClient(contextName, token, oktetoUrlPath string) (*http.Client
 		ctxHttpClient = oktetoHttp.StrictSSLHTTPClient(sslTransportOption)
 	}
 
+	if isContextToken(contextName, token) {
+		ctxHttpClient.Transport = newTokenRefreshTransport(ctxHttpClient.Transport, contextName)
+	}
+
 	ctx := contextWithOauth2HttpClient(context.Background(), ctxHttpClient)
 
 	httpClient := oauth2.NewClient(ctx, src)
@@ -364,6 +368,12 @@ func parseOktetoURLWithPath(u, path string) (string, error) {
 func translateAPIErr(err error) error {
 	oktetoLog.Debugf("returnedAPI error: %s", err.Error())
 
+	// the expired token couldn't be refreshed, and the error already tells how to log in again
+	var uErr oktetoErrors.UserError
+	if errors.Is(err, oktetoErrors.ErrTokenExpired) && errors.As(err, &uErr) {
+		return uErr
+	}
+
 	e := strings.TrimPrefix(err.Error(), "graphql: ")
 	switch e {
 	case "not-authorized":
@@ -79,6 +79,7 @@ type Context struct {
 	ClusterVersion     string               `json:"-" yaml:"-"`
 	ClusterID          string               `json:"-" yaml:"-"`
 	CompanyName        string               `json:"-" yaml:"-"`
+	Platform           string               `json:"platform,omitempty" yaml:"platform,omitempty"`
 	IsOkteto           bool                 `json:"isOkteto,omitempty" yaml:"isOkteto,omitempty"`
 	IsStoredAsInsecure bool                 `json:"isInsecure,omitempty" yaml:"isInsecure,omitempty"`
 	IsInsecure         bool                 `json:"-" yaml:"-"`
@@ -235,8 +236,9 @@ func IsContextInitialized() bool {
 	return ctxStore.CurrentContext != ""
 }
 
+// IsOkteto returns if the platform of the current context is okteto, honoring the platform forced by the context or the '--platform' flag
 func IsOkteto() bool {
-	return GetContext().IsOkteto
+	return GetCapabilities().Platform == PlatformOkteto
 }
 
 func GetContextStore() *ContextStore {
@@ -322,10 +324,15 @@ func AddOktetoContext(name string, u *types.User, namespace, personalNamespace s
 
 func AddKubernetesContext(name, namespace string) {
 	CurrentStore = GetContextStore()
+	var platform string
+	if current, ok := CurrentStore.Contexts[name]; ok && current != nil {
+		platform = current.Platform
+	}
 	CurrentStore.Contexts[name] = &Context{
 		Name:      name,
 		Namespace: namespace,
 		Analytics: true,
+		Platform:  platform,
 	}
 	CurrentStore.CurrentContext = name
 }
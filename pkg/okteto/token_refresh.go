@@ -0,0 +1,211 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/okteto/okteto/pkg/constants"
+	"github.com/okteto/okteto/pkg/env"
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// expiredTokenResponse is the body of the responses of the okteto API to requests with an expired token
+const expiredTokenResponse = "token is expired"
+
+// TokenRefresher logs in again to an okteto context and returns the new token
+type TokenRefresher func(ctx context.Context, contextName string) (string, error)
+
+// tokenRefresher is used to log in again in interactive mode when the token of the current context expires
+var tokenRefresher TokenRefresher
+
+// SetTokenRefresher sets how to log in again when the token of an okteto context expires
+func SetTokenRefresher(r TokenRefresher) {
+	tokenRefresher = r
+}
+
+// tokenRefreshTransport refreshes the token of an okteto context when the okteto API rejects it
+// because it is expired, and retries the request once with the new token
+type tokenRefreshTransport struct {
+	rt          http.RoundTripper
+	refresh     func(ctx context.Context, contextName, expiredToken string) (string, error)
+	contextName string
+	token       string
+	mu          sync.Mutex
+	attempted   bool
+}
+
+func newTokenRefreshTransport(rt http.RoundTripper, contextName string) *tokenRefreshTransport {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &tokenRefreshTransport{
+		rt:          rt,
+		contextName: contextName,
+		refresh:     refreshContextToken,
+	}
+}
+
+// RoundTrip retries the requests rejected because of an expired token after refreshing the token
+func (t *tokenRefreshTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req, err := rewindableRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.rt.RoundTrip(t.withRefreshedToken(req, ""))
+	if err != nil || !isExpiredTokenResponse(resp) {
+		return resp, err
+	}
+
+	expiredToken := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	token, err := t.refreshToken(req.Context(), expiredToken)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	resp.Body.Close()
+
+	oktetoLog.Infof("retrying request to %s with the refreshed token", req.URL.Path)
+	return t.rt.RoundTrip(t.withRefreshedToken(req, token))
+}
+
+// refreshToken refreshes the token only once: concurrent requests with the expired token reuse the refreshed one
+func (t *tokenRefreshTransport) refreshToken(ctx context.Context, expiredToken string) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.token != "" && t.token != expiredToken {
+		return t.token, nil
+	}
+	if t.attempted {
+		return "", newTokenExpiredError(t.contextName)
+	}
+	t.attempted = true
+
+	token, err := t.refresh(ctx, t.contextName, expiredToken)
+	if err != nil {
+		return "", err
+	}
+	t.token = token
+	return token, nil
+}
+
+// withRefreshedToken returns a copy of the request authenticated with the refreshed token, if any
+func (t *tokenRefreshTransport) withRefreshedToken(req *http.Request, token string) *http.Request {
+	if token == "" {
+		t.mu.Lock()
+		token = t.token
+		t.mu.Unlock()
+	}
+	if token == "" && req.GetBody == nil {
+		return req
+	}
+	r := req.Clone(req.Context())
+	if req.GetBody != nil {
+		// the body of the request can't be read twice, so every attempt gets a new one
+		r.Body, _ = req.GetBody()
+	}
+	if token != "" {
+		r.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+	return r
+}
+
+// rewindableRequest buffers the body of the request so it can be sent again
+func rewindableRequest(req *http.Request) (*http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody != nil {
+		return req, nil
+	}
+	b, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	r := req.Clone(req.Context())
+	r.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(b)), nil
+	}
+	r.Body, _ = r.GetBody()
+	return r, nil
+}
+
+// isExpiredTokenResponse checks if the response is the rejection of an expired token, keeping its body readable
+func isExpiredTokenResponse(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusUnauthorized {
+		return false
+	}
+	b, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(b))
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(b), expiredTokenResponse)
+}
+
+// refreshContextToken returns the token of the context if it was updated by another command.
+// Otherwise, it logs in again in interactive mode, and fails fast in non-interactive mode
+func refreshContextToken(ctx context.Context, contextName, expiredToken string) (string, error) {
+	okCtx, ok := GetContextStore().Contexts[contextName]
+	if !ok {
+		return "", newTokenExpiredError(contextName)
+	}
+
+	if ContextExists() {
+		if stored, ok := GetContextStoreFromStorePath().Contexts[contextName]; ok && stored.Token != "" && stored.Token != expiredToken {
+			oktetoLog.Infof("using the token of '%s' updated by another command", contextName)
+			okCtx.Token = stored.Token
+			return stored.Token, nil
+		}
+	}
+
+	if tokenRefresher == nil || !oktetoLog.IsInteractive() || env.LoadBoolean(constants.OktetoNonInteractiveEnvVar) {
+		return "", newTokenExpiredError(contextName)
+	}
+
+	oktetoLog.Warning("Your token has expired. Logging in to '%s' again...", contextName)
+	token, err := tokenRefresher(ctx, contextName)
+	if err != nil {
+		return "", err
+	}
+	oktetoLog.AddMaskedWord(token)
+	okCtx.Token = token
+	if err := NewContextConfigWriter().Write(); err != nil {
+		oktetoLog.Infof("could not save the refreshed token of '%s': %s", contextName, err)
+	}
+	return token, nil
+}
+
+// isContextToken checks if the token is the one of the okteto context, the only one that can be refreshed
+func isContextToken(contextName, token string) bool {
+	if CurrentStore == nil && !ContextExists() {
+		return false
+	}
+	okCtx, ok := GetContextStore().Contexts[contextName]
+	return ok && okCtx.IsOkteto && okCtx.Token == token
+}
+
+func newTokenExpiredError(contextName string) error {
+	return oktetoErrors.UserError{
+		E:    oktetoErrors.ErrTokenExpired,
+		Hint: fmt.Sprintf("Run 'okteto context use %s' to log in again", contextName),
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package okteto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newExpiringTokenServer returns a server that rejects the expired token and records the bodies of the requests
+func newExpiringTokenServer(t *testing.T, validToken string, bodies *[]string) *httptest.Server {
+	t.Helper()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		*bodies = append(*bodies, string(b))
+		if r.Header.Get("Authorization") != fmt.Sprintf("Bearer %s", validToken) {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprintln(w, "not-authorized: token is expired")
+			return
+		}
+		fmt.Fprint(w, "ok")
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func newTestTokenRefreshTransport(refresh func(context.Context, string, string) (string, error)) *tokenRefreshTransport {
+	rt := newTokenRefreshTransport(http.DefaultTransport, "https://okteto.example.com")
+	rt.refresh = refresh
+	return rt
+}
+
+func doTokenRequest(t *testing.T, rt http.RoundTripper, url, token, body string) (*http.Response, error) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, url, io.NopCloser(strings.NewReader(body)))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	return rt.RoundTrip(req)
+}
+
+func TestTokenRefreshTransportRetriesWithRefreshedToken(t *testing.T) {
+	var bodies []string
+	s := newExpiringTokenServer(t, "new-token", &bodies)
+
+	refreshes := 0
+	rt := newTestTokenRefreshTransport(func(_ context.Context, contextName, expiredToken string) (string, error) {
+		refreshes++
+		assert.Equal(t, "https://okteto.example.com", contextName)
+		assert.Equal(t, "old-token", expiredToken)
+		return "new-token", nil
+	})
+
+	resp, err := doTokenRequest(t, rt, s.URL, "old-token", "query")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"query", "query"}, bodies)
+
+	// the next requests use the refreshed token without refreshing it again
+	resp, err = doTokenRequest(t, rt, s.URL, "old-token", "mutation")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 1, refreshes)
+	assert.Equal(t, []string{"query", "query", "mutation"}, bodies)
+}
+
+func TestTokenRefreshTransportFailsWhenRefreshFails(t *testing.T) {
+	var bodies []string
+	s := newExpiringTokenServer(t, "new-token", &bodies)
+
+	rt := newTestTokenRefreshTransport(func(_ context.Context, contextName, _ string) (string, error) {
+		return "", newTokenExpiredError(contextName)
+	})
+
+	resp, err := doTokenRequest(t, rt, s.URL, "old-token", "query")
+	require.Nil(t, resp)
+	require.True(t, errors.Is(err, oktetoErrors.ErrTokenExpired))
+
+	var uErr oktetoErrors.UserError
+	require.True(t, errors.As(err, &uErr))
+	assert.Equal(t, "Run 'okteto context use https://okteto.example.com' to log in again", uErr.Hint)
+	assert.Len(t, bodies, 1)
+}
+
+func TestTokenRefreshTransportRefreshesOnce(t *testing.T) {
+	var bodies []string
+	s := newExpiringTokenServer(t, "valid-token", &bodies)
+
+	refreshes := 0
+	rt := newTestTokenRefreshTransport(func(context.Context, string, string) (string, error) {
+		refreshes++
+		return "also-expired-token", nil
+	})
+
+	resp, err := doTokenRequest(t, rt, s.URL, "old-token", "query")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "not-authorized: token is expired\n", string(b))
+
+	_, err = doTokenRequest(t, rt, s.URL, "also-expired-token", "query")
+	require.True(t, errors.Is(err, oktetoErrors.ErrTokenExpired))
+	assert.Equal(t, 1, refreshes)
+}
+
+func TestTokenRefreshTransportIgnoresOtherErrors(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprintln(w, "not-authorized")
+	}))
+	defer s.Close()
+
+	rt := newTestTokenRefreshTransport(func(context.Context, string, string) (string, error) {
+		t.Fatal("the token should not be refreshed")
+		return "", nil
+	})
+
+	resp, err := doTokenRequest(t, rt, s.URL, "token", "query")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestTranslateAPIErrKeepsTokenExpiredHint(t *testing.T) {
+	err := fmt.Errorf("Post \"https://okteto.example.com/graphql\": %w", newTokenExpiredError("https://okteto.example.com"))
+
+	var uErr oktetoErrors.UserError
+	result := translateAPIErr(err)
+	require.True(t, errors.As(result, &uErr))
+	assert.Equal(t, oktetoErrors.ErrTokenExpired, uErr.E)
+}
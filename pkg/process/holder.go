@@ -0,0 +1,65 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// holderIdentitySeparator separates the hostname and the pid of a holder identity
+const holderIdentitySeparator = "/"
+
+// IsRunning returns if there is a running process with the given PID
+func IsRunning(pid int) bool {
+	p := New(pid)
+	if err := p.Find(); err != nil {
+		return false
+	}
+	return !errors.Is(p.Signal(syscall.Signal(0)), os.ErrProcessDone)
+}
+
+// Hostname returns the hostname of the machine, or 'unknown' if it can't be read
+func Hostname() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		oktetoLog.Infof("failed to get hostname: %s", err)
+		return "unknown"
+	}
+	return hostname
+}
+
+// HolderIdentity returns the identity of the current process, with the format <hostname>/<pid>
+func HolderIdentity() string {
+	return fmt.Sprintf("%s%s%d", Hostname(), holderIdentitySeparator, os.Getpid())
+}
+
+// ParseHolderIdentity returns the hostname and the pid of a holder identity
+func ParseHolderIdentity(holder string) (string, int, error) {
+	hostname, pidStr, found := strings.Cut(holder, holderIdentitySeparator)
+	if !found || hostname == "" {
+		return "", 0, fmt.Errorf("invalid holder '%s': must be <hostname>/<pid>", holder)
+	}
+	pid, err := strconv.Atoi(pidStr)
+	if err != nil || pid <= 0 {
+		return "", 0, fmt.Errorf("invalid holder '%s': the pid must be a positive number", holder)
+	}
+	return hostname, pid, nil
+}
@@ -0,0 +1,45 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package process
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHolderIdentity(t *testing.T) {
+	hostname, pid, err := ParseHolderIdentity(HolderIdentity())
+	require.NoError(t, err)
+	assert.Equal(t, Hostname(), hostname)
+	assert.Equal(t, os.Getpid(), pid)
+}
+
+func TestParseHolderIdentity(t *testing.T) {
+	hostname, pid, err := ParseHolderIdentity("laptop/100")
+	require.NoError(t, err)
+	assert.Equal(t, "laptop", hostname)
+	assert.Equal(t, 100, pid)
+
+	for _, holder := range []string{"", "laptop", "/100", "laptop/", "laptop/abc", "laptop/-1"} {
+		_, _, err := ParseHolderIdentity(holder)
+		assert.Error(t, err, holder)
+	}
+}
+
+func TestIsRunning(t *testing.T) {
+	assert.True(t, IsRunning(os.Getpid()))
+}
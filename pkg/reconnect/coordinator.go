@@ -0,0 +1,205 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconnect
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// defaultMaxConcurrent is the number of transports that can reconnect at the same time
+	defaultMaxConcurrent = 4
+)
+
+var defaultCoordinator = NewCoordinator(defaultMaxConcurrent, realClock{})
+
+// Default returns the coordinator shared by the port forwarders, the SSH forwards and the syncthing transports
+func Default() *Coordinator {
+	return defaultCoordinator
+}
+
+// Clock abstracts the time functions used by the coordinator
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// Backoff configures the delays between the reconnection attempts of a transport
+type Backoff struct {
+	// Initial is the delay before the first reconnection attempt
+	Initial time.Duration
+	// Max is the maximum delay between two reconnection attempts
+	Max time.Duration
+}
+
+// delay returns the delay before the given attempt, starting at 1.
+// The delay doubles on every attempt and is jittered between its half and its full value
+func (b Backoff) delay(attempt int, jitter float64) time.Duration {
+	d := b.Initial
+	for i := 1; i < attempt && d < b.Max; i++ {
+		d *= 2
+	}
+	if b.Max > 0 && d > b.Max {
+		d = b.Max
+	}
+	half := d / 2
+	return half + time.Duration(jitter*float64(d-half))
+}
+
+// Storm summarizes a period where one or more transports were reconnecting
+type Storm struct {
+	// Reestablished is the number of connections re-established during the storm
+	Reestablished int
+	// Duration is the time elapsed since the first transport was disconnected until all of them were connected again
+	Duration time.Duration
+}
+
+// Coordinator coalesces the reconnections of the transports of a development container.
+// It spreads them with a jittered backoff and caps how many of them reconnect at the same time,
+// so a network blip doesn't flood the cluster with port-forward requests
+type Coordinator struct {
+	clock  Clock
+	slots  chan struct{}
+	jitter func() float64
+
+	lock          sync.Mutex
+	attempts      map[string]int
+	stormStart    time.Time
+	reestablished int
+	flooding      bool
+	last          Storm
+}
+
+// NewCoordinator returns a coordinator that allows up to maxConcurrent reconnections at the same time
+func NewCoordinator(maxConcurrent int, clock Clock) *Coordinator {
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+	return &Coordinator{
+		clock:    clock,
+		slots:    make(chan struct{}, maxConcurrent),
+		jitter:   rand.Float64, //nolint:gosec // G404: the jitter doesn't need a secure random number generator
+		attempts: map[string]int{},
+	}
+}
+
+// Wait records that the transport identified by id is disconnected, and waits for the backoff of its next attempt and for a free reconnection slot.
+// The returned function releases the slot and must be called once the reconnection attempt finishes
+func (c *Coordinator) Wait(ctx context.Context, id string, b Backoff) (func(), error) {
+	attempt := c.disconnected(id)
+
+	select {
+	case <-c.clock.After(b.delay(attempt, c.jitter())):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	select {
+	case c.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			<-c.slots
+		})
+	}, nil
+}
+
+func (c *Coordinator) disconnected(id string) int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if len(c.attempts) == 0 {
+		c.stormStart = c.clock.Now()
+		c.reestablished = 0
+		c.flooding = false
+	}
+	c.attempts[id]++
+
+	if !c.flooding && len(c.attempts) > cap(c.slots) {
+		c.flooding = true
+		oktetoLog.Infof("reconnect storm detected: %d connections are reconnecting, limiting to %d concurrent reconnections", len(c.attempts), cap(c.slots))
+	}
+	return c.attempts[id]
+}
+
+// Reconnected records that the transport identified by id re-established its connection.
+// Once all the disconnected transports are connected again, it logs a summary of the storm
+func (c *Coordinator) Reconnected(id string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, ok := c.attempts[id]; !ok {
+		return
+	}
+	delete(c.attempts, id)
+	c.reestablished++
+	c.endStorm()
+}
+
+// Forget drops the transport identified by id, e.g. when it is stopped before reconnecting
+func (c *Coordinator) Forget(id string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, ok := c.attempts[id]; !ok {
+		return
+	}
+	delete(c.attempts, id)
+	c.endStorm()
+}
+
+func (c *Coordinator) endStorm() {
+	if len(c.attempts) > 0 || c.reestablished == 0 {
+		return
+	}
+	c.last = Storm{
+		Reestablished: c.reestablished,
+		Duration:      c.clock.Now().Sub(c.stormStart),
+	}
+	c.reestablished = 0
+	oktetoLog.Infof("%d connections re-established in %s", c.last.Reestablished, c.last.Duration.Round(time.Millisecond))
+}
+
+// LastStorm returns the summary of the last storm where all the transports were connected again
+func (c *Coordinator) LastStorm() Storm {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.last
+}
+
+// Reconnecting returns the number of transports currently reconnecting
+func (c *Coordinator) Reconnecting() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.attempts)
+}
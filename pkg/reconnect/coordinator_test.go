@@ -0,0 +1,256 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconnect
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	now     time.Time
+	waiters []fakeWaiter
+	lock    sync.Mutex
+}
+
+type fakeWaiter struct {
+	at time.Time
+	c  chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) After(d time.Duration) <-chan time.Time {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	c := make(chan time.Time, 1)
+	if d <= 0 {
+		c <- f.now
+		return c
+	}
+	f.waiters = append(f.waiters, fakeWaiter{at: f.now.Add(d), c: c})
+	return c
+}
+
+// Advance moves the clock forward and fires the waiters whose deadline has passed
+func (f *fakeClock) Advance(d time.Duration) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.now = f.now.Add(d)
+	pending := []fakeWaiter{}
+	for _, w := range f.waiters {
+		if w.at.After(f.now) {
+			pending = append(pending, w)
+			continue
+		}
+		w.c <- f.now
+	}
+	f.waiters = pending
+}
+
+func (f *fakeClock) pendingWaiters() int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return len(f.waiters)
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	require.Eventually(t, condition, time.Second, time.Millisecond)
+}
+
+func TestBackoffDelay(t *testing.T) {
+	b := Backoff{Initial: time.Second, Max: 10 * time.Second}
+	tests := []struct {
+		name     string
+		attempt  int
+		jitter   float64
+		expected time.Duration
+	}{
+		{name: "first attempt without jitter", attempt: 1, jitter: 0, expected: 500 * time.Millisecond},
+		{name: "first attempt with full jitter", attempt: 1, jitter: 1, expected: time.Second},
+		{name: "third attempt", attempt: 3, jitter: 1, expected: 4 * time.Second},
+		{name: "capped", attempt: 10, jitter: 1, expected: 10 * time.Second},
+		{name: "capped without jitter", attempt: 10, jitter: 0, expected: 5 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, b.delay(tt.attempt, tt.jitter))
+		})
+	}
+}
+
+func TestWaitAppliesBackoff(t *testing.T) {
+	clock := newFakeClock()
+	c := NewCoordinator(1, clock)
+	c.jitter = func() float64 { return 1 }
+	b := Backoff{Initial: time.Second, Max: time.Minute}
+
+	for attempt, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second} {
+		done := make(chan struct{})
+		go func() {
+			release, err := c.Wait(context.Background(), "svc", b)
+			assert.NoError(t, err)
+			release()
+			close(done)
+		}()
+		waitFor(t, func() bool { return clock.pendingWaiters() == 1 })
+
+		clock.Advance(expected - time.Millisecond)
+		select {
+		case <-done:
+			t.Fatalf("attempt %d didn't wait for its backoff", attempt+1)
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		clock.Advance(time.Millisecond)
+		<-done
+	}
+}
+
+func TestWaitCapsConcurrentReconnections(t *testing.T) {
+	clock := newFakeClock()
+	c := NewCoordinator(2, clock)
+	b := Backoff{}
+
+	release1, err := c.Wait(context.Background(), "a", b)
+	require.NoError(t, err)
+	release2, err := c.Wait(context.Background(), "b", b)
+	require.NoError(t, err)
+
+	acquired := make(chan func())
+	go func() {
+		release, err := c.Wait(context.Background(), "c", b)
+		assert.NoError(t, err)
+		acquired <- release
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("third reconnection didn't wait for a free slot")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	release1()
+	// releasing twice doesn't free an extra slot
+	release1()
+	release3 := <-acquired
+
+	ctx, cancel := context.WithCancel(context.Background())
+	failed := make(chan error)
+	go func() {
+		_, err := c.Wait(ctx, "d", b)
+		failed <- err
+	}()
+	cancel()
+	assert.ErrorIs(t, <-failed, context.Canceled)
+
+	release2()
+	release3()
+}
+
+func TestWaitCanceled(t *testing.T) {
+	clock := newFakeClock()
+	c := NewCoordinator(1, clock)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	failed := make(chan error)
+	go func() {
+		_, err := c.Wait(ctx, "a", Backoff{Initial: time.Minute})
+		failed <- err
+	}()
+	waitFor(t, func() bool { return clock.pendingWaiters() == 1 })
+	cancel()
+	assert.ErrorIs(t, <-failed, context.Canceled)
+}
+
+func TestStormSummary(t *testing.T) {
+	clock := newFakeClock()
+	c := NewCoordinator(4, clock)
+	b := Backoff{}
+
+	for _, id := range []string{"a", "b", "c"} {
+		release, err := c.Wait(context.Background(), id, b)
+		require.NoError(t, err)
+		release()
+	}
+	assert.Equal(t, 3, c.Reconnecting())
+
+	clock.Advance(2 * time.Second)
+	c.Reconnected("a")
+	c.Forget("b")
+	assert.Equal(t, Storm{}, c.LastStorm())
+
+	clock.Advance(time.Second)
+	c.Reconnected("c")
+	assert.Equal(t, Storm{Reestablished: 2, Duration: 3 * time.Second}, c.LastStorm())
+	assert.Equal(t, 0, c.Reconnecting())
+
+	// transports that were not reconnecting don't start a new storm
+	c.Reconnected("a")
+	assert.Equal(t, Storm{Reestablished: 2, Duration: 3 * time.Second}, c.LastStorm())
+
+	release, err := c.Wait(context.Background(), "a", b)
+	require.NoError(t, err)
+	release()
+	clock.Advance(time.Second)
+	c.Reconnected("a")
+	assert.Equal(t, Storm{Reestablished: 1, Duration: time.Second}, c.LastStorm())
+}
+
+func TestForgetWithoutReconnections(t *testing.T) {
+	clock := newFakeClock()
+	c := NewCoordinator(1, clock)
+
+	release, err := c.Wait(context.Background(), "a", Backoff{})
+	require.NoError(t, err)
+	release()
+	c.Forget("a")
+
+	assert.Equal(t, Storm{}, c.LastStorm())
+	assert.Equal(t, 0, c.Reconnecting())
+}
+
+func TestFloodingDetection(t *testing.T) {
+	clock := newFakeClock()
+	c := NewCoordinator(1, clock)
+
+	release, err := c.Wait(context.Background(), "a", Backoff{})
+	require.NoError(t, err)
+	assert.False(t, c.flooding)
+
+	go func() {
+		r, err := c.Wait(context.Background(), "b", Backoff{})
+		assert.NoError(t, err)
+		r()
+	}()
+	waitFor(t, func() bool { return c.Reconnecting() == 2 })
+	c.lock.Lock()
+	assert.True(t, c.flooding)
+	c.lock.Unlock()
+	release()
+}
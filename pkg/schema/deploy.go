@@ -111,6 +111,65 @@ func (deploy) JSONSchema() *jsonschema.Schema {
 		},
 	})
 
+	httpHealthCheckProps := jsonschema.NewProperties()
+	httpHealthCheckProps.Set("url", &jsonschema.Schema{
+		Type:        &jsonschema.Type{Types: []string{"string"}},
+		Description: "URL to request. Environment variables are expanded",
+	})
+	httpHealthCheckProps.Set("status", &jsonschema.Schema{
+		Type:        &jsonschema.Type{Types: []string{"integer"}},
+		Description: "Expected status code of the response. Defaults to 200",
+	})
+
+	execHealthCheckProps := jsonschema.NewProperties()
+	execHealthCheckProps.Set("service", &jsonschema.Schema{
+		Type:        &jsonschema.Type{Types: []string{"string"}},
+		Description: "Name of the deployment or statefulset where the command runs",
+	})
+	execHealthCheckProps.Set("command", &jsonschema.Schema{
+		Description: "Command to execute. The check passes when it exits with code 0",
+		OneOf: []*jsonschema.Schema{
+			{
+				Type: &jsonschema.Type{Types: []string{"string"}},
+			},
+			{
+				Type: &jsonschema.Type{Types: []string{"array"}},
+				Items: &jsonschema.Schema{
+					Type: &jsonschema.Type{Types: []string{"string"}},
+				},
+			},
+		},
+	})
+
+	healthCheckProps := jsonschema.NewProperties()
+	healthCheckProps.Set("name", &jsonschema.Schema{
+		Type:        &jsonschema.Type{Types: []string{"string"}},
+		Description: "Name of the health check",
+	})
+	healthCheckProps.Set("http", &jsonschema.Schema{
+		Type:                 &jsonschema.Type{Types: []string{"object"}},
+		Properties:           httpHealthCheckProps,
+		Required:             []string{"url"},
+		AdditionalProperties: jsonschema.FalseSchema,
+		Description:          "Checks the status code of an HTTP request",
+	})
+	healthCheckProps.Set("exec", &jsonschema.Schema{
+		Type:                 &jsonschema.Type{Types: []string{"object"}},
+		Properties:           execHealthCheckProps,
+		Required:             []string{"service", "command"},
+		AdditionalProperties: jsonschema.FalseSchema,
+		Description:          "Runs a command in a pod of a service",
+	})
+	healthCheckProps.Set("timeout", &jsonschema.Schema{
+		Type:        &jsonschema.Type{Types: []string{"string"}},
+		Description: "Maximum time for each attempt of the health check. Defaults to 10s",
+		Pattern:     "^[0-9]+(h|m|s)$",
+	})
+	healthCheckProps.Set("retries", &jsonschema.Schema{
+		Type:        &jsonschema.Type{Types: []string{"integer"}},
+		Description: "Number of attempts after the first one fails",
+	})
+
 	deployProps := jsonschema.NewProperties()
 	deployProps.Set("image", &jsonschema.Schema{
 		Type:        &jsonschema.Type{Types: []string{"string"}},
@@ -182,6 +241,21 @@ func (deploy) JSONSchema() *jsonschema.Schema {
 		AdditionalProperties: jsonschema.FalseSchema,
 		Description:          "Configuration for diverting traffic between namespaces",
 	})
+	deployProps.Set("timeout", &jsonschema.Schema{
+		Type:        &jsonschema.Type{Types: []string{"string"}},
+		Description: "Maximum time to deploy the dependencies and run the deploy commands, like '10m'. The '--timeout' flag takes precedence",
+		Pattern:     "^([0-9]+(h|m|s))+$",
+	})
+	deployProps.Set("healthchecks", &jsonschema.Schema{
+		Type:        &jsonschema.Type{Types: []string{"array"}},
+		Description: "List of checks run by 'okteto deploy --health-checks' once the deployed resources are healthy",
+		Items: &jsonschema.Schema{
+			Type:                 &jsonschema.Type{Types: []string{"object"}},
+			Properties:           healthCheckProps,
+			OneOf:                []*jsonschema.Schema{{Required: []string{"http"}}, {Required: []string{"exec"}}},
+			AdditionalProperties: jsonschema.FalseSchema,
+		},
+	})
 
 	return &jsonschema.Schema{
 		OneOf: []*jsonschema.Schema{
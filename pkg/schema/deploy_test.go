@@ -133,6 +133,52 @@ deploy:
       service: frontend
       port: 80`,
 		},
+		{
+			name: "deploy with healthchecks",
+			manifest: `
+deploy:
+  commands:
+    - kubectl apply -f k8s
+  healthchecks:
+    - name: frontend
+      http:
+        url: https://frontend-${OKTETO_NAMESPACE}.okteto.example.com/healthz
+        status: 200
+      timeout: 5s
+      retries: 3
+    - exec:
+        service: api
+        command: ["curl", "-f", "localhost:8080"]`,
+		},
+		{
+			name: "deploy with timeout",
+			manifest: `
+deploy:
+  timeout: 1h30m
+  commands:
+    - kubectl apply -f k8s`,
+		},
+		{
+			name: "invalid deploy timeout",
+			manifest: `
+deploy:
+  timeout: 10
+  commands:
+    - kubectl apply -f k8s`,
+			expectErr: true,
+		},
+		{
+			name: "healthcheck with http and exec",
+			manifest: `
+deploy:
+  healthchecks:
+    - http:
+        url: https://example.com
+      exec:
+        service: api
+        command: ls`,
+			expectErr: true,
+		},
 		{
 			name: "invalid commands type",
 			manifest: `
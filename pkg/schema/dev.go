@@ -26,6 +26,41 @@ func (dev) JSONSchema() *jsonschema.Schema {
 		Description: withManifestRefDocLink("Affinity allows you to constrain which nodes your development container is eligible to be scheduled on, based on labels on the node.", "affinity-affinity-optional"),
 	})
 
+	devProps.Set("allowPrivilegedPorts", &jsonschema.Schema{
+		Type:        &jsonschema.Type{Types: []string{"boolean"}},
+		Title:       "allowPrivilegedPorts",
+		Description: withManifestRefDocLink("If set to true, the NET_BIND_SERVICE capability is added to your development container so reverse forwards can bind ports lower than 1024. Otherwise, privileged ports that can't be bound are relayed from an unprivileged port with socat.", "allowprivilegedports-bool-optional"),
+		Default:     false,
+	})
+
+	autoRestartProps := jsonschema.NewProperties()
+	autoRestartProps.Set("enabled", &jsonschema.Schema{
+		Type:    &jsonschema.Type{Types: []string{"boolean"}},
+		Title:   "enabled",
+		Default: false,
+	})
+	autoRestartProps.Set("paths", &jsonschema.Schema{
+		Type:  &jsonschema.Type{Types: []string{"array"}},
+		Title: "paths",
+		Items: &jsonschema.Schema{
+			Type: &jsonschema.Type{Types: []string{"string"}},
+		},
+	})
+	autoRestartProps.Set("debounce", &jsonschema.Schema{
+		Type:    &jsonschema.Type{Types: []string{"string"}},
+		Title:   "debounce",
+		Pattern: "^[0-9]+(ms|s|m|h)$",
+		Default: "500ms",
+	})
+
+	devProps.Set("autoRestart", &jsonschema.Schema{
+		Type:                 &jsonschema.Type{Types: []string{"object"}},
+		Title:                "autoRestart",
+		Description:          withManifestRefDocLink("Restarts the command of your development container when the synchronized files matching paths change. Changes are grouped until no file changes for the debounce duration.", "autorestart-object-optional"),
+		Properties:           autoRestartProps,
+		AdditionalProperties: jsonschema.FalseSchema,
+	})
+
 	devProps.Set("autocreate", &jsonschema.Schema{
 		Type:        &jsonschema.Type{Types: []string{"boolean"}},
 		Title:       "autocreate",
@@ -64,7 +99,13 @@ func (dev) JSONSchema() *jsonschema.Schema {
 				Type: &jsonschema.Type{Types: []string{"object"}},
 				PatternProperties: map[string]*jsonschema.Schema{
 					".*": {
-						Type: &jsonschema.Type{Types: []string{"string", "boolean", "number"}},
+						OneOf: []*jsonschema.Schema{
+							{
+								Type: &jsonschema.Type{Types: []string{"string", "boolean", "number"}},
+							},
+							secretValueSchema(),
+							fromServiceSchema(),
+						},
 					},
 				},
 			},
@@ -77,6 +118,15 @@ func (dev) JSONSchema() *jsonschema.Schema {
 		},
 	})
 
+	devProps.Set("environmentPassthrough", &jsonschema.Schema{
+		Type:        &jsonschema.Type{Types: []string{"array"}},
+		Title:       "environmentPassthrough",
+		Description: "Local environment variables set in the sessions of okteto up and okteto exec, like 'AWS_*' or 'GITHUB_TOKEN'. They are never stored in the development container spec. The variables of 'environment' take precedence",
+		Items: &jsonschema.Schema{
+			Type: &jsonschema.Type{Types: []string{"string"}},
+		},
+	})
+
 	devProps.Set("envFiles", &jsonschema.Schema{
 		Type:        &jsonschema.Type{Types: []string{"array"}},
 		Title:       "envFiles",
@@ -117,6 +167,11 @@ func (dev) JSONSchema() *jsonschema.Schema {
 			},
 		},
 	})
+	forwardItemProps.Set("interface", &jsonschema.Schema{
+		Type:        &jsonschema.Type{Types: []string{"string"}},
+		Title:       "interface",
+		Description: "The local address where the port forward is bound. Defaults to the 'interface' of the development container",
+	})
 
 	devProps.Set("forward", &jsonschema.Schema{
 		Type:        &jsonschema.Type{Types: []string{"array"}},
@@ -138,6 +193,38 @@ func (dev) JSONSchema() *jsonschema.Schema {
 		},
 	})
 
+	hostAliasProps := jsonschema.NewProperties()
+	hostAliasProps.Set("ip", &jsonschema.Schema{
+		Type:  &jsonschema.Type{Types: []string{"string"}},
+		Title: "ip",
+	})
+	hostAliasProps.Set("hostnames", &jsonschema.Schema{
+		Type:  &jsonschema.Type{Types: []string{"array"}},
+		Title: "hostnames",
+		Items: &jsonschema.Schema{
+			Type: &jsonschema.Type{Types: []string{"string"}},
+		},
+	})
+	hostAliasProps.Set("port", &jsonschema.Schema{
+		Type:        &jsonschema.Type{Types: []string{"integer"}},
+		Title:       "port",
+		Description: "The port of the hostnames. If set, the <HOSTNAME>_SERVICE_HOST and <HOSTNAME>_SERVICE_PORT env vars are added to your development container",
+		Minimum:     "1",
+		Maximum:     "65535",
+	})
+
+	devProps.Set("hostAliases", &jsonschema.Schema{
+		Type:        &jsonschema.Type{Types: []string{"array"}},
+		Title:       "hostAliases",
+		Description: withManifestRefDocLink("A list of hostnames resolved to an IP in your development container, like services forwarded to your machine. They are merged with the host aliases of your deployment.", "hostaliases-object-optional"),
+		Items: &jsonschema.Schema{
+			Type:                 &jsonschema.Type{Types: []string{"object"}},
+			Properties:           hostAliasProps,
+			Required:             []string{"ip", "hostnames"},
+			AdditionalProperties: jsonschema.FalseSchema,
+		},
+	})
+
 	initContainerProps := jsonschema.NewProperties()
 	initContainerProps.Set("image", &jsonschema.Schema{
 		Type:  &jsonschema.Type{Types: []string{"string"}},
@@ -217,33 +304,10 @@ func (dev) JSONSchema() *jsonschema.Schema {
 		},
 	})
 
-	metadataProps := jsonschema.NewProperties()
-	metadataProps.Set("annotations", &jsonschema.Schema{
-		Type:  &jsonschema.Type{Types: []string{"object"}},
-		Title: "annotations",
-		PatternProperties: map[string]*jsonschema.Schema{
-			".*": {
-				Type: &jsonschema.Type{Types: []string{"string"}},
-			},
-		},
-	})
-	metadataProps.Set("labels", &jsonschema.Schema{
-		Type:  &jsonschema.Type{Types: []string{"object"}},
-		Title: "labels",
-		PatternProperties: map[string]*jsonschema.Schema{
-			".*": {
-				Type: &jsonschema.Type{Types: []string{"string"}},
-			},
-		},
-	})
-
-	devProps.Set("metadata", &jsonschema.Schema{
-		Type:                 &jsonschema.Type{Types: []string{"object"}},
-		Title:                "metadata",
-		Description:          withManifestRefDocLink("The metadata field allows to inject labels and annotations into your development container.", "metadata-object-optional"),
-		Properties:           metadataProps,
-		AdditionalProperties: jsonschema.FalseSchema,
-	})
+	devMetadata := metadata{}.JSONSchema()
+	devMetadata.Title = "metadata"
+	devMetadata.Description = withManifestRefDocLink("The metadata field allows to inject labels and annotations into your development container.", "metadata-object-optional")
+	devProps.Set("metadata", devMetadata)
 
 	devProps.Set("mode", &jsonschema.Schema{
 		Type:        &jsonschema.Type{Types: []string{"string"}},
@@ -282,7 +346,7 @@ func (dev) JSONSchema() *jsonschema.Schema {
 		Type:        &jsonschema.Type{Types: []string{"string"}},
 		Title:       "size",
 		Default:     "5Gi",
-		Description: "The size of the Okteto persistent volume",
+		Description: "The size of the Okteto persistent volume. If persistent volumes are disabled, it limits the size of the ephemeral volume",
 	})
 	persistentVolumeProps.Set("storageClass", &jsonschema.Schema{
 		Type:        &jsonschema.Type{Types: []string{"string"}},
@@ -295,6 +359,12 @@ func (dev) JSONSchema() *jsonschema.Schema {
 		Default:     "Filesystem",
 		Description: "The Okteto persistent volume mode",
 	})
+	persistentVolumeProps.Set("autoExpand", &jsonschema.Schema{
+		Type:        &jsonschema.Type{Types: []string{"boolean"}},
+		Title:       "autoExpand",
+		Default:     false,
+		Description: "Expand the Okteto persistent volume when it runs out of space. Requires a storage class that allows volume expansion",
+	})
 	persistentVolumeProps.Set("annotations", &jsonschema.Schema{
 		Type:        &jsonschema.Type{Types: []string{"object"}},
 		Title:       "annotations",
@@ -429,6 +499,21 @@ func (dev) JSONSchema() *jsonschema.Schema {
 		AdditionalProperties: jsonschema.FalseSchema,
 	})
 
+	devProps.Set("runAs", &jsonschema.Schema{
+		Title:       "runAs",
+		Description: withManifestRefDocLink("The user that runs the command of your development container, with the format 'UID' or 'UID:GID'. The development container starts as root to prepare its volumes, changes the owner of the synchronized and persistent folders to this user, and runs the command as this user using su-exec, gosu or setpriv.", "runas-string-optional"),
+		OneOf: []*jsonschema.Schema{
+			{
+				Type:    &jsonschema.Type{Types: []string{"string"}},
+				Pattern: "^[0-9]+(:[0-9]+)?$",
+			},
+			{
+				Type:    &jsonschema.Type{Types: []string{"integer"}},
+				Minimum: "0",
+			},
+		},
+	})
+
 	devProps.Set("securityContext", &jsonschema.Schema{
 		Type:                 &jsonschema.Type{Types: []string{"object"}},
 		Title:                "securityContext",
@@ -521,13 +606,48 @@ func (dev) JSONSchema() *jsonschema.Schema {
 		},
 	})
 
+	ignorePermsDescription := "If set to true, the permissions of the synchronized files are ignored and only their content is synchronized. Syncthing always ignores permissions on Windows, because NTFS doesn't store them, so this is useful to avoid synchronizing executable bits set by Windows checkouts from other operating systems."
+	modTimeWindowDescription := "The maximum difference in seconds between the modification times of a file to consider them equal. Useful on filesystems with a low precision of modification times, like FAT."
+
+	syncFolderProps := jsonschema.NewProperties()
+	syncFolderProps.Set("localPath", &jsonschema.Schema{
+		Type:  &jsonschema.Type{Types: []string{"string"}},
+		Title: "localPath",
+	})
+	syncFolderProps.Set("remotePath", &jsonschema.Schema{
+		Type:  &jsonschema.Type{Types: []string{"string"}},
+		Title: "remotePath",
+	})
+	syncFolderProps.Set("ignorePerms", &jsonschema.Schema{
+		Type:        &jsonschema.Type{Types: []string{"boolean"}},
+		Title:       "ignorePerms",
+		Description: ignorePermsDescription,
+	})
+	syncFolderProps.Set("modTimeWindow", &jsonschema.Schema{
+		Type:        &jsonschema.Type{Types: []string{"integer"}},
+		Title:       "modTimeWindow",
+		Description: modTimeWindowDescription,
+	})
+	syncFolder := &jsonschema.Schema{
+		OneOf: []*jsonschema.Schema{
+			{
+				Type:    &jsonschema.Type{Types: []string{"string"}},
+				Pattern: "^.*:.*$",
+			},
+			{
+				Type:                 &jsonschema.Type{Types: []string{"object"}},
+				Properties:           syncFolderProps,
+				Required:             []string{"localPath", "remotePath"},
+				AdditionalProperties: jsonschema.FalseSchema,
+			},
+		},
+	}
+
 	syncProps := jsonschema.NewProperties()
 	syncProps.Set("folders", &jsonschema.Schema{
 		Type:  &jsonschema.Type{Types: []string{"array"}},
 		Title: "folders",
-		Items: &jsonschema.Schema{
-			Type: &jsonschema.Type{Types: []string{"string"}},
-		},
+		Items: syncFolder,
 	})
 	syncProps.Set("verbose", &jsonschema.Schema{
 		Type:    &jsonschema.Type{Types: []string{"boolean"}},
@@ -544,17 +664,58 @@ func (dev) JSONSchema() *jsonschema.Schema {
 		Title:   "rescanInterval",
 		Default: 300,
 	})
+	syncProps.Set("ignorePerms", &jsonschema.Schema{
+		Type:        &jsonschema.Type{Types: []string{"boolean"}},
+		Title:       "ignorePerms",
+		Description: ignorePermsDescription,
+		Default:     false,
+	})
+	syncProps.Set("modTimeWindow", &jsonschema.Schema{
+		Type:        &jsonschema.Type{Types: []string{"integer"}},
+		Title:       "modTimeWindow",
+		Description: modTimeWindowDescription,
+		Default:     0,
+	})
+	syncProps.Set("preservePermissions", &jsonschema.Schema{
+		Type:        &jsonschema.Type{Types: []string{"boolean"}},
+		Title:       "preservePermissions",
+		Description: "If set to false, the permissions of the synchronized files are ignored and only their content is synchronized. Defaults to false on Windows and to true on other operating systems.",
+	})
+	uidMapProps := jsonschema.NewProperties()
+	uidMapProps.Set("uid", &jsonschema.Schema{
+		Type:    &jsonschema.Type{Types: []string{"integer"}},
+		Title:   "uid",
+		Minimum: "0",
+	})
+	uidMapProps.Set("gid", &jsonschema.Schema{
+		Type:    &jsonschema.Type{Types: []string{"integer"}},
+		Title:   "gid",
+		Minimum: "0",
+	})
+	syncProps.Set("uidMap", &jsonschema.Schema{
+		Type:                 &jsonschema.Type{Types: []string{"object"}},
+		Title:                "uidMap",
+		Description:          "The user and group of the development container that own the files synchronized from your local folders. The development container must run as root to change the owner of the files.",
+		Properties:           uidMapProps,
+		Required:             []string{"uid"},
+		AdditionalProperties: jsonschema.FalseSchema,
+	})
+	syncProps.Set("remoteExcludes", &jsonschema.Schema{
+		Type:        &jsonschema.Type{Types: []string{"array"}},
+		Title:       "remoteExcludes",
+		Description: "Patterns ignored only by the development container, so files generated remotely, like build artifacts, are never synchronized back to your local folders",
+		Items: &jsonschema.Schema{
+			Type: &jsonschema.Type{Types: []string{"string"}},
+		},
+	})
 
 	devProps.Set("sync", &jsonschema.Schema{
 		Title:       "sync",
 		Description: withManifestRefDocLink("Specifies local folders that must be synchronized to the development container.", "sync-string-required"),
 		OneOf: []*jsonschema.Schema{
 			{
-				Type: &jsonschema.Type{Types: []string{"array"}},
-				Items: &jsonschema.Schema{
-					Type:    &jsonschema.Type{Types: []string{"string"}},
-					Pattern: "^.*:.*$",
-				},
+				Type:  &jsonschema.Type{Types: []string{"array"}},
+				Items: syncFolder,
 			},
 			{
 				Type:                 &jsonschema.Type{Types: []string{"object"}},
@@ -614,7 +775,7 @@ func (dev) JSONSchema() *jsonschema.Schema {
 	devProps.Set("workdir", &jsonschema.Schema{
 		Type:        &jsonschema.Type{Types: []string{"string"}},
 		Title:       "workdir",
-		Description: withManifestRefDocLink("Sets the working directory of your development container.", "workdir-string-optional"),
+		Description: withManifestRefDocLink("Sets the working directory of your development container and the initial directory of the shells opened by okteto up and okteto exec. Defaults to the remote path of the first sync folder.", "workdir-string-optional"),
 	})
 
 	resourcesProps := jsonschema.NewProperties()
@@ -645,14 +806,55 @@ func (dev) JSONSchema() *jsonschema.Schema {
 		Properties:           resourceValuesProps,
 		AdditionalProperties: jsonschema.FalseSchema,
 	})
-
-	devProps.Set("resources", &jsonschema.Schema{
+	resourcesProps.Set("max", &jsonschema.Schema{
 		Type:                 &jsonschema.Type{Types: []string{"object"}},
-		Title:                "resources",
-		Description:          withManifestRefDocLink("Resource requests and limits for the development container", "resources-object-optional"),
-		Properties:           resourcesProps,
+		Title:                "max",
+		Description:          "Maximum values of the resources inherited from the original container",
+		Properties:           resourceValuesProps,
 		AdditionalProperties: jsonschema.FalseSchema,
 	})
+	resourcesProps.Set("gpus", &jsonschema.Schema{
+		Title:       "gpus",
+		Description: "Number of nvidia.com/gpu or map of GPU resource names to numbers, added to the requests and limits",
+		OneOf: []*jsonschema.Schema{
+			{
+				Type:    &jsonschema.Type{Types: []string{"integer"}},
+				Minimum: "0",
+			},
+			{
+				Type: &jsonschema.Type{Types: []string{"object"}},
+				AdditionalProperties: &jsonschema.Schema{
+					Type: &jsonschema.Type{Types: []string{"integer", "string"}},
+				},
+			},
+		},
+	})
+	resourcesProps.Set("scale", &jsonschema.Schema{
+		Type:        &jsonschema.Type{Types: []string{"number"}},
+		Title:       "scale",
+		Description: "Multiplies the cpu and memory requests and limits of the original container",
+	})
+	resourcesProps.Set("unlimited", &jsonschema.Schema{
+		Type:        &jsonschema.Type{Types: []string{"boolean"}},
+		Title:       "unlimited",
+		Description: "Removes the limits inherited from the original container",
+	})
+
+	devProps.Set("resources", &jsonschema.Schema{
+		Title:       "resources",
+		Description: withManifestRefDocLink("Resource requests and limits for the development container", "resources-object-optional"),
+		OneOf: []*jsonschema.Schema{
+			{
+				Type: &jsonschema.Type{Types: []string{"string"}},
+				Enum: []any{"unlimited"},
+			},
+			{
+				Type:                 &jsonschema.Type{Types: []string{"object"}},
+				Properties:           resourcesProps,
+				AdditionalProperties: jsonschema.FalseSchema,
+			},
+		},
+	})
 
 	return &jsonschema.Schema{
 		Type:                 &jsonschema.Type{Types: []string{"object"}},
@@ -667,3 +869,90 @@ func (dev) JSONSchema() *jsonschema.Schema {
 		},
 	}
 }
+
+// fromServiceSchema returns the schema of an environment variable resolved from a service of the namespace
+func fromServiceSchema() *jsonschema.Schema {
+	secretRefProps := jsonschema.NewProperties()
+	secretRefProps.Set("name", &jsonschema.Schema{
+		Type:  &jsonschema.Type{Types: []string{"string"}},
+		Title: "name",
+	})
+	secretRefProps.Set("key", &jsonschema.Schema{
+		Type:  &jsonschema.Type{Types: []string{"string"}},
+		Title: "key",
+	})
+
+	fromServiceProps := jsonschema.NewProperties()
+	fromServiceProps.Set("name", &jsonschema.Schema{
+		Type:        &jsonschema.Type{Types: []string{"string"}},
+		Title:       "name",
+		Description: "The name of the service in the namespace",
+	})
+	fromServiceProps.Set("port", &jsonschema.Schema{
+		Type:        &jsonschema.Type{Types: []string{"integer"}},
+		Title:       "port",
+		Description: "The port of the service. Defaults to the first port of the service",
+	})
+	fromServiceProps.Set("scheme", &jsonschema.Schema{
+		Type:        &jsonschema.Type{Types: []string{"string"}},
+		Title:       "scheme",
+		Description: "The scheme of the URL. If empty, the value is 'host:port'",
+	})
+	fromServiceProps.Set("user", &jsonschema.Schema{
+		Type:  &jsonschema.Type{Types: []string{"string"}},
+		Title: "user",
+	})
+	fromServiceProps.Set("path", &jsonschema.Schema{
+		Type:  &jsonschema.Type{Types: []string{"string"}},
+		Title: "path",
+	})
+	fromServiceProps.Set("secretRef", &jsonschema.Schema{
+		Type:                 &jsonschema.Type{Types: []string{"object"}},
+		Title:                "secretRef",
+		Description:          "The key of a secret in the namespace with the password of the URL",
+		Properties:           secretRefProps,
+		Required:             []string{"name", "key"},
+		AdditionalProperties: jsonschema.FalseSchema,
+	})
+
+	props := jsonschema.NewProperties()
+	props.Set("fromService", &jsonschema.Schema{
+		Type:                 &jsonschema.Type{Types: []string{"object"}},
+		Title:                "fromService",
+		Description:          "Resolves the value from a service of the namespace when the development container is activated",
+		Properties:           fromServiceProps,
+		Required:             []string{"name"},
+		AdditionalProperties: jsonschema.FalseSchema,
+	})
+	props.Set("secret", secretSchema())
+	return &jsonschema.Schema{
+		Type:                 &jsonschema.Type{Types: []string{"object"}},
+		Properties:           props,
+		Required:             []string{"fromService"},
+		AdditionalProperties: jsonschema.FalseSchema,
+	}
+}
+
+// secretValueSchema returns the schema of an environment variable with a value that can be marked as secret
+func secretValueSchema() *jsonschema.Schema {
+	props := jsonschema.NewProperties()
+	props.Set("value", &jsonschema.Schema{
+		Type:  &jsonschema.Type{Types: []string{"string", "boolean", "number"}},
+		Title: "value",
+	})
+	props.Set("secret", secretSchema())
+	return &jsonschema.Schema{
+		Type:                 &jsonschema.Type{Types: []string{"object"}},
+		Properties:           props,
+		Required:             []string{"value"},
+		AdditionalProperties: jsonschema.FalseSchema,
+	}
+}
+
+func secretSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:        &jsonschema.Type{Types: []string{"boolean"}},
+		Title:       "secret",
+		Description: "Masks the value of the environment variable in the logs and the command output",
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"github.com/kubeark/jsonschema"
+)
+
+type devImages struct{}
+
+func (devImages) JSONSchema() *jsonschema.Schema {
+	props := jsonschema.NewProperties()
+	props.Set("bin", &jsonschema.Schema{
+		Type:        &jsonschema.Type{Types: []string{"string"}},
+		Title:       "bin",
+		Description: "The image of the init container that copies the okteto binaries to your development containers. It defaults to the okteto cli image or the OKTETO_BIN_IMAGE environment variable",
+	})
+	props.Set("sandbox", &jsonschema.Schema{
+		Type:        &jsonschema.Type{Types: []string{"string"}},
+		Title:       "sandbox",
+		Description: "The image of the development containers created by okteto up when they don't define an image",
+		Default:     "okteto/dev:latest",
+	})
+
+	return &jsonschema.Schema{
+		Type:                 &jsonschema.Type{Types: []string{"object"}},
+		Properties:           props,
+		AdditionalProperties: jsonschema.FalseSchema,
+	}
+}
@@ -63,6 +63,11 @@ dev:
                     - web-server
             topologyKey: kubernetes.io/hostname
     autocreate: true
+    autoRestart:
+      enabled: true
+      paths:
+        - "**/*.py"
+      debounce: 500ms
     command: ["python", "main.py"]
     container: api
     environment:
@@ -180,7 +185,10 @@ dev:
       - localPort: 5432
         remotePort: 5432
         labels:
-          app: db`,
+          app: db
+      - localPort: 3000
+        remotePort: 3000
+        interface: 0.0.0.0`,
 		},
 		{
 			name: "with lifecycle object",
@@ -207,6 +215,31 @@ dev:
       compression: true
       rescanInterval: 100`,
 		},
+		{
+			name: "with sync permissions and modification time options",
+			manifest: `
+dev:
+  api:
+    sync:
+      folders:
+        - .:/code
+        - localPath: ./scripts
+          remotePath: /scripts
+          ignorePerms: false
+          modTimeWindow: 2
+      ignorePerms: true
+      modTimeWindow: 1`,
+		},
+		{
+			name: "invalid sync folder object",
+			manifest: `
+dev:
+  api:
+    sync:
+      - localPath: .
+        ignorePerms: true`,
+			wantError: true,
+		},
 		{
 			name: "with timeout object",
 			manifest: `
@@ -216,6 +249,61 @@ dev:
       default: 3m
       resources: 5m`,
 		},
+		{
+			name: "with scaled resources",
+			manifest: `
+dev:
+  api:
+    resources:
+      scale: 2.0
+      max:
+        cpu: "4"
+        memory: 8Gi
+      limits:
+        memory: 4Gi`,
+		},
+		{
+			name: "with gpus count",
+			manifest: `
+dev:
+  api:
+    resources:
+      gpus: 1`,
+		},
+		{
+			name: "with gpus map",
+			manifest: `
+dev:
+  api:
+    resources:
+      gpus:
+        nvidia.com/gpu: 2
+        amd.com/gpu: "1"`,
+		},
+		{
+			name: "invalid gpus",
+			manifest: `
+dev:
+  api:
+    resources:
+      gpus: one`,
+			wantError: true,
+		},
+		{
+			name: "with unlimited resources",
+			manifest: `
+dev:
+  api:
+    resources: unlimited`,
+		},
+		{
+			name: "invalid resources string",
+			manifest: `
+dev:
+  api:
+    resources: unbounded`,
+			wantError: true,
+		},
 		{
 			name: "invalid command type",
 			manifest: `
@@ -317,6 +405,31 @@ dev:
         command: echo "Stopping"
 `,
 		},
+		{
+			name: "valid runAs user and group",
+			manifest: `
+dev:
+  api:
+    runAs: "1000:1000"
+`,
+		},
+		{
+			name: "valid runAs user",
+			manifest: `
+dev:
+  api:
+    runAs: 1000
+`,
+		},
+		{
+			name: "invalid runAs user name",
+			manifest: `
+dev:
+  api:
+    runAs: app
+`,
+			wantError: true,
+		},
 	}
 
 	for _, tt := range tests {
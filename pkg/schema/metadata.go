@@ -0,0 +1,48 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"github.com/kubeark/jsonschema"
+)
+
+type metadata struct{}
+
+func (metadata) JSONSchema() *jsonschema.Schema {
+	props := jsonschema.NewProperties()
+	props.Set("annotations", &jsonschema.Schema{
+		Type:  &jsonschema.Type{Types: []string{"object"}},
+		Title: "annotations",
+		PatternProperties: map[string]*jsonschema.Schema{
+			".*": {
+				Type: &jsonschema.Type{Types: []string{"string"}},
+			},
+		},
+	})
+	props.Set("labels", &jsonschema.Schema{
+		Type:  &jsonschema.Type{Types: []string{"object"}},
+		Title: "labels",
+		PatternProperties: map[string]*jsonschema.Schema{
+			".*": {
+				Type: &jsonschema.Type{Types: []string{"string"}},
+			},
+		},
+	})
+
+	return &jsonschema.Schema{
+		Type:                 &jsonschema.Type{Types: []string{"object"}},
+		Properties:           props,
+		AdditionalProperties: jsonschema.FalseSchema,
+	}
+}
@@ -20,16 +20,21 @@ import (
 )
 
 type manifest struct {
-	Deploy       deploy       `json:"deploy" jsonschema:"title=deploy,description=A list of commands to deploy your development environment. It's usually a combination of helm\\, kubectl\\, and okteto commands.\nDocumentation: https://www.okteto.com/docs/reference/okteto-manifest/#deploy-string-optional"`
-	Icon         icon         `json:"icon" jsonschema:"title=icon,description=The icon associated to your development environment in the Okteto UI.\nDocumentation: https://www.okteto.com/docs/reference/okteto-manifest/#icon-string-optional-1"`
-	Dependencies dependencies `json:"dependencies" jsonschema:"title=dependencies,description=A list of repositories you want to deploy as part of your development environment.\nDocumentation: https://www.okteto.com/docs/reference/okteto-manifest/#dependencies-string-optional"`
-	Dev          dev          `json:"dev" jsonschema:"title=dev,description=A list of development containers to define the behavior of okteto up and synchronize your code in your development environment.\nDocumentation: https://www.okteto.com/docs/reference/okteto-manifest/#dev-object-optional"`
-	Forward      forward      `json:"forward" jsonschema:"title=forward,description=Global port forwards to handle port collisions automatically between multiple okteto up sessions.\nDocumentation: https://www.okteto.com/docs/reference/okteto-manifest/#forward-string-optional-1"`
-	External     external     `json:"external" jsonschema:"title=external,description=A list of external resources that are part of your development environment. Use this section for resources that are deployed outside of the Okteto cluster, like Cloud resources or dashboards.\nDocumentation: https://www.okteto.com/docs/reference/okteto-manifest/#external-object-optional"`
-	Build        build        `json:"build" jsonschema:"title=build,description=A list of images to build as part of your development environment.\nDocumentation: https://www.okteto.com/docs/reference/okteto-manifest/#build-object-optional"`
-	Test         test         `json:"test" jsonschema:"title=test,description=A dictionary of Test Containers to run tests using Remote Execution.\nDocumentation: https://www.okteto.com/docs/reference/okteto-manifest/#test-object-optional"`
-	Destroy      destroy      `json:"destroy" jsonschema:"title=destroy,description=A list of commands to destroy external resources created by your development environment.\nDocumentation: https://www.okteto.com/docs/reference/okteto-manifest/#destroy-string-optional"`
-	Name         string       `json:"name" jsonschema:"title=name,description=The name of your development environment. It defaults to the name of your git repository.\nDocumentation: https://www.okteto.com/docs/reference/okteto-manifest/#name-string-optional"`
+	Deploy        deploy       `json:"deploy" jsonschema:"title=deploy,description=A list of commands to deploy your development environment. It's usually a combination of helm\\, kubectl\\, and okteto commands.\nDocumentation: https://www.okteto.com/docs/reference/okteto-manifest/#deploy-string-optional"`
+	Metadata      metadata     `json:"metadata" jsonschema:"title=metadata,description=Labels and annotations added to the resources created by okteto up for every development container. The values defined in the metadata of a development container take precedence.\nDocumentation: https://www.okteto.com/docs/reference/okteto-manifest/#metadata-object-optional-1"`
+	Icon          icon         `json:"icon" jsonschema:"title=icon,description=The icon associated to your development environment in the Okteto UI.\nDocumentation: https://www.okteto.com/docs/reference/okteto-manifest/#icon-string-optional-1"`
+	Dependencies  dependencies `json:"dependencies" jsonschema:"title=dependencies,description=A list of repositories you want to deploy as part of your development environment.\nDocumentation: https://www.okteto.com/docs/reference/okteto-manifest/#dependencies-string-optional"`
+	DevImages     devImages    `json:"devImages" jsonschema:"title=devImages,description=Overrides the helper images injected by okteto up in your development containers\\, e.g. to pull them from a registry mirror in air-gapped clusters."`
+	Dev           dev          `json:"dev" jsonschema:"title=dev,description=A list of development containers to define the behavior of okteto up and synchronize your code in your development environment.\nDocumentation: https://www.okteto.com/docs/reference/okteto-manifest/#dev-object-optional"`
+	Forward       forward      `json:"forward" jsonschema:"title=forward,description=Global port forwards to handle port collisions automatically between multiple okteto up sessions.\nDocumentation: https://www.okteto.com/docs/reference/okteto-manifest/#forward-string-optional-1"`
+	GlobalForward forward      `json:"global_forward" jsonschema:"title=global_forward,description=Port forwards shared by all the development containers of the manifest. They are established once by the first okteto up session and released when the last session using them ends. It can't be combined with 'forward'."`
+	External      external     `json:"external" jsonschema:"title=external,description=A list of external resources that are part of your development environment. Use this section for resources that are deployed outside of the Okteto cluster, like Cloud resources or dashboards.\nDocumentation: https://www.okteto.com/docs/reference/okteto-manifest/#external-object-optional"`
+	Build         build        `json:"build" jsonschema:"title=build,description=A list of images to build as part of your development environment.\nDocumentation: https://www.okteto.com/docs/reference/okteto-manifest/#build-object-optional"`
+	Test          test         `json:"test" jsonschema:"title=test,description=A dictionary of Test Containers to run tests using Remote Execution.\nDocumentation: https://www.okteto.com/docs/reference/okteto-manifest/#test-object-optional"`
+	Destroy       destroy      `json:"destroy" jsonschema:"title=destroy,description=A list of commands to destroy external resources created by your development environment.\nDocumentation: https://www.okteto.com/docs/reference/okteto-manifest/#destroy-string-optional"`
+	Name          string       `json:"name" jsonschema:"title=name,description=The name of your development environment. It defaults to the name of your git repository.\nDocumentation: https://www.okteto.com/docs/reference/okteto-manifest/#name-string-optional"`
+	Context       string       `json:"context" jsonschema:"title=context,description=The working directory of okteto up and okteto deploy\\, relative to the folder of the manifest. Builds\\, deploy commands and sync folders are resolved from it\\, e.g. '../..' for a manifest of a service of a monorepo. The --workdir flag takes precedence over this value."`
+	Namespace     string       `json:"namespace" jsonschema:"title=namespace,description=The namespace where okteto up activates your development containers. The --namespace flag takes precedence over this value\\, and this value takes precedence over the namespace of your Okteto Context."`
 }
 
 type OktetoJsonSchema struct {
@@ -83,6 +83,32 @@ func (test) JSONSchema() *jsonschema.Schema {
 		},
 	})
 
+	testProps.Set("environment", &jsonschema.Schema{
+		Title:       "environment",
+		Description: "Environment variables added to the Test Container when it runs as a Job with 'okteto test --job'. Environment variables with only a key, or with a value with a $ sign resolve to their values on the machine Okteto is running on",
+		OneOf: []*jsonschema.Schema{
+			{
+				Type: &jsonschema.Type{Types: []string{"object"}},
+				PatternProperties: map[string]*jsonschema.Schema{
+					".*": {
+						OneOf: []*jsonschema.Schema{
+							{
+								Type: &jsonschema.Type{Types: []string{"string", "boolean", "number"}},
+							},
+							secretValueSchema(),
+						},
+					},
+				},
+			},
+			{
+				Type: &jsonschema.Type{Types: []string{"array"}},
+				Items: &jsonschema.Schema{
+					Type: &jsonschema.Type{Types: []string{"string"}},
+				},
+			},
+		},
+	})
+
 	hostsItemProps := jsonschema.NewProperties()
 	hostsItemProps.Set("hostname", &jsonschema.Schema{
 		Type: &jsonschema.Type{Types: []string{"string"}},
@@ -0,0 +1,48 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package selfupdate
+
+import (
+	"fmt"
+	"os"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+// replaceBinary replaces the binary. Windows doesn't allow to overwrite or delete a running binary,
+// but it can be renamed: the previous binary is moved aside and deleted on the next update
+func replaceBinary(newPath, binaryPath string) error {
+	oldPath := binaryPath + ".old"
+	if err := os.Remove(oldPath); err != nil && !os.IsNotExist(err) {
+		oktetoLog.Infof("failed to delete '%s': %s", oldPath, err)
+	}
+
+	if err := os.Rename(binaryPath, oldPath); err != nil {
+		return err
+	}
+
+	if err := os.Rename(newPath, binaryPath); err != nil {
+		if rErr := os.Rename(oldPath, binaryPath); rErr != nil {
+			return fmt.Errorf("%w, and failed to restore the previous binary from '%s': %w", err, oldPath, rErr)
+		}
+		return err
+	}
+
+	if err := os.Remove(oldPath); err != nil {
+		oktetoLog.Infof("failed to delete '%s', it will be deleted in the next update: %s", oldPath, err)
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package selfupdate replaces the okteto binary with a release downloaded from the okteto downloads server
+package selfupdate
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+)
+
+const (
+	// DefaultDownloadsURL is the server where the okteto releases are published
+	DefaultDownloadsURL = "https://downloads.okteto.com/cli"
+
+	// StableChannel is the channel of the okteto releases
+	StableChannel = "stable"
+
+	// BetaChannel is the channel of the okteto pre-releases
+	BetaChannel = "beta"
+
+	requestTimeout = 5 * time.Minute
+)
+
+// ValidateChannel checks that the channel is one of the release channels
+func ValidateChannel(channel string) error {
+	switch channel {
+	case StableChannel, BetaChannel:
+		return nil
+	default:
+		return fmt.Errorf("channel '%s' is not valid. Must be one of: ['%s', '%s']", channel, StableChannel, BetaChannel)
+	}
+}
+
+// Updater downloads the okteto releases and replaces the current binary
+type Updater struct {
+	client  *http.Client
+	baseURL string
+	goos    string
+	goarch  string
+}
+
+// NewUpdater returns an updater for the releases of the given OS and architecture
+func NewUpdater(baseURL, goos, goarch string) *Updater {
+	return &Updater{
+		client:  &http.Client{Timeout: requestTimeout},
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		goos:    goos,
+		goarch:  goarch,
+	}
+}
+
+// GetAssetName returns the name of the release asset for the OS and architecture
+func GetAssetName(goos, goarch string) (string, error) {
+	switch goos {
+	case "darwin":
+		switch goarch {
+		case "amd64":
+			return "okteto-Darwin-x86_64", nil
+		case "arm64":
+			return "okteto-Darwin-arm64", nil
+		}
+	case "linux":
+		switch goarch {
+		case "amd64":
+			return "okteto-Linux-x86_64", nil
+		case "arm64":
+			return "okteto-Linux-arm64", nil
+		}
+	case "windows":
+		switch goarch {
+		case "amd64":
+			return "okteto.exe", nil
+		case "arm64":
+			return "okteto-arm64.exe", nil
+		}
+	}
+
+	return "", fmt.Errorf("%s-%s is not a supported platform", goos, goarch)
+}
+
+// GetLatestVersion returns the latest version published in the channel
+func (u *Updater) GetLatestVersion(ctx context.Context, channel string) (*semver.Version, error) {
+	if err := ValidateChannel(channel); err != nil {
+		return nil, err
+	}
+
+	body, err := u.get(ctx, fmt.Sprintf("%s/%s/versions", u.baseURL, channel))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the versions of the '%s' channel: %w", channel, err)
+	}
+	defer body.Close()
+
+	var latest *semver.Version
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		v, err := semver.NewVersion(line)
+		if err != nil {
+			oktetoLog.Infof("ignoring invalid version '%s': %s", line, err)
+			continue
+		}
+		if latest == nil || v.GreaterThan(latest) {
+			latest = v
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read the versions of the '%s' channel: %w", channel, err)
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("there are no versions in the '%s' channel", channel)
+	}
+
+	return latest, nil
+}
+
+// Update downloads the version from the channel, verifies its checksum and replaces the binary
+func (u *Updater) Update(ctx context.Context, channel string, version *semver.Version, binaryPath string) error {
+	if err := ValidateChannel(channel); err != nil {
+		return err
+	}
+
+	asset, err := GetAssetName(u.goos, u.goarch)
+	if err != nil {
+		return err
+	}
+	assetURL := fmt.Sprintf("%s/%s/%s/%s", u.baseURL, channel, version.Original(), asset)
+
+	checksum, err := u.getChecksum(ctx, assetURL)
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(binaryPath)
+	if err != nil {
+		return fmt.Errorf("failed to read the current binary: %w", err)
+	}
+
+	// the new binary is downloaded next to the current one, so it can be renamed atomically
+	tmp, err := os.CreateTemp(filepath.Dir(binaryPath), ".okteto-update-*")
+	if err != nil {
+		return fmt.Errorf("failed to create the new binary: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := u.download(ctx, assetURL, checksum, tmp); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write the new binary: %w", err)
+	}
+
+	// skipcq GSC-G302 okteto is a binary so it needs exec permissions
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()|0700); err != nil {
+		return fmt.Errorf("failed to set permissions to the new binary: %w", err)
+	}
+
+	if err := replaceBinary(tmp.Name(), binaryPath); err != nil {
+		return fmt.Errorf("failed to replace '%s': %w", binaryPath, err)
+	}
+
+	oktetoLog.Infof("updated '%s' to okteto %s", binaryPath, version)
+	return nil
+}
+
+// getChecksum returns the sha256 checksum published next to the asset
+func (u *Updater) getChecksum(ctx context.Context, assetURL string) (string, error) {
+	body, err := u.get(ctx, assetURL+".sha256")
+	if err != nil {
+		return "", fmt.Errorf("failed to get the checksum of the release: %w", err)
+	}
+	defer body.Close()
+
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the checksum of the release: %w", err)
+	}
+
+	// the checksum file follows the format of sha256sum: '<checksum>  <file>'
+	fields := strings.Fields(string(b))
+	if len(fields) == 0 || len(fields[0]) != sha256.Size*2 {
+		return "", fmt.Errorf("the checksum of the release is not valid")
+	}
+	return strings.ToLower(fields[0]), nil
+}
+
+func (u *Updater) download(ctx context.Context, assetURL, checksum string, w io.Writer) error {
+	body, err := u.get(ctx, assetURL)
+	if err != nil {
+		return fmt.Errorf("failed to download the release: %w", err)
+	}
+	defer body.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(w, h), body); err != nil {
+		return fmt.Errorf("failed to download the release: %w", err)
+	}
+
+	if got := hex.EncodeToString(h.Sum(nil)); got != checksum {
+		return fmt.Errorf("the checksum of the downloaded release doesn't match: expected '%s', got '%s'", checksum, got)
+	}
+	return nil
+}
+
+func (u *Updater) get(ctx context.Context, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("GET %s returned %s", url, resp.Status)
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,198 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selfupdate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fakeBinary = "new okteto binary"
+
+// newFakeReleaseServer serves the stable and beta channels of the okteto releases
+func newFakeReleaseServer(t *testing.T, checksum string) *httptest.Server {
+	t.Helper()
+	if checksum == "" {
+		sum := sha256.Sum256([]byte(fakeBinary))
+		checksum = hex.EncodeToString(sum[:])
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cli/stable/versions", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("2.25.0\n3.1.0\nnot-a-version\n3.0.0\n"))
+	})
+	mux.HandleFunc("/cli/beta/versions", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("3.1.0\n3.2.0-beta.1\n"))
+	})
+	mux.HandleFunc("/cli/stable/3.1.0/okteto-Linux-x86_64", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(fakeBinary))
+	})
+	mux.HandleFunc("/cli/stable/3.1.0/okteto-Linux-x86_64.sha256", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(checksum + "  okteto-Linux-x86_64\n"))
+	})
+	s := httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func newTestBinary(t *testing.T) string {
+	t.Helper()
+	binaryPath := filepath.Join(t.TempDir(), "okteto")
+	require.NoError(t, os.WriteFile(binaryPath, []byte("old okteto binary"), 0755))
+	return binaryPath
+}
+
+func TestGetLatestVersion(t *testing.T) {
+	s := newFakeReleaseServer(t, "")
+	u := NewUpdater(s.URL+"/cli", "linux", "amd64")
+
+	v, err := u.GetLatestVersion(context.Background(), StableChannel)
+	require.NoError(t, err)
+	assert.Equal(t, "3.1.0", v.String())
+
+	v, err = u.GetLatestVersion(context.Background(), BetaChannel)
+	require.NoError(t, err)
+	assert.Equal(t, "3.2.0-beta.1", v.String())
+
+	_, err = u.GetLatestVersion(context.Background(), "nightly")
+	assert.ErrorContains(t, err, "channel 'nightly' is not valid")
+}
+
+func TestGetLatestVersionServerError(t *testing.T) {
+	s := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(s.Close)
+	u := NewUpdater(s.URL, "linux", "amd64")
+
+	_, err := u.GetLatestVersion(context.Background(), StableChannel)
+	assert.ErrorContains(t, err, "404 Not Found")
+}
+
+func TestUpdate(t *testing.T) {
+	s := newFakeReleaseServer(t, "")
+	u := NewUpdater(s.URL+"/cli", "linux", "amd64")
+	binaryPath := newTestBinary(t)
+
+	require.NoError(t, u.Update(context.Background(), StableChannel, semver.MustParse("3.1.0"), binaryPath))
+
+	b, err := os.ReadFile(binaryPath)
+	require.NoError(t, err)
+	assert.Equal(t, fakeBinary, string(b))
+
+	info, err := os.Stat(binaryPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+
+	// the temporary files are cleaned
+	entries, err := os.ReadDir(filepath.Dir(binaryPath))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestUpdateChecksumMismatch(t *testing.T) {
+	sum := sha256.Sum256([]byte("another binary"))
+	s := newFakeReleaseServer(t, hex.EncodeToString(sum[:]))
+	u := NewUpdater(s.URL+"/cli", "linux", "amd64")
+	binaryPath := newTestBinary(t)
+
+	err := u.Update(context.Background(), StableChannel, semver.MustParse("3.1.0"), binaryPath)
+	require.ErrorContains(t, err, "the checksum of the downloaded release doesn't match")
+
+	b, err := os.ReadFile(binaryPath)
+	require.NoError(t, err)
+	assert.Equal(t, "old okteto binary", string(b))
+
+	entries, err := os.ReadDir(filepath.Dir(binaryPath))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestUpdateErrors(t *testing.T) {
+	s := newFakeReleaseServer(t, "not-a-checksum")
+	binaryPath := newTestBinary(t)
+
+	tests := []struct {
+		name        string
+		goos        string
+		goarch      string
+		version     string
+		expectedErr string
+	}{
+		{
+			name:        "invalid checksum",
+			goos:        "linux",
+			goarch:      "amd64",
+			version:     "3.1.0",
+			expectedErr: "the checksum of the release is not valid",
+		},
+		{
+			name:        "missing release",
+			goos:        "linux",
+			goarch:      "amd64",
+			version:     "3.0.0",
+			expectedErr: "failed to get the checksum of the release",
+		},
+		{
+			name:        "unsupported platform",
+			goos:        "freebsd",
+			goarch:      "amd64",
+			version:     "3.1.0",
+			expectedErr: "freebsd-amd64 is not a supported platform",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewUpdater(s.URL+"/cli", tt.goos, tt.goarch)
+			err := u.Update(context.Background(), StableChannel, semver.MustParse(tt.version), binaryPath)
+			require.ErrorContains(t, err, tt.expectedErr)
+
+			b, err := os.ReadFile(binaryPath)
+			require.NoError(t, err)
+			assert.Equal(t, "old okteto binary", string(b))
+		})
+	}
+}
+
+func TestGetAssetName(t *testing.T) {
+	tests := []struct {
+		goos     string
+		goarch   string
+		expected string
+	}{
+		{goos: "darwin", goarch: "amd64", expected: "okteto-Darwin-x86_64"},
+		{goos: "darwin", goarch: "arm64", expected: "okteto-Darwin-arm64"},
+		{goos: "linux", goarch: "amd64", expected: "okteto-Linux-x86_64"},
+		{goos: "linux", goarch: "arm64", expected: "okteto-Linux-arm64"},
+		{goos: "windows", goarch: "amd64", expected: "okteto.exe"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.goos+"-"+tt.goarch, func(t *testing.T) {
+			got, err := GetAssetName(tt.goos, tt.goarch)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+
+	_, err := GetAssetName("linux", "386")
+	assert.Error(t, err)
+}
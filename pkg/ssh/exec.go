@@ -34,6 +34,12 @@ import (
 
 // Exec executes the command over SSH
 func Exec(ctx context.Context, iface string, remotePort int, tty bool, inR io.Reader, outW, errW io.Writer, command []string) error {
+	return ExecWithEnv(ctx, iface, remotePort, tty, inR, outW, errW, command, nil)
+}
+
+// ExecWithEnv executes the command over SSH with the environment variables envs, with the format 'NAME=value'.
+// The variables are set in the environment of the SSH session, so they are not part of the command line
+func ExecWithEnv(ctx context.Context, iface string, remotePort int, tty bool, inR io.Reader, outW, errW io.Writer, command, envs []string) error {
 	sshConfig, err := getSSHClientConfig()
 	if err != nil {
 		return fmt.Errorf("failed to get SSH configuration: %w", err)
@@ -83,6 +89,14 @@ func Exec(ctx context.Context, iface string, remotePort int, tty bool, inR io.Re
 		}
 	}()
 
+	for _, kv := range envs {
+		name, value, _ := strings.Cut(kv, "=")
+		if err := session.Setenv(name, value); err != nil {
+			return fmt.Errorf("failed to set the environment variable '%s': %w", name, err)
+		}
+	}
+
+	height, width := 80, 40
 	if tty {
 		modes := ssh.TerminalModes{
 			ssh.ECHO:          0,      // Disable echoing
@@ -92,7 +106,6 @@ func Exec(ctx context.Context, iface string, remotePort int, tty bool, inR io.Re
 			ssh.TTY_OP_OSPEED: 115200, // baud out
 		}
 
-		height, width := 80, 40
 		var termFD int
 		var ok bool
 		if termFD, ok = isTerminal(inR); ok {
@@ -165,7 +178,11 @@ func Exec(ctx context.Context, iface string, remotePort int, tty bool, inR io.Re
 		}
 	}()
 
-	resizeWindow(session)
+	if tty {
+		resizeCtx, cancelResize := context.WithCancel(ctx)
+		defer cancelResize()
+		resizeWindow(resizeCtx, session, terminalSize{width: width, height: height})
+	}
 
 	cmd := shellescape.QuoteCommand(command)
 	oktetoLog.Infof("executing command over ssh: '%s'", cmd)
@@ -15,6 +15,7 @@ package ssh
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"runtime"
@@ -26,12 +27,21 @@ import (
 	oktetoLog "github.com/okteto/okteto/pkg/log"
 	"github.com/okteto/okteto/pkg/model"
 	forwardModel "github.com/okteto/okteto/pkg/model/forward"
+	"github.com/okteto/okteto/pkg/reconnect"
 )
 
 const (
 	maxSystemPorts = 1024
 )
 
+var (
+	errStartPortForward = errors.New("failed to start SSH port-forward")
+	errSSHConfig        = errors.New("failed to get SSH configuration")
+)
+
+// startBackoff is the backoff between the attempts to connect to the SSH server of the development container
+var startBackoff = reconnect.Backoff{Initial: 200 * time.Millisecond, Max: time.Second}
+
 // ForwardManager handles the lifecycle of all the forwards
 type ForwardManager struct {
 	localInterface  string
@@ -43,6 +53,7 @@ type ForwardManager struct {
 	sshAddr         string
 	pf              *k8sForward.PortForwardManager
 	pool            *pool
+	reconnects      *reconnect.Coordinator
 	namespace       string
 }
 
@@ -57,11 +68,12 @@ func NewForwardManager(ctx context.Context, sshAddr, localInterface, remoteInter
 		reverses:        make(map[int]*reverse),
 		sshAddr:         sshAddr,
 		pf:              pf,
+		reconnects:      reconnect.Default(),
 		namespace:       namespace,
 	}
 }
 
-func (fm *ForwardManager) canAdd(localPort int, checkAvailable bool) error {
+func (fm *ForwardManager) canAdd(localInterface string, localPort int, checkAvailable bool) error {
 	if _, ok := fm.reverses[localPort]; ok {
 		return fmt.Errorf("port %d is listed multiple times, please check your reverse forwards configuration", localPort)
 	}
@@ -78,12 +90,12 @@ func (fm *ForwardManager) canAdd(localPort int, checkAvailable bool) error {
 		return nil
 	}
 
-	if !model.IsPortAvailable(fm.localInterface, localPort) {
+	if !model.IsPortAvailable(localInterface, localPort) {
 		if localPort <= maxSystemPorts {
 			os := runtime.GOOS
 			switch os {
 			case "darwin":
-				if fm.localInterface == model.Localhost {
+				if localInterface == model.Localhost {
 					return fmt.Errorf("local port %d is privileged. Define 'interface: 0.0.0.0' in your okteto manifest and try again", localPort)
 				}
 			case "linux":
@@ -105,12 +117,13 @@ func (fm *ForwardManager) Add(f forwardModel.Forward) error {
 		forwardsToUpdate = fm.globalForwards
 	}
 
-	if err := fm.canAdd(f.Local, true); err != nil {
+	localInterface := f.GetInterface(fm.localInterface)
+	if err := fm.canAdd(localInterface, f.Local, true); err != nil {
 		return err
 	}
 
 	forwardsToUpdate[f.Local] = &forward{
-		localAddress:  net.JoinHostPort(fm.localInterface, strconv.Itoa(f.Local)),
+		localAddress:  net.JoinHostPort(localInterface, strconv.Itoa(f.Local)),
 		remoteAddress: net.JoinHostPort(fm.remoteInterface, strconv.Itoa(f.Remote)),
 	}
 
@@ -125,35 +138,29 @@ func (fm *ForwardManager) Add(f forwardModel.Forward) error {
 func (fm *ForwardManager) Start(devPod, namespace string) error {
 	oktetoLog.Info("starting SSH forward manager")
 
-	ticker := time.NewTicker(200 * time.Millisecond)
 	timeoutDuration := 10 * time.Second
 	to := time.Now().Add(timeoutDuration)
 	retries := 0
+	id := fmt.Sprintf("ssh forward manager %s", fm.sshAddr)
+	release := func() {}
 
 	for {
 		retries++
 		oktetoLog.Infof("SSH forward manager retry %d", retries)
-		if fm.pf != nil {
-			if err := fm.pf.Start(devPod, namespace); err != nil {
-				return fmt.Errorf("failed to start SSH port-forward: %w", err)
-			}
-
-			oktetoLog.Info("k8s port forward to dev pod connected")
-		}
-
-		c, err := getSSHClientConfig()
-		if err != nil {
-			return fmt.Errorf("failed to get SSH configuration: %w", err)
-		}
-
-		oktetoLog.Infof("starting SSH connection pool on %s", fm.sshAddr)
-		pool, err := startPool(fm.ctx, fm.sshAddr, c)
+		err := fm.connect(devPod, namespace)
+		release()
 		if err == nil {
-			fm.pool = pool
+			fm.reconnects.Reconnected(id)
 			break
 		}
+		if errors.Is(err, errStartPortForward) || errors.Is(err, errSSHConfig) {
+			fm.reconnects.Forget(id)
+			return err
+		}
+
 		oktetoLog.Infof("error starting SSH connection pool on %s: %s", fm.sshAddr, err.Error())
 		if time.Now().After(to) && retries > 10 {
+			fm.reconnects.Forget(id)
 			return oktetoErrors.ErrSSHConnectError
 		}
 
@@ -161,14 +168,12 @@ func (fm *ForwardManager) Start(devPod, namespace string) error {
 			fm.pf.Stop()
 		}
 
-		select {
-		case <-ticker.C:
-			continue
-		case <-fm.ctx.Done():
+		release, err = fm.reconnects.Wait(fm.ctx, id, startBackoff)
+		if err != nil {
+			fm.reconnects.Forget(id)
 			oktetoLog.Infof("ForwardManager.Start cancelled")
 			return fmt.Errorf("ForwardManager.Start cancelled")
 		}
-
 	}
 
 	for _, ff := range fm.forwards {
@@ -184,6 +189,30 @@ func (fm *ForwardManager) Start(devPod, namespace string) error {
 	return nil
 }
 
+// connect starts the port-forward to the SSH server and the SSH connection pool
+func (fm *ForwardManager) connect(devPod, namespace string) error {
+	if fm.pf != nil {
+		if err := fm.pf.Start(devPod, namespace); err != nil {
+			return fmt.Errorf("%w: %w", errStartPortForward, err)
+		}
+
+		oktetoLog.Info("k8s port forward to dev pod connected")
+	}
+
+	c, err := getSSHClientConfig()
+	if err != nil {
+		return fmt.Errorf("%w: %w", errSSHConfig, err)
+	}
+
+	oktetoLog.Infof("starting SSH connection pool on %s", fm.sshAddr)
+	pool, err := startPool(fm.ctx, fm.sshAddr, c)
+	if err != nil {
+		return err
+	}
+	fm.pool = pool
+	return nil
+}
+
 // Stop sends a stop signal to all the connections
 func (fm *ForwardManager) Stop() {
 
@@ -367,3 +367,22 @@ func TestAdd(t *testing.T) {
 		t.Fatalf("expected 'svc:15123', got '%s'", pf.forwards[1012].remoteAddress)
 	}
 }
+
+func TestAddWithInterface(t *testing.T) {
+	pf := NewForwardManager(context.Background(), "0.0.0.0:22000", model.Localhost, "0.0.0.0", nil, "")
+	if err := pf.Add(forwardModel.Forward{Local: 10020, Remote: 1020}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := pf.Add(forwardModel.Forward{Local: 10021, Remote: 1021, Interface: "0.0.0.0"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if pf.forwards[10020].localAddress != "localhost:10020" {
+		t.Fatalf("expected 'localhost:10020', got '%s'", pf.forwards[10020].localAddress)
+	}
+
+	if pf.forwards[10021].localAddress != "0.0.0.0:10021" {
+		t.Fatalf("expected '0.0.0.0:10021', got '%s'", pf.forwards[10021].localAddress)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh
+
+import (
+	"context"
+	"os"
+	"time"
+
+	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/term"
+)
+
+const (
+	// windowChangeRequest is the channel request sent when the terminal size changes (RFC 4254, section 6.7)
+	windowChangeRequest = "window-change"
+
+	// resizeDebounce is the time to wait for more resize events before sending the new size
+	resizeDebounce = 100 * time.Millisecond
+)
+
+// windowChangeMsg is the payload of the 'window-change' request
+type windowChangeMsg struct {
+	Columns uint32
+	Rows    uint32
+	Width   uint32
+	Height  uint32
+}
+
+// requestSender sends requests over an SSH channel, like ssh.Session
+type requestSender interface {
+	SendRequest(name string, wantReply bool, payload []byte) (bool, error)
+}
+
+type terminalSize struct {
+	width  int
+	height int
+}
+
+// sizeGetter returns the current size of the local terminal
+type sizeGetter func() (terminalSize, error)
+
+func getStdoutSize() (terminalSize, error) {
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	return terminalSize{width: width, height: height}, err
+}
+
+func encodeWindowChange(size terminalSize) []byte {
+	return ssh.Marshal(windowChangeMsg{
+		Columns: uint32(size.width),
+		Rows:    uint32(size.height),
+	})
+}
+
+func sendWindowChange(s requestSender, size terminalSize) error {
+	_, err := s.SendRequest(windowChangeRequest, false, encodeWindowChange(size))
+	return err
+}
+
+// watchWindowSize sends the size of the local terminal to the remote session every time it changes.
+// Bursts of resize events are debounced so only the last size is sent
+func watchWindowSize(ctx context.Context, events <-chan struct{}, getSize sizeGetter, s requestSender, initial terminalSize, debounce time.Duration) {
+	last := initial
+	timer := time.NewTimer(debounce)
+	if !timer.Stop() {
+		<-timer.C
+	}
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(debounce)
+		case <-timer.C:
+			size, err := getSize()
+			if err != nil {
+				oktetoLog.Infof("request for terminal size failed: %s", err)
+				continue
+			}
+			if size == last {
+				continue
+			}
+			oktetoLog.Infof("terminal width %d height %d", size.width, size.height)
+			if err := sendWindowChange(s, size); err != nil {
+				oktetoLog.Infof("request for terminal resize failed: %s", err)
+				continue
+			}
+			last = size
+		}
+	}
+}
+
+// resizeWindow propagates the resize events of the local terminal to the session until the context is done
+func resizeWindow(ctx context.Context, session requestSender, initial terminalSize) {
+	go watchWindowSize(ctx, resizeEvents(ctx), getStdoutSize, session, initial, resizeDebounce)
+}
@@ -0,0 +1,154 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+const testDebounce = 20 * time.Millisecond
+
+type fakeRequest struct {
+	name    string
+	payload []byte
+}
+
+type fakeChannel struct {
+	err      error
+	requests []fakeRequest
+	mu       sync.Mutex
+}
+
+func (f *fakeChannel) SendRequest(name string, _ bool, payload []byte) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return false, f.err
+	}
+	f.requests = append(f.requests, fakeRequest{name: name, payload: payload})
+	return true, nil
+}
+
+func (f *fakeChannel) getRequests() []fakeRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]fakeRequest{}, f.requests...)
+}
+
+type fakeTerminal struct {
+	size terminalSize
+	mu   sync.Mutex
+}
+
+func (f *fakeTerminal) resize(width, height int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.size = terminalSize{width: width, height: height}
+}
+
+func (f *fakeTerminal) getSize() (terminalSize, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.size, nil
+}
+
+func decodeWindowChange(t *testing.T, payload []byte) windowChangeMsg {
+	t.Helper()
+	msg := windowChangeMsg{}
+	require.NoError(t, ssh.Unmarshal(payload, &msg))
+	return msg
+}
+
+func TestEncodeWindowChange(t *testing.T) {
+	payload := encodeWindowChange(terminalSize{width: 120, height: 40})
+
+	expected := []byte{
+		0, 0, 0, 120, // columns
+		0, 0, 0, 40, // rows
+		0, 0, 0, 0, // width in pixels
+		0, 0, 0, 0, // height in pixels
+	}
+	assert.Equal(t, expected, payload)
+	assert.Equal(t, windowChangeMsg{Columns: 120, Rows: 40}, decodeWindowChange(t, payload))
+}
+
+func TestWatchWindowSizeDebounce(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := make(chan struct{})
+	terminal := &fakeTerminal{size: terminalSize{width: 80, height: 24}}
+	channel := &fakeChannel{}
+	done := make(chan struct{})
+	go func() {
+		watchWindowSize(ctx, events, terminal.getSize, channel, terminalSize{width: 80, height: 24}, testDebounce)
+		close(done)
+	}()
+
+	// a burst of resize events only sends the last size
+	for i := 1; i <= 5; i++ {
+		terminal.resize(80+i*10, 24+i)
+		events <- struct{}{}
+	}
+	require.Eventually(t, func() bool { return len(channel.getRequests()) == 1 }, time.Second, 5*time.Millisecond)
+	time.Sleep(3 * testDebounce)
+	requests := channel.getRequests()
+	require.Len(t, requests, 1)
+	assert.Equal(t, windowChangeRequest, requests[0].name)
+	assert.Equal(t, windowChangeMsg{Columns: 130, Rows: 29}, decodeWindowChange(t, requests[0].payload))
+
+	// events without size changes are not sent
+	events <- struct{}{}
+	time.Sleep(3 * testDebounce)
+	require.Len(t, channel.getRequests(), 1)
+
+	// a later resize is sent
+	terminal.resize(100, 30)
+	events <- struct{}{}
+	require.Eventually(t, func() bool { return len(channel.getRequests()) == 2 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, windowChangeMsg{Columns: 100, Rows: 30}, decodeWindowChange(t, channel.getRequests()[1].payload))
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("watchWindowSize didn't stop after the context was canceled")
+	}
+}
+
+func TestWatchWindowSizeRetriesFailedRequests(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := make(chan struct{})
+	terminal := &fakeTerminal{size: terminalSize{width: 100, height: 30}}
+	channel := &fakeChannel{err: assert.AnError}
+	go watchWindowSize(ctx, events, terminal.getSize, channel, terminalSize{width: 80, height: 24}, testDebounce)
+
+	events <- struct{}{}
+	time.Sleep(3 * testDebounce)
+	require.Empty(t, channel.getRequests())
+
+	channel.mu.Lock()
+	channel.err = nil
+	channel.mu.Unlock()
+	events <- struct{}{}
+	require.Eventually(t, func() bool { return len(channel.getRequests()) == 1 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, windowChangeMsg{Columns: 100, Rows: 30}, decodeWindowChange(t, channel.getRequests()[0].payload))
+}
@@ -17,28 +17,30 @@
 package ssh
 
 import (
+	"context"
 	"os"
 	"os/signal"
 	"syscall"
-
-	oktetoLog "github.com/okteto/okteto/pkg/log"
-	"golang.org/x/crypto/ssh"
-	"golang.org/x/term"
 )
 
-func resizeWindow(session *ssh.Session) {
-	resize := make(chan os.Signal, 1)
-	signal.Notify(resize, syscall.SIGWINCH)
+// resizeEvents notifies every SIGWINCH received until the context is done
+func resizeEvents(ctx context.Context) <-chan struct{} {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGWINCH)
+	events := make(chan struct{}, 1)
 	go func() {
-		for range resize {
-			width, height, err := term.GetSize(int(os.Stdout.Fd()))
-			oktetoLog.Infof("terminal width %d height %d", width, height)
-			if err != nil {
-				oktetoLog.Infof("request for terminal size failed: %s", err)
-			}
-			if err := session.WindowChange(height, width); err != nil {
-				oktetoLog.Infof("request for terminal resize failed: %s", err)
+		defer signal.Stop(sigs)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sigs:
+				select {
+				case events <- struct{}{}:
+				default:
+				}
 			}
 		}
 	}()
+	return events
 }
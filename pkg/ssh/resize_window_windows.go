@@ -4,33 +4,30 @@
 package ssh
 
 import (
-	"os"
+	"context"
 	"time"
-
-	oktetoLog "github.com/okteto/okteto/pkg/log"
-	"golang.org/x/crypto/ssh"
-	"golang.org/x/term"
 )
 
-func resizeWindow(session *ssh.Session) {
+// resizePollInterval is the interval to check the console size, as windows doesn't send SIGWINCH
+const resizePollInterval = 250 * time.Millisecond
+
+// resizeEvents notifies periodically until the context is done so the console size is checked
+func resizeEvents(ctx context.Context) <-chan struct{} {
+	events := make(chan struct{}, 1)
 	go func() {
-		prevW, prevH, err := term.GetSize(int(os.Stdout.Fd()))
-		if err != nil {
-			oktetoLog.Infof("request for terminal size failed: %s", err)
-		}
+		t := time.NewTicker(resizePollInterval)
+		defer t.Stop()
 		for {
-			time.Sleep(time.Millisecond * 250)
-			w, h, err := term.GetSize(int(os.Stdout.Fd()))
-			if err != nil {
-				oktetoLog.Infof("request for terminal size failed: %s", err)
-			}
-			if prevH != h || prevW != w {
-				if err := session.WindowChange(h, w); err != nil {
-					oktetoLog.Infof("request for terminal resize failed: %s", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+				select {
+				case events <- struct{}{}:
+				default:
 				}
 			}
-			prevH = h
-			prevW = w
 		}
 	}()
+	return events
 }
@@ -25,14 +25,24 @@ import (
 	"github.com/okteto/okteto/pkg/model"
 )
 
+// privilegedPortOffset is added to a privileged remote port to get the unprivileged port of its relay
+const privilegedPortOffset = 10000
+
 type reverse struct {
 	forward
 }
 
+// reverseRelay relays a privileged port of the development container to the unprivileged port bound by the SSH server
+type reverseRelay struct {
+	host             string
+	privilegedPort   int
+	unprivilegedPort int
+}
+
 // AddReverse adds a reverse forward
 func (fm *ForwardManager) AddReverse(f model.Reverse) error {
 
-	if err := fm.canAdd(f.Local, false); err != nil {
+	if err := fm.canAdd(fm.localInterface, f.Local, false); err != nil {
 		return err
 	}
 
@@ -50,7 +60,16 @@ func (r *reverse) start(ctx context.Context) {
 	remoteListener, err := r.pool.getListener(r.remoteAddress)
 	if err != nil {
 		oktetoLog.Infof("%s -> failed to listen on remote address: %v", r.String(), err)
-		return
+		relay, ok := planReverseRelay(r.remoteAddress)
+		if !ok {
+			return
+		}
+		remoteListener, err = r.startRelay(ctx, relay)
+		if err != nil {
+			oktetoLog.Infof("%s -> failed to relay privileged port %d: %v", r.String(), relay.privilegedPort, err)
+			oktetoLog.Warning("Port %d couldn't be bound in your development container. Set 'allowPrivilegedPorts: true' in your okteto manifest or use a port higher than %d", relay.privilegedPort, model.MaxPrivilegedPort)
+			return
+		}
 	}
 	defer func() {
 		if err := remoteListener.Close(); err != nil {
@@ -87,6 +106,73 @@ func (r *reverse) start(ctx context.Context) {
 	}
 }
 
+// planReverseRelay returns the relay used when the SSH server can't bind the remote address of a reverse forward.
+// Only privileged ports are relayed, any other bind failure isn't related to the permissions of the SSH server
+func planReverseRelay(remoteAddress string) (*reverseRelay, bool) {
+	host, port, err := net.SplitHostPort(remoteAddress)
+	if err != nil {
+		return nil, false
+	}
+	p, err := strconv.Atoi(port)
+	if err != nil || p <= 0 || p > model.MaxPrivilegedPort {
+		return nil, false
+	}
+	return &reverseRelay{host: host, privilegedPort: p, unprivilegedPort: p + privilegedPortOffset}, true
+}
+
+func (rr *reverseRelay) unprivilegedAddress() string {
+	return net.JoinHostPort(rr.host, strconv.Itoa(rr.unprivilegedPort))
+}
+
+// command returns the socat command that listens on the privileged port and sends the traffic to the unprivileged port
+func (rr *reverseRelay) command() string {
+	listen := fmt.Sprintf("TCP-LISTEN:%d,fork,reuseaddr", rr.privilegedPort)
+	if rr.host != "" && rr.host != "0.0.0.0" {
+		listen = fmt.Sprintf("%s,bind=%s", listen, rr.host)
+	}
+	return fmt.Sprintf("socat %s TCP:127.0.0.1:%d", listen, rr.unprivilegedPort)
+}
+
+// startRelay binds the unprivileged port of the relay and starts the socat relay in the development container
+func (r *reverse) startRelay(ctx context.Context, relay *reverseRelay) (net.Listener, error) {
+	l, err := r.pool.getListener(relay.unprivilegedAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := r.pool.client.NewSession()
+	if err != nil {
+		r.closeListener(l)
+		return nil, fmt.Errorf("failed to create ssh session: %w", err)
+	}
+	if err := session.Start(relay.command()); err != nil {
+		r.closeListener(l)
+		return nil, fmt.Errorf("failed to start socat: %w", err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		if err := session.Close(); err != nil {
+			oktetoLog.Debugf("Error closing relay session '%s': %s", r.String(), err)
+		}
+	}()
+	go func() {
+		if err := session.Wait(); err != nil && ctx.Err() == nil {
+			oktetoLog.Infof("%s -> socat relay exited: %s", r.String(), err)
+			oktetoLog.Warning("The relay of port %d stopped. Check that socat is installed in your development container and can bind privileged ports, or set 'allowPrivilegedPorts: true' in your okteto manifest", relay.privilegedPort)
+		}
+	}()
+
+	oktetoLog.Information("Port %d couldn't be bound in your development container: relaying it to port %d with socat", relay.privilegedPort, relay.unprivilegedPort)
+	return l, nil
+}
+
+func (r *reverse) closeListener(l net.Listener) {
+	if err := l.Close(); err != nil {
+		oktetoLog.Debugf("Error closing remote listener '%s': %s", r.String(), err)
+	}
+}
+
 func (r *reverse) handle(ctx context.Context, remote net.Conn) {
 	defer func() {
 		if err := remote.Close(); err != nil {
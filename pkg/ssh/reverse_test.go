@@ -63,3 +63,53 @@ func TestReverseManager_Add(t *testing.T) {
 		})
 	}
 }
+
+func TestPlanReverseRelay(t *testing.T) {
+	tests := []struct {
+		expected      *reverseRelay
+		name          string
+		remoteAddress string
+		expectedCmd   string
+	}{
+		{
+			name:          "privileged port on all interfaces",
+			remoteAddress: "0.0.0.0:80",
+			expected:      &reverseRelay{host: "0.0.0.0", privilegedPort: 80, unprivilegedPort: 10080},
+			expectedCmd:   "socat TCP-LISTEN:80,fork,reuseaddr TCP:127.0.0.1:10080",
+		},
+		{
+			name:          "privileged port on localhost",
+			remoteAddress: "127.0.0.1:443",
+			expected:      &reverseRelay{host: "127.0.0.1", privilegedPort: 443, unprivilegedPort: 10443},
+			expectedCmd:   "socat TCP-LISTEN:443,fork,reuseaddr,bind=127.0.0.1 TCP:127.0.0.1:10443",
+		},
+		{
+			name:          "unprivileged port",
+			remoteAddress: "0.0.0.0:8080",
+		},
+		{
+			name:          "invalid address",
+			remoteAddress: "8080",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			relay, ok := planReverseRelay(tt.remoteAddress)
+			if tt.expected == nil {
+				if ok {
+					t.Fatalf("unexpected relay for %s: %+v", tt.remoteAddress, relay)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("expected relay for %s", tt.remoteAddress)
+			}
+			if *relay != *tt.expected {
+				t.Fatalf("expected relay %+v, got %+v", tt.expected, relay)
+			}
+			if relay.command() != tt.expectedCmd {
+				t.Fatalf("expected command %q, got %q", tt.expectedCmd, relay.command())
+			}
+		})
+	}
+}
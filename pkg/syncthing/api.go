@@ -24,12 +24,16 @@ import (
 	"time"
 
 	oktetoLog "github.com/okteto/okteto/pkg/log"
+	"github.com/okteto/okteto/pkg/reconnect"
 )
 
 type addAPIKeyTransport struct {
 	T http.RoundTripper
 }
 
+// remoteAPIBackoff is the backoff between the retries of the calls to the remote syncthing API
+var remoteAPIBackoff = reconnect.Backoff{Initial: 200 * time.Millisecond, Max: 3 * time.Second}
+
 const (
 	APIKeyHeader      = "X-Api-Key"
 	APIKeyHeaderValue = "cnd"
@@ -50,15 +54,32 @@ func NewAPIClient() *http.Client {
 	}
 }
 
-// APICall calls the syncthing API and returns the parsed json or an error
+// APICall calls the syncthing API and returns the parsed json or an error.
+// The retries of the calls to the remote syncthing API go through the shared reconnect coordinator, as they reach it through the port-forward
 func (s *Syncthing) APICall(ctx context.Context, url, method string, code int, params map[string]string, local bool, body []byte, readBody bool, maxRetries int) ([]byte, error) {
 	retries := 0
 	ticker := time.NewTicker(200 * time.Millisecond)
+	reconnects := reconnect.Default()
+	id := fmt.Sprintf("syncthing api %s", s.RemoteGUIAddress)
 	for {
 		select {
 		case <-ticker.C:
+			release := func() {}
+			if !local && retries > 0 {
+				var err error
+				release, err = reconnects.Wait(ctx, id, remoteAPIBackoff)
+				if err != nil {
+					reconnects.Forget(id)
+					oktetoLog.Infof("call to syncthing.APICall %s canceled", url)
+					return nil, err
+				}
+			}
 			result, err := s.callWithRetry(ctx, url, method, code, params, local, body, readBody)
+			release()
 			if err == nil {
+				if !local {
+					reconnects.Reconnected(id)
+				}
 				return result, nil
 			}
 
@@ -69,6 +90,9 @@ func (s *Syncthing) APICall(ctx context.Context, url, method string, code int, p
 			}
 
 			if retries >= maxRetries {
+				if !local {
+					reconnects.Forget(id)
+				}
 				return nil, err
 			}
 			retries++
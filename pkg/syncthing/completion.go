@@ -36,6 +36,13 @@ type Completion struct {
 	NeedDeletes int64   `json:"needDeletes"`
 }
 
+// Progress represents the progress of the synchronization of the local files to the remote device
+type Progress struct {
+	Percentage float64
+	DoneBytes  int64
+	TotalBytes int64
+}
+
 // waitForCompletion represents a wait for completion iteration
 type waitForCompletion struct {
 	localCompletion           *Completion
@@ -47,10 +54,12 @@ type waitForCompletion struct {
 	needDeletesRetries        int64
 	retries                   int64
 	progress                  float64
+	doneBytes                 int64
+	totalBytes                int64
 }
 
 // WaitForCompletion waits for the remote to be totally synched
-func (s *Syncthing) WaitForCompletion(ctx context.Context, reporter chan float64) error {
+func (s *Syncthing) WaitForCompletion(ctx context.Context, reporter chan Progress) error {
 	defer close(reporter)
 	ticker := time.NewTicker(250 * time.Millisecond)
 	wfc := &waitForCompletion{sy: s}
@@ -72,13 +81,13 @@ func (s *Syncthing) WaitForCompletion(ctx context.Context, reporter chan float64
 			}
 			if err := wfc.computeProgress(ctx); err != nil {
 				if err == oktetoErrors.ErrBusySyncthing {
-					reporter <- wfc.progress
+					reporter <- wfc.report()
 					continue
 				}
 				return err
 			}
 
-			reporter <- wfc.progress
+			reporter <- wfc.report()
 
 			if wfc.needsDatabaseReset() {
 				return oktetoErrors.ErrNeedsResetSyncError
@@ -102,6 +111,8 @@ func (wfc *waitForCompletion) computeProgress(ctx context.Context) error {
 	}
 	wfc.localCompletion = localCompletion
 	oktetoLog.Infof("syncthing status in local: globalBytes %d, needBytes %d, globalItems %d, needItems %d, needDeletes %d", localCompletion.GlobalBytes, localCompletion.NeedBytes, localCompletion.GlobalItems, localCompletion.NeedItems, localCompletion.NeedDeletes)
+	wfc.totalBytes = localCompletion.GlobalBytes
+	wfc.doneBytes = localCompletion.GlobalBytes - localCompletion.NeedBytes
 	if localCompletion.GlobalBytes == 0 {
 		wfc.progress = completedProgress
 	} else {
@@ -123,6 +134,14 @@ func (wfc *waitForCompletion) computeProgress(ctx context.Context) error {
 	return nil
 }
 
+func (wfc *waitForCompletion) report() Progress {
+	return Progress{
+		Percentage: wfc.progress,
+		DoneBytes:  wfc.doneBytes,
+		TotalBytes: wfc.totalBytes,
+	}
+}
+
 func (wfc *waitForCompletion) needsDatabaseReset() bool {
 	if wfc.localCompletion.GlobalBytes == wfc.remoteCompletion.GlobalBytes {
 		wfc.globalBytesRetries = 0
@@ -23,6 +23,7 @@ import (
 	"net/http"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"regexp"
 	"runtime"
@@ -65,10 +66,10 @@ const (
 	DefaultFileWatcherDelay = 5
 
 	// ClusterPort is the port used by syncthing in the cluster
-	ClusterPort = 22000
+	ClusterPort = model.SyncthingDataPort
 
 	// GUIPort is the port used by syncthing in the cluster for the http endpoint
-	GUIPort = 8384
+	GUIPort = model.SyncthingGUIPort
 
 	maxRetries = 3
 
@@ -116,10 +117,12 @@ type Syncthing struct {
 
 // Folder represents a sync folder
 type Folder struct {
-	Name        string `yaml:"name"`
-	LocalPath   string `yaml:"localPath"`
-	RemotePath  string `yaml:"remotePath"`
-	Overwritten bool   `yaml:"-"`
+	Name           string `yaml:"name"`
+	LocalPath      string `yaml:"localPath"`
+	RemotePath     string `yaml:"remotePath"`
+	ModTimeWindowS int    `yaml:"modTimeWindowS,omitempty"`
+	IgnorePerms    bool   `yaml:"ignorePerms,omitempty"`
+	Overwritten    bool   `yaml:"-"`
 }
 
 // Status represents the status of a syncthing folder.
@@ -175,6 +178,20 @@ type ItemEvent struct {
 	GlobalId int                                        `json:"globalID"`
 }
 
+// ItemFinishedEvent represents an item finished event in syncthing.
+type ItemFinishedEvent struct {
+	Data DataItemFinishedEvent `json:"data"`
+	ID   int                   `json:"id"`
+}
+
+// DataItemFinishedEvent represents data of an item finished event in syncthing.
+type DataItemFinishedEvent struct {
+	Error  *string `json:"error"`
+	Item   string  `json:"item"`
+	Folder string  `json:"folder"`
+	Action string  `json:"action"`
+}
+
 // SystemError represents a system error in syncthing.
 type SystemError struct {
 	Message string `json:"message"`
@@ -204,22 +221,22 @@ type DownloadProgressData struct {
 func New(dev *model.Dev, namespace string, fs afero.Fs) (*Syncthing, error) {
 	fullPath := getInstallPath()
 
-	remotePort, err := model.GetAvailablePort(dev.Interface)
+	remotePort, err := dev.GetAvailableLocalPort()
 	if err != nil {
 		return nil, err
 	}
 
-	remoteGUIPort, err := model.GetAvailablePort(dev.Interface)
+	remoteGUIPort, err := dev.GetAvailableLocalPort()
 	if err != nil {
 		return nil, err
 	}
 
-	guiPort, err := model.GetAvailablePort(dev.Interface)
+	guiPort, err := dev.GetAvailableLocalPort()
 	if err != nil {
 		return nil, err
 	}
 
-	listenPort, err := model.GetAvailablePort(dev.Interface)
+	listenPort, err := dev.GetAvailableLocalPort()
 	if err != nil {
 		return nil, err
 	}
@@ -272,14 +289,29 @@ func New(dev *model.Dev, namespace string, fs afero.Fs) (*Syncthing, error) {
 			s.Folders = append(
 				s.Folders,
 				&Folder{
-					Name:       strconv.Itoa(index),
-					LocalPath:  sync.LocalPath,
-					RemotePath: sync.RemotePath,
+					Name:           strconv.Itoa(index),
+					LocalPath:      sync.LocalPath,
+					RemotePath:     sync.RemotePath,
+					IgnorePerms:    dev.Sync.IsIgnorePermsEnabled(sync),
+					ModTimeWindowS: dev.Sync.GetModTimeWindow(sync),
 				},
 			)
 			index++
 		}
 	}
+	for _, sync := range dev.GetServicesSyncFolders() {
+		s.Folders = append(
+			s.Folders,
+			&Folder{
+				Name:           strconv.Itoa(index),
+				LocalPath:      sync.LocalPath,
+				RemotePath:     sync.RemotePath,
+				IgnorePerms:    dev.Sync.IsIgnorePermsEnabled(sync),
+				ModTimeWindowS: dev.Sync.GetModTimeWindow(sync),
+			},
+		)
+		index++
+	}
 
 	return s, nil
 }
@@ -450,6 +482,22 @@ func (s *Syncthing) Overwrite(ctx context.Context) error {
 	return nil
 }
 
+// Resume rescans the folders of the local and remote syncthing so the items that failed are pulled again
+func (s *Syncthing) Resume(ctx context.Context) error {
+	for _, folder := range s.Folders {
+		params := map[string]string{"folder": GetFolderName(folder)}
+		for _, local := range []bool{true, false} {
+			oktetoLog.Infof("resuming synchronization local=%t path=%s", local, folder.LocalPath)
+			if _, err := s.APICall(ctx, "rest/db/scan", "POST", http.StatusOK, params, local, nil, false, maxRetries); err != nil {
+				oktetoLog.Infof("error posting 'rest/db/scan' local=%t syncthing API: %s", local, err)
+				return oktetoErrors.ErrLostSyncthing
+			}
+		}
+	}
+	isHealthyRetries = 0
+	return nil
+}
+
 // IsAllOverwritten checks if all overwrite operations has been completed
 func (s *Syncthing) IsAllOverwritten() bool {
 	for _, folder := range s.Folders {
@@ -865,6 +913,81 @@ func (s *Syncthing) GetInSynchronizationFile(ctx context.Context) string {
 	return getInSynchronizationLargestFile(events[len(events)-1])
 }
 
+// GetFinishedItems returns the items updated by the remote syncthing since the event "since", and the id of the last event
+func (s *Syncthing) GetFinishedItems(ctx context.Context, since int) ([]string, int, error) {
+	events := []ItemFinishedEvent{}
+	params := map[string]string{
+		"since":   strconv.Itoa(since),
+		"timeout": "0",
+		"events":  "ItemFinished",
+	}
+	body, err := s.APICall(ctx, "rest/events", "GET", http.StatusOK, params, false, nil, true, 0)
+	if err != nil {
+		return nil, since, err
+	}
+
+	if err := json.Unmarshal(body, &events); err != nil {
+		return nil, since, fmt.Errorf("error unmarshalling events: %w", err)
+	}
+
+	items := []string{}
+	for _, e := range events {
+		if e.ID > since {
+			since = e.ID
+		}
+		if e.Data.Error != nil {
+			continue
+		}
+		items = append(items, e.Data.Item)
+	}
+	return items, since, nil
+}
+
+// GetUpdatedRemotePaths returns the paths in the development container of the files created or updated by the remote syncthing
+// since the event "since", and the id of the last event
+func (s *Syncthing) GetUpdatedRemotePaths(ctx context.Context, since int) ([]string, int, error) {
+	events := []ItemFinishedEvent{}
+	params := map[string]string{
+		"since":   strconv.Itoa(since),
+		"timeout": "0",
+		"events":  "ItemFinished",
+	}
+	body, err := s.APICall(ctx, "rest/events", "GET", http.StatusOK, params, false, nil, true, 0)
+	if err != nil {
+		return nil, since, err
+	}
+
+	if err := json.Unmarshal(body, &events); err != nil {
+		return nil, since, fmt.Errorf("error unmarshalling events: %w", err)
+	}
+
+	paths, since := s.getUpdatedRemotePaths(events, since)
+	return paths, since, nil
+}
+
+func (s *Syncthing) getUpdatedRemotePaths(events []ItemFinishedEvent, since int) ([]string, int) {
+	remotePaths := map[string]string{}
+	for _, folder := range s.Folders {
+		remotePaths[fmt.Sprintf("okteto-%s", folder.Name)] = folder.RemotePath
+	}
+
+	paths := []string{}
+	for _, e := range events {
+		if e.ID > since {
+			since = e.ID
+		}
+		if e.Data.Error != nil || e.Data.Action == "delete" {
+			continue
+		}
+		remotePath, ok := remotePaths[e.Data.Folder]
+		if !ok {
+			continue
+		}
+		paths = append(paths, path.Join(remotePath, filepath.ToSlash(e.Data.Item)))
+	}
+	return paths, since
+}
+
 func getInSynchronizationLargestFile(e ItemEvent) string {
 	result := ""
 	var largerFileSize int64
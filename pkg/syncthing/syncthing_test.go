@@ -20,8 +20,11 @@ import (
 
 	"github.com/okteto/okteto/pkg/constants"
 	oktetoErrors "github.com/okteto/okteto/pkg/errors"
+	"github.com/okteto/okteto/pkg/model"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
 )
 
 func TestGetFiles(t *testing.T) {
@@ -99,3 +102,160 @@ func TestIdentifyReadinessIssue(t *testing.T) {
 		})
 	}
 }
+
+func TestNewWithServicesSyncFolders(t *testing.T) {
+	dev := &model.Dev{
+		Name:      "api",
+		Interface: model.Localhost,
+		Sync: model.Sync{
+			Folders: []model.SyncFolder{
+				{LocalPath: "/src/api", RemotePath: "/app"},
+				{LocalPath: "/src/api/sub", RemotePath: "/app/sub"},
+			},
+		},
+		Services: []*model.Dev{
+			{
+				Name: "worker",
+				Sync: model.Sync{
+					Folders: []model.SyncFolder{
+						{LocalPath: "/src/protos", RemotePath: "/protos"},
+					},
+				},
+			},
+		},
+	}
+
+	s, err := New(dev, "namespace", afero.NewMemMapFs())
+	assert.NoError(t, err)
+	assert.Len(t, s.Folders, 2)
+	assert.Equal(t, "1", s.Folders[0].Name)
+	assert.Equal(t, "/src/api", s.Folders[0].LocalPath)
+	assert.Equal(t, "/app", s.Folders[0].RemotePath)
+	assert.Equal(t, "2", s.Folders[1].Name)
+	assert.Equal(t, "/src/protos", s.Folders[1].LocalPath)
+	assert.Equal(t, dev.GetServicesSyncFolders()[0].RemotePath, s.Folders[1].RemotePath)
+}
+
+func TestNewWithServicesSyncFoldersOptions(t *testing.T) {
+	dev := &model.Dev{
+		Name:      "api",
+		Interface: model.Localhost,
+		Sync: model.Sync{
+			IgnorePerms:   true,
+			ModTimeWindow: 1,
+			Folders: []model.SyncFolder{
+				{LocalPath: "/src/api", RemotePath: "/app"},
+			},
+		},
+		Services: []*model.Dev{
+			{
+				Name: "worker",
+				Sync: model.Sync{
+					Folders: []model.SyncFolder{
+						{LocalPath: "/src/protos", RemotePath: "/protos"},
+						{LocalPath: "/src/scripts", RemotePath: "/scripts", IgnorePerms: ptr.To(false), ModTimeWindow: ptr.To(2)},
+					},
+				},
+			},
+			{
+				Name: "frontend",
+				Sync: model.Sync{
+					ModTimeWindow: 3,
+					Folders: []model.SyncFolder{
+						{LocalPath: "/src/web", RemotePath: "/web"},
+					},
+				},
+			},
+		},
+	}
+
+	s, err := New(dev, "namespace", afero.NewMemMapFs())
+	require.NoError(t, err)
+	require.Len(t, s.Folders, 4)
+	options := map[string]*Folder{}
+	for _, f := range s.Folders {
+		options[f.LocalPath] = f
+	}
+	assert.True(t, options["/src/protos"].IgnorePerms)
+	assert.Equal(t, 1, options["/src/protos"].ModTimeWindowS)
+	assert.False(t, options["/src/scripts"].IgnorePerms)
+	assert.Equal(t, 2, options["/src/scripts"].ModTimeWindowS)
+	assert.True(t, options["/src/web"].IgnorePerms)
+	assert.Equal(t, 3, options["/src/web"].ModTimeWindowS)
+}
+
+func TestUpdateConfigFolderOptions(t *testing.T) {
+	dev := &model.Dev{
+		Name:      "api",
+		Interface: model.Localhost,
+		Sync: model.Sync{
+			IgnorePerms:   true,
+			ModTimeWindow: 1,
+			Folders: []model.SyncFolder{
+				{LocalPath: "/src/api", RemotePath: "/app"},
+				{LocalPath: "/src/scripts", RemotePath: "/scripts", IgnorePerms: ptr.To(false), ModTimeWindow: ptr.To(2)},
+			},
+		},
+	}
+
+	s, err := New(dev, "namespace", afero.NewMemMapFs())
+	require.NoError(t, err)
+	require.Len(t, s.Folders, 2)
+	assert.True(t, s.Folders[0].IgnorePerms)
+	assert.Equal(t, 1, s.Folders[0].ModTimeWindowS)
+	assert.False(t, s.Folders[1].IgnorePerms)
+	assert.Equal(t, 2, s.Folders[1].ModTimeWindowS)
+
+	s.Home = t.TempDir()
+	require.NoError(t, s.UpdateConfig())
+	config, err := os.ReadFile(filepath.Join(s.Home, configFile))
+	require.NoError(t, err)
+	assert.Contains(t, string(config), `path="/src/api" type="sendonly" rescanIntervalS="0" fsWatcherEnabled="true" fsWatcherDelayS="1" ignorePerms="true"`)
+	assert.Contains(t, string(config), `path="/src/scripts" type="sendonly" rescanIntervalS="0" fsWatcherEnabled="true" fsWatcherDelayS="1" ignorePerms="false"`)
+	assert.Contains(t, string(config), "<modTimeWindowS>1</modTimeWindowS>")
+	assert.Contains(t, string(config), "<modTimeWindowS>2</modTimeWindowS>")
+}
+
+func TestUpdateConfigPreservePermissions(t *testing.T) {
+	dev := &model.Dev{
+		Name:      "api",
+		Interface: model.Localhost,
+		Sync: model.Sync{
+			PreservePermissions: ptr.To(false),
+			Folders: []model.SyncFolder{
+				{LocalPath: "/src/api", RemotePath: "/app"},
+				{LocalPath: "/src/scripts", RemotePath: "/scripts", IgnorePerms: ptr.To(false)},
+			},
+		},
+	}
+
+	s, err := New(dev, "namespace", afero.NewMemMapFs())
+	require.NoError(t, err)
+	s.Home = t.TempDir()
+	require.NoError(t, s.UpdateConfig())
+	config, err := os.ReadFile(filepath.Join(s.Home, configFile))
+	require.NoError(t, err)
+	assert.Contains(t, string(config), `path="/src/api" type="sendonly" rescanIntervalS="0" fsWatcherEnabled="true" fsWatcherDelayS="1" ignorePerms="true"`)
+	assert.Contains(t, string(config), `path="/src/scripts" type="sendonly" rescanIntervalS="0" fsWatcherEnabled="true" fsWatcherDelayS="1" ignorePerms="false"`)
+}
+
+func TestGetUpdatedRemotePaths(t *testing.T) {
+	failed := "permission denied"
+	s := &Syncthing{
+		Folders: []*Folder{
+			{Name: "1", LocalPath: "/src/api", RemotePath: "/app"},
+			{Name: "2", LocalPath: "/src/protos", RemotePath: "/protos"},
+		},
+	}
+	events := []ItemFinishedEvent{
+		{ID: 3, Data: DataItemFinishedEvent{Folder: "okteto-1", Item: "main.go", Action: "update"}},
+		{ID: 4, Data: DataItemFinishedEvent{Folder: "okteto-2", Item: "api/v1/api.proto", Action: "update"}},
+		{ID: 5, Data: DataItemFinishedEvent{Folder: "okteto-1", Item: "old.go", Action: "delete"}},
+		{ID: 6, Data: DataItemFinishedEvent{Folder: "okteto-1", Item: "locked.go", Action: "update", Error: &failed}},
+		{ID: 7, Data: DataItemFinishedEvent{Folder: "unknown", Item: "file", Action: "update"}},
+	}
+
+	paths, since := s.getUpdatedRemotePaths(events, 2)
+	assert.Equal(t, []string{"/app/main.go", "/protos/api/v1/api.proto"}, paths)
+	assert.Equal(t, 7, since)
+}
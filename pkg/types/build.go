@@ -41,15 +41,18 @@ type BuildOptions struct {
 	// (e.g., "api/Dockerfile" instead of "/Users/.../.okteto/.dockerfile/buildkit-123")
 	OriginalDockerfile string
 	OutputMode         string
-	Path               string
-	Platform           string
-	Tag                string
-	Target             string
-	Namespace          string
-	K8sContext         string
-	BuildArgs          []string
-	Secrets            []string
-	ExportCache        []string
+	// Output sets where the image is exported to, like 'type=docker' or 'type=tar,dest=image.tar'.
+	// The image is pushed to the registry if it's empty
+	Output      string
+	Path        string
+	Platform    string
+	Tag         string
+	Target      string
+	Namespace   string
+	K8sContext  string
+	BuildArgs   []string
+	Secrets     []string
+	ExportCache []string
 	// CommandArgs comes from the user input on the command
 	CommandArgs  []string
 	SshSessions  []BuildSshSession
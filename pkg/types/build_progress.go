@@ -0,0 +1,78 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// BuildProgressKey is the field of the json log lines with the build progress events
+const BuildProgressKey = "build"
+
+// BuildProgressType is the type of a build progress event
+type BuildProgressType string
+
+const (
+	// BuildProgressStep is emitted when a step of the build changes its status or writes logs
+	BuildProgressStep BuildProgressType = "step"
+
+	// BuildProgressSummary is emitted when the build finishes
+	BuildProgressSummary BuildProgressType = "summary"
+)
+
+// BuildStepStatus is the status of a step of the build
+type BuildStepStatus string
+
+const (
+	// BuildStepStarted the step started running
+	BuildStepStarted BuildStepStatus = "started"
+
+	// BuildStepRunning the step is running and wrote logs
+	BuildStepRunning BuildStepStatus = "running"
+
+	// BuildStepCompleted the step finished successfully, or was taken from the build cache
+	BuildStepCompleted BuildStepStatus = "completed"
+
+	// BuildStepError the step failed
+	BuildStepError BuildStepStatus = "error"
+)
+
+// BuildProgressLine is a line written by the builds in the json output mode.
+// The level, stage, message and timestamp fields are the same of the rest of json lines
+type BuildProgressLine struct {
+	Build     *BuildProgressEvent `json:"build"`
+	Level     string              `json:"level"`
+	Stage     string              `json:"stage"`
+	Message   string              `json:"message"`
+	Timestamp int64               `json:"timestamp"`
+}
+
+// BuildProgressEvent is a step of the build changing its status, or the summary of the build
+type BuildProgressEvent struct {
+	Summary *BuildSummary     `json:"summary,omitempty"`
+	Type    BuildProgressType `json:"type"`
+	Step    string            `json:"step,omitempty"`
+	Status  BuildStepStatus   `json:"status,omitempty"`
+	Error   string            `json:"error,omitempty"`
+	Logs    []string          `json:"logs,omitempty"`
+
+	// Duration is the time taken by a completed step, in seconds
+	Duration float64 `json:"duration,omitempty"`
+	Cached   bool    `json:"cached,omitempty"`
+}
+
+// BuildSummary sums up a build
+type BuildSummary struct {
+	// Duration is the time taken by the build, in seconds
+	Duration    float64 `json:"duration"`
+	Steps       int     `json:"steps"`
+	CachedSteps int     `json:"cachedSteps"`
+	Failed      bool    `json:"failed"`
+}
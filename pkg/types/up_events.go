@@ -0,0 +1,132 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"time"
+)
+
+// UpEventsSchemaVersion is the version of the events published by okteto up.
+// It changes when a field is removed or changes its meaning
+const UpEventsSchemaVersion = 1
+
+// UpEventType is the type of an event published by okteto up
+type UpEventType string
+
+const (
+	// UpEventPhase is published when okteto up moves to a new phase
+	UpEventPhase UpEventType = "phase"
+
+	// UpEventSyncProgress is published while the files are synchronized
+	UpEventSyncProgress UpEventType = "syncProgress"
+
+	// UpEventForward is published when the status of a port forward changes
+	UpEventForward UpEventType = "forward"
+
+	// UpEventError is published when okteto up fails
+	UpEventError UpEventType = "error"
+)
+
+// UpPhase is a phase of okteto up
+type UpPhase string
+
+const (
+	// UpPhaseDeploying okteto up is deploying the development environment
+	UpPhaseDeploying UpPhase = "deploying"
+
+	// UpPhaseBuilding okteto up is building the images of the manifest
+	UpPhaseBuilding UpPhase = "building"
+
+	// UpPhaseActivating okteto up is activating the development container
+	UpPhaseActivating UpPhase = "activating"
+
+	// UpPhaseSyncing okteto up is synchronizing the files
+	UpPhaseSyncing UpPhase = "syncing"
+
+	// UpPhaseReady the development container is ready
+	UpPhaseReady UpPhase = "ready"
+)
+
+// UpForwardStatus is the status of a port forward
+type UpForwardStatus string
+
+const (
+	// UpForwardActive the port forward is active
+	UpForwardActive UpForwardStatus = "active"
+
+	// UpForwardStopped the port forward is stopped
+	UpForwardStopped UpForwardStatus = "stopped"
+)
+
+// UpEvent is an event published by okteto up in its events socket, encoded as one JSON object per line
+type UpEvent struct {
+	Timestamp     time.Time   `json:"timestamp"`
+	Forward       *UpForward  `json:"forward,omitempty"`
+	Type          UpEventType `json:"type"`
+	Phase         UpPhase     `json:"phase,omitempty"`
+	Error         string      `json:"error,omitempty"`
+	SchemaVersion int         `json:"schemaVersion"`
+	SyncProgress  float64     `json:"syncProgress,omitempty"`
+}
+
+// UpForward is the port forward of an UpEventForward event
+type UpForward struct {
+	Status      UpForwardStatus `json:"status"`
+	ServiceName string          `json:"serviceName,omitempty"`
+	Local       int             `json:"local"`
+	Remote      int             `json:"remote"`
+}
+
+// UpEventsClient reads the events published by okteto up
+type UpEventsClient struct {
+	conn    net.Conn
+	scanner *bufio.Scanner
+}
+
+// DialUpEvents connects to the events socket of okteto up
+func DialUpEvents(ctx context.Context, path string) (*UpEventsClient, error) {
+	conn, err := dialUpEvents(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return &UpEventsClient{conn: conn, scanner: bufio.NewScanner(conn)}, nil
+}
+
+// Next blocks until the next event is received. It returns io.EOF when okteto up closes the socket
+func (c *UpEventsClient) Next() (*UpEvent, error) {
+	for c.scanner.Scan() {
+		if len(c.scanner.Bytes()) == 0 {
+			continue
+		}
+		e := &UpEvent{}
+		if err := json.Unmarshal(c.scanner.Bytes(), e); err != nil {
+			return nil, err
+		}
+		return e, nil
+	}
+	if err := c.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// Close closes the connection to the events socket
+func (c *UpEventsClient) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,53 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//go:build !windows
+
+package types
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/okteto/okteto/pkg/config"
+)
+
+const upEventsSocketName = "okteto-events.sock"
+
+// GetUpEventsSocketPath returns the path of the events socket of a development container, in its app home
+func GetUpEventsSocketPath(namespace, name string) string {
+	return filepath.Join(config.GetAppHome(namespace, name), upEventsSocketName)
+}
+
+// ListenUpEvents listens in the events socket. Only the current user can connect to it
+func ListenUpEvents(path string) (net.Listener, error) {
+	// remove the socket left by an okteto up that didn't exit cleanly
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		_ = l.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+func dialUpEvents(ctx context.Context, path string) (net.Conn, error) {
+	d := net.Dialer{}
+	return d.DialContext(ctx, "unix", path)
+}
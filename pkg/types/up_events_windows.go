@@ -0,0 +1,40 @@
+// Copyright 2024 The Okteto Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//go:build windows
+
+package types
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/Microsoft/go-winio"
+)
+
+// upEventsPipeSecurityDescriptor only grants access to the owner of the pipe
+const upEventsPipeSecurityDescriptor = "D:P(A;;GA;;;OW)"
+
+// GetUpEventsSocketPath returns the named pipe of the events of a development container
+func GetUpEventsSocketPath(namespace, name string) string {
+	return fmt.Sprintf(`\\.\pipe\okteto-events-%s-%s`, namespace, name)
+}
+
+// ListenUpEvents listens in the events named pipe. Only the current user can connect to it
+func ListenUpEvents(path string) (net.Listener, error) {
+	return winio.ListenPipe(path, &winio.PipeConfig{SecurityDescriptor: upEventsPipeSecurityDescriptor})
+}
+
+func dialUpEvents(ctx context.Context, path string) (net.Conn, error) {
+	return winio.DialPipeContext(ctx, path)
+}